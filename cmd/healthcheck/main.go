@@ -1,42 +1,196 @@
+// Command healthcheck probes a running mygitpanel server for container health
+// checks. By default it only checks liveness (/healthz). With --deep it also
+// reads /readyz and checks that the database is readable, every repository
+// was polled recently (skipping repositories it reports as paused, or as
+// degraded because GitHub no longer serves them), and the GitHub credentials
+// are accepted, exiting with a distinct code for each failure:
+//
+//	0  healthy
+//	1  not serving, or the poll loop is wedged
+//	2  database unreadable
+//	3  GitHub credentials rejected
+//	4  serving but stale: a repository has not been polled within --max-poll-age
 package main
 
 import (
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/config"
+)
+
+// Exit codes, from most to least severe.
+const (
+	exitHealthy     = 0
+	exitUnavailable = 1
+	exitDatabase    = 2
+	exitCredentials = 3
+	exitStale       = 4
 )
 
+// defaultMaxPollAge matches the server's readiness threshold for a repository
+// going without a successful poll.
+const defaultMaxPollAge = time.Hour
+
+// readiness is the part of the /readyz response the deep check reads.
+type readiness struct {
+	Database struct {
+		Status string `json:"status"`
+	} `json:"database"`
+	GitHub struct {
+		Credentials string `json:"credentials"`
+	} `json:"github"`
+	Repos []struct {
+		Repository           string `json:"repository"`
+		Status               string `json:"status"`
+		LastSuccessfulPollAt string `json:"last_successful_poll_at"`
+	} `json:"repos"`
+}
+
 func main() {
-	os.Exit(check())
+	os.Exit(run(os.Args[1:], os.Stderr))
 }
 
-func check() int {
+func run(args []string, stderr io.Writer) int {
+	flags := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	deep := flags.Bool("deep", false, "also check the database, poll recency, and GitHub credentials")
+	maxPollAge := flags.Duration("max-poll-age", defaultMaxPollAge, "with --deep, how long a repository may go without a successful poll")
+	if err := flags.Parse(args); err != nil {
+		return exitUnavailable
+	}
+
 	addr := normalizeAddr(os.Getenv("MYGITPANEL_LISTEN_ADDR"))
+	client, scheme := newClient(addr)
+	baseURL := scheme + "://" + addr + basePath()
+
+	if status, _, err := get(client, baseURL, "/healthz"); err != nil || status != http.StatusOK {
+		fmt.Fprintln(stderr, "liveness check failed")
+		return exitUnavailable
+	}
+	if !*deep {
+		return exitHealthy
+	}
 
+	// /readyz answers 503 when not ready but still describes why.
+	_, body, err := get(client, baseURL, "/readyz")
+	if err != nil {
+		fmt.Fprintln(stderr, "readiness check failed:", err)
+		return exitUnavailable
+	}
+	var ready readiness
+	if err := json.Unmarshal(body, &ready); err != nil {
+		fmt.Fprintln(stderr, "readiness response unreadable:", err)
+		return exitUnavailable
+	}
+	code, reason := evaluate(ready, time.Now(), *maxPollAge)
+	if code != exitHealthy {
+		fmt.Fprintln(stderr, reason)
+	}
+	return code
+}
+
+// evaluate classifies a readiness report, returning the exit code of the most
+// severe failure and a reason for it. Paused repositories are not polled and
+// degraded ones back off for hours, so their poll recency is not checked.
+func evaluate(ready readiness, now time.Time, maxPollAge time.Duration) (code int, reason string) {
+	if ready.Database.Status == "fail" {
+		return exitDatabase, "database unreadable"
+	}
+	if ready.GitHub.Credentials == "invalid" {
+		return exitCredentials, "GitHub credentials rejected"
+	}
+	for _, repo := range ready.Repos {
+		if repo.Status == "paused" || repo.Status == "degraded" {
+			continue
+		}
+		if repo.Status == "stale" && repo.LastSuccessfulPollAt == "" {
+			return exitStale, repo.Repository + " has never been polled successfully"
+		}
+		if repo.LastSuccessfulPollAt == "" {
+			continue // not polled yet
+		}
+		polledAt, err := time.Parse(time.RFC3339, repo.LastSuccessfulPollAt)
+		if err != nil {
+			continue
+		}
+		if age := now.Sub(polledAt); age > maxPollAge {
+			return exitStale, fmt.Sprintf("%s last polled %s ago", repo.Repository, age.Round(time.Minute))
+		}
+	}
+	return exitHealthy, ""
+}
+
+// newClient returns the client for probing the server at addr and the URL
+// scheme to use. The server serves HTTPS when MYGITPANEL_TLS_CERT_FILE or
+// MYGITPANEL_TLS_AUTOCERT_HOST is set. Its certificate names the public
+// hostname rather than the address probed, so verification is skipped when
+// that address is loopback and nowhere else. Autocert picks the certificate
+// by server name, so its hostname is sent as one.
+func newClient(addr string) (*http.Client, string) {
 	client := &http.Client{Timeout: 2 * time.Second}
+	autocertHost := strings.TrimSpace(os.Getenv("MYGITPANEL_TLS_AUTOCERT_HOST"))
+	if strings.TrimSpace(os.Getenv("MYGITPANEL_TLS_CERT_FILE")) == "" && autocertHost == "" {
+		return client, "http"
+	}
 
+	host, _, _ := net.SplitHostPort(addr)
+	client.Transport = &http.Transport{TLSClientConfig: &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: autocertHost,
+		//nolint:gosec // only for loopback, where the certificate cannot name the address
+		InsecureSkipVerify: isLoopback(host),
+	}}
+	return client, "https"
+}
+
+// basePath returns the MYGITPANEL_BASE_PATH prefix the server routes under,
+// so every probe, deep or not, goes through the same URL as a proxied
+// request. A value the server rejects keeps it from starting, so the probe
+// falls back to the root.
+func basePath() string {
+	p, err := config.NormalizeBasePath(os.Getenv("MYGITPANEL_BASE_PATH"))
+	if err != nil {
+		return ""
+	}
+	return p
+}
+
+// isLoopback reports whether host is a loopback address or localhost.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// get requests path from the server at baseURL and returns the status and body.
+func get(client *http.Client, baseURL, path string) (status int, body []byte, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/api/v1/health", addr), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, nil)
 	if err != nil {
-		return 1
+		return 0, nil, err
 	}
-
 	resp, err := client.Do(req)
 	if err != nil {
-		return 1
-	}
-	_ = resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return 1
+		return 0, nil, err
 	}
+	defer func() { _ = resp.Body.Close() }()
 
-	return 0
+	body, err = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	return resp.StatusCode, body, err
 }
 
 // normalizeAddr ensures the healthcheck connects to loopback rather than the
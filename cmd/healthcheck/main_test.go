@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	decode := func(raw string) readiness {
+		var r readiness
+		if err := json.Unmarshal([]byte(raw), &r); err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"healthy", `{"database":{"status":"ok"},"github":{"credentials":"valid"},"repos":[{"repository":"o/r","status":"ok","last_successful_poll_at":"2026-10-01T11:50:00Z"}]}`, exitHealthy},
+		{"pending repo", `{"database":{"status":"ok"},"repos":[{"repository":"o/r","status":"pending"}]}`, exitHealthy},
+		{"database down", `{"database":{"status":"fail"},"github":{"credentials":"invalid"}}`, exitDatabase},
+		{"credentials rejected", `{"database":{"status":"ok"},"github":{"credentials":"invalid"}}`, exitCredentials},
+		{"poll too old", `{"database":{"status":"ok"},"repos":[{"repository":"o/r","status":"ok","last_successful_poll_at":"2026-10-01T10:30:00Z"}]}`, exitStale},
+		{"never polled", `{"database":{"status":"ok"},"repos":[{"repository":"o/r","status":"stale"}]}`, exitStale},
+		{"paused repo", `{"database":{"status":"ok"},"repos":[{"repository":"o/paused","status":"paused","last_successful_poll_at":"2026-09-01T10:30:00Z"}]}`, exitHealthy},
+		{"open circuit", `{"database":{"status":"ok"},"repos":[{"repository":"o/gone","status":"degraded","circuit":"open","last_successful_poll_at":"2026-10-01T06:00:00Z"}]}`, exitHealthy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := evaluate(decode(tt.body), now, time.Hour)
+			assert.Equal(t, tt.want, code)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	readyz := `{"database":{"status":"fail"}}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/readyz":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(readyz))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("MYGITPANEL_LISTEN_ADDR", strings.TrimPrefix(srv.URL, "http://"))
+
+	assert.Equal(t, exitHealthy, run(nil, io.Discard), "liveness only by default")
+	assert.Equal(t, exitDatabase, run([]string{"--deep"}, io.Discard))
+
+	srv.Close()
+	assert.Equal(t, exitUnavailable, run(nil, io.Discard))
+}
+
+func TestRun_TLS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/readyz":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"database":{"status":"ok"},"github":{"credentials":"invalid"}}`))
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("MYGITPANEL_LISTEN_ADDR", strings.TrimPrefix(srv.URL, "https://"))
+
+	assert.Equal(t, exitUnavailable, run(nil, io.Discard), "plain HTTP cannot reach a TLS server")
+
+	t.Setenv("MYGITPANEL_TLS_CERT_FILE", "/certs/server.pem")
+	assert.Equal(t, exitHealthy, run(nil, io.Discard), "the self-signed certificate is accepted on loopback")
+	assert.Equal(t, exitCredentials, run([]string{"--deep"}, io.Discard), "the deep checks go over HTTPS too")
+}
+
+func TestRun_BasePath(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/mygitpanel/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/mygitpanel/readyz":
+			_, _ = w.Write([]byte(`{"database":{"status":"ok"},"repos":[{"repository":"o/r","status":"paused","last_successful_poll_at":"2020-01-01T00:00:00Z"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	t.Setenv("MYGITPANEL_LISTEN_ADDR", strings.TrimPrefix(srv.URL, "http://"))
+
+	assert.Equal(t, exitUnavailable, run(nil, io.Discard), "the server only answers under its base path")
+
+	t.Setenv("MYGITPANEL_BASE_PATH", "mygitpanel/")
+	assert.Equal(t, exitHealthy, run(nil, io.Discard))
+	assert.Equal(t, exitHealthy, run([]string{"--deep"}, io.Discard), "readiness is read under the base path")
+}
+
+func TestNewClient(t *testing.T) {
+	client, scheme := newClient("127.0.0.1:8080")
+	assert.Equal(t, "http", scheme)
+	assert.Nil(t, client.Transport)
+
+	t.Setenv("MYGITPANEL_TLS_AUTOCERT_HOST", "panel.example.com")
+	client, scheme = newClient("127.0.0.1:443")
+	assert.Equal(t, "https", scheme)
+	tlsConfig := client.Transport.(*http.Transport).TLSClientConfig
+	assert.Equal(t, "panel.example.com", tlsConfig.ServerName)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+
+	client, _ = newClient("10.0.0.5:443")
+	assert.False(t, client.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify, "other addresses are verified")
+}
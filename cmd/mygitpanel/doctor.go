@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	githubadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github"
+	sqliteadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/sqlite"
+	"github.com/ericfisherdev/mygitpanel/internal/config"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// doctorTimeout bounds the whole self-test so a hung network call cannot block it.
+const doctorTimeout = 60 * time.Second
+
+// checkStatus is the outcome of a single doctor check.
+type checkStatus string
+
+const (
+	checkPass checkStatus = "PASS"
+	checkWarn checkStatus = "WARN"
+	checkFail checkStatus = "FAIL"
+	checkSkip checkStatus = "SKIP"
+)
+
+// doctorCheck is one line of the doctor report.
+type doctorCheck struct {
+	name   string
+	status checkStatus
+	detail string
+}
+
+// doctorReport accumulates check results in the order they ran.
+type doctorReport struct {
+	checks []doctorCheck
+}
+
+func (r *doctorReport) add(name string, status checkStatus, format string, args ...any) {
+	r.checks = append(r.checks, doctorCheck{name: name, status: status, detail: fmt.Sprintf(format, args...)})
+}
+
+// failed reports whether any check failed.
+func (r *doctorReport) failed() bool {
+	return slices.ContainsFunc(r.checks, func(c doctorCheck) bool { return c.status == checkFail })
+}
+
+// write prints the report followed by a one-line summary.
+func (r *doctorReport) write(w io.Writer) {
+	counts := make(map[checkStatus]int)
+	for _, c := range r.checks {
+		fmt.Fprintf(w, "  %-4s  %-10s  %s\n", c.status, c.name, c.detail)
+		counts[c.status]++
+	}
+	fmt.Fprintf(w, "\n%d passed, %d warnings, %d failed, %d skipped\n",
+		counts[checkPass], counts[checkWarn], counts[checkFail], counts[checkSkip])
+}
+
+// runDoctor validates configuration and external dependencies without
+// starting the daemon or applying migrations, prints a pass/fail report to
+// w, and returns the process exit code.
+func runDoctor(w io.Writer, configPath string) int {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	var report doctorReport
+	doctor(ctx, &report, configPath)
+
+	fmt.Fprintln(w, "mygitpanel doctor")
+	fmt.Fprintln(w)
+	report.write(w)
+
+	if report.failed() {
+		return 1
+	}
+	return 0
+}
+
+// doctor runs each check in dependency order. Checks whose prerequisites
+// failed are reported as skipped rather than omitted.
+func doctor(ctx context.Context, report *doctorReport, configPath string) {
+	// 1. Configuration.
+	cfg, err := config.LoadFile(configPath)
+	if err != nil {
+		report.add("config", checkFail, "%v", err)
+		for _, name := range []string{"database", "migrations", "token", "repo fetch"} {
+			report.add(name, checkSkip, "configuration invalid")
+		}
+		return
+	}
+	report.add("config", checkPass, "user=%s db=%s listen=%s poll=%s",
+		cfg.GitHubUsername, cfg.DBPath, cfg.ListenAddr, cfg.PollInterval)
+	if cfg.ConfigFile != "" {
+		report.add("config", checkPass, "read %s; env vars take precedence", cfg.ConfigFile)
+	}
+
+	// 2. Database. Opening a missing path would create it, so check first.
+	var db *sqliteadapter.DB
+	var dbErr error
+	if !cfg.DBMemory {
+		db, dbErr = openExistingDB(ctx, cfg.DBPath)
+	}
+	switch {
+	case cfg.DBMemory:
+		report.add("database", checkPass, "in memory; created and migrated on every start, lost on exit")
+	case errors.Is(dbErr, os.ErrNotExist):
+		report.add("database", checkWarn, "%s does not exist; it will be created on first start", cfg.DBPath)
+	case dbErr != nil:
+		report.add("database", checkFail, "%v", dbErr)
+	default:
+		defer func() { _ = db.Close() }()
+		report.add("database", checkPass, "opened %s", cfg.DBPath)
+	}
+
+	// 3. Migrations.
+	if db == nil {
+		report.add("migrations", checkSkip, "database not available")
+	} else {
+		checkMigrations(ctx, db, report)
+	}
+
+	// 4. GitHub token and scopes.
+	token := cfg.GitHubToken
+	source := "MYGITPANEL_GITHUB_TOKEN"
+	baseURL, graphqlURL := cfg.GitHubBaseURL, cfg.GitHubGraphQLURL
+	if db != nil && cfg.SecretKey != nil {
+		// The daemon prefers GUI-stored credentials over the env vars; mirror that.
+		credStore := sqliteadapter.NewCredentialRepo(db, cfg.SecretKey)
+		if stored, err := credStore.Get(ctx, "github_token"); err == nil && stored != "" {
+			token = stored
+			source = "credential store"
+		}
+		if stored, err := credStore.Get(ctx, "github_base_url"); err == nil && stored != "" {
+			baseURL = stored
+			graphqlURL, _ = credStore.Get(ctx, "github_graphql_url")
+		}
+	}
+	if token == "" {
+		report.add("token", checkFail, "no GitHub token in MYGITPANEL_GITHUB_TOKEN or the credential store")
+		report.add("repo fetch", checkSkip, "no GitHub token")
+		return
+	}
+
+	client, err := githubadapter.NewClientWithEndpoints(token, cfg.GitHubUsername, baseURL, graphqlURL)
+	if err != nil {
+		report.add("token", checkFail, "GitHub endpoint %s: %v", baseURL, err)
+		report.add("repo fetch", checkSkip, "GitHub endpoint invalid")
+		return
+	}
+	if !checkToken(ctx, client, cfg, source, report) {
+		report.add("repo fetch", checkSkip, "GitHub token invalid")
+		return
+	}
+
+	// 5. Fetch one watched repository end to end.
+	if db == nil {
+		report.add("repo fetch", checkSkip, "database not available")
+		return
+	}
+	checkRepoFetch(ctx, db, client, report)
+}
+
+// openExistingDB opens the database only if the file already exists.
+func openExistingDB(ctx context.Context, path string) (*sqliteadapter.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	return sqliteadapter.NewDB(ctx, path)
+}
+
+// checkMigrations compares the applied schema version with the embedded
+// migrations. It only reads the database, so checking one never changes it.
+func checkMigrations(ctx context.Context, db *sqliteadapter.DB, report *doctorReport) {
+	state, err := sqliteadapter.ReadMigrationState(ctx, db.Reader)
+	switch {
+	case errors.Is(err, sqliteadapter.ErrSchemaNotInitialized):
+		report.add("migrations", checkWarn, "schema not initialized; migrations are applied on first start")
+	case err != nil:
+		report.add("migrations", checkFail, "%v", err)
+	case state.Dirty:
+		report.add("migrations", checkFail, "schema version %d is dirty; a previous migration failed partway", state.Current)
+	case state.Current > state.Latest:
+		report.add("migrations", checkFail, "schema version %d is newer than this binary (%d); upgrade mygitpanel", state.Current, state.Latest)
+	case state.Pending():
+		report.add("migrations", checkWarn, "schema version %d, %d pending; applied on next start", state.Current, state.Latest-state.Current)
+	default:
+		report.add("migrations", checkPass, "schema version %d is current", state.Current)
+	}
+}
+
+// checkToken verifies the token authenticates and carries the scopes polling
+// needs. It returns false when the token is unusable.
+func checkToken(ctx context.Context, client *githubadapter.Client, cfg *config.Config, source string, report *doctorReport) bool {
+	info, err := client.FetchTokenInfo(ctx)
+	if err != nil {
+		report.add("token", checkFail, "token from %s rejected: %v", source, err)
+		return false
+	}
+
+	var problems []string
+	if !strings.EqualFold(info.Login, cfg.GitHubUsername) {
+		problems = append(problems, fmt.Sprintf("token belongs to %s, not MYGITPANEL_GITHUB_USERNAME %s", info.Login, cfg.GitHubUsername))
+	}
+	if info.ScopesKnown {
+		if !slices.Contains(info.Scopes, "repo") {
+			problems = append(problems, "missing repo scope; private repositories will not be visible")
+		}
+		if len(cfg.GitHubTeams) > 0 && !slices.Contains(info.Scopes, "read:org") && !slices.Contains(info.Scopes, "admin:org") {
+			problems = append(problems, "missing read:org scope; team review requests may not be detected")
+		}
+	}
+
+	scopes := "not reported (fine-grained token)"
+	if info.ScopesKnown {
+		scopes = strings.Join(info.Scopes, ",")
+	}
+
+	if len(problems) > 0 {
+		report.add("token", checkWarn, "authenticated as %s via %s; %s", info.Login, source, strings.Join(problems, "; "))
+		return true
+	}
+	report.add("token", checkPass, "authenticated as %s via %s; scopes: %s", info.Login, source, scopes)
+	return true
+}
+
+// checkRepoFetch lists open PRs for the first watched GitHub repository.
+func checkRepoFetch(ctx context.Context, db *sqliteadapter.DB, client *githubadapter.Client, report *doctorReport) {
+	repos, err := sqliteadapter.NewRepoRepo(db).ListAll(ctx)
+	if err != nil {
+		report.add("repo fetch", checkFail, "list watched repositories: %v", err)
+		return
+	}
+	repos = slices.DeleteFunc(repos, func(r model.Repository) bool {
+		return r.Provider != model.ProviderGitHub
+	})
+	if len(repos) == 0 {
+		report.add("repo fetch", checkSkip, "no GitHub repositories watched yet")
+		return
+	}
+
+	repo := repos[0].FullName
+	prs, err := client.FetchPullRequests(ctx, repo, "open")
+	if err != nil {
+		report.add("repo fetch", checkFail, "%s: %v", repo, err)
+		return
+	}
+	report.add("repo fetch", checkPass, "%s: %d open pull requests", repo, len(prs))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github/githubfake"
+)
+
+// fakeGitHubToken is the token sent to the fake GitHub API when none is
+// configured. The fake accepts any token, but the client only polls with one.
+const fakeGitHubToken = "fake-token"
+
+// runFaker serves a scenario as a fake GitHub API until interrupted and
+// returns the process exit code. It takes its own flags, not -config.
+func runFaker(w io.Writer, args []string) int {
+	flags := flag.NewFlagSet("mygitpanel faker", flag.ContinueOnError)
+	flags.SetOutput(w)
+	scenarioPath := flags.String("scenario", "", "path to the scenario YAML file (required)")
+	addr := flags.String("addr", "127.0.0.1:8090", "address to listen on")
+	if err := flags.Parse(args); err != nil || *scenarioPath == "" || flags.NArg() > 0 {
+		fmt.Fprintln(w, "usage: mygitpanel faker -scenario file [-addr host:port]")
+		return 2
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	scenario, err := githubfake.LoadScenario(*scenarioPath)
+	if err != nil {
+		fmt.Fprintf(w, "mygitpanel faker: %v\n", err)
+		return 1
+	}
+	fake, err := githubfake.NewServer(scenario)
+	if err != nil {
+		fmt.Fprintf(w, "mygitpanel faker: %v\n", err)
+		return 1
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(w, "mygitpanel faker: %v\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(w, "serving %d repositories as %s on http://%s\n", len(scenario.Repos), scenario.User, ln.Addr())
+	fmt.Fprintln(w, "point mygitpanel at it with:")
+	fmt.Fprintf(w, "  MYGITPANEL_GITHUB_BASE_URL=http://%s/api/v3/ MYGITPANEL_GITHUB_TOKEN=%s MYGITPANEL_GITHUB_USERNAME=%s\n",
+		ln.Addr(), fakeGitHubToken, scenario.User)
+	if err := serveFake(ctx, ln, fake); err != nil {
+		fmt.Fprintf(w, "mygitpanel faker: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// startGitHubFake serves the scenario at path on a loopback port until ctx
+// is done, and returns the REST base URL to point the GitHub client at and
+// the scenario's repository names.
+func startGitHubFake(ctx context.Context, path string) (baseURL string, repos []string, err error) {
+	scenario, err := githubfake.LoadScenario(path)
+	if err != nil {
+		return "", nil, err
+	}
+	fake, err := githubfake.NewServer(scenario)
+	if err != nil {
+		return "", nil, err
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("listen for the fake GitHub API: %w", err)
+	}
+	go func() {
+		if err := serveFake(ctx, ln, fake); err != nil {
+			slog.Error("fake GitHub API stopped", "error", err)
+		}
+	}()
+	return fmt.Sprintf("http://%s/api/v3/", ln.Addr()), scenario.RepoNames(), nil
+}
+
+// serveFake serves the fake on ln until ctx is done.
+func serveFake(ctx context.Context, ln net.Listener, fake http.Handler) error {
+	srv := &http.Server{Handler: fake, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+	if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
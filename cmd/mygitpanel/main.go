@@ -3,52 +3,154 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	_ "golang.org/x/crypto/x509roots/fallback" // Embed CA certs for scratch container
 
+	bitbucketadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/bitbucket"
 	githubadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github"
+	hookadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/hook"
 	jiraadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/jira"
 	sqliteadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/sqlite"
+	webhookadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/webhook"
 	httphandler "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/http"
 	webhandler "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web"
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/basepath"
 	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/changelog"
 	"github.com/ericfisherdev/mygitpanel/internal/config"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
+// configWatchInterval is how often the config file is checked for changes.
+const configWatchInterval = 5 * time.Second
+
+// slaCheckInterval is how often PRs are checked for first-review SLA breaches.
+const slaCheckInterval = time.Minute
+
+// checkWatchInterval is how often repos with PRs whose checks are watched are
+// refreshed, so a finished CI run is reported within about this long.
+const checkWatchInterval = 30 * time.Second
+
+// staleCheckInterval is how often open PRs are checked against their repo's
+// stale policy.
+const staleCheckInterval = time.Hour
+
+// reportCheckInterval is how often scheduled reports are checked for being due.
+const reportCheckInterval = 5 * time.Minute
+
+// outboxRetryInterval is how often queued GitHub writes are checked for a due retry.
+const outboxRetryInterval = 30 * time.Second
+
+// eventWebhookRetryInterval is how often failed event webhook deliveries are
+// checked for a due retry.
+const eventWebhookRetryInterval = 15 * time.Second
+
+// archivePruneInterval is how often archived PRs past their retention are deleted.
+const archivePruneInterval = time.Hour
+
+// demoUsername is who the demo dashboard belongs to when
+// MYGITPANEL_GITHUB_USERNAME is not set.
+const demoUsername = "octocat"
+
 func main() {
-	if err := run(); err != nil {
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "faker" {
+		os.Exit(runFaker(os.Stdout, args[1:]))
+	}
+	var subcommand string
+	if len(args) > 0 && (args[0] == "doctor" || args[0] == "migrate" || args[0] == "demo") {
+		subcommand, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet("mygitpanel", flag.ExitOnError)
+	configPath := flags.String("config", os.Getenv("MYGITPANEL_CONFIG_FILE"),
+		"path to a YAML config file; env vars override its settings (default $MYGITPANEL_CONFIG_FILE)")
+	_ = flags.Parse(args) // ExitOnError: Parse exits on bad flags
+
+	switch subcommand {
+	case "doctor":
+		os.Exit(runDoctor(os.Stdout, *configPath))
+	case "migrate":
+		os.Exit(runMigrate(os.Stdout, *configPath, flags.Args()))
+	case "demo":
+		if os.Getenv("MYGITPANEL_GITHUB_USERNAME") == "" {
+			_ = os.Setenv("MYGITPANEL_GITHUB_USERNAME", demoUsername)
+		}
+	}
+
+	if err := run(*configPath, subcommand == "demo"); err != nil {
 		slog.Error("fatal error", "error", err)
 		os.Exit(1)
 	}
 }
 
-func run() error {
-	// 1. Load configuration (fail fast on missing required env vars).
-	cfg, err := config.Load()
+// run starts the server. In demo mode it serves generated data from a memory
+// database and never polls GitHub.
+func run(configPath string, demo bool) error {
+	// 1. Load configuration (fail fast on missing required settings).
+	cfg, err := config.LoadFile(configPath)
 	if err != nil {
 		return err
 	}
+	if demo {
+		cfg.DBMemory, cfg.ReadOnly, cfg.GitHubToken = true, false, ""
+	}
 	slog.Info("config loaded",
+		"config_file", cfg.ConfigFile,
 		"listen_addr", cfg.ListenAddr,
+		"base_path", cfg.BasePath,
 		"db_path", cfg.DBPath,
 		"poll_interval", cfg.PollInterval,
 		"github_username", cfg.GitHubUsername,
 	)
+	tlsConfig, err := serverTLSConfig(cfg)
+	if err != nil {
+		return err
+	}
 
 	// 2. Setup signal-based context (SIGINT, SIGTERM).
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	// 3. Open database (dual reader/writer with WAL mode).
-	db, err := sqliteadapter.NewDB(ctx, cfg.DBPath)
+	// 2a. Serve the MYGITPANEL_GITHUB_FAKE scenario as a fake GitHub API and
+	// poll it instead of GitHub.
+	var fakeRepos []string
+	if cfg.GitHubFake != "" && !demo {
+		if cfg.GitHubBaseURL, fakeRepos, err = startGitHubFake(ctx, cfg.GitHubFake); err != nil {
+			return err
+		}
+		if cfg.GitHubToken == "" {
+			cfg.GitHubToken = fakeGitHubToken
+		}
+		slog.Info("polling a fake GitHub API", "scenario", cfg.GitHubFake, "base_url", cfg.GitHubBaseURL)
+	}
+
+	// 3. Open database (dual reader/writer with WAL mode), the existing file
+	// read-only when MYGITPANEL_READ_ONLY is set, or a database in memory
+	// when MYGITPANEL_DB=memory.
+	openDB := sqliteadapter.NewDB
+	switch {
+	case cfg.DBMemory && cfg.ReadOnly:
+		return errors.New("MYGITPANEL_READ_ONLY cannot be combined with MYGITPANEL_DB=memory")
+	case cfg.DBMemory:
+		openDB = sqliteadapter.NewMemoryDB
+	case cfg.ReadOnly:
+		if _, err := os.Stat(cfg.DBPath); err != nil {
+			return fmt.Errorf("read-only mode needs an existing database: %w", err)
+		}
+		openDB = sqliteadapter.NewReadOnlyDB
+	}
+	db, err := openDB(ctx, cfg.DBPath)
 	if err != nil {
 		return err
 	}
@@ -57,13 +159,60 @@ func run() error {
 			slog.Error("error closing database", "error", closeErr)
 		}
 	}()
-	slog.Info("database opened", "path", cfg.DBPath)
+	slog.Info("database opened", "path", cfg.DBPath, "memory", cfg.DBMemory)
 
-	// 4. Run migrations on writer connection.
-	if err := sqliteadapter.RunMigrations(db.Writer); err != nil {
+	// 4. Check the schema version, then run migrations on the writer
+	// connection. A database migrated by a newer release is refused, or
+	// served read-only when opted in. The version before migrating tells the
+	// what's-new panel which migrations this upgrade applied.
+	schemaBefore, err := sqliteadapter.GetMigrationState(db.Writer)
+	if err != nil {
 		return err
 	}
-	slog.Info("migrations complete")
+	readOnly := false
+	readOnlyReason := "mygitpanel is read-only: MYGITPANEL_READ_ONLY is set"
+	switch schemaErr := schemaBefore.Check(); {
+	case cfg.ReadOnly && schemaErr == nil && schemaBefore.Pending():
+		return fmt.Errorf("database schema version %d is older than this binary (%d) and read-only mode cannot migrate it; "+
+			"run mygitpanel migrate up on a copy first", schemaBefore.Current, schemaBefore.Latest)
+	case cfg.ReadOnly && (schemaErr == nil || errors.Is(schemaErr, sqliteadapter.ErrSchemaTooNew)):
+		slog.Warn("serving database read-only; polling and all changes are disabled", "reason", "MYGITPANEL_READ_ONLY")
+		readOnly = true
+	case schemaErr == nil:
+		if schemaBefore.Pending() {
+			backup, err := backupBeforeMigrating(ctx, db, cfg.DBPath, schemaBefore)
+			if err != nil {
+				return err
+			}
+			if backup != "" {
+				slog.Info("database backed up before migrating", "path", backup, "version", schemaBefore.Current)
+			}
+		}
+		if err := sqliteadapter.RunMigrations(db.Writer); err != nil {
+			return err
+		}
+		slog.Info("migrations complete", "from_version", schemaBefore.Current, "to_version", schemaBefore.Latest)
+	case errors.Is(schemaErr, sqliteadapter.ErrSchemaTooNew) && cfg.ReadOnlyOnNewerSchema:
+		slog.Warn("serving database read-only; polling and all changes are disabled", "error", schemaErr)
+		if err := db.Close(); err != nil {
+			return err
+		}
+		if db, err = sqliteadapter.NewReadOnlyDB(ctx, cfg.DBPath); err != nil {
+			return err
+		}
+		readOnly = true
+		readOnlyReason = "mygitpanel is read-only: the database schema is newer than this binary"
+	case errors.Is(schemaErr, sqliteadapter.ErrSchemaTooNew):
+		return fmt.Errorf("%w; set MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA=true to browse it read-only meanwhile", schemaErr)
+	default:
+		return schemaErr
+	}
+	if cfg.DBFixtures != "" {
+		if err := sqliteadapter.ApplyFixtures(ctx, db, cfg.DBFixtures); err != nil {
+			return err
+		}
+		slog.Info("fixtures applied", "path", cfg.DBFixtures)
+	}
 
 	// 5. Wire adapters.
 	prStore := sqliteadapter.NewPRRepo(db)
@@ -74,9 +223,74 @@ func run() error {
 	credStore := sqliteadapter.NewCredentialRepo(db, cfg.SecretKey)
 	thresholdStore := sqliteadapter.NewThresholdRepo(db)
 	ignoreStore := sqliteadapter.NewIgnoreRepo(db)
+	prLinkStore := sqliteadapter.NewPRLinkRepo(db)
+	decisionStore := sqliteadapter.NewDecisionRepo(db)
+	prNoteStore := sqliteadapter.NewPRNoteRepo(db)
+	commentSearchStore := sqliteadapter.NewCommentSearchRepo(db)
+	watchStore := sqliteadapter.NewWatchRepo(db)
+	repoGroupStore := sqliteadapter.NewRepoGroupRepo(db)
+	quickActionStore := sqliteadapter.NewQuickActionRepo(db)
+	githubAccountStore := sqliteadapter.NewGitHubAccountRepo(db, cfg.SecretKey)
+	branchProtectionStore := sqliteadapter.NewBranchProtectionRepo(db)
+	repoSettingsStore := sqliteadapter.NewRepoSettingsRepo(db)
+	httpCacheStore := sqliteadapter.NewHTTPCacheRepo(db)
+	journalStore := sqliteadapter.NewPRJournalRepo(db)
 
-	// 6. Create GitHub client.
-	ghClient := githubadapter.NewClient(cfg.GitHubToken, cfg.GitHubUsername)
+	if demo {
+		if err := application.NewDemoSeeder(repoStore, prStore, reviewStore, checkStore, cfg.GitHubUsername).Seed(ctx, time.Now()); err != nil {
+			return err
+		}
+		slog.Info("demo data seeded; polling is disabled", "username", cfg.GitHubUsername)
+	}
+	if len(fakeRepos) > 0 && !readOnly {
+		repos := make([]model.Repository, 0, len(fakeRepos))
+		for _, name := range fakeRepos {
+			owner, repoName, _ := strings.Cut(name, "/")
+			repos = append(repos, model.Repository{FullName: name, Owner: owner, Name: repoName})
+		}
+		if _, err := repoStore.AddMany(ctx, repos); err != nil {
+			return err
+		}
+	}
+
+	// Cached GitHub responses unused for a month are for repos no longer
+	// watched or URLs no longer requested.
+	if !readOnly {
+		const httpCacheMaxAge = 30 * 24 * time.Hour
+		if n, err := httpCacheStore.PruneResponses(ctx, time.Now().Add(-httpCacheMaxAge)); err != nil {
+			slog.Warn("failed to prune cached GitHub responses", "error", err)
+		} else if n > 0 {
+			slog.Info("pruned cached GitHub responses", "count", n)
+		}
+	}
+
+	// 6. Create GitHub client. GitHub Enterprise URLs saved via the GUI take
+	// precedence over MYGITPANEL_GITHUB_BASE_URL and MYGITPANEL_GITHUB_GRAPHQL_URL,
+	// mirroring how the stored token overrides the env var.
+	githubEndpoints := func(ctx context.Context) (baseURL, graphqlURL string) {
+		stored, _ := credStore.Get(ctx, "github_base_url") // fallback to env vars on error or empty
+		if stored == "" || cfg.GitHubFake != "" {
+			return cfg.GitHubBaseURL, cfg.GitHubGraphQLURL
+		}
+		storedGraphQL, _ := credStore.Get(ctx, "github_graphql_url")
+		return stored, storedGraphQL
+	}
+	newGitHubClient := func(token string) *githubadapter.Client {
+		baseURL, graphqlURL := githubEndpoints(context.Background())
+		client, err := githubadapter.NewClientWithEndpoints(token, cfg.GitHubUsername, baseURL, graphqlURL)
+		if err != nil {
+			// Never fall back to github.com with the token: it may belong to an Enterprise instance.
+			slog.Error("invalid GitHub endpoint configuration; using an unauthenticated client", "base_url", baseURL, "error", err)
+			return githubadapter.NewClient("", cfg.GitHubUsername)
+		}
+		if readOnly {
+			return client
+		}
+		// Persisted ETags let the first poll after a restart revalidate
+		// instead of re-downloading every repository.
+		return client.WithResponseCache(httpCacheStore)
+	}
+	ghClient := newGitHubClient(cfg.GitHubToken)
 
 	// 6a. Wire credential token provider for PollService hot-swap.
 	// The closure reads from the credential store each cycle, falling back to
@@ -89,17 +303,45 @@ func run() error {
 		return stored, nil
 	}
 	clientFactory := func(token string) driven.GitHubClient {
-		return githubadapter.NewClient(token, cfg.GitHubUsername)
+		return newGitHubClient(token)
 	}
 	writerFactory := func(token string) driven.GitHubWriter {
-		return githubadapter.NewClient(token, cfg.GitHubUsername)
+		return newGitHubClient(token)
 	}
 	jiraConnStore := sqliteadapter.NewJiraConnectionRepo(db, cfg.SecretKey)
 	jiraClientFactory := func(conn model.JiraConnection) driven.JiraClient {
 		return jiraadapter.NewJiraClient(conn.BaseURL, conn.Email, conn.Token)
 	}
 
-	// 7. Create and start poll service.
+	// 7. Create and start poll service. Changes it observes are published to
+	// the event hub that backs the /api/v1/events WebSocket stream. @mentions
+	// are recorded as comments are fetched and feed the mentioned signal.
+	// Stored merge history identifies first-time contributors. Synced PRs
+	// are checked against each repo's title and branch lint rules, and their
+	// task-list progress is stored for the checklist signal, as are pending
+	// review requests for the request-aging signal. User-defined attention
+	// rules add to the built-in signals. Computed signals are cached for five
+	// minutes, or until the poller next syncs the PR.
+	mentionStore := sqliteadapter.NewMentionRepo(db)
+	lintSvc := application.NewLintService(sqliteadapter.NewLintRepo(db), prStore)
+	attentionRuleSvc := application.NewAttentionRuleService(sqliteadapter.NewAttentionRuleRepo(db))
+	attentionSvc := application.NewAttentionService(thresholdStore, reviewStore, cfg.GitHubUsername).
+		WithBranchProtectionStore(branchProtectionStore).
+		WithMentionStore(mentionStore).
+		WithContributorStore(prStore).
+		WithRuleService(attentionRuleSvc).
+		WithSignalCache(5 * time.Minute)
+	// Review status, approvals, and unresolved threads are stored on each PR
+	// row so the PR list can filter on them. PRs stored before the rollup
+	// existed are filled in at startup.
+	reviewRollupSvc := application.NewReviewRollupService(reviewStore, botConfigStore, prStore, prStore)
+	go func() {
+		if err := reviewRollupSvc.Backfill(ctx); err != nil {
+			slog.Error("review rollup backfill failed", "error", err)
+		}
+	}()
+	eventHub := application.NewEventHub()
+	archiveRetention := time.Duration(cfg.ArchiveRetentionDays) * 24 * time.Hour
 	pollSvc := application.NewPollService(
 		ghClient,
 		prStore,
@@ -111,32 +353,340 @@ func run() error {
 		cfg.PollInterval,
 		tokenProvider,
 		clientFactory,
-	)
-	go pollSvc.Start(ctx)
+	).WithRepoRenamer(repoStore).
+		WithSyncRecorder(repoStore).
+		WithRepoMetadataStore(repoStore).
+		WithAccountRouting(githubAccountStore).
+		WithBranchProtectionStore(branchProtectionStore).
+		WithRepoSettingsStore(repoSettingsStore).
+		WithRelevantPRsOnly(cfg.RelevantPRsOnly).
+		WithMentionStore(mentionStore).
+		WithPendingCommentStore(reviewStore).
+		WithEventHub(eventHub, attentionSvc).
+		WithArchiveRetention(archiveRetention).
+		WithClosedPRLookback(time.Duration(cfg.ClosedPRLookbackDays) * 24 * time.Hour).
+		WithLintService(lintSvc).
+		WithChecklistStore(prStore).
+		WithReviewRequestStore(prStore).
+		WithJournalStore(journalStore).
+		WithAttentionSignalCache(attentionSvc).
+		WithReviewRollups(reviewRollupSvc)
+	// GitHub Enterprise Server has no public status page; its incidents are
+	// still inferred from the poll error rate.
+	if cfg.GitHubBaseURL == "" {
+		pollSvc.WithStatusPage(githubadapter.NewStatusPage(githubadapter.DefaultStatusPageURL, 10*time.Second))
+	}
+	// Repositories added with the bitbucket provider are polled from
+	// Bitbucket Cloud when its credentials are configured.
+	var bitbucketClient *bitbucketadapter.Client
+	if cfg.BitbucketUsername != "" && cfg.BitbucketAppPassword != "" {
+		bitbucketClient = bitbucketadapter.NewClient(cfg.BitbucketUsername, cfg.BitbucketAppPassword)
+		pollSvc.WithSCMProvider(model.ProviderBitbucket, bitbucketClient, cfg.BitbucketUsername)
+	}
+	if !readOnly && !demo {
+		go pollSvc.Start(ctx)
+	}
+
+	// Reload poll settings when the config file changes. Keys that need a
+	// restart are logged by the watcher instead.
+	go config.Watch(ctx, cfg, configWatchInterval, func(next *config.Config) {
+		pollSvc.UpdateSettings(next.PollInterval, next.GitHubTeams)
+	})
 
-	// 7b. Create review service.
-	reviewSvc := application.NewReviewService(reviewStore, botConfigStore)
+	// 7a. Deliver attention signal webhooks and attention rule notifications
+	// from the event hub. Both only fire on changes the poll loop observes, so
+	// they are off in read-only mode.
+	var signalWebhookSvc *application.SignalWebhookService
+	if !readOnly {
+		webhookSender := webhookadapter.NewSender(10 * time.Second)
+		signalWebhookSvc = application.NewSignalWebhookService(sqliteadapter.NewSignalWebhookRepo(db), webhookSender)
+		go signalWebhookSvc.Run(ctx, eventHub)
+		attentionRuleSvc.WithNotifier(webhookSender)
+		go attentionRuleSvc.Run(ctx, eventHub)
+	}
+
+	// 7b. Record attention inbox events from the event hub. Existing events
+	// stay browsable in read-only mode; only recording needs the poll loop.
+	inboxStore := sqliteadapter.NewInboxRepo(db)
+	inboxSvc := application.NewInboxService(inboxStore, prStore, cfg.GitHubUsername).WithWatchStore(watchStore)
+	if !readOnly {
+		go inboxSvc.Run(ctx, eventHub)
+	}
 
-	// 7c. Create health service.
+	// 7c. Track per-repo first-review SLAs. Deadlines pass without any poll
+	// activity, so breaches are checked on their own timer and land in the inbox.
+	slaSvc := application.NewSLAService(prStore, reviewStore, thresholdStore)
+	if !readOnly {
+		slaSvc.WithInboxStore(inboxStore)
+		go slaSvc.Run(ctx, slaCheckInterval)
+	}
+
+	// 7d. Replay reviews and comments that failed with a transient GitHub
+	// error. Writes use the repo's provider or routed account token, like the
+	// web handler.
+	writerForRepo := func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error) {
+		if repo, err := repoStore.GetByFullName(ctx, repoFullName); err == nil && repo != nil && repo.Provider == model.ProviderBitbucket {
+			if bitbucketClient == nil {
+				return nil, errors.New("no Bitbucket credentials configured")
+			}
+			return bitbucketClient, nil
+		}
+		if account, err := githubAccountStore.GetForRepo(ctx, repoFullName); err == nil && account.Token != "" {
+			return writerFactory(account.Token), nil
+		}
+		token, err := tokenProvider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if token == "" {
+			return nil, errors.New("no GitHub token configured")
+		}
+		return writerFactory(token), nil
+	}
+	var outboxSvc *application.OutboxService
+	if !readOnly {
+		outboxSvc = application.NewOutboxService(sqliteadapter.NewOutboxRepo(db), writerForRepo)
+		go outboxSvc.Run(ctx, outboxRetryInterval)
+	}
+
+	// 7e. Delete merged and closed PRs once they outlive the archive
+	// retention. Pruning writes, so the archive is only browsed when read-only.
+	archiveSvc := application.NewArchiveService(sqliteadapter.NewArchiveRepo(db), archiveRetention)
+	if !readOnly {
+		go archiveSvc.Run(ctx, archivePruneInterval)
+	}
+	// Removed repos keep their history for a while so the removal can be
+	// undone; the same hourly job then deletes them with their PRs.
+	repoTrashSvc := application.NewRepoTrashService(repoStore, time.Duration(cfg.RemovedRepoRetentionDays)*24*time.Hour)
+	if !readOnly {
+		go repoTrashSvc.Run(ctx, archivePruneInterval)
+	}
+
+	// 7f. Create review service. Comments posted from the GUI are stored as
+	// pending echoes until the poll loop fetches them back from GitHub.
+	reviewSvc := application.NewReviewService(reviewStore, botConfigStore).
+		WithPendingCommentStore(reviewStore)
+
+	// 7g. Create health service.
 	healthSvc := application.NewHealthService(checkStore, prStore)
 
-	// 7.5. Create HTTP handler and register API routes.
-	apiHandler := httphandler.NewHandler(prStore, repoStore, botConfigStore, reviewSvc, healthSvc, pollSvc, cfg.GitHubUsername, slog.Default())
+	// 7h. The reviewer leaderboard ranks colleagues, so it is opt-in.
+	var teamStatsSvc *application.TeamStatsService
+	if cfg.TeamStats {
+		teamStatsSvc = application.NewTeamStatsService(sqliteadapter.NewTeamStatsRepo(db))
+	}
+
+	// 7i. Run script hooks on PR events. Shell hooks may only run the
+	// programs in MYGITPANEL_HOOK_COMMANDS; comments scripts ask for are
+	// posted with the same writers as the outbox. Hooks fire on events the
+	// poll loop observes, so they do not run in read-only mode.
+	scriptHookSvc := application.NewScriptHookService(sqliteadapter.NewScriptHookRepo(db), prStore,
+		hookadapter.NewRunner(cfg.HookCommands), cfg.GitHubUsername)
+	if !readOnly {
+		scriptHookSvc.WithWriter(writerForRepo)
+		go scriptHookSvc.Run(ctx, eventHub)
+	}
+
+	// 7j. Deliver signed event webhooks, logging every delivery and retrying
+	// failed ones with backoff. Like the other webhooks they fire on changes
+	// the poll loop observes, so they are off in read-only mode.
+	var eventWebhookSvc *application.EventWebhookService
+	if !readOnly {
+		eventWebhookSvc = application.NewEventWebhookService(sqliteadapter.NewEventWebhookRepo(db), prStore,
+			webhookadapter.NewSender(10*time.Second))
+		go eventWebhookSvc.Run(ctx, eventHub, eventWebhookRetryInterval)
+	}
+
+	// 7k. Notify when watched PRs' checks finish. Watched repos are refreshed
+	// on a short timer through the poll loop, which demo mode does not run.
+	checkWatchStore := sqliteadapter.NewCheckWatchRepo(db)
+	if !readOnly {
+		var refresher application.RepoRefresher
+		if !demo {
+			refresher = pollSvc
+		}
+		go application.NewCheckWatchService(checkWatchStore, prStore, inboxStore, refresher).Run(ctx, checkWatchInterval)
+	}
+
+	// 7l. Label or comment on PRs idle past their repo's stale policy. It
+	// writes to GitHub, so it needs the poll loop's live data.
+	if !readOnly && !demo {
+		staleLabeler := application.NewStaleLabeler(prStore, repoSettingsStore, sqliteadapter.NewStaleMarkRepo(db), writerForRepo).
+			WithInboxStore(inboxStore)
+		go staleLabeler.Run(ctx, staleCheckInterval)
+	}
+
+	// 7m. Post weekly repo health reports to Slack or GitHub. Reports are
+	// managed and previewed from the settings drawer, which needs writes;
+	// sending them needs live data, so demo mode only previews.
+	var reportSvc *application.ReportService
+	if !readOnly {
+		reportSvc = application.NewReportService(sqliteadapter.NewScheduledReportRepo(db), prStore, reviewStore, repoGroupStore)
+		if !demo {
+			reportSvc.WithDelivery(webhookadapter.NewSender(10*time.Second), writerForRepo)
+			go reportSvc.Run(ctx, reportCheckInterval)
+		}
+	}
+
+	// 7n. Ignore PRs matching user-defined ignore rules. Rules are applied to
+	// the queue when they change and to PRs as the poll loop sees them open.
+	ignoreRuleSvc := application.NewIgnoreRuleService(sqliteadapter.NewIgnoreRuleRepo(db), ignoreStore, prStore, cfg.GitHubUsername)
+	if !readOnly {
+		go ignoreRuleSvc.Run(ctx, eventHub)
+	}
+
+	// 7.5. Create HTTP handler and register API routes. API tokens are
+	// enforced on /api/v1 once the first one is created in the GUI.
+	apiTokenSvc := application.NewAPITokenService(sqliteadapter.NewAPITokenRepo(db))
+	repoImportSvc := application.NewRepoImportService(repoStore, pollSvc).
+		WithTeamRepoLister(tokenProvider, func(token string) driven.TeamRepoLister {
+			return newGitHubClient(token)
+		})
+	systemSvc := application.NewSystemService(prStore, repoStore, pollSvc).
+		WithOutboxStore(sqliteadapter.NewOutboxRepo(db))
+	apiHandler := httphandler.NewHandler(prStore, repoStore, botConfigStore, reviewSvc, healthSvc, pollSvc, cfg.GitHubUsername, slog.Default()).
+		WithDBPinger(db).
+		WithRefreshToken(cfg.RefreshToken).
+		WithAPITokens(apiTokenSvc).
+		WithEventHub(eventHub).
+		WithSLAService(slaSvc).
+		WithPRNoteStore(prNoteStore).
+		WithCommentSearchStore(commentSearchStore).
+		WithJournalStore(journalStore).
+		WithRepoGroupStore(repoGroupStore).
+		WithLintService(lintSvc).
+		WithAttentionRuleService(attentionRuleSvc).
+		WithScriptHookService(scriptHookSvc).
+		WithEventWebhookService(eventWebhookSvc).
+		WithRepoImportService(repoImportSvc).
+		WithRepoTrashService(repoTrashSvc).
+		WithOrphanSweeper(repoStore).
+		WithReviewRollups(reviewRollupSvc).
+		WithSystemService(systemSvc).
+		WithIgnoreStore(ignoreStore).
+		WithIgnoreRuleService(ignoreRuleSvc).
+		WithRepoSettingsStore(repoSettingsStore).
+		WithThresholdStore(thresholdStore).
+		WithBasePath(cfg.BasePath)
+	if teamStatsSvc != nil {
+		apiHandler.WithTeamStatsService(teamStatsSvc)
+	}
+	// AI assistants only get drafts back from post_comment unless comment
+	// posting is switched on.
+	if cfg.MCPWrites && !readOnly {
+		apiHandler.WithMCPWriter(writerForRepo)
+	}
+	// Embed tokens are signed with a key derived from the secret key, so the
+	// embeddable PR list is only available when one is set.
+	var embedSigner *application.EmbedSigner
+	if cfg.SecretKey != nil {
+		embedSigner = application.NewEmbedSigner(cfg.SecretKey)
+		apiHandler.WithEmbedSigner(embedSigner)
+	}
 	mux := http.NewServeMux()
 	httphandler.RegisterAPIRoutes(mux, apiHandler)
 
 	// 7.6. Create web handler and register GUI routes.
-	attentionSvc := application.NewAttentionService(thresholdStore, reviewStore, cfg.GitHubUsername)
 	webHandler := webhandler.NewHandler(prStore, repoStore, reviewSvc, healthSvc, pollSvc, cfg.GitHubUsername, slog.Default(), credStore, thresholdStore, ignoreStore, writerFactory, jiraConnStore, jiraConnStore, jiraClientFactory)
 	webHandler.WithAttentionService(attentionSvc)
+	webHandler.WithMentionStore(mentionStore)
+	webHandler.WithSLAService(slaSvc)
+	webHandler.WithLintService(lintSvc)
+	webHandler.WithComparisonService(application.NewComparisonService(prStore, prLinkStore, reviewSvc, healthSvc))
+	webHandler.WithDecisionStore(decisionStore)
+	webHandler.WithPRNoteStore(prNoteStore)
+	webHandler.WithCommentSearchStore(commentSearchStore)
+	webHandler.WithWatchStore(watchStore)
+	if !readOnly {
+		webHandler.WithCheckWatchStore(checkWatchStore)
+	}
+	webHandler.WithMilestoneCache(application.NewMilestoneCache())
+	webHandler.WithRepoGroupStore(repoGroupStore)
+	webHandler.WithReviewHistoryStore(sqliteadapter.NewReviewHistoryRepo(db))
+	webHandler.WithArchiveService(archiveSvc)
+	webHandler.WithBranchProtectionStore(branchProtectionStore)
+	webHandler.WithRepoSettingsStore(repoSettingsStore)
+	webHandler.WithRelevantPRsOnly(cfg.RelevantPRsOnly)
+	webHandler.WithQuickActionStore(quickActionStore)
+	webHandler.WithCardLayoutStore(sqliteadapter.NewCardLayoutRepo(db))
+	webHandler.WithGitHubAccounts(githubAccountStore, githubAccountStore)
+	webHandler.WithAPITokenService(apiTokenSvc)
+	webHandler.WithRepoPauser(repoStore)
+	webHandler.WithRepoImportService(repoImportSvc)
+	webHandler.WithRepoTrashService(repoTrashSvc)
+	if bitbucketClient != nil {
+		webHandler.WithSCMProvider(model.ProviderBitbucket, bitbucketClient, cfg.BitbucketUsername)
+	}
+	webHandler.WithInboxService(inboxSvc)
+	webHandler.WithSystemService(systemSvc)
+	webHandler.WithBotConfigStore(botConfigStore, reviewRollupSvc)
+	webHandler.WithFocusService(application.NewFocusService(sqliteadapter.NewFocusRepo(db)))
+	webHandler.WithReplyTemplateService(application.NewReplyTemplateService(sqliteadapter.NewReplyTemplateRepo(db)))
+	webHandler.WithAutocompleteService(application.NewAutocompleteService(sqliteadapter.NewParticipantRepo(db)))
+	// Opening a PR records the visit, so the since-last-view banner needs writes.
+	if !readOnly {
+		webHandler.WithPRViewStore(sqliteadapter.NewPRViewRepo(db))
+	}
+	// Browser preferences are saved as they change, so they need writes too.
+	// Preferences of browsers unused for half a year are dropped at startup.
+	if !readOnly {
+		browserPrefStore := sqliteadapter.NewBrowserPreferenceRepo(db)
+		const browserPrefMaxAge = 180 * 24 * time.Hour
+		if n, err := browserPrefStore.PrunePreferences(ctx, time.Now().Add(-browserPrefMaxAge)); err != nil {
+			slog.Warn("failed to prune browser preferences", "error", err)
+		} else if n > 0 {
+			slog.Info("pruned browser preferences", "count", n)
+		}
+		webHandler.WithBrowserPreferenceStore(browserPrefStore)
+	}
+	if signalWebhookSvc != nil {
+		webHandler.WithSignalWebhookService(signalWebhookSvc)
+		webHandler.WithAttentionRuleService(attentionRuleSvc)
+	}
+	if outboxSvc != nil {
+		webHandler.WithOutboxService(outboxSvc)
+	}
+	if eventWebhookSvc != nil {
+		webHandler.WithEventWebhookService(eventWebhookSvc)
+	}
+	if reportSvc != nil {
+		webHandler.WithReportService(reportSvc)
+	}
+	if teamStatsSvc != nil {
+		webHandler.WithTeamStatsService(teamStatsSvc)
+	}
+	if embedSigner != nil {
+		webHandler.WithEmbedSigner(embedSigner)
+	}
+	// The what's-new panel is informational; failing to set it up only hides it.
+	// It records dismissals, so it is unavailable in read-only mode.
+	if readOnly {
+		slog.Info("what's new panel disabled in read-only mode")
+	} else if whatsNewSvc, err := newWhatsNewService(ctx, db, schemaBefore); err != nil {
+		slog.Warn("what's new panel disabled", "error", err)
+	} else {
+		webHandler.WithWhatsNewService(whatsNewSvc)
+	}
 	webhandler.RegisterRoutes(mux, webHandler)
+	// Links rendered by the GUI carry the base path; StripBasePath removes it
+	// from requests below.
+	basepath.Set(cfg.BasePath)
 
 	// Apply middleware.
-	handler := httphandler.ApplyMiddleware(mux, slog.Default())
+	var handler http.Handler = mux
+	if readOnly {
+		handler = httphandler.RejectWrites(handler, readOnlyReason)
+	}
+	if cfg.TLSClientCAFile != "" {
+		handler = httphandler.RequireClientCert(handler)
+	}
+	handler = httphandler.StripBasePath(handler, cfg.BasePath)
+	handler = httphandler.ApplyMiddleware(handler, slog.Default())
 
 	srv := &http.Server{
 		Addr:              cfg.ListenAddr,
 		Handler:           handler,
+		TLSConfig:         tlsConfig,
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       10 * time.Second,
 		WriteTimeout:      30 * time.Second,
@@ -144,8 +694,17 @@ func run() error {
 	}
 
 	go func() {
-		slog.Info("http server starting", "addr", cfg.ListenAddr)
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Info("http server starting",
+			"addr", cfg.ListenAddr,
+			"tls", tlsConfig != nil,
+			"client_certs", cfg.TLSClientCAFile != "",
+		)
+		serve := srv.ListenAndServe
+		if tlsConfig != nil {
+			// Certificates come from tlsConfig, so no file paths are passed.
+			serve = func() error { return srv.ListenAndServeTLS("", "") }
+		}
+		if err := serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			slog.Error("http server error", "error", err)
 		}
 	}()
@@ -161,7 +720,7 @@ func run() error {
 	<-ctx.Done()
 	slog.Info("shutting down")
 
-	// 10. Graceful shutdown with 10s timeout for future HTTP server drain.
+	// 10. Graceful shutdown with 10s timeout for HTTP server and poll drain.
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -169,7 +728,37 @@ func run() error {
 		slog.Error("http server shutdown error", "error", err)
 	}
 
+	// Wait for the poll loop to finish or roll back its in-flight PR sync
+	// before the deferred database close runs.
+	if !readOnly && !demo {
+		select {
+		case <-pollSvc.Drained():
+		case <-shutdownCtx.Done():
+			slog.Warn("poll service did not drain before shutdown timeout")
+		}
+	}
+
 	// 11. Log shutdown complete.
 	slog.Info("shutdown complete")
 	return nil
 }
+
+// newWhatsNewService builds the what's-new service from the embedded
+// changelog and migrations and records its first-run baseline. schemaBefore
+// is the migration state read before this startup's migrations ran.
+func newWhatsNewService(ctx context.Context, db *sqliteadapter.DB, schemaBefore sqliteadapter.MigrationState) (*application.WhatsNewService, error) {
+	releases, err := changelog.Releases()
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := sqliteadapter.EmbeddedMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	svc := application.NewWhatsNewService(sqliteadapter.NewWhatsNewRepo(db), releases, migrations, schemaBefore.Latest)
+	if err := svc.Initialize(ctx, schemaBefore.Current); err != nil {
+		return nil, err
+	}
+	return svc, nil
+}
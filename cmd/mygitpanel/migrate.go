@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	sqliteadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/sqlite"
+	"github.com/ericfisherdev/mygitpanel/internal/config"
+)
+
+// migrateUsage documents the migrate subcommand's actions.
+const migrateUsage = `usage: mygitpanel migrate [-config file] <action>
+
+  status           show the applied schema version and every embedded migration
+  up [version]     apply pending migrations, or migrate up or down to version
+  down [steps]     revert the last steps migrations (default 1)
+  force <version>  record version as applied and clear a dirty flag without running anything
+
+up and down back up the database next to it before changing the schema.`
+
+// runMigrate runs a migrate action against the configured database, prints
+// the outcome to w, and returns the process exit code. Stop mygitpanel first;
+// the server migrates up on start, undoing a down.
+func runMigrate(w io.Writer, configPath string, args []string) int {
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Fprintln(w, migrateUsage)
+		return 2
+	}
+
+	ctx := context.Background()
+	if err := migrate(ctx, w, configPath, args[0], args[1:]); err != nil {
+		fmt.Fprintf(w, "mygitpanel migrate %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// migrate opens the database and dispatches a single action.
+func migrate(ctx context.Context, w io.Writer, configPath, action string, args []string) error {
+	cfg, err := config.LoadFile(configPath)
+	if err != nil {
+		return err
+	}
+	if cfg.DBMemory {
+		return errors.New("MYGITPANEL_DB=memory keeps no database to migrate; it is migrated on every start")
+	}
+	db, err := openExistingDB(ctx, cfg.DBPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%s does not exist; it is created and migrated on first start", cfg.DBPath)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() { _ = db.Close() }()
+
+	state, err := sqliteadapter.GetMigrationState(db.Writer)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "status":
+		return writeMigrationStatus(w, state)
+	case "up":
+		target := state.Latest
+		if len(args) == 1 {
+			if target, err = parseVersion(args[0]); err != nil {
+				return err
+			}
+		}
+		if target == state.Current {
+			fmt.Fprintf(w, "schema version %d, nothing to do\n", state.Current)
+			return nil
+		}
+		if err := backupForMigrate(ctx, w, db, cfg.DBPath, state); err != nil {
+			return err
+		}
+		if err := sqliteadapter.MigrateTo(db.Writer, target); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) == 1 {
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("steps must be a positive number, got %q", args[0])
+			}
+		}
+		if err := backupForMigrate(ctx, w, db, cfg.DBPath, state); err != nil {
+			return err
+		}
+		if err := sqliteadapter.RollbackMigrations(db.Writer, steps); err != nil {
+			return err
+		}
+	case "force":
+		if len(args) != 1 {
+			return errors.New("force needs the version to record")
+		}
+		version, err := parseVersion(args[0])
+		if err != nil {
+			return err
+		}
+		if err := sqliteadapter.ForceMigrationVersion(db.Writer, version); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown action\n\n%s", migrateUsage)
+	}
+
+	after, err := sqliteadapter.GetMigrationState(db.Writer)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "schema version %d -> %d (latest %d)\n", state.Current, after.Current, after.Latest)
+	return nil
+}
+
+// writeMigrationStatus prints the applied version and marks each embedded
+// migration as applied or pending.
+func writeMigrationStatus(w io.Writer, state sqliteadapter.MigrationState) error {
+	migrations, err := sqliteadapter.EmbeddedMigrations()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "schema version %d, latest %d", state.Current, state.Latest)
+	if state.Dirty {
+		fmt.Fprint(w, " (dirty)")
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w)
+	for _, m := range migrations {
+		status := "applied"
+		if m.Version > state.Current {
+			status = "pending"
+		}
+		fmt.Fprintf(w, "  %06d  %-7s  %s\n", m.Version, status, m.Description)
+	}
+	return state.Check()
+}
+
+// backupForMigrate backs up the database before a migrate action and says where.
+func backupForMigrate(ctx context.Context, w io.Writer, db *sqliteadapter.DB, dbPath string, state sqliteadapter.MigrationState) error {
+	dest, err := backupBeforeMigrating(ctx, db, dbPath, state)
+	if err != nil {
+		return err
+	}
+	if dest != "" {
+		fmt.Fprintf(w, "backed up schema version %d to %s\n", state.Current, dest)
+	}
+	return nil
+}
+
+// backupBeforeMigrating copies the database next to it before its schema
+// changes, so a failed or unwanted migration can be undone by restoring the
+// copy, and returns the copy's path. A database with no migrations applied
+// holds nothing to back up, so it returns "".
+func backupBeforeMigrating(ctx context.Context, db *sqliteadapter.DB, dbPath string, state sqliteadapter.MigrationState) (string, error) {
+	if state.Current == 0 {
+		return "", nil
+	}
+	dest := sqliteadapter.MigrationBackupPath(dbPath, state.Current, time.Now())
+	if err := db.Backup(ctx, dest); err != nil {
+		return "", fmt.Errorf("back up before migrating: %w", err)
+	}
+	return dest, nil
+}
+
+// parseVersion parses a schema version argument.
+func parseVersion(arg string) (uint, error) {
+	version, err := strconv.ParseUint(arg, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("version must be a non-negative number, got %q", arg)
+	}
+	return uint(version), nil
+}
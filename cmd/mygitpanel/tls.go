@@ -0,0 +1,57 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/acme/autocert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/config"
+)
+
+// serverTLSConfig builds the HTTPS settings described by cfg, or returns nil
+// for plain HTTP. Certificate files are loaded once at startup; with autocert
+// the certificate is obtained from Let's Encrypt on the first handshake and
+// renewed automatically. Let's Encrypt validates with the TLS-ALPN-01
+// challenge, so the listen address must be reachable on port 443 as
+// cfg.TLSAutocertHost.
+func serverTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	var tlsConfig *tls.Config
+	switch {
+	case cfg.TLSAutocertHost != "":
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.TLSAutocertHost),
+			Cache:      autocert.DirCache(cfg.TLSAutocertDir),
+		}
+		tlsConfig = manager.TLSConfig()
+	case cfg.TLSCertFile != "":
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load TLS certificate: %w", err)
+		}
+		tlsConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	default:
+		return nil, nil
+	}
+	tlsConfig.MinVersion = tls.VersionTLS12
+
+	if cfg.TLSClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.TLSClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("client CA file %s holds no PEM certificates", cfg.TLSClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		// Certificates are verified when offered but only required for the
+		// API (httphandler.RequireClientCert), so browsers without one can
+		// still open the dashboard.
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,606 @@
+// Package bitbucket implements the SCMProvider port for Bitbucket Cloud using
+// net/http and the Bitbucket REST API 2.0.
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.SCMProvider = (*Client)(nil)
+
+// DefaultBaseURL is the Bitbucket Cloud REST API.
+const DefaultBaseURL = "https://api.bitbucket.org/2.0"
+
+// commentIDOffset is added to Bitbucket comment IDs so they never collide
+// with GitHub comment IDs stored in the same tables. Writes subtract it.
+const commentIDOffset = 1 << 50
+
+// Client implements driven.SCMProvider for Bitbucket Cloud. Repository full
+// names are "workspace/repo_slug". It authenticates with HTTP Basic auth
+// using a Bitbucket username and an app password or API token.
+type Client struct {
+	baseURL    string
+	username   string
+	password   string
+	httpClient *http.Client
+}
+
+// NewClient creates a Client for Bitbucket Cloud.
+func NewClient(username, appPassword string) *Client {
+	return NewClientWithBaseURL(&http.Client{Timeout: 30 * time.Second}, DefaultBaseURL, username, appPassword)
+}
+
+// NewClientWithBaseURL creates a Client with a custom http.Client and API
+// base URL, for tests.
+func NewClientWithBaseURL(httpClient *http.Client, baseURL, username, appPassword string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		username:   username,
+		password:   appPassword,
+		httpClient: httpClient,
+	}
+}
+
+// --- API types ---
+
+// bbUser is a Bitbucket account.
+type bbUser struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+	AccountID   string `json:"account_id"`
+}
+
+// login is the name mygitpanel knows the account by: its nickname, which
+// Bitbucket uses for @mentions, or its display name when it has none.
+func (u bbUser) login() string {
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.DisplayName
+}
+
+// bbParticipant is a user's involvement in a pull request. State is
+// "approved", "changes_requested", or null.
+type bbParticipant struct {
+	User           bbUser     `json:"user"`
+	Role           string     `json:"role"`
+	Approved       bool       `json:"approved"`
+	State          string     `json:"state"`
+	ParticipatedOn *time.Time `json:"participated_on"`
+}
+
+type bbPullRequest struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"` // OPEN, MERGED, DECLINED, or SUPERSEDED
+	Draft       bool   `json:"draft"`
+	Author      bbUser `json:"author"`
+	Source      struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"source"`
+	Destination struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"destination"`
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on"`
+	Links     struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	Reviewers    []bbUser        `json:"reviewers"`
+	Participants []bbParticipant `json:"participants"`
+}
+
+type bbComment struct {
+	ID      int64 `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	User      bbUser    `json:"user"`
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on"`
+	Deleted   bool      `json:"deleted"`
+	Inline    *struct {
+		Path string `json:"path"`
+		From *int   `json:"from"`
+		To   *int   `json:"to"`
+	} `json:"inline"`
+	Parent *struct {
+		ID int64 `json:"id"`
+	} `json:"parent"`
+	// Resolution is set once the comment's thread is resolved.
+	Resolution *struct{} `json:"resolution"`
+}
+
+type bbCommitStatus struct {
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	State     string    `json:"state"` // INPROGRESS, SUCCESSFUL, FAILED, or STOPPED
+	URL       string    `json:"url"`
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on"`
+}
+
+type bbDiffStat struct {
+	LinesAdded   int `json:"lines_added"`
+	LinesRemoved int `json:"lines_removed"`
+}
+
+// page is one page of a paginated Bitbucket collection; Next is the URL of
+// the following page, empty on the last.
+type page[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"`
+}
+
+// --- HTTP helpers ---
+
+// do sends a request to rawURL, or to the API path when it is relative, and
+// decodes a JSON response into v when v is non-nil.
+func (c *Client) do(ctx context.Context, method, rawURL string, body, v any) error {
+	if !strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://") {
+		rawURL = c.baseURL + rawURL
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("bitbucket: marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, reqBody)
+	if err != nil {
+		return fmt.Errorf("bitbucket: build request: %w", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		return fmt.Errorf("%w: bitbucket: %s %s: %w", driven.ErrTransient, method, req.URL.Path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return statusError(method, req.URL.Path, resp)
+	}
+	if v == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("bitbucket: decode %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+// statusError turns an error response into an error, wrapping
+// driven.ErrRepoUnreachable for 404 and 403 and driven.ErrTransient for 429
+// and 5xx, as the GitHub adapter does.
+func statusError(method, path string, resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body)
+	err := fmt.Errorf("bitbucket: %s %s: HTTP %d: %s", method, path, resp.StatusCode, body.Error.Message)
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusNotFound || code == http.StatusForbidden:
+		return fmt.Errorf("%w: %w", driven.ErrRepoUnreachable, err)
+	case code == http.StatusTooManyRequests || code >= http.StatusInternalServerError:
+		return fmt.Errorf("%w: %w", driven.ErrTransient, err)
+	}
+	return err
+}
+
+// getAll fetches every page of a collection starting at path.
+func getAll[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	var all []T
+	for next := path; next != ""; {
+		var p page[T]
+		if err := c.do(ctx, http.MethodGet, next, nil, &p); err != nil {
+			return nil, err
+		}
+		all = append(all, p.Values...)
+		next = p.Next
+	}
+	return all, nil
+}
+
+// repoPath returns the API path of a repository, "/repositories/{workspace}/{slug}".
+func repoPath(repoFullName string) (string, error) {
+	workspace, slug, ok := strings.Cut(repoFullName, "/")
+	if !ok || workspace == "" || slug == "" || strings.Contains(slug, "/") {
+		return "", fmt.Errorf("invalid repo name %q: expected workspace/repo", repoFullName)
+	}
+	return "/repositories/" + url.PathEscape(workspace) + "/" + url.PathEscape(slug), nil
+}
+
+// pullPath returns the API path of a pull request.
+func pullPath(repoFullName string, prNumber int) (string, error) {
+	base, err := repoPath(repoFullName)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/pullrequests/%d", base, prNumber), nil
+}
+
+// syntheticID derives a stable ID for Bitbucket objects that have no numeric
+// one, such as approvals and commit statuses. IDs are in the upper half of
+// the positive int64 range, far above GitHub's and offset comment IDs.
+func syntheticID(parts ...string) int64 {
+	h := fnv.New64a()
+	for _, p := range parts {
+		_, _ = h.Write([]byte(p))
+		_, _ = h.Write([]byte{0})
+	}
+	return int64(h.Sum64()>>2) | 1<<61
+}
+
+// --- Reads ---
+
+// pullStates maps the GitHub-style state filter to Bitbucket states.
+var pullStates = map[string][]string{
+	"open":   {"OPEN"},
+	"closed": {"MERGED", "DECLINED", "SUPERSEDED"},
+	"all":    {"OPEN", "MERGED", "DECLINED", "SUPERSEDED"},
+}
+
+// FetchPullRequests retrieves pull requests for the given repository filtered
+// by state: "open", "closed", or "all". Merged maps to merged; declined and
+// superseded map to closed.
+func (c *Client) FetchPullRequests(ctx context.Context, repoFullName string, state string) ([]model.PullRequest, error) {
+	base, err := repoPath(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+	states, ok := pullStates[state]
+	if !ok {
+		return nil, fmt.Errorf("invalid pull request state %q", state)
+	}
+
+	q := url.Values{"pagelen": {"50"}, "sort": {"-updated_on"}, "fields": {"+values.reviewers,+values.participants,+values.draft"}}
+	for _, s := range states {
+		q.Add("state", s)
+	}
+	prs, err := getAll[bbPullRequest](ctx, c, base+"/pullrequests?"+q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("listing pull requests for %s: %w", repoFullName, err)
+	}
+
+	out := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		out = append(out, mapPullRequest(pr, repoFullName))
+	}
+	return out, nil
+}
+
+// mapPullRequest converts a Bitbucket pull request to a domain model
+// PullRequest. Reviewers who have not approved or requested changes yet are
+// the requested reviewers, since Bitbucket keeps both in one list.
+func mapPullRequest(pr bbPullRequest, repoFullName string) model.PullRequest {
+	status := model.PRStatusOpen
+	var closedAt time.Time
+	switch pr.State {
+	case "MERGED":
+		status, closedAt = model.PRStatusMerged, pr.UpdatedOn
+	case "DECLINED", "SUPERSEDED":
+		status, closedAt = model.PRStatusClosed, pr.UpdatedOn
+	}
+
+	reviewed := make(map[string]bool)
+	for _, p := range pr.Participants {
+		if p.Approved || p.State != "" {
+			reviewed[p.User.AccountID] = true
+		}
+	}
+	requested := []string{}
+	for _, r := range pr.Reviewers {
+		if !reviewed[r.AccountID] {
+			requested = append(requested, r.login())
+		}
+	}
+
+	return model.PullRequest{
+		Number:             pr.ID,
+		RepoFullName:       repoFullName,
+		Title:              pr.Title,
+		Author:             pr.Author.login(),
+		Status:             status,
+		IsDraft:            pr.Draft,
+		URL:                pr.Links.HTML.Href,
+		Branch:             pr.Source.Branch.Name,
+		BaseBranch:         pr.Destination.Branch.Name,
+		HeadSHA:            pr.Source.Commit.Hash,
+		Labels:             []string{},
+		OpenedAt:           pr.CreatedOn,
+		UpdatedAt:          pr.UpdatedOn,
+		LastActivityAt:     pr.UpdatedOn,
+		ClosedAt:           closedAt,
+		RequestedReviewers: requested,
+		RequestedTeamSlugs: []string{},
+		Body:               pr.Description,
+	}
+}
+
+// fetchPull retrieves a single pull request with its participants.
+func (c *Client) fetchPull(ctx context.Context, repoFullName string, prNumber int) (bbPullRequest, error) {
+	path, err := pullPath(repoFullName, prNumber)
+	if err != nil {
+		return bbPullRequest{}, err
+	}
+	var pr bbPullRequest
+	if err := c.do(ctx, http.MethodGet, path, nil, &pr); err != nil {
+		return bbPullRequest{}, fmt.Errorf("fetching pull request %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return pr, nil
+}
+
+// FetchReviews returns one review per participant who approved or requested
+// changes. Bitbucket keeps only each participant's current verdict, so there
+// is no review history.
+func (c *Client) FetchReviews(ctx context.Context, repoFullName string, prNumber int) ([]model.Review, error) {
+	pr, err := c.fetchPull(ctx, repoFullName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := []model.Review{}
+	for _, p := range pr.Participants {
+		var state model.ReviewState
+		switch {
+		case p.State == "changes_requested":
+			state = model.ReviewStateChangesRequested
+		case p.Approved || p.State == "approved":
+			state = model.ReviewStateApproved
+		default:
+			continue
+		}
+		var submittedAt time.Time
+		if p.ParticipatedOn != nil {
+			submittedAt = *p.ParticipatedOn
+		}
+		reviews = append(reviews, model.Review{
+			ID:            syntheticID("review", repoFullName, fmt.Sprint(prNumber), p.User.AccountID),
+			ReviewerLogin: p.User.login(),
+			State:         state,
+			CommitID:      pr.Source.Commit.Hash,
+			SubmittedAt:   submittedAt,
+		})
+	}
+	return reviews, nil
+}
+
+// fetchComments retrieves every comment on a pull request, deleted ones
+// excluded.
+func (c *Client) fetchComments(ctx context.Context, repoFullName string, prNumber int) ([]bbComment, error) {
+	path, err := pullPath(repoFullName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	all, err := getAll[bbComment](ctx, c, path+"/comments?pagelen=100")
+	if err != nil {
+		return nil, fmt.Errorf("listing comments for %s#%d: %w", repoFullName, prNumber, err)
+	}
+	comments := all[:0]
+	for _, cm := range all {
+		if !cm.Deleted {
+			comments = append(comments, cm)
+		}
+	}
+	return comments, nil
+}
+
+// threadRoots maps each comment ID to the ID of the first comment of its
+// thread. Bitbucket replies name their direct parent; GitHub replies, which
+// the domain model follows, name the thread's first comment.
+func threadRoots(comments []bbComment) map[int64]int64 {
+	parents := make(map[int64]int64, len(comments))
+	for _, cm := range comments {
+		if cm.Parent != nil {
+			parents[cm.ID] = cm.Parent.ID
+		}
+	}
+	roots := make(map[int64]int64, len(comments))
+	for _, cm := range comments {
+		root := cm.ID
+		for depth := 0; depth < len(comments); depth++ {
+			parent, ok := parents[root]
+			if !ok {
+				break
+			}
+			root = parent
+		}
+		roots[cm.ID] = root
+	}
+	return roots
+}
+
+// FetchReviewComments returns the inline comments on a pull request.
+func (c *Client) FetchReviewComments(ctx context.Context, repoFullName string, prNumber int) ([]model.ReviewComment, error) {
+	comments, err := c.fetchComments(ctx, repoFullName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	roots := threadRoots(comments)
+
+	out := []model.ReviewComment{}
+	for _, cm := range comments {
+		if cm.Inline == nil {
+			continue
+		}
+		rc := model.ReviewComment{
+			ID:          cm.ID + commentIDOffset,
+			Author:      cm.User.login(),
+			Body:        cm.Content.Raw,
+			Path:        cm.Inline.Path,
+			SubjectType: "file",
+			CreatedAt:   cm.CreatedOn,
+			UpdatedAt:   cm.UpdatedOn,
+		}
+		switch {
+		case cm.Inline.To != nil:
+			rc.Line, rc.Side, rc.SubjectType = *cm.Inline.To, "RIGHT", "line"
+		case cm.Inline.From != nil:
+			rc.Line, rc.Side, rc.SubjectType = *cm.Inline.From, "LEFT", "line"
+		}
+		if root := roots[cm.ID]; root != cm.ID {
+			id := root + commentIDOffset
+			rc.InReplyToID = &id
+		}
+		out = append(out, rc)
+	}
+	return out, nil
+}
+
+// FetchIssueComments returns the comments on a pull request that are not
+// attached to a line or file, replies included.
+func (c *Client) FetchIssueComments(ctx context.Context, repoFullName string, prNumber int) ([]model.IssueComment, error) {
+	comments, err := c.fetchComments(ctx, repoFullName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []model.IssueComment{}
+	for _, cm := range comments {
+		if cm.Inline != nil {
+			continue
+		}
+		out = append(out, model.IssueComment{
+			ID:        cm.ID + commentIDOffset,
+			Author:    cm.User.login(),
+			Body:      cm.Content.Raw,
+			CreatedAt: cm.CreatedOn,
+			UpdatedAt: cm.UpdatedOn,
+		})
+	}
+	return out, nil
+}
+
+// FetchThreadResolution returns whether each inline thread, keyed by its
+// first comment's ID, is resolved.
+func (c *Client) FetchThreadResolution(ctx context.Context, repoFullName string, prNumber int) (map[int64]bool, error) {
+	comments, err := c.fetchComments(ctx, repoFullName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(map[int64]bool)
+	for _, cm := range comments {
+		if cm.Inline != nil && cm.Parent == nil {
+			resolved[cm.ID+commentIDOffset] = cm.Resolution != nil
+		}
+	}
+	return resolved, nil
+}
+
+// FetchCheckRuns returns the build statuses of a commit as check runs, which
+// is how Bitbucket Pipelines and external CI report on pull requests.
+func (c *Client) FetchCheckRuns(ctx context.Context, repoFullName string, ref string) ([]model.CheckRun, error) {
+	base, err := repoPath(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := getAll[bbCommitStatus](ctx, c, base+"/commit/"+url.PathEscape(ref)+"/statuses?pagelen=100")
+	if err != nil {
+		return nil, fmt.Errorf("listing commit statuses for %s@%s: %w", repoFullName, ref, err)
+	}
+
+	runs := make([]model.CheckRun, 0, len(statuses))
+	for _, s := range statuses {
+		run := model.CheckRun{
+			ID:         syntheticID("status", repoFullName, ref, s.Key),
+			Name:       s.Name,
+			Status:     "completed",
+			DetailsURL: s.URL,
+			StartedAt:  s.CreatedOn,
+		}
+		if run.Name == "" {
+			run.Name = s.Key
+		}
+		switch s.State {
+		case "INPROGRESS":
+			run.Status = "in_progress"
+		case "SUCCESSFUL":
+			run.Conclusion = "success"
+		case "FAILED":
+			run.Conclusion = "failure"
+		case "STOPPED":
+			run.Conclusion = "cancelled" //nolint:misspell // GitHub API uses British "cancelled"
+		}
+		if run.Status == "completed" {
+			run.CompletedAt = s.UpdatedOn
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// FetchCombinedStatus returns nil: Bitbucket build statuses are reported by
+// FetchCheckRuns.
+func (c *Client) FetchCombinedStatus(context.Context, string, string) (*model.CombinedStatus, error) {
+	return nil, nil
+}
+
+// FetchPRDetail returns diff stats for a single PR. Bitbucket does not report
+// whether a pull request can merge, so Mergeable is always unknown.
+func (c *Client) FetchPRDetail(ctx context.Context, repoFullName string, prNumber int) (*model.PRDetail, error) {
+	path, err := pullPath(repoFullName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := getAll[bbDiffStat](ctx, c, path+"/diffstat?pagelen=500")
+	if err != nil {
+		return nil, fmt.Errorf("fetching diffstat for %s#%d: %w", repoFullName, prNumber, err)
+	}
+
+	detail := &model.PRDetail{ChangedFiles: len(stats), Mergeable: model.MergeableUnknown}
+	for _, s := range stats {
+		detail.Additions += s.LinesAdded
+		detail.Deletions += s.LinesRemoved
+	}
+	return detail, nil
+}
+
+// FetchRequiredStatusChecks returns nil: Bitbucket's merge checks are not
+// read, so no check is marked required.
+func (c *Client) FetchRequiredStatusChecks(context.Context, string, string) ([]string, error) {
+	return nil, nil
+}
+
+// FetchBranchProtection returns a protection without RequiresReviews:
+// Bitbucket's branch restrictions are not read.
+func (c *Client) FetchBranchProtection(_ context.Context, repoFullName string, branch string) (model.BranchProtection, error) {
+	return model.BranchProtection{RepoFullName: repoFullName, Branch: branch}, nil
+}
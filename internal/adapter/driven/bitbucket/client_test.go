@@ -0,0 +1,226 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// request is a write the test server received.
+type request struct {
+	Method, Path string
+	Body         map[string]any
+}
+
+// newTestClient serves canned responses keyed by "METHOD path" and records
+// every write.
+func newTestClient(t *testing.T, responses map[string]string) (*Client, *[]request) {
+	t.Helper()
+	var writes []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "alice" || pass != "app-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Method != http.MethodGet {
+			req := request{Method: r.Method, Path: r.URL.Path}
+			data, _ := io.ReadAll(r.Body)
+			if len(data) > 0 {
+				require.NoError(t, json.Unmarshal(data, &req.Body))
+			}
+			writes = append(writes, req)
+		}
+		body, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"message":"not found"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return NewClientWithBaseURL(srv.Client(), srv.URL, "alice", "app-password"), &writes
+}
+
+const prJSON = `{
+	"id": 12,
+	"title": "Add caching",
+	"description": "Caches the feed.",
+	"state": "OPEN",
+	"author": {"nickname": "bob", "account_id": "b"},
+	"source": {"branch": {"name": "cache"}, "commit": {"hash": "abc123"}},
+	"destination": {"branch": {"name": "main"}},
+	"created_on": "2026-10-01T10:00:00Z",
+	"updated_on": "2026-10-02T10:00:00Z",
+	"links": {"html": {"href": "https://bitbucket.org/acme/api/pull-requests/12"}},
+	"reviewers": [{"nickname": "alice", "account_id": "a"}, {"nickname": "carol", "account_id": "c"}],
+	"participants": [
+		{"user": {"nickname": "carol", "account_id": "c"}, "role": "REVIEWER", "approved": true, "state": "approved", "participated_on": "2026-10-02T09:00:00Z"},
+		{"user": {"nickname": "dave", "account_id": "d"}, "role": "PARTICIPANT", "approved": false, "state": "changes_requested", "participated_on": "2026-10-02T08:00:00Z"},
+		{"user": {"nickname": "erin", "account_id": "e"}, "role": "PARTICIPANT", "approved": false, "state": null}
+	]
+}`
+
+func TestClient_FetchPullRequests(t *testing.T) {
+	client, _ := newTestClient(t, nil)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"MERGED", "DECLINED", "SUPERSEDED"}, r.URL.Query()["state"])
+		if r.URL.Query().Get("page") == "" {
+			_, _ = io.WriteString(w, `{"values": [`+prJSON+`], "next": "http://`+r.Host+r.URL.Path+`?page=2&state=MERGED&state=DECLINED&state=SUPERSEDED"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"values": [{"id": 3, "state": "DECLINED", "updated_on": "2026-09-01T00:00:00Z"}]}`)
+	}))
+	defer srv.Close()
+	client.baseURL = srv.URL
+
+	prs, err := client.FetchPullRequests(context.Background(), "acme/api", "closed")
+	require.NoError(t, err)
+	require.Len(t, prs, 2, "follows the next link")
+
+	pr := prs[0]
+	assert.Equal(t, 12, pr.Number)
+	assert.Equal(t, "acme/api", pr.RepoFullName)
+	assert.Equal(t, "bob", pr.Author)
+	assert.Equal(t, model.PRStatusOpen, pr.Status)
+	assert.Equal(t, "cache", pr.Branch)
+	assert.Equal(t, "main", pr.BaseBranch)
+	assert.Equal(t, "abc123", pr.HeadSHA)
+	assert.Equal(t, []string{"alice"}, pr.RequestedReviewers, "carol already approved")
+	assert.True(t, pr.ClosedAt.IsZero())
+
+	assert.Equal(t, model.PRStatusClosed, prs[1].Status)
+	assert.False(t, prs[1].ClosedAt.IsZero())
+
+	_, err = client.FetchPullRequests(context.Background(), "acme/api", "draft")
+	assert.Error(t, err)
+	_, err = client.FetchPullRequests(context.Background(), "acme", "open")
+	assert.ErrorContains(t, err, "expected workspace/repo")
+}
+
+func TestClient_Reads(t *testing.T) {
+	const pr = "/repositories/acme/api/pullrequests/12"
+	client, _ := newTestClient(t, map[string]string{
+		"GET " + pr: prJSON,
+		"GET " + pr + "/comments": `{"values": [
+			{"id": 1, "content": {"raw": "Why a map?"}, "user": {"nickname": "carol"}, "inline": {"path": "cache.go", "to": 10}, "created_on": "2026-10-02T09:00:00Z"},
+			{"id": 2, "content": {"raw": "Simpler."}, "user": {"nickname": "bob"}, "inline": {"path": "cache.go", "to": 10}, "parent": {"id": 1}},
+			{"id": 3, "content": {"raw": "Fair."}, "user": {"nickname": "carol"}, "inline": {"path": "cache.go", "to": 10}, "parent": {"id": 2}},
+			{"id": 4, "content": {"raw": "Old line"}, "user": {"nickname": "carol"}, "inline": {"path": "feed.go", "from": 3}, "resolution": {"type": "comment"}},
+			{"id": 5, "content": {"raw": "Looks good"}, "user": {"nickname": "erin"}},
+			{"id": 6, "content": {"raw": ""}, "user": {"nickname": "erin"}, "deleted": true}
+		]}`,
+		"GET /repositories/acme/api/commit/abc123/statuses": `{"values": [
+			{"key": "build", "name": "Pipeline #7", "state": "SUCCESSFUL", "url": "https://ci/7"},
+			{"key": "lint", "state": "INPROGRESS"},
+			{"key": "deploy", "state": "STOPPED"}
+		]}`,
+		"GET " + pr + "/diffstat": `{"values": [{"lines_added": 10, "lines_removed": 2}, {"lines_added": 5, "lines_removed": 0}]}`,
+	})
+	ctx := context.Background()
+
+	reviews, err := client.FetchReviews(ctx, "acme/api", 12)
+	require.NoError(t, err)
+	require.Len(t, reviews, 2, "erin has no verdict")
+	assert.Equal(t, "carol", reviews[0].ReviewerLogin)
+	assert.Equal(t, model.ReviewStateApproved, reviews[0].State)
+	assert.Equal(t, "abc123", reviews[0].CommitID)
+	assert.Equal(t, model.ReviewStateChangesRequested, reviews[1].State)
+	assert.Greater(t, reviews[0].ID, int64(1<<61), "synthetic IDs stay clear of real ones")
+
+	comments, err := client.FetchReviewComments(ctx, "acme/api", 12)
+	require.NoError(t, err)
+	require.Len(t, comments, 4)
+	assert.Equal(t, int64(1+commentIDOffset), comments[0].ID)
+	assert.Nil(t, comments[0].InReplyToID)
+	assert.Equal(t, "RIGHT", comments[0].Side)
+	assert.Equal(t, 10, comments[0].Line)
+	require.NotNil(t, comments[2].InReplyToID)
+	assert.Equal(t, int64(1+commentIDOffset), *comments[2].InReplyToID, "replies point at the thread's first comment")
+	assert.Equal(t, "LEFT", comments[3].Side)
+
+	issueComments, err := client.FetchIssueComments(ctx, "acme/api", 12)
+	require.NoError(t, err)
+	require.Len(t, issueComments, 1, "deleted comments are skipped")
+	assert.Equal(t, "Looks good", issueComments[0].Body)
+
+	resolution, err := client.FetchThreadResolution(ctx, "acme/api", 12)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{1 + commentIDOffset: false, 4 + commentIDOffset: true}, resolution)
+
+	runs, err := client.FetchCheckRuns(ctx, "acme/api", "abc123")
+	require.NoError(t, err)
+	require.Len(t, runs, 3)
+	assert.Equal(t, "Pipeline #7", runs[0].Name)
+	assert.Equal(t, "completed", runs[0].Status)
+	assert.Equal(t, "success", runs[0].Conclusion)
+	assert.Equal(t, "lint", runs[1].Name)
+	assert.Equal(t, "in_progress", runs[1].Status)
+	assert.Equal(t, "cancelled", runs[2].Conclusion) //nolint:misspell // GitHub API uses British "cancelled"
+
+	detail, err := client.FetchPRDetail(ctx, "acme/api", 12)
+	require.NoError(t, err)
+	assert.Equal(t, model.PRDetail{Additions: 15, Deletions: 2, ChangedFiles: 2, Mergeable: model.MergeableUnknown}, *detail)
+
+	_, err = client.FetchReviews(ctx, "acme/gone", 1)
+	assert.ErrorIs(t, err, driven.ErrRepoUnreachable)
+}
+
+func TestClient_Writes(t *testing.T) {
+	const pr = "/repositories/acme/api/pullrequests/12"
+	client, writes := newTestClient(t, map[string]string{
+		"GET " + pr:                          prJSON,
+		"POST " + pr + "/comments":           `{}`,
+		"POST " + pr + "/request-changes":    `{}`,
+		"POST " + pr + "/comments/1/resolve": `{}`,
+		"GET /repositories/acme/api/effective-default-reviewers": `{"values": [
+			{"user": {"nickname": "bob", "account_id": "b"}},
+			{"user": {"nickname": "carol", "account_id": "c"}}
+		]}`,
+		"GET /user": `{"nickname": "alice"}`,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, client.SubmitReview(ctx, "acme/api", 12, driven.ReviewRequest{
+		Event:    "REQUEST_CHANGES",
+		Body:     "A few things",
+		Comments: []driven.DraftLineComment{{Path: "cache.go", Line: 4, Side: "LEFT", Body: "Stale"}},
+	}))
+	require.NoError(t, client.CreateReplyComment(ctx, "acme/api", 12, 1+commentIDOffset, "Done"))
+	require.NoError(t, client.CreateIssueComment(ctx, "acme/api", 12, "Rebased"))
+	require.NoError(t, client.ResolveReviewThread(ctx, "acme/api", 12, 1+commentIDOffset))
+
+	require.Len(t, *writes, 6)
+	assert.Equal(t, map[string]any{
+		"content": map[string]any{"raw": "Stale"},
+		"inline":  map[string]any{"path": "cache.go", "from": float64(4)},
+	}, (*writes)[0].Body)
+	assert.Equal(t, map[string]any{"content": map[string]any{"raw": "A few things"}}, (*writes)[1].Body)
+	assert.Equal(t, pr+"/request-changes", (*writes)[2].Path)
+	assert.Equal(t, map[string]any{"id": float64(1)}, (*writes)[3].Body["parent"], "the offset is removed")
+	assert.Equal(t, map[string]any{"content": map[string]any{"raw": "Rebased"}}, (*writes)[4].Body)
+	assert.Equal(t, pr+"/comments/1/resolve", (*writes)[5].Path)
+
+	suggested, err := client.FetchSuggestedReviewers(ctx, "acme/api", 12)
+	require.NoError(t, err)
+	assert.Equal(t, []model.SuggestedReviewer{{Login: "carol"}}, suggested, "the author is excluded")
+
+	assert.ErrorIs(t, client.ConvertPullRequestToDraft(ctx, "acme/api", 12), driven.ErrUnsupported)
+	assert.ErrorIs(t, client.RequestReviewers(ctx, "acme/api", 12, []string{"carol"}), driven.ErrUnsupported)
+
+	login, err := client.ValidateToken(ctx, "app-password")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", login)
+	_, err = client.ValidateToken(ctx, "wrong")
+	assert.Error(t, err)
+}
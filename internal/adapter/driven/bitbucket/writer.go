@@ -0,0 +1,189 @@
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// commentRequest is the body of a pull request comment create.
+type commentRequest struct {
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Inline *inlineRequest `json:"inline,omitempty"`
+	Parent *parentRequest `json:"parent,omitempty"`
+}
+
+type inlineRequest struct {
+	Path string `json:"path"`
+	From *int   `json:"from,omitempty"`
+	To   *int   `json:"to,omitempty"`
+}
+
+type parentRequest struct {
+	ID int64 `json:"id"`
+}
+
+// postComment creates a comment on a pull request.
+func (c *Client) postComment(ctx context.Context, repoFullName string, prNumber int, req commentRequest) error {
+	path, err := pullPath(repoFullName, prNumber)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodPost, path+"/comments", req, nil)
+}
+
+// ValidateToken checks an app password for the client's username and returns
+// the account's nickname.
+func (c *Client) ValidateToken(ctx context.Context, token string) (string, error) {
+	probe := NewClientWithBaseURL(&http.Client{Timeout: 10 * time.Second}, c.baseURL, c.username, token)
+	var user bbUser
+	if err := probe.do(ctx, http.MethodGet, "/user", nil, &user); err != nil {
+		return "", fmt.Errorf("token validation failed: %w", err)
+	}
+	return user.login(), nil
+}
+
+// SubmitReview posts the inline comments and body as pull request comments,
+// then approves or requests changes for APPROVE and REQUEST_CHANGES events.
+// Bitbucket has no review object, so a partial failure leaves the comments
+// posted so far in place. CommitID is ignored: Bitbucket comments attach to
+// the pull request, not a commit.
+func (c *Client) SubmitReview(ctx context.Context, repoFullName string, prNumber int, req driven.ReviewRequest) error {
+	path, err := pullPath(repoFullName, prNumber)
+	if err != nil {
+		return err
+	}
+
+	for _, dc := range req.Comments {
+		line := dc.Line
+		inline := &inlineRequest{Path: dc.Path, To: &line}
+		if dc.Side == "LEFT" {
+			inline = &inlineRequest{Path: dc.Path, From: &line}
+		}
+		cr := commentRequest{Inline: inline}
+		cr.Content.Raw = dc.Body
+		if err := c.postComment(ctx, repoFullName, prNumber, cr); err != nil {
+			return fmt.Errorf("posting review comment on %s#%d: %w", repoFullName, prNumber, err)
+		}
+	}
+
+	if req.Body != "" {
+		var cr commentRequest
+		cr.Content.Raw = req.Body
+		if err := c.postComment(ctx, repoFullName, prNumber, cr); err != nil {
+			return fmt.Errorf("posting review body on %s#%d: %w", repoFullName, prNumber, err)
+		}
+	}
+
+	var action string
+	switch req.Event {
+	case "APPROVE":
+		action = "/approve"
+	case "REQUEST_CHANGES":
+		action = "/request-changes"
+	default:
+		return nil
+	}
+	if err := c.do(ctx, http.MethodPost, path+action, nil, nil); err != nil {
+		return fmt.Errorf("submitting review on %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return nil
+}
+
+// CreateReplyComment replies to the comment with the given ID.
+func (c *Client) CreateReplyComment(ctx context.Context, repoFullName string, prNumber int, inReplyTo int64, body string) error {
+	cr := commentRequest{Parent: &parentRequest{ID: inReplyTo - commentIDOffset}}
+	cr.Content.Raw = body
+	if err := c.postComment(ctx, repoFullName, prNumber, cr); err != nil {
+		return fmt.Errorf("creating reply comment on %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return nil
+}
+
+// CreateIssueComment creates a top-level comment on a pull request.
+func (c *Client) CreateIssueComment(ctx context.Context, repoFullName string, prNumber int, body string) error {
+	var cr commentRequest
+	cr.Content.Raw = body
+	if err := c.postComment(ctx, repoFullName, prNumber, cr); err != nil {
+		return fmt.Errorf("creating comment on %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return nil
+}
+
+// ResolveReviewThread resolves the comment thread rooted at rootCommentID.
+func (c *Client) ResolveReviewThread(ctx context.Context, repoFullName string, prNumber int, rootCommentID int64) error {
+	path, err := pullPath(repoFullName, prNumber)
+	if err != nil {
+		return err
+	}
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("%s/comments/%d/resolve", path, rootCommentID-commentIDOffset), nil, nil); err != nil {
+		return fmt.Errorf("resolving comment thread on %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return nil
+}
+
+// ConvertPullRequestToDraft is not supported: Bitbucket Cloud's API cannot
+// change a pull request's draft state.
+func (c *Client) ConvertPullRequestToDraft(context.Context, string, int) error {
+	return fmt.Errorf("converting to draft: %w", driven.ErrUnsupported)
+}
+
+// MarkPullRequestReadyForReview is not supported; see ConvertPullRequestToDraft.
+func (c *Client) MarkPullRequestReadyForReview(context.Context, string, int) error {
+	return fmt.Errorf("marking ready for review: %w", driven.ErrUnsupported)
+}
+
+// FetchSuggestedReviewers returns the repository's effective default
+// reviewers, excluding the pull request's author.
+func (c *Client) FetchSuggestedReviewers(ctx context.Context, repoFullName string, prNumber int) ([]model.SuggestedReviewer, error) {
+	base, err := repoPath(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+	pr, err := c.fetchPull(ctx, repoFullName, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := getAll[struct {
+		User bbUser `json:"user"`
+	}](ctx, c, base+"/effective-default-reviewers?pagelen=100")
+	if err != nil {
+		return nil, fmt.Errorf("listing default reviewers for %s: %w", repoFullName, err)
+	}
+
+	suggested := []model.SuggestedReviewer{}
+	for _, d := range defaults {
+		if d.User.AccountID == pr.Author.AccountID {
+			continue
+		}
+		suggested = append(suggested, model.SuggestedReviewer{Login: d.User.login()})
+	}
+	return suggested, nil
+}
+
+// RequestReviewers is not supported: Bitbucket adds reviewers by account ID
+// through a full pull request update, which mygitpanel does not do.
+func (c *Client) RequestReviewers(context.Context, string, int, []string) error {
+	return fmt.Errorf("requesting reviewers: %w", driven.ErrUnsupported)
+}
+
+// AddLabels is not supported: Bitbucket pull requests have no labels.
+func (c *Client) AddLabels(context.Context, string, int, []string) error {
+	return fmt.Errorf("adding labels: %w", driven.ErrUnsupported)
+}
+
+// RemoveLabel is not supported: Bitbucket pull requests have no labels.
+func (c *Client) RemoveLabel(context.Context, string, int, string) error {
+	return fmt.Errorf("removing label: %w", driven.ErrUnsupported)
+}
+
+// SetMilestone is not supported: Bitbucket pull requests have no milestones.
+func (c *Client) SetMilestone(context.Context, string, int, int) error {
+	return fmt.Errorf("setting milestone: %w", driven.ErrUnsupported)
+}
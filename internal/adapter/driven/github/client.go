@@ -3,48 +3,168 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	gh "github.com/google/go-github/v82/github"
 	"github.com/gregjones/httpcache"
 
 	"github.com/gofri/go-github-ratelimit/v2/github_ratelimit"
+	"github.com/gofri/go-github-ratelimit/v2/github_ratelimit/github_secondary_ratelimit"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
-// Compile-time interface satisfaction check.
-var _ driven.GitHubClient = (*Client)(nil)
+// Compile-time interface satisfaction checks.
+var (
+	_ driven.GitHubClient         = (*Client)(nil)
+	_ driven.TeamRepoLister       = (*Client)(nil)
+	_ driven.RepoMetadataFetcher  = (*Client)(nil)
+	_ driven.LabelLister          = (*Client)(nil)
+	_ driven.MilestoneLister      = (*Client)(nil)
+	_ driven.ReviewRequestFetcher = (*Client)(nil)
+	_ driven.PRWindowFetcher      = (*Client)(nil)
+	_ driven.PRFetcher            = (*Client)(nil)
+	_ driven.GitHubStatusReporter = (*Client)(nil)
+	_ driven.SCMProvider          = (*Client)(nil)
+
+	_ driven.SecondaryRateLimitNotifier = (*Client)(nil)
+)
 
 // Client implements the driven.GitHubClient port using the go-github library.
 type Client struct {
 	gh         *gh.Client
 	username   string
 	token      string // Stored for GraphQL Authorization header.
-	graphqlURL string // "https://api.github.com/graphql" in production; derived from baseURL in tests.
+	graphqlURL string // "https://api.github.com/graphql" for github.com; derived from the REST base URL otherwise.
+
+	// cache is the conditional request cache; in memory unless
+	// WithResponseCache persists it. nil for NewClientWithHTTPClient.
+	cache *httpcache.Transport
+
+	// statusMu guards status, which is written after each API call and read by
+	// health probes from other goroutines, and onSecondaryLimit.
+	statusMu         sync.RWMutex
+	status           model.GitHubAPIStatus
+	onSecondaryLimit func(until time.Time, waiting bool)
 }
 
+// maxSecondaryLimitWait is the longest the client sleeps out a secondary rate
+// limit before retrying. Longer limits fail the request instead, so a poll
+// cycle is abandoned rather than stalled past the next scheduler tick.
+const maxSecondaryLimitWait = time.Minute
+
 // NewClient creates a new GitHub API client with the following transport stack:
-//  1. httpcache (ETag-based conditional request caching)
-//  2. go-github-ratelimit (secondary rate limit middleware, sleeps on 429)
+//  1. httpcache (ETag-based conditional request caching; in memory unless WithResponseCache)
+//  2. go-github-ratelimit (secondary rate limit middleware, sleeps on 429 for
+//     up to maxSecondaryLimitWait and reports it; see NotifySecondaryRateLimit)
 //  3. go-github (GitHub REST API client with PAT auth)
 func NewClient(token, username string) *Client {
-	cacheTransport := httpcache.NewMemoryCacheTransport()
-	rateLimitClient := github_ratelimit.NewClient(cacheTransport)
-	client := gh.NewClient(rateLimitClient).WithAuthToken(token)
-
-	return &Client{
-		gh:         client,
+	c := &Client{
 		username:   username,
 		token:      token,
 		graphqlURL: "https://api.github.com/graphql",
+		cache:      httpcache.NewMemoryCacheTransport(),
+	}
+	rateLimitClient := github_ratelimit.NewClient(c.cache,
+		github_secondary_ratelimit.WithLimitDetectedCallback(c.secondaryLimitHit(true)),
+		github_secondary_ratelimit.WithSingleSleepLimit(maxSecondaryLimitWait, c.secondaryLimitHit(false)),
+	)
+	c.gh = gh.NewClient(rateLimitClient).WithAuthToken(token)
+	return c
+}
+
+// NotifySecondaryRateLimit registers notify to be called whenever GitHub
+// answers with a secondary rate limit, replacing any earlier callback.
+func (c *Client) NotifySecondaryRateLimit(notify func(until time.Time, waiting bool)) {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	c.onSecondaryLimit = notify
+}
+
+// secondaryLimitHit returns the rate limiter callback for a secondary rate
+// limit the client either sleeps out (waiting) or gives up on. The limiter
+// holds its lock while calling it.
+func (c *Client) secondaryLimitHit(waiting bool) func(*github_secondary_ratelimit.CallbackContext) {
+	return func(cb *github_secondary_ratelimit.CallbackContext) {
+		if cb.ResetTime == nil {
+			return
+		}
+		until := *cb.ResetTime
+		if waiting {
+			slog.Warn("github secondary rate limit; waiting", "until", until.Format(time.RFC3339))
+		} else {
+			slog.Warn("github secondary rate limit too long to wait; request failed", "until", until.Format(time.RFC3339))
+		}
+
+		c.statusMu.RLock()
+		notify := c.onSecondaryLimit
+		c.statusMu.RUnlock()
+		if notify != nil {
+			notify(until, waiting)
+		}
+	}
+}
+
+// WithResponseCache keeps the client's cached responses in store instead of
+// memory, so conditional requests keep working after a restart. It must be
+// called before the client is used.
+func (c *Client) WithResponseCache(store driven.HTTPCacheStore) *Client {
+	if c.cache != nil {
+		c.cache.Cache = newPersistentCache(store, c.token)
+	}
+	return c
+}
+
+// NewClientWithEndpoints creates a Client like NewClient, but pointed at a
+// GitHub Enterprise Server instance when baseURL is non-empty. baseURL may be
+// the instance root (https://ghe.example.com) or its REST API root
+// (https://ghe.example.com/api/v3/). An empty graphqlURL is derived from the
+// REST root: /api/v3/ becomes /api/graphql.
+func NewClientWithEndpoints(token, username, baseURL, graphqlURL string) (*Client, error) {
+	c := NewClient(token, username)
+	if baseURL == "" {
+		if graphqlURL != "" {
+			return nil, errors.New("a GraphQL URL requires a REST base URL")
+		}
+		return c, nil
+	}
+
+	enterprise, err := c.gh.WithEnterpriseURLs(baseURL, baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing base URL: %w", err)
+	}
+	c.gh = enterprise
+
+	if graphqlURL == "" {
+		graphqlURL = defaultGraphQLURL(enterprise.BaseURL)
+	} else if _, err := url.Parse(graphqlURL); err != nil {
+		return nil, fmt.Errorf("parsing GraphQL URL: %w", err)
 	}
+	c.graphqlURL = graphqlURL
+
+	return c, nil
+}
+
+// defaultGraphQLURL derives the GraphQL endpoint that sits beside a REST API
+// root: https://ghe.example.com/api/v3/ maps to https://ghe.example.com/api/graphql
+// and https://api.example.com/ maps to https://api.example.com/graphql.
+func defaultGraphQLURL(restBase *url.URL) string {
+	u := *restBase
+	if prefix, ok := strings.CutSuffix(u.Path, "/v3/"); ok {
+		u.Path = prefix + "/graphql"
+	} else {
+		u.Path = strings.TrimSuffix(u.Path, "/") + "/graphql"
+	}
+	return u.String()
 }
 
 // NewClientWithHTTPClient creates a Client with a custom http.Client and base URL.
@@ -70,10 +190,101 @@ func NewClientWithHTTPClient(httpClient *http.Client, baseURL, username, token s
 	}, nil
 }
 
+// TokenInfo describes the identity and OAuth scopes of the client's token.
+type TokenInfo struct {
+	Login  string
+	Scopes []string
+	// ScopesKnown is false for fine-grained tokens and GitHub Apps, which do
+	// not report scopes in the X-OAuth-Scopes header.
+	ScopesKnown bool
+}
+
+// FetchTokenInfo returns the authenticated user and the OAuth scopes granted
+// to the client's token.
+func (c *Client) FetchTokenInfo(ctx context.Context) (TokenInfo, error) {
+	user, resp, err := c.gh.Users.Get(ctx, "")
+	if err != nil {
+		c.recordStatus(resp)
+		return TokenInfo{}, fmt.Errorf("fetching authenticated user: %w", err)
+	}
+
+	c.logRateLimit(resp, "user", 0, 1)
+
+	info := TokenInfo{Login: user.GetLogin()}
+	if values, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		info.ScopesKnown = true
+		info.Scopes = []string{}
+		for _, value := range values {
+			for _, scope := range strings.Split(value, ",") {
+				if scope = strings.TrimSpace(scope); scope != "" {
+					info.Scopes = append(info.Scopes, scope)
+				}
+			}
+		}
+	}
+
+	return info, nil
+}
+
 // FetchPullRequests retrieves pull requests for the given repository filtered by state.
 // Valid state values are "open", "closed", or "all" (as accepted by the GitHub API).
 // It handles pagination automatically and maps go-github types to domain model types.
 func (c *Client) FetchPullRequests(ctx context.Context, repoFullName string, state string) ([]model.PullRequest, error) {
+	return c.listPullRequests(ctx, repoFullName, state, time.Time{})
+}
+
+// FetchPullRequestsSince retrieves every open pull request and the closed
+// and merged ones updated at or after since. Closed PRs are listed newest
+// first, so paging stops at the first one older than since instead of
+// walking the repository's whole history.
+func (c *Client) FetchPullRequestsSince(ctx context.Context, repoFullName string, since time.Time) ([]model.PullRequest, error) {
+	open, err := c.listPullRequests(ctx, repoFullName, "open", time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	closed, err := c.listPullRequests(ctx, repoFullName, "closed", since)
+	if err != nil {
+		return nil, err
+	}
+
+	// A PR closed between the two listings appears in both; the closed
+	// listing is the later one.
+	closedNumbers := make(map[int]bool, len(closed))
+	for _, pr := range closed {
+		closedNumbers[pr.Number] = true
+	}
+	prs := closed
+	for _, pr := range open {
+		if !closedNumbers[pr.Number] {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil
+}
+
+// FetchPullRequest retrieves a single pull request by number.
+func (c *Client) FetchPullRequest(ctx context.Context, repoFullName string, prNumber int) (*model.PullRequest, error) {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, resp, err := c.gh.PullRequests.Get(ctx, owner, repo, prNumber)
+	if err != nil {
+		c.recordStatus(resp)
+		return nil, fmt.Errorf("fetching pull request %s#%d: %w", repoFullName, prNumber, err)
+	}
+
+	c.logRateLimit(resp, repoFullName+"/pr", 0, 1)
+
+	mapped := mapPullRequest(pr, repoFullName)
+	return &mapped, nil
+}
+
+// listPullRequests pages through the repository's pull requests in state,
+// most recently updated first, stopping at the first one updated before
+// since unless since is zero.
+func (c *Client) listPullRequests(ctx context.Context, repoFullName, state string, since time.Time) ([]model.PullRequest, error) {
 	owner, repo, err := splitRepo(repoFullName)
 	if err != nil {
 		return nil, err
@@ -88,17 +299,30 @@ func (c *Client) FetchPullRequests(ctx context.Context, repoFullName string, sta
 		},
 	}
 
-	var allPRs []model.PullRequest
+	allPRs := []model.PullRequest{}
 
 	for {
 		prs, resp, err := c.gh.PullRequests.List(ctx, owner, repo, opts)
 		if err != nil {
-			return nil, fmt.Errorf("listing pull requests for %s (page %d): %w", repoFullName, opts.Page, err)
+			c.recordStatus(resp)
+			return nil, fmt.Errorf("listing pull requests for %s (page %d): %w", repoFullName, opts.Page, classifyRepoError(err))
 		}
 
-		logRateLimit(resp, repoFullName, opts.Page, len(prs))
+		c.logRateLimit(resp, repoFullName, opts.Page, len(prs))
+
+		// GitHub answers requests for a renamed or transferred repo with a 301
+		// that the HTTP client follows transparently. Surface the move instead
+		// of silently storing PRs under the stale name.
+		if opts.Page == 0 && wasRedirected(resp) {
+			if moved := c.detectMove(ctx, owner, repo, repoFullName); moved != nil {
+				return nil, moved
+			}
+		}
 
 		for _, pr := range prs {
+			if !since.IsZero() && pr.GetUpdatedAt().Before(since) {
+				return allPRs, nil
+			}
 			allPRs = append(allPRs, mapPullRequest(pr, repoFullName))
 		}
 
@@ -108,10 +332,6 @@ func (c *Client) FetchPullRequests(ctx context.Context, repoFullName string, sta
 		opts.Page = resp.NextPage
 	}
 
-	if allPRs == nil {
-		allPRs = []model.PullRequest{}
-	}
-
 	return allPRs, nil
 }
 
@@ -232,23 +452,26 @@ func mapReviewComment(c *gh.PullRequestComment) model.ReviewComment {
 	}
 
 	return model.ReviewComment{
-		ID:          c.GetID(),
-		ReviewID:    c.GetPullRequestReviewID(),
-		PRID:        0, // Caller assigns before storing.
-		Author:      c.GetUser().GetLogin(),
-		Body:        c.GetBody(),
-		Path:        c.GetPath(),
-		Line:        c.GetLine(),
-		StartLine:   c.GetStartLine(),
-		Side:        c.GetSide(),
-		SubjectType: c.GetSubjectType(),
-		DiffHunk:    c.GetDiffHunk(),
-		CommitID:    c.GetCommitID(),
-		IsResolved:  false, // Set later from GraphQL data.
-		IsOutdated:  false, // Set later by enrichment service.
-		InReplyToID: inReplyTo,
-		CreatedAt:   c.GetCreatedAt().Time,
-		UpdatedAt:   c.GetUpdatedAt().Time,
+		ID:           c.GetID(),
+		ReviewID:     c.GetPullRequestReviewID(),
+		PRID:         0, // Caller assigns before storing.
+		Author:       c.GetUser().GetLogin(),
+		Body:         c.GetBody(),
+		Path:         c.GetPath(),
+		Line:         c.GetLine(),
+		StartLine:    c.GetStartLine(),
+		Side:         c.GetSide(),
+		SubjectType:  c.GetSubjectType(),
+		DiffHunk:     c.GetDiffHunk(),
+		CommitID:     c.GetCommitID(),
+		Position:     c.Position,
+		OriginalLine: c.GetOriginalLine(),
+		IsResolved:   false, // Set later from GraphQL data.
+		IsOutdated:   false, // Set by the poll loop against the PR head.
+		InReplyToID:  inReplyTo,
+		HTMLURL:      c.GetHTMLURL(),
+		CreatedAt:    c.GetCreatedAt().Time,
+		UpdatedAt:    c.GetUpdatedAt().Time,
 	}
 }
 
@@ -260,17 +483,49 @@ func mapIssueComment(c *gh.IssueComment) model.IssueComment {
 		Author:    c.GetUser().GetLogin(),
 		Body:      c.GetBody(),
 		IsBot:     false, // Enrichment service handles bot detection.
+		HTMLURL:   c.GetHTMLURL(),
 		CreatedAt: c.GetCreatedAt().Time,
 		UpdatedAt: c.GetUpdatedAt().Time,
 	}
 }
 
-// logRateLimit logs the GitHub API rate limit status after each call.
-func logRateLimit(resp *gh.Response, endpoint string, page, count int) {
+// APIStatus returns the rate-limit and credential state observed on the most
+// recent API response.
+func (c *Client) APIStatus() model.GitHubAPIStatus {
+	c.statusMu.RLock()
+	defer c.statusMu.RUnlock()
+	return c.status
+}
+
+// recordStatus captures rate-limit headers and authorization state from resp.
+// A nil resp (transport failure) leaves the previous snapshot untouched.
+func (c *Client) recordStatus(resp *gh.Response) {
+	if resp == nil || resp.Response == nil {
+		return
+	}
+
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+
+	c.status.Unauthorized = resp.StatusCode == http.StatusUnauthorized
+	c.status.ObservedAt = time.Now()
+	// Rate headers are absent on some error responses; keep the last known values.
+	if resp.Rate.Limit > 0 {
+		c.status.RateLimit = resp.Rate.Limit
+		c.status.RateRemaining = resp.Rate.Remaining
+		c.status.RateReset = resp.Rate.Reset.Time
+	}
+}
+
+// logRateLimit logs the GitHub API rate limit status after each call and
+// records it for APIStatus.
+func (c *Client) logRateLimit(resp *gh.Response, endpoint string, page, count int) {
 	if resp == nil {
 		return
 	}
 
+	c.recordStatus(resp)
+
 	slog.Debug("github api call",
 		"endpoint", endpoint,
 		"page", page,
@@ -312,6 +567,11 @@ func mapPullRequest(pr *gh.PullRequest, repoFullName string) model.PullRequest {
 		teamSlugs = append(teamSlugs, t.GetSlug())
 	}
 
+	assignees := make([]string, 0, len(pr.Assignees))
+	for _, a := range pr.Assignees {
+		assignees = append(assignees, a.GetLogin())
+	}
+
 	return model.PullRequest{
 		Number:             pr.GetNumber(),
 		RepoFullName:       repoFullName,
@@ -327,8 +587,13 @@ func mapPullRequest(pr *gh.PullRequest, repoFullName string) model.PullRequest {
 		OpenedAt:           pr.GetCreatedAt().Time,
 		UpdatedAt:          pr.GetUpdatedAt().Time,
 		LastActivityAt:     pr.GetUpdatedAt().Time,
+		ClosedAt:           pr.GetClosedAt().Time,
+		AuthorAssociation:  model.AuthorAssociation(pr.GetAuthorAssociation()),
+		Milestone:          pr.GetMilestone().GetTitle(),
 		RequestedReviewers: reviewers,
 		RequestedTeamSlugs: teamSlugs,
+		Body:               pr.GetBody(),
+		Assignees:          assignees,
 	}
 }
 
@@ -352,7 +617,7 @@ func (c *Client) FetchCheckRuns(ctx context.Context, repoFullName string, ref st
 			return nil, fmt.Errorf("listing check runs for %s@%s (page %d): %w", repoFullName, ref, opts.Page, err)
 		}
 
-		logRateLimit(resp, repoFullName+"/check-runs", opts.Page, len(result.CheckRuns))
+		c.logRateLimit(resp, repoFullName+"/check-runs", opts.Page, len(result.CheckRuns))
 
 		for _, cr := range result.CheckRuns {
 			allRuns = append(allRuns, mapCheckRun(cr))
@@ -380,7 +645,7 @@ func (c *Client) FetchCombinedStatus(ctx context.Context, repoFullName string, r
 		return nil, fmt.Errorf("fetching combined status for %s@%s: %w", repoFullName, ref, err)
 	}
 
-	logRateLimit(resp, repoFullName+"/status", 0, len(cs.Statuses))
+	c.logRateLimit(resp, repoFullName+"/status", 0, len(cs.Statuses))
 
 	return mapCombinedStatus(cs), nil
 }
@@ -397,7 +662,7 @@ func (c *Client) FetchPRDetail(ctx context.Context, repoFullName string, prNumbe
 		return nil, fmt.Errorf("fetching PR detail for %s#%d: %w", repoFullName, prNumber, err)
 	}
 
-	logRateLimit(resp, repoFullName+"/pr-detail", 0, 1)
+	c.logRateLimit(resp, repoFullName+"/pr-detail", 0, 1)
 
 	return &model.PRDetail{
 		Additions:    pr.GetAdditions(),
@@ -424,7 +689,7 @@ func (c *Client) FetchRequiredStatusChecks(ctx context.Context, repoFullName str
 		return nil, fmt.Errorf("fetching required status checks for %s branch %s: %w", repoFullName, branch, err)
 	}
 
-	logRateLimit(resp, repoFullName+"/required-checks", 0, 0)
+	c.logRateLimit(resp, repoFullName+"/required-checks", 0, 0)
 
 	requiredContexts := checks.GetChecks()
 	if requiredContexts == nil {
@@ -439,6 +704,34 @@ func (c *Client) FetchRequiredStatusChecks(ctx context.Context, repoFullName str
 	return contexts, nil
 }
 
+// FetchBranchProtection returns the branch's required pull request review
+// settings. Returns a protection without RequiresReviews, and a nil error, if
+// the branch has no review requirement (404) or if we lack permissions (403).
+func (c *Client) FetchBranchProtection(ctx context.Context, repoFullName string, branch string) (model.BranchProtection, error) {
+	protection := model.BranchProtection{RepoFullName: repoFullName, Branch: branch}
+
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return protection, err
+	}
+
+	enforcement, resp, err := c.gh.Repositories.GetPullRequestReviewEnforcement(ctx, owner, repo, branch)
+	if err != nil {
+		if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden) {
+			return protection, nil
+		}
+		return protection, fmt.Errorf("fetching review enforcement for %s branch %s: %w", repoFullName, branch, err)
+	}
+
+	c.logRateLimit(resp, repoFullName+"/review-enforcement", 0, 0)
+
+	protection.RequiresReviews = true
+	protection.RequiredApprovals = enforcement.RequiredApprovingReviewCount
+	protection.RequireCodeOwnerReviews = enforcement.RequireCodeOwnerReviews
+	protection.DismissStaleReviews = enforcement.DismissStaleReviews
+	return protection, nil
+}
+
 // mapCheckRun converts a go-github CheckRun to a domain model CheckRun.
 func mapCheckRun(cr *gh.CheckRun) model.CheckRun {
 	var startedAt, completedAt time.Time
@@ -497,6 +790,214 @@ func mapMergeable(mergeable *bool) model.MergeableStatus {
 	return model.MergeableConflicted
 }
 
+// FetchRepoMetadata returns the repository's description, default branch,
+// visibility, and archived flag.
+func (c *Client) FetchRepoMetadata(ctx context.Context, repoFullName string) (model.RepoMetadata, error) {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return model.RepoMetadata{}, err
+	}
+
+	r, resp, err := c.gh.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		c.recordStatus(resp)
+		return model.RepoMetadata{}, fmt.Errorf("getting repository %s: %w", repoFullName, classifyRepoError(err))
+	}
+
+	return model.RepoMetadata{
+		Description:   r.GetDescription(),
+		DefaultBranch: r.GetDefaultBranch(),
+		Private:       r.GetPrivate(),
+		Archived:      r.GetArchived(),
+	}, nil
+}
+
+// ListTeamRepos returns the full names of the repositories the organization
+// team identified by teamSlug has access to.
+func (c *Client) ListTeamRepos(ctx context.Context, org, teamSlug string) ([]string, error) {
+	opts := &gh.ListOptions{PerPage: 100}
+	var names []string
+
+	for {
+		repos, resp, err := c.gh.Teams.ListTeamReposBySlug(ctx, org, teamSlug, opts)
+		if err != nil {
+			c.recordStatus(resp)
+			return nil, fmt.Errorf("listing repositories of team %s/%s (page %d): %w", org, teamSlug, opts.Page, err)
+		}
+
+		for _, r := range repos {
+			names = append(names, r.GetFullName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return names, nil
+}
+
+// FetchRepoLabels returns the names of the labels defined in the repository.
+func (c *Client) FetchRepoLabels(ctx context.Context, repoFullName string) ([]string, error) {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &gh.ListOptions{PerPage: 100}
+	var names []string
+
+	for {
+		labels, resp, err := c.gh.Issues.ListLabels(ctx, owner, repo, opts)
+		if err != nil {
+			c.recordStatus(resp)
+			return nil, fmt.Errorf("listing labels of %s (page %d): %w", repoFullName, opts.Page, err)
+		}
+
+		for _, l := range labels {
+			names = append(names, l.GetName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return names, nil
+}
+
+// FetchMilestones returns the repository's open milestones, soonest due first.
+func (c *Client) FetchMilestones(ctx context.Context, repoFullName string) ([]model.Milestone, error) {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &gh.MilestoneListOptions{
+		State:       "open",
+		Sort:        "due_on",
+		Direction:   "asc",
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	var milestones []model.Milestone
+
+	for {
+		page, resp, err := c.gh.Issues.ListMilestones(ctx, owner, repo, opts)
+		if err != nil {
+			c.recordStatus(resp)
+			return nil, fmt.Errorf("listing milestones of %s (page %d): %w", repoFullName, opts.Page, err)
+		}
+
+		for _, m := range page {
+			milestones = append(milestones, model.Milestone{Number: m.GetNumber(), Title: m.GetTitle()})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return milestones, nil
+}
+
+// FetchReviewRequests replays the PR's timeline and returns the review
+// requests still pending at its end, each with the time of its latest
+// request, oldest first.
+func (c *Client) FetchReviewRequests(ctx context.Context, repoFullName string, prNumber int) ([]model.ReviewRequest, error) {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &gh.ListOptions{PerPage: 100}
+	pending := make(map[model.ReviewRequest]time.Time)
+
+	for {
+		events, resp, err := c.gh.Issues.ListIssueTimeline(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			c.recordStatus(resp)
+			return nil, fmt.Errorf("listing timeline of %s#%d (page %d): %w", repoFullName, prNumber, opts.Page, err)
+		}
+
+		for _, e := range events {
+			key := model.ReviewRequest{Reviewer: e.GetReviewer().GetLogin()}
+			if e.RequestedTeam != nil {
+				key = model.ReviewRequest{Reviewer: e.GetRequestedTeam().GetSlug(), IsTeam: true}
+			}
+			switch e.GetEvent() {
+			case "review_requested":
+				pending[key] = e.GetCreatedAt().Time
+			case "review_request_removed":
+				delete(pending, key)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	requests := make([]model.ReviewRequest, 0, len(pending))
+	for req, at := range pending {
+		if req.Reviewer == "" {
+			continue
+		}
+		req.RequestedAt = at
+		requests = append(requests, req)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if !requests[i].RequestedAt.Equal(requests[j].RequestedAt) {
+			return requests[i].RequestedAt.Before(requests[j].RequestedAt)
+		}
+		return requests[i].Reviewer < requests[j].Reviewer
+	})
+	return requests, nil
+}
+
+// classifyRepoError wraps 404 and 403 responses with driven.ErrRepoUnreachable.
+// Rate-limit 403s are returned unchanged: they say nothing about the repository.
+func classifyRepoError(err error) error {
+	var rateErr *gh.RateLimitError
+	var abuseErr *gh.AbuseRateLimitError
+	if errors.As(err, &rateErr) || errors.As(err, &abuseErr) {
+		return err
+	}
+
+	var respErr *gh.ErrorResponse
+	if errors.As(err, &respErr) && respErr.Response != nil {
+		switch respErr.Response.StatusCode {
+		case http.StatusNotFound, http.StatusForbidden:
+			return fmt.Errorf("%w (HTTP %d): %w", driven.ErrRepoUnreachable, respErr.Response.StatusCode, err)
+		}
+	}
+	return err
+}
+
+// wasRedirected reports whether the HTTP client followed a redirect to produce resp.
+func wasRedirected(resp *gh.Response) bool {
+	return resp != nil && resp.Response != nil && resp.Request != nil && resp.Request.Response != nil
+}
+
+// detectMove resolves the current full name of a repository that redirected.
+// It returns nil when the name is unchanged or cannot be resolved.
+func (c *Client) detectMove(ctx context.Context, owner, repo, repoFullName string) *driven.RepoMovedError {
+	r, resp, err := c.gh.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		c.recordStatus(resp)
+		slog.Warn("could not resolve redirected repository", "repo", repoFullName, "error", err)
+		return nil
+	}
+
+	if newName := r.GetFullName(); newName != "" && !strings.EqualFold(newName, repoFullName) {
+		return &driven.RepoMovedError{From: repoFullName, To: newName}
+	}
+	return nil
+}
+
 // splitRepo splits a "owner/repo" string into its two components.
 func splitRepo(fullName string) (string, string, error) {
 	parts := strings.SplitN(fullName, "/", 2)
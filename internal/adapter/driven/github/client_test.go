@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
+
+	gh "github.com/google/go-github/v82/github"
 
 	ghAdapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -173,6 +178,42 @@ func TestFetchPullRequests_Pagination(t *testing.T) {
 	assert.Equal(t, "PR Two", result[1].Title)
 }
 
+func TestFetchPullRequestsSince(t *testing.T) {
+	pr := func(number int, state, updated string) prJSON {
+		return prJSON{Number: number, State: state, User: userJSON{Login: "dev"}, Labels: []lblJSON{}, Created: updated, Updated: updated}
+	}
+	var closedPages []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		if q.Get("state") == "open" {
+			json.NewEncoder(w).Encode([]prJSON{pr(5, "open", "2025-01-01T00:00:00Z"), pr(4, "open", "2026-03-01T00:00:00Z")})
+			return
+		}
+		closedPages = append(closedPages, q.Get("page"))
+		if q.Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?state=closed&page=2>; rel="next"`, "http://"+r.Host+r.URL.Path))
+			// #4 closed after the open listing.
+			json.NewEncoder(w).Encode([]prJSON{pr(4, "closed", "2026-03-02T00:00:00Z"), pr(3, "closed", "2026-02-01T00:00:00Z")})
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s?state=closed&page=3>; rel="next"`, "http://"+r.Host+r.URL.Path))
+		json.NewEncoder(w).Encode([]prJSON{pr(2, "closed", "2026-01-15T00:00:00Z"), pr(1, "closed", "2025-06-01T00:00:00Z")})
+	})
+
+	client, _ := newTestClient(t, handler)
+	result, err := client.FetchPullRequestsSince(context.Background(), "owner/repo", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	numbers := make([]int, len(result))
+	for i, p := range result {
+		numbers[i] = p.Number
+	}
+	assert.Equal(t, []int{4, 3, 2, 5}, numbers, "old open PRs are kept, old closed ones are not")
+	assert.Equal(t, model.PRStatusClosed, result[0].Status)
+	assert.Equal(t, []string{"", "2"}, closedPages, "paging stops at the first closed PR outside the window")
+}
+
 func TestFetchPullRequests_DraftDetection(t *testing.T) {
 	prs := []prJSON{
 		{
@@ -343,6 +384,9 @@ func TestFetchReviewComments(t *testing.T) {
 			"subject_type":           "line",
 			"diff_hunk":              "@@ -38,7 +38,7 @@\n context line\n-old line\n+new line",
 			"commit_id":              "abc123",
+			"position":               5,
+			"original_line":          41,
+			"html_url":               "https://github.com/owner/repo/pull/42#discussion_r2001",
 			"created_at":             "2026-01-10T10:00:00Z",
 			"updated_at":             "2026-01-10T10:00:00Z",
 			"user":                   map[string]any{"login": "alice"},
@@ -387,6 +431,10 @@ func TestFetchReviewComments(t *testing.T) {
 	assert.Equal(t, "line", result[0].SubjectType)
 	assert.Contains(t, result[0].DiffHunk, "@@ -38,7 +38,7 @@")
 	assert.Equal(t, "abc123", result[0].CommitID)
+	require.NotNil(t, result[0].Position)
+	assert.Equal(t, 5, *result[0].Position)
+	assert.Equal(t, 41, result[0].OriginalLine)
+	assert.Equal(t, "https://github.com/owner/repo/pull/42#discussion_r2001", result[0].HTMLURL)
 	assert.Nil(t, result[0].InReplyToID, "root comment should have nil InReplyToID")
 	assert.False(t, result[0].IsResolved)
 	assert.False(t, result[0].IsOutdated)
@@ -403,6 +451,7 @@ func TestFetchIssueComments(t *testing.T) {
 		{
 			"id":         int64(3001),
 			"body":       "Great work on this PR!",
+			"html_url":   "https://github.com/owner/repo/pull/42#issuecomment-3001",
 			"created_at": "2026-01-10T10:00:00Z",
 			"updated_at": "2026-01-10T10:00:00Z",
 			"user":       map[string]any{"login": "charlie"},
@@ -424,6 +473,7 @@ func TestFetchIssueComments(t *testing.T) {
 	assert.Equal(t, int64(0), result[0].PRID, "PRID should be 0 (caller assigns)")
 	assert.Equal(t, "charlie", result[0].Author)
 	assert.Equal(t, "Great work on this PR!", result[0].Body)
+	assert.Equal(t, "https://github.com/owner/repo/pull/42#issuecomment-3001", result[0].HTMLURL)
 	assert.False(t, result[0].IsBot)
 	assert.False(t, result[0].CreatedAt.IsZero())
 	assert.False(t, result[0].UpdatedAt.IsZero())
@@ -574,6 +624,34 @@ func TestFetchCombinedStatus(t *testing.T) {
 
 // --- FetchPRDetail tests ---
 
+func TestFetchPullRequest(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/pulls/42", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"number":     42,
+			"title":      "Add feature",
+			"state":      "open",
+			"user":       map[string]any{"login": "alice"},
+			"head":       map[string]any{"ref": "feature", "sha": "abc123"},
+			"base":       map[string]any{"ref": "main"},
+			"created_at": "2026-01-01T00:00:00Z",
+			"updated_at": "2026-01-02T00:00:00Z",
+		})
+	})
+
+	client, _ := newTestClient(t, handler)
+	pr, err := client.FetchPullRequest(context.Background(), "owner/repo", 42)
+
+	require.NoError(t, err)
+	require.NotNil(t, pr)
+	assert.Equal(t, 42, pr.Number)
+	assert.Equal(t, "owner/repo", pr.RepoFullName)
+	assert.Equal(t, "Add feature", pr.Title)
+	assert.Equal(t, "abc123", pr.HeadSHA)
+	assert.Equal(t, model.PRStatusOpen, pr.Status)
+}
+
 func TestFetchPRDetail(t *testing.T) {
 	mergeable := true
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -684,3 +762,362 @@ func TestFetchRequiredStatusChecks_403(t *testing.T) {
 	require.NoError(t, err, "403 should not return an error")
 	assert.Nil(t, result, "403 should return nil slice")
 }
+
+func TestFetchBranchProtection(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/repo/branches/main/protection/required_pull_request_reviews", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"dismiss_stale_reviews":           true,
+			"require_code_owner_reviews":      true,
+			"required_approving_review_count": 2,
+		})
+	})
+
+	client, _ := newTestClient(t, handler)
+	protection, err := client.FetchBranchProtection(context.Background(), "owner/repo", "main")
+
+	require.NoError(t, err)
+	assert.True(t, protection.RequiresReviews)
+	assert.True(t, protection.DismissStaleReviews)
+	assert.True(t, protection.RequireCodeOwnerReviews)
+	assert.Equal(t, 2, protection.RequiredApprovals)
+	assert.Equal(t, "owner/repo", protection.RepoFullName)
+	assert.Equal(t, "main", protection.Branch)
+}
+
+func TestFetchBranchProtection_404(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{
+			"message": "Branch not protected",
+		})
+	})
+
+	client, _ := newTestClient(t, handler)
+	protection, err := client.FetchBranchProtection(context.Background(), "owner/repo", "main")
+
+	require.NoError(t, err, "404 should not return an error")
+	assert.False(t, protection.RequiresReviews)
+	assert.False(t, protection.DismissStaleReviews)
+}
+
+func TestFetchPullRequests_Unreachable(t *testing.T) {
+	for _, code := range []int{http.StatusNotFound, http.StatusForbidden} {
+		t.Run(strconv.Itoa(code), func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(code)
+				w.Write([]byte(`{"message":"Not Found"}`))
+			})
+
+			client, _ := newTestClient(t, handler)
+			_, err := client.FetchPullRequests(context.Background(), "owner/gone", "all")
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, driven.ErrRepoUnreachable)
+		})
+	}
+}
+
+func TestFetchPullRequests_ServerErrorNotUnreachable(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	client, _ := newTestClient(t, handler)
+	_, err := client.FetchPullRequests(context.Background(), "owner/repo", "all")
+
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, driven.ErrRepoUnreachable, "5xx is transient, not a repo problem")
+}
+
+func TestFetchPullRequests_RenamedRepo(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/old-owner/old-name/pulls", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/repositories/42/pulls?"+r.URL.RawQuery, http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/repositories/42/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]prJSON{})
+	})
+	mux.HandleFunc("/repos/old-owner/old-name", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/repositories/42", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/repositories/42", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":42,"full_name":"new-owner/new-name"}`))
+	})
+
+	client, _ := newTestClient(t, mux)
+	_, err := client.FetchPullRequests(context.Background(), "old-owner/old-name", "all")
+
+	var moved *driven.RepoMovedError
+	require.ErrorAs(t, err, &moved)
+	assert.Equal(t, "old-owner/old-name", moved.From)
+	assert.Equal(t, "new-owner/new-name", moved.To)
+}
+
+func TestAPIStatus_RecordsRateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		json.NewEncoder(w).Encode([]prJSON{})
+	})
+
+	client, _ := newTestClient(t, handler)
+	assert.True(t, client.APIStatus().ObservedAt.IsZero(), "no status before the first call")
+
+	_, err := client.FetchPullRequests(context.Background(), "owner/repo", "all")
+	require.NoError(t, err)
+
+	status := client.APIStatus()
+	assert.Equal(t, 5000, status.RateLimit)
+	assert.Equal(t, 4321, status.RateRemaining)
+	assert.True(t, status.RateReset.Equal(reset))
+	assert.False(t, status.Unauthorized)
+	assert.False(t, status.ObservedAt.IsZero())
+}
+
+func TestAPIStatus_Unauthorized(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Bad credentials"}`))
+	})
+
+	client, _ := newTestClient(t, handler)
+
+	_, err := client.FetchPullRequests(context.Background(), "owner/repo", "all")
+	require.Error(t, err)
+	assert.True(t, client.APIStatus().Unauthorized)
+}
+
+func TestFetchTokenInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		scopes      *string
+		wantScopes  []string
+		wantKnownOK bool
+	}{
+		{
+			name:        "classic token",
+			scopes:      gh.Ptr("repo, read:org"),
+			wantScopes:  []string{"repo", "read:org"},
+			wantKnownOK: true,
+		},
+		{
+			name:        "classic token without scopes",
+			scopes:      gh.Ptr(""),
+			wantScopes:  []string{},
+			wantKnownOK: true,
+		},
+		{
+			name:        "fine-grained token",
+			wantKnownOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/user", r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				if tt.scopes != nil {
+					w.Header().Set("X-OAuth-Scopes", *tt.scopes)
+				}
+				w.Write([]byte(`{"login":"octocat"}`))
+			})
+
+			client, _ := newTestClient(t, handler)
+
+			info, err := client.FetchTokenInfo(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "octocat", info.Login)
+			assert.Equal(t, tt.wantKnownOK, info.ScopesKnown)
+			assert.Equal(t, tt.wantScopes, info.Scopes)
+		})
+	}
+}
+
+func TestNewClientWithEndpoints_Enterprise(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/graphql" {
+			_, _ = w.Write([]byte(`{"data":{}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	// The instance root is enough; the REST and GraphQL paths are derived.
+	client, err := ghAdapter.NewClientWithEndpoints("test-token", "testuser", server.URL, "")
+	require.NoError(t, err)
+
+	_, err = client.FetchPullRequests(context.Background(), "octocat/hello-world", "open")
+	require.NoError(t, err)
+	_, err = client.FetchThreadResolution(context.Background(), "octocat/hello-world", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/api/v3/repos/octocat/hello-world/pulls", "/api/graphql"}, paths)
+}
+
+func TestNewClientWithEndpoints_GraphQLWithoutBase(t *testing.T) {
+	_, err := ghAdapter.NewClientWithEndpoints("test-token", "testuser", "", "https://ghe.example.com/api/graphql")
+	require.Error(t, err)
+}
+
+func TestNotifySecondaryRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "600")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"message":"You have exceeded a secondary rate limit."}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ghAdapter.NewClientWithEndpoints("test-token", "testuser", server.URL, "")
+	require.NoError(t, err)
+
+	var until time.Time
+	waiting := true
+	client.NotifySecondaryRateLimit(func(u time.Time, w bool) { until, waiting = u, w })
+
+	// A limit longer than the client will sleep fails the request at once.
+	_, err = client.FetchPullRequests(context.Background(), "octocat/hello-world", "open")
+	require.Error(t, err)
+	assert.False(t, waiting)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), until, 5*time.Second)
+}
+
+func TestRequestReviewers(t *testing.T) {
+	var gotPath string
+	var gotBody struct {
+		Reviewers []string `json:"reviewers"`
+	}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.Method + " " + r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"number": 42})
+	})
+
+	client, _ := newTestClient(t, handler)
+	err := client.RequestReviewers(context.Background(), "owner/repo", 42, []string{"alice"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "POST /repos/owner/repo/pulls/42/requested_reviewers", gotPath)
+	assert.Equal(t, []string{"alice"}, gotBody.Reviewers)
+}
+
+func TestCreateIssueComment_TransientErrors(t *testing.T) {
+	tests := []struct {
+		code      int
+		transient bool
+	}{
+		{http.StatusBadGateway, true},
+		{http.StatusServiceUnavailable, true},
+		{http.StatusTooManyRequests, true},
+		{http.StatusNotFound, false},
+		{http.StatusUnprocessableEntity, false},
+	}
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.code), func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.code)
+				w.Write([]byte(`{"message":"nope"}`))
+			})
+
+			client, _ := newTestClient(t, handler)
+			err := client.CreateIssueComment(context.Background(), "owner/repo", 42, "hello")
+
+			require.Error(t, err)
+			if tt.transient {
+				assert.ErrorIs(t, err, driven.ErrTransient)
+			} else {
+				assert.NotErrorIs(t, err, driven.ErrTransient)
+			}
+		})
+	}
+}
+
+func TestCreateIssueComment_NetworkErrorIsTransient(t *testing.T) {
+	client, server := newTestClient(t, http.NotFoundHandler())
+	server.Close()
+
+	err := client.CreateIssueComment(context.Background(), "owner/repo", 42, "hello")
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, driven.ErrTransient)
+}
+
+func TestListTeamRepos(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /orgs/acme/teams/platform/repos", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"full_name": "acme/api"}, {"full_name": "acme/web"}]`))
+	})
+	client, _ := newTestClient(t, mux)
+
+	names, err := client.ListTeamRepos(context.Background(), "acme", "platform")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/api", "acme/web"}, names)
+}
+
+func TestFetchRepoMetadata(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/api", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"full_name": "acme/api", "description": "Public API", "default_branch": "trunk", "private": true, "archived": true}`))
+	})
+	client, _ := newTestClient(t, mux)
+
+	got, err := client.FetchRepoMetadata(context.Background(), "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, model.RepoMetadata{Description: "Public API", DefaultBranch: "trunk", Private: true, Archived: true}, got)
+}
+
+func TestFetchRepoLabels(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/api/labels", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name": "bug"}, {"name": "dependencies"}]`))
+	})
+	client, _ := newTestClient(t, mux)
+
+	got, err := client.FetchRepoLabels(context.Background(), "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug", "dependencies"}, got)
+}
+
+func TestFetchReviewRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/api/issues/7/timeline", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"event": "review_requested", "created_at": "2026-10-01T09:00:00Z", "requested_reviewer": {"login": "alice"}},
+			{"event": "review_requested", "created_at": "2026-10-01T09:00:00Z", "requested_reviewer": {"login": "bob"}},
+			{"event": "commented", "created_at": "2026-10-02T09:00:00Z"},
+			{"event": "review_requested", "created_at": "2026-10-03T09:00:00Z", "requested_team": {"slug": "core"}},
+			{"event": "review_request_removed", "created_at": "2026-10-04T09:00:00Z", "requested_reviewer": {"login": "bob"}},
+			{"event": "review_requested", "created_at": "2026-10-05T09:00:00Z", "requested_reviewer": {"login": "alice"}}
+		]`))
+	})
+	client, _ := newTestClient(t, mux)
+
+	got, err := client.FetchReviewRequests(context.Background(), "acme/api", 7)
+	require.NoError(t, err)
+	assert.Equal(t, []model.ReviewRequest{
+		{Reviewer: "core", IsTeam: true, RequestedAt: time.Date(2026, 10, 3, 9, 0, 0, 0, time.UTC)},
+		{Reviewer: "alice", RequestedAt: time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC)},
+	}, got, "removed requests drop out and re-requests keep the latest time")
+}
@@ -0,0 +1,105 @@
+package githubfake_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sqliteadapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/sqlite"
+	httphandler "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/http"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// TestPipeline polls the fake into a memory database and reads the result
+// back through the REST API, the way the app serves it.
+func TestPipeline(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake, client := newFake(t)
+	db, err := sqliteadapter.NewMemoryDB(ctx, t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, sqliteadapter.RunMigrations(db.Writer))
+
+	prStore := sqliteadapter.NewPRRepo(db)
+	repoStore := sqliteadapter.NewRepoRepo(db)
+	reviewStore := sqliteadapter.NewReviewRepo(db)
+	checkStore := sqliteadapter.NewCheckRepo(db)
+	scenario := fake.Scenario()
+	repos := make([]model.Repository, 0, len(scenario.Repos))
+	for _, name := range scenario.RepoNames() {
+		repos = append(repos, model.Repository{FullName: name, Owner: "acme", Name: name[len("acme/"):]})
+	}
+	_, err = repoStore.AddMany(ctx, repos)
+	require.NoError(t, err)
+
+	pollSvc := application.NewPollService(client, prStore, repoStore, reviewStore, checkStore, scenario.User, nil, time.Hour, nil, nil)
+	done := make(chan struct{})
+	go func() {
+		pollSvc.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	for _, name := range scenario.RepoNames() {
+		require.NoError(t, pollSvc.RefreshRepo(ctx, name))
+	}
+
+	api := httphandler.NewServeMux(httphandler.NewHandler(
+		prStore, repoStore, sqliteadapter.NewBotConfigRepo(db),
+		application.NewReviewService(reviewStore, sqliteadapter.NewBotConfigRepo(db)),
+		application.NewHealthService(checkStore, prStore),
+		pollSvc, scenario.User, slog.Default(),
+	), slog.Default())
+	get := func(path string, v any) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+	}
+
+	var prs []httphandler.PRResponse
+	get("/api/v1/prs", &prs)
+	statuses := make(map[string]string)
+	for _, pr := range prs {
+		statuses[fmt.Sprintf("%s#%d", pr.Repository, pr.Number)] = pr.Status
+	}
+	assert.Equal(t, map[string]string{
+		"acme/api#7": "open",
+		"acme/api#8": "open",
+		"acme/api#5": "merged",
+		"acme/web#3": "open",
+	}, statuses)
+
+	var pr httphandler.PRResponse
+	get("/api/v1/repos/acme/api/prs/7", &pr)
+	assert.True(t, pr.NeedsReview, "octocat is a requested reviewer")
+	assert.Equal(t, "changes_requested", pr.ReviewStatus)
+	require.Len(t, pr.Threads, 1)
+	assert.Equal(t, 2, pr.Threads[0].CommentCount)
+	assert.Equal(t, 1, pr.UnresolvedThreads)
+	assert.Equal(t, 120, pr.Additions)
+	assert.Equal(t, "mergeable", pr.MergeableStatus)
+	require.Len(t, pr.CheckRuns, 2)
+	assert.Equal(t, "failing", pr.CIStatus)
+
+	// A comment posted through the writer shows up on the next poll.
+	require.NoError(t, client.CreateIssueComment(ctx, "acme/api", 7, "Rebased"))
+	require.NoError(t, pollSvc.RefreshRepo(ctx, "acme/api"))
+	get("/api/v1/repos/acme/api/prs/7", &pr)
+	require.NotEmpty(t, pr.IssueComments)
+	assert.Equal(t, "Rebased", pr.IssueComments[len(pr.IssueComments)-1].Body)
+}
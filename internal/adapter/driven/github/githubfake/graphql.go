@@ -0,0 +1,202 @@
+package githubfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// graphqlRequest is the JSON body of a GraphQL request.
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// graphql answers the GraphQL operations the github adapter sends: review
+// thread resolution and resolving, suggested reviewers, and the draft toggles. Operations
+// are told apart by the fields they name rather than parsed.
+func (s *Server) graphql(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		graphqlError(w, "invalid request: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.Contains(req.Query, "resolveReviewThread"):
+		s.resolveThread(w, req)
+	case strings.Contains(req.Query, "convertPullRequestToDraft"):
+		s.setDraft(w, req, true)
+	case strings.Contains(req.Query, "markPullRequestReadyForReview"):
+		s.setDraft(w, req, false)
+	case strings.Contains(req.Query, "reviewThreads"):
+		s.reviewThreads(w, req)
+	case strings.Contains(req.Query, "suggestedReviewers"):
+		s.suggestedReviewers(w, req)
+	default:
+		graphqlError(w, "unsupported operation")
+	}
+}
+
+// graphqlPull returns the repository and pull request named by the owner,
+// repo, and pr variables, or nils. The caller holds s.mu.
+func (s *Server) graphqlPull(req graphqlRequest) (*Repo, *Pull) {
+	owner, _ := req.Variables["owner"].(string)
+	name, _ := req.Variables["repo"].(string)
+	number, _ := req.Variables["pr"].(float64)
+	for ri := range s.scenario.Repos {
+		repo := &s.scenario.Repos[ri]
+		if !strings.EqualFold(repo.FullName, owner+"/"+name) {
+			continue
+		}
+		for pi := range repo.Pulls {
+			if float64(repo.Pulls[pi].Number) == number {
+				return repo, &repo.Pulls[pi]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// setDraft converts the pull request named by the pullRequestId variable to
+// a draft or marks it ready for review. The caller holds s.mu.
+func (s *Server) setDraft(w http.ResponseWriter, req graphqlRequest, draft bool) {
+	id, _ := req.Variables["pullRequestId"].(string)
+	for ri := range s.scenario.Repos {
+		repo := &s.scenario.Repos[ri]
+		for pi := range repo.Pulls {
+			pr := &repo.Pulls[pi]
+			if nodeID(repo, pr) != id {
+				continue
+			}
+			pr.Draft = draft
+			pr.UpdatedAt = s.now()
+			result := map[string]any{"pullRequest": map[string]any{"isDraft": draft}}
+			field := "markPullRequestReadyForReview"
+			if draft {
+				field = "convertPullRequestToDraft"
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{field: result}})
+			return
+		}
+	}
+	graphqlError(w, "Could not resolve to a node with the global id of '"+id+"'")
+}
+
+// reviewThreads answers one thread per review comment that is not a reply,
+// resolved as the scenario says. The caller holds s.mu.
+func (s *Server) reviewThreads(w http.ResponseWriter, req graphqlRequest) {
+	_, pr := s.graphqlPull(req)
+	if pr == nil {
+		graphqlError(w, "Could not resolve to a PullRequest")
+		return
+	}
+
+	nodes := []map[string]any{}
+	for _, c := range pr.ReviewComments {
+		if c.InReplyTo != 0 {
+			continue
+		}
+		nodes = append(nodes, map[string]any{
+			"id":         threadNodeID(c.ID),
+			"isResolved": c.Resolved,
+			"comments": map[string]any{
+				"nodes": []map[string]any{{"databaseId": c.ID}},
+			},
+		})
+	}
+	writePullRequestData(w, map[string]any{
+		"reviewThreads": map[string]any{
+			"pageInfo": map[string]any{"hasNextPage": false},
+			"nodes":    nodes,
+		},
+	})
+}
+
+// resolveThread resolves the review thread named by the threadId variable.
+// The caller holds s.mu.
+func (s *Server) resolveThread(w http.ResponseWriter, req graphqlRequest) {
+	id, _ := req.Variables["threadId"].(string)
+	for ri := range s.scenario.Repos {
+		repo := &s.scenario.Repos[ri]
+		for pi := range repo.Pulls {
+			pr := &repo.Pulls[pi]
+			for ci := range pr.ReviewComments {
+				c := &pr.ReviewComments[ci]
+				if c.InReplyTo != 0 || threadNodeID(c.ID) != id {
+					continue
+				}
+				c.Resolved = true
+				pr.UpdatedAt = s.now()
+				writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{
+					"resolveReviewThread": map[string]any{"thread": map[string]any{"isResolved": true}},
+				}})
+				return
+			}
+		}
+	}
+	graphqlError(w, "Could not resolve to a node with the global id of '"+id+"'")
+}
+
+// threadNodeID is the GraphQL node ID of the thread whose first comment has
+// the given ID.
+func threadNodeID(rootCommentID int64) string {
+	return fmt.Sprintf("PRRT_%d", rootCommentID)
+}
+
+// suggestedReviewers suggests the authors of the repository's other pull
+// requests, in file order, the way GitHub suggests recent contributors.
+// The caller holds s.mu.
+func (s *Server) suggestedReviewers(w http.ResponseWriter, req graphqlRequest) {
+	repo, pr := s.graphqlPull(req)
+	if pr == nil {
+		graphqlError(w, "Could not resolve to a PullRequest")
+		return
+	}
+
+	var seen []string
+	suggestions := []map[string]any{}
+	for _, other := range repo.Pulls {
+		login := other.Author
+		if login == pr.Author || slices.Contains(seen, login) {
+			continue
+		}
+		seen = append(seen, login)
+		suggestions = append(suggestions, map[string]any{
+			"isAuthor":    true,
+			"isCommenter": commented(pr, login),
+			"reviewer":    map[string]any{"login": login},
+		})
+	}
+	writePullRequestData(w, map[string]any{"suggestedReviewers": suggestions})
+}
+
+// commented reports whether login has commented on or reviewed pr.
+func commented(pr *Pull, login string) bool {
+	return slices.ContainsFunc(pr.Reviews, func(r Review) bool { return r.User == login }) ||
+		slices.ContainsFunc(pr.ReviewComments, func(c ReviewComment) bool { return c.User == login }) ||
+		slices.ContainsFunc(pr.IssueComments, func(c IssueComment) bool { return c.User == login })
+}
+
+// writePullRequestData writes a response whose data is
+// repository.pullRequest, the shape of both pull request queries.
+func writePullRequestData(w http.ResponseWriter, pullRequest map[string]any) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"data": map[string]any{
+			"repository": map[string]any{"pullRequest": pullRequest},
+		},
+	})
+}
+
+// graphqlError writes a GraphQL error response. GraphQL reports errors with
+// a 200 status.
+func graphqlError(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"errors": []map[string]string{{"message": message}},
+	})
+}
@@ -0,0 +1,271 @@
+// Package githubfake serves a fake GitHub REST and GraphQL API from a
+// scenario file, so the whole poll, store, and render pipeline can run end to
+// end without network access or a real token. It covers the endpoints the
+// github adapter calls; anything else answers 404.
+package githubfake
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // fake commit SHAs, not security
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is the fake GitHub's state: the authenticated user and the
+// repositories with their pull requests. Writes made through the API, such
+// as submitted reviews and comments, are added to it.
+type Scenario struct {
+	User   string   `yaml:"user"`
+	Scopes []string `yaml:"scopes"` // reported in X-OAuth-Scopes; defaults to repo and read:org
+	Repos  []Repo   `yaml:"repos"`
+}
+
+// Repo is a repository in a scenario.
+type Repo struct {
+	FullName       string   `yaml:"full_name"`
+	DefaultBranch  string   `yaml:"default_branch"` // defaults to main
+	Archived       bool     `yaml:"archived"`
+	RequiredChecks []string `yaml:"required_checks"` // branch protection on the default branch; none answers 404
+	Labels         []string `yaml:"labels"`          // defined labels; those used on pulls are added
+	Milestones     []string `yaml:"milestones"`      // open milestone titles, numbered from 1 in order
+	Pulls          []Pull   `yaml:"pulls"`
+}
+
+// Pull is a pull request in a scenario. Zero times default to when the
+// scenario was loaded and zero IDs are numbered in file order, so a scenario
+// only needs the fields a test cares about.
+type Pull struct {
+	Number             int       `yaml:"number"`
+	Title              string    `yaml:"title"`
+	Body               string    `yaml:"body"`
+	Author             string    `yaml:"author"`
+	State              string    `yaml:"state"` // open, closed, or merged; defaults to open
+	Draft              bool      `yaml:"draft"`
+	Branch             string    `yaml:"branch"` // defaults to pr-<number>
+	Base               string    `yaml:"base"`   // defaults to the repo's default branch
+	HeadSHA            string    `yaml:"head_sha"`
+	Labels             []string  `yaml:"labels"`
+	Milestone          string    `yaml:"milestone"` // one of the repo's milestones
+	RequestedReviewers []string  `yaml:"requested_reviewers"`
+	RequestedTeams     []string  `yaml:"requested_teams"`
+	CreatedAt          time.Time `yaml:"created_at"`
+	UpdatedAt          time.Time `yaml:"updated_at"`
+	ClosedAt           time.Time `yaml:"closed_at"`
+	Additions          int       `yaml:"additions"`
+	Deletions          int       `yaml:"deletions"`
+	ChangedFiles       int       `yaml:"changed_files"`
+	Mergeable          *bool     `yaml:"mergeable"` // unset reports GitHub's "not computed yet"
+
+	Reviews        []Review        `yaml:"reviews"`
+	ReviewComments []ReviewComment `yaml:"review_comments"`
+	IssueComments  []IssueComment  `yaml:"issue_comments"`
+	CheckRuns      []CheckRun      `yaml:"check_runs"`
+}
+
+// Review is a submitted pull request review.
+type Review struct {
+	ID          int64     `yaml:"id"`
+	User        string    `yaml:"user"`
+	State       string    `yaml:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, or DISMISSED
+	Body        string    `yaml:"body"`
+	CommitID    string    `yaml:"commit_id"` // defaults to the PR's head SHA
+	SubmittedAt time.Time `yaml:"submitted_at"`
+}
+
+// ReviewComment is an inline review comment. Replies set InReplyTo to the
+// thread's first comment, whose Resolved flag the GraphQL API reports.
+type ReviewComment struct {
+	ID        int64     `yaml:"id"`
+	ReviewID  int64     `yaml:"review_id"`
+	User      string    `yaml:"user"`
+	Body      string    `yaml:"body"`
+	Path      string    `yaml:"path"`
+	Line      int       `yaml:"line"`
+	InReplyTo int64     `yaml:"in_reply_to"`
+	CommitID  string    `yaml:"commit_id"` // defaults to the PR's head SHA
+	Resolved  bool      `yaml:"resolved"`
+	CreatedAt time.Time `yaml:"created_at"`
+}
+
+// IssueComment is a conversation comment on a pull request.
+type IssueComment struct {
+	ID        int64     `yaml:"id"`
+	User      string    `yaml:"user"`
+	Body      string    `yaml:"body"`
+	CreatedAt time.Time `yaml:"created_at"`
+}
+
+// CheckRun is a check run on a pull request's head commit.
+type CheckRun struct {
+	ID          int64     `yaml:"id"`
+	Name        string    `yaml:"name"`
+	Status      string    `yaml:"status"`     // defaults to completed
+	Conclusion  string    `yaml:"conclusion"` // success, failure, ...; empty while not completed
+	StartedAt   time.Time `yaml:"started_at"`
+	CompletedAt time.Time `yaml:"completed_at"`
+}
+
+// LoadScenario reads a scenario from a YAML file. Unknown keys are rejected
+// so a typo does not silently drop part of a test's setup.
+func LoadScenario(path string) (Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Scenario{}, fmt.Errorf("read scenario: %w", err)
+	}
+
+	var s Scenario
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&s); err != nil {
+		return Scenario{}, fmt.Errorf("parse scenario %s: %w", path, err)
+	}
+	if err := s.normalize(time.Now().UTC()); err != nil {
+		return Scenario{}, fmt.Errorf("scenario %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// normalize validates the scenario and fills in defaults, with now for
+// missing times.
+func (s *Scenario) normalize(now time.Time) error {
+	if s.User == "" {
+		return errors.New("user is required")
+	}
+	if s.Scopes == nil {
+		s.Scopes = []string{"repo", "read:org"}
+	}
+
+	// Generated IDs start after the largest one given, so they never collide.
+	nextID := s.maxID()
+	id := func(v *int64) {
+		if *v == 0 {
+			nextID++
+			*v = nextID
+		}
+	}
+	orNow := func(t *time.Time) {
+		if t.IsZero() {
+			*t = now
+		}
+	}
+
+	seen := make(map[string]bool)
+	for ri := range s.Repos {
+		repo := &s.Repos[ri]
+		if repo.FullName == "" {
+			return fmt.Errorf("repos[%d]: full_name is required", ri)
+		}
+		if seen[repo.FullName] {
+			return fmt.Errorf("repo %s is listed twice", repo.FullName)
+		}
+		seen[repo.FullName] = true
+		if repo.DefaultBranch == "" {
+			repo.DefaultBranch = "main"
+		}
+
+		for pi := range repo.Pulls {
+			pr := &repo.Pulls[pi]
+			if pr.Number <= 0 {
+				return fmt.Errorf("%s pulls[%d]: number is required", repo.FullName, pi)
+			}
+			switch pr.State {
+			case "":
+				pr.State = "open"
+			case "open", "closed", "merged":
+			default:
+				return fmt.Errorf("%s#%d: state must be open, closed, or merged, got %q", repo.FullName, pr.Number, pr.State)
+			}
+			if pr.Milestone != "" && !slices.Contains(repo.Milestones, pr.Milestone) {
+				return fmt.Errorf("%s#%d: milestone %q is not one of the repo's milestones", repo.FullName, pr.Number, pr.Milestone)
+			}
+			if pr.Author == "" {
+				pr.Author = s.User
+			}
+			if pr.Branch == "" {
+				pr.Branch = fmt.Sprintf("pr-%d", pr.Number)
+			}
+			if pr.Base == "" {
+				pr.Base = repo.DefaultBranch
+			}
+			if pr.HeadSHA == "" {
+				sum := sha1.Sum(fmt.Appendf(nil, "%s#%d", repo.FullName, pr.Number)) //nolint:gosec // fake commit SHAs, not security
+				pr.HeadSHA = hex.EncodeToString(sum[:])
+			}
+			orNow(&pr.CreatedAt)
+			orNow(&pr.UpdatedAt)
+			if pr.State != "open" {
+				orNow(&pr.ClosedAt)
+			}
+
+			for i := range pr.Reviews {
+				r := &pr.Reviews[i]
+				id(&r.ID)
+				if r.CommitID == "" {
+					r.CommitID = pr.HeadSHA
+				}
+				orNow(&r.SubmittedAt)
+			}
+			for i := range pr.ReviewComments {
+				c := &pr.ReviewComments[i]
+				id(&c.ID)
+				if c.CommitID == "" {
+					c.CommitID = pr.HeadSHA
+				}
+				orNow(&c.CreatedAt)
+			}
+			for i := range pr.IssueComments {
+				id(&pr.IssueComments[i].ID)
+				orNow(&pr.IssueComments[i].CreatedAt)
+			}
+			for i := range pr.CheckRuns {
+				c := &pr.CheckRuns[i]
+				id(&c.ID)
+				if c.Status == "" {
+					c.Status = "completed"
+				}
+				orNow(&c.StartedAt)
+				if c.Status == "completed" {
+					orNow(&c.CompletedAt)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// maxID returns the largest review, comment, or check run ID in the scenario.
+func (s *Scenario) maxID() int64 {
+	var maxID int64
+	for _, repo := range s.Repos {
+		for _, pr := range repo.Pulls {
+			for _, r := range pr.Reviews {
+				maxID = max(maxID, r.ID)
+			}
+			for _, c := range pr.ReviewComments {
+				maxID = max(maxID, c.ID)
+			}
+			for _, c := range pr.IssueComments {
+				maxID = max(maxID, c.ID)
+			}
+			for _, c := range pr.CheckRuns {
+				maxID = max(maxID, c.ID)
+			}
+		}
+	}
+	return maxID
+}
+
+// RepoNames returns the full names of the scenario's repositories, in file order.
+func (s *Scenario) RepoNames() []string {
+	names := make([]string, 0, len(s.Repos))
+	for _, repo := range s.Repos {
+		names = append(names, repo.FullName)
+	}
+	return names
+}
@@ -0,0 +1,775 @@
+package githubfake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gh "github.com/google/go-github/v82/github"
+)
+
+// restPrefix is where the REST API is served, matching GitHub Enterprise
+// Server, so clients reach it through the enterprise base URL setting.
+const restPrefix = "/api/v3"
+
+// Server serves a scenario over the GitHub REST and GraphQL APIs. It is safe
+// for concurrent use; writes made through the API change the scenario.
+type Server struct {
+	mu       sync.Mutex
+	scenario Scenario
+	nextID   int64
+	now      func() time.Time
+	handler  http.Handler
+}
+
+// NewServer returns a Server for the scenario. Point a client at it with the
+// base URL <server>/api/v3/; GraphQL is served at /api/graphql, where the
+// github adapter derives it from that base URL, and at /graphql.
+func NewServer(scenario Scenario) (*Server, error) {
+	if err := scenario.normalize(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	s := &Server{
+		scenario: scenario,
+		nextID:   scenario.maxID(),
+		now:      func() time.Time { return time.Now().UTC() },
+	}
+
+	rest := http.NewServeMux()
+	rest.HandleFunc("GET /user", s.getUser)
+	rest.HandleFunc("GET /repos/{owner}/{repo}", s.getRepo)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/pulls", s.listPulls)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}", s.getPull)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/reviews", s.listReviews)
+	rest.HandleFunc("POST /repos/{owner}/{repo}/pulls/{number}/reviews", s.createReview)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/pulls/{number}/comments", s.listReviewComments)
+	rest.HandleFunc("POST /repos/{owner}/{repo}/pulls/{number}/comments", s.createReply)
+	rest.HandleFunc("POST /repos/{owner}/{repo}/pulls/{number}/requested_reviewers", s.requestReviewers)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}/comments", s.listIssueComments)
+	rest.HandleFunc("POST /repos/{owner}/{repo}/issues/{number}/comments", s.createIssueComment)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/issues/{number}/timeline", s.listTimeline)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/labels", s.listLabels)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/milestones", s.listMilestones)
+	rest.HandleFunc("PATCH /repos/{owner}/{repo}/issues/{number}", s.editIssue)
+	rest.HandleFunc("POST /repos/{owner}/{repo}/issues/{number}/labels", s.addLabels)
+	rest.HandleFunc("DELETE /repos/{owner}/{repo}/issues/{number}/labels/{name}", s.removeLabel)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/commits/{ref}/check-runs", s.listCheckRuns)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/commits/{ref}/status", s.getCombinedStatus)
+	rest.HandleFunc("GET /repos/{owner}/{repo}/branches/{branch}/protection/required_status_checks", s.getRequiredChecks)
+	rest.HandleFunc("GET /orgs/{org}/teams/{team}/repos", s.listTeamRepos)
+
+	mux := http.NewServeMux()
+	mux.Handle(restPrefix+"/", http.StripPrefix(restPrefix, rest))
+	mux.HandleFunc("POST /api/graphql", s.graphql)
+	mux.HandleFunc("POST /graphql", s.graphql)
+	s.handler = mux
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler. Every response carries a generous rate
+// limit so clients never back off.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-RateLimit-Limit", "5000")
+	w.Header().Set("X-RateLimit-Remaining", "4999")
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(s.now().Add(time.Hour).Unix(), 10))
+	s.handler.ServeHTTP(w, r)
+}
+
+// Scenario returns a copy of the current state, including writes made
+// through the API.
+func (s *Server) Scenario() Scenario {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := s.scenario
+	out.Scopes = slices.Clone(out.Scopes)
+	out.Repos = slices.Clone(out.Repos)
+	for ri := range out.Repos {
+		repo := &out.Repos[ri]
+		repo.RequiredChecks = slices.Clone(repo.RequiredChecks)
+		repo.Pulls = slices.Clone(repo.Pulls)
+		for pi := range repo.Pulls {
+			pr := &repo.Pulls[pi]
+			pr.Labels = slices.Clone(pr.Labels)
+			pr.RequestedReviewers = slices.Clone(pr.RequestedReviewers)
+			pr.RequestedTeams = slices.Clone(pr.RequestedTeams)
+			pr.Reviews = slices.Clone(pr.Reviews)
+			pr.ReviewComments = slices.Clone(pr.ReviewComments)
+			pr.IssueComments = slices.Clone(pr.IssueComments)
+			pr.CheckRuns = slices.Clone(pr.CheckRuns)
+		}
+	}
+	return out
+}
+
+// repo returns the repository named by the request path, or nil. The caller
+// holds s.mu.
+func (s *Server) repo(r *http.Request) *Repo {
+	fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	for i := range s.scenario.Repos {
+		if strings.EqualFold(s.scenario.Repos[i].FullName, fullName) {
+			return &s.scenario.Repos[i]
+		}
+	}
+	return nil
+}
+
+// pull returns the repository and pull request named by the request path,
+// writing a 404 and returning nils when either does not exist. The caller
+// holds s.mu.
+func (s *Server) pull(w http.ResponseWriter, r *http.Request) (*Repo, *Pull) {
+	repo := s.repo(r)
+	if repo == nil {
+		notFound(w)
+		return nil, nil
+	}
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		notFound(w)
+		return nil, nil
+	}
+	for i := range repo.Pulls {
+		if repo.Pulls[i].Number == number {
+			return repo, &repo.Pulls[i]
+		}
+	}
+	notFound(w)
+	return nil, nil
+}
+
+// newID returns an ID for a review or comment created through the API. The
+// caller holds s.mu.
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) getUser(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Header().Set("X-OAuth-Scopes", strings.Join(s.scenario.Scopes, ", "))
+	writeJSON(w, http.StatusOK, user(s.scenario.User))
+}
+
+func (s *Server) getRepo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(r)
+	if repo == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, ghRepo(repo))
+}
+
+// listPulls answers every matching pull request on one page, most recently
+// updated first, the order the poller asks for.
+func (s *Server) listPulls(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(r)
+	if repo == nil {
+		notFound(w)
+		return
+	}
+	state := r.URL.Query().Get("state")
+	if state == "" {
+		state = "open"
+	}
+
+	out := []*gh.PullRequest{}
+	for i := range repo.Pulls {
+		pr := &repo.Pulls[i]
+		open := pr.State == "open"
+		if state == "all" || (state == "open") == open {
+			out = append(out, ghPull(repo, pr))
+		}
+	}
+	slices.SortStableFunc(out, func(a, b *gh.PullRequest) int {
+		return b.GetUpdatedAt().Compare(a.GetUpdatedAt().Time)
+	})
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) getPull(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, ghPull(repo, pr))
+}
+
+func (s *Server) listReviews(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	out := make([]*gh.PullRequestReview, 0, len(pr.Reviews))
+	for _, review := range pr.Reviews {
+		out = append(out, &gh.PullRequestReview{
+			ID:          gh.Ptr(review.ID),
+			User:        user(review.User),
+			Body:        gh.Ptr(review.Body),
+			State:       gh.Ptr(review.State),
+			CommitID:    gh.Ptr(review.CommitID),
+			SubmittedAt: &gh.Timestamp{Time: review.SubmittedAt},
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// reviewStates maps a submitted review's event to the state GitHub reports.
+var reviewStates = map[string]string{
+	"APPROVE":         "APPROVED",
+	"REQUEST_CHANGES": "CHANGES_REQUESTED",
+	"COMMENT":         "COMMENTED",
+}
+
+// createReview records a submitted review and its inline comments. Like
+// GitHub, it answers 422 for a commit that is not the head commit.
+func (s *Server) createReview(w http.ResponseWriter, r *http.Request) {
+	var req gh.PullRequestReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		unprocessable(w, "invalid review: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	if commit := req.GetCommitID(); commit != "" && commit != pr.HeadSHA {
+		unprocessable(w, "commit_id is not part of the pull request")
+		return
+	}
+	state, ok := reviewStates[req.GetEvent()]
+	if !ok {
+		unprocessable(w, fmt.Sprintf("unsupported event %q", req.GetEvent()))
+		return
+	}
+
+	now := s.now()
+	review := Review{
+		ID:          s.newID(),
+		User:        s.scenario.User,
+		State:       state,
+		Body:        req.GetBody(),
+		CommitID:    pr.HeadSHA,
+		SubmittedAt: now,
+	}
+	pr.Reviews = append(pr.Reviews, review)
+	for _, c := range req.Comments {
+		pr.ReviewComments = append(pr.ReviewComments, ReviewComment{
+			ID:        s.newID(),
+			ReviewID:  review.ID,
+			User:      s.scenario.User,
+			Body:      c.GetBody(),
+			Path:      c.GetPath(),
+			Line:      c.GetLine(),
+			CommitID:  pr.HeadSHA,
+			CreatedAt: now,
+		})
+	}
+	pr.UpdatedAt = now
+
+	writeJSON(w, http.StatusOK, &gh.PullRequestReview{
+		ID:    gh.Ptr(review.ID),
+		User:  user(review.User),
+		State: gh.Ptr(review.State),
+	})
+}
+
+func (s *Server) listReviewComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	out := make([]*gh.PullRequestComment, 0, len(pr.ReviewComments))
+	for _, c := range pr.ReviewComments {
+		out = append(out, ghReviewComment(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// createReply records a reply to a review thread. Only replies are
+// supported; new inline comments are submitted with a review.
+func (s *Server) createReply(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Body      string `json:"body"`
+		InReplyTo int64  `json:"in_reply_to"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		unprocessable(w, "invalid comment: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	i := slices.IndexFunc(pr.ReviewComments, func(c ReviewComment) bool { return c.ID == req.InReplyTo })
+	if i < 0 {
+		unprocessable(w, "in_reply_to must name a review comment on the pull request")
+		return
+	}
+	parent := pr.ReviewComments[i]
+
+	now := s.now()
+	reply := ReviewComment{
+		ID:        s.newID(),
+		ReviewID:  parent.ReviewID,
+		User:      s.scenario.User,
+		Body:      req.Body,
+		Path:      parent.Path,
+		Line:      parent.Line,
+		InReplyTo: parent.ID,
+		CommitID:  parent.CommitID,
+		CreatedAt: now,
+	}
+	if parent.InReplyTo != 0 {
+		reply.InReplyTo = parent.InReplyTo
+	}
+	pr.ReviewComments = append(pr.ReviewComments, reply)
+	pr.UpdatedAt = now
+
+	writeJSON(w, http.StatusCreated, ghReviewComment(reply))
+}
+
+func (s *Server) requestReviewers(w http.ResponseWriter, r *http.Request) {
+	var req gh.ReviewersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		unprocessable(w, "invalid reviewers: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	for _, login := range req.Reviewers {
+		if !slices.Contains(pr.RequestedReviewers, login) {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, login)
+		}
+	}
+	for _, team := range req.TeamReviewers {
+		if !slices.Contains(pr.RequestedTeams, team) {
+			pr.RequestedTeams = append(pr.RequestedTeams, team)
+		}
+	}
+	pr.UpdatedAt = s.now()
+
+	writeJSON(w, http.StatusCreated, ghPull(repo, pr))
+}
+
+// listLabels answers the repository's defined labels together with any used
+// on its pulls, sorted, on one page.
+func (s *Server) listLabels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(r)
+	if repo == nil {
+		notFound(w)
+		return
+	}
+	names := slices.Clone(repo.Labels)
+	for _, pr := range repo.Pulls {
+		names = append(names, pr.Labels...)
+	}
+	slices.Sort(names)
+
+	out := []*gh.Label{}
+	for _, name := range slices.Compact(names) {
+		out = append(out, &gh.Label{Name: gh.Ptr(name)})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// addLabels adds the posted label names to the pull request, defining any
+// the repository does not have yet, as GitHub does.
+func (s *Server) addLabels(w http.ResponseWriter, r *http.Request) {
+	var names []string
+	if err := json.NewDecoder(r.Body).Decode(&names); err != nil {
+		unprocessable(w, "invalid labels: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	for _, name := range names {
+		if !slices.Contains(pr.Labels, name) {
+			pr.Labels = append(pr.Labels, name)
+		}
+		if !slices.Contains(repo.Labels, name) {
+			repo.Labels = append(repo.Labels, name)
+		}
+	}
+	pr.UpdatedAt = s.now()
+
+	writeJSON(w, http.StatusOK, ghLabels(pr.Labels))
+}
+
+// removeLabel removes one label from the pull request, answering 404 when
+// the pull request does not have it.
+func (s *Server) removeLabel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	i := slices.Index(pr.Labels, r.PathValue("name"))
+	if i < 0 {
+		notFound(w)
+		return
+	}
+	pr.Labels = slices.Delete(pr.Labels, i, i+1)
+	pr.UpdatedAt = s.now()
+
+	writeJSON(w, http.StatusOK, ghLabels(pr.Labels))
+}
+
+// listMilestones answers the repository's milestones, in scenario order, on
+// one page.
+func (s *Server) listMilestones(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(r)
+	if repo == nil {
+		notFound(w)
+		return
+	}
+	out := []*gh.Milestone{}
+	for i, title := range repo.Milestones {
+		out = append(out, &gh.Milestone{Number: gh.Ptr(i + 1), Title: gh.Ptr(title), State: gh.Ptr("open")})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// editIssue supports setting and clearing the pull request's milestone,
+// the only issue field the github adapter edits.
+func (s *Server) editIssue(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Milestone *int `json:"milestone"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		unprocessable(w, "invalid issue: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	switch {
+	case req.Milestone == nil:
+		pr.Milestone = ""
+	case *req.Milestone < 1 || *req.Milestone > len(repo.Milestones):
+		unprocessable(w, "milestone does not exist")
+		return
+	default:
+		pr.Milestone = repo.Milestones[*req.Milestone-1]
+	}
+	pr.UpdatedAt = s.now()
+
+	writeJSON(w, http.StatusOK, ghPull(repo, pr))
+}
+
+func (s *Server) listIssueComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	out := make([]*gh.IssueComment, 0, len(pr.IssueComments))
+	for _, c := range pr.IssueComments {
+		out = append(out, ghIssueComment(c))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// listTimeline reports a review_requested event for each pending request,
+// dated when the pull request was opened.
+func (s *Server) listTimeline(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	out := make([]*gh.Timeline, 0, len(pr.RequestedReviewers)+len(pr.RequestedTeams))
+	requestedAt := &gh.Timestamp{Time: pr.CreatedAt}
+	for _, login := range pr.RequestedReviewers {
+		out = append(out, &gh.Timeline{Event: gh.Ptr("review_requested"), CreatedAt: requestedAt, Reviewer: user(login)})
+	}
+	for _, slug := range pr.RequestedTeams {
+		out = append(out, &gh.Timeline{Event: gh.Ptr("review_requested"), CreatedAt: requestedAt, RequestedTeam: &gh.Team{Slug: gh.Ptr(slug)}})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createIssueComment(w http.ResponseWriter, r *http.Request) {
+	var req gh.IssueComment
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		unprocessable(w, "invalid comment: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, pr := s.pull(w, r)
+	if pr == nil {
+		return
+	}
+	now := s.now()
+	comment := IssueComment{ID: s.newID(), User: s.scenario.User, Body: req.GetBody(), CreatedAt: now}
+	pr.IssueComments = append(pr.IssueComments, comment)
+	pr.UpdatedAt = now
+
+	writeJSON(w, http.StatusCreated, ghIssueComment(comment))
+}
+
+// listCheckRuns answers the check runs of the pull request whose head
+// commit or branch is ref.
+func (s *Server) listCheckRuns(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(r)
+	if repo == nil {
+		notFound(w)
+		return
+	}
+	ref := r.PathValue("ref")
+	runs := []*gh.CheckRun{}
+	for _, pr := range repo.Pulls {
+		if pr.HeadSHA != ref && pr.Branch != ref {
+			continue
+		}
+		for _, c := range pr.CheckRuns {
+			run := &gh.CheckRun{
+				ID:         gh.Ptr(c.ID),
+				Name:       gh.Ptr(c.Name),
+				HeadSHA:    gh.Ptr(pr.HeadSHA),
+				Status:     gh.Ptr(c.Status),
+				StartedAt:  &gh.Timestamp{Time: c.StartedAt},
+				DetailsURL: gh.Ptr(fmt.Sprintf("https://github.com/%s/runs/%d", repo.FullName, c.ID)),
+			}
+			if c.Conclusion != "" {
+				run.Conclusion = gh.Ptr(c.Conclusion)
+			}
+			if !c.CompletedAt.IsZero() {
+				run.CompletedAt = &gh.Timestamp{Time: c.CompletedAt}
+			}
+			runs = append(runs, run)
+		}
+		break
+	}
+	writeJSON(w, http.StatusOK, &gh.ListCheckRunsResults{Total: gh.Ptr(len(runs)), CheckRuns: runs})
+}
+
+// getCombinedStatus answers an empty combined status: scenarios report CI
+// through check runs only.
+func (s *Server) getCombinedStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.repo(r) == nil {
+		notFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, &gh.CombinedStatus{SHA: gh.Ptr(r.PathValue("ref")), Statuses: []*gh.RepoStatus{}})
+}
+
+// getRequiredChecks answers the repository's required checks for its
+// default branch, and 404 when it has none, as for an unprotected branch.
+func (s *Server) getRequiredChecks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	repo := s.repo(r)
+	if repo == nil || len(repo.RequiredChecks) == 0 || r.PathValue("branch") != repo.DefaultBranch {
+		notFound(w)
+		return
+	}
+	checks := make([]*gh.RequiredStatusCheck, 0, len(repo.RequiredChecks))
+	for _, name := range repo.RequiredChecks {
+		checks = append(checks, &gh.RequiredStatusCheck{Context: name})
+	}
+	writeJSON(w, http.StatusOK, &gh.RequiredStatusChecks{Strict: true, Checks: &checks})
+}
+
+// listTeamRepos answers every scenario repository owned by the org:
+// scenarios do not model team membership.
+func (s *Server) listTeamRepos(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := []*gh.Repository{}
+	for i := range s.scenario.Repos {
+		repo := &s.scenario.Repos[i]
+		if owner, _, _ := strings.Cut(repo.FullName, "/"); strings.EqualFold(owner, r.PathValue("org")) {
+			out = append(out, ghRepo(repo))
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// user returns a GitHub user with the given login.
+func user(login string) *gh.User {
+	return &gh.User{Login: gh.Ptr(login)}
+}
+
+// nodeID returns the GraphQL node ID of a pull request.
+func nodeID(repo *Repo, pr *Pull) string {
+	return fmt.Sprintf("PR_%s#%d", repo.FullName, pr.Number)
+}
+
+func ghLabels(names []string) []*gh.Label {
+	out := []*gh.Label{}
+	for _, name := range names {
+		out = append(out, &gh.Label{Name: gh.Ptr(name)})
+	}
+	return out
+}
+
+func ghRepo(repo *Repo) *gh.Repository {
+	owner, name, _ := strings.Cut(repo.FullName, "/")
+	return &gh.Repository{
+		Name:          gh.Ptr(name),
+		FullName:      gh.Ptr(repo.FullName),
+		Owner:         user(owner),
+		DefaultBranch: gh.Ptr(repo.DefaultBranch),
+		Archived:      gh.Ptr(repo.Archived),
+		Private:       gh.Ptr(false),
+		HTMLURL:       gh.Ptr("https://github.com/" + repo.FullName),
+	}
+}
+
+func ghPull(repo *Repo, pr *Pull) *gh.PullRequest {
+	out := &gh.PullRequest{
+		Number:            gh.Ptr(pr.Number),
+		NodeID:            gh.Ptr(nodeID(repo, pr)),
+		Title:             gh.Ptr(pr.Title),
+		Body:              gh.Ptr(pr.Body),
+		State:             gh.Ptr("open"),
+		Draft:             gh.Ptr(pr.Draft),
+		User:              user(pr.Author),
+		HTMLURL:           gh.Ptr(fmt.Sprintf("https://github.com/%s/pull/%d", repo.FullName, pr.Number)),
+		Head:              &gh.PullRequestBranch{Ref: gh.Ptr(pr.Branch), SHA: gh.Ptr(pr.HeadSHA)},
+		Base:              &gh.PullRequestBranch{Ref: gh.Ptr(pr.Base)},
+		CreatedAt:         &gh.Timestamp{Time: pr.CreatedAt},
+		UpdatedAt:         &gh.Timestamp{Time: pr.UpdatedAt},
+		AuthorAssociation: gh.Ptr("MEMBER"),
+		Additions:         gh.Ptr(pr.Additions),
+		Deletions:         gh.Ptr(pr.Deletions),
+		ChangedFiles:      gh.Ptr(pr.ChangedFiles),
+		Mergeable:         pr.Mergeable,
+	}
+	if pr.State != "open" {
+		out.State = gh.Ptr("closed")
+		out.ClosedAt = &gh.Timestamp{Time: pr.ClosedAt}
+	}
+	if pr.State == "merged" {
+		out.Merged = gh.Ptr(true)
+		out.MergedAt = &gh.Timestamp{Time: pr.ClosedAt}
+	}
+	out.Labels = ghLabels(pr.Labels)
+	if i := slices.Index(repo.Milestones, pr.Milestone); pr.Milestone != "" && i >= 0 {
+		out.Milestone = &gh.Milestone{Number: gh.Ptr(i + 1), Title: gh.Ptr(pr.Milestone)}
+	}
+	for _, login := range pr.RequestedReviewers {
+		out.RequestedReviewers = append(out.RequestedReviewers, user(login))
+	}
+	for _, slug := range pr.RequestedTeams {
+		out.RequestedTeams = append(out.RequestedTeams, &gh.Team{Slug: gh.Ptr(slug)})
+	}
+	return out
+}
+
+func ghReviewComment(c ReviewComment) *gh.PullRequestComment {
+	out := &gh.PullRequestComment{
+		ID:                  gh.Ptr(c.ID),
+		PullRequestReviewID: gh.Ptr(c.ReviewID),
+		User:                user(c.User),
+		Body:                gh.Ptr(c.Body),
+		Path:                gh.Ptr(c.Path),
+		Line:                gh.Ptr(c.Line),
+		Side:                gh.Ptr("RIGHT"),
+		SubjectType:         gh.Ptr("line"),
+		CommitID:            gh.Ptr(c.CommitID),
+		CreatedAt:           &gh.Timestamp{Time: c.CreatedAt},
+		UpdatedAt:           &gh.Timestamp{Time: c.CreatedAt},
+	}
+	if c.InReplyTo != 0 {
+		out.InReplyTo = gh.Ptr(c.InReplyTo)
+	}
+	return out
+}
+
+func ghIssueComment(c IssueComment) *gh.IssueComment {
+	return &gh.IssueComment{
+		ID:        gh.Ptr(c.ID),
+		User:      user(c.User),
+		Body:      gh.Ptr(c.Body),
+		CreatedAt: &gh.Timestamp{Time: c.CreatedAt},
+		UpdatedAt: &gh.Timestamp{Time: c.CreatedAt},
+	}
+}
+
+// writeJSON writes v as a JSON response with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// notFound writes GitHub's 404 body.
+func notFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+}
+
+// unprocessable writes a 422 with GitHub's error body.
+func unprocessable(w http.ResponseWriter, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": message})
+}
@@ -0,0 +1,254 @@
+package githubfake_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ghAdapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github"
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github/githubfake"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// newFake serves testdata/scenario.yaml and returns a github adapter client
+// pointed at it the way MYGITPANEL_GITHUB_BASE_URL would.
+func newFake(t *testing.T) (*githubfake.Server, *ghAdapter.Client) {
+	t.Helper()
+
+	scenario, err := githubfake.LoadScenario("testdata/scenario.yaml")
+	require.NoError(t, err)
+	fake, err := githubfake.NewServer(scenario)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client, err := ghAdapter.NewClientWithEndpoints("fake-token", scenario.User, srv.URL+"/api/v3/", "")
+	require.NoError(t, err)
+	return fake, client
+}
+
+// findPull returns the pull request with the given number from s.
+func findPull(t *testing.T, s githubfake.Scenario, repo string, number int) githubfake.Pull {
+	t.Helper()
+	for _, r := range s.Repos {
+		if r.FullName != repo {
+			continue
+		}
+		for _, pr := range r.Pulls {
+			if pr.Number == number {
+				return pr
+			}
+		}
+	}
+	t.Fatalf("%s#%d not in scenario", repo, number)
+	return githubfake.Pull{}
+}
+
+func TestServer_Reads(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	info, err := client.FetchTokenInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", info.Login)
+	assert.Equal(t, []string{"repo", "read:org"}, info.Scopes)
+
+	open, err := client.FetchPullRequests(ctx, "acme/api", "open")
+	require.NoError(t, err)
+	require.Len(t, open, 2)
+	assert.Equal(t, 8, open[0].Number, "most recently updated first")
+	pr := open[1]
+	assert.Equal(t, "Add rate limiting to the public API", pr.Title)
+	assert.Equal(t, "alice", pr.Author)
+	assert.Equal(t, "rate-limit", pr.Branch)
+	assert.Equal(t, "main", pr.BaseBranch)
+	assert.Equal(t, []string{"enhancement"}, pr.Labels)
+	assert.Equal(t, []string{"octocat"}, pr.RequestedReviewers)
+	assert.Len(t, pr.HeadSHA, 40)
+	assert.True(t, open[0].IsDraft)
+
+	all, err := client.FetchPullRequests(ctx, "acme/api", "all")
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, model.PRStatusMerged, all[2].Status)
+
+	detail, err := client.FetchPRDetail(ctx, "acme/api", 7)
+	require.NoError(t, err)
+	assert.Equal(t, model.PRDetail{Additions: 120, Deletions: 14, ChangedFiles: 6, Mergeable: model.MergeableMergeable}, *detail)
+
+	reviews, err := client.FetchReviews(ctx, "acme/api", 7)
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	assert.Equal(t, model.ReviewState("changes_requested"), reviews[0].State)
+	assert.Equal(t, pr.HeadSHA, reviews[0].CommitID)
+
+	comments, err := client.FetchReviewComments(ctx, "acme/api", 7)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	require.NotNil(t, comments[1].InReplyToID)
+	assert.Equal(t, int64(201), *comments[1].InReplyToID)
+
+	resolution, err := client.FetchThreadResolution(ctx, "acme/api", 7)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{201: false}, resolution)
+
+	issueComments, err := client.FetchIssueComments(ctx, "acme/api", 8)
+	require.NoError(t, err)
+	require.Len(t, issueComments, 1)
+	assert.Equal(t, "carol", issueComments[0].Author)
+
+	runs, err := client.FetchCheckRuns(ctx, "acme/api", pr.HeadSHA)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "failure", runs[1].Conclusion)
+
+	status, err := client.FetchCombinedStatus(ctx, "acme/api", pr.HeadSHA)
+	require.NoError(t, err)
+	assert.Nil(t, status, "scenarios report CI through check runs only")
+
+	required, err := client.FetchRequiredStatusChecks(ctx, "acme/api", "main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build"}, required)
+	required, err = client.FetchRequiredStatusChecks(ctx, "acme/web", "main")
+	require.NoError(t, err)
+	assert.Nil(t, required, "unprotected")
+
+	suggested, err := client.FetchSuggestedReviewers(ctx, "acme/api", 7)
+	require.NoError(t, err)
+	require.Len(t, suggested, 2)
+	assert.Equal(t, "octocat", suggested[0].Login)
+	assert.True(t, suggested[1].IsCommenter, "bob reviewed the PR")
+
+	labels, err := client.FetchRepoLabels(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug", "dependencies", "enhancement"}, labels)
+
+	milestones, err := client.FetchMilestones(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, []model.Milestone{{Number: 1, Title: "v1.0"}, {Number: 2, Title: "v1.1"}}, milestones)
+
+	teamRepos, err := client.ListTeamRepos(ctx, "acme", "core")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/api", "acme/web"}, teamRepos)
+
+	_, err = client.FetchPullRequests(ctx, "acme/missing", "open")
+	assert.ErrorIs(t, err, driven.ErrRepoUnreachable)
+}
+
+func TestServer_Writes(t *testing.T) {
+	fake, client := newFake(t)
+	ctx := context.Background()
+	before := findPull(t, fake.Scenario(), "acme/api", 7)
+
+	require.NoError(t, client.SubmitReview(ctx, "acme/api", 7, driven.ReviewRequest{
+		Event:    "APPROVE",
+		Comments: []driven.DraftLineComment{{Path: "limiter.go", Line: 50, Side: "RIGHT", Body: "nit"}},
+	}))
+	require.NoError(t, client.CreateReplyComment(ctx, "acme/api", 7, 202, "Thanks"))
+	require.NoError(t, client.CreateIssueComment(ctx, "acme/api", 7, "LGTM"))
+	require.NoError(t, client.RequestReviewers(ctx, "acme/api", 7, []string{"carol"}))
+	require.NoError(t, client.AddLabels(ctx, "acme/api", 7, []string{"triaged"}))
+	require.NoError(t, client.RemoveLabel(ctx, "acme/api", 7, "triaged"))
+	assert.Error(t, client.RemoveLabel(ctx, "acme/api", 7, "triaged"), "the label is gone")
+	require.NoError(t, client.ConvertPullRequestToDraft(ctx, "acme/api", 7))
+	require.NoError(t, client.ResolveReviewThread(ctx, "acme/api", 7, 201))
+	assert.ErrorContains(t, client.ResolveReviewThread(ctx, "acme/api", 7, 202), "no thread starts with comment 202")
+
+	err := client.SubmitReview(ctx, "acme/api", 7, driven.ReviewRequest{CommitID: "stale", Event: "COMMENT", Body: "late"})
+	assert.ErrorContains(t, err, "PR was updated since you started reviewing")
+
+	pr := findPull(t, fake.Scenario(), "acme/api", 7)
+	require.Len(t, pr.Reviews, 2)
+	assert.Equal(t, githubfake.Review{
+		ID:          pr.Reviews[1].ID,
+		User:        "octocat",
+		State:       "APPROVED",
+		CommitID:    pr.HeadSHA,
+		SubmittedAt: pr.Reviews[1].SubmittedAt,
+	}, pr.Reviews[1])
+	require.Len(t, pr.ReviewComments, 4)
+	assert.Equal(t, pr.Reviews[1].ID, pr.ReviewComments[2].ReviewID)
+	assert.Equal(t, "nit", pr.ReviewComments[2].Body)
+	assert.Equal(t, int64(201), pr.ReviewComments[3].InReplyTo, "replies join the thread's first comment")
+	assert.Equal(t, "limiter.go", pr.ReviewComments[3].Path)
+	assert.True(t, pr.ReviewComments[0].Resolved)
+	require.Len(t, pr.IssueComments, 1)
+	assert.Equal(t, "LGTM", pr.IssueComments[0].Body)
+	assert.Equal(t, []string{"octocat", "carol"}, pr.RequestedReviewers)
+	assert.True(t, pr.Draft)
+	assert.True(t, pr.UpdatedAt.After(before.UpdatedAt), "writes bump updated_at so the poller refetches")
+
+	require.NoError(t, client.MarkPullRequestReadyForReview(ctx, "acme/api", 7))
+	assert.False(t, findPull(t, fake.Scenario(), "acme/api", 7).Draft)
+
+	require.NoError(t, client.SetMilestone(ctx, "acme/api", 7, 2))
+	assert.Equal(t, "v1.1", findPull(t, fake.Scenario(), "acme/api", 7).Milestone)
+	prs, err := client.FetchPullRequests(ctx, "acme/api", "open")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1", prs[0].Milestone, "polled with the PR")
+	require.NoError(t, client.SetMilestone(ctx, "acme/api", 7, 0))
+	assert.Empty(t, findPull(t, fake.Scenario(), "acme/api", 7).Milestone)
+	assert.Error(t, client.SetMilestone(ctx, "acme/api", 7, 9))
+}
+
+func TestLoadScenario_Errors(t *testing.T) {
+	for _, tt := range []struct {
+		name, yaml, want string
+	}{
+		{"unknown key", "user: me\nrepos:\n  - full_name: a/b\n    pulls:\n      - number: 1\n        titel: typo\n", "field titel not found"},
+		{"no user", "repos: []\n", "user is required"},
+		{"no number", "user: me\nrepos:\n  - full_name: a/b\n    pulls:\n      - title: x\n", "number is required"},
+		{"bad state", "user: me\nrepos:\n  - full_name: a/b\n    pulls:\n      - number: 1\n        state: draft\n", "state must be open, closed, or merged"},
+		{"unknown milestone", "user: me\nrepos:\n  - full_name: a/b\n    pulls:\n      - number: 1\n        milestone: v2\n", "not one of the repo's milestones"},
+		{"duplicate repo", "user: me\nrepos:\n  - full_name: a/b\n  - full_name: a/b\n", "listed twice"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := t.TempDir() + "/scenario.yaml"
+			require.NoError(t, os.WriteFile(path, []byte(tt.yaml), 0o600))
+			_, err := githubfake.LoadScenario(path)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestLoadScenario_Defaults(t *testing.T) {
+	path := t.TempDir() + "/scenario.yaml"
+	require.NoError(t, os.WriteFile(path, []byte(`user: me
+repos:
+  - full_name: a/b
+    pulls:
+      - number: 1
+        reviews:
+          - user: x
+            state: APPROVED
+          - id: 1
+            user: y
+            state: COMMENTED
+        check_runs:
+          - name: ci
+            status: in_progress
+`), 0o600))
+	before := time.Now().UTC()
+	s, err := githubfake.LoadScenario(path)
+	require.NoError(t, err)
+
+	pr := s.Repos[0].Pulls[0]
+	assert.Equal(t, "main", s.Repos[0].DefaultBranch)
+	assert.Equal(t, "open", pr.State)
+	assert.Equal(t, "me", pr.Author)
+	assert.Equal(t, "pr-1", pr.Branch)
+	assert.Equal(t, "main", pr.Base)
+	assert.False(t, pr.CreatedAt.Before(before))
+	assert.Equal(t, int64(2), pr.Reviews[0].ID, "generated IDs skip the ones given")
+	assert.Equal(t, pr.HeadSHA, pr.Reviews[0].CommitID)
+	assert.Equal(t, int64(3), pr.CheckRuns[0].ID)
+	assert.True(t, pr.CheckRuns[0].CompletedAt.IsZero(), "still running")
+	assert.Equal(t, []string{"a/b"}, s.RepoNames())
+}
@@ -8,6 +8,8 @@ import (
 	"log/slog"
 	"net/http"
 	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 )
 
 const convertToDraftMutation = `
@@ -24,6 +26,30 @@ mutation MarkReady($pullRequestId: ID!) {
     }
 }`
 
+const resolveReviewThreadMutation = `
+mutation ResolveThread($threadId: ID!) {
+    resolveReviewThread(input: { threadId: $threadId }) {
+        thread { isResolved }
+    }
+}`
+
+const reviewThreadIDsQuery = `query($owner: String!, $repo: String!, $pr: Int!) {
+	repository(owner: $owner, name: $repo) {
+		pullRequest(number: $pr) {
+			reviewThreads(first: 100) {
+				nodes {
+					id
+					comments(first: 1) {
+						nodes {
+							databaseId
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
 const threadResolutionQuery = `query($owner: String!, $repo: String!, $pr: Int!) {
 	repository(owner: $owner, name: $repo) {
 		pullRequest(number: $pr) {
@@ -44,6 +70,20 @@ const threadResolutionQuery = `query($owner: String!, $repo: String!, $pr: Int!)
 	}
 }`
 
+const suggestedReviewersQuery = `query($owner: String!, $repo: String!, $pr: Int!) {
+	repository(owner: $owner, name: $repo) {
+		pullRequest(number: $pr) {
+			suggestedReviewers {
+				isAuthor
+				isCommenter
+				reviewer {
+					login
+				}
+			}
+		}
+	}
+}`
+
 // graphqlRequest is the JSON body sent to the GitHub GraphQL API.
 type graphqlRequest struct {
 	Query     string         `json:"query"`
@@ -77,6 +117,44 @@ type graphqlResponse struct {
 	} `json:"errors"`
 }
 
+// suggestedReviewersResponse is the shape of the suggested reviewers query response.
+type suggestedReviewersResponse struct {
+	Data struct {
+		Repository struct {
+			PullRequest struct {
+				SuggestedReviewers []struct {
+					IsAuthor    bool `json:"isAuthor"`
+					IsCommenter bool `json:"isCommenter"`
+					Reviewer    struct {
+						Login string `json:"login"`
+					} `json:"reviewer"`
+				} `json:"suggestedReviewers"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// reviewThreadIDsData is the data of the review thread IDs query.
+type reviewThreadIDsData struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				Nodes []struct {
+					ID       string `json:"id"`
+					Comments struct {
+						Nodes []struct {
+							DatabaseID int64 `json:"databaseId"`
+						} `json:"nodes"`
+					} `json:"comments"`
+				} `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
 // draftMutationResponse is the minimal struct used to detect errors in draft toggle mutations.
 type draftMutationResponse struct {
 	Data   map[string]any `json:"data"`
@@ -130,6 +208,53 @@ func (c *Client) executeDraftMutation(ctx context.Context, mutation, nodeID stri
 	return nil
 }
 
+// executeGraphQL sends a GraphQL request and decodes the response data into
+// out, which may be nil. op names the operation in errors.
+func (c *Client) executeGraphQL(ctx context.Context, op string, reqBody graphqlRequest, out any) error {
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return fmt.Errorf("graphql %s: marshal request: %w", op, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphqlURL, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("graphql %s: create request: %w", op, err)
+	}
+	httpReq.Header.Set("Authorization", fmt.Sprintf("bearer %s", c.token))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("graphql %s: request failed: %w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("graphql %s: non-200 response: %d", op, resp.StatusCode)
+	}
+
+	var gqlResp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&gqlResp); err != nil {
+		return fmt.Errorf("graphql %s: decode response: %w", op, err)
+	}
+	if len(gqlResp.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(gqlResp.Data, out); err != nil {
+		return fmt.Errorf("graphql %s: decode data: %w", op, err)
+	}
+	return nil
+}
+
 // FetchThreadResolution queries the GitHub GraphQL API for review thread resolution status.
 // It returns a map of review comment database ID to its resolved status (true = resolved).
 //
@@ -214,3 +339,68 @@ func (c *Client) FetchThreadResolution(ctx context.Context, repoFullName string,
 
 	return result, nil
 }
+
+// FetchSuggestedReviewers queries the GitHub GraphQL API for the reviewers
+// GitHub suggests for a pull request, in GitHub's order. Unlike
+// FetchThreadResolution it returns errors, since the caller shows them.
+func (c *Client) FetchSuggestedReviewers(ctx context.Context, repoFullName string, prNumber int) ([]model.SuggestedReviewer, error) {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	reqBody := graphqlRequest{
+		Query: suggestedReviewersQuery,
+		Variables: map[string]any{
+			"owner": owner,
+			"repo":  repo,
+			"pr":    prNumber,
+		},
+	}
+
+	bodyBytes, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("graphql suggested reviewers: marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphqlURL, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("graphql suggested reviewers: create request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", fmt.Sprintf("bearer %s", c.token))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	resp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("graphql suggested reviewers: request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("graphql suggested reviewers: non-200 response: %d", resp.StatusCode)
+	}
+
+	var gqlResp suggestedReviewersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&gqlResp); err != nil {
+		return nil, fmt.Errorf("graphql suggested reviewers: decode response: %w", err)
+	}
+
+	if len(gqlResp.Errors) > 0 {
+		return nil, fmt.Errorf("GraphQL error: %s", gqlResp.Errors[0].Message)
+	}
+
+	nodes := gqlResp.Data.Repository.PullRequest.SuggestedReviewers
+	result := make([]model.SuggestedReviewer, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Reviewer.Login == "" {
+			continue
+		}
+		result = append(result, model.SuggestedReviewer{
+			Login:       n.Reviewer.Login,
+			IsAuthor:    n.IsAuthor,
+			IsCommenter: n.IsCommenter,
+		})
+	}
+	return result, nil
+}
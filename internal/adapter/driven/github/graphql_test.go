@@ -138,3 +138,67 @@ func TestFetchThreadResolution_HTTPError(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, result, "HTTP 500 should return empty map")
 }
+
+func TestFetchSuggestedReviewers(t *testing.T) {
+	gqlResponse := map[string]any{
+		"data": map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"suggestedReviewers": []any{
+						map[string]any{"isAuthor": true, "isCommenter": false, "reviewer": map[string]any{"login": "alice"}},
+						map[string]any{"isAuthor": false, "isCommenter": true, "reviewer": map[string]any{"login": "bob"}},
+						map[string]any{"isAuthor": true, "isCommenter": true, "reviewer": nil},
+					},
+				},
+			},
+		},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/graphql" {
+			var req struct {
+				Variables map[string]any `json:"variables"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "owner", req.Variables["owner"])
+			assert.Equal(t, "repo", req.Variables["repo"])
+			assert.EqualValues(t, 42, req.Variables["pr"])
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(gqlResponse)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client, err := ghAdapter.NewClientWithHTTPClient(server.Client(), server.URL+"/", "testuser", "test-token")
+	require.NoError(t, err)
+
+	result, err := client.FetchSuggestedReviewers(context.Background(), "owner/repo", 42)
+	require.NoError(t, err)
+
+	require.Len(t, result, 2, "entries without a reviewer login are skipped")
+	assert.Equal(t, "alice", result[0].Login)
+	assert.True(t, result[0].IsAuthor)
+	assert.False(t, result[0].IsCommenter)
+	assert.Equal(t, "bob", result[1].Login)
+	assert.True(t, result[1].IsCommenter)
+}
+
+func TestFetchSuggestedReviewers_GraphQLErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"data":   nil,
+			"errors": []any{map[string]any{"message": "Resource not accessible by integration"}},
+		})
+	}))
+	defer server.Close()
+
+	client, err := ghAdapter.NewClientWithHTTPClient(server.Client(), server.URL+"/", "testuser", "test-token")
+	require.NoError(t, err)
+
+	_, err = client.FetchSuggestedReviewers(context.Background(), "owner/repo", 42)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Resource not accessible by integration")
+}
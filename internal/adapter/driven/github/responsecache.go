@@ -0,0 +1,130 @@
+package github
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"time"
+
+	"github.com/gregjones/httpcache"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// responseCacheTimeout bounds each cache store call, so a busy database
+// delays an API request by at most this long.
+const responseCacheTimeout = 2 * time.Second
+
+// variedAuthHeader is where httpcache records the request's Authorization
+// header, because GitHub responses vary on it.
+const variedAuthHeader = "X-Varied-Authorization"
+
+// persistentCache adapts a driven.HTTPCacheStore to httpcache.Cache, so
+// ETag and Last-Modified validators survive restarts. Entries are keyed by
+// a hash of the token as well as the URL, so accounts sharing a store do not
+// evict each other, and the token itself is never written: the recorded
+// Authorization header is replaced by its hash on Set and restored on Get.
+// Store errors are logged and treated as cache misses.
+type persistentCache struct {
+	store     driven.HTTPCacheStore
+	namespace string
+	auth      string // the Authorization header go-github sends for the token
+	authHash  string
+}
+
+// Compile-time interface satisfaction check.
+var _ httpcache.Cache = (*persistentCache)(nil)
+
+// newPersistentCache creates a cache for responses fetched with token.
+func newPersistentCache(store driven.HTTPCacheStore, token string) *persistentCache {
+	auth := "Bearer " + token
+	return &persistentCache{
+		store:     store,
+		namespace: hashHeader(token)[:16],
+		auth:      auth,
+		authHash:  hashHeader(auth),
+	}
+}
+
+// hashHeader returns the hex SHA-256 of v.
+func hashHeader(v string) string {
+	sum := sha256.Sum256([]byte(v))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *persistentCache) key(key string) string {
+	return c.namespace + " " + key
+}
+
+// Get returns the cached response for key with its Authorization header
+// restored.
+func (c *persistentCache) Get(key string) ([]byte, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), responseCacheTimeout)
+	defer cancel()
+
+	stored, ok, err := c.store.GetResponse(ctx, c.key(key))
+	if err != nil {
+		slog.Debug("failed to read cached GitHub response", "error", err)
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+
+	resp, ok := rewriteVariedAuth(stored, c.authHash, c.auth)
+	return resp, ok
+}
+
+// Set stores the response for key with its Authorization header hashed.
+func (c *persistentCache) Set(key string, resp []byte) {
+	redacted, ok := rewriteVariedAuth(resp, c.auth, c.authHash)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), responseCacheTimeout)
+	defer cancel()
+	if err := c.store.SetResponse(ctx, c.key(key), redacted); err != nil {
+		slog.Debug("failed to store GitHub response", "error", err)
+	}
+}
+
+// Delete removes the cached response for key.
+func (c *persistentCache) Delete(key string) {
+	ctx, cancel := context.WithTimeout(context.Background(), responseCacheTimeout)
+	defer cancel()
+	if err := c.store.DeleteResponse(ctx, c.key(key)); err != nil {
+		slog.Debug("failed to delete cached GitHub response", "error", err)
+	}
+}
+
+// rewriteVariedAuth replaces a recorded Authorization header of from with to in a
+// serialized response. Responses without one are returned unchanged; ok is
+// false when resp cannot be parsed or was recorded for another header.
+func rewriteVariedAuth(resp []byte, from, to string) ([]byte, bool) {
+	parsed, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(resp)), nil)
+	if err != nil {
+		return nil, false
+	}
+	defer func() { _ = parsed.Body.Close() }()
+
+	switch parsed.Header.Get(variedAuthHeader) {
+	case "":
+		return resp, true
+	case from:
+	default:
+		return nil, false
+	}
+	parsed.Header.Set(variedAuthHeader, to)
+
+	dumped, err := httputil.DumpResponse(parsed, true)
+	if err != nil {
+		return nil, false
+	}
+	return dumped, true
+}
@@ -0,0 +1,94 @@
+package github_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ghAdapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github"
+)
+
+// memoryHTTPCacheStore is an in-memory driven.HTTPCacheStore.
+type memoryHTTPCacheStore struct {
+	mu      sync.Mutex
+	entries map[string][]byte
+}
+
+func (m *memoryHTTPCacheStore) GetResponse(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	resp, ok := m.entries[key]
+	return resp, ok, nil
+}
+
+func (m *memoryHTTPCacheStore) SetResponse(_ context.Context, key string, response []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = response
+	return nil
+}
+
+func (m *memoryHTTPCacheStore) DeleteResponse(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *memoryHTTPCacheStore) PruneResponses(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestWithResponseCache_SurvivesRestart(t *testing.T) {
+	var mu sync.Mutex
+	var full, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Cache-Control", "private, max-age=0, must-revalidate")
+		w.Header().Set("Vary", "Accept, Authorization")
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"full_name": "acme/api", "default_branch": "main"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	store := &memoryHTTPCacheStore{entries: make(map[string][]byte)}
+	newClient := func(token string) *ghAdapter.Client {
+		client, err := ghAdapter.NewClientWithEndpoints(token, "testuser", server.URL+"/", "")
+		require.NoError(t, err)
+		return client.WithResponseCache(store)
+	}
+
+	_, err := newClient("secret-token").FetchRepoMetadata(context.Background(), "acme/api")
+	require.NoError(t, err)
+	require.Len(t, store.entries, 1)
+	for _, entry := range store.entries {
+		assert.False(t, bytes.Contains(entry, []byte("secret-token")), "the token is not persisted")
+	}
+
+	// A new client, as after a restart, revalidates instead of refetching.
+	got, err := newClient("secret-token").FetchRepoMetadata(context.Background(), "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, "main", got.DefaultBranch)
+	assert.Equal(t, 1, full)
+	assert.Equal(t, 1, notModified)
+
+	// Another token does not reuse the first token's response.
+	_, err = newClient("other-token").FetchRepoMetadata(context.Background(), "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, 2, full)
+}
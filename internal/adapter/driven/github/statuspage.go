@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.GitHubStatusPage = (*StatusPage)(nil)
+
+// DefaultStatusPageURL is the components endpoint of github.com's status page.
+// GitHub Enterprise Server has no public status page.
+const DefaultStatusPageURL = "https://www.githubstatus.com/api/v2/components.json"
+
+// statusPageComponent is the status page component that covers the REST and
+// GraphQL APIs the poll loop depends on.
+const statusPageComponent = "API Requests"
+
+// StatusPage implements the driven.GitHubStatusPage port against a
+// Statuspage.io components endpoint.
+type StatusPage struct {
+	httpClient *http.Client
+	url        string
+}
+
+// NewStatusPage creates a StatusPage that reads url, normally
+// DefaultStatusPageURL, with requests timing out after timeout.
+func NewStatusPage(url string, timeout time.Duration) *StatusPage {
+	return &StatusPage{httpClient: &http.Client{Timeout: timeout}, url: url}
+}
+
+// componentsResponse is the subset of the Statuspage.io components payload
+// that is read.
+type componentsResponse struct {
+	Components []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	} `json:"components"`
+}
+
+// FetchAPIStatus reports the "API Requests" component as degraded unless its
+// status is "operational". A page without that component counts as healthy.
+func (p *StatusPage) FetchAPIStatus(ctx context.Context) (model.GitHubServiceStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return model.GitHubServiceStatus{}, fmt.Errorf("build status page request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return model.GitHubServiceStatus{}, fmt.Errorf("fetch status page: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return model.GitHubServiceStatus{}, fmt.Errorf("fetch status page: unexpected status %d", resp.StatusCode)
+	}
+
+	var body componentsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return model.GitHubServiceStatus{}, fmt.Errorf("decode status page: %w", err)
+	}
+
+	for _, c := range body.Components {
+		if c.Name != statusPageComponent {
+			continue
+		}
+		if c.Status == "operational" {
+			return model.GitHubServiceStatus{}, nil
+		}
+		// "degraded_performance" reads as "Degraded performance".
+		desc := strings.ReplaceAll(c.Status, "_", " ")
+		if desc != "" {
+			desc = strings.ToUpper(desc[:1]) + desc[1:]
+		}
+		return model.GitHubServiceStatus{Degraded: true, Description: desc}, nil
+	}
+	return model.GitHubServiceStatus{}, nil
+}
@@ -0,0 +1,67 @@
+package github_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ghAdapter "github.com/ericfisherdev/mygitpanel/internal/adapter/driven/github"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestStatusPage_FetchAPIStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    model.GitHubServiceStatus
+		wantErr bool
+	}{
+		{
+			name:   "operational",
+			status: http.StatusOK,
+			body:   `{"components": [{"name": "Git Operations", "status": "major_outage"}, {"name": "API Requests", "status": "operational"}]}`,
+			want:   model.GitHubServiceStatus{},
+		},
+		{
+			name:   "degraded",
+			status: http.StatusOK,
+			body:   `{"components": [{"name": "API Requests", "status": "degraded_performance"}]}`,
+			want:   model.GitHubServiceStatus{Degraded: true, Description: "Degraded performance"},
+		},
+		{
+			name:   "component missing",
+			status: http.StatusOK,
+			body:   `{"components": []}`,
+			want:   model.GitHubServiceStatus{},
+		},
+		{
+			name:    "server error",
+			status:  http.StatusBadGateway,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			got, err := ghAdapter.NewStatusPage(server.URL, time.Second).FetchAPIStatus(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -33,6 +33,8 @@ func isValidCommentSide(s string) bool {
 func (c *Client) ValidateToken(ctx context.Context, token string) (string, error) {
 	httpClient := &http.Client{Timeout: 10 * time.Second}
 	tempClient := gh.NewClient(httpClient).WithAuthToken(token)
+	// Validate against the same host the receiver talks to (github.com or GHES).
+	tempClient.BaseURL = c.gh.BaseURL
 	user, _, err := tempClient.Users.Get(ctx, "")
 	if err != nil {
 		return "", fmt.Errorf("token validation failed: %w", err)
@@ -98,7 +100,7 @@ func (c *Client) SubmitReview(ctx context.Context, repoFullName string, prNumber
 		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == 422 {
 			return fmt.Errorf("PR was updated since you started reviewing; refresh and try again: %w", err)
 		}
-		return fmt.Errorf("submitting review for %s#%d: %w", repoFullName, prNumber, err)
+		return fmt.Errorf("submitting review for %s#%d: %w", repoFullName, prNumber, classifyWriteError(err))
 	}
 
 	return nil
@@ -114,7 +116,7 @@ func (c *Client) CreateReplyComment(ctx context.Context, repoFullName string, pr
 	// CreateCommentInReplyTo uses the correct in_reply_to JSON key (not in_reply_to_id).
 	_, _, err = c.gh.PullRequests.CreateCommentInReplyTo(ctx, owner, repo, prNumber, body, inReplyTo)
 	if err != nil {
-		return fmt.Errorf("creating reply comment on %s#%d: %w", repoFullName, prNumber, err)
+		return fmt.Errorf("creating reply comment on %s#%d: %w", repoFullName, prNumber, classifyWriteError(err))
 	}
 
 	return nil
@@ -131,12 +133,40 @@ func (c *Client) CreateIssueComment(ctx context.Context, repoFullName string, pr
 		Body: gh.Ptr(body),
 	})
 	if err != nil {
-		return fmt.Errorf("creating issue comment on %s#%d: %w", repoFullName, prNumber, err)
+		return fmt.Errorf("creating issue comment on %s#%d: %w", repoFullName, prNumber, classifyWriteError(err))
 	}
 
 	return nil
 }
 
+// classifyWriteError wraps failures worth retrying with driven.ErrTransient:
+// rate limits, 429 and 5xx responses, and errors that never reached GitHub.
+// Other 4xx responses and a canceled request are returned unchanged.
+func classifyWriteError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var rateErr *gh.RateLimitError
+	var abuseErr *gh.AbuseRateLimitError
+	if errors.As(err, &rateErr) || errors.As(err, &abuseErr) {
+		return fmt.Errorf("%w: %w", driven.ErrTransient, err)
+	}
+
+	var respErr *gh.ErrorResponse
+	if errors.As(err, &respErr) {
+		if respErr.Response != nil {
+			code := respErr.Response.StatusCode
+			if code == http.StatusTooManyRequests || code >= http.StatusInternalServerError {
+				return fmt.Errorf("%w (HTTP %d): %w", driven.ErrTransient, code, err)
+			}
+		}
+		return err
+	}
+
+	return fmt.Errorf("%w: %w", driven.ErrTransient, err)
+}
+
 // fetchPRNodeID retrieves the GraphQL node ID for a pull request via REST.
 func (c *Client) fetchPRNodeID(ctx context.Context, owner, repo string, prNumber int) (string, error) {
 	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, prNumber)
@@ -177,3 +207,108 @@ func (c *Client) MarkPullRequestReadyForReview(ctx context.Context, repoFullName
 	}
 	return c.executeDraftMutation(ctx, markReadyMutation, nodeID)
 }
+
+// ResolveReviewThread resolves a review thread using the GitHub GraphQL API.
+// The thread's node ID is looked up by its root comment first.
+func (c *Client) ResolveReviewThread(ctx context.Context, repoFullName string, prNumber int, rootCommentID int64) error {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return err
+	}
+
+	var threads reviewThreadIDsData
+	err = c.executeGraphQL(ctx, "review thread IDs", graphqlRequest{
+		Query:     reviewThreadIDsQuery,
+		Variables: map[string]any{"owner": owner, "repo": repo, "pr": prNumber},
+	}, &threads)
+	if err != nil {
+		return fmt.Errorf("resolving review thread on %s#%d: %w", repoFullName, prNumber, err)
+	}
+
+	threadID := ""
+	for _, node := range threads.Repository.PullRequest.ReviewThreads.Nodes {
+		if len(node.Comments.Nodes) > 0 && node.Comments.Nodes[0].DatabaseID == rootCommentID {
+			threadID = node.ID
+			break
+		}
+	}
+	if threadID == "" {
+		return fmt.Errorf("resolving review thread on %s#%d: no thread starts with comment %d", repoFullName, prNumber, rootCommentID)
+	}
+
+	err = c.executeGraphQL(ctx, "resolve review thread", graphqlRequest{
+		Query:     resolveReviewThreadMutation,
+		Variables: map[string]any{"threadId": threadID},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("resolving review thread on %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return nil
+}
+
+// RequestReviewers asks the given users to review a pull request.
+func (c *Client) RequestReviewers(ctx context.Context, repoFullName string, prNumber int, logins []string) error {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.gh.PullRequests.RequestReviewers(ctx, owner, repo, prNumber, gh.ReviewersRequest{
+		Reviewers: logins,
+	})
+	if err != nil {
+		return fmt.Errorf("requesting reviewers on %s#%d: %w", repoFullName, prNumber, err)
+	}
+
+	return nil
+}
+
+// AddLabels adds labels to a pull request through the issues API.
+func (c *Client) AddLabels(ctx context.Context, repoFullName string, prNumber int, labels []string) error {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.gh.Issues.AddLabelsToIssue(ctx, owner, repo, prNumber, labels)
+	if err != nil {
+		return fmt.Errorf("adding labels to %s#%d: %w", repoFullName, prNumber, classifyWriteError(err))
+	}
+
+	return nil
+}
+
+// RemoveLabel removes a label from a pull request through the issues API.
+func (c *Client) RemoveLabel(ctx context.Context, repoFullName string, prNumber int, label string) error {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.gh.Issues.RemoveLabelForIssue(ctx, owner, repo, prNumber, label)
+	if err != nil {
+		return fmt.Errorf("removing label %q from %s#%d: %w", label, repoFullName, prNumber, classifyWriteError(err))
+	}
+
+	return nil
+}
+
+// SetMilestone assigns the milestone with the given number to a pull
+// request, or clears its milestone when number is 0.
+func (c *Client) SetMilestone(ctx context.Context, repoFullName string, prNumber, number int) error {
+	owner, repo, err := splitRepo(repoFullName)
+	if err != nil {
+		return err
+	}
+
+	if number == 0 {
+		_, _, err = c.gh.Issues.RemoveMilestone(ctx, owner, repo, prNumber)
+	} else {
+		_, _, err = c.gh.Issues.Edit(ctx, owner, repo, prNumber, &gh.IssueRequest{Milestone: gh.Ptr(number)})
+	}
+	if err != nil {
+		return fmt.Errorf("setting milestone on %s#%d: %w", repoFullName, prNumber, classifyWriteError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package hook
+
+import (
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// invocationPayload is the JSON a hook receives: on stdin for shell hooks,
+// and as the event, pr, review, check, and comment globals for Starlark.
+type invocationPayload struct {
+	Event      string          `json:"event"`
+	OccurredAt string          `json:"occurred_at"`
+	PR         pullRequestBody `json:"pr"`
+	Review     *reviewBody     `json:"review"`
+	Check      *checkBody      `json:"check"`
+	Comment    *commentBody    `json:"comment"`
+}
+
+// pullRequestBody is the PR as hooks see it.
+type pullRequestBody struct {
+	Repository   string   `json:"repository"`
+	Number       int      `json:"number"`
+	Title        string   `json:"title"`
+	Author       string   `json:"author"`
+	URL          string   `json:"url"`
+	Branch       string   `json:"branch"`
+	BaseBranch   string   `json:"base_branch"`
+	Status       string   `json:"status"`
+	IsDraft      bool     `json:"is_draft"`
+	CIStatus     string   `json:"ci_status"`
+	Mergeable    string   `json:"mergeable"`
+	Labels       []string `json:"labels"`
+	Additions    int      `json:"additions"`
+	Deletions    int      `json:"deletions"`
+	ChangedFiles int      `json:"changed_files"`
+	JiraKey      string   `json:"jira_key"`
+	OpenedAt     string   `json:"opened_at"`
+}
+
+// reviewBody is the review that triggered an approved or changes_requested hook.
+type reviewBody struct {
+	ID          int64  `json:"id"`
+	Reviewer    string `json:"reviewer"`
+	State       string `json:"state"`
+	Body        string `json:"body"`
+	SubmittedAt string `json:"submitted_at"`
+}
+
+// checkBody is the check run that triggered a ci_failed hook.
+type checkBody struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Conclusion string `json:"conclusion"`
+	Required   bool   `json:"required"`
+	DetailsURL string `json:"details_url"`
+}
+
+// commentBody is the comment that triggered a commented hook.
+type commentBody struct {
+	ID        int64  `json:"id"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+}
+
+// toPayload converts an invocation to its JSON representation.
+func toPayload(inv model.HookInvocation) invocationPayload {
+	pr := inv.PullRequest
+	labels := pr.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	p := invocationPayload{
+		Event:      string(inv.Event),
+		OccurredAt: formatTime(inv.OccurredAt),
+		PR: pullRequestBody{
+			Repository:   pr.RepoFullName,
+			Number:       pr.Number,
+			Title:        pr.Title,
+			Author:       pr.Author,
+			URL:          pr.URL,
+			Branch:       pr.Branch,
+			BaseBranch:   pr.BaseBranch,
+			Status:       string(pr.Status),
+			IsDraft:      pr.IsDraft,
+			CIStatus:     string(pr.CIStatus),
+			Mergeable:    string(pr.MergeableStatus),
+			Labels:       labels,
+			Additions:    pr.Additions,
+			Deletions:    pr.Deletions,
+			ChangedFiles: pr.ChangedFiles,
+			JiraKey:      pr.JiraKey,
+			OpenedAt:     formatTime(pr.OpenedAt),
+		},
+	}
+	if r := inv.Review; r != nil {
+		p.Review = &reviewBody{ID: r.ID, Reviewer: r.ReviewerLogin, State: string(r.State), Body: r.Body, SubmittedAt: formatTime(r.SubmittedAt)}
+	}
+	if c := inv.CheckRun; c != nil {
+		p.Check = &checkBody{ID: c.ID, Name: c.Name, Conclusion: c.Conclusion, Required: c.IsRequired, DetailsURL: c.DetailsURL}
+	}
+	if c := inv.Comment; c != nil {
+		p.Comment = &commentBody{ID: c.ID, Author: c.Author, Body: c.Body, CreatedAt: formatTime(c.CreatedAt)}
+	}
+	return p
+}
+
+// formatTime renders t as RFC 3339, or "" when it is zero.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
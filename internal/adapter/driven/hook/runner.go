@@ -0,0 +1,274 @@
+// Package hook implements the HookRunner port: Starlark scripts run in an
+// in-process sandbox, and allowlisted programs run as child processes.
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.HookRunner = (*Runner)(nil)
+
+const (
+	// runTimeout bounds a single hook run.
+	runTimeout = 10 * time.Second
+
+	// maxSteps bounds the Starlark computation of a single run, so a script
+	// stuck in a loop fails quickly rather than at the timeout.
+	maxSteps = 10_000_000
+
+	// maxOutput bounds the output kept from a run.
+	maxOutput = 4 << 10
+
+	// maxActions bounds the comments a single run may post.
+	maxActions = 5
+
+	// maxCommentLen bounds a comment posted by a script.
+	maxCommentLen = 65536
+)
+
+// fileOptions lets scripts use top-level if and for statements, which the
+// Starlark default reserves for functions.
+var fileOptions = &syntax.FileOptions{Set: true, TopLevelControl: true, GlobalReassign: true}
+
+// globals are the names predeclared for Starlark hooks.
+var globals = []string{"event", "pr", "review", "check", "comment", "post_comment"}
+
+// Runner implements the driven.HookRunner port.
+type Runner struct {
+	commands []string
+}
+
+// NewRunner creates a Runner. Shell hooks may only run the programs in
+// commands, matched exactly against the first word of the hook's script;
+// with none, only Starlark hooks run.
+func NewRunner(commands []string) *Runner {
+	return &Runner{commands: commands}
+}
+
+// Check reports whether hook could run.
+func (r *Runner) Check(hook model.ScriptHook) error {
+	switch hook.Runtime {
+	case model.HookRuntimeStarlark:
+		_, _, err := r.compile(hook)
+		return err
+	case model.HookRuntimeShell:
+		_, err := r.command(hook)
+		return err
+	default:
+		return fmt.Errorf("unknown runtime %q", hook.Runtime)
+	}
+}
+
+// Run executes hook for inv, stopping it after runTimeout.
+func (r *Runner) Run(ctx context.Context, hook model.ScriptHook, inv model.HookInvocation) (model.HookResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+
+	switch hook.Runtime {
+	case model.HookRuntimeStarlark:
+		return r.runStarlark(ctx, hook, inv)
+	case model.HookRuntimeShell:
+		return r.runShell(ctx, hook, inv)
+	default:
+		return model.HookResult{}, fmt.Errorf("unknown runtime %q", hook.Runtime)
+	}
+}
+
+// compile parses and resolves a Starlark hook.
+func (r *Runner) compile(hook model.ScriptHook) (*syntax.File, *starlark.Program, error) {
+	return starlark.SourceProgramOptions(fileOptions, hook.Name, hook.Script, func(name string) bool {
+		return slices.Contains(globals, name)
+	})
+}
+
+// runStarlark executes a Starlark hook. Scripts have no load statement and no
+// file system or network access; print output is kept, and post_comment
+// records an action the caller performs.
+func (r *Runner) runStarlark(ctx context.Context, hook model.ScriptHook, inv model.HookInvocation) (model.HookResult, error) {
+	_, prog, err := r.compile(hook)
+	if err != nil {
+		return model.HookResult{}, err
+	}
+
+	var result model.HookResult
+	out := &limitedBuffer{limit: maxOutput}
+
+	predeclared, err := toStarlarkGlobals(toPayload(inv))
+	if err != nil {
+		return model.HookResult{}, err
+	}
+	predeclared["post_comment"] = starlark.NewBuiltin("post_comment", func(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var body string
+		if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "body", &body); err != nil {
+			return nil, err
+		}
+		body = strings.TrimSpace(body)
+		switch {
+		case body == "":
+			return nil, errors.New("post_comment: body is empty")
+		case len(body) > maxCommentLen:
+			return nil, fmt.Errorf("post_comment: body is longer than %d bytes", maxCommentLen)
+		case len(result.Actions) >= maxActions:
+			return nil, fmt.Errorf("post_comment: at most %d comments per run", maxActions)
+		}
+		result.Actions = append(result.Actions, model.HookAction{Kind: model.HookActionComment, Body: body})
+		return starlark.None, nil
+	})
+
+	thread := &starlark.Thread{
+		Name:  hook.Name,
+		Print: func(_ *starlark.Thread, msg string) { _, _ = out.WriteString(msg + "\n") },
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+	stop := context.AfterFunc(ctx, func() { thread.Cancel(ctx.Err().Error()) })
+	defer stop()
+
+	_, err = prog.Init(thread, predeclared)
+	result.Output = out.String()
+	if err != nil {
+		var evalErr *starlark.EvalError
+		if errors.As(err, &evalErr) {
+			return result, errors.New(evalErr.Backtrace())
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// command returns the program and arguments of a shell hook, or an error
+// when the program is not allowlisted.
+func (r *Runner) command(hook model.ScriptHook) ([]string, error) {
+	fields := strings.Fields(hook.Script)
+	if len(fields) == 0 {
+		return nil, errors.New("command is empty")
+	}
+	if !slices.Contains(r.commands, fields[0]) {
+		return nil, fmt.Errorf("command %q is not in MYGITPANEL_HOOK_COMMANDS", fields[0])
+	}
+	return fields, nil
+}
+
+// runShell runs a shell hook's program directly, without a shell, with the
+// invocation as JSON on stdin. The environment is limited to PATH, HOME, and
+// the MYGITPANEL_HOOK_* variables so the app's secrets do not leak.
+func (r *Runner) runShell(ctx context.Context, hook model.ScriptHook, inv model.HookInvocation) (model.HookResult, error) {
+	args, err := r.command(hook)
+	if err != nil {
+		return model.HookResult{}, err
+	}
+	payload, err := json.Marshal(toPayload(inv))
+	if err != nil {
+		return model.HookResult{}, fmt.Errorf("encoding hook payload: %w", err)
+	}
+
+	out := &limitedBuffer{limit: maxOutput}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // the program is allowlisted by the operator
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout, cmd.Stderr = out, out
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + os.Getenv("HOME"),
+		"MYGITPANEL_HOOK_EVENT=" + string(inv.Event),
+		"MYGITPANEL_HOOK_REPO=" + inv.PullRequest.RepoFullName,
+		"MYGITPANEL_HOOK_PR=" + strconv.Itoa(inv.PullRequest.Number),
+	}
+	err = cmd.Run()
+	return model.HookResult{Output: out.String()}, err
+}
+
+// toStarlarkGlobals converts a payload to the Starlark globals of the same
+// names, via its JSON form so both runtimes see the same fields.
+func toStarlarkGlobals(p invocationPayload) (starlark.StringDict, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("encoding hook payload: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("decoding hook payload: %w", err)
+	}
+	globals := starlark.StringDict{}
+	for _, name := range []string{"event", "pr", "review", "check", "comment"} {
+		globals[name] = toStarlark(fields[name])
+	}
+	return globals, nil
+}
+
+// toStarlark converts a decoded JSON value to Starlark. Whole numbers become
+// ints; objects become frozen dicts so a script cannot alter what the next
+// hook sees.
+func toStarlark(v any) starlark.Value {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None
+	case bool:
+		return starlark.Bool(v)
+	case string:
+		return starlark.String(v)
+	case float64:
+		if v == float64(int64(v)) {
+			return starlark.MakeInt64(int64(v))
+		}
+		return starlark.Float(v)
+	case []any:
+		elems := make([]starlark.Value, 0, len(v))
+		for _, e := range v {
+			elems = append(elems, toStarlark(e))
+		}
+		list := starlark.NewList(elems)
+		list.Freeze()
+		return list
+	case map[string]any:
+		dict := starlark.NewDict(len(v))
+		for k, e := range v {
+			_ = dict.SetKey(starlark.String(k), toStarlark(e))
+		}
+		dict.Freeze()
+		return dict
+	default:
+		return starlark.None
+	}
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the
+// rest, so a chatty hook cannot exhaust memory.
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+// Write implements io.Writer. It never fails, so a process is not killed by
+// a broken pipe when it writes past the limit.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room > 0 {
+		b.buf.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// WriteString implements io.StringWriter.
+func (b *limitedBuffer) WriteString(s string) (int, error) {
+	return b.Write([]byte(s))
+}
+
+// String returns what was kept.
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,102 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func testInvocation() model.HookInvocation {
+	return model.HookInvocation{
+		Event: model.HookEventApproved,
+		PullRequest: model.PullRequest{
+			RepoFullName: "acme/api", Number: 7, Title: "Add caching", Author: "bob",
+			Status: model.PRStatusOpen, Labels: []string{"backend"}, Additions: 120,
+		},
+		Review:     &model.Review{ID: 1, ReviewerLogin: "carol", State: model.ReviewStateApproved},
+		OccurredAt: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRunner_Starlark(t *testing.T) {
+	runner := NewRunner(nil)
+	hook := model.ScriptHook{Name: "thanks", Runtime: model.HookRuntimeStarlark, Script: `
+print(event, pr["number"], check)
+if "backend" in pr["labels"] and pr["additions"] > 100:
+    post_comment("Thanks @%s for reviewing %s" % (review["reviewer"], pr["repository"]))
+`}
+	require.NoError(t, runner.Check(hook))
+
+	result, err := runner.Run(context.Background(), hook, testInvocation())
+	require.NoError(t, err)
+	assert.Equal(t, "approved 7 None\n", result.Output)
+	assert.Equal(t, []model.HookAction{{Kind: model.HookActionComment, Body: "Thanks @carol for reviewing acme/api"}}, result.Actions)
+}
+
+func TestRunner_StarlarkErrors(t *testing.T) {
+	runner := NewRunner(nil)
+	ctx := context.Background()
+
+	assert.Error(t, runner.Check(model.ScriptHook{Runtime: model.HookRuntimeStarlark, Script: "post_comment("}), "syntax error")
+	assert.ErrorContains(t, runner.Check(model.ScriptHook{Runtime: model.HookRuntimeStarlark, Script: "open('/etc/passwd')"}),
+		"undefined: open", "no file access")
+
+	_, err := runner.Run(ctx, model.ScriptHook{Runtime: model.HookRuntimeStarlark, Script: `load("x.star", "y")`}, testInvocation())
+	assert.ErrorContains(t, err, "load not implemented")
+
+	_, err = runner.Run(ctx, model.ScriptHook{Runtime: model.HookRuntimeStarlark, Script: `pr["title"] = "x"`}, testInvocation())
+	assert.ErrorContains(t, err, "frozen", "scripts cannot alter the payload")
+
+	start := time.Now()
+	_, err = runner.Run(ctx, model.ScriptHook{Runtime: model.HookRuntimeStarlark, Script: "for i in range(1000000000):\n    pass"}, testInvocation())
+	assert.ErrorContains(t, err, "too many steps")
+	assert.Less(t, time.Since(start), runTimeout)
+
+	result, err := runner.Run(ctx, model.ScriptHook{Runtime: model.HookRuntimeStarlark, Script: `
+for i in range(10):
+    post_comment("spam")
+`}, testInvocation())
+	assert.ErrorContains(t, err, "at most 5 comments")
+	assert.Len(t, result.Actions, maxActions, "actions before the failure are returned")
+}
+
+func TestRunner_Shell(t *testing.T) {
+	cat, err := exec.LookPath("cat")
+	if err != nil {
+		t.Skip("cat not available")
+	}
+	runner := NewRunner([]string{cat})
+
+	assert.ErrorContains(t, runner.Check(model.ScriptHook{Runtime: model.HookRuntimeShell, Script: "rm -rf /"}), "not in MYGITPANEL_HOOK_COMMANDS")
+	assert.ErrorContains(t, runner.Check(model.ScriptHook{Runtime: model.HookRuntimeShell, Script: "  "}), "empty")
+	assert.Error(t, runner.Check(model.ScriptHook{Runtime: "lua"}))
+
+	hook := model.ScriptHook{Runtime: model.HookRuntimeShell, Script: cat}
+	require.NoError(t, runner.Check(hook))
+	result, err := runner.Run(context.Background(), hook, testInvocation())
+	require.NoError(t, err)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(result.Output), &payload), "the invocation arrives on stdin")
+	assert.Equal(t, "approved", payload["event"])
+	assert.Equal(t, "acme/api", payload["pr"].(map[string]any)["repository"])
+	assert.Equal(t, "carol", payload["review"].(map[string]any)["reviewer"])
+	assert.Nil(t, payload["check"])
+	assert.Empty(t, result.Actions)
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{limit: 4}
+	n, err := b.Write([]byte("abcdef"))
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+	_, _ = b.WriteString("gh")
+	assert.Equal(t, "abcd", b.String())
+}
@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.APITokenStore = (*APITokenRepo)(nil)
+
+// APITokenRepo is the SQLite implementation of the APITokenStore port interface.
+type APITokenRepo struct {
+	db *DB
+}
+
+// NewAPITokenRepo creates a new APITokenRepo backed by the given DB.
+func NewAPITokenRepo(db *DB) *APITokenRepo {
+	return &APITokenRepo{db: db}
+}
+
+// Create persists a token with the hash of its secret and returns the assigned ID.
+func (r *APITokenRepo) Create(ctx context.Context, token model.APIToken, hash string) (int64, error) {
+	const query = `INSERT INTO api_tokens (name, token_hash, prefix, scope, created_at) VALUES (?, ?, ?, ?, ?)`
+
+	createdAt := token.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query, token.Name, hash, token.Prefix, string(token.Scope), createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create api token %q: %w", token.Name, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create api token %q: last insert id: %w", token.Name, err)
+	}
+	return id, nil
+}
+
+// List returns all tokens, newest first.
+func (r *APITokenRepo) List(ctx context.Context) ([]model.APIToken, error) {
+	const query = `SELECT id, name, prefix, scope, created_at, last_used_at
+		FROM api_tokens ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list api tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []model.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan api token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate api tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// Delete revokes a token by ID.
+func (r *APITokenRepo) Delete(ctx context.Context, id int64) error {
+	const query = `DELETE FROM api_tokens WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete api token %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetByHash returns the token whose secret hashes to hash, or nil if none does.
+func (r *APITokenRepo) GetByHash(ctx context.Context, hash string) (*model.APIToken, error) {
+	const query = `SELECT id, name, prefix, scope, created_at, last_used_at
+		FROM api_tokens WHERE token_hash = ?`
+
+	token, err := scanAPIToken(r.db.Reader.QueryRowContext(ctx, query, hash))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get api token by hash: %w", err)
+	}
+	return &token, nil
+}
+
+// Count returns the number of tokens.
+func (r *APITokenRepo) Count(ctx context.Context) (int, error) {
+	var n int
+	if err := r.db.Reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM api_tokens`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count api tokens: %w", err)
+	}
+	return n, nil
+}
+
+// TouchLastUsed records that a token authenticated a request at the given time.
+func (r *APITokenRepo) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	const query = `UPDATE api_tokens SET last_used_at = ? WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, at.UTC(), id); err != nil {
+		return fmt.Errorf("touch api token %d: %w", id, err)
+	}
+	return nil
+}
+
+// scanAPIToken scans a single api_tokens row from the given scanner.
+func scanAPIToken(s scanner) (model.APIToken, error) {
+	var token model.APIToken
+	var scope, createdAt string
+	var lastUsedAt sql.NullString
+
+	if err := s.Scan(&token.ID, &token.Name, &token.Prefix, &scope, &createdAt, &lastUsedAt); err != nil {
+		return model.APIToken{}, err
+	}
+	token.Scope = model.APITokenScope(scope)
+
+	var err error
+	if token.CreatedAt, err = parseTime(createdAt); err != nil {
+		return model.APIToken{}, fmt.Errorf("parse created_at for api token %d: %w", token.ID, err)
+	}
+	if lastUsedAt.Valid {
+		if token.LastUsedAt, err = parseTime(lastUsedAt.String); err != nil {
+			return model.APIToken{}, fmt.Errorf("parse last_used_at for api token %d: %w", token.ID, err)
+		}
+	}
+	return token, nil
+}
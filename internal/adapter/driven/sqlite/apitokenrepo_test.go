@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITokenRepo_CreateAndLookup(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAPITokenRepo(db)
+	ctx := context.Background()
+
+	n, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	id, err := repo.Create(ctx, model.APIToken{Name: "ci", Prefix: "mgp_abcdef", Scope: model.APITokenScopeRead}, "hash-1")
+	require.NoError(t, err)
+
+	got, err := repo.GetByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, id, got.ID)
+	assert.Equal(t, "ci", got.Name)
+	assert.Equal(t, "mgp_abcdef", got.Prefix)
+	assert.Equal(t, model.APITokenScopeRead, got.Scope)
+	assert.True(t, got.LastUsedAt.IsZero())
+
+	missing, err := repo.GetByHash(ctx, "hash-2")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	usedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.TouchLastUsed(ctx, id, usedAt))
+	got, err = repo.GetByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.True(t, usedAt.Equal(got.LastUsedAt))
+}
+
+func TestAPITokenRepo_ListAndDelete(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAPITokenRepo(db)
+	ctx := context.Background()
+
+	older := time.Now().Add(-time.Hour)
+	_, err := repo.Create(ctx, model.APIToken{Name: "old", Prefix: "mgp_111111", Scope: model.APITokenScopeRead, CreatedAt: older}, "hash-old")
+	require.NoError(t, err)
+	newID, err := repo.Create(ctx, model.APIToken{Name: "new", Prefix: "mgp_222222", Scope: model.APITokenScopeWrite}, "hash-new")
+	require.NoError(t, err)
+
+	tokens, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, tokens, 2)
+	assert.Equal(t, "new", tokens[0].Name, "newest token should be listed first")
+
+	require.NoError(t, repo.Delete(ctx, newID))
+	n, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
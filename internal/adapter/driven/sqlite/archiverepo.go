@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.ArchiveStore = (*ArchiveRepo)(nil)
+
+// ArchiveRepo is the SQLite implementation of the ArchiveStore port interface.
+// Archived PRs are the merged and closed rows of pull_requests, ordered and
+// filtered by closed_at.
+type ArchiveRepo struct {
+	db *DB
+}
+
+// NewArchiveRepo creates a new ArchiveRepo backed by the given DB.
+func NewArchiveRepo(db *DB) *ArchiveRepo {
+	return &ArchiveRepo{db: db}
+}
+
+// ListArchived returns one page of merged and closed PRs, most recently closed first.
+func (r *ArchiveRepo) ListArchived(ctx context.Context, filter model.ArchiveFilter, limit, offset int) ([]model.PullRequest, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	where, args := archiveWhere(filter)
+	query := `
+		SELECT id, number, repo_full_name, title, author, status, is_draft, needs_review,
+		       url, branch, base_branch, labels, head_sha,
+		       additions, deletions, changed_files, mergeable_status, ci_status,
+		       opened_at, updated_at, last_activity_at, jira_key, closed_at, author_association, milestone, checklist_done, checklist_total, review_requests,
+		       review_status, approval_count, unresolved_thread_count
+		FROM pull_requests
+		WHERE ` + where + `
+		ORDER BY closed_at DESC, id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("list archived PRs: %w", err)
+	}
+	defer rows.Close()
+
+	var prs []model.PullRequest
+	for rows.Next() {
+		pr, err := scanPR(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan archived PR: %w", err)
+		}
+		prs = append(prs, *pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate archived PRs: %w", err)
+	}
+	return prs, nil
+}
+
+// MergeHistory counts merged and closed PRs per repository and computes the
+// median time to merge. Durations are computed in Go because timestamps are
+// stored in a format SQLite's date functions do not parse.
+func (r *ArchiveRepo) MergeHistory(ctx context.Context, filter model.ArchiveFilter) ([]model.RepoMergeHistory, error) {
+	where, args := archiveWhere(filter)
+	query := `
+		SELECT repo_full_name, status, opened_at, closed_at
+		FROM pull_requests
+		WHERE ` + where + `
+		ORDER BY repo_full_name`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query merge history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []model.RepoMergeHistory
+	var durations []time.Duration
+	flush := func() {
+		if n := len(history); n > 0 {
+			history[n-1].MedianTimeToMerge = medianDuration(durations)
+		}
+		durations = durations[:0]
+	}
+	for rows.Next() {
+		var repo, status, openedAt, closedAt string
+		if err := rows.Scan(&repo, &status, &openedAt, &closedAt); err != nil {
+			return nil, fmt.Errorf("scan merge history: %w", err)
+		}
+		if n := len(history); n == 0 || history[n-1].RepoFullName != repo {
+			flush()
+			history = append(history, model.RepoMergeHistory{RepoFullName: repo})
+		}
+		last := &history[len(history)-1]
+		if model.PRStatus(status) != model.PRStatusMerged {
+			last.Closed++
+			continue
+		}
+		last.Merged++
+
+		opened, err := parseTime(openedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse opened_at in %s: %w", repo, err)
+		}
+		closed, err := parseTime(closedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse closed_at in %s: %w", repo, err)
+		}
+		durations = append(durations, closed.Sub(opened))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate merge history: %w", err)
+	}
+	flush()
+	return history, nil
+}
+
+// PruneClosedBefore deletes archived PRs closed before cutoff. Reviews,
+// comments, and checks go with them via ON DELETE CASCADE; notes and
+// decisions are keyed by PR number and are kept.
+func (r *ArchiveRepo) PruneClosedBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	res, err := r.db.Writer.ExecContext(ctx,
+		`DELETE FROM pull_requests WHERE status != 'open' AND closed_at < ?`, cutoff.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("prune archived PRs: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("check rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// archiveWhere builds the WHERE clause and arguments selecting archived PRs
+// that match filter.
+func archiveWhere(filter model.ArchiveFilter) (string, []any) {
+	clauses := []string{"status != 'open'", "closed_at IS NOT NULL", "repo_full_name IN " + watchedRepos}
+	var args []any
+	if filter.RepoFullName != "" {
+		clauses = append(clauses, "repo_full_name = ?")
+		args = append(args, filter.RepoFullName)
+	}
+	if filter.Status != "" {
+		clauses = append(clauses, "status = ?")
+		args = append(args, string(filter.Status))
+	}
+	if !filter.ClosedAfter.IsZero() {
+		clauses = append(clauses, "closed_at >= ?")
+		args = append(args, filter.ClosedAfter.UTC())
+	}
+	if !filter.ClosedBefore.IsZero() {
+		clauses = append(clauses, "closed_at < ?")
+		args = append(args, filter.ClosedBefore.UTC())
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// medianDuration returns the median of d, or zero when d is empty. It sorts d
+// in place.
+func medianDuration(d []time.Duration) time.Duration {
+	if len(d) == 0 {
+		return 0
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	mid := len(d) / 2
+	if len(d)%2 == 1 {
+		return d[mid]
+	}
+	return (d[mid-1] + d[mid]) / 2
+}
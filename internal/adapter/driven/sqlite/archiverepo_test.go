@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestArchiveRepo(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	addTestRepo(t, db, "org/api")
+	addTestRepo(t, db, "org/web")
+
+	prRepo := NewPRRepo(db)
+	opened := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	add := func(repo string, number int, status model.PRStatus, openFor time.Duration) {
+		pr := makePR(repo, number, "PR", status)
+		pr.OpenedAt = opened
+		if status != model.PRStatusOpen {
+			pr.ClosedAt = opened.Add(openFor)
+		}
+		require.NoError(t, prRepo.Upsert(ctx, pr))
+	}
+	add("org/api", 1, model.PRStatusMerged, 2*time.Hour)
+	add("org/api", 2, model.PRStatusMerged, 6*time.Hour)
+	add("org/api", 3, model.PRStatusClosed, 48*time.Hour)
+	add("org/api", 4, model.PRStatusOpen, 0)
+	add("org/web", 5, model.PRStatusMerged, 24*time.Hour)
+
+	repo := NewArchiveRepo(db)
+
+	all, err := repo.ListArchived(ctx, model.ArchiveFilter{}, 10, 0)
+	require.NoError(t, err)
+	numbers := make([]int, 0, len(all))
+	for _, pr := range all {
+		numbers = append(numbers, pr.Number)
+	}
+	assert.Equal(t, []int{3, 5, 2, 1}, numbers, "most recently closed first; open PRs excluded")
+	assert.True(t, all[0].ClosedAt.Equal(opened.Add(48*time.Hour)))
+
+	page, err := repo.ListArchived(ctx, model.ArchiveFilter{}, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, 2, page[0].Number)
+
+	filtered, err := repo.ListArchived(ctx, model.ArchiveFilter{
+		RepoFullName: "org/api",
+		Status:       model.PRStatusMerged,
+		ClosedAfter:  opened.Add(3 * time.Hour),
+		ClosedBefore: opened.Add(7 * time.Hour),
+	}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, 2, filtered[0].Number)
+
+	history, err := repo.MergeHistory(ctx, model.ArchiveFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, []model.RepoMergeHistory{
+		{RepoFullName: "org/api", Merged: 2, Closed: 1, MedianTimeToMerge: 4 * time.Hour},
+		{RepoFullName: "org/web", Merged: 1, MedianTimeToMerge: 24 * time.Hour},
+	}, history)
+
+	pruned, err := repo.PruneClosedBefore(ctx, opened.Add(12*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, pruned)
+
+	remaining, err := prRepo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 3, "open PR and PRs closed after the cutoff survive")
+}
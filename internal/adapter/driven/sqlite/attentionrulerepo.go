@@ -0,0 +1,108 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.AttentionRuleStore = (*AttentionRuleRepo)(nil)
+
+// AttentionRuleRepo is the SQLite implementation of the AttentionRuleStore port interface.
+type AttentionRuleRepo struct {
+	db *DB
+}
+
+// NewAttentionRuleRepo creates a new AttentionRuleRepo backed by the given DB.
+func NewAttentionRuleRepo(db *DB) *AttentionRuleRepo {
+	return &AttentionRuleRepo{db: db}
+}
+
+// Create persists a rule and returns the assigned ID.
+func (r *AttentionRuleRepo) Create(ctx context.Context, rule model.AttentionRule) (int64, error) {
+	const query = `INSERT INTO attention_rules (name, expression, severity, notify_url, enabled, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	createdAt := rule.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query,
+		rule.Name, rule.Expression, rule.Severity, rule.NotifyURL, boolToInt(rule.Enabled), createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create attention rule %q: %w", rule.Name, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create attention rule %q: last insert id: %w", rule.Name, err)
+	}
+	return id, nil
+}
+
+// Update replaces a rule's editable fields.
+func (r *AttentionRuleRepo) Update(ctx context.Context, rule model.AttentionRule) error {
+	const query = `UPDATE attention_rules
+		SET name = ?, expression = ?, severity = ?, notify_url = ?, enabled = ?
+		WHERE id = ?`
+
+	res, err := r.db.Writer.ExecContext(ctx, query,
+		rule.Name, rule.Expression, rule.Severity, rule.NotifyURL, boolToInt(rule.Enabled), rule.ID)
+	if err != nil {
+		return fmt.Errorf("update attention rule %d: %w", rule.ID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("update attention rule %d: %w", rule.ID, driven.ErrAttentionRuleNotFound)
+	}
+	return nil
+}
+
+// Delete removes a rule by ID.
+func (r *AttentionRuleRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.Writer.ExecContext(ctx, `DELETE FROM attention_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete attention rule %d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("delete attention rule %d: %w", id, driven.ErrAttentionRuleNotFound)
+	}
+	return nil
+}
+
+// List returns all rules ordered by creation.
+func (r *AttentionRuleRepo) List(ctx context.Context) ([]model.AttentionRule, error) {
+	const query = `SELECT id, name, expression, severity, notify_url, enabled, created_at
+		FROM attention_rules ORDER BY created_at, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list attention rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []model.AttentionRule
+	for rows.Next() {
+		var rule model.AttentionRule
+		var createdAt string
+		if err := rows.Scan(&rule.ID, &rule.Name, &rule.Expression, &rule.Severity, &rule.NotifyURL, &rule.Enabled, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan attention rule: %w", err)
+		}
+		if rule.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for attention rule %d: %w", rule.ID, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate attention rules: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttentionRuleRepo_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewAttentionRuleRepo(db)
+	ctx := context.Background()
+
+	rules, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	id, err := repo.Create(ctx, model.AttentionRule{
+		Name:       "My red builds",
+		Expression: `ci_status == "failing" && author == me`,
+		Severity:   5,
+		NotifyURL:  "https://hooks.slack.com/services/T/B/X",
+		Enabled:    true,
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.AttentionRule{Name: "Big", Expression: "additions > 1000", Severity: 1})
+	require.NoError(t, err)
+
+	rules, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, id, rules[0].ID)
+	assert.Equal(t, `ci_status == "failing" && author == me`, rules[0].Expression)
+	assert.Equal(t, 5, rules[0].Severity)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", rules[0].NotifyURL)
+	assert.True(t, rules[0].Enabled)
+	assert.False(t, rules[0].CreatedAt.IsZero())
+	assert.False(t, rules[1].Enabled)
+
+	updated := rules[0]
+	updated.Enabled = false
+	updated.Severity = 2
+	require.NoError(t, repo.Update(ctx, updated))
+	rules, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.False(t, rules[0].Enabled)
+	assert.Equal(t, 2, rules[0].Severity)
+
+	assert.ErrorIs(t, repo.Update(ctx, model.AttentionRule{ID: 999}), driven.ErrAttentionRuleNotFound)
+	require.NoError(t, repo.Delete(ctx, id))
+	assert.ErrorIs(t, repo.Delete(ctx, id), driven.ErrAttentionRuleNotFound)
+	rules, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+}
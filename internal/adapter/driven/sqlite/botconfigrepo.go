@@ -3,6 +3,7 @@ package sqlite
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -29,14 +30,19 @@ func NewBotConfigRepo(db *DB) *BotConfigRepo {
 // Add inserts a new bot configuration entry. Returns an error if the username
 // already exists (unique constraint violation).
 func (r *BotConfigRepo) Add(ctx context.Context, config model.BotConfig) (model.BotConfig, error) {
-	const query = `INSERT INTO bot_config (username, added_at) VALUES (?, ?)`
+	const query = `INSERT INTO bot_config (username, added_at, nitpick_patterns) VALUES (?, ?, ?)`
 
 	addedAt := config.AddedAt
 	if addedAt.IsZero() {
 		addedAt = time.Now().UTC()
 	}
 
-	result, err := r.db.Writer.ExecContext(ctx, query, config.Username, addedAt.UTC())
+	patterns, err := marshalNames(config.NitpickPatterns)
+	if err != nil {
+		return model.BotConfig{}, fmt.Errorf("marshal nitpick patterns for %q: %w", config.Username, err)
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query, config.Username, addedAt.UTC(), patterns)
 	if err != nil {
 		var se *sqlite.Error
 		if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
@@ -51,9 +57,10 @@ func (r *BotConfigRepo) Add(ctx context.Context, config model.BotConfig) (model.
 	}
 
 	return model.BotConfig{
-		ID:       id,
-		Username: config.Username,
-		AddedAt:  addedAt.UTC(),
+		ID:              id,
+		Username:        config.Username,
+		AddedAt:         addedAt.UTC(),
+		NitpickPatterns: config.NitpickPatterns,
 	}, nil
 }
 
@@ -79,9 +86,36 @@ func (r *BotConfigRepo) Remove(ctx context.Context, username string) error {
 	return nil
 }
 
+// SetNitpickPatterns replaces the extra nitpick markers of a bot. Returns an
+// error if the username does not exist.
+func (r *BotConfigRepo) SetNitpickPatterns(ctx context.Context, username string, patterns []string) error {
+	const query = `UPDATE bot_config SET nitpick_patterns = ? WHERE username = ?`
+
+	encoded, err := marshalNames(patterns)
+	if err != nil {
+		return fmt.Errorf("marshal nitpick patterns for %q: %w", username, err)
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query, encoded, username)
+	if err != nil {
+		return fmt.Errorf("set nitpick patterns for %q: %w", username, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("set nitpick patterns for %q: %w", username, driven.ErrBotNotFound)
+	}
+
+	return nil
+}
+
 // ListAll returns all bot configuration entries ordered by username.
 func (r *BotConfigRepo) ListAll(ctx context.Context) ([]model.BotConfig, error) {
-	const query = `SELECT id, username, added_at FROM bot_config ORDER BY username`
+	const query = `SELECT id, username, added_at, nitpick_patterns FROM bot_config ORDER BY username`
 
 	rows, err := r.db.Reader.QueryContext(ctx, query)
 	if err != nil {
@@ -92,12 +126,16 @@ func (r *BotConfigRepo) ListAll(ctx context.Context) ([]model.BotConfig, error)
 	var configs []model.BotConfig
 	for rows.Next() {
 		var config model.BotConfig
-		var addedAt string
+		var addedAt, patterns string
 
-		if err := rows.Scan(&config.ID, &config.Username, &addedAt); err != nil {
+		if err := rows.Scan(&config.ID, &config.Username, &addedAt, &patterns); err != nil {
 			return nil, fmt.Errorf("scan bot config: %w", err)
 		}
 
+		if err := json.Unmarshal([]byte(patterns), &config.NitpickPatterns); err != nil {
+			return nil, fmt.Errorf("unmarshal nitpick patterns for %q: %w", config.Username, err)
+		}
+
 		config.AddedAt, err = parseTime(addedAt)
 		if err != nil {
 			return nil, fmt.Errorf("parse added_at: %w", err)
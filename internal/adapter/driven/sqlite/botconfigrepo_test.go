@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -97,3 +98,24 @@ func TestBotConfigRepo_GetUsernames(t *testing.T) {
 
 	assert.Equal(t, []string{"coderabbitai", "copilot[bot]", "github-actions[bot]"}, usernames)
 }
+
+func TestBotConfigRepo_SetNitpickPatterns(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewBotConfigRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SetNitpickPatterns(ctx, "coderabbitai", []string{"🧹 nitpick", "minor:"}))
+
+	configs, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"🧹 nitpick", "minor:"}, configs[0].NitpickPatterns)
+	assert.Empty(t, configs[1].NitpickPatterns)
+
+	require.NoError(t, repo.SetNitpickPatterns(ctx, "coderabbitai", nil))
+	configs, err = repo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, configs[0].NitpickPatterns)
+
+	err = repo.SetNitpickPatterns(ctx, "nonexistent-bot", []string{"nit"})
+	assert.ErrorIs(t, err, driven.ErrBotNotFound)
+}
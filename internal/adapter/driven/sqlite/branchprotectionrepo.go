@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.BranchProtectionStore = (*BranchProtectionRepo)(nil)
+
+// BranchProtectionRepo is the SQLite implementation of the BranchProtectionStore
+// port interface.
+type BranchProtectionRepo struct {
+	db *DB
+}
+
+// NewBranchProtectionRepo creates a new BranchProtectionRepo backed by the given DB.
+func NewBranchProtectionRepo(db *DB) *BranchProtectionRepo {
+	return &BranchProtectionRepo{db: db}
+}
+
+// Upsert records the protection settings for a branch.
+func (r *BranchProtectionRepo) Upsert(ctx context.Context, p model.BranchProtection) error {
+	const query = `
+		INSERT INTO branch_protection (repo_full_name, branch, requires_reviews, required_approvals,
+			require_code_owner_reviews, dismiss_stale_reviews, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(repo_full_name, branch) DO UPDATE SET
+			requires_reviews = excluded.requires_reviews,
+			required_approvals = excluded.required_approvals,
+			require_code_owner_reviews = excluded.require_code_owner_reviews,
+			dismiss_stale_reviews = excluded.dismiss_stale_reviews,
+			updated_at = excluded.updated_at`
+
+	updatedAt := p.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now().UTC()
+	}
+
+	_, err := r.db.Writer.ExecContext(ctx, query,
+		p.RepoFullName, p.Branch, boolToInt(p.RequiresReviews), p.RequiredApprovals,
+		boolToInt(p.RequireCodeOwnerReviews), boolToInt(p.DismissStaleReviews), updatedAt.UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("upsert branch protection for %s branch %s: %w", p.RepoFullName, p.Branch, err)
+	}
+	return nil
+}
+
+// Get returns the protection settings for a branch, or nil if none are stored.
+func (r *BranchProtectionRepo) Get(ctx context.Context, repoFullName, branch string) (*model.BranchProtection, error) {
+	const query = `
+		SELECT repo_full_name, branch, requires_reviews, required_approvals,
+			require_code_owner_reviews, dismiss_stale_reviews, updated_at
+		FROM branch_protection
+		WHERE repo_full_name = ? AND branch = ?`
+
+	var p model.BranchProtection
+	var requiresReviews, codeOwners, dismiss int
+	var updatedAt string
+	err := r.db.Reader.QueryRowContext(ctx, query, repoFullName, branch).Scan(
+		&p.RepoFullName, &p.Branch, &requiresReviews, &p.RequiredApprovals, &codeOwners, &dismiss, &updatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get branch protection for %s branch %s: %w", repoFullName, branch, err)
+	}
+
+	p.RequiresReviews = requiresReviews != 0
+	p.RequireCodeOwnerReviews = codeOwners != 0
+	p.DismissStaleReviews = dismiss != 0
+	if p.UpdatedAt, err = parseTime(updatedAt); err != nil {
+		return nil, fmt.Errorf("parse updated_at for %s branch %s: %w", repoFullName, branch, err)
+	}
+	return &p, nil
+}
+
+// boolToInt encodes b as SQLite's 0/1 boolean.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestBranchProtectionRepo_UpsertAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, testRepoFullName)
+	repo := NewBranchProtectionRepo(db)
+	ctx := context.Background()
+
+	got, err := repo.Get(ctx, testRepoFullName, "main")
+	require.NoError(t, err)
+	assert.Nil(t, got, "unfetched branch returns nil")
+
+	require.NoError(t, repo.Upsert(ctx, model.BranchProtection{
+		RepoFullName: testRepoFullName, Branch: "main",
+		RequiresReviews: true, RequiredApprovals: 2, RequireCodeOwnerReviews: true, DismissStaleReviews: true,
+	}))
+	got, err = repo.Get(ctx, testRepoFullName, "main")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.True(t, got.RequiresReviews)
+	assert.Equal(t, 2, got.RequiredApprovals)
+	assert.True(t, got.RequireCodeOwnerReviews)
+	assert.True(t, got.DismissStaleReviews)
+	assert.False(t, got.UpdatedAt.IsZero())
+
+	require.NoError(t, repo.Upsert(ctx, model.BranchProtection{RepoFullName: testRepoFullName, Branch: "main"}))
+	got, err = repo.Get(ctx, testRepoFullName, "main")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.False(t, got.DismissStaleReviews, "upsert replaces the setting")
+	assert.False(t, got.RequiresReviews)
+	assert.Zero(t, got.RequiredApprovals)
+}
+
+func TestBranchProtectionRepo_CascadeOnRepoRemove(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, testRepoFullName)
+	repo := NewBranchProtectionRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Upsert(ctx, model.BranchProtection{RepoFullName: testRepoFullName, Branch: "main", DismissStaleReviews: true}))
+	require.NoError(t, NewRepoRepo(db).Remove(ctx, testRepoFullName))
+
+	got, err := repo.Get(ctx, testRepoFullName, "main")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.BrowserPreferenceStore = (*BrowserPreferenceRepo)(nil)
+
+// BrowserPreferenceRepo is the SQLite implementation of the
+// BrowserPreferenceStore port interface.
+type BrowserPreferenceRepo struct {
+	db *DB
+}
+
+// NewBrowserPreferenceRepo creates a new BrowserPreferenceRepo backed by the given DB.
+func NewBrowserPreferenceRepo(db *DB) *BrowserPreferenceRepo {
+	return &BrowserPreferenceRepo{db: db}
+}
+
+// ListPreferences returns the browser's preferences by key.
+func (r *BrowserPreferenceRepo) ListPreferences(ctx context.Context, browserID string) (map[string]string, error) {
+	const query = `SELECT pref_key, value FROM browser_preferences WHERE browser_id = ?`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, browserID)
+	if err != nil {
+		return nil, fmt.Errorf("list browser preferences: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	prefs := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("scan browser preference: %w", err)
+		}
+		prefs[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list browser preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// SetPreference stores or replaces one preference; an empty value deletes it.
+func (r *BrowserPreferenceRepo) SetPreference(ctx context.Context, browserID, key, value string) error {
+	if value == "" {
+		const query = `DELETE FROM browser_preferences WHERE browser_id = ? AND pref_key = ?`
+		if _, err := r.db.Writer.ExecContext(ctx, query, browserID, key); err != nil {
+			return fmt.Errorf("delete browser preference: %w", err)
+		}
+		return nil
+	}
+
+	const query = `
+		INSERT INTO browser_preferences (browser_id, pref_key, value, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (browser_id, pref_key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, browserID, key, value, time.Now().UTC()); err != nil {
+		return fmt.Errorf("set browser preference: %w", err)
+	}
+	return nil
+}
+
+// PrunePreferences deletes the preferences of browsers whose newest saved
+// preference is older than cutoff, so a browser keeps all its settings until
+// it goes unused as a whole.
+func (r *BrowserPreferenceRepo) PrunePreferences(ctx context.Context, cutoff time.Time) (int64, error) {
+	const query = `
+		DELETE FROM browser_preferences WHERE browser_id IN (
+			SELECT browser_id FROM browser_preferences
+			GROUP BY browser_id HAVING MAX(updated_at) < ?
+		)`
+
+	result, err := r.db.Writer.ExecContext(ctx, query, cutoff.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("prune browser preferences: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("prune browser preferences: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrowserPreferenceRepo(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewBrowserPreferenceRepo(db)
+	ctx := context.Background()
+
+	prefs, err := repo.ListPreferences(ctx, "browser-a")
+	require.NoError(t, err)
+	assert.Empty(t, prefs)
+
+	require.NoError(t, repo.SetPreference(ctx, "browser-a", "density", "compact"))
+	require.NoError(t, repo.SetPreference(ctx, "browser-a", "density", "table"))
+	require.NoError(t, repo.SetPreference(ctx, "browser-a", "filters", "status=open"))
+	require.NoError(t, repo.SetPreference(ctx, "browser-b", "density", "compact"))
+
+	prefs, err = repo.ListPreferences(ctx, "browser-a")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"density": "table", "filters": "status=open"}, prefs)
+
+	require.NoError(t, repo.SetPreference(ctx, "browser-a", "filters", ""))
+	prefs, err = repo.ListPreferences(ctx, "browser-a")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"density": "table"}, prefs, "an empty value deletes the preference")
+
+	n, err := repo.PrunePreferences(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n, "recently used browsers are kept")
+	n, err = repo.PrunePreferences(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
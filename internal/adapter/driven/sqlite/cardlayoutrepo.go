@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.CardLayoutStore = (*CardLayoutRepo)(nil)
+
+// cardFieldsKey is the global_settings key holding the comma-separated fields.
+const cardFieldsKey = "card_fields"
+
+// CardLayoutRepo is the SQLite implementation of the CardLayoutStore port
+// interface, persisted as a single row of global_settings.
+type CardLayoutRepo struct {
+	db *DB
+}
+
+// NewCardLayoutRepo creates a new CardLayoutRepo backed by the given DB.
+func NewCardLayoutRepo(db *DB) *CardLayoutRepo {
+	return &CardLayoutRepo{db: db}
+}
+
+// GetCardFields returns the visible card fields, or the defaults when the
+// preference has never been saved. Unknown stored values are dropped.
+func (r *CardLayoutRepo) GetCardFields(ctx context.Context) ([]model.CardField, error) {
+	const query = `SELECT value FROM global_settings WHERE key = ?`
+
+	var value string
+	err := r.db.Reader.QueryRowContext(ctx, query, cardFieldsKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.DefaultCardFields(), nil
+	}
+	if err != nil {
+		return model.DefaultCardFields(), fmt.Errorf("get card fields: %w", err)
+	}
+
+	var fields []model.CardField
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			fields = append(fields, model.CardField(part))
+		}
+	}
+	return model.NormalizeCardFields(fields), nil
+}
+
+// SetCardFields persists the visible card fields in display order.
+func (r *CardLayoutRepo) SetCardFields(ctx context.Context, fields []model.CardField) error {
+	const query = `INSERT OR REPLACE INTO global_settings (key, value) VALUES (?, ?)`
+
+	normalized := model.NormalizeCardFields(fields)
+	parts := make([]string, len(normalized))
+	for i, f := range normalized {
+		parts[i] = string(f)
+	}
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, cardFieldsKey, strings.Join(parts, ",")); err != nil {
+		return fmt.Errorf("set card fields: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardLayoutRepo_DefaultsWhenUnset(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCardLayoutRepo(db)
+
+	got, err := repo.GetCardFields(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultCardFields(), got)
+}
+
+func TestCardLayoutRepo_SetAndGetKeepsOrder(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCardLayoutRepo(db)
+	ctx := context.Background()
+
+	// Stored in the chosen order, with unknown and duplicate values dropped.
+	require.NoError(t, repo.SetCardFields(ctx, []model.CardField{
+		model.CardFieldReviewers, "bogus", model.CardFieldAge, model.CardFieldReviewers, model.CardFieldCIStatus,
+	}))
+
+	got, err := repo.GetCardFields(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.CardField{model.CardFieldReviewers, model.CardFieldAge, model.CardFieldCIStatus}, got)
+}
+
+func TestCardLayoutRepo_EmptyHidesAll(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCardLayoutRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SetCardFields(ctx, nil))
+
+	got, err := repo.GetCardFields(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
@@ -101,6 +101,45 @@ func (r *CheckRepo) GetCheckRunsByPR(ctx context.Context, prID int64) ([]model.C
 	return runs, nil
 }
 
+// GetCheckRunsByPRs returns the check runs of each given PR in a single
+// query, each PR's ordered by name as in GetCheckRunsByPR. PRs without check
+// runs are absent from the map.
+func (r *CheckRepo) GetCheckRunsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.CheckRun, error) {
+	result := make(map[int64][]model.CheckRun, len(prIDs))
+	if len(prIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders, args := idPlaceholders(prIDs)
+	//nolint:gosec // placeholders contains only comma-separated "?" literals, never user input
+	query := fmt.Sprintf(`
+		SELECT id, pr_id, name, status, conclusion, is_required, details_url, started_at, completed_at
+		FROM check_runs
+		WHERE pr_id IN (%s)
+		ORDER BY pr_id, name
+	`, placeholders)
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query check runs for %d PRs: %w", len(prIDs), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		run, err := scanCheckRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan check run: %w", err)
+		}
+		result[run.PRID] = append(result[run.PRID], *run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate check runs: %w", err)
+	}
+
+	return result, nil
+}
+
 func scanCheckRun(s scanner) (*model.CheckRun, error) {
 	var run model.CheckRun
 	var isRequired int
@@ -110,6 +110,30 @@ func TestCheckRepo_ReplaceAndGet(t *testing.T) {
 	assert.True(t, got[0].CompletedAt.IsZero(), "completed_at should be zero for in-progress run")
 }
 
+func TestCheckRepo_GetCheckRunsByPRs(t *testing.T) {
+	db := setupTestDB(t)
+	pr1 := insertTestPR(t, db, "octocat/hello-world", 1)
+	pr2 := insertTestPR(t, db, "octocat/spoon-knife", 2)
+	checkRepo := NewCheckRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, checkRepo.ReplaceCheckRunsForPR(ctx, pr1, []model.CheckRun{
+		{ID: 1, PRID: pr1, Name: "lint", Status: "completed", Conclusion: "success"},
+		{ID: 2, PRID: pr1, Name: "build", Status: "in_progress"},
+	}))
+	require.NoError(t, checkRepo.ReplaceCheckRunsForPR(ctx, pr2, []model.CheckRun{
+		{ID: 3, PRID: pr2, Name: "build", Status: "completed", Conclusion: "failure"},
+	}))
+
+	byPR, err := checkRepo.GetCheckRunsByPRs(ctx, []int64{pr1, pr2})
+	require.NoError(t, err)
+	require.Len(t, byPR[pr1], 2)
+	assert.Equal(t, "build", byPR[pr1][0].Name, "each PR's runs are ordered by name")
+	assert.Equal(t, "lint", byPR[pr1][1].Name)
+	require.Len(t, byPR[pr2], 1)
+	assert.Equal(t, "failure", byPR[pr2][0].Conclusion)
+}
+
 func TestCheckRepo_GetCheckRunsByPR_Empty(t *testing.T) {
 	db := setupTestDB(t)
 	prID := insertTestPR(t, db, "octocat/hello-world", 1)
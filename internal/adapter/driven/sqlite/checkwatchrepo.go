@@ -0,0 +1,81 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.CheckWatchStore = (*CheckWatchRepo)(nil)
+
+// CheckWatchRepo is the SQLite implementation of the CheckWatchStore port interface.
+type CheckWatchRepo struct {
+	db *DB
+}
+
+// NewCheckWatchRepo creates a new CheckWatchRepo backed by the given DB.
+func NewCheckWatchRepo(db *DB) *CheckWatchRepo {
+	return &CheckWatchRepo{db: db}
+}
+
+// SetCheckWatch starts or stops watching a PR's checks.
+func (r *CheckWatchRepo) SetCheckWatch(ctx context.Context, prID int64, watching bool) error {
+	if !watching {
+		if _, err := r.db.Writer.ExecContext(ctx, `DELETE FROM check_watches WHERE pr_id = ?`, prID); err != nil {
+			return fmt.Errorf("unwatch checks of PR %d: %w", prID, err)
+		}
+		return nil
+	}
+
+	const query = `INSERT INTO check_watches (pr_id) VALUES (?) ON CONFLICT(pr_id) DO NOTHING`
+	if _, err := r.db.Writer.ExecContext(ctx, query, prID); err != nil {
+		return fmt.Errorf("watch checks of PR %d: %w", prID, err)
+	}
+	return nil
+}
+
+// IsWatchingChecks reports whether a PR's checks are watched.
+func (r *CheckWatchRepo) IsWatchingChecks(ctx context.Context, prID int64) (bool, error) {
+	var n int
+	err := r.db.Reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM check_watches WHERE pr_id = ?`, prID).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("get check watch of PR %d: %w", prID, err)
+	}
+	return n > 0, nil
+}
+
+// ListCheckWatches returns every watch with its PR's repository and number,
+// oldest first.
+func (r *CheckWatchRepo) ListCheckWatches(ctx context.Context) ([]model.CheckWatch, error) {
+	const query = `
+		SELECT w.pr_id, p.repo_full_name, p.number, w.created_at
+		FROM check_watches w
+		JOIN pull_requests p ON p.id = w.pr_id
+		ORDER BY w.created_at, w.pr_id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list check watches: %w", err)
+	}
+	defer rows.Close()
+
+	var watches []model.CheckWatch
+	for rows.Next() {
+		var w model.CheckWatch
+		var createdAt string
+		if err := rows.Scan(&w.PRID, &w.RepoFullName, &w.PRNumber, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan check watch: %w", err)
+		}
+		if w.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for check watch of PR %d: %w", w.PRID, err)
+		}
+		watches = append(watches, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate check watches: %w", err)
+	}
+	return watches, nil
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWatchRepo_SetAndList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewCheckWatchRepo(db)
+	ctx := context.Background()
+	first := addTestPR(t, db, "octocat/hello-world", 1)
+	second := addTestPR(t, db, "octocat/other", 2)
+
+	watching, err := repo.IsWatchingChecks(ctx, first)
+	require.NoError(t, err)
+	assert.False(t, watching)
+
+	require.NoError(t, repo.SetCheckWatch(ctx, first, true))
+	require.NoError(t, repo.SetCheckWatch(ctx, first, true), "watching twice is a no-op")
+	require.NoError(t, repo.SetCheckWatch(ctx, second, true))
+
+	watching, err = repo.IsWatchingChecks(ctx, first)
+	require.NoError(t, err)
+	assert.True(t, watching)
+
+	watches, err := repo.ListCheckWatches(ctx)
+	require.NoError(t, err)
+	require.Len(t, watches, 2)
+	assert.Equal(t, first, watches[0].PRID)
+	assert.Equal(t, "octocat/hello-world", watches[0].RepoFullName)
+	assert.Equal(t, 1, watches[0].PRNumber)
+	assert.False(t, watches[0].CreatedAt.IsZero())
+
+	require.NoError(t, repo.SetCheckWatch(ctx, first, false))
+	watches, err = repo.ListCheckWatches(ctx)
+	require.NoError(t, err)
+	require.Len(t, watches, 1)
+	assert.Equal(t, second, watches[0].PRID)
+}
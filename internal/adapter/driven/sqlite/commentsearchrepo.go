@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.CommentSearchStore = (*CommentSearchRepo)(nil)
+
+// defaultCommentSearchLimit caps a comment search without an explicit limit.
+const defaultCommentSearchLimit = 50
+
+// CommentSearchRepo is the SQLite implementation of the CommentSearchStore
+// port interface. Text matching uses the FTS5 indexes the comment tables keep
+// in sync through triggers.
+type CommentSearchRepo struct {
+	db *DB
+}
+
+// NewCommentSearchRepo creates a new CommentSearchRepo backed by the given DB.
+func NewCommentSearchRepo(db *DB) *CommentSearchRepo {
+	return &CommentSearchRepo{db: db}
+}
+
+// SearchComments returns review and issue comments on the PRs of watched
+// repositories that match filter, newest first. Every word of filter.Text
+// must appear in the body; words match their stems, and the last word also
+// matches as a prefix so results keep up while the user types.
+func (r *CommentSearchRepo) SearchComments(ctx context.Context, filter model.CommentSearchFilter) ([]model.CommentSearchResult, error) {
+	match := ftsMatchQuery(filter.Text)
+	reviewSelect, reviewArgs := commentSearchSelect(model.CommentKindReview, "review_comments", "c.path", match, filter)
+	issueSelect, issueArgs := commentSearchSelect(model.CommentKindIssue, "issue_comments", "''", match, filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultCommentSearchLimit
+	}
+
+	query := reviewSelect + "\nUNION ALL\n" + issueSelect + "\nORDER BY created_at DESC, id DESC\nLIMIT ?"
+	args := append(append(reviewArgs, issueArgs...), limit)
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("search comments: %w", err)
+	}
+	defer rows.Close()
+
+	var results []model.CommentSearchResult
+	for rows.Next() {
+		var c model.CommentSearchResult
+		var kind, createdAt string
+		if err := rows.Scan(
+			&kind, &c.CommentID, &c.RepoFullName, &c.PRNumber, &c.PRTitle,
+			&c.Author, &c.Body, &c.Snippet, &c.Path, &createdAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan comment search result: %w", err)
+		}
+		c.Kind = model.CommentKind(kind)
+		if c.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for comment %d: %w", c.CommentID, err)
+		}
+		results = append(results, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate comment search results: %w", err)
+	}
+	return results, nil
+}
+
+// commentSearchSelect builds the SELECT over one comment table and its FTS
+// index, returning it with its arguments. The FTS index is joined only when
+// there is a match expression.
+func commentSearchSelect(kind model.CommentKind, table, pathColumn, match string, filter model.CommentSearchFilter) (string, []any) {
+	fts := table + "_fts"
+	snippet := "''"
+	from := table + " c JOIN pull_requests p ON p.id = c.pr_id"
+	clauses := []string{"p.repo_full_name IN " + watchedRepos}
+	var args []any
+
+	if match != "" {
+		snippet = "snippet(" + fts + ", 0, '', '', '…', 24)"
+		from += " JOIN " + fts + " ON " + fts + ".rowid = c.id"
+		clauses = append(clauses, fts+" MATCH ?")
+		args = append(args, match)
+	}
+	if filter.Author != "" {
+		clauses = append(clauses, "c.author = ? COLLATE NOCASE")
+		args = append(args, filter.Author)
+	}
+	if filter.RepoFullName != "" {
+		clauses = append(clauses, "p.repo_full_name = ?")
+		args = append(args, filter.RepoFullName)
+	}
+	if !filter.After.IsZero() {
+		clauses = append(clauses, "c.created_at >= ?")
+		args = append(args, filter.After.UTC())
+	}
+	if !filter.Before.IsZero() {
+		clauses = append(clauses, "c.created_at < ?")
+		args = append(args, filter.Before.UTC())
+	}
+
+	query := fmt.Sprintf(`SELECT '%s' AS kind, c.id AS id, p.repo_full_name, p.number, p.title,
+			c.author, c.body, %s, %s, c.created_at AS created_at
+		FROM %s
+		WHERE %s`, kind, snippet, pathColumn, from, strings.Join(clauses, " AND "))
+	return query, args
+}
+
+// ftsMatchQuery turns free text into an FTS5 match expression that requires
+// every word, quoting each so FTS5 operators in the text match literally. The
+// last word also matches as a prefix. Empty text yields an empty expression.
+func ftsMatchQuery(text string) string {
+	words := strings.Fields(text)
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
+	}
+	if len(words) > 0 {
+		words[len(words)-1] += "*"
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,96 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestCommentSearchRepo_SearchComments(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	reviews := NewReviewRepo(db)
+	jan := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	mar := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+
+	prID := addTestPR(t, db, "octocat/hello-world", 1)
+	otherID := addTestPR(t, db, "octocat/other", 2)
+
+	require.NoError(t, reviews.UpsertReviewComment(ctx, model.ReviewComment{
+		ID: 10, PRID: prID, Author: "alice", Body: "The retry config lives in config/retries.yaml",
+		Path: "client.go", SubjectType: "line", CreatedAt: jan, UpdatedAt: jan,
+	}))
+	require.NoError(t, reviews.UpsertIssueComment(ctx, model.IssueComment{
+		ID: 20, PRID: otherID, Author: "bob", Body: "Retrying is configured per client", CreatedAt: mar, UpdatedAt: mar,
+	}))
+	require.NoError(t, reviews.UpsertIssueComment(ctx, model.IssueComment{
+		ID: 21, PRID: prID, Author: "bob", Body: "LGTM", CreatedAt: mar, UpdatedAt: mar,
+	}))
+
+	repo := NewCommentSearchRepo(db)
+	ids := func(results []model.CommentSearchResult) []int64 {
+		var out []int64
+		for _, r := range results {
+			out = append(out, r.CommentID)
+		}
+		return out
+	}
+
+	got, err := repo.SearchComments(ctx, model.CommentSearchFilter{Text: "retry config"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{20, 10}, ids(got), "stemmed words match across both tables, newest first")
+	assert.Equal(t, model.CommentKindIssue, got[0].Kind)
+	review := got[1]
+	assert.Equal(t, model.CommentKindReview, review.Kind)
+	assert.Equal(t, "octocat/hello-world", review.RepoFullName)
+	assert.Equal(t, 1, review.PRNumber)
+	assert.Equal(t, "Test PR", review.PRTitle)
+	assert.Equal(t, "client.go", review.Path)
+	assert.Contains(t, review.Snippet, "retry config")
+	assert.True(t, jan.Equal(review.CreatedAt))
+
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Text: "retr"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{20, 10}, ids(got), "the last word matches as a prefix")
+
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Text: `config" OR "lgtm`})
+	require.NoError(t, err)
+	assert.Empty(t, got, "FTS operators in the text match literally")
+
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Author: "BOB"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{20, 21}, ids(got))
+	assert.Empty(t, got[0].Snippet)
+
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Text: "retry", RepoFullName: "octocat/hello-world"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10}, ids(got))
+
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{After: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{20, 21}, ids(got))
+
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Before: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10}, ids(got))
+
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	require.NoError(t, reviews.UpsertReviewComment(ctx, model.ReviewComment{
+		ID: 10, PRID: prID, Author: "alice", Body: "Backoff settings are hardcoded",
+		Path: "client.go", SubjectType: "line", CreatedAt: jan, UpdatedAt: jan,
+	}))
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Text: "retry"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{20}, ids(got), "edited bodies are reindexed")
+	got, err = repo.SearchComments(ctx, model.CommentSearchFilter{Text: "backoff"})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10}, ids(got))
+}
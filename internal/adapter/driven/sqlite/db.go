@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 
 	_ "modernc.org/sqlite" // SQLite driver registration.
 )
@@ -17,13 +18,53 @@ type DB struct {
 	path   string
 }
 
+// dbPragmas are set on every connection, read-only or not.
+const dbPragmas = "_pragma=busy_timeout(5000)&_pragma=synchronous(NORMAL)&_pragma=foreign_keys(ON)&_pragma=cache_size(-64000)"
+
 // NewDB creates a new dual-connection SQLite database with WAL mode, busy timeout,
 // synchronous NORMAL, foreign keys enabled, and a 64MB cache.
 func NewDB(ctx context.Context, dbPath string) (*DB, error) {
-	dsn := fmt.Sprintf(
-		"file:%s?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=synchronous(NORMAL)&_pragma=foreign_keys(ON)&_pragma=cache_size(-64000)",
-		dbPath,
-	)
+	return openDB(ctx, dbPath, "_pragma=journal_mode(WAL)&"+dbPragmas)
+}
+
+// NewReadOnlyDB opens an existing database file read-only, with query_only
+// set on every connection, so any write fails instead of modifying a database
+// this binary does not fully understand or a snapshot that must stay as it
+// is. The journal mode is left as found, so copies such as migration backups
+// open too.
+func NewReadOnlyDB(ctx context.Context, dbPath string) (*DB, error) {
+	return openDB(ctx, dbPath, dbPragmas+"&_pragma=query_only(ON)&mode=ro")
+}
+
+// NewMemoryDB creates a database held entirely in memory under name, for
+// demos and integration tests that must not touch disk; it is lost when
+// closed. A memory database cannot use WAL, and sharing one between two pools
+// needs SQLite's shared cache, whose table locks stall readers behind an open
+// write transaction. Reader and Writer are therefore the same pool of one
+// connection, which is kept open for the life of the DB since the database
+// goes with it.
+func NewMemoryDB(ctx context.Context, name string) (*DB, error) {
+	dbPath := url.PathEscape(name)
+	conn, err := sql.Open("sqlite", fmt.Sprintf("file:%s?mode=memory&%s", dbPath, dbPragmas))
+	if err != nil {
+		return nil, fmt.Errorf("open memory database: %w", err)
+	}
+	conn.SetMaxOpenConns(1)
+	conn.SetMaxIdleConns(1)
+	conn.SetConnMaxLifetime(0)
+	conn.SetConnMaxIdleTime(0)
+
+	if err := conn.PingContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("ping memory database: %w", err)
+	}
+
+	return &DB{Writer: conn, Reader: conn, path: dbPath}, nil
+}
+
+// openDB opens the reader and writer pools with the given DSN query parameters.
+func openDB(ctx context.Context, dbPath, params string) (*DB, error) {
+	dsn := fmt.Sprintf("file:%s?%s", dbPath, params)
 
 	writer, err := sql.Open("sqlite", dsn)
 	if err != nil {
@@ -56,6 +97,26 @@ func NewDB(ctx context.Context, dbPath string) (*DB, error) {
 	}, nil
 }
 
+// Ping verifies that both the reader and writer pools can reach the database.
+func (db *DB) Ping(ctx context.Context) error {
+	if err := db.Writer.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping writer: %w", err)
+	}
+	if err := db.Reader.PingContext(ctx); err != nil {
+		return fmt.Errorf("ping reader: %w", err)
+	}
+	return nil
+}
+
+// Backup writes a consistent copy of the database to dest, which must not
+// exist yet. The copy is compacted and can be opened like the original.
+func (db *DB) Backup(ctx context.Context, dest string) error {
+	if _, err := db.Writer.ExecContext(ctx, `VACUUM INTO ?`, dest); err != nil {
+		return fmt.Errorf("back up database to %s: %w", dest, err)
+	}
+	return nil
+}
+
 // Close closes both reader and writer connections. Returns the first error encountered.
 func (db *DB) Close() error {
 	var firstErr error
@@ -63,6 +124,9 @@ func (db *DB) Close() error {
 	if err := db.Reader.Close(); err != nil {
 		firstErr = fmt.Errorf("close reader: %w", err)
 	}
+	if db.Writer == db.Reader {
+		return firstErr
+	}
 
 	if err := db.Writer.Close(); err != nil && firstErr == nil {
 		firstErr = fmt.Errorf("close writer: %w", err)
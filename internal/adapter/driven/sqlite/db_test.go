@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMemoryDB_ReadDuringWriteTransaction(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewMemoryDB(ctx, t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, RunMigrations(db.Writer))
+
+	tx, err := db.Writer.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(ctx, `INSERT INTO repositories (full_name, owner, name) VALUES ('org/api', 'org', 'api')`)
+	require.NoError(t, err)
+
+	// A read while the write is open waits its turn for the connection and
+	// gives up at its deadline. Under SQLite's shared cache it hung instead.
+	waited := make(chan error, 1)
+	go func() {
+		readCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		var count int
+		waited <- db.Reader.QueryRowContext(readCtx, `SELECT COUNT(*) FROM repositories`).Scan(&count)
+	}()
+	select {
+	case err := <-waited:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("read hung behind the write transaction")
+	}
+
+	// A read without a deadline runs once the write commits and sees it.
+	done := make(chan int, 1)
+	go func() {
+		var count int
+		if err := db.Reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM repositories`).Scan(&count); err != nil {
+			count = -1
+		}
+		done <- count
+	}()
+	require.NoError(t, tx.Commit())
+	select {
+	case count := <-done:
+		assert.Equal(t, 1, count)
+	case <-time.After(5 * time.Second):
+		t.Fatal("read stalled after the write transaction committed")
+	}
+}
+
+func TestNewMemoryDB_Close(t *testing.T) {
+	db, err := NewMemoryDB(context.Background(), t.Name())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.DecisionStore = (*DecisionRepo)(nil)
+
+// likeEscaper escapes LIKE wildcards so search queries match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// DecisionRepo is the SQLite implementation of the DecisionStore port interface.
+type DecisionRepo struct {
+	db *DB
+}
+
+// NewDecisionRepo creates a new DecisionRepo backed by the given DB.
+func NewDecisionRepo(db *DB) *DecisionRepo {
+	return &DecisionRepo{db: db}
+}
+
+// Save inserts a decision or, if the thread is already a decision, updates its
+// summary and PR context while preserving created_at.
+func (r *DecisionRepo) Save(ctx context.Context, d model.Decision) error {
+	const query = `
+		INSERT INTO decisions (repo_full_name, pr_number, pr_title, root_comment_id, file_path, summary, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(root_comment_id) DO UPDATE SET
+			repo_full_name = excluded.repo_full_name,
+			pr_number = excluded.pr_number,
+			pr_title = excluded.pr_title,
+			file_path = excluded.file_path,
+			summary = excluded.summary,
+			updated_at = excluded.updated_at`
+
+	now := time.Now().UTC()
+	_, err := r.db.Writer.ExecContext(ctx, query,
+		d.RepoFullName, d.PRNumber, d.PRTitle, d.RootCommentID, d.FilePath, d.Summary, now, now,
+	)
+	if err != nil {
+		return fmt.Errorf("save decision for comment %d: %w", d.RootCommentID, err)
+	}
+	return nil
+}
+
+// Delete removes the decision for the given thread.
+func (r *DecisionRepo) Delete(ctx context.Context, rootCommentID int64) error {
+	const query = `DELETE FROM decisions WHERE root_comment_id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, rootCommentID); err != nil {
+		return fmt.Errorf("delete decision for comment %d: %w", rootCommentID, err)
+	}
+	return nil
+}
+
+// ListByPR returns the decisions on a pull request ordered by creation time.
+func (r *DecisionRepo) ListByPR(ctx context.Context, repoFullName string, prNumber int) ([]model.Decision, error) {
+	const query = `
+		SELECT id, repo_full_name, pr_number, pr_title, root_comment_id, file_path, summary, created_at, updated_at
+		FROM decisions
+		WHERE repo_full_name = ? AND pr_number = ?
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, repoFullName, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("list decisions for %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return scanDecisions(rows)
+}
+
+// Search returns decisions matching query, newest first. Matching is a
+// case-insensitive substring match on summary, file path, and PR title.
+func (r *DecisionRepo) Search(ctx context.Context, repoFullName, query string) ([]model.Decision, error) {
+	const stmt = `
+		SELECT id, repo_full_name, pr_number, pr_title, root_comment_id, file_path, summary, created_at, updated_at
+		FROM decisions
+		WHERE (? = '' OR repo_full_name = ?)
+		  AND (? = '' OR summary LIKE ? ESCAPE '\' OR file_path LIKE ? ESCAPE '\' OR pr_title LIKE ? ESCAPE '\')
+		ORDER BY updated_at DESC, id DESC`
+
+	query = strings.TrimSpace(query)
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+
+	rows, err := r.db.Reader.QueryContext(ctx, stmt,
+		repoFullName, repoFullName,
+		query, pattern, pattern, pattern,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search decisions: %w", err)
+	}
+	return scanDecisions(rows)
+}
+
+// scanDecisions reads all decision rows and closes rows.
+func scanDecisions(rows *sql.Rows) ([]model.Decision, error) {
+	defer rows.Close()
+
+	var result []model.Decision
+	for rows.Next() {
+		var d model.Decision
+		var createdAt, updatedAt string
+		if err := rows.Scan(
+			&d.ID, &d.RepoFullName, &d.PRNumber, &d.PRTitle, &d.RootCommentID,
+			&d.FilePath, &d.Summary, &createdAt, &updatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan decision: %w", err)
+		}
+
+		var err error
+		if d.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for decision %d: %w", d.ID, err)
+		}
+		if d.UpdatedAt, err = parseTime(updatedAt); err != nil {
+			return nil, fmt.Errorf("parse updated_at for decision %d: %w", d.ID, err)
+		}
+		result = append(result, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate decisions: %w", err)
+	}
+	return result, nil
+}
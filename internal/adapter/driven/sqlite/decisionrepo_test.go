@@ -0,0 +1,105 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func testDecision(repo string, prNumber int, rootCommentID int64, summary string) model.Decision {
+	return model.Decision{
+		RepoFullName:  repo,
+		PRNumber:      prNumber,
+		PRTitle:       "Add caching layer",
+		RootCommentID: rootCommentID,
+		FilePath:      "internal/cache/cache.go",
+		Summary:       summary,
+	}
+}
+
+func TestDecisionRepo_SaveAndListByPR(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewDecisionRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, testDecision("octocat/hello-world", 1, 100, "Use functional options for constructors")))
+	require.NoError(t, repo.Save(ctx, testDecision("octocat/hello-world", 1, 101, "Errors are wrapped, never logged and returned")))
+	require.NoError(t, repo.Save(ctx, testDecision("octocat/hello-world", 2, 200, "Other PR")))
+
+	decisions, err := repo.ListByPR(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	require.Len(t, decisions, 2)
+	assert.Equal(t, int64(100), decisions[0].RootCommentID)
+	assert.Equal(t, "Use functional options for constructors", decisions[0].Summary)
+	assert.Equal(t, "internal/cache/cache.go", decisions[0].FilePath)
+	assert.False(t, decisions[0].CreatedAt.IsZero())
+}
+
+func TestDecisionRepo_SaveUpdatesExisting(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewDecisionRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, testDecision("octocat/hello-world", 1, 100, "first")))
+	require.NoError(t, repo.Save(ctx, testDecision("octocat/hello-world", 1, 100, "revised")))
+
+	decisions, err := repo.ListByPR(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	require.Len(t, decisions, 1, "saving the same thread twice must not duplicate it")
+	assert.Equal(t, "revised", decisions[0].Summary)
+}
+
+func TestDecisionRepo_Delete(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewDecisionRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, testDecision("octocat/hello-world", 1, 100, "gone soon")))
+	require.NoError(t, repo.Delete(ctx, 100))
+	require.NoError(t, repo.Delete(ctx, 100), "deleting a missing decision is a no-op")
+
+	decisions, err := repo.ListByPR(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	assert.Empty(t, decisions)
+}
+
+func TestDecisionRepo_Search(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewDecisionRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Save(ctx, testDecision("org/api", 1, 100, "Prefer table-driven tests")))
+	require.NoError(t, repo.Save(ctx, testDecision("org/api", 2, 101, "Use 100% coverage only for parsers")))
+	require.NoError(t, repo.Save(ctx, testDecision("org/web", 3, 102, "Table layout for forms is fine")))
+
+	all, err := repo.Search(ctx, "", "")
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+	assert.Equal(t, int64(102), all[0].RootCommentID, "newest first")
+
+	byRepo, err := repo.Search(ctx, "org/api", "")
+	require.NoError(t, err)
+	assert.Len(t, byRepo, 2)
+
+	byText, err := repo.Search(ctx, "", "TABLE")
+	require.NoError(t, err)
+	assert.Len(t, byText, 2, "search is case-insensitive")
+
+	both, err := repo.Search(ctx, "org/web", "table")
+	require.NoError(t, err)
+	require.Len(t, both, 1)
+	assert.Equal(t, int64(102), both[0].RootCommentID)
+
+	literal, err := repo.Search(ctx, "", "100%")
+	require.NoError(t, err)
+	require.Len(t, literal, 1, "LIKE wildcards in the query match literally")
+	assert.Equal(t, int64(101), literal[0].RootCommentID)
+
+	byPath, err := repo.Search(ctx, "", "cache.go")
+	require.NoError(t, err)
+	assert.Len(t, byPath, 3)
+}
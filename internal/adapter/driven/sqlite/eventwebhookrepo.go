@@ -0,0 +1,266 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.EventWebhookStore = (*EventWebhookRepo)(nil)
+
+const eventWebhookColumns = `id, url, secret, events, repo_full_name, enabled, created_at`
+
+const webhookDeliveryColumns = `id, webhook_id, event, repo_full_name, pr_number, payload,
+	status, attempts, response_status, last_error, next_attempt_at, created_at, delivered_at`
+
+// EventWebhookRepo is the SQLite implementation of the EventWebhookStore port interface.
+type EventWebhookRepo struct {
+	db *DB
+}
+
+// NewEventWebhookRepo creates a new EventWebhookRepo backed by the given DB.
+func NewEventWebhookRepo(db *DB) *EventWebhookRepo {
+	return &EventWebhookRepo{db: db}
+}
+
+// Create persists a webhook and returns the assigned ID. Events are stored
+// comma-separated.
+func (r *EventWebhookRepo) Create(ctx context.Context, hook model.EventWebhook) (int64, error) {
+	const query = `INSERT INTO event_webhooks (url, secret, events, repo_full_name, enabled, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	createdAt := hook.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	events := make([]string, 0, len(hook.Events))
+	for _, e := range hook.Events {
+		events = append(events, string(e))
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query,
+		hook.URL, hook.Secret, strings.Join(events, ","), hook.RepoFullName, boolToInt(hook.Enabled), createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create event webhook: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create event webhook: last insert id: %w", err)
+	}
+	return id, nil
+}
+
+// Get returns one webhook, or nil if it does not exist.
+func (r *EventWebhookRepo) Get(ctx context.Context, id int64) (*model.EventWebhook, error) {
+	query := `SELECT ` + eventWebhookColumns + ` FROM event_webhooks WHERE id = ?`
+
+	hook, err := scanEventWebhook(r.db.Reader.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get event webhook %d: %w", id, err)
+	}
+	return &hook, nil
+}
+
+// List returns all webhooks ordered by creation.
+func (r *EventWebhookRepo) List(ctx context.Context) ([]model.EventWebhook, error) {
+	query := `SELECT ` + eventWebhookColumns + ` FROM event_webhooks ORDER BY created_at, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list event webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []model.EventWebhook
+	for rows.Next() {
+		hook, err := scanEventWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list event webhooks: scan: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list event webhooks: iterate: %w", err)
+	}
+	return hooks, nil
+}
+
+// SetEnabled turns a webhook on or off.
+func (r *EventWebhookRepo) SetEnabled(ctx context.Context, id int64, enabled bool) error {
+	res, err := r.db.Writer.ExecContext(ctx, `UPDATE event_webhooks SET enabled = ? WHERE id = ?`, boolToInt(enabled), id)
+	if err != nil {
+		return fmt.Errorf("update event webhook %d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("update event webhook %d: %w", id, driven.ErrEventWebhookNotFound)
+	}
+	return nil
+}
+
+// Delete removes a webhook; its deliveries go with it by cascade.
+func (r *EventWebhookRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.Writer.ExecContext(ctx, `DELETE FROM event_webhooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete event webhook %d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("delete event webhook %d: %w", id, driven.ErrEventWebhookNotFound)
+	}
+	return nil
+}
+
+// AddDelivery inserts a delivery and returns its ID.
+func (r *EventWebhookRepo) AddDelivery(ctx context.Context, d model.WebhookDelivery) (int64, error) {
+	const query = `
+		INSERT INTO webhook_deliveries (webhook_id, event, repo_full_name, pr_number, payload,
+			status, attempts, response_status, last_error, next_attempt_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	createdAt := d.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query,
+		d.WebhookID, string(d.Event), d.RepoFullName, d.PRNumber, d.Payload,
+		string(d.Status), d.Attempts, d.ResponseStatus, d.LastError, d.NextAttemptAt.UTC(), createdAt.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("add %s delivery for webhook %d: %w", d.Event, d.WebhookID, err)
+	}
+	return result.LastInsertId()
+}
+
+// UpdateDelivery saves a delivery's outcome.
+func (r *EventWebhookRepo) UpdateDelivery(ctx context.Context, d model.WebhookDelivery) error {
+	const query = `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, response_status = ?, last_error = ?, next_attempt_at = ?, delivered_at = ?
+		WHERE id = ?`
+
+	var deliveredAt any
+	if !d.DeliveredAt.IsZero() {
+		deliveredAt = d.DeliveredAt.UTC()
+	}
+	if _, err := r.db.Writer.ExecContext(ctx, query,
+		string(d.Status), d.Attempts, d.ResponseStatus, d.LastError, d.NextAttemptAt.UTC(), deliveredAt, d.ID); err != nil {
+		return fmt.Errorf("update webhook delivery %d: %w", d.ID, err)
+	}
+	return nil
+}
+
+// ListDeliveries returns a webhook's deliveries newest first, at most limit.
+func (r *EventWebhookRepo) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]model.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?`
+	return r.queryDeliveries(ctx, "list webhook deliveries", query, webhookID, limit)
+}
+
+// ListDueDeliveries returns pending deliveries due at or before now, oldest first.
+func (r *EventWebhookRepo) ListDueDeliveries(ctx context.Context, now time.Time) ([]model.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id`
+	return r.queryDeliveries(ctx, "list due webhook deliveries", query, string(model.WebhookDeliveryPending), now.UTC())
+}
+
+// PruneDeliveries deletes finished deliveries created before cutoff.
+func (r *EventWebhookRepo) PruneDeliveries(ctx context.Context, cutoff time.Time) (int, error) {
+	res, err := r.db.Writer.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE status != ? AND created_at < ?`,
+		string(model.WebhookDeliveryPending), cutoff.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("prune webhook deliveries: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("check rows affected: %w", err)
+	}
+	return int(n), nil
+}
+
+// queryDeliveries runs a SELECT of webhookDeliveryColumns and scans every row.
+func (r *EventWebhookRepo) queryDeliveries(ctx context.Context, op, query string, args ...any) ([]model.WebhookDelivery, error) {
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var deliveries []model.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate: %w", op, err)
+	}
+	return deliveries, nil
+}
+
+// scanEventWebhook scans a single event_webhooks row from the given scanner.
+func scanEventWebhook(s scanner) (model.EventWebhook, error) {
+	var hook model.EventWebhook
+	var events, createdAt string
+	if err := s.Scan(&hook.ID, &hook.URL, &hook.Secret, &events, &hook.RepoFullName, &hook.Enabled, &createdAt); err != nil {
+		return model.EventWebhook{}, err
+	}
+	for _, e := range strings.Split(events, ",") {
+		if e != "" {
+			hook.Events = append(hook.Events, model.WebhookEventType(e))
+		}
+	}
+
+	var err error
+	if hook.CreatedAt, err = parseTime(createdAt); err != nil {
+		return model.EventWebhook{}, fmt.Errorf("parse created_at for event webhook %d: %w", hook.ID, err)
+	}
+	return hook, nil
+}
+
+// scanWebhookDelivery scans a single webhook_deliveries row from the given scanner.
+func scanWebhookDelivery(s scanner) (model.WebhookDelivery, error) {
+	var d model.WebhookDelivery
+	var event, status, nextAttemptAt, createdAt string
+	var deliveredAt sql.NullString
+
+	if err := s.Scan(&d.ID, &d.WebhookID, &event, &d.RepoFullName, &d.PRNumber, &d.Payload,
+		&status, &d.Attempts, &d.ResponseStatus, &d.LastError, &nextAttemptAt, &createdAt, &deliveredAt); err != nil {
+		return model.WebhookDelivery{}, err
+	}
+	d.Event = model.WebhookEventType(event)
+	d.Status = model.WebhookDeliveryStatus(status)
+
+	var err error
+	if d.NextAttemptAt, err = parseTime(nextAttemptAt); err != nil {
+		return model.WebhookDelivery{}, fmt.Errorf("parse next_attempt_at for webhook delivery %d: %w", d.ID, err)
+	}
+	if d.CreatedAt, err = parseTime(createdAt); err != nil {
+		return model.WebhookDelivery{}, fmt.Errorf("parse created_at for webhook delivery %d: %w", d.ID, err)
+	}
+	if deliveredAt.Valid {
+		if d.DeliveredAt, err = parseTime(deliveredAt.String); err != nil {
+			return model.WebhookDelivery{}, fmt.Errorf("parse delivered_at for webhook delivery %d: %w", d.ID, err)
+		}
+	}
+	return d, nil
+}
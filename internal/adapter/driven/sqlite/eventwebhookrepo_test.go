@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventWebhookRepo_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewEventWebhookRepo(db)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, model.EventWebhook{
+		URL:          "https://ci.example.com/hook",
+		Secret:       "s3cret",
+		Events:       []model.WebhookEventType{model.WebhookPRCreated, model.WebhookCheckFailed},
+		RepoFullName: "acme/api",
+		Enabled:      true,
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.EventWebhook{URL: "https://bot.example.com", Secret: "x"})
+	require.NoError(t, err)
+
+	hooks, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, hooks, 2)
+	assert.Equal(t, []model.WebhookEventType{model.WebhookPRCreated, model.WebhookCheckFailed}, hooks[0].Events)
+	assert.Equal(t, "s3cret", hooks[0].Secret)
+	assert.Equal(t, "acme/api", hooks[0].RepoFullName)
+	assert.True(t, hooks[0].Enabled)
+	assert.Empty(t, hooks[1].Events, "no events subscribes to all")
+	assert.False(t, hooks[1].Enabled)
+
+	got, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "https://ci.example.com/hook", got.URL)
+	got, err = repo.Get(ctx, 999)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	require.NoError(t, repo.SetEnabled(ctx, id, false))
+	got, err = repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.False(t, got.Enabled)
+	assert.ErrorIs(t, repo.SetEnabled(ctx, 999, true), driven.ErrEventWebhookNotFound)
+
+	require.NoError(t, repo.Delete(ctx, id))
+	assert.ErrorIs(t, repo.Delete(ctx, id), driven.ErrEventWebhookNotFound)
+}
+
+func TestEventWebhookRepo_Deliveries(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewEventWebhookRepo(db)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	hookID, err := repo.Create(ctx, model.EventWebhook{URL: "https://ci.example.com/hook", Secret: "s", Enabled: true})
+	require.NoError(t, err)
+
+	add := func(createdAt, next time.Time, status model.WebhookDeliveryStatus) int64 {
+		id, err := repo.AddDelivery(ctx, model.WebhookDelivery{
+			WebhookID: hookID, Event: model.WebhookReviewSubmitted, RepoFullName: "acme/api", PRNumber: 7,
+			Payload: `{"event":"review.submitted"}`, Status: status, NextAttemptAt: next, CreatedAt: createdAt,
+		})
+		require.NoError(t, err)
+		return id
+	}
+	old := add(now.Add(-10*24*time.Hour), now.Add(-10*24*time.Hour), model.WebhookDeliveryDelivered)
+	due := add(now.Add(-time.Hour), now.Add(-time.Minute), model.WebhookDeliveryPending)
+	add(now.Add(-time.Minute), now.Add(time.Hour), model.WebhookDeliveryPending)
+
+	dueList, err := repo.ListDueDeliveries(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, dueList, 1)
+	assert.Equal(t, due, dueList[0].ID)
+	assert.Equal(t, `{"event":"review.submitted"}`, dueList[0].Payload)
+	assert.True(t, dueList[0].DeliveredAt.IsZero())
+
+	delivered := dueList[0]
+	delivered.Status = model.WebhookDeliveryDelivered
+	delivered.Attempts = 2
+	delivered.ResponseStatus = 204
+	delivered.DeliveredAt = now
+	require.NoError(t, repo.UpdateDelivery(ctx, delivered))
+
+	list, err := repo.ListDeliveries(ctx, hookID, 10)
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, due, list[1].ID, "newest first")
+	assert.Equal(t, 204, list[1].ResponseStatus)
+	assert.Equal(t, 2, list[1].Attempts)
+	assert.Equal(t, now, list[1].DeliveredAt)
+
+	n, err := repo.PruneDeliveries(ctx, now.Add(-7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	list, err = repo.ListDeliveries(ctx, hookID, 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.NotEqual(t, old, list[1].ID)
+
+	require.NoError(t, repo.Delete(ctx, hookID))
+	list, err = repo.ListDeliveries(ctx, hookID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, list, "deliveries go with their webhook")
+}
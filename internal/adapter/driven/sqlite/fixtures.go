@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// ApplyFixtures runs the SQL statements in the file at path in a single
+// transaction, typically to seed a memory database after migrations. Nothing
+// is applied if any statement fails.
+func ApplyFixtures(ctx context.Context, db *DB, path string) error {
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read fixtures: %w", err)
+	}
+
+	tx, err := db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after commit is a no-op.
+
+	if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+		return fmt.Errorf("apply fixtures from %s: %w", path, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit fixtures: %w", err)
+	}
+	return nil
+}
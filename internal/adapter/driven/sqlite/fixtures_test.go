@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyFixtures_MemoryDB(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewMemoryDB(ctx, t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, RunMigrations(db.Writer))
+
+	path := filepath.Join(t.TempDir(), "fixtures.sql")
+	require.NoError(t, os.WriteFile(path, []byte(`
+		INSERT INTO repositories (full_name, owner, name) VALUES ('org/api', 'org', 'api');
+		INSERT INTO repositories (full_name, owner, name) VALUES ('org/web', 'org', 'web');
+	`), 0o600))
+	require.NoError(t, ApplyFixtures(ctx, db, path))
+
+	repos, err := NewRepoRepo(db).ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, repos, 2, "the reader sees the writer's memory database")
+
+	bad := filepath.Join(t.TempDir(), "bad.sql")
+	require.NoError(t, os.WriteFile(bad, []byte(`
+		INSERT INTO repositories (full_name, owner, name) VALUES ('org/cli', 'org', 'cli');
+		INSERT INTO no_such_table VALUES (1);
+	`), 0o600))
+	require.Error(t, ApplyFixtures(ctx, db, bad))
+
+	repos, err = NewRepoRepo(db).ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, repos, 2, "a failed fixture file applies nothing")
+}
@@ -0,0 +1,71 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.FocusStore = (*FocusRepo)(nil)
+
+// FocusRepo is the SQLite implementation of the FocusStore port interface.
+type FocusRepo struct {
+	db *DB
+}
+
+// NewFocusRepo creates a new FocusRepo backed by the given DB.
+func NewFocusRepo(db *DB) *FocusRepo {
+	return &FocusRepo{db: db}
+}
+
+// SetFocusMark records mark, replacing the PR's mark for the same day.
+func (r *FocusRepo) SetFocusMark(ctx context.Context, mark model.FocusMark) error {
+	const query = `
+		INSERT INTO focus_marks (pr_id, day, state, marked_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT(pr_id, day) DO UPDATE SET state = excluded.state, marked_at = excluded.marked_at`
+	if _, err := r.db.Writer.ExecContext(ctx, query, mark.PRID, mark.Day, string(mark.State), mark.MarkedAt.UTC()); err != nil {
+		return fmt.Errorf("set focus mark of PR %d on %s: %w", mark.PRID, mark.Day, err)
+	}
+	return nil
+}
+
+// ClearFocusMark removes a PR's mark for day, if any.
+func (r *FocusRepo) ClearFocusMark(ctx context.Context, prID int64, day string) error {
+	if _, err := r.db.Writer.ExecContext(ctx, `DELETE FROM focus_marks WHERE pr_id = ? AND day = ?`, prID, day); err != nil {
+		return fmt.Errorf("clear focus mark of PR %d on %s: %w", prID, day, err)
+	}
+	return nil
+}
+
+// ListFocusMarks returns the marks of the day since and every later day,
+// oldest day first.
+func (r *FocusRepo) ListFocusMarks(ctx context.Context, since string) ([]model.FocusMark, error) {
+	const query = `SELECT pr_id, day, state, marked_at FROM focus_marks WHERE day >= ? ORDER BY day, pr_id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("list focus marks since %s: %w", since, err)
+	}
+	defer rows.Close()
+
+	var marks []model.FocusMark
+	for rows.Next() {
+		var m model.FocusMark
+		var state, markedAt string
+		if err := rows.Scan(&m.PRID, &m.Day, &state, &markedAt); err != nil {
+			return nil, fmt.Errorf("scan focus mark: %w", err)
+		}
+		m.State = model.FocusState(state)
+		if m.MarkedAt, err = parseTime(markedAt); err != nil {
+			return nil, fmt.Errorf("parse marked_at of PR %d: %w", m.PRID, err)
+		}
+		marks = append(marks, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate focus marks: %w", err)
+	}
+	return marks, nil
+}
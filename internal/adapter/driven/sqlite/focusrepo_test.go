@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestFocusRepo_SetListClear(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewFocusRepo(db)
+	ctx := context.Background()
+	first := addTestPR(t, db, "octocat/hello-world", 1)
+	second := addTestPR(t, db, "octocat/other", 2)
+	at := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.SetFocusMark(ctx, model.FocusMark{PRID: first, Day: "2026-10-15", State: model.FocusSkipped, MarkedAt: at.Add(-24 * time.Hour)}))
+	require.NoError(t, repo.SetFocusMark(ctx, model.FocusMark{PRID: first, Day: "2026-10-16", State: model.FocusSkipped, MarkedAt: at}))
+	require.NoError(t, repo.SetFocusMark(ctx, model.FocusMark{PRID: first, Day: "2026-10-16", State: model.FocusDone, MarkedAt: at.Add(time.Hour)}))
+	require.NoError(t, repo.SetFocusMark(ctx, model.FocusMark{PRID: second, Day: "2026-10-16", State: model.FocusSkipped, MarkedAt: at}))
+
+	marks, err := repo.ListFocusMarks(ctx, "2026-10-16")
+	require.NoError(t, err)
+	assert.Equal(t, []model.FocusMark{
+		{PRID: first, Day: "2026-10-16", State: model.FocusDone, MarkedAt: at.Add(time.Hour)},
+		{PRID: second, Day: "2026-10-16", State: model.FocusSkipped, MarkedAt: at},
+	}, marks, "a new mark replaces the day's old one")
+
+	marks, err = repo.ListFocusMarks(ctx, "2026-10-01")
+	require.NoError(t, err)
+	assert.Len(t, marks, 3)
+
+	require.NoError(t, repo.ClearFocusMark(ctx, first, "2026-10-16"))
+	require.NoError(t, repo.ClearFocusMark(ctx, first, "2026-10-16"), "clearing twice is a no-op")
+	marks, err = repo.ListFocusMarks(ctx, "2026-10-16")
+	require.NoError(t, err)
+	require.Len(t, marks, 1)
+	assert.Equal(t, second, marks[0].PRID)
+}
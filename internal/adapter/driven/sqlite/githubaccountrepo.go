@@ -0,0 +1,210 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Compile-time interface satisfaction checks.
+var _ driven.GitHubAccountStore = (*GitHubAccountRepo)(nil)
+var _ driven.GitHubRepoAccountStore = (*GitHubAccountRepo)(nil)
+
+// GitHubAccountRepo is the SQLite implementation of the GitHubAccountStore and
+// GitHubRepoAccountStore port interfaces. Tokens are encrypted with AES-256-GCM
+// before write and decrypted after read.
+type GitHubAccountRepo struct {
+	db  *DB
+	key []byte // 32-byte AES-256 key; nil when encryption is disabled.
+}
+
+// NewGitHubAccountRepo creates a new GitHubAccountRepo. key must be exactly 32
+// bytes for AES-256-GCM, or nil to disable account storage (token operations
+// return ErrEncryptionKeyNotSet). Panics if key is non-nil with wrong length.
+func NewGitHubAccountRepo(db *DB, key []byte) *GitHubAccountRepo {
+	if key != nil && len(key) != 32 {
+		panic(fmt.Errorf("invalid AES-256 key length: got %d, want 32", len(key)))
+	}
+	return &GitHubAccountRepo{db: db, key: key}
+}
+
+// Create persists a new GitHub account and returns the assigned ID.
+func (r *GitHubAccountRepo) Create(ctx context.Context, account model.GitHubAccount) (int64, error) {
+	encrypted, err := encryptAES(r.key, account.Token)
+	if err != nil {
+		return 0, err
+	}
+
+	const query = `INSERT INTO github_accounts (name, username, token) VALUES (?, ?, ?)`
+	result, err := r.db.Writer.ExecContext(ctx, query, account.Name, account.Username, encrypted)
+	if err != nil {
+		var se *sqlite.Error
+		if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return 0, fmt.Errorf("create github account %q: %w", account.Name, driven.ErrGitHubAccountExists)
+		}
+		return 0, fmt.Errorf("create github account %q: %w", account.Name, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create github account %q: last insert id: %w", account.Name, err)
+	}
+	return id, nil
+}
+
+// Delete removes a GitHub account by ID. FK cascade removes its repo assignments.
+func (r *GitHubAccountRepo) Delete(ctx context.Context, id int64) error {
+	const query = `DELETE FROM github_accounts WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete github account %d: %w", id, err)
+	}
+	return nil
+}
+
+// List returns all GitHub accounts with decrypted tokens, ordered by name.
+func (r *GitHubAccountRepo) List(ctx context.Context) ([]model.GitHubAccount, error) {
+	if r.key == nil {
+		return nil, driven.ErrEncryptionKeyNotSet
+	}
+
+	const query = `SELECT id, name, username, token, created_at, updated_at
+		FROM github_accounts ORDER BY name`
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list github accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []model.GitHubAccount
+	for rows.Next() {
+		account, err := r.scanAccount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan github account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate github accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// GetForRepo returns the account assigned to the repository.
+// Returns a zero-value GitHubAccount (ID==0) and nil error if none is assigned.
+func (r *GitHubAccountRepo) GetForRepo(ctx context.Context, repoFullName string) (model.GitHubAccount, error) {
+	if r.key == nil {
+		return model.GitHubAccount{}, driven.ErrEncryptionKeyNotSet
+	}
+
+	const query = `
+		SELECT ga.id, ga.name, ga.username, ga.token, ga.created_at, ga.updated_at
+		FROM github_accounts ga
+		JOIN repo_github_account rga ON rga.github_account_id = ga.id
+		WHERE rga.repo_full_name = ?`
+
+	account, err := r.scanAccount(r.db.Reader.QueryRowContext(ctx, query, repoFullName))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.GitHubAccount{}, nil
+	}
+	if err != nil {
+		return model.GitHubAccount{}, fmt.Errorf("get github account for repo %s: %w", repoFullName, err)
+	}
+	return account, nil
+}
+
+// GetRepoAccounts returns the assigned account ID for each given repo in a single query.
+// Repos with no assignment map to 0.
+func (r *GitHubAccountRepo) GetRepoAccounts(ctx context.Context, repoFullNames []string) (map[string]int64, error) {
+	result := make(map[string]int64, len(repoFullNames))
+	if len(repoFullNames) == 0 {
+		return result, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(repoFullNames))
+	placeholders = placeholders[:len(placeholders)-1]
+
+	args := make([]any, len(repoFullNames))
+	for i, name := range repoFullNames {
+		args[i] = name
+		result[name] = 0
+	}
+
+	//nolint:gosec // placeholders contains only comma-separated "?" literals, never user input
+	query := fmt.Sprintf(
+		`SELECT repo_full_name, github_account_id FROM repo_github_account WHERE repo_full_name IN (%s)`,
+		placeholders,
+	)
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("get repo github accounts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var repo string
+		var accountID int64
+		if err := rows.Scan(&repo, &accountID); err != nil {
+			return nil, fmt.Errorf("scan repo github account: %w", err)
+		}
+		result[repo] = accountID
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate repo github accounts: %w", err)
+	}
+	return result, nil
+}
+
+// SetRepoAccount assigns a repository to a GitHub account.
+// Pass accountID=0 to clear the assignment.
+func (r *GitHubAccountRepo) SetRepoAccount(ctx context.Context, repoFullName string, accountID int64) error {
+	if accountID == 0 {
+		const query = `DELETE FROM repo_github_account WHERE repo_full_name = ?`
+		if _, err := r.db.Writer.ExecContext(ctx, query, repoFullName); err != nil {
+			return fmt.Errorf("clear repo github account %s: %w", repoFullName, err)
+		}
+		return nil
+	}
+
+	const query = `INSERT INTO repo_github_account (repo_full_name, github_account_id) VALUES (?, ?)
+		ON CONFLICT(repo_full_name) DO UPDATE SET github_account_id = excluded.github_account_id`
+	if _, err := r.db.Writer.ExecContext(ctx, query, repoFullName, accountID); err != nil {
+		return fmt.Errorf("set repo github account %s -> %d: %w", repoFullName, accountID, err)
+	}
+	return nil
+}
+
+// scanAccount scans a single github_accounts row from the given scanner.
+func (r *GitHubAccountRepo) scanAccount(s scanner) (model.GitHubAccount, error) {
+	var account model.GitHubAccount
+	var encrypted, createdAt, updatedAt string
+
+	err := s.Scan(&account.ID, &account.Name, &account.Username, &encrypted, &createdAt, &updatedAt)
+	if err != nil {
+		return model.GitHubAccount{}, err
+	}
+
+	account.Token, err = decryptAES(r.key, encrypted)
+	if err != nil {
+		return model.GitHubAccount{}, fmt.Errorf("decrypt token for github account %d: %w", account.ID, err)
+	}
+
+	account.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return model.GitHubAccount{}, fmt.Errorf("parse created_at for github account %d: %w", account.ID, err)
+	}
+
+	account.UpdatedAt, err = parseTime(updatedAt)
+	if err != nil {
+		return model.GitHubAccount{}, fmt.Errorf("parse updated_at for github account %d: %w", account.ID, err)
+	}
+
+	return account, nil
+}
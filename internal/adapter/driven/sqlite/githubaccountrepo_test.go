@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAccountRepo_CreateAndList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGitHubAccountRepo(db, testKey())
+	ctx := context.Background()
+
+	workID, err := repo.Create(ctx, model.GitHubAccount{Name: "work", Username: "octo-work", Token: "ghp_work"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.GitHubAccount{Name: "personal", Username: "octocat", Token: "ghp_personal"})
+	require.NoError(t, err)
+
+	accounts, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "personal", accounts[0].Name, "accounts should be ordered by name")
+	assert.Equal(t, workID, accounts[1].ID)
+	assert.Equal(t, "octo-work", accounts[1].Username)
+	assert.Equal(t, "ghp_work", accounts[1].Token, "token should be decrypted on read")
+
+	_, err = repo.Create(ctx, model.GitHubAccount{Name: "work", Token: "ghp_other"})
+	assert.ErrorIs(t, err, driven.ErrGitHubAccountExists)
+}
+
+func TestGitHubAccountRepo_RepoAssignment(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGitHubAccountRepo(db, testKey())
+	ctx := context.Background()
+	addTestRepo(t, db, "acme/api")
+	addTestRepo(t, db, "octocat/dotfiles")
+
+	got, err := repo.GetForRepo(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got.ID, "unassigned repo should return zero-value account")
+
+	id, err := repo.Create(ctx, model.GitHubAccount{Name: "work", Token: "ghp_work"})
+	require.NoError(t, err)
+	require.NoError(t, repo.SetRepoAccount(ctx, "acme/api", id))
+
+	got, err = repo.GetForRepo(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, id, got.ID)
+	assert.Equal(t, "ghp_work", got.Token)
+
+	assignments, err := repo.GetRepoAccounts(ctx, []string{"acme/api", "octocat/dotfiles"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"acme/api": id, "octocat/dotfiles": 0}, assignments)
+
+	// Deleting the account falls the repo back to the default token.
+	require.NoError(t, repo.Delete(ctx, id))
+	got, err = repo.GetForRepo(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got.ID)
+}
+
+func TestGitHubAccountRepo_ClearAssignment(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewGitHubAccountRepo(db, testKey())
+	ctx := context.Background()
+	addTestRepo(t, db, "acme/api")
+
+	id, err := repo.Create(ctx, model.GitHubAccount{Name: "work", Token: "ghp_work"})
+	require.NoError(t, err)
+	require.NoError(t, repo.SetRepoAccount(ctx, "acme/api", id))
+	require.NoError(t, repo.SetRepoAccount(ctx, "acme/api", 0))
+
+	got, err := repo.GetForRepo(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got.ID)
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.HTTPCacheStore = (*HTTPCacheRepo)(nil)
+
+// HTTPCacheRepo is the SQLite implementation of the HTTPCacheStore port interface.
+type HTTPCacheRepo struct {
+	db *DB
+}
+
+// NewHTTPCacheRepo creates a new HTTPCacheRepo backed by the given DB.
+func NewHTTPCacheRepo(db *DB) *HTTPCacheRepo {
+	return &HTTPCacheRepo{db: db}
+}
+
+// GetResponse returns the cached response for key.
+func (r *HTTPCacheRepo) GetResponse(ctx context.Context, key string) ([]byte, bool, error) {
+	const query = `SELECT response FROM http_cache WHERE cache_key = ?`
+
+	var response []byte
+	err := r.db.Reader.QueryRowContext(ctx, query, key).Scan(&response)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("get cached response: %w", err)
+	}
+	return response, true, nil
+}
+
+// SetResponse stores or replaces the response for key.
+func (r *HTTPCacheRepo) SetResponse(ctx context.Context, key string, response []byte) error {
+	const query = `
+		INSERT INTO http_cache (cache_key, response, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (cache_key) DO UPDATE SET response = excluded.response, updated_at = excluded.updated_at`
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, key, response, time.Now().UTC()); err != nil {
+		return fmt.Errorf("set cached response: %w", err)
+	}
+	return nil
+}
+
+// DeleteResponse removes the response for key.
+func (r *HTTPCacheRepo) DeleteResponse(ctx context.Context, key string) error {
+	const query = `DELETE FROM http_cache WHERE cache_key = ?`
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, key); err != nil {
+		return fmt.Errorf("delete cached response: %w", err)
+	}
+	return nil
+}
+
+// PruneResponses deletes responses last stored before cutoff.
+func (r *HTTPCacheRepo) PruneResponses(ctx context.Context, cutoff time.Time) (int64, error) {
+	const query = `DELETE FROM http_cache WHERE updated_at < ?`
+
+	result, err := r.db.Writer.ExecContext(ctx, query, cutoff.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("prune cached responses: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("prune cached responses: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPCacheRepo(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewHTTPCacheRepo(db)
+	ctx := context.Background()
+
+	_, ok, err := repo.GetResponse(ctx, "https://api.github.com/repos/o/r/pulls")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, repo.SetResponse(ctx, "https://api.github.com/repos/o/r/pulls", []byte("v1")))
+	require.NoError(t, repo.SetResponse(ctx, "https://api.github.com/repos/o/r/pulls", []byte("v2")))
+	got, ok, err := repo.GetResponse(ctx, "https://api.github.com/repos/o/r/pulls")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("v2"), got)
+
+	require.NoError(t, repo.SetResponse(ctx, "https://api.github.com/repos/o/other", []byte("x")))
+	require.NoError(t, repo.DeleteResponse(ctx, "https://api.github.com/repos/o/other"))
+	_, ok, err = repo.GetResponse(ctx, "https://api.github.com/repos/o/other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	n, err := repo.PruneResponses(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, n, "fresh responses are kept")
+	n, err = repo.PruneResponses(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
@@ -20,10 +20,12 @@ func NewIgnoreRepo(db *DB) *IgnoreRepo {
 	return &IgnoreRepo{db: db}
 }
 
-// Ignore marks a PR as ignored. Idempotent — silently succeeds if already ignored.
-func (r *IgnoreRepo) Ignore(ctx context.Context, prID int64) error {
-	const query = `INSERT OR IGNORE INTO ignored_prs (pr_id) VALUES (?)`
-	_, err := r.db.Writer.ExecContext(ctx, query, prID)
+// Ignore marks a PR as ignored with an optional reason. Idempotent —
+// ignoring an ignored PR keeps its ignored_at and replaces its reason.
+func (r *IgnoreRepo) Ignore(ctx context.Context, prID int64, reason string) error {
+	const query = `INSERT INTO ignored_prs (pr_id, reason) VALUES (?, ?)
+		ON CONFLICT(pr_id) DO UPDATE SET reason = excluded.reason`
+	_, err := r.db.Writer.ExecContext(ctx, query, prID, reason)
 	if err != nil {
 		return fmt.Errorf("ignore PR %d: %w", prID, err)
 	}
@@ -52,7 +54,7 @@ func (r *IgnoreRepo) IsIgnored(ctx context.Context, prID int64) (bool, error) {
 
 // ListIgnored returns all ignored PRs ordered by ignored_at DESC.
 func (r *IgnoreRepo) ListIgnored(ctx context.Context) ([]driven.IgnoredPR, error) {
-	const query = `SELECT pr_id, ignored_at FROM ignored_prs ORDER BY ignored_at DESC`
+	const query = `SELECT pr_id, reason, ignored_at FROM ignored_prs ORDER BY ignored_at DESC`
 	rows, err := r.db.Reader.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("list ignored PRs: %w", err)
@@ -63,7 +65,7 @@ func (r *IgnoreRepo) ListIgnored(ctx context.Context) ([]driven.IgnoredPR, error
 	for rows.Next() {
 		var item driven.IgnoredPR
 		var ignoredAt string
-		if err := rows.Scan(&item.PRID, &ignoredAt); err != nil {
+		if err := rows.Scan(&item.PRID, &item.Reason, &ignoredAt); err != nil {
 			return nil, fmt.Errorf("scan ignored PR: %w", err)
 		}
 		item.IgnoredAt, err = parseTime(ignoredAt)
@@ -28,7 +28,7 @@ func TestIgnoreRepo_IgnoreAndIsIgnored(t *testing.T) {
 	repo := NewIgnoreRepo(db)
 	ctx := context.Background()
 
-	err := repo.Ignore(ctx, prID)
+	err := repo.Ignore(ctx, prID, "")
 	require.NoError(t, err)
 
 	ignored, err := repo.IsIgnored(ctx, prID)
@@ -42,7 +42,7 @@ func TestIgnoreRepo_UnignoreAndIsIgnored(t *testing.T) {
 	repo := NewIgnoreRepo(db)
 	ctx := context.Background()
 
-	require.NoError(t, repo.Ignore(ctx, prID))
+	require.NoError(t, repo.Ignore(ctx, prID, ""))
 	require.NoError(t, repo.Unignore(ctx, prID))
 
 	ignored, err := repo.IsIgnored(ctx, prID)
@@ -56,9 +56,9 @@ func TestIgnoreRepo_DoubleIgnore_Idempotent(t *testing.T) {
 	repo := NewIgnoreRepo(db)
 	ctx := context.Background()
 
-	require.NoError(t, repo.Ignore(ctx, prID))
+	require.NoError(t, repo.Ignore(ctx, prID, ""))
 	// Second Ignore should not return an error.
-	err := repo.Ignore(ctx, prID)
+	err := repo.Ignore(ctx, prID, "")
 	require.NoError(t, err)
 
 	ignored, err := repo.IsIgnored(ctx, prID)
@@ -66,6 +66,27 @@ func TestIgnoreRepo_DoubleIgnore_Idempotent(t *testing.T) {
 	assert.True(t, ignored)
 }
 
+func TestIgnoreRepo_Ignore_ReplacesReason(t *testing.T) {
+	db := setupTestDB(t)
+	prID := addTestPR(t, db, testRepoFullName, 1)
+	repo := NewIgnoreRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Ignore(ctx, prID, "waiting on upstream"))
+	list, err := repo.ListIgnored(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "waiting on upstream", list[0].Reason)
+	ignoredAt := list[0].IgnoredAt
+
+	require.NoError(t, repo.Ignore(ctx, prID, "draft forever"))
+	list, err = repo.ListIgnored(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "draft forever", list[0].Reason)
+	assert.Equal(t, ignoredAt, list[0].IgnoredAt, "re-ignoring keeps the original timestamp")
+}
+
 func TestIgnoreRepo_ListIgnored_OrderedByDesc(t *testing.T) {
 	db := setupTestDB(t)
 	// Add the repo once, then insert 3 PRs.
@@ -76,9 +97,9 @@ func TestIgnoreRepo_ListIgnored_OrderedByDesc(t *testing.T) {
 	repo := NewIgnoreRepo(db)
 	ctx := context.Background()
 
-	require.NoError(t, repo.Ignore(ctx, prID1))
-	require.NoError(t, repo.Ignore(ctx, prID2))
-	require.NoError(t, repo.Ignore(ctx, prID3))
+	require.NoError(t, repo.Ignore(ctx, prID1, ""))
+	require.NoError(t, repo.Ignore(ctx, prID2, ""))
+	require.NoError(t, repo.Ignore(ctx, prID3, ""))
 
 	list, err := repo.ListIgnored(ctx)
 	require.NoError(t, err)
@@ -108,8 +129,8 @@ func TestIgnoreRepo_ListIgnoredIDs(t *testing.T) {
 	repo := NewIgnoreRepo(db)
 	ctx := context.Background()
 
-	require.NoError(t, repo.Ignore(ctx, prID1))
-	require.NoError(t, repo.Ignore(ctx, prID2))
+	require.NoError(t, repo.Ignore(ctx, prID1, ""))
+	require.NoError(t, repo.Ignore(ctx, prID2, ""))
 
 	ids, err := repo.ListIgnoredIDs(ctx)
 	require.NoError(t, err)
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.IgnoreRuleStore = (*IgnoreRuleRepo)(nil)
+
+// IgnoreRuleRepo is the SQLite implementation of the IgnoreRuleStore port interface.
+type IgnoreRuleRepo struct {
+	db *DB
+}
+
+// NewIgnoreRuleRepo creates a new IgnoreRuleRepo backed by the given DB.
+func NewIgnoreRuleRepo(db *DB) *IgnoreRuleRepo {
+	return &IgnoreRuleRepo{db: db}
+}
+
+// Create persists a rule and returns the assigned ID.
+func (r *IgnoreRuleRepo) Create(ctx context.Context, rule model.IgnoreRule) (int64, error) {
+	const query = `INSERT INTO ignore_rules (name, expression, enabled, created_at) VALUES (?, ?, ?, ?)`
+
+	createdAt := rule.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query, rule.Name, rule.Expression, boolToInt(rule.Enabled), createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create ignore rule %q: %w", rule.Name, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create ignore rule %q: last insert id: %w", rule.Name, err)
+	}
+	return id, nil
+}
+
+// Update replaces a rule's editable fields.
+func (r *IgnoreRuleRepo) Update(ctx context.Context, rule model.IgnoreRule) error {
+	const query = `UPDATE ignore_rules SET name = ?, expression = ?, enabled = ? WHERE id = ?`
+
+	res, err := r.db.Writer.ExecContext(ctx, query, rule.Name, rule.Expression, boolToInt(rule.Enabled), rule.ID)
+	if err != nil {
+		return fmt.Errorf("update ignore rule %d: %w", rule.ID, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("update ignore rule %d: %w", rule.ID, driven.ErrIgnoreRuleNotFound)
+	}
+	return nil
+}
+
+// Delete removes a rule by ID.
+func (r *IgnoreRuleRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.Writer.ExecContext(ctx, `DELETE FROM ignore_rules WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete ignore rule %d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("delete ignore rule %d: %w", id, driven.ErrIgnoreRuleNotFound)
+	}
+	return nil
+}
+
+// List returns all rules ordered by creation.
+func (r *IgnoreRuleRepo) List(ctx context.Context) ([]model.IgnoreRule, error) {
+	const query = `SELECT id, name, expression, enabled, created_at FROM ignore_rules ORDER BY created_at, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list ignore rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []model.IgnoreRule
+	for rows.Next() {
+		var rule model.IgnoreRule
+		var createdAt string
+		if err := rows.Scan(&rule.ID, &rule.Name, &rule.Expression, &rule.Enabled, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan ignore rule: %w", err)
+		}
+		if rule.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for ignore rule %d: %w", rule.ID, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate ignore rules: %w", err)
+	}
+	return rules, nil
+}
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreRuleRepo_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewIgnoreRuleRepo(db)
+	ctx := context.Background()
+
+	rules, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	id, err := repo.Create(ctx, model.IgnoreRule{Name: "Bots", Expression: `author =~ "\\[bot\\]$"`, Enabled: true})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.IgnoreRule{Name: "WIP", Expression: `labels contains "wip"`})
+	require.NoError(t, err)
+
+	rules, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, id, rules[0].ID)
+	assert.Equal(t, `author =~ "\\[bot\\]$"`, rules[0].Expression)
+	assert.True(t, rules[0].Enabled)
+	assert.False(t, rules[0].CreatedAt.IsZero())
+	assert.False(t, rules[1].Enabled)
+
+	updated := rules[0]
+	updated.Enabled = false
+	updated.Name = "Dependency bots"
+	require.NoError(t, repo.Update(ctx, updated))
+	rules, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.False(t, rules[0].Enabled)
+	assert.Equal(t, "Dependency bots", rules[0].Name)
+
+	assert.ErrorIs(t, repo.Update(ctx, model.IgnoreRule{ID: 999}), driven.ErrIgnoreRuleNotFound)
+	require.NoError(t, repo.Delete(ctx, id))
+	assert.ErrorIs(t, repo.Delete(ctx, id), driven.ErrIgnoreRuleNotFound)
+	rules, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+}
@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.InboxStore = (*InboxRepo)(nil)
+
+// InboxRepo is the SQLite implementation of the InboxStore port interface.
+type InboxRepo struct {
+	db *DB
+}
+
+// NewInboxRepo creates a new InboxRepo backed by the given DB.
+func NewInboxRepo(db *DB) *InboxRepo {
+	return &InboxRepo{db: db}
+}
+
+// Add inserts an event, ignoring duplicates of the same kind, PR, and source.
+func (r *InboxRepo) Add(ctx context.Context, e model.InboxEvent) (bool, error) {
+	const query = `
+		INSERT OR IGNORE INTO inbox_events (kind, repo_full_name, pr_number, pr_title, actor, summary, source_id, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	occurredAt := e.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query,
+		string(e.Kind), e.RepoFullName, e.PRNumber, e.PRTitle, e.Actor, e.Summary, e.SourceID, occurredAt.UTC(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("add inbox event %s for %s#%d: %w", e.Kind, e.RepoFullName, e.PRNumber, err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("add inbox event %s for %s#%d: rows affected: %w", e.Kind, e.RepoFullName, e.PRNumber, err)
+	}
+	return n > 0, nil
+}
+
+// List returns events newest first.
+func (r *InboxRepo) List(ctx context.Context, unreadOnly bool, limit int) ([]model.InboxEvent, error) {
+	query := `SELECT id, kind, repo_full_name, pr_number, pr_title, actor, summary, source_id, occurred_at, read_at
+		FROM inbox_events`
+	if unreadOnly {
+		query += ` WHERE read_at IS NULL`
+	}
+	query += ` ORDER BY occurred_at DESC, id DESC LIMIT ?`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list inbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []model.InboxEvent
+	for rows.Next() {
+		e, err := scanInboxEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan inbox event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate inbox events: %w", err)
+	}
+	return events, nil
+}
+
+// UnreadCount returns the number of unread events.
+func (r *InboxRepo) UnreadCount(ctx context.Context) (int, error) {
+	var n int
+	if err := r.db.Reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM inbox_events WHERE read_at IS NULL`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count unread inbox events: %w", err)
+	}
+	return n, nil
+}
+
+// SetRead marks an event read or unread.
+func (r *InboxRepo) SetRead(ctx context.Context, id int64, read bool) error {
+	var readAt any
+	if read {
+		readAt = time.Now().UTC()
+	}
+	const query = `UPDATE inbox_events SET read_at = ? WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, readAt, id); err != nil {
+		return fmt.Errorf("set inbox event %d read=%t: %w", id, read, err)
+	}
+	return nil
+}
+
+// MarkAllRead marks every unread event read.
+func (r *InboxRepo) MarkAllRead(ctx context.Context) error {
+	const query = `UPDATE inbox_events SET read_at = ? WHERE read_at IS NULL`
+	if _, err := r.db.Writer.ExecContext(ctx, query, time.Now().UTC()); err != nil {
+		return fmt.Errorf("mark all inbox events read: %w", err)
+	}
+	return nil
+}
+
+// scanInboxEvent scans a single inbox_events row from the given scanner.
+func scanInboxEvent(s scanner) (model.InboxEvent, error) {
+	var e model.InboxEvent
+	var kind, occurredAt string
+	var readAt sql.NullString
+
+	if err := s.Scan(&e.ID, &kind, &e.RepoFullName, &e.PRNumber, &e.PRTitle, &e.Actor, &e.Summary, &e.SourceID, &occurredAt, &readAt); err != nil {
+		return model.InboxEvent{}, err
+	}
+	e.Kind = model.InboxEventKind(kind)
+
+	var err error
+	if e.OccurredAt, err = parseTime(occurredAt); err != nil {
+		return model.InboxEvent{}, fmt.Errorf("parse occurred_at for inbox event %d: %w", e.ID, err)
+	}
+	if readAt.Valid {
+		t, err := parseTime(readAt.String)
+		if err != nil {
+			return model.InboxEvent{}, fmt.Errorf("parse read_at for inbox event %d: %w", e.ID, err)
+		}
+		e.ReadAt = &t
+	}
+	return e, nil
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInboxRepo_AddDeduplicates(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewInboxRepo(db)
+	ctx := context.Background()
+
+	event := model.InboxEvent{
+		Kind:         model.InboxApprovalReceived,
+		RepoFullName: "octocat/hello-world",
+		PRNumber:     7,
+		PRTitle:      "Add greeting",
+		Actor:        "hubot",
+		SourceID:     "101",
+		OccurredAt:   time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	added, err := repo.Add(ctx, event)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = repo.Add(ctx, event)
+	require.NoError(t, err)
+	assert.False(t, added, "same kind, PR, and source is recorded once")
+
+	event.SourceID = "102"
+	added, err = repo.Add(ctx, event)
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
+func TestInboxRepo_ReadState(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewInboxRepo(db)
+	ctx := context.Background()
+
+	base := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	for i, kind := range []model.InboxEventKind{model.InboxReviewRequested, model.InboxCIFailed, model.InboxNewComment} {
+		_, err := repo.Add(ctx, model.InboxEvent{Kind: kind, RepoFullName: "octocat/hello-world", PRNumber: i + 1, OccurredAt: base.Add(time.Duration(i) * time.Hour)})
+		require.NoError(t, err)
+	}
+
+	events, err := repo.List(ctx, false, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.Equal(t, model.InboxNewComment, events[0].Kind, "newest first")
+	assert.Equal(t, base.Add(2*time.Hour), events[0].OccurredAt)
+	assert.False(t, events[0].IsRead())
+
+	count, err := repo.UnreadCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	require.NoError(t, repo.SetRead(ctx, events[0].ID, true))
+	unread, err := repo.List(ctx, true, 10)
+	require.NoError(t, err)
+	assert.Len(t, unread, 2)
+
+	all, err := repo.List(ctx, false, 1)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.True(t, all[0].IsRead())
+
+	require.NoError(t, repo.SetRead(ctx, events[0].ID, false))
+	count, err = repo.UnreadCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	require.NoError(t, repo.MarkAllRead(ctx))
+	count, err = repo.UnreadCount(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
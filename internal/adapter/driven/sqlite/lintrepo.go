@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.LintStore = (*LintRepo)(nil)
+
+// LintRepo is the SQLite implementation of the LintStore port interface.
+type LintRepo struct {
+	db *DB
+}
+
+// NewLintRepo creates a new LintRepo backed by the given DB.
+func NewLintRepo(db *DB) *LintRepo {
+	return &LintRepo{db: db}
+}
+
+// ListRules returns a repository's rules in creation order.
+func (r *LintRepo) ListRules(ctx context.Context, repoFullName string) ([]model.LintRule, error) {
+	const query = `
+		SELECT id, repo_full_name, target, pattern, description
+		FROM lint_rules
+		WHERE repo_full_name = ?
+		ORDER BY id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, repoFullName)
+	if err != nil {
+		return nil, fmt.Errorf("list lint rules for %s: %w", repoFullName, err)
+	}
+	defer rows.Close()
+
+	var rules []model.LintRule
+	for rows.Next() {
+		var rule model.LintRule
+		var target string
+		if err := rows.Scan(&rule.ID, &rule.RepoFullName, &target, &rule.Pattern, &rule.Description); err != nil {
+			return nil, fmt.Errorf("scan lint rule: %w", err)
+		}
+		rule.Target = model.LintTarget(target)
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lint rules: %w", err)
+	}
+	return rules, nil
+}
+
+// ReplaceRules deletes a repository's rules, cascading to their violations,
+// and inserts rules in one transaction. Returns driven.ErrRepoNotFound if the
+// repository is not watched.
+func (r *LintRepo) ReplaceRules(ctx context.Context, repoFullName string, rules []model.LintRule) ([]model.LintRule, error) {
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin replace lint rules: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lint_rules WHERE repo_full_name = ?`, repoFullName); err != nil {
+		return nil, fmt.Errorf("delete lint rules for %s: %w", repoFullName, err)
+	}
+
+	const insert = `INSERT INTO lint_rules (repo_full_name, target, pattern, description) VALUES (?, ?, ?, ?)`
+	saved := make([]model.LintRule, 0, len(rules))
+	for _, rule := range rules {
+		res, err := tx.ExecContext(ctx, insert, repoFullName, string(rule.Target), rule.Pattern, rule.Description)
+		if err != nil {
+			var se *sqlite.Error
+			if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+				return nil, fmt.Errorf("insert lint rule for %s: %w", repoFullName, driven.ErrRepoNotFound)
+			}
+			return nil, fmt.Errorf("insert lint rule for %s: %w", repoFullName, err)
+		}
+		if rule.ID, err = res.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("get lint rule id: %w", err)
+		}
+		rule.RepoFullName = repoFullName
+		saved = append(saved, rule)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit replace lint rules: %w", err)
+	}
+	return saved, nil
+}
+
+// SetViolations replaces the rules a PR fails in one transaction.
+func (r *LintRepo) SetViolations(ctx context.Context, prID int64, ruleIDs []int64) error {
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin set lint violations: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lint_violations WHERE pr_id = ?`, prID); err != nil {
+		return fmt.Errorf("delete lint violations for PR %d: %w", prID, err)
+	}
+	for _, id := range ruleIDs {
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO lint_violations (pr_id, rule_id) VALUES (?, ?)`, prID, id); err != nil {
+			return fmt.Errorf("insert lint violation for PR %d: %w", prID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit lint violations: %w", err)
+	}
+	return nil
+}
+
+// ViolationsByPR returns the failed rules of open PRs, keyed by PR ID.
+func (r *LintRepo) ViolationsByPR(ctx context.Context, repoFullName string) (map[int64][]model.LintRule, error) {
+	const query = `
+		SELECT v.pr_id, lr.id, lr.repo_full_name, lr.target, lr.pattern, lr.description
+		FROM lint_violations v
+		INNER JOIN lint_rules lr ON lr.id = v.rule_id
+		INNER JOIN pull_requests pr ON pr.id = v.pr_id
+		WHERE pr.status = 'open'
+		  AND pr.repo_full_name IN ` + watchedRepos + `
+		  AND (? = '' OR pr.repo_full_name = ?)
+		ORDER BY v.pr_id, lr.id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, repoFullName, repoFullName)
+	if err != nil {
+		return nil, fmt.Errorf("list lint violations: %w", err)
+	}
+	defer rows.Close()
+
+	result := make(map[int64][]model.LintRule)
+	for rows.Next() {
+		var prID int64
+		var rule model.LintRule
+		var target string
+		if err := rows.Scan(&prID, &rule.ID, &rule.RepoFullName, &target, &rule.Pattern, &rule.Description); err != nil {
+			return nil, fmt.Errorf("scan lint violation: %w", err)
+		}
+		rule.Target = model.LintTarget(target)
+		result[prID] = append(result[prID], rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate lint violations: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+func TestLintRepo_RulesAndViolations(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	addTestRepo(t, db, testRepoFullName)
+	repo := NewLintRepo(db)
+
+	prRepo := NewPRRepo(db)
+	require.NoError(t, prRepo.Upsert(ctx, makePR(testRepoFullName, 1, "Open PR", model.PRStatusOpen)))
+	require.NoError(t, prRepo.Upsert(ctx, makePR(testRepoFullName, 2, "Merged PR", model.PRStatusMerged)))
+	open, err := prRepo.GetByNumber(ctx, testRepoFullName, 1)
+	require.NoError(t, err)
+	merged, err := prRepo.GetByNumber(ctx, testRepoFullName, 2)
+	require.NoError(t, err)
+
+	rules, err := repo.ReplaceRules(ctx, testRepoFullName, []model.LintRule{
+		{Target: model.LintTargetTitle, Pattern: `^feat: `, Description: "Conventional Commits"},
+		{Target: model.LintTargetBranch, Pattern: `^[A-Z]+-\d+`},
+	})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+
+	listed, err := repo.ListRules(ctx, testRepoFullName)
+	require.NoError(t, err)
+	assert.Equal(t, rules, listed)
+
+	require.NoError(t, repo.SetViolations(ctx, open.ID, []int64{rules[0].ID, rules[1].ID}))
+	require.NoError(t, repo.SetViolations(ctx, merged.ID, []int64{rules[0].ID}))
+	require.NoError(t, repo.SetViolations(ctx, open.ID, []int64{rules[1].ID}))
+
+	byPR, err := repo.ViolationsByPR(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[int64][]model.LintRule{open.ID: {rules[1]}}, byPR, "violations are replaced and closed PRs left out")
+
+	_, err = repo.ReplaceRules(ctx, testRepoFullName, nil)
+	require.NoError(t, err)
+	byPR, err = repo.ViolationsByPR(ctx, testRepoFullName)
+	require.NoError(t, err)
+	assert.Empty(t, byPR, "removing rules drops their violations")
+
+	_, err = repo.ReplaceRules(ctx, "other/repo", []model.LintRule{{Target: model.LintTargetTitle, Pattern: "x"}})
+	assert.ErrorIs(t, err, driven.ErrRepoNotFound)
+}
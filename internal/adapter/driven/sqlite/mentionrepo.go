@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.MentionStore = (*MentionRepo)(nil)
+
+// MentionRepo is the SQLite implementation of the MentionStore port interface.
+type MentionRepo struct {
+	db *DB
+}
+
+// NewMentionRepo creates a new MentionRepo backed by the given DB.
+func NewMentionRepo(db *DB) *MentionRepo {
+	return &MentionRepo{db: db}
+}
+
+// SetMentions replaces a comment's mentions in a single transaction.
+func (r *MentionRepo) SetMentions(ctx context.Context, source model.MentionSource, commentID int64, mentions []model.Mention) error {
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after commit is a no-op.
+
+	const deleteQuery = `DELETE FROM mentions WHERE source = ? AND comment_id = ?`
+	if _, err := tx.ExecContext(ctx, deleteQuery, string(source), commentID); err != nil {
+		return fmt.Errorf("clear mentions for %s %d: %w", source, commentID, err)
+	}
+
+	const insertQuery = `
+		INSERT OR IGNORE INTO mentions (source, comment_id, pr_id, author, login, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+	for _, m := range mentions {
+		if _, err := tx.ExecContext(ctx, insertQuery, string(source), commentID, m.PRID, m.Author, m.Login, m.CreatedAt.UTC()); err != nil {
+			return fmt.Errorf("insert mention of %s in %s %d: %w", m.Login, source, commentID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit mentions for %s %d: %w", source, commentID, err)
+	}
+	return nil
+}
+
+// GetMentionsByPR returns a PR's mentions ordered by created_at.
+func (r *MentionRepo) GetMentionsByPR(ctx context.Context, prID int64) ([]model.Mention, error) {
+	const query = `
+		SELECT source, comment_id, pr_id, author, login, created_at
+		FROM mentions WHERE pr_id = ?
+		ORDER BY created_at ASC, comment_id ASC`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, prID)
+	if err != nil {
+		return nil, fmt.Errorf("get mentions for PR %d: %w", prID, err)
+	}
+	defer rows.Close()
+
+	var mentions []model.Mention
+	for rows.Next() {
+		var m model.Mention
+		var source, createdAt string
+		if err := rows.Scan(&source, &m.CommentID, &m.PRID, &m.Author, &m.Login, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan mention: %w", err)
+		}
+		m.Source = model.MentionSource(source)
+		if m.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for mention in %s %d: %w", source, m.CommentID, err)
+		}
+		mentions = append(mentions, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate mentions: %w", err)
+	}
+	return mentions, nil
+}
+
+// ListPRIDsMentioning returns distinct PR IDs; the login column is NOCASE.
+func (r *MentionRepo) ListPRIDsMentioning(ctx context.Context, login string) ([]int64, error) {
+	rows, err := r.db.Reader.QueryContext(ctx, `SELECT DISTINCT pr_id FROM mentions WHERE login = ? ORDER BY pr_id`, login)
+	if err != nil {
+		return nil, fmt.Errorf("list PRs mentioning %s: %w", login, err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan mentioned PR id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate mentioned PR ids: %w", err)
+	}
+	return ids, nil
+}
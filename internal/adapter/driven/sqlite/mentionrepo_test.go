@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMentionRepo_SetAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	prID := addTestPR(t, db, "octocat/hello-world", 1)
+	otherPRID := addTestPR(t, db, "octocat/other", 2)
+	repo := NewMentionRepo(db)
+	ctx := context.Background()
+
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	mention := func(prID, commentID int64, login string, offset time.Duration) model.Mention {
+		return model.Mention{PRID: prID, Source: model.MentionInIssueComment, CommentID: commentID, Author: "alice", Login: login, CreatedAt: at.Add(offset)}
+	}
+
+	require.NoError(t, repo.SetMentions(ctx, model.MentionInIssueComment, 11, []model.Mention{
+		mention(prID, 11, "Octocat", time.Hour),
+		mention(prID, 11, "bob", time.Hour),
+	}))
+	require.NoError(t, repo.SetMentions(ctx, model.MentionInIssueComment, 10, []model.Mention{
+		mention(prID, 10, "carol", 0),
+	}))
+	require.NoError(t, repo.SetMentions(ctx, model.MentionInIssueComment, 20, []model.Mention{
+		mention(otherPRID, 20, "bob", 0),
+	}))
+
+	got, err := repo.GetMentionsByPR(ctx, prID)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, "carol", got[0].Login, "oldest first")
+	assert.Equal(t, at, got[0].CreatedAt)
+	assert.Equal(t, model.MentionInIssueComment, got[0].Source)
+
+	ids, err := repo.ListPRIDsMentioning(ctx, "OCTOCAT")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{prID}, ids, "logins match case-insensitively")
+
+	ids, err = repo.ListPRIDsMentioning(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{prID, otherPRID}, ids)
+
+	// An edit that drops the mentions replaces the comment's rows.
+	require.NoError(t, repo.SetMentions(ctx, model.MentionInIssueComment, 11, nil))
+	ids, err = repo.ListPRIDsMentioning(ctx, "octocat")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	// Mentions go away with their PR.
+	require.NoError(t, NewPRRepo(db).Delete(ctx, "octocat/other", 2))
+	ids, err = repo.ListPRIDsMentioning(ctx, "bob")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
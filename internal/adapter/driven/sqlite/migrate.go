@@ -1,40 +1,306 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	migratesqlite "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 )
 
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
+// MigrationState describes how far a database's schema is from the migrations
+// embedded in the binary.
+type MigrationState struct {
+	Current uint // 0 when no migration has been applied
+	Latest  uint
+	Dirty   bool // a previous migration failed partway through
+}
+
+// Pending reports whether migrations remain to be applied.
+func (s MigrationState) Pending() bool {
+	return s.Current < s.Latest
+}
+
+// Schema compatibility errors returned by RunMigrations and MigrationState.Check.
+var (
+	// ErrSchemaTooNew means the database was migrated by a newer mygitpanel
+	// than the running binary, so queries may reference columns it lacks.
+	ErrSchemaTooNew = errors.New("database schema is newer than this binary")
+
+	// ErrSchemaDirty means a previous migration failed partway through.
+	ErrSchemaDirty = errors.New("database schema is dirty")
+
+	// ErrSchemaNotInitialized means the database has no schema_migrations
+	// table: no migration has ever run against it.
+	ErrSchemaNotInitialized = errors.New("database schema is not initialized")
+)
+
+// Check reports whether the running binary can safely use a database in this
+// state. It wraps ErrSchemaTooNew or ErrSchemaDirty with a message that says
+// what to do about it.
+func (s MigrationState) Check() error {
+	switch {
+	case s.Dirty:
+		return fmt.Errorf("%w: migration %d failed partway through; restore the backup taken before it, "+
+			"or repair the schema by hand and run mygitpanel migrate force", ErrSchemaDirty, s.Current)
+	case s.Current > s.Latest:
+		return fmt.Errorf("%w: database is at schema version %d but this binary only knows up to %d; "+
+			"it was upgraded by a newer mygitpanel release, so run that release or restore a backup taken before the upgrade",
+			ErrSchemaTooNew, s.Current, s.Latest)
+	}
+	return nil
+}
+
 // RunMigrations applies all pending database migrations embedded in the binary.
 // It is safe to call on every startup; already-applied migrations are skipped.
+// A database migrated by a newer binary, or left dirty by a failed migration,
+// is rejected before anything is applied (see MigrationState.Check).
 func RunMigrations(db *sql.DB) error {
+	m, _, err := checkedMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("run migrations: %w", err)
+	}
+
+	return nil
+}
+
+// MigrateTo applies up or down migrations until the schema is at version;
+// 0 reverts every migration. Like RunMigrations it refuses a dirty database
+// or one migrated by a newer binary.
+func MigrateTo(db *sql.DB, version uint) error {
+	m, state, err := checkedMigrator(db)
+	if err != nil {
+		return err
+	}
+	if version > state.Latest {
+		return fmt.Errorf("migrate to %d: this binary only knows up to schema version %d", version, state.Latest)
+	}
+
+	if version == 0 {
+		err = m.Down()
+	} else {
+		err = m.Migrate(version)
+	}
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("migrate to %d: %w", version, err)
+	}
+	return nil
+}
+
+// RollbackMigrations reverts the last steps applied migrations.
+func RollbackMigrations(db *sql.DB, steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("roll back %d migrations: steps must be at least 1", steps)
+	}
+	m, state, err := checkedMigrator(db)
+	if err != nil {
+		return err
+	}
+	if uint(steps) > state.Current {
+		return fmt.Errorf("roll back %d migrations: only %d applied", steps, state.Current)
+	}
+
+	if err := m.Steps(-steps); err != nil {
+		return fmt.Errorf("roll back %d migrations: %w", steps, err)
+	}
+	return nil
+}
+
+// ForceMigrationVersion records version as applied and clears the dirty flag
+// without running any migration. It is the way out of ErrSchemaDirty once the
+// schema has been repaired by hand or restored from a backup.
+func ForceMigrationVersion(db *sql.DB, version uint) error {
+	m, sourceDriver, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+	latest, err := latestVersion(sourceDriver)
+	if err != nil {
+		return err
+	}
+	if version > latest {
+		return fmt.Errorf("force version %d: this binary only knows up to schema version %d", version, latest)
+	}
+
+	// migrate treats -1 as "no migration applied".
+	forced := int(version)
+	if version == 0 {
+		forced = -1
+	}
+	if err := m.Force(forced); err != nil {
+		return fmt.Errorf("force version %d: %w", version, err)
+	}
+	return nil
+}
+
+// MigrationBackupPath names the backup taken before migrating the database at
+// dbPath away from schema version, such as mygitpanel.db.v39-20261017T093000Z.bak.
+func MigrationBackupPath(dbPath string, version uint, at time.Time) string {
+	return fmt.Sprintf("%s.v%d-%s.bak", dbPath, version, at.UTC().Format("20060102T150405Z"))
+}
+
+// checkedMigrator builds a migrator after checking, as RunMigrations does,
+// that the schema is safe to migrate.
+func checkedMigrator(db *sql.DB) (*migrate.Migrate, MigrationState, error) {
+	m, sourceDriver, err := newMigrator(db)
+	if err != nil {
+		return nil, MigrationState{}, err
+	}
+	state, err := readMigrationState(m, sourceDriver)
+	if err != nil {
+		return nil, MigrationState{}, err
+	}
+	if err := state.Check(); err != nil {
+		return nil, MigrationState{}, err
+	}
+	return m, state, nil
+}
+
+// GetMigrationState reports the applied schema version without applying any migrations.
+func GetMigrationState(db *sql.DB) (MigrationState, error) {
+	m, sourceDriver, err := newMigrator(db)
+	if err != nil {
+		return MigrationState{}, err
+	}
+	return readMigrationState(m, sourceDriver)
+}
+
+// ReadMigrationState reports the applied schema version like
+// GetMigrationState, but only reads from db. The migrate driver creates
+// schema_migrations when it is missing, which a diagnostic must not do, so
+// this queries the table directly and returns ErrSchemaNotInitialized when it
+// does not exist.
+func ReadMigrationState(ctx context.Context, db *sql.DB) (MigrationState, error) {
+	var tables int
+	const tableQuery = `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`
+	if err := db.QueryRowContext(ctx, tableQuery).Scan(&tables); err != nil {
+		return MigrationState{}, fmt.Errorf("look up schema_migrations: %w", err)
+	}
+	if tables == 0 {
+		return MigrationState{}, ErrSchemaNotInitialized
+	}
+
+	var state MigrationState
+	var version int64
+	err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &state.Dirty)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		// No row means no migration is applied.
+	case err != nil:
+		return MigrationState{}, fmt.Errorf("read schema version: %w", err)
+	case version > 0:
+		state.Current = uint(version)
+	}
+
+	sourceDriver, err := iofs.New(migrationsFS, "migrations")
+	if err != nil {
+		return MigrationState{}, fmt.Errorf("create migration source: %w", err)
+	}
+	if state.Latest, err = latestVersion(sourceDriver); err != nil {
+		return MigrationState{}, err
+	}
+	return state, nil
+}
+
+// readMigrationState reads the applied version and the latest embedded one.
+func readMigrationState(m *migrate.Migrate, sourceDriver source.Driver) (MigrationState, error) {
+	var state MigrationState
+
+	current, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return MigrationState{}, fmt.Errorf("read schema version: %w", err)
+	}
+	state.Current = current
+	state.Dirty = dirty
+
+	latest, err := latestVersion(sourceDriver)
+	if err != nil {
+		return MigrationState{}, err
+	}
+	state.Latest = latest
+
+	return state, nil
+}
+
+// EmbeddedMigrations lists the migrations embedded in the binary in version
+// order. Descriptions come from the file names, so
+// 000019_add_branch_protection.up.sql is described as "add branch protection".
+func EmbeddedMigrations() ([]model.SchemaMigration, error) {
+	files, err := fs.Glob(migrationsFS, "migrations/*.up.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+
+	result := make([]model.SchemaMigration, 0, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), ".up.sql")
+		num, desc, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(num, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse migration version of %s: %w", file, err)
+		}
+		result = append(result, model.SchemaMigration{
+			Version:     uint(version),
+			Description: strings.ReplaceAll(desc, "_", " "),
+		})
+	}
+	return result, nil
+}
+
+// newMigrator builds a migrate instance over the embedded migrations.
+func newMigrator(db *sql.DB) (*migrate.Migrate, source.Driver, error) {
 	sourceDriver, err := iofs.New(migrationsFS, "migrations")
 	if err != nil {
-		return fmt.Errorf("create migration source: %w", err)
+		return nil, nil, fmt.Errorf("create migration source: %w", err)
 	}
 
 	dbDriver, err := migratesqlite.WithInstance(db, &migratesqlite.Config{})
 	if err != nil {
-		return fmt.Errorf("create migration db driver: %w", err)
+		return nil, nil, fmt.Errorf("create migration db driver: %w", err)
 	}
 
 	m, err := migrate.NewWithInstance("iofs", sourceDriver, "sqlite", dbDriver)
 	if err != nil {
-		return fmt.Errorf("create migrator: %w", err)
+		return nil, nil, fmt.Errorf("create migrator: %w", err)
 	}
 
-	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-		return fmt.Errorf("run migrations: %w", err)
+	return m, sourceDriver, nil
+}
+
+// latestVersion walks the migration source to find the highest version.
+func latestVersion(sourceDriver source.Driver) (uint, error) {
+	version, err := sourceDriver.First()
+	if err != nil {
+		return 0, fmt.Errorf("read first migration: %w", err)
 	}
 
-	return nil
+	for {
+		next, err := sourceDriver.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read migration after %d: %w", version, err)
+		}
+		version = next
+	}
 }
@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestGetMigrationState_UpToDate(t *testing.T) {
+	db := setupTestDB(t)
+
+	state, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+
+	assert.Positive(t, state.Latest)
+	assert.Equal(t, state.Latest, state.Current)
+	assert.False(t, state.Dirty)
+	assert.False(t, state.Pending())
+}
+
+func TestGetMigrationState_FreshDatabase(t *testing.T) {
+	raw, err := sql.Open("sqlite", "file:fresh_migration_state?mode=memory&cache=shared")
+	require.NoError(t, err)
+	raw.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = raw.Close() })
+
+	state, err := GetMigrationState(raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(0), state.Current)
+	assert.Positive(t, state.Latest)
+	assert.True(t, state.Pending())
+}
+
+func TestReadMigrationState(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+
+	want, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+	state, err := ReadMigrationState(ctx, db.Reader)
+	require.NoError(t, err)
+	assert.Equal(t, want, state)
+
+	_, err = db.Writer.Exec(`UPDATE schema_migrations SET dirty = 1`)
+	require.NoError(t, err)
+	state, err = ReadMigrationState(ctx, db.Reader)
+	require.NoError(t, err)
+	assert.True(t, state.Dirty)
+}
+
+func TestReadMigrationState_FreshDatabase(t *testing.T) {
+	ctx := context.Background()
+	raw, err := sql.Open("sqlite", "file:fresh_read_migration_state?mode=memory&cache=shared")
+	require.NoError(t, err)
+	raw.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = raw.Close() })
+
+	_, err = ReadMigrationState(ctx, raw)
+	require.ErrorIs(t, err, ErrSchemaNotInitialized)
+
+	var tables int
+	require.NoError(t, raw.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'`).Scan(&tables))
+	assert.Zero(t, tables, "reading the state creates nothing")
+}
+
+func TestRunMigrations_RejectsNewerSchema(t *testing.T) {
+	db := setupTestDB(t)
+
+	state, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+
+	// Simulate a database upgraded by a future release.
+	_, err = db.Writer.Exec(`UPDATE schema_migrations SET version = ?`, state.Latest+3)
+	require.NoError(t, err)
+
+	err = RunMigrations(db.Writer)
+	require.ErrorIs(t, err, ErrSchemaTooNew)
+	assert.Contains(t, err.Error(), "newer mygitpanel release")
+
+	state, err = GetMigrationState(db.Writer)
+	require.NoError(t, err)
+	assert.Equal(t, state.Latest+3, state.Current, "a rejected database is left untouched")
+}
+
+func TestMigrationStateCheck(t *testing.T) {
+	assert.NoError(t, MigrationState{Current: 0, Latest: 19}.Check())
+	assert.NoError(t, MigrationState{Current: 19, Latest: 19}.Check())
+	assert.ErrorIs(t, MigrationState{Current: 20, Latest: 19}.Check(), ErrSchemaTooNew)
+	assert.ErrorIs(t, MigrationState{Current: 19, Latest: 19, Dirty: true}.Check(), ErrSchemaDirty)
+}
+
+func TestNewReadOnlyDB_RejectsWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "readonly.db")
+	ctx := context.Background()
+
+	db, err := NewDB(ctx, path)
+	require.NoError(t, err)
+	require.NoError(t, RunMigrations(db.Writer))
+	require.NoError(t, NewRepoRepo(db).Add(ctx, model.Repository{FullName: testRepoFullName}))
+	require.NoError(t, db.Close())
+
+	ro, err := NewReadOnlyDB(ctx, path)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ro.Close() })
+
+	repos, err := NewRepoRepo(ro).ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, repos, 1)
+
+	err = NewRepoRepo(ro).Add(ctx, model.Repository{FullName: "owner/other"})
+	assert.Error(t, err)
+}
+
+func TestNewReadOnlyDB_OpensSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	db, err := NewDB(ctx, filepath.Join(dir, "live.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, RunMigrations(db.Writer))
+	require.NoError(t, NewRepoRepo(db).Add(ctx, model.Repository{FullName: testRepoFullName}))
+
+	// A VACUUM INTO copy is not in WAL mode and must stay untouched.
+	snapshot := filepath.Join(dir, "snapshot.db")
+	require.NoError(t, db.Backup(ctx, snapshot))
+
+	ro, err := NewReadOnlyDB(ctx, snapshot)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ro.Close() })
+
+	repos, err := NewRepoRepo(ro).ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, repos, 1)
+	assert.Error(t, NewRepoRepo(ro).Add(ctx, model.Repository{FullName: "owner/other"}))
+
+	_, err = NewReadOnlyDB(ctx, filepath.Join(dir, "missing.db"))
+	assert.Error(t, err, "read-only mode never creates a database")
+}
+
+func TestMigrateTo_RoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+
+	state, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+
+	// Every down migration must undo its up migration cleanly, so stopping
+	// at any version and migrating back up works.
+	for v := int(state.Latest) - 1; v >= 0; v-- {
+		require.NoError(t, MigrateTo(db.Writer, uint(v)), "down to %d", v)
+		down, err := GetMigrationState(db.Writer)
+		require.NoError(t, err)
+		assert.Equal(t, uint(v), down.Current)
+
+		require.NoError(t, MigrateTo(db.Writer, state.Latest), "up from %d", v)
+		up, err := GetMigrationState(db.Writer)
+		require.NoError(t, err)
+		assert.Equal(t, state.Latest, up.Current)
+	}
+
+	assert.Error(t, MigrateTo(db.Writer, state.Latest+1), "unknown versions are rejected")
+}
+
+func TestRollbackMigrations(t *testing.T) {
+	db := setupTestDB(t)
+
+	state, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+
+	require.NoError(t, RollbackMigrations(db.Writer, 2))
+	after, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+	assert.Equal(t, state.Latest-2, after.Current)
+	assert.True(t, after.Pending())
+
+	assert.Error(t, RollbackMigrations(db.Writer, 0))
+	assert.Error(t, RollbackMigrations(db.Writer, int(state.Latest)), "cannot roll back more than applied")
+}
+
+func TestForceMigrationVersion_ClearsDirty(t *testing.T) {
+	db := setupTestDB(t)
+
+	state, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+	_, err = db.Writer.Exec(`UPDATE schema_migrations SET dirty = 1`)
+	require.NoError(t, err)
+	require.ErrorIs(t, RunMigrations(db.Writer), ErrSchemaDirty)
+
+	require.NoError(t, ForceMigrationVersion(db.Writer, state.Latest))
+	forced, err := GetMigrationState(db.Writer)
+	require.NoError(t, err)
+	assert.False(t, forced.Dirty)
+	assert.Equal(t, state.Latest, forced.Current)
+	assert.NoError(t, RunMigrations(db.Writer))
+
+	assert.Error(t, ForceMigrationVersion(db.Writer, state.Latest+1))
+}
+
+func TestDBBackup(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	db, err := NewDB(ctx, filepath.Join(dir, "source.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+	require.NoError(t, RunMigrations(db.Writer))
+	require.NoError(t, NewRepoRepo(db).Add(ctx, model.Repository{FullName: testRepoFullName}))
+
+	dest := MigrationBackupPath(filepath.Join(dir, "source.db"), 40, time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC))
+	assert.Equal(t, filepath.Join(dir, "source.db.v40-20261017T093000Z.bak"), dest)
+	require.NoError(t, db.Backup(ctx, dest))
+	assert.Error(t, db.Backup(ctx, dest), "an existing backup is never overwritten")
+
+	backup, err := NewDB(ctx, dest)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = backup.Close() })
+	repos, err := NewRepoRepo(backup).ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, repos, 1)
+}
@@ -1,2 +1 @@
--- SQLite does not support DROP COLUMN in older versions; migration is irreversible.
-SELECT 1;
+ALTER TABLE pull_requests DROP COLUMN jira_key;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS pr_links (
+    pr_id        INTEGER  NOT NULL,
+    linked_pr_id INTEGER  NOT NULL,
+    linked_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (pr_id, linked_pr_id),
+    CHECK (pr_id < linked_pr_id),
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE,
+    FOREIGN KEY (linked_pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_links_linked_pr_id ON pr_links(linked_pr_id);
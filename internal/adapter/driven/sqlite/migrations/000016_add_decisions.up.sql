@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS decisions (
+    id              INTEGER  PRIMARY KEY AUTOINCREMENT,
+    repo_full_name  TEXT     NOT NULL,
+    pr_number       INTEGER  NOT NULL,
+    pr_title        TEXT     NOT NULL DEFAULT '',
+    root_comment_id INTEGER  NOT NULL UNIQUE,
+    file_path       TEXT     NOT NULL DEFAULT '',
+    summary         TEXT     NOT NULL,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_decisions_repo_pr ON decisions(repo_full_name, pr_number);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_github_account;
+DROP TABLE IF EXISTS github_accounts;
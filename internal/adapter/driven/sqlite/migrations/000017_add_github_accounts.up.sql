@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS github_accounts (
+    id         INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name       TEXT     NOT NULL UNIQUE,
+    username   TEXT     NOT NULL DEFAULT '',
+    token      TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS repo_github_account (
+    repo_full_name    TEXT    NOT NULL PRIMARY KEY,
+    github_account_id INTEGER NOT NULL,
+    FOREIGN KEY (repo_full_name) REFERENCES repositories(full_name) ON DELETE CASCADE,
+    FOREIGN KEY (github_account_id) REFERENCES github_accounts(id) ON DELETE CASCADE
+);
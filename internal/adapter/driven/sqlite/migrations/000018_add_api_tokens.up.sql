@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id           INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name         TEXT     NOT NULL,
+    token_hash   TEXT     NOT NULL UNIQUE,
+    prefix       TEXT     NOT NULL,
+    scope        TEXT     NOT NULL CHECK (scope IN ('read', 'write')),
+    created_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at DATETIME
+);
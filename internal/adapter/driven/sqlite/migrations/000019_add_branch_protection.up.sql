@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS branch_protection (
+    repo_full_name        TEXT     NOT NULL,
+    branch                TEXT     NOT NULL,
+    dismiss_stale_reviews INTEGER  NOT NULL DEFAULT 0,
+    updated_at            DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (repo_full_name, branch),
+    FOREIGN KEY (repo_full_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN paused INTEGER NOT NULL DEFAULT 0;
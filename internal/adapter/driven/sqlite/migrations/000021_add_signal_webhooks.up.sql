@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS signal_webhooks (
+    id             INTEGER  PRIMARY KEY AUTOINCREMENT,
+    signal         TEXT     NOT NULL,
+    url            TEXT     NOT NULL,
+    repo_full_name TEXT     NOT NULL DEFAULT '',
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
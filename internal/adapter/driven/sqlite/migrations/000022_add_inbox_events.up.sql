@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS inbox_events (
+    id             INTEGER  PRIMARY KEY AUTOINCREMENT,
+    kind           TEXT     NOT NULL,
+    repo_full_name TEXT     NOT NULL,
+    pr_number      INTEGER  NOT NULL,
+    pr_title       TEXT     NOT NULL DEFAULT '',
+    actor          TEXT     NOT NULL DEFAULT '',
+    summary        TEXT     NOT NULL DEFAULT '',
+    source_id      TEXT     NOT NULL DEFAULT '',
+    occurred_at    DATETIME NOT NULL,
+    read_at        DATETIME,
+    UNIQUE (kind, repo_full_name, pr_number, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_inbox_events_occurred ON inbox_events (occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_inbox_events_unread ON inbox_events (read_at) WHERE read_at IS NULL;
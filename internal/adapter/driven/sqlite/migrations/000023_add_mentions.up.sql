@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS mentions (
+    source     TEXT     NOT NULL,
+    comment_id INTEGER  NOT NULL,
+    pr_id      INTEGER  NOT NULL,
+    author     TEXT     NOT NULL,
+    login      TEXT     NOT NULL COLLATE NOCASE,
+    created_at DATETIME NOT NULL,
+    PRIMARY KEY (source, comment_id, login),
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_mentions_pr_id ON mentions (pr_id);
+CREATE INDEX IF NOT EXISTS idx_mentions_login ON mentions (login);
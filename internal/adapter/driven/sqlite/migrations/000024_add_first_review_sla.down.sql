@@ -0,0 +1 @@
+ALTER TABLE repo_thresholds DROP COLUMN first_review_sla_hours;
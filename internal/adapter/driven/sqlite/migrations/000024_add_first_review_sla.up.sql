@@ -0,0 +1 @@
+ALTER TABLE repo_thresholds ADD COLUMN first_review_sla_hours INTEGER;
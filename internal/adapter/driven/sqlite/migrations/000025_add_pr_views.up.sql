@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS pr_views (
+    pr_id     INTEGER  PRIMARY KEY,
+    viewed_at DATETIME NOT NULL,
+    head_sha  TEXT     NOT NULL DEFAULT '',
+    ci_status TEXT     NOT NULL DEFAULT 'unknown',
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE branch_protection DROP COLUMN require_code_owner_reviews;
+ALTER TABLE branch_protection DROP COLUMN required_approvals;
+ALTER TABLE branch_protection DROP COLUMN requires_reviews;
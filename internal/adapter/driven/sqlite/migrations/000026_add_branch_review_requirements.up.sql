@@ -0,0 +1,3 @@
+ALTER TABLE branch_protection ADD COLUMN requires_reviews INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE branch_protection ADD COLUMN required_approvals INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE branch_protection ADD COLUMN require_code_owner_reviews INTEGER NOT NULL DEFAULT 0;
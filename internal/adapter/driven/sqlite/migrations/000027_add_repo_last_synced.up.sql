@@ -0,0 +1 @@
+ALTER TABLE repositories ADD COLUMN last_synced_at DATETIME;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS outbound_actions (
+    id              INTEGER  PRIMARY KEY AUTOINCREMENT,
+    kind            TEXT     NOT NULL,
+    repo_full_name  TEXT     NOT NULL,
+    pr_number       INTEGER  NOT NULL,
+    body            TEXT     NOT NULL DEFAULT '',
+    in_reply_to     INTEGER  NOT NULL DEFAULT 0,
+    payload         TEXT     NOT NULL DEFAULT '',
+    status          TEXT     NOT NULL,
+    attempts        INTEGER  NOT NULL DEFAULT 0,
+    last_error      TEXT     NOT NULL DEFAULT '',
+    next_attempt_at DATETIME NOT NULL,
+    created_at      DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbound_actions_due ON outbound_actions (status, next_attempt_at);
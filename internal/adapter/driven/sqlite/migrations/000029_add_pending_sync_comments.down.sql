@@ -0,0 +1,2 @@
+ALTER TABLE issue_comments DROP COLUMN pending_sync;
+ALTER TABLE review_comments DROP COLUMN pending_sync;
@@ -0,0 +1,2 @@
+ALTER TABLE review_comments ADD COLUMN pending_sync INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE issue_comments ADD COLUMN pending_sync INTEGER NOT NULL DEFAULT 0;
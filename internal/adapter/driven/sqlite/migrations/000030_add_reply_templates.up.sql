@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS reply_templates (
+    id         INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name       TEXT     NOT NULL,
+    kind       TEXT     NOT NULL,
+    body       TEXT     NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS pr_notes (
+    id             INTEGER  PRIMARY KEY AUTOINCREMENT,
+    repo_full_name TEXT     NOT NULL,
+    pr_number      INTEGER  NOT NULL,
+    body           TEXT     NOT NULL,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_notes_repo_pr ON pr_notes(repo_full_name, pr_number);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_group_members;
+DROP TABLE IF EXISTS repo_groups;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS repo_groups (
+    id         INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name       TEXT     NOT NULL UNIQUE COLLATE NOCASE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS repo_group_members (
+    group_id       INTEGER NOT NULL REFERENCES repo_groups(id) ON DELETE CASCADE,
+    repo_full_name TEXT    NOT NULL,
+    PRIMARY KEY (group_id, repo_full_name)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pull_requests_closed_at;
+ALTER TABLE pull_requests DROP COLUMN closed_at;
@@ -0,0 +1,5 @@
+ALTER TABLE pull_requests ADD COLUMN closed_at DATETIME;
+-- GitHub bumps updated_at when a PR is merged or closed, so it is the best
+-- available close time for PRs stored before closed_at was tracked.
+UPDATE pull_requests SET closed_at = updated_at WHERE status != 'open';
+CREATE INDEX idx_pull_requests_closed_at ON pull_requests(closed_at);
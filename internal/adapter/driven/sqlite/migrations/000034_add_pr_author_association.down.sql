@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pull_requests_repo_author_status;
+ALTER TABLE pull_requests DROP COLUMN author_association;
@@ -0,0 +1,4 @@
+-- GitHub's author_association for the PR author (e.g. MEMBER,
+-- FIRST_TIME_CONTRIBUTOR). Empty until the PR is next polled.
+ALTER TABLE pull_requests ADD COLUMN author_association TEXT NOT NULL DEFAULT '';
+CREATE INDEX idx_pull_requests_repo_author_status ON pull_requests(repo_full_name, author, status);
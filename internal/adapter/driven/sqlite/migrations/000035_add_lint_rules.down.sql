@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS lint_violations;
+DROP TABLE IF EXISTS lint_rules;
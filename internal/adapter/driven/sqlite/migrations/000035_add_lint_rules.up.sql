@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS lint_rules (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    repo_full_name TEXT    NOT NULL,
+    target         TEXT    NOT NULL CHECK (target IN ('title', 'branch')),
+    pattern        TEXT    NOT NULL,
+    description    TEXT    NOT NULL DEFAULT '',
+    FOREIGN KEY (repo_full_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_lint_rules_repo ON lint_rules(repo_full_name);
+
+-- One row per PR and rule it fails, as of the PR's last sync.
+CREATE TABLE IF NOT EXISTS lint_violations (
+    pr_id   INTEGER NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    rule_id INTEGER NOT NULL REFERENCES lint_rules(id) ON DELETE CASCADE,
+    PRIMARY KEY (pr_id, rule_id)
+);
+CREATE INDEX IF NOT EXISTS idx_lint_violations_rule ON lint_violations(rule_id);
@@ -0,0 +1,4 @@
+-- Markdown task list progress across the PR body and its conversation
+-- comments, counted when the PR is synced.
+ALTER TABLE pull_requests ADD COLUMN checklist_done INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pull_requests ADD COLUMN checklist_total INTEGER NOT NULL DEFAULT 0;
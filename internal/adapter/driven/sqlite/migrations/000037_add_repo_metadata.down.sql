@@ -0,0 +1,6 @@
+ALTER TABLE repositories DROP COLUMN metadata_fetched_at;
+ALTER TABLE repositories DROP COLUMN open_pr_count;
+ALTER TABLE repositories DROP COLUMN is_archived;
+ALTER TABLE repositories DROP COLUMN is_private;
+ALTER TABLE repositories DROP COLUMN default_branch;
+ALTER TABLE repositories DROP COLUMN description;
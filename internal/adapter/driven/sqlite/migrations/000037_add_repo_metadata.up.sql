@@ -0,0 +1,9 @@
+-- Repository details refreshed from GitHub by the poll loop. The open PR
+-- count comes from every poll; the rest is refetched at most daily and
+-- metadata_fetched_at stays NULL until the first fetch.
+ALTER TABLE repositories ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE repositories ADD COLUMN default_branch TEXT NOT NULL DEFAULT '';
+ALTER TABLE repositories ADD COLUMN is_private INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repositories ADD COLUMN is_archived INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repositories ADD COLUMN open_pr_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repositories ADD COLUMN metadata_fetched_at DATETIME;
@@ -0,0 +1,9 @@
+-- Cached GitHub API responses keyed by request URL, so conditional requests
+-- (ETag / Last-Modified) survive restarts.
+CREATE TABLE IF NOT EXISTS http_cache (
+    cache_key  TEXT     PRIMARY KEY,
+    response   BLOB     NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_http_cache_updated_at ON http_cache (updated_at);
@@ -0,0 +1,11 @@
+-- GUI preferences (filters, density, collapsed sections) per browser, keyed
+-- by an anonymous ID the browser keeps in a cookie.
+CREATE TABLE IF NOT EXISTS browser_preferences (
+    browser_id TEXT     NOT NULL,
+    pref_key   TEXT     NOT NULL,
+    value      TEXT     NOT NULL,
+    updated_at DATETIME NOT NULL,
+    PRIMARY KEY (browser_id, pref_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_browser_preferences_updated_at ON browser_preferences (updated_at);
@@ -0,0 +1,3 @@
+-- Finish pending removals rather than bringing the repositories back.
+DELETE FROM repositories WHERE removed_at IS NOT NULL;
+ALTER TABLE repositories DROP COLUMN removed_at;
@@ -0,0 +1,3 @@
+-- Removing a repository soft-deletes it: removed_at is set and the row keeps
+-- its PRs, reviews, and checks until purged, so the removal can be undone.
+ALTER TABLE repositories ADD COLUMN removed_at DATETIME;
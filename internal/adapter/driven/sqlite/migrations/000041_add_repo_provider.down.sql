@@ -0,0 +1,3 @@
+-- Repositories on other providers cannot be polled without the column.
+DELETE FROM repositories WHERE provider != 'github';
+ALTER TABLE repositories DROP COLUMN provider;
@@ -0,0 +1,3 @@
+-- The source code host each repository is polled from. Repositories watched
+-- before providers existed are on GitHub.
+ALTER TABLE repositories ADD COLUMN provider TEXT NOT NULL DEFAULT 'github';
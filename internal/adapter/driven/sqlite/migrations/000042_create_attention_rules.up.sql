@@ -0,0 +1,11 @@
+-- User-defined attention rules: predicate expressions over stored PR fields
+-- that add severity, and optionally notify a webhook, when they match.
+CREATE TABLE IF NOT EXISTS attention_rules (
+    id         INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name       TEXT     NOT NULL,
+    expression TEXT     NOT NULL,
+    severity   INTEGER  NOT NULL DEFAULT 1,
+    notify_url TEXT     NOT NULL DEFAULT '',
+    enabled    INTEGER  NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,12 @@
+-- Script hooks: Starlark scripts or allowlisted commands run when an event
+-- such as an approval or a CI failure occurs on a PR.
+CREATE TABLE IF NOT EXISTS script_hooks (
+    id             INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name           TEXT     NOT NULL,
+    event          TEXT     NOT NULL,
+    runtime        TEXT     NOT NULL,
+    script         TEXT     NOT NULL,
+    repo_full_name TEXT     NOT NULL DEFAULT '',
+    enabled        INTEGER  NOT NULL DEFAULT 1,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
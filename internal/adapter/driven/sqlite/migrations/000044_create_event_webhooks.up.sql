@@ -0,0 +1,31 @@
+-- Event webhooks: URLs that receive signed JSON for PR events, and the log
+-- of deliveries to them, which doubles as the retry queue.
+CREATE TABLE IF NOT EXISTS event_webhooks (
+    id             INTEGER  PRIMARY KEY AUTOINCREMENT,
+    url            TEXT     NOT NULL,
+    secret         TEXT     NOT NULL,
+    events         TEXT     NOT NULL DEFAULT '',
+    repo_full_name TEXT     NOT NULL DEFAULT '',
+    enabled        INTEGER  NOT NULL DEFAULT 1,
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              INTEGER  PRIMARY KEY AUTOINCREMENT,
+    webhook_id      INTEGER  NOT NULL,
+    event           TEXT     NOT NULL,
+    repo_full_name  TEXT     NOT NULL,
+    pr_number       INTEGER  NOT NULL,
+    payload         TEXT     NOT NULL,
+    status          TEXT     NOT NULL,
+    attempts        INTEGER  NOT NULL DEFAULT 0,
+    response_status INTEGER  NOT NULL DEFAULT 0,
+    last_error      TEXT     NOT NULL DEFAULT '',
+    next_attempt_at DATETIME NOT NULL,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at    DATETIME,
+    FOREIGN KEY (webhook_id) REFERENCES event_webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries (webhook_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
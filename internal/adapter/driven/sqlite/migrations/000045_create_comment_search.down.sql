@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS issue_comments_fts_update;
+DROP TRIGGER IF EXISTS issue_comments_fts_delete;
+DROP TRIGGER IF EXISTS issue_comments_fts_insert;
+DROP TABLE IF EXISTS issue_comments_fts;
+DROP TRIGGER IF EXISTS review_comments_fts_update;
+DROP TRIGGER IF EXISTS review_comments_fts_delete;
+DROP TRIGGER IF EXISTS review_comments_fts_insert;
+DROP TABLE IF EXISTS review_comments_fts;
@@ -0,0 +1,45 @@
+-- Full-text indexes over review and issue comment bodies, kept in sync with
+-- the comment tables by triggers so comment search covers every stored PR.
+CREATE VIRTUAL TABLE IF NOT EXISTS review_comments_fts USING fts5(
+    body,
+    content = 'review_comments',
+    content_rowid = 'id',
+    tokenize = 'porter unicode61'
+);
+
+CREATE TRIGGER IF NOT EXISTS review_comments_fts_insert AFTER INSERT ON review_comments BEGIN
+    INSERT INTO review_comments_fts (rowid, body) VALUES (new.id, new.body);
+END;
+
+CREATE TRIGGER IF NOT EXISTS review_comments_fts_delete AFTER DELETE ON review_comments BEGIN
+    INSERT INTO review_comments_fts (review_comments_fts, rowid, body) VALUES ('delete', old.id, old.body);
+END;
+
+CREATE TRIGGER IF NOT EXISTS review_comments_fts_update AFTER UPDATE OF body ON review_comments BEGIN
+    INSERT INTO review_comments_fts (review_comments_fts, rowid, body) VALUES ('delete', old.id, old.body);
+    INSERT INTO review_comments_fts (rowid, body) VALUES (new.id, new.body);
+END;
+
+CREATE VIRTUAL TABLE IF NOT EXISTS issue_comments_fts USING fts5(
+    body,
+    content = 'issue_comments',
+    content_rowid = 'id',
+    tokenize = 'porter unicode61'
+);
+
+CREATE TRIGGER IF NOT EXISTS issue_comments_fts_insert AFTER INSERT ON issue_comments BEGIN
+    INSERT INTO issue_comments_fts (rowid, body) VALUES (new.id, new.body);
+END;
+
+CREATE TRIGGER IF NOT EXISTS issue_comments_fts_delete AFTER DELETE ON issue_comments BEGIN
+    INSERT INTO issue_comments_fts (issue_comments_fts, rowid, body) VALUES ('delete', old.id, old.body);
+END;
+
+CREATE TRIGGER IF NOT EXISTS issue_comments_fts_update AFTER UPDATE OF body ON issue_comments BEGIN
+    INSERT INTO issue_comments_fts (issue_comments_fts, rowid, body) VALUES ('delete', old.id, old.body);
+    INSERT INTO issue_comments_fts (rowid, body) VALUES (new.id, new.body);
+END;
+
+-- Index the comments stored before this migration.
+INSERT INTO review_comments_fts (review_comments_fts) VALUES ('rebuild');
+INSERT INTO issue_comments_fts (issue_comments_fts) VALUES ('rebuild');
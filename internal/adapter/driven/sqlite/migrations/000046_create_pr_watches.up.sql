@@ -0,0 +1,10 @@
+-- Per-PR watch state overriding whether a PR is relevant to the user:
+-- 'watching' pulls it into the needing-attention views and notifications,
+-- 'unwatched' takes a review request out of them. PRs with no row follow
+-- their review request as usual.
+CREATE TABLE IF NOT EXISTS pr_watches (
+    pr_id      INTEGER  NOT NULL PRIMARY KEY,
+    state      TEXT     NOT NULL CHECK (state IN ('watching', 'unwatched')),
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+-- Title of the PR's milestone; empty when it has none. Empty until the PR
+-- is next polled.
+ALTER TABLE pull_requests ADD COLUMN milestone TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS check_watches;
@@ -0,0 +1,7 @@
+-- PRs the user asked to be notified about once their CI checks finish. A
+-- row is removed when the notification fires or the PR is no longer open.
+CREATE TABLE IF NOT EXISTS check_watches (
+    pr_id      INTEGER  NOT NULL PRIMARY KEY,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
@@ -0,0 +1,9 @@
+-- Per-repository settings that are not attention thresholds. The check
+-- lists are JSON arrays of check names: required_checks are treated as
+-- required on top of branch protection, optional_checks never are.
+CREATE TABLE IF NOT EXISTS repo_settings (
+    repo_full_name  TEXT NOT NULL PRIMARY KEY,
+    required_checks TEXT NOT NULL DEFAULT '[]',
+    optional_checks TEXT NOT NULL DEFAULT '[]',
+    FOREIGN KEY (repo_full_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
@@ -0,0 +1 @@
+ALTER TABLE repo_settings DROP COLUMN ignored_checks;
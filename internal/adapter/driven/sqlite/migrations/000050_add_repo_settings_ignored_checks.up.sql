@@ -0,0 +1,3 @@
+-- JSON array of check run names and status contexts left out of the PR's
+-- combined CI status, e.g. a perpetually red optional coverage check.
+ALTER TABLE repo_settings ADD COLUMN ignored_checks TEXT NOT NULL DEFAULT '[]';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS stale_marks;
+ALTER TABLE repo_settings DROP COLUMN stale_exempt_labels;
+ALTER TABLE repo_settings DROP COLUMN stale_dry_run;
+ALTER TABLE repo_settings DROP COLUMN stale_label;
+ALTER TABLE repo_settings DROP COLUMN stale_action;
+ALTER TABLE repo_settings DROP COLUMN stale_after_days;
@@ -0,0 +1,18 @@
+-- Per-repo stale PR policy: PRs idle for stale_after_days (0 disables) get
+-- the stale label or a comment, or are only reported in dry-run mode. PRs
+-- with one of the exempt labels (a JSON array) are left alone.
+ALTER TABLE repo_settings ADD COLUMN stale_after_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repo_settings ADD COLUMN stale_action TEXT NOT NULL DEFAULT 'label';
+ALTER TABLE repo_settings ADD COLUMN stale_label TEXT NOT NULL DEFAULT '';
+ALTER TABLE repo_settings ADD COLUMN stale_dry_run INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repo_settings ADD COLUMN stale_exempt_labels TEXT NOT NULL DEFAULT '[]';
+
+-- The last time each PR was marked stale, keyed to the activity time it had
+-- then, so a PR is marked at most once per idle period.
+CREATE TABLE IF NOT EXISTS stale_marks (
+    pr_id       INTEGER  NOT NULL PRIMARY KEY,
+    activity_at DATETIME NOT NULL,
+    marked_at   DATETIME NOT NULL,
+    dry_run     INTEGER  NOT NULL DEFAULT 0,
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
@@ -0,0 +1,15 @@
+-- Weekly summaries posted to a Slack webhook or as a GitHub issue comment.
+-- group_id scopes a report to a repo group; NULL covers every watched
+-- repository. weekday (0 = Sunday) and hour are in UTC.
+CREATE TABLE IF NOT EXISTS scheduled_reports (
+    id           INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name         TEXT     NOT NULL,
+    group_id     INTEGER  REFERENCES repo_groups(id) ON DELETE CASCADE,
+    destination  TEXT     NOT NULL,
+    target       TEXT     NOT NULL,
+    template     TEXT     NOT NULL DEFAULT '',
+    weekday      INTEGER  NOT NULL,
+    hour         INTEGER  NOT NULL,
+    last_sent_at DATETIME,
+    created_at   DATETIME NOT NULL
+);
@@ -0,0 +1,19 @@
+-- Append-only journal of changes to stored PR state, written by the poll
+-- loop: status transitions, CI flips, new reviews, and new review requests.
+-- Repository and number are kept alongside pr_id so entries can be queried
+-- without a join.
+CREATE TABLE IF NOT EXISTS pr_events (
+    id             INTEGER  PRIMARY KEY AUTOINCREMENT,
+    pr_id          INTEGER  NOT NULL,
+    repo_full_name TEXT     NOT NULL,
+    pr_number      INTEGER  NOT NULL,
+    kind           TEXT     NOT NULL,
+    actor          TEXT     NOT NULL DEFAULT '',
+    from_value     TEXT     NOT NULL DEFAULT '',
+    to_value       TEXT     NOT NULL DEFAULT '',
+    occurred_at    DATETIME NOT NULL,
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_pr_events_pr_id ON pr_events(pr_id);
+CREATE INDEX IF NOT EXISTS idx_pr_events_repo_number ON pr_events(repo_full_name, pr_number);
@@ -0,0 +1,2 @@
+ALTER TABLE review_comments DROP COLUMN original_line;
+ALTER TABLE review_comments DROP COLUMN position;
@@ -0,0 +1,4 @@
+-- position is the comment's offset in the PR's current diff, NULL once its
+-- line is gone from it; original_line is its line in the commit it was made on.
+ALTER TABLE review_comments ADD COLUMN position INTEGER;
+ALTER TABLE review_comments ADD COLUMN original_line INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE issue_comments DROP COLUMN html_url;
+ALTER TABLE review_comments DROP COLUMN html_url;
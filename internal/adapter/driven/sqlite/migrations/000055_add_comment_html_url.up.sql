@@ -0,0 +1,3 @@
+-- The comment's page on GitHub, for copy-link actions.
+ALTER TABLE review_comments ADD COLUMN html_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE issue_comments ADD COLUMN html_url TEXT NOT NULL DEFAULT '';
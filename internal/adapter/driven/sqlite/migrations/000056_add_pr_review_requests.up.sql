@@ -0,0 +1,2 @@
+-- Pending review requests with when each was made, as a JSON array.
+ALTER TABLE pull_requests ADD COLUMN review_requests TEXT NOT NULL DEFAULT '[]';
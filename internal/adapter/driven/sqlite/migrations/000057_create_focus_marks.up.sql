@@ -0,0 +1,11 @@
+-- Done and skip marks on the daily focus list, at most one per PR per day.
+-- A PR marked done leaves that day's list until it changes again; skips on
+-- earlier days lower its rank.
+CREATE TABLE IF NOT EXISTS focus_marks (
+    pr_id     INTEGER  NOT NULL,
+    day       TEXT     NOT NULL,
+    state     TEXT     NOT NULL CHECK (state IN ('done', 'skipped')),
+    marked_at DATETIME NOT NULL,
+    PRIMARY KEY (pr_id, day),
+    FOREIGN KEY (pr_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
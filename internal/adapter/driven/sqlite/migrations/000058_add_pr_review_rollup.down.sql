@@ -0,0 +1,3 @@
+ALTER TABLE pull_requests DROP COLUMN unresolved_thread_count;
+ALTER TABLE pull_requests DROP COLUMN approval_count;
+ALTER TABLE pull_requests DROP COLUMN review_status;
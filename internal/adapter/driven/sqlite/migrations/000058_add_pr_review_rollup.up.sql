@@ -0,0 +1,6 @@
+-- Review state aggregated from stored reviews and threads, maintained by the
+-- poll loop so listings need no per-PR review queries. An empty review_status
+-- marks a PR not yet rolled up.
+ALTER TABLE pull_requests ADD COLUMN review_status TEXT NOT NULL DEFAULT '';
+ALTER TABLE pull_requests ADD COLUMN approval_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pull_requests ADD COLUMN unresolved_thread_count INTEGER NOT NULL DEFAULT 0;
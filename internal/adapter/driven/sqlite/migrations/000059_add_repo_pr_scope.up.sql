@@ -0,0 +1,3 @@
+-- Which of a repository's PRs the poller stores: 'all', 'relevant' (only
+-- those involving the user), or '' to follow the relevant_prs_only setting.
+ALTER TABLE repo_settings ADD COLUMN pr_scope TEXT NOT NULL DEFAULT '';
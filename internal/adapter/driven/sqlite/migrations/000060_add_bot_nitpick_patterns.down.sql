@@ -0,0 +1 @@
+ALTER TABLE bot_config DROP COLUMN nitpick_patterns;
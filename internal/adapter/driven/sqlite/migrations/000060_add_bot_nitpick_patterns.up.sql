@@ -0,0 +1,3 @@
+-- Extra markers, beyond the built-in ones, that flag a bot's review as a
+-- nitpick: a JSON array of lowercase substrings of the review body.
+ALTER TABLE bot_config ADD COLUMN nitpick_patterns TEXT NOT NULL DEFAULT '[]';
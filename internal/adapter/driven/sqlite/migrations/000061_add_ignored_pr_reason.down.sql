@@ -0,0 +1 @@
+ALTER TABLE ignored_prs DROP COLUMN reason;
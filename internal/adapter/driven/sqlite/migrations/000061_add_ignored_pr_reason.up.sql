@@ -0,0 +1,2 @@
+-- Why a PR was ignored, as given by whoever ignored it; empty when none was.
+ALTER TABLE ignored_prs ADD COLUMN reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- User-defined ignore rules: attention rule expressions whose matching PRs
+-- are added to the ignore list.
+CREATE TABLE IF NOT EXISTS ignore_rules (
+    id         INTEGER  PRIMARY KEY AUTOINCREMENT,
+    name       TEXT     NOT NULL,
+    expression TEXT     NOT NULL,
+    enabled    INTEGER  NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
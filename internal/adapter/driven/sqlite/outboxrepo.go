@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.OutboxStore = (*OutboxRepo)(nil)
+
+const outboundActionColumns = `id, kind, repo_full_name, pr_number, body, in_reply_to, payload,
+	status, attempts, last_error, next_attempt_at, created_at`
+
+// OutboxRepo is the SQLite implementation of the OutboxStore port interface.
+type OutboxRepo struct {
+	db *DB
+}
+
+// NewOutboxRepo creates a new OutboxRepo backed by the given DB.
+func NewOutboxRepo(db *DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// Add inserts an action and returns its ID.
+func (r *OutboxRepo) Add(ctx context.Context, a model.OutboundAction) (int64, error) {
+	const query = `
+		INSERT INTO outbound_actions (kind, repo_full_name, pr_number, body, in_reply_to, payload,
+			status, attempts, last_error, next_attempt_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	createdAt := a.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query,
+		string(a.Kind), a.RepoFullName, a.PRNumber, a.Body, a.InReplyTo, a.Payload,
+		string(a.Status), a.Attempts, a.LastError, a.NextAttemptAt.UTC(), createdAt.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("add outbound %s for %s#%d: %w", a.Kind, a.RepoFullName, a.PRNumber, err)
+	}
+	return result.LastInsertId()
+}
+
+// Get returns one action, or nil if it does not exist.
+func (r *OutboxRepo) Get(ctx context.Context, id int64) (*model.OutboundAction, error) {
+	query := `SELECT ` + outboundActionColumns + ` FROM outbound_actions WHERE id = ?`
+
+	a, err := scanOutboundAction(r.db.Reader.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get outbound action %d: %w", id, err)
+	}
+	return &a, nil
+}
+
+// List returns all actions newest first.
+func (r *OutboxRepo) List(ctx context.Context) ([]model.OutboundAction, error) {
+	query := `SELECT ` + outboundActionColumns + ` FROM outbound_actions ORDER BY created_at DESC, id DESC`
+	return r.query(ctx, "list outbound actions", query)
+}
+
+// ListDue returns pending actions due at or before now, oldest first.
+func (r *OutboxRepo) ListDue(ctx context.Context, now time.Time) ([]model.OutboundAction, error) {
+	query := `SELECT ` + outboundActionColumns + ` FROM outbound_actions
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at, id`
+	return r.query(ctx, "list due outbound actions", query, string(model.OutboundPending), now.UTC())
+}
+
+// Update saves an action's retry state.
+func (r *OutboxRepo) Update(ctx context.Context, a model.OutboundAction) error {
+	const query = `
+		UPDATE outbound_actions SET status = ?, attempts = ?, last_error = ?, next_attempt_at = ?
+		WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, string(a.Status), a.Attempts, a.LastError, a.NextAttemptAt.UTC(), a.ID); err != nil {
+		return fmt.Errorf("update outbound action %d: %w", a.ID, err)
+	}
+	return nil
+}
+
+// Delete removes an action.
+func (r *OutboxRepo) Delete(ctx context.Context, id int64) error {
+	if _, err := r.db.Writer.ExecContext(ctx, `DELETE FROM outbound_actions WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("delete outbound action %d: %w", id, err)
+	}
+	return nil
+}
+
+// query runs a SELECT of outboundActionColumns and scans every row.
+func (r *OutboxRepo) query(ctx context.Context, op, query string, args ...any) ([]model.OutboundAction, error) {
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var actions []model.OutboundAction
+	for rows.Next() {
+		a, err := scanOutboundAction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		actions = append(actions, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: iterate: %w", op, err)
+	}
+	return actions, nil
+}
+
+// scanOutboundAction scans a single outbound_actions row from the given scanner.
+func scanOutboundAction(s scanner) (model.OutboundAction, error) {
+	var a model.OutboundAction
+	var kind, status, nextAttemptAt, createdAt string
+
+	if err := s.Scan(&a.ID, &kind, &a.RepoFullName, &a.PRNumber, &a.Body, &a.InReplyTo, &a.Payload,
+		&status, &a.Attempts, &a.LastError, &nextAttemptAt, &createdAt); err != nil {
+		return model.OutboundAction{}, err
+	}
+	a.Kind = model.OutboundActionKind(kind)
+	a.Status = model.OutboundStatus(status)
+
+	var err error
+	if a.NextAttemptAt, err = parseTime(nextAttemptAt); err != nil {
+		return model.OutboundAction{}, fmt.Errorf("parse next_attempt_at for outbound action %d: %w", a.ID, err)
+	}
+	if a.CreatedAt, err = parseTime(createdAt); err != nil {
+		return model.OutboundAction{}, fmt.Errorf("parse created_at for outbound action %d: %w", a.ID, err)
+	}
+	return a, nil
+}
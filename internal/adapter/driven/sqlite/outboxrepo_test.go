@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepo_RoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewOutboxRepo(db)
+	ctx := context.Background()
+
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	action := model.OutboundAction{
+		Kind:          model.OutboundReply,
+		RepoFullName:  "octocat/hello-world",
+		PRNumber:      7,
+		Body:          "Good catch, fixed.",
+		InReplyTo:     555,
+		Payload:       `{"x":1}`,
+		Status:        model.OutboundPending,
+		Attempts:      1,
+		LastError:     "502 Bad Gateway",
+		NextAttemptAt: created.Add(time.Minute),
+		CreatedAt:     created,
+	}
+
+	id, err := repo.Add(ctx, action)
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	action.ID = id
+	assert.Equal(t, action.Body, got.Body)
+	assert.Equal(t, action.InReplyTo, got.InReplyTo)
+	assert.Equal(t, action.Payload, got.Payload)
+	assert.Equal(t, action.LastError, got.LastError)
+	assert.True(t, action.NextAttemptAt.Equal(got.NextAttemptAt))
+	assert.True(t, action.CreatedAt.Equal(got.CreatedAt))
+
+	got.Status = model.OutboundFailed
+	got.Attempts = 2
+	got.LastError = "422 Unprocessable Entity"
+	require.NoError(t, repo.Update(ctx, *got))
+
+	list, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, model.OutboundFailed, list[0].Status)
+	assert.Equal(t, 2, list[0].Attempts)
+	assert.Equal(t, "422 Unprocessable Entity", list[0].LastError)
+
+	require.NoError(t, repo.Delete(ctx, id))
+	got, err = repo.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestOutboxRepo_ListDue(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewOutboxRepo(db)
+	ctx := context.Background()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	add := func(body string, status model.OutboundStatus, next time.Time) {
+		t.Helper()
+		_, err := repo.Add(ctx, model.OutboundAction{
+			Kind: model.OutboundIssueComment, RepoFullName: "octocat/hello-world", PRNumber: 1,
+			Body: body, Status: status, NextAttemptAt: next,
+		})
+		require.NoError(t, err)
+	}
+	add("later", model.OutboundPending, now.Add(time.Second))
+	add("due", model.OutboundPending, now.Add(-90*time.Second))
+	add("just due", model.OutboundPending, now.Add(-500*time.Millisecond))
+	add("failed", model.OutboundFailed, now.Add(-time.Hour))
+
+	due, err := repo.ListDue(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, "due", due[0].Body, "oldest due action first")
+	assert.Equal(t, "just due", due[1].Body)
+}
@@ -0,0 +1,69 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.ParticipantStore = (*ParticipantRepo)(nil)
+
+// ParticipantRepo is the SQLite implementation of the ParticipantStore port
+// interface. It reads PR authors, reviewers, and commenters from the tables
+// the poll loop already fills.
+type ParticipantRepo struct {
+	db *DB
+}
+
+// NewParticipantRepo creates a new ParticipantRepo backed by the given DB.
+func NewParticipantRepo(db *DB) *ParticipantRepo {
+	return &ParticipantRepo{db: db}
+}
+
+// SearchParticipants returns up to limit non-bot logins seen on the stored
+// PRs of repoFullName (or every repository when empty) that start with
+// prefix, ordered by how often they appear.
+func (r *ParticipantRepo) SearchParticipants(ctx context.Context, repoFullName, prefix string, limit int) ([]string, error) {
+	const query = `WITH logins(login) AS (
+			SELECT author FROM pull_requests
+			WHERE ?1 = '' OR repo_full_name = ?1
+			UNION ALL
+			SELECT rv.reviewer_login FROM reviews rv JOIN pull_requests p ON p.id = rv.pr_id
+			WHERE rv.is_bot = 0 AND (?1 = '' OR p.repo_full_name = ?1)
+			UNION ALL
+			SELECT rc.author FROM review_comments rc JOIN pull_requests p ON p.id = rc.pr_id
+			WHERE ?1 = '' OR p.repo_full_name = ?1
+			UNION ALL
+			SELECT ic.author FROM issue_comments ic JOIN pull_requests p ON p.id = ic.pr_id
+			WHERE ic.is_bot = 0 AND (?1 = '' OR p.repo_full_name = ?1)
+		)
+		SELECT MIN(login) FROM logins
+		WHERE login <> ''
+			AND login LIKE ?2 ESCAPE '\'
+			AND login NOT LIKE '%[bot]'
+			AND lower(login) NOT IN (SELECT lower(username) FROM bot_config)
+		GROUP BY lower(login)
+		ORDER BY COUNT(*) DESC, lower(login)
+		LIMIT ?3`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, repoFullName, likeEscaper.Replace(prefix)+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("search participants of %q: %w", repoFullName, err)
+	}
+	defer rows.Close()
+
+	var logins []string
+	for rows.Next() {
+		var login string
+		if err := rows.Scan(&login); err != nil {
+			return nil, fmt.Errorf("scan participant: %w", err)
+		}
+		logins = append(logins, login)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate participants: %w", err)
+	}
+	return logins, nil
+}
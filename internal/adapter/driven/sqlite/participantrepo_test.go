@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParticipantRepo_SearchParticipants(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	reviews := NewReviewRepo(db)
+	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+
+	prID := addTestPR(t, db, "octocat/hello-world", 1)
+	otherID := addTestPR(t, db, "octocat/other", 2)
+
+	require.NoError(t, reviews.UpsertReview(ctx, model.Review{ID: 1, PRID: prID, ReviewerLogin: "alice", State: model.ReviewStateApproved, CommitID: "abc", SubmittedAt: now}))
+	require.NoError(t, reviews.UpsertReview(ctx, model.Review{ID: 2, PRID: prID, ReviewerLogin: "coderabbitai", State: model.ReviewStateCommented, CommitID: "abc", SubmittedAt: now, IsBot: true}))
+	require.NoError(t, reviews.UpsertIssueComment(ctx, model.IssueComment{ID: 3, PRID: prID, Author: "Alice", Body: "hi", CreatedAt: now, UpdatedAt: now}))
+	require.NoError(t, reviews.UpsertIssueComment(ctx, model.IssueComment{ID: 4, PRID: prID, Author: "al_bot", Body: "hi", CreatedAt: now, UpdatedAt: now}))
+	require.NoError(t, reviews.UpsertIssueComment(ctx, model.IssueComment{ID: 5, PRID: prID, Author: "dependabot[bot]", Body: "bump", CreatedAt: now, UpdatedAt: now}))
+	require.NoError(t, reviews.UpsertIssueComment(ctx, model.IssueComment{ID: 6, PRID: otherID, Author: "alfred", Body: "hi", CreatedAt: now, UpdatedAt: now}))
+
+	repo := NewParticipantRepo(db)
+
+	got, err := repo.SearchParticipants(ctx, "octocat/hello-world", "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "al_bot", "testuser"}, got, "most active first, case-insensitive, bots excluded")
+
+	got, err = repo.SearchParticipants(ctx, "octocat/hello-world", "AL", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "al_bot"}, got)
+
+	got, err = repo.SearchParticipants(ctx, "octocat/hello-world", "al_", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"al_bot"}, got, "LIKE wildcards in the prefix match literally")
+
+	got, err = repo.SearchParticipants(ctx, "", "al", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Alice", "al_bot", "alfred"}, got)
+
+	got, err = repo.SearchParticipants(ctx, "", "", 1)
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.PRJournalStore = (*PRJournalRepo)(nil)
+
+// defaultPRJournalLimit caps a journal query without an explicit limit.
+const defaultPRJournalLimit = 100
+
+// PRJournalRepo is the SQLite implementation of the PRJournalStore port interface.
+type PRJournalRepo struct {
+	db *DB
+}
+
+// NewPRJournalRepo creates a new PRJournalRepo backed by the given DB.
+func NewPRJournalRepo(db *DB) *PRJournalRepo {
+	return &PRJournalRepo{db: db}
+}
+
+// AppendPREvents inserts entries in a single transaction, in order.
+func (r *PRJournalRepo) AppendPREvents(ctx context.Context, entries []model.PRJournalEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after commit is a no-op.
+
+	const query = `
+		INSERT INTO pr_events (pr_id, repo_full_name, pr_number, kind, actor, from_value, to_value, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, e := range entries {
+		occurredAt := e.OccurredAt
+		if occurredAt.IsZero() {
+			occurredAt = time.Now()
+		}
+		if _, err := tx.ExecContext(ctx, query, e.PRID, e.RepoFullName, e.PRNumber, string(e.Kind),
+			e.Actor, e.From, e.To, occurredAt.UTC()); err != nil {
+			return fmt.Errorf("append %s event for %s#%d: %w", e.Kind, e.RepoFullName, e.PRNumber, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit PR events: %w", err)
+	}
+	return nil
+}
+
+// ListPREvents returns the entries matching filter, oldest first.
+func (r *PRJournalRepo) ListPREvents(ctx context.Context, filter model.PRJournalFilter) ([]model.PRJournalEntry, error) {
+	conditions := []string{"id > ?"}
+	args := []any{filter.AfterID}
+	if filter.RepoFullName != "" {
+		conditions = append(conditions, "repo_full_name = ?")
+		args = append(args, filter.RepoFullName)
+		if filter.PRNumber != 0 {
+			conditions = append(conditions, "pr_number = ?")
+			args = append(args, filter.PRNumber)
+		}
+	}
+	if len(filter.Kinds) > 0 {
+		placeholders := strings.Repeat("?,", len(filter.Kinds))
+		conditions = append(conditions, "kind IN ("+placeholders[:len(placeholders)-1]+")")
+		for _, k := range filter.Kinds {
+			args = append(args, string(k))
+		}
+	}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultPRJournalLimit
+	}
+	args = append(args, limit)
+
+	//nolint:gosec // conditions contains only fixed clauses and "?" placeholders, never user input
+	query := `
+		SELECT id, pr_id, repo_full_name, pr_number, kind, actor, from_value, to_value, occurred_at
+		FROM pr_events WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY id LIMIT ?`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list PR events: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []model.PRJournalEntry
+	for rows.Next() {
+		var e model.PRJournalEntry
+		var kind, occurredAt string
+		if err := rows.Scan(&e.ID, &e.PRID, &e.RepoFullName, &e.PRNumber, &kind, &e.Actor, &e.From, &e.To, &occurredAt); err != nil {
+			return nil, fmt.Errorf("scan PR event: %w", err)
+		}
+		e.Kind = model.PRJournalKind(kind)
+		if e.OccurredAt, err = parseTime(occurredAt); err != nil {
+			return nil, fmt.Errorf("parse occurred_at for PR event %d: %w", e.ID, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate PR events: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,56 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestPRJournalRepo_AppendAndList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPRJournalRepo(db)
+	ctx := context.Background()
+	first := addTestPR(t, db, "octocat/hello-world", 1)
+	second := addTestPR(t, db, "octocat/spoon-knife", 2)
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.AppendPREvents(ctx, nil))
+	require.NoError(t, repo.AppendPREvents(ctx, []model.PRJournalEntry{
+		{PRID: first, RepoFullName: "octocat/hello-world", PRNumber: 1, Kind: model.JournalCIChanged, From: "pending", To: "failing", OccurredAt: at},
+		{PRID: first, RepoFullName: "octocat/hello-world", PRNumber: 1, Kind: model.JournalReviewAdded, Actor: "alice", To: "APPROVED", OccurredAt: at},
+		{PRID: second, RepoFullName: "octocat/spoon-knife", PRNumber: 2, Kind: model.JournalReviewRequested, Actor: "bob", OccurredAt: at},
+	}))
+
+	all, err := repo.ListPREvents(ctx, model.PRJournalFilter{})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, model.JournalCIChanged, all[0].Kind, "oldest first")
+	assert.Equal(t, "failing", all[0].To)
+	assert.True(t, at.Equal(all[0].OccurredAt))
+	assert.Less(t, all[0].ID, all[1].ID)
+
+	got, err := repo.ListPREvents(ctx, model.PRJournalFilter{RepoFullName: "octocat/hello-world", PRNumber: 1})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	got, err = repo.ListPREvents(ctx, model.PRJournalFilter{Kinds: []model.PRJournalKind{model.JournalReviewAdded, model.JournalReviewRequested}})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "alice", got[0].Actor)
+
+	got, err = repo.ListPREvents(ctx, model.PRJournalFilter{AfterID: all[0].ID, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, all[1].ID, got[0].ID)
+
+	_, err = db.Writer.ExecContext(ctx, `DELETE FROM pull_requests WHERE id = ?`, first)
+	require.NoError(t, err)
+	all, err = repo.ListPREvents(ctx, model.PRJournalFilter{})
+	require.NoError(t, err)
+	assert.Len(t, all, 1, "entries go with their PR")
+}
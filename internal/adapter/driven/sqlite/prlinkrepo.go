@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.PRLinkStore = (*PRLinkRepo)(nil)
+
+// PRLinkRepo is the SQLite implementation of the PRLinkStore port interface.
+// Each link is stored once with the smaller PR ID first, so lookups must
+// check both columns.
+type PRLinkRepo struct {
+	db *DB
+}
+
+// NewPRLinkRepo creates a new PRLinkRepo backed by the given DB.
+func NewPRLinkRepo(db *DB) *PRLinkRepo {
+	return &PRLinkRepo{db: db}
+}
+
+// orderedPair returns the two IDs with the smaller first, matching the
+// table's CHECK constraint.
+func orderedPair(a, b int64) (lo, hi int64) {
+	if a < b {
+		return a, b
+	}
+	return b, a
+}
+
+// Link associates two PRs. Idempotent — silently succeeds if already linked.
+func (r *PRLinkRepo) Link(ctx context.Context, prID, otherID int64) error {
+	if prID == otherID {
+		return fmt.Errorf("link PR %d to itself", prID)
+	}
+
+	lo, hi := orderedPair(prID, otherID)
+	const query = `INSERT OR IGNORE INTO pr_links (pr_id, linked_pr_id) VALUES (?, ?)`
+	if _, err := r.db.Writer.ExecContext(ctx, query, lo, hi); err != nil {
+		return fmt.Errorf("link PRs %d and %d: %w", prID, otherID, err)
+	}
+	return nil
+}
+
+// Unlink removes the association between two PRs. No-op if not linked.
+func (r *PRLinkRepo) Unlink(ctx context.Context, prID, otherID int64) error {
+	lo, hi := orderedPair(prID, otherID)
+	const query = `DELETE FROM pr_links WHERE pr_id = ? AND linked_pr_id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, lo, hi); err != nil {
+		return fmt.Errorf("unlink PRs %d and %d: %w", prID, otherID, err)
+	}
+	return nil
+}
+
+// ListLinkedIDs returns the IDs of all PRs linked to the given PR, oldest link first.
+func (r *PRLinkRepo) ListLinkedIDs(ctx context.Context, prID int64) ([]int64, error) {
+	const query = `
+		SELECT linked_pr_id, linked_at FROM pr_links WHERE pr_id = ?
+		UNION ALL
+		SELECT pr_id, linked_at FROM pr_links WHERE linked_pr_id = ?
+		ORDER BY linked_at, 1`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, prID, prID)
+	if err != nil {
+		return nil, fmt.Errorf("list links for PR %d: %w", prID, err)
+	}
+	defer rows.Close()
+
+	var result []int64
+	for rows.Next() {
+		var id int64
+		var linkedAt string
+		if err := rows.Scan(&id, &linkedAt); err != nil {
+			return nil, fmt.Errorf("scan linked PR: %w", err)
+		}
+		result = append(result, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate linked PRs: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRLinkRepo_LinkIsUndirected(t *testing.T) {
+	db := setupTestDB(t)
+	first := addTestPR(t, db, testRepoFullName, 1)
+	second := insertPRForIgnoreTest(t, db, testRepoFullName, 2)
+	repo := NewPRLinkRepo(db)
+	ctx := context.Background()
+
+	// Link from the higher ID to exercise pair ordering.
+	require.NoError(t, repo.Link(ctx, second, first))
+
+	fromFirst, err := repo.ListLinkedIDs(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{second}, fromFirst)
+
+	fromSecond, err := repo.ListLinkedIDs(ctx, second)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{first}, fromSecond)
+}
+
+func TestPRLinkRepo_Link_Idempotent(t *testing.T) {
+	db := setupTestDB(t)
+	first := addTestPR(t, db, testRepoFullName, 1)
+	second := insertPRForIgnoreTest(t, db, testRepoFullName, 2)
+	repo := NewPRLinkRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Link(ctx, first, second))
+	require.NoError(t, repo.Link(ctx, second, first))
+
+	linked, err := repo.ListLinkedIDs(ctx, first)
+	require.NoError(t, err)
+	assert.Len(t, linked, 1)
+}
+
+func TestPRLinkRepo_Link_Self(t *testing.T) {
+	db := setupTestDB(t)
+	first := addTestPR(t, db, testRepoFullName, 1)
+
+	err := NewPRLinkRepo(db).Link(context.Background(), first, first)
+	assert.Error(t, err)
+}
+
+func TestPRLinkRepo_Unlink(t *testing.T) {
+	db := setupTestDB(t)
+	first := addTestPR(t, db, testRepoFullName, 1)
+	second := insertPRForIgnoreTest(t, db, testRepoFullName, 2)
+	third := insertPRForIgnoreTest(t, db, testRepoFullName, 3)
+	repo := NewPRLinkRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Link(ctx, first, second))
+	require.NoError(t, repo.Link(ctx, first, third))
+	require.NoError(t, repo.Unlink(ctx, second, first))
+
+	linked, err := repo.ListLinkedIDs(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{third}, linked)
+}
+
+func TestPRLinkRepo_CascadeOnPRDelete(t *testing.T) {
+	db := setupTestDB(t)
+	first := addTestPR(t, db, testRepoFullName, 1)
+	second := insertPRForIgnoreTest(t, db, testRepoFullName, 2)
+	repo := NewPRLinkRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Link(ctx, first, second))
+	require.NoError(t, NewPRRepo(db).Delete(ctx, testRepoFullName, 2))
+
+	linked, err := repo.ListLinkedIDs(ctx, first)
+	require.NoError(t, err)
+	assert.Empty(t, linked)
+}
@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.PRNoteStore = (*PRNoteRepo)(nil)
+
+// PRNoteRepo is the SQLite implementation of the PRNoteStore port interface.
+type PRNoteRepo struct {
+	db *DB
+}
+
+// NewPRNoteRepo creates a new PRNoteRepo backed by the given DB.
+func NewPRNoteRepo(db *DB) *PRNoteRepo {
+	return &PRNoteRepo{db: db}
+}
+
+// Add inserts a note stamped with the current time.
+func (r *PRNoteRepo) Add(ctx context.Context, note model.PRNote) (model.PRNote, error) {
+	const query = `
+		INSERT INTO pr_notes (repo_full_name, pr_number, body, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	now := time.Now().UTC()
+	res, err := r.db.Writer.ExecContext(ctx, query, note.RepoFullName, note.PRNumber, note.Body, now, now)
+	if err != nil {
+		return model.PRNote{}, fmt.Errorf("add note to %s#%d: %w", note.RepoFullName, note.PRNumber, err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return model.PRNote{}, fmt.Errorf("get note id: %w", err)
+	}
+
+	note.ID = id
+	note.CreatedAt = now
+	note.UpdatedAt = now
+	return note, nil
+}
+
+// Delete removes the note with the given ID.
+func (r *PRNoteRepo) Delete(ctx context.Context, id int64) error {
+	const query = `DELETE FROM pr_notes WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete note %d: %w", id, err)
+	}
+	return nil
+}
+
+// ListByPR returns the notes on a pull request ordered by creation time.
+func (r *PRNoteRepo) ListByPR(ctx context.Context, repoFullName string, prNumber int) ([]model.PRNote, error) {
+	const query = `
+		SELECT id, repo_full_name, pr_number, body, created_at, updated_at
+		FROM pr_notes
+		WHERE repo_full_name = ? AND pr_number = ?
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, repoFullName, prNumber)
+	if err != nil {
+		return nil, fmt.Errorf("list notes for %s#%d: %w", repoFullName, prNumber, err)
+	}
+	return scanPRNotes(rows)
+}
+
+// Search returns notes matching query, newest first. Matching is a
+// case-insensitive substring match on the note body.
+func (r *PRNoteRepo) Search(ctx context.Context, repoFullName, query string) ([]model.PRNote, error) {
+	const stmt = `
+		SELECT id, repo_full_name, pr_number, body, created_at, updated_at
+		FROM pr_notes
+		WHERE (? = '' OR repo_full_name = ?)
+		  AND (? = '' OR body LIKE ? ESCAPE '\')
+		ORDER BY updated_at DESC, id DESC`
+
+	query = strings.TrimSpace(query)
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+
+	rows, err := r.db.Reader.QueryContext(ctx, stmt, repoFullName, repoFullName, query, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("search notes: %w", err)
+	}
+	return scanPRNotes(rows)
+}
+
+// Import inserts notes in a single transaction, skipping any whose body
+// already exists on the same PR so that re-importing an export is harmless.
+// Zero timestamps are replaced with the current time.
+func (r *PRNoteRepo) Import(ctx context.Context, notes []model.PRNote) (int, error) {
+	const query = `
+		INSERT INTO pr_notes (repo_full_name, pr_number, body, created_at, updated_at)
+		SELECT ?1, ?2, ?3, ?4, ?5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM pr_notes WHERE repo_full_name = ?1 AND pr_number = ?2 AND body = ?3
+		)`
+
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin note import: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	added := 0
+	for _, n := range notes {
+		createdAt, updatedAt := n.CreatedAt.UTC(), n.UpdatedAt.UTC()
+		if n.CreatedAt.IsZero() {
+			createdAt = now
+		}
+		if n.UpdatedAt.IsZero() {
+			updatedAt = createdAt
+		}
+
+		res, err := tx.ExecContext(ctx, query, n.RepoFullName, n.PRNumber, n.Body, createdAt, updatedAt)
+		if err != nil {
+			return 0, fmt.Errorf("import note for %s#%d: %w", n.RepoFullName, n.PRNumber, err)
+		}
+		if affected, err := res.RowsAffected(); err == nil && affected > 0 {
+			added++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit note import: %w", err)
+	}
+	return added, nil
+}
+
+// scanPRNotes reads all note rows and closes rows.
+func scanPRNotes(rows *sql.Rows) ([]model.PRNote, error) {
+	defer rows.Close()
+
+	var result []model.PRNote
+	for rows.Next() {
+		var n model.PRNote
+		var createdAt, updatedAt string
+		if err := rows.Scan(&n.ID, &n.RepoFullName, &n.PRNumber, &n.Body, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("scan note: %w", err)
+		}
+
+		var err error
+		if n.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for note %d: %w", n.ID, err)
+		}
+		if n.UpdatedAt, err = parseTime(updatedAt); err != nil {
+			return nil, fmt.Errorf("parse updated_at for note %d: %w", n.ID, err)
+		}
+		result = append(result, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate notes: %w", err)
+	}
+	return result, nil
+}
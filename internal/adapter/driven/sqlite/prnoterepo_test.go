@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestPRNoteRepo_AddListDelete(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPRNoteRepo(db)
+	ctx := context.Background()
+
+	first, err := repo.Add(ctx, model.PRNote{RepoFullName: "octocat/hello-world", PRNumber: 1, Body: "Agreed on a call to split the migration"})
+	require.NoError(t, err)
+	assert.NotZero(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+
+	_, err = repo.Add(ctx, model.PRNote{RepoFullName: "octocat/hello-world", PRNumber: 1, Body: "Follow up after release"})
+	require.NoError(t, err)
+	_, err = repo.Add(ctx, model.PRNote{RepoFullName: "octocat/hello-world", PRNumber: 2, Body: "Other PR"})
+	require.NoError(t, err)
+
+	notes, err := repo.ListByPR(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, "Agreed on a call to split the migration", notes[0].Body)
+
+	require.NoError(t, repo.Delete(ctx, first.ID))
+	notes, err = repo.ListByPR(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "Follow up after release", notes[0].Body)
+}
+
+func TestPRNoteRepo_Search(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPRNoteRepo(db)
+	ctx := context.Background()
+
+	for _, n := range []model.PRNote{
+		{RepoFullName: "octocat/hello-world", PRNumber: 1, Body: "Verbal OK from Dana"},
+		{RepoFullName: "octocat/other", PRNumber: 3, Body: "dana wants benchmarks"},
+		{RepoFullName: "octocat/other", PRNumber: 4, Body: "100% coverage required"},
+	} {
+		_, err := repo.Add(ctx, n)
+		require.NoError(t, err)
+	}
+
+	notes, err := repo.Search(ctx, "", "DANA")
+	require.NoError(t, err)
+	assert.Len(t, notes, 2, "match is case-insensitive")
+
+	notes, err = repo.Search(ctx, "octocat/other", "dana")
+	require.NoError(t, err)
+	require.Len(t, notes, 1)
+	assert.Equal(t, 3, notes[0].PRNumber)
+
+	notes, err = repo.Search(ctx, "", "0%")
+	require.NoError(t, err)
+	require.Len(t, notes, 1, "LIKE wildcards match literally")
+
+	notes, err = repo.Search(ctx, "", "")
+	require.NoError(t, err)
+	assert.Len(t, notes, 3)
+}
+
+func TestPRNoteRepo_ImportSkipsDuplicates(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewPRNoteRepo(db)
+	ctx := context.Background()
+
+	_, err := repo.Add(ctx, model.PRNote{RepoFullName: "octocat/hello-world", PRNumber: 1, Body: "existing"})
+	require.NoError(t, err)
+
+	created := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	added, err := repo.Import(ctx, []model.PRNote{
+		{RepoFullName: "octocat/hello-world", PRNumber: 1, Body: "existing"},
+		{RepoFullName: "octocat/hello-world", PRNumber: 1, Body: "imported", CreatedAt: created, UpdatedAt: created},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	notes, err := repo.ListByPR(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	assert.Equal(t, "imported", notes[0].Body, "imported timestamps are kept")
+	assert.True(t, created.Equal(notes[0].CreatedAt))
+
+	added, err = repo.Import(ctx, notes)
+	require.NoError(t, err)
+	assert.Zero(t, added, "re-importing is a no-op")
+}
@@ -6,13 +6,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
-// Compile-time interface satisfaction check.
-var _ driven.PRStore = (*PRRepo)(nil)
+// Compile-time interface satisfaction checks.
+var (
+	_ driven.PRStore            = (*PRRepo)(nil)
+	_ driven.ContributorStore   = (*PRRepo)(nil)
+	_ driven.ChecklistStore     = (*PRRepo)(nil)
+	_ driven.ReviewRequestStore = (*PRRepo)(nil)
+	_ driven.ReviewRollupStore  = (*PRRepo)(nil)
+)
 
 // PRRepo is the SQLite implementation of the PRStore port interface.
 type PRRepo struct {
@@ -32,8 +39,8 @@ func (r *PRRepo) Upsert(ctx context.Context, pr model.PullRequest) error {
 			number, repo_full_name, title, author, status, is_draft, needs_review,
 			url, branch, base_branch, labels, head_sha,
 			additions, deletions, changed_files, mergeable_status, ci_status,
-			opened_at, updated_at, last_activity_at, jira_key
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			opened_at, updated_at, last_activity_at, jira_key, closed_at, author_association, milestone
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(repo_full_name, number) DO UPDATE SET
 			title = excluded.title,
 			author = excluded.author,
@@ -53,7 +60,10 @@ func (r *PRRepo) Upsert(ctx context.Context, pr model.PullRequest) error {
 			opened_at = excluded.opened_at,
 			updated_at = excluded.updated_at,
 			last_activity_at = excluded.last_activity_at,
-			jira_key = excluded.jira_key
+			jira_key = excluded.jira_key,
+			closed_at = excluded.closed_at,
+			author_association = excluded.author_association,
+			milestone = excluded.milestone
 	`
 
 	labels := pr.Labels
@@ -85,11 +95,17 @@ func (r *PRRepo) Upsert(ctx context.Context, pr model.PullRequest) error {
 		ciStatus = string(model.CIStatusUnknown)
 	}
 
+	var closedAt any
+	if !pr.ClosedAt.IsZero() {
+		closedAt = pr.ClosedAt.UTC()
+	}
+
 	_, err = r.db.Writer.ExecContext(ctx, query,
 		pr.Number, pr.RepoFullName, pr.Title, pr.Author, string(pr.Status), isDraft, needsReview,
 		pr.URL, pr.Branch, pr.BaseBranch, string(labelsJSON), pr.HeadSHA,
 		pr.Additions, pr.Deletions, pr.ChangedFiles, mergeableStatus, ciStatus,
-		pr.OpenedAt.UTC(), pr.UpdatedAt.UTC(), pr.LastActivityAt.UTC(), pr.JiraKey,
+		pr.OpenedAt.UTC(), pr.UpdatedAt.UTC(), pr.LastActivityAt.UTC(), pr.JiraKey, closedAt, string(pr.AuthorAssociation),
+		pr.Milestone,
 	)
 	if err != nil {
 		return fmt.Errorf("upsert pull request %s#%d: %w", pr.RepoFullName, pr.Number, err)
@@ -104,7 +120,8 @@ func (r *PRRepo) GetByRepository(ctx context.Context, repoFullName string) ([]mo
 		SELECT id, number, repo_full_name, title, author, status, is_draft, needs_review,
 		       url, branch, base_branch, labels, head_sha,
 		       additions, deletions, changed_files, mergeable_status, ci_status,
-		       opened_at, updated_at, last_activity_at, jira_key
+		       opened_at, updated_at, last_activity_at, jira_key, closed_at, author_association, milestone, checklist_done, checklist_total, review_requests,
+		       review_status, approval_count, unresolved_thread_count
 		FROM pull_requests
 		WHERE repo_full_name = ?
 		ORDER BY number
@@ -113,15 +130,22 @@ func (r *PRRepo) GetByRepository(ctx context.Context, repoFullName string) ([]mo
 	return r.queryPRs(ctx, query, repoFullName)
 }
 
+// watchedRepos selects the full names of repositories not in the trash. PR
+// listings filter on it so a removed repository's PRs disappear with it.
+const watchedRepos = `(SELECT full_name FROM repositories WHERE removed_at IS NULL)`
+
 // GetByStatus returns all pull requests with the given status, ordered by updated_at descending.
+// PRs of trashed repositories are excluded.
 func (r *PRRepo) GetByStatus(ctx context.Context, status model.PRStatus) ([]model.PullRequest, error) {
 	const query = `
 		SELECT id, number, repo_full_name, title, author, status, is_draft, needs_review,
 		       url, branch, base_branch, labels, head_sha,
 		       additions, deletions, changed_files, mergeable_status, ci_status,
-		       opened_at, updated_at, last_activity_at, jira_key
+		       opened_at, updated_at, last_activity_at, jira_key, closed_at, author_association, milestone, checklist_done, checklist_total, review_requests,
+		       review_status, approval_count, unresolved_thread_count
 		FROM pull_requests
 		WHERE status = ?
+		  AND repo_full_name IN ` + watchedRepos + `
 		ORDER BY updated_at DESC
 	`
 
@@ -135,7 +159,8 @@ func (r *PRRepo) GetByNumber(ctx context.Context, repoFullName string, number in
 		SELECT id, number, repo_full_name, title, author, status, is_draft, needs_review,
 		       url, branch, base_branch, labels, head_sha,
 		       additions, deletions, changed_files, mergeable_status, ci_status,
-		       opened_at, updated_at, last_activity_at, jira_key
+		       opened_at, updated_at, last_activity_at, jira_key, closed_at, author_association, milestone, checklist_done, checklist_total, review_requests,
+		       review_status, approval_count, unresolved_thread_count
 		FROM pull_requests
 		WHERE repo_full_name = ? AND number = ?
 	`
@@ -152,35 +177,77 @@ func (r *PRRepo) GetByNumber(ctx context.Context, repoFullName string, number in
 }
 
 // ListAll returns all pull requests ordered by updated_at descending.
-// Ignored PRs (those with a matching ignored_prs record) are excluded automatically.
+// Ignored PRs (those with a matching ignored_prs record) and PRs of trashed
+// repositories are excluded automatically.
 func (r *PRRepo) ListAll(ctx context.Context) ([]model.PullRequest, error) {
 	const query = `
 		SELECT pr.id, pr.number, pr.repo_full_name, pr.title, pr.author, pr.status, pr.is_draft, pr.needs_review,
 		       pr.url, pr.branch, pr.base_branch, pr.labels, pr.head_sha,
 		       pr.additions, pr.deletions, pr.changed_files, pr.mergeable_status, pr.ci_status,
-		       pr.opened_at, pr.updated_at, pr.last_activity_at, pr.jira_key
+		       pr.opened_at, pr.updated_at, pr.last_activity_at, pr.jira_key, pr.closed_at, pr.author_association, pr.milestone, pr.checklist_done, pr.checklist_total, pr.review_requests,
+		       pr.review_status, pr.approval_count, pr.unresolved_thread_count
 		FROM pull_requests pr
 		LEFT JOIN ignored_prs ip ON ip.pr_id = pr.id
 		WHERE ip.pr_id IS NULL
+		  AND pr.repo_full_name IN ` + watchedRepos + `
 		ORDER BY pr.updated_at DESC
 	`
 
 	return r.queryPRs(ctx, query)
 }
 
+// CountByStatus counts the pull requests ListAll returns, grouped by
+// repository, status, and CI status.
+func (r *PRRepo) CountByStatus(ctx context.Context) ([]driven.PRStatusCount, error) {
+	const query = `
+		SELECT pr.repo_full_name, pr.status, pr.ci_status, COUNT(*)
+		FROM pull_requests pr
+		LEFT JOIN ignored_prs ip ON ip.pr_id = pr.id
+		WHERE ip.pr_id IS NULL
+		  AND pr.repo_full_name IN ` + watchedRepos + `
+		GROUP BY pr.repo_full_name, pr.status, pr.ci_status
+	`
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("count PRs by status: %w", err)
+	}
+	defer rows.Close()
+
+	var result []driven.PRStatusCount
+	for rows.Next() {
+		var c driven.PRStatusCount
+		if err := rows.Scan(&c.RepoFullName, &c.Status, &c.CIStatus, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan PR count: %w", err)
+		}
+		result = append(result, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate PR counts: %w", err)
+	}
+	return result, nil
+}
+
 // ListNeedingReview returns all pull requests where needs_review is true,
-// ordered by updated_at descending.
-// Ignored PRs are excluded automatically.
+// ordered by updated_at descending. Watch states override needs_review: open
+// PRs the user watches are included and unwatched PRs are left out.
+// Ignored PRs and PRs of trashed repositories are excluded automatically.
 func (r *PRRepo) ListNeedingReview(ctx context.Context) ([]model.PullRequest, error) {
 	const query = `
 		SELECT pr.id, pr.number, pr.repo_full_name, pr.title, pr.author, pr.status, pr.is_draft, pr.needs_review,
 		       pr.url, pr.branch, pr.base_branch, pr.labels, pr.head_sha,
 		       pr.additions, pr.deletions, pr.changed_files, pr.mergeable_status, pr.ci_status,
-		       pr.opened_at, pr.updated_at, pr.last_activity_at, pr.jira_key
+		       pr.opened_at, pr.updated_at, pr.last_activity_at, pr.jira_key, pr.closed_at, pr.author_association, pr.milestone, pr.checklist_done, pr.checklist_total, pr.review_requests,
+		       pr.review_status, pr.approval_count, pr.unresolved_thread_count
 		FROM pull_requests pr
 		LEFT JOIN ignored_prs ip ON ip.pr_id = pr.id
-		WHERE pr.needs_review = 1
+		LEFT JOIN pr_watches w ON w.pr_id = pr.id
+		WHERE CASE w.state
+		        WHEN 'watching' THEN pr.status = 'open'
+		        WHEN 'unwatched' THEN 0
+		        ELSE pr.needs_review = 1
+		      END
 		  AND ip.pr_id IS NULL
+		  AND pr.repo_full_name IN ` + watchedRepos + `
 		ORDER BY pr.updated_at DESC
 	`
 
@@ -194,15 +261,84 @@ func (r *PRRepo) ListIgnoredWithPRData(ctx context.Context) ([]model.PullRequest
 		SELECT pr.id, pr.number, pr.repo_full_name, pr.title, pr.author, pr.status, pr.is_draft, pr.needs_review,
 		       pr.url, pr.branch, pr.base_branch, pr.labels, pr.head_sha,
 		       pr.additions, pr.deletions, pr.changed_files, pr.mergeable_status, pr.ci_status,
-		       pr.opened_at, pr.updated_at, pr.last_activity_at, pr.jira_key
+		       pr.opened_at, pr.updated_at, pr.last_activity_at, pr.jira_key, pr.closed_at, pr.author_association, pr.milestone, pr.checklist_done, pr.checklist_total, pr.review_requests,
+		       pr.review_status, pr.approval_count, pr.unresolved_thread_count
 		FROM pull_requests pr
 		INNER JOIN ignored_prs ip ON ip.pr_id = pr.id
+		WHERE pr.repo_full_name IN ` + watchedRepos + `
 		ORDER BY ip.ignored_at DESC
 	`
 
 	return r.queryPRs(ctx, query)
 }
 
+// HasMergedPR reports whether author has a merged pull request stored for the
+// repository. Pruned archive entries no longer count.
+func (r *PRRepo) HasMergedPR(ctx context.Context, repoFullName, author string) (bool, error) {
+	const query = `
+		SELECT EXISTS (
+			SELECT 1 FROM pull_requests
+			WHERE repo_full_name = ? AND author = ? AND status = 'merged'
+		)`
+
+	var merged bool
+	if err := r.db.Reader.QueryRowContext(ctx, query, repoFullName, author).Scan(&merged); err != nil {
+		return false, fmt.Errorf("check merged PRs by %s in %s: %w", author, repoFullName, err)
+	}
+	return merged, nil
+}
+
+// SetReviewRollup stores a PR's aggregated review state.
+func (r *PRRepo) SetReviewRollup(ctx context.Context, prID int64, rollup model.ReviewRollup) error {
+	const query = `UPDATE pull_requests SET review_status = ?, approval_count = ?, unresolved_thread_count = ? WHERE id = ?`
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, string(rollup.Status), rollup.Approvals, rollup.UnresolvedThreads, prID); err != nil {
+		return fmt.Errorf("set review rollup for PR %d: %w", prID, err)
+	}
+	return nil
+}
+
+// SetChecklist stores a PR's task list progress.
+func (r *PRRepo) SetChecklist(ctx context.Context, prID int64, progress model.ChecklistProgress) error {
+	const query = `UPDATE pull_requests SET checklist_done = ?, checklist_total = ? WHERE id = ?`
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, progress.Done, progress.Total, prID); err != nil {
+		return fmt.Errorf("set checklist for PR %d: %w", prID, err)
+	}
+	return nil
+}
+
+// storedReviewRequest is the JSON form of a review request in the
+// review_requests column.
+type storedReviewRequest struct {
+	Reviewer    string `json:"reviewer"`
+	IsTeam      bool   `json:"team,omitempty"`
+	RequestedAt string `json:"requested_at"`
+}
+
+// SetReviewRequests stores a PR's pending review requests.
+func (r *PRRepo) SetReviewRequests(ctx context.Context, prID int64, requests []model.ReviewRequest) error {
+	const query = `UPDATE pull_requests SET review_requests = ? WHERE id = ?`
+
+	stored := make([]storedReviewRequest, 0, len(requests))
+	for _, req := range requests {
+		stored = append(stored, storedReviewRequest{
+			Reviewer:    req.Reviewer,
+			IsTeam:      req.IsTeam,
+			RequestedAt: req.RequestedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	requestsJSON, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("marshal review requests: %w", err)
+	}
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, string(requestsJSON), prID); err != nil {
+		return fmt.Errorf("set review requests for PR %d: %w", prID, err)
+	}
+	return nil
+}
+
 // Delete removes a pull request by repository and number. Returns an error if
 // the pull request does not exist.
 func (r *PRRepo) Delete(ctx context.Context, repoFullName string, number int) error {
@@ -256,13 +392,19 @@ func scanPR(s scanner) (*model.PullRequest, error) {
 	var labelsJSON string
 	var mergeableStatus, ciStatus string
 	var openedAt, updatedAt, lastActivityAt string
+	var closedAt sql.NullString
+	var association string
+	var requestsJSON string
+	var reviewStatus string
 
 	err := s.Scan(
 		&pr.ID, &pr.Number, &pr.RepoFullName, &pr.Title, &pr.Author,
 		&status, &isDraft, &needsReview, &pr.URL, &pr.Branch, &pr.BaseBranch,
 		&labelsJSON, &pr.HeadSHA,
 		&pr.Additions, &pr.Deletions, &pr.ChangedFiles, &mergeableStatus, &ciStatus,
-		&openedAt, &updatedAt, &lastActivityAt, &pr.JiraKey,
+		&openedAt, &updatedAt, &lastActivityAt, &pr.JiraKey, &closedAt, &association, &pr.Milestone,
+		&pr.Checklist.Done, &pr.Checklist.Total, &requestsJSON,
+		&reviewStatus, &pr.ReviewRollup.Approvals, &pr.ReviewRollup.UnresolvedThreads,
 	)
 	if err != nil {
 		return nil, err
@@ -273,11 +415,30 @@ func scanPR(s scanner) (*model.PullRequest, error) {
 	pr.NeedsReview = needsReview != 0
 	pr.MergeableStatus = model.MergeableStatus(mergeableStatus)
 	pr.CIStatus = model.CIStatus(ciStatus)
+	pr.AuthorAssociation = model.AuthorAssociation(association)
+	pr.ReviewRollup.Status = model.ReviewState(reviewStatus)
 
 	if err := json.Unmarshal([]byte(labelsJSON), &pr.Labels); err != nil {
 		return nil, fmt.Errorf("unmarshal labels: %w", err)
 	}
 
+	var requests []storedReviewRequest
+	if err := json.Unmarshal([]byte(requestsJSON), &requests); err != nil {
+		return nil, fmt.Errorf("unmarshal review requests: %w", err)
+	}
+	for _, req := range requests {
+		requestedAt, err := parseTime(req.RequestedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse review request time: %w", err)
+		}
+		pr.ReviewRequests = append(pr.ReviewRequests, model.ReviewRequest{Reviewer: req.Reviewer, IsTeam: req.IsTeam, RequestedAt: requestedAt})
+		if req.IsTeam {
+			pr.RequestedTeamSlugs = append(pr.RequestedTeamSlugs, req.Reviewer)
+		} else {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, req.Reviewer)
+		}
+	}
+
 	pr.OpenedAt, err = parseTime(openedAt)
 	if err != nil {
 		return nil, fmt.Errorf("parse opened_at: %w", err)
@@ -293,5 +454,12 @@ func scanPR(s scanner) (*model.PullRequest, error) {
 		return nil, fmt.Errorf("parse last_activity_at: %w", err)
 	}
 
+	if closedAt.Valid {
+		pr.ClosedAt, err = parseTime(closedAt.String)
+		if err != nil {
+			return nil, fmt.Errorf("parse closed_at: %w", err)
+		}
+	}
+
 	return &pr, nil
 }
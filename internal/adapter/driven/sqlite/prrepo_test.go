@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -162,6 +163,32 @@ func TestPRRepo_ListAll(t *testing.T) {
 	assert.Len(t, all, 3)
 }
 
+func TestPRRepo_CountByStatus(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, "octocat/hello-world")
+	addTestRepo(t, db, "octocat/other-repo")
+	prRepo := NewPRRepo(db)
+	ctx := context.Background()
+
+	failing := makePR("octocat/hello-world", 1, "PR 1", model.PRStatusOpen)
+	failing.CIStatus = model.CIStatusFailing
+	require.NoError(t, prRepo.Upsert(ctx, failing))
+	failing.Number = 2
+	require.NoError(t, prRepo.Upsert(ctx, failing))
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 3, "PR 3", model.PRStatusMerged)))
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/other-repo", 1, "PR 4", model.PRStatusOpen)))
+	ignored := insertPRForIgnoreTest(t, db, "octocat/other-repo", 2)
+	require.NoError(t, NewIgnoreRepo(db).Ignore(ctx, ignored, ""))
+
+	counts, err := prRepo.CountByStatus(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []driven.PRStatusCount{
+		{RepoFullName: "octocat/hello-world", Status: model.PRStatusOpen, CIStatus: model.CIStatusFailing, Count: 2},
+		{RepoFullName: "octocat/hello-world", Status: model.PRStatusMerged, CIStatus: model.CIStatusUnknown, Count: 1},
+		{RepoFullName: "octocat/other-repo", Status: model.PRStatusOpen, CIStatus: model.CIStatusUnknown, Count: 1},
+	}, counts, "ignored PRs are not counted")
+}
+
 func TestPRRepo_Delete(t *testing.T) {
 	db := setupTestDB(t)
 	addTestRepo(t, db, "octocat/hello-world")
@@ -282,6 +309,39 @@ func TestPRRepo_ListNeedingReview(t *testing.T) {
 	assert.True(t, prs[0].NeedsReview)
 }
 
+func TestPRRepo_ListNeedingReview_WatchStates(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, "octocat/hello-world")
+	prRepo := NewPRRepo(db)
+	watches := NewWatchRepo(db)
+	ctx := context.Background()
+
+	requested := makePR("octocat/hello-world", 1, "Requested", model.PRStatusOpen)
+	requested.NeedsReview = true
+	unwatched := makePR("octocat/hello-world", 2, "Requested but unwatched", model.PRStatusOpen)
+	unwatched.NeedsReview = true
+	watched := makePR("octocat/hello-world", 3, "Watched", model.PRStatusOpen)
+	watchedClosed := makePR("octocat/hello-world", 4, "Watched but closed", model.PRStatusClosed)
+	ids := make(map[int]int64)
+	for _, pr := range []model.PullRequest{requested, unwatched, watched, watchedClosed} {
+		require.NoError(t, prRepo.Upsert(ctx, pr))
+		got, err := prRepo.GetByNumber(ctx, pr.RepoFullName, pr.Number)
+		require.NoError(t, err)
+		ids[pr.Number] = got.ID
+	}
+	require.NoError(t, watches.SetWatchState(ctx, ids[2], model.WatchStateUnwatched))
+	require.NoError(t, watches.SetWatchState(ctx, ids[3], model.WatchStateWatching))
+	require.NoError(t, watches.SetWatchState(ctx, ids[4], model.WatchStateWatching))
+
+	prs, err := prRepo.ListNeedingReview(ctx)
+	require.NoError(t, err)
+	var numbers []int
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+	assert.ElementsMatch(t, []int{1, 3}, numbers)
+}
+
 func TestPRRepo_IsDraft(t *testing.T) {
 	db := setupTestDB(t)
 	addTestRepo(t, db, "octocat/hello-world")
@@ -344,3 +404,95 @@ func TestPRRepo_HealthSignals_Defaults(t *testing.T) {
 	assert.Equal(t, model.MergeableUnknown, got.MergeableStatus)
 	assert.Equal(t, model.CIStatusUnknown, got.CIStatus)
 }
+
+func TestPRRepo_HasMergedPR(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, "octocat/hello-world")
+	prRepo := NewPRRepo(db)
+	ctx := context.Background()
+
+	open := makePR("octocat/hello-world", 1, "Open PR", model.PRStatusOpen)
+	open.Author = "newcomer"
+	open.AuthorAssociation = model.AssociationNone
+	require.NoError(t, prRepo.Upsert(ctx, open))
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 2, "Merged PR", model.PRStatusMerged)))
+
+	got, err := prRepo.GetByNumber(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.AssociationNone, got.AuthorAssociation)
+
+	merged, err := prRepo.HasMergedPR(ctx, "octocat/hello-world", "testuser")
+	require.NoError(t, err)
+	assert.True(t, merged)
+
+	merged, err = prRepo.HasMergedPR(ctx, "octocat/hello-world", "newcomer")
+	require.NoError(t, err)
+	assert.False(t, merged, "an open PR is not merge history")
+}
+
+func TestPRRepo_SetChecklist(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, "octocat/hello-world")
+	prRepo := NewPRRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 1, "Checklist PR", model.PRStatusOpen)))
+	stored, err := prRepo.GetByNumber(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	require.NoError(t, prRepo.SetChecklist(ctx, stored.ID, model.ChecklistProgress{Done: 2, Total: 5}))
+
+	// A later upsert from polling leaves the checklist alone.
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 1, "Renamed", model.PRStatusOpen)))
+	got, err := prRepo.GetByNumber(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.ChecklistProgress{Done: 2, Total: 5}, got.Checklist)
+}
+
+func TestPRRepo_SetReviewRequests(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, "octocat/hello-world")
+	prRepo := NewPRRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 1, "Requests PR", model.PRStatusOpen)))
+	stored, err := prRepo.GetByNumber(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	assert.Empty(t, stored.ReviewRequests)
+
+	requestedAt := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+	requests := []model.ReviewRequest{
+		{Reviewer: "alice", RequestedAt: requestedAt},
+		{Reviewer: "core", IsTeam: true, RequestedAt: requestedAt.Add(time.Hour)},
+	}
+	require.NoError(t, prRepo.SetReviewRequests(ctx, stored.ID, requests))
+
+	// A later upsert from polling leaves the requests alone.
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 1, "Renamed", model.PRStatusOpen)))
+	got, err := prRepo.GetByNumber(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	assert.Equal(t, requests, got.ReviewRequests)
+	assert.Equal(t, []string{"alice"}, got.RequestedReviewers)
+	assert.Equal(t, []string{"core"}, got.RequestedTeamSlugs)
+}
+
+func TestPRRepo_SetReviewRollup(t *testing.T) {
+	db := setupTestDB(t)
+	addTestRepo(t, db, "octocat/hello-world")
+	prRepo := NewPRRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 1, "Rollup PR", model.PRStatusOpen)))
+	stored, err := prRepo.GetByNumber(ctx, "octocat/hello-world", 1)
+	require.NoError(t, err)
+	assert.False(t, stored.ReviewRollup.Known(), "a new PR has no rollup yet")
+
+	rollup := model.ReviewRollup{Status: model.ReviewStateApproved, Approvals: 2, UnresolvedThreads: 1}
+	require.NoError(t, prRepo.SetReviewRollup(ctx, stored.ID, rollup))
+
+	// A later upsert from polling leaves the rollup alone.
+	require.NoError(t, prRepo.Upsert(ctx, makePR("octocat/hello-world", 1, "Renamed", model.PRStatusOpen)))
+	prs, err := prRepo.ListAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, rollup, prs[0].ReviewRollup)
+}
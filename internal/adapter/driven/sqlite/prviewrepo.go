@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.PRViewStore = (*PRViewRepo)(nil)
+
+// PRViewRepo is the SQLite implementation of the PRViewStore port interface.
+type PRViewRepo struct {
+	db *DB
+}
+
+// NewPRViewRepo creates a new PRViewRepo backed by the given DB.
+func NewPRViewRepo(db *DB) *PRViewRepo {
+	return &PRViewRepo{db: db}
+}
+
+// GetPRView returns the PR's last view, or nil when none is recorded.
+func (r *PRViewRepo) GetPRView(ctx context.Context, prID int64) (*model.PRView, error) {
+	const query = `SELECT viewed_at, head_sha, ci_status FROM pr_views WHERE pr_id = ?`
+
+	view := model.PRView{PRID: prID}
+	var viewedAt, ciStatus string
+	err := r.db.Reader.QueryRowContext(ctx, query, prID).Scan(&viewedAt, &view.HeadSHA, &ciStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get view of PR %d: %w", prID, err)
+	}
+	view.CIStatus = model.CIStatus(ciStatus)
+	if view.ViewedAt, err = parseTime(viewedAt); err != nil {
+		return nil, fmt.Errorf("parse viewed_at for PR %d: %w", prID, err)
+	}
+	return &view, nil
+}
+
+// RecordPRView upserts the PR's last view.
+func (r *PRViewRepo) RecordPRView(ctx context.Context, view model.PRView) error {
+	const query = `
+		INSERT INTO pr_views (pr_id, viewed_at, head_sha, ci_status)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(pr_id) DO UPDATE SET
+			viewed_at = excluded.viewed_at,
+			head_sha = excluded.head_sha,
+			ci_status = excluded.ci_status`
+
+	_, err := r.db.Writer.ExecContext(ctx, query, view.PRID, view.ViewedAt.UTC(), view.HeadSHA, string(view.CIStatus))
+	if err != nil {
+		return fmt.Errorf("record view of PR %d: %w", view.PRID, err)
+	}
+	return nil
+}
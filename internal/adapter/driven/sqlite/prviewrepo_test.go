@@ -0,0 +1,37 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPRViewRepo_RecordAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	prID := addTestPR(t, db, "octocat/hello-world", 1)
+	repo := NewPRViewRepo(db)
+	ctx := context.Background()
+
+	got, err := repo.GetPRView(ctx, prID)
+	require.NoError(t, err)
+	assert.Nil(t, got, "never viewed")
+
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.RecordPRView(ctx, model.PRView{PRID: prID, ViewedAt: at, HeadSHA: "abc", CIStatus: model.CIStatusFailing}))
+	require.NoError(t, repo.RecordPRView(ctx, model.PRView{PRID: prID, ViewedAt: at.Add(time.Hour), HeadSHA: "def", CIStatus: model.CIStatusPassing}))
+
+	got, err = repo.GetPRView(ctx, prID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, model.PRView{PRID: prID, ViewedAt: at.Add(time.Hour), HeadSHA: "def", CIStatus: model.CIStatusPassing}, *got)
+
+	// Views go away with their PR.
+	require.NoError(t, NewPRRepo(db).Delete(ctx, "octocat/hello-world", 1))
+	got, err = repo.GetPRView(ctx, prID)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
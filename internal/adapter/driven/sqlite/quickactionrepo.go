@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.QuickActionStore = (*QuickActionRepo)(nil)
+
+// global_settings keys for the quick action preferences.
+const (
+	// quickActionsKey holds the comma-separated enabled actions.
+	quickActionsKey = "card_quick_actions"
+	// approveMessageKey holds the body of one-click approving reviews.
+	approveMessageKey = "approve_message"
+	// approveConfirmKey holds "1" when one-click approving asks first.
+	approveConfirmKey = "approve_confirm"
+)
+
+// QuickActionRepo is the SQLite implementation of the QuickActionStore port
+// interface, persisted as a single row of global_settings.
+type QuickActionRepo struct {
+	db *DB
+}
+
+// NewQuickActionRepo creates a new QuickActionRepo backed by the given DB.
+func NewQuickActionRepo(db *DB) *QuickActionRepo {
+	return &QuickActionRepo{db: db}
+}
+
+// GetQuickActions returns the enabled quick actions, or the defaults when the
+// preference has never been saved. Unknown stored values are dropped.
+func (r *QuickActionRepo) GetQuickActions(ctx context.Context) ([]model.QuickAction, error) {
+	const query = `SELECT value FROM global_settings WHERE key = ?`
+
+	var value string
+	err := r.db.Reader.QueryRowContext(ctx, query, quickActionsKey).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.DefaultQuickActions(), nil
+	}
+	if err != nil {
+		return model.DefaultQuickActions(), fmt.Errorf("get quick actions: %w", err)
+	}
+
+	var actions []model.QuickAction
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			actions = append(actions, model.QuickAction(part))
+		}
+	}
+	return model.NormalizeQuickActions(actions), nil
+}
+
+// SetQuickActions persists the enabled quick actions in display order.
+func (r *QuickActionRepo) SetQuickActions(ctx context.Context, actions []model.QuickAction) error {
+	const query = `INSERT OR REPLACE INTO global_settings (key, value) VALUES (?, ?)`
+
+	normalized := model.NormalizeQuickActions(actions)
+	parts := make([]string, len(normalized))
+	for i, a := range normalized {
+		parts[i] = string(a)
+	}
+
+	if _, err := r.db.Writer.ExecContext(ctx, query, quickActionsKey, strings.Join(parts, ",")); err != nil {
+		return fmt.Errorf("set quick actions: %w", err)
+	}
+	return nil
+}
+
+// GetApproveSettings returns the approve action settings, falling back to
+// model.DefaultApproveSettings() for any key that has never been saved.
+func (r *QuickActionRepo) GetApproveSettings(ctx context.Context) (model.ApproveSettings, error) {
+	const query = `SELECT key, value FROM global_settings WHERE key IN (?, ?)`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, approveMessageKey, approveConfirmKey)
+	if err != nil {
+		return model.DefaultApproveSettings(), fmt.Errorf("get approve settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := model.DefaultApproveSettings()
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return model.DefaultApproveSettings(), fmt.Errorf("scan approve setting: %w", err)
+		}
+		switch key {
+		case approveMessageKey:
+			settings.Message = value
+		case approveConfirmKey:
+			settings.Confirm = value == "1"
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return model.DefaultApproveSettings(), fmt.Errorf("iterate approve settings: %w", err)
+	}
+	return settings, nil
+}
+
+// SetApproveSettings persists the approve action settings in one transaction.
+func (r *QuickActionRepo) SetApproveSettings(ctx context.Context, settings model.ApproveSettings) error {
+	const upsert = `INSERT OR REPLACE INTO global_settings (key, value) VALUES (?, ?)`
+
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	confirm := "0"
+	if settings.Confirm {
+		confirm = "1"
+	}
+	if _, err := tx.ExecContext(ctx, upsert, approveMessageKey, strings.TrimSpace(settings.Message)); err != nil {
+		return fmt.Errorf("set approve message: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, upsert, approveConfirmKey, confirm); err != nil {
+		return fmt.Errorf("set approve confirmation: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit approve settings: %w", err)
+	}
+	return nil
+}
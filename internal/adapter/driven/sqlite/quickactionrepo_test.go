@@ -0,0 +1,62 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickActionRepo_DefaultsWhenUnset(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewQuickActionRepo(db)
+
+	got, err := repo.GetQuickActions(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultQuickActions(), got)
+}
+
+func TestQuickActionRepo_SetAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewQuickActionRepo(db)
+	ctx := context.Background()
+
+	// Stored in display order, with unknown and duplicate values dropped.
+	require.NoError(t, repo.SetQuickActions(ctx, []model.QuickAction{
+		model.QuickActionApprove, "bogus", model.QuickActionOpenGitHub, model.QuickActionApprove,
+	}))
+
+	got, err := repo.GetQuickActions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []model.QuickAction{model.QuickActionOpenGitHub, model.QuickActionApprove}, got)
+}
+
+func TestQuickActionRepo_EmptyHidesAll(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewQuickActionRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SetQuickActions(ctx, nil))
+
+	got, err := repo.GetQuickActions(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestQuickActionRepo_ApproveSettings(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewQuickActionRepo(db)
+	ctx := context.Background()
+
+	got, err := repo.GetApproveSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultApproveSettings(), got)
+
+	require.NoError(t, repo.SetApproveSettings(ctx, model.ApproveSettings{Message: "  LGTM, dependency bump  \n"}))
+
+	got, err = repo.GetApproveSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApproveSettings{Message: "LGTM, dependency bump"}, got)
+}
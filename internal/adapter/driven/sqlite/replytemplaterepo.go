@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.ReplyTemplateStore = (*ReplyTemplateRepo)(nil)
+
+// ReplyTemplateRepo is the SQLite implementation of the ReplyTemplateStore port interface.
+type ReplyTemplateRepo struct {
+	db *DB
+}
+
+// NewReplyTemplateRepo creates a new ReplyTemplateRepo backed by the given DB.
+func NewReplyTemplateRepo(db *DB) *ReplyTemplateRepo {
+	return &ReplyTemplateRepo{db: db}
+}
+
+// Create persists a template and returns the assigned ID.
+func (r *ReplyTemplateRepo) Create(ctx context.Context, tpl model.ReplyTemplate) (int64, error) {
+	const query = `INSERT INTO reply_templates (name, kind, body, created_at) VALUES (?, ?, ?, ?)`
+
+	createdAt := tpl.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query, tpl.Name, string(tpl.Kind), tpl.Body, createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create reply template %q: %w", tpl.Name, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create reply template %q: last insert id: %w", tpl.Name, err)
+	}
+	return id, nil
+}
+
+// List returns all templates ordered by kind, then name.
+func (r *ReplyTemplateRepo) List(ctx context.Context) ([]model.ReplyTemplate, error) {
+	const query = `SELECT id, name, kind, body, created_at
+		FROM reply_templates ORDER BY kind, name COLLATE NOCASE, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list reply templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []model.ReplyTemplate
+	for rows.Next() {
+		var tpl model.ReplyTemplate
+		var kind, createdAt string
+		if err := rows.Scan(&tpl.ID, &tpl.Name, &kind, &tpl.Body, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan reply template: %w", err)
+		}
+		tpl.Kind = model.ReplyTemplateKind(kind)
+		if tpl.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for reply template %d: %w", tpl.ID, err)
+		}
+		templates = append(templates, tpl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reply templates: %w", err)
+	}
+	return templates, nil
+}
+
+// Delete removes a template by ID.
+func (r *ReplyTemplateRepo) Delete(ctx context.Context, id int64) error {
+	const query = `DELETE FROM reply_templates WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete reply template %d: %w", id, err)
+	}
+	return nil
+}
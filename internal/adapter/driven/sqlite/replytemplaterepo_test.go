@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplyTemplateRepo_CreateListDelete(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewReplyTemplateRepo(db)
+	ctx := context.Background()
+
+	templates, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, templates)
+
+	thanksID, err := repo.Create(ctx, model.ReplyTemplate{Name: "thanks", Kind: model.ReplyTemplateSnippet, Body: "Thanks {{author}}!"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.ReplyTemplate{Name: "LGTM", Kind: model.ReplyTemplateReview, Body: "Looks good."})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.ReplyTemplate{Name: "Ack", Kind: model.ReplyTemplateSnippet, Body: "Done."})
+	require.NoError(t, err)
+
+	templates, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, templates, 3)
+	assert.Equal(t, model.ReplyTemplateReview, templates[0].Kind, "ordered by kind")
+	assert.Equal(t, "Ack", templates[1].Name, "then by name, ignoring case")
+	assert.Equal(t, thanksID, templates[2].ID)
+	assert.Equal(t, "Thanks {{author}}!", templates[2].Body)
+	assert.False(t, templates[2].CreatedAt.IsZero())
+
+	require.NoError(t, repo.Delete(ctx, thanksID))
+	templates, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, templates, 2)
+}
@@ -0,0 +1,202 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.RepoGroupStore = (*RepoGroupRepo)(nil)
+
+// RepoGroupRepo is the SQLite implementation of the RepoGroupStore port interface.
+type RepoGroupRepo struct {
+	db *DB
+}
+
+// NewRepoGroupRepo creates a new RepoGroupRepo backed by the given DB.
+func NewRepoGroupRepo(db *DB) *RepoGroupRepo {
+	return &RepoGroupRepo{db: db}
+}
+
+// Create inserts a group and its members in one transaction.
+func (r *RepoGroupRepo) Create(ctx context.Context, group model.RepoGroup) (model.RepoGroup, error) {
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return model.RepoGroup{}, fmt.Errorf("begin create repo group: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	now := time.Now().UTC()
+	res, err := tx.ExecContext(ctx, `INSERT INTO repo_groups (name, created_at) VALUES (?, ?)`, group.Name, now)
+	if err != nil {
+		return model.RepoGroup{}, fmt.Errorf("create repo group %q: %w", group.Name, uniqueViolation(err, driven.ErrRepoGroupExists))
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return model.RepoGroup{}, fmt.Errorf("get repo group id: %w", err)
+	}
+
+	if err := replaceGroupMembers(ctx, tx, id, group.Repos); err != nil {
+		return model.RepoGroup{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return model.RepoGroup{}, fmt.Errorf("commit create repo group: %w", err)
+	}
+
+	group.ID = id
+	group.Repos = sortedUnique(group.Repos)
+	group.CreatedAt = now
+	return group, nil
+}
+
+// Update renames a group and replaces its members in one transaction.
+func (r *RepoGroupRepo) Update(ctx context.Context, group model.RepoGroup) error {
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin update repo group: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.ExecContext(ctx, `UPDATE repo_groups SET name = ? WHERE id = ?`, group.Name, group.ID)
+	if err != nil {
+		return fmt.Errorf("update repo group %d: %w", group.ID, uniqueViolation(err, driven.ErrRepoGroupExists))
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("update repo group %d: %w", group.ID, driven.ErrRepoGroupNotFound)
+	}
+
+	if err := replaceGroupMembers(ctx, tx, group.ID, group.Repos); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit update repo group: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a group; its members go with it via ON DELETE CASCADE.
+func (r *RepoGroupRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.Writer.ExecContext(ctx, `DELETE FROM repo_groups WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete repo group %d: %w", id, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+	if n == 0 {
+		return fmt.Errorf("delete repo group %d: %w", id, driven.ErrRepoGroupNotFound)
+	}
+	return nil
+}
+
+// Get returns the group with the given ID, or nil if none exists.
+func (r *RepoGroupRepo) Get(ctx context.Context, id int64) (*model.RepoGroup, error) {
+	groups, err := r.list(ctx, `WHERE g.id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("get repo group %d: %w", id, err)
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	return &groups[0], nil
+}
+
+// List returns every group ordered by name, ignoring case.
+func (r *RepoGroupRepo) List(ctx context.Context) ([]model.RepoGroup, error) {
+	groups, err := r.list(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("list repo groups: %w", err)
+	}
+	return groups, nil
+}
+
+// list reads groups with their members, one row per member, applying the
+// given WHERE clause to repo_groups aliased as g.
+func (r *RepoGroupRepo) list(ctx context.Context, where string, args ...any) ([]model.RepoGroup, error) {
+	query := `
+		SELECT g.id, g.name, g.created_at, COALESCE(m.repo_full_name, '')
+		FROM repo_groups g
+		LEFT JOIN repo_group_members m ON m.group_id = g.id
+		` + where + `
+		ORDER BY g.name COLLATE NOCASE, g.id, m.repo_full_name`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var groups []model.RepoGroup
+	for rows.Next() {
+		var g model.RepoGroup
+		var createdAt, repo string
+		if err := rows.Scan(&g.ID, &g.Name, &createdAt, &repo); err != nil {
+			return nil, fmt.Errorf("scan repo group: %w", err)
+		}
+		if n := len(groups); n == 0 || groups[n-1].ID != g.ID {
+			if g.CreatedAt, err = parseTime(createdAt); err != nil {
+				return nil, fmt.Errorf("parse created_at for repo group %d: %w", g.ID, err)
+			}
+			groups = append(groups, g)
+		}
+		if repo != "" {
+			last := &groups[len(groups)-1]
+			last.Repos = append(last.Repos, repo)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate repo groups: %w", err)
+	}
+	return groups, nil
+}
+
+// replaceGroupMembers sets the repositories of a group within tx.
+func replaceGroupMembers(ctx context.Context, tx *sql.Tx, groupID int64, repos []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM repo_group_members WHERE group_id = ?`, groupID); err != nil {
+		return fmt.Errorf("clear members of repo group %d: %w", groupID, err)
+	}
+	for _, repo := range sortedUnique(repos) {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO repo_group_members (group_id, repo_full_name) VALUES (?, ?)`, groupID, repo,
+		); err != nil {
+			return fmt.Errorf("add %s to repo group %d: %w", repo, groupID, err)
+		}
+	}
+	return nil
+}
+
+// uniqueViolation maps a UNIQUE constraint failure to sentinel and returns
+// any other error unchanged.
+func uniqueViolation(err, sentinel error) error {
+	var se *sqlite.Error
+	if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return sentinel
+	}
+	return err
+}
+
+// sortedUnique returns the distinct values of s in sorted order.
+func sortedUnique(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := make([]string, 0, len(s))
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+func TestRepoGroupRepo_CreateListGet(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoGroupRepo(db)
+	ctx := context.Background()
+
+	infra, err := repo.Create(ctx, model.RepoGroup{Name: "Infra", Repos: []string{"acme/terraform", "acme/charts", "acme/charts"}})
+	require.NoError(t, err)
+	assert.NotZero(t, infra.ID)
+	assert.Equal(t, []string{"acme/charts", "acme/terraform"}, infra.Repos)
+
+	_, err = repo.Create(ctx, model.RepoGroup{Name: "backend"})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, model.RepoGroup{Name: "INFRA"})
+	require.ErrorIs(t, err, driven.ErrRepoGroupExists, "names are unique ignoring case")
+
+	groups, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	assert.Equal(t, "backend", groups[0].Name)
+	assert.Empty(t, groups[0].Repos)
+	assert.Equal(t, []string{"acme/charts", "acme/terraform"}, groups[1].Repos)
+
+	got, err := repo.Get(ctx, infra.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "Infra", got.Name)
+	assert.False(t, got.CreatedAt.IsZero())
+
+	missing, err := repo.Get(ctx, 999)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestRepoGroupRepo_UpdateAndDelete(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoGroupRepo(db)
+	ctx := context.Background()
+
+	group, err := repo.Create(ctx, model.RepoGroup{Name: "OSS", Repos: []string{"acme/lib"}})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.RepoGroup{Name: "Backend"})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Update(ctx, model.RepoGroup{ID: group.ID, Name: "Open source", Repos: []string{"acme/cli", "acme/lib"}}))
+	got, err := repo.Get(ctx, group.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Open source", got.Name)
+	assert.Equal(t, []string{"acme/cli", "acme/lib"}, got.Repos)
+
+	err = repo.Update(ctx, model.RepoGroup{ID: group.ID, Name: "backend"})
+	require.ErrorIs(t, err, driven.ErrRepoGroupExists)
+	err = repo.Update(ctx, model.RepoGroup{ID: 999, Name: "Nope"})
+	require.ErrorIs(t, err, driven.ErrRepoGroupNotFound)
+
+	require.NoError(t, repo.Delete(ctx, group.ID))
+	require.ErrorIs(t, repo.Delete(ctx, group.ID), driven.ErrRepoGroupNotFound)
+
+	var members int
+	require.NoError(t, db.Reader.QueryRowContext(ctx, `SELECT COUNT(*) FROM repo_group_members`).Scan(&members))
+	assert.Zero(t, members, "members are deleted with their group")
+}
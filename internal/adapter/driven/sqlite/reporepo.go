@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
@@ -13,8 +14,76 @@ import (
 	sqlite3 "modernc.org/sqlite/lib"
 )
 
-// Compile-time interface satisfaction check.
-var _ driven.RepoStore = (*RepoRepo)(nil)
+// Compile-time interface satisfaction checks.
+var (
+	_ driven.RepoStore         = (*RepoRepo)(nil)
+	_ driven.RepoRenamer       = (*RepoRepo)(nil)
+	_ driven.RepoPauser        = (*RepoRepo)(nil)
+	_ driven.RepoSyncRecorder  = (*RepoRepo)(nil)
+	_ driven.RepoImporter      = (*RepoRepo)(nil)
+	_ driven.RepoMetadataStore = (*RepoRepo)(nil)
+	_ driven.RepoTrash         = (*RepoRepo)(nil)
+	_ driven.OrphanSweeper     = (*RepoRepo)(nil)
+)
+
+// repoNameTables lists every table that references a repository by full name.
+// RenameRepo rewrites all of them so a rename keeps PRs, settings, and history.
+var repoNameTables = []string{
+	"pull_requests",
+	"repo_thresholds",
+	"repo_jira_mapping",
+	"repo_github_account",
+	"decisions",
+	"pr_notes",
+	"repo_group_members",
+	"branch_protection",
+	"signal_webhooks",
+	"inbox_events",
+	"outbound_actions",
+	"lint_rules",
+	"repo_settings",
+	"pr_events",
+	"script_hooks",
+	"event_webhooks",
+	"webhook_deliveries",
+}
+
+// prChildTables lists the tables whose rows belong to a pull request by
+// pr_id. They reference pull_requests with ON DELETE CASCADE, but removal
+// also deletes them explicitly so nothing is left behind in databases
+// written while foreign key enforcement was off.
+var prChildTables = []string{
+	"reviews",
+	"review_comments",
+	"issue_comments",
+	"check_runs",
+	"ignored_prs",
+	"mentions",
+	"pr_views",
+	"lint_violations",
+	"pr_events",
+	"focus_marks",
+	"pr_watches",
+	"check_watches",
+	"stale_marks",
+}
+
+// repoOwnedTables lists the tables whose rows belong to a repository by full
+// name and are deleted with it, pull_requests first. Decisions, notes, and
+// signal webhooks are the user's own records and are kept, as archive
+// pruning keeps them; repo groups may name repositories that are not
+// watched, so their members are kept too.
+var repoOwnedTables = []string{
+	"pull_requests",
+	"repo_thresholds",
+	"repo_jira_mapping",
+	"repo_github_account",
+	"branch_protection",
+	"lint_rules",
+	"repo_settings",
+	"inbox_events",
+	"outbound_actions",
+}
 
 // RepoRepo is the SQLite implementation of the RepoStore port interface.
 type RepoRepo struct {
@@ -26,38 +95,250 @@ func NewRepoRepo(db *DB) *RepoRepo {
 	return &RepoRepo{db: db}
 }
 
-// Add inserts a new repository. Returns an error if a repository with the same
-// full_name already exists.
+// Add inserts a new repository, or restores it from the trash with its
+// history. Returns an error if a repository with the same full_name is
+// already watched.
 func (r *RepoRepo) Add(ctx context.Context, repo model.Repository) error {
-	const query = `INSERT INTO repositories (full_name, owner, name, added_at) VALUES (?, ?, ?, ?)`
+	const query = `INSERT INTO repositories (full_name, owner, name, added_at, provider) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (full_name) DO UPDATE SET removed_at = NULL WHERE removed_at IS NOT NULL`
 
 	addedAt := repo.AddedAt
 	if addedAt.IsZero() {
 		addedAt = time.Now().UTC()
 	}
 
-	_, err := r.db.Writer.ExecContext(ctx, query, repo.FullName, repo.Owner, repo.Name, addedAt)
+	result, err := r.db.Writer.ExecContext(ctx, query, repo.FullName, repo.Owner, repo.Name, addedAt, providerOrDefault(repo.Provider))
 	if err != nil {
-		var se *sqlite.Error
-		if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
-			return fmt.Errorf("add repository %s: %w", repo.FullName, driven.ErrRepoAlreadyExists)
-		}
 		return fmt.Errorf("add repository %s: %w", repo.FullName, err)
 	}
 
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("add repository %s: %w", repo.FullName, driven.ErrRepoAlreadyExists)
+	}
+
 	return nil
 }
 
-// Remove deletes a repository by full name. Returns an error if the repository
-// does not exist. Due to foreign key cascade, all associated pull requests are
-// also deleted.
+// AddMany inserts repos in one transaction, skipping any already watched,
+// and returns the full names it added in the order given. Trashed repos are
+// restored and count as added.
+func (r *RepoRepo) AddMany(ctx context.Context, repos []model.Repository) ([]string, error) {
+	const query = `INSERT INTO repositories (full_name, owner, name, added_at, provider) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (full_name) DO UPDATE SET removed_at = NULL WHERE removed_at IS NOT NULL`
+
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin add repositories: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	added := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		addedAt := repo.AddedAt
+		if addedAt.IsZero() {
+			addedAt = time.Now().UTC()
+		}
+		res, err := tx.ExecContext(ctx, query, repo.FullName, repo.Owner, repo.Name, addedAt, providerOrDefault(repo.Provider))
+		if err != nil {
+			return nil, fmt.Errorf("add repository %s: %w", repo.FullName, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("add repository %s: %w", repo.FullName, err)
+		}
+		if n > 0 {
+			added = append(added, repo.FullName)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit add repositories: %w", err)
+	}
+	return added, nil
+}
+
+// Remove deletes a repository by full name, whether watched or trashed,
+// together with its pull requests and everything stored about them, in a
+// single transaction. Returns an error if the repository does not exist.
 func (r *RepoRepo) Remove(ctx context.Context, fullName string) error {
-	const query = `DELETE FROM repositories WHERE full_name = ?`
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after commit is a no-op.
 
-	result, err := r.db.Writer.ExecContext(ctx, query, fullName)
+	if err := deleteRepo(ctx, tx, fullName); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit removal of repository %s: %w", fullName, err)
+	}
+	return nil
+}
+
+// deleteRepo deletes a repository and the rows of prChildTables and
+// repoOwnedTables that belong to it, children first. Returns ErrRepoNotFound
+// if the repository does not exist.
+func deleteRepo(ctx context.Context, tx *sql.Tx, fullName string) error {
+	const prIDs = `(SELECT id FROM pull_requests WHERE repo_full_name = ?)`
+
+	for _, table := range prChildTables {
+		query := `DELETE FROM ` + table + ` WHERE pr_id IN ` + prIDs
+		if _, err := tx.ExecContext(ctx, query, fullName); err != nil {
+			return fmt.Errorf("remove repository %s from %s: %w", fullName, table, err)
+		}
+	}
+	const linksQuery = `DELETE FROM pr_links WHERE pr_id IN ` + prIDs + ` OR linked_pr_id IN ` + prIDs
+	if _, err := tx.ExecContext(ctx, linksQuery, fullName, fullName); err != nil {
+		return fmt.Errorf("remove repository %s from pr_links: %w", fullName, err)
+	}
+	for _, table := range repoOwnedTables {
+		query := `DELETE FROM ` + table + ` WHERE repo_full_name = ?`
+		if _, err := tx.ExecContext(ctx, query, fullName); err != nil {
+			return fmt.Errorf("remove repository %s from %s: %w", fullName, table, err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM repositories WHERE full_name = ?`, fullName)
 	if err != nil {
 		return fmt.Errorf("remove repository %s: %w", fullName, err)
 	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("remove repository %s: %w", fullName, driven.ErrRepoNotFound)
+	}
+	return nil
+}
+
+// SweepOrphans deletes rows left behind by repositories and pull requests
+// that no longer exist, in a single transaction, and returns how many it
+// deleted per table; tables with none are omitted.
+func (r *RepoRepo) SweepOrphans(ctx context.Context) (map[string]int, error) {
+	type sweep struct {
+		table, where string
+	}
+	const (
+		repoNames = `(SELECT full_name FROM repositories)`
+		livePRs   = `(SELECT id FROM pull_requests WHERE repo_full_name IN ` + repoNames + `)`
+		liveRules = `(SELECT id FROM lint_rules WHERE repo_full_name IN ` + repoNames + `)`
+	)
+
+	// Children go before their parents, so each row is counted in its own
+	// table rather than removed unseen by ON DELETE CASCADE.
+	var sweeps []sweep
+	for _, table := range prChildTables {
+		sweeps = append(sweeps, sweep{table, `pr_id NOT IN ` + livePRs})
+	}
+	sweeps = append(sweeps,
+		sweep{"pr_links", `pr_id NOT IN ` + livePRs + ` OR linked_pr_id NOT IN ` + livePRs},
+		sweep{"lint_violations", `rule_id NOT IN ` + liveRules},
+		sweep{"repo_group_members", `group_id NOT IN (SELECT id FROM repo_groups)`},
+	)
+	for _, table := range repoOwnedTables {
+		sweeps = append(sweeps, sweep{table, `repo_full_name NOT IN ` + repoNames})
+	}
+
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after commit is a no-op.
+
+	deleted := make(map[string]int)
+	for _, s := range sweeps {
+		result, err := tx.ExecContext(ctx, `DELETE FROM `+s.table+` WHERE `+s.where)
+		if err != nil {
+			return nil, fmt.Errorf("sweep orphans from %s: %w", s.table, err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("check rows affected: %w", err)
+		}
+		if n > 0 {
+			deleted[s.table] += int(n)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit orphan sweep: %w", err)
+	}
+	return deleted, nil
+}
+
+// RenameRepo moves a watched repository and everything stored under its name
+// to a new full name in a single transaction. Foreign keys reference the full
+// name, so the new row is inserted first, dependents are repointed, and the
+// old row is deleted last. Returns ErrRepoNotFound if from is not watched and
+// ErrRepoAlreadyExists if to is already watched.
+func (r *RepoRepo) RenameRepo(ctx context.Context, from, to string) error {
+	owner, name, ok := strings.Cut(to, "/")
+	if !ok || owner == "" || name == "" {
+		return fmt.Errorf("rename repository %s: invalid new name %q", from, to)
+	}
+
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after commit is a no-op.
+
+	const insertQuery = `
+		INSERT INTO repositories (full_name, owner, name, added_at, paused, last_synced_at,
+			description, default_branch, is_private, is_archived, open_pr_count, metadata_fetched_at, removed_at, provider)
+		SELECT ?, ?, ?, added_at, paused, last_synced_at,
+			description, default_branch, is_private, is_archived, open_pr_count, metadata_fetched_at, removed_at, provider
+		FROM repositories WHERE full_name = ?`
+
+	result, err := tx.ExecContext(ctx, insertQuery, to, owner, name, from)
+	if err != nil {
+		var se *sqlite.Error
+		if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return fmt.Errorf("rename repository %s to %s: %w", from, to, driven.ErrRepoAlreadyExists)
+		}
+		return fmt.Errorf("rename repository %s to %s: %w", from, to, err)
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if rows == 0 {
+		return fmt.Errorf("rename repository %s: %w", from, driven.ErrRepoNotFound)
+	}
+
+	// A group may already list the new name; OR REPLACE keeps one membership
+	// instead of failing the rename on the duplicate.
+	for _, table := range repoNameTables {
+		query := `UPDATE OR REPLACE ` + table + ` SET repo_full_name = ? WHERE repo_full_name = ?`
+		if _, err := tx.ExecContext(ctx, query, to, from); err != nil {
+			return fmt.Errorf("rename repository %s in %s: %w", from, table, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM repositories WHERE full_name = ?`, from); err != nil {
+		return fmt.Errorf("delete renamed repository %s: %w", from, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit rename of repository %s: %w", from, err)
+	}
+	return nil
+}
+
+// SetPaused pauses or resumes background polling of a repository. Returns
+// ErrRepoNotFound if the repository is not watched.
+func (r *RepoRepo) SetPaused(ctx context.Context, fullName string, paused bool) error {
+	const query = `UPDATE repositories SET paused = ? WHERE full_name = ?`
+
+	result, err := r.db.Writer.ExecContext(ctx, query, paused, fullName)
+	if err != nil {
+		return fmt.Errorf("set paused for repository %s: %w", fullName, err)
+	}
 
 	rows, err := result.RowsAffected()
 	if err != nil {
@@ -65,16 +346,68 @@ func (r *RepoRepo) Remove(ctx context.Context, fullName string) error {
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("remove repository %s: %w", fullName, driven.ErrRepoNotFound)
+		return fmt.Errorf("set paused for repository %s: %w", fullName, driven.ErrRepoNotFound)
 	}
 
 	return nil
 }
 
-// GetByFullName retrieves a repository by its full name. Returns nil, nil if
-// the repository does not exist.
+// MarkSynced records that a repository was polled successfully at the given
+// time. Returns ErrRepoNotFound if the repository is not watched.
+func (r *RepoRepo) MarkSynced(ctx context.Context, fullName string, at time.Time) error {
+	const query = `UPDATE repositories SET last_synced_at = ? WHERE full_name = ?`
+
+	result, err := r.db.Writer.ExecContext(ctx, query, at.UTC(), fullName)
+	if err != nil {
+		return fmt.Errorf("mark repository %s synced: %w", fullName, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("mark repository %s synced: %w", fullName, driven.ErrRepoNotFound)
+	}
+
+	return nil
+}
+
+// SetMetadata stores what GitHub reports about a repository. A zero
+// FetchedAt is stored as NULL.
+func (r *RepoRepo) SetMetadata(ctx context.Context, fullName string, metadata model.RepoMetadata) error {
+	const query = `UPDATE repositories
+		SET description = ?, default_branch = ?, is_private = ?, is_archived = ?, open_pr_count = ?, metadata_fetched_at = ?
+		WHERE full_name = ?`
+
+	var fetchedAt any
+	if !metadata.FetchedAt.IsZero() {
+		fetchedAt = metadata.FetchedAt.UTC()
+	}
+	result, err := r.db.Writer.ExecContext(ctx, query, metadata.Description, metadata.DefaultBranch,
+		metadata.Private, metadata.Archived, metadata.OpenPRCount, fetchedAt, fullName)
+	if err != nil {
+		return fmt.Errorf("set repository %s metadata: %w", fullName, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("set repository %s metadata: %w", fullName, driven.ErrRepoNotFound)
+	}
+
+	return nil
+}
+
+// GetByFullName retrieves a watched repository by its full name. Returns nil,
+// nil if the repository does not exist or is in the trash.
 func (r *RepoRepo) GetByFullName(ctx context.Context, fullName string) (*model.Repository, error) {
-	const query = `SELECT id, full_name, owner, name, added_at FROM repositories WHERE full_name = ?`
+	const query = `SELECT ` + repoColumns + `
+		FROM repositories WHERE full_name = ? AND removed_at IS NULL`
 
 	repo, err := scanRepository(r.db.Reader.QueryRowContext(ctx, query, fullName))
 	if errors.Is(err, sql.ErrNoRows) {
@@ -87,11 +420,107 @@ func (r *RepoRepo) GetByFullName(ctx context.Context, fullName string) (*model.R
 	return repo, nil
 }
 
-// ListAll returns all repositories ordered by full name.
+// ListAll returns all watched repositories ordered by full name; trashed
+// repositories are excluded.
 func (r *RepoRepo) ListAll(ctx context.Context) ([]model.Repository, error) {
-	const query = `SELECT id, full_name, owner, name, added_at FROM repositories ORDER BY full_name`
+	const query = `SELECT ` + repoColumns + `
+		FROM repositories WHERE removed_at IS NULL ORDER BY full_name`
 
-	rows, err := r.db.Reader.QueryContext(ctx, query)
+	return r.queryRepos(ctx, query)
+}
+
+// Trash soft-deletes a watched repository, hiding it and its PRs until it is
+// restored or purged. Returns ErrRepoNotFound if the repository is not watched.
+func (r *RepoRepo) Trash(ctx context.Context, fullName string, at time.Time) error {
+	const query = `UPDATE repositories SET removed_at = ? WHERE full_name = ? AND removed_at IS NULL`
+
+	result, err := r.db.Writer.ExecContext(ctx, query, at.UTC(), fullName)
+	if err != nil {
+		return fmt.Errorf("trash repository %s: %w", fullName, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("trash repository %s: %w", fullName, driven.ErrRepoNotFound)
+	}
+	return nil
+}
+
+// Restore moves a repository out of the trash. Returns ErrRepoNotFound if it
+// is not in the trash.
+func (r *RepoRepo) Restore(ctx context.Context, fullName string) error {
+	const query = `UPDATE repositories SET removed_at = NULL WHERE full_name = ? AND removed_at IS NOT NULL`
+
+	result, err := r.db.Writer.ExecContext(ctx, query, fullName)
+	if err != nil {
+		return fmt.Errorf("restore repository %s: %w", fullName, err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("restore repository %s: %w", fullName, driven.ErrRepoNotFound)
+	}
+	return nil
+}
+
+// ListTrashed returns the repositories in the trash, most recently removed first.
+func (r *RepoRepo) ListTrashed(ctx context.Context) ([]model.Repository, error) {
+	const query = `SELECT ` + repoColumns + `
+		FROM repositories WHERE removed_at IS NOT NULL ORDER BY removed_at DESC, full_name`
+
+	return r.queryRepos(ctx, query)
+}
+
+// PurgeTrashed deletes repositories trashed before cutoff in a single
+// transaction, with their PRs, reviews, comments, and checks.
+func (r *RepoRepo) PurgeTrashed(ctx context.Context, cutoff time.Time) (int, error) {
+	const query = `SELECT full_name FROM repositories WHERE removed_at IS NOT NULL AND removed_at < ?`
+
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // Rollback after commit is a no-op.
+
+	rows, err := tx.QueryContext(ctx, query, cutoff.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("list expired trashed repositories: %w", err)
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan trashed repository: %w", err)
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate trashed repositories: %w", err)
+	}
+
+	for _, name := range names {
+		if err := deleteRepo(ctx, tx, name); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit purge of trashed repositories: %w", err)
+	}
+	return len(names), nil
+}
+
+// queryRepos runs a query selecting repoColumns and scans every row.
+func (r *RepoRepo) queryRepos(ctx context.Context, query string, args ...any) ([]model.Repository, error) {
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("list repositories: %w", err)
 	}
@@ -113,6 +542,10 @@ func (r *RepoRepo) ListAll(ctx context.Context) ([]model.Repository, error) {
 	return repos, nil
 }
 
+// repoColumns are the repositories columns scanRepository reads, in order.
+const repoColumns = `id, full_name, owner, name, added_at, paused, last_synced_at,
+		description, default_branch, is_private, is_archived, open_pr_count, metadata_fetched_at, removed_at, provider`
+
 // scanner is satisfied by both *sql.Row and *sql.Rows.
 type scanner interface {
 	Scan(dest ...any) error
@@ -121,8 +554,11 @@ type scanner interface {
 func scanRepository(s scanner) (*model.Repository, error) {
 	var repo model.Repository
 	var addedAt string
+	var lastSyncedAt, metadataFetchedAt, removedAt sql.NullString
 
-	err := s.Scan(&repo.ID, &repo.FullName, &repo.Owner, &repo.Name, &addedAt)
+	md := &repo.Metadata
+	err := s.Scan(&repo.ID, &repo.FullName, &repo.Owner, &repo.Name, &addedAt, &repo.Paused, &lastSyncedAt,
+		&md.Description, &md.DefaultBranch, &md.Private, &md.Archived, &md.OpenPRCount, &metadataFetchedAt, &removedAt, &repo.Provider)
 	if err != nil {
 		return nil, err
 	}
@@ -131,10 +567,33 @@ func scanRepository(s scanner) (*model.Repository, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parse added_at: %w", err)
 	}
+	if lastSyncedAt.Valid {
+		if repo.LastSyncedAt, err = parseTime(lastSyncedAt.String); err != nil {
+			return nil, fmt.Errorf("parse last_synced_at: %w", err)
+		}
+	}
+	if metadataFetchedAt.Valid {
+		if md.FetchedAt, err = parseTime(metadataFetchedAt.String); err != nil {
+			return nil, fmt.Errorf("parse metadata_fetched_at: %w", err)
+		}
+	}
+	if removedAt.Valid {
+		if repo.RemovedAt, err = parseTime(removedAt.String); err != nil {
+			return nil, fmt.Errorf("parse removed_at: %w", err)
+		}
+	}
 
 	return &repo, nil
 }
 
+// providerOrDefault stores an unset provider as GitHub.
+func providerOrDefault(p model.Provider) model.Provider {
+	if p == "" {
+		return model.ProviderGitHub
+	}
+	return p
+}
+
 // parseTime tries multiple SQLite datetime formats.
 func parseTime(s string) (time.Time, error) {
 	formats := []string{
@@ -144,6 +603,9 @@ func parseTime(s string) (time.Time, error) {
 		"2006-01-02 15:04:05.000",
 		time.RFC3339,
 		time.RFC3339Nano,
+		// time.Time values are stored in this form; aggregates such as MAX()
+		// return it as text because they drop the column's DATETIME type.
+		"2006-01-02 15:04:05.999999999 -0700 MST",
 	}
 
 	for _, format := range formats {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +36,14 @@ func TestRepoRepo_Add(t *testing.T) {
 	assert.Equal(t, "octocat", got.Owner)
 	assert.Equal(t, "hello-world", got.Name)
 	assert.False(t, got.AddedAt.IsZero())
+	assert.Equal(t, model.ProviderGitHub, got.Provider, "GitHub when unset")
+
+	bb := makeRepo("acme/payments", "acme", "payments")
+	bb.Provider = model.ProviderBitbucket
+	require.NoError(t, repo.Add(ctx, bb))
+	got, err = repo.GetByFullName(ctx, "acme/payments")
+	require.NoError(t, err)
+	assert.Equal(t, model.ProviderBitbucket, got.Provider)
 }
 
 func TestRepoRepo_Add_Duplicate(t *testing.T) {
@@ -49,6 +58,26 @@ func TestRepoRepo_Add_Duplicate(t *testing.T) {
 	assert.Error(t, err, "adding duplicate repository should fail")
 }
 
+func TestRepoRepo_AddMany(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Add(ctx, makeRepo("org/api", "org", "api")))
+
+	added, err := repo.AddMany(ctx, []model.Repository{
+		makeRepo("org/web", "org", "web"),
+		makeRepo("org/api", "org", "api"),
+		makeRepo("org/cli", "org", "cli"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/web", "org/cli"}, added, "already watched repos are skipped")
+
+	all, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+}
+
 func TestRepoRepo_Remove(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewRepoRepo(db)
@@ -73,6 +102,199 @@ func TestRepoRepo_Remove_NotFound(t *testing.T) {
 	assert.Error(t, err, "removing non-existent repo should fail")
 }
 
+// seedRepoHistory adds a repository with a PR, a review, and an inbox event.
+func seedRepoHistory(t *testing.T, db *DB, owner, name string) {
+	t.Helper()
+	ctx := context.Background()
+	fullName := owner + "/" + name
+
+	require.NoError(t, NewRepoRepo(db).Add(ctx, makeRepo(fullName, owner, name)))
+	prs := NewPRRepo(db)
+	require.NoError(t, prs.Upsert(ctx, makePR(fullName, 1, "Change", model.PRStatusOpen)))
+	pr, err := prs.GetByNumber(ctx, fullName, 1)
+	require.NoError(t, err)
+	require.NoError(t, NewReviewRepo(db).UpsertReview(ctx, model.Review{
+		ID: pr.ID*100 + 1, PRID: pr.ID, ReviewerLogin: "alice", State: model.ReviewStateApproved, SubmittedAt: time.Now(),
+	}))
+	_, err = NewInboxRepo(db).Add(ctx, model.InboxEvent{
+		Kind: model.InboxApprovalReceived, RepoFullName: fullName, PRNumber: 1, SourceID: "1", OccurredAt: time.Now(),
+	})
+	require.NoError(t, err)
+}
+
+// countRows returns the number of rows in table.
+func countRows(t *testing.T, db *DB, table string) int {
+	t.Helper()
+	var n int
+	require.NoError(t, db.Reader.QueryRow(`SELECT COUNT(*) FROM `+table).Scan(&n))
+	return n
+}
+
+func TestRepoRepo_Remove_DeletesHistory(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	seedRepoHistory(t, db, "org", "api")
+	seedRepoHistory(t, db, "org", "web")
+
+	// Removal must not rely on ON DELETE CASCADE being enforced.
+	_, err := db.Writer.ExecContext(ctx, `PRAGMA foreign_keys = OFF`)
+	require.NoError(t, err)
+	require.NoError(t, repo.Remove(ctx, "org/api"))
+
+	assert.Equal(t, 1, countRows(t, db, "pull_requests"))
+	assert.Equal(t, 1, countRows(t, db, "reviews"))
+	assert.Equal(t, 1, countRows(t, db, "inbox_events"))
+}
+
+func TestRepoRepo_SweepOrphans(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	seedRepoHistory(t, db, "org", "api")
+	seedRepoHistory(t, db, "org", "web")
+
+	// Delete a repository the way older releases could, leaving its data.
+	_, err := db.Writer.ExecContext(ctx, `PRAGMA foreign_keys = OFF`)
+	require.NoError(t, err)
+	_, err = db.Writer.ExecContext(ctx, `DELETE FROM repositories WHERE full_name = 'org/api'`)
+	require.NoError(t, err)
+	_, err = db.Writer.ExecContext(ctx, `PRAGMA foreign_keys = ON`)
+	require.NoError(t, err)
+
+	deleted, err := repo.SweepOrphans(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"pull_requests": 1, "reviews": 1, "inbox_events": 1}, deleted)
+	assert.Equal(t, 1, countRows(t, db, "pull_requests"))
+
+	deleted, err = repo.SweepOrphans(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, deleted, "a second sweep finds nothing")
+}
+
+// prStateTables are the per-PR tables added after the orphan sweep was
+// introduced.
+var prStateTables = []string{"pr_watches", "check_watches", "stale_marks"}
+
+// seedPRState gives the PR of a repository seeded by seedRepoHistory a row
+// in each of prStateTables and returns its ID.
+func seedPRState(t *testing.T, db *DB, fullName string) int64 {
+	t.Helper()
+	ctx := context.Background()
+
+	pr, err := NewPRRepo(db).GetByNumber(ctx, fullName, 1)
+	require.NoError(t, err)
+	require.NoError(t, NewWatchRepo(db).SetWatchState(ctx, pr.ID, model.WatchStateWatching))
+	require.NoError(t, NewCheckWatchRepo(db).SetCheckWatch(ctx, pr.ID, true))
+	require.NoError(t, NewStaleMarkRepo(db).RecordStaleMark(ctx, model.StaleMark{
+		PRID: pr.ID, ActivityAt: time.Now().Add(-30 * 24 * time.Hour), MarkedAt: time.Now(),
+	}))
+	return pr.ID
+}
+
+func TestRepoRepo_SweepOrphans_PRState(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	seedRepoHistory(t, db, "org", "api")
+	seedRepoHistory(t, db, "org", "web")
+	prID := seedPRState(t, db, "org/api")
+	seedPRState(t, db, "org/web")
+
+	_, err := db.Writer.ExecContext(ctx, `PRAGMA foreign_keys = OFF`)
+	require.NoError(t, err)
+	_, err = db.Writer.ExecContext(ctx, `DELETE FROM pull_requests WHERE id = ?`, prID)
+	require.NoError(t, err)
+
+	deleted, err := repo.SweepOrphans(ctx)
+	require.NoError(t, err)
+	for _, table := range prStateTables {
+		assert.Equal(t, 1, deleted[table], table)
+		assert.Equal(t, 1, countRows(t, db, table), "%s keeps the live PR's row", table)
+	}
+
+	// Removal deletes them too, with foreign keys still off.
+	require.NoError(t, repo.Remove(ctx, "org/web"))
+	for _, table := range prStateTables {
+		assert.Zero(t, countRows(t, db, table), table)
+	}
+}
+
+func TestRepoRepo_Trash(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	prs := NewPRRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Add(ctx, makeRepo("org/api", "org", "api")))
+	require.NoError(t, prs.Upsert(ctx, makePR("org/api", 1, "Fix login", model.PRStatusOpen)))
+
+	removedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Trash(ctx, "org/api", removedAt))
+	assert.ErrorIs(t, repo.Trash(ctx, "org/api", removedAt), driven.ErrRepoNotFound, "already trashed")
+
+	all, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+	got, err := repo.GetByFullName(ctx, "org/api")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	open, err := prs.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, open, "a trashed repo's PRs are hidden")
+
+	trashed, err := repo.ListTrashed(ctx)
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, "org/api", trashed[0].FullName)
+	assert.True(t, removedAt.Equal(trashed[0].RemovedAt))
+
+	require.NoError(t, repo.Restore(ctx, "org/api"))
+	assert.ErrorIs(t, repo.Restore(ctx, "org/api"), driven.ErrRepoNotFound, "not in the trash")
+	open, err = prs.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, open, 1, "restoring brings the PRs back")
+
+	// Re-adding a trashed repo restores it rather than failing.
+	require.NoError(t, repo.Trash(ctx, "org/api", removedAt))
+	require.NoError(t, repo.Add(ctx, makeRepo("org/api", "org", "api")))
+	assert.ErrorIs(t, repo.Add(ctx, makeRepo("org/api", "org", "api")), driven.ErrRepoAlreadyExists)
+}
+
+func TestRepoRepo_PurgeTrashed(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	prs := NewPRRepo(db)
+	ctx := context.Background()
+
+	for _, name := range []string{"old", "recent", "watched"} {
+		require.NoError(t, repo.Add(ctx, makeRepo("org/"+name, "org", name)))
+		require.NoError(t, prs.Upsert(ctx, makePR("org/"+name, 1, "Change", model.PRStatusOpen)))
+	}
+	cutoff := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.Trash(ctx, "org/old", cutoff.Add(-time.Hour)))
+	require.NoError(t, repo.Trash(ctx, "org/recent", cutoff.Add(time.Hour)))
+
+	n, err := repo.PurgeTrashed(ctx, cutoff)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	trashed, err := repo.ListTrashed(ctx)
+	require.NoError(t, err)
+	require.Len(t, trashed, 1)
+	assert.Equal(t, "org/recent", trashed[0].FullName)
+
+	gone, err := prs.GetByRepository(ctx, "org/old")
+	require.NoError(t, err)
+	assert.Empty(t, gone, "purging deletes the repo's PRs")
+	kept, err := prs.GetByRepository(ctx, "org/recent")
+	require.NoError(t, err)
+	assert.Len(t, kept, 1)
+}
+
 func TestRepoRepo_ListAll(t *testing.T) {
 	db := setupTestDB(t)
 	repo := NewRepoRepo(db)
@@ -101,3 +323,209 @@ func TestRepoRepo_GetByFullName_NotFound(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, got, "non-existent repo should return nil without error")
 }
+
+func TestRepoRepo_RenameRepo(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	repo := NewRepoRepo(db)
+
+	prID := addTestPR(t, db, "octocat/old-name", 7)
+	reviewCount := 3
+	require.NoError(t, NewThresholdRepo(db).SetRepoThreshold(ctx, model.RepoThreshold{
+		RepoFullName: "octocat/old-name",
+		ReviewCount:  &reviewCount,
+	}))
+	require.NoError(t, NewDecisionRepo(db).Save(ctx, testDecision("octocat/old-name", 7, 100, "Keep the cache")))
+	_, err := NewPRNoteRepo(db).Add(ctx, model.PRNote{RepoFullName: "octocat/old-name", PRNumber: 7, Body: "Ask about the cache"})
+	require.NoError(t, err)
+	group, err := NewRepoGroupRepo(db).Create(ctx, model.RepoGroup{Name: "Core", Repos: []string{"octocat/old-name", "acme/new-name"}})
+	require.NoError(t, err)
+	_, err = NewScriptHookRepo(db).Create(ctx, model.ScriptHook{
+		Name: "Notify", Event: model.HookEventApproved, Runtime: model.HookRuntimeShell, Script: "notify", RepoFullName: "octocat/old-name",
+	})
+	require.NoError(t, err)
+	webhooks := NewEventWebhookRepo(db)
+	webhookID, err := webhooks.Create(ctx, model.EventWebhook{URL: "https://ci.example.com/hook", Secret: "s", RepoFullName: "octocat/old-name", Enabled: true})
+	require.NoError(t, err)
+	_, err = webhooks.AddDelivery(ctx, model.WebhookDelivery{
+		WebhookID: webhookID, Event: model.WebhookPRCreated, RepoFullName: "octocat/old-name", PRNumber: 7,
+		Payload: `{}`, Status: model.WebhookDeliveryPending, NextAttemptAt: time.Now(), CreatedAt: time.Now(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.RenameRepo(ctx, "octocat/old-name", "acme/new-name"))
+
+	old, err := repo.GetByFullName(ctx, "octocat/old-name")
+	require.NoError(t, err)
+	assert.Nil(t, old)
+
+	renamed, err := repo.GetByFullName(ctx, "acme/new-name")
+	require.NoError(t, err)
+	require.NotNil(t, renamed)
+	assert.Equal(t, "acme", renamed.Owner)
+	assert.Equal(t, "new-name", renamed.Name)
+
+	pr, err := NewPRRepo(db).GetByNumber(ctx, "acme/new-name", 7)
+	require.NoError(t, err)
+	require.NotNil(t, pr)
+	assert.Equal(t, prID, pr.ID, "PR row and its history should be kept, not recreated")
+
+	threshold, err := NewThresholdRepo(db).GetRepoThreshold(ctx, "acme/new-name")
+	require.NoError(t, err)
+	require.NotNil(t, threshold.ReviewCount)
+	assert.Equal(t, 3, *threshold.ReviewCount)
+
+	decisions, err := NewDecisionRepo(db).ListByPR(ctx, "acme/new-name", 7)
+	require.NoError(t, err)
+	assert.Len(t, decisions, 1)
+
+	notes, err := NewPRNoteRepo(db).ListByPR(ctx, "acme/new-name", 7)
+	require.NoError(t, err)
+	assert.Len(t, notes, 1)
+
+	got, err := NewRepoGroupRepo(db).Get(ctx, group.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, []string{"acme/new-name"}, got.Repos, "a group listing both names keeps one")
+
+	hooks, err := NewScriptHookRepo(db).List(ctx)
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "acme/new-name", hooks[0].RepoFullName)
+
+	webhook, err := webhooks.Get(ctx, webhookID)
+	require.NoError(t, err)
+	require.NotNil(t, webhook)
+	assert.Equal(t, "acme/new-name", webhook.RepoFullName)
+	deliveries, err := webhooks.ListDeliveries(ctx, webhookID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "acme/new-name", deliveries[0].RepoFullName)
+}
+
+func TestRepoRepo_RenameRepo_Errors(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	err := repo.RenameRepo(ctx, "nonexistent/repo", "acme/repo")
+	require.ErrorIs(t, err, driven.ErrRepoNotFound)
+
+	require.NoError(t, repo.Add(ctx, makeRepo("octocat/old-name", "octocat", "old-name")))
+	require.NoError(t, repo.Add(ctx, makeRepo("acme/new-name", "acme", "new-name")))
+
+	err = repo.RenameRepo(ctx, "octocat/old-name", "acme/new-name")
+	require.ErrorIs(t, err, driven.ErrRepoAlreadyExists)
+
+	old, err := repo.GetByFullName(ctx, "octocat/old-name")
+	require.NoError(t, err)
+	assert.NotNil(t, old, "failed rename should leave the original repository in place")
+}
+
+func TestRepoRepo_RenameRepo_KeepsProvider(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	bb := makeRepo("team/old-name", "team", "old-name")
+	bb.Provider = model.ProviderBitbucket
+	require.NoError(t, repo.Add(ctx, bb))
+
+	require.NoError(t, repo.RenameRepo(ctx, "team/old-name", "team/new-name"))
+
+	renamed, err := repo.GetByFullName(ctx, "team/new-name")
+	require.NoError(t, err)
+	require.NotNil(t, renamed)
+	assert.Equal(t, model.ProviderBitbucket, renamed.Provider)
+}
+
+func TestRepoRepo_SetPaused(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Add(ctx, makeRepo("octocat/hello-world", "octocat", "hello-world")))
+
+	got, err := repo.GetByFullName(ctx, "octocat/hello-world")
+	require.NoError(t, err)
+	assert.False(t, got.Paused, "new repositories should not be paused")
+
+	require.NoError(t, repo.SetPaused(ctx, "octocat/hello-world", true))
+	repos, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.True(t, repos[0].Paused)
+
+	require.NoError(t, repo.RenameRepo(ctx, "octocat/hello-world", "acme/hello-world"))
+	got, err = repo.GetByFullName(ctx, "acme/hello-world")
+	require.NoError(t, err)
+	assert.True(t, got.Paused, "rename should keep the paused flag")
+
+	require.NoError(t, repo.SetPaused(ctx, "acme/hello-world", false))
+	got, err = repo.GetByFullName(ctx, "acme/hello-world")
+	require.NoError(t, err)
+	assert.False(t, got.Paused)
+
+	err = repo.SetPaused(ctx, "nonexistent/repo", true)
+	require.ErrorIs(t, err, driven.ErrRepoNotFound)
+}
+
+func TestRepoRepo_MarkSynced(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Add(ctx, makeRepo("octocat/hello-world", "octocat", "hello-world")))
+
+	got, err := repo.GetByFullName(ctx, "octocat/hello-world")
+	require.NoError(t, err)
+	assert.True(t, got.LastSyncedAt.IsZero(), "new repositories should not have synced")
+
+	syncedAt := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, repo.MarkSynced(ctx, "octocat/hello-world", syncedAt))
+	repos, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.True(t, syncedAt.Equal(repos[0].LastSyncedAt))
+
+	require.NoError(t, repo.RenameRepo(ctx, "octocat/hello-world", "acme/hello-world"))
+	got, err = repo.GetByFullName(ctx, "acme/hello-world")
+	require.NoError(t, err)
+	assert.True(t, syncedAt.Equal(got.LastSyncedAt), "rename should keep the last sync time")
+
+	err = repo.MarkSynced(ctx, "nonexistent/repo", syncedAt)
+	require.ErrorIs(t, err, driven.ErrRepoNotFound)
+}
+
+func TestRepoRepo_SetMetadata(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoRepo(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Add(ctx, makeRepo("octocat/hello-world", "octocat", "hello-world")))
+
+	got, err := repo.GetByFullName(ctx, "octocat/hello-world")
+	require.NoError(t, err)
+	assert.Equal(t, model.RepoMetadata{}, got.Metadata, "new repositories have no metadata")
+
+	metadata := model.RepoMetadata{
+		Description:   "My first repository",
+		DefaultBranch: "main",
+		Private:       true,
+		Archived:      true,
+		OpenPRCount:   4,
+		FetchedAt:     time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC),
+	}
+	require.NoError(t, repo.SetMetadata(ctx, "octocat/hello-world", metadata))
+
+	require.NoError(t, repo.RenameRepo(ctx, "octocat/hello-world", "acme/hello-world"))
+	repos, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.True(t, metadata.FetchedAt.Equal(repos[0].Metadata.FetchedAt))
+	repos[0].Metadata.FetchedAt = metadata.FetchedAt
+	assert.Equal(t, metadata, repos[0].Metadata, "rename should keep the metadata")
+
+	err = repo.SetMetadata(ctx, "nonexistent/repo", metadata)
+	require.ErrorIs(t, err, driven.ErrRepoNotFound)
+}
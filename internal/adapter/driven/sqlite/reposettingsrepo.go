@@ -0,0 +1,210 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.RepoSettingsStore = (*RepoSettingsRepo)(nil)
+
+// repoSettingsColumns lists the repo_settings columns scanRepoSettings reads, in order.
+const repoSettingsColumns = `repo_full_name, required_checks, optional_checks, ignored_checks,
+	stale_after_days, stale_action, stale_label, stale_dry_run, stale_exempt_labels, pr_scope`
+
+// RepoSettingsRepo is the SQLite implementation of the RepoSettingsStore port interface.
+type RepoSettingsRepo struct {
+	db *DB
+}
+
+// NewRepoSettingsRepo creates a new RepoSettingsRepo backed by the given DB.
+func NewRepoSettingsRepo(db *DB) *RepoSettingsRepo {
+	return &RepoSettingsRepo{db: db}
+}
+
+// GetRepoSettings returns a repository's settings, empty settings when none are saved.
+func (r *RepoSettingsRepo) GetRepoSettings(ctx context.Context, repoFullName string) (model.RepoSettings, error) {
+	const query = `SELECT ` + repoSettingsColumns + ` FROM repo_settings WHERE repo_full_name = ?`
+
+	settings, err := scanRepoSettings(r.db.Reader.QueryRowContext(ctx, query, repoFullName))
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.RepoSettings{RepoFullName: repoFullName}, nil
+	}
+	if err != nil {
+		return model.RepoSettings{}, fmt.Errorf("get repo settings for %s: %w", repoFullName, err)
+	}
+	return settings, nil
+}
+
+// ListRepoSettings returns the saved settings of the given repositories in a
+// single query, keyed by full name.
+func (r *RepoSettingsRepo) ListRepoSettings(ctx context.Context, repoFullNames []string) (map[string]model.RepoSettings, error) {
+	result := make(map[string]model.RepoSettings, len(repoFullNames))
+	if len(repoFullNames) == 0 {
+		return result, nil
+	}
+
+	placeholders := strings.Repeat("?,", len(repoFullNames))
+	placeholders = placeholders[:len(placeholders)-1]
+
+	args := make([]any, len(repoFullNames))
+	for i, name := range repoFullNames {
+		args[i] = name
+	}
+
+	//nolint:gosec // placeholders contains only comma-separated "?" literals, never user input
+	query := fmt.Sprintf(
+		`SELECT %s FROM repo_settings WHERE repo_full_name IN (%s)`,
+		repoSettingsColumns, placeholders,
+	)
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list repo settings: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		settings, err := scanRepoSettings(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan repo settings: %w", err)
+		}
+		result[settings.RepoFullName] = settings
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate repo settings: %w", err)
+	}
+	return result, nil
+}
+
+// SetRepoSettings saves a repository's settings, replacing earlier ones.
+// Returns driven.ErrRepoNotFound if the repository is not watched.
+func (r *RepoSettingsRepo) SetRepoSettings(ctx context.Context, settings model.RepoSettings) error {
+	return setRepoSettings(ctx, r.db.Writer, settings)
+}
+
+// ReplaceRepoSettings saves settings and threshold in one transaction,
+// deleting the threshold override when it sets nothing.
+func (r *RepoSettingsRepo) ReplaceRepoSettings(ctx context.Context, settings model.RepoSettings, threshold model.RepoThreshold) error {
+	tx, err := r.db.Writer.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin repo settings replace: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := setRepoSettings(ctx, tx, settings); err != nil {
+		return err
+	}
+	if threshold == (model.RepoThreshold{RepoFullName: threshold.RepoFullName}) {
+		err = deleteRepoThreshold(ctx, tx, threshold.RepoFullName)
+	} else {
+		err = setRepoThreshold(ctx, tx, threshold)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit repo settings replace: %w", err)
+	}
+	return nil
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx, so a write can run alone or
+// as part of a transaction.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// setRepoSettings upserts settings through ex.
+func setRepoSettings(ctx context.Context, ex execer, settings model.RepoSettings) error {
+	required, err := marshalNames(settings.RequiredChecks)
+	if err != nil {
+		return fmt.Errorf("marshal required checks: %w", err)
+	}
+	optional, err := marshalNames(settings.OptionalChecks)
+	if err != nil {
+		return fmt.Errorf("marshal optional checks: %w", err)
+	}
+	ignored, err := marshalNames(settings.IgnoredChecks)
+	if err != nil {
+		return fmt.Errorf("marshal ignored checks: %w", err)
+	}
+	exempt, err := marshalNames(settings.Stale.ExemptLabels)
+	if err != nil {
+		return fmt.Errorf("marshal stale exempt labels: %w", err)
+	}
+	action := settings.Stale.Action
+	if action == "" {
+		action = model.StaleActionLabel
+	}
+
+	const query = `
+		INSERT INTO repo_settings (` + repoSettingsColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(repo_full_name) DO UPDATE SET
+			required_checks = excluded.required_checks,
+			optional_checks = excluded.optional_checks,
+			ignored_checks = excluded.ignored_checks,
+			stale_after_days = excluded.stale_after_days,
+			stale_action = excluded.stale_action,
+			stale_label = excluded.stale_label,
+			stale_dry_run = excluded.stale_dry_run,
+			stale_exempt_labels = excluded.stale_exempt_labels,
+			pr_scope = excluded.pr_scope`
+	_, err = ex.ExecContext(ctx, query, settings.RepoFullName, required, optional, ignored,
+		settings.Stale.AfterDays, string(action), settings.Stale.Label, boolToInt(settings.Stale.DryRun), exempt, string(settings.PRScope))
+	if err != nil {
+		var se *sqlite.Error
+		if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+			return fmt.Errorf("set repo settings for %s: %w", settings.RepoFullName, driven.ErrRepoNotFound)
+		}
+		return fmt.Errorf("set repo settings for %s: %w", settings.RepoFullName, err)
+	}
+	return nil
+}
+
+// scanRepoSettings scans a single repo_settings row from the given scanner.
+func scanRepoSettings(s scanner) (model.RepoSettings, error) {
+	var settings model.RepoSettings
+	var required, optional, ignored, action, exempt, scope string
+	var dryRun int
+	if err := s.Scan(&settings.RepoFullName, &required, &optional, &ignored,
+		&settings.Stale.AfterDays, &action, &settings.Stale.Label, &dryRun, &exempt, &scope); err != nil {
+		return model.RepoSettings{}, err
+	}
+	settings.Stale.Action = model.StaleAction(action)
+	settings.Stale.DryRun = dryRun != 0
+	settings.PRScope = model.PRScope(scope)
+	if err := json.Unmarshal([]byte(required), &settings.RequiredChecks); err != nil {
+		return model.RepoSettings{}, fmt.Errorf("unmarshal required checks for %s: %w", settings.RepoFullName, err)
+	}
+	if err := json.Unmarshal([]byte(optional), &settings.OptionalChecks); err != nil {
+		return model.RepoSettings{}, fmt.Errorf("unmarshal optional checks for %s: %w", settings.RepoFullName, err)
+	}
+	if err := json.Unmarshal([]byte(ignored), &settings.IgnoredChecks); err != nil {
+		return model.RepoSettings{}, fmt.Errorf("unmarshal ignored checks for %s: %w", settings.RepoFullName, err)
+	}
+	if err := json.Unmarshal([]byte(exempt), &settings.Stale.ExemptLabels); err != nil {
+		return model.RepoSettings{}, fmt.Errorf("unmarshal stale exempt labels for %s: %w", settings.RepoFullName, err)
+	}
+	return settings, nil
+}
+
+// marshalNames serializes a list of names as a JSON array, never null.
+func marshalNames(names []string) (string, error) {
+	if names == nil {
+		names = []string{}
+	}
+	b, err := json.Marshal(names)
+	return string(b), err
+}
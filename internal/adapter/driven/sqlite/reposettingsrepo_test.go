@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+func TestRepoSettingsRepo_SetGetList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoSettingsRepo(db)
+	ctx := context.Background()
+	addTestRepo(t, db, "octocat/hello-world")
+	addTestRepo(t, db, "octocat/other")
+
+	settings, err := repo.GetRepoSettings(ctx, "octocat/hello-world")
+	require.NoError(t, err)
+	assert.Equal(t, model.RepoSettings{RepoFullName: "octocat/hello-world"}, settings, "no saved settings")
+
+	saved := model.RepoSettings{
+		RepoFullName:   "octocat/hello-world",
+		RequiredChecks: []string{"build", "test"},
+		OptionalChecks: []string{"codecov/patch"},
+		IgnoredChecks:  []string{"codecov/project"},
+		Stale: model.StalePolicy{
+			AfterDays:    14,
+			Action:       model.StaleActionComment,
+			Label:        "idle",
+			DryRun:       true,
+			ExemptLabels: []string{"pinned"},
+		},
+	}
+	require.NoError(t, repo.SetRepoSettings(ctx, saved))
+	settings, err = repo.GetRepoSettings(ctx, "octocat/hello-world")
+	require.NoError(t, err)
+	assert.Equal(t, saved, settings)
+
+	saved.RequiredChecks = nil
+	require.NoError(t, repo.SetRepoSettings(ctx, saved))
+	all, err := repo.ListRepoSettings(ctx, []string{"octocat/hello-world", "octocat/other"})
+	require.NoError(t, err)
+	require.Len(t, all, 1, "repos without settings are omitted")
+	assert.Empty(t, all["octocat/hello-world"].RequiredChecks, "saving replaces the earlier lists")
+	assert.Equal(t, []string{"codecov/patch"}, all["octocat/hello-world"].OptionalChecks)
+
+	err = repo.SetRepoSettings(ctx, model.RepoSettings{RepoFullName: "octocat/unwatched"})
+	assert.ErrorIs(t, err, driven.ErrRepoNotFound)
+}
+
+func TestRepoSettingsRepo_ReplaceRepoSettings(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewRepoSettingsRepo(db)
+	thresholds := NewThresholdRepo(db)
+	ctx := context.Background()
+	addTestRepo(t, db, "octocat/hello-world")
+
+	reviews := 3
+	settings := model.RepoSettings{RepoFullName: "octocat/hello-world", RequiredChecks: []string{"build"}}
+	threshold := model.RepoThreshold{RepoFullName: "octocat/hello-world", ReviewCount: &reviews}
+	require.NoError(t, repo.ReplaceRepoSettings(ctx, settings, threshold))
+	got, err := thresholds.GetRepoThreshold(ctx, "octocat/hello-world")
+	require.NoError(t, err)
+	require.NotNil(t, got.ReviewCount)
+	assert.Equal(t, 3, *got.ReviewCount)
+
+	// A threshold write that fails leaves the settings as they were.
+	_, err = db.Writer.ExecContext(ctx, `DROP TABLE repo_thresholds`)
+	require.NoError(t, err)
+	err = repo.ReplaceRepoSettings(ctx, model.RepoSettings{RepoFullName: "octocat/hello-world"}, threshold)
+	require.Error(t, err)
+	stored, err := repo.GetRepoSettings(ctx, "octocat/hello-world")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"build"}, stored.RequiredChecks)
+
+	err = repo.ReplaceRepoSettings(ctx, model.RepoSettings{RepoFullName: "octocat/unwatched"}, model.RepoThreshold{RepoFullName: "octocat/unwatched"})
+	assert.ErrorIs(t, err, driven.ErrRepoNotFound)
+}
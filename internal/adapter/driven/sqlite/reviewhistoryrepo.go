@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.ReviewHistoryStore = (*ReviewHistoryRepo)(nil)
+
+// ReviewHistoryRepo is the SQLite implementation of the ReviewHistoryStore port
+// interface. It reads the PR, review, and decision tables; merged and closed
+// PRs are never pruned by polling, so their history stays queryable.
+type ReviewHistoryRepo struct {
+	db *DB
+}
+
+// NewReviewHistoryRepo creates a new ReviewHistoryRepo backed by the given DB.
+func NewReviewHistoryRepo(db *DB) *ReviewHistoryRepo {
+	return &ReviewHistoryRepo{db: db}
+}
+
+// ListReviewed returns the user's reviewed, no longer open PRs with their
+// latest verdict, comment count, and recorded decisions.
+func (r *ReviewHistoryRepo) ListReviewed(ctx context.Context, reviewers []string, repoFullName, query string, limit int) ([]model.ReviewedPR, error) {
+	if len(reviewers) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	values := strings.Repeat("(?),", len(reviewers))
+	values = values[:len(values)-1]
+
+	query = strings.TrimSpace(query)
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+
+	args := make([]any, 0, len(reviewers)+8)
+	for _, login := range reviewers {
+		args = append(args, strings.ToLower(login))
+	}
+	args = append(args, repoFullName, repoFullName, query, pattern, pattern, pattern, pattern, limit)
+
+	//nolint:gosec // values contains only comma-separated "(?)" literals, never user input
+	stmt := fmt.Sprintf(`
+		WITH me(login) AS (VALUES %s),
+		mine AS (
+			SELECT pr_id, MAX(submitted_at) AS last_reviewed_at
+			FROM reviews
+			WHERE lower(reviewer_login) IN (SELECT login FROM me)
+			GROUP BY pr_id
+		)
+		SELECT pr.id, pr.number, pr.repo_full_name, pr.title, pr.author, pr.status, pr.is_draft, pr.needs_review,
+		       pr.url, pr.branch, pr.base_branch, pr.labels, pr.head_sha,
+		       pr.additions, pr.deletions, pr.changed_files, pr.mergeable_status, pr.ci_status,
+		       pr.opened_at, pr.updated_at, pr.last_activity_at, pr.jira_key, pr.closed_at, pr.author_association, pr.milestone, pr.checklist_done, pr.checklist_total, pr.review_requests,
+		       pr.review_status, pr.approval_count, pr.unresolved_thread_count,
+		       (SELECT r.state FROM reviews r
+		        WHERE r.pr_id = pr.id AND lower(r.reviewer_login) IN (SELECT login FROM me)
+		        ORDER BY r.submitted_at DESC, r.id DESC LIMIT 1),
+		       mine.last_reviewed_at,
+		       (SELECT COUNT(*) FROM review_comments rc
+		        WHERE rc.pr_id = pr.id AND lower(rc.author) IN (SELECT login FROM me))
+		FROM pull_requests pr
+		INNER JOIN mine ON mine.pr_id = pr.id
+		WHERE pr.status != 'open'
+		  AND pr.repo_full_name IN (SELECT full_name FROM repositories WHERE removed_at IS NULL)
+		  AND (? = '' OR pr.repo_full_name = ?)
+		  AND (? = ''
+		       OR pr.title LIKE ? ESCAPE '\'
+		       OR EXISTS (SELECT 1 FROM reviews r
+		                  WHERE r.pr_id = pr.id AND lower(r.reviewer_login) IN (SELECT login FROM me)
+		                    AND r.body LIKE ? ESCAPE '\')
+		       OR EXISTS (SELECT 1 FROM review_comments rc
+		                  WHERE rc.pr_id = pr.id AND lower(rc.author) IN (SELECT login FROM me)
+		                    AND rc.body LIKE ? ESCAPE '\')
+		       OR EXISTS (SELECT 1 FROM decisions d
+		                  WHERE d.repo_full_name = pr.repo_full_name AND d.pr_number = pr.number
+		                    AND d.summary LIKE ? ESCAPE '\'))
+		ORDER BY mine.last_reviewed_at DESC, pr.id DESC
+		LIMIT ?`, values)
+
+	rows, err := r.db.Reader.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list reviewed PRs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []model.ReviewedPR
+	for rows.Next() {
+		var item model.ReviewedPR
+		var state, lastReviewedAt string
+		pr, err := scanPR(scannerFunc(func(dest ...any) error {
+			return rows.Scan(append(dest, &state, &lastReviewedAt, &item.CommentCount)...)
+		}))
+		if err != nil {
+			return nil, fmt.Errorf("scan reviewed PR: %w", err)
+		}
+		item.PullRequest = *pr
+		item.LastState = model.ReviewState(state)
+		if item.LastReviewedAt, err = parseTime(lastReviewedAt); err != nil {
+			return nil, fmt.Errorf("parse last review time for PR %d: %w", pr.ID, err)
+		}
+		result = append(result, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewed PRs: %w", err)
+	}
+
+	if err := r.attachDecisions(ctx, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// attachDecisions loads the decisions recorded on each listed PR in one query.
+func (r *ReviewHistoryRepo) attachDecisions(ctx context.Context, items []model.ReviewedPR) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	values := strings.Repeat("(?,?),", len(items))
+	values = values[:len(values)-1]
+
+	args := make([]any, 0, 2*len(items))
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		args = append(args, item.PullRequest.RepoFullName, item.PullRequest.Number)
+		index[fmt.Sprintf("%s#%d", item.PullRequest.RepoFullName, item.PullRequest.Number)] = i
+	}
+
+	//nolint:gosec // values contains only comma-separated "(?,?)" literals, never user input
+	stmt := fmt.Sprintf(`
+		SELECT id, repo_full_name, pr_number, pr_title, root_comment_id, file_path, summary, created_at, updated_at
+		FROM decisions
+		WHERE (repo_full_name, pr_number) IN (VALUES %s)
+		ORDER BY created_at, id`, values)
+
+	rows, err := r.db.Reader.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return fmt.Errorf("list decisions for reviewed PRs: %w", err)
+	}
+	decisions, err := scanDecisions(rows)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range decisions {
+		if i, ok := index[fmt.Sprintf("%s#%d", d.RepoFullName, d.PRNumber)]; ok {
+			items[i].Decisions = append(items[i].Decisions, d)
+		}
+	}
+	return nil
+}
+
+// scannerFunc adapts a function to the scanner interface, letting callers
+// scan extra trailing columns after those read by scanPR.
+type scannerFunc func(dest ...any) error
+
+// Scan calls f.
+func (f scannerFunc) Scan(dest ...any) error { return f(dest...) }
@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestReviewHistoryRepo_ListReviewed(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	addTestRepo(t, db, testRepoFullName)
+
+	prRepo := NewPRRepo(db)
+	reviewRepo := NewReviewRepo(db)
+	prID := func(number int, title string, status model.PRStatus) int64 {
+		require.NoError(t, prRepo.Upsert(ctx, makePR(testRepoFullName, number, title, status)))
+		pr, err := prRepo.GetByNumber(ctx, testRepoFullName, number)
+		require.NoError(t, err)
+		return pr.ID
+	}
+	merged := prID(1, "Add caching layer", model.PRStatusMerged)
+	open := prID(2, "Still in review", model.PRStatusOpen)
+	othersOnly := prID(3, "Someone else's review", model.PRStatusClosed)
+
+	at := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	reviews := []model.Review{
+		{ID: 1, PRID: merged, ReviewerLogin: "Me", State: model.ReviewStateChangesRequested, SubmittedAt: at},
+		{ID: 2, PRID: merged, ReviewerLogin: "me", State: model.ReviewStateApproved, SubmittedAt: at.Add(time.Hour)},
+		{ID: 3, PRID: open, ReviewerLogin: "me", State: model.ReviewStateApproved, SubmittedAt: at},
+		{ID: 4, PRID: othersOnly, ReviewerLogin: "bob", State: model.ReviewStateApproved, SubmittedAt: at},
+	}
+	for _, rv := range reviews {
+		require.NoError(t, reviewRepo.UpsertReview(ctx, rv))
+	}
+	require.NoError(t, reviewRepo.UpsertReviewComment(ctx, model.ReviewComment{
+		ID: 10, PRID: merged, Author: "me", Body: "Bound the eviction queue", Path: "cache.go",
+		CreatedAt: at, UpdatedAt: at,
+	}))
+	require.NoError(t, NewDecisionRepo(db).Save(ctx, testDecision(testRepoFullName, 1, 10, "Use LRU eviction")))
+
+	repo := NewReviewHistoryRepo(db)
+
+	got, err := repo.ListReviewed(ctx, []string{"me"}, "", "", 50)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, 1, got[0].PullRequest.Number)
+	assert.Equal(t, model.ReviewStateApproved, got[0].LastState)
+	assert.True(t, got[0].LastReviewedAt.Equal(at.Add(time.Hour)))
+	assert.Equal(t, 1, got[0].CommentCount)
+	require.Len(t, got[0].Decisions, 1)
+	assert.Equal(t, "Use LRU eviction", got[0].Decisions[0].Summary)
+
+	for _, query := range []string{"caching", "eviction queue", "LRU"} {
+		got, err = repo.ListReviewed(ctx, []string{"me"}, testRepoFullName, query, 50)
+		require.NoError(t, err)
+		assert.Len(t, got, 1, "query %q", query)
+	}
+
+	got, err = repo.ListReviewed(ctx, []string{"me"}, "", "no such text", 50)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = repo.ListReviewed(ctx, []string{"me"}, "other/repo", "", 50)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
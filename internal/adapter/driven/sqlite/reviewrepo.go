@@ -4,13 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
-// Compile-time interface satisfaction check.
-var _ driven.ReviewStore = (*ReviewRepo)(nil)
+// Compile-time interface satisfaction checks.
+var (
+	_ driven.ReviewStore         = (*ReviewRepo)(nil)
+	_ driven.PendingCommentStore = (*ReviewRepo)(nil)
+)
 
 // ReviewRepo is the SQLite implementation of the ReviewStore port interface.
 type ReviewRepo struct {
@@ -59,8 +63,8 @@ func (r *ReviewRepo) UpsertReviewComment(ctx context.Context, comment model.Revi
 		INSERT INTO review_comments (
 			id, review_id, pr_id, author, body, path, line, start_line,
 			side, subject_type, diff_hunk, commit_id, is_resolved, is_outdated,
-			in_reply_to_id, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			in_reply_to_id, created_at, updated_at, position, original_line, html_url
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			review_id = excluded.review_id,
 			pr_id = excluded.pr_id,
@@ -77,7 +81,10 @@ func (r *ReviewRepo) UpsertReviewComment(ctx context.Context, comment model.Revi
 			is_outdated = excluded.is_outdated,
 			in_reply_to_id = excluded.in_reply_to_id,
 			created_at = excluded.created_at,
-			updated_at = excluded.updated_at
+			updated_at = excluded.updated_at,
+			position = excluded.position,
+			original_line = excluded.original_line,
+			html_url = excluded.html_url
 	`
 
 	isResolved := 0
@@ -101,6 +108,7 @@ func (r *ReviewRepo) UpsertReviewComment(ctx context.Context, comment model.Revi
 		comment.Side, comment.SubjectType, comment.DiffHunk, comment.CommitID,
 		isResolved, isOutdated, inReplyToID,
 		comment.CreatedAt.UTC(), comment.UpdatedAt.UTC(),
+		comment.Position, comment.OriginalLine, comment.HTMLURL,
 	)
 	if err != nil {
 		return fmt.Errorf("upsert review comment %d: %w", comment.ID, err)
@@ -112,15 +120,16 @@ func (r *ReviewRepo) UpsertReviewComment(ctx context.Context, comment model.Revi
 // UpsertIssueComment inserts or updates an issue comment by its GitHub ID.
 func (r *ReviewRepo) UpsertIssueComment(ctx context.Context, comment model.IssueComment) error {
 	const query = `
-		INSERT INTO issue_comments (id, pr_id, author, body, is_bot, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO issue_comments (id, pr_id, author, body, is_bot, created_at, updated_at, html_url)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			pr_id = excluded.pr_id,
 			author = excluded.author,
 			body = excluded.body,
 			is_bot = excluded.is_bot,
 			created_at = excluded.created_at,
-			updated_at = excluded.updated_at
+			updated_at = excluded.updated_at,
+			html_url = excluded.html_url
 	`
 
 	isBot := 0
@@ -130,7 +139,7 @@ func (r *ReviewRepo) UpsertIssueComment(ctx context.Context, comment model.Issue
 
 	_, err := r.db.Writer.ExecContext(ctx, query,
 		comment.ID, comment.PRID, comment.Author, comment.Body,
-		isBot, comment.CreatedAt.UTC(), comment.UpdatedAt.UTC(),
+		isBot, comment.CreatedAt.UTC(), comment.UpdatedAt.UTC(), comment.HTMLURL,
 	)
 	if err != nil {
 		return fmt.Errorf("upsert issue comment %d: %w", comment.ID, err)
@@ -139,6 +148,74 @@ func (r *ReviewRepo) UpsertIssueComment(ctx context.Context, comment model.Issue
 	return nil
 }
 
+// AddPendingReviewComment stores a local echo of a posted review comment under
+// the next unused negative ID and returns that ID.
+func (r *ReviewRepo) AddPendingReviewComment(ctx context.Context, comment model.ReviewComment) (int64, error) {
+	const query = `
+		INSERT INTO review_comments (
+			id, review_id, pr_id, author, body, path, line, start_line,
+			side, subject_type, diff_hunk, commit_id, is_resolved, is_outdated,
+			in_reply_to_id, created_at, updated_at, pending_sync
+		) VALUES (
+			(SELECT COALESCE(MIN(id), 0) - 1 FROM review_comments WHERE id < 0),
+			?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 0, 0, ?, ?, ?, 1
+		)
+	`
+
+	var inReplyToID any
+	if comment.InReplyToID != nil {
+		inReplyToID = *comment.InReplyToID
+	}
+
+	res, err := r.db.Writer.ExecContext(ctx, query,
+		comment.ReviewID, comment.PRID, comment.Author,
+		comment.Body, comment.Path, comment.Line, comment.StartLine,
+		comment.Side, comment.SubjectType, comment.DiffHunk, comment.CommitID,
+		inReplyToID, comment.CreatedAt.UTC(), comment.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("add pending review comment on PR %d: %w", comment.PRID, err)
+	}
+	return res.LastInsertId()
+}
+
+// AddPendingIssueComment stores a local echo of a posted issue comment under
+// the next unused negative ID and returns that ID.
+func (r *ReviewRepo) AddPendingIssueComment(ctx context.Context, comment model.IssueComment) (int64, error) {
+	const query = `
+		INSERT INTO issue_comments (id, pr_id, author, body, is_bot, created_at, updated_at, pending_sync)
+		VALUES ((SELECT COALESCE(MIN(id), 0) - 1 FROM issue_comments WHERE id < 0), ?, ?, ?, 0, ?, ?, 1)
+	`
+
+	res, err := r.db.Writer.ExecContext(ctx, query,
+		comment.PRID, comment.Author, comment.Body, comment.CreatedAt.UTC(), comment.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("add pending issue comment on PR %d: %w", comment.PRID, err)
+	}
+	return res.LastInsertId()
+}
+
+// DeletePendingReviewComment removes a pending review comment. No-op for
+// synced comments or unknown IDs.
+func (r *ReviewRepo) DeletePendingReviewComment(ctx context.Context, id int64) error {
+	const query = `DELETE FROM review_comments WHERE id = ? AND pending_sync = 1`
+	if _, err := r.db.Writer.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete pending review comment %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeletePendingIssueComment removes a pending issue comment. No-op for synced
+// comments or unknown IDs.
+func (r *ReviewRepo) DeletePendingIssueComment(ctx context.Context, id int64) error {
+	const query = `DELETE FROM issue_comments WHERE id = ? AND pending_sync = 1`
+	if _, err := r.db.Writer.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete pending issue comment %d: %w", id, err)
+	}
+	return nil
+}
+
 // GetReviewsByPR returns all reviews for the given PR, ordered by submitted_at.
 func (r *ReviewRepo) GetReviewsByPR(ctx context.Context, prID int64) ([]model.Review, error) {
 	const query = `
@@ -170,12 +247,61 @@ func (r *ReviewRepo) GetReviewsByPR(ctx context.Context, prID int64) ([]model.Re
 	return reviews, nil
 }
 
+// GetReviewsByPRs returns the reviews of each given PR in a single query,
+// each PR's ordered by submitted_at as in GetReviewsByPR. PRs without reviews
+// are absent from the map.
+func (r *ReviewRepo) GetReviewsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.Review, error) {
+	result := make(map[int64][]model.Review, len(prIDs))
+	if len(prIDs) == 0 {
+		return result, nil
+	}
+
+	placeholders, args := idPlaceholders(prIDs)
+	//nolint:gosec // placeholders contains only comma-separated "?" literals, never user input
+	query := fmt.Sprintf(`
+		SELECT id, pr_id, reviewer_login, state, body, commit_id, submitted_at, is_bot
+		FROM reviews
+		WHERE pr_id IN (%s)
+		ORDER BY pr_id, submitted_at
+	`, placeholders)
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query reviews for %d PRs: %w", len(prIDs), err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan review: %w", err)
+		}
+		result[review.PRID] = append(result[review.PRID], *review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviews: %w", err)
+	}
+
+	return result, nil
+}
+
+// idPlaceholders returns an "?,?,..." list for an IN clause over ids, and
+// the ids as query arguments.
+func idPlaceholders(ids []int64) (string, []any) {
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args
+}
+
 // GetReviewCommentsByPR returns all review comments for the given PR, ordered by created_at.
 func (r *ReviewRepo) GetReviewCommentsByPR(ctx context.Context, prID int64) ([]model.ReviewComment, error) {
 	const query = `
 		SELECT id, review_id, pr_id, author, body, path, line, start_line,
 		       side, subject_type, diff_hunk, commit_id, is_resolved, is_outdated,
-		       in_reply_to_id, created_at, updated_at
+		       in_reply_to_id, created_at, updated_at, pending_sync, position, original_line, html_url
 		FROM review_comments
 		WHERE pr_id = ?
 		ORDER BY created_at
@@ -206,7 +332,7 @@ func (r *ReviewRepo) GetReviewCommentsByPR(ctx context.Context, prID int64) ([]m
 // GetIssueCommentsByPR returns all issue comments for the given PR, ordered by created_at.
 func (r *ReviewRepo) GetIssueCommentsByPR(ctx context.Context, prID int64) ([]model.IssueComment, error) {
 	const query = `
-		SELECT id, pr_id, author, body, is_bot, created_at, updated_at
+		SELECT id, pr_id, author, body, is_bot, created_at, updated_at, pending_sync, html_url
 		FROM issue_comments
 		WHERE pr_id = ?
 		ORDER BY created_at
@@ -300,15 +426,16 @@ func scanReview(s scanner) (*model.Review, error) {
 
 func scanReviewComment(s scanner) (*model.ReviewComment, error) {
 	var comment model.ReviewComment
-	var isResolved, isOutdated int
-	var inReplyToID sql.NullInt64
+	var isResolved, isOutdated, pendingSync int
+	var inReplyToID, position sql.NullInt64
 	var createdAt, updatedAt string
 
 	err := s.Scan(
 		&comment.ID, &comment.ReviewID, &comment.PRID, &comment.Author,
 		&comment.Body, &comment.Path, &comment.Line, &comment.StartLine,
 		&comment.Side, &comment.SubjectType, &comment.DiffHunk, &comment.CommitID,
-		&isResolved, &isOutdated, &inReplyToID, &createdAt, &updatedAt,
+		&isResolved, &isOutdated, &inReplyToID, &createdAt, &updatedAt, &pendingSync,
+		&position, &comment.OriginalLine, &comment.HTMLURL,
 	)
 	if err != nil {
 		return nil, err
@@ -316,11 +443,16 @@ func scanReviewComment(s scanner) (*model.ReviewComment, error) {
 
 	comment.IsResolved = isResolved != 0
 	comment.IsOutdated = isOutdated != 0
+	comment.PendingSync = pendingSync != 0
 
 	if inReplyToID.Valid {
 		id := inReplyToID.Int64
 		comment.InReplyToID = &id
 	}
+	if position.Valid {
+		p := int(position.Int64)
+		comment.Position = &p
+	}
 
 	comment.CreatedAt, err = parseTime(createdAt)
 	if err != nil {
@@ -337,18 +469,19 @@ func scanReviewComment(s scanner) (*model.ReviewComment, error) {
 
 func scanIssueComment(s scanner) (*model.IssueComment, error) {
 	var comment model.IssueComment
-	var isBot int
+	var isBot, pendingSync int
 	var createdAt, updatedAt string
 
 	err := s.Scan(
 		&comment.ID, &comment.PRID, &comment.Author, &comment.Body,
-		&isBot, &createdAt, &updatedAt,
+		&isBot, &createdAt, &updatedAt, &pendingSync, &comment.HTMLURL,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	comment.IsBot = isBot != 0
+	comment.PendingSync = pendingSync != 0
 
 	comment.CreatedAt, err = parseTime(createdAt)
 	if err != nil {
@@ -76,6 +76,34 @@ func TestReviewRepo_UpsertAndGetReviews(t *testing.T) {
 	assert.Equal(t, "abc123", reviews[1].CommitID)
 }
 
+func TestReviewRepo_GetReviewsByPRs(t *testing.T) {
+	db := setupTestDB(t)
+	pr1 := addTestPR(t, db, "octocat/hello-world", 1)
+	pr2 := addTestPR(t, db, "octocat/spoon-knife", 2)
+	pr3 := addTestPR(t, db, "octocat/linguist", 3)
+	repo := NewReviewRepo(db)
+	ctx := context.Background()
+
+	at := time.Date(2026, 1, 20, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.UpsertReview(ctx, model.Review{ID: 1, PRID: pr1, ReviewerLogin: "alice", State: model.ReviewStateApproved, SubmittedAt: at.Add(time.Hour)}))
+	require.NoError(t, repo.UpsertReview(ctx, model.Review{ID: 2, PRID: pr1, ReviewerLogin: "bob", State: model.ReviewStateCommented, SubmittedAt: at}))
+	require.NoError(t, repo.UpsertReview(ctx, model.Review{ID: 3, PRID: pr2, ReviewerLogin: "carol", State: model.ReviewStateApproved, SubmittedAt: at}))
+	require.NoError(t, repo.UpsertReview(ctx, model.Review{ID: 4, PRID: pr3, ReviewerLogin: "dave", State: model.ReviewStateApproved, SubmittedAt: at}))
+
+	byPR, err := repo.GetReviewsByPRs(ctx, []int64{pr1, pr2, 999})
+	require.NoError(t, err)
+	require.Len(t, byPR, 2, "only requested PRs with reviews are present")
+	require.Len(t, byPR[pr1], 2)
+	assert.Equal(t, int64(2), byPR[pr1][0].ID, "each PR's reviews are ordered by submitted_at")
+	assert.Equal(t, int64(1), byPR[pr1][1].ID)
+	require.Len(t, byPR[pr2], 1)
+	assert.Equal(t, "carol", byPR[pr2][0].ReviewerLogin)
+
+	empty, err := repo.GetReviewsByPRs(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
 func TestReviewRepo_UpsertReviewComment_WithReply(t *testing.T) {
 	db := setupTestDB(t)
 	prID := addTestPR(t, db, "octocat/hello-world", 1)
@@ -83,25 +111,28 @@ func TestReviewRepo_UpsertReviewComment_WithReply(t *testing.T) {
 	ctx := context.Background()
 
 	now := time.Date(2026, 1, 20, 12, 0, 0, 0, time.UTC)
+	position := 7
 
 	rootComment := model.ReviewComment{
-		ID:          2001,
-		ReviewID:    1001,
-		PRID:        prID,
-		Author:      "alice",
-		Body:        "This needs a nil check",
-		Path:        "main.go",
-		Line:        42,
-		StartLine:   40,
-		Side:        "RIGHT",
-		SubjectType: "line",
-		DiffHunk:    "@@ -38,6 +38,10 @@",
-		CommitID:    "abc123",
-		IsResolved:  false,
-		IsOutdated:  false,
-		InReplyToID: nil,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:           2001,
+		ReviewID:     1001,
+		PRID:         prID,
+		Author:       "alice",
+		Body:         "This needs a nil check",
+		Path:         "main.go",
+		Line:         42,
+		StartLine:    40,
+		Side:         "RIGHT",
+		SubjectType:  "line",
+		DiffHunk:     "@@ -38,6 +38,10 @@",
+		CommitID:     "abc123",
+		Position:     &position,
+		OriginalLine: 41,
+		IsResolved:   false,
+		IsOutdated:   false,
+		InReplyToID:  nil,
+		CreatedAt:    now,
+		UpdatedAt:    now,
 	}
 
 	replyToID := int64(2001)
@@ -138,6 +169,9 @@ func TestReviewRepo_UpsertReviewComment_WithReply(t *testing.T) {
 	assert.Equal(t, 40, comments[0].StartLine)
 	assert.Equal(t, "line", comments[0].SubjectType)
 	assert.Equal(t, "abc123", comments[0].CommitID)
+	require.NotNil(t, comments[0].Position)
+	assert.Equal(t, 7, *comments[0].Position)
+	assert.Equal(t, 41, comments[0].OriginalLine)
 	assert.Nil(t, comments[0].InReplyToID)
 
 	// Reply comment second
@@ -145,6 +179,7 @@ func TestReviewRepo_UpsertReviewComment_WithReply(t *testing.T) {
 	assert.Equal(t, "Good catch, will fix", comments[1].Body)
 	require.NotNil(t, comments[1].InReplyToID)
 	assert.Equal(t, int64(2001), *comments[1].InReplyToID)
+	assert.Nil(t, comments[1].Position, "a missing position is stored as NULL")
 }
 
 func TestReviewRepo_UpsertAndGetIssueComments(t *testing.T) {
@@ -161,6 +196,7 @@ func TestReviewRepo_UpsertAndGetIssueComments(t *testing.T) {
 		Author:    "coderabbitai",
 		Body:      "## Summary\nThis PR adds feature X",
 		IsBot:     true,
+		HTMLURL:   "https://github.com/octocat/hello-world/pull/1#issuecomment-3001",
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -175,6 +211,7 @@ func TestReviewRepo_UpsertAndGetIssueComments(t *testing.T) {
 	assert.Equal(t, "coderabbitai", comments[0].Author)
 	assert.Equal(t, "## Summary\nThis PR adds feature X", comments[0].Body)
 	assert.True(t, comments[0].IsBot)
+	assert.Equal(t, "https://github.com/octocat/hello-world/pull/1#issuecomment-3001", comments[0].HTMLURL)
 }
 
 func TestReviewRepo_UpdateCommentResolution(t *testing.T) {
@@ -294,3 +331,59 @@ func TestReviewRepo_UpsertIdempotency(t *testing.T) {
 	assert.Equal(t, "Updated review", reviews[0].Body)
 	assert.Equal(t, model.ReviewStateApproved, reviews[0].State)
 }
+
+func TestReviewRepo_PendingComments(t *testing.T) {
+	db := setupTestDB(t)
+	prID := addTestPR(t, db, "octocat/hello-world", 1)
+	repo := NewReviewRepo(db)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	root := int64(500)
+	require.NoError(t, repo.UpsertReviewComment(ctx, model.ReviewComment{
+		ID: root, PRID: prID, Author: "alice", Body: "why?", Path: "main.go", Line: 3, CreatedAt: now, UpdatedAt: now,
+	}))
+
+	first, err := repo.AddPendingReviewComment(ctx, model.ReviewComment{
+		PRID: prID, Author: "me", Body: "because", Path: "main.go", Line: 3, InReplyToID: &root, CreatedAt: now, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	second, err := repo.AddPendingReviewComment(ctx, model.ReviewComment{
+		PRID: prID, Author: "me", Body: "also", InReplyToID: &root, CreatedAt: now, UpdatedAt: now,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), first)
+	assert.Equal(t, int64(-2), second)
+
+	comments, err := repo.GetReviewCommentsByPR(ctx, prID)
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	var pending int
+	for _, c := range comments {
+		if c.PendingSync {
+			pending++
+			assert.Negative(t, c.ID)
+			assert.Equal(t, root, *c.InReplyToID)
+		}
+	}
+	assert.Equal(t, 2, pending)
+
+	require.NoError(t, repo.DeletePendingReviewComment(ctx, first))
+	require.NoError(t, repo.DeletePendingReviewComment(ctx, root), "synced comments are not deleted")
+	comments, err = repo.GetReviewCommentsByPR(ctx, prID)
+	require.NoError(t, err)
+	assert.Len(t, comments, 2)
+
+	icID, err := repo.AddPendingIssueComment(ctx, model.IssueComment{PRID: prID, Author: "me", Body: "hi", CreatedAt: now, UpdatedAt: now})
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), icID)
+	issueComments, err := repo.GetIssueCommentsByPR(ctx, prID)
+	require.NoError(t, err)
+	require.Len(t, issueComments, 1)
+	assert.True(t, issueComments[0].PendingSync)
+
+	require.NoError(t, repo.DeletePendingIssueComment(ctx, icID))
+	issueComments, err = repo.GetIssueCommentsByPR(ctx, prID)
+	require.NoError(t, err)
+	assert.Empty(t, issueComments)
+}
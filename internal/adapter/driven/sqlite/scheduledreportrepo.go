@@ -0,0 +1,127 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.ScheduledReportStore = (*ScheduledReportRepo)(nil)
+
+const scheduledReportColumns = `id, name, group_id, destination, target, template, weekday, hour, last_sent_at, created_at`
+
+// ScheduledReportRepo is the SQLite implementation of the ScheduledReportStore port interface.
+type ScheduledReportRepo struct {
+	db *DB
+}
+
+// NewScheduledReportRepo creates a new ScheduledReportRepo backed by the given DB.
+func NewScheduledReportRepo(db *DB) *ScheduledReportRepo {
+	return &ScheduledReportRepo{db: db}
+}
+
+// Create persists a report and returns the assigned ID. A zero GroupID is
+// stored as NULL.
+func (r *ScheduledReportRepo) Create(ctx context.Context, report model.ScheduledReport) (int64, error) {
+	const query = `INSERT INTO scheduled_reports (name, group_id, destination, target, template, weekday, hour, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	createdAt := report.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	var groupID sql.NullInt64
+	if report.GroupID != 0 {
+		groupID = sql.NullInt64{Int64: report.GroupID, Valid: true}
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query, report.Name, groupID, string(report.Destination),
+		report.Target, report.Template, int(report.Weekday), report.Hour, createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create scheduled report: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create scheduled report: last insert id: %w", err)
+	}
+	return id, nil
+}
+
+// List returns all reports ordered by name.
+func (r *ScheduledReportRepo) List(ctx context.Context) ([]model.ScheduledReport, error) {
+	query := `SELECT ` + scheduledReportColumns + ` FROM scheduled_reports ORDER BY name COLLATE NOCASE, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []model.ScheduledReport
+	for rows.Next() {
+		report, err := scanScheduledReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("list scheduled reports: scan: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("list scheduled reports: iterate: %w", err)
+	}
+	return reports, nil
+}
+
+// Delete removes a report.
+func (r *ScheduledReportRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.Writer.ExecContext(ctx, `DELETE FROM scheduled_reports WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete scheduled report %d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("delete scheduled report %d: %w", id, driven.ErrScheduledReportNotFound)
+	}
+	return nil
+}
+
+// MarkSent records when a report was last sent.
+func (r *ScheduledReportRepo) MarkSent(ctx context.Context, id int64, at time.Time) error {
+	if _, err := r.db.Writer.ExecContext(ctx, `UPDATE scheduled_reports SET last_sent_at = ? WHERE id = ?`, at.UTC(), id); err != nil {
+		return fmt.Errorf("mark scheduled report %d sent: %w", id, err)
+	}
+	return nil
+}
+
+// scanScheduledReport scans a single scheduled_reports row from the given scanner.
+func scanScheduledReport(s scanner) (model.ScheduledReport, error) {
+	var report model.ScheduledReport
+	var groupID sql.NullInt64
+	var destination, createdAt string
+	var weekday int
+	var lastSentAt sql.NullString
+	if err := s.Scan(&report.ID, &report.Name, &groupID, &destination, &report.Target, &report.Template,
+		&weekday, &report.Hour, &lastSentAt, &createdAt); err != nil {
+		return model.ScheduledReport{}, err
+	}
+	report.GroupID = groupID.Int64
+	report.Destination = model.ReportDestination(destination)
+	report.Weekday = time.Weekday(weekday)
+
+	var err error
+	if report.CreatedAt, err = parseTime(createdAt); err != nil {
+		return model.ScheduledReport{}, fmt.Errorf("parse created_at for scheduled report %d: %w", report.ID, err)
+	}
+	if lastSentAt.Valid {
+		if report.LastSentAt, err = parseTime(lastSentAt.String); err != nil {
+			return model.ScheduledReport{}, fmt.Errorf("parse last_sent_at for scheduled report %d: %w", report.ID, err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledReportRepo_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScheduledReportRepo(db)
+	ctx := context.Background()
+
+	group, err := NewRepoGroupRepo(db).Create(ctx, model.RepoGroup{Name: "Backend"})
+	require.NoError(t, err)
+
+	id, err := repo.Create(ctx, model.ScheduledReport{
+		Name:        "Weekly",
+		GroupID:     group.ID,
+		Destination: model.ReportToSlack,
+		Target:      "https://hooks.slack.com/services/T/B/X",
+		Template:    "{{.Name}}",
+		Weekday:     time.Monday,
+		Hour:        9,
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.ScheduledReport{
+		Name:        "All repos",
+		Destination: model.ReportToGitHubIssue,
+		Target:      "acme/api#12",
+		Weekday:     time.Friday,
+		Hour:        16,
+	})
+	require.NoError(t, err)
+
+	sentAt := time.Date(2026, 10, 12, 9, 0, 30, 0, time.UTC)
+	require.NoError(t, repo.MarkSent(ctx, id, sentAt))
+
+	reports, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "All repos", reports[0].Name)
+	assert.Zero(t, reports[0].GroupID, "no group covers every repository")
+	assert.True(t, reports[0].LastSentAt.IsZero())
+	assert.Equal(t, model.ReportToGitHubIssue, reports[0].Destination)
+	assert.Equal(t, group.ID, reports[1].GroupID)
+	assert.Equal(t, time.Monday, reports[1].Weekday)
+	assert.Equal(t, 9, reports[1].Hour)
+	assert.Equal(t, "{{.Name}}", reports[1].Template)
+	assert.True(t, sentAt.Equal(reports[1].LastSentAt))
+
+	require.NoError(t, NewRepoGroupRepo(db).Delete(ctx, group.ID))
+	reports, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, reports, 1, "deleting a group deletes its reports")
+
+	require.NoError(t, repo.Delete(ctx, reports[0].ID))
+	assert.ErrorIs(t, repo.Delete(ctx, reports[0].ID), driven.ErrScheduledReportNotFound)
+}
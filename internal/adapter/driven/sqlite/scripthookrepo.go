@@ -0,0 +1,104 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.ScriptHookStore = (*ScriptHookRepo)(nil)
+
+// ScriptHookRepo is the SQLite implementation of the ScriptHookStore port interface.
+type ScriptHookRepo struct {
+	db *DB
+}
+
+// NewScriptHookRepo creates a new ScriptHookRepo backed by the given DB.
+func NewScriptHookRepo(db *DB) *ScriptHookRepo {
+	return &ScriptHookRepo{db: db}
+}
+
+// Create persists a hook and returns the assigned ID.
+func (r *ScriptHookRepo) Create(ctx context.Context, hook model.ScriptHook) (int64, error) {
+	const query = `INSERT INTO script_hooks (name, event, runtime, script, repo_full_name, enabled, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	createdAt := hook.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query,
+		hook.Name, string(hook.Event), string(hook.Runtime), hook.Script, hook.RepoFullName, boolToInt(hook.Enabled), createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create script hook %q: %w", hook.Name, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create script hook %q: last insert id: %w", hook.Name, err)
+	}
+	return id, nil
+}
+
+// SetEnabled turns a hook on or off.
+func (r *ScriptHookRepo) SetEnabled(ctx context.Context, id int64, enabled bool) error {
+	res, err := r.db.Writer.ExecContext(ctx, `UPDATE script_hooks SET enabled = ? WHERE id = ?`, boolToInt(enabled), id)
+	if err != nil {
+		return fmt.Errorf("update script hook %d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("update script hook %d: %w", id, driven.ErrScriptHookNotFound)
+	}
+	return nil
+}
+
+// Delete removes a hook by ID.
+func (r *ScriptHookRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.Writer.ExecContext(ctx, `DELETE FROM script_hooks WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete script hook %d: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("check rows affected: %w", err)
+	} else if n == 0 {
+		return fmt.Errorf("delete script hook %d: %w", id, driven.ErrScriptHookNotFound)
+	}
+	return nil
+}
+
+// List returns all hooks ordered by creation.
+func (r *ScriptHookRepo) List(ctx context.Context) ([]model.ScriptHook, error) {
+	const query = `SELECT id, name, event, runtime, script, repo_full_name, enabled, created_at
+		FROM script_hooks ORDER BY created_at, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list script hooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []model.ScriptHook
+	for rows.Next() {
+		var hook model.ScriptHook
+		var event, runtime, createdAt string
+		if err := rows.Scan(&hook.ID, &hook.Name, &event, &runtime, &hook.Script, &hook.RepoFullName, &hook.Enabled, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan script hook: %w", err)
+		}
+		hook.Event, hook.Runtime = model.HookEvent(event), model.HookRuntime(runtime)
+		if hook.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for script hook %d: %w", hook.ID, err)
+		}
+		hooks = append(hooks, hook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate script hooks: %w", err)
+	}
+	return hooks, nil
+}
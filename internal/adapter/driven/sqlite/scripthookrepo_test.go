@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptHookRepo_CRUD(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewScriptHookRepo(db)
+	ctx := context.Background()
+
+	hooks, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, hooks)
+
+	id, err := repo.Create(ctx, model.ScriptHook{
+		Name:         "Thank approvers",
+		Event:        model.HookEventApproved,
+		Runtime:      model.HookRuntimeStarlark,
+		Script:       `comment("Thanks @" + review["reviewer"])`,
+		RepoFullName: "acme/api",
+		Enabled:      true,
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.ScriptHook{
+		Name: "Ticket", Event: model.HookEventCIFailed, Runtime: model.HookRuntimeShell, Script: "jira-note --failed",
+	})
+	require.NoError(t, err)
+
+	hooks, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, hooks, 2)
+	assert.Equal(t, id, hooks[0].ID)
+	assert.Equal(t, model.HookEventApproved, hooks[0].Event)
+	assert.Equal(t, model.HookRuntimeStarlark, hooks[0].Runtime)
+	assert.Equal(t, `comment("Thanks @" + review["reviewer"])`, hooks[0].Script)
+	assert.Equal(t, "acme/api", hooks[0].RepoFullName)
+	assert.True(t, hooks[0].Enabled)
+	assert.False(t, hooks[0].CreatedAt.IsZero())
+	assert.Equal(t, model.HookRuntimeShell, hooks[1].Runtime)
+	assert.False(t, hooks[1].Enabled)
+
+	require.NoError(t, repo.SetEnabled(ctx, id, false))
+	hooks, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.False(t, hooks[0].Enabled)
+
+	assert.ErrorIs(t, repo.SetEnabled(ctx, 999, true), driven.ErrScriptHookNotFound)
+	require.NoError(t, repo.Delete(ctx, id))
+	assert.ErrorIs(t, repo.Delete(ctx, id), driven.ErrScriptHookNotFound)
+	hooks, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, hooks, 1)
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.SignalWebhookStore = (*SignalWebhookRepo)(nil)
+
+// SignalWebhookRepo is the SQLite implementation of the SignalWebhookStore port interface.
+type SignalWebhookRepo struct {
+	db *DB
+}
+
+// NewSignalWebhookRepo creates a new SignalWebhookRepo backed by the given DB.
+func NewSignalWebhookRepo(db *DB) *SignalWebhookRepo {
+	return &SignalWebhookRepo{db: db}
+}
+
+// Create persists a webhook and returns the assigned ID.
+func (r *SignalWebhookRepo) Create(ctx context.Context, hook model.SignalWebhook) (int64, error) {
+	const query = `INSERT INTO signal_webhooks (signal, url, repo_full_name, created_at) VALUES (?, ?, ?, ?)`
+
+	createdAt := hook.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	result, err := r.db.Writer.ExecContext(ctx, query, string(hook.Signal), hook.URL, hook.RepoFullName, createdAt.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("create signal webhook for %s: %w", hook.Signal, err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("create signal webhook for %s: last insert id: %w", hook.Signal, err)
+	}
+	return id, nil
+}
+
+// List returns all webhooks ordered by signal, then creation.
+func (r *SignalWebhookRepo) List(ctx context.Context) ([]model.SignalWebhook, error) {
+	const query = `SELECT id, signal, url, repo_full_name, created_at
+		FROM signal_webhooks ORDER BY signal, created_at, id`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("list signal webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	var hooks []model.SignalWebhook
+	for rows.Next() {
+		var hook model.SignalWebhook
+		var signal, createdAt string
+		if err := rows.Scan(&hook.ID, &signal, &hook.URL, &hook.RepoFullName, &createdAt); err != nil {
+			return nil, fmt.Errorf("scan signal webhook: %w", err)
+		}
+		hook.Signal = model.AttentionSignal(signal)
+		if hook.CreatedAt, err = parseTime(createdAt); err != nil {
+			return nil, fmt.Errorf("parse created_at for signal webhook %d: %w", hook.ID, err)
+		}
+		hooks = append(hooks, hook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate signal webhooks: %w", err)
+	}
+	return hooks, nil
+}
+
+// Delete removes a webhook by ID.
+func (r *SignalWebhookRepo) Delete(ctx context.Context, id int64) error {
+	const query = `DELETE FROM signal_webhooks WHERE id = ?`
+	if _, err := r.db.Writer.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("delete signal webhook %d: %w", id, err)
+	}
+	return nil
+}
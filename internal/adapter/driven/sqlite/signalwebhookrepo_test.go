@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalWebhookRepo_CreateListDelete(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSignalWebhookRepo(db)
+	ctx := context.Background()
+
+	hooks, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, hooks)
+
+	ciID, err := repo.Create(ctx, model.SignalWebhook{Signal: model.SignalCIFailure, URL: "http://lamp.local/red"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, model.SignalWebhook{Signal: model.SignalAgeUrgent, URL: "https://example.com/hook", RepoFullName: "octocat/hello-world"})
+	require.NoError(t, err)
+
+	hooks, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, hooks, 2)
+	assert.Equal(t, model.SignalAgeUrgent, hooks[0].Signal, "ordered by signal")
+	assert.Equal(t, "octocat/hello-world", hooks[0].RepoFullName)
+	assert.Equal(t, ciID, hooks[1].ID)
+	assert.Equal(t, "http://lamp.local/red", hooks[1].URL)
+	assert.Empty(t, hooks[1].RepoFullName)
+	assert.False(t, hooks[1].CreatedAt.IsZero())
+
+	require.NoError(t, repo.Delete(ctx, ciID))
+	hooks, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, hooks, 1)
+}
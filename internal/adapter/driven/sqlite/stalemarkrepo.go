@@ -0,0 +1,68 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.StaleMarkStore = (*StaleMarkRepo)(nil)
+
+// StaleMarkRepo is the SQLite implementation of the StaleMarkStore port interface.
+type StaleMarkRepo struct {
+	db *DB
+}
+
+// NewStaleMarkRepo creates a new StaleMarkRepo backed by the given DB.
+func NewStaleMarkRepo(db *DB) *StaleMarkRepo {
+	return &StaleMarkRepo{db: db}
+}
+
+// ListStaleMarks returns the latest mark of every marked PR.
+func (r *StaleMarkRepo) ListStaleMarks(ctx context.Context) (map[int64]model.StaleMark, error) {
+	rows, err := r.db.Reader.QueryContext(ctx, `SELECT pr_id, activity_at, marked_at, dry_run FROM stale_marks`)
+	if err != nil {
+		return nil, fmt.Errorf("list stale marks: %w", err)
+	}
+	defer rows.Close()
+
+	marks := make(map[int64]model.StaleMark)
+	for rows.Next() {
+		var m model.StaleMark
+		var activityAt, markedAt string
+		var dryRun int
+		if err := rows.Scan(&m.PRID, &activityAt, &markedAt, &dryRun); err != nil {
+			return nil, fmt.Errorf("scan stale mark: %w", err)
+		}
+		if m.ActivityAt, err = parseTime(activityAt); err != nil {
+			return nil, fmt.Errorf("parse activity_at for stale mark of PR %d: %w", m.PRID, err)
+		}
+		if m.MarkedAt, err = parseTime(markedAt); err != nil {
+			return nil, fmt.Errorf("parse marked_at for stale mark of PR %d: %w", m.PRID, err)
+		}
+		m.DryRun = dryRun != 0
+		marks[m.PRID] = m
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate stale marks: %w", err)
+	}
+	return marks, nil
+}
+
+// RecordStaleMark saves a PR's mark, replacing any earlier one.
+func (r *StaleMarkRepo) RecordStaleMark(ctx context.Context, mark model.StaleMark) error {
+	const query = `
+		INSERT INTO stale_marks (pr_id, activity_at, marked_at, dry_run) VALUES (?, ?, ?, ?)
+		ON CONFLICT(pr_id) DO UPDATE SET
+			activity_at = excluded.activity_at,
+			marked_at = excluded.marked_at,
+			dry_run = excluded.dry_run`
+	_, err := r.db.Writer.ExecContext(ctx, query, mark.PRID, mark.ActivityAt.UTC(), mark.MarkedAt.UTC(), boolToInt(mark.DryRun))
+	if err != nil {
+		return fmt.Errorf("record stale mark of PR %d: %w", mark.PRID, err)
+	}
+	return nil
+}
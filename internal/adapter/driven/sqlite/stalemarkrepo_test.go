@@ -0,0 +1,35 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestStaleMarkRepo_RecordAndList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewStaleMarkRepo(db)
+	ctx := context.Background()
+	prID := addTestPR(t, db, "octocat/hello-world", 1)
+	activity := time.Date(2026, 9, 1, 9, 0, 0, 0, time.UTC)
+	marked := time.Date(2026, 9, 15, 9, 0, 0, 0, time.UTC)
+
+	marks, err := repo.ListStaleMarks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, marks)
+
+	require.NoError(t, repo.RecordStaleMark(ctx, model.StaleMark{PRID: prID, ActivityAt: activity, MarkedAt: marked, DryRun: true}))
+	require.NoError(t, repo.RecordStaleMark(ctx, model.StaleMark{PRID: prID, ActivityAt: marked, MarkedAt: marked.Add(time.Hour)}))
+
+	marks, err = repo.ListStaleMarks(ctx)
+	require.NoError(t, err)
+	require.Len(t, marks, 1)
+	assert.True(t, marks[prID].ActivityAt.Equal(marked), "a new mark replaces the old one")
+	assert.True(t, marks[prID].MarkedAt.Equal(marked.Add(time.Hour)))
+	assert.False(t, marks[prID].DryRun)
+}
@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.TeamStatsStore = (*TeamStatsRepo)(nil)
+
+// TeamStatsRepo is the SQLite implementation of the TeamStatsStore port
+// interface. It aggregates the reviews and review_comments tables.
+type TeamStatsRepo struct {
+	db *DB
+}
+
+// NewTeamStatsRepo creates a new TeamStatsRepo backed by the given DB.
+func NewTeamStatsRepo(db *DB) *TeamStatsRepo {
+	return &TeamStatsRepo{db: db}
+}
+
+// ReviewerStats aggregates review counts, response times, and applied
+// suggestions per reviewer. Logins are matched case-insensitively and
+// reported in lower case. Response times are computed in Go because
+// timestamps are stored in a format SQLite's date functions do not parse.
+func (r *TeamStatsRepo) ReviewerStats(ctx context.Context, since time.Time, repoFullName string) ([]model.ReviewerStats, error) {
+	stats := make(map[string]*model.ReviewerStats)
+	var order []string
+	entry := func(login string) *model.ReviewerStats {
+		s, ok := stats[login]
+		if !ok {
+			s = &model.ReviewerStats{Login: login}
+			stats[login] = s
+			order = append(order, login)
+		}
+		return s
+	}
+
+	// One row per reviewer and PR: reviews in the period, and the reviewer's
+	// first review of the PR ever, which counts toward response time only if
+	// it also falls in the period.
+	const reviewQuery = `
+		SELECT lower(r.reviewer_login), pr.opened_at, MIN(r.submitted_at),
+		       SUM(CASE WHEN r.submitted_at >= ? THEN 1 ELSE 0 END)
+		FROM reviews r
+		INNER JOIN pull_requests pr ON pr.id = r.pr_id
+		WHERE r.is_bot = 0
+		  AND r.state != 'pending'
+		  AND lower(r.reviewer_login) != lower(pr.author)
+		  AND lower(r.reviewer_login) NOT IN (SELECT lower(username) FROM bot_config)
+		  AND pr.repo_full_name IN ` + watchedRepos + `
+		  AND (? = '' OR pr.repo_full_name = ?)
+		GROUP BY lower(r.reviewer_login), pr.id
+		HAVING MAX(r.submitted_at) >= ?`
+
+	sinceUTC := since.UTC()
+	rows, err := r.db.Reader.QueryContext(ctx, reviewQuery, sinceUTC, repoFullName, repoFullName, sinceUTC)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewer stats: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make(map[string]time.Duration)
+	for rows.Next() {
+		var login, openedAt, firstReviewAt string
+		var reviews int
+		if err := rows.Scan(&login, &openedAt, &firstReviewAt, &reviews); err != nil {
+			return nil, fmt.Errorf("scan reviewer stats: %w", err)
+		}
+		s := entry(login)
+		s.ReviewsGiven += reviews
+
+		first, err := parseTime(firstReviewAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse first review time for %s: %w", login, err)
+		}
+		if first.Before(since) {
+			continue
+		}
+		opened, err := parseTime(openedAt)
+		if err != nil {
+			return nil, fmt.Errorf("parse opened_at for %s: %w", login, err)
+		}
+		s.PRsReviewed++
+		totals[login] += max(first.Sub(opened), 0)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reviewer stats: %w", err)
+	}
+
+	const suggestionQuery = `
+		SELECT lower(rc.author), COUNT(*)
+		FROM review_comments rc
+		INNER JOIN pull_requests pr ON pr.id = rc.pr_id
+		WHERE rc.in_reply_to_id IS NULL
+		  AND rc.is_outdated = 1
+		  AND rc.body LIKE '%` + "```suggestion" + `%'
+		  AND rc.created_at >= ?
+		  AND lower(rc.author) != lower(pr.author)
+		  AND lower(rc.author) NOT IN (SELECT lower(username) FROM bot_config)
+		  AND pr.repo_full_name IN ` + watchedRepos + `
+		  AND (? = '' OR pr.repo_full_name = ?)
+		GROUP BY lower(rc.author)`
+
+	suggestionRows, err := r.db.Reader.QueryContext(ctx, suggestionQuery, sinceUTC, repoFullName, repoFullName)
+	if err != nil {
+		return nil, fmt.Errorf("query applied suggestions: %w", err)
+	}
+	defer suggestionRows.Close()
+
+	for suggestionRows.Next() {
+		var login string
+		var count int
+		if err := suggestionRows.Scan(&login, &count); err != nil {
+			return nil, fmt.Errorf("scan applied suggestions: %w", err)
+		}
+		entry(login).SuggestionsApplied = count
+	}
+	if err := suggestionRows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate applied suggestions: %w", err)
+	}
+
+	result := make([]model.ReviewerStats, 0, len(order))
+	for _, key := range order {
+		s := stats[key]
+		if s.PRsReviewed > 0 {
+			s.AvgResponseTime = totals[key] / time.Duration(s.PRsReviewed)
+		}
+		result = append(result, *s)
+	}
+	return result, nil
+}
@@ -0,0 +1,77 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestTeamStatsRepo_ReviewerStats(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	addTestRepo(t, db, testRepoFullName)
+
+	prRepo := NewPRRepo(db)
+	reviewRepo := NewReviewRepo(db)
+	opened := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	prID := func(number int) int64 {
+		pr := makePR(testRepoFullName, number, "PR", model.PRStatusOpen)
+		pr.OpenedAt = opened
+		require.NoError(t, prRepo.Upsert(ctx, pr))
+		stored, err := prRepo.GetByNumber(ctx, testRepoFullName, number)
+		require.NoError(t, err)
+		return stored.ID
+	}
+	first, second, old := prID(1), prID(2), prID(3)
+
+	since := opened
+	reviews := []model.Review{
+		{ID: 1, PRID: first, ReviewerLogin: "alice", State: model.ReviewStateCommented, SubmittedAt: opened.Add(2 * time.Hour)},
+		{ID: 2, PRID: first, ReviewerLogin: "Alice", State: model.ReviewStateApproved, SubmittedAt: opened.Add(5 * time.Hour)},
+		{ID: 3, PRID: second, ReviewerLogin: "alice", State: model.ReviewStateApproved, SubmittedAt: opened.Add(4 * time.Hour)},
+		{ID: 4, PRID: first, ReviewerLogin: "bob", State: model.ReviewStatePending, SubmittedAt: opened.Add(time.Hour)},
+		{ID: 5, PRID: first, ReviewerLogin: "testuser", State: model.ReviewStateCommented, SubmittedAt: opened.Add(time.Hour)},
+		{ID: 6, PRID: first, ReviewerLogin: "coderabbitai", State: model.ReviewStateCommented, SubmittedAt: opened.Add(time.Hour)},
+		{ID: 7, PRID: old, ReviewerLogin: "carol", State: model.ReviewStateApproved, SubmittedAt: opened.Add(-24 * time.Hour)},
+		{ID: 8, PRID: old, ReviewerLogin: "carol", State: model.ReviewStateApproved, SubmittedAt: opened.Add(time.Hour)},
+	}
+	for _, rv := range reviews {
+		require.NoError(t, reviewRepo.UpsertReview(ctx, rv))
+	}
+	comments := []model.ReviewComment{
+		{ID: 10, PRID: first, Author: "alice", Body: "```suggestion\nreturn nil\n```", IsOutdated: true},
+		{ID: 11, PRID: first, Author: "alice", Body: "```suggestion\nreturn err\n```"},
+		{ID: 12, PRID: second, Author: "alice", Body: "Rename this", IsOutdated: true},
+	}
+	for _, c := range comments {
+		c.CreatedAt, c.UpdatedAt = opened.Add(time.Hour), opened.Add(time.Hour)
+		require.NoError(t, reviewRepo.UpsertReviewComment(ctx, c))
+	}
+
+	got, err := NewTeamStatsRepo(db).ReviewerStats(ctx, since, "")
+	require.NoError(t, err)
+
+	byLogin := make(map[string]model.ReviewerStats, len(got))
+	for _, s := range got {
+		byLogin[s.Login] = s
+	}
+	require.Len(t, byLogin, 2, "pending, self, and bot reviews are excluded")
+	assert.Equal(t, model.ReviewerStats{
+		Login:              "alice",
+		ReviewsGiven:       3,
+		PRsReviewed:        2,
+		AvgResponseTime:    3 * time.Hour,
+		SuggestionsApplied: 1,
+	}, byLogin["alice"])
+	assert.Equal(t, model.ReviewerStats{Login: "carol", ReviewsGiven: 1}, byLogin["carol"],
+		"a PR first reviewed before the period adds reviews but no response time")
+
+	other, err := NewTeamStatsRepo(db).ReviewerStats(ctx, since, "other/repo")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
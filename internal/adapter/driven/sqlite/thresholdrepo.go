@@ -27,7 +27,7 @@ func NewThresholdRepo(db *DB) *ThresholdRepo {
 // GetGlobalSettings returns the current global threshold defaults.
 // Falls back to model.DefaultGlobalSettings() for any missing key or if the table is empty.
 func (r *ThresholdRepo) GetGlobalSettings(ctx context.Context) (model.GlobalSettings, error) {
-	const query = `SELECT key, value FROM global_settings WHERE key IN ('review_count_threshold', 'age_urgency_days', 'stale_review_enabled', 'ci_failure_enabled')`
+	const query = `SELECT key, value FROM global_settings WHERE key IN ('review_count_threshold', 'age_urgency_days', 'stale_review_enabled', 'ci_failure_enabled', 'first_timer_boost_enabled', 'checklist_enabled', 'request_age_days')`
 
 	rows, err := r.db.Reader.QueryContext(ctx, query)
 	if err != nil {
@@ -56,6 +56,14 @@ func (r *ThresholdRepo) GetGlobalSettings(ctx context.Context) (model.GlobalSett
 			settings.StaleReviewEnabled = value == "1"
 		case "ci_failure_enabled":
 			settings.CIFailureEnabled = value == "1"
+		case "first_timer_boost_enabled":
+			settings.FirstTimerBoostEnabled = value == "1"
+		case "checklist_enabled":
+			settings.ChecklistEnabled = value == "1"
+		case "request_age_days":
+			if v, err := strconv.Atoi(value); err == nil {
+				settings.RequestAgeDays = v
+			}
 		}
 	}
 	if err := rows.Err(); err != nil {
@@ -84,12 +92,23 @@ func (r *ThresholdRepo) SetGlobalSettings(ctx context.Context, settings model.Gl
 	if settings.CIFailureEnabled {
 		ciVal = "1"
 	}
+	boostVal := "0"
+	if settings.FirstTimerBoostEnabled {
+		boostVal = "1"
+	}
+	checklistVal := "0"
+	if settings.ChecklistEnabled {
+		checklistVal = "1"
+	}
 
 	rows := []struct{ key, value string }{
 		{"review_count_threshold", strconv.Itoa(settings.ReviewCountThreshold)},
 		{"age_urgency_days", strconv.Itoa(settings.AgeUrgencyDays)},
 		{"stale_review_enabled", staleVal},
 		{"ci_failure_enabled", ciVal},
+		{"first_timer_boost_enabled", boostVal},
+		{"checklist_enabled", checklistVal},
+		{"request_age_days", strconv.Itoa(settings.RequestAgeDays)},
 	}
 	for _, row := range rows {
 		if _, err := tx.ExecContext(ctx, upsert, row.key, row.value); err != nil {
@@ -107,13 +126,13 @@ func (r *ThresholdRepo) SetGlobalSettings(ctx context.Context, settings model.Gl
 // Returns a zero-value RepoThreshold (all nil pointers) when no override exists.
 func (r *ThresholdRepo) GetRepoThreshold(ctx context.Context, repoFullName string) (model.RepoThreshold, error) {
 	const query = `
-		SELECT repo_full_name, review_count, age_urgency_days, stale_review_enabled, ci_failure_enabled
+		SELECT repo_full_name, review_count, age_urgency_days, stale_review_enabled, ci_failure_enabled, first_review_sla_hours
 		FROM repo_thresholds
 		WHERE repo_full_name = ?
 	`
 
 	var result model.RepoThreshold
-	var reviewCount, ageUrgencyDays sql.NullInt64
+	var reviewCount, ageUrgencyDays, slaHours sql.NullInt64
 	var staleEnabled, ciEnabled sql.NullInt64
 
 	err := r.db.Reader.QueryRowContext(ctx, query, repoFullName).Scan(
@@ -122,6 +141,7 @@ func (r *ThresholdRepo) GetRepoThreshold(ctx context.Context, repoFullName strin
 		&ageUrgencyDays,
 		&staleEnabled,
 		&ciEnabled,
+		&slaHours,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return model.RepoThreshold{RepoFullName: repoFullName}, nil
@@ -146,18 +166,27 @@ func (r *ThresholdRepo) GetRepoThreshold(ctx context.Context, repoFullName strin
 		v := ciEnabled.Int64 != 0
 		result.CIFailureEnabled = &v
 	}
+	if slaHours.Valid {
+		v := int(slaHours.Int64)
+		result.FirstReviewSLAHours = &v
+	}
 
 	return result, nil
 }
 
 // SetRepoThreshold persists per-repository threshold overrides.
 func (r *ThresholdRepo) SetRepoThreshold(ctx context.Context, threshold model.RepoThreshold) error {
+	return setRepoThreshold(ctx, r.db.Writer, threshold)
+}
+
+// setRepoThreshold upserts threshold through ex.
+func setRepoThreshold(ctx context.Context, ex execer, threshold model.RepoThreshold) error {
 	const query = `
-		INSERT OR REPLACE INTO repo_thresholds (repo_full_name, review_count, age_urgency_days, stale_review_enabled, ci_failure_enabled)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT OR REPLACE INTO repo_thresholds (repo_full_name, review_count, age_urgency_days, stale_review_enabled, ci_failure_enabled, first_review_sla_hours)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
-	var reviewCount, ageUrgencyDays, staleEnabled, ciEnabled interface{}
+	var reviewCount, ageUrgencyDays, staleEnabled, ciEnabled, slaHours interface{}
 	if threshold.ReviewCount != nil {
 		reviewCount = *threshold.ReviewCount
 	}
@@ -178,9 +207,12 @@ func (r *ThresholdRepo) SetRepoThreshold(ctx context.Context, threshold model.Re
 			ciEnabled = 0
 		}
 	}
+	if threshold.FirstReviewSLAHours != nil {
+		slaHours = *threshold.FirstReviewSLAHours
+	}
 
-	_, err := r.db.Writer.ExecContext(ctx, query,
-		threshold.RepoFullName, reviewCount, ageUrgencyDays, staleEnabled, ciEnabled,
+	_, err := ex.ExecContext(ctx, query,
+		threshold.RepoFullName, reviewCount, ageUrgencyDays, staleEnabled, ciEnabled, slaHours,
 	)
 	if err != nil {
 		return fmt.Errorf("set repo threshold %q: %w", threshold.RepoFullName, err)
@@ -191,8 +223,13 @@ func (r *ThresholdRepo) SetRepoThreshold(ctx context.Context, threshold model.Re
 // DeleteRepoThreshold removes the per-repository override for the given repo,
 // causing it to fall back to global settings.
 func (r *ThresholdRepo) DeleteRepoThreshold(ctx context.Context, repoFullName string) error {
+	return deleteRepoThreshold(ctx, r.db.Writer, repoFullName)
+}
+
+// deleteRepoThreshold removes the override of repoFullName through ex.
+func deleteRepoThreshold(ctx context.Context, ex execer, repoFullName string) error {
 	const query = `DELETE FROM repo_thresholds WHERE repo_full_name = ?`
-	_, err := r.db.Writer.ExecContext(ctx, query, repoFullName)
+	_, err := ex.ExecContext(ctx, query, repoFullName)
 	if err != nil {
 		return fmt.Errorf("delete repo threshold %q: %w", repoFullName, err)
 	}
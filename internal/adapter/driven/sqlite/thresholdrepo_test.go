@@ -31,10 +31,13 @@ func TestThresholdRepo_SetAndGetGlobalSettings(t *testing.T) {
 	ctx := context.Background()
 
 	want := model.GlobalSettings{
-		ReviewCountThreshold: 3,
-		AgeUrgencyDays:       14,
-		StaleReviewEnabled:   false,
-		CIFailureEnabled:     true,
+		ReviewCountThreshold:   3,
+		AgeUrgencyDays:         14,
+		StaleReviewEnabled:     false,
+		CIFailureEnabled:       true,
+		FirstTimerBoostEnabled: true,
+		ChecklistEnabled:       true,
+		RequestAgeDays:         5,
 	}
 
 	err := repo.SetGlobalSettings(ctx, want)
@@ -46,6 +49,9 @@ func TestThresholdRepo_SetAndGetGlobalSettings(t *testing.T) {
 	assert.Equal(t, want.AgeUrgencyDays, got.AgeUrgencyDays)
 	assert.Equal(t, want.StaleReviewEnabled, got.StaleReviewEnabled)
 	assert.Equal(t, want.CIFailureEnabled, got.CIFailureEnabled)
+	assert.Equal(t, want.RequestAgeDays, got.RequestAgeDays)
+	assert.Equal(t, want.FirstTimerBoostEnabled, got.FirstTimerBoostEnabled)
+	assert.Equal(t, want.ChecklistEnabled, got.ChecklistEnabled)
 }
 
 func TestThresholdRepo_GetRepoThreshold_NotFound(t *testing.T) {
@@ -73,13 +79,15 @@ func TestThresholdRepo_SetAndGetRepoThreshold(t *testing.T) {
 	ageUrgency := 5
 	staleEnabled := false
 	ciEnabled := true
+	slaHours := 24
 
 	want := model.RepoThreshold{
-		RepoFullName:       testRepoFullName,
-		ReviewCount:        &reviewCount,
-		AgeUrgencyDays:     &ageUrgency,
-		StaleReviewEnabled: &staleEnabled,
-		CIFailureEnabled:   &ciEnabled,
+		RepoFullName:        testRepoFullName,
+		ReviewCount:         &reviewCount,
+		AgeUrgencyDays:      &ageUrgency,
+		StaleReviewEnabled:  &staleEnabled,
+		CIFailureEnabled:    &ciEnabled,
+		FirstReviewSLAHours: &slaHours,
 	}
 
 	err := repo.SetRepoThreshold(ctx, want)
@@ -91,10 +99,12 @@ func TestThresholdRepo_SetAndGetRepoThreshold(t *testing.T) {
 	require.NotNil(t, got.AgeUrgencyDays)
 	require.NotNil(t, got.StaleReviewEnabled)
 	require.NotNil(t, got.CIFailureEnabled)
+	require.NotNil(t, got.FirstReviewSLAHours)
 	assert.Equal(t, reviewCount, *got.ReviewCount)
 	assert.Equal(t, ageUrgency, *got.AgeUrgencyDays)
 	assert.Equal(t, staleEnabled, *got.StaleReviewEnabled)
 	assert.Equal(t, ciEnabled, *got.CIFailureEnabled)
+	assert.Equal(t, slaHours, *got.FirstReviewSLAHours)
 }
 
 func TestThresholdRepo_SetRepoThreshold_NilFields(t *testing.T) {
@@ -0,0 +1,79 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.WatchStore = (*WatchRepo)(nil)
+
+// WatchRepo is the SQLite implementation of the WatchStore port interface.
+type WatchRepo struct {
+	db *DB
+}
+
+// NewWatchRepo creates a new WatchRepo backed by the given DB.
+func NewWatchRepo(db *DB) *WatchRepo {
+	return &WatchRepo{db: db}
+}
+
+// SetWatchState records the watch state of a PR, replacing any earlier one.
+// WatchStateDefault deletes the override.
+func (r *WatchRepo) SetWatchState(ctx context.Context, prID int64, state model.WatchState) error {
+	if state == model.WatchStateDefault {
+		if _, err := r.db.Writer.ExecContext(ctx, `DELETE FROM pr_watches WHERE pr_id = ?`, prID); err != nil {
+			return fmt.Errorf("clear watch state of PR %d: %w", prID, err)
+		}
+		return nil
+	}
+
+	const query = `
+		INSERT INTO pr_watches (pr_id, state) VALUES (?, ?)
+		ON CONFLICT(pr_id) DO UPDATE SET state = excluded.state, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.Writer.ExecContext(ctx, query, prID, string(state)); err != nil {
+		return fmt.Errorf("set watch state of PR %d: %w", prID, err)
+	}
+	return nil
+}
+
+// GetWatchState returns the watch state of a PR, WatchStateDefault when none is set.
+func (r *WatchRepo) GetWatchState(ctx context.Context, prID int64) (model.WatchState, error) {
+	var state string
+	err := r.db.Reader.QueryRowContext(ctx, `SELECT state FROM pr_watches WHERE pr_id = ?`, prID).Scan(&state)
+	if errors.Is(err, sql.ErrNoRows) {
+		return model.WatchStateDefault, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get watch state of PR %d: %w", prID, err)
+	}
+	return model.WatchState(state), nil
+}
+
+// ListWatchStates returns the watch state of every PR that has one.
+func (r *WatchRepo) ListWatchStates(ctx context.Context) (map[int64]model.WatchState, error) {
+	rows, err := r.db.Reader.QueryContext(ctx, `SELECT pr_id, state FROM pr_watches`)
+	if err != nil {
+		return nil, fmt.Errorf("list watch states: %w", err)
+	}
+	defer rows.Close()
+
+	states := make(map[int64]model.WatchState)
+	for rows.Next() {
+		var prID int64
+		var state string
+		if err := rows.Scan(&prID, &state); err != nil {
+			return nil, fmt.Errorf("scan watch state: %w", err)
+		}
+		states[prID] = model.WatchState(state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate watch states: %w", err)
+	}
+	return states, nil
+}
@@ -0,0 +1,41 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestWatchRepo_SetAndList(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewWatchRepo(db)
+	ctx := context.Background()
+	first := addTestPR(t, db, "octocat/hello-world", 1)
+	second := addTestPR(t, db, "octocat/other", 2)
+
+	state, err := repo.GetWatchState(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, model.WatchStateDefault, state)
+
+	require.NoError(t, repo.SetWatchState(ctx, first, model.WatchStateWatching))
+	require.NoError(t, repo.SetWatchState(ctx, second, model.WatchStateWatching))
+	require.NoError(t, repo.SetWatchState(ctx, second, model.WatchStateUnwatched))
+
+	state, err = repo.GetWatchState(ctx, second)
+	require.NoError(t, err)
+	assert.Equal(t, model.WatchStateUnwatched, state, "a new state replaces the old one")
+
+	states, err := repo.ListWatchStates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]model.WatchState{first: model.WatchStateWatching, second: model.WatchStateUnwatched}, states)
+
+	require.NoError(t, repo.SetWatchState(ctx, first, model.WatchStateDefault))
+	require.NoError(t, repo.SetWatchState(ctx, first, model.WatchStateDefault), "clearing twice is a no-op")
+	states, err = repo.ListWatchStates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]model.WatchState{second: model.WatchStateUnwatched}, states)
+}
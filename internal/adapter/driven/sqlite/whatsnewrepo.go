@@ -0,0 +1,82 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction check.
+var _ driven.WhatsNewStore = (*WhatsNewRepo)(nil)
+
+// global_settings keys holding the what's-new acknowledgement.
+const (
+	whatsNewReleaseKey = "whats_new_release"
+	whatsNewSchemaKey  = "whats_new_schema_version"
+)
+
+// WhatsNewRepo is the SQLite implementation of the WhatsNewStore port
+// interface. It keeps its two values in the global_settings key/value table.
+type WhatsNewRepo struct {
+	db *DB
+}
+
+// NewWhatsNewRepo creates a new WhatsNewRepo backed by the given DB.
+func NewWhatsNewRepo(db *DB) *WhatsNewRepo {
+	return &WhatsNewRepo{db: db}
+}
+
+// GetSeen returns the last acknowledged release and schema version. ok is
+// false until SetSeen has been called once.
+func (r *WhatsNewRepo) GetSeen(ctx context.Context) (model.WhatsNewSeen, bool, error) {
+	const query = `SELECT key, value FROM global_settings WHERE key IN (?, ?)`
+
+	rows, err := r.db.Reader.QueryContext(ctx, query, whatsNewReleaseKey, whatsNewSchemaKey)
+	if err != nil {
+		return model.WhatsNewSeen{}, false, fmt.Errorf("query what's new state: %w", err)
+	}
+	defer rows.Close()
+
+	var seen model.WhatsNewSeen
+	found := false
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return model.WhatsNewSeen{}, false, fmt.Errorf("scan what's new state: %w", err)
+		}
+		found = true
+		switch key {
+		case whatsNewReleaseKey:
+			seen.ReleaseID = value
+		case whatsNewSchemaKey:
+			v, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return model.WhatsNewSeen{}, false, fmt.Errorf("parse %s %q: %w", key, value, err)
+			}
+			seen.SchemaVersion = uint(v)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return model.WhatsNewSeen{}, false, fmt.Errorf("iterate what's new state: %w", err)
+	}
+	return seen, found, nil
+}
+
+// SetSeen records the acknowledged release and schema version.
+func (r *WhatsNewRepo) SetSeen(ctx context.Context, seen model.WhatsNewSeen) error {
+	const upsert = `
+		INSERT INTO global_settings (key, value) VALUES (?, ?), (?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value`
+
+	_, err := r.db.Writer.ExecContext(ctx, upsert,
+		whatsNewReleaseKey, seen.ReleaseID,
+		whatsNewSchemaKey, strconv.FormatUint(uint64(seen.SchemaVersion), 10),
+	)
+	if err != nil {
+		return fmt.Errorf("save what's new state: %w", err)
+	}
+	return nil
+}
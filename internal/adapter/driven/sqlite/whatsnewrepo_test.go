@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestWhatsNewRepo_GetSetSeen(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewWhatsNewRepo(db)
+	ctx := context.Background()
+
+	_, ok, err := repo.GetSeen(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok, "nothing recorded on a new database")
+
+	require.NoError(t, repo.SetSeen(ctx, model.WhatsNewSeen{ReleaseID: "2026-10-16", SchemaVersion: 19}))
+	require.NoError(t, repo.SetSeen(ctx, model.WhatsNewSeen{ReleaseID: "2026-11-02", SchemaVersion: 21}))
+
+	seen, ok, err := repo.GetSeen(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, model.WhatsNewSeen{ReleaseID: "2026-11-02", SchemaVersion: 21}, seen)
+
+	// Threshold settings share the table and must be unaffected.
+	settings, err := NewThresholdRepo(db).GetGlobalSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, model.DefaultGlobalSettings(), settings)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := EmbeddedMigrations()
+	require.NoError(t, err)
+
+	state, err := GetMigrationState(setupTestDB(t).Writer)
+	require.NoError(t, err)
+
+	require.Len(t, migrations, int(state.Latest))
+	assert.Equal(t, model.SchemaMigration{Version: 1, Description: "initial schema"}, migrations[0])
+	for i, m := range migrations {
+		assert.Equal(t, uint(i+1), m.Version, "migrations are listed in version order")
+	}
+}
@@ -0,0 +1,218 @@
+// Package webhook implements the WebhookSender, RuleNotifier, EventWebhookSender,
+// and ReportSender ports by POSTing JSON over net/http.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Compile-time interface satisfaction checks.
+var (
+	_ driven.WebhookSender      = (*Sender)(nil)
+	_ driven.RuleNotifier       = (*Sender)(nil)
+	_ driven.EventWebhookSender = (*Sender)(nil)
+	_ driven.ReportSender       = (*Sender)(nil)
+)
+
+// userAgent identifies webhook requests to receivers.
+const userAgent = "mygitpanel-webhook"
+
+// Sender implements the driven.WebhookSender port.
+type Sender struct {
+	httpClient *http.Client
+}
+
+// NewSender creates a Sender whose requests time out after timeout.
+func NewSender(timeout time.Duration) *Sender {
+	return &Sender{httpClient: &http.Client{Timeout: timeout}}
+}
+
+// signalFiredPayload is the JSON body posted for a fired attention signal.
+type signalFiredPayload struct {
+	Event   string           `json:"event"`
+	Signal  string           `json:"signal"`
+	FiredAt string           `json:"fired_at"`
+	PR      pullRequestBody  `json:"pull_request"`
+	Signals attentionSignals `json:"signals"`
+}
+
+// ruleMatchedPayload is the JSON body posted when an attention rule starts
+// matching a PR. Text makes it a valid Slack incoming webhook message.
+type ruleMatchedPayload struct {
+	Event     string          `json:"event"`
+	Text      string          `json:"text"`
+	Rule      ruleBody        `json:"rule"`
+	MatchedAt string          `json:"matched_at"`
+	PR        pullRequestBody `json:"pull_request"`
+}
+
+// ruleBody is the rule context included in rule payloads.
+type ruleBody struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Severity   int    `json:"severity"`
+}
+
+// pullRequestBody is the PR context included in webhook payloads.
+type pullRequestBody struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Author     string `json:"author"`
+	URL        string `json:"url"`
+	Branch     string `json:"branch"`
+	BaseBranch string `json:"base_branch"`
+	IsDraft    bool   `json:"is_draft"`
+	CIStatus   string `json:"ci_status"`
+}
+
+// attentionSignals mirrors model.AttentionSignals in the payload.
+type attentionSignals struct {
+	NeedsMoreReviews    bool `json:"needs_more_reviews"`
+	AgeUrgent           bool `json:"age_urgent"`
+	StaleReview         bool `json:"stale_review"`
+	CIFailure           bool `json:"ci_failure"`
+	ApprovalDismissed   bool `json:"approval_dismissed"`
+	Mentioned           bool `json:"mentioned"`
+	SLABreached         bool `json:"sla_breached"`
+	NewcomerWaiting     bool `json:"newcomer_waiting"`
+	ChecklistIncomplete bool `json:"checklist_incomplete"`
+	RequestAging        bool `json:"request_aging"`
+}
+
+// SendSignalFired posts event to url as JSON. Non-2xx responses are errors.
+func (s *Sender) SendSignalFired(ctx context.Context, url string, event model.SignalFired) error {
+	payload := signalFiredPayload{
+		Event:   "signal.fired",
+		Signal:  string(event.Signal),
+		FiredAt: event.FiredAt.UTC().Format(time.RFC3339),
+		PR:      toPullRequestBody(event.PullRequest),
+		Signals: attentionSignals{
+			NeedsMoreReviews:    event.Signals.NeedsMoreReviews,
+			AgeUrgent:           event.Signals.IsAgeUrgent,
+			StaleReview:         event.Signals.HasStaleReview,
+			CIFailure:           event.Signals.HasCIFailure,
+			ApprovalDismissed:   event.Signals.ApprovalDismissed,
+			Mentioned:           event.Signals.Mentioned,
+			SLABreached:         event.Signals.SLABreached,
+			NewcomerWaiting:     event.Signals.NewcomerWaiting,
+			ChecklistIncomplete: event.Signals.ChecklistIncomplete,
+			RequestAging:        event.Signals.RequestAging,
+		},
+	}
+	return s.post(ctx, url, payload)
+}
+
+// SendRuleMatched posts match to url as JSON. Non-2xx responses are errors.
+func (s *Sender) SendRuleMatched(ctx context.Context, url string, match model.RuleMatched) error {
+	pr := match.PullRequest
+	text := fmt.Sprintf("%s: %s#%d %s", match.Rule.Name, pr.RepoFullName, pr.Number, pr.Title)
+	if pr.URL != "" {
+		text += " " + pr.URL
+	}
+	payload := ruleMatchedPayload{
+		Event: "rule.matched",
+		Text:  text,
+		Rule: ruleBody{
+			ID:         match.Rule.ID,
+			Name:       match.Rule.Name,
+			Expression: match.Rule.Expression,
+			Severity:   match.Rule.Severity,
+		},
+		MatchedAt: match.MatchedAt.UTC().Format(time.RFC3339),
+		PR:        toPullRequestBody(pr),
+	}
+	return s.post(ctx, url, payload)
+}
+
+// toPullRequestBody converts a PR to its payload representation.
+func toPullRequestBody(pr model.PullRequest) pullRequestBody {
+	return pullRequestBody{
+		Repository: pr.RepoFullName,
+		Number:     pr.Number,
+		Title:      pr.Title,
+		Author:     pr.Author,
+		URL:        pr.URL,
+		Branch:     pr.Branch,
+		BaseBranch: pr.BaseBranch,
+		IsDraft:    pr.IsDraft,
+		CIStatus:   string(pr.CIStatus),
+	}
+}
+
+// SendEvent posts delivery's stored payload to url. The body is signed with
+// HMAC-SHA256 under secret, sent as "sha256=<hex>" in X-Mygitpanel-Signature-256
+// like GitHub's own webhooks. It returns the response status, 0 when no
+// response arrived. Non-2xx responses are errors.
+func (s *Sender) SendEvent(ctx context.Context, url, secret string, delivery model.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return s.send(ctx, url, body, map[string]string{
+		"X-Mygitpanel-Event":         string(delivery.Event),
+		"X-Mygitpanel-Delivery":      fmt.Sprintf("%d", delivery.ID),
+		"X-Mygitpanel-Signature-256": "sha256=" + hex.EncodeToString(mac.Sum(nil)),
+	})
+}
+
+// reportPayload is the JSON body posted for a scheduled report, a Slack
+// incoming webhook message.
+type reportPayload struct {
+	Text string `json:"text"`
+}
+
+// SendReport posts a scheduled report's text to url as a Slack message.
+// Non-2xx responses are errors.
+func (s *Sender) SendReport(ctx context.Context, url, text string) error {
+	return s.post(ctx, url, reportPayload{Text: text})
+}
+
+// post sends payload to url as JSON. Non-2xx responses are errors.
+func (s *Sender) post(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook: encoding payload: %w", err)
+	}
+	_, err = s.send(ctx, url, body, nil)
+	return err
+}
+
+// send POSTs a JSON body to url with the extra headers and returns the
+// response status. Non-2xx responses are errors.
+func (s *Sender) send(ctx context.Context, url string, body []byte, headers map[string]string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("webhook: building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook: unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestSender_SendSignalFired(t *testing.T) {
+	var got map[string]any
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	event := model.SignalFired{
+		Signal:      model.SignalCIFailure,
+		PullRequest: model.PullRequest{RepoFullName: "octocat/hello-world", Number: 7, Title: "Fix it", CIStatus: model.CIStatusFailing},
+		Signals:     model.AttentionSignals{HasCIFailure: true},
+		FiredAt:     time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, NewSender(time.Second).SendSignalFired(context.Background(), srv.URL, event))
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "signal.fired", got["event"])
+	assert.Equal(t, "ci_failure", got["signal"])
+	assert.Equal(t, "2026-03-01T12:00:00Z", got["fired_at"])
+	pr := got["pull_request"].(map[string]any)
+	assert.Equal(t, "octocat/hello-world", pr["repository"])
+	assert.EqualValues(t, 7, pr["number"])
+	assert.Equal(t, "failing", pr["ci_status"])
+	assert.Equal(t, true, got["signals"].(map[string]any)["ci_failure"])
+}
+
+func TestSender_SendRuleMatched(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	match := model.RuleMatched{
+		Rule:        model.AttentionRule{ID: 3, Name: "My red builds", Expression: "ci_failure", Severity: 5},
+		PullRequest: model.PullRequest{RepoFullName: "octocat/hello-world", Number: 7, Title: "Fix it", URL: "https://github.com/octocat/hello-world/pull/7"},
+		MatchedAt:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, NewSender(time.Second).SendRuleMatched(context.Background(), srv.URL, match))
+
+	assert.Equal(t, "rule.matched", got["event"])
+	assert.Equal(t, "My red builds: octocat/hello-world#7 Fix it https://github.com/octocat/hello-world/pull/7", got["text"])
+	assert.Equal(t, "2026-03-01T12:00:00Z", got["matched_at"])
+	rule := got["rule"].(map[string]any)
+	assert.EqualValues(t, 5, rule["severity"])
+	assert.Equal(t, "ci_failure", rule["expression"])
+	assert.EqualValues(t, 7, got["pull_request"].(map[string]any)["number"])
+}
+
+func TestSender_SendReport(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	require.NoError(t, NewSender(time.Second).SendReport(context.Background(), srv.URL, "Weekly report\n- one"))
+
+	assert.Equal(t, map[string]any{"text": "Weekly report\n- one"}, got)
+}
+
+func TestSender_NonSuccessStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := NewSender(time.Second).SendSignalFired(context.Background(), srv.URL, model.SignalFired{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
+
+func TestSender_SendEvent(t *testing.T) {
+	var body []byte
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	delivery := model.WebhookDelivery{ID: 42, Event: model.WebhookCheckFailed, Payload: `{"event":"check.failed"}`}
+	status, err := NewSender(time.Second).SendEvent(context.Background(), srv.URL, "s3cret", delivery)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, `{"event":"check.failed"}`, string(body), "the stored payload is sent verbatim")
+	assert.Equal(t, "check.failed", header.Get("X-Mygitpanel-Event"))
+	assert.Equal(t, "42", header.Get("X-Mygitpanel-Delivery"))
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get("X-Mygitpanel-Signature-256"))
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	status, err = NewSender(time.Second).SendEvent(context.Background(), srv.URL, "s3cret", delivery)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusGone, status)
+}
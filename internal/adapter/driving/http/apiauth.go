@@ -0,0 +1,92 @@
+package httphandler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// WithAPITokens enables API token authentication on /api/v1. Once at least
+// one token exists, every API request must present one, and read-scoped
+// tokens are limited to safe methods. Without it the API is unauthenticated.
+func (h *Handler) WithAPITokens(svc *application.APITokenService) *Handler {
+	h.apiTokens = svc
+	return h
+}
+
+// requireAPIToken is the /api/v1 authentication middleware. It passes requests
+// through while API tokens are not configured or none have been created.
+func (h *Handler) requireAPIToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.apiTokens == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		enforced, err := h.apiTokens.Enforced(r.Context())
+		if err != nil {
+			h.logger.Error("failed to check API token configuration", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		if !enforced {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		secret, ok := bearerToken(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mygitpanel"`)
+			writeError(w, http.StatusUnauthorized, "missing API token")
+			return
+		}
+
+		token, err := h.apiTokens.Authenticate(r.Context(), secret)
+		if errors.Is(err, application.ErrInvalidAPIToken) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mygitpanel", error="invalid_token"`)
+			writeError(w, http.StatusUnauthorized, "invalid API token")
+			return
+		}
+		if err != nil {
+			h.logger.Error("failed to authenticate API token", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// The MCP endpoint takes every call as a POST, so it checks the
+		// scope per tool instead.
+		if token.Scope != model.APITokenScopeWrite && !isSafeMethod(r.Method) && r.URL.Path != mcpPath {
+			writeError(w, http.StatusForbidden, "API token is read-only")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiTokenScopeKey{}, token.Scope)))
+	})
+}
+
+// apiTokenScopeKey is the context key for the scope of the request's API token.
+type apiTokenScopeKey struct{}
+
+// canWrite reports whether the request's API token, if any, may change state.
+func canWrite(ctx context.Context) bool {
+	scope, ok := ctx.Value(apiTokenScopeKey{}).(model.APITokenScope)
+	return !ok || scope == model.APITokenScopeWrite
+}
+
+// bearerToken extracts the credential from an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// isSafeMethod reports whether method only reads state.
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
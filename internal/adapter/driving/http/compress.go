@@ -0,0 +1,183 @@
+package httphandler
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+)
+
+// compressMinBytes is the smallest declared Content-Length worth compressing;
+// below it the encoding overhead outweighs the savings.
+const compressMinBytes = 1024
+
+// compressibleTypes are the media types (or type prefixes ending in "/")
+// that compress well. Images other than SVG, fonts, and archives are
+// already compressed.
+var compressibleTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/manifest+json",
+	"image/svg+xml",
+}
+
+// compressionMiddleware compresses text responses with brotli or gzip,
+// whichever the client prefers (brotli on a tie). Responses that already
+// carry a Content-Encoding, are too small, or are not text pass through,
+// as do HEAD requests and WebSocket upgrades.
+func compressionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks "br" or "gzip" from an Accept-Encoding header by
+// q-value, or returns "" when the client accepts neither.
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "br" && name != "gzip" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ || (q == bestQ && name == "br") {
+			best, bestQ = name, q
+		}
+	}
+	if bestQ <= 0 {
+		return ""
+	}
+	return best
+}
+
+// compressible reports whether contentType is one of compressibleTypes.
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range compressibleTypes {
+		if mediaType == t || (strings.HasSuffix(t, "/") && strings.HasPrefix(mediaType, t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter decides whether to compress when the status is written,
+// from the response headers set by then.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	decided  bool
+	enc      io.WriteCloser // nil when the response is sent uncompressed
+}
+
+// WriteHeader picks the encoding for final statuses and delegates.
+func (cw *compressWriter) WriteHeader(status int) {
+	if status >= http.StatusOK && !cw.decided {
+		cw.decide(status)
+	}
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+// Write sniffs a missing Content-Type, as net/http would, before deciding.
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		if cw.Header().Get("Content-Type") == "" {
+			cw.Header().Set("Content-Type", http.DetectContentType(p))
+		}
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.enc != nil {
+		return cw.enc.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// decide sets up compression unless the response should pass through.
+func (cw *compressWriter) decide(status int) {
+	cw.decided = true
+
+	h := cw.Header()
+	if status == http.StatusNoContent || status == http.StatusNotModified ||
+		h.Get("Content-Encoding") != "" || !compressible(h.Get("Content-Type")) {
+		return
+	}
+	if n, err := strconv.Atoi(h.Get("Content-Length")); err == nil && n < compressMinBytes {
+		return
+	}
+
+	h.Del("Content-Length")
+	h.Set("Content-Encoding", cw.encoding)
+	// A strong ETag names the uncompressed bytes.
+	if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		h.Set("ETag", "W/"+etag)
+	}
+
+	switch cw.encoding {
+	case "br":
+		cw.enc = brotli.NewWriterLevel(cw.ResponseWriter, brotli.DefaultCompression)
+	default:
+		cw.enc = gzip.NewWriter(cw.ResponseWriter)
+	}
+}
+
+// Flush sends any compressed bytes buffered so far to the client.
+func (cw *compressWriter) Flush() {
+	if f, ok := cw.enc.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	if f, ok := cw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack hands the connection to WebSocket handlers uncompressed.
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	cw.decided = true
+	return hj.Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// close finishes the compressed stream, if any.
+func (cw *compressWriter) close() {
+	if cw.enc != nil {
+		_ = cw.enc.Close()
+	}
+}
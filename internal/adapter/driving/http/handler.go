@@ -25,8 +25,36 @@ type Handler struct {
 	reviewSvc      *application.ReviewService
 	healthSvc      *application.HealthService
 	pollSvc        *application.PollService
+	db             DBPinger                          // optional; readiness reports the database as unknown when nil
+	refreshToken   string                            // optional; the CI refresh endpoint is disabled when empty
+	apiTokens      *application.APITokenService      // optional; /api/v1 is unauthenticated when nil
+	eventHub       *application.EventHub             // optional; the event stream returns 503 when nil
+	slaSvc         *application.SLAService           // optional; the SLA breach list returns 503 when nil
+	noteStore      driven.PRNoteStore                // optional; note export and import return 503 when nil
+	commentSearch  driven.CommentSearchStore         // optional; comment search returns 503 when nil
+	groupStore     driven.RepoGroupStore             // optional; the group endpoints return 503 when nil
+	teamStatsSvc   *application.TeamStatsService     // optional; the reviewer leaderboard returns 503 when nil
+	lintSvc        *application.LintService          // optional; the lint endpoints return 503 when nil
+	embedSigner    *application.EmbedSigner          // optional; the embed link endpoint returns 503 when nil
+	repoImportSvc  *application.RepoImportService    // optional; the bulk repo import returns 503 when nil
+	basePath       string                            // optional; prefixes links to the GUI, empty at the root
+	repoTrashSvc   *application.RepoTrashService     // optional; repo removal deletes immediately when nil
+	orphanSweeper  driven.OrphanSweeper              // optional; the orphan sweep returns 503 when nil
+	ruleSvc        *application.AttentionRuleService // optional; the attention rule endpoints return 503 when nil
+	hookSvc        *application.ScriptHookService    // optional; the script hook endpoints return 503 when nil
+	eventHookSvc   *application.EventWebhookService  // optional; the event webhook endpoints return 503 when nil
+	journal        driven.PRJournalStore             // optional; the event journal returns 503 when nil
+	reviewRollups  *application.ReviewRollupService  // optional; bot changes leave stored review rollups as they are when nil
+	systemSvc      *application.SystemService        // optional; the system stats endpoint returns 503 when nil
+	ignoreStore    driven.IgnoreStore                // optional; the ignore endpoints return 503 when nil
+	ignoreRuleSvc  *application.IgnoreRuleService    // optional; the ignore rule endpoints return 503 when nil
+	repoSettings   driven.RepoSettingsStore          // optional; the repo settings endpoints return 503 when nil
+	thresholdStore driven.ThresholdStore             // optional; the threshold and repo settings endpoints return 503 when nil
 	username       string
 	logger         *slog.Logger
+
+	// mcpWriterFor is optional; the MCP post_comment tool only returns drafts when nil.
+	mcpWriterFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)
 }
 
 // NewHandler creates a Handler with all required dependencies.
@@ -54,29 +82,92 @@ func NewHandler(
 
 // RegisterAPIRoutes registers all JSON API routes on the provided mux.
 func RegisterAPIRoutes(mux *http.ServeMux, h *Handler) {
-	mux.HandleFunc("GET /api/v1/prs", h.ListPRs)
-	mux.HandleFunc("GET /api/v1/prs/attention", h.ListPRsNeedingAttention)
-	mux.HandleFunc("GET /api/v1/repos/{owner}/{repo}/prs/{number}", h.GetPR)
-	mux.HandleFunc("GET /api/v1/repos", h.ListRepos)
-	mux.HandleFunc("POST /api/v1/repos", h.AddRepo)
-	mux.HandleFunc("DELETE /api/v1/repos/{owner}/{repo}", h.RemoveRepo)
-	mux.HandleFunc("GET /api/v1/health", h.Health)
-	mux.HandleFunc("GET /api/v1/bots", h.ListBots)
-	mux.HandleFunc("POST /api/v1/bots", h.AddBot)
-	mux.HandleFunc("DELETE /api/v1/bots/{username}", h.RemoveBot)
+	api := http.NewServeMux()
+	api.HandleFunc("GET /api/v1/prs", h.ListPRs)
+	api.HandleFunc("GET /api/v1/prs/attention", h.ListPRsNeedingAttention)
+	api.HandleFunc("GET /api/v1/prs/sla-breaches", h.ListSLABreaches)
+	api.HandleFunc("GET /api/v1/prs/lint-violations", h.ListLintViolations)
+	api.HandleFunc("GET /api/v1/prs/ignored", h.ListIgnoredPRs)
+	api.HandleFunc("GET /api/v1/repos/{owner}/{repo}/prs/{number}", h.GetPR)
+	api.HandleFunc("PUT /api/v1/repos/{owner}/{repo}/prs/{number}/ignore", h.IgnorePR)
+	api.HandleFunc("DELETE /api/v1/repos/{owner}/{repo}/prs/{number}/ignore", h.UnignorePR)
+	api.HandleFunc("GET /api/v1/ignore/rules", h.ListIgnoreRules)
+	api.HandleFunc("POST /api/v1/ignore/rules", h.CreateIgnoreRule)
+	api.HandleFunc("PUT /api/v1/ignore/rules/{id}", h.UpdateIgnoreRule)
+	api.HandleFunc("DELETE /api/v1/ignore/rules/{id}", h.DeleteIgnoreRule)
+	api.HandleFunc("GET /api/v1/repos", h.ListRepos)
+	api.HandleFunc("POST /api/v1/repos", h.AddRepo)
+	api.HandleFunc("POST /api/v1/repos/import", h.ImportRepos)
+	api.HandleFunc("DELETE /api/v1/repos/{owner}/{repo}", h.RemoveRepo)
+	api.HandleFunc("GET /api/v1/repos/removed", h.ListRemovedRepos)
+	api.HandleFunc("POST /api/v1/repos/{owner}/{repo}/restore", h.RestoreRepo)
+	api.HandleFunc("GET /api/v1/repos/{owner}/{repo}/settings", h.GetRepoSettings)
+	api.HandleFunc("PUT /api/v1/repos/{owner}/{repo}/settings", h.ReplaceRepoSettings)
+	api.HandleFunc("DELETE /api/v1/repos/{owner}/{repo}/settings", h.ResetRepoSettings)
+	api.HandleFunc("GET /api/v1/settings/thresholds", h.GetGlobalThresholds)
+	api.HandleFunc("PUT /api/v1/settings/thresholds", h.ReplaceGlobalThresholds)
+	api.HandleFunc("DELETE /api/v1/settings/thresholds", h.ResetGlobalThresholds)
+	api.HandleFunc("GET /api/v1/settings", h.ExportSettings)
+	api.HandleFunc("POST /api/v1/settings", h.ImportSettings)
+	api.HandleFunc("GET /api/v1/repos/{owner}/{repo}/lint-rules", h.ListLintRules)
+	api.HandleFunc("PUT /api/v1/repos/{owner}/{repo}/lint-rules", h.ReplaceLintRules)
+	api.HandleFunc("GET /api/v1/repos/{owner}/{repo}/embed", h.GetEmbedLink)
+	api.HandleFunc("GET /api/v1/attention/rules", h.ListAttentionRules)
+	api.HandleFunc("POST /api/v1/attention/rules", h.CreateAttentionRule)
+	api.HandleFunc("POST /api/v1/attention/rules/validate", h.ValidateAttentionRule)
+	api.HandleFunc("PUT /api/v1/attention/rules/{id}", h.UpdateAttentionRule)
+	api.HandleFunc("DELETE /api/v1/attention/rules/{id}", h.DeleteAttentionRule)
+	api.HandleFunc("GET /api/v1/hooks", h.ListScriptHooks)
+	api.HandleFunc("POST /api/v1/hooks", h.CreateScriptHook)
+	api.HandleFunc("PUT /api/v1/hooks/{id}/enabled", h.SetScriptHookEnabled)
+	api.HandleFunc("DELETE /api/v1/hooks/{id}", h.DeleteScriptHook)
+	api.HandleFunc("GET /api/v1/webhooks", h.ListEventWebhooks)
+	api.HandleFunc("POST /api/v1/webhooks", h.CreateEventWebhook)
+	api.HandleFunc("PUT /api/v1/webhooks/{id}/enabled", h.SetEventWebhookEnabled)
+	api.HandleFunc("DELETE /api/v1/webhooks/{id}", h.DeleteEventWebhook)
+	api.HandleFunc("GET /api/v1/webhooks/{id}/deliveries", h.ListWebhookDeliveries)
+	api.HandleFunc("POST "+mcpPath, h.MCP)
+	api.HandleFunc("GET /api/v1/bots", h.ListBots)
+	api.HandleFunc("POST /api/v1/bots", h.AddBot)
+	api.HandleFunc("PUT /api/v1/bots/{username}", h.UpdateBot)
+	api.HandleFunc("DELETE /api/v1/bots/{username}", h.RemoveBot)
+	api.HandleFunc("GET /api/v1/events", h.StreamEvents)
+	api.HandleFunc("GET /api/v1/journal", h.ListJournal)
+	api.HandleFunc("GET /api/v1/groups", h.ListRepoGroups)
+	api.HandleFunc("POST /api/v1/groups", h.CreateRepoGroup)
+	api.HandleFunc("PUT /api/v1/groups/{id}", h.UpdateRepoGroup)
+	api.HandleFunc("DELETE /api/v1/groups/{id}", h.DeleteRepoGroup)
+	api.HandleFunc("GET /api/v1/comments/search", h.SearchComments)
+	api.HandleFunc("GET /api/v1/notes", h.ExportNotes)
+	api.HandleFunc("POST /api/v1/notes", h.ImportNotes)
+	api.HandleFunc("GET /api/v1/stats/reviewers", h.ListReviewerStats)
+	api.HandleFunc("POST /api/v1/admin/orphans/sweep", h.SweepOrphans)
+	mux.Handle("/api/v1/", h.requireAPIToken(api))
+
+	// The refresh endpoint checks its own credentials; probes stay unauthenticated.
+	mux.HandleFunc("POST /api/v1/repos/{owner}/{repo}/refresh", h.TriggerRepoRefresh)
+	mux.HandleFunc("GET /healthz", h.Liveness)
+	mux.HandleFunc("GET /readyz", h.Readiness)
+	// Kept for existing clients; equivalent to /healthz.
+	mux.HandleFunc("GET /api/v1/health", h.Liveness)
+	// Read by the healthcheck binary, which has no API token; like /readyz
+	// it reports poll state without PR contents.
+	mux.HandleFunc("GET /api/v1/system", h.GetSystem)
 }
 
-// ApplyMiddleware wraps an http.Handler with logging and recovery middleware.
+// ApplyMiddleware wraps an http.Handler with logging, response compression,
+// and recovery middleware.
 func ApplyMiddleware(handler http.Handler, logger *slog.Logger) http.Handler {
 	// Recovery innermost so panics are caught before logging.
 	wrapped := recoveryMiddleware(logger, handler)
+	wrapped = compressionMiddleware(wrapped)
 	wrapped = loggingMiddleware(logger, wrapped)
 
 	return wrapped
 }
 
 // NewServeMux creates an http.Handler with all routes registered and wrapped
-// with logging and recovery middleware. This is a convenience function that
+// with the middleware of ApplyMiddleware. This is a convenience function that
 // combines RegisterAPIRoutes and ApplyMiddleware.
 func NewServeMux(h *Handler, logger *slog.Logger) http.Handler {
 	mux := http.NewServeMux()
@@ -85,8 +176,23 @@ func NewServeMux(h *Handler, logger *slog.Logger) http.Handler {
 	return ApplyMiddleware(mux, logger)
 }
 
-// ListPRs returns all tracked pull requests.
+// ListPRs returns all tracked pull requests, optionally filtered by
+// ?status= (open, merged, closed) and ?review_status= (approved,
+// changes_requested, commented, pending). Review status filters on the
+// rollup stored with each PR, so "approved but unmerged" is
+// ?status=open&review_status=approved.
 func (h *Handler) ListPRs(w http.ResponseWriter, r *http.Request) {
+	status := model.PRStatus(r.URL.Query().Get("status"))
+	if status != "" && !status.IsValid() {
+		writeError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+	reviewStatus := model.ReviewState(r.URL.Query().Get("review_status"))
+	if reviewStatus != "" && !model.IsRollupStatus(reviewStatus) {
+		writeError(w, http.StatusBadRequest, "invalid review_status")
+		return
+	}
+
 	prs, err := h.prStore.ListAll(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list PRs", "error", err)
@@ -96,6 +202,9 @@ func (h *Handler) ListPRs(w http.ResponseWriter, r *http.Request) {
 
 	resp := make([]PRResponse, 0, len(prs))
 	for _, pr := range prs {
+		if (status != "" && pr.Status != status) || (reviewStatus != "" && pr.ReviewRollup.Status != reviewStatus) {
+			continue
+		}
 		resp = append(resp, toPRResponse(pr))
 	}
 
@@ -130,11 +239,18 @@ func (h *Handler) GetPR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := toPRResponse(*pr)
+	writeJSON(w, http.StatusOK, h.prDetail(r.Context(), *pr))
+}
+
+// prDetail converts pr to its JSON representation enriched with reviews,
+// lint violations, and check runs from whichever services are available.
+// Enrichment failures are logged and leave the corresponding fields empty.
+func (h *Handler) prDetail(ctx context.Context, pr model.PullRequest) PRResponse {
+	resp := toPRResponse(pr)
 
 	// Enrich with review data if ReviewService is available.
 	if h.reviewSvc != nil {
-		summary, err := h.reviewSvc.GetPRReviewSummary(r.Context(), pr.ID, pr.HeadSHA)
+		summary, err := h.reviewSvc.GetPRReviewSummary(ctx, pr.ID, pr.HeadSHA)
 		if err != nil {
 			h.logger.Error("failed to get review summary", "error", err)
 			// Fall through with basic response -- review enrichment failure is not fatal.
@@ -145,9 +261,19 @@ func (h *Handler) GetPR(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Enrich with lint violations if LintService is available.
+	if h.lintSvc != nil {
+		byPR, err := h.lintSvc.ViolationsByPR(ctx, pr.RepoFullName)
+		if err != nil {
+			h.logger.Error("failed to get lint violations", "error", err)
+			// Fall through -- lint enrichment failure is not fatal.
+		}
+		resp.LintViolations = toLintViolationResponses(pr, byPR[pr.ID])
+	}
+
 	// Enrich with health data if HealthService is available.
 	if h.healthSvc != nil {
-		healthSummary, err := h.healthSvc.GetPRHealthSummary(r.Context(), pr.ID, pr.RepoFullName, pr.Number)
+		healthSummary, err := h.healthSvc.GetPRHealthSummary(ctx, pr.ID, pr.RepoFullName, pr.Number)
 		if err != nil {
 			h.logger.Error("failed to get health summary", "error", err)
 			// Fall through -- health enrichment failure is not fatal.
@@ -162,17 +288,15 @@ func (h *Handler) GetPR(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	return resp
 }
 
 // enrichPRResponse populates the enriched review fields on a PRResponse from
 // a PRReviewSummary. It is separated from GetPR for testability and clarity.
 func (h *Handler) enrichPRResponse(resp *PRResponse, summary *application.PRReviewSummary, headSHA string) {
-	botUsernames := summary.BotUsernames
-
 	resp.Reviews = make([]ReviewResponse, 0, len(summary.Reviews))
 	for _, rev := range summary.Reviews {
-		resp.Reviews = append(resp.Reviews, toReviewResponse(rev, headSHA, botUsernames))
+		resp.Reviews = append(resp.Reviews, toReviewResponse(rev, headSHA))
 	}
 
 	resp.Threads = make([]ReviewThreadResponse, 0, len(summary.Threads))
@@ -182,7 +306,7 @@ func (h *Handler) enrichPRResponse(resp *PRResponse, summary *application.PRRevi
 
 	resp.IssueComments = make([]IssueCommentResponse, 0, len(summary.IssueComments))
 	for _, ic := range summary.IssueComments {
-		resp.IssueComments = append(resp.IssueComments, toIssueCommentResponse(ic))
+		resp.IssueComments = append(resp.IssueComments, toIssueCommentResponse(ic, summary.IssueCommentRepeats))
 	}
 
 	resp.Suggestions = make([]SuggestionResponse, 0, len(summary.Suggestions))
@@ -244,6 +368,14 @@ func (h *Handler) AddRepo(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "invalid repository name: expected owner/repo format")
 		return
 	}
+	provider := model.ProviderGitHub
+	if req.Provider != "" {
+		provider = model.Provider(req.Provider)
+	}
+	if !provider.Valid() {
+		writeError(w, http.StatusBadRequest, "invalid provider: expected github or bitbucket")
+		return
+	}
 
 	parts := strings.SplitN(req.FullName, "/", 2)
 	repo := model.Repository{
@@ -251,6 +383,7 @@ func (h *Handler) AddRepo(w http.ResponseWriter, r *http.Request) {
 		Owner:    parts[0],
 		Name:     parts[1],
 		AddedAt:  time.Now().UTC(),
+		Provider: provider,
 	}
 
 	if err := h.repoStore.Add(r.Context(), repo); err != nil {
@@ -276,13 +409,19 @@ func (h *Handler) AddRepo(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, toRepoResponse(repo))
 }
 
-// RemoveRepo removes a repository from the watch list.
+// RemoveRepo removes a repository from the watch list. With a
+// RepoTrashService its history is kept until purged, so the removal can be
+// undone with RestoreRepo.
 func (h *Handler) RemoveRepo(w http.ResponseWriter, r *http.Request) {
 	owner := r.PathValue("owner")
 	repo := r.PathValue("repo")
 	fullName := owner + "/" + repo
 
-	if err := h.repoStore.Remove(r.Context(), fullName); err != nil {
+	remove := h.repoStore.Remove
+	if h.repoTrashSvc != nil {
+		remove = h.repoTrashSvc.Remove
+	}
+	if err := remove(r.Context(), fullName); err != nil {
 		if errors.Is(err, driven.ErrRepoNotFound) {
 			writeError(w, http.StatusNotFound, "repository not found")
 			return
@@ -294,11 +433,3 @@ func (h *Handler) RemoveRepo(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
-
-// Health returns a simple health check response.
-func (h *Handler) Health(w http.ResponseWriter, _ *http.Request) {
-	writeJSON(w, http.StatusOK, HealthResponse{
-		Status: "ok",
-		Time:   time.Now().UTC().Format(time.RFC3339),
-	})
-}
@@ -1,16 +1,25 @@
 package httphandler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/ericfisherdev/mygitpanel/internal/application"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
+// WithReviewRollups recomputes the review rollups stored on each PR when the
+// bot list changes, since bot reviews do not count towards them.
+func (h *Handler) WithReviewRollups(svc *application.ReviewRollupService) *Handler {
+	h.reviewRollups = svc
+	return h
+}
+
 // ListBots returns all configured bot usernames.
 func (h *Handler) ListBots(w http.ResponseWriter, r *http.Request) {
 	bots, err := h.botConfigStore.ListAll(r.Context())
@@ -43,8 +52,9 @@ func (h *Handler) AddBot(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bot := model.BotConfig{
-		Username: username,
-		AddedAt:  time.Now().UTC(),
+		Username:        username,
+		AddedAt:         time.Now().UTC(),
+		NitpickPatterns: application.NormalizeNitpickPatterns(req.NitpickPatterns),
 	}
 
 	saved, err := h.botConfigStore.Add(r.Context(), bot)
@@ -58,9 +68,35 @@ func (h *Handler) AddBot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.refreshReviewRollups(r.Context())
 	writeJSON(w, http.StatusCreated, toBotConfigResponse(saved))
 }
 
+// UpdateBot replaces the nitpick patterns of a configured bot. Patterns are
+// matched case-insensitively on top of the built-in nitpick markers.
+func (h *Handler) UpdateBot(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+
+	var req UpdateBotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	patterns := application.NormalizeNitpickPatterns(req.NitpickPatterns)
+	if err := h.botConfigStore.SetNitpickPatterns(r.Context(), username, patterns); err != nil {
+		if errors.Is(err, driven.ErrBotNotFound) {
+			writeError(w, http.StatusNotFound, "bot not found")
+			return
+		}
+		h.logger.Error("failed to update bot", "username", username, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // RemoveBot removes a bot username from the configuration.
 func (h *Handler) RemoveBot(w http.ResponseWriter, r *http.Request) {
 	username := r.PathValue("username")
@@ -75,5 +111,20 @@ func (h *Handler) RemoveBot(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.refreshReviewRollups(r.Context())
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// refreshReviewRollups recomputes every stored review rollup in the
+// background after a bot list change, so the response does not wait on it.
+func (h *Handler) refreshReviewRollups(ctx context.Context) {
+	if h.reviewRollups == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := h.reviewRollups.RecordAll(ctx); err != nil {
+			h.logger.Error("failed to refresh review rollups", "error", err)
+		}
+	}()
+}
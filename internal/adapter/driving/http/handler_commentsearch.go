@@ -0,0 +1,111 @@
+package httphandler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+const (
+	// commentSearchDateLayout is the format of the since and until parameters.
+	commentSearchDateLayout = "2006-01-02"
+	// maxCommentSearchLimit bounds the limit parameter of a comment search.
+	maxCommentSearchLimit = 200
+)
+
+// CommentSearchResultResponse is the JSON representation of one comment
+// found by a comment search.
+type CommentSearchResultResponse struct {
+	Kind       string `json:"kind"`
+	CommentID  int64  `json:"comment_id"`
+	Repository string `json:"repository"`
+	PRNumber   int    `json:"pr_number"`
+	PRTitle    string `json:"pr_title"`
+	Author     string `json:"author"`
+	Body       string `json:"body"`
+	Snippet    string `json:"snippet,omitempty"`
+	Path       string `json:"path,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// WithCommentSearchStore enables GET /api/v1/comments/search. Without it the
+// endpoint returns 503.
+func (h *Handler) WithCommentSearchStore(store driven.CommentSearchStore) *Handler {
+	h.commentSearch = store
+	return h
+}
+
+// SearchComments returns stored review and issue comments, newest first,
+// filtered by ?q= (words the body must contain), ?author=, ?repo=owner/name,
+// and the inclusive dates ?since= and ?until= (YYYY-MM-DD). ?limit= caps the
+// results, up to 200.
+func (h *Handler) SearchComments(w http.ResponseWriter, r *http.Request) {
+	if h.commentSearch == nil {
+		writeError(w, http.StatusServiceUnavailable, "comment search not configured")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := model.CommentSearchFilter{
+		Text:         strings.TrimSpace(q.Get("q")),
+		Author:       strings.TrimSpace(q.Get("author")),
+		RepoFullName: q.Get("repo"),
+	}
+	if filter.RepoFullName != "" && !validate.IsValidRepoName(filter.RepoFullName) {
+		writeError(w, http.StatusBadRequest, "invalid repository name")
+		return
+	}
+	if v := q.Get("since"); v != "" {
+		since, err := time.Parse(commentSearchDateLayout, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "since must be a YYYY-MM-DD date")
+			return
+		}
+		filter.After = since
+	}
+	if v := q.Get("until"); v != "" {
+		until, err := time.Parse(commentSearchDateLayout, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "until must be a YYYY-MM-DD date")
+			return
+		}
+		filter.Before = until.AddDate(0, 0, 1)
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxCommentSearchLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 200")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	results, err := h.commentSearch.SearchComments(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to search comments", "query", filter.Text, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]CommentSearchResultResponse, 0, len(results))
+	for _, c := range results {
+		resp = append(resp, CommentSearchResultResponse{
+			Kind:       string(c.Kind),
+			CommentID:  c.CommentID,
+			Repository: c.RepoFullName,
+			PRNumber:   c.PRNumber,
+			PRTitle:    c.PRTitle,
+			Author:     c.Author,
+			Body:       c.Body,
+			Snippet:    c.Snippet,
+			Path:       c.Path,
+			CreatedAt:  c.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,63 @@
+package httphandler
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// EmbedLinkResponse is the JSON representation of a repository's embed link.
+type EmbedLinkResponse struct {
+	Path    string   `json:"path"` // relative to the host, base path and token included
+	Token   string   `json:"token"`
+	Columns []string `json:"columns"`
+}
+
+// WithEmbedSigner enables GET /api/v1/repos/{owner}/{repo}/embed. Without it,
+// which is the case when no secret key is set, the endpoint returns 503.
+func (h *Handler) WithEmbedSigner(signer *application.EmbedSigner) *Handler {
+	h.embedSigner = signer
+	return h
+}
+
+// WithBasePath sets the URL prefix the GUI is served under behind a reverse
+// proxy, so links to it, such as embed paths, include it.
+func (h *Handler) WithBasePath(prefix string) *Handler {
+	h.basePath = prefix
+	return h
+}
+
+// GetEmbedLink returns the path of the repository's embeddable PR list,
+// signed with its embed token. ?columns= picks the optional columns as a
+// comma-separated list; the defaults are used when it is empty.
+func (h *Handler) GetEmbedLink(w http.ResponseWriter, r *http.Request) {
+	if h.embedSigner == nil {
+		writeError(w, http.StatusServiceUnavailable, "embedding requires MYGITPANEL_SECRET_KEY")
+		return
+	}
+
+	fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	repo, err := h.repoStore.GetByFullName(r.Context(), fullName)
+	if err != nil {
+		h.logger.Error("failed to get repo", "repo", fullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if repo == nil {
+		writeError(w, http.StatusNotFound, "repository not found")
+		return
+	}
+
+	token := h.embedSigner.Token(repo.FullName)
+	resp := EmbedLinkResponse{Token: token}
+	for _, c := range model.ParseEmbedColumns(r.URL.Query().Get("columns")) {
+		resp.Columns = append(resp.Columns, string(c))
+	}
+	query := url.Values{"token": {token}, "columns": {strings.Join(resp.Columns, ",")}}
+	resp.Path = h.basePath + "/embed/" + repo.FullName + "?" + query.Encode()
+
+	writeJSON(w, http.StatusOK, resp)
+}
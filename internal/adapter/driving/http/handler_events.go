@@ -0,0 +1,231 @@
+package httphandler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// eventWriteTimeout bounds each event write so a stuck client cannot pin a
+// subscription open indefinitely.
+const eventWriteTimeout = 10 * time.Second
+
+// EventResponse is the JSON representation of a PR event sent over the
+// WebSocket stream. The payload field matching Type is set; attention.changed
+// events also include the PR and the previous signals.
+type EventResponse struct {
+	Type       string `json:"type"`
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	OccurredAt string `json:"occurred_at"`
+
+	PR        *PRResponse           `json:"pr,omitempty"`
+	Review    *ReviewResponse       `json:"review,omitempty"`
+	CheckRun  *CheckRunResponse     `json:"check_run,omitempty"`
+	Comment   *IssueCommentResponse `json:"comment,omitempty"`
+	Attention *AttentionResponse    `json:"attention,omitempty"`
+
+	PreviousAttention *AttentionResponse `json:"previous_attention,omitempty"`
+}
+
+// AttentionResponse is the JSON representation of a PR's attention signals.
+type AttentionResponse struct {
+	NeedsMoreReviews    bool                `json:"needs_more_reviews"`
+	IsAgeUrgent         bool                `json:"is_age_urgent"`
+	HasStaleReview      bool                `json:"has_stale_review"`
+	HasCIFailure        bool                `json:"has_ci_failure"`
+	ApprovalDismissed   bool                `json:"approval_dismissed"`
+	Mentioned           bool                `json:"mentioned"`
+	SLABreached         bool                `json:"sla_breached"`
+	NewcomerWaiting     bool                `json:"newcomer_waiting"`
+	ChecklistIncomplete bool                `json:"checklist_incomplete"`
+	RequestAging        bool                `json:"request_aging"`
+	Rules               []RuleMatchResponse `json:"rules,omitempty"`
+	Severity            int                 `json:"severity"`
+}
+
+// WithEventHub enables the WebSocket event stream at /api/v1/events.
+// Without it the endpoint returns 503.
+func (h *Handler) WithEventHub(hub *application.EventHub) *Handler {
+	h.eventHub = hub
+	return h
+}
+
+// StreamEvents handles GET /api/v1/events?repo=owner/name&type=pr.updated.
+// It upgrades to a WebSocket and streams PR events as JSON text messages.
+// repo and type may be repeated or comma-separated; omitting them subscribes
+// to everything. The stream is server-to-client; client messages are ignored.
+func (h *Handler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	if h.eventHub == nil {
+		writeError(w, http.StatusServiceUnavailable, "event stream not configured")
+		return
+	}
+
+	filter, err := parseEventFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	server := websocket.Server{
+		Handshake: checkEventOrigin,
+		Handler:   func(ws *websocket.Conn) { h.streamEvents(ws, filter) },
+	}
+	server.ServeHTTP(w, r)
+}
+
+// streamEvents forwards hub events matching filter to ws until either side closes.
+func (h *Handler) streamEvents(ws *websocket.Conn, filter application.EventFilter) {
+	defer ws.Close()
+
+	// The server's read/write timeouts are meant for request/response
+	// traffic; a stream stays open until the client leaves.
+	_ = ws.SetDeadline(time.Time{})
+
+	events, cancel := h.eventHub.Subscribe(filter)
+	defer cancel()
+
+	// Reading is the only way to notice the client going away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var discard string
+		for websocket.Message.Receive(ws, &discard) == nil {
+		}
+	}()
+
+	ctx := ws.Request().Context()
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			_ = ws.SetWriteDeadline(time.Now().Add(eventWriteTimeout))
+			if err := websocket.JSON.Send(ws, toEventResponse(e)); err != nil {
+				h.logger.Debug("event stream write failed", "error", err)
+				return
+			}
+		case <-closed:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// parseEventFilter reads the repo and type query parameters.
+func parseEventFilter(query url.Values) (application.EventFilter, error) {
+	var filter application.EventFilter
+	for _, repo := range splitQueryList(query["repo"]) {
+		if !validate.IsValidRepoName(repo) {
+			return filter, fmt.Errorf("invalid repository name %q", repo)
+		}
+		filter.Repos = append(filter.Repos, repo)
+	}
+	for _, raw := range splitQueryList(query["type"]) {
+		t := model.PREventType(raw)
+		if !t.IsValid() {
+			return filter, fmt.Errorf("unknown event type %q", raw)
+		}
+		filter.Types = append(filter.Types, t)
+	}
+	return filter, nil
+}
+
+// splitQueryList flattens repeated and comma-separated query values.
+func splitQueryList(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// checkEventOrigin rejects cross-site browser connections. Non-browser
+// clients send no Origin header and are allowed; browsers must be same-origin.
+func checkEventOrigin(cfg *websocket.Config, r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || !strings.EqualFold(u.Host, r.Host) {
+		return fmt.Errorf("cross-origin WebSocket connection from %q rejected", origin)
+	}
+	cfg.Origin = u
+	return nil
+}
+
+// toEventResponse converts a domain PREvent to its JSON representation.
+func toEventResponse(e model.PREvent) EventResponse {
+	resp := EventResponse{
+		Type:       string(e.Type),
+		Repository: e.RepoFullName,
+		Number:     e.PRNumber,
+		OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339),
+	}
+
+	if e.PullRequest != nil {
+		pr := toPRResponse(*e.PullRequest)
+		resp.PR = &pr
+	}
+	if e.Review != nil {
+		review := ReviewResponse{
+			ID:            e.Review.ID,
+			ReviewerLogin: e.Review.ReviewerLogin,
+			State:         string(e.Review.State),
+			Body:          e.Review.Body,
+			CommitID:      e.Review.CommitID,
+			SubmittedAt:   e.Review.SubmittedAt.UTC().Format(time.RFC3339),
+			IsBot:         e.Review.IsBot,
+		}
+		resp.Review = &review
+	}
+	if e.CheckRun != nil {
+		run := toCheckRunResponse(*e.CheckRun)
+		resp.CheckRun = &run
+	}
+	if e.Comment != nil {
+		comment := toIssueCommentResponse(*e.Comment, nil)
+		resp.Comment = &comment
+	}
+	if e.Attention != nil {
+		resp.Attention = toAttentionResponse(*e.Attention)
+	}
+	if e.PreviousAttention != nil {
+		resp.PreviousAttention = toAttentionResponse(*e.PreviousAttention)
+	}
+
+	return resp
+}
+
+// toAttentionResponse converts domain attention signals to their JSON representation.
+func toAttentionResponse(s model.AttentionSignals) *AttentionResponse {
+	return &AttentionResponse{
+		NeedsMoreReviews:    s.NeedsMoreReviews,
+		IsAgeUrgent:         s.IsAgeUrgent,
+		HasStaleReview:      s.HasStaleReview,
+		HasCIFailure:        s.HasCIFailure,
+		ApprovalDismissed:   s.ApprovalDismissed,
+		Mentioned:           s.Mentioned,
+		SLABreached:         s.SLABreached,
+		NewcomerWaiting:     s.NewcomerWaiting,
+		ChecklistIncomplete: s.ChecklistIncomplete,
+		RequestAging:        s.RequestAging,
+		Rules:               toRuleMatchResponses(s.Rules),
+		Severity:            s.Severity(),
+	}
+}
@@ -0,0 +1,237 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// EventWebhookResponse is the JSON representation of an event webhook. Secret
+// is only set in the response to a create.
+type EventWebhookResponse struct {
+	ID           int64    `json:"id"`
+	URL          string   `json:"url"`
+	Secret       string   `json:"secret,omitempty"`
+	Events       []string `json:"events"`
+	RepoFullName string   `json:"repo_full_name"`
+	Enabled      bool     `json:"enabled"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+// EventWebhookRequest is the body of an event webhook create. No events
+// subscribes to all of them; Enabled defaults to true.
+type EventWebhookRequest struct {
+	URL          string   `json:"url"`
+	Events       []string `json:"events"`
+	RepoFullName string   `json:"repo_full_name"`
+	Enabled      *bool    `json:"enabled"`
+}
+
+// WebhookDeliveryResponse is the JSON representation of a webhook delivery.
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	Event          string          `json:"event"`
+	RepoFullName   string          `json:"repo_full_name"`
+	PRNumber       int             `json:"pr_number"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  string          `json:"next_attempt_at,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+	DeliveredAt    string          `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// WithEventWebhookService enables the event webhook endpoints. Without it they
+// return 503.
+func (h *Handler) WithEventWebhookService(svc *application.EventWebhookService) *Handler {
+	h.eventHookSvc = svc
+	return h
+}
+
+// ListEventWebhooks handles GET /api/v1/webhooks.
+func (h *Handler) ListEventWebhooks(w http.ResponseWriter, r *http.Request) {
+	if h.eventHookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "event webhooks not configured")
+		return
+	}
+
+	hooks, err := h.eventHookSvc.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list event webhooks", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	resp := make([]EventWebhookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		hook.Secret = ""
+		resp = append(resp, toEventWebhookResponse(hook))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateEventWebhook handles POST /api/v1/webhooks. The response carries the
+// signing secret, which is not shown again.
+func (h *Handler) CreateEventWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.eventHookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "event webhooks not configured")
+		return
+	}
+
+	var req EventWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	events := make([]model.WebhookEventType, 0, len(req.Events))
+	for _, e := range req.Events {
+		events = append(events, model.WebhookEventType(e))
+	}
+	saved, err := h.eventHookSvc.Create(r.Context(), model.EventWebhook{
+		URL:          req.URL,
+		Events:       events,
+		RepoFullName: req.RepoFullName,
+		Enabled:      req.Enabled == nil || *req.Enabled,
+	})
+	switch {
+	case errors.Is(err, application.ErrInvalidEventWebhook):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create event webhook", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toEventWebhookResponse(saved))
+}
+
+// SetEventWebhookEnabled handles PUT /api/v1/webhooks/{id}/enabled.
+func (h *Handler) SetEventWebhookEnabled(w http.ResponseWriter, r *http.Request) {
+	if h.eventHookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "event webhooks not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+	var req SetHookEnabledRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	err = h.eventHookSvc.SetEnabled(r.Context(), id, req.Enabled)
+	switch {
+	case errors.Is(err, driven.ErrEventWebhookNotFound):
+		writeError(w, http.StatusNotFound, "webhook not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to update event webhook", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteEventWebhook handles DELETE /api/v1/webhooks/{id}.
+func (h *Handler) DeleteEventWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.eventHookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "event webhooks not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+	err = h.eventHookSvc.Delete(r.Context(), id)
+	switch {
+	case errors.Is(err, driven.ErrEventWebhookNotFound):
+		writeError(w, http.StatusNotFound, "webhook not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to delete event webhook", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries handles GET /api/v1/webhooks/{id}/deliveries.
+func (h *Handler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	if h.eventHookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "event webhooks not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid webhook ID")
+		return
+	}
+	deliveries, err := h.eventHookSvc.Deliveries(r.Context(), id)
+	switch {
+	case errors.Is(err, driven.ErrEventWebhookNotFound):
+		writeError(w, http.StatusNotFound, "webhook not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to list webhook deliveries", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	resp := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		resp = append(resp, toWebhookDeliveryResponse(d))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// toEventWebhookResponse converts a webhook to its JSON representation.
+func toEventWebhookResponse(hook model.EventWebhook) EventWebhookResponse {
+	events := make([]string, 0, len(hook.Events))
+	for _, e := range hook.Events {
+		events = append(events, string(e))
+	}
+	return EventWebhookResponse{
+		ID:           hook.ID,
+		URL:          hook.URL,
+		Secret:       hook.Secret,
+		Events:       events,
+		RepoFullName: hook.RepoFullName,
+		Enabled:      hook.Enabled,
+		CreatedAt:    hook.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// toWebhookDeliveryResponse converts a delivery to its JSON representation.
+func toWebhookDeliveryResponse(d model.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             d.ID,
+		Event:          string(d.Event),
+		RepoFullName:   d.RepoFullName,
+		PRNumber:       d.PRNumber,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.UTC().Format(time.RFC3339),
+		Payload:        json.RawMessage(d.Payload),
+	}
+	if d.Status == model.WebhookDeliveryPending {
+		resp.NextAttemptAt = d.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	if !d.DeliveredAt.IsZero() {
+		resp.DeliveredAt = d.DeliveredAt.UTC().Format(time.RFC3339)
+	}
+	return resp
+}
@@ -0,0 +1,192 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// maxRepoGroupNameLen bounds group names, which label the sidebar switcher.
+const maxRepoGroupNameLen = 50
+
+// RepoGroupResponse is the JSON representation of a repository group.
+type RepoGroupResponse struct {
+	ID        int64    `json:"id"`
+	Name      string   `json:"name"`
+	Repos     []string `json:"repos"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// RepoGroupRequest is the JSON body for creating or replacing a group.
+type RepoGroupRequest struct {
+	Name  string   `json:"name"`
+	Repos []string `json:"repos"`
+}
+
+// WithRepoGroupStore enables the /api/v1/groups endpoints. Without it they
+// return 503.
+func (h *Handler) WithRepoGroupStore(store driven.RepoGroupStore) *Handler {
+	h.groupStore = store
+	return h
+}
+
+// ListRepoGroups returns all repository groups ordered by name.
+func (h *Handler) ListRepoGroups(w http.ResponseWriter, r *http.Request) {
+	if h.groupStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo groups not configured")
+		return
+	}
+
+	groups, err := h.groupStore.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list repo groups", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]RepoGroupResponse, 0, len(groups))
+	for _, g := range groups {
+		resp = append(resp, toRepoGroupResponse(g))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateRepoGroup creates a group from a name and a list of repositories.
+func (h *Handler) CreateRepoGroup(w http.ResponseWriter, r *http.Request) {
+	if h.groupStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo groups not configured")
+		return
+	}
+
+	group, ok := decodeRepoGroupRequest(w, r)
+	if !ok {
+		return
+	}
+
+	saved, err := h.groupStore.Create(r.Context(), group)
+	if errors.Is(err, driven.ErrRepoGroupExists) {
+		writeError(w, http.StatusConflict, "a group with this name already exists")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to create repo group", "name", group.Name, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toRepoGroupResponse(saved))
+}
+
+// UpdateRepoGroup renames a group and replaces its repositories.
+func (h *Handler) UpdateRepoGroup(w http.ResponseWriter, r *http.Request) {
+	if h.groupStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo groups not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid group ID")
+		return
+	}
+
+	group, ok := decodeRepoGroupRequest(w, r)
+	if !ok {
+		return
+	}
+	group.ID = id
+
+	err = h.groupStore.Update(r.Context(), group)
+	switch {
+	case errors.Is(err, driven.ErrRepoGroupNotFound):
+		writeError(w, http.StatusNotFound, "group not found")
+		return
+	case errors.Is(err, driven.ErrRepoGroupExists):
+		writeError(w, http.StatusConflict, "a group with this name already exists")
+		return
+	case err != nil:
+		h.logger.Error("failed to update repo group", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	saved, err := h.groupStore.Get(r.Context(), id)
+	if err != nil || saved == nil {
+		h.logger.Error("failed to reload repo group", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toRepoGroupResponse(*saved))
+}
+
+// DeleteRepoGroup removes a group. Its repositories stay watched.
+func (h *Handler) DeleteRepoGroup(w http.ResponseWriter, r *http.Request) {
+	if h.groupStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo groups not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid group ID")
+		return
+	}
+
+	if err := h.groupStore.Delete(r.Context(), id); err != nil {
+		if errors.Is(err, driven.ErrRepoGroupNotFound) {
+			writeError(w, http.StatusNotFound, "group not found")
+			return
+		}
+		h.logger.Error("failed to delete repo group", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeRepoGroupRequest parses and validates a group request body. It writes
+// an error response and returns false when the body is invalid.
+func decodeRepoGroupRequest(w http.ResponseWriter, r *http.Request) (model.RepoGroup, bool) {
+	var req RepoGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return model.RepoGroup{}, false
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxRepoGroupNameLen {
+		writeError(w, http.StatusBadRequest, "name is required and must be at most 50 characters")
+		return model.RepoGroup{}, false
+	}
+	for _, repo := range req.Repos {
+		if !validate.IsValidRepoName(repo) {
+			writeError(w, http.StatusBadRequest, "invalid repository name: "+repo)
+			return model.RepoGroup{}, false
+		}
+	}
+
+	return model.RepoGroup{Name: name, Repos: req.Repos}, true
+}
+
+// toRepoGroupResponse converts a domain RepoGroup to its JSON representation.
+func toRepoGroupResponse(g model.RepoGroup) RepoGroupResponse {
+	repos := g.Repos
+	if repos == nil {
+		repos = []string{}
+	}
+	return RepoGroupResponse{
+		ID:        g.ID,
+		Name:      g.Name,
+		Repos:     repos,
+		CreatedAt: g.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,260 @@
+package httphandler
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+const (
+	// pollStallThreshold is how long the poll loop may go without progress
+	// before liveness fails. Ticks happen every minute and each repo poll
+	// records progress, so a quarter hour of silence means the loop is stuck.
+	pollStallThreshold = 15 * time.Minute
+
+	// repoPollStaleAfter is how long a repo may go without a successful poll
+	// before readiness fails. Twice the slowest adaptive tier interval.
+	repoPollStaleAfter = time.Hour
+
+	// rateLimitLowWatermark matches the GitHub adapter's low rate limit warning.
+	rateLimitLowWatermark = 100
+
+	// dbPingTimeout bounds the readiness database probe.
+	dbPingTimeout = 2 * time.Second
+)
+
+// Probe status values.
+const (
+	probeOK       = "ok"
+	probeFail     = "fail"
+	probeDegraded = "degraded"
+	probeUnknown  = "unknown"
+	probePending  = "pending"
+	probeStale    = "stale"
+	probePaused   = "paused"
+)
+
+// DBPinger is implemented by database adapters that can verify connectivity.
+type DBPinger interface {
+	Ping(ctx context.Context) error
+}
+
+// WithDBPinger injects the database used by the readiness probe. Without it
+// the database check reports "unknown" and does not affect readiness.
+func (h *Handler) WithDBPinger(db DBPinger) *Handler {
+	h.db = db
+	return h
+}
+
+// Liveness reports whether the process is alive and the poll loop is still
+// making progress. It returns 503 when polling is wedged so an orchestrator
+// can restart the service.
+func (h *Handler) Liveness(w http.ResponseWriter, _ *http.Request) {
+	now := time.Now().UTC()
+	resp := HealthResponse{
+		Status: probeOK,
+		Time:   now.Format(time.RFC3339),
+	}
+
+	if h.pollSvc != nil {
+		startedAt, lastProgressAt := h.pollSvc.LoopStatus()
+		if !startedAt.IsZero() {
+			resp.PollLoopStartedAt = startedAt.UTC().Format(time.RFC3339)
+			resp.LastPollProgressAt = lastProgressAt.UTC().Format(time.RFC3339)
+
+			if now.Sub(lastProgressAt) > pollStallThreshold {
+				resp.Status = probeFail
+				resp.Error = "poll loop has not made progress since " + resp.LastPollProgressAt
+				writeJSON(w, http.StatusServiceUnavailable, resp)
+				return
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Readiness reports dependency health: database connectivity, GitHub
+// credential validity and rate-limit headroom, and the last successful poll
+// time per watched repository. It returns 503 when the database is
+// unreachable, the GitHub token is rejected, or any repo has gone stale.
+// Paused repos are not polled, so they are reported as "paused" and never
+// count as stale. Repos with an open circuit are "degraded": they are gone
+// or forbidden on GitHub, which restarting the service would not fix.
+func (h *Handler) Readiness(w http.ResponseWriter, r *http.Request) {
+	now := time.Now().UTC()
+	resp := ReadinessResponse{
+		Time:     now.Format(time.RFC3339),
+		Database: h.checkDatabase(r.Context()),
+		GitHub:   h.checkGitHub(),
+		Pacing:   h.pollPacing(),
+		Repos:    []RepoPollCheckResponse{},
+	}
+
+	ready := resp.Database.Status != probeFail && resp.GitHub.Status != probeFail
+
+	repos, err := h.checkRepoPolls(r.Context(), now)
+	if err != nil {
+		h.logger.Error("failed to list repos for readiness", "error", err)
+		ready = false
+	}
+	for _, repo := range repos {
+		if repo.Status == probeStale {
+			ready = false
+		}
+	}
+	resp.Repos = append(resp.Repos, repos...)
+
+	status := http.StatusOK
+	resp.Status = "ready"
+	if !ready {
+		status = http.StatusServiceUnavailable
+		resp.Status = "not_ready"
+	}
+
+	writeJSON(w, status, resp)
+}
+
+// checkDatabase pings the database with a short timeout.
+func (h *Handler) checkDatabase(ctx context.Context) DependencyCheckResponse {
+	if h.db == nil {
+		return DependencyCheckResponse{Status: probeUnknown}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dbPingTimeout)
+	defer cancel()
+
+	if err := h.db.Ping(ctx); err != nil {
+		h.logger.Error("readiness database ping failed", "error", err)
+		return DependencyCheckResponse{Status: probeFail, Error: "database unreachable"}
+	}
+	return DependencyCheckResponse{Status: probeOK}
+}
+
+// checkGitHub summarizes the GitHub API state observed by the poll service.
+func (h *Handler) checkGitHub() GitHubCheckResponse {
+	resp := GitHubCheckResponse{Status: probeUnknown, Credentials: probeUnknown}
+	if h.pollSvc == nil {
+		return resp
+	}
+
+	apiStatus, ok := h.pollSvc.GitHubStatus()
+	if !ok || apiStatus.ObservedAt.IsZero() {
+		return resp
+	}
+
+	resp.ObservedAt = apiStatus.ObservedAt.UTC().Format(time.RFC3339)
+	resp.RateLimit = apiStatus.RateLimit
+	resp.RateRemaining = apiStatus.RateRemaining
+	if !apiStatus.RateReset.IsZero() {
+		resp.RateResetAt = apiStatus.RateReset.UTC().Format(time.RFC3339)
+	}
+
+	switch {
+	case apiStatus.Unauthorized:
+		resp.Status = probeFail
+		resp.Credentials = "invalid"
+	case apiStatus.RateLimit > 0 && apiStatus.RateRemaining < rateLimitLowWatermark:
+		resp.Status = probeDegraded
+		resp.Credentials = "valid"
+	default:
+		resp.Status = probeOK
+		resp.Credentials = "valid"
+	}
+
+	return resp
+}
+
+// pollPacing reports the poll loop's latest pacing decision, or nil before
+// its first scheduled tick.
+func (h *Handler) pollPacing() *PollPacingResponse {
+	if h.pollSvc == nil {
+		return nil
+	}
+	p := h.pollSvc.Pacing()
+	if p.DecidedAt.IsZero() {
+		return nil
+	}
+	return &PollPacingResponse{
+		DecidedAt:    p.DecidedAt.UTC().Format(time.RFC3339),
+		Due:          p.Due,
+		Budget:       p.Budget,
+		Polled:       p.Polled,
+		Deferred:     p.Deferred,
+		CallsPerPoll: math.Round(p.CallsPerPoll*10) / 10,
+		Reason:       p.Reason,
+	}
+}
+
+// checkRepoPolls reports the last successful poll for every watched repo.
+// Repos that have never been polled are "pending" until repoPollStaleAfter
+// has elapsed since the poll loop started; paused repos are "paused".
+func (h *Handler) checkRepoPolls(ctx context.Context, now time.Time) ([]RepoPollCheckResponse, error) {
+	if h.pollSvc == nil {
+		return nil, nil
+	}
+
+	repos, err := h.repoStore.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt, _ := h.pollSvc.LoopStatus()
+	schedules := h.pollSvc.Schedules()
+
+	result := make([]RepoPollCheckResponse, 0, len(repos))
+	for _, repo := range repos {
+		check := repoPollCheck(repo.FullName, schedules, startedAt, now)
+		if repo.Paused {
+			check.Status = probePaused
+		}
+		result = append(result, check)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Repository < result[j].Repository
+	})
+
+	return result, nil
+}
+
+// repoPollCheck classifies a single repo's poll freshness. A repo whose
+// circuit is open backs off for hours and is reported "degraded" rather than
+// stale.
+func repoPollCheck(
+	fullName string,
+	schedules map[string]application.ScheduleInfo,
+	startedAt, now time.Time,
+) RepoPollCheckResponse {
+	check := RepoPollCheckResponse{Repository: fullName, Status: probeOK}
+
+	sched, ok := schedules[fullName]
+	if ok && sched.ConsecutiveFailures > 0 {
+		check.Circuit = sched.Circuit.String()
+		check.ConsecutiveFailures = sched.ConsecutiveFailures
+		check.LastError = sched.LastError
+		check.NextRetryAt = sched.NextPollAt.UTC().Format(time.RFC3339)
+		check.MovedTo = sched.MovedTo
+	}
+
+	switch {
+	case ok && sched.Circuit != application.CircuitClosed:
+		check.Status = probeDegraded
+	case !ok || sched.LastPolled.IsZero():
+		check.Status = probePending
+		if !startedAt.IsZero() && now.Sub(startedAt) > repoPollStaleAfter {
+			check.Status = probeStale
+		}
+	case now.Sub(sched.LastPolled) > repoPollStaleAfter:
+		check.Status = probeStale
+	}
+
+	if ok && !sched.LastPolled.IsZero() {
+		check.LastSuccessfulPollAt = sched.LastPolled.UTC().Format(time.RFC3339)
+	}
+	return check
+}
@@ -0,0 +1,169 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// ScriptHookResponse is the JSON representation of a script hook.
+type ScriptHookResponse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Event        string `json:"event"`
+	Runtime      string `json:"runtime"`
+	Script       string `json:"script"`
+	RepoFullName string `json:"repo_full_name"`
+	Enabled      bool   `json:"enabled"`
+	CreatedAt    string `json:"created_at"`
+}
+
+// ScriptHookRequest is the body of a script hook create. Enabled defaults to
+// true.
+type ScriptHookRequest struct {
+	Name         string `json:"name"`
+	Event        string `json:"event"`
+	Runtime      string `json:"runtime"`
+	Script       string `json:"script"`
+	RepoFullName string `json:"repo_full_name"`
+	Enabled      *bool  `json:"enabled"`
+}
+
+// SetHookEnabledRequest is the body of a script hook enable or disable.
+type SetHookEnabledRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// WithScriptHookService enables the script hook endpoints. Without it they
+// return 503.
+func (h *Handler) WithScriptHookService(svc *application.ScriptHookService) *Handler {
+	h.hookSvc = svc
+	return h
+}
+
+// ListScriptHooks handles GET /api/v1/hooks.
+func (h *Handler) ListScriptHooks(w http.ResponseWriter, r *http.Request) {
+	if h.hookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "script hooks not configured")
+		return
+	}
+
+	hooks, err := h.hookSvc.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list script hooks", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	resp := make([]ScriptHookResponse, 0, len(hooks))
+	for _, hook := range hooks {
+		resp = append(resp, toScriptHookResponse(hook))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateScriptHook handles POST /api/v1/hooks.
+func (h *Handler) CreateScriptHook(w http.ResponseWriter, r *http.Request) {
+	if h.hookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "script hooks not configured")
+		return
+	}
+
+	var req ScriptHookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	saved, err := h.hookSvc.Create(r.Context(), model.ScriptHook{
+		Name:         req.Name,
+		Event:        model.HookEvent(req.Event),
+		Runtime:      model.HookRuntime(req.Runtime),
+		Script:       req.Script,
+		RepoFullName: req.RepoFullName,
+		Enabled:      req.Enabled == nil || *req.Enabled,
+	})
+	switch {
+	case errors.Is(err, application.ErrInvalidScriptHook):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create script hook", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toScriptHookResponse(saved))
+}
+
+// SetScriptHookEnabled handles PUT /api/v1/hooks/{id}/enabled.
+func (h *Handler) SetScriptHookEnabled(w http.ResponseWriter, r *http.Request) {
+	if h.hookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "script hooks not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid hook ID")
+		return
+	}
+	var req SetHookEnabledRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	err = h.hookSvc.SetEnabled(r.Context(), id, req.Enabled)
+	switch {
+	case errors.Is(err, driven.ErrScriptHookNotFound):
+		writeError(w, http.StatusNotFound, "hook not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to update script hook", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteScriptHook handles DELETE /api/v1/hooks/{id}.
+func (h *Handler) DeleteScriptHook(w http.ResponseWriter, r *http.Request) {
+	if h.hookSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "script hooks not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid hook ID")
+		return
+	}
+	err = h.hookSvc.Delete(r.Context(), id)
+	switch {
+	case errors.Is(err, driven.ErrScriptHookNotFound):
+		writeError(w, http.StatusNotFound, "hook not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to delete script hook", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toScriptHookResponse converts a hook to its JSON representation.
+func toScriptHookResponse(hook model.ScriptHook) ScriptHookResponse {
+	return ScriptHookResponse{
+		ID:           hook.ID,
+		Name:         hook.Name,
+		Event:        string(hook.Event),
+		Runtime:      string(hook.Runtime),
+		Script:       hook.Script,
+		RepoFullName: hook.RepoFullName,
+		Enabled:      hook.Enabled,
+		CreatedAt:    hook.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,146 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// maxIgnoreReasonLength caps the reason stored with an ignored PR.
+const maxIgnoreReasonLength = 500
+
+// IgnoredPRResponse is the JSON representation of an ignored pull request.
+type IgnoredPRResponse struct {
+	PRResponse
+	Reason    string `json:"reason"` // empty when none was given
+	IgnoredAt string `json:"ignored_at"`
+}
+
+// IgnorePRRequest is the optional JSON body for ignoring a pull request.
+type IgnorePRRequest struct {
+	Reason string `json:"reason"`
+}
+
+// WithIgnoreStore enables listing ignored PRs and ignoring or unignoring
+// them through the API. Without it those endpoints return 503.
+func (h *Handler) WithIgnoreStore(store driven.IgnoreStore) *Handler {
+	h.ignoreStore = store
+	return h
+}
+
+// ListIgnoredPRs lists the ignored pull requests with why and when they were
+// ignored, most recently ignored first.
+func (h *Handler) ListIgnoredPRs(w http.ResponseWriter, r *http.Request) {
+	if h.ignoreStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "ignore list not configured")
+		return
+	}
+
+	ignored, err := h.ignoreStore.ListIgnored(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list ignored PRs", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	prs, err := h.prStore.ListIgnoredWithPRData(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list ignored PR data", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	byID := make(map[int64]model.PullRequest, len(prs))
+	for _, pr := range prs {
+		byID[pr.ID] = pr
+	}
+
+	resp := make([]IgnoredPRResponse, 0, len(ignored))
+	for _, item := range ignored {
+		pr, ok := byID[item.PRID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, IgnoredPRResponse{
+			PRResponse: toPRResponse(pr),
+			Reason:     item.Reason,
+			IgnoredAt:  item.IgnoredAt.UTC().Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// IgnorePR hides a pull request from the queue, recording the optional
+// reason from the request body. Ignoring an ignored PR replaces its reason.
+func (h *Handler) IgnorePR(w http.ResponseWriter, r *http.Request) {
+	var req IgnorePRRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > maxIgnoreReasonLength {
+		writeError(w, http.StatusBadRequest, "reason must be at most "+strconv.Itoa(maxIgnoreReasonLength)+" characters")
+		return
+	}
+
+	pr, ok := h.ignoreTarget(w, r)
+	if !ok {
+		return
+	}
+	if err := h.ignoreStore.Ignore(r.Context(), pr.ID, reason); err != nil {
+		h.logger.Error("failed to ignore PR", "repo", pr.RepoFullName, "number", pr.Number, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnignorePR returns an ignored pull request to the queue. Unignoring a PR
+// that is not ignored succeeds.
+func (h *Handler) UnignorePR(w http.ResponseWriter, r *http.Request) {
+	pr, ok := h.ignoreTarget(w, r)
+	if !ok {
+		return
+	}
+	if err := h.ignoreStore.Unignore(r.Context(), pr.ID); err != nil {
+		h.logger.Error("failed to unignore PR", "repo", pr.RepoFullName, "number", pr.Number, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ignoreTarget resolves the pull request named by the request path for the
+// ignore endpoints. It writes the error response and returns false when the
+// ignore list is not configured or the PR cannot be found.
+func (h *Handler) ignoreTarget(w http.ResponseWriter, r *http.Request) (*model.PullRequest, bool) {
+	if h.ignoreStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "ignore list not configured")
+		return nil, false
+	}
+
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid PR number")
+		return nil, false
+	}
+	repoFullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+
+	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number)
+	if err != nil {
+		h.logger.Error("failed to get PR", "repo", repoFullName, "number", number, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return nil, false
+	}
+	if pr == nil {
+		writeError(w, http.StatusNotFound, "pull request not found")
+		return nil, false
+	}
+	return pr, true
+}
@@ -0,0 +1,168 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// IgnoreRuleResponse is the JSON representation of a user-defined ignore rule.
+type IgnoreRuleResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Enabled    bool   `json:"enabled"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// IgnoreRuleRequest is the body of an ignore rule create or update. Enabled
+// defaults to true.
+type IgnoreRuleRequest struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Enabled    *bool  `json:"enabled"`
+}
+
+// WithIgnoreRuleService enables the ignore rule endpoints. Without it they
+// return 503.
+func (h *Handler) WithIgnoreRuleService(svc *application.IgnoreRuleService) *Handler {
+	h.ignoreRuleSvc = svc
+	return h
+}
+
+// ListIgnoreRules handles GET /api/v1/ignore/rules.
+func (h *Handler) ListIgnoreRules(w http.ResponseWriter, r *http.Request) {
+	if h.ignoreRuleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "ignore rules not configured")
+		return
+	}
+
+	rules, err := h.ignoreRuleSvc.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list ignore rules", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	resp := make([]IgnoreRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, toIgnoreRuleResponse(rule))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateIgnoreRule handles POST /api/v1/ignore/rules. The queued PRs the new
+// rule matches are ignored before it responds.
+func (h *Handler) CreateIgnoreRule(w http.ResponseWriter, r *http.Request) {
+	if h.ignoreRuleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "ignore rules not configured")
+		return
+	}
+
+	rule, ok := decodeIgnoreRuleRequest(w, r)
+	if !ok {
+		return
+	}
+	saved, err := h.ignoreRuleSvc.Create(r.Context(), rule)
+	switch {
+	case errors.Is(err, application.ErrInvalidIgnoreRule):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create ignore rule", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toIgnoreRuleResponse(saved))
+}
+
+// UpdateIgnoreRule handles PUT /api/v1/ignore/rules/{id}.
+func (h *Handler) UpdateIgnoreRule(w http.ResponseWriter, r *http.Request) {
+	if h.ignoreRuleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "ignore rules not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid rule ID")
+		return
+	}
+	rule, ok := decodeIgnoreRuleRequest(w, r)
+	if !ok {
+		return
+	}
+	rule.ID = id
+
+	err = h.ignoreRuleSvc.Update(r.Context(), rule)
+	switch {
+	case errors.Is(err, application.ErrInvalidIgnoreRule):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, driven.ErrIgnoreRuleNotFound):
+		writeError(w, http.StatusNotFound, "rule not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to update ignore rule", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteIgnoreRule handles DELETE /api/v1/ignore/rules/{id}. PRs the rule
+// ignored stay ignored.
+func (h *Handler) DeleteIgnoreRule(w http.ResponseWriter, r *http.Request) {
+	if h.ignoreRuleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "ignore rules not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid rule ID")
+		return
+	}
+	err = h.ignoreRuleSvc.Delete(r.Context(), id)
+	switch {
+	case errors.Is(err, driven.ErrIgnoreRuleNotFound):
+		writeError(w, http.StatusNotFound, "rule not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to delete ignore rule", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeIgnoreRuleRequest reads a rule from the request body, writing a 400
+// and returning false when it is malformed.
+func decodeIgnoreRuleRequest(w http.ResponseWriter, r *http.Request) (model.IgnoreRule, bool) {
+	var req IgnoreRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return model.IgnoreRule{}, false
+	}
+	return model.IgnoreRule{
+		Name:       req.Name,
+		Expression: req.Expression,
+		Enabled:    req.Enabled == nil || *req.Enabled,
+	}, true
+}
+
+// toIgnoreRuleResponse converts a rule to its JSON representation.
+func toIgnoreRuleResponse(rule model.IgnoreRule) IgnoreRuleResponse {
+	return IgnoreRuleResponse{
+		ID:         rule.ID,
+		Name:       rule.Name,
+		Expression: rule.Expression,
+		Enabled:    rule.Enabled,
+		CreatedAt:  rule.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,109 @@
+package httphandler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// maxJournalLimit bounds the limit parameter of a journal query.
+const maxJournalLimit = 500
+
+// PRJournalEntryResponse is the JSON representation of one event journal
+// entry.
+type PRJournalEntryResponse struct {
+	ID         int64  `json:"id"`
+	Repository string `json:"repository"`
+	PRNumber   int    `json:"pr_number"`
+	Kind       string `json:"kind"`
+	Actor      string `json:"actor,omitempty"`
+	From       string `json:"from,omitempty"`
+	To         string `json:"to,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+// WithJournalStore enables GET /api/v1/journal. Without it the endpoint
+// returns 503.
+func (h *Handler) WithJournalStore(store driven.PRJournalStore) *Handler {
+	h.journal = store
+	return h
+}
+
+// ListJournal returns event journal entries, oldest first, filtered by
+// ?repo=owner/name, ?pr= (with repo), ?kind= (comma-separated), and ?after=,
+// an entry ID to continue from. ?limit= caps the results, up to 500.
+func (h *Handler) ListJournal(w http.ResponseWriter, r *http.Request) {
+	if h.journal == nil {
+		writeError(w, http.StatusServiceUnavailable, "event journal not configured")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := model.PRJournalFilter{RepoFullName: q.Get("repo")}
+	if filter.RepoFullName != "" && !validate.IsValidRepoName(filter.RepoFullName) {
+		writeError(w, http.StatusBadRequest, "invalid repository name")
+		return
+	}
+	if v := q.Get("pr"); v != "" {
+		number, err := strconv.Atoi(v)
+		if err != nil || number < 1 || filter.RepoFullName == "" {
+			writeError(w, http.StatusBadRequest, "pr must be a PR number and needs repo")
+			return
+		}
+		filter.PRNumber = number
+	}
+	for _, k := range strings.Split(q.Get("kind"), ",") {
+		kind := model.PRJournalKind(strings.TrimSpace(k))
+		if kind == "" {
+			continue
+		}
+		if !kind.Valid() {
+			writeError(w, http.StatusBadRequest, "unknown kind: "+string(kind))
+			return
+		}
+		filter.Kinds = append(filter.Kinds, kind)
+	}
+	if v := q.Get("after"); v != "" {
+		after, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || after < 0 {
+			writeError(w, http.StatusBadRequest, "after must be an entry ID")
+			return
+		}
+		filter.AfterID = after
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 || limit > maxJournalLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 500")
+			return
+		}
+		filter.Limit = limit
+	}
+
+	entries, err := h.journal.ListPREvents(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list journal entries", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]PRJournalEntryResponse, 0, len(entries))
+	for _, e := range entries {
+		resp = append(resp, PRJournalEntryResponse{
+			ID:         e.ID,
+			Repository: e.RepoFullName,
+			PRNumber:   e.PRNumber,
+			Kind:       string(e.Kind),
+			Actor:      e.Actor,
+			From:       e.From,
+			To:         e.To,
+			OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
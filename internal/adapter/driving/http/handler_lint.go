@@ -0,0 +1,172 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// LintRuleResponse is the JSON representation of a PR title or branch rule.
+type LintRuleResponse struct {
+	ID          int64  `json:"id"`
+	Target      string `json:"target"`
+	Pattern     string `json:"pattern"`
+	Description string `json:"description"`
+}
+
+// LintRuleRequest is one rule in the body of a rule replacement.
+type LintRuleRequest struct {
+	Target      string `json:"target"`
+	Pattern     string `json:"pattern"`
+	Description string `json:"description"`
+}
+
+// LintViolationResponse is the JSON representation of a rule a PR fails.
+type LintViolationResponse struct {
+	RuleID  int64  `json:"rule_id"`
+	Target  string `json:"target"`
+	Pattern string `json:"pattern"`
+	Message string `json:"message"`
+	Value   string `json:"value"` // the title or branch that failed
+}
+
+// PRLintResponse is the JSON representation of an open PR failing lint rules.
+type PRLintResponse struct {
+	PR         PRResponse              `json:"pr"`
+	Violations []LintViolationResponse `json:"violations"`
+}
+
+// WithLintService enables the lint rule endpoints, the violation list, and
+// lint_violations on single PRs. Without it the endpoints return 503.
+func (h *Handler) WithLintService(svc *application.LintService) *Handler {
+	h.lintSvc = svc
+	return h
+}
+
+// ListLintRules handles GET /api/v1/repos/{owner}/{repo}/lint-rules.
+func (h *Handler) ListLintRules(w http.ResponseWriter, r *http.Request) {
+	if h.lintSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "lint rules not configured")
+		return
+	}
+
+	repoFullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	rules, err := h.lintSvc.Rules(r.Context(), repoFullName)
+	if err != nil {
+		h.logger.Error("failed to list lint rules", "repo", repoFullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toLintRuleResponses(rules))
+}
+
+// ReplaceLintRules handles PUT /api/v1/repos/{owner}/{repo}/lint-rules. The
+// body is the repository's complete rule list; an empty list removes all
+// rules. Open PRs are rechecked before the response is sent.
+func (h *Handler) ReplaceLintRules(w http.ResponseWriter, r *http.Request) {
+	if h.lintSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "lint rules not configured")
+		return
+	}
+
+	repoFullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	if !validate.IsValidRepoName(repoFullName) {
+		writeError(w, http.StatusBadRequest, "invalid repository name")
+		return
+	}
+
+	var req []LintRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	rules := make([]model.LintRule, 0, len(req))
+	for _, rr := range req {
+		rules = append(rules, model.LintRule{
+			Target:      model.LintTarget(rr.Target),
+			Pattern:     rr.Pattern,
+			Description: strings.TrimSpace(rr.Description),
+		})
+	}
+
+	saved, err := h.lintSvc.SetRules(r.Context(), repoFullName, rules)
+	switch {
+	case errors.Is(err, application.ErrInvalidLintRule):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, driven.ErrRepoNotFound):
+		writeError(w, http.StatusNotFound, "repository not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to replace lint rules", "repo", repoFullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toLintRuleResponses(saved))
+}
+
+// ListLintViolations handles GET /api/v1/prs/lint-violations?repo=owner/name.
+// It returns open PRs failing at least one rule, by repository and number.
+func (h *Handler) ListLintViolations(w http.ResponseWriter, r *http.Request) {
+	if h.lintSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "lint rules not configured")
+		return
+	}
+
+	repo := r.URL.Query().Get("repo")
+	if repo != "" && !validate.IsValidRepoName(repo) {
+		writeError(w, http.StatusBadRequest, "invalid repository name")
+		return
+	}
+
+	items, err := h.lintSvc.Violations(r.Context(), repo)
+	if err != nil {
+		h.logger.Error("failed to list lint violations", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]PRLintResponse, 0, len(items))
+	for _, item := range items {
+		resp = append(resp, PRLintResponse{
+			PR:         toPRResponse(item.PR),
+			Violations: toLintViolationResponses(item.PR, item.Violations),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// toLintRuleResponses converts rules to their JSON representation.
+func toLintRuleResponses(rules []model.LintRule) []LintRuleResponse {
+	resp := make([]LintRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, LintRuleResponse{
+			ID:          rule.ID,
+			Target:      string(rule.Target),
+			Pattern:     rule.Pattern,
+			Description: rule.Description,
+		})
+	}
+	return resp
+}
+
+// toLintViolationResponses converts the rules pr fails to their JSON representation.
+func toLintViolationResponses(pr model.PullRequest, rules []model.LintRule) []LintViolationResponse {
+	resp := make([]LintViolationResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, LintViolationResponse{
+			RuleID:  rule.ID,
+			Target:  string(rule.Target),
+			Pattern: rule.Pattern,
+			Message: rule.Label(),
+			Value:   rule.Value(pr),
+		})
+	}
+	return resp
+}
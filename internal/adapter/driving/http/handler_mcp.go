@@ -0,0 +1,403 @@
+package httphandler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// mcpProtocolVersion is the Model Context Protocol revision the endpoint speaks.
+const mcpProtocolVersion = "2025-06-18"
+
+// mcpPath is the MCP endpoint.
+const mcpPath = "/api/v1/mcp"
+
+// mcpMaxRequestBytes bounds a single MCP request body.
+const mcpMaxRequestBytes = 1 << 20
+
+// JSON-RPC 2.0 error codes used by the MCP endpoint.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+)
+
+// rpcRequest is a JSON-RPC 2.0 request or, without an ID, a notification.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response carrying either Result or Error.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError is a JSON-RPC 2.0 error object.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// mcpTool describes a tool in a tools/list result.
+type mcpTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema"`
+	Annotations mcpAnnotations  `json:"annotations"`
+}
+
+// mcpAnnotations are hints to the client about a tool's behavior.
+type mcpAnnotations struct {
+	ReadOnlyHint bool `json:"readOnlyHint"`
+}
+
+// mcpContent is one text block of a tool result.
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// mcpToolResult is the result of tools/call. Tool failures are reported here
+// with IsError, so the model sees them, rather than as JSON-RPC errors.
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+// mcpPRArgs are the arguments of the tools that act on one PR.
+type mcpPRArgs struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+}
+
+// mcpListPRsArgs are the arguments of list_prs.
+type mcpListPRsArgs struct {
+	Repository  string `json:"repository"`
+	NeedsReview bool   `json:"needs_review"`
+}
+
+// mcpThreadsArgs are the arguments of get_threads.
+type mcpThreadsArgs struct {
+	mcpPRArgs
+	UnresolvedOnly bool `json:"unresolved_only"`
+}
+
+// mcpPostCommentArgs are the arguments of post_comment.
+type mcpPostCommentArgs struct {
+	mcpPRArgs
+	Body      string `json:"body"`
+	InReplyTo int64  `json:"in_reply_to"`
+	Confirm   bool   `json:"confirm"`
+}
+
+// mcpThreadsResult is the get_threads payload.
+type mcpThreadsResult struct {
+	Threads       []ReviewThreadResponse `json:"threads"`
+	IssueComments []IssueCommentResponse `json:"issue_comments"`
+}
+
+// mcpTools lists the tools the MCP endpoint offers.
+var mcpTools = []mcpTool{
+	{
+		Name:        "list_prs",
+		Description: "List the pull requests in the review queue: open PRs in watched repositories, with title, author, review status, CI status, and age.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+			`"repository":{"type":"string","description":"Only PRs in this owner/name repository"},` +
+			`"needs_review":{"type":"boolean","description":"Only PRs waiting for the user's review"}}}`),
+		Annotations: mcpAnnotations{ReadOnlyHint: true},
+	},
+	{
+		Name:        "get_pr_detail",
+		Description: "Get one pull request with its reviews, review threads, comments, check runs, and lint violations.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+			`"repository":{"type":"string","description":"owner/name"},` +
+			`"number":{"type":"integer"}},"required":["repository","number"]}`),
+		Annotations: mcpAnnotations{ReadOnlyHint: true},
+	},
+	{
+		Name:        "get_threads",
+		Description: "Get the review threads and top-level comments of a pull request. Thread root comment IDs can be passed to post_comment as in_reply_to.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+			`"repository":{"type":"string","description":"owner/name"},` +
+			`"number":{"type":"integer"},` +
+			`"unresolved_only":{"type":"boolean","description":"Leave out resolved threads"}},"required":["repository","number"]}`),
+		Annotations: mcpAnnotations{ReadOnlyHint: true},
+	},
+	{
+		Name: "post_comment",
+		Description: "Post a comment on a pull request as the user, or reply to a review thread with in_reply_to. " +
+			"Show the user the exact text and only pass confirm: true once they approve it; without it nothing is posted.",
+		InputSchema: json.RawMessage(`{"type":"object","properties":{` +
+			`"repository":{"type":"string","description":"owner/name"},` +
+			`"number":{"type":"integer"},` +
+			`"body":{"type":"string","description":"Markdown comment text"},` +
+			`"in_reply_to":{"type":"integer","description":"Root comment ID of the review thread to reply to"},` +
+			`"confirm":{"type":"boolean","description":"True once the user approved posting this exact text"}},` +
+			`"required":["repository","number","body"]}`),
+	},
+}
+
+// WithMCPWriter lets the MCP post_comment tool post through the writer
+// writerFor returns. Without it the tool refuses and hands the draft back.
+func (h *Handler) WithMCPWriter(writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)) *Handler {
+	h.mcpWriterFor = writerFor
+	return h
+}
+
+// MCP handles POST /api/v1/mcp, a Model Context Protocol server over the
+// streamable HTTP transport that answers every request with a single JSON
+// response. It lets AI assistants read the review queue and draft replies.
+func (h *Handler) MCP(w http.ResponseWriter, r *http.Request) {
+	var req rpcRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, mcpMaxRequestBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &rpcError{Code: rpcParseError, Message: "invalid JSON"}})
+		return
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		writeJSON(w, http.StatusBadRequest, rpcResponse{JSONRPC: "2.0", ID: orNull(req.ID),
+			Error: &rpcError{Code: rpcInvalidRequest, Message: "not a JSON-RPC 2.0 request"}})
+		return
+	}
+	if len(req.ID) == 0 {
+		// Notifications such as notifications/initialized need no answer.
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	switch req.Method {
+	case "initialize":
+		resp.Result = map[string]any{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": "mygitpanel", "version": "1"},
+			"instructions": "mygitpanel tracks the pull requests the user reviews and authors. " +
+				"Use list_prs to see the review queue and get_threads to read discussions before drafting replies.",
+		}
+	case "ping":
+		resp.Result = map[string]any{}
+	case "tools/list":
+		resp.Result = map[string]any{"tools": mcpTools}
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			resp.Error = &rpcError{Code: rpcInvalidParams, Message: "invalid tools/call params"}
+			break
+		}
+		result, ok := h.callMCPTool(r.Context(), params.Name, params.Arguments)
+		if !ok {
+			resp.Error = &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("unknown tool %q", params.Name)}
+			break
+		}
+		resp.Result = result
+	default:
+		resp.Error = &rpcError{Code: rpcMethodNotFound, Message: fmt.Sprintf("method %q not found", req.Method)}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// callMCPTool runs the named tool. It reports false for an unknown tool.
+func (h *Handler) callMCPTool(ctx context.Context, name string, rawArgs json.RawMessage) (mcpToolResult, bool) {
+	if len(rawArgs) == 0 {
+		rawArgs = json.RawMessage("{}")
+	}
+	switch name {
+	case "list_prs":
+		var args mcpListPRsArgs
+		if err := json.Unmarshal(rawArgs, &args); err != nil {
+			return mcpError("invalid arguments: " + err.Error()), true
+		}
+		return h.mcpListPRs(ctx, args), true
+	case "get_pr_detail":
+		var args mcpPRArgs
+		if err := json.Unmarshal(rawArgs, &args); err != nil {
+			return mcpError("invalid arguments: " + err.Error()), true
+		}
+		pr, errResult := h.mcpPR(ctx, args)
+		if pr == nil {
+			return errResult, true
+		}
+		return mcpJSON(h.prDetail(ctx, *pr)), true
+	case "get_threads":
+		var args mcpThreadsArgs
+		if err := json.Unmarshal(rawArgs, &args); err != nil {
+			return mcpError("invalid arguments: " + err.Error()), true
+		}
+		return h.mcpGetThreads(ctx, args), true
+	case "post_comment":
+		var args mcpPostCommentArgs
+		if err := json.Unmarshal(rawArgs, &args); err != nil {
+			return mcpError("invalid arguments: " + err.Error()), true
+		}
+		return h.mcpPostComment(ctx, args), true
+	}
+	return mcpToolResult{}, false
+}
+
+// mcpListPRs lists open PRs, optionally only those needing review or in one
+// repository.
+func (h *Handler) mcpListPRs(ctx context.Context, args mcpListPRsArgs) mcpToolResult {
+	list := h.prStore.ListAll
+	if args.NeedsReview {
+		list = h.prStore.ListNeedingReview
+	}
+	prs, err := list(ctx)
+	if err != nil {
+		h.logger.Error("failed to list PRs for MCP", "error", err)
+		return mcpError("failed to list pull requests")
+	}
+
+	resp := make([]PRResponse, 0, len(prs))
+	for _, pr := range prs {
+		if pr.Status != model.PRStatusOpen || (args.Repository != "" && !strings.EqualFold(pr.RepoFullName, args.Repository)) {
+			continue
+		}
+		resp = append(resp, toPRResponse(pr))
+	}
+	return mcpJSON(resp)
+}
+
+// mcpGetThreads returns a PR's review threads and top-level comments.
+func (h *Handler) mcpGetThreads(ctx context.Context, args mcpThreadsArgs) mcpToolResult {
+	pr, errResult := h.mcpPR(ctx, args.mcpPRArgs)
+	if pr == nil {
+		return errResult
+	}
+	if h.reviewSvc == nil {
+		return mcpError("review data is not available")
+	}
+	summary, err := h.reviewSvc.GetPRReviewSummary(ctx, pr.ID, pr.HeadSHA)
+	if err != nil {
+		h.logger.Error("failed to get review summary for MCP", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return mcpError("failed to load review threads")
+	}
+
+	result := mcpThreadsResult{
+		Threads:       make([]ReviewThreadResponse, 0, len(summary.Threads)),
+		IssueComments: make([]IssueCommentResponse, 0, len(summary.IssueComments)),
+	}
+	for _, thread := range summary.Threads {
+		if args.UnresolvedOnly && thread.IsResolved {
+			continue
+		}
+		result.Threads = append(result.Threads, toReviewThreadResponse(thread))
+	}
+	for _, ic := range summary.IssueComments {
+		result.IssueComments = append(result.IssueComments, toIssueCommentResponse(ic, summary.IssueCommentRepeats))
+	}
+	return mcpJSON(result)
+}
+
+// mcpPostComment posts a comment or thread reply once every gate passes:
+// writes are enabled, the API token may write, and the call is confirmed.
+// Otherwise nothing is posted and the draft is handed back.
+func (h *Handler) mcpPostComment(ctx context.Context, args mcpPostCommentArgs) mcpToolResult {
+	body := strings.TrimSpace(args.Body)
+	if body == "" {
+		return mcpError("body is required")
+	}
+	if h.mcpWriterFor == nil {
+		return mcpError("Posting from assistants is disabled (MYGITPANEL_MCP_WRITES). Nothing was posted; give the user this draft to post themselves:\n\n" + body)
+	}
+	if !canWrite(ctx) {
+		return mcpError("The API token is read-only. Nothing was posted; give the user this draft to post themselves:\n\n" + body)
+	}
+	pr, errResult := h.mcpPR(ctx, args.mcpPRArgs)
+	if pr == nil {
+		return errResult
+	}
+	target := fmt.Sprintf("%s#%d", pr.RepoFullName, pr.Number)
+	if !args.Confirm {
+		return mcpText(fmt.Sprintf("Not posted. Show the user this comment for %s and call post_comment again with confirm: true once they approve it:\n\n%s", target, body))
+	}
+
+	writer, err := h.mcpWriterFor(ctx, pr.RepoFullName)
+	if err != nil {
+		return mcpError("cannot post to " + pr.RepoFullName + ": " + err.Error())
+	}
+	if args.InReplyTo != 0 {
+		err = writer.CreateReplyComment(ctx, pr.RepoFullName, pr.Number, args.InReplyTo, body)
+	} else {
+		err = writer.CreateIssueComment(ctx, pr.RepoFullName, pr.Number, body)
+	}
+	if err != nil {
+		h.logger.Error("failed to post MCP comment", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return mcpError("failed to post the comment: " + err.Error())
+	}
+	h.logger.Info("posted comment from MCP", "repo", pr.RepoFullName, "pr", pr.Number, "in_reply_to", args.InReplyTo)
+
+	if h.reviewSvc != nil {
+		var echoErr error
+		if args.InReplyTo != 0 {
+			echoErr = h.reviewSvc.EchoReply(ctx, pr.ID, args.InReplyTo, h.username, body)
+		} else {
+			echoErr = h.reviewSvc.EchoIssueComment(ctx, pr.ID, h.username, body)
+		}
+		if echoErr != nil {
+			h.logger.Warn("failed to store comment echo", "repo", pr.RepoFullName, "pr", pr.Number, "error", echoErr)
+		}
+	}
+	return mcpText("Posted the comment on " + target + ".")
+}
+
+// mcpPR loads the tracked PR args name. When it returns nil, the tool result
+// explains why.
+func (h *Handler) mcpPR(ctx context.Context, args mcpPRArgs) (*model.PullRequest, mcpToolResult) {
+	if !validate.IsValidRepoName(args.Repository) || args.Number <= 0 {
+		return nil, mcpError("repository must be owner/name and number a PR number")
+	}
+	pr, err := h.prStore.GetByNumber(ctx, args.Repository, args.Number)
+	if err != nil {
+		h.logger.Error("failed to get PR for MCP", "repo", args.Repository, "number", args.Number, "error", err)
+		return nil, mcpError("failed to load the pull request")
+	}
+	if pr == nil {
+		return nil, mcpError(fmt.Sprintf("%s#%d is not a tracked pull request", args.Repository, args.Number))
+	}
+	return pr, mcpToolResult{}
+}
+
+// mcpJSON returns v as an indented JSON text result.
+func mcpJSON(v any) mcpToolResult {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return mcpError("failed to encode the result")
+	}
+	return mcpText(string(b))
+}
+
+// mcpText returns a successful text result.
+func mcpText(text string) mcpToolResult {
+	return mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}}
+}
+
+// mcpError returns a failed tool result the model can read.
+func mcpError(text string) mcpToolResult {
+	return mcpToolResult{Content: []mcpContent{{Type: "text", Text: text}}, IsError: true}
+}
+
+// orNull returns id, or JSON null when it is empty.
+func orNull(id json.RawMessage) json.RawMessage {
+	if len(id) == 0 {
+		return json.RawMessage("null")
+	}
+	return id
+}
@@ -0,0 +1,123 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// maxNoteImportBytes bounds the body of a note import request.
+const maxNoteImportBytes = 10 << 20
+
+// NoteResponse is the JSON representation of a private PR note. The same
+// shape is accepted by the import endpoint, so an export can be re-imported
+// as is.
+type NoteResponse struct {
+	Repository string `json:"repository"`
+	PRNumber   int    `json:"pr_number"`
+	Body       string `json:"body"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// ImportNotesResponse reports how many notes an import added.
+type ImportNotesResponse struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+// WithPRNoteStore enables GET and POST /api/v1/notes for exporting and
+// importing private PR notes. Without it both return 503.
+func (h *Handler) WithPRNoteStore(store driven.PRNoteStore) *Handler {
+	h.noteStore = store
+	return h
+}
+
+// ExportNotes returns every private note, or those of ?repo=owner/name,
+// most recently updated first.
+func (h *Handler) ExportNotes(w http.ResponseWriter, r *http.Request) {
+	if h.noteStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "PR notes not configured")
+		return
+	}
+
+	repo := r.URL.Query().Get("repo")
+	if repo != "" && !validate.IsValidRepoName(repo) {
+		writeError(w, http.StatusBadRequest, "invalid repository name")
+		return
+	}
+
+	notes, err := h.noteStore.Search(r.Context(), repo, "")
+	if err != nil {
+		h.logger.Error("failed to export notes", "repo", repo, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]NoteResponse, 0, len(notes))
+	for _, n := range notes {
+		resp = append(resp, NoteResponse{
+			Repository: n.RepoFullName,
+			PRNumber:   n.PRNumber,
+			Body:       n.Body,
+			CreatedAt:  n.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt:  n.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ImportNotes adds the notes in a JSON array shaped like the export. Notes
+// already present on the same PR are skipped, so re-importing is harmless.
+// The whole request is rejected if any note is invalid.
+func (h *Handler) ImportNotes(w http.ResponseWriter, r *http.Request) {
+	if h.noteStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "PR notes not configured")
+		return
+	}
+
+	var req []NoteResponse
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxNoteImportBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	notes := make([]model.PRNote, 0, len(req))
+	for _, n := range req {
+		body := strings.TrimSpace(n.Body)
+		if !validate.IsValidRepoName(n.Repository) || n.PRNumber <= 0 || body == "" {
+			writeError(w, http.StatusBadRequest, "each note needs a repository, a positive pr_number, and a body")
+			return
+		}
+
+		note := model.PRNote{RepoFullName: n.Repository, PRNumber: n.PRNumber, Body: body}
+		var err error
+		if n.CreatedAt != "" {
+			if note.CreatedAt, err = time.Parse(time.RFC3339, n.CreatedAt); err != nil {
+				writeError(w, http.StatusBadRequest, "created_at must be an RFC 3339 timestamp")
+				return
+			}
+		}
+		if n.UpdatedAt != "" {
+			if note.UpdatedAt, err = time.Parse(time.RFC3339, n.UpdatedAt); err != nil {
+				writeError(w, http.StatusBadRequest, "updated_at must be an RFC 3339 timestamp")
+				return
+			}
+		}
+		notes = append(notes, note)
+	}
+
+	imported, err := h.noteStore.Import(r.Context(), notes)
+	if err != nil {
+		h.logger.Error("failed to import notes", "count", len(notes), "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ImportNotesResponse{Imported: imported, Skipped: len(notes) - imported})
+}
@@ -0,0 +1,46 @@
+package httphandler
+
+import (
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// OrphanSweepResponse reports what an orphan sweep deleted.
+type OrphanSweepResponse struct {
+	Deleted map[string]int `json:"deleted"` // rows deleted per table; tables with none are omitted
+	Total   int            `json:"total"`
+}
+
+// WithOrphanSweeper enables the orphan sweep endpoint, which cleans up data
+// left behind by repositories removed before removal deleted their history.
+func (h *Handler) WithOrphanSweeper(sweeper driven.OrphanSweeper) *Handler {
+	h.orphanSweeper = sweeper
+	return h
+}
+
+// SweepOrphans deletes pull requests, reviews, comments, check runs, and
+// repository settings that no longer belong to a repository. It is safe to
+// run repeatedly; a clean database reports nothing deleted.
+func (h *Handler) SweepOrphans(w http.ResponseWriter, r *http.Request) {
+	if h.orphanSweeper == nil {
+		writeError(w, http.StatusServiceUnavailable, "orphan sweep not configured")
+		return
+	}
+
+	deleted, err := h.orphanSweeper.SweepOrphans(r.Context())
+	if err != nil {
+		h.logger.Error("failed to sweep orphaned rows", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	total := 0
+	for _, n := range deleted {
+		total += n
+	}
+	if total > 0 {
+		h.logger.Info("swept orphaned rows", "total", total, "tables", deleted)
+	}
+	writeJSON(w, http.StatusOK, OrphanSweepResponse{Deleted: deleted, Total: total})
+}
@@ -0,0 +1,97 @@
+package httphandler
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// refreshTimeout bounds a CI-triggered refresh. The caller has already been
+// answered, so this only stops a wedged poll loop from pinning the goroutine.
+const refreshTimeout = 5 * time.Minute
+
+// WithRefreshToken enables POST /api/v1/repos/{owner}/{repo}/refresh for
+// callers presenting token as a bearer credential. Write-scoped API tokens are
+// accepted too; with neither configured the endpoint returns 503.
+func (h *Handler) WithRefreshToken(token string) *Handler {
+	h.refreshToken = token
+	return h
+}
+
+// TriggerRepoRefresh queues an immediate poll of a watched repository. It is
+// meant as the final step of a CI job, so the dashboard reflects the job's
+// outcome without waiting for the next scheduled poll. With ?history=full
+// the poll also fetches merged and closed PRs older than the lookback
+// window. The response is sent as soon as the refresh is queued.
+func (h *Handler) TriggerRepoRefresh(w http.ResponseWriter, r *http.Request) {
+	if (h.refreshToken == "" && h.apiTokens == nil) || h.pollSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "repository refresh endpoint is not enabled")
+		return
+	}
+
+	if !h.validRefreshToken(r) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="mygitpanel"`)
+		writeError(w, http.StatusUnauthorized, "invalid or missing refresh token")
+		return
+	}
+
+	fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	if !validate.IsValidRepoName(fullName) {
+		writeError(w, http.StatusBadRequest, "invalid repository name: expected owner/repo format")
+		return
+	}
+	history := r.URL.Query().Get("history")
+	if history != "" && history != "full" {
+		writeError(w, http.StatusBadRequest, "invalid history: expected full")
+		return
+	}
+
+	repo, err := h.repoStore.GetByFullName(r.Context(), fullName)
+	if err != nil {
+		h.logger.Error("failed to look up repo for refresh", "repo", fullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	if repo == nil {
+		writeError(w, http.StatusNotFound, "repository not found")
+		return
+	}
+
+	h.logger.Info("repo refresh requested via API", "repo", fullName, "history", history)
+
+	go func() { //nolint:contextcheck // the request context ends once the 202 is sent
+		ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+		defer cancel()
+		refresh := h.pollSvc.RefreshRepo
+		if history == "full" {
+			refresh = h.pollSvc.RefreshRepoFullHistory
+		}
+		if err := refresh(ctx, fullName); err != nil {
+			h.logger.Error("API repo refresh failed", "repo", fullName, "error", err)
+		}
+	}()
+
+	writeJSON(w, http.StatusAccepted, RefreshRepoResponse{Repository: fullName, Status: "queued"})
+}
+
+// validRefreshToken reports whether the request carries the configured refresh
+// token or a write-scoped API token as a bearer credential. The refresh token
+// comparison is constant time.
+func (h *Handler) validRefreshToken(r *http.Request) bool {
+	token, ok := bearerToken(r)
+	if !ok {
+		return false
+	}
+	if h.refreshToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.refreshToken)) == 1 {
+		return true
+	}
+	if h.apiTokens == nil {
+		return false
+	}
+	apiToken, err := h.apiTokens.Authenticate(r.Context(), token)
+	return err == nil && apiToken.Scope == model.APITokenScopeWrite
+}
@@ -0,0 +1,104 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// maxRepoImportBytes bounds the body of a bulk repo import request.
+const maxRepoImportBytes = 1 << 20
+
+// ImportReposRequest is the JSON body of a bulk repo import. Repos and the
+// team's repositories are combined.
+type ImportReposRequest struct {
+	Repos []string `json:"repos"`
+	Team  string   `json:"team"` // org/team-slug; optional
+}
+
+// RepoImportResultResponse is the JSON representation of one imported repo.
+type RepoImportResultResponse struct {
+	FullName string `json:"full_name"`
+	Status   string `json:"status"`
+}
+
+// ImportReposResponse reports what a bulk repo import did with each repo.
+type ImportReposResponse struct {
+	Added   int                        `json:"added"`
+	Results []RepoImportResultResponse `json:"results"`
+}
+
+// WithRepoImportService enables POST /api/v1/repos/import. Without it the
+// endpoint returns 503.
+func (h *Handler) WithRepoImportService(svc *application.RepoImportService) *Handler {
+	h.repoImportSvc = svc
+	return h
+}
+
+// ImportRepos adds many repositories at once. The body is either JSON
+// shaped like ImportReposRequest or, with Content-Type text/plain, one
+// owner/repo per line. Valid repos are added in one transaction and
+// refreshed in the background; the response has a status per repo.
+func (h *Handler) ImportRepos(w http.ResponseWriter, r *http.Request) {
+	if h.repoImportSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo import not configured")
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxRepoImportBytes)
+	var req ImportReposRequest
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "text/plain" {
+		text, err := io.ReadAll(body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+		req.Repos = application.ParseRepoList(string(text))
+	} else if err := json.NewDecoder(body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	names := req.Repos
+	if req.Team != "" {
+		teamRepos, err := h.repoImportSvc.TeamRepos(r.Context(), req.Team)
+		switch {
+		case errors.Is(err, application.ErrInvalidTeam):
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		case errors.Is(err, application.ErrTeamImportUnavailable):
+			writeError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		case err != nil:
+			h.logger.Error("failed to list team repos", "team", req.Team, "error", err)
+			writeError(w, http.StatusBadGateway, "failed to list the team's repositories")
+			return
+		}
+		names = append(names, teamRepos...)
+	}
+	if len(names) == 0 {
+		writeError(w, http.StatusBadRequest, "no repositories to import")
+		return
+	}
+
+	results, err := h.repoImportSvc.Import(r.Context(), names)
+	if err != nil {
+		h.logger.Error("failed to import repos", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := ImportReposResponse{Results: make([]RepoImportResultResponse, 0, len(results))}
+	for _, res := range results {
+		if res.Status == model.RepoImportAdded {
+			resp.Added++
+		}
+		resp.Results = append(resp.Results, RepoImportResultResponse{FullName: res.FullName, Status: string(res.Status)})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,81 @@
+package httphandler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// RemovedRepoResponse is the JSON representation of a removed repository
+// that can still be restored.
+type RemovedRepoResponse struct {
+	RepoResponse
+	RemovedAt string `json:"removed_at"`
+	PurgeAt   string `json:"purge_at"` // when the repository and its history are deleted for good
+}
+
+// WithRepoTrashService makes repo removal undoable: DELETE keeps the
+// repository's history until it is purged, and the removed list and restore
+// endpoints are enabled. Without it DELETE deletes immediately and the other
+// endpoints return 503.
+func (h *Handler) WithRepoTrashService(svc *application.RepoTrashService) *Handler {
+	h.repoTrashSvc = svc
+	return h
+}
+
+// ListRemovedRepos lists the removed repositories that can be restored, most
+// recently removed first.
+func (h *Handler) ListRemovedRepos(w http.ResponseWriter, r *http.Request) {
+	if h.repoTrashSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo restore not configured")
+		return
+	}
+
+	repos, err := h.repoTrashSvc.ListRemoved(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list removed repos", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]RemovedRepoResponse, 0, len(repos))
+	for _, repo := range repos {
+		resp = append(resp, RemovedRepoResponse{
+			RepoResponse: toRepoResponse(repo),
+			RemovedAt:    repo.RemovedAt.UTC().Format(time.RFC3339),
+			PurgeAt:      h.repoTrashSvc.PurgeAt(repo.RemovedAt).UTC().Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RestoreRepo puts a removed repository back on the watch list with its
+// history and returns it.
+func (h *Handler) RestoreRepo(w http.ResponseWriter, r *http.Request) {
+	if h.repoTrashSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo restore not configured")
+		return
+	}
+
+	fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	if err := h.repoTrashSvc.Restore(r.Context(), fullName); err != nil {
+		if errors.Is(err, driven.ErrRepoNotFound) {
+			writeError(w, http.StatusNotFound, "removed repository not found")
+			return
+		}
+		h.logger.Error("failed to restore repo", "repo", fullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	repo, err := h.repoStore.GetByFullName(r.Context(), fullName)
+	if err != nil || repo == nil {
+		h.logger.Error("failed to get restored repo", "repo", fullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toRepoResponse(*repo))
+}
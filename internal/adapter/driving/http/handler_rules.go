@@ -0,0 +1,229 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// AttentionRuleResponse is the JSON representation of a user-defined attention rule.
+type AttentionRuleResponse struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Severity   int    `json:"severity"`
+	NotifyURL  string `json:"notify_url"`
+	Enabled    bool   `json:"enabled"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// AttentionRuleRequest is the body of an attention rule create or update.
+// Enabled defaults to true.
+type AttentionRuleRequest struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+	Severity   int    `json:"severity"`
+	NotifyURL  string `json:"notify_url"`
+	Enabled    *bool  `json:"enabled"`
+}
+
+// RuleMatchResponse is the JSON representation of a rule a PR matches.
+type RuleMatchResponse struct {
+	RuleID   int64  `json:"rule_id"`
+	Name     string `json:"name"`
+	Severity int    `json:"severity"`
+}
+
+// ValidateRuleRequest is the body of an expression validation.
+type ValidateRuleRequest struct {
+	Expression string `json:"expression"`
+}
+
+// ValidateRuleResponse reports whether an expression compiles, the first
+// problem when it does not, and the fields expressions may use.
+type ValidateRuleResponse struct {
+	Valid  bool     `json:"valid"`
+	Error  string   `json:"error,omitempty"`
+	Fields []string `json:"fields"`
+}
+
+// WithAttentionRuleService enables the attention rule endpoints. Without it
+// they return 503.
+func (h *Handler) WithAttentionRuleService(svc *application.AttentionRuleService) *Handler {
+	h.ruleSvc = svc
+	return h
+}
+
+// ListAttentionRules handles GET /api/v1/attention/rules.
+func (h *Handler) ListAttentionRules(w http.ResponseWriter, r *http.Request) {
+	if h.ruleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "attention rules not configured")
+		return
+	}
+
+	rules, err := h.ruleSvc.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list attention rules", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	resp := make([]AttentionRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, toAttentionRuleResponse(rule))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// CreateAttentionRule handles POST /api/v1/attention/rules.
+func (h *Handler) CreateAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if h.ruleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "attention rules not configured")
+		return
+	}
+
+	rule, ok := decodeAttentionRuleRequest(w, r)
+	if !ok {
+		return
+	}
+	saved, err := h.ruleSvc.Create(r.Context(), rule)
+	switch {
+	case errors.Is(err, application.ErrInvalidAttentionRule):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create attention rule", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, toAttentionRuleResponse(saved))
+}
+
+// UpdateAttentionRule handles PUT /api/v1/attention/rules/{id}.
+func (h *Handler) UpdateAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if h.ruleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "attention rules not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid rule ID")
+		return
+	}
+	rule, ok := decodeAttentionRuleRequest(w, r)
+	if !ok {
+		return
+	}
+	rule.ID = id
+
+	err = h.ruleSvc.Update(r.Context(), rule)
+	switch {
+	case errors.Is(err, application.ErrInvalidAttentionRule):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, driven.ErrAttentionRuleNotFound):
+		writeError(w, http.StatusNotFound, "rule not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to update attention rule", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAttentionRule handles DELETE /api/v1/attention/rules/{id}.
+func (h *Handler) DeleteAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if h.ruleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "attention rules not configured")
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid rule ID")
+		return
+	}
+	err = h.ruleSvc.Delete(r.Context(), id)
+	switch {
+	case errors.Is(err, driven.ErrAttentionRuleNotFound):
+		writeError(w, http.StatusNotFound, "rule not found")
+		return
+	case err != nil:
+		h.logger.Error("failed to delete attention rule", "id", id, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ValidateAttentionRule handles POST /api/v1/attention/rules/validate. An
+// expression that does not compile is a 200 with valid false, so editors can
+// check as the user types.
+func (h *Handler) ValidateAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if h.ruleSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "attention rules not configured")
+		return
+	}
+
+	var req ValidateRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	resp := ValidateRuleResponse{Valid: true, Fields: application.RuleFieldNames()}
+	if err := h.ruleSvc.Validate(req.Expression); err != nil {
+		resp.Valid, resp.Error = false, err.Error()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// decodeAttentionRuleRequest reads a rule from the request body, writing a
+// 400 and returning false when it is malformed.
+func decodeAttentionRuleRequest(w http.ResponseWriter, r *http.Request) (model.AttentionRule, bool) {
+	var req AttentionRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return model.AttentionRule{}, false
+	}
+	enabled := req.Enabled == nil || *req.Enabled
+	return model.AttentionRule{
+		Name:       req.Name,
+		Expression: req.Expression,
+		Severity:   req.Severity,
+		NotifyURL:  req.NotifyURL,
+		Enabled:    enabled,
+	}, true
+}
+
+// toAttentionRuleResponse converts a rule to its JSON representation.
+func toAttentionRuleResponse(rule model.AttentionRule) AttentionRuleResponse {
+	return AttentionRuleResponse{
+		ID:         rule.ID,
+		Name:       rule.Name,
+		Expression: rule.Expression,
+		Severity:   rule.Severity,
+		NotifyURL:  rule.NotifyURL,
+		Enabled:    rule.Enabled,
+		CreatedAt:  rule.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// toRuleMatchResponses converts rule matches to their JSON representation,
+// nil when there are none.
+func toRuleMatchResponses(matches []model.RuleMatch) []RuleMatchResponse {
+	if len(matches) == 0 {
+		return nil
+	}
+	resp := make([]RuleMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		resp = append(resp, RuleMatchResponse{RuleID: m.RuleID, Name: m.Name, Severity: m.Severity})
+	}
+	return resp
+}
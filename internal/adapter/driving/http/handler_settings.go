@@ -0,0 +1,535 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// maxSettingsImportBytes bounds the body of a settings import request.
+const maxSettingsImportBytes = 10 << 20
+
+// RepoSettingsResponse is the JSON representation of a repository's
+// settings. RepoSettingsRequest has the same fields apart from the
+// repository name, so a GET can be saved and replayed with PUT.
+type RepoSettingsResponse struct {
+	RepoFullName   string                `json:"repo_full_name"`
+	RequiredChecks []string              `json:"required_checks"`
+	OptionalChecks []string              `json:"optional_checks"`
+	IgnoredChecks  []string              `json:"ignored_checks"`
+	Stale          StalePolicyResponse   `json:"stale"`
+	PRScope        string                `json:"pr_scope"` // "all", "relevant", or "" for the default
+	Thresholds     RepoThresholdResponse `json:"thresholds"`
+}
+
+// RepoSettingsRequest is the body of a repository settings replace. The
+// repository comes from the path; omitted fields are cleared.
+type RepoSettingsRequest struct {
+	RequiredChecks []string              `json:"required_checks"`
+	OptionalChecks []string              `json:"optional_checks"`
+	IgnoredChecks  []string              `json:"ignored_checks"`
+	Stale          StalePolicyResponse   `json:"stale"`
+	PRScope        string                `json:"pr_scope"`
+	Thresholds     RepoThresholdResponse `json:"thresholds"`
+}
+
+// StalePolicyResponse is the JSON representation of a repository's stale
+// PR policy.
+type StalePolicyResponse struct {
+	AfterDays    int      `json:"after_days"` // 0 turns the labeler off
+	Action       string   `json:"action"`     // "label" or "comment"; "" means "label"
+	Label        string   `json:"label"`
+	DryRun       bool     `json:"dry_run"`
+	ExemptLabels []string `json:"exempt_labels"`
+}
+
+// RepoThresholdResponse is the JSON representation of a repository's
+// attention threshold overrides. Null fields use the global thresholds.
+type RepoThresholdResponse struct {
+	ReviewCount         *int  `json:"review_count"`
+	AgeUrgencyDays      *int  `json:"age_urgency_days"`
+	StaleReviewEnabled  *bool `json:"stale_review_enabled"`
+	CIFailureEnabled    *bool `json:"ci_failure_enabled"`
+	FirstReviewSLAHours *int  `json:"first_review_sla_hours"`
+}
+
+// GlobalThresholdsResponse is the JSON representation of the global
+// attention thresholds.
+type GlobalThresholdsResponse struct {
+	ReviewCountThreshold   int  `json:"review_count_threshold"`
+	AgeUrgencyDays         int  `json:"age_urgency_days"`
+	StaleReviewEnabled     bool `json:"stale_review_enabled"`
+	CIFailureEnabled       bool `json:"ci_failure_enabled"`
+	FirstTimerBoostEnabled bool `json:"first_timer_boost_enabled"`
+	ChecklistEnabled       bool `json:"checklist_enabled"`
+	RequestAgeDays         int  `json:"request_age_days"` // 0 disables the signal
+}
+
+// GlobalThresholdsRequest is the body of a global thresholds replace, with
+// the fields of GlobalThresholdsResponse. Omitted fields are zero.
+type GlobalThresholdsRequest struct {
+	ReviewCountThreshold   int  `json:"review_count_threshold"`
+	AgeUrgencyDays         int  `json:"age_urgency_days"`
+	StaleReviewEnabled     bool `json:"stale_review_enabled"`
+	CIFailureEnabled       bool `json:"ci_failure_enabled"`
+	FirstTimerBoostEnabled bool `json:"first_timer_boost_enabled"`
+	ChecklistEnabled       bool `json:"checklist_enabled"`
+	RequestAgeDays         int  `json:"request_age_days"`
+}
+
+// SettingsExportResponse is the settings export: the global thresholds and
+// the settings of every watched repository. The import endpoint accepts the
+// same shape.
+type SettingsExportResponse struct {
+	Thresholds GlobalThresholdsResponse `json:"thresholds"`
+	Repos      []RepoSettingsResponse   `json:"repos"`
+}
+
+// SettingsImportRequest is the body of a settings import. The global
+// thresholds are kept when thresholds is absent.
+type SettingsImportRequest struct {
+	Thresholds *GlobalThresholdsRequest    `json:"thresholds"`
+	Repos      []RepoSettingsImportRequest `json:"repos"`
+}
+
+// RepoSettingsImportRequest is one repository's settings in an import.
+type RepoSettingsImportRequest struct {
+	RepoFullName string `json:"repo_full_name"`
+	RepoSettingsRequest
+}
+
+// ImportSettingsResponse reports how many repositories an import updated.
+// Repositories that are not watched are skipped.
+type ImportSettingsResponse struct {
+	Imported int `json:"imported"`
+	Skipped  int `json:"skipped"`
+}
+
+// WithRepoSettingsStore enables the per-repository settings endpoints, which
+// also need a threshold store for the repository's threshold overrides.
+// Without both they return 503.
+func (h *Handler) WithRepoSettingsStore(store driven.RepoSettingsStore) *Handler {
+	h.repoSettings = store
+	return h
+}
+
+// WithThresholdStore enables the global threshold endpoints and, together
+// with a repo settings store, the per-repository settings endpoints.
+// Without it they return 503.
+func (h *Handler) WithThresholdStore(store driven.ThresholdStore) *Handler {
+	h.thresholdStore = store
+	return h
+}
+
+// GetRepoSettings returns a watched repository's settings and threshold
+// overrides, empty when none are saved.
+func (h *Handler) GetRepoSettings(w http.ResponseWriter, r *http.Request) {
+	repoFullName, ok := h.settingsRepo(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := h.repoSettings.GetRepoSettings(r.Context(), repoFullName)
+	if err != nil {
+		h.logger.Error("failed to get repo settings", "repo", repoFullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	threshold, err := h.thresholdStore.GetRepoThreshold(r.Context(), repoFullName)
+	if err != nil {
+		h.logger.Error("failed to get repo threshold", "repo", repoFullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toRepoSettingsResponse(settings, threshold))
+}
+
+// ReplaceRepoSettings replaces a watched repository's settings and threshold
+// overrides with the request body and returns them. Check overrides apply
+// as PRs next sync and the PR scope from the next poll.
+func (h *Handler) ReplaceRepoSettings(w http.ResponseWriter, r *http.Request) {
+	repoFullName, ok := h.settingsRepo(w, r)
+	if !ok {
+		return
+	}
+
+	var req RepoSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	settings, threshold, msg := fromRepoSettingsRequest(repoFullName, req)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := h.saveRepoSettings(r, settings, threshold); err != nil {
+		h.logger.Error("failed to save repo settings", "repo", repoFullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toRepoSettingsResponse(settings, threshold))
+}
+
+// ResetRepoSettings clears a watched repository's settings and threshold
+// overrides, so it falls back to the defaults and global thresholds.
+func (h *Handler) ResetRepoSettings(w http.ResponseWriter, r *http.Request) {
+	repoFullName, ok := h.settingsRepo(w, r)
+	if !ok {
+		return
+	}
+
+	settings := model.RepoSettings{RepoFullName: repoFullName}
+	threshold := model.RepoThreshold{RepoFullName: repoFullName}
+	if err := h.saveRepoSettings(r, settings, threshold); err != nil {
+		h.logger.Error("failed to reset repo settings", "repo", repoFullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetGlobalThresholds returns the global attention thresholds.
+func (h *Handler) GetGlobalThresholds(w http.ResponseWriter, r *http.Request) {
+	if h.thresholdStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "thresholds not configured")
+		return
+	}
+
+	settings, err := h.thresholdStore.GetGlobalSettings(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get global thresholds", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toGlobalThresholdsResponse(settings))
+}
+
+// ReplaceGlobalThresholds replaces the global attention thresholds with the
+// request body and returns them.
+func (h *Handler) ReplaceGlobalThresholds(w http.ResponseWriter, r *http.Request) {
+	if h.thresholdStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "thresholds not configured")
+		return
+	}
+
+	var req GlobalThresholdsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	settings, msg := fromGlobalThresholdsRequest(req)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	h.writeGlobalThresholds(w, r, settings)
+}
+
+// ResetGlobalThresholds restores the built-in global thresholds and returns
+// them.
+func (h *Handler) ResetGlobalThresholds(w http.ResponseWriter, r *http.Request) {
+	if h.thresholdStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "thresholds not configured")
+		return
+	}
+	h.writeGlobalThresholds(w, r, model.DefaultGlobalSettings())
+}
+
+// writeGlobalThresholds saves settings as the global thresholds and writes
+// them as the response.
+func (h *Handler) writeGlobalThresholds(w http.ResponseWriter, r *http.Request, settings model.GlobalSettings) {
+	if err := h.thresholdStore.SetGlobalSettings(r.Context(), settings); err != nil {
+		h.logger.Error("failed to save global thresholds", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, toGlobalThresholdsResponse(settings))
+}
+
+// ExportSettings returns the global thresholds and the settings and
+// threshold overrides of every watched repository.
+func (h *Handler) ExportSettings(w http.ResponseWriter, r *http.Request) {
+	if h.repoSettings == nil || h.thresholdStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo settings not configured")
+		return
+	}
+
+	global, err := h.thresholdStore.GetGlobalSettings(r.Context())
+	if err != nil {
+		h.logger.Error("failed to get global thresholds", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	repos, err := h.repoStore.ListAll(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list repos", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.FullName)
+	}
+	saved, err := h.repoSettings.ListRepoSettings(r.Context(), names)
+	if err != nil {
+		h.logger.Error("failed to list repo settings", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := SettingsExportResponse{
+		Thresholds: toGlobalThresholdsResponse(global),
+		Repos:      make([]RepoSettingsResponse, 0, len(names)),
+	}
+	for _, name := range names {
+		settings, ok := saved[name]
+		if !ok {
+			settings = model.RepoSettings{RepoFullName: name}
+		}
+		threshold, err := h.thresholdStore.GetRepoThreshold(r.Context(), name)
+		if err != nil {
+			h.logger.Error("failed to get repo threshold", "repo", name, "error", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		resp.Repos = append(resp.Repos, toRepoSettingsResponse(settings, threshold))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ImportSettings applies a settings export: the global thresholds when
+// present, then each listed repository's settings and threshold overrides.
+// Repositories that are not watched are skipped. The whole request is
+// rejected if any entry is invalid.
+func (h *Handler) ImportSettings(w http.ResponseWriter, r *http.Request) {
+	if h.repoSettings == nil || h.thresholdStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo settings not configured")
+		return
+	}
+
+	var req SettingsImportRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSettingsImportBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	var global *model.GlobalSettings
+	if req.Thresholds != nil {
+		settings, msg := fromGlobalThresholdsRequest(*req.Thresholds)
+		if msg != "" {
+			writeError(w, http.StatusBadRequest, msg)
+			return
+		}
+		global = &settings
+	}
+	type repoEntry struct {
+		settings  model.RepoSettings
+		threshold model.RepoThreshold
+	}
+	entries := make([]repoEntry, 0, len(req.Repos))
+	for _, repo := range req.Repos {
+		if !validate.IsValidRepoName(repo.RepoFullName) {
+			writeError(w, http.StatusBadRequest, "each repository needs a repo_full_name of the form owner/name")
+			return
+		}
+		settings, threshold, msg := fromRepoSettingsRequest(repo.RepoFullName, repo.RepoSettingsRequest)
+		if msg != "" {
+			writeError(w, http.StatusBadRequest, repo.RepoFullName+": "+msg)
+			return
+		}
+		entries = append(entries, repoEntry{settings: settings, threshold: threshold})
+	}
+
+	if global != nil {
+		if err := h.thresholdStore.SetGlobalSettings(r.Context(), *global); err != nil {
+			h.logger.Error("failed to import global thresholds", "error", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+	}
+	var resp ImportSettingsResponse
+	for _, e := range entries {
+		err := h.repoSettings.ReplaceRepoSettings(r.Context(), e.settings, e.threshold)
+		switch {
+		case errors.Is(err, driven.ErrRepoNotFound):
+			resp.Skipped++
+		case err != nil:
+			h.logger.Error("failed to import repo settings", "repo", e.settings.RepoFullName, "error", err)
+			writeError(w, http.StatusInternalServerError, "internal server error")
+			return
+		default:
+			resp.Imported++
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// settingsRepo resolves the watched repository named by the request path
+// for the repo settings endpoints. It writes the error response and returns
+// false when the endpoints are not configured or the repository is not
+// watched.
+func (h *Handler) settingsRepo(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if h.repoSettings == nil || h.thresholdStore == nil {
+		writeError(w, http.StatusServiceUnavailable, "repo settings not configured")
+		return "", false
+	}
+
+	repoFullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	repo, err := h.repoStore.GetByFullName(r.Context(), repoFullName)
+	if err != nil {
+		h.logger.Error("failed to get repo", "repo", repoFullName, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return "", false
+	}
+	if repo == nil {
+		writeError(w, http.StatusNotFound, "repository not found")
+		return "", false
+	}
+	return repoFullName, true
+}
+
+// saveRepoSettings stores settings and threshold together, deleting the
+// threshold override when it sets nothing.
+func (h *Handler) saveRepoSettings(r *http.Request, settings model.RepoSettings, threshold model.RepoThreshold) error {
+	return h.repoSettings.ReplaceRepoSettings(r.Context(), settings, threshold)
+}
+
+// fromGlobalThresholdsRequest validates req and converts it to the global
+// thresholds. msg describes the first invalid field and is empty when req is
+// valid.
+func fromGlobalThresholdsRequest(req GlobalThresholdsRequest) (model.GlobalSettings, string) {
+	if req.ReviewCountThreshold < 0 || req.AgeUrgencyDays < 0 || req.RequestAgeDays < 0 {
+		return model.GlobalSettings{}, "thresholds must not be negative"
+	}
+	return model.GlobalSettings{
+		ReviewCountThreshold:   req.ReviewCountThreshold,
+		AgeUrgencyDays:         req.AgeUrgencyDays,
+		StaleReviewEnabled:     req.StaleReviewEnabled,
+		CIFailureEnabled:       req.CIFailureEnabled,
+		FirstTimerBoostEnabled: req.FirstTimerBoostEnabled,
+		ChecklistEnabled:       req.ChecklistEnabled,
+		RequestAgeDays:         req.RequestAgeDays,
+	}, ""
+}
+
+// fromRepoSettingsRequest validates req and converts it to the settings and
+// threshold overrides of repoFullName. msg describes the first invalid field
+// and is empty when req is valid.
+func fromRepoSettingsRequest(repoFullName string, req RepoSettingsRequest) (model.RepoSettings, model.RepoThreshold, string) {
+	settings := model.RepoSettings{
+		RepoFullName:   repoFullName,
+		RequiredChecks: normalizeNames(req.RequiredChecks),
+		OptionalChecks: normalizeNames(req.OptionalChecks),
+		IgnoredChecks:  normalizeNames(req.IgnoredChecks),
+		Stale: model.StalePolicy{
+			AfterDays:    req.Stale.AfterDays,
+			Action:       model.StaleAction(req.Stale.Action),
+			Label:        strings.TrimSpace(req.Stale.Label),
+			DryRun:       req.Stale.DryRun,
+			ExemptLabels: normalizeNames(req.Stale.ExemptLabels),
+		},
+		PRScope: model.PRScope(req.PRScope),
+	}
+	t := req.Thresholds
+	threshold := model.RepoThreshold{
+		RepoFullName:        repoFullName,
+		ReviewCount:         t.ReviewCount,
+		AgeUrgencyDays:      t.AgeUrgencyDays,
+		StaleReviewEnabled:  t.StaleReviewEnabled,
+		CIFailureEnabled:    t.CIFailureEnabled,
+		FirstReviewSLAHours: t.FirstReviewSLAHours,
+	}
+
+	for _, name := range settings.RequiredChecks {
+		if slices.ContainsFunc(settings.OptionalChecks, func(o string) bool { return strings.EqualFold(o, name) }) {
+			return settings, threshold, name + " cannot be both required and optional"
+		}
+	}
+	if settings.Stale.Action == "" {
+		settings.Stale.Action = model.StaleActionLabel
+	}
+	switch {
+	case !settings.Stale.Action.IsValid():
+		return settings, threshold, "stale action must be label or comment"
+	case settings.Stale.AfterDays < 0:
+		return settings, threshold, "stale after_days must not be negative"
+	case !settings.PRScope.IsValid():
+		return settings, threshold, "pr_scope must be all, relevant, or empty"
+	}
+	for _, n := range []*int{t.ReviewCount, t.AgeUrgencyDays, t.FirstReviewSLAHours} {
+		if n != nil && *n < 0 {
+			return settings, threshold, "thresholds must not be negative"
+		}
+	}
+	return settings, threshold, ""
+}
+
+// normalizeNames trims check and label names, dropping blanks and
+// case-insensitive duplicates.
+func normalizeNames(names []string) []string {
+	result := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+func toRepoSettingsResponse(settings model.RepoSettings, threshold model.RepoThreshold) RepoSettingsResponse {
+	return RepoSettingsResponse{
+		RepoFullName:   settings.RepoFullName,
+		RequiredChecks: nonNilNames(settings.RequiredChecks),
+		OptionalChecks: nonNilNames(settings.OptionalChecks),
+		IgnoredChecks:  nonNilNames(settings.IgnoredChecks),
+		Stale: StalePolicyResponse{
+			AfterDays:    settings.Stale.AfterDays,
+			Action:       string(settings.Stale.Action),
+			Label:        settings.Stale.Label,
+			DryRun:       settings.Stale.DryRun,
+			ExemptLabels: nonNilNames(settings.Stale.ExemptLabels),
+		},
+		PRScope: string(settings.PRScope),
+		Thresholds: RepoThresholdResponse{
+			ReviewCount:         threshold.ReviewCount,
+			AgeUrgencyDays:      threshold.AgeUrgencyDays,
+			StaleReviewEnabled:  threshold.StaleReviewEnabled,
+			CIFailureEnabled:    threshold.CIFailureEnabled,
+			FirstReviewSLAHours: threshold.FirstReviewSLAHours,
+		},
+	}
+}
+
+func toGlobalThresholdsResponse(settings model.GlobalSettings) GlobalThresholdsResponse {
+	return GlobalThresholdsResponse{
+		ReviewCountThreshold:   settings.ReviewCountThreshold,
+		AgeUrgencyDays:         settings.AgeUrgencyDays,
+		StaleReviewEnabled:     settings.StaleReviewEnabled,
+		CIFailureEnabled:       settings.CIFailureEnabled,
+		FirstTimerBoostEnabled: settings.FirstTimerBoostEnabled,
+		ChecklistEnabled:       settings.ChecklistEnabled,
+		RequestAgeDays:         settings.RequestAgeDays,
+	}
+}
+
+// nonNilNames returns names, or an empty list when it is nil, so it encodes
+// as [] rather than null.
+func nonNilNames(names []string) []string {
+	if names == nil {
+		return []string{}
+	}
+	return names
+}
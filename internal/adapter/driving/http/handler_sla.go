@@ -0,0 +1,52 @@
+package httphandler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// SLABreachResponse is the JSON representation of a PR past its repo's
+// first-review SLA.
+type SLABreachResponse struct {
+	PR           PRResponse `json:"pr"`
+	SLAHours     int        `json:"sla_hours"`
+	Deadline     string     `json:"deadline"`
+	OverdueHours int        `json:"overdue_hours"`
+}
+
+// WithSLAService enables GET /api/v1/prs/sla-breaches. Without it the
+// endpoint returns 503.
+func (h *Handler) WithSLAService(svc *application.SLAService) *Handler {
+	h.slaSvc = svc
+	return h
+}
+
+// ListSLABreaches returns open PRs still waiting for a first review past their
+// repository's SLA, most overdue first.
+func (h *Handler) ListSLABreaches(w http.ResponseWriter, r *http.Request) {
+	if h.slaSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "SLA tracking not configured")
+		return
+	}
+
+	breaches, err := h.slaSvc.Breaches(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list SLA breaches", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	now := time.Now()
+	resp := make([]SLABreachResponse, 0, len(breaches))
+	for _, b := range breaches {
+		resp = append(resp, SLABreachResponse{
+			PR:           toPRResponse(b.PR),
+			SLAHours:     b.Status.Hours,
+			Deadline:     b.Status.Deadline.UTC().Format(time.RFC3339),
+			OverdueHours: int(now.Sub(b.Status.Deadline).Hours()),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
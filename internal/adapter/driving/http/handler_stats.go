@@ -0,0 +1,70 @@
+package httphandler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// ReviewerStatsResponse is the JSON representation of one leaderboard entry.
+type ReviewerStatsResponse struct {
+	Rank                   int    `json:"rank"`
+	Login                  string `json:"login"`
+	ReviewsGiven           int    `json:"reviews_given"`
+	PRsReviewed            int    `json:"prs_reviewed"`
+	AvgResponseTimeSeconds int64  `json:"avg_response_time_seconds"`
+	SuggestionsApplied     int    `json:"suggestions_applied"`
+}
+
+// WithTeamStatsService enables GET /api/v1/stats/reviewers. Without it, which
+// is the default until team stats are opted into, the endpoint returns 503.
+func (h *Handler) WithTeamStatsService(svc *application.TeamStatsService) *Handler {
+	h.teamStatsSvc = svc
+	return h
+}
+
+// ListReviewerStats returns the reviewer leaderboard over ?period= days (one
+// of 7, 30, 90, or 365; default 30), optionally for ?repo=owner/name.
+func (h *Handler) ListReviewerStats(w http.ResponseWriter, r *http.Request) {
+	if h.teamStatsSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "team stats not enabled")
+		return
+	}
+
+	days := application.DefaultTeamStatsPeriod
+	if v := r.URL.Query().Get("period"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || !application.IsTeamStatsPeriod(parsed) {
+			writeError(w, http.StatusBadRequest, "period must be 7, 30, 90, or 365")
+			return
+		}
+		days = parsed
+	}
+	repo := r.URL.Query().Get("repo")
+	if repo != "" && !validate.IsValidRepoName(repo) {
+		writeError(w, http.StatusBadRequest, "invalid repository name")
+		return
+	}
+
+	stats, err := h.teamStatsSvc.Leaderboard(r.Context(), days, repo)
+	if err != nil {
+		h.logger.Error("failed to compute reviewer stats", "period", days, "repo", repo, "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	resp := make([]ReviewerStatsResponse, 0, len(stats))
+	for i, s := range stats {
+		resp = append(resp, ReviewerStatsResponse{
+			Rank:                   i + 1,
+			Login:                  s.Login,
+			ReviewsGiven:           s.ReviewsGiven,
+			PRsReviewed:            s.PRsReviewed,
+			AvgResponseTimeSeconds: int64(s.AvgResponseTime.Seconds()),
+			SuggestionsApplied:     s.SuggestionsApplied,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,180 @@
+package httphandler
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// SystemResponse is the JSON representation of the system stats.
+type SystemResponse struct {
+	GeneratedAt string               `json:"generated_at"`
+	PRsByStatus map[string]int       `json:"prs_by_status"`
+	OpenPRsByCI map[string]int       `json:"open_prs_by_ci_status"`
+	Repos       []RepoSystemResponse `json:"repos"`
+	Outbox      OutboxStatsResponse  `json:"outbox"`
+}
+
+// RepoSystemResponse is a watched repository's PR count and latest poll result.
+type RepoSystemResponse struct {
+	Repository          string `json:"repository"`
+	Paused              bool   `json:"paused"`
+	OpenPRs             int    `json:"open_prs"`
+	Tier                string `json:"tier,omitempty"`
+	LastPolledAt        string `json:"last_polled_at,omitempty"`
+	NextPollAt          string `json:"next_poll_at,omitempty"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	LastFailedAt        string `json:"last_failed_at,omitempty"`
+}
+
+// OutboxStatsResponse counts the GitHub writes waiting in the outbox.
+type OutboxStatsResponse struct {
+	Pending int `json:"pending"`
+	Failed  int `json:"failed"`
+}
+
+// prometheusContentType is the Prometheus text exposition format.
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WithSystemService enables GET /api/v1/system. Without it the endpoint
+// returns 503.
+func (h *Handler) WithSystemService(svc *application.SystemService) *Handler {
+	h.systemSvc = svc
+	return h
+}
+
+// GetSystem handles GET /api/v1/system?format=. It reports PR counts by
+// status and CI state, each watched repository's latest poll, and the
+// outbox size, as JSON or, with format=prometheus, as Prometheus metrics.
+func (h *Handler) GetSystem(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "prometheus" {
+		writeError(w, http.StatusBadRequest, "invalid format: expected json or prometheus")
+		return
+	}
+	if h.systemSvc == nil {
+		writeError(w, http.StatusServiceUnavailable, "system stats not configured")
+		return
+	}
+
+	stats, err := h.systemSvc.Stats(r.Context())
+	if err != nil {
+		h.logger.Error("failed to compute system stats", "error", err)
+		writeError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	if format == "prometheus" {
+		w.Header().Set("Content-Type", prometheusContentType)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(prometheusMetrics(stats)))
+		return
+	}
+	writeJSON(w, http.StatusOK, toSystemResponse(stats))
+}
+
+// toSystemResponse converts system stats to their JSON representation.
+func toSystemResponse(stats application.SystemStats) SystemResponse {
+	resp := SystemResponse{
+		GeneratedAt: stats.GeneratedAt.UTC().Format(time.RFC3339),
+		PRsByStatus: make(map[string]int, len(stats.PRsByStatus)),
+		OpenPRsByCI: make(map[string]int, len(stats.OpenPRsByCI)),
+		Repos:       make([]RepoSystemResponse, 0, len(stats.Repos)),
+		Outbox:      OutboxStatsResponse{Pending: stats.OutboxPending, Failed: stats.OutboxFailed},
+	}
+	for status, n := range stats.PRsByStatus {
+		resp.PRsByStatus[string(status)] = n
+	}
+	for status, n := range stats.OpenPRsByCI {
+		resp.OpenPRsByCI[string(status)] = n
+	}
+	for _, repo := range stats.Repos {
+		r := RepoSystemResponse{
+			Repository:          repo.FullName,
+			Paused:              repo.Paused,
+			OpenPRs:             repo.OpenPRs,
+			ConsecutiveFailures: repo.ConsecutiveFailures,
+			LastError:           repo.LastError,
+			LastPolledAt:        formatOptionalTime(repo.LastPolledAt),
+			NextPollAt:          formatOptionalTime(repo.NextPollAt),
+			LastFailedAt:        formatOptionalTime(repo.LastFailedAt),
+		}
+		if !repo.LastPolledAt.IsZero() {
+			r.Tier = repo.Tier.String()
+		}
+		resp.Repos = append(resp.Repos, r)
+	}
+	return resp
+}
+
+// formatOptionalTime formats t as RFC 3339 in UTC, or returns "" when t is zero.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// prometheusMetrics renders system stats as gauges in the Prometheus text
+// exposition format.
+func prometheusMetrics(stats application.SystemStats) string {
+	var b strings.Builder
+	gauge := func(name, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+	}
+	sample := func(name, label, value string, n float64) {
+		fmt.Fprintf(&b, "%s{%s=\"%s\"} %g\n", name, label, prometheusLabelEscaper.Replace(value), n)
+	}
+
+	gauge("mygitpanel_prs", "Stored pull requests by status.")
+	for _, status := range sortedKeys(stats.PRsByStatus) {
+		sample("mygitpanel_prs", "status", string(status), float64(stats.PRsByStatus[status]))
+	}
+
+	gauge("mygitpanel_open_prs", "Open pull requests by CI status.")
+	for _, status := range sortedKeys(stats.OpenPRsByCI) {
+		sample("mygitpanel_open_prs", "ci_status", string(status), float64(stats.OpenPRsByCI[status]))
+	}
+
+	gauge("mygitpanel_repo_open_prs", "Open pull requests per watched repository.")
+	for _, repo := range stats.Repos {
+		sample("mygitpanel_repo_open_prs", "repo", repo.FullName, float64(repo.OpenPRs))
+	}
+
+	gauge("mygitpanel_repo_last_poll_timestamp_seconds", "Unix time of each repository's last successful poll.")
+	for _, repo := range stats.Repos {
+		if !repo.LastPolledAt.IsZero() {
+			sample("mygitpanel_repo_last_poll_timestamp_seconds", "repo", repo.FullName, float64(repo.LastPolledAt.Unix()))
+		}
+	}
+
+	gauge("mygitpanel_repo_poll_failures", "Consecutive failed polls per repository.")
+	for _, repo := range stats.Repos {
+		sample("mygitpanel_repo_poll_failures", "repo", repo.FullName, float64(repo.ConsecutiveFailures))
+	}
+
+	gauge("mygitpanel_outbox_actions", "GitHub writes waiting in the outbox by status.")
+	sample("mygitpanel_outbox_actions", "status", "failed", float64(stats.OutboxFailed))
+	sample("mygitpanel_outbox_actions", "status", "pending", float64(stats.OutboxPending))
+
+	return b.String()
+}
+
+// prometheusLabelEscaper escapes a Prometheus label value.
+var prometheusLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// sortedKeys returns the keys of counts in sorted order, so metrics render
+// in a stable order.
+func sortedKeys[K ~string](counts map[K]int) []K {
+	keys := make([]K, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -1,30 +1,37 @@
 package httphandler_test
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/andybalholm/brotli"
 	httphandler "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/http"
 	"github.com/ericfisherdev/mygitpanel/internal/application"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
 )
 
 // --- Mock implementations ---
 
 type mockPRStore struct {
-	prs []model.PullRequest
-	pr  *model.PullRequest
-	err error
+	prs     []model.PullRequest
+	pr      *model.PullRequest
+	ignored []model.PullRequest
+	err     error
 }
 
 func (m *mockPRStore) Upsert(_ context.Context, _ model.PullRequest) error { return nil }
@@ -44,12 +51,25 @@ func (m *mockPRStore) ListNeedingReview(_ context.Context) ([]model.PullRequest,
 	return m.prs, m.err
 }
 func (m *mockPRStore) ListIgnoredWithPRData(_ context.Context) ([]model.PullRequest, error) {
-	return nil, nil
+	return m.ignored, nil
+}
+func (m *mockPRStore) CountByStatus(_ context.Context) ([]driven.PRStatusCount, error) {
+	counts := make(map[driven.PRStatusCount]int)
+	for _, pr := range m.prs {
+		counts[driven.PRStatusCount{RepoFullName: pr.RepoFullName, Status: pr.Status, CIStatus: pr.CIStatus}]++
+	}
+	result := make([]driven.PRStatusCount, 0, len(counts))
+	for key, n := range counts {
+		key.Count = n
+		result = append(result, key)
+	}
+	return result, m.err
 }
 func (m *mockPRStore) Delete(_ context.Context, _ string, _ int) error { return nil }
 
 type mockRepoStore struct {
 	repos     []model.Repository
+	repo      *model.Repository
 	err       error
 	addErr    error
 	removeErr error
@@ -64,7 +84,7 @@ func (m *mockRepoStore) Remove(_ context.Context, _ string) error {
 	return m.removeErr
 }
 func (m *mockRepoStore) GetByFullName(_ context.Context, _ string) (*model.Repository, error) {
-	return nil, nil
+	return m.repo, m.err
 }
 func (m *mockRepoStore) ListAll(_ context.Context) ([]model.Repository, error) {
 	return m.repos, m.err
@@ -73,9 +93,11 @@ func (m *mockRepoStore) ListAll(_ context.Context) ([]model.Repository, error) {
 type mockBotConfigStore struct {
 	bots      []model.BotConfig
 	usernames []string
+	patterns  map[string][]string
 	err       error
 	addErr    error
 	removeErr error
+	updateErr error
 }
 
 func (m *mockBotConfigStore) Add(_ context.Context, bot model.BotConfig) (model.BotConfig, error) {
@@ -84,6 +106,13 @@ func (m *mockBotConfigStore) Add(_ context.Context, bot model.BotConfig) (model.
 func (m *mockBotConfigStore) Remove(_ context.Context, _ string) error {
 	return m.removeErr
 }
+func (m *mockBotConfigStore) SetNitpickPatterns(_ context.Context, username string, patterns []string) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.patterns = map[string][]string{username: patterns}
+	return nil
+}
 func (m *mockBotConfigStore) ListAll(_ context.Context) ([]model.BotConfig, error) {
 	return m.bots, m.err
 }
@@ -104,6 +133,18 @@ func (m *mockCheckStore) GetCheckRunsByPR(_ context.Context, _ int64) ([]model.C
 	return m.checkRuns, m.err
 }
 
+func (m *mockCheckStore) GetCheckRunsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.CheckRun, error) {
+	result := make(map[int64][]model.CheckRun, len(prIDs))
+	for _, id := range prIDs {
+		runs, err := m.GetCheckRunsByPR(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = runs
+	}
+	return result, nil
+}
+
 // mockReviewStore implements driven.ReviewStore for handler tests.
 type mockReviewStore struct {
 	reviews        []model.Review
@@ -121,6 +162,18 @@ func (m *mockReviewStore) UpsertIssueComment(_ context.Context, _ model.IssueCom
 func (m *mockReviewStore) GetReviewsByPR(_ context.Context, _ int64) ([]model.Review, error) {
 	return m.reviews, nil
 }
+
+func (m *mockReviewStore) GetReviewsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.Review, error) {
+	result := make(map[int64][]model.Review, len(prIDs))
+	for _, id := range prIDs {
+		reviews, err := m.GetReviewsByPR(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = reviews
+	}
+	return result, nil
+}
 func (m *mockReviewStore) GetReviewCommentsByPR(_ context.Context, _ int64) ([]model.ReviewComment, error) {
 	return m.reviewComments, nil
 }
@@ -139,6 +192,36 @@ func (m *errReviewStore) GetReviewsByPR(_ context.Context, _ int64) ([]model.Rev
 	return nil, errors.New("review store error")
 }
 
+func (m *errReviewStore) GetReviewsByPRs(_ context.Context, _ []int64) (map[int64][]model.Review, error) {
+	return nil, errors.New("review store error")
+}
+
+// mockAPITokenStore is an in-memory driven.APITokenStore keyed by hash.
+type mockAPITokenStore struct {
+	tokens map[string]model.APIToken
+}
+
+func (m *mockAPITokenStore) Create(_ context.Context, token model.APIToken, hash string) (int64, error) {
+	if m.tokens == nil {
+		m.tokens = make(map[string]model.APIToken)
+	}
+	token.ID = int64(len(m.tokens) + 1)
+	m.tokens[hash] = token
+	return token.ID, nil
+}
+func (m *mockAPITokenStore) List(_ context.Context) ([]model.APIToken, error) { return nil, nil }
+func (m *mockAPITokenStore) Delete(_ context.Context, _ int64) error          { return nil }
+func (m *mockAPITokenStore) GetByHash(_ context.Context, hash string) (*model.APIToken, error) {
+	if t, ok := m.tokens[hash]; ok {
+		return &t, nil
+	}
+	return nil, nil
+}
+func (m *mockAPITokenStore) Count(_ context.Context) (int, error) { return len(m.tokens), nil }
+func (m *mockAPITokenStore) TouchLastUsed(_ context.Context, _ int64, _ time.Time) error {
+	return nil
+}
+
 // --- Test helpers ---
 
 var (
@@ -221,6 +304,9 @@ func TestListPRs(t *testing.T) {
 					Labels:       []string{"bug", "urgent"},
 					OpenedAt:     testTime,
 					UpdatedAt:    testTime,
+					ReviewRequests: []model.ReviewRequest{
+						{Reviewer: "core", IsTeam: true, RequestedAt: testTime},
+					},
 				},
 				{
 					Number:       43,
@@ -263,6 +349,7 @@ func TestListPRs(t *testing.T) {
 				issueComments, ok := pr["issue_comments"].([]any)
 				require.True(t, ok)
 				assert.Len(t, issueComments, 0)
+				assert.Equal(t, []any{map[string]any{"reviewer": "core", "team": true, "requested_at": testTimeStr}}, pr["review_requests"])
 			},
 		},
 		{
@@ -295,6 +382,49 @@ func TestListPRs(t *testing.T) {
 	}
 }
 
+func TestListPRs_Filters(t *testing.T) {
+	prStore := &mockPRStore{prs: []model.PullRequest{
+		{Number: 1, Status: model.PRStatusOpen, ReviewRollup: model.ReviewRollup{Status: model.ReviewStateApproved, Approvals: 2}},
+		{Number: 2, Status: model.PRStatusOpen, ReviewRollup: model.ReviewRollup{Status: model.ReviewStatePending}},
+		{Number: 3, Status: model.PRStatusMerged, ReviewRollup: model.ReviewRollup{Status: model.ReviewStateApproved, Approvals: 1}},
+	}}
+	mux := setupMux(prStore, &mockRepoStore{})
+
+	tests := []struct {
+		query       string
+		wantStatus  int
+		wantNumbers []float64
+	}{
+		{query: "", wantStatus: http.StatusOK, wantNumbers: []float64{1, 2, 3}},
+		{query: "?status=open&review_status=approved", wantStatus: http.StatusOK, wantNumbers: []float64{1}},
+		{query: "?review_status=approved", wantStatus: http.StatusOK, wantNumbers: []float64{1, 3}},
+		{query: "?status=draft", wantStatus: http.StatusBadRequest},
+		{query: "?review_status=dismissed", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/prs"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp []map[string]any
+			decodeJSON(t, rec, &resp)
+			numbers := make([]float64, 0, len(resp))
+			for _, pr := range resp {
+				numbers = append(numbers, pr["number"].(float64))
+			}
+			assert.Equal(t, tt.wantNumbers, numbers)
+			if len(resp) > 0 && tt.query != "" {
+				assert.Equal(t, "approved", resp[0]["review_status"])
+			}
+		})
+	}
+}
+
 func TestGetPR(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -400,179 +530,1435 @@ func TestListPRsNeedingAttention(t *testing.T) {
 			req := httptest.NewRequest(http.MethodGet, "/api/v1/prs/attention", nil)
 			rec := httptest.NewRecorder()
 
-			mux.ServeHTTP(rec, req)
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var resp []map[string]any
+			decodeJSON(t, rec, &resp)
+			assert.Len(t, resp, tt.wantLen)
+		})
+	}
+}
+
+func TestListRepos(t *testing.T) {
+	tests := []struct {
+		name       string
+		repoStore  *mockRepoStore
+		wantStatus int
+		wantLen    int
+	}{
+		{
+			name:       "empty",
+			repoStore:  &mockRepoStore{repos: nil},
+			wantStatus: http.StatusOK,
+			wantLen:    0,
+		},
+		{
+			name: "two repos",
+			repoStore: &mockRepoStore{repos: []model.Repository{
+				{FullName: "owner/repo1", Owner: "owner", Name: "repo1", AddedAt: testTime},
+				{FullName: "owner/repo2", Owner: "owner", Name: "repo2", AddedAt: testTime},
+			}},
+			wantStatus: http.StatusOK,
+			wantLen:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := setupMux(&mockPRStore{}, tt.repoStore)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/repos", nil)
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var resp []map[string]any
+			decodeJSON(t, rec, &resp)
+			assert.Len(t, resp, tt.wantLen)
+
+			if tt.wantLen == 2 {
+				assert.Equal(t, "owner/repo1", resp[0]["full_name"])
+				assert.Equal(t, "owner", resp[0]["owner"])
+				assert.Equal(t, "repo1", resp[0]["name"])
+				assert.Equal(t, testTimeStr, resp[0]["added_at"])
+			}
+		})
+	}
+}
+
+func TestAddRepo(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		repoStore    *mockRepoStore
+		wantStatus   int
+		wantError    string
+		wantProvider string
+	}{
+		{
+			name:         "valid",
+			body:         `{"full_name": "owner/repo"}`,
+			repoStore:    &mockRepoStore{},
+			wantStatus:   http.StatusCreated,
+			wantProvider: "github",
+		},
+		{
+			name:       "invalid format - no slash",
+			body:       `{"full_name": "invalid"}`,
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid repository name: expected owner/repo format",
+		},
+		{
+			name:       "invalid format - empty owner",
+			body:       `{"full_name": "/repo"}`,
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid repository name: expected owner/repo format",
+		},
+		{
+			name:       "invalid format - extra slashes",
+			body:       `{"full_name": "a/b/c"}`,
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid repository name: expected owner/repo format",
+		},
+		{
+			name:       "duplicate",
+			body:       `{"full_name": "owner/repo"}`,
+			repoStore:  &mockRepoStore{addErr: driven.ErrRepoAlreadyExists},
+			wantStatus: http.StatusConflict,
+			wantError:  "repository already exists",
+		},
+		{
+			name:         "bitbucket",
+			body:         `{"full_name": "owner/repo", "provider": "bitbucket"}`,
+			repoStore:    &mockRepoStore{},
+			wantStatus:   http.StatusCreated,
+			wantProvider: "bitbucket",
+		},
+		{
+			name:       "unknown provider",
+			body:       `{"full_name": "owner/repo", "provider": "gitea"}`,
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid provider: expected github or bitbucket",
+		},
+		{
+			name:       "invalid JSON",
+			body:       `not json`,
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusBadRequest,
+			wantError:  "invalid request body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := setupMux(&mockPRStore{}, tt.repoStore)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/repos", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantStatus == http.StatusCreated {
+				var resp map[string]any
+				decodeJSON(t, rec, &resp)
+				assert.Equal(t, "owner/repo", resp["full_name"])
+				assert.Equal(t, "owner", resp["owner"])
+				assert.Equal(t, "repo", resp["name"])
+				assert.NotEmpty(t, resp["added_at"])
+				assert.Equal(t, tt.wantProvider, resp["provider"])
+			}
+
+			if tt.wantError != "" {
+				var resp map[string]any
+				decodeJSON(t, rec, &resp)
+				assert.Equal(t, tt.wantError, resp["error"])
+			}
+		})
+	}
+}
+
+func TestRemoveRepo(t *testing.T) {
+	tests := []struct {
+		name       string
+		repoStore  *mockRepoStore
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "success",
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "not found",
+			repoStore:  &mockRepoStore{removeErr: driven.ErrRepoNotFound},
+			wantStatus: http.StatusNotFound,
+			wantError:  "repository not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := setupMux(&mockPRStore{}, tt.repoStore)
+			req := httptest.NewRequest(http.MethodDelete, "/api/v1/repos/owner/repo", nil)
+			rec := httptest.NewRecorder()
+
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			if tt.wantStatus == http.StatusNoContent {
+				assert.Empty(t, rec.Body.String())
+			}
+
+			if tt.wantError != "" {
+				var resp map[string]any
+				decodeJSON(t, rec, &resp)
+				assert.Equal(t, tt.wantError, resp["error"])
+			}
+		})
+	}
+}
+
+type mockRepoTrash struct {
+	trashed  []model.Repository
+	restored string
+}
+
+func (m *mockRepoTrash) Trash(_ context.Context, fullName string, at time.Time) error {
+	m.trashed = append(m.trashed, model.Repository{FullName: fullName, RemovedAt: at})
+	return nil
+}
+func (m *mockRepoTrash) Restore(_ context.Context, fullName string) error {
+	if len(m.trashed) == 0 || m.trashed[0].FullName != fullName {
+		return driven.ErrRepoNotFound
+	}
+	m.restored = fullName
+	return nil
+}
+func (m *mockRepoTrash) ListTrashed(_ context.Context) ([]model.Repository, error) {
+	return m.trashed, nil
+}
+func (m *mockRepoTrash) PurgeTrashed(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestRepoTrash(t *testing.T) {
+	trash := &mockRepoTrash{}
+	repoStore := &mockRepoStore{
+		repo:      &model.Repository{FullName: "owner/repo", Owner: "owner", Name: "repo"},
+		removeErr: errors.New("repo must not be deleted outright"),
+	}
+	h := httphandler.NewHandler(&mockPRStore{}, repoStore, nil, nil, nil, nil, "testuser", slog.Default()).
+		WithRepoTrashService(application.NewRepoTrashService(trash, 7*24*time.Hour))
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/repos/owner/repo", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Len(t, trash.trashed, 1)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/repos/removed", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var removed []map[string]any
+	decodeJSON(t, rec, &removed)
+	require.Len(t, removed, 1)
+	assert.Equal(t, "owner/repo", removed[0]["full_name"])
+	removedAt, err := time.Parse(time.RFC3339, removed[0]["removed_at"].(string))
+	require.NoError(t, err)
+	purgeAt, err := time.Parse(time.RFC3339, removed[0]["purge_at"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, 7*24*time.Hour, purgeAt.Sub(removedAt))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/repos/owner/repo/restore", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "owner/repo", trash.restored)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/repos/other/repo/restore", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+type mockIgnoreStore struct {
+	ignored []driven.IgnoredPR
+}
+
+func (m *mockIgnoreStore) Ignore(_ context.Context, prID int64, reason string) error {
+	m.ignored = append(m.ignored, driven.IgnoredPR{PRID: prID, Reason: reason, IgnoredAt: time.Now()})
+	return nil
+}
+func (m *mockIgnoreStore) Unignore(_ context.Context, prID int64) error {
+	m.ignored = slices.DeleteFunc(m.ignored, func(item driven.IgnoredPR) bool { return item.PRID == prID })
+	return nil
+}
+func (m *mockIgnoreStore) IsIgnored(context.Context, int64) (bool, error) { return false, nil }
+func (m *mockIgnoreStore) ListIgnored(context.Context) ([]driven.IgnoredPR, error) {
+	return m.ignored, nil
+}
+func (m *mockIgnoreStore) ListIgnoredIDs(context.Context) (map[int64]struct{}, error) {
+	return nil, nil
+}
+
+func TestIgnorePRs(t *testing.T) {
+	pr := model.PullRequest{ID: 7, Number: 42, RepoFullName: "owner/repo", Title: "Bump deps"}
+	prStore := &mockPRStore{pr: &pr, ignored: []model.PullRequest{pr}}
+	h := httphandler.NewHandler(prStore, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/prs/ignored", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	store := &mockIgnoreStore{}
+	h.WithIgnoreStore(store)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/v1/repos/owner/repo/prs/42/ignore",
+		strings.NewReader(`{"reason":"  waiting on upstream "}`)))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Len(t, store.ignored, 1)
+	assert.Equal(t, int64(7), store.ignored[0].PRID)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/prs/ignored", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var ignored []httphandler.IgnoredPRResponse
+	decodeJSON(t, rec, &ignored)
+	require.Len(t, ignored, 1)
+	assert.Equal(t, 42, ignored[0].Number)
+	assert.Equal(t, "owner/repo", ignored[0].Repository)
+	assert.Equal(t, "waiting on upstream", ignored[0].Reason)
+	assert.NotEmpty(t, ignored[0].IgnoredAt)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/api/v1/repos/owner/repo/prs/42/ignore", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, store.ignored)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/v1/repos/owner/repo/prs/42/ignore",
+		strings.NewReader(`{"reason":"`+strings.Repeat("x", 501)+`"}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	prStore.pr = nil
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/v1/repos/owner/repo/prs/43/ignore", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+type mockIgnoreRuleStore struct {
+	rules []model.IgnoreRule
+}
+
+func (m *mockIgnoreRuleStore) Create(_ context.Context, rule model.IgnoreRule) (int64, error) {
+	rule.ID = int64(len(m.rules) + 1)
+	m.rules = append(m.rules, rule)
+	return rule.ID, nil
+}
+
+func (m *mockIgnoreRuleStore) Update(_ context.Context, rule model.IgnoreRule) error {
+	for i := range m.rules {
+		if m.rules[i].ID == rule.ID {
+			m.rules[i] = rule
+			return nil
+		}
+	}
+	return driven.ErrIgnoreRuleNotFound
+}
+
+func (m *mockIgnoreRuleStore) Delete(_ context.Context, id int64) error {
+	for i := range m.rules {
+		if m.rules[i].ID == id {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrIgnoreRuleNotFound
+}
+
+func (m *mockIgnoreRuleStore) List(_ context.Context) ([]model.IgnoreRule, error) {
+	return m.rules, nil
+}
+
+func TestIgnoreRules(t *testing.T) {
+	prStore := &mockPRStore{prs: []model.PullRequest{
+		{ID: 1, Number: 1, RepoFullName: "owner/repo", Author: "dependabot[bot]"},
+		{ID: 2, Number: 2, RepoFullName: "owner/repo", Author: "alice"},
+	}}
+	ignores := &mockIgnoreStore{}
+	h := httphandler.NewHandler(prStore, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/api/v1/ignore/rules", "").Code)
+	h.WithIgnoreRuleService(application.NewIgnoreRuleService(&mockIgnoreRuleStore{}, ignores, prStore, "testuser"))
+
+	rec := serve(http.MethodPost, "/api/v1/ignore/rules", `{"name": "Bots", "expression": "author == \"dependabot[bot]\""}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created httphandler.IgnoreRuleResponse
+	decodeJSON(t, rec, &created)
+	assert.Equal(t, "Bots", created.Name)
+	assert.True(t, created.Enabled, "rules are enabled by default")
+	require.Len(t, ignores.ignored, 1, "the new rule is applied to the queue")
+	assert.Equal(t, int64(1), ignores.ignored[0].PRID)
+	assert.Equal(t, "rule: Bots", ignores.ignored[0].Reason)
+
+	rec = serve(http.MethodPost, "/api/v1/ignore/rules", `{"name": "Bad", "expression": "ci_failure"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "attention signal ci_failure")
+
+	rec = serve(http.MethodPut, "/api/v1/ignore/rules/1", `{"name": "Mine", "expression": "author == me", "enabled": false}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/api/v1/ignore/rules/9", `{"name": "x", "expression": "draft"}`).Code)
+
+	rec = serve(http.MethodGet, "/api/v1/ignore/rules", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var rules []httphandler.IgnoreRuleResponse
+	decodeJSON(t, rec, &rules)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "author == me", rules[0].Expression)
+	assert.False(t, rules[0].Enabled)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/api/v1/ignore/rules/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/api/v1/ignore/rules/1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodDelete, "/api/v1/ignore/rules/x", "").Code)
+}
+
+type mockRepoSettingsStore struct {
+	settings   map[string]model.RepoSettings
+	thresholds *mockThresholdStore
+	unwatched  string // ReplaceRepoSettings returns ErrRepoNotFound for it
+}
+
+func (m *mockRepoSettingsStore) GetRepoSettings(_ context.Context, repoFullName string) (model.RepoSettings, error) {
+	if s, ok := m.settings[repoFullName]; ok {
+		return s, nil
+	}
+	return model.RepoSettings{RepoFullName: repoFullName}, nil
+}
+func (m *mockRepoSettingsStore) ListRepoSettings(context.Context, []string) (map[string]model.RepoSettings, error) {
+	return m.settings, nil
+}
+func (m *mockRepoSettingsStore) SetRepoSettings(_ context.Context, settings model.RepoSettings) error {
+	m.settings[settings.RepoFullName] = settings
+	return nil
+}
+func (m *mockRepoSettingsStore) ReplaceRepoSettings(ctx context.Context, settings model.RepoSettings, threshold model.RepoThreshold) error {
+	if settings.RepoFullName == m.unwatched {
+		return driven.ErrRepoNotFound
+	}
+	m.settings[settings.RepoFullName] = settings
+	if threshold == (model.RepoThreshold{RepoFullName: threshold.RepoFullName}) {
+		return m.thresholds.DeleteRepoThreshold(ctx, threshold.RepoFullName)
+	}
+	return m.thresholds.SetRepoThreshold(ctx, threshold)
+}
+
+type mockThresholdStore struct {
+	global model.GlobalSettings
+	repos  map[string]model.RepoThreshold
+}
+
+func (m *mockThresholdStore) GetGlobalSettings(context.Context) (model.GlobalSettings, error) {
+	return m.global, nil
+}
+func (m *mockThresholdStore) SetGlobalSettings(_ context.Context, settings model.GlobalSettings) error {
+	m.global = settings
+	return nil
+}
+func (m *mockThresholdStore) GetRepoThreshold(_ context.Context, repoFullName string) (model.RepoThreshold, error) {
+	return m.repos[repoFullName], nil
+}
+func (m *mockThresholdStore) SetRepoThreshold(_ context.Context, threshold model.RepoThreshold) error {
+	m.repos[threshold.RepoFullName] = threshold
+	return nil
+}
+func (m *mockThresholdStore) DeleteRepoThreshold(_ context.Context, repoFullName string) error {
+	delete(m.repos, repoFullName)
+	return nil
+}
+
+func TestRepoSettings(t *testing.T) {
+	repoStore := &mockRepoStore{repo: &model.Repository{FullName: "owner/repo", Owner: "owner", Name: "repo"}}
+	h := httphandler.NewHandler(&mockPRStore{}, repoStore, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/api/v1/repos/owner/repo/settings", "").Code)
+
+	thresholds := &mockThresholdStore{repos: map[string]model.RepoThreshold{}}
+	settingsStore := &mockRepoSettingsStore{settings: map[string]model.RepoSettings{}, thresholds: thresholds}
+	h.WithRepoSettingsStore(settingsStore).WithThresholdStore(thresholds)
+
+	rec := serve(http.MethodPut, "/api/v1/repos/owner/repo/settings", `{
+		"required_checks": ["build", " Build ", ""],
+		"ignored_checks": ["codecov"],
+		"stale": {"after_days": 14, "action": "comment", "exempt_labels": ["pinned"]},
+		"pr_scope": "relevant",
+		"thresholds": {"review_count": 2, "ci_failure_enabled": false}
+	}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	saved := settingsStore.settings["owner/repo"]
+	assert.Equal(t, []string{"build"}, saved.RequiredChecks)
+	assert.Equal(t, model.StaleActionComment, saved.Stale.Action)
+	assert.Equal(t, model.PRScopeRelevant, saved.PRScope)
+	require.NotNil(t, thresholds.repos["owner/repo"].ReviewCount)
+	assert.Equal(t, 2, *thresholds.repos["owner/repo"].ReviewCount)
+	assert.Nil(t, thresholds.repos["owner/repo"].AgeUrgencyDays)
+
+	rec = serve(http.MethodGet, "/api/v1/repos/owner/repo/settings", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got httphandler.RepoSettingsResponse
+	decodeJSON(t, rec, &got)
+	assert.Equal(t, "owner/repo", got.RepoFullName)
+	assert.Equal(t, []string{"codecov"}, got.IgnoredChecks)
+	assert.Equal(t, []string{}, got.OptionalChecks)
+	assert.Equal(t, 14, got.Stale.AfterDays)
+	require.NotNil(t, got.Thresholds.CIFailureEnabled)
+	assert.False(t, *got.Thresholds.CIFailureEnabled)
+
+	rec = serve(http.MethodPut, "/api/v1/repos/owner/repo/settings", `{"required_checks": ["lint"], "optional_checks": ["LINT"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = serve(http.MethodPut, "/api/v1/repos/owner/repo/settings", `{"pr_scope": "everything"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	require.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/api/v1/repos/owner/repo/settings", "").Code)
+	assert.Empty(t, settingsStore.settings["owner/repo"].RequiredChecks)
+	assert.NotContains(t, thresholds.repos, "owner/repo")
+
+	repoStore.repo = nil
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/repos/other/repo/settings", "").Code)
+}
+
+func TestGlobalThresholds(t *testing.T) {
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, "/api/v1/settings/thresholds", strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "").Code)
+
+	store := &mockThresholdStore{global: model.DefaultGlobalSettings()}
+	h.WithThresholdStore(store)
+
+	rec := serve(http.MethodPut, `{"review_count_threshold": 3, "age_urgency_days": 5, "checklist_enabled": true}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 3, store.global.ReviewCountThreshold)
+	assert.True(t, store.global.ChecklistEnabled)
+	assert.False(t, store.global.CIFailureEnabled, "PUT replaces every threshold")
+
+	rec = serve(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got httphandler.GlobalThresholdsResponse
+	decodeJSON(t, rec, &got)
+	assert.Equal(t, 5, got.AgeUrgencyDays)
+
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPut, `{"age_urgency_days": -1}`).Code)
+
+	require.Equal(t, http.StatusOK, serve(http.MethodDelete, "").Code)
+	assert.Equal(t, model.DefaultGlobalSettings(), store.global)
+}
+
+func TestSettingsExportImport(t *testing.T) {
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "owner/repo"}, {FullName: "owner/plain"}}}
+	h := httphandler.NewHandler(&mockPRStore{}, repoStore, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	serve := func(method, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, "/api/v1/settings", strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "").Code)
+
+	reviews := 2
+	thresholds := &mockThresholdStore{
+		global: model.DefaultGlobalSettings(),
+		repos:  map[string]model.RepoThreshold{"owner/repo": {RepoFullName: "owner/repo", ReviewCount: &reviews}},
+	}
+	settingsStore := &mockRepoSettingsStore{
+		settings:   map[string]model.RepoSettings{"owner/repo": {RepoFullName: "owner/repo", RequiredChecks: []string{"build"}}},
+		thresholds: thresholds,
+		unwatched:  "owner/gone",
+	}
+	h.WithRepoSettingsStore(settingsStore).WithThresholdStore(thresholds)
+
+	rec := serve(http.MethodGet, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var exported httphandler.SettingsExportResponse
+	decodeJSON(t, rec, &exported)
+	assert.Equal(t, model.DefaultGlobalSettings().ReviewCountThreshold, exported.Thresholds.ReviewCountThreshold)
+	require.Len(t, exported.Repos, 2, "every watched repo is exported")
+	assert.Equal(t, []string{"build"}, exported.Repos[0].RequiredChecks)
+	require.NotNil(t, exported.Repos[0].Thresholds.ReviewCount)
+	assert.Equal(t, "owner/plain", exported.Repos[1].RepoFullName)
+	assert.Equal(t, []string{}, exported.Repos[1].RequiredChecks)
+
+	// Replaying the export, edited, restores it; unwatched repos are skipped.
+	exported.Thresholds.ReviewCountThreshold = 4
+	exported.Repos[0].RequiredChecks = []string{"test"}
+	exported.Repos = append(exported.Repos, httphandler.RepoSettingsResponse{RepoFullName: "owner/gone"})
+	body, err := json.Marshal(exported)
+	require.NoError(t, err)
+	rec = serve(http.MethodPost, string(body))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var imported httphandler.ImportSettingsResponse
+	decodeJSON(t, rec, &imported)
+	assert.Equal(t, httphandler.ImportSettingsResponse{Imported: 2, Skipped: 1}, imported)
+	assert.Equal(t, 4, thresholds.global.ReviewCountThreshold)
+	assert.Equal(t, []string{"test"}, settingsStore.settings["owner/repo"].RequiredChecks)
+	require.NotNil(t, thresholds.repos["owner/repo"].ReviewCount)
+	assert.Equal(t, 2, *thresholds.repos["owner/repo"].ReviewCount)
+
+	rec = serve(http.MethodPost, `{"thresholds": {"age_urgency_days": 9}, "repos": [{"repo_full_name": "owner/repo", "pr_scope": "everything"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "owner/repo: pr_scope")
+	assert.Equal(t, 4, thresholds.global.ReviewCountThreshold, "nothing is written when an entry is invalid")
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, `{"repos": [{"repo_full_name": "nope"}]}`).Code)
+}
+
+type mockOrphanSweeper struct {
+	deleted map[string]int
+}
+
+func (m *mockOrphanSweeper) SweepOrphans(context.Context) (map[string]int, error) {
+	return m.deleted, nil
+}
+
+func TestSweepOrphans(t *testing.T) {
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/orphans/sweep", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	h.WithOrphanSweeper(&mockOrphanSweeper{deleted: map[string]int{"pull_requests": 2, "reviews": 5}})
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/admin/orphans/sweep", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp httphandler.OrphanSweepResponse
+	decodeJSON(t, rec, &resp)
+	assert.Equal(t, map[string]int{"pull_requests": 2, "reviews": 5}, resp.Deleted)
+	assert.Equal(t, 7, resp.Total)
+}
+
+func TestGetSystem(t *testing.T) {
+	prStore := &mockPRStore{prs: []model.PullRequest{
+		{RepoFullName: "org/repo", Status: model.PRStatusOpen, CIStatus: model.CIStatusFailing},
+		{RepoFullName: "org/repo", Status: model.PRStatusMerged},
+	}}
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+	h := httphandler.NewHandler(prStore, repoStore, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/system", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	h.WithSystemService(application.NewSystemService(prStore, repoStore, nil))
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/system", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp httphandler.SystemResponse
+	decodeJSON(t, rec, &resp)
+	assert.Equal(t, map[string]int{"open": 1, "merged": 1}, resp.PRsByStatus)
+	assert.Equal(t, map[string]int{"failing": 1}, resp.OpenPRsByCI)
+	assert.Equal(t, []httphandler.RepoSystemResponse{{Repository: "org/repo", OpenPRs: 1}}, resp.Repos)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/system?format=prometheus", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	assert.Contains(t, body, "# TYPE mygitpanel_prs gauge\n")
+	assert.Contains(t, body, `mygitpanel_prs{status="merged"} 1`+"\n")
+	assert.Contains(t, body, `mygitpanel_open_prs{ci_status="failing"} 1`+"\n")
+	assert.Contains(t, body, `mygitpanel_repo_open_prs{repo="org/repo"} 1`+"\n")
+	assert.NotContains(t, body, "mygitpanel_repo_last_poll_timestamp_seconds{", "never polled")
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/system?format=xml", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+type mockAttentionRuleStore struct {
+	rules []model.AttentionRule
+}
+
+func (m *mockAttentionRuleStore) Create(_ context.Context, rule model.AttentionRule) (int64, error) {
+	rule.ID = int64(len(m.rules) + 1)
+	m.rules = append(m.rules, rule)
+	return rule.ID, nil
+}
+
+func (m *mockAttentionRuleStore) Update(_ context.Context, rule model.AttentionRule) error {
+	for i := range m.rules {
+		if m.rules[i].ID == rule.ID {
+			m.rules[i] = rule
+			return nil
+		}
+	}
+	return driven.ErrAttentionRuleNotFound
+}
+
+func (m *mockAttentionRuleStore) Delete(_ context.Context, id int64) error {
+	for i := range m.rules {
+		if m.rules[i].ID == id {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrAttentionRuleNotFound
+}
+
+func (m *mockAttentionRuleStore) List(_ context.Context) ([]model.AttentionRule, error) {
+	return m.rules, nil
+}
+
+func TestAttentionRules(t *testing.T) {
+	store := &mockAttentionRuleStore{}
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/api/v1/attention/rules", "").Code)
+	h.WithAttentionRuleService(application.NewAttentionRuleService(store))
+
+	rec := serve(http.MethodPost, "/api/v1/attention/rules",
+		`{"name": "My red builds", "expression": "ci_status == \"failing\" && author == me", "severity": 5}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var created httphandler.AttentionRuleResponse
+	decodeJSON(t, rec, &created)
+	assert.Equal(t, "My red builds", created.Name)
+	assert.True(t, created.Enabled, "rules are enabled by default")
+
+	rec = serve(http.MethodPost, "/api/v1/attention/rules", `{"name": "Bad", "expression": "ci == 1", "severity": 1}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `unknown field \"ci\"`)
+
+	rec = serve(http.MethodPut, "/api/v1/attention/rules/1", `{"name": "Mine", "expression": "ci_failure", "severity": 2, "enabled": false}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/api/v1/attention/rules/9", `{"name": "x", "expression": "draft", "severity": 1}`).Code)
+
+	rec = serve(http.MethodGet, "/api/v1/attention/rules", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var rules []httphandler.AttentionRuleResponse
+	decodeJSON(t, rec, &rules)
+	require.Len(t, rules, 1)
+	assert.Equal(t, "ci_failure", rules[0].Expression)
+	assert.False(t, rules[0].Enabled)
+
+	rec = serve(http.MethodPost, "/api/v1/attention/rules/validate", `{"expression": "age_days > \"2\""}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var validation httphandler.ValidateRuleResponse
+	decodeJSON(t, rec, &validation)
+	assert.False(t, validation.Valid)
+	assert.Contains(t, validation.Error, "cannot compare number > string")
+	assert.Contains(t, validation.Fields, "age_days")
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/api/v1/attention/rules/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/api/v1/attention/rules/1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodDelete, "/api/v1/attention/rules/x", "").Code)
+}
+
+// mockScriptHookStore is an in-memory ScriptHookStore.
+type mockScriptHookStore struct {
+	hooks []model.ScriptHook
+}
+
+func (m *mockScriptHookStore) Create(_ context.Context, hook model.ScriptHook) (int64, error) {
+	hook.ID = int64(len(m.hooks) + 1)
+	m.hooks = append(m.hooks, hook)
+	return hook.ID, nil
+}
+
+func (m *mockScriptHookStore) SetEnabled(_ context.Context, id int64, enabled bool) error {
+	for i := range m.hooks {
+		if m.hooks[i].ID == id {
+			m.hooks[i].Enabled = enabled
+			return nil
+		}
+	}
+	return driven.ErrScriptHookNotFound
+}
+
+func (m *mockScriptHookStore) Delete(_ context.Context, id int64) error {
+	for i, hook := range m.hooks {
+		if hook.ID == id {
+			m.hooks = append(m.hooks[:i], m.hooks[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrScriptHookNotFound
+}
+
+func (m *mockScriptHookStore) List(_ context.Context) ([]model.ScriptHook, error) {
+	return m.hooks, nil
+}
+
+// mockHookRunner accepts Starlark hooks and rejects shell hooks.
+type mockHookRunner struct{}
+
+func (mockHookRunner) Check(hook model.ScriptHook) error {
+	if hook.Runtime == model.HookRuntimeShell {
+		return errors.New("command is not allowed")
+	}
+	return nil
+}
+
+func (mockHookRunner) Run(_ context.Context, _ model.ScriptHook, _ model.HookInvocation) (model.HookResult, error) {
+	return model.HookResult{}, nil
+}
+
+func TestScriptHooks(t *testing.T) {
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/api/v1/hooks", "").Code)
+	h.WithScriptHookService(application.NewScriptHookService(&mockScriptHookStore{}, &mockPRStore{}, mockHookRunner{}, "testuser"))
+
+	rec := serve(http.MethodPost, "/api/v1/hooks",
+		`{"name": "Thanks", "event": "approved", "runtime": "starlark", "script": "post_comment(\"Thanks @\" + review[\"reviewer\"])"}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var created httphandler.ScriptHookResponse
+	decodeJSON(t, rec, &created)
+	assert.Equal(t, "approved", created.Event)
+	assert.True(t, created.Enabled, "hooks are enabled by default")
+
+	rec = serve(http.MethodPost, "/api/v1/hooks", `{"name": "Wipe", "event": "opened", "runtime": "shell", "script": "rm -rf /"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "command is not allowed")
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPut, "/api/v1/hooks/1/enabled", `{"enabled": false}`).Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/api/v1/hooks/9/enabled", `{"enabled": true}`).Code)
+
+	rec = serve(http.MethodGet, "/api/v1/hooks", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var hooks []httphandler.ScriptHookResponse
+	decodeJSON(t, rec, &hooks)
+	require.Len(t, hooks, 1)
+	assert.False(t, hooks[0].Enabled)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/api/v1/hooks/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/api/v1/hooks/1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodDelete, "/api/v1/hooks/x", "").Code)
+}
+
+// mockEventWebhookStore is an in-memory EventWebhookStore with one stored
+// delivery for webhook 1.
+type mockEventWebhookStore struct {
+	hooks []model.EventWebhook
+}
+
+func (m *mockEventWebhookStore) Create(_ context.Context, hook model.EventWebhook) (int64, error) {
+	hook.ID = int64(len(m.hooks) + 1)
+	m.hooks = append(m.hooks, hook)
+	return hook.ID, nil
+}
+
+func (m *mockEventWebhookStore) Get(_ context.Context, id int64) (*model.EventWebhook, error) {
+	for _, hook := range m.hooks {
+		if hook.ID == id {
+			return &hook, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockEventWebhookStore) List(_ context.Context) ([]model.EventWebhook, error) {
+	return m.hooks, nil
+}
+
+func (m *mockEventWebhookStore) SetEnabled(_ context.Context, id int64, enabled bool) error {
+	for i := range m.hooks {
+		if m.hooks[i].ID == id {
+			m.hooks[i].Enabled = enabled
+			return nil
+		}
+	}
+	return driven.ErrEventWebhookNotFound
+}
+
+func (m *mockEventWebhookStore) Delete(_ context.Context, id int64) error {
+	for i, hook := range m.hooks {
+		if hook.ID == id {
+			m.hooks = append(m.hooks[:i], m.hooks[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrEventWebhookNotFound
+}
+
+func (m *mockEventWebhookStore) AddDelivery(_ context.Context, _ model.WebhookDelivery) (int64, error) {
+	return 1, nil
+}
+
+func (m *mockEventWebhookStore) UpdateDelivery(_ context.Context, _ model.WebhookDelivery) error {
+	return nil
+}
+
+func (m *mockEventWebhookStore) ListDeliveries(_ context.Context, _ int64, _ int) ([]model.WebhookDelivery, error) {
+	return []model.WebhookDelivery{{
+		ID: 1, WebhookID: 1, Event: model.WebhookPRCreated, RepoFullName: "org/api", PRNumber: 7,
+		Payload: `{"event":"pr.created"}`, Status: model.WebhookDeliveryDelivered, Attempts: 1, ResponseStatus: 200,
+	}}, nil
+}
+
+func (m *mockEventWebhookStore) ListDueDeliveries(_ context.Context, _ time.Time) ([]model.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (m *mockEventWebhookStore) PruneDeliveries(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestEventWebhooks(t *testing.T) {
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodGet, "/api/v1/webhooks", "").Code)
+	h.WithEventWebhookService(application.NewEventWebhookService(&mockEventWebhookStore{}, &mockPRStore{}, nil))
+
+	rec := serve(http.MethodPost, "/api/v1/webhooks", `{"url": "https://ci.example.com/hook", "events": ["pr.created", "check.failed"]}`)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	var created httphandler.EventWebhookResponse
+	decodeJSON(t, rec, &created)
+	assert.Equal(t, []string{"pr.created", "check.failed"}, created.Events)
+	assert.NotEmpty(t, created.Secret, "the secret is shown on create")
+	assert.True(t, created.Enabled)
+
+	rec = serve(http.MethodPost, "/api/v1/webhooks", `{"url": "https://ci.example.com/hook", "events": ["pr.exploded"]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unknown event")
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodPut, "/api/v1/webhooks/1/enabled", `{"enabled": false}`).Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodPut, "/api/v1/webhooks/9/enabled", `{"enabled": true}`).Code)
+
+	rec = serve(http.MethodGet, "/api/v1/webhooks", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var hooks []httphandler.EventWebhookResponse
+	decodeJSON(t, rec, &hooks)
+	require.Len(t, hooks, 1)
+	assert.False(t, hooks[0].Enabled)
+	assert.Empty(t, hooks[0].Secret, "the secret is not listed")
+
+	rec = serve(http.MethodGet, "/api/v1/webhooks/1/deliveries", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var deliveries []httphandler.WebhookDeliveryResponse
+	decodeJSON(t, rec, &deliveries)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "delivered", deliveries[0].Status)
+	assert.JSONEq(t, `{"event":"pr.created"}`, string(deliveries[0].Payload))
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, "/api/v1/webhooks/9/deliveries", "").Code)
+
+	assert.Equal(t, http.StatusNoContent, serve(http.MethodDelete, "/api/v1/webhooks/1", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodDelete, "/api/v1/webhooks/1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodDelete, "/api/v1/webhooks/x", "").Code)
+}
+
+// mockCommentWriter records the comments posted through it.
+type mockCommentWriter struct {
+	driven.GitHubWriter
+	comments []string
+	replyTo  []int64
+}
+
+func (m *mockCommentWriter) CreateIssueComment(_ context.Context, _ string, _ int, body string) error {
+	m.comments = append(m.comments, body)
+	return nil
+}
+
+func (m *mockCommentWriter) CreateReplyComment(_ context.Context, _ string, _ int, inReplyTo int64, body string) error {
+	m.comments = append(m.comments, body)
+	m.replyTo = append(m.replyTo, inReplyTo)
+	return nil
+}
+
+func TestMCP(t *testing.T) {
+	pr := model.PullRequest{ID: 1, RepoFullName: "acme/api", Number: 7, Title: "Fix it", Status: model.PRStatusOpen}
+	prStore := &mockPRStore{prs: []model.PullRequest{pr, {ID: 2, RepoFullName: "acme/web", Number: 3, Status: model.PRStatusOpen}}, pr: &pr}
+	h := httphandler.NewHandler(prStore, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	type toolResult struct {
+		Content []struct {
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	call := func(body string) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/mcp", strings.NewReader(body)))
+		var resp map[string]json.RawMessage
+		if rec.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		}
+		return rec, resp
+	}
+	callTool := func(name, args string) toolResult {
+		rec, resp := call(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"` + name + `","arguments":` + args + `}}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var result toolResult
+		require.NoError(t, json.Unmarshal(resp["result"], &result))
+		require.Len(t, result.Content, 1)
+		return result
+	}
+
+	_, resp := call(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18"}}`)
+	assert.JSONEq(t, `1`, string(resp["id"]))
+	assert.Contains(t, string(resp["result"]), `"protocolVersion":"2025-06-18"`)
+
+	rec, _ := call(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	_, resp = call(`{"jsonrpc":"2.0","id":"a","method":"tools/list"}`)
+	var list struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(resp["result"], &list))
+	var names []string
+	for _, tool := range list.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Equal(t, []string{"list_prs", "get_pr_detail", "get_threads", "post_comment"}, names)
+
+	result := callTool("list_prs", `{"repository":"acme/api"}`)
+	assert.False(t, result.IsError)
+	var prs []httphandler.PRResponse
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), &prs))
+	require.Len(t, prs, 1)
+	assert.Equal(t, "Fix it", prs[0].Title)
+
+	result = callTool("get_pr_detail", `{"repository":"acme/api","number":7}`)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, `"title": "Fix it"`)
+
+	_, resp = call(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"merge_pr","arguments":{}}}`)
+	assert.Contains(t, string(resp["error"]), `-32602`)
+	_, resp = call(`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`)
+	assert.Contains(t, string(resp["error"]), `-32601`)
+	rec, _ = call(`not json`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Posting is off until a writer is configured; the draft comes back.
+	result = callTool("post_comment", `{"repository":"acme/api","number":7,"body":"LGTM","confirm":true}`)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "MYGITPANEL_MCP_WRITES")
+	assert.Contains(t, result.Content[0].Text, "LGTM")
+
+	writer := &mockCommentWriter{}
+	h.WithMCPWriter(func(context.Context, string) (driven.GitHubWriter, error) { return writer, nil })
+
+	result = callTool("post_comment", `{"repository":"acme/api","number":7,"body":"LGTM"}`)
+	assert.False(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "Not posted")
+	assert.Empty(t, writer.comments, "nothing is posted without confirmation")
+
+	result = callTool("post_comment", `{"repository":"acme/api","number":7,"body":"Fixed, thanks","in_reply_to":42,"confirm":true}`)
+	assert.False(t, result.IsError, result.Content[0].Text)
+	assert.Equal(t, []string{"Fixed, thanks"}, writer.comments)
+	assert.Equal(t, []int64{42}, writer.replyTo)
+
+	prStore.pr = nil
+	result = callTool("get_threads", `{"repository":"acme/api","number":8}`)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].Text, "not a tracked pull request")
+}
+
+func TestMCP_ReadOnlyToken(t *testing.T) {
+	ctx := context.Background()
+	tokenSvc := application.NewAPITokenService(&mockAPITokenStore{})
+	readSecret, _, err := tokenSvc.Create(ctx, "assistant", model.APITokenScopeRead)
+	require.NoError(t, err)
+
+	pr := model.PullRequest{ID: 1, RepoFullName: "acme/api", Number: 7, Status: model.PRStatusOpen}
+	writer := &mockCommentWriter{}
+	h := httphandler.NewHandler(&mockPRStore{pr: &pr}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default()).
+		WithAPITokens(tokenSvc).
+		WithMCPWriter(func(context.Context, string) (driven.GitHubWriter, error) { return writer, nil })
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	call := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/mcp", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+readSecret)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// A read token may use the MCP endpoint, but not to post.
+	rec := call(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = call(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"post_comment","arguments":{"repository":"acme/api","number":7,"body":"hi","confirm":true}}}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "read-only")
+	assert.Empty(t, writer.comments)
+}
+
+type mockCommentSearchStore struct {
+	filter  model.CommentSearchFilter
+	results []model.CommentSearchResult
+}
+
+func (m *mockCommentSearchStore) SearchComments(_ context.Context, filter model.CommentSearchFilter) ([]model.CommentSearchResult, error) {
+	m.filter = filter
+	return m.results, nil
+}
+
+func TestSearchComments(t *testing.T) {
+	created := time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)
+	store := &mockCommentSearchStore{results: []model.CommentSearchResult{{
+		Kind: model.CommentKindReview, CommentID: 10, RepoFullName: "acme/api", PRNumber: 7, PRTitle: "Fix it",
+		Author: "alice", Body: "The retry config lives in retries.yaml", Snippet: "retry config", Path: "client.go", CreatedAt: created,
+	}}}
+
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/comments/search?q=retry", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "503 without a search store")
+
+	h.WithCommentSearchStore(store)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/api/v1/comments/search?q=+retry+config+&author=alice&repo=acme/api&since=2026-03-01&until=2026-03-05&limit=5", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, model.CommentSearchFilter{
+		Text:         "retry config",
+		Author:       "alice",
+		RepoFullName: "acme/api",
+		After:        time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Before:       time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC),
+		Limit:        5,
+	}, store.filter, "until is inclusive")
+
+	var resp []httphandler.CommentSearchResultResponse
+	decodeJSON(t, rec, &resp)
+	require.Len(t, resp, 1)
+	assert.Equal(t, "review", resp[0].Kind)
+	assert.Equal(t, "acme/api", resp[0].Repository)
+	assert.Equal(t, "client.go", resp[0].Path)
+	assert.Equal(t, "2026-03-05T12:00:00Z", resp[0].CreatedAt)
+
+	for _, query := range []string{"repo=nope", "since=yesterday", "until=2026-13-01", "limit=0", "limit=500"} {
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/comments/search?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+type mockJournalStore struct {
+	filter  model.PRJournalFilter
+	entries []model.PRJournalEntry
+}
+
+func (m *mockJournalStore) AppendPREvents(_ context.Context, _ []model.PRJournalEntry) error {
+	return nil
+}
+
+func (m *mockJournalStore) ListPREvents(_ context.Context, filter model.PRJournalFilter) ([]model.PRJournalEntry, error) {
+	m.filter = filter
+	return m.entries, nil
+}
+
+func TestListJournal(t *testing.T) {
+	store := &mockJournalStore{entries: []model.PRJournalEntry{{
+		ID: 42, RepoFullName: "acme/api", PRNumber: 7, Kind: model.JournalCIChanged,
+		From: "pending", To: "failing", OccurredAt: time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC),
+	}}}
+
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/journal", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "503 without a journal store")
+
+	h.WithJournalStore(store)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/api/v1/journal?repo=acme/api&pr=7&kind=ci_changed,+review_added&after=41&limit=10", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, model.PRJournalFilter{
+		RepoFullName: "acme/api",
+		PRNumber:     7,
+		Kinds:        []model.PRJournalKind{model.JournalCIChanged, model.JournalReviewAdded},
+		AfterID:      41,
+		Limit:        10,
+	}, store.filter)
+
+	var resp []httphandler.PRJournalEntryResponse
+	decodeJSON(t, rec, &resp)
+	assert.Equal(t, []httphandler.PRJournalEntryResponse{{
+		ID: 42, Repository: "acme/api", PRNumber: 7, Kind: "ci_changed",
+		From: "pending", To: "failing", OccurredAt: "2026-03-05T12:00:00Z",
+	}}, resp)
+
+	for _, query := range []string{"repo=nope", "pr=7", "repo=acme/api&pr=x", "kind=merged", "after=-1", "limit=0", "limit=501"} {
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/journal?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+type mockRepoImporter struct {
+	got []model.Repository
+}
+
+func (m *mockRepoImporter) AddMany(_ context.Context, repos []model.Repository) ([]string, error) {
+	m.got = repos
+	return []string{repos[0].FullName}, nil
+}
+
+func TestImportRepos(t *testing.T) {
+	importer := &mockRepoImporter{}
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default()).
+		WithRepoImportService(application.NewRepoImportService(importer, nil))
+	mux := httphandler.NewServeMux(h, slog.Default())
 
-			assert.Equal(t, tt.wantStatus, rec.Code)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/repos/import", strings.NewReader("org/api\n# skipped\norg/web\nbad name\n"))
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
 
-			var resp []map[string]any
-			decodeJSON(t, rec, &resp)
-			assert.Len(t, resp, tt.wantLen)
-		})
-	}
+	var resp httphandler.ImportReposResponse
+	decodeJSON(t, rec, &resp)
+	assert.Equal(t, 1, resp.Added)
+	assert.Equal(t, []httphandler.RepoImportResultResponse{
+		{FullName: "org/api", Status: "added"},
+		{FullName: "org/web", Status: "already_watched"},
+		{FullName: "bad name", Status: "invalid"},
+	}, resp.Results)
+	assert.Len(t, importer.got, 2)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/repos/import", strings.NewReader(`{"team": "org/platform"}`)))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "team import needs a GitHub client")
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/repos/import", strings.NewReader(`{"repos": []}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
-func TestListRepos(t *testing.T) {
-	tests := []struct {
-		name       string
-		repoStore  *mockRepoStore
-		wantStatus int
-		wantLen    int
-	}{
-		{
-			name:       "empty",
-			repoStore:  &mockRepoStore{repos: nil},
-			wantStatus: http.StatusOK,
-			wantLen:    0,
-		},
-		{
-			name: "two repos",
-			repoStore: &mockRepoStore{repos: []model.Repository{
-				{FullName: "owner/repo1", Owner: "owner", Name: "repo1", AddedAt: testTime},
-				{FullName: "owner/repo2", Owner: "owner", Name: "repo2", AddedAt: testTime},
-			}},
-			wantStatus: http.StatusOK,
-			wantLen:    2,
-		},
-	}
+func TestGetEmbedLink(t *testing.T) {
+	signer := application.NewEmbedSigner([]byte(strings.Repeat("k", 32)))
+	repoStore := &mockRepoStore{repo: &model.Repository{FullName: "owner/repo"}}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mux := setupMux(&mockPRStore{}, tt.repoStore)
-			req := httptest.NewRequest(http.MethodGet, "/api/v1/repos", nil)
-			rec := httptest.NewRecorder()
+	h := httphandler.NewHandler(&mockPRStore{}, repoStore, nil, nil, nil, nil, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/repos/owner/repo/embed", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "disabled without a signer")
 
-			mux.ServeHTTP(rec, req)
+	h.WithEmbedSigner(signer)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/repos/owner/repo/embed?columns=size,bogus,author", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
 
-			assert.Equal(t, tt.wantStatus, rec.Code)
+	var resp httphandler.EmbedLinkResponse
+	decodeJSON(t, rec, &resp)
+	assert.Equal(t, signer.Token("owner/repo"), resp.Token)
+	assert.Equal(t, []string{"size", "author"}, resp.Columns)
+	assert.Equal(t, "/embed/owner/repo?columns=size%2Cauthor&token="+resp.Token, resp.Path)
 
-			var resp []map[string]any
-			decodeJSON(t, rec, &resp)
-			assert.Len(t, resp, tt.wantLen)
+	h.WithBasePath("/mygitpanel")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/repos/owner/repo/embed", nil))
+	decodeJSON(t, rec, &resp)
+	assert.True(t, strings.HasPrefix(resp.Path, "/mygitpanel/embed/owner/repo?"), "the path includes the base path")
 
-			if tt.wantLen == 2 {
-				assert.Equal(t, "owner/repo1", resp[0]["full_name"])
-				assert.Equal(t, "owner", resp[0]["owner"])
-				assert.Equal(t, "repo1", resp[0]["name"])
-				assert.Equal(t, testTimeStr, resp[0]["added_at"])
-			}
-		})
-	}
+	repoStore.repo = nil
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/repos/owner/repo/embed", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
 
-func TestAddRepo(t *testing.T) {
+func TestTriggerRepoRefresh(t *testing.T) {
+	watched := &model.Repository{FullName: "owner/repo", Owner: "owner", Name: "repo"}
+
 	tests := []struct {
 		name       string
-		body       string
+		query      string
+		token      string
+		authHeader string
 		repoStore  *mockRepoStore
 		wantStatus int
 		wantError  string
 	}{
 		{
-			name:       "valid",
-			body:       `{"full_name": "owner/repo"}`,
-			repoStore:  &mockRepoStore{},
-			wantStatus: http.StatusCreated,
+			name:       "queued",
+			token:      "ci-secret",
+			authHeader: "Bearer ci-secret",
+			repoStore:  &mockRepoStore{repo: watched},
+			wantStatus: http.StatusAccepted,
 		},
 		{
-			name:       "invalid format - no slash",
-			body:       `{"full_name": "invalid"}`,
-			repoStore:  &mockRepoStore{},
-			wantStatus: http.StatusBadRequest,
-			wantError:  "invalid repository name: expected owner/repo format",
+			name:       "full history queued",
+			query:      "?history=full",
+			token:      "ci-secret",
+			authHeader: "Bearer ci-secret",
+			repoStore:  &mockRepoStore{repo: watched},
+			wantStatus: http.StatusAccepted,
 		},
 		{
-			name:       "invalid format - empty owner",
-			body:       `{"full_name": "/repo"}`,
-			repoStore:  &mockRepoStore{},
+			name:       "unknown history",
+			query:      "?history=all",
+			token:      "ci-secret",
+			authHeader: "Bearer ci-secret",
+			repoStore:  &mockRepoStore{repo: watched},
 			wantStatus: http.StatusBadRequest,
-			wantError:  "invalid repository name: expected owner/repo format",
+			wantError:  "invalid history: expected full",
 		},
 		{
-			name:       "invalid format - extra slashes",
-			body:       `{"full_name": "a/b/c"}`,
-			repoStore:  &mockRepoStore{},
-			wantStatus: http.StatusBadRequest,
-			wantError:  "invalid repository name: expected owner/repo format",
+			name:       "endpoint disabled without token",
+			authHeader: "Bearer ",
+			repoStore:  &mockRepoStore{repo: watched},
+			wantStatus: http.StatusServiceUnavailable,
+			wantError:  "repository refresh endpoint is not enabled",
 		},
 		{
-			name:       "duplicate",
-			body:       `{"full_name": "owner/repo"}`,
-			repoStore:  &mockRepoStore{addErr: driven.ErrRepoAlreadyExists},
-			wantStatus: http.StatusConflict,
-			wantError:  "repository already exists",
+			name:       "missing authorization",
+			token:      "ci-secret",
+			repoStore:  &mockRepoStore{repo: watched},
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid or missing refresh token",
 		},
 		{
-			name:       "invalid JSON",
-			body:       `not json`,
+			name:       "wrong token",
+			token:      "ci-secret",
+			authHeader: "Bearer guess",
+			repoStore:  &mockRepoStore{repo: watched},
+			wantStatus: http.StatusUnauthorized,
+			wantError:  "invalid or missing refresh token",
+		},
+		{
+			name:       "repo not watched",
+			token:      "ci-secret",
+			authHeader: "Bearer ci-secret",
 			repoStore:  &mockRepoStore{},
-			wantStatus: http.StatusBadRequest,
-			wantError:  "invalid request body",
+			wantStatus: http.StatusNotFound,
+			wantError:  "repository not found",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mux := setupMux(&mockPRStore{}, tt.repoStore)
-			req := httptest.NewRequest(http.MethodPost, "/api/v1/repos", strings.NewReader(tt.body))
-			req.Header.Set("Content-Type", "application/json")
+			// The poll loop is never started; the queued refresh is simply never picked up.
+			pollSvc := application.NewPollService(nil, &mockPRStore{}, tt.repoStore, nil, nil, "testuser", nil, time.Minute, nil, nil)
+			h := httphandler.NewHandler(&mockPRStore{}, tt.repoStore, nil, nil, nil, pollSvc, "testuser", slog.Default()).
+				WithRefreshToken(tt.token)
+			mux := httphandler.NewServeMux(h, slog.Default())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/repos/owner/repo/refresh"+tt.query, nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
 			rec := httptest.NewRecorder()
 
 			mux.ServeHTTP(rec, req)
 
 			assert.Equal(t, tt.wantStatus, rec.Code)
 
-			if tt.wantStatus == http.StatusCreated {
-				var resp map[string]any
-				decodeJSON(t, rec, &resp)
-				assert.Equal(t, "owner/repo", resp["full_name"])
-				assert.Equal(t, "owner", resp["owner"])
-				assert.Equal(t, "repo", resp["name"])
-				assert.NotEmpty(t, resp["added_at"])
-			}
-
+			var resp map[string]any
+			decodeJSON(t, rec, &resp)
 			if tt.wantError != "" {
-				var resp map[string]any
-				decodeJSON(t, rec, &resp)
 				assert.Equal(t, tt.wantError, resp["error"])
+				return
 			}
+			assert.Equal(t, "owner/repo", resp["repository"])
+			assert.Equal(t, "queued", resp["status"])
 		})
 	}
 }
 
-func TestRemoveRepo(t *testing.T) {
+func TestAPITokenScopes(t *testing.T) {
+	ctx := context.Background()
+	tokenSvc := application.NewAPITokenService(&mockAPITokenStore{})
+
+	// With no tokens created the API stays open.
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default()).
+		WithAPITokens(tokenSvc)
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/prs", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	readSecret, _, err := tokenSvc.Create(ctx, "dashboard script", model.APITokenScopeRead)
+	require.NoError(t, err)
+	writeSecret, _, err := tokenSvc.Create(ctx, "ci", model.APITokenScopeWrite)
+	require.NoError(t, err)
+
 	tests := []struct {
 		name       string
-		repoStore  *mockRepoStore
+		method     string
+		path       string
+		secret     string
 		wantStatus int
 		wantError  string
 	}{
-		{
-			name:       "success",
-			repoStore:  &mockRepoStore{},
-			wantStatus: http.StatusNoContent,
-		},
-		{
-			name:       "not found",
-			repoStore:  &mockRepoStore{removeErr: driven.ErrRepoNotFound},
-			wantStatus: http.StatusNotFound,
-			wantError:  "repository not found",
-		},
+		{name: "missing token", method: http.MethodGet, path: "/api/v1/prs", wantStatus: http.StatusUnauthorized, wantError: "missing API token"},
+		{name: "unknown token", method: http.MethodGet, path: "/api/v1/prs", secret: "mgp_guess", wantStatus: http.StatusUnauthorized, wantError: "invalid API token"},
+		{name: "read token reads", method: http.MethodGet, path: "/api/v1/prs", secret: readSecret, wantStatus: http.StatusOK},
+		{name: "read token cannot write", method: http.MethodDelete, path: "/api/v1/repos/owner/repo", secret: readSecret, wantStatus: http.StatusForbidden, wantError: "API token is read-only"},
+		{name: "write token writes", method: http.MethodDelete, path: "/api/v1/repos/owner/repo", secret: writeSecret, wantStatus: http.StatusNoContent},
+		{name: "health probe stays open", method: http.MethodGet, path: "/api/v1/health", wantStatus: http.StatusOK},
+		{name: "system stats stay open", method: http.MethodGet, path: "/api/v1/system", wantStatus: http.StatusServiceUnavailable},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mux := setupMux(&mockPRStore{}, tt.repoStore)
-			req := httptest.NewRequest(http.MethodDelete, "/api/v1/repos/owner/repo", nil)
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.secret != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.secret)
+			}
 			rec := httptest.NewRecorder()
 
 			mux.ServeHTTP(rec, req)
 
 			assert.Equal(t, tt.wantStatus, rec.Code)
-
-			if tt.wantStatus == http.StatusNoContent {
-				assert.Empty(t, rec.Body.String())
-			}
-
 			if tt.wantError != "" {
 				var resp map[string]any
 				decodeJSON(t, rec, &resp)
@@ -597,6 +1983,176 @@ func TestHealth(t *testing.T) {
 	assert.NotEmpty(t, resp["time"])
 }
 
+func TestLiveness(t *testing.T) {
+	mux := setupMux(&mockPRStore{}, &mockRepoStore{})
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var resp map[string]any
+	decodeJSON(t, rec, &resp)
+	assert.Equal(t, "ok", resp["status"])
+	assert.NotContains(t, resp, "last_poll_progress_at", "poll loop fields omitted when polling is not running")
+}
+
+// stubPinger is a DBPinger returning a fixed error.
+type stubPinger struct {
+	err error
+}
+
+func (p stubPinger) Ping(_ context.Context) error { return p.err }
+
+func TestReadiness(t *testing.T) {
+	repos := []model.Repository{{FullName: "octocat/zeta"}, {FullName: "octocat/alpha"}}
+
+	tests := []struct {
+		name       string
+		pinger     httphandler.DBPinger
+		repoStore  *mockRepoStore
+		withPoll   bool
+		wantStatus int
+		wantDB     string
+		wantRepos  []string
+	}{
+		{
+			name:       "no dependencies wired",
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusOK,
+			wantDB:     "unknown",
+		},
+		{
+			name:       "database reachable",
+			pinger:     stubPinger{},
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusOK,
+			wantDB:     "ok",
+		},
+		{
+			name:       "database unreachable",
+			pinger:     stubPinger{err: errors.New("disk I/O error")},
+			repoStore:  &mockRepoStore{},
+			wantStatus: http.StatusServiceUnavailable,
+			wantDB:     "fail",
+		},
+		{
+			name:       "repos pending before first poll",
+			pinger:     stubPinger{},
+			repoStore:  &mockRepoStore{repos: repos},
+			withPoll:   true,
+			wantStatus: http.StatusOK,
+			wantDB:     "ok",
+			wantRepos:  []string{"octocat/alpha", "octocat/zeta"},
+		},
+		{
+			name:       "repo store failure",
+			pinger:     stubPinger{},
+			repoStore:  &mockRepoStore{err: errors.New("db error")},
+			withPoll:   true,
+			wantStatus: http.StatusServiceUnavailable,
+			wantDB:     "ok",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pollSvc *application.PollService
+			if tt.withPoll {
+				pollSvc = application.NewPollService(nil, &mockPRStore{}, tt.repoStore, nil, nil, "testuser", nil, time.Minute, nil, nil)
+			}
+			h := httphandler.NewHandler(&mockPRStore{}, tt.repoStore, nil, nil, nil, pollSvc, "testuser", slog.Default())
+			if tt.pinger != nil {
+				h.WithDBPinger(tt.pinger)
+			}
+			mux := httphandler.NewServeMux(h, slog.Default())
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			var resp httphandler.ReadinessResponse
+			decodeJSON(t, rec, &resp)
+			assert.Equal(t, tt.wantDB, resp.Database.Status)
+			assert.Equal(t, "unknown", resp.GitHub.Credentials)
+
+			gotRepos := make([]string, 0, len(resp.Repos))
+			for _, repo := range resp.Repos {
+				assert.Equal(t, "pending", repo.Status)
+				gotRepos = append(gotRepos, repo.Repository)
+			}
+			if tt.wantRepos == nil {
+				assert.Empty(t, gotRepos)
+			} else {
+				assert.Equal(t, tt.wantRepos, gotRepos)
+			}
+		})
+	}
+}
+
+func TestReadiness_PausedRepo(t *testing.T) {
+	repoStore := &mockRepoStore{repos: []model.Repository{
+		{FullName: "octocat/alpha", Paused: true},
+		{FullName: "octocat/zeta"},
+	}}
+	pollSvc := application.NewPollService(nil, &mockPRStore{}, repoStore, nil, nil, "testuser", nil, time.Minute, nil, nil)
+	h := httphandler.NewHandler(&mockPRStore{}, repoStore, nil, nil, nil, pollSvc, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp httphandler.ReadinessResponse
+	decodeJSON(t, rec, &resp)
+	require.Len(t, resp.Repos, 2)
+	assert.Equal(t, "paused", resp.Repos[0].Status)
+	assert.Equal(t, "pending", resp.Repos[1].Status)
+}
+
+// goneGitHub answers every pull request listing as if the repository had
+// been deleted.
+type goneGitHub struct {
+	driven.GitHubClient
+}
+
+func (goneGitHub) FetchPullRequests(context.Context, string, string) ([]model.PullRequest, error) {
+	return nil, driven.ErrRepoUnreachable
+}
+
+func TestReadiness_OpenCircuit(t *testing.T) {
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "octocat/gone"}}}
+	pollSvc := application.NewPollService(goneGitHub{}, &mockPRStore{}, repoStore, nil, nil, "testuser", nil, time.Minute, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	go pollSvc.Start(ctx)
+	t.Cleanup(func() {
+		cancel()
+		<-pollSvc.Drained()
+	})
+
+	// The initial poll fails once; two refreshes make three in a row.
+	for range 2 {
+		require.ErrorIs(t, pollSvc.RefreshRepo(ctx, "octocat/gone"), driven.ErrRepoUnreachable)
+	}
+
+	h := httphandler.NewHandler(&mockPRStore{}, repoStore, nil, nil, nil, pollSvc, "testuser", slog.Default())
+	mux := httphandler.NewServeMux(h, slog.Default())
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code, "an open circuit does not make the service unready")
+	var resp httphandler.ReadinessResponse
+	decodeJSON(t, rec, &resp)
+	require.Len(t, resp.Repos, 1)
+	assert.Equal(t, "degraded", resp.Repos[0].Status)
+	assert.Equal(t, "open", resp.Repos[0].Circuit)
+}
+
 func TestNilLabelsBecomesEmptyArray(t *testing.T) {
 	prStore := &mockPRStore{prs: []model.PullRequest{
 		{
@@ -701,7 +2257,7 @@ func TestGetPR_WithEnrichedReviews(t *testing.T) {
 	}
 
 	botConfigStore := &mockBotConfigStore{
-		usernames: []string{"coderabbitai", "github-actions[bot]"},
+		bots: []model.BotConfig{{Username: "coderabbitai"}, {Username: "github-actions[bot]"}},
 	}
 
 	mux := setupMuxWithReview(prStore, &mockRepoStore{}, botConfigStore, reviewStore)
@@ -989,6 +2545,25 @@ func TestRemoveBot(t *testing.T) {
 	assert.Empty(t, rec.Body.String())
 }
 
+func TestUpdateBot(t *testing.T) {
+	botStore := &mockBotConfigStore{}
+
+	mux := setupMuxWithBots(botStore)
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/bots/copilot%5Bbot%5D",
+		strings.NewReader(`{"nitpick_patterns":[" Minor: ", "", "minor:"]}`))
+	rec := httptest.NewRecorder()
+
+	mux.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, map[string][]string{"copilot[bot]": {"minor:"}}, botStore.patterns)
+
+	botStore.updateErr = driven.ErrBotNotFound
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/api/v1/bots/ghost", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
 // --- Phase 5 Health Signal Tests ---
 
 func TestGetPR_WithHealthSignals(t *testing.T) {
@@ -1130,3 +2705,182 @@ func TestListPRs_IncludesHealthFields(t *testing.T) {
 	require.True(t, ok, "check_runs should be an array")
 	assert.Len(t, checkRuns, 0, "check_runs should be empty on list endpoint")
 }
+
+func TestStreamEvents(t *testing.T) {
+	hub := application.NewEventHub()
+	h := httphandler.NewHandler(&mockPRStore{}, &mockRepoStore{}, nil, nil, nil, nil, "testuser", slog.Default()).
+		WithEventHub(hub)
+	srv := httptest.NewServer(httphandler.NewServeMux(h, slog.Default()))
+	defer srv.Close()
+
+	t.Run("rejects unknown event type", func(t *testing.T) {
+		resp, err := http.Get(srv.URL + "/api/v1/events?type=pr.deleted")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("streams matching events", func(t *testing.T) {
+		wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/events?repo=org/repo&type=check.completed,review.added"
+		ws, err := websocket.Dial(wsURL, "", srv.URL)
+		require.NoError(t, err)
+		defer ws.Close()
+
+		// The subscription is registered asynchronously after the handshake,
+		// so keep publishing until the first event arrives.
+		stop := make(chan struct{})
+		defer close(stop)
+		go func() {
+			ticker := time.NewTicker(10 * time.Millisecond)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					hub.Publish(model.PREvent{Type: model.PREventCheckCompleted, RepoFullName: "org/other", PRNumber: 1,
+						CheckRun: &model.CheckRun{ID: 1, Name: "lint", Status: "completed", Conclusion: "failure"}})
+					hub.Publish(model.PREvent{Type: model.PREventUpdated, RepoFullName: "org/repo", PRNumber: 2,
+						PullRequest: &model.PullRequest{Number: 2, RepoFullName: "org/repo"}})
+					hub.Publish(model.PREvent{Type: model.PREventCheckCompleted, RepoFullName: "org/repo", PRNumber: 3,
+						CheckRun: &model.CheckRun{ID: 7, Name: "test", Status: "completed", Conclusion: "success"}})
+				}
+			}
+		}()
+
+		require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+		var event httphandler.EventResponse
+		require.NoError(t, websocket.JSON.Receive(ws, &event))
+
+		assert.Equal(t, "check.completed", event.Type)
+		assert.Equal(t, "org/repo", event.Repository)
+		assert.Equal(t, 3, event.Number)
+		require.NotNil(t, event.CheckRun)
+		assert.Equal(t, "success", event.CheckRun.Conclusion)
+		assert.Nil(t, event.PR)
+	})
+
+	t.Run("rejects cross-origin browsers", func(t *testing.T) {
+		wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/v1/events"
+		_, err := websocket.Dial(wsURL, "", "https://evil.example")
+		assert.Error(t, err)
+	})
+}
+
+func TestRejectWrites(t *testing.T) {
+	mux := httphandler.RejectWrites(setupMux(&mockPRStore{}, &mockRepoStore{}), "database is read-only")
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/prs", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/repos", strings.NewReader(`{"full_name":"owner/repo"}`)))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp map[string]any
+	decodeJSON(t, rec, &resp)
+	assert.Equal(t, "database is read-only", resp["error"])
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/app/repos", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), "database is read-only")
+}
+
+func TestRequireClientCert(t *testing.T) {
+	mux := httphandler.RequireClientCert(setupMux(&mockPRStore{}, &mockRepoStore{}))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/prs", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "plain HTTP has no certificate")
+
+	req := httptest.NewRequest(http.MethodGet, "https://panel.example.com/api/v1/prs", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code, "TLS without a client certificate")
+
+	req = httptest.NewRequest(http.MethodGet, "https://panel.example.com/api/v1/prs", nil)
+	req.TLS.VerifiedChains = [][]*x509.Certificate{{{}}}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.NotEqual(t, http.StatusUnauthorized, rec.Code, "only the API requires a certificate")
+}
+
+func TestStripBasePath(t *testing.T) {
+	var gotPath string
+	handler := httphandler.StripBasePath(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+	}), "/mygitpanel")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mygitpanel/app/prs/search?q=fix", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "/app/prs/search", gotPath)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mygitpanel?tab=ci", nil))
+	assert.Equal(t, http.StatusMovedPermanently, rec.Code)
+	assert.Equal(t, "/mygitpanel/?tab=ci", rec.Header().Get("Location"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, "/healthz", gotPath, "paths a proxy already stripped are routed as is")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mygitpanelx/app", nil))
+	assert.Equal(t, "/mygitpanelx/app", gotPath, "only whole path segments match")
+}
+
+func TestCompression(t *testing.T) {
+	page := strings.Repeat("<p>pull request</p>", 200)
+	handler := httphandler.ApplyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/small":
+			w.Header().Set("Content-Length", "2")
+			_, _ = w.Write([]byte("ok"))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte(page))
+		default:
+			_, _ = w.Write([]byte(page))
+		}
+	}), slog.Default())
+
+	get := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("brotli preferred", func(t *testing.T) {
+		rec := get("/", "gzip, deflate, br")
+		assert.Equal(t, "br", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+		body, err := io.ReadAll(brotli.NewReader(rec.Body))
+		require.NoError(t, err)
+		assert.Equal(t, page, string(body))
+	})
+
+	t.Run("gzip by q-value", func(t *testing.T) {
+		rec := get("/", "br;q=0.5, gzip")
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		zr, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		assert.Equal(t, page, string(body))
+	})
+
+	t.Run("passes through", func(t *testing.T) {
+		assert.Empty(t, get("/", "identity").Header().Get("Content-Encoding"))
+		assert.Empty(t, get("/", "gzip;q=0").Header().Get("Content-Encoding"))
+		assert.Empty(t, get("/small", "gzip").Header().Get("Content-Encoding"), "too small")
+		assert.Empty(t, get("/image", "gzip").Header().Get("Content-Encoding"), "already compressed")
+	})
+}
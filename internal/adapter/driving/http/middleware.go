@@ -1,8 +1,12 @@
 package httphandler
 
 import (
+	"bufio"
+	"errors"
 	"log/slog"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -18,6 +22,17 @@ func (sw *statusWriter) WriteHeader(status int) {
 	sw.ResponseWriter.WriteHeader(status)
 }
 
+// Hijack hands the connection to WebSocket handlers. The status is recorded as
+// 101 since the upgrade response is written directly to the connection.
+func (sw *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := sw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	sw.status = http.StatusSwitchingProtocols
+	return hj.Hijack()
+}
+
 // loggingMiddleware logs each HTTP request with method, path, status, and duration.
 func loggingMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -35,6 +50,67 @@ func loggingMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
 	})
 }
 
+// RejectWrites wraps handler so that only safe (read) methods reach it; every
+// other request is answered with 503 and reason. It backs read-only mode,
+// where writes would otherwise fail deep inside the database layer. API paths
+// get a JSON error body and GUI paths plain text. The MCP endpoint passes
+// through: its reads arrive as POSTs, and it has no writer in read-only mode.
+func RejectWrites(handler http.Handler, reason string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isSafeMethod(r.Method) || r.URL.Path == mcpPath {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			writeError(w, http.StatusServiceUnavailable, reason)
+			return
+		}
+		http.Error(w, reason, http.StatusServiceUnavailable)
+	})
+}
+
+// RequireClientCert wraps handler so that /api/ requests are only served over
+// TLS connections that presented a client certificate the server verified.
+// The server's tls.Config must request certificates without demanding them
+// (tls.VerifyClientCertIfGiven), so browsers can still load the dashboard.
+// Other requests pass through.
+func RequireClientCert(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") && (r.TLS == nil || len(r.TLS.VerifiedChains) == 0) {
+			writeError(w, http.StatusUnauthorized, "a verified client certificate is required")
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// StripBasePath serves handler under prefix, for deployments behind a reverse
+// proxy that mounts the app at a subpath such as /mygitpanel. The prefix is
+// removed from requests under it before routing, and a request for the bare
+// prefix is redirected to prefix + "/". Other requests are routed unchanged,
+// so proxies that strip the prefix themselves and health probes that reach
+// the server directly keep working. An empty prefix returns handler as is.
+func StripBasePath(handler http.Handler, prefix string) http.Handler {
+	if prefix == "" {
+		return handler
+	}
+	stripped := http.StripPrefix(prefix, handler)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == prefix:
+			target := prefix + "/"
+			if r.URL.RawQuery != "" {
+				target += "?" + r.URL.RawQuery
+			}
+			http.Redirect(w, r, target, http.StatusMovedPermanently)
+		case strings.HasPrefix(r.URL.Path, prefix+"/"):
+			stripped.ServeHTTP(w, r)
+		default:
+			handler.ServeHTTP(w, r)
+		}
+	})
+}
+
 // recoveryMiddleware recovers from panics in HTTP handlers, logs the error,
 // and returns a 500 response.
 func recoveryMiddleware(logger *slog.Logger, next http.Handler) http.Handler {
@@ -51,18 +51,25 @@ type PRResponse struct {
 	OpenedAt    string   `json:"opened_at"`
 	UpdatedAt   string   `json:"updated_at"`
 
+	// ReviewRequests are the pending review requests, with when each was made.
+	ReviewRequests []ReviewRequestResponse `json:"review_requests"`
+
+	// Review rollup -- populated from the PR row on all endpoints and
+	// recomputed from the stored reviews on the single PR detail endpoint.
+	ReviewStatus      string `json:"review_status"`
+	ApprovalCount     int    `json:"approval_count"`
+	UnresolvedThreads int    `json:"unresolved_threads"`
+
 	// Enriched review data -- populated only on single PR detail endpoint.
 	HeadSHA             string                 `json:"head_sha"`
 	Reviews             []ReviewResponse       `json:"reviews"`
 	Threads             []ReviewThreadResponse `json:"threads"`
 	IssueComments       []IssueCommentResponse `json:"issue_comments"`
 	Suggestions         []SuggestionResponse   `json:"suggestions"`
-	ReviewStatus        string                 `json:"review_status"`
 	HasBotReview        bool                   `json:"has_bot_review"`
 	HasCoderabbitReview bool                   `json:"has_coderabbit_review"`
 	AwaitingCoderabbit  bool                   `json:"awaiting_coderabbit"`
 	ResolvedThreads     int                    `json:"resolved_threads"`
-	UnresolvedThreads   int                    `json:"unresolved_threads"`
 
 	// Health signal fields -- populated from PR model on all endpoints.
 	DaysSinceOpened       int                `json:"days_since_opened"`
@@ -73,6 +80,12 @@ type PRResponse struct {
 	MergeableStatus       string             `json:"mergeable_status"`
 	CIStatus              string             `json:"ci_status"`
 	CheckRuns             []CheckRunResponse `json:"check_runs"`
+	ChecklistDone         int                `json:"checklist_done"`
+	ChecklistTotal        int                `json:"checklist_total"`
+
+	// LintViolations lists the title and branch rules the PR fails --
+	// populated only on single PR detail endpoint.
+	LintViolations []LintViolationResponse `json:"lint_violations"`
 }
 
 // ReviewResponse is the JSON representation of a single review.
@@ -101,6 +114,7 @@ type ReviewCommentResponse struct {
 	DiffHunk    string `json:"diff_hunk"`
 	CommitID    string `json:"commit_id"`
 	IsOutdated  bool   `json:"is_outdated"`
+	HTMLURL     string `json:"html_url,omitempty"`
 	CreatedAt   string `json:"created_at"`
 }
 
@@ -110,6 +124,9 @@ type ReviewThreadResponse struct {
 	Replies      []ReviewCommentResponse `json:"replies"`
 	IsResolved   bool                    `json:"is_resolved"`
 	CommentCount int                     `json:"comment_count"`
+	// RepeatCount is how many earlier postings of the same bot comment were
+	// collapsed into this thread.
+	RepeatCount int `json:"repeat_count,omitempty"`
 }
 
 // SuggestionResponse is a structured proposed code change extracted from a comment.
@@ -127,7 +144,11 @@ type IssueCommentResponse struct {
 	Author    string `json:"author"`
 	Body      string `json:"body"`
 	IsBot     bool   `json:"is_bot"`
+	HTMLURL   string `json:"html_url,omitempty"`
 	CreatedAt string `json:"created_at"`
+	// RepeatCount is how many earlier postings of the same bot comment were
+	// collapsed into this one.
+	RepeatCount int `json:"repeat_count,omitempty"`
 }
 
 // CheckRunResponse is the JSON representation of an individual CI/CD check run.
@@ -142,32 +163,119 @@ type CheckRunResponse struct {
 
 // BotConfigResponse is the JSON representation of a bot configuration entry.
 type BotConfigResponse struct {
-	Username string `json:"username"`
-	AddedAt  string `json:"added_at"`
+	Username        string   `json:"username"`
+	AddedAt         string   `json:"added_at"`
+	NitpickPatterns []string `json:"nitpick_patterns"`
 }
 
 // AddBotRequest is the JSON body for the add bot endpoint.
 type AddBotRequest struct {
-	Username string `json:"username"`
+	Username        string   `json:"username"`
+	NitpickPatterns []string `json:"nitpick_patterns"`
+}
+
+// UpdateBotRequest is the JSON body for the update bot endpoint.
+type UpdateBotRequest struct {
+	NitpickPatterns []string `json:"nitpick_patterns"`
 }
 
 // RepoResponse is the JSON representation of a watched repository.
 type RepoResponse struct {
-	FullName string `json:"full_name"`
-	Owner    string `json:"owner"`
-	Name     string `json:"name"`
-	AddedAt  string `json:"added_at"`
+	FullName     string `json:"full_name"`
+	Owner        string `json:"owner"`
+	Name         string `json:"name"`
+	AddedAt      string `json:"added_at"`
+	LastSyncedAt string `json:"last_synced_at,omitempty"`
+	Provider     string `json:"provider"`
+
+	// GitHub metadata; omitted until first fetched by the poll loop.
+	Description   string `json:"description,omitempty"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	Private       bool   `json:"private,omitempty"`
+	Archived      bool   `json:"archived,omitempty"`
+	OpenPRCount   int    `json:"open_pr_count,omitempty"`
 }
 
-// HealthResponse is the JSON representation of the health check endpoint.
+// HealthResponse is the JSON representation of the liveness endpoint.
 type HealthResponse struct {
+	Status             string `json:"status"`
+	Time               string `json:"time"`
+	PollLoopStartedAt  string `json:"poll_loop_started_at,omitempty"`
+	LastPollProgressAt string `json:"last_poll_progress_at,omitempty"`
+	Error              string `json:"error,omitempty"`
+}
+
+// ReadinessResponse is the JSON representation of the readiness endpoint.
+type ReadinessResponse struct {
+	Status   string                  `json:"status"`
+	Time     string                  `json:"time"`
+	Database DependencyCheckResponse `json:"database"`
+	GitHub   GitHubCheckResponse     `json:"github"`
+	Pacing   *PollPacingResponse     `json:"pacing,omitempty"`
+	Repos    []RepoPollCheckResponse `json:"repos"`
+}
+
+// PollPacingResponse reports the poll loop's latest pacing decision: how
+// many due repos the rate limit allowed it to poll on its last tick.
+type PollPacingResponse struct {
+	DecidedAt    string  `json:"decided_at"`
+	Due          int     `json:"due"`
+	Budget       int     `json:"budget"` // -1 when the rate limit is unknown and polls are not capped
+	Polled       int     `json:"polled"`
+	Deferred     int     `json:"deferred"`
+	CallsPerPoll float64 `json:"calls_per_poll"`
+	Reason       string  `json:"reason,omitempty"`
+}
+
+// DependencyCheckResponse is the result of probing a single dependency.
+type DependencyCheckResponse struct {
 	Status string `json:"status"`
-	Time   string `json:"time"`
+	Error  string `json:"error,omitempty"`
+}
+
+// GitHubCheckResponse reports credential validity and rate-limit headroom as
+// observed on the most recent GitHub API response.
+type GitHubCheckResponse struct {
+	Status        string `json:"status"`
+	Credentials   string `json:"credentials"`
+	RateLimit     int    `json:"rate_limit"`
+	RateRemaining int    `json:"rate_remaining"`
+	RateResetAt   string `json:"rate_reset_at,omitempty"`
+	ObservedAt    string `json:"observed_at,omitempty"`
+}
+
+// RepoPollCheckResponse reports when a watched repository was last polled successfully.
+type RepoPollCheckResponse struct {
+	Repository           string `json:"repository"`
+	Status               string `json:"status"`
+	LastSuccessfulPollAt string `json:"last_successful_poll_at,omitempty"`
+	Circuit              string `json:"circuit,omitempty"`
+	ConsecutiveFailures  int    `json:"consecutive_failures,omitempty"`
+	LastError            string `json:"last_error,omitempty"`
+	NextRetryAt          string `json:"next_retry_at,omitempty"`
+	MovedTo              string `json:"moved_to,omitempty"`
+}
+
+// RefreshRepoResponse acknowledges a queued repository refresh.
+type RefreshRepoResponse struct {
+	Repository string `json:"repository"`
+	Status     string `json:"status"`
 }
 
 // AddRepoRequest is the JSON body for the add repository endpoint.
 type AddRepoRequest struct {
 	FullName string `json:"full_name"`
+	// Provider is where the repository is hosted: "github" (the default) or
+	// "bitbucket", in which case FullName is "workspace/repo".
+	Provider string `json:"provider,omitempty"`
+}
+
+// ReviewRequestResponse is the JSON representation of a pending review
+// request. Reviewer is a team slug when Team is set.
+type ReviewRequestResponse struct {
+	Reviewer    string `json:"reviewer"`
+	Team        bool   `json:"team"`
+	RequestedAt string `json:"requested_at"`
 }
 
 // toPRResponse converts a domain PullRequest to its JSON response representation.
@@ -179,20 +287,36 @@ func toPRResponse(pr model.PullRequest) PRResponse {
 		labels = []string{}
 	}
 
+	requests := make([]ReviewRequestResponse, 0, len(pr.ReviewRequests))
+	for _, req := range pr.ReviewRequests {
+		requests = append(requests, ReviewRequestResponse{
+			Reviewer:    req.Reviewer,
+			Team:        req.IsTeam,
+			RequestedAt: req.RequestedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
 	return PRResponse{
-		Number:        pr.Number,
-		Repository:    pr.RepoFullName,
-		Title:         pr.Title,
-		Author:        pr.Author,
-		Status:        string(pr.Status),
-		IsDraft:       pr.IsDraft,
-		NeedsReview:   pr.NeedsReview,
-		URL:           pr.URL,
-		Branch:        pr.Branch,
-		BaseBranch:    pr.BaseBranch,
-		Labels:        labels,
-		OpenedAt:      pr.OpenedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:     pr.UpdatedAt.UTC().Format(time.RFC3339),
+		Number:      pr.Number,
+		Repository:  pr.RepoFullName,
+		Title:       pr.Title,
+		Author:      pr.Author,
+		Status:      string(pr.Status),
+		IsDraft:     pr.IsDraft,
+		NeedsReview: pr.NeedsReview,
+		URL:         pr.URL,
+		Branch:      pr.Branch,
+		BaseBranch:  pr.BaseBranch,
+		Labels:      labels,
+		OpenedAt:    pr.OpenedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:   pr.UpdatedAt.UTC().Format(time.RFC3339),
+
+		ReviewRequests: requests,
+
+		ReviewStatus:      string(pr.ReviewRollup.Status),
+		ApprovalCount:     pr.ReviewRollup.Approvals,
+		UnresolvedThreads: pr.ReviewRollup.UnresolvedThreads,
+
 		HeadSHA:       pr.HeadSHA,
 		Reviews:       []ReviewResponse{},
 		Threads:       []ReviewThreadResponse{},
@@ -208,13 +332,14 @@ func toPRResponse(pr model.PullRequest) PRResponse {
 		MergeableStatus:       string(pr.MergeableStatus),
 		CIStatus:              string(pr.CIStatus),
 		CheckRuns:             []CheckRunResponse{},
+		ChecklistDone:         pr.Checklist.Done,
+		ChecklistTotal:        pr.Checklist.Total,
 	}
 }
 
 // toReviewResponse converts a domain Review to its JSON response representation.
-func toReviewResponse(r model.Review, headSHA string, botUsernames []string) ReviewResponse {
+func toReviewResponse(r model.Review, headSHA string) ReviewResponse {
 	isOutdated := r.CommitID != "" && r.CommitID != headSHA
-	isNitpick := application.IsNitpickComment(r.ReviewerLogin, r.Body, botUsernames)
 
 	return ReviewResponse{
 		ID:            r.ID,
@@ -225,7 +350,7 @@ func toReviewResponse(r model.Review, headSHA string, botUsernames []string) Rev
 		SubmittedAt:   r.SubmittedAt.UTC().Format(time.RFC3339),
 		IsBot:         r.IsBot,
 		IsOutdated:    isOutdated,
-		IsNitpick:     isNitpick,
+		IsNitpick:     r.IsNitpick,
 	}
 }
 
@@ -243,6 +368,7 @@ func toReviewCommentResponse(c model.ReviewComment) ReviewCommentResponse {
 		DiffHunk:    c.DiffHunk,
 		CommitID:    c.CommitID,
 		IsOutdated:  c.IsOutdated,
+		HTMLURL:     c.HTMLURL,
 		CreatedAt:   c.CreatedAt.UTC().Format(time.RFC3339),
 	}
 }
@@ -259,6 +385,7 @@ func toReviewThreadResponse(t application.CommentThread) ReviewThreadResponse {
 		Replies:      replies,
 		IsResolved:   t.IsResolved,
 		CommentCount: 1 + len(t.Replies),
+		RepeatCount:  len(t.Repeats),
 	}
 }
 
@@ -273,14 +400,17 @@ func toSuggestionResponse(s application.Suggestion) SuggestionResponse {
 	}
 }
 
-// toIssueCommentResponse converts a domain IssueComment to its JSON representation.
-func toIssueCommentResponse(c model.IssueComment) IssueCommentResponse {
+// toIssueCommentResponse converts a domain IssueComment to its JSON
+// representation, counting its collapsed repeats from a review summary.
+func toIssueCommentResponse(c model.IssueComment, repeats map[int64][]model.IssueComment) IssueCommentResponse {
 	return IssueCommentResponse{
-		ID:        c.ID,
-		Author:    c.Author,
-		Body:      c.Body,
-		IsBot:     c.IsBot,
-		CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
+		ID:          c.ID,
+		Author:      c.Author,
+		Body:        c.Body,
+		IsBot:       c.IsBot,
+		HTMLURL:     c.HTMLURL,
+		CreatedAt:   c.CreatedAt.UTC().Format(time.RFC3339),
+		RepeatCount: len(repeats[c.ID]),
 	}
 }
 
@@ -298,18 +428,37 @@ func toCheckRunResponse(cr model.CheckRun) CheckRunResponse {
 
 // toBotConfigResponse converts a domain BotConfig to its JSON representation.
 func toBotConfigResponse(bot model.BotConfig) BotConfigResponse {
+	patterns := bot.NitpickPatterns
+	if patterns == nil {
+		patterns = []string{}
+	}
 	return BotConfigResponse{
-		Username: bot.Username,
-		AddedAt:  bot.AddedAt.UTC().Format(time.RFC3339),
+		Username:        bot.Username,
+		AddedAt:         bot.AddedAt.UTC().Format(time.RFC3339),
+		NitpickPatterns: patterns,
 	}
 }
 
 // toRepoResponse converts a domain Repository to its JSON response representation.
 func toRepoResponse(repo model.Repository) RepoResponse {
-	return RepoResponse{
+	resp := RepoResponse{
 		FullName: repo.FullName,
 		Owner:    repo.Owner,
 		Name:     repo.Name,
 		AddedAt:  repo.AddedAt.UTC().Format(time.RFC3339),
+		Provider: string(model.ProviderGitHub),
+
+		Description:   repo.Metadata.Description,
+		DefaultBranch: repo.Metadata.DefaultBranch,
+		Private:       repo.Metadata.Private,
+		Archived:      repo.Metadata.Archived,
+		OpenPRCount:   repo.Metadata.OpenPRCount,
+	}
+	if !repo.LastSyncedAt.IsZero() {
+		resp.LastSyncedAt = repo.LastSyncedAt.UTC().Format(time.RFC3339)
+	}
+	if repo.Provider != "" {
+		resp.Provider = string(repo.Provider)
 	}
+	return resp
 }
@@ -0,0 +1,30 @@
+package web
+
+import (
+	"io/fs"
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/static"
+)
+
+// immutableCacheControl lets browsers keep a versioned asset for a year
+// without revalidating; a new release changes its URL instead.
+const immutableCacheControl = "public, max-age=31536000, immutable"
+
+// staticHandler serves embedded assets. Requests for the current version, as
+// linked by static.URL, are cacheable forever; unversioned or outdated ones
+// must revalidate against the asset's ETag.
+func staticHandler(assets fs.FS) http.Handler {
+	files := http.FileServerFS(assets)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hash := static.Hash(r.URL.Path); hash != "" {
+			w.Header().Set("ETag", `"`+hash+`"`)
+			if r.URL.Query().Get("v") == hash {
+				w.Header().Set("Cache-Control", immutableCacheControl)
+			} else {
+				w.Header().Set("Cache-Control", "no-cache")
+			}
+		}
+		files.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,25 @@
+// Package basepath holds the URL prefix the web GUI is served under, so
+// templates and handlers can build links that work behind a reverse proxy
+// mounting the app at a subpath such as /mygitpanel/.
+package basepath
+
+// prefix is the configured base path without a trailing slash; empty when
+// the app is served from the root.
+var prefix string
+
+// Set configures the base path. It is called once at startup, before the
+// server handles requests; p must be normalized ("" or "/mygitpanel").
+func Set(p string) {
+	prefix = p
+}
+
+// Get returns the base path, "" when the app is served from the root.
+func Get() string {
+	return prefix
+}
+
+// URL returns the browser-facing URL of the app-relative path p, which must
+// start with a slash.
+func URL(p string) string {
+	return prefix + p
+}
@@ -15,6 +15,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/a-h/templ"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/basepath"
 	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates"
 	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
 	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/pages"
@@ -39,12 +42,93 @@ const dnsLookupTimeout = 5 * time.Second
 
 // Handler is the web GUI driving adapter that serves HTML via templ components.
 type Handler struct {
-	prStore        driven.PRStore
-	repoStore      driven.RepoStore
-	reviewSvc      *application.ReviewService
-	healthSvc      *application.HealthService
-	pollSvc        *application.PollService
-	attentionSvc   *application.AttentionService
+	prStore       driven.PRStore
+	repoStore     driven.RepoStore
+	reviewSvc     *application.ReviewService
+	healthSvc     *application.HealthService
+	pollSvc       *application.PollService
+	attentionSvc  *application.AttentionService
+	comparisonSvc *application.ComparisonService
+	decisionStore driven.DecisionStore
+	// noteStore backs private per-PR notes; optional.
+	noteStore driven.PRNoteStore
+	// quickActionStore holds which quick actions PR cards show; optional.
+	quickActionStore driven.QuickActionStore
+	// cardLayoutStore holds which fields PR cards show and in what order; optional.
+	cardLayoutStore driven.CardLayoutStore
+	// githubAccountStore and githubRepoAccountStore manage named GitHub
+	// accounts and which repos use them; optional.
+	githubAccountStore     driven.GitHubAccountStore
+	githubRepoAccountStore driven.GitHubRepoAccountStore
+	// branchProtectionStore supplies stale approval dismissal per base branch; optional.
+	branchProtectionStore driven.BranchProtectionStore
+	// reviewHistoryStore backs the archive of reviewed, closed PRs; optional.
+	reviewHistoryStore driven.ReviewHistoryStore
+	// archiveSvc backs the archive of all merged and closed PRs; optional.
+	archiveSvc *application.ArchiveService
+	// teamStatsSvc backs the opt-in reviewer leaderboard; optional.
+	teamStatsSvc *application.TeamStatsService
+	// apiTokenSvc manages REST API tokens from the settings drawer; optional.
+	apiTokenSvc *application.APITokenService
+	// whatsNewSvc backs the post-upgrade what's-new panel; optional.
+	whatsNewSvc *application.WhatsNewService
+	// repoPauser pauses background polling from the bulk repo editor; optional.
+	repoPauser driven.RepoPauser
+	// signalWebhookSvc manages attention signal webhooks; optional.
+	signalWebhookSvc *application.SignalWebhookService
+	// inboxSvc backs the attention inbox and its unread badge; optional.
+	inboxSvc *application.InboxService
+	// mentionStore backs the Mentions filter; optional.
+	mentionStore driven.MentionStore
+	// slaSvc supplies the first-review SLA badge on PR cards; optional.
+	slaSvc *application.SLAService
+	// lintSvc supplies the title and branch lint badge on PR cards; optional.
+	lintSvc *application.LintService
+	// prViewStore remembers each PR's last visit for the since-last-view banner; optional.
+	prViewStore driven.PRViewStore
+	// outboxSvc queues reviews and comments that hit transient GitHub errors; optional.
+	outboxSvc *application.OutboxService
+	// replyTemplateSvc backs saved reply snippets and review templates; optional.
+	replyTemplateSvc *application.ReplyTemplateService
+	// autocompleteSvc backs emoji and @mention autocomplete in composers; optional.
+	autocompleteSvc *application.AutocompleteService
+	// groupStore backs the repo group switcher that scopes the sidebar; optional.
+	groupStore driven.RepoGroupStore
+	// embedSigner checks the tokens of the embeddable PR list; optional.
+	embedSigner *application.EmbedSigner
+	// repoImportSvc backs the bulk repo import form; optional.
+	repoImportSvc *application.RepoImportService
+	// prefStore remembers filters, density, and collapsed sections per browser; optional.
+	prefStore driven.BrowserPreferenceStore
+	// repoTrashSvc keeps removed repos restorable behind an undo toast; optional.
+	repoTrashSvc *application.RepoTrashService
+	// ruleSvc backs the attention rule builder in the settings drawer; optional.
+	ruleSvc *application.AttentionRuleService
+	// commentSearch backs searching stored comments across all PRs; optional.
+	commentSearch driven.CommentSearchStore
+	// watchStore holds per-PR watch states that override relevance; optional.
+	watchStore driven.WatchStore
+	// checkWatchStore holds "notify when checks complete" requests; optional.
+	checkWatchStore driven.CheckWatchStore
+	// repoSettingsStore holds per-repository settings such as check overrides; optional.
+	repoSettingsStore driven.RepoSettingsStore
+	// relevantPRsOnly is the PR scope of repositories left at the default,
+	// shown in the scope form (see WithRelevantPRsOnly).
+	relevantPRsOnly bool
+	// milestoneCache reuses fetched repository milestones; optional.
+	milestoneCache *application.MilestoneCache
+	// eventHookSvc backs the event webhook settings and delivery log; optional.
+	eventHookSvc *application.EventWebhookService
+	// reportSvc backs the scheduled report settings and previews; optional.
+	reportSvc *application.ReportService
+	// focusSvc backs the daily focus list; optional.
+	focusSvc *application.FocusService
+	// systemSvc backs the system status section of the settings drawer; optional.
+	systemSvc *application.SystemService
+	// botConfigStore backs the bot list in the settings drawer; optional.
+	botConfigStore driven.BotConfigStore
+	// reviewRollups recomputes review rollups after bot list changes; optional.
+	reviewRollups  *application.ReviewRollupService
 	username       string
 	logger         *slog.Logger
 	credStore      driven.CredentialStore
@@ -53,6 +137,9 @@ type Handler struct {
 	// writerFactory creates a fresh GitHubWriter per request using the current token,
 	// allowing credentials updated via the GUI to take effect without restarting.
 	writerFactory func(token string) driven.GitHubWriter
+	// providers holds the writers for repositories hosted outside GitHub (see
+	// WithSCMProvider); optional.
+	providers map[model.Provider]providerWriter
 	// jiraConnStore manages Jira connection lifecycle (create, update, delete, list).
 	jiraConnStore driven.JiraConnectionStore
 	// jiraRepoMappingStore manages per-repo Jira connection assignments.
@@ -104,8 +191,49 @@ func (h *Handler) WithAttentionService(svc *application.AttentionService) *Handl
 	return h
 }
 
+// WithMentionStore enables the Mentions filter, which narrows the PR list to
+// PRs with a comment @mentioning the user.
+func (h *Handler) WithMentionStore(store driven.MentionStore) *Handler {
+	h.mentionStore = store
+	return h
+}
+
+// WithSLAService shows a countdown or overdue badge on cards of PRs in repos
+// with a first-review SLA.
+func (h *Handler) WithSLAService(svc *application.SLAService) *Handler {
+	h.slaSvc = svc
+	return h
+}
+
+// WithLintService shows a lint badge on cards of PRs whose title or branch
+// breaks one of their repository's naming rules.
+func (h *Handler) WithLintService(svc *application.LintService) *Handler {
+	h.lintSvc = svc
+	return h
+}
+
 // Dashboard renders the main dashboard page with PR list in the sidebar.
+// Filters in the query string pre-filter the list; see listFilters.
 func (h *Handler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	// A bare visit restores the filters this browser last used, through a
+	// redirect so the URL still shows them and can be shared.
+	if r.URL.RawQuery == "" {
+		if saved, err := url.ParseQuery(h.preferences(r)[prefFilters]); err == nil {
+			values := url.Values{}
+			parseListFilters(saved).encode(values)
+			if len(values) > 0 {
+				http.Redirect(w, r, basepath.URL("/")+"?"+values.Encode(), http.StatusSeeOther)
+				return
+			}
+		}
+	}
+	h.renderDashboard(w, r, nil)
+}
+
+// renderDashboard renders the full dashboard page. detail, when non-nil,
+// fills the main area in place of the placeholder so deep links to a PR
+// render server-side.
+func (h *Handler) renderDashboard(w http.ResponseWriter, r *http.Request, detail templ.Component) {
 	prs, err := h.prStore.ListAll(r.Context())
 	if err != nil {
 		h.logger.Error("failed to list PRs", "error", err)
@@ -131,10 +259,19 @@ func (h *Handler) Dashboard(w http.ResponseWriter, r *http.Request) {
 	// Ensure CSRF cookie is set for mutating requests.
 	csrfToken(w, r)
 
-	cards := h.toPRCardViewModelsWithSignals(r.Context(), prs)
+	group := h.activeRepoGroup(w, r)
+	filters := parseListFilters(r.URL.Query())
+	cards := h.toPRCardViewModelsWithSignals(r.Context(), h.applyListFilters(r.Context(), scopePRsToGroup(prs, group), filters))
 	data := h.buildDashboardViewModel(r.Context(), cards, repos, ignoredPRs, globalSettings)
-	component := pages.Dashboard(data)
-	layout := templates.Layout("ReviewHub", component, globalSettings, data.JiraConnections)
+	data.RepoNames = extractRepoNames(scopeReposToGroup(repos, group))
+	data.Groups = h.repoGroupOptions(r.Context(), group)
+	data.List = h.prListLayout(w, r)
+	data.Filters = filters.viewModel()
+	data.Preferences = h.preferences(r)
+	component := pages.Dashboard(data, detail)
+	quickActions := toQuickActionSettings(h.quickActions(r.Context()), h.approveSettings(r.Context()))
+	cardFields := toCardFieldOptions(h.cardFields(r.Context()))
+	layout := templates.Layout("ReviewHub", component, globalSettings, data.JiraConnections, h.githubSettings(r.Context()), quickActions, cardFields, h.apiTokenViewModels(r.Context()), h.signalWebhookSettings(r.Context()), h.replyTemplateSettings(r.Context()))
 
 	if err := layout.Render(r.Context(), w); err != nil {
 		h.logger.Error("failed to render dashboard", "error", err)
@@ -142,10 +279,9 @@ func (h *Handler) Dashboard(w http.ResponseWriter, r *http.Request) {
 }
 
 // SearchPRs handles HTMX search/filter requests and returns an updated PR list partial.
+// The browser URL is updated to carry the filters so it can be shared.
 func (h *Handler) SearchPRs(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query().Get("q")
-	status := r.URL.Query().Get("status")
-	repo := r.URL.Query().Get("repo")
+	filters := parseListFilters(r.URL.Query())
 
 	prs, err := h.prStore.ListAll(r.Context())
 	if err != nil {
@@ -154,17 +290,45 @@ func (h *Handler) SearchPRs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	filtered := filterPRs(prs, query, status, repo)
+	group := h.activeRepoGroup(w, r)
+	prs = scopePRsToGroup(prs, group)
+
+	filtered := h.applyListFilters(r.Context(), prs, filters)
 	cards := h.toPRCardViewModelsWithSignals(r.Context(), filtered)
-	component := partials.PRList(cards, nil)
+	component := partials.PRList(cards, h.prListLayout(w, r), nil)
+
+	saved := url.Values{}
+	filters.encode(saved)
+	h.savePreference(w, r, prefFilters, saved.Encode())
+
+	if current := currentPageURL(r); current != nil {
+		w.Header().Set("HX-Replace-Url", deepLink(current.Path, filters, current.Query().Get("tab")))
+	}
 
 	if err := component.Render(r.Context(), w); err != nil {
 		h.logger.Error("failed to render search results", "error", err)
+		return
+	}
+
+	// Switching groups also narrows the repo filter to the group's repos.
+	if r.Header.Get("HX-Trigger-Name") == "group" {
+		repos, err := h.repoStore.ListAll(r.Context())
+		if err != nil {
+			h.logger.Warn("failed to list repos for group filter", "error", err)
+			return
+		}
+		filterComp := components.RepoFilterOptions(extractRepoNames(scopeReposToGroup(repos, group)))
+		if err := filterComp.Render(r.Context(), w); err != nil {
+			h.logger.Error("failed to render OOB repo filter", "error", err)
+		}
 	}
 }
 
-// GetPRDetail renders the PR detail partial for HTMX swap into the main panel.
-// Enrichment failures (review, health) are non-fatal: basic PR data is always shown.
+// GetPRDetail renders the PR detail partial for HTMX swap into the main panel,
+// pushing its URL with the list filters so the view can be shared. Loaded
+// directly, e.g. from a pasted link, it renders the whole dashboard with the
+// detail open on the ?tab= tab.
+// Enrichment failures (Jira, competing PRs) are non-fatal: basic PR data is always shown.
 func (h *Handler) GetPRDetail(w http.ResponseWriter, r *http.Request) {
 	owner := r.PathValue("owner")
 	repo := r.PathValue("repo")
@@ -190,41 +354,35 @@ func (h *Handler) GetPRDetail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Enrich with review data (non-fatal).
-	var summary *application.PRReviewSummary
-	var botUsernames []string
-
-	if h.reviewSvc != nil {
-		summary, err = h.reviewSvc.GetPRReviewSummary(r.Context(), pr.ID, pr.HeadSHA)
-		if err != nil {
-			h.logger.Error("failed to get review summary", "error", err)
-		}
-
-		if summary != nil {
-			botUsernames = summary.BotUsernames
-		}
-	}
-
-	// Enrich with health/CI data (non-fatal).
-	var checkRuns []model.CheckRun
-
-	if h.healthSvc != nil {
-		healthSummary, healthErr := h.healthSvc.GetPRHealthSummary(r.Context(), pr.ID, pr.RepoFullName, pr.Number)
-		if healthErr != nil {
-			h.logger.Error("failed to get health summary", "error", healthErr)
-		}
-
-		if healthSummary != nil {
-			checkRuns = healthSummary.CheckRuns
-		}
-	}
-
-	detail := toPRDetailViewModel(*pr, summary, checkRuns, botUsernames, h.authenticatedUsername(r.Context()))
+	// Reviews, threads, checks, and merge requirements load afterwards as
+	// sections (see handler_prdetailsections.go), so the detail opens from
+	// stored PR data alone.
+	username := h.usernameForRepo(r.Context(), pr.RepoFullName)
+	detail := toPRDetailViewModel(*pr, nil, nil, username)
+	h.applyNotes(r.Context(), &detail)
+	h.applyWatch(r.Context(), &detail)
+	h.applyChecksWatch(r.Context(), &detail)
+	h.applyApprove(r.Context(), &detail, *pr)
+	applyActionsAvailability(&detail, h.actionsDisabledReason(r.Context(), pr.RepoFullName, h.syncStatus()))
 
 	// Jira enrichment (non-fatal — errors populate LoadError, never prevent the detail from rendering).
 	detail.JiraCard = h.buildJiraCardVM(r.Context(), *pr, owner, repo, number)
 
+	// Competing PR enrichment (non-fatal).
+	detail.Competing = h.buildCompetingPRsVM(r.Context(), pr.ID)
+	detail.Tab = prDetailTab(r.URL.Query().Get("tab"))
+
 	component := partials.PRDetailContent(detail)
+	if !isHTMXNavigation(r) {
+		h.renderDashboard(w, r, component)
+		return
+	}
+
+	filters := parseListFilters(r.URL.Query())
+	if current := currentPageURL(r); current != nil {
+		filters = parseListFilters(current.Query())
+	}
+	w.Header().Set("HX-Push-Url", deepLink(basepath.URL(detail.DetailPath), filters, detail.Tab))
 
 	if err := component.Render(r.Context(), w); err != nil {
 		h.logger.Error("failed to render PR detail", "error", err)
@@ -329,14 +487,12 @@ func (h *Handler) CreateJiraComment(w http.ResponseWriter, r *http.Request) {
 
 	body := strings.TrimSpace(r.FormValue("body"))
 	if body == "" {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Comment body is required</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Comment body is required")
 		return
 	}
 
 	if h.jiraRepoMappingStore == nil || h.jiraClientFactory == nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Jira integration not configured</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Jira integration not configured")
 		return
 	}
 
@@ -345,28 +501,24 @@ func (h *Handler) CreateJiraComment(w http.ResponseWriter, r *http.Request) {
 	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number)
 	if err != nil || pr == nil {
 		h.logger.Error("failed to get PR for jira comment", "repo", repoFullName, "number", number, "error", err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Pull request not found</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Pull request not found")
 		return
 	}
 
 	if pr.JiraKey == "" {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">No Jira issue linked to this PR</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "No Jira issue linked to this PR")
 		return
 	}
 
 	conn, err := h.jiraRepoMappingStore.GetForRepo(r.Context(), pr.RepoFullName)
 	if err != nil {
 		h.logger.Error("jira: getForRepo failed", "repo", pr.RepoFullName, "error", err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Failed to resolve Jira connection</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Failed to resolve Jira connection")
 		return
 	}
 
 	if conn.ID == 0 {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">No Jira connection configured for this repo</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "No Jira connection configured for this repo")
 		return
 	}
 
@@ -374,15 +526,13 @@ func (h *Handler) CreateJiraComment(w http.ResponseWriter, r *http.Request) {
 
 	// Validate connectivity before posting.
 	if err := client.Ping(r.Context()); err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">%s</span>`, html.EscapeString(friendlyJiraError(err)))
+		writeErrorToast(w, http.StatusUnprocessableEntity, friendlyJiraError(err))
 		return
 	}
 
 	if err := client.AddComment(r.Context(), pr.JiraKey, body); err != nil {
 		h.logger.Error("jira: add comment failed", "key", pr.JiraKey, "error", err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Failed to post comment: %s</span>`, html.EscapeString(friendlyJiraError(err)))
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Failed to post comment: "+friendlyJiraError(err))
 		return
 	}
 
@@ -412,6 +562,14 @@ func (h *Handler) AddRepo(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid repository name: expected owner/repo format", http.StatusBadRequest)
 		return
 	}
+	provider := model.ProviderGitHub
+	if v := r.FormValue("provider"); v != "" {
+		provider = model.Provider(v)
+	}
+	if !provider.Valid() {
+		http.Error(w, "invalid provider: expected github or bitbucket", http.StatusBadRequest)
+		return
+	}
 
 	parts := strings.SplitN(fullName, "/", 2)
 	repo := model.Repository{
@@ -419,6 +577,7 @@ func (h *Handler) AddRepo(w http.ResponseWriter, r *http.Request) {
 		Owner:    parts[0],
 		Name:     parts[1],
 		AddedAt:  time.Now().UTC(),
+		Provider: provider,
 	}
 
 	if err := h.repoStore.Add(r.Context(), repo); err != nil {
@@ -444,6 +603,8 @@ func (h *Handler) AddRepo(w http.ResponseWriter, r *http.Request) {
 }
 
 // RemoveRepo removes a repo from the watch list via the GUI and returns updated partials.
+// With a RepoTrashService the repo's history is kept and an undo toast offers
+// to restore it.
 func (h *Handler) RemoveRepo(w http.ResponseWriter, r *http.Request) {
 	if !validateCSRF(r) {
 		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
@@ -454,7 +615,11 @@ func (h *Handler) RemoveRepo(w http.ResponseWriter, r *http.Request) {
 	repo := r.PathValue("repo")
 	fullName := owner + "/" + repo
 
-	if err := h.repoStore.Remove(r.Context(), fullName); err != nil {
+	remove := h.repoStore.Remove
+	if h.repoTrashSvc != nil {
+		remove = h.repoTrashSvc.Remove
+	}
+	if err := remove(r.Context(), fullName); err != nil {
 		if errors.Is(err, driven.ErrRepoNotFound) {
 			http.Error(w, "repository not found", http.StatusNotFound)
 			return
@@ -465,6 +630,14 @@ func (h *Handler) RemoveRepo(w http.ResponseWriter, r *http.Request) {
 	}
 
 	h.renderRepoMutationResponse(w, r)
+	if h.repoTrashSvc != nil {
+		h.renderUndoToast(w, r, vm.UndoToastViewModel{
+			Message:  "Removed " + fullName,
+			UndoPath: fmt.Sprintf("/app/repos/%s/%s/restore", owner, repo),
+			Target:   "#repo-list",
+		})
+		h.renderRemovedReposOOB(w, r)
+	}
 }
 
 // renderRepoMutationResponse renders the updated repo list with OOB swaps for PR list
@@ -484,9 +657,10 @@ func (h *Handler) renderRepoMutationResponse(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	group := h.activeRepoGroup(w, r)
 	repoVMs := h.toRepoViewModels(r.Context(), repos)
-	cards := h.toPRCardViewModelsWithSignals(r.Context(), prs)
-	repoNames := extractRepoNames(repos)
+	cards := h.toPRCardViewModelsWithSignals(r.Context(), scopePRsToGroup(prs, group))
+	repoNames := extractRepoNames(scopeReposToGroup(repos, group))
 
 	ignoredPRs, ignoredErr := h.prStore.ListIgnoredWithPRData(r.Context())
 	if ignoredErr != nil {
@@ -494,26 +668,15 @@ func (h *Handler) renderRepoMutationResponse(w http.ResponseWriter, r *http.Requ
 		ignoredPRs = nil
 	}
 
-	// Fetch Jira connections for repo popover assignment dropdown.
-	var jiraConnVMs []vm.JiraConnectionViewModel
-	if h.jiraConnStore != nil {
-		conns, jiraErr := h.jiraConnStore.List(r.Context())
-		if jiraErr != nil {
-			h.logger.Warn("failed to list jira connections for repo list", "error", jiraErr)
-		} else {
-			jiraConnVMs = h.toJiraConnectionViewModels(conns)
-		}
-	}
-
 	// Primary target: repo list.
-	repoListComp := partials.RepoList(repoVMs, jiraConnVMs)
+	repoListComp := partials.RepoList(repoVMs, h.repoJiraConnectionViewModels(r.Context()), h.githubAccountViewModels(r.Context()))
 	if err := repoListComp.Render(r.Context(), w); err != nil {
 		h.logger.Error("failed to render repo list", "error", err)
 		return
 	}
 
 	// OOB swap: PR list.
-	prListComp := partials.PRListOOB(cards, ignoredPRs)
+	prListComp := partials.PRListOOB(cards, h.prListLayout(w, r), ignoredPRs)
 	if err := prListComp.Render(r.Context(), w); err != nil {
 		h.logger.Error("failed to render OOB PR list", "error", err)
 		return
@@ -526,14 +689,28 @@ func (h *Handler) renderRepoMutationResponse(w http.ResponseWriter, r *http.Requ
 	}
 }
 
+// repoJiraConnectionViewModels lists Jira connections for the repo popover
+// assignment dropdown. Failures are logged and yield no options.
+func (h *Handler) repoJiraConnectionViewModels(ctx context.Context) []vm.JiraConnectionViewModel {
+	if h.jiraConnStore == nil {
+		return nil
+	}
+	conns, err := h.jiraConnStore.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list jira connections for repo list", "error", err)
+		return nil
+	}
+	return h.toJiraConnectionViewModels(conns)
+}
+
 // IgnorePR handles POST /app/prs/{id}/ignore.
 // It marks a PR as ignored and returns an OOB swap to refresh the PR list.
 func (h *Handler) IgnorePR(w http.ResponseWriter, r *http.Request) {
 	var action func(context.Context, int64) error
 	if h.ignoreStore != nil {
-		action = h.ignoreStore.Ignore
+		action = func(ctx context.Context, id int64) error { return h.ignoreStore.Ignore(ctx, id, "") }
 	}
-	h.handleIgnoreToggle(w, r, action, "failed to ignore PR")
+	h.handleIgnoreToggle(w, r, action, "failed to ignore PR", "/app/prs/%d/unignore")
 }
 
 // UnignorePR handles POST /app/prs/{id}/unignore.
@@ -543,12 +720,14 @@ func (h *Handler) UnignorePR(w http.ResponseWriter, r *http.Request) {
 	if h.ignoreStore != nil {
 		action = h.ignoreStore.Unignore
 	}
-	h.handleIgnoreToggle(w, r, action, "failed to unignore PR")
+	h.handleIgnoreToggle(w, r, action, "failed to unignore PR", "")
 }
 
 // handleIgnoreToggle is the shared implementation for IgnorePR and UnignorePR.
 // action is called with the parsed PR ID if non-nil; pass nil to skip the store call.
-func (h *Handler) handleIgnoreToggle(w http.ResponseWriter, r *http.Request, action func(context.Context, int64) error, logMsg string) {
+// undoPath, a format string taking the PR ID, adds an undo toast posting to
+// it; pass "" for none.
+func (h *Handler) handleIgnoreToggle(w http.ResponseWriter, r *http.Request, action func(context.Context, int64) error, logMsg, undoPath string) {
 	if !validateCSRF(r) {
 		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
 		return
@@ -572,6 +751,13 @@ func (h *Handler) handleIgnoreToggle(w http.ResponseWriter, r *http.Request, act
 	}
 
 	h.renderPRListOOB(w, r)
+	if undoPath != "" {
+		h.renderUndoToast(w, r, vm.UndoToastViewModel{
+			Message:  "PR ignored",
+			UndoPath: fmt.Sprintf(undoPath, id),
+			Target:   "#pr-list",
+		})
+	}
 }
 
 // SaveGlobalThresholds handles POST /app/settings/thresholds/global.
@@ -603,14 +789,27 @@ func (h *Handler) SaveGlobalThresholds(w http.ResponseWriter, r *http.Request) {
 	if ageDays < 0 {
 		ageDays = 0
 	}
+	requestDays, err := strconv.Atoi(r.FormValue("request_age_days"))
+	if err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: request_age_days must be a number</span>`)
+		return
+	}
+	if requestDays < 0 {
+		requestDays = 0
+	}
 	staleEnabled := r.FormValue("stale_review_enabled") == "on" || r.FormValue("stale_review_enabled") == "1"
 	ciEnabled := r.FormValue("ci_failure_enabled") == "on" || r.FormValue("ci_failure_enabled") == "1"
+	boostEnabled := r.FormValue("first_timer_boost_enabled") == "on" || r.FormValue("first_timer_boost_enabled") == "1"
+	checklistEnabled := r.FormValue("checklist_enabled") == "on" || r.FormValue("checklist_enabled") == "1"
 
 	settings := model.GlobalSettings{
-		ReviewCountThreshold: reviewCount,
-		AgeUrgencyDays:       ageDays,
-		StaleReviewEnabled:   staleEnabled,
-		CIFailureEnabled:     ciEnabled,
+		ReviewCountThreshold:   reviewCount,
+		AgeUrgencyDays:         ageDays,
+		StaleReviewEnabled:     staleEnabled,
+		CIFailureEnabled:       ciEnabled,
+		FirstTimerBoostEnabled: boostEnabled,
+		ChecklistEnabled:       checklistEnabled,
+		RequestAgeDays:         requestDays,
 	}
 
 	if h.thresholdStore == nil {
@@ -668,6 +867,14 @@ func (h *Handler) SaveRepoThreshold(w http.ResponseWriter, r *http.Request) {
 		}
 		threshold.AgeUrgencyDays = &n
 	}
+	if v := r.FormValue("first_review_sla_hours"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: first_review_sla_hours must be a non-negative integer</span>`)
+			return
+		}
+		threshold.FirstReviewSLAHours = &n
+	}
 	switch r.FormValue("stale_review_enabled") {
 	case "true":
 		b := true
@@ -746,8 +953,8 @@ func (h *Handler) renderPRListOOB(w http.ResponseWriter, r *http.Request) {
 		ignoredPRs = nil
 	}
 
-	cards := h.toPRCardViewModelsWithSignals(r.Context(), prs)
-	prListComp := partials.PRListOOB(cards, ignoredPRs)
+	cards := h.toPRCardViewModelsWithSignals(r.Context(), scopePRsToGroup(prs, h.activeRepoGroup(w, r)))
+	prListComp := partials.PRListOOB(cards, h.prListLayout(w, r), ignoredPRs)
 	if err := prListComp.Render(r.Context(), w); err != nil {
 		h.logger.Error("failed to render OOB PR list", "error", err)
 	}
@@ -774,6 +981,43 @@ func filterPRs(prs []model.PullRequest, query, status, repo string) []model.Pull
 	return filtered
 }
 
+// filterMentioned keeps the PRs with a comment @mentioning the user, resolving
+// the user per repository since repos may be routed to different accounts.
+// Without a MentionStore, or when it fails, no PRs match.
+func (h *Handler) filterMentioned(ctx context.Context, prs []model.PullRequest) []model.PullRequest {
+	if h.mentionStore == nil {
+		return nil
+	}
+
+	usersByRepo := make(map[string]string)
+	mentionedByUser := make(map[string]map[int64]bool)
+	filtered := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		user, ok := usersByRepo[pr.RepoFullName]
+		if !ok {
+			user = h.usernameForRepo(ctx, pr.RepoFullName)
+			usersByRepo[pr.RepoFullName] = user
+		}
+		mentioned, ok := mentionedByUser[user]
+		if !ok {
+			ids, err := h.mentionStore.ListPRIDsMentioning(ctx, user)
+			if err != nil {
+				h.logger.Error("failed to list mentioned PRs", "user", user, "error", err)
+				return nil
+			}
+			mentioned = make(map[int64]bool, len(ids))
+			for _, id := range ids {
+				mentioned[id] = true
+			}
+			mentionedByUser[user] = mentioned
+		}
+		if mentioned[pr.ID] {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
 // matchesPRQuery checks if any searchable PR field contains the query substring.
 func matchesPRQuery(pr model.PullRequest, queryLower string) bool {
 	return strings.Contains(strings.ToLower(pr.Title), queryLower) ||
@@ -808,6 +1052,14 @@ func (h *Handler) buildDashboardViewModel(ctx context.Context, cards []vm.PRCard
 		IgnoredPRs:      ignoredCards,
 		GlobalSettings:  globalSettings,
 		JiraConnections: jiraConnVMs,
+		GitHubAccounts:  h.githubAccountViewModels(ctx),
+		InboxEnabled:    h.inboxSvc != nil,
+		MentionsEnabled: h.mentionStore != nil,
+		InboxUnread:     h.inboxUnread(ctx),
+		TodayEnabled:    h.focusSvc != nil,
+		OutboxEnabled:   h.outboxSvc != nil,
+		StatsEnabled:    h.teamStatsSvc != nil,
+		Sync:            toSyncBannerViewModel(h.syncStatus(), repos, time.Now()),
 	}
 }
 
@@ -815,62 +1067,104 @@ func (h *Handler) buildDashboardViewModel(ctx context.Context, cards []vm.PRCard
 // Thresholds are resolved once per unique repo to avoid N+1 DB lookups. On signal computation
 // failure, falls back to zero-value signals (non-fatal).
 func (h *Handler) toPRCardViewModelsWithSignals(ctx context.Context, prs []model.PullRequest) []vm.PRCardViewModel {
-	// Pre-fetch thresholds once per unique repo.
-	thresholdsByRepo := make(map[string]model.EffectiveThresholds, len(prs))
+	// Per-PR data is loaded in batches and per-repo settings once per repo,
+	// so the number of queries does not grow with the number of PRs.
+	var signalsByPR map[int64]model.AttentionSignals
 	if h.attentionSvc != nil {
+		thresholdsByRepo := make(map[string]model.EffectiveThresholds, len(prs))
 		for _, pr := range prs {
 			if _, seen := thresholdsByRepo[pr.RepoFullName]; !seen {
 				thresholdsByRepo[pr.RepoFullName] = h.attentionSvc.EffectiveThresholdsFor(ctx, pr.RepoFullName)
 			}
 		}
+		signalsByPR = h.attentionSvc.SignalsForPRs(ctx, prs, thresholdsByRepo)
+	}
+	var slaByPR map[int64]model.SLAStatus
+	if h.slaSvc != nil {
+		slaByPR = h.slaSvc.StatusesFor(ctx, prs)
+	}
+
+	enabledActions := h.quickActions(ctx)
+	approveConfirm := h.approveSettings(ctx).Confirm
+	fields := h.cardFields(ctx)
+	reviewersByPR := h.cardReviewers(ctx, prs, fields)
+	usersByRepo := make(map[string]string)
+	disabledByRepo := make(map[string]string)
+	sync := h.syncStatus()
+	now := time.Now()
+	watches := h.watchStates(ctx)
+
+	var lintByPR map[int64][]model.LintRule
+	if h.lintSvc != nil {
+		var err error
+		if lintByPR, err = h.lintSvc.ViolationsByPR(ctx, ""); err != nil {
+			h.logger.Warn("failed to get lint violations for cards", "error", err)
+		}
 	}
 
 	cards := make([]vm.PRCardViewModel, 0, len(prs))
 	for _, pr := range prs {
-		var signals model.AttentionSignals
-		if h.attentionSvc != nil {
-			var err error
-			signals, err = h.attentionSvc.SignalsForPR(ctx, pr, thresholdsByRepo[pr.RepoFullName])
-			if err != nil {
-				h.logger.Warn("failed to compute attention signals, using zero-value", "pr_id", pr.ID, "error", err)
-			}
+		signals := signalsByPR[pr.ID]
+		user, ok := usersByRepo[pr.RepoFullName]
+		if !ok {
+			user = h.usernameForRepo(ctx, pr.RepoFullName)
+			usersByRepo[pr.RepoFullName] = user
+		}
+		card := toPRCardViewModel(pr, signals)
+		card.QuickActions = h.cardQuickActions(enabledActions, pr, user)
+		card.ApproveConfirm = approveConfirm
+		card.Fields = fields
+		card.Reviewers = reviewersByPR[pr.ID]
+		card.Watch = watches[pr.ID]
+		reason, seen := disabledByRepo[pr.RepoFullName]
+		if !seen {
+			reason = h.actionsDisabledReason(ctx, pr.RepoFullName, sync)
+			disabledByRepo[pr.RepoFullName] = reason
+		}
+		card.ActionsDisabledReason = reason
+		for _, rule := range lintByPR[pr.ID] {
+			card.LintViolations = append(card.LintViolations, rule.Label())
+		}
+		if at, ok := pr.ReviewRequestedAt(user); ok && pr.Status == model.PRStatusOpen {
+			card.RequestedLabel = "requested " + formatShortDuration(now.Sub(at)) + " ago"
 		}
-		cards = append(cards, toPRCardViewModel(pr, signals))
+		if status, ok := slaByPR[pr.ID]; ok {
+			card.SLALabel, card.SLAOverdue = slaBadge(status, now)
+		}
+		cards = append(cards, card)
 	}
 	return cards
 }
 
-// requireGitHubToken retrieves and validates the stored GitHub token.
+// requireGitHubToken retrieves and validates the GitHub token for repoFullName:
+// the token of its assigned account if any, otherwise the stored default token.
 // It writes an HTML error fragment and returns "" when the token is unavailable;
 // callers must return immediately when the result is "".
-// action describes the operation (e.g. "reply to comments") and is HTML-escaped in error output.
-func (h *Handler) requireGitHubToken(w http.ResponseWriter, r *http.Request, action string) string {
-	safeAction := html.EscapeString(action)
+// action describes the operation (e.g. "reply to comments") in the error message.
+func (h *Handler) requireGitHubToken(w http.ResponseWriter, r *http.Request, repoFullName, action string) string {
+	if account := h.githubAccountForRepo(r.Context(), repoFullName); account.Token != "" && h.writerFactory != nil {
+		return account.Token
+	}
 	if h.credStore == nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Configure a GitHub token in Settings to %s.</p>`, safeAction)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Configure a GitHub token in Settings to "+action+".")
 		return ""
 	}
 	token, err := h.credStore.Get(r.Context(), "github_token")
 	if errors.Is(err, driven.ErrEncryptionKeyNotSet) {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Credential storage requires MYGITPANEL_SECRET_KEY to be set.</p>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Credential storage requires MYGITPANEL_SECRET_KEY to be set.")
 		return ""
 	}
 	if err != nil {
 		h.logger.Error("failed to retrieve github token", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Failed to read credentials.</p>`)
+		writeErrorToast(w, http.StatusInternalServerError, "Failed to read credentials.")
 		return ""
 	}
 	if token == "" {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Configure a GitHub token in Settings to %s.</p>`, safeAction)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Configure a GitHub token in Settings to "+action+".")
 		return ""
 	}
 	if h.writerFactory == nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">GitHub write operations are not configured.</p>`)
+		writeErrorToast(w, http.StatusInternalServerError, "GitHub write operations are not configured.")
 		return ""
 	}
 	return token
@@ -961,16 +1255,40 @@ func (h *Handler) toRepoViewModels(ctx context.Context, repos []model.Repository
 		mappings = map[string]int64{}
 	}
 
+	accounts := h.githubAccountAssignments(ctx, repos)
+
+	var schedules map[string]application.ScheduleInfo
+	if h.pollSvc != nil {
+		schedules = h.pollSvc.Schedules()
+	}
+
+	now := time.Now()
 	vms := make([]vm.RepoViewModel, 0, len(repos))
 	for _, r := range repos {
-		vms = append(vms, vm.RepoViewModel{
+		repoVM := vm.RepoViewModel{
 			FullName:                 r.FullName,
 			Owner:                    r.Owner,
 			Name:                     r.Name,
 			DeletePath:               fmt.Sprintf("/app/repos/%s/%s", r.Owner, r.Name),
 			AssignedJiraConnectionID: mappings[r.FullName],
-		})
+			AssignedGitHubAccountID:  accounts[r.FullName],
+			Paused:                   r.Paused,
+			Details:                  repoDetails(r.Metadata),
+			Private:                  r.Metadata.Private,
+			Archived:                 r.Metadata.Archived,
+			OpenPRCount:              r.Metadata.OpenPRCount,
+			Bitbucket:                r.Provider == model.ProviderBitbucket,
+		}
+		repoVM.LastSynced, repoVM.LastSyncedTitle = lastSyncedLabel(r.LastSyncedAt, now)
+		if sched, ok := schedules[r.FullName]; ok && sched.Circuit != application.CircuitClosed {
+			repoVM.Unreachable = true
+			repoVM.MovedTo = sched.MovedTo
+			repoVM.UnreachableTitle = fmt.Sprintf("%d failed polls; next retry %s. Last error: %s",
+				sched.ConsecutiveFailures, sched.NextPollAt.Format("15:04"), sched.LastError)
+		}
+		vms = append(vms, repoVM)
 	}
+	h.applyRepoSettings(ctx, vms)
 	return vms
 }
 
@@ -1009,11 +1327,32 @@ func (h *Handler) SaveGitHubCredentials(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	baseURL := strings.TrimSpace(r.FormValue("github_base_url"))
+	graphqlURL := strings.TrimSpace(r.FormValue("github_graphql_url"))
+	if err := validateGitHubEndpoints(baseURL, graphqlURL); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: %s</span>`, html.EscapeString(err.Error()))
+		return
+	}
+
+	// Writers read the endpoints from the credential store, so save them first
+	// and put the previous ones back if the token does not validate there.
+	restoreEndpoints, err := h.saveGitHubEndpoints(r.Context(), baseURL, graphqlURL)
+	if err != nil {
+		if errors.Is(err, driven.ErrEncryptionKeyNotSet) {
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Credential storage requires MYGITPANEL_SECRET_KEY to be set</span>`)
+			return
+		}
+		h.logger.Error("failed to store github endpoints", "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to save GitHub Enterprise URLs</span>`)
+		return
+	}
+
 	// Validate the token against the GitHub API.
 	// A token-less writer is sufficient for validation since we pass the token explicitly.
 	validatedUsername, err := h.writerFactory("").ValidateToken(r.Context(), token)
 	if err != nil {
-		h.logger.Error("github token validation failed", "error", err)
+		restoreEndpoints()
+		h.logger.Error("github token validation failed", "base_url", baseURL, "error", err)
 		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: %s</span>`, html.EscapeString(err.Error()))
 		return
 	}
@@ -1038,6 +1377,75 @@ func (h *Handler) SaveGitHubCredentials(w http.ResponseWriter, r *http.Request)
 	fmt.Fprintf(w, `<span class="text-green-600 text-sm">GitHub token: configured (%s)</span>`, html.EscapeString(validatedUsername))
 }
 
+// validateGitHubEndpoints checks the optional GitHub Enterprise URLs from the
+// credentials form. Both empty means github.com.
+func validateGitHubEndpoints(baseURL, graphqlURL string) error {
+	if baseURL == "" && graphqlURL != "" {
+		return errors.New("GraphQL URL requires an Enterprise base URL")
+	}
+	for _, raw := range []string{baseURL, graphqlURL} {
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%q is not an absolute http or https URL", raw)
+		}
+	}
+	return nil
+}
+
+// saveGitHubEndpoints stores the GitHub Enterprise URLs, deleting them when
+// empty so github.com (or the env var defaults) apply again. The returned
+// function restores the previously stored values.
+func (h *Handler) saveGitHubEndpoints(ctx context.Context, baseURL, graphqlURL string) (restore func(), err error) {
+	keys := []string{"github_base_url", "github_graphql_url"}
+	values := []string{baseURL, graphqlURL}
+
+	previous := make([]string, len(keys))
+	for i, key := range keys {
+		if previous[i], err = h.credStore.Get(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+
+	set := func(vals []string) error {
+		for i, key := range keys {
+			var err error
+			if vals[i] == "" {
+				err = h.credStore.Delete(ctx, key)
+			} else {
+				err = h.credStore.Set(ctx, key, vals[i])
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := set(values); err != nil {
+		return nil, err
+	}
+	return func() {
+		if err := set(previous); err != nil {
+			h.logger.Error("failed to restore github endpoints", "error", err)
+		}
+	}, nil
+}
+
+// githubSettings loads the stored GitHub Enterprise URLs for the credentials
+// form. Failures (including a missing secret key) yield empty fields.
+func (h *Handler) githubSettings(ctx context.Context) vm.GitHubSettingsViewModel {
+	settings := vm.GitHubSettingsViewModel{Accounts: h.githubAccountViewModels(ctx)}
+	if h.credStore == nil {
+		return settings
+	}
+	settings.BaseURL, _ = h.credStore.Get(ctx, "github_base_url")
+	settings.GraphQLURL, _ = h.credStore.Get(ctx, "github_graphql_url")
+	return settings
+}
+
 // SaveJiraCredentials is a deprecated stub that returns 410 Gone.
 // The single-connection endpoint has been replaced by multi-connection handlers in Phase 9.
 func (h *Handler) SaveJiraCredentials(w http.ResponseWriter, r *http.Request) {
@@ -1048,8 +1456,7 @@ func (h *Handler) SaveJiraCredentials(w http.ResponseWriter, r *http.Request) {
 // It validates the connection via Ping before persisting and returns the updated connection list HTML fragment.
 func (h *Handler) CreateJiraConnection(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: invalid form data</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
 		return
 	}
 
@@ -1064,20 +1471,17 @@ func (h *Handler) CreateJiraConnection(w http.ResponseWriter, r *http.Request) {
 	token := strings.TrimSpace(r.FormValue("token"))
 
 	if displayName == "" || baseURL == "" || email == "" || token == "" {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">All fields are required</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "All fields are required")
 		return
 	}
 
 	if err := validateJiraBaseURL(r.Context(), baseURL); err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Invalid base URL: %s</span>`, html.EscapeString(err.Error()))
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Invalid base URL: "+err.Error())
 		return
 	}
 
 	if h.jiraClientFactory == nil || h.jiraConnStore == nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Jira integration not configured</span>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Jira integration not configured")
 		return
 	}
 
@@ -1106,13 +1510,11 @@ func (h *Handler) CreateJiraConnection(w http.ResponseWriter, r *http.Request) {
 
 	if _, err := h.jiraConnStore.Create(r.Context(), conn); err != nil {
 		if errors.Is(err, driven.ErrEncryptionKeyNotSet) {
-			w.WriteHeader(http.StatusUnprocessableEntity)
-			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Credential storage requires MYGITPANEL_SECRET_KEY to be set.</span>`)
+			writeErrorToast(w, http.StatusUnprocessableEntity, "Credential storage requires MYGITPANEL_SECRET_KEY to be set.")
 			return
 		}
 		h.logger.Error("failed to create jira connection", "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to save connection</span>`)
+		writeErrorToast(w, http.StatusInternalServerError, "Error: failed to save connection")
 		return
 	}
 
@@ -1263,8 +1665,7 @@ func (h *Handler) CreateReplyComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := r.ParseForm(); err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: invalid form data</p>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
 		return
 	}
 
@@ -1276,37 +1677,42 @@ func (h *Handler) CreateReplyComment(w http.ResponseWriter, r *http.Request) {
 	body := strings.TrimSpace(r.FormValue("body"))
 
 	if body == "" {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: reply body cannot be empty</p>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: reply body cannot be empty")
 		return
 	}
 
-	token := h.requireGitHubToken(w, r, "reply to comments")
-	if token == "" {
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "reply to comments")
+	if writer == nil {
 		return
 	}
 
-	repoFullName := owner + "/" + repo
-	writer := h.writerFactory(token)
-
 	if err := writer.CreateReplyComment(r.Context(), repoFullName, number, rootID, body); err != nil {
+		if h.queueOnTransient(w, r, err, repoFullName, number, owner, repo, func(ctx context.Context) (model.OutboundAction, error) {
+			return h.outboxSvc.EnqueueReply(ctx, repoFullName, number, rootID, body, err)
+		}) {
+			return
+		}
 		h.logger.Error("failed to create reply comment", "repo", repoFullName, "pr", number, "error", err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: %s</p>`, html.EscapeString(err.Error()))
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: "+err.Error())
 		return
 	}
 
+	h.echoPostedComment(r.Context(), repoFullName, number, func(ctx context.Context, prID int64, author string) error {
+		return h.reviewSvc.EchoReply(ctx, prID, rootID, author, body)
+	})
+
 	// Re-fetch PR and render the updated thread for morph swap targeting #thread-{rootID}.
-	h.renderThreadAfterReply(w, r, repoFullName, number, rootID, owner, repo)
+	h.renderThread(w, r, repoFullName, number, rootID, owner, repo)
 }
 
-// renderThreadAfterReply fetches updated PR review data and renders just the
-// updated thread component for morph swap targeting #thread-{rootID}.
-func (h *Handler) renderThreadAfterReply(w http.ResponseWriter, r *http.Request, repoFullName string, prNumber int, rootID int64, owner, repo string) {
+// renderThread fetches updated PR review data and renders just the updated
+// thread component for morph swap targeting #thread-{rootID}. Used after a
+// reply and after marking or clearing a decision.
+func (h *Handler) renderThread(w http.ResponseWriter, r *http.Request, repoFullName string, prNumber int, rootID int64, owner, repo string) {
 	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, prNumber)
 	if err != nil || pr == nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: failed to load PR after reply</p>`)
+		writeErrorToast(w, http.StatusInternalServerError, "Error: failed to load PR")
 		return
 	}
 
@@ -1318,12 +1724,12 @@ func (h *Handler) renderThreadAfterReply(w http.ResponseWriter, r *http.Request,
 		}
 	}
 
-	var botUsernames []string
-	if summary != nil {
-		botUsernames = summary.BotUsernames
-	}
-
-	detail := toPRDetailViewModel(*pr, summary, nil, botUsernames, h.authenticatedUsername(r.Context()))
+	username := h.usernameForRepo(r.Context(), pr.RepoFullName)
+	detail := toPRDetailViewModel(*pr, summary, nil, username)
+	h.applyDecisions(r.Context(), &detail)
+	h.applyOutbox(r.Context(), &detail)
+	h.applyReplyTemplates(r.Context(), &detail, *pr, username)
+	h.applyReviewPolicy(r.Context(), &detail)
 
 	// Find the specific thread to re-render.
 	for _, thread := range detail.Threads {
@@ -1355,8 +1761,7 @@ func (h *Handler) parsePRWriteRequest(w http.ResponseWriter, r *http.Request) (o
 	}
 
 	if err := r.ParseForm(); err != nil {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: invalid form data</p>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
 		return "", "", 0, false
 	}
 
@@ -1386,8 +1791,7 @@ func (h *Handler) SubmitReview(w http.ResponseWriter, r *http.Request) {
 	case "APPROVE", "REQUEST_CHANGES", "COMMENT":
 		// valid
 	default:
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: invalid review event; must be APPROVE, REQUEST_CHANGES, or COMMENT</p>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid review event; must be APPROVE, REQUEST_CHANGES, or COMMENT")
 		return
 	}
 
@@ -1396,27 +1800,23 @@ func (h *Handler) SubmitReview(w http.ResponseWriter, r *http.Request) {
 	if commentsJSON != "" && commentsJSON != "null" {
 		if err := json.Unmarshal([]byte(commentsJSON), &lineComments); err != nil {
 			h.logger.Error("failed to decode line comments JSON", "error", err)
-			w.WriteHeader(http.StatusUnprocessableEntity)
-			fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: invalid pending comments format</p>`)
+			writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid pending comments format")
 			return
 		}
 	}
 
-	token := h.requireGitHubToken(w, r, "submit reviews")
-	if token == "" {
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "submit reviews")
+	if writer == nil {
 		return
 	}
 
-	repoFullName := owner + "/" + repo
-
 	// Resolve the current HEAD SHA from the store to avoid GitHub 422s caused by
 	// a stale commit_sha baked into the form when the PR received new commits.
 	if pr, fetchErr := h.prStore.GetByNumber(r.Context(), repoFullName, number); fetchErr == nil && pr != nil {
 		commitSHA = pr.HeadSHA
 	}
 
-	writer := h.writerFactory(token)
-
 	req := driven.ReviewRequest{
 		CommitID: commitSHA,
 		Event:    event,
@@ -1425,9 +1825,13 @@ func (h *Handler) SubmitReview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := writer.SubmitReview(r.Context(), repoFullName, number, req); err != nil {
+		if h.queueOnTransient(w, r, err, repoFullName, number, owner, repo, func(ctx context.Context) (model.OutboundAction, error) {
+			return h.outboxSvc.EnqueueReview(ctx, repoFullName, number, req, err)
+		}) {
+			return
+		}
 		h.logger.Error("failed to submit review", "repo", repoFullName, "pr", number, "error", err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: %s</p>`, html.EscapeString(err.Error()))
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: "+err.Error())
 		return
 	}
 
@@ -1445,30 +1849,52 @@ func (h *Handler) CreateIssueComment(w http.ResponseWriter, r *http.Request) {
 
 	body := strings.TrimSpace(r.FormValue("body"))
 	if body == "" {
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: comment body cannot be empty</p>`)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: comment body cannot be empty")
 		return
 	}
 
-	token := h.requireGitHubToken(w, r, "post comments")
-	if token == "" {
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "post comments")
+	if writer == nil {
 		return
 	}
 
-	repoFullName := owner + "/" + repo
-	writer := h.writerFactory(token)
-
 	if err := writer.CreateIssueComment(r.Context(), repoFullName, number, body); err != nil {
+		if h.queueOnTransient(w, r, err, repoFullName, number, owner, repo, func(ctx context.Context) (model.OutboundAction, error) {
+			return h.outboxSvc.EnqueueIssueComment(ctx, repoFullName, number, body, err)
+		}) {
+			return
+		}
 		h.logger.Error("failed to create issue comment", "repo", repoFullName, "pr", number, "error", err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: %s</p>`, html.EscapeString(err.Error()))
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: "+err.Error())
 		return
 	}
 
+	h.echoPostedComment(r.Context(), repoFullName, number, func(ctx context.Context, prID int64, author string) error {
+		return h.reviewSvc.EchoIssueComment(ctx, prID, author, body)
+	})
+
 	// Re-fetch and re-render the full reviews section for morph swap.
 	h.renderReviewsSectionForPR(w, r, repoFullName, number, owner, repo)
 }
 
+// echoPostedComment stores a local copy of a comment just posted to GitHub
+// via echo, so the re-render shows it before the poll loop syncs it. Failures
+// are logged; the comment still appears after the next poll.
+func (h *Handler) echoPostedComment(ctx context.Context, repoFullName string, prNumber int, echo func(ctx context.Context, prID int64, author string) error) {
+	if h.reviewSvc == nil {
+		return
+	}
+	pr, err := h.prStore.GetByNumber(ctx, repoFullName, prNumber)
+	if err != nil || pr == nil {
+		h.logger.Warn("failed to load PR for comment echo", "repo", repoFullName, "pr", prNumber, "error", err)
+		return
+	}
+	if err := echo(ctx, pr.ID, h.usernameForRepo(ctx, repoFullName)); err != nil {
+		h.logger.Warn("failed to store comment echo", "repo", repoFullName, "pr", prNumber, "error", err)
+	}
+}
+
 // ToggleDraftStatus handles POST /app/prs/{owner}/{repo}/{number}/draft-toggle.
 // It converts a ready-for-review PR to draft (or vice-versa) and morphs the header section.
 func (h *Handler) ToggleDraftStatus(w http.ResponseWriter, r *http.Request) {
@@ -1487,18 +1913,16 @@ func (h *Handler) ToggleDraftStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token := h.requireGitHubToken(w, r, "toggle draft status")
-	if token == "" {
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "toggle draft status")
+	if writer == nil {
 		return
 	}
 
-	repoFullName := owner + "/" + repo
-
 	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number)
 	if err != nil {
 		h.logger.Error("failed to get PR for draft toggle", "repo", repoFullName, "number", number, "error", err)
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: failed to load PR data</p>`)
+		writeErrorToast(w, http.StatusInternalServerError, "Error: failed to load PR data")
 		return
 	}
 	if pr == nil {
@@ -1507,15 +1931,13 @@ func (h *Handler) ToggleDraftStatus(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Server-side author check: only the PR author can toggle draft status.
-	authUser := h.authenticatedUsername(r.Context())
+	authUser := h.usernameForRepo(r.Context(), repoFullName)
 	if authUser == "" || !strings.EqualFold(pr.Author, authUser) {
-		w.WriteHeader(http.StatusForbidden)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: only the PR author can toggle draft status</p>`)
+		writeErrorToast(w, http.StatusForbidden, "Error: only the PR author can toggle draft status")
 		return
 	}
 
 	// Execute the appropriate mutation based on current draft state.
-	writer := h.writerFactory(token)
 	if pr.IsDraft {
 		err = writer.MarkPullRequestReadyForReview(r.Context(), repoFullName, number)
 	} else {
@@ -1523,8 +1945,7 @@ func (h *Handler) ToggleDraftStatus(w http.ResponseWriter, r *http.Request) {
 	}
 	if err != nil {
 		h.logger.Error("failed to toggle draft status", "repo", repoFullName, "pr", number, "error", err)
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: %s</p>`, html.EscapeString(err.Error()))
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: "+err.Error())
 		return
 	}
 
@@ -1550,7 +1971,7 @@ func (h *Handler) ToggleDraftStatus(w http.ResponseWriter, r *http.Request) {
 		flipped.IsDraft = !pr.IsDraft
 	}
 
-	detail := toPRDetailViewModel(flipped, nil, nil, nil, authUser)
+	detail := toPRDetailViewModel(flipped, nil, nil, authUser)
 	comp := components.PRDetailHeader(detail)
 	if err := comp.Render(r.Context(), w); err != nil {
 		h.logger.Error("failed to render PR detail header after draft toggle", "error", err)
@@ -1562,8 +1983,7 @@ func (h *Handler) ToggleDraftStatus(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) renderReviewsSectionForPR(w http.ResponseWriter, r *http.Request, repoFullName string, prNumber int, owner, repo string) {
 	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, prNumber)
 	if err != nil || pr == nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		fmt.Fprintf(w, `<p class="text-red-600 text-sm">Error: failed to load PR data</p>`)
+		writeErrorToast(w, http.StatusInternalServerError, "Error: failed to load PR data")
 		return
 	}
 
@@ -1575,18 +1995,19 @@ func (h *Handler) renderReviewsSectionForPR(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	var botUsernames []string
-	if summary != nil {
-		botUsernames = summary.BotUsernames
-	}
-
-	detail := toPRDetailViewModel(*pr, summary, nil, botUsernames, h.authenticatedUsername(r.Context()))
+	username := h.usernameForRepo(r.Context(), pr.RepoFullName)
+	detail := toPRDetailViewModel(*pr, summary, nil, username)
+	h.applyDecisions(r.Context(), &detail)
+	h.applyOutbox(r.Context(), &detail)
+	h.applyReplyTemplates(r.Context(), &detail, *pr, username)
+	h.applyReviewPolicy(r.Context(), &detail)
 	h.renderReviewsSection(w, r, detail, owner, repo)
 }
 
 // authenticatedUsername returns the currently authenticated GitHub username.
 // It checks the credential store first (dynamic credentials set via GUI), then
-// falls back to the static username from configuration.
+// falls back to the static username from configuration. Use usernameForRepo
+// when the repository is known, since it may be routed to another account.
 func (h *Handler) authenticatedUsername(ctx context.Context) string {
 	if h.credStore != nil {
 		username, err := h.credStore.Get(ctx, "github_username")
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// apiTokenTimeLayout is how token creation and last-use times are shown.
+const apiTokenTimeLayout = "2006-01-02 15:04 UTC"
+
+// WithAPITokenService enables managing REST API tokens from the settings
+// drawer. Without it the API tab is empty and the routes return 503.
+func (h *Handler) WithAPITokenService(svc *application.APITokenService) *Handler {
+	h.apiTokenSvc = svc
+	return h
+}
+
+// CreateAPIToken handles POST /app/settings/api-tokens.
+// It issues a token and returns the updated token list with the new secret
+// shown once; it cannot be retrieved afterwards.
+func (h *Handler) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.apiTokenSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("token_name"))
+	if name == "" {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Name is required")
+		return
+	}
+
+	secret, _, err := h.apiTokenSvc.Create(r.Context(), name, model.APITokenScope(r.FormValue("token_scope")))
+	if errors.Is(err, application.ErrInvalidAPITokenScope) {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Scope must be read or write")
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to create api token", "name", name, "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: failed to create token")
+		return
+	}
+
+	h.renderAPITokenList(w, r, secret)
+}
+
+// RevokeAPIToken handles DELETE /app/settings/api-tokens/{id}.
+// Requests presenting the token are rejected from then on.
+func (h *Handler) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid token ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.apiTokenSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.apiTokenSvc.Revoke(r.Context(), id); err != nil {
+		h.logger.Error("failed to revoke api token", "id", id, "error", err)
+		http.Error(w, "failed to revoke token", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderAPITokenList(w, r, "")
+}
+
+// renderAPITokenList renders the token list fragment for the settings drawer.
+// newSecret, when set, is the just-created token to show the user once.
+func (h *Handler) renderAPITokenList(w http.ResponseWriter, r *http.Request, newSecret string) {
+	if err := components.APITokenList(h.apiTokenViewModels(r.Context()), newSecret).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render api token list", "error", err)
+	}
+}
+
+// apiTokenViewModels lists the API tokens for the settings drawer. Failures
+// are logged and yield no tokens.
+func (h *Handler) apiTokenViewModels(ctx context.Context) []vm.APITokenViewModel {
+	if h.apiTokenSvc == nil {
+		return nil
+	}
+
+	tokens, err := h.apiTokenSvc.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list api tokens", "error", err)
+		return nil
+	}
+
+	vms := make([]vm.APITokenViewModel, 0, len(tokens))
+	for _, t := range tokens {
+		v := vm.APITokenViewModel{
+			ID:        t.ID,
+			Name:      t.Name,
+			Prefix:    t.Prefix,
+			Scope:     string(t.Scope),
+			CreatedAt: t.CreatedAt.UTC().Format(apiTokenTimeLayout),
+		}
+		if !t.LastUsedAt.IsZero() {
+			v.LastUsedAt = t.LastUsedAt.UTC().Format(apiTokenTimeLayout)
+		}
+		vms = append(vms, v)
+	}
+	return vms
+}
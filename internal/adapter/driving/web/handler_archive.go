@@ -0,0 +1,106 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// archiveDateLayout is the format of the archive's date range inputs.
+const archiveDateLayout = "2006-01-02"
+
+// WithArchiveService enables the archive of merged and closed PRs. Without it
+// the route returns 503.
+func (h *Handler) WithArchiveService(svc *application.ArchiveService) *Handler {
+	h.archiveSvc = svc
+	return h
+}
+
+// Archive handles GET /app/archive?repo=&status=&from=&to=&page=.
+// It renders one page of merged and closed PRs, most recently closed first,
+// with time to merge per PR and a per-repository merge history for the same
+// filters, into #pr-detail. from and to are inclusive close dates.
+func (h *Handler) Archive(w http.ResponseWriter, r *http.Request) {
+	if h.archiveSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	q := r.URL.Query()
+	data := vm.ArchiveViewModel{Repo: strings.TrimSpace(q.Get("repo"))}
+	filter := model.ArchiveFilter{RepoFullName: data.Repo}
+
+	switch status := model.PRStatus(q.Get("status")); status {
+	case model.PRStatusMerged, model.PRStatusClosed:
+		filter.Status = status
+		data.Status = string(status)
+	}
+	if from, err := time.Parse(archiveDateLayout, q.Get("from")); err == nil {
+		filter.ClosedAfter = from
+		data.From = from.Format(archiveDateLayout)
+	}
+	if to, err := time.Parse(archiveDateLayout, q.Get("to")); err == nil {
+		filter.ClosedBefore = to.AddDate(0, 0, 1)
+		data.To = to.Format(archiveDateLayout)
+	}
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	result, err := h.archiveSvc.List(r.Context(), filter, page)
+	if err != nil {
+		h.logger.Error("failed to list archived PRs", "repo", data.Repo, "page", page, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	history, err := h.archiveSvc.MergeHistory(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to load merge history", "repo", data.Repo, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if repos, err := h.repoStore.ListAll(r.Context()); err != nil {
+		h.logger.Warn("failed to list repos for archive filter", "error", err)
+	} else {
+		data.Repos = extractRepoNames(repos)
+	}
+
+	data.Page = result.Page
+	if result.Page > 1 {
+		data.PrevPath = archivePagePath(data, result.Page-1)
+	}
+	if result.HasMore {
+		data.NextPath = archivePagePath(data, result.Page+1)
+	}
+	if retention := h.archiveSvc.Retention(); retention > 0 {
+		data.Retention = fmt.Sprintf("%d days", int(retention.Hours()/24))
+	}
+	data.Items = toArchivedPRViewModels(result.PRs)
+	data.History = toMergeHistoryViewModels(history)
+
+	if err := partials.ArchiveContent(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render archive", "error", err)
+	}
+}
+
+// archivePagePath links to another page of the archive with the same filters.
+func archivePagePath(data vm.ArchiveViewModel, page int) string {
+	q := url.Values{}
+	for key, value := range map[string]string{"repo": data.Repo, "status": data.Status, "from": data.From, "to": data.To} {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	q.Set("page", strconv.Itoa(page))
+	return "/app/archive?" + q.Encode()
+}
@@ -0,0 +1,209 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithAttentionRuleService enables the attention rule builder in the settings
+// drawer. Without it the section stays empty and the routes return 503.
+func (h *Handler) WithAttentionRuleService(svc *application.AttentionRuleService) *Handler {
+	h.ruleSvc = svc
+	return h
+}
+
+// AttentionRules handles GET /app/settings/attention-rules. It renders the
+// rule list and builder, or nothing when rules are unavailable.
+func (h *Handler) AttentionRules(w http.ResponseWriter, r *http.Request) {
+	if h.ruleSvc == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	settings := vm.AttentionRuleSettingsViewModel{
+		Rules:  h.attentionRuleViewModels(r.Context()),
+		Fields: application.RuleFieldNames(),
+	}
+	for severity := 1; severity <= model.MaxRuleSeverity; severity++ {
+		settings.Severities = append(settings.Severities, severity)
+	}
+	if err := components.AttentionRuleSection(settings).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render attention rules", "error", err)
+	}
+}
+
+// CreateAttentionRule handles POST /app/settings/attention-rules.
+// It stores the rule and returns the updated rule list.
+func (h *Handler) CreateAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.ruleSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	severity, _ := strconv.Atoi(r.FormValue("severity"))
+	_, err := h.ruleSvc.Create(r.Context(), model.AttentionRule{
+		Name:       r.FormValue("name"),
+		Expression: r.FormValue("expression"),
+		Severity:   severity,
+		NotifyURL:  r.FormValue("notify_url"),
+		Enabled:    true,
+	})
+	switch {
+	case errors.Is(err, application.ErrInvalidAttentionRule):
+		writeErrorToast(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create attention rule", "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: failed to save rule")
+		return
+	}
+
+	h.renderAttentionRuleList(w, r)
+}
+
+// ValidateAttentionRule handles POST /app/settings/attention-rules/validate.
+// It answers 200 with a one-line verdict on the form's expression, so the
+// rule builder can show problems as the user types.
+func (h *Handler) ValidateAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, errMsgInvalidFormData, http.StatusBadRequest)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.ruleSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	fmt.Fprint(w, ruleValidationFragment(h.ruleSvc.Validate(r.FormValue("expression"))))
+}
+
+// ruleValidationFragment renders the verdict of an expression validation.
+func ruleValidationFragment(err error) string {
+	if err != nil {
+		return fmt.Sprintf(`<span class="text-red-600">%s</span>`, html.EscapeString(err.Error()))
+	}
+	return `<span class="text-green-600">Valid condition</span>`
+}
+
+// ToggleAttentionRule handles POST /app/settings/attention-rules/{id}/toggle.
+// It enables a disabled rule or disables an enabled one.
+func (h *Handler) ToggleAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid rule ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.ruleSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	rules, err := h.ruleSvc.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list attention rules", "error", err)
+		http.Error(w, "failed to update rule", http.StatusInternalServerError)
+		return
+	}
+	for _, rule := range rules {
+		if rule.ID != id {
+			continue
+		}
+		rule.Enabled = !rule.Enabled
+		if err := h.ruleSvc.Update(r.Context(), rule); err != nil {
+			h.logger.Error("failed to toggle attention rule", "id", id, "error", err)
+			http.Error(w, "failed to update rule", http.StatusInternalServerError)
+			return
+		}
+		h.renderAttentionRuleList(w, r)
+		return
+	}
+	http.Error(w, "rule not found", http.StatusNotFound)
+}
+
+// DeleteAttentionRule handles DELETE /app/settings/attention-rules/{id}.
+func (h *Handler) DeleteAttentionRule(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid rule ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.ruleSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.ruleSvc.Delete(r.Context(), id); err != nil && !errors.Is(err, driven.ErrAttentionRuleNotFound) {
+		h.logger.Error("failed to delete attention rule", "id", id, "error", err)
+		http.Error(w, "failed to delete rule", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderAttentionRuleList(w, r)
+}
+
+// renderAttentionRuleList renders the rule list fragment for the settings drawer.
+func (h *Handler) renderAttentionRuleList(w http.ResponseWriter, r *http.Request) {
+	if err := components.AttentionRuleList(h.attentionRuleViewModels(r.Context())).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render attention rule list", "error", err)
+	}
+}
+
+// attentionRuleViewModels lists the rules for the settings drawer. Failures
+// are logged and yield no rules.
+func (h *Handler) attentionRuleViewModels(ctx context.Context) []vm.AttentionRuleViewModel {
+	rules, err := h.ruleSvc.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list attention rules", "error", err)
+		return nil
+	}
+	result := make([]vm.AttentionRuleViewModel, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, vm.AttentionRuleViewModel{
+			ID:         rule.ID,
+			Name:       rule.Name,
+			Expression: rule.Expression,
+			Severity:   rule.Severity,
+			NotifyURL:  rule.NotifyURL,
+			Enabled:    rule.Enabled,
+		})
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// autocompleteLimit caps the suggestions returned per lookup.
+const autocompleteLimit = 8
+
+// autocompleteSuggestion is one entry of an autocomplete response. Value is
+// the text the composer inserts; Emoji is set for emoji suggestions.
+type autocompleteSuggestion struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// WithAutocompleteService enables emoji and @mention autocomplete in the
+// comment and review composers. Without it the lookups return 503 and the
+// composers offer no suggestions.
+func (h *Handler) WithAutocompleteService(svc *application.AutocompleteService) *Handler {
+	h.autocompleteSvc = svc
+	return h
+}
+
+// AutocompleteEmoji handles GET /app/autocomplete/emoji?q=. It returns the
+// matching shortcodes as JSON for static/js/autocomplete.js.
+func (h *Handler) AutocompleteEmoji(w http.ResponseWriter, r *http.Request) {
+	if h.autocompleteSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	emoji := h.autocompleteSvc.Emoji(r.URL.Query().Get("q"), autocompleteLimit)
+	suggestions := make([]autocompleteSuggestion, 0, len(emoji))
+	for _, e := range emoji {
+		suggestions = append(suggestions, autocompleteSuggestion{Value: ":" + e.Code + ":", Label: e.Code, Emoji: e.Emoji})
+	}
+	h.writeSuggestions(w, suggestions)
+}
+
+// AutocompleteUsers handles GET /app/autocomplete/users?q=&repo=. It returns
+// the PR authors, reviewers, and commenters of repo (or of every repository
+// when repo is omitted) matching q as JSON.
+func (h *Handler) AutocompleteUsers(w http.ResponseWriter, r *http.Request) {
+	if h.autocompleteSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	query := r.URL.Query()
+	repo := query.Get("repo")
+	if repo != "" && !validate.IsValidRepoName(repo) {
+		http.Error(w, "invalid repository name", http.StatusBadRequest)
+		return
+	}
+
+	logins, err := h.autocompleteSvc.Users(r.Context(), repo, query.Get("q"), autocompleteLimit)
+	if err != nil {
+		h.logger.Error("failed to search participants", "repo", repo, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	suggestions := make([]autocompleteSuggestion, 0, len(logins))
+	for _, login := range logins {
+		suggestions = append(suggestions, autocompleteSuggestion{Value: "@" + login, Label: login})
+	}
+	h.writeSuggestions(w, suggestions)
+}
+
+// writeSuggestions writes an autocomplete response.
+func (h *Handler) writeSuggestions(w http.ResponseWriter, suggestions []autocompleteSuggestion) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(suggestions); err != nil {
+		h.logger.Error("failed to write autocomplete suggestions", "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// Board handles GET /app/board.
+// It renders the active repo group's open PRs into #pr-detail as columns
+// placed from stored state. Without a review service every PR that is not a
+// draft or failing CI counts as awaiting review.
+func (h *Handler) Board(w http.ResponseWriter, r *http.Request) {
+	prs, err := h.prStore.ListAll(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list PRs for board", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	open := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range scopePRsToGroup(prs, h.activeRepoGroup(w, r)) {
+		if pr.Status == model.PRStatusOpen {
+			open = append(open, pr)
+		}
+	}
+
+	var reviewStatus map[int64]model.ReviewState
+	if h.reviewSvc != nil {
+		if reviewStatus, err = h.reviewSvc.ReviewStatusByPR(r.Context(), open); err != nil {
+			h.logger.Warn("failed to get review status for board", "error", err)
+		}
+	}
+
+	cards := h.toPRCardViewModelsWithSignals(r.Context(), open)
+	data := toBoardViewModel(open, cards, reviewStatus)
+	if err := partials.BoardContent(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render board", "error", err)
+	}
+}
+
+// toBoardViewModel places each PR's card in its board column. cards must be
+// in the same order as prs; PRs without a review status count as pending.
+func toBoardViewModel(prs []model.PullRequest, cards []vm.PRCardViewModel, reviewStatus map[int64]model.ReviewState) vm.BoardViewModel {
+	byColumn := make(map[model.BoardColumn][]vm.PRCardViewModel)
+	for i, pr := range prs {
+		review, ok := reviewStatus[pr.ID]
+		if !ok {
+			review = model.ReviewStatePending
+		}
+		column := application.BoardColumnFor(pr, review)
+		cards[i].ReviewStatus = string(review)
+		byColumn[column] = append(byColumn[column], cards[i])
+	}
+
+	data := vm.BoardViewModel{Columns: make([]vm.BoardColumnViewModel, 0, len(model.AllBoardColumns()))}
+	for _, c := range model.AllBoardColumns() {
+		data.Columns = append(data.Columns, vm.BoardColumnViewModel{Column: c, Label: c.Label(), Cards: byColumn[c]})
+	}
+	return data
+}
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToBoardViewModel(t *testing.T) {
+	prs := []model.PullRequest{
+		{ID: 1, Number: 1, IsDraft: true},
+		{ID: 2, Number: 2, CIStatus: model.CIStatusPassing},
+		{ID: 3, Number: 3, CIStatus: model.CIStatusPassing},
+	}
+	cards := []vm.PRCardViewModel{{Number: 1}, {Number: 2}, {Number: 3}}
+	reviews := map[int64]model.ReviewState{3: model.ReviewStateApproved}
+
+	data := toBoardViewModel(prs, cards, reviews)
+
+	require.Len(t, data.Columns, len(model.AllBoardColumns()))
+	numbers := make(map[model.BoardColumn][]int)
+	for _, col := range data.Columns {
+		for _, card := range col.Cards {
+			numbers[col.Column] = append(numbers[col.Column], card.Number)
+		}
+	}
+	assert.Equal(t, map[model.BoardColumn][]int{
+		model.BoardDraft:          {1},
+		model.BoardAwaitingReview: {2},
+		model.BoardReadyToMerge:   {3},
+	}, numbers, "a PR without a review status awaits review")
+	assert.Equal(t, "Awaiting review", data.Columns[1].Label)
+}
@@ -0,0 +1,181 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithBotConfigStore enables managing bot usernames and their nitpick
+// patterns from the settings drawer. rollups, which may be nil, recomputes
+// the stored review rollups when the bot list changes, since bot reviews do
+// not count towards them. Without a store the section stays empty and the
+// routes return 503.
+func (h *Handler) WithBotConfigStore(store driven.BotConfigStore, rollups *application.ReviewRollupService) *Handler {
+	h.botConfigStore = store
+	h.reviewRollups = rollups
+	return h
+}
+
+// BotSettings handles GET /app/settings/bots. It renders the bot list and
+// add form, or nothing when bots cannot be configured.
+func (h *Handler) BotSettings(w http.ResponseWriter, r *http.Request) {
+	if h.botConfigStore == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	settings := vm.BotSettingsViewModel{
+		Bots:            h.botViewModels(r.Context()),
+		DefaultPatterns: application.DefaultNitpickPatterns,
+	}
+	if err := components.BotSection(settings).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render bot settings", "error", err)
+	}
+}
+
+// AddBot handles POST /app/settings/bots. It adds the form's username and
+// returns the updated bot list.
+func (h *Handler) AddBot(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.botConfigStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	username := strings.TrimSpace(r.FormValue("bot_username"))
+	if username == "" {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Bot username is required")
+		return
+	}
+
+	_, err := h.botConfigStore.Add(r.Context(), model.BotConfig{Username: username, AddedAt: time.Now().UTC()})
+	switch {
+	case errors.Is(err, driven.ErrBotAlreadyExists):
+		writeErrorToast(w, http.StatusUnprocessableEntity, username+" is already a bot")
+		return
+	case err != nil:
+		h.logger.Error("failed to add bot", "username", username, "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: failed to add bot")
+		return
+	}
+
+	h.refreshReviewRollups(r.Context())
+	h.renderBotList(w, r)
+}
+
+// SaveBotNitpickPatterns handles POST /app/settings/bots/{username}/nitpick-patterns.
+// It replaces the bot's nitpick patterns with the form's, one per line, and
+// returns the updated bot list.
+func (h *Handler) SaveBotNitpickPatterns(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.botConfigStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	username := r.PathValue("username")
+	patterns := application.NormalizeNitpickPatterns(strings.Split(r.FormValue("nitpick_patterns"), "\n"))
+	err := h.botConfigStore.SetNitpickPatterns(r.Context(), username, patterns)
+	switch {
+	case errors.Is(err, driven.ErrBotNotFound):
+		http.Error(w, "bot not found", http.StatusNotFound)
+		return
+	case err != nil:
+		h.logger.Error("failed to save nitpick patterns", "username", username, "error", err)
+		writeErrorToast(w, http.StatusInternalServerError, "Error: failed to save nitpick patterns")
+		return
+	}
+
+	triggerToast(w, Toast{Level: ToastSuccess, Message: "Nitpick patterns saved for " + username})
+	h.renderBotList(w, r)
+}
+
+// RemoveBot handles DELETE /app/settings/bots/{username}.
+func (h *Handler) RemoveBot(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.botConfigStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	username := r.PathValue("username")
+	if err := h.botConfigStore.Remove(r.Context(), username); err != nil && !errors.Is(err, driven.ErrBotNotFound) {
+		h.logger.Error("failed to remove bot", "username", username, "error", err)
+		http.Error(w, "failed to remove bot", http.StatusInternalServerError)
+		return
+	}
+
+	h.refreshReviewRollups(r.Context())
+	h.renderBotList(w, r)
+}
+
+// renderBotList renders the bot list fragment for the settings drawer.
+func (h *Handler) renderBotList(w http.ResponseWriter, r *http.Request) {
+	if err := components.BotList(h.botViewModels(r.Context())).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render bot list", "error", err)
+	}
+}
+
+// botViewModels lists the configured bots for the settings drawer. Failures
+// are logged and yield no bots.
+func (h *Handler) botViewModels(ctx context.Context) []vm.BotViewModel {
+	bots, err := h.botConfigStore.ListAll(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list bots", "error", err)
+		return nil
+	}
+	result := make([]vm.BotViewModel, 0, len(bots))
+	for _, bot := range bots {
+		result = append(result, vm.BotViewModel{
+			Username:        bot.Username,
+			NitpickPatterns: strings.Join(bot.NitpickPatterns, "\n"),
+			PatternCount:    len(bot.NitpickPatterns),
+		})
+	}
+	return result
+}
+
+// refreshReviewRollups recomputes every stored review rollup in the
+// background after a bot list change, so the response does not wait on it.
+func (h *Handler) refreshReviewRollups(ctx context.Context) {
+	if h.reviewRollups == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := h.reviewRollups.RecordAll(ctx); err != nil {
+			h.logger.Error("failed to refresh review rollups", "error", err)
+		}
+	}()
+}
@@ -0,0 +1,118 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memoryBotStore is an in-memory driven.BotConfigStore.
+type memoryBotStore struct {
+	bots []model.BotConfig
+}
+
+func (m *memoryBotStore) Add(_ context.Context, bot model.BotConfig) (model.BotConfig, error) {
+	if m.index(bot.Username) >= 0 {
+		return model.BotConfig{}, driven.ErrBotAlreadyExists
+	}
+	m.bots = append(m.bots, bot)
+	return bot, nil
+}
+
+func (m *memoryBotStore) Remove(_ context.Context, username string) error {
+	i := m.index(username)
+	if i < 0 {
+		return driven.ErrBotNotFound
+	}
+	m.bots = slices.Delete(m.bots, i, i+1)
+	return nil
+}
+
+func (m *memoryBotStore) SetNitpickPatterns(_ context.Context, username string, patterns []string) error {
+	i := m.index(username)
+	if i < 0 {
+		return driven.ErrBotNotFound
+	}
+	m.bots[i].NitpickPatterns = patterns
+	return nil
+}
+
+func (m *memoryBotStore) ListAll(context.Context) ([]model.BotConfig, error) { return m.bots, nil }
+
+func (m *memoryBotStore) GetUsernames(context.Context) ([]string, error) {
+	names := make([]string, len(m.bots))
+	for i, bot := range m.bots {
+		names[i] = bot.Username
+	}
+	return names, nil
+}
+
+func (m *memoryBotStore) index(username string) int {
+	return slices.IndexFunc(m.bots, func(bot model.BotConfig) bool { return bot.Username == username })
+}
+
+func TestBotSettings(t *testing.T) {
+	h := &Handler{logger: slog.Default()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /app/settings/bots", h.BotSettings)
+	mux.HandleFunc("POST /app/settings/bots", h.AddBot)
+	mux.HandleFunc("POST /app/settings/bots/{username}/nitpick-patterns", h.SaveBotNitpickPatterns)
+	mux.HandleFunc("DELETE /app/settings/bots/{username}", h.RemoveBot)
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusNoContent, serve(httptest.NewRequest(http.MethodGet, "/app/settings/bots", nil)).Code)
+
+	store := &memoryBotStore{bots: []model.BotConfig{{Username: "coderabbitai"}}}
+	h.WithBotConfigStore(store, nil)
+
+	rec := serve(httptest.NewRequest(http.MethodGet, "/app/settings/bots", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "coderabbitai")
+	assert.Contains(t, rec.Body.String(), "[nitpick]", "the built-in patterns are listed")
+
+	rec = serve(preferenceRequest(http.MethodPost, "/app/settings/bots", "bot_username=+renovate%5Bbot%5D+", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "renovate[bot]")
+	assert.Equal(t, "renovate[bot]", store.bots[1].Username)
+
+	rec = serve(preferenceRequest(http.MethodPost, "/app/settings/bots", "bot_username=coderabbitai", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	rec = serve(preferenceRequest(http.MethodPost, "/app/settings/bots/renovate%5Bbot%5D/nitpick-patterns", "nitpick_patterns=Minor%3A%0D%0A%0D%0Aminor%3A%0D%0Anit", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"minor:", "nit"}, store.bots[1].NitpickPatterns)
+	assert.Contains(t, rec.Body.String(), "2 nitpick patterns")
+
+	rec = serve(preferenceRequest(http.MethodPost, "/app/settings/bots/ghost/nitpick-patterns", "nitpick_patterns=nit", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve(preferenceRequest(http.MethodDelete, "/app/settings/bots/renovate%5Bbot%5D", "", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, store.bots, 1)
+}
+
+func TestBotSettings_RequiresCSRF(t *testing.T) {
+	store := &memoryBotStore{}
+	h := (&Handler{logger: slog.Default()}).WithBotConfigStore(store, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/app/settings/bots", nil)
+	req.Form = map[string][]string{"bot_username": {"renovate[bot]"}}
+	rec := httptest.NewRecorder()
+	h.AddBot(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Empty(t, store.bots)
+}
@@ -0,0 +1,249 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithRepoPauser enables pausing and resuming background polling from the
+// bulk repo editor. Without it, bulk edits that change polling return 503.
+func (h *Handler) WithRepoPauser(pauser driven.RepoPauser) *Handler {
+	h.repoPauser = pauser
+	return h
+}
+
+// boolOverride is a bulk change to a tri-state threshold override.
+type boolOverride struct {
+	set   bool  // false leaves each repo's current override untouched
+	value *bool // nil clears the override so the repo inherits the global value
+}
+
+// bulkRepoEdit is the change applied to every repo selected in the bulk
+// editor. Zero-value fields leave each repo's current setting as it is.
+type bulkRepoEdit struct {
+	resetThresholds bool // drop existing overrides before applying the fields below
+	reviewCount     *int
+	ageUrgencyDays  *int
+	staleReview     boolOverride
+	ciFailure       boolOverride
+	paused          *bool
+}
+
+// changesThresholds reports whether the edit touches threshold overrides.
+func (e bulkRepoEdit) changesThresholds() bool {
+	return e.resetThresholds || e.reviewCount != nil || e.ageUrgencyDays != nil ||
+		e.staleReview.set || e.ciFailure.set
+}
+
+// apply merges the edit onto a repo's current threshold overrides.
+func (e bulkRepoEdit) apply(current model.RepoThreshold) model.RepoThreshold {
+	merged := current
+	if e.resetThresholds {
+		merged = model.RepoThreshold{RepoFullName: current.RepoFullName}
+	}
+	if e.reviewCount != nil {
+		n := *e.reviewCount
+		merged.ReviewCount = &n
+	}
+	if e.ageUrgencyDays != nil {
+		n := *e.ageUrgencyDays
+		merged.AgeUrgencyDays = &n
+	}
+	if e.staleReview.set {
+		merged.StaleReviewEnabled = e.staleReview.value
+	}
+	if e.ciFailure.set {
+		merged.CIFailureEnabled = e.ciFailure.value
+	}
+	return merged
+}
+
+// hasOverrides reports whether t overrides any global threshold.
+func hasOverrides(t model.RepoThreshold) bool {
+	return t.ReviewCount != nil || t.AgeUrgencyDays != nil || t.StaleReviewEnabled != nil || t.CIFailureEnabled != nil ||
+		t.FirstReviewSLAHours != nil
+}
+
+// parseBulkRepoEdit reads the bulk editor form. Empty fields mean "unchanged";
+// the tri-state selects also accept "inherit" to clear the override.
+func parseBulkRepoEdit(form url.Values) (bulkRepoEdit, error) {
+	edit := bulkRepoEdit{resetThresholds: form.Get("reset_thresholds") == "on"}
+
+	var err error
+	if edit.reviewCount, err = parseOptionalCount(form.Get("review_count"), "review_count"); err != nil {
+		return edit, err
+	}
+	if edit.ageUrgencyDays, err = parseOptionalCount(form.Get("age_urgency_days"), "age_urgency_days"); err != nil {
+		return edit, err
+	}
+	if edit.staleReview, err = parseBoolOverride(form.Get("stale_review_enabled"), "stale_review_enabled"); err != nil {
+		return edit, err
+	}
+	if edit.ciFailure, err = parseBoolOverride(form.Get("ci_failure_enabled"), "ci_failure_enabled"); err != nil {
+		return edit, err
+	}
+
+	switch form.Get("polling") {
+	case "":
+	case "pause":
+		paused := true
+		edit.paused = &paused
+	case "resume":
+		paused := false
+		edit.paused = &paused
+	default:
+		return edit, errors.New("polling must be pause or resume")
+	}
+
+	return edit, nil
+}
+
+// parseOptionalCount parses a non-negative integer field; empty yields nil.
+func parseOptionalCount(v, field string) (*int, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer", field)
+	}
+	return &n, nil
+}
+
+// parseBoolOverride parses a tri-state select: "" leaves the override alone,
+// "inherit" clears it, and "true"/"false" set it.
+func parseBoolOverride(v, field string) (boolOverride, error) {
+	switch v {
+	case "":
+		return boolOverride{}, nil
+	case "inherit":
+		return boolOverride{set: true}, nil
+	case "true", "false":
+		b := v == "true"
+		return boolOverride{set: true, value: &b}, nil
+	}
+	return boolOverride{}, fmt.Errorf("%s must be inherit, true, or false", field)
+}
+
+// BulkEditRepos handles POST /app/settings/repos/bulk.
+// It applies threshold overrides and poll pausing to every selected repo,
+// merging onto each repo's existing overrides, then refreshes the repo list
+// and PR list via OOB swaps.
+func (h *Handler) BulkEditRepos(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	edit, err := parseBulkRepoEdit(r.PostForm)
+	if err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: %s</span>`, html.EscapeString(err.Error()))
+		return
+	}
+
+	selected := r.PostForm["repos"]
+	if len(selected) == 0 {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: select at least one repo</span>`)
+		return
+	}
+	if !edit.changesThresholds() && edit.paused == nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: nothing to change</span>`)
+		return
+	}
+
+	if h.thresholdStore == nil || (edit.paused != nil && h.repoPauser == nil) {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repos, err := h.repoStore.ListAll(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list repos for bulk edit", "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to load repos</span>`)
+		return
+	}
+	watched := make(map[string]bool, len(repos))
+	for _, repo := range repos {
+		watched[repo.FullName] = true
+	}
+	for _, name := range selected {
+		if !watched[name] {
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: %s is not watched</span>`, html.EscapeString(name))
+			return
+		}
+	}
+
+	for i, name := range selected {
+		if err := h.applyBulkRepoEdit(r, name, edit); err != nil {
+			h.logger.Error("bulk repo edit failed", "repo", name, "error", err)
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to update %s; %d of %d repos updated</span>`,
+				html.EscapeString(name), i, len(selected))
+			h.renderRepoListOOB(w, r)
+			return
+		}
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-sm">Updated %d repos</span>`, len(selected))
+
+	// OOB swaps: paused badges in the repo list and signals in the PR list.
+	h.renderRepoListOOB(w, r)
+	h.renderPRListOOB(w, r)
+}
+
+// applyBulkRepoEdit applies edit to a single repo.
+func (h *Handler) applyBulkRepoEdit(r *http.Request, repoFullName string, edit bulkRepoEdit) error {
+	ctx := r.Context()
+
+	if edit.changesThresholds() {
+		current, err := h.thresholdStore.GetRepoThreshold(ctx, repoFullName)
+		if err != nil {
+			return fmt.Errorf("get threshold: %w", err)
+		}
+		current.RepoFullName = repoFullName
+
+		merged := edit.apply(current)
+		if hasOverrides(merged) {
+			err = h.thresholdStore.SetRepoThreshold(ctx, merged)
+		} else {
+			err = h.thresholdStore.DeleteRepoThreshold(ctx, repoFullName)
+		}
+		if err != nil {
+			return fmt.Errorf("save threshold: %w", err)
+		}
+	}
+
+	if edit.paused != nil {
+		if err := h.repoPauser.SetPaused(ctx, repoFullName, *edit.paused); err != nil {
+			return fmt.Errorf("set paused: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// renderRepoListOOB writes the watched repo list as an OOB swap.
+func (h *Handler) renderRepoListOOB(w http.ResponseWriter, r *http.Request) {
+	repos, err := h.repoStore.ListAll(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list repos for OOB swap", "error", err)
+		return
+	}
+
+	comp := partials.RepoListOOB(h.toRepoViewModels(r.Context(), repos), h.repoJiraConnectionViewModels(r.Context()), h.githubAccountViewModels(r.Context()))
+	if err := comp.Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render OOB repo list", "error", err)
+	}
+}
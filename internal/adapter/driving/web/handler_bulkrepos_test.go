@@ -0,0 +1,72 @@
+package web
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestParseBulkRepoEdit(t *testing.T) {
+	edit, err := parseBulkRepoEdit(url.Values{})
+	require.NoError(t, err)
+	assert.False(t, edit.changesThresholds(), "an empty form changes nothing")
+	assert.Nil(t, edit.paused)
+
+	edit, err = parseBulkRepoEdit(url.Values{
+		"review_count":         {"2"},
+		"stale_review_enabled": {"inherit"},
+		"ci_failure_enabled":   {"false"},
+		"polling":              {"pause"},
+	})
+	require.NoError(t, err)
+	assert.True(t, edit.changesThresholds())
+	require.NotNil(t, edit.paused)
+	assert.True(t, *edit.paused)
+
+	for _, form := range []url.Values{
+		{"review_count": {"-1"}},
+		{"age_urgency_days": {"soon"}},
+		{"stale_review_enabled": {"maybe"}},
+		{"polling": {"stop"}},
+	} {
+		_, err := parseBulkRepoEdit(form)
+		assert.Error(t, err, "form %v should be rejected", form)
+	}
+}
+
+func TestBulkRepoEditApply(t *testing.T) {
+	three, seven, enabled := 3, 7, true
+	current := model.RepoThreshold{
+		RepoFullName:       "octocat/hello-world",
+		ReviewCount:        &three,
+		AgeUrgencyDays:     &seven,
+		StaleReviewEnabled: &enabled,
+	}
+
+	edit, err := parseBulkRepoEdit(url.Values{
+		"review_count":         {"1"},
+		"stale_review_enabled": {"inherit"},
+		"ci_failure_enabled":   {"false"},
+	})
+	require.NoError(t, err)
+
+	merged := edit.apply(current)
+	require.NotNil(t, merged.ReviewCount)
+	assert.Equal(t, 1, *merged.ReviewCount)
+	require.NotNil(t, merged.AgeUrgencyDays, "untouched overrides are kept")
+	assert.Equal(t, 7, *merged.AgeUrgencyDays)
+	assert.Nil(t, merged.StaleReviewEnabled, "inherit clears the override")
+	require.NotNil(t, merged.CIFailureEnabled)
+	assert.False(t, *merged.CIFailureEnabled)
+	assert.Equal(t, 3, *current.ReviewCount, "apply must not modify its input")
+
+	reset, err := parseBulkRepoEdit(url.Values{"reset_thresholds": {"on"}})
+	require.NoError(t, err)
+	cleared := reset.apply(current)
+	assert.Equal(t, "octocat/hello-world", cleared.RepoFullName)
+	assert.False(t, hasOverrides(cleared))
+}
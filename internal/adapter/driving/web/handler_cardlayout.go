@@ -0,0 +1,81 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithCardLayoutStore enables choosing which fields appear on PR cards and
+// their order. Without it cards show model.DefaultCardFields() and the
+// setting returns 503.
+func (h *Handler) WithCardLayoutStore(store driven.CardLayoutStore) *Handler {
+	h.cardLayoutStore = store
+	return h
+}
+
+// cardFields returns the visible card fields in display order, falling back
+// to the defaults when the preference is unavailable.
+func (h *Handler) cardFields(ctx context.Context) []model.CardField {
+	if h.cardLayoutStore == nil {
+		return model.DefaultCardFields()
+	}
+	fields, err := h.cardLayoutStore.GetCardFields(ctx)
+	if err != nil {
+		h.logger.Warn("failed to load card fields, using defaults", "error", err)
+		return model.DefaultCardFields()
+	}
+	return fields
+}
+
+// cardReviewers loads each PR's reviewers, but only when the reviewers field
+// is visible, since it costs a query per PR.
+func (h *Handler) cardReviewers(ctx context.Context, prs []model.PullRequest, fields []model.CardField) map[int64][]string {
+	if h.reviewSvc == nil || !slices.Contains(fields, model.CardFieldReviewers) {
+		return nil
+	}
+	reviewers, err := h.reviewSvc.ReviewersByPR(ctx, prs)
+	if err != nil {
+		h.logger.Warn("failed to load reviewers for cards", "error", err)
+		return nil
+	}
+	return reviewers
+}
+
+// SaveCardLayout handles POST /app/settings/card-layout.
+// It stores the checked "fields" in submitted order and refreshes the PR list
+// so cards pick them up.
+func (h *Handler) SaveCardLayout(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.cardLayoutStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	var fields []model.CardField
+	for _, v := range r.Form["fields"] {
+		fields = append(fields, model.CardField(v))
+	}
+
+	if err := h.cardLayoutStore.SetCardFields(r.Context(), fields); err != nil {
+		h.logger.Error("failed to save card fields", "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to save card layout</span>`)
+		return
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-sm">Card layout saved</span>`)
+	h.renderPRListOOB(w, r)
+}
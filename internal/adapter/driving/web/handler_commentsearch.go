@@ -0,0 +1,96 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithCommentSearchStore enables the comment search page. Without it the
+// route returns 503.
+func (h *Handler) WithCommentSearchStore(store driven.CommentSearchStore) *Handler {
+	h.commentSearch = store
+	return h
+}
+
+// CommentSearch handles GET /app/comments?q=&author=&repo=&from=&to=.
+// It renders the review and issue comments of every stored PR that match the
+// filters, newest first, into #pr-detail. from and to are inclusive dates.
+// Nothing is searched until a filter is set.
+func (h *Handler) CommentSearch(w http.ResponseWriter, r *http.Request) {
+	if h.commentSearch == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	q := r.URL.Query()
+	data := vm.CommentSearchViewModel{
+		Query:  strings.TrimSpace(q.Get("q")),
+		Author: strings.TrimPrefix(strings.TrimSpace(q.Get("author")), "@"),
+		Repo:   strings.TrimSpace(q.Get("repo")),
+	}
+	filter := model.CommentSearchFilter{Text: data.Query, Author: data.Author, RepoFullName: data.Repo}
+	if from, err := time.Parse(archiveDateLayout, q.Get("from")); err == nil {
+		filter.After = from
+		data.From = from.Format(archiveDateLayout)
+	}
+	if to, err := time.Parse(archiveDateLayout, q.Get("to")); err == nil {
+		filter.Before = to.AddDate(0, 0, 1)
+		data.To = to.Format(archiveDateLayout)
+	}
+
+	data.Searched = data.Query != "" || data.Author != "" || data.Repo != "" || data.From != "" || data.To != ""
+	if data.Searched {
+		results, err := h.commentSearch.SearchComments(r.Context(), filter)
+		if err != nil {
+			h.logger.Error("failed to search comments", "query", data.Query, "error", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+		data.Results = toCommentSearchResultViewModels(results)
+	}
+
+	if repos, err := h.repoStore.ListAll(r.Context()); err != nil {
+		h.logger.Warn("failed to list repos for comment search filter", "error", err)
+	} else {
+		data.Repos = extractRepoNames(repos)
+	}
+
+	if err := partials.CommentSearchContent(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render comment search", "error", err)
+	}
+}
+
+// toCommentSearchResultViewModels converts comment search results, showing
+// the matched snippet when there is one and the body otherwise.
+func toCommentSearchResultViewModels(results []model.CommentSearchResult) []vm.CommentSearchResultViewModel {
+	out := make([]vm.CommentSearchResultViewModel, 0, len(results))
+	for _, c := range results {
+		kind := "Comment"
+		if c.Kind == model.CommentKindReview {
+			kind = "Review comment"
+		}
+		excerpt := c.Snippet
+		if excerpt == "" {
+			excerpt = c.Body
+		}
+		out = append(out, vm.CommentSearchResultViewModel{
+			Kind:       kind,
+			Repository: c.RepoFullName,
+			PRNumber:   c.PRNumber,
+			PRTitle:    c.PRTitle,
+			Author:     c.Author,
+			Excerpt:    excerpt,
+			FilePath:   c.Path,
+			DetailPath: fmt.Sprintf("/app/prs/%s/%d", c.RepoFullName, c.PRNumber),
+			CreatedAt:  c.CreatedAt.UTC().Format(archiveDateLayout),
+		})
+	}
+	return out
+}
@@ -0,0 +1,28 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToCommentSearchResultViewModels(t *testing.T) {
+	created := time.Date(2026, 3, 5, 23, 0, 0, 0, time.UTC)
+	got := toCommentSearchResultViewModels([]model.CommentSearchResult{
+		{Kind: model.CommentKindReview, RepoFullName: "acme/api", PRNumber: 7, Body: "full body", Snippet: "matched part", Path: "a.go", CreatedAt: created},
+		{Kind: model.CommentKindIssue, RepoFullName: "acme/web", PRNumber: 3, Body: "full body", CreatedAt: created},
+	})
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "Review comment", got[0].Kind)
+	assert.Equal(t, "matched part", got[0].Excerpt)
+	assert.Equal(t, "a.go", got[0].FilePath)
+	assert.Equal(t, "/app/prs/acme/api/7", got[0].DetailPath)
+	assert.Equal(t, "2026-03-05", got[0].CreatedAt)
+	assert.Equal(t, "Comment", got[1].Kind)
+	assert.Equal(t, "full body", got[1].Excerpt, "the body stands in without a snippet")
+}
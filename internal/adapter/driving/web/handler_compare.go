@@ -0,0 +1,198 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// WithComparisonService enables the competing-PRs section and the side-by-side
+// comparison view. Without it the section is hidden and the routes return 503.
+func (h *Handler) WithComparisonService(svc *application.ComparisonService) *Handler {
+	h.comparisonSvc = svc
+	return h
+}
+
+// ComparePRs handles GET /app/prs/compare?ids=1,2[,3,4].
+// It renders the competing PRs side by side into #pr-detail.
+func (h *Handler) ComparePRs(w http.ResponseWriter, r *http.Request) {
+	if h.comparisonSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	var ids []int64
+	for _, part := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid PR ID", http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) < 2 || len(ids) > application.MaxComparedPRs {
+		http.Error(w, fmt.Sprintf("select between 2 and %d pull requests to compare", application.MaxComparedPRs), http.StatusBadRequest)
+		return
+	}
+
+	comparisons, err := h.comparisonSvc.Compare(r.Context(), ids)
+	if errors.Is(err, application.ErrPRNotFound) {
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to compare PRs", "ids", ids, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	component := partials.PRComparisonContent(toPRComparisonViewModel(comparisons))
+	if err := component.Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render PR comparison", "error", err)
+	}
+}
+
+// LinkPR handles POST /app/prs/{id}/links.
+// It records that the PR competes with the one named in the "target" form field
+// and re-renders the competing-PRs section.
+func (h *Handler) LinkPR(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid PR ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, errMsgInvalidFormData, http.StatusBadRequest)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.comparisonSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFullName, number, ok := parsePRReference(r.FormValue("target"))
+	if !ok {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Enter a PR as #123, owner/repo#123, or a GitHub PR URL")
+		return
+	}
+
+	err = h.comparisonSvc.LinkByNumber(r.Context(), id, repoFullName, number)
+	switch {
+	case errors.Is(err, application.ErrSelfLink):
+		writeErrorToast(w, http.StatusUnprocessableEntity, "A pull request cannot be linked to itself")
+		return
+	case errors.Is(err, application.ErrPRNotFound):
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Pull request not found; is its repository watched?")
+		return
+	case err != nil:
+		h.logger.Error("failed to link PRs", "pr_id", id, "target", r.FormValue("target"), "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderCompetingPRs(w, r, id)
+}
+
+// UnlinkPR handles DELETE /app/prs/{id}/links/{otherID}.
+// It removes a manual link and re-renders the competing-PRs section.
+func (h *Handler) UnlinkPR(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid PR ID", http.StatusBadRequest)
+		return
+	}
+	otherID, err := strconv.ParseInt(r.PathValue("otherID"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid PR ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.comparisonSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.comparisonSvc.Unlink(r.Context(), id, otherID); err != nil {
+		h.logger.Error("failed to unlink PRs", "pr_id", id, "other_id", otherID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderCompetingPRs(w, r, id)
+}
+
+// renderCompetingPRs renders the competing-PRs section for prID after a mutation.
+func (h *Handler) renderCompetingPRs(w http.ResponseWriter, r *http.Request, prID int64) {
+	component := components.CompetingPRs(h.buildCompetingPRsVM(r.Context(), prID))
+	if err := component.Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render competing PRs", "error", err)
+	}
+}
+
+// buildCompetingPRsVM loads the competitors of prID. Failures are logged and
+// produce an empty section so they never block the PR detail from rendering.
+func (h *Handler) buildCompetingPRsVM(ctx context.Context, prID int64) vm.CompetingPRsViewModel {
+	if h.comparisonSvc == nil {
+		return vm.CompetingPRsViewModel{}
+	}
+
+	competing, err := h.comparisonSvc.CompetingPRs(ctx, prID)
+	if err != nil {
+		h.logger.Error("failed to list competing PRs", "pr_id", prID, "error", err)
+		competing = nil
+	}
+
+	return toCompetingPRsViewModel(prID, competing)
+}
+
+// parsePRReference parses a user-typed PR reference: "123", "#123",
+// "owner/repo#123", or a GitHub pull request URL. An empty repoFullName
+// means the reference is relative to the current repository.
+func parsePRReference(input string) (repoFullName string, number int, ok bool) {
+	input = strings.TrimSpace(input)
+
+	if u, err := url.Parse(input); err == nil && u.Host != "" {
+		// https://github.com/owner/repo/pull/123
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) < 4 || parts[2] != "pull" {
+			return "", 0, false
+		}
+		input = parts[0] + "/" + parts[1] + "#" + parts[3]
+	}
+
+	if repo, num, found := strings.Cut(input, "#"); found && repo != "" {
+		if !validate.IsValidRepoName(repo) {
+			return "", 0, false
+		}
+		repoFullName, input = repo, num
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(input, "#"))
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	return repoFullName, n, true
+}
@@ -0,0 +1,37 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePRReference(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantRepo string
+		wantNum  int
+		wantOK   bool
+	}{
+		{input: "123", wantNum: 123, wantOK: true},
+		{input: " #42 ", wantNum: 42, wantOK: true},
+		{input: "octocat/hello-world#7", wantRepo: "octocat/hello-world", wantNum: 7, wantOK: true},
+		{input: "https://github.com/octocat/hello-world/pull/9", wantRepo: "octocat/hello-world", wantNum: 9, wantOK: true},
+		{input: "https://github.com/octocat/hello-world/pull/9/files", wantRepo: "octocat/hello-world", wantNum: 9, wantOK: true},
+		{input: "https://github.com/octocat/hello-world/issues/9"},
+		{input: "octocat#7"},
+		{input: "bad repo/x#7"},
+		{input: "#0"},
+		{input: "abc"},
+		{input: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			repo, num, ok := parsePRReference(tt.input)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantRepo, repo)
+			assert.Equal(t, tt.wantNum, num)
+		})
+	}
+}
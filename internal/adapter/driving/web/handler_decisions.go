@@ -0,0 +1,163 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// maxDecisionSummaryLen bounds decision summaries; they are one-line references,
+// not a place to restate the thread.
+const maxDecisionSummaryLen = 280
+
+// WithDecisionStore enables marking review threads as decisions and the
+// decisions log page. Without it the controls are hidden and the routes return 503.
+func (h *Handler) WithDecisionStore(store driven.DecisionStore) *Handler {
+	h.decisionStore = store
+	return h
+}
+
+// MarkDecision handles POST /app/prs/{owner}/{repo}/{number}/comments/{rootID}/decision.
+// It records the thread as a decision with the submitted summary and re-renders the thread.
+func (h *Handler) MarkDecision(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rootID, err := strconv.ParseInt(r.PathValue("rootID"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.decisionStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	summary := strings.TrimSpace(r.FormValue("summary"))
+	if summary == "" {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: decision summary cannot be empty")
+		return
+	}
+	if utf8.RuneCountInString(summary) > maxDecisionSummaryLen {
+		writeErrorToast(w, http.StatusUnprocessableEntity, fmt.Sprintf("Error: decision summary must be at most %d characters", maxDecisionSummaryLen))
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	decision := model.Decision{
+		RepoFullName:  repoFullName,
+		PRNumber:      number,
+		RootCommentID: rootID,
+		FilePath:      strings.TrimSpace(r.FormValue("path")),
+		Summary:       summary,
+	}
+
+	// The title gives the decisions page context; a missing PR is not fatal.
+	if pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number); err == nil && pr != nil {
+		decision.PRTitle = pr.Title
+	}
+
+	if err := h.decisionStore.Save(r.Context(), decision); err != nil {
+		h.logger.Error("failed to save decision", "repo", repoFullName, "pr", number, "comment", rootID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderThread(w, r, repoFullName, number, rootID, owner, repo)
+}
+
+// ClearDecision handles DELETE /app/prs/{owner}/{repo}/{number}/comments/{rootID}/decision.
+// It removes the decision mark from a thread and re-renders the thread.
+func (h *Handler) ClearDecision(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rootID, err := strconv.ParseInt(r.PathValue("rootID"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.decisionStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.decisionStore.Delete(r.Context(), rootID); err != nil {
+		h.logger.Error("failed to delete decision", "comment", rootID, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderThread(w, r, owner+"/"+repo, number, rootID, owner, repo)
+}
+
+// Decisions handles GET /app/decisions?repo=&q=.
+// It renders the decisions log, filtered by repository and search text, into #pr-detail.
+func (h *Handler) Decisions(w http.ResponseWriter, r *http.Request) {
+	if h.decisionStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFilter := strings.TrimSpace(r.URL.Query().Get("repo"))
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	decisions, err := h.decisionStore.Search(r.Context(), repoFilter, query)
+	if err != nil {
+		h.logger.Error("failed to search decisions", "repo", repoFilter, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var repoNames []string
+	if repos, err := h.repoStore.ListAll(r.Context()); err != nil {
+		h.logger.Warn("failed to list repos for decisions filter", "error", err)
+	} else {
+		repoNames = extractRepoNames(repos)
+	}
+
+	component := partials.DecisionsContent(toDecisionsViewModel(decisions, repoNames, repoFilter, query))
+	if err := component.Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render decisions", "error", err)
+	}
+}
+
+// applyDecisions marks the threads of a PR detail that are logged decisions.
+// Failures are logged and leave the threads unmarked.
+func (h *Handler) applyDecisions(ctx context.Context, detail *vm.PRDetailViewModel) {
+	if h.decisionStore == nil || len(detail.Threads) == 0 {
+		return
+	}
+
+	for i := range detail.Threads {
+		detail.Threads[i].CanMarkDecision = true
+	}
+
+	decisions, err := h.decisionStore.ListByPR(ctx, detail.Repository, detail.Number)
+	if err != nil {
+		h.logger.Error("failed to list decisions", "repo", detail.Repository, "pr", detail.Number, "error", err)
+		return
+	}
+
+	summaries := make(map[int64]string, len(decisions))
+	for _, d := range decisions {
+		summaries[d.RootCommentID] = d.Summary
+	}
+	for i := range detail.Threads {
+		detail.Threads[i].Decision = summaries[detail.Threads[i].RootComment.ID]
+	}
+}
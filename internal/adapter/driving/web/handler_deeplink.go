@@ -0,0 +1,119 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/basepath"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// listFilters are the sidebar search and filter values. They travel in the
+// query string of the dashboard and PR detail URLs, so a copied link reopens
+// the same filtered list.
+type listFilters struct {
+	Query    string
+	Status   string
+	Repo     string
+	Mentions bool
+}
+
+// parseListFilters reads the filters from q, status, repo, and mentions.
+func parseListFilters(values url.Values) listFilters {
+	return listFilters{
+		Query:    values.Get("q"),
+		Status:   values.Get("status"),
+		Repo:     values.Get("repo"),
+		Mentions: values.Get("mentions") != "",
+	}
+}
+
+// encode adds the filters that narrow the list to values; "all" and empty
+// filters are left out to keep links short.
+func (f listFilters) encode(values url.Values) {
+	if q := strings.TrimSpace(f.Query); q != "" {
+		values.Set("q", q)
+	}
+	if f.Status != "" && f.Status != "all" {
+		values.Set("status", f.Status)
+	}
+	if f.Repo != "" && f.Repo != "all" {
+		values.Set("repo", f.Repo)
+	}
+	if f.Mentions {
+		values.Set("mentions", "1")
+	}
+}
+
+// viewModel returns the values the search bar is pre-filled with.
+func (f listFilters) viewModel() vm.SearchFiltersViewModel {
+	return vm.SearchFiltersViewModel{Query: f.Query, Status: f.Status, Repo: f.Repo, Mentions: f.Mentions}
+}
+
+// applyListFilters narrows prs to those matching the filters, including PRs
+// whose private notes match the search text.
+func (h *Handler) applyListFilters(ctx context.Context, prs []model.PullRequest, f listFilters) []model.PullRequest {
+	filtered := filterPRs(prs, f.Query, f.Status, f.Repo)
+	filtered = h.appendNoteMatches(ctx, filtered, prs, f.Query, f.Status, f.Repo)
+	if f.Mentions {
+		filtered = h.filterMentioned(ctx, filtered)
+	}
+	return filtered
+}
+
+// prDetailTabs are the PR detail tabs addressable with ?tab=.
+var prDetailTabs = map[string]string{
+	"reviews":  "reviews",
+	"threads":  "threads",
+	"comments": "comments",
+	"ci":       "ci",
+	"checks":   "ci",
+	"notes":    "notes",
+}
+
+// prDetailTab resolves a ?tab= value, defaulting to the reviews tab.
+func prDetailTab(value string) string {
+	if tab, ok := prDetailTabs[strings.ToLower(value)]; ok {
+		return tab
+	}
+	return "reviews"
+}
+
+// isHTMXNavigation reports whether r is an HTMX partial request. History
+// restores after a cache miss want the full page, like a fresh load.
+func isHTMXNavigation(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true" && r.Header.Get("HX-History-Restore-Request") != "true"
+}
+
+// currentPageURL is the browser URL an HTMX request was made from, or nil.
+func currentPageURL(r *http.Request) *url.URL {
+	u, err := url.Parse(r.Header.Get("HX-Current-URL"))
+	if err != nil || u.Path == "" {
+		return nil
+	}
+	return u
+}
+
+// deepLink builds path with the given filters and, when not the default, tab.
+func deepLink(path string, f listFilters, tab string) string {
+	values := url.Values{}
+	f.encode(values)
+	if tab != "" && tab != "reviews" {
+		values.Set("tab", tab)
+	}
+	if len(values) == 0 {
+		return path
+	}
+	return path + "?" + values.Encode()
+}
+
+// commentPermalink links to the element anchor in pr's detail panel, opened
+// on the threads tab: comment-<id> for a review comment, issuecomment-<id>
+// for a general comment.
+func commentPermalink(pr model.PullRequest, anchor string) string {
+	return basepath.URL(fmt.Sprintf("/app/prs/%s/%d", pr.RepoFullName, pr.Number)) + "?tab=threads#" + anchor
+}
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/basepath"
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/static"
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestDeepLink(t *testing.T) {
+	filters := parseListFilters(url.Values{
+		"q":        {" flaky test "},
+		"status":   {"open"},
+		"repo":     {"all"},
+		"mentions": {"1"},
+	})
+
+	assert.Equal(t, "/app/prs/acme/api/42?mentions=1&q=flaky+test&status=open&tab=ci",
+		deepLink("/app/prs/acme/api/42", filters, "ci"))
+	assert.Equal(t, "/", deepLink("/", listFilters{Status: "all"}, ""), "default filters are left out")
+	assert.Equal(t, "/app/prs/acme/api/42", deepLink("/app/prs/acme/api/42", listFilters{}, "reviews"),
+		"the default tab is left out")
+}
+
+func TestCommentPermalink(t *testing.T) {
+	pr := model.PullRequest{RepoFullName: "acme/api", Number: 42}
+	assert.Equal(t, "/app/prs/acme/api/42?tab=threads#comment-7", commentPermalink(pr, "comment-7"))
+
+	basepath.Set("/mygitpanel")
+	t.Cleanup(func() { basepath.Set("") })
+	assert.Equal(t, "/mygitpanel/app/prs/acme/api/42?tab=threads#issuecomment-9", commentPermalink(pr, "issuecomment-9"))
+
+	pending := toIssueCommentViewModel(model.IssueComment{ID: -1, PendingSync: true}, pr, "")
+	assert.Empty(t, pending.Permalink, "local echoes have no stable anchor")
+}
+
+func TestBasePathLinks(t *testing.T) {
+	basepath.Set("/mygitpanel")
+	t.Cleanup(func() { basepath.Set("") })
+
+	var b strings.Builder
+	require.NoError(t, components.Board(vm.BoardViewModel{}).Render(context.Background(), &b))
+	assert.Contains(t, b.String(), `hx-get="/mygitpanel/app/board"`, "HTMX requests go through the proxy prefix")
+	assert.True(t, strings.HasPrefix(static.URL("css/output.css"), "/mygitpanel/static/css/output.css"))
+}
+
+func TestPRDetailTab(t *testing.T) {
+	assert.Equal(t, "ci", prDetailTab("checks"))
+	assert.Equal(t, "notes", prDetailTab("Notes"))
+	assert.Equal(t, "reviews", prDetailTab(""))
+	assert.Equal(t, "reviews", prDetailTab("bogus"))
+}
+
+func TestIsHTMXNavigation(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/app/prs/acme/api/42", nil)
+	assert.False(t, isHTMXNavigation(r), "a pasted link loads the full page")
+
+	r.Header.Set("HX-Request", "true")
+	assert.True(t, isHTMXNavigation(r))
+
+	r.Header.Set("HX-History-Restore-Request", "true")
+	assert.False(t, isHTMXNavigation(r), "history restores need the full page")
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+)
+
+// densityCookieName remembers the chosen PR list density across page loads.
+const densityCookieName = "pr_list_density"
+
+// tablePageSize is how many rows table density renders per "Show more".
+const tablePageSize = 100
+
+// prListLayout resolves the PR list layout for a request. A "density" query
+// parameter switches density and is remembered in a cookie and the browser's
+// preferences; otherwise the cookie applies, then the saved preference,
+// defaulting to comfortable. A "limit" parameter extends the
+// rows rendered in table density.
+func (h *Handler) prListLayout(w http.ResponseWriter, r *http.Request) vm.PRListLayoutViewModel {
+	density := vm.DensityComfortable
+	if r.URL.Query().Has("density") {
+		if d, ok := parseListDensity(r.URL.Query().Get("density")); ok {
+			density = d
+			http.SetCookie(w, &http.Cookie{
+				Name:     densityCookieName,
+				Value:    string(d),
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+			h.savePreference(w, r, prefDensity, string(d))
+		}
+	} else if cookie, err := r.Cookie(densityCookieName); err == nil {
+		if d, ok := parseListDensity(cookie.Value); ok {
+			density = d
+		}
+	} else if d, ok := parseListDensity(h.preferences(r)[prefDensity]); ok {
+		density = d
+	}
+
+	layout := vm.PRListLayoutViewModel{Density: density}
+	if density == vm.DensityTable {
+		layout.Limit = tablePageSize
+		if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > tablePageSize {
+			layout.Limit = n
+		}
+	}
+	return layout
+}
+
+// parseListDensity validates a density value from a request or cookie.
+func parseListDensity(value string) (vm.ListDensity, bool) {
+	switch d := vm.ListDensity(value); d {
+	case vm.DensityComfortable, vm.DensityCompact, vm.DensityTable:
+		return d, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,38 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+)
+
+func TestPRListLayout(t *testing.T) {
+	h := &Handler{}
+
+	rec := httptest.NewRecorder()
+	layout := h.prListLayout(rec, httptest.NewRequest(http.MethodGet, "/app/prs/search", nil))
+	assert.Equal(t, vm.PRListLayoutViewModel{Density: vm.DensityComfortable}, layout, "comfortable by default")
+
+	rec = httptest.NewRecorder()
+	layout = h.prListLayout(rec, httptest.NewRequest(http.MethodGet, "/app/prs/search?density=table&limit=300", nil))
+	assert.Equal(t, vm.PRListLayoutViewModel{Density: vm.DensityTable, Limit: 300}, layout)
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "table", cookies[0].Value)
+
+	req := httptest.NewRequest(http.MethodGet, "/app/prs/search?limit=5", nil)
+	req.AddCookie(cookies[0])
+	layout = h.prListLayout(httptest.NewRecorder(), req)
+	assert.Equal(t, vm.PRListLayoutViewModel{Density: vm.DensityTable, Limit: tablePageSize}, layout,
+		"the cookie is remembered and limits below a page are ignored")
+
+	req = httptest.NewRequest(http.MethodGet, "/app/prs/search?density=bogus", nil)
+	req.AddCookie(cookies[0])
+	layout = h.prListLayout(httptest.NewRecorder(), req)
+	assert.Equal(t, vm.DensityComfortable, layout.Density, "an unknown density falls back to the default")
+}
@@ -0,0 +1,106 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/pages"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// WithEmbedSigner enables the embeddable PR list at /embed/{owner}/{repo}.
+// Without it the page returns 503.
+func (h *Handler) WithEmbedSigner(signer *application.EmbedSigner) *Handler {
+	h.embedSigner = signer
+	return h
+}
+
+// Embed handles GET /embed/{owner}/{repo}.
+// It renders a standalone, read-only list of the repository's open PRs for
+// iframing into wikis and dashboards. The "token" query parameter must be
+// the repository's embed token; "columns" is a comma-separated list of
+// model.EmbedColumn values.
+func (h *Handler) Embed(w http.ResponseWriter, r *http.Request) {
+	if h.embedSigner == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	if !h.embedSigner.Valid(repoFullName, r.URL.Query().Get("token")) {
+		http.Error(w, "invalid embed token", http.StatusForbidden)
+		return
+	}
+
+	repo, err := h.repoStore.GetByFullName(r.Context(), repoFullName)
+	if err != nil {
+		h.logger.Error("failed to get repo for embed", "repo", repoFullName, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if repo == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	prs, err := h.prStore.GetByRepository(r.Context(), repo.FullName)
+	if err != nil {
+		h.logger.Error("failed to list PRs for embed", "repo", repo.FullName, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	open := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if pr.Status == model.PRStatusOpen {
+			open = append(open, pr)
+		}
+	}
+
+	columns := model.ParseEmbedColumns(r.URL.Query().Get("columns"))
+	data := vm.EmbedViewModel{Repository: repo.FullName, Rows: make([]vm.PRCardViewModel, 0, len(open))}
+	for _, c := range columns {
+		data.Columns = append(data.Columns, vm.EmbedColumnViewModel{Column: c, Label: c.Label()})
+	}
+
+	reviewStatus, reviewers := h.embedReviews(r, open, columns)
+	for _, pr := range open {
+		row := toPRCardViewModel(pr, model.AttentionSignals{})
+		row.ReviewStatus = string(model.ReviewStatePending)
+		if state, ok := reviewStatus[pr.ID]; ok {
+			row.ReviewStatus = string(state)
+		}
+		row.Reviewers = reviewers[pr.ID]
+		data.Rows = append(data.Rows, row)
+	}
+
+	// The token is in the URL; keep it out of the Referer sent to GitHub.
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	if err := pages.Embed(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render embed", "error", err)
+	}
+}
+
+// embedReviews loads review state and reviewers for the embed rows, each only
+// when its column is shown.
+func (h *Handler) embedReviews(r *http.Request, prs []model.PullRequest, columns []model.EmbedColumn) (map[int64]model.ReviewState, map[int64][]string) {
+	if h.reviewSvc == nil {
+		return nil, nil
+	}
+
+	var reviewStatus map[int64]model.ReviewState
+	var reviewers map[int64][]string
+	var err error
+	if slices.Contains(columns, model.EmbedColumnReview) {
+		if reviewStatus, err = h.reviewSvc.ReviewStatusByPR(r.Context(), prs); err != nil {
+			h.logger.Warn("failed to get review status for embed", "error", err)
+		}
+	}
+	if slices.Contains(columns, model.EmbedColumnReviewers) {
+		if reviewers, err = h.reviewSvc.ReviewersByPR(r.Context(), prs); err != nil {
+			h.logger.Warn("failed to get reviewers for embed", "error", err)
+		}
+	}
+	return reviewStatus, reviewers
+}
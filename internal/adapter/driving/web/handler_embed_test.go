@@ -0,0 +1,34 @@
+package web
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+func TestEmbed_Authorization(t *testing.T) {
+	signer := application.NewEmbedSigner(bytes.Repeat([]byte{1}, 32))
+	mux := http.NewServeMux()
+	h := &Handler{}
+	mux.HandleFunc("GET /embed/{owner}/{repo}", h.Embed)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/embed/org/api?token="+signer.Token("org/api"), nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "disabled without a signer")
+
+	h.WithEmbedSigner(signer)
+	for _, target := range []string{
+		"/embed/org/api",
+		"/embed/org/api?token=bogus",
+		"/embed/org/web?token=" + signer.Token("org/api"),
+	} {
+		rec = httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code, target)
+	}
+}
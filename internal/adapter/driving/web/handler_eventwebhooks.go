@@ -0,0 +1,228 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithEventWebhookService enables managing event webhooks from the settings
+// drawer. Without it the section stays empty and the routes return 503.
+func (h *Handler) WithEventWebhookService(svc *application.EventWebhookService) *Handler {
+	h.eventHookSvc = svc
+	return h
+}
+
+// EventWebhooks handles GET /app/settings/event-webhooks. It renders the
+// webhook list and add form, or nothing when event webhooks are unavailable.
+func (h *Handler) EventWebhooks(w http.ResponseWriter, r *http.Request) {
+	if h.eventHookSvc == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	settings := vm.EventWebhookSettingsViewModel{Hooks: h.eventWebhookViewModels(r.Context())}
+	for _, e := range model.AllWebhookEventTypes {
+		settings.Events = append(settings.Events, string(e))
+	}
+	if err := components.EventWebhookSection(settings).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render event webhooks", "error", err)
+	}
+}
+
+// CreateEventWebhook handles POST /app/settings/event-webhooks.
+// It stores the webhook and returns the updated list with the new signing
+// secret shown once.
+func (h *Handler) CreateEventWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.eventHookSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	var events []model.WebhookEventType
+	for _, e := range r.Form["events"] {
+		events = append(events, model.WebhookEventType(e))
+	}
+	hook, err := h.eventHookSvc.Create(r.Context(), model.EventWebhook{
+		URL:          r.FormValue("webhook_url"),
+		Events:       events,
+		RepoFullName: r.FormValue("repo_full_name"),
+		Enabled:      true,
+	})
+	switch {
+	case errors.Is(err, application.ErrInvalidEventWebhook):
+		writeErrorToast(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create event webhook", "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: failed to save webhook")
+		return
+	}
+
+	h.renderEventWebhookList(w, r, hook.Secret)
+}
+
+// ToggleEventWebhook handles POST /app/settings/event-webhooks/{id}/toggle.
+// It resumes a paused webhook or pauses an active one.
+func (h *Handler) ToggleEventWebhook(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.eventHookSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	hooks, err := h.eventHookSvc.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list event webhooks", "error", err)
+		http.Error(w, "failed to update webhook", http.StatusInternalServerError)
+		return
+	}
+	for _, hook := range hooks {
+		if hook.ID != id {
+			continue
+		}
+		if err := h.eventHookSvc.SetEnabled(r.Context(), id, !hook.Enabled); err != nil {
+			h.logger.Error("failed to toggle event webhook", "id", id, "error", err)
+			http.Error(w, "failed to update webhook", http.StatusInternalServerError)
+			return
+		}
+		h.renderEventWebhookList(w, r, "")
+		return
+	}
+	http.Error(w, "webhook not found", http.StatusNotFound)
+}
+
+// DeleteEventWebhook handles DELETE /app/settings/event-webhooks/{id}.
+func (h *Handler) DeleteEventWebhook(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.eventHookSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.eventHookSvc.Delete(r.Context(), id); err != nil && !errors.Is(err, driven.ErrEventWebhookNotFound) {
+		h.logger.Error("failed to delete event webhook", "id", id, "error", err)
+		http.Error(w, "failed to delete webhook", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderEventWebhookList(w, r, "")
+}
+
+// EventWebhookDeliveries handles GET /app/settings/event-webhooks/{id}/deliveries.
+// It renders the webhook's recent deliveries, newest first.
+func (h *Handler) EventWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.eventHookSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	deliveries, err := h.eventHookSvc.Deliveries(r.Context(), id)
+	switch {
+	case errors.Is(err, driven.ErrEventWebhookNotFound):
+		http.Error(w, "webhook not found", http.StatusNotFound)
+		return
+	case err != nil:
+		h.logger.Error("failed to list webhook deliveries", "id", id, "error", err)
+		http.Error(w, "failed to load deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	result := make([]vm.WebhookDeliveryViewModel, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, vm.WebhookDeliveryViewModel{
+			Event:          string(d.Event),
+			Target:         fmt.Sprintf("%s#%d", d.RepoFullName, d.PRNumber),
+			Status:         string(d.Status),
+			Attempts:       d.Attempts,
+			ResponseStatus: d.ResponseStatus,
+			LastError:      d.LastError,
+			CreatedAt:      d.CreatedAt.UTC().Format(apiTokenTimeLayout),
+		})
+	}
+	if err := components.WebhookDeliveryList(result).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render webhook deliveries", "error", err)
+	}
+}
+
+// renderEventWebhookList renders the webhook list fragment for the settings
+// drawer, with newSecret shown above it when set.
+func (h *Handler) renderEventWebhookList(w http.ResponseWriter, r *http.Request, newSecret string) {
+	if err := components.EventWebhookList(h.eventWebhookViewModels(r.Context()), newSecret).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render event webhook list", "error", err)
+	}
+}
+
+// eventWebhookViewModels lists the webhooks for the settings drawer. Failures
+// are logged and yield no webhooks.
+func (h *Handler) eventWebhookViewModels(ctx context.Context) []vm.EventWebhookViewModel {
+	hooks, err := h.eventHookSvc.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list event webhooks", "error", err)
+		return nil
+	}
+	result := make([]vm.EventWebhookViewModel, 0, len(hooks))
+	for _, hook := range hooks {
+		events := "all events"
+		if len(hook.Events) > 0 {
+			names := make([]string, 0, len(hook.Events))
+			for _, e := range hook.Events {
+				names = append(names, string(e))
+			}
+			events = strings.Join(names, ", ")
+		}
+		result = append(result, vm.EventWebhookViewModel{
+			ID:      hook.ID,
+			URL:     hook.URL,
+			Events:  events,
+			Repo:    hook.RepoFullName,
+			Enabled: hook.Enabled,
+		})
+	}
+	return result
+}
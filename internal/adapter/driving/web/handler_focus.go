@@ -0,0 +1,185 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// maxFocusSize bounds the size parameter of the daily focus list.
+const maxFocusSize = 50
+
+// WithFocusService enables the daily focus list. Without it the Today routes
+// return 503.
+func (h *Handler) WithFocusService(svc *application.FocusService) *Handler {
+	h.focusSvc = svc
+	return h
+}
+
+// Today handles GET /app/today?size=. It renders the active repo group's
+// daily focus list into #pr-detail, showing size PRs (5 by default).
+func (h *Handler) Today(w http.ResponseWriter, r *http.Request) {
+	if h.focusSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	size, ok := focusSize(r.URL.Query().Get("size"))
+	if !ok {
+		http.Error(w, "invalid size", http.StatusBadRequest)
+		return
+	}
+	h.renderToday(w, r, size)
+}
+
+// MarkFocus handles POST /app/today/{id}/{state}, where state is done or
+// skipped, and re-renders the list at the form's size.
+func (h *Handler) MarkFocus(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid PR ID", http.StatusBadRequest)
+		return
+	}
+	state := model.FocusState(r.PathValue("state"))
+	if !state.IsValid() {
+		http.Error(w, "invalid state", http.StatusBadRequest)
+		return
+	}
+	size, ok := focusSize(r.FormValue("size"))
+	if !ok {
+		http.Error(w, "invalid size", http.StatusBadRequest)
+		return
+	}
+
+	if h.focusSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.focusSvc.Mark(r.Context(), id, state, time.Now()); err != nil {
+		h.logger.Error("failed to mark focus PR", "id", id, "state", state, "error", err)
+		http.Error(w, "failed to update focus list", http.StatusInternalServerError)
+		return
+	}
+	h.renderToday(w, r, size)
+}
+
+// ClearFocus handles DELETE /app/today/{id}. It removes today's done or skip
+// mark from the PR and re-renders the list at the size query value.
+func (h *Handler) ClearFocus(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid PR ID", http.StatusBadRequest)
+		return
+	}
+	size, ok := focusSize(r.URL.Query().Get("size"))
+	if !ok {
+		http.Error(w, "invalid size", http.StatusBadRequest)
+		return
+	}
+
+	if h.focusSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.focusSvc.Clear(r.Context(), id, time.Now()); err != nil {
+		h.logger.Error("failed to clear focus mark", "id", id, "error", err)
+		http.Error(w, "failed to update focus list", http.StatusInternalServerError)
+		return
+	}
+	h.renderToday(w, r, size)
+}
+
+// focusSize parses a focus list size, defaulting to DefaultFocusSize when v
+// is empty. ok is false when v is not a number between 1 and maxFocusSize.
+func focusSize(v string) (size int, ok bool) {
+	if v == "" {
+		return application.DefaultFocusSize, true
+	}
+	size, err := strconv.Atoi(v)
+	if err != nil || size < 1 || size > maxFocusSize {
+		return 0, false
+	}
+	return size, true
+}
+
+// renderToday builds the focus list from the active repo group's open PRs
+// and renders it into #pr-detail.
+func (h *Handler) renderToday(w http.ResponseWriter, r *http.Request, size int) {
+	ctx := r.Context()
+	prs, err := h.prStore.ListAll(ctx)
+	if err != nil {
+		h.logger.Error("failed to list PRs for focus list", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	open := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range scopePRsToGroup(prs, h.activeRepoGroup(w, r)) {
+		if pr.Status == model.PRStatusOpen {
+			open = append(open, pr)
+		}
+	}
+
+	cards := h.toPRCardViewModelsWithSignals(ctx, open)
+	candidates := make([]application.FocusCandidate, len(open))
+	cardsByID := make(map[int64]vm.PRCardViewModel, len(open))
+	for i, pr := range open {
+		candidates[i] = application.FocusCandidate{PR: pr, Signals: cards[i].Attention}
+		cardsByID[pr.ID] = cards[i]
+	}
+
+	username, now := h.authenticatedUsername(ctx), time.Now()
+	list, err := h.focusSvc.Today(ctx, candidates, username, size, now)
+	if err != nil {
+		// Without the marks the list still ranks by attention alone.
+		h.logger.Warn("failed to load focus marks", "error", err)
+		list = application.BuildFocusList(candidates, nil, username, size, now)
+	}
+
+	data := toTodayViewModel(list, cardsByID, size)
+	if err := partials.TodayContent(data).Render(ctx, w); err != nil {
+		h.logger.Error("failed to render focus list", "error", err)
+	}
+}
+
+// toTodayViewModel pairs each focus list entry with its PR card and the
+// reasons it made the list.
+func toTodayViewModel(list application.FocusList, cardsByID map[int64]vm.PRCardViewModel, size int) vm.TodayViewModel {
+	data := vm.TodayViewModel{
+		Items:    make([]vm.TodayItemViewModel, 0, len(list.Entries)),
+		Done:     list.Done,
+		More:     list.More,
+		Size:     size,
+		NextSize: min(size+application.DefaultFocusSize, maxFocusSize),
+	}
+	for _, e := range list.Entries {
+		item := vm.TodayItemViewModel{Card: cardsByID[e.PR.ID], Skipped: e.Skipped}
+		if e.PR.NeedsReview {
+			item.Reasons = append(item.Reasons, "Review requested")
+		}
+		for _, signal := range model.AllAttentionSignals {
+			if e.Signals.Has(signal) {
+				item.Reasons = append(item.Reasons, signalLabels[signal])
+			}
+		}
+		data.Items = append(data.Items, item)
+	}
+	return data
+}
@@ -0,0 +1,36 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToTodayViewModel(t *testing.T) {
+	list := application.FocusList{
+		Entries: []application.FocusEntry{
+			{PR: model.PullRequest{ID: 2, NeedsReview: true}, Signals: model.AttentionSignals{HasCIFailure: true}},
+			{PR: model.PullRequest{ID: 1}, Signals: model.AttentionSignals{IsAgeUrgent: true}, Skipped: true},
+		},
+		Done: 1,
+		More: 3,
+	}
+	cards := map[int64]vm.PRCardViewModel{1: {ID: 1, Number: 10}, 2: {ID: 2, Number: 20}}
+
+	data := toTodayViewModel(list, cards, 48)
+
+	require.Len(t, data.Items, 2)
+	assert.Equal(t, 20, data.Items[0].Card.Number)
+	assert.Equal(t, []string{"Review requested", signalLabels[model.SignalCIFailure]}, data.Items[0].Reasons)
+	assert.False(t, data.Items[0].Skipped)
+	assert.Equal(t, []string{signalLabels[model.SignalAgeUrgent]}, data.Items[1].Reasons)
+	assert.True(t, data.Items[1].Skipped)
+	assert.Equal(t, 1, data.Done)
+	assert.Equal(t, 3, data.More)
+	assert.Equal(t, maxFocusSize, data.NextSize, "show more stops at the size limit")
+}
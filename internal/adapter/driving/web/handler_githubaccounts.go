@@ -0,0 +1,218 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithGitHubAccounts enables named GitHub accounts and routing watched repos
+// through them. Without it every repo uses the default token and the account
+// routes return 503.
+func (h *Handler) WithGitHubAccounts(accounts driven.GitHubAccountStore, repoAccounts driven.GitHubRepoAccountStore) *Handler {
+	h.githubAccountStore = accounts
+	h.githubRepoAccountStore = repoAccounts
+	return h
+}
+
+// CreateGitHubAccount handles POST /app/settings/github/accounts.
+// It validates the token, records the login it belongs to, and returns the
+// updated account list HTML fragment.
+func (h *Handler) CreateGitHubAccount(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.githubAccountStore == nil || h.writerFactory == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	name := strings.TrimSpace(r.FormValue("account_name"))
+	token := strings.TrimSpace(r.FormValue("account_token"))
+	if name == "" || token == "" {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Name and token are required")
+		return
+	}
+
+	username, err := h.writerFactory("").ValidateToken(r.Context(), token)
+	if err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: "+err.Error())
+		return
+	}
+
+	account := model.GitHubAccount{Name: name, Username: username, Token: token}
+	if _, err := h.githubAccountStore.Create(r.Context(), account); err != nil {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		switch {
+		case errors.Is(err, driven.ErrGitHubAccountExists):
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">An account named %q already exists</span>`, html.EscapeString(name))
+		case errors.Is(err, driven.ErrEncryptionKeyNotSet):
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Credential storage requires MYGITPANEL_SECRET_KEY to be set.</span>`)
+		default:
+			h.logger.Error("failed to create github account", "name", name, "error", err)
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to save account</span>`)
+		}
+		return
+	}
+
+	h.renderGitHubAccountList(w, r)
+}
+
+// DeleteGitHubAccount handles DELETE /app/settings/github/accounts/{id}.
+// Repos assigned to the account fall back to the default token.
+func (h *Handler) DeleteGitHubAccount(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid account ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.githubAccountStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.githubAccountStore.Delete(r.Context(), id); err != nil {
+		h.logger.Error("failed to delete github account", "id", id, "error", err)
+		http.Error(w, "failed to delete account", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderGitHubAccountList(w, r)
+}
+
+// SaveGitHubRepoAccount handles POST /app/settings/github/repo-account.
+// It assigns a repo to a GitHub account or clears the assignment when github_account_id is 0.
+// The poll loop and write actions pick up the change on their next use.
+func (h *Handler) SaveGitHubRepoAccount(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, errMsgInvalidFormData, http.StatusBadRequest)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.githubRepoAccountStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFullName := strings.TrimSpace(r.FormValue("repo_full_name"))
+	if repoFullName == "" {
+		http.Error(w, "repo_full_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var accountID int64
+	if raw := r.FormValue("github_account_id"); raw != "" {
+		var err error
+		accountID, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || accountID < 0 {
+			http.Error(w, "invalid github_account_id", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.githubRepoAccountStore.SetRepoAccount(r.Context(), repoFullName, accountID); err != nil {
+		h.logger.Error("failed to set repo github account", "repo", repoFullName, "account", accountID, "error", err)
+		http.Error(w, "failed to save assignment", http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-xs">Saved</span>`)
+}
+
+// renderGitHubAccountList renders the account list fragment for the settings drawer.
+func (h *Handler) renderGitHubAccountList(w http.ResponseWriter, r *http.Request) {
+	if err := components.GitHubAccountList(h.githubAccountViewModels(r.Context())).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render github account list", "error", err)
+	}
+}
+
+// githubAccountViewModels lists the named accounts for the settings drawer and
+// the per-repo assignment dropdown. Failures are logged and yield no accounts.
+func (h *Handler) githubAccountViewModels(ctx context.Context) []vm.GitHubAccountViewModel {
+	if h.githubAccountStore == nil {
+		return nil
+	}
+
+	accounts, err := h.githubAccountStore.List(ctx)
+	if err != nil {
+		if !errors.Is(err, driven.ErrEncryptionKeyNotSet) {
+			h.logger.Warn("failed to list github accounts", "error", err)
+		}
+		return nil
+	}
+
+	vms := make([]vm.GitHubAccountViewModel, 0, len(accounts))
+	for _, a := range accounts {
+		vms = append(vms, vm.GitHubAccountViewModel{ID: a.ID, Name: a.Name, Username: a.Username})
+	}
+	return vms
+}
+
+// githubAccountAssignments returns the assigned account ID for each repo, 0 when unassigned.
+func (h *Handler) githubAccountAssignments(ctx context.Context, repos []model.Repository) map[string]int64 {
+	if h.githubRepoAccountStore == nil || len(repos) == 0 {
+		return map[string]int64{}
+	}
+
+	assignments, err := h.githubRepoAccountStore.GetRepoAccounts(ctx, extractRepoNames(repos))
+	if err != nil {
+		h.logger.Warn("failed to get github accounts for repos", "error", err)
+		return map[string]int64{}
+	}
+	return assignments
+}
+
+// githubAccountForRepo returns the account a repo is routed through, or a
+// zero-value account when it uses the default token.
+func (h *Handler) githubAccountForRepo(ctx context.Context, repoFullName string) model.GitHubAccount {
+	if h.githubRepoAccountStore == nil || repoFullName == "" {
+		return model.GitHubAccount{}
+	}
+
+	account, err := h.githubRepoAccountStore.GetForRepo(ctx, repoFullName)
+	if err != nil {
+		h.logger.Warn("failed to get github account for repo", "repo", repoFullName, "error", err)
+		return model.GitHubAccount{}
+	}
+	return account
+}
+
+// usernameForRepo returns the login acting on repoFullName: the provider login
+// for repositories hosted outside GitHub, then the login of its assigned
+// account, otherwise the authenticated default user.
+func (h *Handler) usernameForRepo(ctx context.Context, repoFullName string) string {
+	if username, ok := h.providerUsername(ctx, repoFullName); ok {
+		return username
+	}
+	if account := h.githubAccountForRepo(ctx, repoFullName); account.Username != "" {
+		return account.Username
+	}
+	return h.authenticatedUsername(ctx)
+}
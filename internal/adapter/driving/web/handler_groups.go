@@ -0,0 +1,121 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// groupCookieName remembers the selected repo group across page loads.
+const groupCookieName = "repo_group"
+
+// WithRepoGroupStore enables the sidebar's repo group switcher, which scopes
+// the PR list, the repo filter, and the list summary to one group. Without it
+// the switcher is hidden and every repository is shown.
+func (h *Handler) WithRepoGroupStore(store driven.RepoGroupStore) *Handler {
+	h.groupStore = store
+	return h
+}
+
+// activeRepoGroup returns the selected repo group, or nil when all repos are
+// shown. A "group" query parameter selects a group and is remembered in a
+// cookie; otherwise the cookie decides. Unknown groups select all repos.
+func (h *Handler) activeRepoGroup(w http.ResponseWriter, r *http.Request) *model.RepoGroup {
+	if h.groupStore == nil {
+		return nil
+	}
+
+	value := ""
+	if r.URL.Query().Has("group") {
+		value = r.URL.Query().Get("group")
+		http.SetCookie(w, &http.Cookie{
+			Name:     groupCookieName,
+			Value:    value,
+			Path:     "/",
+			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
+		})
+	} else if cookie, err := r.Cookie(groupCookieName); err == nil {
+		value = cookie.Value
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+	group, err := h.groupStore.Get(r.Context(), id)
+	if err != nil {
+		h.logger.Warn("failed to load repo group", "group", id, "error", err)
+		return nil
+	}
+	return group
+}
+
+// repoGroupOptions lists the groups for the sidebar switcher, marking the
+// selected one. Failures are logged and hide the switcher.
+func (h *Handler) repoGroupOptions(ctx context.Context, selected *model.RepoGroup) []vm.RepoGroupOptionViewModel {
+	if h.groupStore == nil {
+		return nil
+	}
+
+	groups, err := h.groupStore.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list repo groups", "error", err)
+		return nil
+	}
+
+	options := make([]vm.RepoGroupOptionViewModel, 0, len(groups))
+	for _, g := range groups {
+		options = append(options, vm.RepoGroupOptionViewModel{
+			ID:       g.ID,
+			Name:     g.Name,
+			Selected: selected != nil && selected.ID == g.ID,
+		})
+	}
+	return options
+}
+
+// scopePRsToGroup keeps the PRs of repositories in group; a nil group keeps all.
+func scopePRsToGroup(prs []model.PullRequest, group *model.RepoGroup) []model.PullRequest {
+	if group == nil {
+		return prs
+	}
+
+	members := groupMembers(group)
+	scoped := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if members[pr.RepoFullName] {
+			scoped = append(scoped, pr)
+		}
+	}
+	return scoped
+}
+
+// scopeReposToGroup keeps the repositories in group; a nil group keeps all.
+func scopeReposToGroup(repos []model.Repository, group *model.RepoGroup) []model.Repository {
+	if group == nil {
+		return repos
+	}
+
+	members := groupMembers(group)
+	scoped := make([]model.Repository, 0, len(repos))
+	for _, repo := range repos {
+		if members[repo.FullName] {
+			scoped = append(scoped, repo)
+		}
+	}
+	return scoped
+}
+
+// groupMembers returns the set of repository names in group.
+func groupMembers(group *model.RepoGroup) map[string]bool {
+	members := make(map[string]bool, len(group.Repos))
+	for _, name := range group.Repos {
+		members[name] = true
+	}
+	return members
+}
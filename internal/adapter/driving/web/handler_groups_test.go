@@ -0,0 +1,31 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestScopeToGroup(t *testing.T) {
+	prs := []model.PullRequest{
+		{ID: 1, RepoFullName: "acme/api"},
+		{ID: 2, RepoFullName: "acme/terraform"},
+		{ID: 3, RepoFullName: "acme/api"},
+	}
+	repos := []model.Repository{{FullName: "acme/api"}, {FullName: "acme/terraform"}}
+
+	assert.Equal(t, prs, scopePRsToGroup(prs, nil), "no group keeps every PR")
+	assert.Equal(t, repos, scopeReposToGroup(repos, nil))
+
+	backend := &model.RepoGroup{Name: "Backend", Repos: []string{"acme/api", "acme/unwatched"}}
+	scoped := scopePRsToGroup(prs, backend)
+	assert.Len(t, scoped, 2)
+	for _, pr := range scoped {
+		assert.Equal(t, "acme/api", pr.RepoFullName)
+	}
+	assert.Equal(t, []model.Repository{{FullName: "acme/api"}}, scopeReposToGroup(repos, backend))
+
+	assert.Empty(t, scopePRsToGroup(prs, &model.RepoGroup{Name: "Empty"}))
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// reviewHistoryLimit caps how many archived PRs one page of the review
+// history shows; narrowing by repository or keyword reaches older ones.
+const reviewHistoryLimit = 100
+
+// WithReviewHistoryStore enables the review history archive. Without it the
+// route returns 503.
+func (h *Handler) WithReviewHistoryStore(store driven.ReviewHistoryStore) *Handler {
+	h.reviewHistoryStore = store
+	return h
+}
+
+// ReviewHistory handles GET /app/history?repo=&q=.
+// It renders the closed and merged PRs the user reviewed, with their verdicts
+// and recorded decisions, into #pr-detail.
+func (h *Handler) ReviewHistory(w http.ResponseWriter, r *http.Request) {
+	if h.reviewHistoryStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFilter := strings.TrimSpace(r.URL.Query().Get("repo"))
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	items, err := h.reviewHistoryStore.ListReviewed(r.Context(), h.reviewerLogins(r.Context()), repoFilter, query, reviewHistoryLimit+1)
+	if err != nil {
+		h.logger.Error("failed to list review history", "repo", repoFilter, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	truncated := len(items) > reviewHistoryLimit
+	if truncated {
+		items = items[:reviewHistoryLimit]
+	}
+
+	var repoNames []string
+	if repos, err := h.repoStore.ListAll(r.Context()); err != nil {
+		h.logger.Warn("failed to list repos for review history filter", "error", err)
+	} else {
+		repoNames = extractRepoNames(repos)
+	}
+
+	component := partials.ReviewHistoryContent(toReviewHistoryViewModel(items, repoNames, repoFilter, query, truncated))
+	if err := component.Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render review history", "error", err)
+	}
+}
+
+// reviewerLogins returns every GitHub login the user reviews as: the default
+// account plus any named accounts.
+func (h *Handler) reviewerLogins(ctx context.Context) []string {
+	logins := []string{h.authenticatedUsername(ctx)}
+	for _, account := range h.githubAccountViewModels(ctx) {
+		if !slices.ContainsFunc(logins, func(l string) bool { return strings.EqualFold(l, account.Username) }) {
+			logins = append(logins, account.Username)
+		}
+	}
+	return logins
+}
@@ -0,0 +1,142 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// inboxLimit caps how many events the inbox shows; older events remain stored.
+const inboxLimit = 200
+
+// WithInboxService enables the attention inbox and its unread badge. Without
+// it the sidebar button is hidden and the routes return 503.
+func (h *Handler) WithInboxService(svc *application.InboxService) *Handler {
+	h.inboxSvc = svc
+	return h
+}
+
+// Inbox handles GET /app/inbox?unread=1.
+// It renders the attention inbox into #pr-detail; unread=1 hides read events.
+func (h *Handler) Inbox(w http.ResponseWriter, r *http.Request) {
+	if h.inboxSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+	h.renderInbox(w, r, r.URL.Query().Get("unread") == "1")
+}
+
+// InboxBadge handles GET /app/inbox/badge, the self-refreshing unread count.
+func (h *Handler) InboxBadge(w http.ResponseWriter, r *http.Request) {
+	if h.inboxSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+	if err := components.InboxBadge(h.inboxUnread(r.Context()), false).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render inbox badge", "error", err)
+	}
+}
+
+// SetInboxEventRead handles POST /app/inbox/{id}/read and POST /app/inbox/{id}/unread.
+// It updates the event and re-renders the inbox with the submitted filter.
+func (h *Handler) SetInboxEventRead(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid inbox event ID", http.StatusBadRequest)
+		return
+	}
+
+	var read bool
+	switch r.PathValue("state") {
+	case "read":
+		read = true
+	case "unread":
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	if h.inboxSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.inboxSvc.SetRead(r.Context(), id, read); err != nil {
+		h.logger.Error("failed to update inbox event", "id", id, "read", read, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderInbox(w, r, r.FormValue("unread") == "1")
+}
+
+// MarkInboxRead handles POST /app/inbox/read-all.
+func (h *Handler) MarkInboxRead(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.inboxSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.inboxSvc.MarkAllRead(r.Context()); err != nil {
+		h.logger.Error("failed to mark inbox read", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderInbox(w, r, r.FormValue("unread") == "1")
+}
+
+// renderInbox writes the inbox content and an OOB unread badge.
+func (h *Handler) renderInbox(w http.ResponseWriter, r *http.Request, unreadOnly bool) {
+	events, err := h.inboxSvc.List(r.Context(), unreadOnly, inboxLimit+1)
+	if err != nil {
+		h.logger.Error("failed to list inbox events", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := vm.InboxViewModel{
+		UnreadOnly: unreadOnly,
+		Unread:     h.inboxUnread(r.Context()),
+		Truncated:  len(events) > inboxLimit,
+	}
+	if data.Truncated {
+		events = events[:inboxLimit]
+	}
+	data.Events = make([]vm.InboxEventViewModel, 0, len(events))
+	for _, e := range events {
+		data.Events = append(data.Events, toInboxEventViewModel(e))
+	}
+
+	if err := partials.InboxContent(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render inbox", "error", err)
+	}
+}
+
+// inboxUnread returns the unread inbox count, or 0 when it cannot be read.
+func (h *Handler) inboxUnread(ctx context.Context) int {
+	if h.inboxSvc == nil {
+		return 0
+	}
+	n, err := h.inboxSvc.UnreadCount(ctx)
+	if err != nil {
+		h.logger.Warn("failed to count unread inbox events", "error", err)
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,136 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// LabelEditor handles GET /app/prs/{owner}/{repo}/{number}/labels.
+// It renders the PR's labels with remove buttons and a typeahead over the
+// repository's labels, which are fetched from GitHub on demand.
+func (h *Handler) LabelEditor(w http.ResponseWriter, r *http.Request) {
+	owner := r.PathValue("owner")
+	repo := r.PathValue("repo")
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		http.Error(w, errMsgInvalidPRNumber, http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.prStore.GetByNumber(r.Context(), owner+"/"+repo, number)
+	if err != nil {
+		h.logger.Error("failed to get PR for label editor", "repo", owner+"/"+repo, "number", number, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if pr == nil {
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return
+	}
+
+	h.renderLabelEditor(w, r, *pr)
+}
+
+// AddLabel handles POST /app/prs/{owner}/{repo}/{number}/labels.
+// It adds the label form value to the PR and re-renders the editor.
+func (h *Handler) AddLabel(w http.ResponseWriter, r *http.Request) {
+	h.editLabel(w, r, "add labels", func(writer driven.GitHubWriter, pr *model.PullRequest, label string) error {
+		if err := writer.AddLabels(r.Context(), pr.RepoFullName, pr.Number, []string{label}); err != nil {
+			return err
+		}
+		if !slices.Contains(pr.Labels, label) {
+			pr.Labels = append(pr.Labels, label)
+		}
+		return nil
+	})
+}
+
+// RemoveLabel handles POST /app/prs/{owner}/{repo}/{number}/labels/remove.
+// It removes the label form value from the PR and re-renders the editor.
+func (h *Handler) RemoveLabel(w http.ResponseWriter, r *http.Request) {
+	h.editLabel(w, r, "remove labels", func(writer driven.GitHubWriter, pr *model.PullRequest, label string) error {
+		if err := writer.RemoveLabel(r.Context(), pr.RepoFullName, pr.Number, label); err != nil {
+			return err
+		}
+		pr.Labels = slices.DeleteFunc(pr.Labels, func(l string) bool { return l == label })
+		return nil
+	})
+}
+
+// editLabel applies edit to the PR named by the request and stores its new
+// labels, so the editor and cards show them before the next poll. Errors are
+// plain text so the editor can show them in an alert.
+func (h *Handler) editLabel(w http.ResponseWriter, r *http.Request, action string, edit func(driven.GitHubWriter, *model.PullRequest, string) error) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	label := strings.TrimSpace(r.FormValue("label"))
+	if label == "" {
+		http.Error(w, "label is required", http.StatusUnprocessableEntity)
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number)
+	if err != nil {
+		h.logger.Error("failed to get PR for label edit", "repo", repoFullName, "number", number, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if pr == nil {
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return
+	}
+
+	writer := h.requireWriter(w, r, repoFullName, action)
+	if writer == nil {
+		return
+	}
+
+	if err := edit(writer, pr, label); err != nil {
+		h.logger.Error("failed to edit labels", "repo", repoFullName, "pr", number, "label", label, "error", err)
+		http.Error(w, "Label update failed: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	if err := h.prStore.Upsert(r.Context(), *pr); err != nil {
+		h.logger.Warn("failed to store labels", "repo", repoFullName, "pr", number, "error", err)
+	}
+
+	h.renderLabelEditor(w, r, *pr)
+}
+
+// renderLabelEditor renders the label editor for pr. Labels can be edited
+// only while GitHub actions are available for the repository.
+func (h *Handler) renderLabelEditor(w http.ResponseWriter, r *http.Request, pr model.PullRequest) {
+	ctx := r.Context()
+	owner, repo, _ := strings.Cut(pr.RepoFullName, "/")
+	editor := vm.LabelEditorViewModel{Owner: owner, RepoName: repo, Number: pr.Number, Labels: pr.Labels}
+
+	// Only providers that can list labels have them; Bitbucket has none.
+	lister, ok := h.writerFor(ctx, pr.RepoFullName).(driven.LabelLister)
+	if ok && h.actionsDisabledReason(ctx, pr.RepoFullName, h.syncStatus()) == "" {
+		editor.Editable = true
+		available, err := lister.FetchRepoLabels(ctx, pr.RepoFullName)
+		if err != nil {
+			h.logger.Warn("failed to fetch repo labels", "repo", pr.RepoFullName, "error", err)
+		}
+		for _, name := range available {
+			if !slices.Contains(pr.Labels, name) {
+				editor.Available = append(editor.Available, name)
+			}
+		}
+	}
+
+	if err := components.LabelEditor(editor).Render(ctx, w); err != nil {
+		h.logger.Error("failed to render label editor", "error", err)
+	}
+}
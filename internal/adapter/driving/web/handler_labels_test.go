@@ -0,0 +1,51 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestLabelEditor(t *testing.T) {
+	get := func(h *Handler, number string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/app/prs/acme/api/"+number+"/labels", nil)
+		req.SetPathValue("owner", "acme")
+		req.SetPathValue("repo", "api")
+		req.SetPathValue("number", number)
+		rec := httptest.NewRecorder()
+		h.LabelEditor(rec, req)
+		return rec
+	}
+
+	h := &Handler{
+		logger:  slog.Default(),
+		prStore: singlePRStore{pr: model.PullRequest{RepoFullName: "acme/api", Number: 7, Labels: []string{"bug"}}},
+	}
+
+	assert.Equal(t, http.StatusBadRequest, get(h, "x").Code)
+	assert.Equal(t, http.StatusNotFound, get(h, "8").Code)
+
+	rec := get(h, "7")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "bug")
+	assert.NotContains(t, rec.Body.String(), `name="label"`, "labels are read-only without a GitHub token")
+}
+
+func TestAddLabel_RequiresLabel(t *testing.T) {
+	req := preferenceRequest(http.MethodPost, "/app/prs/acme/api/7/labels", "label=+", nil)
+	req.SetPathValue("owner", "acme")
+	req.SetPathValue("repo", "api")
+	req.SetPathValue("number", "7")
+	rec := httptest.NewRecorder()
+
+	h := &Handler{logger: slog.Default()}
+	h.AddLabel(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
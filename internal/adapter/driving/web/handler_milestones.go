@@ -0,0 +1,150 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithMilestoneCache reuses each repository's fetched milestones across PR
+// detail views. Without it milestones are fetched for every picker.
+func (h *Handler) WithMilestoneCache(cache *application.MilestoneCache) *Handler {
+	h.milestoneCache = cache
+	return h
+}
+
+// MilestonePicker handles GET /app/prs/{owner}/{repo}/{number}/milestone.
+// It renders the PR's milestone with a dropdown of the repository's open
+// milestones when it can be changed.
+func (h *Handler) MilestonePicker(w http.ResponseWriter, r *http.Request) {
+	owner := r.PathValue("owner")
+	repo := r.PathValue("repo")
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		http.Error(w, errMsgInvalidPRNumber, http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.prStore.GetByNumber(r.Context(), owner+"/"+repo, number)
+	if err != nil {
+		h.logger.Error("failed to get PR for milestone picker", "repo", owner+"/"+repo, "number", number, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if pr == nil {
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return
+	}
+
+	h.renderMilestonePicker(w, r, *pr)
+}
+
+// SetMilestone handles POST /app/prs/{owner}/{repo}/{number}/milestone with
+// form value milestone set to a milestone number, or 0 to clear it. It stores
+// the new milestone so it shows before the next poll and re-renders the
+// picker. Errors are plain text so the picker can show them in an alert.
+func (h *Handler) SetMilestone(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	milestone, err := strconv.Atoi(r.FormValue("milestone"))
+	if err != nil || milestone < 0 {
+		http.Error(w, "invalid milestone", http.StatusBadRequest)
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number)
+	if err != nil {
+		h.logger.Error("failed to get PR for milestone", "repo", repoFullName, "number", number, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if pr == nil {
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return
+	}
+
+	writer := h.requireWriter(w, r, repoFullName, "set milestones")
+	if writer == nil {
+		return
+	}
+
+	title := ""
+	if milestone != 0 {
+		lister, _ := writer.(driven.MilestoneLister)
+		for _, m := range h.milestones(r, repoFullName, lister) {
+			if m.Number == milestone {
+				title = m.Title
+			}
+		}
+		if title == "" {
+			if h.milestoneCache != nil {
+				h.milestoneCache.Invalidate(repoFullName)
+			}
+			http.Error(w, "Milestone not found; it may have been closed.", http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
+	if err := writer.SetMilestone(r.Context(), repoFullName, number, milestone); err != nil {
+		h.logger.Error("failed to set milestone", "repo", repoFullName, "pr", number, "milestone", milestone, "error", err)
+		http.Error(w, "Milestone update failed: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	pr.Milestone = title
+	if err := h.prStore.Upsert(r.Context(), *pr); err != nil {
+		h.logger.Warn("failed to store milestone", "repo", repoFullName, "pr", number, "error", err)
+	}
+
+	h.renderMilestonePicker(w, r, *pr)
+}
+
+// milestones returns the open milestones of repoFullName through the cache
+// when one is configured. Failures are logged and yield none.
+func (h *Handler) milestones(r *http.Request, repoFullName string, lister driven.MilestoneLister) []model.Milestone {
+	if lister == nil {
+		return nil
+	}
+
+	var milestones []model.Milestone
+	var err error
+	if h.milestoneCache != nil {
+		milestones, err = h.milestoneCache.Milestones(r.Context(), repoFullName, lister)
+	} else {
+		milestones, err = lister.FetchMilestones(r.Context(), repoFullName)
+	}
+	if err != nil {
+		h.logger.Warn("failed to fetch milestones", "repo", repoFullName, "error", err)
+		return nil
+	}
+	return milestones
+}
+
+// renderMilestonePicker renders the milestone picker for pr. The milestone
+// can be changed only while GitHub actions are available for the repository.
+func (h *Handler) renderMilestonePicker(w http.ResponseWriter, r *http.Request, pr model.PullRequest) {
+	ctx := r.Context()
+	owner, repo, _ := strings.Cut(pr.RepoFullName, "/")
+	picker := vm.MilestonePickerViewModel{Owner: owner, RepoName: repo, Number: pr.Number, Current: pr.Milestone}
+
+	// Only providers that can list milestones have them; Bitbucket has none.
+	lister, ok := h.writerFor(ctx, pr.RepoFullName).(driven.MilestoneLister)
+	if ok && h.actionsDisabledReason(ctx, pr.RepoFullName, h.syncStatus()) == "" {
+		picker.Editable = true
+		picker.Milestones = h.milestones(r, pr.RepoFullName, lister)
+	}
+
+	if err := components.MilestonePicker(picker).Render(ctx, w); err != nil {
+		h.logger.Error("failed to render milestone picker", "error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestMilestonePicker_ReadOnlyWithoutToken(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/app/prs/acme/api/7/milestone", nil)
+	req.SetPathValue("owner", "acme")
+	req.SetPathValue("repo", "api")
+	req.SetPathValue("number", "7")
+	rec := httptest.NewRecorder()
+
+	h := &Handler{
+		logger:  slog.Default(),
+		prStore: singlePRStore{pr: model.PullRequest{RepoFullName: "acme/api", Number: 7, Milestone: "v1.0"}},
+	}
+	h.MilestonePicker(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "v1.0")
+	assert.NotContains(t, rec.Body.String(), "<select")
+}
+
+func TestMilestonePickerComponent(t *testing.T) {
+	render := func(picker vm.MilestonePickerViewModel) string {
+		rec := httptest.NewRecorder()
+		require.NoError(t, components.MilestonePicker(picker).Render(t.Context(), rec))
+		return rec.Body.String()
+	}
+	open := []model.Milestone{{Number: 1, Title: "v1.0"}, {Number: 2, Title: "v1.1"}}
+
+	html := render(vm.MilestonePickerViewModel{Owner: "acme", RepoName: "api", Number: 7, Current: "v1.1", Milestones: open, Editable: true})
+	assert.Contains(t, html, `<option value="2" selected>v1.1</option>`)
+
+	html = render(vm.MilestonePickerViewModel{Owner: "acme", RepoName: "api", Number: 7, Current: "v0.9", Milestones: open, Editable: true})
+	assert.Contains(t, html, `<option value="" selected disabled>v0.9</option>`, "a closed milestone is shown but not offered")
+}
+
+func TestSetMilestone_InvalidValue(t *testing.T) {
+	req := preferenceRequest(http.MethodPost, "/app/prs/acme/api/7/milestone", "milestone=-1", nil)
+	req.SetPathValue("owner", "acme")
+	req.SetPathValue("repo", "api")
+	req.SetPathValue("number", "7")
+	rec := httptest.NewRecorder()
+
+	h := &Handler{logger: slog.Default()}
+	h.SetMilestone(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,169 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// maxNoteLen bounds private notes; they record context, not documents.
+const maxNoteLen = 4000
+
+// WithPRNoteStore enables private per-PR notes: the Notes tab of the detail
+// panel and matching notes in PR search. Without it the tab explains that
+// notes are unavailable and the routes return 503.
+func (h *Handler) WithPRNoteStore(store driven.PRNoteStore) *Handler {
+	h.noteStore = store
+	return h
+}
+
+// CreatePRNote handles POST /app/prs/{owner}/{repo}/{number}/notes.
+// It stores a private note and re-renders the Notes tab.
+func (h *Handler) CreatePRNote(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if h.noteStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	body := strings.TrimSpace(r.FormValue("body"))
+	if body == "" {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: note cannot be empty")
+		return
+	}
+	if utf8.RuneCountInString(body) > maxNoteLen {
+		writeErrorToast(w, http.StatusUnprocessableEntity, fmt.Sprintf("Error: note must be at most %d characters", maxNoteLen))
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	if _, err := h.noteStore.Add(r.Context(), model.PRNote{RepoFullName: repoFullName, PRNumber: number, Body: body}); err != nil {
+		h.logger.Error("failed to add note", "repo", repoFullName, "pr", number, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderNotes(w, r, owner, repo, number)
+}
+
+// DeletePRNote handles DELETE /app/prs/{owner}/{repo}/{number}/notes/{id}.
+// It removes a private note and re-renders the Notes tab.
+func (h *Handler) DeletePRNote(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid note ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.noteStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.noteStore.Delete(r.Context(), id); err != nil {
+		h.logger.Error("failed to delete note", "note", id, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderNotes(w, r, owner, repo, number)
+}
+
+// renderNotes renders the Notes tab of a PR after a change.
+func (h *Handler) renderNotes(w http.ResponseWriter, r *http.Request, owner, repo string, number int) {
+	notes := h.buildNotesVM(r.Context(), owner, repo, number)
+	if err := components.PRNotes(notes).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render notes", "error", err)
+	}
+}
+
+// applyNotes fills the Notes tab of a PR detail.
+func (h *Handler) applyNotes(ctx context.Context, detail *vm.PRDetailViewModel) {
+	detail.Notes = h.buildNotesVM(ctx, detail.Owner, detail.RepoName, detail.Number)
+}
+
+// buildNotesVM lists a PR's notes. Failures are logged and leave the list
+// empty; the add form stays usable.
+func (h *Handler) buildNotesVM(ctx context.Context, owner, repo string, number int) vm.PRNotesViewModel {
+	result := vm.PRNotesViewModel{
+		Owner:    owner,
+		RepoName: repo,
+		Number:   number,
+		Enabled:  h.noteStore != nil,
+	}
+	if h.noteStore == nil {
+		return result
+	}
+
+	notes, err := h.noteStore.ListByPR(ctx, owner+"/"+repo, number)
+	if err != nil {
+		h.logger.Error("failed to list notes", "repo", owner+"/"+repo, "pr", number, "error", err)
+		return result
+	}
+	for _, n := range notes {
+		result.Notes = append(result.Notes, vm.PRNoteViewModel{
+			ID:        n.ID,
+			Body:      n.Body,
+			CreatedAt: n.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+// appendNoteMatches adds to filtered the PRs whose private notes contain
+// query and that pass the status and repo filters, keeping dashboard order.
+// Without a note store, or when the search fails, filtered is returned as is.
+func (h *Handler) appendNoteMatches(ctx context.Context, filtered, prs []model.PullRequest, query, status, repo string) []model.PullRequest {
+	query = strings.TrimSpace(query)
+	if h.noteStore == nil || query == "" {
+		return filtered
+	}
+
+	repoFilter := repo
+	if repoFilter == "all" {
+		repoFilter = ""
+	}
+	notes, err := h.noteStore.Search(ctx, repoFilter, query)
+	if err != nil {
+		h.logger.Warn("failed to search notes", "error", err)
+		return filtered
+	}
+	if len(notes) == 0 {
+		return filtered
+	}
+
+	noted := make(map[string]bool, len(notes))
+	for _, n := range notes {
+		noted[fmt.Sprintf("%s#%d", n.RepoFullName, n.PRNumber)] = true
+	}
+	included := make(map[int64]bool, len(filtered))
+	for _, pr := range filtered {
+		included[pr.ID] = true
+	}
+
+	result := make([]model.PullRequest, 0, len(filtered))
+	for _, pr := range filterPRs(prs, "", status, repo) {
+		if included[pr.ID] || noted[fmt.Sprintf("%s#%d", pr.RepoFullName, pr.Number)] {
+			result = append(result, pr)
+		}
+	}
+	return result
+}
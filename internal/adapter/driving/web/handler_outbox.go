@@ -0,0 +1,150 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithOutboxService queues reviews and comments that fail with a transient
+// GitHub error instead of returning the error. Without it the outbox routes
+// return 503 and such failures are shown as before.
+func (h *Handler) WithOutboxService(svc *application.OutboxService) *Handler {
+	h.outboxSvc = svc
+	return h
+}
+
+// Outbox handles GET /app/outbox, the list of queued GitHub writes.
+func (h *Handler) Outbox(w http.ResponseWriter, r *http.Request) {
+	if h.outboxSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+	h.renderOutbox(w, r)
+}
+
+// RetryOutboundAction handles POST /app/outbox/{id}/retry. It sends the
+// action now and re-renders the outbox; a failure is recorded on the action.
+func (h *Handler) RetryOutboundAction(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseOutboxRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.outboxSvc.Retry(r.Context(), id); err != nil {
+		if errors.Is(err, application.ErrOutboundActionNotFound) {
+			http.Error(w, "queued action not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Warn("outbound action retry failed", "id", id, "error", err)
+	}
+	h.renderOutbox(w, r)
+}
+
+// DiscardOutboundAction handles DELETE /app/outbox/{id}.
+func (h *Handler) DiscardOutboundAction(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseOutboxRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.outboxSvc.Discard(r.Context(), id); err != nil {
+		h.logger.Error("failed to discard outbound action", "id", id, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	h.renderOutbox(w, r)
+}
+
+// parseOutboxRequest validates CSRF and the action ID for outbox writes. It
+// writes an error response and returns false on failure.
+func (h *Handler) parseOutboxRequest(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return 0, false
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid queued action ID", http.StatusBadRequest)
+		return 0, false
+	}
+
+	if h.outboxSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return 0, false
+	}
+	return id, true
+}
+
+// renderOutbox writes the outbox content for swap into #pr-detail.
+func (h *Handler) renderOutbox(w http.ResponseWriter, r *http.Request) {
+	actions, err := h.outboxSvc.List(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list outbound actions", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	var data vm.OutboxViewModel
+	data.Actions = make([]vm.OutboundActionViewModel, 0, len(actions))
+	for _, a := range actions {
+		action := toOutboundActionViewModel(a)
+		if action.Failed {
+			data.Failed++
+		} else {
+			data.Pending++
+		}
+		data.Actions = append(data.Actions, action)
+	}
+
+	if err := partials.OutboxContent(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render outbox", "error", err)
+	}
+}
+
+// applyOutbox lists the PR's queued writes on its detail view. Failures are
+// logged and leave the list empty.
+func (h *Handler) applyOutbox(ctx context.Context, detail *vm.PRDetailViewModel) {
+	if h.outboxSvc == nil {
+		return
+	}
+
+	actions, err := h.outboxSvc.ListForPR(ctx, detail.Repository, detail.Number)
+	if err != nil {
+		h.logger.Warn("failed to list outbound actions for PR", "repo", detail.Repository, "pr", detail.Number, "error", err)
+		return
+	}
+	for _, a := range actions {
+		detail.Outbox = append(detail.Outbox, toOutboundActionViewModel(a))
+	}
+}
+
+// queueOnTransient queues a write that failed with a transient GitHub error
+// and re-renders the reviews section, which lists it as queued. It reports
+// whether it handled the failure; otherwise the caller shows err as usual.
+func (h *Handler) queueOnTransient(w http.ResponseWriter, r *http.Request, err error, repoFullName string, number int, owner, repo string, enqueue func(ctx context.Context) (model.OutboundAction, error)) bool {
+	if h.outboxSvc == nil || !errors.Is(err, driven.ErrTransient) {
+		return false
+	}
+
+	action, qErr := enqueue(r.Context())
+	if qErr != nil {
+		h.logger.Error("failed to queue GitHub write", "repo", repoFullName, "pr", number, "error", qErr)
+		return false
+	}
+	h.logger.Info("queued GitHub write for retry", "id", action.ID, "kind", action.Kind, "repo", repoFullName, "pr", number, "error", err)
+
+	// Replies target their thread; the queued notice lives in the section.
+	w.Header().Set("HX-Retarget", "#pr-reviews-section")
+	w.Header().Set("HX-Reswap", "morph")
+	h.renderReviewsSectionForPR(w, r, repoFullName, number, owner, repo)
+	return true
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// The PR detail renders its header from stored PR data alone. The sections
+// that need enrichment load afterwards through these handlers, each
+// replacing its skeleton placeholder, so large PRs open at once.
+
+// PRDetailActivity handles GET /app/prs/{owner}/{repo}/{number}/activity.
+// It renders the Reviews, Threads, and Comments tabs, and updates the tab
+// counts, thread counts, and since-last-view banner out of band. Opening it
+// records the visit.
+func (h *Handler) PRDetailActivity(w http.ResponseWriter, r *http.Request) {
+	pr, ok := h.prForSection(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	summary := h.prReviewSummary(ctx, *pr)
+	checkRuns := h.prCheckRuns(ctx, *pr)
+
+	username := h.usernameForRepo(ctx, pr.RepoFullName)
+	detail := toPRDetailViewModel(*pr, summary, checkRuns, username)
+	h.applyDecisions(ctx, &detail)
+	h.applyOutbox(ctx, &detail)
+	h.applyReviewPolicy(ctx, &detail)
+	h.applySinceLastView(ctx, &detail, *pr, summary, checkRuns, username)
+	applyActionsAvailability(&detail, h.actionsDisabledReason(ctx, pr.RepoFullName, h.syncStatus()))
+
+	if err := components.PRDetailActivity(detail).Render(ctx, w); err != nil {
+		h.logger.Error("failed to render PR activity", "error", err)
+	}
+}
+
+// PRDetailChecks handles GET /app/prs/{owner}/{repo}/{number}/checks. It
+// renders the CI tab and updates its count out of band.
+func (h *Handler) PRDetailChecks(w http.ResponseWriter, r *http.Request) {
+	pr, ok := h.prForSection(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	detail := toPRDetailViewModel(*pr, nil, h.prCheckRuns(ctx, *pr), "")
+	if err := components.PRDetailChecks(detail).Render(ctx, w); err != nil {
+		h.logger.Error("failed to render PR checks", "error", err)
+	}
+}
+
+// PRDetailHealth handles GET /app/prs/{owner}/{repo}/{number}/health. It
+// renders the merge requirements checklist.
+func (h *Handler) PRDetailHealth(w http.ResponseWriter, r *http.Request) {
+	pr, ok := h.prForSection(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	summary := h.prReviewSummary(ctx, *pr)
+	detail := toPRDetailViewModel(*pr, nil, nil, "")
+	h.applyMergeRequirements(ctx, &detail, *pr, summary, h.prCheckRuns(ctx, *pr))
+	if err := components.PRDetailHealth(detail).Render(ctx, w); err != nil {
+		h.logger.Error("failed to render PR health", "error", err)
+	}
+}
+
+// prForSection loads the PR named by the request path for a detail section.
+// It writes the error response and returns false when there is none.
+func (h *Handler) prForSection(w http.ResponseWriter, r *http.Request) (*model.PullRequest, bool) {
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		http.Error(w, errMsgInvalidPRNumber, http.StatusBadRequest)
+		return nil, false
+	}
+
+	repoFullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number)
+	if err != nil {
+		h.logger.Error("failed to get PR", "repo", repoFullName, "number", number, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return nil, false
+	}
+	if pr == nil {
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return nil, false
+	}
+	return pr, true
+}
+
+// prReviewSummary returns the PR's reviews, threads, and comments. Failures
+// are logged and yield nil, so the section renders empty.
+func (h *Handler) prReviewSummary(ctx context.Context, pr model.PullRequest) *application.PRReviewSummary {
+	if h.reviewSvc == nil {
+		return nil
+	}
+	summary, err := h.reviewSvc.GetPRReviewSummary(ctx, pr.ID, pr.HeadSHA)
+	if err != nil {
+		h.logger.Error("failed to get review summary", "error", err)
+		return nil
+	}
+	return summary
+}
+
+// prCheckRuns returns the PR's stored check runs. Failures are logged and
+// yield none.
+func (h *Handler) prCheckRuns(ctx context.Context, pr model.PullRequest) []model.CheckRun {
+	if h.healthSvc == nil {
+		return nil
+	}
+	health, err := h.healthSvc.GetPRHealthSummary(ctx, pr.ID, pr.RepoFullName, pr.Number)
+	if err != nil {
+		h.logger.Error("failed to get health summary", "error", err)
+		return nil
+	}
+	if health == nil {
+		return nil
+	}
+	return health.CheckRuns
+}
@@ -0,0 +1,43 @@
+package web
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestPRDetailSections(t *testing.T) {
+	h := &Handler{logger: slog.Default(), prStore: singlePRStore{pr: model.PullRequest{ID: 1, RepoFullName: "acme/api", Number: 7}}}
+	get := func(handler http.HandlerFunc, number string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/app/prs/acme/api/"+number+"/section", nil)
+		req.SetPathValue("owner", "acme")
+		req.SetPathValue("repo", "api")
+		req.SetPathValue("number", number)
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec
+	}
+
+	rec := get(h.PRDetailActivity, "7")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `<div id="pr-activity">`, "replaces the placeholder without reloading")
+	assert.Contains(t, body, "No reviews yet")
+	assert.Contains(t, body, `<span id="pr-tab-count-threads" hx-swap-oob="true">(0)</span>`)
+	assert.Contains(t, body, `id="pr-since-last-view" hx-swap-oob="true"`)
+
+	rec = get(h.PRDetailChecks, "7")
+	assert.Contains(t, rec.Body.String(), "No CI checks")
+	assert.Contains(t, rec.Body.String(), `<span id="pr-tab-count-ci" hx-swap-oob="true">(0)</span>`)
+
+	rec = get(h.PRDetailHealth, "7")
+	assert.Equal(t, `<div id="pr-merge-requirements"></div>`, rec.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, get(h.PRDetailChecks, "8").Code)
+	assert.Equal(t, http.StatusBadRequest, get(h.PRDetailHealth, "x").Code)
+}
@@ -0,0 +1,162 @@
+package web
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// browserCookieName holds the anonymous ID a browser's preferences are saved
+// under. There are no user accounts, so the browser stands in for the user.
+const browserCookieName = "browser_id"
+
+// browserCookieMaxAge keeps the browser ID for a year; saving a preference
+// renews it.
+const browserCookieMaxAge = 365 * 24 * 60 * 60
+
+// Preference limits, so a misbehaving script cannot grow the table unbounded.
+const (
+	maxPreferenceValueLen    = 2048
+	maxPreferencesPerBrowser = 50
+)
+
+// Preference keys read by the server. The GUI saves others, such as collapsed
+// sections, through SavePreference.
+const (
+	prefFilters          = "filters"
+	prefDensity          = "density"
+	prefSidebarCollapsed = "sidebar.collapsed"
+)
+
+// preferenceKeyPattern limits keys to short dotted names like "sidebar.collapsed".
+var preferenceKeyPattern = regexp.MustCompile(`^[a-z0-9_.-]{1,64}$`)
+
+// WithBrowserPreferenceStore enables per-browser preferences: list filters,
+// density, and collapsed sections are restored on the next visit. Without it
+// the preference routes return 503 and the GUI starts from its defaults.
+func (h *Handler) WithBrowserPreferenceStore(store driven.BrowserPreferenceStore) *Handler {
+	h.prefStore = store
+	return h
+}
+
+// browserID returns the request's browser ID, or "" when it has none yet or
+// the cookie was not issued by this server.
+func browserID(r *http.Request) string {
+	cookie, err := r.Cookie(browserCookieName)
+	if err != nil || len(cookie.Value) != csrfTokenBytes*2 {
+		return ""
+	}
+	if _, err := hex.DecodeString(cookie.Value); err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// ensureBrowserID returns the request's browser ID, issuing one when absent,
+// and renews the cookie's expiry.
+func ensureBrowserID(w http.ResponseWriter, r *http.Request) string {
+	id := browserID(r)
+	if id == "" {
+		id = generateToken()
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     browserCookieName,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   browserCookieMaxAge,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return id
+}
+
+// preferences returns the browser's saved preferences. It returns nil when
+// preferences are disabled, the browser has none, or loading them failed;
+// failures are logged since the GUI works without them.
+func (h *Handler) preferences(r *http.Request) map[string]string {
+	id := browserID(r)
+	if h.prefStore == nil || id == "" {
+		return nil
+	}
+	prefs, err := h.prefStore.ListPreferences(r.Context(), id)
+	if err != nil {
+		h.logger.Warn("failed to load browser preferences", "error", err)
+		return nil
+	}
+	return prefs
+}
+
+// savePreference remembers a preference for the browser making r; an empty
+// value forgets it. Failures are logged and otherwise ignored.
+func (h *Handler) savePreference(w http.ResponseWriter, r *http.Request, key, value string) {
+	if h.prefStore == nil {
+		return
+	}
+	if value == "" && browserID(r) == "" {
+		return // nothing saved yet, so nothing to forget
+	}
+	if err := h.prefStore.SetPreference(r.Context(), ensureBrowserID(w, r), key, value); err != nil {
+		h.logger.Warn("failed to save browser preference", "key", key, "error", err)
+	}
+}
+
+// GetPreferences handles GET /app/preferences.
+// It returns the browser's saved preferences as a JSON object of strings.
+func (h *Handler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	if h.prefStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	prefs := h.preferences(r)
+	if prefs == nil {
+		prefs = map[string]string{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		h.logger.Error("failed to write browser preferences", "error", err)
+	}
+}
+
+// SavePreference handles POST /app/preferences.
+// The form's key and value are saved for the browser; an empty value clears
+// the key. It responds 204 No Content.
+func (h *Handler) SavePreference(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+	if h.prefStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	key, value := r.FormValue("key"), r.FormValue("value")
+	if !preferenceKeyPattern.MatchString(key) {
+		http.Error(w, "invalid preference key", http.StatusBadRequest)
+		return
+	}
+	if len(value) > maxPreferenceValueLen {
+		http.Error(w, "preference value too long", http.StatusBadRequest)
+		return
+	}
+	if value != "" {
+		prefs := h.preferences(r)
+		if _, exists := prefs[key]; !exists && len(prefs) >= maxPreferencesPerBrowser {
+			http.Error(w, "too many preferences", http.StatusBadRequest)
+			return
+		}
+	}
+
+	id := ensureBrowserID(w, r)
+	if err := h.prefStore.SetPreference(r.Context(), id, key, value); err != nil {
+		h.logger.Error("failed to save browser preference", "key", key, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
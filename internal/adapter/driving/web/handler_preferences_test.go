@@ -0,0 +1,121 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+)
+
+// memoryPreferenceStore is an in-memory driven.BrowserPreferenceStore.
+type memoryPreferenceStore struct {
+	prefs map[string]map[string]string
+}
+
+func (m *memoryPreferenceStore) ListPreferences(_ context.Context, browserID string) (map[string]string, error) {
+	out := make(map[string]string)
+	for k, v := range m.prefs[browserID] {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (m *memoryPreferenceStore) SetPreference(_ context.Context, browserID, key, value string) error {
+	if m.prefs[browserID] == nil {
+		m.prefs[browserID] = make(map[string]string)
+	}
+	if value == "" {
+		delete(m.prefs[browserID], key)
+		return nil
+	}
+	m.prefs[browserID][key] = value
+	return nil
+}
+
+func (m *memoryPreferenceStore) PrunePreferences(_ context.Context, _ time.Time) (int64, error) {
+	return 0, nil
+}
+
+// preferenceRequest builds a request carrying the browser and CSRF cookies.
+func preferenceRequest(method, target, body string, browser *http.Cookie) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	req.AddCookie(&http.Cookie{Name: csrfCookieName, Value: "tok"})
+	req.Header.Set("X-CSRF-Token", "tok")
+	if browser != nil {
+		req.AddCookie(browser)
+	}
+	return req
+}
+
+func TestSavePreference(t *testing.T) {
+	store := &memoryPreferenceStore{prefs: make(map[string]map[string]string)}
+	h := (&Handler{logger: slog.Default()}).WithBrowserPreferenceStore(store)
+
+	rec := httptest.NewRecorder()
+	h.SavePreference(rec, preferenceRequest(http.MethodPost, "/app/preferences", "key=sidebar.collapsed&value=true", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	browser := cookies[0]
+	assert.Equal(t, browserCookieName, browser.Name)
+	assert.Positive(t, browser.MaxAge, "the browser ID outlives the session")
+
+	rec = httptest.NewRecorder()
+	h.GetPreferences(rec, preferenceRequest(http.MethodGet, "/app/preferences", "", browser))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var prefs map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &prefs))
+	assert.Equal(t, map[string]string{"sidebar.collapsed": "true"}, prefs)
+
+	rec = httptest.NewRecorder()
+	h.GetPreferences(rec, preferenceRequest(http.MethodGet, "/app/preferences", "", nil))
+	assert.JSONEq(t, `{}`, rec.Body.String(), "another browser has its own preferences")
+
+	rec = httptest.NewRecorder()
+	h.SavePreference(rec, preferenceRequest(http.MethodPost, "/app/preferences", "key=Bad%20Key&value=1", browser))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/app/preferences", strings.NewReader("key=density&value=table"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec = httptest.NewRecorder()
+	h.SavePreference(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "CSRF token required")
+}
+
+func TestPreferencesRestoreListState(t *testing.T) {
+	store := &memoryPreferenceStore{prefs: make(map[string]map[string]string)}
+	h := (&Handler{logger: slog.Default()}).WithBrowserPreferenceStore(store)
+
+	rec := httptest.NewRecorder()
+	h.prListLayout(rec, preferenceRequest(http.MethodGet, "/app/prs/search?density=compact", "", nil))
+	var browser *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == browserCookieName {
+			browser = c
+		}
+	}
+	require.NotNil(t, browser)
+	store.prefs[browser.Value][prefFilters] = url.Values{"status": {"open"}, "q": {"flaky"}}.Encode()
+
+	// Without the session density cookie, the saved preference applies.
+	layout := h.prListLayout(httptest.NewRecorder(), preferenceRequest(http.MethodGet, "/app/prs/search", "", browser))
+	assert.Equal(t, vm.DensityCompact, layout.Density)
+
+	rec = httptest.NewRecorder()
+	h.Dashboard(rec, preferenceRequest(http.MethodGet, "/", "", browser))
+	assert.Equal(t, http.StatusSeeOther, rec.Code)
+	assert.Equal(t, "/?q=flaky&status=open", rec.Header().Get("Location"), "a bare visit restores the saved filters")
+}
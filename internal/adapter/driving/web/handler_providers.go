@@ -0,0 +1,87 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// providerWriter is the writer and login for repositories hosted outside
+// GitHub.
+type providerWriter struct {
+	writer   driven.GitHubWriter
+	username string
+}
+
+// WithSCMProvider sends writes for repositories recorded with provider to
+// writer, acting as username, the user's login there.
+func (h *Handler) WithSCMProvider(provider model.Provider, writer driven.GitHubWriter, username string) *Handler {
+	if h.providers == nil {
+		h.providers = make(map[model.Provider]providerWriter)
+	}
+	h.providers[provider] = providerWriter{writer: writer, username: username}
+	return h
+}
+
+// repoProvider returns where repoFullName is hosted, GitHub when it is not
+// tracked or the lookup fails.
+func (h *Handler) repoProvider(ctx context.Context, repoFullName string) model.Provider {
+	if h.repoStore == nil {
+		return model.ProviderGitHub
+	}
+	repo, err := h.repoStore.GetByFullName(ctx, repoFullName)
+	if err != nil {
+		h.logger.Warn("failed to get repository provider", "repo", repoFullName, "error", err)
+		return model.ProviderGitHub
+	}
+	if repo == nil || repo.Provider == "" {
+		return model.ProviderGitHub
+	}
+	return repo.Provider
+}
+
+// requireWriter returns the writer to act on repoFullName with: its
+// provider's for repositories hosted outside GitHub, otherwise one built from
+// the GitHub token (see requireGitHubToken). It writes an error fragment and
+// returns nil when none is configured.
+func (h *Handler) requireWriter(w http.ResponseWriter, r *http.Request, repoFullName, action string) driven.GitHubWriter {
+	if provider := h.repoProvider(r.Context(), repoFullName); provider != model.ProviderGitHub {
+		if p, ok := h.providers[provider]; ok {
+			return p.writer
+		}
+		writeErrorToast(w, http.StatusUnprocessableEntity, fmt.Sprintf("Configure %s credentials to %s.", provider, action))
+		return nil
+	}
+
+	token := h.requireGitHubToken(w, r, repoFullName, action)
+	if token == "" {
+		return nil
+	}
+	return h.writerFactory(token)
+}
+
+// writerFor returns the writer that would act on repoFullName, or nil when
+// none is configured. Unlike requireWriter it writes no response.
+func (h *Handler) writerFor(ctx context.Context, repoFullName string) driven.GitHubWriter {
+	if provider := h.repoProvider(ctx, repoFullName); provider != model.ProviderGitHub {
+		return h.providers[provider].writer
+	}
+	token := h.gitHubTokenFor(ctx, repoFullName)
+	if token == "" {
+		return nil
+	}
+	return h.writerFactory(token)
+}
+
+// providerUsername returns the user's login on repoFullName's provider, and
+// false for repositories hosted on GitHub.
+func (h *Handler) providerUsername(ctx context.Context, repoFullName string) (string, bool) {
+	if len(h.providers) == 0 {
+		return "", false
+	}
+	p, ok := h.providers[h.repoProvider(ctx, repoFullName)]
+	return p.username, ok
+}
@@ -0,0 +1,100 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithPRViewStore records when each PR detail is opened, so the next visit
+// shows a banner of what changed since and highlights new reviews and comments.
+func (h *Handler) WithPRViewStore(store driven.PRViewStore) *Handler {
+	h.prViewStore = store
+	return h
+}
+
+// applySinceLastView compares the PR against the user's previous visit, fills
+// in the detail's banner and new-item highlights, and records this visit.
+// Failures are logged; the detail then renders without highlights.
+func (h *Handler) applySinceLastView(ctx context.Context, detail *vm.PRDetailViewModel, pr model.PullRequest, summary *application.PRReviewSummary, checkRuns []model.CheckRun, username string) {
+	if h.prViewStore == nil {
+		return
+	}
+
+	previous, err := h.prViewStore.GetPRView(ctx, pr.ID)
+	if err != nil {
+		h.logger.Error("failed to get PR view", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+	view := model.PRView{PRID: pr.ID, ViewedAt: time.Now().UTC(), HeadSHA: pr.HeadSHA, CIStatus: pr.CIStatus}
+	if err := h.prViewStore.RecordPRView(ctx, view); err != nil {
+		h.logger.Error("failed to record PR view", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	}
+	if previous == nil {
+		return
+	}
+
+	changes := application.ChangesSinceView(*previous, pr, summary, checkRuns, username)
+	if changes.IsEmpty() {
+		return
+	}
+	detail.SinceLastView = toSinceLastViewViewModel(changes, pr)
+
+	for i := range detail.Reviews {
+		detail.Reviews[i].IsNew = changes.NewReviewIDs[detail.Reviews[i].ID]
+	}
+	for i := range detail.Threads {
+		thread := &detail.Threads[i]
+		thread.RootComment.IsNew = changes.NewReviewCommentIDs[thread.RootComment.ID]
+		for j := range thread.Replies {
+			thread.Replies[j].IsNew = changes.NewReviewCommentIDs[thread.Replies[j].ID]
+		}
+	}
+	for i := range detail.IssueComments {
+		detail.IssueComments[i].IsNew = changes.NewIssueCommentIDs[detail.IssueComments[i].ID]
+	}
+}
+
+// toSinceLastViewViewModel lists the changes in display order: commits,
+// reviews, comments, then CI.
+func toSinceLastViewViewModel(changes application.PRChanges, pr model.PullRequest) vm.SinceLastViewViewModel {
+	result := vm.SinceLastViewViewModel{LastViewedAt: changes.Since.UTC().Format(time.RFC3339)}
+
+	if changes.PreviousHeadSHA != "" {
+		result.Items = append(result.Items, fmt.Sprintf("New commits pushed (%s → %s)", shortSHA(changes.PreviousHeadSHA), shortSHA(pr.HeadSHA)))
+	}
+	if n := len(changes.NewReviewIDs); n > 0 {
+		result.Items = append(result.Items, countNoun(n, "new review", "new reviews"))
+	}
+	if n := len(changes.NewReviewCommentIDs) + len(changes.NewIssueCommentIDs); n > 0 {
+		result.Items = append(result.Items, countNoun(n, "new comment", "new comments"))
+	}
+	if changes.PreviousCIStatus != "" {
+		result.Items = append(result.Items, fmt.Sprintf("CI %s → %s", changes.PreviousCIStatus, pr.CIStatus))
+	}
+	if changes.CompletedChecks > 0 {
+		result.Items = append(result.Items, countNoun(changes.CompletedChecks, "check finished", "checks finished"))
+	}
+	return result
+}
+
+// countNoun formats n with the singular or plural noun, e.g. "1 new review".
+func countNoun(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// shortSHA abbreviates a commit SHA the way GitHub displays it.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,35 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToSinceLastViewViewModel(t *testing.T) {
+	since := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	pr := model.PullRequest{HeadSHA: "0123456789abcdef", CIStatus: model.CIStatusPassing}
+	changes := application.PRChanges{
+		Since:               since,
+		PreviousHeadSHA:     "fedcba9876543210",
+		PreviousCIStatus:    model.CIStatusFailing,
+		CompletedChecks:     3,
+		NewReviewIDs:        map[int64]bool{1: true},
+		NewReviewCommentIDs: map[int64]bool{2: true},
+		NewIssueCommentIDs:  map[int64]bool{3: true},
+	}
+
+	got := toSinceLastViewViewModel(changes, pr)
+	assert.Equal(t, "2026-10-01T09:00:00Z", got.LastViewedAt)
+	assert.Equal(t, []string{
+		"New commits pushed (fedcba9 → 0123456)",
+		"1 new review",
+		"2 new comments",
+		"CI failing → passing",
+		"3 checks finished",
+	}, got.Items)
+}
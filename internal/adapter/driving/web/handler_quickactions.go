@@ -0,0 +1,295 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithQuickActionStore enables choosing which quick actions appear on PR cards.
+// Without it cards show model.DefaultQuickActions() and the setting returns 503.
+func (h *Handler) WithQuickActionStore(store driven.QuickActionStore) *Handler {
+	h.quickActionStore = store
+	return h
+}
+
+// quickActions returns the enabled card quick actions, falling back to the
+// defaults when the preference is unavailable.
+func (h *Handler) quickActions(ctx context.Context) []model.QuickAction {
+	if h.quickActionStore == nil {
+		return model.DefaultQuickActions()
+	}
+	actions, err := h.quickActionStore.GetQuickActions(ctx)
+	if err != nil {
+		h.logger.Warn("failed to load quick actions, using defaults", "error", err)
+		return model.DefaultQuickActions()
+	}
+	return actions
+}
+
+// approveSettings returns the one-click approve settings, falling back to the
+// defaults when the preference is unavailable.
+func (h *Handler) approveSettings(ctx context.Context) model.ApproveSettings {
+	if h.quickActionStore == nil {
+		return model.DefaultApproveSettings()
+	}
+	settings, err := h.quickActionStore.GetApproveSettings(ctx)
+	if err != nil {
+		h.logger.Warn("failed to load approve settings, using defaults", "error", err)
+		return model.DefaultApproveSettings()
+	}
+	return settings
+}
+
+// cardQuickActions narrows the enabled actions to those that make sense for
+// pr: approving and requesting changes are only offered on other people's open PRs, and actions whose
+// backing service is not wired are dropped.
+func (h *Handler) cardQuickActions(enabled []model.QuickAction, pr model.PullRequest, user string) []model.QuickAction {
+	actions := make([]model.QuickAction, 0, len(enabled))
+	for _, a := range enabled {
+		switch a {
+		case model.QuickActionApprove, model.QuickActionRequestChanges:
+			if pr.Status != model.PRStatusOpen || strings.EqualFold(pr.Author, user) || h.writerFactory == nil {
+				continue
+			}
+		case model.QuickActionRefresh:
+			if h.pollSvc == nil {
+				continue
+			}
+		case model.QuickActionIgnore:
+			if h.ignoreStore == nil {
+				continue
+			}
+		case model.QuickActionOpenGitHub, model.QuickActionCopyBranch, model.QuickActionCopyCheckout:
+		}
+		actions = append(actions, a)
+	}
+	return actions
+}
+
+// applyApprove offers the header approve button when the card action would
+// be offered for pr.
+func (h *Handler) applyApprove(ctx context.Context, detail *vm.PRDetailViewModel, pr model.PullRequest) {
+	detail.CanApprove = !detail.IsOwnPR && pr.Status == model.PRStatusOpen && h.writerFactory != nil
+	detail.ApproveConfirm = h.approveSettings(ctx).Confirm
+}
+
+// SaveQuickActions handles POST /app/settings/quick-actions.
+// It stores the checked "actions" and refreshes the PR list so cards pick them up.
+func (h *Handler) SaveQuickActions(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.quickActionStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	var actions []model.QuickAction
+	for _, v := range r.Form["actions"] {
+		actions = append(actions, model.QuickAction(v))
+	}
+
+	if err := h.quickActionStore.SetQuickActions(r.Context(), actions); err != nil {
+		h.logger.Error("failed to save quick actions", "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to save quick actions</span>`)
+		return
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-sm">Quick actions saved</span>`)
+	h.renderPRListOOB(w, r)
+}
+
+// SaveApproveSettings handles POST /app/settings/approve.
+// It stores the canned "message" and whether approving asks for confirmation
+// ("confirm" checkbox), then refreshes the PR list so cards pick them up.
+func (h *Handler) SaveApproveSettings(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.quickActionStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	settings := model.ApproveSettings{
+		Message: r.FormValue("message"),
+		Confirm: r.FormValue("confirm") == "on",
+	}
+	if err := h.quickActionStore.SetApproveSettings(r.Context(), settings); err != nil {
+		h.logger.Error("failed to save approve settings", "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to save approve settings</span>`)
+		return
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-sm">Approve settings saved</span>`)
+	h.renderPRListOOB(w, r)
+}
+
+// RefreshPRCard handles POST /app/prs/{owner}/{repo}/{number}/refresh.
+// It re-fetches the PR with its reviews and checks and returns an OOB swap of
+// the PR list. Errors are plain text so the card can show them in an alert.
+func (h *Handler) RefreshPRCard(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if h.pollSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	if err := h.pollSvc.RefreshPR(r.Context(), repoFullName, number); err != nil {
+		h.logger.Error("failed to refresh PR", "repo", repoFullName, "pr", number, "error", err)
+		http.Error(w, "Refresh failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	h.renderPRListOOB(w, r)
+}
+
+// ApprovePRCard handles POST /app/prs/{owner}/{repo}/{number}/approve.
+// It submits an approving review with the canned approve message, if any,
+// against the stored head SHA and returns an OOB swap of the PR list. The
+// user's own PRs are refused, since GitHub does not allow self-approval.
+// Errors are plain text so the card can show them in an alert.
+func (h *Handler) ApprovePRCard(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "approve pull requests")
+	if writer == nil {
+		return
+	}
+
+	req := driven.ReviewRequest{Event: "APPROVE", Body: h.approveSettings(r.Context()).Message}
+	if pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number); err == nil && pr != nil {
+		if strings.EqualFold(pr.Author, h.usernameForRepo(r.Context(), repoFullName)) {
+			http.Error(w, "You cannot approve your own pull request.", http.StatusUnprocessableEntity)
+			return
+		}
+		req.CommitID = pr.HeadSHA
+	}
+
+	if err := writer.SubmitReview(r.Context(), repoFullName, number, req); err != nil {
+		h.logger.Error("failed to approve PR", "repo", repoFullName, "pr", number, "error", err)
+		http.Error(w, "Approve failed: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.renderPRListOOB(w, r)
+}
+
+// RequestChangesDialog handles GET /app/prs/{owner}/{repo}/{number}/request-changes.
+// It renders the request-changes dialog into #quick-action-modal, offering
+// the saved review templates with their variables filled in for the PR.
+func (h *Handler) RequestChangesDialog(w http.ResponseWriter, r *http.Request) {
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		http.Error(w, errMsgInvalidPRNumber, http.StatusBadRequest)
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number)
+	if err != nil {
+		h.logger.Error("failed to get PR for request changes", "repo", repoFullName, "pr", number, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if pr == nil {
+		http.Error(w, "PR not found", http.StatusNotFound)
+		return
+	}
+
+	data := vm.RequestChangesViewModel{Owner: owner, Repo: repo, Number: number, Title: pr.Title}
+	if h.replyTemplateSvc != nil {
+		templates, err := h.replyTemplateSvc.List(r.Context())
+		if err != nil {
+			h.logger.Warn("failed to list review templates", "repo", repoFullName, "pr", number, "error", err)
+		}
+		username := h.usernameForRepo(r.Context(), repoFullName)
+		for _, tpl := range templates {
+			if tpl.Kind != model.ReplyTemplateReview {
+				continue
+			}
+			data.Templates = append(data.Templates, vm.ReplyTemplateViewModel{
+				ID:   tpl.ID,
+				Name: tpl.Name,
+				Body: application.ExpandReplyTemplate(tpl.Body, *pr, username),
+			})
+		}
+	}
+
+	if err := components.RequestChangesDialog(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render request changes dialog", "error", err)
+	}
+}
+
+// RequestChangesPRCard handles POST /app/prs/{owner}/{repo}/{number}/request-changes.
+// It submits a review requesting changes with the form's "body" against the
+// stored head SHA, closes the dialog, and returns an OOB swap of the PR list.
+// Errors are plain text so the dialog can show them in an alert.
+func (h *Handler) RequestChangesPRCard(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	body := strings.TrimSpace(r.FormValue("body"))
+	if body == "" {
+		http.Error(w, "Explain the changes you are requesting.", http.StatusUnprocessableEntity)
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "request changes")
+	if writer == nil {
+		return
+	}
+
+	req := driven.ReviewRequest{Event: "REQUEST_CHANGES", Body: body}
+	if pr, err := h.prStore.GetByNumber(r.Context(), repoFullName, number); err == nil && pr != nil {
+		if strings.EqualFold(pr.Author, h.usernameForRepo(r.Context(), repoFullName)) {
+			http.Error(w, "You cannot request changes on your own pull request.", http.StatusUnprocessableEntity)
+			return
+		}
+		req.CommitID = pr.HeadSHA
+	}
+
+	if err := writer.SubmitReview(r.Context(), repoFullName, number, req); err != nil {
+		h.logger.Error("failed to request changes", "repo", repoFullName, "pr", number, "error", err)
+		http.Error(w, "Request changes failed: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.renderPRListOOB(w, r)
+}
@@ -0,0 +1,142 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memoryQuickActionStore is an in-memory driven.QuickActionStore.
+type memoryQuickActionStore struct {
+	actions []model.QuickAction
+	approve model.ApproveSettings
+}
+
+func (m *memoryQuickActionStore) GetQuickActions(context.Context) ([]model.QuickAction, error) {
+	return m.actions, nil
+}
+
+func (m *memoryQuickActionStore) SetQuickActions(_ context.Context, actions []model.QuickAction) error {
+	m.actions = actions
+	return nil
+}
+
+func (m *memoryQuickActionStore) GetApproveSettings(context.Context) (model.ApproveSettings, error) {
+	return m.approve, nil
+}
+
+func (m *memoryQuickActionStore) SetApproveSettings(_ context.Context, settings model.ApproveSettings) error {
+	m.approve = settings
+	return nil
+}
+
+func TestSaveApproveSettings(t *testing.T) {
+	post := func(h *Handler, body string) *httptest.ResponseRecorder {
+		req := preferenceRequest(http.MethodPost, "/app/settings/approve", body, nil)
+		rec := httptest.NewRecorder()
+		h.SaveApproveSettings(rec, req)
+		return rec
+	}
+
+	h := &Handler{logger: slog.Default(), prStore: emptyPRStore{}}
+	assert.Equal(t, http.StatusServiceUnavailable, post(h, "message=LGTM").Code)
+	assert.Equal(t, model.DefaultApproveSettings(), h.approveSettings(context.Background()))
+
+	store := &memoryQuickActionStore{}
+	h.WithQuickActionStore(store)
+
+	rec := post(h, "message=LGTM&confirm=on")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Approve settings saved")
+	assert.Equal(t, model.ApproveSettings{Message: "LGTM", Confirm: true}, store.approve)
+
+	post(h, "message=")
+	assert.Equal(t, model.ApproveSettings{}, store.approve, "an unchecked box approves without asking")
+}
+
+func TestCardQuickActions_ApproveSkipsOwnPRs(t *testing.T) {
+	h := &Handler{}
+	enabled := []model.QuickAction{model.QuickActionApprove}
+	pr := model.PullRequest{Author: "Octocat", Status: model.PRStatusOpen}
+	assert.Empty(t, h.cardQuickActions(enabled, pr, "octocat"), "no writer")
+
+	h.writerFactory = func(string) driven.GitHubWriter { return nil }
+	assert.Empty(t, h.cardQuickActions(enabled, pr, "octocat"), "own PR, matched case-insensitively")
+	assert.Equal(t, enabled, h.cardQuickActions(enabled, pr, "carol"))
+}
+
+// listedReplyTemplates is a driven.ReplyTemplateStore serving fixed templates.
+type listedReplyTemplates struct {
+	driven.ReplyTemplateStore
+	templates []model.ReplyTemplate
+}
+
+func (s listedReplyTemplates) List(context.Context) ([]model.ReplyTemplate, error) {
+	return s.templates, nil
+}
+
+// singlePRStore is a driven.PRStore holding one PR, found by number.
+type singlePRStore struct {
+	emptyPRStore
+	pr model.PullRequest
+}
+
+func (s singlePRStore) GetByNumber(_ context.Context, repoFullName string, number int) (*model.PullRequest, error) {
+	if repoFullName != s.pr.RepoFullName || number != s.pr.Number {
+		return nil, nil
+	}
+	return &s.pr, nil
+}
+
+func TestRequestChangesDialog(t *testing.T) {
+	get := func(h *Handler, number string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/app/prs/acme/api/"+number+"/request-changes", nil)
+		req.SetPathValue("owner", "acme")
+		req.SetPathValue("repo", "api")
+		req.SetPathValue("number", number)
+		rec := httptest.NewRecorder()
+		h.RequestChangesDialog(rec, req)
+		return rec
+	}
+
+	h := &Handler{
+		logger:  slog.Default(),
+		prStore: singlePRStore{pr: model.PullRequest{RepoFullName: "acme/api", Number: 7, Title: "Bump deps", Author: "carol"}},
+	}
+	h.WithReplyTemplateService(application.NewReplyTemplateService(listedReplyTemplates{templates: []model.ReplyTemplate{
+		{ID: 1, Name: "Needs tests", Kind: model.ReplyTemplateReview, Body: "@{{author}} please add tests"},
+		{ID: 2, Name: "Thanks", Kind: model.ReplyTemplateSnippet, Body: "Thanks!"},
+	}}))
+
+	assert.Equal(t, http.StatusBadRequest, get(h, "x").Code)
+	assert.Equal(t, http.StatusNotFound, get(h, "8").Code)
+
+	rec := get(h, "7")
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "/app/prs/acme/api/7/request-changes")
+	assert.Contains(t, body, "@carol please add tests", "review templates are filled in for the PR")
+	assert.NotContains(t, body, "Thanks!", "snippets are not offered")
+}
+
+func TestRequestChangesPRCard_RequiresBody(t *testing.T) {
+	req := preferenceRequest(http.MethodPost, "/app/prs/acme/api/7/request-changes", "body=++", nil)
+	req.SetPathValue("owner", "acme")
+	req.SetPathValue("repo", "api")
+	req.SetPathValue("number", "7")
+	rec := httptest.NewRecorder()
+
+	h := &Handler{logger: slog.Default()}
+	h.RequestChangesPRCard(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
@@ -0,0 +1,154 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// replyTemplateKindLabels are the settings drawer names of each template kind.
+var replyTemplateKindLabels = map[model.ReplyTemplateKind]string{
+	model.ReplyTemplateSnippet: "Snippet",
+	model.ReplyTemplateReview:  "Review",
+}
+
+// WithReplyTemplateService enables saved reply snippets and review templates:
+// the settings drawer section and the quick-insert menus in the reply and
+// review composers. Without it both are hidden and the routes return 503.
+func (h *Handler) WithReplyTemplateService(svc *application.ReplyTemplateService) *Handler {
+	h.replyTemplateSvc = svc
+	return h
+}
+
+// CreateReplyTemplate handles POST /app/settings/reply-templates.
+// It stores the template and returns the updated template list.
+func (h *Handler) CreateReplyTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.replyTemplateSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	kind := model.ReplyTemplateKind(r.FormValue("template_kind"))
+	_, err := h.replyTemplateSvc.Create(r.Context(), r.FormValue("template_name"), kind, r.FormValue("template_body"))
+	switch {
+	case errors.Is(err, application.ErrInvalidReplyTemplateName),
+		errors.Is(err, application.ErrInvalidReplyTemplateKind),
+		errors.Is(err, application.ErrEmptyReplyTemplateBody):
+		writeErrorToast(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create reply template", "kind", kind, "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: failed to save template")
+		return
+	}
+
+	h.renderReplyTemplateList(w, r)
+}
+
+// DeleteReplyTemplate handles DELETE /app/settings/reply-templates/{id}.
+func (h *Handler) DeleteReplyTemplate(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid template ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.replyTemplateSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.replyTemplateSvc.Delete(r.Context(), id); err != nil {
+		h.logger.Error("failed to delete reply template", "id", id, "error", err)
+		http.Error(w, "failed to delete template", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderReplyTemplateList(w, r)
+}
+
+// renderReplyTemplateList renders the template list fragment for the settings drawer.
+func (h *Handler) renderReplyTemplateList(w http.ResponseWriter, r *http.Request) {
+	if err := components.ReplyTemplateList(h.replyTemplateSettings(r.Context()).Templates).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render reply template list", "error", err)
+	}
+}
+
+// replyTemplateSettings builds the settings drawer's template section.
+// Failures are logged and yield no templates.
+func (h *Handler) replyTemplateSettings(ctx context.Context) vm.ReplyTemplateSettingsViewModel {
+	if h.replyTemplateSvc == nil {
+		return vm.ReplyTemplateSettingsViewModel{}
+	}
+
+	settings := vm.ReplyTemplateSettingsViewModel{Enabled: true}
+	templates, err := h.replyTemplateSvc.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list reply templates", "error", err)
+		return settings
+	}
+	for _, tpl := range templates {
+		settings.Templates = append(settings.Templates, vm.ReplyTemplateViewModel{
+			ID:        tpl.ID,
+			Name:      tpl.Name,
+			KindLabel: replyTemplateKindLabels[tpl.Kind],
+			Body:      tpl.Body,
+		})
+	}
+	return settings
+}
+
+// applyReplyTemplates offers the saved templates in the PR detail's review
+// composer and reply boxes, with their variables filled in for pr. Failures
+// are logged and leave the menus empty.
+func (h *Handler) applyReplyTemplates(ctx context.Context, detail *vm.PRDetailViewModel, pr model.PullRequest, username string) {
+	if h.replyTemplateSvc == nil {
+		return
+	}
+
+	templates, err := h.replyTemplateSvc.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list reply templates", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+
+	var snippets []vm.ReplyTemplateViewModel
+	for _, tpl := range templates {
+		option := vm.ReplyTemplateViewModel{
+			ID:        tpl.ID,
+			Name:      tpl.Name,
+			KindLabel: replyTemplateKindLabels[tpl.Kind],
+			Body:      application.ExpandReplyTemplate(tpl.Body, pr, username),
+		}
+		switch tpl.Kind {
+		case model.ReplyTemplateReview:
+			detail.ReviewTemplates = append(detail.ReviewTemplates, option)
+		case model.ReplyTemplateSnippet:
+			snippets = append(snippets, option)
+		}
+	}
+	for i := range detail.Threads {
+		detail.Threads[i].ReplySnippets = snippets
+	}
+}
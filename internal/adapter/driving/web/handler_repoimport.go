@@ -0,0 +1,93 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// maxRepoImportBytes bounds the form, including an uploaded list, of a bulk
+// repo import.
+const maxRepoImportBytes = 1 << 20
+
+// WithRepoImportService enables the bulk repo import form. Without it the
+// import returns 503.
+func (h *Handler) WithRepoImportService(svc *application.RepoImportService) *Handler {
+	h.repoImportSvc = svc
+	return h
+}
+
+// ImportRepos handles POST /app/repos/import.
+// It combines the pasted "repos" list, an uploaded "file", and the
+// repositories of "team", adds them in one transaction, and reports the
+// outcome per repo. The repo and PR lists are refreshed via OOB swaps.
+func (h *Handler) ImportRepos(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRepoImportBytes)
+	if err := r.ParseMultipartForm(maxRepoImportBytes); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.repoImportSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	names := application.ParseRepoList(r.FormValue("repos"))
+	if file, _, err := r.FormFile("file"); err == nil {
+		text, err := io.ReadAll(file)
+		_ = file.Close()
+		if err != nil {
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: could not read the uploaded file</span>`)
+			return
+		}
+		names = append(names, application.ParseRepoList(string(text))...)
+	}
+	if team := r.FormValue("team"); team != "" {
+		teamRepos, err := h.repoImportSvc.TeamRepos(r.Context(), team)
+		if err != nil {
+			h.logger.Warn("failed to list team repos for import", "team", team, "error", err)
+			fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: %s</span>`, html.EscapeString(teamImportError(err)))
+			return
+		}
+		names = append(names, teamRepos...)
+	}
+	if len(names) == 0 {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: no repositories to import</span>`)
+		return
+	}
+
+	results, err := h.repoImportSvc.Import(r.Context(), names)
+	if err != nil {
+		h.logger.Error("failed to import repos", "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: import failed; no repos were added</span>`)
+		return
+	}
+
+	if err := components.RepoImportResults(results).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render repo import results", "error", err)
+		return
+	}
+	h.renderRepoListOOB(w, r)
+	h.renderPRListOOB(w, r)
+}
+
+// teamImportError describes a failed team lookup for the import form.
+func teamImportError(err error) string {
+	switch {
+	case errors.Is(err, application.ErrInvalidTeam), errors.Is(err, application.ErrTeamImportUnavailable):
+		return err.Error()
+	default:
+		return "could not list the team's repositories on GitHub"
+	}
+}
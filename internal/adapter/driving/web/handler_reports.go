@@ -0,0 +1,226 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithReportService enables managing scheduled reports from the settings
+// drawer. Without it the section stays empty and the routes return 503.
+func (h *Handler) WithReportService(svc *application.ReportService) *Handler {
+	h.reportSvc = svc
+	return h
+}
+
+// ScheduledReports handles GET /app/settings/reports. It renders the report
+// list and add form, or nothing when scheduled reports are unavailable.
+func (h *Handler) ScheduledReports(w http.ResponseWriter, r *http.Request) {
+	if h.reportSvc == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	settings := vm.ScheduledReportSettingsViewModel{
+		Reports:         h.scheduledReportViewModels(r.Context()),
+		DefaultTemplate: application.DefaultReportTemplate,
+	}
+	if h.groupStore != nil {
+		groups, err := h.groupStore.List(r.Context())
+		if err != nil {
+			h.logger.Warn("failed to list repo groups", "error", err)
+		}
+		for _, g := range groups {
+			settings.Groups = append(settings.Groups, vm.RepoGroupOptionViewModel{ID: g.ID, Name: g.Name})
+		}
+	}
+	if err := components.ScheduledReportSection(settings).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render scheduled reports", "error", err)
+	}
+}
+
+// CreateScheduledReport handles POST /app/settings/reports with form values
+// name, group_id (blank for all repositories), destination, target, weekday
+// (0 for Sunday), hour (UTC), and template (blank for the default). It
+// returns the updated report list.
+func (h *Handler) CreateScheduledReport(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.reportSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	report, ok := scheduledReportFromForm(r)
+	if !ok {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid schedule")
+		return
+	}
+	_, err := h.reportSvc.Create(r.Context(), report)
+	switch {
+	case errors.Is(err, application.ErrInvalidScheduledReport):
+		writeErrorToast(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create scheduled report", "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: failed to save report")
+		return
+	}
+
+	h.renderScheduledReportList(w, r)
+}
+
+// PreviewScheduledReport handles POST /app/settings/reports/preview with the
+// add form's values. It renders the report as it would be posted now.
+func (h *Handler) PreviewScheduledReport(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.reportSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	report, _ := scheduledReportFromForm(r)
+	text, err := h.reportSvc.Preview(r.Context(), report)
+	switch {
+	case errors.Is(err, application.ErrInvalidScheduledReport):
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">%s</span>`, html.EscapeString(err.Error()))
+		return
+	case err != nil:
+		h.logger.Error("failed to preview scheduled report", "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-sm">Error: failed to generate report</span>`)
+		return
+	}
+
+	if err := components.ScheduledReportPreview(text).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render report preview", "error", err)
+	}
+}
+
+// DeleteScheduledReport handles DELETE /app/settings/reports/{id}.
+func (h *Handler) DeleteScheduledReport(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid report ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.reportSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.reportSvc.Delete(r.Context(), id); err != nil && !errors.Is(err, driven.ErrScheduledReportNotFound) {
+		h.logger.Error("failed to delete scheduled report", "id", id, "error", err)
+		http.Error(w, "failed to delete report", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderScheduledReportList(w, r)
+}
+
+// scheduledReportFromForm reads a report from the add form. ok is false when
+// the group, weekday, or hour is not a number.
+func scheduledReportFromForm(r *http.Request) (report model.ScheduledReport, ok bool) {
+	report = model.ScheduledReport{
+		Name:        r.FormValue("name"),
+		Destination: model.ReportDestination(r.FormValue("destination")),
+		Target:      r.FormValue("target"),
+		Template:    r.FormValue("template"),
+	}
+	ok = true
+	if v := r.FormValue("group_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		ok = ok && err == nil
+		report.GroupID = id
+	}
+	weekday, err := strconv.Atoi(r.FormValue("weekday"))
+	ok = ok && err == nil
+	report.Weekday = time.Weekday(weekday)
+	report.Hour, err = strconv.Atoi(r.FormValue("hour"))
+	ok = ok && err == nil
+	return report, ok
+}
+
+// renderScheduledReportList renders the report list fragment for the
+// settings drawer.
+func (h *Handler) renderScheduledReportList(w http.ResponseWriter, r *http.Request) {
+	if err := components.ScheduledReportList(h.scheduledReportViewModels(r.Context())).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render scheduled report list", "error", err)
+	}
+}
+
+// scheduledReportViewModels lists the reports for the settings drawer.
+// Failures are logged and yield no reports.
+func (h *Handler) scheduledReportViewModels(ctx context.Context) []vm.ScheduledReportViewModel {
+	reports, err := h.reportSvc.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list scheduled reports", "error", err)
+		return nil
+	}
+
+	groupNames := make(map[int64]string)
+	if h.groupStore != nil {
+		groups, err := h.groupStore.List(ctx)
+		if err != nil {
+			h.logger.Warn("failed to list repo groups", "error", err)
+		}
+		for _, g := range groups {
+			groupNames[g.ID] = g.Name
+		}
+	}
+
+	result := make([]vm.ScheduledReportViewModel, 0, len(reports))
+	for _, report := range reports {
+		item := vm.ScheduledReportViewModel{
+			ID:          report.ID,
+			Name:        report.Name,
+			Scope:       "All repositories",
+			Destination: "Slack",
+			Target:      report.Target,
+			Schedule:    fmt.Sprintf("%ss at %02d:00 UTC", report.Weekday, report.Hour),
+		}
+		if report.GroupID != 0 {
+			item.Scope = groupNames[report.GroupID]
+		}
+		if report.Destination == model.ReportToGitHubIssue {
+			item.Destination = "GitHub issue"
+		}
+		if !report.LastSentAt.IsZero() {
+			item.LastSent = report.LastSentAt.UTC().Format(apiTokenTimeLayout)
+		}
+		result = append(result, item)
+	}
+	return result
+}
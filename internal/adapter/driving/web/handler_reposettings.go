@@ -0,0 +1,259 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithRepoSettingsStore enables per-repository settings such as the check
+// overrides in the repo popover. Without it the form is hidden and the route
+// returns 503.
+func (h *Handler) WithRepoSettingsStore(store driven.RepoSettingsStore) *Handler {
+	h.repoSettingsStore = store
+	return h
+}
+
+// WithRelevantPRsOnly tells the repo settings form that repositories left at
+// the default PR scope store only relevant PRs, matching the poller.
+func (h *Handler) WithRelevantPRsOnly(on bool) *Handler {
+	h.relevantPRsOnly = on
+	return h
+}
+
+// SaveRepoCheckOverrides handles POST /app/settings/repo-checks with form
+// values repo_full_name, required_checks, optional_checks, and
+// ignored_checks, the check lists separated by commas or newlines. They apply
+// from each PR's next sync.
+func (h *Handler) SaveRepoCheckOverrides(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.repoSettingsStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFullName := strings.TrimSpace(r.FormValue("repo_full_name"))
+	if repoFullName == "" {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: repo name required</span>`)
+		return
+	}
+
+	ctx := r.Context()
+	settings, err := h.repoSettingsStore.GetRepoSettings(ctx, repoFullName)
+	if err != nil {
+		h.logger.Error("failed to get repo settings", "repo", repoFullName, "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: failed to save settings</span>`)
+		return
+	}
+	settings.RequiredChecks = parseCheckNames(r.FormValue("required_checks"))
+	settings.OptionalChecks = parseCheckNames(r.FormValue("optional_checks"))
+	settings.IgnoredChecks = parseCheckNames(r.FormValue("ignored_checks"))
+	for _, name := range settings.RequiredChecks {
+		if slices.ContainsFunc(settings.OptionalChecks, func(o string) bool { return strings.EqualFold(o, name) }) {
+			fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: %s cannot be both required and optional</span>`, html.EscapeString(name))
+			return
+		}
+	}
+
+	if err := h.repoSettingsStore.SetRepoSettings(ctx, settings); err != nil {
+		if errors.Is(err, driven.ErrRepoNotFound) {
+			fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: repository is not watched</span>`)
+			return
+		}
+		h.logger.Error("failed to save repo check overrides", "repo", repoFullName, "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: failed to save settings</span>`)
+		return
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-xs">Saved; applies as PRs next sync</span>`)
+}
+
+// SaveRepoStalePolicy handles POST /app/settings/repo-stale with form values
+// repo_full_name, stale_after_days (blank or 0 turns the labeler off),
+// stale_action ("label" or "comment"), stale_label, stale_exempt_labels
+// separated by commas or newlines, and stale_dry_run set to "on".
+func (h *Handler) SaveRepoStalePolicy(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.repoSettingsStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFullName := strings.TrimSpace(r.FormValue("repo_full_name"))
+	if repoFullName == "" {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: repo name required</span>`)
+		return
+	}
+
+	policy := model.StalePolicy{
+		Action:       model.StaleAction(r.FormValue("stale_action")),
+		Label:        strings.TrimSpace(r.FormValue("stale_label")),
+		DryRun:       r.FormValue("stale_dry_run") == "on",
+		ExemptLabels: parseCheckNames(r.FormValue("stale_exempt_labels")),
+	}
+	if v := strings.TrimSpace(r.FormValue("stale_after_days")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: days must be a non-negative integer</span>`)
+			return
+		}
+		policy.AfterDays = n
+	}
+	if !policy.Action.IsValid() {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: unknown stale action</span>`)
+		return
+	}
+
+	ctx := r.Context()
+	settings, err := h.repoSettingsStore.GetRepoSettings(ctx, repoFullName)
+	if err != nil {
+		h.logger.Error("failed to get repo settings", "repo", repoFullName, "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: failed to save settings</span>`)
+		return
+	}
+	settings.Stale = policy
+
+	if err := h.repoSettingsStore.SetRepoSettings(ctx, settings); err != nil {
+		if errors.Is(err, driven.ErrRepoNotFound) {
+			fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: repository is not watched</span>`)
+			return
+		}
+		h.logger.Error("failed to save repo stale policy", "repo", repoFullName, "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: failed to save settings</span>`)
+		return
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-xs">Saved</span>`)
+}
+
+// SaveRepoPRScope handles POST /app/settings/repo-pr-scope with form values
+// repo_full_name and pr_scope: "all", "relevant", or blank for the default.
+// It applies from the repository's next poll; PRs already stored are kept.
+func (h *Handler) SaveRepoPRScope(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: invalid form data</span>`)
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.repoSettingsStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	repoFullName := strings.TrimSpace(r.FormValue("repo_full_name"))
+	if repoFullName == "" {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: repo name required</span>`)
+		return
+	}
+	scope := model.PRScope(r.FormValue("pr_scope"))
+	if !scope.IsValid() {
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: unknown PR scope</span>`)
+		return
+	}
+
+	ctx := r.Context()
+	settings, err := h.repoSettingsStore.GetRepoSettings(ctx, repoFullName)
+	if err != nil {
+		h.logger.Error("failed to get repo settings", "repo", repoFullName, "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: failed to save settings</span>`)
+		return
+	}
+	settings.PRScope = scope
+
+	if err := h.repoSettingsStore.SetRepoSettings(ctx, settings); err != nil {
+		if errors.Is(err, driven.ErrRepoNotFound) {
+			fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: repository is not watched</span>`)
+			return
+		}
+		h.logger.Error("failed to save repo PR scope", "repo", repoFullName, "error", err)
+		fmt.Fprintf(w, `<span class="text-red-600 text-xs">Error: failed to save settings</span>`)
+		return
+	}
+
+	fmt.Fprintf(w, `<span class="text-green-600 text-xs">Saved; applies from the next poll</span>`)
+}
+
+// parseCheckNames splits a list of check or label names on commas and
+// newlines, dropping blanks and case-insensitive duplicates.
+func parseCheckNames(raw string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// applyRepoSettings fills in the repo view models' saved check overrides and
+// stale policy and offers their forms when repo settings are configured.
+// Failures are logged and show the lists empty.
+func (h *Handler) applyRepoSettings(ctx context.Context, repos []vm.RepoViewModel) {
+	if h.repoSettingsStore == nil || len(repos) == 0 {
+		return
+	}
+
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.FullName
+	}
+	settings, err := h.repoSettingsStore.ListRepoSettings(ctx, names)
+	if err != nil {
+		h.logger.Warn("failed to list repo settings", "error", err)
+	}
+
+	for i := range repos {
+		repos[i].CheckOverridesEnabled = true
+		s := settings[repos[i].FullName]
+		repos[i].RequiredChecks = strings.Join(s.RequiredChecks, ", ")
+		repos[i].OptionalChecks = strings.Join(s.OptionalChecks, ", ")
+		repos[i].IgnoredChecks = strings.Join(s.IgnoredChecks, ", ")
+		repos[i].Stale = vm.StalePolicyViewModel{
+			AfterDays:    s.Stale.AfterDays,
+			Comment:      s.Stale.Action == model.StaleActionComment,
+			Label:        s.Stale.Label,
+			ExemptLabels: strings.Join(s.Stale.ExemptLabels, ", "),
+			// New policies start as a dry run.
+			DryRun: s.Stale.DryRun || !s.Stale.Enabled(),
+		}
+		repos[i].PRScope = string(s.PRScope)
+		repos[i].DefaultRelevantOnly = h.relevantPRsOnly
+	}
+}
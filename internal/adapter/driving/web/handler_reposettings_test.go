@@ -0,0 +1,123 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memoryRepoSettingsStore is an in-memory driven.RepoSettingsStore.
+type memoryRepoSettingsStore map[string]model.RepoSettings
+
+func (m memoryRepoSettingsStore) GetRepoSettings(_ context.Context, repoFullName string) (model.RepoSettings, error) {
+	if s, ok := m[repoFullName]; ok {
+		return s, nil
+	}
+	return model.RepoSettings{RepoFullName: repoFullName}, nil
+}
+
+func (m memoryRepoSettingsStore) ListRepoSettings(_ context.Context, _ []string) (map[string]model.RepoSettings, error) {
+	return m, nil
+}
+
+func (m memoryRepoSettingsStore) SetRepoSettings(_ context.Context, settings model.RepoSettings) error {
+	m[settings.RepoFullName] = settings
+	return nil
+}
+
+func (m memoryRepoSettingsStore) ReplaceRepoSettings(_ context.Context, _ model.RepoSettings, _ model.RepoThreshold) error {
+	panic("unused")
+}
+
+func TestSaveRepoCheckOverrides(t *testing.T) {
+	post := func(h *Handler, body string) *httptest.ResponseRecorder {
+		req := preferenceRequest(http.MethodPost, "/app/settings/repo-checks", body, nil)
+		rec := httptest.NewRecorder()
+		h.SaveRepoCheckOverrides(rec, req)
+		return rec
+	}
+
+	h := &Handler{logger: slog.Default()}
+	assert.Equal(t, http.StatusServiceUnavailable, post(h, "repo_full_name=o/r").Code)
+
+	store := memoryRepoSettingsStore{}
+	h.WithRepoSettingsStore(store)
+
+	rec := post(h, "repo_full_name=o/r&required_checks=build,+test,+Build&optional_checks=codecov/patch%0Alint&ignored_checks=codecov/project")
+	assert.Contains(t, rec.Body.String(), "Saved")
+	assert.Equal(t, []string{"build", "test"}, store["o/r"].RequiredChecks, "blanks and duplicates are dropped")
+	assert.Equal(t, []string{"codecov/patch", "lint"}, store["o/r"].OptionalChecks)
+	assert.Equal(t, []string{"codecov/project"}, store["o/r"].IgnoredChecks)
+
+	rec = post(h, "repo_full_name=o/r&required_checks=lint&optional_checks=LINT")
+	assert.Contains(t, rec.Body.String(), "cannot be both required and optional")
+	assert.Equal(t, []string{"build", "test"}, store["o/r"].RequiredChecks, "a conflicting save is rejected")
+
+	repos := []vm.RepoViewModel{{FullName: "o/r"}}
+	h.applyRepoSettings(context.Background(), repos)
+	assert.True(t, repos[0].CheckOverridesEnabled)
+	assert.Equal(t, "build, test", repos[0].RequiredChecks)
+	assert.Equal(t, "codecov/project", repos[0].IgnoredChecks)
+}
+
+func TestSaveRepoStalePolicy(t *testing.T) {
+	post := func(h *Handler, body string) *httptest.ResponseRecorder {
+		req := preferenceRequest(http.MethodPost, "/app/settings/repo-stale", body, nil)
+		rec := httptest.NewRecorder()
+		h.SaveRepoStalePolicy(rec, req)
+		return rec
+	}
+
+	store := memoryRepoSettingsStore{"o/r": {RepoFullName: "o/r", IgnoredChecks: []string{"codecov/patch"}}}
+	h := (&Handler{logger: slog.Default()}).WithRepoSettingsStore(store)
+
+	assert.Contains(t, post(h, "repo_full_name=o/r&stale_after_days=-1&stale_action=label").Body.String(), "non-negative")
+	assert.Contains(t, post(h, "repo_full_name=o/r&stale_after_days=14&stale_action=close").Body.String(), "unknown stale action")
+
+	rec := post(h, "repo_full_name=o/r&stale_after_days=14&stale_action=comment&stale_label=&stale_exempt_labels=pinned,+wip&stale_dry_run=on")
+	assert.Contains(t, rec.Body.String(), "Saved")
+	assert.Equal(t, model.StalePolicy{
+		AfterDays:    14,
+		Action:       model.StaleActionComment,
+		DryRun:       true,
+		ExemptLabels: []string{"pinned", "wip"},
+	}, store["o/r"].Stale)
+	assert.Equal(t, []string{"codecov/patch"}, store["o/r"].IgnoredChecks, "other settings are kept")
+
+	repos := []vm.RepoViewModel{{FullName: "o/r"}, {FullName: "o/new"}}
+	h.applyRepoSettings(context.Background(), repos)
+	assert.True(t, repos[0].Stale.Comment)
+	assert.Equal(t, "pinned, wip", repos[0].Stale.ExemptLabels)
+	assert.True(t, repos[1].Stale.DryRun, "a repo without a policy starts as a dry run")
+}
+
+func TestSaveRepoPRScope(t *testing.T) {
+	post := func(h *Handler, body string) *httptest.ResponseRecorder {
+		req := preferenceRequest(http.MethodPost, "/app/settings/repo-pr-scope", body, nil)
+		rec := httptest.NewRecorder()
+		h.SaveRepoPRScope(rec, req)
+		return rec
+	}
+
+	store := memoryRepoSettingsStore{"o/r": {RepoFullName: "o/r", IgnoredChecks: []string{"codecov/patch"}}}
+	h := (&Handler{logger: slog.Default()}).WithRepoSettingsStore(store).WithRelevantPRsOnly(true)
+
+	assert.Contains(t, post(h, "repo_full_name=o/r&pr_scope=mine").Body.String(), "unknown PR scope")
+
+	assert.Contains(t, post(h, "repo_full_name=o/r&pr_scope=all").Body.String(), "Saved")
+	assert.Equal(t, model.PRScopeAll, store["o/r"].PRScope)
+	assert.Equal(t, []string{"codecov/patch"}, store["o/r"].IgnoredChecks, "other settings are kept")
+
+	repos := []vm.RepoViewModel{{FullName: "o/r"}, {FullName: "o/new"}}
+	h.applyRepoSettings(context.Background(), repos)
+	assert.Equal(t, "all", repos[0].PRScope)
+	assert.Empty(t, repos[1].PRScope)
+	assert.True(t, repos[1].DefaultRelevantOnly)
+}
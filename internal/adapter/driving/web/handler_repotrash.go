@@ -0,0 +1,125 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithRepoTrashService makes repo removal undoable: removing a repo shows an
+// undo toast, and the settings drawer lists removed repos that can still be
+// restored. Without it repos are deleted immediately.
+func (h *Handler) WithRepoTrashService(svc *application.RepoTrashService) *Handler {
+	h.repoTrashSvc = svc
+	return h
+}
+
+// RemovedRepos handles GET /app/repos/removed.
+// It renders the settings drawer's list of restorable repos, or responds 204
+// No Content when removal is not undoable so the section stays hidden.
+func (h *Handler) RemovedRepos(w http.ResponseWriter, r *http.Request) {
+	if h.repoTrashSvc == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	repos, err := h.removedRepoViewModels(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list removed repos", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err := components.RemovedRepoSection(repos, h.removedRepoRetentionDays()).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render removed repos", "error", err)
+	}
+}
+
+// RestoreRepo handles POST /app/repos/{owner}/{repo}/restore.
+// It puts a removed repo back on the watch list with its history, refreshes
+// it in the background, and returns the same partials as adding a repo.
+func (h *Handler) RestoreRepo(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+	if h.repoTrashSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+	if err := h.repoTrashSvc.Restore(r.Context(), fullName); err != nil {
+		if errors.Is(err, driven.ErrRepoNotFound) {
+			http.Error(w, "removed repository not found", http.StatusNotFound)
+			return
+		}
+		h.logger.Error("failed to restore repo", "repo", fullName, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// PRs may have changed while the repo was removed.
+	if h.pollSvc != nil {
+		go func() { //nolint:contextcheck // intentional background context for fire-and-forget
+			if err := h.pollSvc.RefreshRepo(context.Background(), fullName); err != nil {
+				h.logger.Error("async repo refresh failed", "repo", fullName, "error", err)
+			}
+		}()
+	}
+
+	h.renderRepoMutationResponse(w, r)
+	h.renderRemovedReposOOB(w, r)
+}
+
+// renderRemovedReposOOB refreshes the settings drawer's removed repo list
+// after a removal or restore. Failures are logged; the list catches up on
+// the next page load.
+func (h *Handler) renderRemovedReposOOB(w http.ResponseWriter, r *http.Request) {
+	repos, err := h.removedRepoViewModels(r.Context())
+	if err != nil {
+		h.logger.Warn("failed to list removed repos for OOB swap", "error", err)
+		return
+	}
+	if err := components.RemovedRepoSectionOOB(repos, h.removedRepoRetentionDays()).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render OOB removed repos", "error", err)
+	}
+}
+
+// renderUndoToast appends the undo toast to a response as an OOB swap.
+func (h *Handler) renderUndoToast(w http.ResponseWriter, r *http.Request, toast vm.UndoToastViewModel) {
+	if err := components.UndoToast(toast).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render undo toast", "error", err)
+	}
+}
+
+// removedRepoViewModels lists the restorable repos, most recently removed first.
+func (h *Handler) removedRepoViewModels(ctx context.Context) ([]vm.RemovedRepoViewModel, error) {
+	repos, err := h.repoTrashSvc.ListRemoved(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	vms := make([]vm.RemovedRepoViewModel, 0, len(repos))
+	for _, repo := range repos {
+		vms = append(vms, vm.RemovedRepoViewModel{
+			FullName:    repo.FullName,
+			RestorePath: fmt.Sprintf("/app/repos/%s/%s/restore", repo.Owner, repo.Name),
+			Removed:     "removed " + formatShortDuration(now.Sub(repo.RemovedAt)) + " ago",
+			PurgeAt:     h.repoTrashSvc.PurgeAt(repo.RemovedAt).Local().Format("2 Jan 2006"),
+		})
+	}
+	return vms, nil
+}
+
+// removedRepoRetentionDays is how many days removed repos stay restorable.
+func (h *Handler) removedRepoRetentionDays() int {
+	return int(h.repoTrashSvc.Retention() / (24 * time.Hour))
+}
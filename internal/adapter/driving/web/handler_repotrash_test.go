@@ -0,0 +1,66 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memoryRepoTrash is an in-memory driven.RepoTrash.
+type memoryRepoTrash struct {
+	trashed []model.Repository
+}
+
+func (m *memoryRepoTrash) Trash(context.Context, string, time.Time) error { return nil }
+
+func (m *memoryRepoTrash) Restore(context.Context, string) error { return nil }
+
+func (m *memoryRepoTrash) ListTrashed(context.Context) ([]model.Repository, error) {
+	return m.trashed, nil
+}
+
+func (m *memoryRepoTrash) PurgeTrashed(context.Context, time.Time) (int, error) { return 0, nil }
+
+func TestRemovedRepos(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&Handler{logger: slog.Default()}).RemovedRepos(rec, httptest.NewRequest(http.MethodGet, "/app/repos/removed", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code, "hidden when removal is not undoable")
+
+	trash := &memoryRepoTrash{trashed: []model.Repository{
+		{FullName: "org/api", Owner: "org", Name: "api", RemovedAt: time.Now().Add(-2 * time.Hour)},
+	}}
+	h := (&Handler{logger: slog.Default()}).
+		WithRepoTrashService(application.NewRepoTrashService(trash, 7*24*time.Hour))
+
+	rec = httptest.NewRecorder()
+	h.RemovedRepos(rec, httptest.NewRequest(http.MethodGet, "/app/repos/removed", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, "org/api")
+	assert.Contains(t, body, "removed 2h ago")
+	assert.Contains(t, body, "for 7 days")
+	assert.Contains(t, body, `hx-post="/app/repos/org/api/restore"`)
+}
+
+func TestUndoToast(t *testing.T) {
+	rec := httptest.NewRecorder()
+	(&Handler{logger: slog.Default()}).renderUndoToast(rec, httptest.NewRequest(http.MethodPost, "/app/prs/7/ignore", nil), vm.UndoToastViewModel{
+		Message:  "PR ignored",
+		UndoPath: "/app/prs/7/unignore",
+		Target:   "#pr-list",
+	})
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `id="undo-toast" hx-swap-oob="true"`)
+	assert.Contains(t, body, `hx-post="/app/prs/7/unignore"`)
+	assert.Contains(t, body, "setTimeout(() =&gt; open = false, 30000)")
+}
@@ -0,0 +1,157 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// SuggestedReviewers handles GET /app/prs/{owner}/{repo}/{number}/reviewers.
+// It renders GitHub's suggested reviewers for the PR. Problems such as a
+// missing token are shown inside the panel, since it loads on its own.
+func (h *Handler) SuggestedReviewers(w http.ResponseWriter, r *http.Request) {
+	owner := r.PathValue("owner")
+	repo := r.PathValue("repo")
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		http.Error(w, errMsgInvalidPRNumber, http.StatusBadRequest)
+		return
+	}
+
+	h.renderReviewersPanel(w, r, owner, repo, number, "")
+}
+
+// RequestReviewer handles POST /app/prs/{owner}/{repo}/{number}/reviewers.
+// It requests a review from the login form value and re-renders the panel.
+func (h *Handler) RequestReviewer(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	login := strings.TrimSpace(r.FormValue("login"))
+	if login == "" {
+		http.Error(w, "reviewer login is required", http.StatusUnprocessableEntity)
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "request reviewers")
+	if writer == nil {
+		return
+	}
+
+	if err := writer.RequestReviewers(r.Context(), repoFullName, number, []string{login}); err != nil {
+		h.logger.Error("failed to request reviewer", "repo", repoFullName, "pr", number, "reviewer", login, "error", err)
+		http.Error(w, "Request review failed: "+err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// Fire-and-forget background refresh so the PR's review request state catches up.
+	if h.pollSvc != nil {
+		go func() { //nolint:contextcheck // intentional background context for fire-and-forget
+			if err := h.pollSvc.RefreshRepo(context.Background(), repoFullName); err != nil {
+				h.logger.Error("async repo refresh after reviewer request failed", "repo", repoFullName, "error", err)
+			}
+		}()
+	}
+
+	h.renderReviewersPanel(w, r, owner, repo, number, login)
+}
+
+// renderReviewersPanel fetches the suggestions for a PR and renders the
+// panel, marking requested as just asked for a review.
+func (h *Handler) renderReviewersPanel(w http.ResponseWriter, r *http.Request, owner, repo string, number int, requested string) {
+	ctx := r.Context()
+	repoFullName := owner + "/" + repo
+	panel := vm.ReviewersViewModel{Owner: owner, RepoName: repo, Number: number}
+
+	pr, err := h.prStore.GetByNumber(ctx, repoFullName, number)
+	switch {
+	case err != nil:
+		h.logger.Error("failed to get PR for suggested reviewers", "repo", repoFullName, "number", number, "error", err)
+		panel.Message = "Suggested reviewers are unavailable."
+	case pr == nil:
+		http.Error(w, "pull request not found", http.StatusNotFound)
+		return
+	default:
+		writer := h.writerFor(ctx, repoFullName)
+		if writer == nil {
+			panel.Message = "Configure a GitHub token in Settings to see suggested reviewers."
+			break
+		}
+		suggested, err := writer.FetchSuggestedReviewers(ctx, repoFullName, number)
+		if err != nil {
+			h.logger.Warn("failed to fetch suggested reviewers", "repo", repoFullName, "number", number, "error", err)
+			panel.Message = "Suggested reviewers are unavailable."
+			break
+		}
+		panel.Suggested = toSuggestedReviewerViewModels(*pr, suggested, requested)
+	}
+
+	if err := components.ReviewersPanel(panel).Render(ctx, w); err != nil {
+		h.logger.Error("failed to render reviewers panel", "error", err)
+	}
+}
+
+// gitHubTokenFor returns the token that would act on repoFullName, or "" when
+// none is configured. Unlike requireGitHubToken it writes no response.
+func (h *Handler) gitHubTokenFor(ctx context.Context, repoFullName string) string {
+	if h.writerFactory == nil {
+		return ""
+	}
+	if account := h.githubAccountForRepo(ctx, repoFullName); account.Token != "" {
+		return account.Token
+	}
+	if h.credStore == nil {
+		return ""
+	}
+	token, err := h.credStore.Get(ctx, "github_token")
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// toSuggestedReviewerViewModels converts GitHub's suggestions, dropping the
+// PR author, who cannot review their own PR. The requested login, if any,
+// is marked and kept even when GitHub no longer suggests it.
+func toSuggestedReviewerViewModels(pr model.PullRequest, suggested []model.SuggestedReviewer, requested string) []vm.SuggestedReviewerViewModel {
+	result := make([]vm.SuggestedReviewerViewModel, 0, len(suggested)+1)
+	found := false
+	for _, s := range suggested {
+		if strings.EqualFold(s.Login, pr.Author) {
+			continue
+		}
+		isRequested := requested != "" && strings.EqualFold(s.Login, requested)
+		found = found || isRequested
+		result = append(result, vm.SuggestedReviewerViewModel{
+			Login:     s.Login,
+			Reason:    suggestionReason(s),
+			Requested: isRequested,
+		})
+	}
+	if requested != "" && !found {
+		result = append(result, vm.SuggestedReviewerViewModel{Login: requested, Requested: true})
+	}
+	return result
+}
+
+// suggestionReason explains why GitHub suggests a reviewer.
+func suggestionReason(s model.SuggestedReviewer) string {
+	switch {
+	case s.IsAuthor && s.IsCommenter:
+		return "Recently edited and commented on these files"
+	case s.IsAuthor:
+		return "Recently edited these files"
+	case s.IsCommenter:
+		return "Recently commented on these files"
+	default:
+		return ""
+	}
+}
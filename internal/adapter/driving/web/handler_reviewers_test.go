@@ -0,0 +1,44 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToSuggestedReviewerViewModels(t *testing.T) {
+	pr := model.PullRequest{Author: "Octocat"}
+	suggested := []model.SuggestedReviewer{
+		{Login: "alice", IsAuthor: true},
+		{Login: "octocat", IsAuthor: true, IsCommenter: true},
+		{Login: "bob", IsCommenter: true},
+		{Login: "carol", IsAuthor: true, IsCommenter: true},
+	}
+
+	t.Run("drops the PR author", func(t *testing.T) {
+		got := toSuggestedReviewerViewModels(pr, suggested, "")
+		assert.Equal(t, []vm.SuggestedReviewerViewModel{
+			{Login: "alice", Reason: "Recently edited these files"},
+			{Login: "bob", Reason: "Recently commented on these files"},
+			{Login: "carol", Reason: "Recently edited and commented on these files"},
+		}, got)
+	})
+
+	t.Run("marks the requested reviewer", func(t *testing.T) {
+		got := toSuggestedReviewerViewModels(pr, suggested, "BOB")
+		assert.False(t, got[0].Requested)
+		assert.True(t, got[1].Requested)
+		assert.Len(t, got, 3)
+	})
+
+	t.Run("keeps a requested reviewer GitHub stopped suggesting", func(t *testing.T) {
+		got := toSuggestedReviewerViewModels(pr, suggested[:1], "dave")
+		assert.Equal(t, []vm.SuggestedReviewerViewModel{
+			{Login: "alice", Reason: "Recently edited these files"},
+			{Login: "dave", Requested: true},
+		}, got)
+	})
+}
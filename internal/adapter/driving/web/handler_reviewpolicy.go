@@ -0,0 +1,96 @@
+package web
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithBranchProtectionStore enables warning on PR detail when the base branch
+// dismisses approvals on new pushes, and the merge requirements checklist.
+// Without it neither is shown.
+func (h *Handler) WithBranchProtectionStore(store driven.BranchProtectionStore) *Handler {
+	h.branchProtectionStore = store
+	return h
+}
+
+// applyReviewPolicy flags whether the PR's base branch dismisses stale
+// approvals and whether the user holds a current approval that the next push
+// would dismiss. Failures are logged and leave the flags unset.
+func (h *Handler) applyReviewPolicy(ctx context.Context, detail *vm.PRDetailViewModel) {
+	if h.branchProtectionStore == nil || detail.BaseBranch == "" {
+		return
+	}
+
+	protection, err := h.branchProtectionStore.Get(ctx, detail.Repository, detail.BaseBranch)
+	if err != nil {
+		h.logger.Warn("failed to get branch protection", "repo", detail.Repository, "branch", detail.BaseBranch, "error", err)
+		return
+	}
+	if protection == nil || !protection.DismissStaleReviews {
+		return
+	}
+	detail.DismissesStaleApprovals = true
+
+	// Reviews are ordered by submission time, so the last match is the user's latest.
+	user := h.usernameForRepo(ctx, detail.Repository)
+	var latest *vm.ReviewViewModel
+	for i := range detail.Reviews {
+		if strings.EqualFold(detail.Reviews[i].Reviewer, user) {
+			latest = &detail.Reviews[i]
+		}
+	}
+	detail.ApprovalExpiresOnPush = latest != nil &&
+		latest.State == string(model.ReviewStateApproved) &&
+		!latest.IsOutdated
+}
+
+// applyMergeRequirements builds the merge requirements checklist from the base
+// branch's protection, the PR's reviews, and its check runs. Without stored
+// protection only required checks are listed. Failures are logged and leave
+// the checklist empty.
+func (h *Handler) applyMergeRequirements(ctx context.Context, detail *vm.PRDetailViewModel, pr model.PullRequest, summary *application.PRReviewSummary, checkRuns []model.CheckRun) {
+	var protection *model.BranchProtection
+	if h.branchProtectionStore != nil && pr.BaseBranch != "" {
+		var err error
+		protection, err = h.branchProtectionStore.Get(ctx, pr.RepoFullName, pr.BaseBranch)
+		if err != nil {
+			h.logger.Warn("failed to get branch protection", "repo", pr.RepoFullName, "branch", pr.BaseBranch, "error", err)
+			return
+		}
+	}
+
+	var reviews []model.Review
+	if summary != nil {
+		reviews = summary.Reviews
+	}
+	for _, req := range application.MergeRequirements(pr, protection, reviews, checkRuns) {
+		detail.MergeRequirements = append(detail.MergeRequirements, vm.MergeRequirementViewModel{
+			Label: mergeRequirementLabel(req),
+			State: string(req.State),
+		})
+	}
+}
+
+// mergeRequirementLabel describes a merge requirement for the checklist.
+func mergeRequirementLabel(req model.MergeRequirement) string {
+	switch req.Kind {
+	case model.RequirementApprovals:
+		return fmt.Sprintf("%d of %d approving reviews", req.Have, req.Need)
+	case model.RequirementCodeOwnerReview:
+		return "Code owner approval"
+	case model.RequirementNoChangesRequested:
+		return "No changes requested"
+	case model.RequirementStatusChecks:
+		return fmt.Sprintf("%d of %d required checks passing", req.Have, req.Need)
+	case model.RequirementNoConflicts:
+		return "No merge conflicts"
+	default:
+		return string(req.Kind)
+	}
+}
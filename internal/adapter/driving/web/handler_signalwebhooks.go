@@ -0,0 +1,131 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// signalLabels are the settings drawer names of each attention signal.
+var signalLabels = map[model.AttentionSignal]string{
+	model.SignalNeedsMoreReviews:    "Needs more reviews",
+	model.SignalAgeUrgent:           "Age urgent",
+	model.SignalStaleReview:         "Stale review",
+	model.SignalCIFailure:           "CI failure (own PRs)",
+	model.SignalApprovalDismissed:   "Approval dismissed",
+	model.SignalMentioned:           "Mentioned, no reply yet",
+	model.SignalSLABreached:         "First-review SLA breached",
+	model.SignalNewcomerWaiting:     "First-time contributor waiting",
+	model.SignalChecklistIncomplete: "Checklist incomplete",
+	model.SignalRequestAging:        "Review request waiting",
+}
+
+// WithSignalWebhookService enables managing attention signal webhooks from
+// the settings drawer. Without it the section is hidden and the routes return 503.
+func (h *Handler) WithSignalWebhookService(svc *application.SignalWebhookService) *Handler {
+	h.signalWebhookSvc = svc
+	return h
+}
+
+// CreateSignalWebhook handles POST /app/settings/signal-webhooks.
+// It stores the webhook and returns the updated webhook list.
+func (h *Handler) CreateSignalWebhook(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: invalid form data")
+		return
+	}
+
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.signalWebhookSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	signal := model.AttentionSignal(r.FormValue("signal"))
+	_, err := h.signalWebhookSvc.Create(r.Context(), signal, r.FormValue("webhook_url"), r.FormValue("repo_full_name"))
+	switch {
+	case errors.Is(err, application.ErrInvalidWebhookSignal),
+		errors.Is(err, application.ErrInvalidWebhookURL),
+		errors.Is(err, application.ErrInvalidWebhookRepo):
+		writeErrorToast(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	case err != nil:
+		h.logger.Error("failed to create signal webhook", "signal", signal, "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: failed to save webhook")
+		return
+	}
+
+	h.renderSignalWebhookList(w, r)
+}
+
+// DeleteSignalWebhook handles DELETE /app/settings/signal-webhooks/{id}.
+func (h *Handler) DeleteSignalWebhook(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.signalWebhookSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.signalWebhookSvc.Delete(r.Context(), id); err != nil {
+		h.logger.Error("failed to delete signal webhook", "id", id, "error", err)
+		http.Error(w, "failed to delete webhook", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderSignalWebhookList(w, r)
+}
+
+// renderSignalWebhookList renders the webhook list fragment for the settings drawer.
+func (h *Handler) renderSignalWebhookList(w http.ResponseWriter, r *http.Request) {
+	if err := components.SignalWebhookList(h.signalWebhookSettings(r.Context()).Hooks).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render signal webhook list", "error", err)
+	}
+}
+
+// signalWebhookSettings builds the settings drawer's webhook section.
+// Failures are logged and yield no webhooks.
+func (h *Handler) signalWebhookSettings(ctx context.Context) vm.SignalWebhookSettingsViewModel {
+	if h.signalWebhookSvc == nil {
+		return vm.SignalWebhookSettingsViewModel{}
+	}
+
+	settings := vm.SignalWebhookSettingsViewModel{Enabled: true}
+	for _, signal := range model.AllAttentionSignals {
+		settings.Signals = append(settings.Signals, vm.SignalOptionViewModel{Value: string(signal), Label: signalLabels[signal]})
+	}
+
+	hooks, err := h.signalWebhookSvc.List(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list signal webhooks", "error", err)
+		return settings
+	}
+	for _, hook := range hooks {
+		settings.Hooks = append(settings.Hooks, vm.SignalWebhookViewModel{
+			ID:          hook.ID,
+			SignalLabel: signalLabels[hook.Signal],
+			URL:         hook.URL,
+			Repo:        hook.RepoFullName,
+		})
+	}
+	return settings
+}
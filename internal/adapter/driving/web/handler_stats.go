@@ -0,0 +1,58 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// WithTeamStatsService enables the reviewer leaderboard. Without it, which is
+// the default until team stats are opted into, the sidebar hides the button
+// and the route returns 503.
+func (h *Handler) WithTeamStatsService(svc *application.TeamStatsService) *Handler {
+	h.teamStatsSvc = svc
+	return h
+}
+
+// TeamStats handles GET /app/stats?period=&repo=.
+// It renders the reviewer leaderboard for the chosen period into #pr-detail.
+// Unknown periods fall back to the default.
+func (h *Handler) TeamStats(w http.ResponseWriter, r *http.Request) {
+	if h.teamStatsSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	period, err := strconv.Atoi(r.URL.Query().Get("period"))
+	if err != nil || !application.IsTeamStatsPeriod(period) {
+		period = application.DefaultTeamStatsPeriod
+	}
+	repoFilter := strings.TrimSpace(r.URL.Query().Get("repo"))
+
+	stats, err := h.teamStatsSvc.Leaderboard(r.Context(), period, repoFilter)
+	if err != nil {
+		h.logger.Error("failed to compute team stats", "period", period, "repo", repoFilter, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := vm.TeamStatsViewModel{
+		Period:  period,
+		Periods: application.TeamStatsPeriods,
+		Repo:    repoFilter,
+		Rows:    toReviewerStatsViewModels(stats),
+	}
+	if repos, err := h.repoStore.ListAll(r.Context()); err != nil {
+		h.logger.Warn("failed to list repos for team stats filter", "error", err)
+	} else {
+		data.Repos = extractRepoNames(repos)
+	}
+
+	if err := partials.TeamStatsContent(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render team stats", "error", err)
+	}
+}
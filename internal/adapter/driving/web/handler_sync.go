@@ -0,0 +1,95 @@
+package web
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// noTokenReason is shown on GitHub actions when no token is configured.
+const noTokenReason = "Add a GitHub token in Settings to enable GitHub actions."
+
+// syncStatus returns the poll loop's view of GitHub reachability.
+func (h *Handler) syncStatus() application.SyncStatus {
+	if h.pollSvc == nil {
+		return application.SyncStatus{}
+	}
+	return h.pollSvc.SyncStatus()
+}
+
+// actionsDisabledReason explains why GitHub actions on repoFullName would
+// fail, or returns "" when they should work.
+func (h *Handler) actionsDisabledReason(ctx context.Context, repoFullName string, sync application.SyncStatus) string {
+	if provider := h.repoProvider(ctx, repoFullName); provider != model.ProviderGitHub {
+		if _, ok := h.providers[provider]; !ok {
+			return "Configure " + string(provider) + " credentials to enable actions."
+		}
+		return ""
+	}
+	if h.gitHubTokenFor(ctx, repoFullName) == "" {
+		return noTokenReason
+	}
+	if sync.Offline {
+		return sync.Reason + " GitHub actions are disabled until it is back."
+	}
+	return ""
+}
+
+// applyActionsAvailability disables the detail page's GitHub actions with
+// reason, or leaves them enabled when reason is empty.
+func applyActionsAvailability(detail *vm.PRDetailViewModel, reason string) {
+	detail.ActionsDisabledReason = reason
+	for i := range detail.Threads {
+		detail.Threads[i].RepliesDisabledReason = reason
+	}
+}
+
+// toSyncBannerViewModel builds the stale-data banner from the sync status and
+// the most recent successful sync across repos.
+func toSyncBannerViewModel(sync application.SyncStatus, repos []model.Repository, now time.Time) vm.SyncBannerViewModel {
+	if !sync.Offline && !sync.Degraded {
+		return vm.SyncBannerViewModel{}
+	}
+
+	banner := vm.SyncBannerViewModel{Offline: sync.Offline, Degraded: sync.Degraded, Reason: sync.Reason}
+	var latest time.Time
+	for _, r := range repos {
+		if r.LastSyncedAt.After(latest) {
+			latest = r.LastSyncedAt
+		}
+	}
+	if !latest.IsZero() {
+		banner.LastSynced = "Last synced " + formatShortDuration(now.Sub(latest)) + " ago."
+	}
+	return banner
+}
+
+// lastSyncedLabel describes when a repo last synced, with the exact time for
+// a tooltip.
+func lastSyncedLabel(syncedAt, now time.Time) (label, title string) {
+	if syncedAt.IsZero() {
+		return "never synced", "This repository has not been polled successfully yet"
+	}
+	return "synced " + formatShortDuration(now.Sub(syncedAt)) + " ago",
+		"Last synced " + syncedAt.Local().Format("2006-01-02 15:04")
+}
+
+// repoDetails describes a repo's GitHub metadata for the repo list tooltip,
+// e.g. "Public API · default branch main"; empty until first fetched.
+func repoDetails(md model.RepoMetadata) string {
+	if md.FetchedAt.IsZero() {
+		return ""
+	}
+	parts := make([]string, 0, 2)
+	if md.Description != "" {
+		parts = append(parts, md.Description)
+	}
+	if md.DefaultBranch != "" {
+		parts = append(parts, "default branch "+md.DefaultBranch)
+	}
+	return strings.Join(parts, " · ")
+}
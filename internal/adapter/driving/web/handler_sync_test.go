@@ -0,0 +1,69 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToSyncBannerViewModel(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	repos := []model.Repository{
+		{FullName: "org/a", LastSyncedAt: now.Add(-2 * time.Hour)},
+		{FullName: "org/b", LastSyncedAt: now.Add(-12 * time.Minute)},
+		{FullName: "org/new"},
+	}
+	offline := application.SyncStatus{Offline: true, Reason: "GitHub is unreachable."}
+
+	assert.Equal(t, vm.SyncBannerViewModel{}, toSyncBannerViewModel(application.SyncStatus{}, repos, now))
+	assert.Equal(t, vm.SyncBannerViewModel{
+		Offline:    true,
+		Reason:     "GitHub is unreachable.",
+		LastSynced: "Last synced 12m ago.",
+	}, toSyncBannerViewModel(offline, repos, now))
+	assert.Empty(t, toSyncBannerViewModel(offline, repos[2:], now).LastSynced)
+
+	degraded := application.SyncStatus{Degraded: true, Reason: "4 of the last 5 GitHub polls failed."}
+	assert.Equal(t, vm.SyncBannerViewModel{
+		Degraded:   true,
+		Reason:     "4 of the last 5 GitHub polls failed.",
+		LastSynced: "Last synced 12m ago.",
+	}, toSyncBannerViewModel(degraded, repos, now))
+}
+
+func TestLastSyncedLabel(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	label, _ := lastSyncedLabel(time.Time{}, now)
+	assert.Equal(t, "never synced", label)
+
+	label, title := lastSyncedLabel(now.Add(-3*time.Hour), now)
+	assert.Equal(t, "synced 3h ago", label)
+	assert.Contains(t, title, "Last synced ")
+}
+
+func TestApplyActionsAvailability(t *testing.T) {
+	detail := vm.PRDetailViewModel{Threads: make([]vm.ThreadViewModel, 2)}
+
+	applyActionsAvailability(&detail, noTokenReason)
+
+	assert.Equal(t, noTokenReason, detail.ActionsDisabledReason)
+	for _, thread := range detail.Threads {
+		assert.Equal(t, noTokenReason, thread.RepliesDisabledReason)
+	}
+}
+
+func TestRepoDetails(t *testing.T) {
+	assert.Empty(t, repoDetails(model.RepoMetadata{Description: "API"}), "unfetched metadata is not shown")
+
+	fetched := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, "Public API · default branch main",
+		repoDetails(model.RepoMetadata{Description: "Public API", DefaultBranch: "main", FetchedAt: fetched}))
+	assert.Equal(t, "default branch trunk",
+		repoDetails(model.RepoMetadata{DefaultBranch: "trunk", FetchedAt: fetched}))
+}
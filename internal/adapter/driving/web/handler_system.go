@@ -0,0 +1,76 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// WithSystemService enables the system status section of the settings drawer.
+// Without it the section stays empty.
+func (h *Handler) WithSystemService(svc *application.SystemService) *Handler {
+	h.systemSvc = svc
+	return h
+}
+
+// SystemStatus handles GET /app/settings/system. It renders PR counts, each
+// repository's latest poll, and the outbox size, or nothing when system stats
+// are unavailable.
+func (h *Handler) SystemStatus(w http.ResponseWriter, r *http.Request) {
+	if h.systemSvc == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	stats, err := h.systemSvc.Stats(r.Context())
+	if err != nil {
+		h.logger.Error("failed to compute system stats", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if err := components.SystemStatusSection(toSystemStatusViewModel(stats, time.Now())).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render system status", "error", err)
+	}
+}
+
+// toSystemStatusViewModel converts system stats for display, listing counts
+// in a fixed order and skipping states with none.
+func toSystemStatusViewModel(stats application.SystemStats, now time.Time) vm.SystemStatusViewModel {
+	data := vm.SystemStatusViewModel{
+		Repos:         make([]vm.RepoPollStatusViewModel, 0, len(stats.Repos)),
+		OutboxPending: stats.OutboxPending,
+		OutboxFailed:  stats.OutboxFailed,
+	}
+	for _, status := range []model.PRStatus{model.PRStatusOpen, model.PRStatusMerged, model.PRStatusClosed} {
+		if n := stats.PRsByStatus[status]; n > 0 {
+			data.PRCounts = append(data.PRCounts, vm.StatCountViewModel{Label: string(status), Count: n})
+		}
+	}
+	for _, ci := range []model.CIStatus{model.CIStatusFailing, model.CIStatusPending, model.CIStatusPassing, model.CIStatusUnknown} {
+		if n := stats.OpenPRsByCI[ci]; n > 0 {
+			data.CICounts = append(data.CICounts, vm.StatCountViewModel{Label: string(ci), Count: n})
+		}
+	}
+	for _, repo := range stats.Repos {
+		row := vm.RepoPollStatusViewModel{
+			FullName:  repo.FullName,
+			OpenPRs:   repo.OpenPRs,
+			Failures:  repo.ConsecutiveFailures,
+			LastError: repo.LastError,
+		}
+		switch {
+		case repo.Paused:
+			row.LastPoll = "paused"
+		case repo.LastPolledAt.IsZero():
+			row.LastPoll = "not polled yet"
+		default:
+			row.LastPoll = "polled " + formatShortDuration(now.Sub(repo.LastPolledAt)) + " ago"
+		}
+		data.Repos = append(data.Repos, row)
+	}
+	return data
+}
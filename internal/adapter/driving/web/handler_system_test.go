@@ -0,0 +1,37 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToSystemStatusViewModel(t *testing.T) {
+	now := time.Now()
+	stats := application.SystemStats{
+		PRsByStatus: map[model.PRStatus]int{model.PRStatusMerged: 4, model.PRStatusOpen: 2},
+		OpenPRsByCI: map[model.CIStatus]int{model.CIStatusPassing: 1, model.CIStatusFailing: 1},
+		Repos: []application.RepoPollStats{
+			{FullName: "org/a", OpenPRs: 2, LastPolledAt: now.Add(-3 * time.Minute), ConsecutiveFailures: 1, LastError: "timeout"},
+			{FullName: "org/b", Paused: true},
+			{FullName: "org/c"},
+		},
+		OutboxPending: 1,
+	}
+
+	data := toSystemStatusViewModel(stats, now)
+
+	assert.Equal(t, []vm.StatCountViewModel{{Label: "open", Count: 2}, {Label: "merged", Count: 4}}, data.PRCounts)
+	assert.Equal(t, []vm.StatCountViewModel{{Label: "failing", Count: 1}, {Label: "passing", Count: 1}}, data.CICounts)
+	assert.Equal(t, []vm.RepoPollStatusViewModel{
+		{FullName: "org/a", OpenPRs: 2, LastPoll: "polled 3m ago", Failures: 1, LastError: "timeout"},
+		{FullName: "org/b", LastPoll: "paused"},
+		{FullName: "org/c", LastPoll: "not polled yet"},
+	}, data.Repos)
+	assert.Equal(t, 1, data.OutboxPending)
+}
@@ -0,0 +1,129 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/partials"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// Threads handles GET /app/threads.
+// It renders the unresolved review threads of the active repo group's open
+// PRs that the user authored or reviews into #pr-detail, grouped by PR and
+// file, with the same reply and resolve controls as the PR detail.
+func (h *Handler) Threads(w http.ResponseWriter, r *http.Request) {
+	if h.reviewSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	prs, err := h.prStore.ListAll(r.Context())
+	if err != nil {
+		h.logger.Error("failed to list PRs for threads", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	usernames := make(map[string]string)
+	usernameFor := func(repoFullName string) string {
+		if name, ok := usernames[repoFullName]; ok {
+			return name
+		}
+		name := h.usernameForRepo(r.Context(), repoFullName)
+		usernames[repoFullName] = name
+		return name
+	}
+
+	triage, err := h.reviewSvc.UnresolvedThreads(r.Context(), scopePRsToGroup(prs, h.activeRepoGroup(w, r)), usernameFor)
+	if err != nil {
+		h.logger.Error("failed to list unresolved threads", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	data := toThreadTriageViewModel(triage, usernameFor)
+	sync := h.syncStatus()
+	for i := range data.PRs {
+		p := &data.PRs[i]
+		reason := h.actionsDisabledReason(r.Context(), p.Owner+"/"+p.Repo, sync)
+		for j := range p.Files {
+			for k := range p.Files[j].Threads {
+				p.Files[j].Threads[k].RepliesDisabledReason = reason
+			}
+		}
+	}
+
+	if err := partials.ThreadsContent(data).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render threads", "error", err)
+	}
+}
+
+// ResolveThread handles POST /app/prs/{owner}/{repo}/{number}/comments/{rootID}/resolve.
+// It resolves the review thread on GitHub, records the resolution locally so
+// it shows before the next poll, and re-renders the thread.
+func (h *Handler) ResolveThread(w http.ResponseWriter, r *http.Request) {
+	owner, repo, number, ok := h.parsePRWriteRequest(w, r)
+	if !ok {
+		return
+	}
+
+	rootID, err := strconv.ParseInt(r.PathValue("rootID"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid comment ID", http.StatusBadRequest)
+		return
+	}
+
+	repoFullName := owner + "/" + repo
+	writer := h.requireWriter(w, r, repoFullName, "resolve threads")
+	if writer == nil {
+		return
+	}
+
+	if err := writer.ResolveReviewThread(r.Context(), repoFullName, number, rootID); err != nil {
+		h.logger.Error("failed to resolve review thread", "repo", repoFullName, "pr", number, "comment", rootID, "error", err)
+		writeErrorToast(w, http.StatusUnprocessableEntity, "Error: "+err.Error())
+		return
+	}
+
+	if h.reviewSvc != nil {
+		if err := h.reviewSvc.SetThreadResolved(r.Context(), rootID, true); err != nil {
+			h.logger.Warn("failed to store thread resolution", "comment", rootID, "error", err)
+		}
+	}
+
+	h.renderThread(w, r, repoFullName, number, rootID, owner, repo)
+}
+
+// toThreadTriageViewModel builds the threads page, grouping each PR's threads
+// by file in the order the service sorted them.
+func toThreadTriageViewModel(triage []application.PRThreads, usernameFor func(repoFullName string) string) vm.ThreadTriageViewModel {
+	data := vm.ThreadTriageViewModel{PRs: make([]vm.ThreadTriagePRViewModel, 0, len(triage))}
+	for _, t := range triage {
+		user := usernameFor(t.PR.RepoFullName)
+		owner, repo, _ := strings.Cut(t.PR.RepoFullName, "/")
+		p := vm.ThreadTriagePRViewModel{
+			Owner:      owner,
+			Repo:       repo,
+			Number:     t.PR.Number,
+			Title:      t.PR.Title,
+			Author:     t.PR.Author,
+			IsMine:     strings.EqualFold(t.PR.Author, user),
+			DetailPath: fmt.Sprintf("/app/prs/%s/%d", t.PR.RepoFullName, t.PR.Number),
+		}
+		for _, thread := range toThreadViewModels(t.Threads, t.PR, user) {
+			path := thread.RootComment.FilePath
+			if n := len(p.Files); n == 0 || p.Files[n-1].Path != path {
+				p.Files = append(p.Files, vm.ThreadFileViewModel{Path: path})
+			}
+			last := &p.Files[len(p.Files)-1]
+			last.Threads = append(last.Threads, thread)
+		}
+		data.ThreadCount += len(t.Threads)
+		data.PRs = append(data.PRs, p)
+	}
+	return data
+}
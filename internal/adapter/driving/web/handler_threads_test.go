@@ -0,0 +1,46 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestToThreadTriageViewModel(t *testing.T) {
+	thread := func(id int64, path string) application.CommentThread {
+		return application.CommentThread{RootComment: model.ReviewComment{ID: id, Path: path, Body: "hm @me"}}
+	}
+	triage := []application.PRThreads{
+		{
+			PR:      model.PullRequest{RepoFullName: "acme/api", Number: 7, Title: "Fix it", Author: "Me"},
+			Threads: []application.CommentThread{thread(1, "a.go"), thread(2, "a.go"), thread(3, "b.go")},
+		},
+		{
+			PR:      model.PullRequest{RepoFullName: "acme/web", Number: 3, Author: "carol"},
+			Threads: []application.CommentThread{thread(4, "")},
+		},
+	}
+
+	data := toThreadTriageViewModel(triage, func(string) string { return "me" })
+
+	assert.Equal(t, 4, data.ThreadCount)
+	require.Len(t, data.PRs, 2)
+	api := data.PRs[0]
+	assert.Equal(t, "acme", api.Owner)
+	assert.Equal(t, "api", api.Repo)
+	assert.Equal(t, "/app/prs/acme/api/7", api.DetailPath)
+	assert.True(t, api.IsMine)
+	require.Len(t, api.Files, 2)
+	assert.Equal(t, "a.go", api.Files[0].Path)
+	assert.Len(t, api.Files[0].Threads, 2)
+	assert.True(t, api.Files[0].Threads[0].RootComment.MentionsMe)
+	assert.Equal(t, "b.go", api.Files[1].Path)
+
+	assert.False(t, data.PRs[1].IsMine)
+	require.Len(t, data.PRs[1].Files, 1)
+	assert.Empty(t, data.PRs[1].Files[0].Path)
+}
@@ -0,0 +1,133 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithWatchStore enables per-PR watch states, which override whether a PR
+// counts as needing the user's attention. Without it the watch selector is
+// hidden and the route returns 503.
+func (h *Handler) WithWatchStore(store driven.WatchStore) *Handler {
+	h.watchStore = store
+	return h
+}
+
+// SetWatch handles POST /app/prs/{id}/watch with form value state set to
+// "watching", "unwatched", or empty for the default. It returns an OOB swap of
+// the PR list so card badges and counts follow.
+func (h *Handler) SetWatch(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid PR ID", http.StatusBadRequest)
+		return
+	}
+	if h.watchStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	state := model.WatchState(r.FormValue("state"))
+	if !state.IsValid() {
+		http.Error(w, "invalid watch state", http.StatusBadRequest)
+		return
+	}
+	if err := h.watchStore.SetWatchState(r.Context(), id, state); err != nil {
+		h.logger.Error("failed to set watch state", "pr_id", id, "state", state, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderPRListOOB(w, r)
+}
+
+// watchStates returns the watch state of every PR that has one. Failures are
+// logged and leave every PR in the default state.
+func (h *Handler) watchStates(ctx context.Context) map[int64]model.WatchState {
+	if h.watchStore == nil {
+		return nil
+	}
+	states, err := h.watchStore.ListWatchStates(ctx)
+	if err != nil {
+		h.logger.Warn("failed to list watch states", "error", err)
+		return nil
+	}
+	return states
+}
+
+// applyWatch sets the detail's watch state and offers the selector when
+// watch states are configured. Failures are logged and show the default.
+func (h *Handler) applyWatch(ctx context.Context, detail *vm.PRDetailViewModel) {
+	if h.watchStore == nil {
+		return
+	}
+	detail.WatchEnabled = true
+	state, err := h.watchStore.GetWatchState(ctx, detail.ID)
+	if err != nil {
+		h.logger.Warn("failed to get watch state", "pr_id", detail.ID, "error", err)
+		return
+	}
+	detail.Watch = state
+}
+
+// WithCheckWatchStore enables "notify when checks complete" requests. Without
+// it the toggle is hidden and the route returns 503.
+func (h *Handler) WithCheckWatchStore(store driven.CheckWatchStore) *Handler {
+	h.checkWatchStore = store
+	return h
+}
+
+// SetChecksWatch handles POST /app/prs/{id}/checks-watch with form value
+// watch set to "on" to be notified when the PR's checks finish, or empty to
+// stop. It returns the re-rendered toggle.
+func (h *Handler) SetChecksWatch(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		http.Error(w, "invalid PR ID", http.StatusBadRequest)
+		return
+	}
+	if h.checkWatchStore == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	watching := r.FormValue("watch") == "on"
+	if err := h.checkWatchStore.SetCheckWatch(r.Context(), id, watching); err != nil {
+		h.logger.Error("failed to set check watch", "pr_id", id, "watching", watching, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := components.ChecksWatchToggle(id, watching).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render checks watch toggle", "error", err)
+	}
+}
+
+// applyChecksWatch offers the checks watch toggle on open PRs when check
+// watches are configured. Failures are logged and show the PR unwatched.
+func (h *Handler) applyChecksWatch(ctx context.Context, detail *vm.PRDetailViewModel) {
+	if h.checkWatchStore == nil || detail.Status != string(model.PRStatusOpen) {
+		return
+	}
+	detail.ChecksWatchEnabled = true
+	watching, err := h.checkWatchStore.IsWatchingChecks(ctx, detail.ID)
+	if err != nil {
+		h.logger.Warn("failed to get check watch", "pr_id", detail.ID, "error", err)
+		return
+	}
+	detail.WatchingChecks = watching
+}
@@ -0,0 +1,116 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memoryWatchStore is an in-memory driven.WatchStore.
+type memoryWatchStore map[int64]model.WatchState
+
+func (m memoryWatchStore) SetWatchState(_ context.Context, prID int64, state model.WatchState) error {
+	if state == model.WatchStateDefault {
+		delete(m, prID)
+		return nil
+	}
+	m[prID] = state
+	return nil
+}
+
+func (m memoryWatchStore) GetWatchState(_ context.Context, prID int64) (model.WatchState, error) {
+	return m[prID], nil
+}
+
+func (m memoryWatchStore) ListWatchStates(_ context.Context) (map[int64]model.WatchState, error) {
+	return m, nil
+}
+
+// emptyPRStore is a driven.PRStore without PRs, enough to render the PR list.
+type emptyPRStore struct{ driven.PRStore }
+
+func (emptyPRStore) ListAll(context.Context) ([]model.PullRequest, error) { return nil, nil }
+
+func (emptyPRStore) ListIgnoredWithPRData(context.Context) ([]model.PullRequest, error) {
+	return nil, nil
+}
+func (emptyPRStore) CountByStatus(context.Context) ([]driven.PRStatusCount, error) { return nil, nil }
+
+func TestSetWatch(t *testing.T) {
+	post := func(h *Handler, id, body string) *httptest.ResponseRecorder {
+		req := preferenceRequest(http.MethodPost, "/app/prs/"+id+"/watch", body, nil)
+		req.SetPathValue("id", id)
+		rec := httptest.NewRecorder()
+		h.SetWatch(rec, req)
+		return rec
+	}
+
+	h := &Handler{logger: slog.Default(), prStore: emptyPRStore{}}
+	assert.Equal(t, http.StatusServiceUnavailable, post(h, "7", "state=watching").Code)
+
+	store := memoryWatchStore{}
+	h.WithWatchStore(store)
+	assert.Equal(t, http.StatusBadRequest, post(h, "x", "state=watching").Code)
+	assert.Equal(t, http.StatusBadRequest, post(h, "7", "state=muted").Code)
+
+	rec := post(h, "7", "state=watching")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `hx-swap-oob`, "the PR list is refreshed")
+	assert.Equal(t, memoryWatchStore{7: model.WatchStateWatching}, store)
+
+	post(h, "7", "state=")
+	assert.Empty(t, store, "an empty state restores the default")
+}
+
+// memoryCheckWatchStore is an in-memory driven.CheckWatchStore.
+type memoryCheckWatchStore map[int64]bool
+
+func (m memoryCheckWatchStore) SetCheckWatch(_ context.Context, prID int64, watching bool) error {
+	if !watching {
+		delete(m, prID)
+		return nil
+	}
+	m[prID] = true
+	return nil
+}
+
+func (m memoryCheckWatchStore) IsWatchingChecks(_ context.Context, prID int64) (bool, error) {
+	return m[prID], nil
+}
+
+func (m memoryCheckWatchStore) ListCheckWatches(_ context.Context) ([]model.CheckWatch, error) {
+	panic("unused")
+}
+
+func TestSetChecksWatch(t *testing.T) {
+	post := func(h *Handler, id, body string) *httptest.ResponseRecorder {
+		req := preferenceRequest(http.MethodPost, "/app/prs/"+id+"/checks-watch", body, nil)
+		req.SetPathValue("id", id)
+		rec := httptest.NewRecorder()
+		h.SetChecksWatch(rec, req)
+		return rec
+	}
+
+	h := &Handler{logger: slog.Default()}
+	assert.Equal(t, http.StatusServiceUnavailable, post(h, "7", "watch=on").Code)
+
+	store := memoryCheckWatchStore{}
+	h.WithCheckWatchStore(store)
+	assert.Equal(t, http.StatusBadRequest, post(h, "x", "watch=on").Code)
+
+	rec := post(h, "7", "watch=on")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Notifying when checks finish")
+	assert.Equal(t, memoryCheckWatchStore{7: true}, store)
+
+	rec = post(h, "7", "watch=")
+	assert.Contains(t, rec.Body.String(), "Notify when checks finish")
+	assert.Empty(t, store)
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// WithWhatsNewService enables the what's-new panel shown after upgrades.
+// Without it the routes return 503.
+func (h *Handler) WithWhatsNewService(svc *application.WhatsNewService) *Handler {
+	h.whatsNewSvc = svc
+	return h
+}
+
+// WhatsNew handles GET /app/whats-new.
+// The layout requests it on load; it renders the panel when there are
+// unacknowledged releases or migrations and 204 No Content otherwise.
+func (h *Handler) WhatsNew(w http.ResponseWriter, r *http.Request) {
+	if h.whatsNewSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	pending, err := h.whatsNewSvc.Pending(r.Context())
+	if err != nil {
+		h.logger.Error("failed to load what's new", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if pending.IsEmpty() {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	component := components.WhatsNewPanel(toWhatsNewViewModel(pending))
+	if err := component.Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render what's new", "error", err)
+	}
+}
+
+// DismissWhatsNew handles POST /app/whats-new/dismiss.
+// It acknowledges everything pending and returns an empty body so HTMX
+// removes the panel.
+func (h *Handler) DismissWhatsNew(w http.ResponseWriter, r *http.Request) {
+	if !validateCSRF(r) {
+		http.Error(w, errMsgCSRFInvalid, http.StatusForbidden)
+		return
+	}
+
+	if h.whatsNewSvc == nil {
+		http.Error(w, errMsgServiceUnavail, http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.whatsNewSvc.Dismiss(r.Context()); err != nil {
+		h.logger.Error("failed to dismiss what's new", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,42 @@
+package web
+
+import (
+	"io/fs"
+	"mime"
+	"net/http"
+	"regexp"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/static"
+)
+
+// The static file server picks content types by extension, and Go does not
+// know the web app manifest's.
+func init() {
+	_ = mime.AddExtensionType(".webmanifest", "application/manifest+json")
+}
+
+// swAssetPattern matches the quoted asset paths in the service worker's
+// precache list.
+var swAssetPattern = regexp.MustCompile(`'/static/([^'?]+)'`)
+
+// serviceWorkerHandler serves the service worker from the root path so its
+// scope covers the whole app, which a worker under /static/ could not.
+// It is never cached, so a new release takes effect on the next visit. Asset
+// paths are rewritten to their versioned URLs, which both matches what pages
+// request and changes the worker whenever an asset does, so it reinstalls.
+func serviceWorkerHandler(staticFS fs.FS) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		script, err := fs.ReadFile(staticFS, "js/sw.js")
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		script = swAssetPattern.ReplaceAllFunc(script, func(m []byte) []byte {
+			name := swAssetPattern.FindSubmatch(m)[1]
+			return []byte("'" + static.URL(string(name)) + "'")
+		})
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		_, _ = w.Write(script)
+	}
+}
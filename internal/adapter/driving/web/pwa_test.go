@@ -0,0 +1,45 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/static"
+)
+
+func TestServiceWorkerHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	serviceWorkerHandler(static.FS)(rec, httptest.NewRequest(http.MethodGet, "/sw.js", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/javascript; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	assert.Contains(t, rec.Body.String(), "addEventListener('fetch'")
+	assert.Contains(t, rec.Body.String(), "'"+static.URL("vendor/htmx.min.js")+"'", "precached assets use versioned URLs")
+}
+
+func TestStaticHandler(t *testing.T) {
+	handler := http.StripPrefix("/static/", staticHandler(static.FS))
+	hash := static.Hash("vendor/htmx.min.js")
+	require.NotEmpty(t, hash)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, static.URL("vendor/htmx.min.js"), nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, immutableCacheControl, rec.Header().Get("Cache-Control"))
+	assert.Equal(t, `"`+hash+`"`, rec.Header().Get("ETag"))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/static/vendor/htmx.min.js", nil))
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"), "unversioned URLs revalidate")
+
+	req := httptest.NewRequest(http.MethodGet, "/static/vendor/htmx.min.js", nil)
+	req.Header.Set("If-None-Match", `"`+hash+`"`)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
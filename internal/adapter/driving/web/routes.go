@@ -1,9 +1,9 @@
 package web
 
 import (
-	"io/fs"
-	"log"
 	"net/http"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/static"
 )
 
 // RegisterRoutes registers all web GUI routes on the provided mux.
@@ -11,25 +11,69 @@ import (
 // Static assets are served from the embedded filesystem at /static/*.
 func RegisterRoutes(mux *http.ServeMux, h *Handler) {
 	// Static assets (embedded via go:embed).
-	staticFS, err := fs.Sub(StaticFS, "static")
-	if err != nil {
-		log.Fatalf("failed to create static sub-filesystem: %v", err)
-	}
-	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(staticFS)))
+	mux.Handle("GET /static/", http.StripPrefix("/static/", staticHandler(static.FS)))
+	mux.HandleFunc("GET /sw.js", serviceWorkerHandler(static.FS))
 
 	// Page routes.
 	mux.HandleFunc("GET /{$}", h.Dashboard)
 
+	// Read-only PR list for iframing, authorized by a signed token.
+	mux.HandleFunc("GET /embed/{owner}/{repo}", h.Embed)
+
 	// HTMX partial routes.
 	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}", h.GetPRDetail)
 	mux.HandleFunc("GET /app/prs/search", h.SearchPRs)
+	mux.HandleFunc("GET /app/prs/compare", h.ComparePRs)
+
+	// PR detail sections, loaded after the detail header.
+	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}/activity", h.PRDetailActivity)
+	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}/checks", h.PRDetailChecks)
+	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}/health", h.PRDetailHealth)
 
 	// Repo management routes.
 	mux.HandleFunc("POST /app/repos", h.AddRepo)
+	mux.HandleFunc("POST /app/repos/import", h.ImportRepos)
 	mux.HandleFunc("DELETE /app/repos/{owner}/{repo}", h.RemoveRepo)
+	mux.HandleFunc("GET /app/repos/removed", h.RemovedRepos)
+	mux.HandleFunc("POST /app/repos/{owner}/{repo}/restore", h.RestoreRepo)
+	mux.HandleFunc("POST /app/settings/repos/bulk", h.BulkEditRepos)
 
 	// Settings / credential management routes.
 	mux.HandleFunc("POST /app/settings/github", h.SaveGitHubCredentials)
+	mux.HandleFunc("POST /app/settings/github/accounts", h.CreateGitHubAccount)
+	mux.HandleFunc("DELETE /app/settings/github/accounts/{id}", h.DeleteGitHubAccount)
+	mux.HandleFunc("POST /app/settings/github/repo-account", h.SaveGitHubRepoAccount)
+	mux.HandleFunc("POST /app/settings/repo-checks", h.SaveRepoCheckOverrides)
+	mux.HandleFunc("POST /app/settings/repo-stale", h.SaveRepoStalePolicy)
+	mux.HandleFunc("POST /app/settings/repo-pr-scope", h.SaveRepoPRScope)
+	mux.HandleFunc("POST /app/settings/quick-actions", h.SaveQuickActions)
+	mux.HandleFunc("POST /app/settings/approve", h.SaveApproveSettings)
+	mux.HandleFunc("POST /app/settings/card-layout", h.SaveCardLayout)
+	mux.HandleFunc("POST /app/settings/api-tokens", h.CreateAPIToken)
+	mux.HandleFunc("DELETE /app/settings/api-tokens/{id}", h.RevokeAPIToken)
+	mux.HandleFunc("POST /app/settings/signal-webhooks", h.CreateSignalWebhook)
+	mux.HandleFunc("DELETE /app/settings/signal-webhooks/{id}", h.DeleteSignalWebhook)
+	mux.HandleFunc("GET /app/settings/attention-rules", h.AttentionRules)
+	mux.HandleFunc("POST /app/settings/attention-rules", h.CreateAttentionRule)
+	mux.HandleFunc("POST /app/settings/attention-rules/validate", h.ValidateAttentionRule)
+	mux.HandleFunc("POST /app/settings/attention-rules/{id}/toggle", h.ToggleAttentionRule)
+	mux.HandleFunc("DELETE /app/settings/attention-rules/{id}", h.DeleteAttentionRule)
+	mux.HandleFunc("GET /app/settings/event-webhooks", h.EventWebhooks)
+	mux.HandleFunc("POST /app/settings/event-webhooks", h.CreateEventWebhook)
+	mux.HandleFunc("POST /app/settings/event-webhooks/{id}/toggle", h.ToggleEventWebhook)
+	mux.HandleFunc("DELETE /app/settings/event-webhooks/{id}", h.DeleteEventWebhook)
+	mux.HandleFunc("GET /app/settings/event-webhooks/{id}/deliveries", h.EventWebhookDeliveries)
+	mux.HandleFunc("GET /app/settings/reports", h.ScheduledReports)
+	mux.HandleFunc("GET /app/settings/system", h.SystemStatus)
+	mux.HandleFunc("GET /app/settings/bots", h.BotSettings)
+	mux.HandleFunc("POST /app/settings/bots", h.AddBot)
+	mux.HandleFunc("POST /app/settings/bots/{username}/nitpick-patterns", h.SaveBotNitpickPatterns)
+	mux.HandleFunc("DELETE /app/settings/bots/{username}", h.RemoveBot)
+	mux.HandleFunc("POST /app/settings/reports", h.CreateScheduledReport)
+	mux.HandleFunc("POST /app/settings/reports/preview", h.PreviewScheduledReport)
+	mux.HandleFunc("DELETE /app/settings/reports/{id}", h.DeleteScheduledReport)
+	mux.HandleFunc("POST /app/settings/reply-templates", h.CreateReplyTemplate)
+	mux.HandleFunc("DELETE /app/settings/reply-templates/{id}", h.DeleteReplyTemplate)
 
 	// Jira connection management routes.
 	mux.HandleFunc("POST /app/settings/jira/connections", h.CreateJiraConnection)
@@ -40,10 +84,24 @@ func RegisterRoutes(mux *http.ServeMux, h *Handler) {
 	// Jira comment route.
 	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/jira-comment", h.CreateJiraComment)
 
+	// PR card quick action routes.
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/refresh", h.RefreshPRCard)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/approve", h.ApprovePRCard)
+	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}/request-changes", h.RequestChangesDialog)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/request-changes", h.RequestChangesPRCard)
+
 	// PR ignore routes.
 	mux.HandleFunc("POST /app/prs/{id}/ignore", h.IgnorePR)
 	mux.HandleFunc("POST /app/prs/{id}/unignore", h.UnignorePR)
 
+	// PR watch routes.
+	mux.HandleFunc("POST /app/prs/{id}/watch", h.SetWatch)
+	mux.HandleFunc("POST /app/prs/{id}/checks-watch", h.SetChecksWatch)
+
+	// Competing PR link routes.
+	mux.HandleFunc("POST /app/prs/{id}/links", h.LinkPR)
+	mux.HandleFunc("DELETE /app/prs/{id}/links/{otherID}", h.UnlinkPR)
+
 	// Threshold settings routes.
 	mux.HandleFunc("POST /app/settings/thresholds/global", h.SaveGlobalThresholds)
 	mux.HandleFunc("POST /app/settings/thresholds/repo", h.SaveRepoThreshold)
@@ -51,7 +109,79 @@ func RegisterRoutes(mux *http.ServeMux, h *Handler) {
 
 	// Review write routes.
 	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/comments/{rootID}/reply", h.CreateReplyComment)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/comments/{rootID}/resolve", h.ResolveThread)
 	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/review", h.SubmitReview)
 	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/issue-comments", h.CreateIssueComment)
 	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/draft-toggle", h.ToggleDraftStatus)
+
+	// Composer autocomplete routes.
+	mux.HandleFunc("GET /app/autocomplete/emoji", h.AutocompleteEmoji)
+	mux.HandleFunc("GET /app/autocomplete/users", h.AutocompleteUsers)
+
+	// Reviewer suggestion routes.
+	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}/reviewers", h.SuggestedReviewers)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/reviewers", h.RequestReviewer)
+
+	// Label editor routes.
+	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}/labels", h.LabelEditor)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/labels", h.AddLabel)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/labels/remove", h.RemoveLabel)
+
+	// Milestone routes.
+	mux.HandleFunc("GET /app/prs/{owner}/{repo}/{number}/milestone", h.MilestonePicker)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/milestone", h.SetMilestone)
+
+	// Decisions log routes.
+	mux.HandleFunc("GET /app/decisions", h.Decisions)
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/comments/{rootID}/decision", h.MarkDecision)
+	mux.HandleFunc("DELETE /app/prs/{owner}/{repo}/{number}/comments/{rootID}/decision", h.ClearDecision)
+
+	// Comment search routes.
+	mux.HandleFunc("GET /app/comments", h.CommentSearch)
+
+	// Private PR note routes.
+	mux.HandleFunc("POST /app/prs/{owner}/{repo}/{number}/notes", h.CreatePRNote)
+	mux.HandleFunc("DELETE /app/prs/{owner}/{repo}/{number}/notes/{id}", h.DeletePRNote)
+
+	// Review history archive routes.
+	mux.HandleFunc("GET /app/history", h.ReviewHistory)
+
+	// Archive of all merged and closed PRs.
+	mux.HandleFunc("GET /app/archive", h.Archive)
+
+	// Opt-in reviewer leaderboard.
+	mux.HandleFunc("GET /app/stats", h.TeamStats)
+
+	// Board view of open PRs grouped by review state.
+	mux.HandleFunc("GET /app/board", h.Board)
+
+	// Daily focus list routes.
+	mux.HandleFunc("GET /app/today", h.Today)
+	mux.HandleFunc("POST /app/today/{id}/{state}", h.MarkFocus)
+	mux.HandleFunc("DELETE /app/today/{id}", h.ClearFocus)
+
+	// Unresolved review threads across PRs.
+	mux.HandleFunc("GET /app/threads", h.Threads)
+
+	// Background poll failures, raised as toasts.
+	mux.HandleFunc("GET /app/poll-errors", h.PollErrors)
+
+	// Attention inbox routes.
+	mux.HandleFunc("GET /app/inbox", h.Inbox)
+	mux.HandleFunc("GET /app/inbox/badge", h.InboxBadge)
+	mux.HandleFunc("POST /app/inbox/read-all", h.MarkInboxRead)
+	mux.HandleFunc("POST /app/inbox/{id}/{state}", h.SetInboxEventRead)
+
+	// Outbox routes.
+	mux.HandleFunc("GET /app/outbox", h.Outbox)
+	mux.HandleFunc("POST /app/outbox/{id}/retry", h.RetryOutboundAction)
+	mux.HandleFunc("DELETE /app/outbox/{id}", h.DiscardOutboundAction)
+
+	// Per-browser preference routes.
+	mux.HandleFunc("GET /app/preferences", h.GetPreferences)
+	mux.HandleFunc("POST /app/preferences", h.SavePreference)
+
+	// What's-new panel routes.
+	mux.HandleFunc("GET /app/whats-new", h.WhatsNew)
+	mux.HandleFunc("POST /app/whats-new/dismiss", h.DismissWhatsNew)
 }
@@ -0,0 +1,112 @@
+// Emoji shortcode and @mention autocomplete for comment composers.
+// Like stores.js, this file MUST be loaded with defer BEFORE alpine core so
+// the alpine:init listener registers the component in time.
+//
+// Usage: wrap a textarea in an element with x-data="composerAutocomplete"
+// and an optional data-repo="owner/name", and render the AutocompleteMenu
+// component inside it. The textarea's own x-model keeps working, since
+// picking a suggestion fires an input event.
+document.addEventListener('alpine:init', function() {
+    // A completion starts at the beginning of the text or after whitespace
+    // or an opening bracket: ":" followed by two shortcode characters, or
+    // "@" followed by one login character.
+    var base = document.documentElement.dataset.basePath || '';
+    var triggers = [
+        { pattern: /(^|[\s(\[])(:[a-z0-9_+\-]{2,})$/i, url: base + '/app/autocomplete/emoji' },
+        { pattern: /(^|[\s(\[])(@[a-z0-9\-]{1,39})$/i, url: base + '/app/autocomplete/users' }
+    ];
+
+    Alpine.data('composerAutocomplete', function() {
+        return {
+            items: [],
+            active: 0,
+            // start and end offsets of the text being completed.
+            start: 0,
+            end: 0,
+            // seq discards responses to lookups that were superseded.
+            seq: 0,
+
+            textarea() {
+                return this.$root.querySelector('textarea');
+            },
+
+            onInput() {
+                var el = this.textarea();
+                var before = el.value.slice(0, el.selectionStart);
+                for (var i = 0; i < triggers.length; i++) {
+                    var match = triggers[i].pattern.exec(before);
+                    if (match) {
+                        this.lookup(triggers[i].url, match[2], el.selectionStart);
+                        return;
+                    }
+                }
+                this.close();
+            },
+
+            lookup(url, text, end) {
+                var self = this;
+                var seq = ++this.seq;
+                var params = new URLSearchParams({ q: text.slice(1) });
+                if (this.$root.dataset.repo) {
+                    params.set('repo', this.$root.dataset.repo);
+                }
+                fetch(url + '?' + params.toString(), { headers: { 'Accept': 'application/json' } })
+                    .then(function(res) { return res.ok ? res.json() : []; })
+                    .then(function(items) {
+                        if (seq !== self.seq) {
+                            return;
+                        }
+                        self.items = items || [];
+                        self.active = 0;
+                        self.start = end - text.length;
+                        self.end = end;
+                    })
+                    .catch(function() { self.close(); });
+            },
+
+            onKeydown(event) {
+                if (this.items.length === 0) {
+                    return;
+                }
+                switch (event.key) {
+                case 'ArrowDown':
+                    this.active = (this.active + 1) % this.items.length;
+                    break;
+                case 'ArrowUp':
+                    this.active = (this.active + this.items.length - 1) % this.items.length;
+                    break;
+                case 'Enter':
+                case 'Tab':
+                    this.pick(this.active);
+                    break;
+                case 'Escape':
+                    this.close();
+                    break;
+                default:
+                    return;
+                }
+                event.preventDefault();
+            },
+
+            pick(index) {
+                var item = this.items[index];
+                var el = this.textarea();
+                if (!item || !el) {
+                    return;
+                }
+                var insert = item.value + ' ';
+                el.value = el.value.slice(0, this.start) + insert + el.value.slice(this.end);
+                var caret = this.start + insert.length;
+                el.setSelectionRange(caret, caret);
+                el.focus();
+                this.close();
+                el.dispatchEvent(new Event('input', { bubbles: true }));
+            },
+
+            close() {
+                this.seq++;
+                this.items = [];
+            }
+        };
+    });
+});
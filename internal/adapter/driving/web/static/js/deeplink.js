@@ -0,0 +1,29 @@
+// Keeps the open PR detail tab in the URL so a copied link reopens it.
+// Only PR detail URLs are touched; the reviews tab is the default and is
+// left out of the query string.
+function syncDetailTab(tab) {
+    var url = new URL(window.location.href);
+    var base = document.documentElement.dataset.basePath || '';
+    if (url.pathname.indexOf(base + '/app/prs/') !== 0) {
+        return;
+    }
+    if (tab === 'reviews') {
+        url.searchParams.delete('tab');
+    } else {
+        url.searchParams.set('tab', tab);
+    }
+    history.replaceState(history.state, '', url);
+}
+
+// Comment links point into the PR detail's activity tabs, which load after
+// the detail itself, so scroll to the linked comment once they arrive.
+document.addEventListener('htmx:afterSettle', function(event) {
+    var hash = window.location.hash;
+    if (!hash || event.detail.elt.id !== 'pr-activity') {
+        return;
+    }
+    var target = document.getElementById(decodeURIComponent(hash.slice(1)));
+    if (target) {
+        target.scrollIntoView({ block: 'center' });
+    }
+});
@@ -0,0 +1,64 @@
+// Keyboard triage for the attention inbox.
+// Like stores.js, this file MUST be loaded with defer BEFORE alpine core so
+// the alpine:init listener registers the component in time.
+document.addEventListener('alpine:init', function() {
+    Alpine.data('inboxTriage', function() {
+        return {
+            selected: 0,
+
+            rows() {
+                return Array.from(this.$root.querySelectorAll('[data-inbox-row]'));
+            },
+
+            // click presses the given action button of the selected row, or of
+            // the inbox itself when no row is needed.
+            click(action, inRow) {
+                var scope = inRow ? this.rows()[this.selected] : this.$root;
+                var button = scope && scope.querySelector('[data-inbox-action="' + action + '"]');
+                if (button && !button.disabled) {
+                    button.click();
+                }
+            },
+
+            move(delta) {
+                var count = this.rows().length;
+                if (count === 0) {
+                    return;
+                }
+                this.selected = Math.min(Math.max(this.selected + delta, 0), count - 1);
+                this.rows()[this.selected].scrollIntoView({ block: 'nearest' });
+            },
+
+            onKey(event) {
+                var target = event.target;
+                if (event.ctrlKey || event.metaKey || event.altKey ||
+                    target.closest('input, textarea, select, [contenteditable]')) {
+                    return;
+                }
+                switch (event.key) {
+                case 'j':
+                    this.move(1);
+                    break;
+                case 'k':
+                    this.move(-1);
+                    break;
+                case 'o':
+                    this.click('open', true);
+                    break;
+                case 'e':
+                    this.click('read', true);
+                    break;
+                case 'u':
+                    this.click('unread', true);
+                    break;
+                case 'A':
+                    this.click('read-all', false);
+                    break;
+                default:
+                    return;
+                }
+                event.preventDefault();
+            }
+        };
+    });
+});
@@ -0,0 +1,45 @@
+// Narrow-screen layout and PWA registration.
+// On small screens the sidebar and the detail area share the screen: opening
+// anything into #pr-detail shows the detail, and the back button or a swipe
+// to the right returns to the list. Like stores.js, this file MUST be loaded
+// with defer BEFORE alpine core.
+document.addEventListener('alpine:init', function() {
+    Alpine.store('layout', {
+        showDetail: false,
+        back() { this.showDetail = false; }
+    });
+});
+
+document.addEventListener('htmx:afterSwap', function(event) {
+    if (event.detail.target && event.detail.target.id === 'pr-detail') {
+        Alpine.store('layout').showDetail = true;
+    }
+});
+
+// A mostly horizontal swipe to the right over [data-swipe-back] goes back.
+(function() {
+    var startX = 0;
+    var startY = 0;
+    document.addEventListener('touchstart', function(event) {
+        var touch = event.changedTouches[0];
+        startX = touch.clientX;
+        startY = touch.clientY;
+    }, { passive: true });
+    document.addEventListener('touchend', function(event) {
+        if (!event.target.closest || !event.target.closest('[data-swipe-back]')) {
+            return;
+        }
+        var touch = event.changedTouches[0];
+        var dx = touch.clientX - startX;
+        var dy = touch.clientY - startY;
+        if (dx > 80 && Math.abs(dy) < 50) {
+            Alpine.store('layout').back();
+        }
+    }, { passive: true });
+})();
+
+if ('serviceWorker' in navigator) {
+    window.addEventListener('load', function() {
+        navigator.serviceWorker.register((document.documentElement.dataset.basePath || '') + '/sw.js').catch(function() {});
+    });
+}
@@ -6,6 +6,30 @@ document.addEventListener('alpine:init', function() {
         dark: Alpine.$persist(false).as('darkMode')
     });
 
+    // Preferences saved for this browser, such as collapsed sections. The
+    // dashboard renders the saved values; set() saves a change in the
+    // background.
+    var saved = document.getElementById('browser-preferences');
+    Alpine.store('prefs', {
+        values: (saved && JSON.parse(saved.textContent)) || {},
+        get(key, fallback) {
+            return Object.prototype.hasOwnProperty.call(this.values, key) ? this.values[key] : fallback;
+        },
+        set(key, value) {
+            value = String(value);
+            if (this.values[key] === value) {
+                return;
+            }
+            this.values[key] = value;
+            var csrf = document.cookie.match(/(?:^|;\s*)csrf_token=([^;]*)/);
+            fetch((document.documentElement.dataset.basePath || '') + '/app/preferences', {
+                method: 'POST',
+                headers: { 'X-CSRF-Token': csrf ? decodeURIComponent(csrf[1]) : '' },
+                body: new URLSearchParams({ key: key, value: value })
+            }).catch(function() {});
+        }
+    });
+
     Alpine.store('drawer', {
         open: false,
         section: 'credentials',
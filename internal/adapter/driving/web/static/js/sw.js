@@ -0,0 +1,78 @@
+// Service worker that makes ReviewHub installable as a PWA.
+// Served from /sw.js so its scope covers the whole app, and BASE is the
+// path the app is mounted at behind a reverse proxy. Static assets are
+// served from cache and refreshed in the background; pages always go to the
+// network so PR data is never stale, falling back to the cached dashboard
+// when offline. HTMX partials and API calls are not intercepted.
+var CACHE = 'reviewhub-v1';
+var BASE = new URL(self.registration.scope).pathname.replace(/\/$/, '');
+var SHELL = [
+    BASE + '/',
+    '/static/css/output.css',
+    '/static/vendor/htmx.min.js',
+    '/static/vendor/htmx-ext-alpine-morph.js',
+    '/static/vendor/alpine-morph.min.js',
+    '/static/vendor/alpine-persist.min.js',
+    '/static/vendor/alpine.min.js',
+    '/static/icons/icon.svg'
+];
+
+self.addEventListener('install', function(event) {
+    // Cache entries one by one so a missing asset does not fail the install.
+    event.waitUntil(caches.open(CACHE).then(function(cache) {
+        return Promise.all(SHELL.map(function(url) {
+            return cache.add(url).catch(function() {});
+        }));
+    }).then(function() {
+        return self.skipWaiting();
+    }));
+});
+
+self.addEventListener('activate', function(event) {
+    event.waitUntil(caches.keys().then(function(keys) {
+        return Promise.all(keys.filter(function(key) {
+            return key !== CACHE;
+        }).map(function(key) {
+            return caches.delete(key);
+        }));
+    }).then(function() {
+        return self.clients.claim();
+    }));
+});
+
+self.addEventListener('fetch', function(event) {
+    var request = event.request;
+    var url = new URL(request.url);
+    if (request.method !== 'GET' || url.origin !== self.location.origin) {
+        return;
+    }
+
+    if (url.pathname.indexOf(BASE + '/static/') === 0) {
+        event.respondWith(caches.open(CACHE).then(function(cache) {
+            return cache.match(request).then(function(cached) {
+                var fetched = fetch(request).then(function(response) {
+                    if (response.ok) {
+                        cache.put(request, response.clone());
+                    }
+                    return response;
+                });
+                return cached || fetched;
+            });
+        }));
+        return;
+    }
+
+    if (request.mode === 'navigate') {
+        event.respondWith(fetch(request).then(function(response) {
+            if (response.ok && url.pathname === BASE + '/') {
+                var copy = response.clone();
+                caches.open(CACHE).then(function(cache) {
+                    cache.put(BASE + '/', copy);
+                });
+            }
+            return response;
+        }).catch(function() {
+            return caches.match(BASE + '/');
+        }));
+    }
+});
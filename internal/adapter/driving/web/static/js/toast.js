@@ -0,0 +1,125 @@
+// Toast notifications.
+// A response raises a toast through its HX-Trigger header:
+//   HX-Trigger: {"toast": {"level": "error", "message": "...", "retry": true}}
+// level is success, info, warning, or error; retry offers to resend the
+// request that raised the toast. Failed requests without a toast get an
+// error toast from their response text, or from the data-error-message of
+// the element that sent them. Elements under data-inline-errors show their
+// own errors instead.
+// Like stores.js, this file MUST be loaded with defer BEFORE alpine core so
+// the alpine:init listener registers the store in time.
+document.addEventListener('alpine:init', function() {
+    var maxToasts = 5;
+    // Seconds each level stays up; errors stay until dismissed.
+    var lifetimes = { success: 4, info: 6, warning: 10 };
+    var nextID = 1;
+
+    Alpine.store('toasts', {
+        items: [],
+
+        add(toast) {
+            var item = {
+                id: nextID++,
+                level: toast.level || 'info',
+                message: toast.message || '',
+                retry: !!toast.retry && !!toast.request,
+                request: toast.request
+            };
+            this.items.push(item);
+            if (this.items.length > maxToasts) {
+                this.items.shift();
+            }
+            var seconds = lifetimes[item.level];
+            if (seconds) {
+                var store = this;
+                setTimeout(function() { store.dismiss(item.id); }, seconds * 1000);
+            }
+        },
+
+        dismiss(id) {
+            this.items = this.items.filter(function(t) { return t.id !== id; });
+        },
+
+        // retry resends the toast's request from the element that sent it,
+        // so it targets and swaps as before.
+        retry(toast) {
+            this.dismiss(toast.id);
+            var req = toast.request;
+            if (req.elt.isConnected) {
+                htmx.ajax(req.verb, req.path, { source: req.elt });
+            } else {
+                htmx.ajax(req.verb, req.path, { target: req.target, swap: 'none' });
+            }
+        }
+    });
+});
+
+(function() {
+    // requestOf describes an htmx request so its toast can resend it.
+    function requestOf(detail) {
+        var cfg = detail.requestConfig || {};
+        if (!cfg.verb || !cfg.path) {
+            return null;
+        }
+        return { verb: cfg.verb, path: cfg.path, elt: detail.elt, target: detail.target };
+    }
+
+    function showToast(toast) {
+        if (window.Alpine) {
+            Alpine.store('toasts').add(toast);
+        }
+    }
+
+    // responseMessage turns an error response, often an HTML fragment, into
+    // one line of text.
+    function responseMessage(xhr) {
+        var div = document.createElement('div');
+        div.innerHTML = xhr.responseText || '';
+        var text = (div.textContent || '').replace(/\s+/g, ' ').trim();
+        return text.length > 300 ? text.slice(0, 299) + '…' : text;
+    }
+
+    function inlineErrors(elt) {
+        return elt && elt.closest && elt.closest('[data-inline-errors]');
+    }
+
+    // Toasts raised by HX-Trigger: remember the request for Retry.
+    var lastRequest = null;
+    document.addEventListener('htmx:beforeOnLoad', function(event) {
+        lastRequest = requestOf(event.detail);
+    });
+    document.addEventListener('toast', function(event) {
+        var toast = Object.assign({}, event.detail);
+        delete toast.elt;
+        toast.request = lastRequest;
+        showToast(toast);
+    });
+
+    document.addEventListener('htmx:responseError', function(event) {
+        var xhr = event.detail.xhr;
+        var elt = event.detail.elt;
+        if (/"toast"/.test(xhr.getResponseHeader('HX-Trigger') || '') || inlineErrors(elt)) {
+            return;
+        }
+        var fallback = elt && elt.closest && elt.closest('[data-error-message]');
+        showToast({
+            level: 'error',
+            message: responseMessage(xhr) ||
+                (fallback ? fallback.dataset.errorMessage : 'Request failed (' + xhr.status + ').'),
+            retry: xhr.status >= 500,
+            request: requestOf(event.detail)
+        });
+    });
+
+    document.addEventListener('htmx:sendError', function(event) {
+        if (inlineErrors(event.detail.elt)) {
+            return;
+        }
+        showToast({
+            level: 'error',
+            message: 'Could not reach the server. Check your connection.',
+            retry: true,
+            request: requestOf(event.detail)
+        });
+    });
+})();
@@ -0,0 +1,57 @@
+// Package static embeds the web GUI's assets (vendor JS, app JS, CSS, icons,
+// and the web app manifest) and names them by content hash, so browsers can
+// cache them until a release changes them.
+package static
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"sync"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/basepath"
+)
+
+// FS holds the embedded assets, rooted at this directory.
+//
+//go:embed css icons js vendor manifest.webmanifest
+var FS embed.FS
+
+// hashLen is the number of hex digits of an asset's SHA-256 used as its version.
+const hashLen = 12
+
+// hashes maps each asset's path within FS to its content hash.
+var hashes = sync.OnceValue(func() map[string]string {
+	m := make(map[string]string)
+	_ = fs.WalkDir(FS, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(FS, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		m[name] = hex.EncodeToString(sum[:])[:hashLen]
+		return nil
+	})
+	return m
+})
+
+// Hash returns the content hash of the asset at name, e.g. "css/output.css",
+// or "" when there is no such asset.
+func Hash(name string) string {
+	return hashes()[name]
+}
+
+// URL returns the path the asset at name is served from, versioned by its
+// content hash so the URL changes whenever the content does, and under the
+// configured base path. Unknown assets, such as CSS not built yet in
+// development, get an unversioned path.
+func URL(name string) string {
+	if hash := Hash(name); hash != "" {
+		return basepath.URL("/static/" + name + "?v=" + hash)
+	}
+	return basepath.URL("/static/" + name)
+}
@@ -12,7 +12,10 @@ import "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmod
 import "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
 
 // Dashboard renders the main dashboard page with sidebar and detail area.
-func Dashboard(data viewmodel.DashboardViewModel) templ.Component {
+// detail, when non-nil, is rendered open in the detail area, as for a deep
+// link to a PR; it must keep id="pr-detail".
+// On narrow screens only one of them is shown at a time; see mobile.js.
+func Dashboard(data viewmodel.DashboardViewModel, detail templ.Component) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -33,6 +36,10 @@ func Dashboard(data viewmodel.DashboardViewModel) templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templ.JSONScript("browser-preferences", data.Preferences).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
 		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<div class=\"flex h-screen overflow-hidden\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
@@ -41,7 +48,36 @@ func Dashboard(data viewmodel.DashboardViewModel) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<!-- Main content area --><main class=\"flex-1 p-6 overflow-y-auto\"><div id=\"pr-detail\" class=\"flex items-center justify-center h-full\"><p class=\"text-gray-400 dark:text-gray-500 text-lg\">Select a PR to view details</p></div></main></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<!-- Main content area --><main data-swipe-back x-bind:class=\"$store.layout.showDetail ? '' : 'max-md:hidden'\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if detail != nil {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, " x-init=\"$store.layout.showDetail = true\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, " class=\"flex-1 p-6 max-md:p-3 overflow-y-auto\"><button type=\"button\" @click=\"$store.layout.back()\" class=\"md:hidden mb-3 inline-flex items-center gap-1 px-3 py-2 text-sm font-medium text-indigo-600 dark:text-indigo-400 rounded-md hover:bg-gray-100 dark:hover:bg-gray-800\" aria-label=\"Back to pull requests\"><svg class=\"w-4 h-4\" fill=\"none\" stroke=\"currentColor\" viewBox=\"0 0 24 24\"><path stroke-linecap=\"round\" stroke-linejoin=\"round\" stroke-width=\"2\" d=\"M15 19l-7-7 7-7\"></path></svg> Pull requests</button>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = components.SyncBanner(data.Sync).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if detail != nil {
+			templ_7745c5c3_Err = detail.Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		} else {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<div id=\"pr-detail\" class=\"flex items-center justify-center h-full\"><p class=\"text-gray-400 dark:text-gray-500 text-lg\">Select a PR to view details</p></div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</main></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -14,8 +14,8 @@ import "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templat
 // RepoList renders the watched repo list for the repo manager section.
 // This is the primary target for OOB swaps after add/remove operations.
 // Each repo row includes a gear icon to open a per-repo threshold override popover
-// and an optional Jira connection assignment dropdown.
-func RepoList(repos []viewmodel.RepoViewModel, jiraConnections []viewmodel.JiraConnectionViewModel) templ.Component {
+// and optional Jira connection and GitHub account assignment dropdowns.
+func RepoList(repos []viewmodel.RepoViewModel, jiraConnections []viewmodel.JiraConnectionViewModel, githubAccounts []viewmodel.GitHubAccountViewModel) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -37,7 +37,7 @@ func RepoList(repos []viewmodel.RepoViewModel, jiraConnections []viewmodel.JiraC
 		}
 		ctx = templ.ClearChildren(ctx)
 		for _, repo := range repos {
-			templ_7745c5c3_Err = components.RepoThresholdPopover(repo, jiraConnections).Render(ctx, templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = components.RepoThresholdPopover(repo, jiraConnections, githubAccounts).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -52,4 +52,43 @@ func RepoList(repos []viewmodel.RepoViewModel, jiraConnections []viewmodel.JiraC
 	})
 }
 
+// RepoListOOB wraps RepoList for an out-of-band swap of #repo-list, used when
+// the primary target of a response is elsewhere (e.g. the bulk editor status).
+func RepoListOOB(repos []viewmodel.RepoViewModel, jiraConnections []viewmodel.JiraConnectionViewModel, githubAccounts []viewmodel.GitHubAccountViewModel) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var2 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var2 == nil {
+			templ_7745c5c3_Var2 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<div id=\"repo-list\" hx-swap-oob=\"morph\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = RepoList(repos, jiraConnections, githubAccounts).Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</div>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
 var _ = templruntime.GeneratedTemplate
@@ -0,0 +1,126 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/templates/components"
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+// ToastLevel is the severity of a toast notification. It picks the toast's
+// colour and how long it stays up.
+type ToastLevel string
+
+// Toast severity levels.
+const (
+	ToastSuccess ToastLevel = "success"
+	ToastInfo    ToastLevel = "info"
+	ToastWarning ToastLevel = "warning"
+	ToastError   ToastLevel = "error"
+)
+
+// toastEvent is the client event a response raises through its HX-Trigger
+// header to show a toast; static/js/toast.js listens for it.
+const toastEvent = "toast"
+
+// Toast is one notification shown in the page's toast stack.
+type Toast struct {
+	Level   ToastLevel `json:"level"`
+	Message string     `json:"message"`
+	// Retry offers to resend the request that raised the toast.
+	Retry bool `json:"retry,omitempty"`
+}
+
+// triggerToast raises toast on the client through the HX-Trigger header. It
+// must be called before the response is written, and replaces any toast
+// already set.
+func triggerToast(w http.ResponseWriter, toast Toast) {
+	payload, err := json.Marshal(map[string]Toast{toastEvent: toast})
+	if err != nil {
+		return
+	}
+	w.Header().Set("HX-Trigger", asciiJSON(payload))
+}
+
+// writeErrorToast responds with status, an error toast carrying message, and
+// the message as a red fragment for callers that swap error responses.
+// Server errors offer a retry; client errors need the input fixed first.
+func writeErrorToast(w http.ResponseWriter, status int, message string) {
+	triggerToast(w, Toast{Level: ToastError, Message: message, Retry: status >= http.StatusInternalServerError})
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<span class="text-red-600 text-sm">%s</span>`, html.EscapeString(message))
+}
+
+// asciiJSON escapes the non-ASCII runes of JSON text as \u sequences, since
+// browsers decode header values as Latin-1.
+func asciiJSON(payload []byte) string {
+	var b strings.Builder
+	for _, r := range string(payload) {
+		switch {
+		case r < 0x80:
+			b.WriteRune(r)
+		case r > 0xFFFF:
+			r -= 0x10000
+			fmt.Fprintf(&b, `\u%04x\u%04x`, 0xD800+(r>>10), 0xDC00+(r&0x3FF))
+		default:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		}
+	}
+	return b.String()
+}
+
+// PollErrors handles GET /app/poll-errors?since=, polled by the toast stack.
+// It raises a warning toast for repositories whose background poll failed
+// after since, a Unix time, and returns the poller with since moved to now.
+// Without since it only starts the clock.
+func (h *Handler) PollErrors(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	if v := r.URL.Query().Get("since"); v != "" && h.pollSvc != nil {
+		since, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid since", http.StatusBadRequest)
+			return
+		}
+		if toast, ok := pollErrorToast(h.pollSvc.Schedules(), time.Unix(since, 0)); ok {
+			triggerToast(w, toast)
+		}
+	}
+
+	if err := components.PollErrorPoller(now.Unix()).Render(r.Context(), w); err != nil {
+		h.logger.Error("failed to render poll error poller", "error", err)
+	}
+}
+
+// pollErrorToast summarizes the polls in schedules that failed after since.
+// ok is false when none did.
+func pollErrorToast(schedules map[string]application.ScheduleInfo, since time.Time) (toast Toast, ok bool) {
+	var failed []string
+	for repo, sched := range schedules {
+		if sched.LastFailedAt.After(since) {
+			failed = append(failed, repo)
+		}
+	}
+	switch len(failed) {
+	case 0:
+		return Toast{}, false
+	case 1:
+		sched := schedules[failed[0]]
+		return Toast{
+			Level:   ToastWarning,
+			Message: fmt.Sprintf("Polling %s failed: %s. Next try at %s.", failed[0], sched.LastError, sched.NextPollAt.Format("15:04")),
+		}, true
+	default:
+		sort.Strings(failed)
+		return Toast{
+			Level:   ToastWarning,
+			Message: fmt.Sprintf("Polling failed for %d repositories (%s). Hover a repository for details.", len(failed), strings.Join(failed, ", ")),
+		}, true
+	}
+}
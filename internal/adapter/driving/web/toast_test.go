@@ -0,0 +1,55 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+func TestWriteErrorToast(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeErrorToast(rec, http.StatusUnprocessableEntity, "Invalid credentials — check <token>")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, `<span class="text-red-600 text-sm">Invalid credentials — check &lt;token&gt;</span>`, rec.Body.String())
+
+	header := rec.Header().Get("HX-Trigger")
+	assert.NotContains(t, header, "—", "header values must stay ASCII")
+	var trigger map[string]Toast
+	require.NoError(t, json.Unmarshal([]byte(header), &trigger))
+	assert.Equal(t, Toast{Level: ToastError, Message: "Invalid credentials — check <token>"}, trigger[toastEvent])
+
+	rec = httptest.NewRecorder()
+	writeErrorToast(rec, http.StatusInternalServerError, "failed")
+	require.NoError(t, json.Unmarshal([]byte(rec.Header().Get("HX-Trigger")), &trigger))
+	assert.True(t, trigger[toastEvent].Retry, "server errors offer a retry")
+}
+
+func TestPollErrorToast(t *testing.T) {
+	since := time.Date(2026, 10, 1, 12, 0, 0, 0, time.Local)
+	schedules := map[string]application.ScheduleInfo{
+		"org/old": {LastFailedAt: since.Add(-time.Minute), LastError: "timeout"},
+		"org/ok":  {},
+	}
+
+	_, ok := pollErrorToast(schedules, since)
+	assert.False(t, ok, "failures before since were already reported")
+
+	schedules["org/new"] = application.ScheduleInfo{LastFailedAt: since.Add(time.Minute), LastError: "502 Bad Gateway", NextPollAt: since.Add(3 * time.Minute)}
+	toast, ok := pollErrorToast(schedules, since)
+	require.True(t, ok)
+	assert.Equal(t, ToastWarning, toast.Level)
+	assert.Equal(t, "Polling org/new failed: 502 Bad Gateway. Next try at 12:03.", toast.Message)
+
+	schedules["org/also"] = application.ScheduleInfo{LastFailedAt: since.Add(time.Second)}
+	toast, ok = pollErrorToast(schedules, since)
+	require.True(t, ok)
+	assert.Equal(t, "Polling failed for 2 repositories (org/also, org/new). Hover a repository for details.", toast.Message)
+}
@@ -2,12 +2,15 @@ package web
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
 	"github.com/ericfisherdev/mygitpanel/internal/application"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
 )
 
 // toPRCardViewModel converts a single domain PullRequest to a PRCardViewModel.
@@ -35,9 +38,129 @@ func toPRCardViewModel(pr model.PullRequest, signals model.AttentionSignals) vm.
 		URL:                   pr.URL,
 		DetailPath:            fmt.Sprintf("/app/prs/%s/%d", pr.RepoFullName, pr.Number),
 		Attention:             signals,
+		ExternalContributor:   pr.AuthorAssociation.IsExternal(),
+		Checklist:             pr.Checklist,
+		Branch:                pr.Branch,
+		CheckoutCommand:       checkoutCommand(pr.Number, pr.Branch),
+		SizeLabel:             fmt.Sprintf("+%d −%d", pr.Additions, pr.Deletions),
+		JiraKey:               pr.JiraKey,
 	}
 }
 
+// slaBadge returns the card badge text for a first-review SLA status and
+// whether it is overdue. Only pending and overdue PRs get a badge; once
+// reviewed, the SLA no longer needs the reader's attention.
+func slaBadge(status model.SLAStatus, now time.Time) (label string, overdue bool) {
+	switch status.State {
+	case model.SLAPending:
+		return formatShortDuration(status.Deadline.Sub(now)) + " left", false
+	case model.SLAOverdue:
+		return "overdue " + formatShortDuration(now.Sub(status.Deadline)), true
+	default:
+		return "", false
+	}
+}
+
+// formatShortDuration renders d compactly: minutes under an hour, hours under
+// two days, then days.
+func formatShortDuration(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return strconv.Itoa(max(int(d.Minutes()), 1)) + "m"
+	case d < 48*time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h"
+	default:
+		return strconv.Itoa(int(d.Hours()/24)) + "d"
+	}
+}
+
+// toPendingReviewerViewModels lists a PR's pending review requests, oldest
+// first. Team slugs are shown under the repository owner, as GitHub does.
+func toPendingReviewerViewModels(requests []model.ReviewRequest, owner string, now time.Time) []vm.PendingReviewerViewModel {
+	sorted := append([]model.ReviewRequest(nil), requests...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].RequestedAt.Before(sorted[j].RequestedAt) })
+
+	out := make([]vm.PendingReviewerViewModel, 0, len(sorted))
+	for _, req := range sorted {
+		name := req.Reviewer
+		if req.IsTeam && owner != "" {
+			name = owner + "/" + req.Reviewer
+		}
+		out = append(out, vm.PendingReviewerViewModel{
+			Name:   name,
+			IsTeam: req.IsTeam,
+			Age:    formatShortDuration(now.Sub(req.RequestedAt)),
+		})
+	}
+	return out
+}
+
+// checkoutCommand builds a command that checks out a PR's head locally.
+// Fetching pull/N/head works for fork PRs too, whose branch is not on origin.
+func checkoutCommand(number int, branch string) string {
+	if branch == "" {
+		branch = fmt.Sprintf("pr-%d", number)
+	}
+	local := shellQuote(branch)
+	return fmt.Sprintf("git fetch origin pull/%d/head:%s && git checkout %s", number, local, local)
+}
+
+// shellQuote single-quotes s unless it consists only of characters that are
+// safe unquoted in a POSIX shell. Branch names may legally contain $, ;, &, etc.
+func shellQuote(s string) string {
+	safe := true
+	for _, ch := range s {
+		if !validate.IsValidRepoChar(ch) && ch != '/' {
+			safe = false
+			break
+		}
+	}
+	if safe && s != "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// toCardFieldOptions builds the card field rows for the settings drawer: the
+// visible fields first in their chosen order, then the hidden ones.
+func toCardFieldOptions(enabled []model.CardField) []vm.CardFieldOptionViewModel {
+	options := make([]vm.CardFieldOptionViewModel, 0, len(model.AllCardFields()))
+	on := make(map[model.CardField]bool, len(enabled))
+	for _, f := range enabled {
+		on[f] = true
+		options = append(options, vm.CardFieldOptionViewModel{Field: f, Label: f.Label(), Enabled: true})
+	}
+	for _, f := range model.AllCardFields() {
+		if !on[f] {
+			options = append(options, vm.CardFieldOptionViewModel{Field: f, Label: f.Label()})
+		}
+	}
+	return options
+}
+
+// toQuickActionSettings builds the quick action section of the settings drawer.
+func toQuickActionSettings(enabled []model.QuickAction, approve model.ApproveSettings) vm.QuickActionSettingsViewModel {
+	return vm.QuickActionSettingsViewModel{
+		Actions:        toQuickActionOptions(enabled),
+		ApproveMessage: approve.Message,
+		ApproveConfirm: approve.Confirm,
+	}
+}
+
+// toQuickActionOptions builds the quick action checkboxes for the settings drawer.
+func toQuickActionOptions(enabled []model.QuickAction) []vm.QuickActionOptionViewModel {
+	on := make(map[model.QuickAction]bool, len(enabled))
+	for _, a := range enabled {
+		on[a] = true
+	}
+
+	options := make([]vm.QuickActionOptionViewModel, 0, len(model.AllQuickActions()))
+	for _, a := range model.AllQuickActions() {
+		options = append(options, vm.QuickActionOptionViewModel{Action: a, Label: a.Label(), Enabled: on[a]})
+	}
+	return options
+}
+
 // toPRDetailViewModel converts domain data into a fully enriched PRDetailViewModel.
 // Review enrichment failure is non-fatal: pass nil for summary/checkRuns if unavailable.
 // authenticatedUser is used to set IsOwnPR; pass empty string if unauthenticated.
@@ -45,7 +168,6 @@ func toPRDetailViewModel(
 	pr model.PullRequest,
 	summary *application.PRReviewSummary,
 	checkRuns []model.CheckRun,
-	botUsernames []string,
 	authenticatedUser string,
 ) vm.PRDetailViewModel {
 	card := toPRCardViewModel(pr, model.AttentionSignals{})
@@ -68,7 +190,6 @@ func toPRDetailViewModel(
 		PRCardViewModel: card,
 		Owner:           owner,
 		RepoName:        repoName,
-		Branch:          pr.Branch,
 		BaseBranch:      pr.BaseBranch,
 		HeadSHA:         shortSHA,
 		Additions:       pr.Additions,
@@ -80,12 +201,14 @@ func toPRDetailViewModel(
 		IssueComments:   []vm.IssueCommentViewModel{},
 		CheckRuns:       []vm.CheckRunViewModel{},
 		Suggestions:     []vm.SuggestionViewModel{},
+
+		PendingReviewers: toPendingReviewerViewModels(pr.ReviewRequests, owner, time.Now()),
 	}
 
 	if summary != nil {
-		detail.Reviews = toReviewViewModels(summary.Reviews, headSHA, botUsernames)
-		detail.Threads = toThreadViewModels(summary.Threads)
-		detail.IssueComments = toIssueCommentViewModels(summary.IssueComments)
+		detail.Reviews = toReviewViewModels(summary.Reviews, headSHA)
+		detail.Threads = toThreadViewModels(summary.Threads, pr, authenticatedUser)
+		detail.IssueComments = toIssueCommentViewModels(summary.IssueComments, summary.IssueCommentRepeats, pr, authenticatedUser)
 		detail.Suggestions = toSuggestionViewModels(summary.Suggestions)
 		detail.ReviewStatus = string(summary.ReviewStatus)
 		detail.HasBotReview = summary.HasBotReview
@@ -103,11 +226,10 @@ func toPRDetailViewModel(
 }
 
 // toReviewViewModels converts domain Reviews to ReviewViewModels.
-func toReviewViewModels(reviews []model.Review, headSHA string, botUsernames []string) []vm.ReviewViewModel {
+func toReviewViewModels(reviews []model.Review, headSHA string) []vm.ReviewViewModel {
 	vms := make([]vm.ReviewViewModel, 0, len(reviews))
 	for _, r := range reviews {
 		isOutdated := r.CommitID != "" && r.CommitID != headSHA
-		isNitpick := application.IsNitpickComment(r.ReviewerLogin, r.Body, botUsernames)
 
 		vms = append(vms, vm.ReviewViewModel{
 			ID:          r.ID,
@@ -119,24 +241,31 @@ func toReviewViewModels(reviews []model.Review, headSHA string, botUsernames []s
 			SubmittedAt: r.SubmittedAt.UTC().Format(time.RFC3339),
 			IsBot:       r.IsBot,
 			IsOutdated:  isOutdated,
-			IsNitpick:   isNitpick,
+			IsNitpick:   r.IsNitpick,
 		})
 	}
 	return vms
 }
 
-// toThreadViewModels converts application CommentThreads to ThreadViewModels.
-func toThreadViewModels(threads []application.CommentThread) []vm.ThreadViewModel {
+// toThreadViewModels converts pr's application CommentThreads to
+// ThreadViewModels. Comments that @mention user are flagged for highlighting.
+func toThreadViewModels(threads []application.CommentThread, pr model.PullRequest, user string) []vm.ThreadViewModel {
 	vms := make([]vm.ThreadViewModel, 0, len(threads))
 	for _, t := range threads {
 		replies := make([]vm.ReviewCommentViewModel, 0, len(t.Replies))
 		for _, r := range t.Replies {
-			replies = append(replies, toReviewCommentViewModel(r))
+			replies = append(replies, toReviewCommentViewModel(r, pr, user))
+		}
+
+		var repeats []vm.ReviewCommentViewModel
+		for _, r := range t.Repeats {
+			repeats = append(repeats, toReviewCommentViewModel(r, pr, user))
 		}
 
 		vms = append(vms, vm.ThreadViewModel{
-			RootComment:  toReviewCommentViewModel(t.RootComment),
+			RootComment:  toReviewCommentViewModel(t.RootComment, pr, user),
 			Replies:      replies,
+			Repeats:      repeats,
 			IsResolved:   t.IsResolved,
 			CommentCount: 1 + len(t.Replies),
 		})
@@ -144,9 +273,9 @@ func toThreadViewModels(threads []application.CommentThread) []vm.ThreadViewMode
 	return vms
 }
 
-// toReviewCommentViewModel converts a domain ReviewComment to a ReviewCommentViewModel.
-func toReviewCommentViewModel(c model.ReviewComment) vm.ReviewCommentViewModel {
-	return vm.ReviewCommentViewModel{
+// toReviewCommentViewModel converts a domain ReviewComment on pr to a ReviewCommentViewModel.
+func toReviewCommentViewModel(c model.ReviewComment, pr model.PullRequest, user string) vm.ReviewCommentViewModel {
+	item := vm.ReviewCommentViewModel{
 		ID:           c.ID,
 		Author:       c.Author,
 		Body:         c.Body,
@@ -158,26 +287,51 @@ func toReviewCommentViewModel(c model.ReviewComment) vm.ReviewCommentViewModel {
 		DiffHunkHTML: RenderDiffHunk(c.DiffHunk),
 		CommitID:     c.CommitID,
 		IsOutdated:   c.IsOutdated,
+		MentionsMe:   application.MentionsLogin(c.Body, user),
+		PendingSync:  c.PendingSync,
 		CreatedAt:    c.CreatedAt.UTC().Format(time.RFC3339),
+		HTMLURL:      c.HTMLURL,
 	}
+	if !c.PendingSync {
+		item.Permalink = commentPermalink(pr, fmt.Sprintf("comment-%d", c.ID))
+	}
+	return item
 }
 
-// toIssueCommentViewModels converts domain IssueComments to IssueCommentViewModels.
-func toIssueCommentViewModels(comments []model.IssueComment) []vm.IssueCommentViewModel {
+// toIssueCommentViewModels converts pr's domain IssueComments to
+// IssueCommentViewModels, attaching each kept bot comment's earlier postings
+// from repeats. Comments that @mention user are flagged for highlighting.
+func toIssueCommentViewModels(comments []model.IssueComment, repeats map[int64][]model.IssueComment, pr model.PullRequest, user string) []vm.IssueCommentViewModel {
 	vms := make([]vm.IssueCommentViewModel, 0, len(comments))
 	for _, c := range comments {
-		vms = append(vms, vm.IssueCommentViewModel{
-			ID:        c.ID,
-			Author:    c.Author,
-			Body:      c.Body,
-			BodyHTML:  RenderMarkdown(c.Body),
-			IsBot:     c.IsBot,
-			CreatedAt: c.CreatedAt.UTC().Format(time.RFC3339),
-		})
+		item := toIssueCommentViewModel(c, pr, user)
+		for _, r := range repeats[c.ID] {
+			item.Repeats = append(item.Repeats, toIssueCommentViewModel(r, pr, user))
+		}
+		vms = append(vms, item)
 	}
 	return vms
 }
 
+// toIssueCommentViewModel converts a domain IssueComment on pr to an IssueCommentViewModel.
+func toIssueCommentViewModel(c model.IssueComment, pr model.PullRequest, user string) vm.IssueCommentViewModel {
+	item := vm.IssueCommentViewModel{
+		ID:          c.ID,
+		Author:      c.Author,
+		Body:        c.Body,
+		BodyHTML:    RenderMarkdown(c.Body),
+		IsBot:       c.IsBot,
+		MentionsMe:  application.MentionsLogin(c.Body, user),
+		PendingSync: c.PendingSync,
+		CreatedAt:   c.CreatedAt.UTC().Format(time.RFC3339),
+		HTMLURL:     c.HTMLURL,
+	}
+	if !c.PendingSync {
+		item.Permalink = commentPermalink(pr, fmt.Sprintf("issuecomment-%d", c.ID))
+	}
+	return item
+}
+
 // toCheckRunViewModels converts domain CheckRuns to CheckRunViewModels.
 func toCheckRunViewModels(runs []model.CheckRun) []vm.CheckRunViewModel {
 	vms := make([]vm.CheckRunViewModel, 0, len(runs))
@@ -208,3 +362,278 @@ func toSuggestionViewModels(suggestions []application.Suggestion) []vm.Suggestio
 	}
 	return vms
 }
+
+// toCompetingPRsViewModel converts the competitors of prID into the detail-panel section.
+// The "compare all" path includes prID plus as many competitors as the comparison allows.
+func toCompetingPRsViewModel(prID int64, competing []application.CompetingPR) vm.CompetingPRsViewModel {
+	section := vm.CompetingPRsViewModel{
+		Enabled: true,
+		PRID:    prID,
+		Items:   make([]vm.CompetingPRViewModel, 0, len(competing)),
+	}
+
+	allIDs := []int64{prID}
+	for _, c := range competing {
+		section.Items = append(section.Items, vm.CompetingPRViewModel{
+			PRCardViewModel: toPRCardViewModel(c.PR, model.AttentionSignals{}),
+			ManualLink:      c.ManualLink,
+			SharedJiraKey:   c.SharedJiraKey,
+			ComparePath:     comparePath([]int64{prID, c.PR.ID}),
+		})
+		if len(allIDs) < application.MaxComparedPRs {
+			allIDs = append(allIDs, c.PR.ID)
+		}
+	}
+
+	if len(allIDs) > 1 {
+		section.CompareAllPath = comparePath(allIDs)
+	}
+	return section
+}
+
+// toPRComparisonViewModel converts comparison results into table columns.
+func toPRComparisonViewModel(comparisons []application.PRComparison) vm.PRComparisonViewModel {
+	result := vm.PRComparisonViewModel{
+		Columns: make([]vm.PRComparisonColumnViewModel, 0, len(comparisons)),
+	}
+
+	for _, c := range comparisons {
+		card := toPRCardViewModel(c.PR, model.AttentionSignals{})
+		card.ReviewStatus = string(c.ReviewStatus)
+
+		result.Columns = append(result.Columns, vm.PRComparisonColumnViewModel{
+			PRCardViewModel:   card,
+			Additions:         c.PR.Additions,
+			Deletions:         c.PR.Deletions,
+			ChangedFiles:      c.PR.ChangedFiles,
+			ChecksPassed:      c.ChecksPassed,
+			ChecksFailed:      c.ChecksFailed,
+			ChecksPending:     c.ChecksPending,
+			Approvals:         c.Approvals,
+			ChangesRequested:  c.ChangesRequested,
+			UnresolvedThreads: c.UnresolvedThreads,
+		})
+	}
+
+	if len(result.Columns) > 0 {
+		result.BackPath = result.Columns[0].DetailPath
+	}
+	return result
+}
+
+// comparePath builds the comparison URL for the given PR IDs.
+func comparePath(ids []int64) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.FormatInt(id, 10))
+	}
+	return "/app/prs/compare?ids=" + strings.Join(parts, ",")
+}
+
+// toReviewHistoryViewModel converts archived PRs, keeping the store's
+// most-recently-reviewed-first order.
+func toReviewHistoryViewModel(items []model.ReviewedPR, repos []string, repo, query string, truncated bool) vm.ReviewHistoryViewModel {
+	result := vm.ReviewHistoryViewModel{
+		Query:     query,
+		Repo:      repo,
+		Repos:     repos,
+		Items:     make([]vm.ReviewedPRViewModel, 0, len(items)),
+		Truncated: truncated,
+	}
+
+	for _, item := range items {
+		pr := item.PullRequest
+		v := vm.ReviewedPRViewModel{
+			Repository:     pr.RepoFullName,
+			Number:         pr.Number,
+			Title:          pr.Title,
+			Author:         pr.Author,
+			Status:         string(pr.Status),
+			URL:            pr.URL,
+			DetailPath:     fmt.Sprintf("/app/prs/%s/%d", pr.RepoFullName, pr.Number),
+			Verdict:        strings.ReplaceAll(string(item.LastState), "_", " "),
+			LastReviewedAt: item.LastReviewedAt.UTC().Format(time.RFC3339),
+			CommentCount:   item.CommentCount,
+		}
+		for _, d := range item.Decisions {
+			v.Decisions = append(v.Decisions, d.Summary)
+		}
+		result.Items = append(result.Items, v)
+	}
+	return result
+}
+
+// toArchivedPRViewModels converts archived PRs for the archive page.
+func toArchivedPRViewModels(prs []model.PullRequest) []vm.ArchivedPRViewModel {
+	items := make([]vm.ArchivedPRViewModel, 0, len(prs))
+	for _, pr := range prs {
+		item := vm.ArchivedPRViewModel{
+			Repository: pr.RepoFullName,
+			Number:     pr.Number,
+			Title:      pr.Title,
+			Author:     pr.Author,
+			Status:     string(pr.Status),
+			URL:        pr.URL,
+			DetailPath: fmt.Sprintf("/app/prs/%s/%d", pr.RepoFullName, pr.Number),
+			ClosedAt:   pr.ClosedAt.UTC().Format("2006-01-02"),
+		}
+		if d, ok := pr.TimeToMerge(); ok {
+			item.TimeToMerge = formatShortDuration(d)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// toMergeHistoryViewModels converts per-repository merge history.
+func toMergeHistoryViewModels(history []model.RepoMergeHistory) []vm.RepoMergeHistoryViewModel {
+	result := make([]vm.RepoMergeHistoryViewModel, 0, len(history))
+	for _, h := range history {
+		v := vm.RepoMergeHistoryViewModel{Repository: h.RepoFullName, Merged: h.Merged, Closed: h.Closed}
+		if h.Merged > 0 {
+			v.MedianTimeToMerge = formatShortDuration(h.MedianTimeToMerge)
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// toReviewerStatsViewModels converts ranked reviewer stats to leaderboard rows.
+func toReviewerStatsViewModels(stats []model.ReviewerStats) []vm.ReviewerStatsViewModel {
+	rows := make([]vm.ReviewerStatsViewModel, 0, len(stats))
+	for i, s := range stats {
+		row := vm.ReviewerStatsViewModel{
+			Rank:               i + 1,
+			Login:              s.Login,
+			ReviewsGiven:       s.ReviewsGiven,
+			PRsReviewed:        s.PRsReviewed,
+			SuggestionsApplied: s.SuggestionsApplied,
+		}
+		if s.PRsReviewed > 0 {
+			row.AvgResponseTime = formatShortDuration(s.AvgResponseTime)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// toDecisionsViewModel groups search results by repository. Groups are sorted
+// by name; within a group decisions keep the store's newest-first order.
+func toDecisionsViewModel(decisions []model.Decision, repos []string, repo, query string) vm.DecisionsViewModel {
+	result := vm.DecisionsViewModel{
+		Query:  query,
+		Repo:   repo,
+		Repos:  repos,
+		Groups: []vm.DecisionGroupViewModel{},
+		Total:  len(decisions),
+	}
+
+	groupIndex := make(map[string]int)
+	for _, d := range decisions {
+		i, ok := groupIndex[d.RepoFullName]
+		if !ok {
+			i = len(result.Groups)
+			groupIndex[d.RepoFullName] = i
+			result.Groups = append(result.Groups, vm.DecisionGroupViewModel{Repository: d.RepoFullName})
+		}
+		result.Groups[i].Decisions = append(result.Groups[i].Decisions, vm.DecisionViewModel{
+			Summary:    d.Summary,
+			PRNumber:   d.PRNumber,
+			PRTitle:    d.PRTitle,
+			FilePath:   d.FilePath,
+			DetailPath: fmt.Sprintf("/app/prs/%s/%d", d.RepoFullName, d.PRNumber),
+			UpdatedAt:  d.UpdatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+
+	sort.Slice(result.Groups, func(i, j int) bool {
+		return result.Groups[i].Repository < result.Groups[j].Repository
+	})
+	return result
+}
+
+// inboxKindLabels are the headings shown for each inbox event kind.
+var inboxKindLabels = map[model.InboxEventKind]string{
+	model.InboxReviewRequested:  "Review requested",
+	model.InboxCIFailed:         "CI failed",
+	model.InboxNewComment:       "New comment",
+	model.InboxApprovalReceived: "Approved",
+	model.InboxSLABreached:      "SLA breached",
+	model.InboxChecksFinished:   "Checks finished",
+	model.InboxStaleMarked:      "Stale",
+}
+
+// toInboxEventViewModel converts a domain InboxEvent for display.
+func toInboxEventViewModel(e model.InboxEvent) vm.InboxEventViewModel {
+	label := inboxKindLabels[e.Kind]
+	if label == "" {
+		label = string(e.Kind)
+	}
+	return vm.InboxEventViewModel{
+		ID:         e.ID,
+		Kind:       string(e.Kind),
+		KindLabel:  label,
+		Repository: e.RepoFullName,
+		PRNumber:   e.PRNumber,
+		PRTitle:    e.PRTitle,
+		Actor:      e.Actor,
+		Summary:    e.Summary,
+		DetailPath: fmt.Sprintf("/app/prs/%s/%d", e.RepoFullName, e.PRNumber),
+		OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339),
+		IsRead:     e.IsRead(),
+	}
+}
+
+// outboundKindLabels are the headings shown for each queued write kind.
+var outboundKindLabels = map[model.OutboundActionKind]string{
+	model.OutboundReview:       "Review",
+	model.OutboundReply:        "Reply",
+	model.OutboundIssueComment: "Comment",
+}
+
+// toOutboundActionViewModel converts a queued GitHub write for display.
+func toOutboundActionViewModel(a model.OutboundAction) vm.OutboundActionViewModel {
+	label := outboundKindLabels[a.Kind]
+	if label == "" {
+		label = string(a.Kind)
+	}
+	result := vm.OutboundActionViewModel{
+		ID:         a.ID,
+		KindLabel:  label,
+		Repository: a.RepoFullName,
+		PRNumber:   a.PRNumber,
+		DetailPath: fmt.Sprintf("/app/prs/%s/%d", a.RepoFullName, a.PRNumber),
+		Body:       a.Body,
+		Failed:     a.Status == model.OutboundFailed,
+		Attempts:   a.Attempts,
+		LastError:  a.LastError,
+		CreatedAt:  a.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if !result.Failed {
+		result.NextAttempt = a.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+// toWhatsNewViewModel converts pending changelog releases and migrations for the what's-new panel.
+func toWhatsNewViewModel(pending application.WhatsNew) vm.WhatsNewViewModel {
+	result := vm.WhatsNewViewModel{
+		Releases: make([]vm.ReleaseViewModel, 0, len(pending.Releases)),
+	}
+
+	for _, r := range pending.Releases {
+		date := r.ID
+		if t, err := time.Parse(time.DateOnly, r.ID); err == nil {
+			date = t.Format("Jan 2, 2006")
+		}
+		result.Releases = append(result.Releases, vm.ReleaseViewModel{
+			Date:    date,
+			Changes: r.Changes,
+			Notices: r.Notices,
+		})
+	}
+	for _, m := range pending.Migrations {
+		result.Migrations = append(result.Migrations, fmt.Sprintf("%d: %s", m.Version, m.Description))
+	}
+	return result
+}
@@ -23,6 +23,39 @@ type PRCardViewModel struct {
 	URL                   string
 	DetailPath            string
 	Attention             model.AttentionSignals
+	ExternalContributor   bool                    // author is not an owner, member, or collaborator of the repo
+	Checklist             model.ChecklistProgress // task list items in the body and conversation
+	Branch                string
+	CheckoutCommand       string              // shell command that fetches and checks out the PR branch
+	QuickActions          []model.QuickAction // actions shown on the card, in display order
+	ApproveConfirm        bool                // the approve action asks before submitting
+	// Fields are the optional details shown on the card, in the order chosen
+	// in settings. SizeLabel, JiraKey, and Reviewers back the matching fields.
+	Fields    []model.CardField
+	SizeLabel string // lines changed, e.g. "+120 −30"
+	JiraKey   string
+	Reviewers []string // people who have reviewed, in order of first review
+	// SLALabel counts down to the repo's first-review deadline ("5h left") or
+	// shows how far past it the PR is ("overdue 3h"); empty when no SLA is pending.
+	SLALabel   string
+	SLAOverdue bool
+	// RequestedLabel says how long ago the user's review was requested
+	// ("requested 2d ago"); empty when no request is pending.
+	RequestedLabel string
+	// LintViolations describes the repo's title and branch rules the PR
+	// fails, e.g. "Conventional Commits"; empty when it passes or has none.
+	LintViolations []string
+	// ActionsDisabledReason explains why GitHub actions such as approving are
+	// unavailable (no token, or GitHub unreachable); empty when they work.
+	ActionsDisabledReason string
+	// Watch overrides whether the PR needs the user's attention; empty when
+	// it follows the review request. WatchEnabled offers the selector.
+	Watch        model.WatchState
+	WatchEnabled bool
+	// WatchingChecks is set while the user waits to be notified that the
+	// PR's checks finished. ChecksWatchEnabled offers the toggle.
+	WatchingChecks     bool
+	ChecksWatchEnabled bool
 }
 
 // PRDetailViewModel holds presentation-ready data for the full PR detail panel.
@@ -31,14 +64,20 @@ type PRDetailViewModel struct {
 
 	Owner        string // Repository owner (e.g. "octocat")
 	RepoName     string // Repository name without owner (e.g. "hello-world")
-	Branch       string
 	BaseBranch   string
 	HeadSHA      string
 	Additions    int
 	Deletions    int
 	ChangedFiles int
 
+	// PendingReviewers are the users and teams whose review is still
+	// requested, oldest request first.
+	PendingReviewers []PendingReviewerViewModel
+
 	IsOwnPR bool // True when the PR author matches the authenticated user.
+	// CanApprove offers the one-click approve button in the header: the PR
+	// is someone else's, open, and GitHub writes are configured.
+	CanApprove bool
 
 	Reviews       []ReviewViewModel
 	Threads       []ThreadViewModel
@@ -52,7 +91,155 @@ type PRDetailViewModel struct {
 	ResolvedThreads     int
 	UnresolvedThreads   int
 
+	// DismissesStaleApprovals is true when the base branch dismisses approvals
+	// on new pushes; ApprovalExpiresOnPush additionally means the user holds
+	// an approval on the current head that the next push will dismiss.
+	DismissesStaleApprovals bool
+	ApprovalExpiresOnPush   bool
+
+	// MergeRequirements is the base branch's merge checklist; empty when the
+	// branch requires neither reviews nor status checks.
+	MergeRequirements []MergeRequirementViewModel
+
 	JiraCard JiraCardViewModel
+
+	// Tab is the detail tab open on load: reviews, threads, comments, ci, or notes.
+	Tab string
+
+	Competing CompetingPRsViewModel
+
+	SinceLastView SinceLastViewViewModel
+
+	// Outbox lists this PR's reviews and comments queued for retry after a
+	// transient GitHub failure.
+	Outbox []OutboundActionViewModel
+
+	// ReviewTemplates are the saved review templates offered in the review
+	// composer, with variables filled in for this PR.
+	ReviewTemplates []ReplyTemplateViewModel
+
+	// Notes are the user's private annotations on this PR.
+	Notes PRNotesViewModel
+}
+
+// PRNotesViewModel is the Notes tab of the PR detail panel. It is re-rendered
+// on its own when a note is added or deleted.
+type PRNotesViewModel struct {
+	Owner    string
+	RepoName string
+	Number   int
+	Enabled  bool // false when no note store is configured
+	Notes    []PRNoteViewModel
+}
+
+// PRNoteViewModel holds presentation-ready data for one private note.
+type PRNoteViewModel struct {
+	ID        int64
+	Body      string
+	CreatedAt string // RFC 3339
+}
+
+// PendingReviewerViewModel is a user or team whose review is requested.
+type PendingReviewerViewModel struct {
+	Name   string // login, or org/team slug for teams
+	IsTeam bool
+	Age    string // how long ago the review was requested, e.g. "3d"
+}
+
+// MergeRequirementViewModel is one line of the merge requirements checklist.
+type MergeRequirementViewModel struct {
+	Label string // e.g. "1 of 2 approvals"
+	State string // met, unmet, pending, or unknown
+}
+
+// ReviewersViewModel is the suggested reviewers panel on the PR detail page,
+// loaded separately because the suggestions come from GitHub on demand.
+type ReviewersViewModel struct {
+	Owner     string
+	RepoName  string
+	Number    int
+	Suggested []SuggestedReviewerViewModel
+	// Message replaces the suggestions when they cannot be shown.
+	Message string
+}
+
+// LabelEditorViewModel is the label editor in the PR detail header, loaded
+// separately because the repository's labels come from GitHub on demand.
+type LabelEditorViewModel struct {
+	Owner    string
+	RepoName string
+	Number   int
+	Labels   []string // applied to the PR
+	// Available are the repository's labels not yet applied, offered in the
+	// typeahead. Editable is false when labels cannot be changed.
+	Available []string
+	Editable  bool
+}
+
+// MilestonePickerViewModel is the milestone dropdown in the PR detail
+// header, loaded separately because milestones come from GitHub on demand.
+type MilestonePickerViewModel struct {
+	Owner    string
+	RepoName string
+	Number   int
+	Current  string // title of the PR's milestone; empty when it has none
+	// Milestones are the repository's open milestones. Editable is false
+	// when the milestone cannot be changed.
+	Milestones []model.Milestone
+	Editable   bool
+}
+
+// SuggestedReviewerViewModel is one reviewer GitHub suggests for a PR.
+type SuggestedReviewerViewModel struct {
+	Login     string
+	Reason    string // e.g. "Recently edited these files"
+	Requested bool   // a review was just requested from this user
+}
+
+// SinceLastViewViewModel is the banner summarizing what changed on a PR since
+// the user last opened it. It is hidden when Items is empty.
+type SinceLastViewViewModel struct {
+	LastViewedAt string   // RFC 3339
+	Items        []string // one line per kind of change, e.g. "2 new reviews"
+}
+
+// CompetingPRsViewModel holds the "competing PRs" section of the PR detail panel:
+// PRs linked manually or through a shared Jira issue that address the same work.
+type CompetingPRsViewModel struct {
+	Enabled        bool  // false when comparison is not configured; the section is hidden
+	PRID           int64 // database ID of the PR being viewed
+	Items          []CompetingPRViewModel
+	CompareAllPath string // comparison of this PR with every competitor (capped); empty when there are none
+}
+
+// CompetingPRViewModel is one PR competing with the PR being viewed.
+type CompetingPRViewModel struct {
+	PRCardViewModel
+
+	ManualLink    bool
+	SharedJiraKey string
+	ComparePath   string // side-by-side comparison with the PR being viewed
+}
+
+// PRComparisonViewModel holds the side-by-side comparison of competing PRs.
+type PRComparisonViewModel struct {
+	Columns  []PRComparisonColumnViewModel
+	BackPath string // detail path of the PR the comparison was opened from
+}
+
+// PRComparisonColumnViewModel is one PR's column in the comparison table.
+type PRComparisonColumnViewModel struct {
+	PRCardViewModel
+
+	Additions         int
+	Deletions         int
+	ChangedFiles      int
+	ChecksPassed      int
+	ChecksFailed      int
+	ChecksPending     int
+	Approvals         int
+	ChangesRequested  int
+	UnresolvedThreads int
 }
 
 // ReviewViewModel holds presentation-ready data for a single review.
@@ -67,6 +254,7 @@ type ReviewViewModel struct {
 	IsBot       bool
 	IsOutdated  bool
 	IsNitpick   bool
+	IsNew       bool // submitted since the user last viewed the PR
 }
 
 // ThreadViewModel holds presentation-ready data for a review comment thread.
@@ -75,6 +263,19 @@ type ThreadViewModel struct {
 	Replies      []ReviewCommentViewModel
 	IsResolved   bool
 	CommentCount int
+
+	// Repeats are earlier postings of the same bot comment, newest first,
+	// shown collapsed under the root comment.
+	Repeats []ReviewCommentViewModel
+
+	CanMarkDecision bool   // true when the decisions log is configured
+	Decision        string // summary when the thread is marked as a decision
+
+	RepliesDisabledReason string // why replying is unavailable; empty when it works
+
+	// ReplySnippets are the saved snippets offered in the reply box, with
+	// variables filled in for the PR.
+	ReplySnippets []ReplyTemplateViewModel
 }
 
 // ReviewCommentViewModel holds presentation-ready data for a single review comment.
@@ -90,17 +291,32 @@ type ReviewCommentViewModel struct {
 	DiffHunkHTML string
 	CommitID     string
 	IsOutdated   bool
+	MentionsMe   bool // body @mentions the authenticated user
+	IsNew        bool // posted since the user last viewed the PR
+	PendingSync  bool // posted from the app and not yet synced from GitHub
 	CreatedAt    string
+
+	Permalink string // deep link to the comment in the PR detail panel
+	HTMLURL   string // the comment on GitHub; empty until synced
 }
 
 // IssueCommentViewModel holds presentation-ready data for a PR-level general comment.
 type IssueCommentViewModel struct {
-	ID        int64
-	Author    string
-	Body      string
-	BodyHTML  string
-	IsBot     bool
-	CreatedAt string
+	ID          int64
+	Author      string
+	Body        string
+	BodyHTML    string
+	IsBot       bool
+	MentionsMe  bool // body @mentions the authenticated user
+	IsNew       bool // posted since the user last viewed the PR
+	PendingSync bool // posted from the app and not yet synced from GitHub
+	CreatedAt   string
+
+	Permalink string // deep link to the comment in the PR detail panel
+	HTMLURL   string // the comment on GitHub; empty until synced
+
+	// Repeats are earlier postings of the same bot comment, newest first.
+	Repeats []IssueCommentViewModel
 }
 
 // CheckRunViewModel holds presentation-ready data for a single CI/CD check run.
@@ -135,6 +351,73 @@ type RepoViewModel struct {
 	Name                     string
 	DeletePath               string // computed: /app/repos/{owner}/{repo}
 	AssignedJiraConnectionID int64  // 0 means no explicit assignment (use default)
+	AssignedGitHubAccountID  int64  // 0 means the default GitHub token
+
+	// Unreachable is set while polling is paused by the circuit breaker after
+	// repeated 404/403 responses or a rename.
+	Unreachable      bool
+	UnreachableTitle string // tooltip: last error and next retry time
+	MovedTo          string // new full name when GitHub reported a rename or transfer
+
+	// Paused repos are skipped by background polling until resumed.
+	Paused bool
+
+	// LastSynced is e.g. "synced 5m ago" or "never synced"; LastSyncedTitle
+	// holds the exact time for the tooltip.
+	LastSynced      string
+	LastSyncedTitle string
+
+	// Details reports the description and default branch for the name's
+	// tooltip; empty until GitHub metadata is first fetched.
+	Details     string
+	Private     bool
+	Archived    bool // archived on GitHub, so no new PRs will arrive
+	OpenPRCount int
+
+	// Bitbucket is set for repositories hosted on Bitbucket Cloud.
+	Bitbucket bool
+
+	// RequiredChecks and OptionalChecks list the check names manually marked
+	// required or optional, and IgnoredChecks those left out of the CI
+	// status, comma separated. CheckOverridesEnabled offers the form.
+	RequiredChecks        string
+	OptionalChecks        string
+	IgnoredChecks         string
+	CheckOverridesEnabled bool
+	// Stale is the repo's stale PR policy, shown with the check overrides.
+	Stale StalePolicyViewModel
+	// PRScope is which PRs are stored: "all", "relevant", or "" for the
+	// default, which is relevant only when DefaultRelevantOnly is set.
+	PRScope             string
+	DefaultRelevantOnly bool
+}
+
+// StalePolicyViewModel holds a repo's stale PR labeler settings for its form.
+type StalePolicyViewModel struct {
+	AfterDays    int  // 0 when the labeler is off
+	Comment      bool // comment on idle PRs instead of labeling them
+	Label        string
+	ExemptLabels string // comma separated
+	DryRun       bool
+}
+
+// RemovedRepoViewModel holds presentation data for a removed repo that can
+// still be restored.
+type RemovedRepoViewModel struct {
+	FullName    string
+	RestorePath string // computed: /app/repos/{owner}/{repo}/restore
+	Removed     string // e.g. "removed 2h ago"
+	PurgeAt     string // e.g. "24 Oct 2026", when its history is deleted for good
+}
+
+// UndoToastSeconds is how long the undo toast offers to revert an action.
+const UndoToastSeconds = 30
+
+// UndoToastViewModel holds the notice shown after a destructive action.
+type UndoToastViewModel struct {
+	Message  string
+	UndoPath string // POSTed by the Undo button
+	Target   string // selector of the element the undo response replaces
 }
 
 // DashboardViewModel holds all data needed to render the dashboard page.
@@ -145,6 +428,66 @@ type DashboardViewModel struct {
 	IgnoredPRs      []PRCardViewModel
 	GlobalSettings  model.GlobalSettings
 	JiraConnections []JiraConnectionViewModel
+	GitHubAccounts  []GitHubAccountViewModel
+	InboxEnabled    bool
+	InboxUnread     int
+	TodayEnabled    bool // shows the daily focus list button
+	OutboxEnabled   bool // shows the outbox button for queued GitHub writes
+	StatsEnabled    bool // shows the team stats button once opted into
+	MentionsEnabled bool // shows the Mentions filter in the search bar
+	Sync            SyncBannerViewModel
+	// Groups are the repo groups offered by the sidebar switcher; the
+	// switcher is hidden when empty.
+	Groups []RepoGroupOptionViewModel
+	// List is how the sidebar PR list is laid out.
+	List PRListLayoutViewModel
+	// Filters pre-fill the search bar when a shared link carries filters.
+	Filters SearchFiltersViewModel
+	// Preferences are the browser's saved GUI state, such as collapsed
+	// sections; nil when none are saved.
+	Preferences map[string]string
+}
+
+// SearchFiltersViewModel holds the sidebar search and filter values.
+type SearchFiltersViewModel struct {
+	Query    string
+	Status   string
+	Repo     string
+	Mentions bool
+}
+
+// ListDensity is how the sidebar PR list is laid out.
+type ListDensity string
+
+// ListDensity values.
+const (
+	DensityComfortable ListDensity = "comfortable" // full PR cards
+	DensityCompact     ListDensity = "compact"     // one line per PR
+	DensityTable       ListDensity = "table"       // paged table rows for very long lists
+)
+
+// PRListLayoutViewModel selects the PR list partial and, in table density,
+// how many rows are rendered before a "Show more" button.
+type PRListLayoutViewModel struct {
+	Density ListDensity
+	Limit   int // rows rendered in table density; 0 renders all
+}
+
+// RepoGroupOptionViewModel is one entry of the sidebar's repo group switcher.
+type RepoGroupOptionViewModel struct {
+	ID       int64
+	Name     string
+	Selected bool
+}
+
+// SyncBannerViewModel is the stale-data banner shown while GitHub cannot be
+// reached or its API is degraded; Offline and Degraded are both false when
+// everything is syncing normally.
+type SyncBannerViewModel struct {
+	Offline    bool
+	Degraded   bool
+	Reason     string
+	LastSynced string // e.g. "Last synced 12m ago."; empty if no repo ever synced
 }
 
 // JiraConnectionViewModel holds presentation data for a single Jira connection in the Settings drawer.
@@ -204,3 +547,459 @@ type CredentialStatusViewModel struct {
 	Message  string
 	Username string // populated on successful GitHub token validation
 }
+
+// QuickActionSettingsViewModel holds the card quick action settings.
+type QuickActionSettingsViewModel struct {
+	Actions []QuickActionOptionViewModel
+	// ApproveMessage is the body of one-click approving reviews; empty
+	// approves without a comment.
+	ApproveMessage string
+	ApproveConfirm bool
+}
+
+// RequestChangesViewModel holds the request-changes quick action dialog.
+type RequestChangesViewModel struct {
+	Owner     string
+	Repo      string
+	Number    int
+	Title     string
+	Templates []ReplyTemplateViewModel // review templates, filled in for the PR
+}
+
+// QuickActionOptionViewModel is one checkbox in the card quick actions settings.
+type QuickActionOptionViewModel struct {
+	Action  model.QuickAction
+	Label   string
+	Enabled bool
+}
+
+// CardFieldOptionViewModel is one row in the card fields settings.
+type CardFieldOptionViewModel struct {
+	Field   model.CardField
+	Label   string
+	Enabled bool
+}
+
+// GitHubSettingsViewModel pre-fills the GitHub Enterprise fields of the
+// credentials form. Empty fields mean github.com or the env var defaults.
+type GitHubSettingsViewModel struct {
+	BaseURL    string
+	GraphQLURL string
+	Accounts   []GitHubAccountViewModel
+}
+
+// GitHubAccountViewModel holds presentation data for a named GitHub account.
+type GitHubAccountViewModel struct {
+	ID       int64
+	Name     string
+	Username string
+}
+
+// APITokenViewModel holds presentation data for a REST API token. The secret
+// itself is never stored, so only its display prefix is shown.
+type APITokenViewModel struct {
+	ID         int64
+	Name       string
+	Prefix     string
+	Scope      string
+	CreatedAt  string
+	LastUsedAt string // empty when the token has never been used
+}
+
+// ReplyTemplateSettingsViewModel holds the saved reply snippets and review
+// templates shown in the settings drawer.
+type ReplyTemplateSettingsViewModel struct {
+	Enabled   bool // false when no template store is configured
+	Templates []ReplyTemplateViewModel
+}
+
+// ReplyTemplateViewModel holds presentation data for one saved template.
+type ReplyTemplateViewModel struct {
+	ID        int64
+	Name      string
+	KindLabel string // "Snippet" or "Review"
+	Body      string
+}
+
+// SignalWebhookSettingsViewModel holds the attention signal webhooks shown
+// in the settings drawer and the signals they can be routed from.
+type SignalWebhookSettingsViewModel struct {
+	Enabled bool // false when webhooks are unavailable (e.g. read-only mode)
+	Hooks   []SignalWebhookViewModel
+	Signals []SignalOptionViewModel
+}
+
+// SignalWebhookViewModel holds presentation data for one signal webhook.
+type SignalWebhookViewModel struct {
+	ID          int64
+	SignalLabel string
+	URL         string
+	Repo        string // empty when the webhook applies to every repo
+}
+
+// AttentionRuleSettingsViewModel holds the user-defined attention rules shown
+// in the settings drawer and what the rule builder offers.
+type AttentionRuleSettingsViewModel struct {
+	Rules      []AttentionRuleViewModel
+	Fields     []string // identifiers usable in expressions
+	Severities []int
+}
+
+// AttentionRuleViewModel holds presentation data for one attention rule.
+type AttentionRuleViewModel struct {
+	ID         int64
+	Name       string
+	Expression string
+	Severity   int
+	NotifyURL  string
+	Enabled    bool
+}
+
+// EventWebhookSettingsViewModel holds the event webhooks shown in the
+// settings drawer and the events they can subscribe to.
+type EventWebhookSettingsViewModel struct {
+	Hooks  []EventWebhookViewModel
+	Events []string
+}
+
+// EventWebhookViewModel holds presentation data for one event webhook.
+type EventWebhookViewModel struct {
+	ID      int64
+	URL     string
+	Events  string // comma-separated; "all events" when unfiltered
+	Repo    string // empty for every repository
+	Enabled bool
+}
+
+// WebhookDeliveryViewModel holds presentation data for one logged webhook
+// delivery.
+type WebhookDeliveryViewModel struct {
+	Event          string
+	Target         string // owner/repo#number
+	Status         string // pending, delivered, or failed
+	Attempts       int
+	ResponseStatus int // 0 when no response arrived
+	LastError      string
+	CreatedAt      string
+}
+
+// ScheduledReportSettingsViewModel holds the scheduled reports shown in the
+// settings drawer and the choices offered by the add form.
+type ScheduledReportSettingsViewModel struct {
+	Reports         []ScheduledReportViewModel
+	Groups          []RepoGroupOptionViewModel
+	DefaultTemplate string
+}
+
+// ScheduledReportViewModel holds presentation data for one scheduled report.
+type ScheduledReportViewModel struct {
+	ID          int64
+	Name        string
+	Scope       string // repo group name, or "All repositories"
+	Destination string // "Slack" or "GitHub issue"
+	Target      string
+	Schedule    string // e.g. "Mondays at 09:00 UTC"
+	LastSent    string // empty until first sent
+}
+
+// SignalOptionViewModel is one attention signal offered in the webhook form.
+type SignalOptionViewModel struct {
+	Value string
+	Label string
+}
+
+// ReviewHistoryViewModel holds the searchable archive of closed and merged
+// PRs the user reviewed.
+type ReviewHistoryViewModel struct {
+	Query     string
+	Repo      string   // selected repository filter; empty for all
+	Repos     []string // repositories available in the filter
+	Items     []ReviewedPRViewModel
+	Truncated bool // more PRs matched than are shown
+}
+
+// ReviewedPRViewModel is one archived PR with the user's participation.
+type ReviewedPRViewModel struct {
+	Repository     string
+	Number         int
+	Title          string
+	Author         string
+	Status         string
+	URL            string
+	DetailPath     string
+	Verdict        string // the user's last review state, human readable
+	LastReviewedAt string
+	CommentCount   int
+	Decisions      []string
+}
+
+// ArchiveViewModel holds one page of the archive of merged and closed PRs
+// with its filters and the per-repository merge history.
+type ArchiveViewModel struct {
+	Repo     string   // selected repository filter; empty for all
+	Status   string   // "merged", "closed", or empty for both
+	From     string   // earliest close date shown, YYYY-MM-DD; empty for no bound
+	To       string   // latest close date shown, YYYY-MM-DD; empty for no bound
+	Repos    []string // repositories available in the filter
+	Items    []ArchivedPRViewModel
+	History  []RepoMergeHistoryViewModel
+	Page     int
+	PrevPath string // empty on the first page
+	NextPath string // empty on the last page
+	// Retention describes how long archived PRs are kept, e.g. "365 days";
+	// empty when they are kept forever.
+	Retention string
+}
+
+// ArchivedPRViewModel is one merged or closed PR in the archive.
+type ArchivedPRViewModel struct {
+	Repository  string
+	Number      int
+	Title       string
+	Author      string
+	Status      string
+	URL         string
+	DetailPath  string
+	ClosedAt    string // YYYY-MM-DD, UTC
+	TimeToMerge string // compact duration; empty unless merged
+}
+
+// RepoMergeHistoryViewModel summarizes one repository's archived PRs.
+type RepoMergeHistoryViewModel struct {
+	Repository        string
+	Merged            int
+	Closed            int
+	MedianTimeToMerge string // compact duration; empty when none merged
+}
+
+// BoardViewModel holds the board view: open PRs in columns by review state.
+type BoardViewModel struct {
+	Columns []BoardColumnViewModel
+}
+
+// BoardColumnViewModel is one board column and the PR cards placed in it.
+type BoardColumnViewModel struct {
+	Column model.BoardColumn
+	Label  string
+	Cards  []PRCardViewModel
+}
+
+// TodayViewModel holds the daily focus list.
+type TodayViewModel struct {
+	Items    []TodayItemViewModel
+	Done     int // PRs marked done today
+	More     int // PRs beyond the list's size
+	Size     int // requested list size
+	NextSize int // list size after "Show more"
+}
+
+// TodayItemViewModel is one PR on the daily focus list.
+type TodayItemViewModel struct {
+	Card    PRCardViewModel
+	Reasons []string // why the PR is on the list
+	Skipped bool     // skipped today; ranked last
+}
+
+// EmbedViewModel holds the embeddable, read-only PR list of one repository.
+type EmbedViewModel struct {
+	Repository string
+	Columns    []EmbedColumnViewModel
+	Rows       []PRCardViewModel
+}
+
+// EmbedColumnViewModel is one optional column of the embed table.
+type EmbedColumnViewModel struct {
+	Column model.EmbedColumn
+	Label  string
+}
+
+// TeamStatsViewModel holds the reviewer leaderboard page.
+type TeamStatsViewModel struct {
+	Period  int      // days covered
+	Periods []int    // periods offered, in days
+	Repo    string   // selected repository filter; empty for all
+	Repos   []string // repositories available in the filter
+	Rows    []ReviewerStatsViewModel
+}
+
+// ReviewerStatsViewModel is one leaderboard row.
+type ReviewerStatsViewModel struct {
+	Rank               int
+	Login              string
+	ReviewsGiven       int
+	PRsReviewed        int
+	AvgResponseTime    string // compact duration; empty when no PR was first reviewed in the period
+	SuggestionsApplied int
+}
+
+// DecisionsViewModel holds the searchable decisions log page.
+type DecisionsViewModel struct {
+	Query  string
+	Repo   string   // selected repository filter; empty for all
+	Repos  []string // repositories available in the filter
+	Groups []DecisionGroupViewModel
+	Total  int
+}
+
+// DecisionGroupViewModel is the decisions recorded in one repository.
+type DecisionGroupViewModel struct {
+	Repository string
+	Decisions  []DecisionViewModel
+}
+
+// DecisionViewModel holds presentation-ready data for one logged decision.
+type DecisionViewModel struct {
+	Summary    string
+	PRNumber   int
+	PRTitle    string
+	FilePath   string
+	DetailPath string // PR detail panel the decision was made on
+	UpdatedAt  string
+}
+
+// CommentSearchViewModel holds the comment search page: its filters and the
+// matching review and issue comments, newest first.
+type CommentSearchViewModel struct {
+	Query    string
+	Author   string
+	Repo     string   // selected repository filter; empty for all
+	From     string   // earliest comment date, YYYY-MM-DD; empty for no bound
+	To       string   // latest comment date, YYYY-MM-DD; empty for no bound
+	Repos    []string // repositories available in the filter
+	Searched bool     // false until any filter is set
+	Results  []CommentSearchResultViewModel
+}
+
+// CommentSearchResultViewModel is one comment found by a comment search.
+type CommentSearchResultViewModel struct {
+	Kind       string // "Review comment" or "Comment"
+	Repository string
+	PRNumber   int
+	PRTitle    string
+	Author     string
+	Excerpt    string // the matched part of the body, or its start
+	FilePath   string // empty for conversation comments
+	DetailPath string // PR detail panel the comment is on
+	CreatedAt  string
+}
+
+// ThreadTriageViewModel holds the threads page: unresolved review threads
+// across the user's open PRs, grouped by PR and file.
+type ThreadTriageViewModel struct {
+	PRs         []ThreadTriagePRViewModel
+	ThreadCount int
+}
+
+// ThreadTriagePRViewModel is one PR's unresolved threads, by file.
+type ThreadTriagePRViewModel struct {
+	Owner      string
+	Repo       string
+	Number     int
+	Title      string
+	Author     string
+	IsMine     bool   // authored by the user rather than under their review
+	DetailPath string // PR detail panel
+	Files      []ThreadFileViewModel
+}
+
+// ThreadFileViewModel is the unresolved threads on one file of a PR.
+type ThreadFileViewModel struct {
+	Path    string
+	Threads []ThreadViewModel
+}
+
+// WhatsNewViewModel holds the post-upgrade what's-new panel.
+type WhatsNewViewModel struct {
+	Releases   []ReleaseViewModel
+	Migrations []string // schema migrations applied since the panel was last dismissed
+}
+
+// ReleaseViewModel is one changelog release.
+type ReleaseViewModel struct {
+	Date    string // e.g. "Oct 16, 2026"
+	Changes []string
+	Notices []string // settings that need attention
+}
+
+// InboxViewModel holds the attention inbox page.
+type InboxViewModel struct {
+	UnreadOnly bool
+	Unread     int
+	Events     []InboxEventViewModel
+	Truncated  bool // more events matched than are shown
+}
+
+// InboxEventViewModel holds presentation-ready data for one inbox event.
+type InboxEventViewModel struct {
+	ID         int64
+	Kind       string // model.InboxEventKind, used for styling
+	KindLabel  string
+	Repository string
+	PRNumber   int
+	PRTitle    string
+	Actor      string
+	Summary    string
+	DetailPath string
+	OccurredAt string
+	IsRead     bool
+}
+
+// OutboxViewModel holds the outbox page: GitHub writes queued for retry.
+type OutboxViewModel struct {
+	Actions []OutboundActionViewModel
+	Pending int
+	Failed  int
+}
+
+// OutboundActionViewModel holds presentation-ready data for one queued write.
+type OutboundActionViewModel struct {
+	ID          int64
+	KindLabel   string // e.g. "Review" or "Reply"
+	Repository  string
+	PRNumber    int
+	DetailPath  string
+	Body        string
+	Failed      bool
+	Attempts    int
+	LastError   string
+	NextAttempt string // RFC 3339; empty once failed
+	CreatedAt   string
+}
+
+// BotSettingsViewModel holds the bot section of the settings drawer.
+type BotSettingsViewModel struct {
+	Bots []BotViewModel
+	// DefaultPatterns are the nitpick markers recognized for every bot.
+	DefaultPatterns []string
+}
+
+// BotViewModel is one configured bot.
+type BotViewModel struct {
+	Username        string
+	NitpickPatterns string // one per line, for the pattern textarea
+	PatternCount    int
+}
+
+// SystemStatusViewModel holds the system stats shown in the settings drawer.
+type SystemStatusViewModel struct {
+	PRCounts      []StatCountViewModel // PRs by status
+	CICounts      []StatCountViewModel // open PRs by CI state
+	Repos         []RepoPollStatusViewModel
+	OutboxPending int
+	OutboxFailed  int
+}
+
+// StatCountViewModel is one labelled count.
+type StatCountViewModel struct {
+	Label string
+	Count int
+}
+
+// RepoPollStatusViewModel holds one repository's latest poll result.
+type RepoPollStatusViewModel struct {
+	FullName  string
+	OpenPRs   int
+	LastPoll  string // e.g. "polled 3m ago", "paused", or "not polled yet"
+	Failures  int
+	LastError string
+}
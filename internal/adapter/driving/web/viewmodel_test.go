@@ -0,0 +1,95 @@
+package web
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	vm "github.com/ericfisherdev/mygitpanel/internal/adapter/driving/web/viewmodel"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestCheckoutCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		branch string
+		want   string
+	}{
+		{name: "plain branch", number: 12, branch: "feature/login", want: "git fetch origin pull/12/head:feature/login && git checkout feature/login"},
+		{name: "missing branch", number: 7, want: "git fetch origin pull/7/head:pr-7 && git checkout pr-7"},
+		{name: "shell metacharacters", number: 3, branch: "fix;rm -rf", want: "git fetch origin pull/3/head:'fix;rm -rf' && git checkout 'fix;rm -rf'"},
+		{name: "single quote", number: 4, branch: "it's", want: `git fetch origin pull/4/head:'it'\''s' && git checkout 'it'\''s'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, checkoutCommand(tt.number, tt.branch))
+		})
+	}
+}
+
+func TestSLABadge(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		status      model.SLAStatus
+		wantLabel   string
+		wantOverdue bool
+	}{
+		{name: "hours left", status: model.SLAStatus{State: model.SLAPending, Deadline: now.Add(5*time.Hour + 30*time.Minute)}, wantLabel: "5h left"},
+		{name: "minutes left", status: model.SLAStatus{State: model.SLAPending, Deadline: now.Add(20 * time.Minute)}, wantLabel: "20m left"},
+		{name: "overdue", status: model.SLAStatus{State: model.SLAOverdue, Deadline: now.Add(-3 * time.Hour)}, wantLabel: "overdue 3h", wantOverdue: true},
+		{name: "overdue days", status: model.SLAStatus{State: model.SLAOverdue, Deadline: now.Add(-72 * time.Hour)}, wantLabel: "overdue 3d", wantOverdue: true},
+		{name: "met", status: model.SLAStatus{State: model.SLAMet, Deadline: now}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, overdue := slaBadge(tt.status, now)
+			assert.Equal(t, tt.wantLabel, label)
+			assert.Equal(t, tt.wantOverdue, overdue)
+		})
+	}
+}
+
+func TestToPendingReviewerViewModels(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	requests := []model.ReviewRequest{
+		{Reviewer: "alice", RequestedAt: now.Add(-3 * time.Hour)},
+		{Reviewer: "core", IsTeam: true, RequestedAt: now.Add(-72 * time.Hour)},
+	}
+
+	got := toPendingReviewerViewModels(requests, "octocat", now)
+
+	assert.Equal(t, []vm.PendingReviewerViewModel{
+		{Name: "octocat/core", IsTeam: true, Age: "3d"},
+		{Name: "alice", Age: "3h"},
+	}, got)
+}
+
+func TestToOutboundActionViewModel(t *testing.T) {
+	next := time.Date(2026, 10, 16, 12, 5, 0, 0, time.UTC)
+	pending := model.OutboundAction{
+		ID:            7,
+		Kind:          model.OutboundReply,
+		RepoFullName:  "org/repo",
+		PRNumber:      12,
+		Body:          "thanks",
+		Status:        model.OutboundPending,
+		Attempts:      2,
+		NextAttemptAt: next,
+	}
+
+	got := toOutboundActionViewModel(pending)
+	assert.Equal(t, "Reply", got.KindLabel)
+	assert.Equal(t, "/app/prs/org/repo/12", got.DetailPath)
+	assert.False(t, got.Failed)
+	assert.Equal(t, "2026-10-16T12:05:00Z", got.NextAttempt)
+
+	failed := pending
+	failed.Status = model.OutboundFailed
+	got = toOutboundActionViewModel(failed)
+	assert.True(t, got.Failed)
+	assert.Empty(t, got.NextAttempt, "failed actions are not rescheduled")
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// accountClient is a GitHub client built for one named account, kept until
+// the account's token changes.
+type accountClient struct {
+	token  string
+	client driven.GitHubClient
+}
+
+// WithAccountRouting polls each repository with the token of the GitHub
+// account it is assigned to. Unassigned repositories, and all repositories
+// when no clientFactory was given, use the default client.
+func (s *PollService) WithAccountRouting(store driven.GitHubRepoAccountStore) *PollService {
+	s.repoAccounts = store
+	return s
+}
+
+// providerClient is the client and login for one non-GitHub provider.
+type providerClient struct {
+	client   driven.SCMProvider
+	username string
+}
+
+// WithSCMProvider polls repositories recorded with provider using client,
+// matching review requests against username, the user's login there.
+func (s *PollService) WithSCMProvider(provider model.Provider, client driven.SCMProvider, username string) *PollService {
+	if s.providers == nil {
+		s.providers = make(map[model.Provider]providerClient)
+	}
+	s.providers[provider] = providerClient{client: client, username: username}
+	return s
+}
+
+// clientForRepo returns the client and login to poll repoFullName with.
+// Repositories hosted outside GitHub use their provider's client; a provider
+// with no client configured falls back to GitHub, whose errors then surface
+// in the repo's poll status. Lookup failures fall back to the default client
+// so one bad assignment never stalls polling. Only the Start goroutine calls
+// it, so the cache is unguarded.
+func (s *PollService) clientForRepo(ctx context.Context, repoFullName string) (driven.GitHubClient, string) {
+	if len(s.providers) > 0 {
+		repo, err := s.repoStore.GetByFullName(ctx, repoFullName)
+		if err != nil {
+			slog.Warn("repository provider lookup failed; using GitHub", "repo", repoFullName, "error", err)
+		} else if repo != nil {
+			if pc, ok := s.providers[repo.Provider]; ok {
+				return pc.client, pc.username
+			}
+		}
+	}
+
+	if s.repoAccounts == nil || s.clientFactory == nil {
+		return s.ghClient, s.username
+	}
+
+	account, err := s.repoAccounts.GetForRepo(ctx, repoFullName)
+	if err != nil {
+		slog.Warn("github account lookup failed; using default client", "repo", repoFullName, "error", err)
+		return s.ghClient, s.username
+	}
+	if account.ID == 0 || account.Token == "" {
+		return s.ghClient, s.username
+	}
+
+	cached, ok := s.accountClients[account.ID]
+	if !ok || cached.token != account.Token {
+		cached = accountClient{token: account.Token, client: s.clientFactory(account.Token)}
+		s.accountClients[account.ID] = cached
+		s.watchSecondaryRateLimit(cached.client)
+	}
+
+	username := account.Username
+	if username == "" {
+		username = s.username
+	}
+	return cached.client, username
+}
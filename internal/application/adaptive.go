@@ -82,11 +82,19 @@ func classifyActivity(lastActivity time.Time) ActivityTier {
 	}
 }
 
-// repoSchedule tracks per-repository adaptive polling state.
+// repoSchedule tracks per-repository adaptive polling state. The failure
+// fields are reset by the next successful poll.
 type repoSchedule struct {
 	tier       ActivityTier
 	nextPollAt time.Time
 	lastPolled time.Time
+
+	failures          int       // consecutive failed polls of any kind
+	unreachableStreak int       // consecutive 404/403/moved failures
+	lastError         string    // error from the most recent failed poll
+	lastFailedAt      time.Time // when the most recent failed poll ended
+	movedTo           string    // new full name when GitHub reported a rename or transfer
+	circuitOpen       bool
 }
 
 // ScheduleInfo is an exported view of a repo's adaptive polling schedule,
@@ -95,6 +103,12 @@ type ScheduleInfo struct {
 	Tier       ActivityTier
 	NextPollAt time.Time
 	LastPolled time.Time
+
+	Circuit             CircuitState
+	ConsecutiveFailures int
+	LastError           string
+	LastFailedAt        time.Time
+	MovedTo             string
 }
 
 // freshestActivity finds the most recent LastActivityAt across all PRs.
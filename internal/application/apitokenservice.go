@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+const (
+	// apiTokenPrefix marks mygitpanel API tokens so they are recognizable in
+	// scripts and secret scanners.
+	apiTokenPrefix = "mgp_"
+
+	// apiTokenDisplayLen is how much of a token is kept for display.
+	apiTokenDisplayLen = len(apiTokenPrefix) + 6
+
+	// lastUsedResolution limits last-used writes to one per token per interval.
+	lastUsedResolution = time.Minute
+)
+
+var (
+	// ErrInvalidAPIToken is returned when a presented API token is unknown or revoked.
+	ErrInvalidAPIToken = errors.New("invalid API token")
+
+	// ErrInvalidAPITokenScope is returned when creating a token with an unknown scope.
+	ErrInvalidAPITokenScope = errors.New("API token scope must be read or write")
+)
+
+// APITokenService issues, verifies, and revokes REST API tokens. Secrets are
+// shown once at creation and stored only as SHA-256 hashes; they are random
+// 256-bit values, so a fast hash is sufficient.
+type APITokenService struct {
+	store driven.APITokenStore
+}
+
+// NewAPITokenService creates an APITokenService backed by store.
+func NewAPITokenService(store driven.APITokenStore) *APITokenService {
+	return &APITokenService{store: store}
+}
+
+// Create issues a new token and returns its secret, which cannot be recovered later.
+func (s *APITokenService) Create(ctx context.Context, name string, scope model.APITokenScope) (string, model.APIToken, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", model.APIToken{}, errors.New("API token name is required")
+	}
+	if !scope.Valid() {
+		return "", model.APIToken{}, ErrInvalidAPITokenScope
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", model.APIToken{}, fmt.Errorf("generate API token: %w", err)
+	}
+	secret := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+
+	token := model.APIToken{
+		Name:      name,
+		Prefix:    secret[:apiTokenDisplayLen],
+		Scope:     scope,
+		CreatedAt: time.Now().UTC(),
+	}
+	id, err := s.store.Create(ctx, token, hashAPIToken(secret))
+	if err != nil {
+		return "", model.APIToken{}, err
+	}
+	token.ID = id
+	return secret, token, nil
+}
+
+// Authenticate returns the token matching secret, or ErrInvalidAPIToken.
+func (s *APITokenService) Authenticate(ctx context.Context, secret string) (*model.APIToken, error) {
+	if !strings.HasPrefix(secret, apiTokenPrefix) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	token, err := s.store.GetByHash(ctx, hashAPIToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrInvalidAPIToken
+	}
+
+	if now := time.Now().UTC(); now.Sub(token.LastUsedAt) >= lastUsedResolution {
+		if err := s.store.TouchLastUsed(ctx, token.ID, now); err != nil {
+			slog.Warn("failed to record API token use", "token_id", token.ID, "error", err)
+		}
+	}
+	return token, nil
+}
+
+// Enforced reports whether any tokens exist. The REST API stays open until the
+// first token is created, so existing unauthenticated setups keep working.
+func (s *APITokenService) Enforced(ctx context.Context) (bool, error) {
+	n, err := s.store.Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// List returns all tokens, newest first.
+func (s *APITokenService) List(ctx context.Context) ([]model.APIToken, error) {
+	return s.store.List(ctx)
+}
+
+// Revoke deletes a token; requests using it fail from then on.
+func (s *APITokenService) Revoke(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// hashAPIToken returns the hex SHA-256 of a token secret.
+func hashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// ArchivePageSize is how many PRs one page of the archive shows.
+const ArchivePageSize = 50
+
+// ArchivePage is one page of archived PRs. HasMore reports whether a later
+// page exists.
+type ArchivePage struct {
+	PRs     []model.PullRequest
+	Page    int
+	HasMore bool
+}
+
+// ArchiveService serves the archive of merged and closed PRs and enforces its
+// retention period. Retention is independent of how long open PRs are kept:
+// polling only removes open PRs that vanish from GitHub.
+type ArchiveService struct {
+	store     driven.ArchiveStore
+	retention time.Duration // zero keeps the archive forever
+	now       func() time.Time
+}
+
+// NewArchiveService creates an ArchiveService. A retention of zero keeps
+// archived PRs forever.
+func NewArchiveService(store driven.ArchiveStore, retention time.Duration) *ArchiveService {
+	return &ArchiveService{store: store, retention: retention, now: time.Now}
+}
+
+// Retention returns how long archived PRs are kept after closing; zero means forever.
+func (s *ArchiveService) Retention() time.Duration {
+	return s.retention
+}
+
+// List returns the given 1-based page of archived PRs matching filter, most
+// recently closed first.
+func (s *ArchiveService) List(ctx context.Context, filter model.ArchiveFilter, page int) (ArchivePage, error) {
+	if page < 1 {
+		page = 1
+	}
+	prs, err := s.store.ListArchived(ctx, filter, ArchivePageSize+1, (page-1)*ArchivePageSize)
+	if err != nil {
+		return ArchivePage{}, err
+	}
+
+	result := ArchivePage{PRs: prs, Page: page}
+	if len(prs) > ArchivePageSize {
+		result.PRs = prs[:ArchivePageSize]
+		result.HasMore = true
+	}
+	return result, nil
+}
+
+// MergeHistory returns per-repository merge counts for the PRs matching filter.
+func (s *ArchiveService) MergeHistory(ctx context.Context, filter model.ArchiveFilter) ([]model.RepoMergeHistory, error) {
+	return s.store.MergeHistory(ctx, filter)
+}
+
+// Prune deletes archived PRs that closed longer than the retention period
+// ago. It is a no-op when the archive is kept forever.
+func (s *ArchiveService) Prune(ctx context.Context) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	n, err := s.store.PruneClosedBefore(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("prune archive: %w", err)
+	}
+	return n, nil
+}
+
+// Run prunes the archive every interval until ctx is canceled. It returns
+// immediately when the archive is kept forever.
+func (s *ArchiveService) Run(ctx context.Context, interval time.Duration) {
+	if s.retention <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.Prune(ctx); err != nil {
+			slog.Error("archive retention failed", "error", err)
+		} else if n > 0 {
+			slog.Info("pruned archived PRs", "count", n, "retention", s.retention)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WithArchiveRetention stops polling from storing merged and closed PRs that
+// closed longer than retention ago, so PRs pruned from the archive are not
+// re-imported from GitHub's full PR list. Zero stores every PR.
+func (s *PollService) WithArchiveRetention(retention time.Duration) *PollService {
+	s.archiveRetention = retention
+	return s
+}
+
+// beyondRetention reports whether pr closed before the archive retention cutoff.
+func (s *PollService) beyondRetention(pr model.PullRequest) bool {
+	if s.archiveRetention <= 0 || pr.Status == model.PRStatusOpen || pr.ClosedAt.IsZero() {
+		return false
+	}
+	return pr.ClosedAt.Before(time.Now().Add(-s.archiveRetention))
+}
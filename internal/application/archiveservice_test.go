@@ -0,0 +1,104 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+type fakeArchiveStore struct {
+	prs         []model.PullRequest
+	limit       int
+	offset      int
+	prunedUntil time.Time
+}
+
+func (f *fakeArchiveStore) ListArchived(_ context.Context, _ model.ArchiveFilter, limit, offset int) ([]model.PullRequest, error) {
+	f.limit, f.offset = limit, offset
+	if offset >= len(f.prs) {
+		return nil, nil
+	}
+	return f.prs[offset:min(offset+limit, len(f.prs))], nil
+}
+
+func (f *fakeArchiveStore) MergeHistory(context.Context, model.ArchiveFilter) ([]model.RepoMergeHistory, error) {
+	return nil, nil
+}
+
+func (f *fakeArchiveStore) PruneClosedBefore(_ context.Context, cutoff time.Time) (int, error) {
+	f.prunedUntil = cutoff
+	return 1, nil
+}
+
+func TestArchiveService_List(t *testing.T) {
+	store := &fakeArchiveStore{prs: make([]model.PullRequest, application.ArchivePageSize+3)}
+	svc := application.NewArchiveService(store, 0)
+
+	first, err := svc.List(context.Background(), model.ArchiveFilter{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Page, "pages below 1 show the first page")
+	assert.Len(t, first.PRs, application.ArchivePageSize)
+	assert.True(t, first.HasMore)
+
+	second, err := svc.List(context.Background(), model.ArchiveFilter{}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, application.ArchivePageSize, store.offset)
+	assert.Len(t, second.PRs, 3)
+	assert.False(t, second.HasMore)
+}
+
+func TestArchiveService_Prune(t *testing.T) {
+	store := &fakeArchiveStore{}
+
+	n, err := application.NewArchiveService(store, 0).Prune(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n, "zero retention keeps the archive forever")
+	assert.True(t, store.prunedUntil.IsZero())
+
+	n, err = application.NewArchiveService(store, 30*24*time.Hour).Prune(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.WithinDuration(t, time.Now().Add(-30*24*time.Hour), store.prunedUntil, time.Minute)
+}
+
+func TestPollRepo_SkipsPRsBeyondArchiveRetention(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 1, RepoFullName: "org/repo", Status: model.PRStatusMerged, UpdatedAt: now, ClosedAt: now.Add(-400 * 24 * time.Hour)},
+				{Number: 2, RepoFullName: "org/repo", Status: model.PRStatusMerged, UpdatedAt: now, ClosedAt: now.Add(-time.Hour)},
+			}, nil
+		},
+	}
+	prStore := &mockPRStore{}
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), newMockCheckStore(), "testuser", nil, time.Hour, nil, nil).
+		WithArchiveRetention(365 * 24 * time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	require.NoError(t, svc.RefreshRepo(ctx, "org/repo"))
+
+	prStore.mu.Lock()
+	defer prStore.mu.Unlock()
+	require.NotEmpty(t, prStore.upserts)
+	for _, u := range prStore.upserts {
+		assert.Equal(t, 2, u.PR.Number, "PR closed beyond retention is not stored again")
+	}
+}
@@ -0,0 +1,280 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+const (
+	// maxAttentionRules bounds the rules evaluated for every PR on every poll.
+	maxAttentionRules = 50
+
+	// maxRuleNameLen bounds an attention rule's display name.
+	maxRuleNameLen = 100
+)
+
+// ErrInvalidAttentionRule is returned for a rule with a missing name, an
+// expression that does not compile, a severity out of range, or a notify URL
+// that is not an absolute http(s) URL.
+var ErrInvalidAttentionRule = errors.New("invalid attention rule")
+
+// compiledRule is an enabled rule with its parsed expression.
+type compiledRule struct {
+	rule model.AttentionRule
+	expr *RuleExpr
+}
+
+// AttentionRuleService manages user-defined attention rules and evaluates
+// them for AttentionService. Enabled rules are compiled once and cached until
+// a rule changes.
+type AttentionRuleService struct {
+	store    driven.AttentionRuleStore
+	notifier driven.RuleNotifier // optional; rule notify URLs are ignored when nil
+	logger   *slog.Logger
+
+	mu       sync.RWMutex
+	compiled []compiledRule
+	loaded   bool
+	// generation counts rule changes, so cached signals can tell they were
+	// matched against older rules.
+	generation uint64
+}
+
+// NewAttentionRuleService creates an AttentionRuleService.
+func NewAttentionRuleService(store driven.AttentionRuleStore) *AttentionRuleService {
+	return &AttentionRuleService{store: store, logger: slog.Default()}
+}
+
+// WithNotifier enables rule notifications: Run posts to a rule's notify URL
+// when it starts matching a PR.
+func (s *AttentionRuleService) WithNotifier(notifier driven.RuleNotifier) *AttentionRuleService {
+	s.notifier = notifier
+	return s
+}
+
+// Validate compiles expr without storing anything. It returns an error
+// wrapping ErrInvalidAttentionRule that describes the first problem.
+func (s *AttentionRuleService) Validate(expr string) error {
+	if _, err := CompileRuleExpr(expr); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAttentionRule, err)
+	}
+	return nil
+}
+
+// validate normalizes and checks rule.
+func (s *AttentionRuleService) validate(rule *model.AttentionRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Expression = strings.TrimSpace(rule.Expression)
+	rule.NotifyURL = strings.TrimSpace(rule.NotifyURL)
+
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidAttentionRule)
+	}
+	if len(rule.Name) > maxRuleNameLen {
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidAttentionRule, maxRuleNameLen)
+	}
+	if rule.Severity < 1 || rule.Severity > model.MaxRuleSeverity {
+		return fmt.Errorf("%w: severity must be between 1 and %d", ErrInvalidAttentionRule, model.MaxRuleSeverity)
+	}
+	if rule.NotifyURL != "" {
+		u, err := url.Parse(rule.NotifyURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: notify URL must be an absolute http or https URL", ErrInvalidAttentionRule)
+		}
+	}
+	return s.Validate(rule.Expression)
+}
+
+// List returns all rules, enabled or not.
+func (s *AttentionRuleService) List(ctx context.Context) ([]model.AttentionRule, error) {
+	return s.store.List(ctx)
+}
+
+// Create validates and stores a rule. It returns ErrInvalidAttentionRule for
+// a bad rule or when the rule limit is reached.
+func (s *AttentionRuleService) Create(ctx context.Context, rule model.AttentionRule) (model.AttentionRule, error) {
+	if err := s.validate(&rule); err != nil {
+		return model.AttentionRule{}, err
+	}
+	existing, err := s.store.List(ctx)
+	if err != nil {
+		return model.AttentionRule{}, err
+	}
+	if len(existing) >= maxAttentionRules {
+		return model.AttentionRule{}, fmt.Errorf("%w: at most %d rules", ErrInvalidAttentionRule, maxAttentionRules)
+	}
+
+	rule.CreatedAt = time.Now().UTC()
+	id, err := s.store.Create(ctx, rule)
+	if err != nil {
+		return model.AttentionRule{}, err
+	}
+	rule.ID = id
+	s.invalidate()
+	return rule, nil
+}
+
+// Update validates and replaces a rule. It returns ErrInvalidAttentionRule
+// for a bad rule and driven.ErrAttentionRuleNotFound if it does not exist.
+func (s *AttentionRuleService) Update(ctx context.Context, rule model.AttentionRule) error {
+	if err := s.validate(&rule); err != nil {
+		return err
+	}
+	if err := s.store.Update(ctx, rule); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// Delete removes a rule. It returns driven.ErrAttentionRuleNotFound if it
+// does not exist.
+func (s *AttentionRuleService) Delete(ctx context.Context, id int64) error {
+	if err := s.store.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.invalidate()
+	return nil
+}
+
+// invalidate drops the compiled rules so the next Match reloads them.
+func (s *AttentionRuleService) invalidate() {
+	s.mu.Lock()
+	s.compiled, s.loaded = nil, false
+	s.generation++
+	s.mu.Unlock()
+}
+
+// version returns the rule generation, which changes whenever a rule does.
+func (s *AttentionRuleService) version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.generation
+}
+
+// rules returns the compiled enabled rules, loading them on first use.
+// Store errors are logged and leave the cache empty so the next call retries;
+// stored rules that no longer compile are logged and skipped.
+func (s *AttentionRuleService) rules(ctx context.Context) []compiledRule {
+	s.mu.RLock()
+	if s.loaded {
+		defer s.mu.RUnlock()
+		return s.compiled
+	}
+	s.mu.RUnlock()
+
+	stored, err := s.store.List(ctx)
+	if err != nil {
+		s.logger.Warn("failed to list attention rules", "error", err)
+		return nil
+	}
+	var compiled []compiledRule
+	for _, rule := range stored {
+		if !rule.Enabled {
+			continue
+		}
+		expr, err := CompileRuleExpr(rule.Expression)
+		if err != nil {
+			s.logger.Warn("skipping attention rule that does not compile", "rule", rule.ID, "error", err)
+			continue
+		}
+		compiled = append(compiled, compiledRule{rule: rule, expr: expr})
+	}
+
+	s.mu.Lock()
+	s.compiled, s.loaded = compiled, true
+	s.mu.Unlock()
+	return compiled
+}
+
+// Match returns the enabled rules that in satisfies, in rule order, or nil
+// when none do.
+func (s *AttentionRuleService) Match(ctx context.Context, in RuleInput) []model.RuleMatch {
+	var matches []model.RuleMatch
+	for _, c := range s.rules(ctx) {
+		if c.expr.Match(in) {
+			matches = append(matches, model.RuleMatch{RuleID: c.rule.ID, Name: c.rule.Name, Severity: c.rule.Severity})
+		}
+	}
+	return matches
+}
+
+// Run posts to a rule's notify URL whenever an attention.changed event on hub
+// shows the rule newly matching a PR, until ctx is canceled. Deliveries are
+// sequential; a failed delivery is logged and not retried. Without a notifier
+// Run returns immediately.
+func (s *AttentionRuleService) Run(ctx context.Context, hub *EventHub) {
+	if s.notifier == nil {
+		return
+	}
+	events, cancel := hub.Subscribe(EventFilter{Types: []model.PREventType{model.PREventAttentionChanged}})
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			s.notify(ctx, e)
+		}
+	}
+}
+
+// notify delivers e to the notify URL of every rule that matches now and did
+// not before.
+func (s *AttentionRuleService) notify(ctx context.Context, e model.PREvent) {
+	if e.Attention == nil || len(e.Attention.Rules) == 0 {
+		return
+	}
+	var previous model.AttentionSignals
+	if e.PreviousAttention != nil {
+		previous = *e.PreviousAttention
+	}
+
+	byID := make(map[int64]model.AttentionRule)
+	for _, c := range s.rules(ctx) {
+		byID[c.rule.ID] = c.rule
+	}
+
+	var pr model.PullRequest
+	if e.PullRequest != nil {
+		pr = *e.PullRequest
+	} else {
+		pr = model.PullRequest{RepoFullName: e.RepoFullName, Number: e.PRNumber}
+	}
+
+	for _, m := range e.Attention.Rules {
+		rule, ok := byID[m.RuleID]
+		if !ok || rule.NotifyURL == "" || previous.HasRule(m.RuleID) {
+			continue
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, webhookSendTimeout)
+		err := s.notifier.SendRuleMatched(sendCtx, rule.NotifyURL, model.RuleMatched{Rule: rule, PullRequest: pr, MatchedAt: e.OccurredAt})
+		cancel()
+		if err != nil {
+			s.logger.Warn("attention rule notification failed",
+				"rule", rule.ID, "repo", e.RepoFullName, "pr", e.PRNumber, "error", err)
+			continue
+		}
+		s.logger.Info("attention rule notification delivered", "rule", rule.ID, "repo", e.RepoFullName, "pr", e.PRNumber)
+	}
+}
+
+// WithRuleService adds the matches of user-defined attention rules to the
+// signals of every PR. Rules see the built-in signals, so they can refine them.
+func (s *AttentionService) WithRuleService(svc *AttentionRuleService) *AttentionService {
+	s.rules = svc
+	return s
+}
@@ -0,0 +1,160 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memAttentionRuleStore is an in-memory AttentionRuleStore that counts lists.
+type memAttentionRuleStore struct {
+	rules []model.AttentionRule
+	lists int
+}
+
+func (m *memAttentionRuleStore) Create(_ context.Context, rule model.AttentionRule) (int64, error) {
+	rule.ID = int64(len(m.rules) + 1)
+	m.rules = append(m.rules, rule)
+	return rule.ID, nil
+}
+
+func (m *memAttentionRuleStore) Update(_ context.Context, rule model.AttentionRule) error {
+	for i, r := range m.rules {
+		if r.ID == rule.ID {
+			m.rules[i] = rule
+			return nil
+		}
+	}
+	return driven.ErrAttentionRuleNotFound
+}
+
+func (m *memAttentionRuleStore) Delete(_ context.Context, id int64) error {
+	for i, r := range m.rules {
+		if r.ID == id {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrAttentionRuleNotFound
+}
+
+func (m *memAttentionRuleStore) List(_ context.Context) ([]model.AttentionRule, error) {
+	m.lists++
+	return m.rules, nil
+}
+
+// recordingNotifier is a RuleNotifier that records deliveries.
+type recordingNotifier struct {
+	mu   sync.Mutex
+	urls []string
+	sent []model.RuleMatched
+}
+
+func (r *recordingNotifier) SendRuleMatched(_ context.Context, url string, match model.RuleMatched) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.urls = append(r.urls, url)
+	r.sent = append(r.sent, match)
+	return nil
+}
+
+func TestAttentionRuleService_Create(t *testing.T) {
+	svc := NewAttentionRuleService(&memAttentionRuleStore{})
+	ctx := context.Background()
+
+	rule, err := svc.Create(ctx, model.AttentionRule{Name: " Mine failing ", Expression: `ci_failure`, Severity: 5, Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Mine failing", rule.Name)
+	assert.NotZero(t, rule.ID)
+
+	for _, bad := range []model.AttentionRule{
+		{Name: "", Expression: "draft", Severity: 1},
+		{Name: "x", Expression: "draft", Severity: 0},
+		{Name: "x", Expression: "draft", Severity: model.MaxRuleSeverity + 1},
+		{Name: "x", Expression: "drafty", Severity: 1},
+		{Name: "x", Expression: "draft", Severity: 1, NotifyURL: "slack"},
+	} {
+		_, err := svc.Create(ctx, bad)
+		assert.ErrorIs(t, err, ErrInvalidAttentionRule, "%+v", bad)
+	}
+
+	err = svc.Validate(`age_days > "3"`)
+	require.ErrorIs(t, err, ErrInvalidAttentionRule)
+	assert.Contains(t, err.Error(), "cannot compare number > string")
+}
+
+func TestAttentionRuleService_MatchCachesEnabledRules(t *testing.T) {
+	store := &memAttentionRuleStore{}
+	svc := NewAttentionRuleService(store)
+	ctx := context.Background()
+
+	big, err := svc.Create(ctx, model.AttentionRule{Name: "Big", Expression: "additions > 500", Severity: 2, Enabled: true})
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, model.AttentionRule{Name: "Off", Expression: "true", Severity: 1})
+	require.NoError(t, err)
+	lists := store.lists
+
+	in := RuleInput{PR: model.PullRequest{Additions: 900}}
+	assert.Equal(t, []model.RuleMatch{{RuleID: big.ID, Name: "Big", Severity: 2}}, svc.Match(ctx, in))
+	assert.Nil(t, svc.Match(ctx, RuleInput{}))
+	assert.Equal(t, lists+1, store.lists, "rules are loaded once")
+
+	big.Enabled = false
+	require.NoError(t, svc.Update(ctx, big))
+	assert.Nil(t, svc.Match(ctx, in), "updates refresh the cache")
+	assert.ErrorIs(t, svc.Delete(ctx, 99), driven.ErrAttentionRuleNotFound)
+}
+
+func TestAttentionService_AddsRuleMatches(t *testing.T) {
+	rules := NewAttentionRuleService(&memAttentionRuleStore{})
+	ctx := context.Background()
+	_, err := rules.Create(ctx, model.AttentionRule{
+		Name: "My red builds", Expression: `ci_status == "failing" && author == me && needs_more_reviews`, Severity: 5, Enabled: true,
+	})
+	require.NoError(t, err)
+
+	svc := NewAttentionService(nil, &testReviewStore{}, "alice").WithRuleService(rules)
+	pr := model.PullRequest{ID: 1, Author: "alice", CIStatus: model.CIStatusFailing, Status: model.PRStatusOpen}
+	signals, err := svc.SignalsForPR(ctx, pr, model.EffectiveThresholds{ReviewCountThreshold: 1})
+	require.NoError(t, err)
+
+	require.Len(t, signals.Rules, 1)
+	assert.Equal(t, "My red builds", signals.Rules[0].Name)
+	assert.Equal(t, 6, signals.Severity(), "needs more reviews plus the rule's severity")
+	assert.True(t, signals.HasAny())
+}
+
+func TestAttentionRuleService_NotifiesNewMatches(t *testing.T) {
+	notifier := &recordingNotifier{}
+	svc := NewAttentionRuleService(&memAttentionRuleStore{}).WithNotifier(notifier)
+	ctx := context.Background()
+
+	hooked, err := svc.Create(ctx, model.AttentionRule{Name: "Hooked", Expression: "true", Severity: 1, NotifyURL: "https://hooks.slack.com/x", Enabled: true})
+	require.NoError(t, err)
+	quiet, err := svc.Create(ctx, model.AttentionRule{Name: "Quiet", Expression: "true", Severity: 1, Enabled: true})
+	require.NoError(t, err)
+
+	pr := model.PullRequest{RepoFullName: "org/repo", Number: 7, Title: "Fix it"}
+	matched := &model.AttentionSignals{Rules: []model.RuleMatch{{RuleID: hooked.ID}, {RuleID: quiet.ID}}}
+	svc.notify(ctx, model.PREvent{
+		Type: model.PREventAttentionChanged, RepoFullName: "org/repo", PRNumber: 7, OccurredAt: time.Now(),
+		PullRequest: &pr, Attention: matched, PreviousAttention: &model.AttentionSignals{},
+	})
+	require.Len(t, notifier.sent, 1, "rules without a notify URL are silent")
+	assert.Equal(t, "https://hooks.slack.com/x", notifier.urls[0])
+	assert.Equal(t, "Hooked", notifier.sent[0].Rule.Name)
+	assert.Equal(t, "Fix it", notifier.sent[0].PullRequest.Title)
+
+	svc.notify(ctx, model.PREvent{
+		Type: model.PREventAttentionChanged, RepoFullName: "org/repo", PRNumber: 7,
+		Attention: matched, PreviousAttention: matched,
+	})
+	assert.Len(t, notifier.sent, 1, "a rule that kept matching does not notify again")
+}
@@ -3,6 +3,9 @@ package application
 import (
 	"context"
 	"log/slog"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
@@ -38,6 +41,16 @@ func ComputeAttentionSignals(
 		pr.Author == authenticatedUser &&
 		pr.CIStatus == model.CIStatusFailing
 
+	// ChecklistIncomplete: marked ready for review with unchecked task list items.
+	signals.ChecklistIncomplete = thresholds.ChecklistEnabled &&
+		pr.Status == model.PRStatusOpen && !pr.IsDraft &&
+		pr.Checklist.Incomplete()
+
+	// RequestAging: the user's pending review request is older than threshold days.
+	if requestedAt, ok := pr.ReviewRequestedAt(authenticatedUser); ok && thresholds.RequestAgeDays > 0 && pr.Status == model.PRStatusOpen {
+		signals.RequestAging = time.Since(requestedAt) >= time.Duration(thresholds.RequestAgeDays)*24*time.Hour
+	}
+
 	return signals
 }
 
@@ -46,8 +59,30 @@ func ComputeAttentionSignals(
 type AttentionService struct {
 	thresholdStore driven.ThresholdStore
 	reviewStore    driven.ReviewStore
+	protections    driven.BranchProtectionStore // optional; enables stale approval dismissal awareness
+	mentions       driven.MentionStore          // optional; enables the mentioned signal
+	contributors   driven.ContributorStore      // optional; detects first-time contributors from stored history
+	rules          *AttentionRuleService        // optional; adds user-defined rule matches
 	username       string
 	logger         *slog.Logger
+
+	// cacheTTL enables the signal cache (see WithSignalCache); zero computes
+	// signals on every call.
+	cacheTTL  time.Duration
+	cacheMu   sync.Mutex
+	cache     map[int64]cachedSignals
+	nextSweep time.Time
+}
+
+// cachedSignals is a PR's computed signals with what they were computed from.
+// An entry is reused only while all of it still matches.
+type cachedSignals struct {
+	signals     model.AttentionSignals
+	thresholds  model.EffectiveThresholds
+	updatedAt   time.Time
+	headSHA     string
+	ruleVersion uint64
+	expiresAt   time.Time
 }
 
 // NewAttentionService creates a new AttentionService.
@@ -60,6 +95,107 @@ func NewAttentionService(ts driven.ThresholdStore, rs driven.ReviewStore, userna
 	}
 }
 
+// WithBranchProtectionStore makes signals account for base branches that
+// dismiss approvals on new pushes: approvals on older commits stop counting
+// toward the review threshold and stale reviews are flagged as dismissed.
+func (s *AttentionService) WithBranchProtectionStore(store driven.BranchProtectionStore) *AttentionService {
+	s.protections = store
+	return s
+}
+
+// WithMentionStore enables the Mentioned signal: the user was @mentioned on a
+// PR and has not commented or reviewed since.
+func (s *AttentionService) WithMentionStore(store driven.MentionStore) *AttentionService {
+	s.mentions = store
+	return s
+}
+
+// WithContributorStore lets signals fall back to stored merge history when
+// GitHub's author association does not settle whether a PR's author is a
+// first-time contributor.
+func (s *AttentionService) WithContributorStore(store driven.ContributorStore) *AttentionService {
+	s.contributors = store
+	return s
+}
+
+// WithSignalCache keeps each PR's computed signals for up to ttl, so pages
+// listing many PRs do not re-read every PR's reviews and comments on each
+// load. An entry is recomputed early when the PR's updated time or head
+// commit, its thresholds, or the attention rules change; the poller drops the
+// entries of the PRs it syncs (see InvalidateSignals). ttl bounds how late
+// the time-based signals, such as age and request aging, can turn on.
+func (s *AttentionService) WithSignalCache(ttl time.Duration) *AttentionService {
+	s.cacheTTL = ttl
+	s.cache = make(map[int64]cachedSignals)
+	return s
+}
+
+// InvalidateSignals drops the cached signals of the given PRs, or of every PR
+// when none are given. It is a no-op without WithSignalCache.
+func (s *AttentionService) InvalidateSignals(prIDs ...int64) {
+	if s.cacheTTL <= 0 {
+		return
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if len(prIDs) == 0 {
+		clear(s.cache)
+		return
+	}
+	for _, id := range prIDs {
+		delete(s.cache, id)
+	}
+}
+
+// WithAttentionSignalCache drops svc's cached signals for each PR this
+// service syncs, so the next page load recomputes them from the fresh data.
+func (s *PollService) WithAttentionSignalCache(svc *AttentionService) *PollService {
+	s.signalCache = svc
+	return s
+}
+
+// cachedSignalsFor returns pr's cached signals when they were computed from
+// the same PR state, thresholds, and rules and have not expired.
+func (s *AttentionService) cachedSignalsFor(pr model.PullRequest, thresholds model.EffectiveThresholds, ruleVersion uint64, now time.Time) (model.AttentionSignals, bool) {
+	if s.cacheTTL <= 0 {
+		return model.AttentionSignals{}, false
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	e, ok := s.cache[pr.ID]
+	if !ok || now.After(e.expiresAt) || e.thresholds != thresholds || e.ruleVersion != ruleVersion ||
+		!e.updatedAt.Equal(pr.UpdatedAt) || e.headSHA != pr.HeadSHA {
+		return model.AttentionSignals{}, false
+	}
+	return e.signals, true
+}
+
+// storeSignals caches signals for pr, sweeping out expired entries (such as
+// those of deleted PRs) once per TTL.
+func (s *AttentionService) storeSignals(pr model.PullRequest, thresholds model.EffectiveThresholds, ruleVersion uint64, signals model.AttentionSignals, now time.Time) {
+	if s.cacheTTL <= 0 {
+		return
+	}
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	if now.After(s.nextSweep) {
+		for id, e := range s.cache {
+			if now.After(e.expiresAt) {
+				delete(s.cache, id)
+			}
+		}
+		s.nextSweep = now.Add(s.cacheTTL)
+	}
+	s.cache[pr.ID] = cachedSignals{
+		signals:     signals,
+		thresholds:  thresholds,
+		updatedAt:   pr.UpdatedAt,
+		headSHA:     pr.HeadSHA,
+		ruleVersion: ruleVersion,
+		expiresAt:   now.Add(s.cacheTTL),
+	}
+}
+
 // EffectiveThresholdsFor returns the resolved thresholds for a repo (global + per-repo merge).
 // Errors from the store are logged and fall back to defaults (non-fatal).
 func (s *AttentionService) EffectiveThresholdsFor(ctx context.Context, repoFullName string) model.EffectiveThresholds {
@@ -76,7 +212,15 @@ func (s *AttentionService) EffectiveThresholdsFor(ctx context.Context, repoFullN
 	}
 
 	// Merge: repo override wins if non-nil.
-	effective := model.EffectiveThresholds(global)
+	effective := model.EffectiveThresholds{
+		ReviewCountThreshold:   global.ReviewCountThreshold,
+		AgeUrgencyDays:         global.AgeUrgencyDays,
+		StaleReviewEnabled:     global.StaleReviewEnabled,
+		CIFailureEnabled:       global.CIFailureEnabled,
+		FirstTimerBoostEnabled: global.FirstTimerBoostEnabled,
+		ChecklistEnabled:       global.ChecklistEnabled,
+		RequestAgeDays:         global.RequestAgeDays,
+	}
 
 	if repoThreshold.ReviewCount != nil {
 		effective.ReviewCountThreshold = *repoThreshold.ReviewCount
@@ -90,6 +234,9 @@ func (s *AttentionService) EffectiveThresholdsFor(ctx context.Context, repoFullN
 	if repoThreshold.CIFailureEnabled != nil {
 		effective.CIFailureEnabled = *repoThreshold.CIFailureEnabled
 	}
+	if repoThreshold.FirstReviewSLAHours != nil {
+		effective.FirstReviewSLAHours = *repoThreshold.FirstReviewSLAHours
+	}
 
 	return effective
 }
@@ -97,13 +244,101 @@ func (s *AttentionService) EffectiveThresholdsFor(ctx context.Context, repoFullN
 // SignalsForPR computes attention signals for a single PR using pre-resolved thresholds.
 // Callers should fetch thresholds once per unique repo via EffectiveThresholdsFor to avoid
 // per-PR DB lookups. Returns zero-value AttentionSignals on review store error (non-fatal).
+// With WithSignalCache, signals computed earlier for the same PR state are reused.
 func (s *AttentionService) SignalsForPR(ctx context.Context, pr model.PullRequest, thresholds model.EffectiveThresholds) (model.AttentionSignals, error) {
+	ruleVersion, now := s.ruleVersion(), time.Now()
+	if signals, ok := s.cachedSignalsFor(pr, thresholds, ruleVersion, now); ok {
+		return signals, nil
+	}
+
 	reviews, err := s.reviewStore.GetReviewsByPR(ctx, pr.ID)
 	if err != nil {
 		s.logger.Warn("failed to get reviews for attention signals", "pr_id", pr.ID, "error", err)
 		return model.AttentionSignals{}, nil
 	}
+	signals := s.computeSignals(ctx, pr, reviews, thresholds, nil)
+	s.storeSignals(pr, thresholds, ruleVersion, signals, now)
+	return signals, nil
+}
+
+// SignalsForPRs computes the signals of many PRs with a fixed number of
+// queries, keyed by PR ID. thresholdsByRepo holds each repo's thresholds as
+// resolved by EffectiveThresholdsFor. The reviews of every PR not in the
+// signal cache are loaded in one query, and branch protection and contributor
+// history once per repo. A review store error is logged and leaves those PRs
+// with zero signals, as in SignalsForPR.
+func (s *AttentionService) SignalsForPRs(ctx context.Context, prs []model.PullRequest, thresholdsByRepo map[string]model.EffectiveThresholds) map[int64]model.AttentionSignals {
+	ruleVersion, now := s.ruleVersion(), time.Now()
+	result := make(map[int64]model.AttentionSignals, len(prs))
+	var misses []model.PullRequest
+	for _, pr := range prs {
+		if signals, ok := s.cachedSignalsFor(pr, thresholdsByRepo[pr.RepoFullName], ruleVersion, now); ok {
+			result[pr.ID] = signals
+		} else {
+			misses = append(misses, pr)
+		}
+	}
+	if len(misses) == 0 {
+		return result
+	}
+
+	reviewsByPR, err := s.reviewStore.GetReviewsByPRs(ctx, prIDs(misses))
+	if err != nil {
+		s.logger.Warn("failed to get reviews for attention signals", "prs", len(misses), "error", err)
+		return result
+	}
+
+	batch := s.newSignalBatch(ctx)
+	for _, pr := range misses {
+		thresholds := thresholdsByRepo[pr.RepoFullName]
+		signals := s.computeSignals(ctx, pr, reviewsByPR[pr.ID], thresholds, batch)
+		s.storeSignals(pr, thresholds, ruleVersion, signals, now)
+		result[pr.ID] = signals
+	}
+	return result
+}
+
+// signalBatch memoizes the lookups shared by the PRs of one SignalsForPRs
+// call.
+type signalBatch struct {
+	// mentioned holds the PRs with a comment mentioning the user; nil when
+	// unknown, in which case each PR's mentions are read.
+	mentioned    map[int64]bool
+	dismissStale map[string]bool // by repo and base branch
+	firstTimers  map[string]bool // by repo and author
+}
+
+// newSignalBatch starts a signal batch, loading the PRs that mention the
+// user in one query. A store error is logged and leaves mentions per PR.
+func (s *AttentionService) newSignalBatch(ctx context.Context) *signalBatch {
+	batch := &signalBatch{dismissStale: make(map[string]bool), firstTimers: make(map[string]bool)}
+	if s.mentions == nil || s.username == "" {
+		return batch
+	}
+	ids, err := s.mentions.ListPRIDsMentioning(ctx, s.username)
+	if err != nil {
+		s.logger.Warn("failed to list mentioning PRs for attention signals", "error", err)
+		return batch
+	}
+	batch.mentioned = make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		batch.mentioned[id] = true
+	}
+	return batch
+}
 
+// ruleVersion returns the attention rule generation cached signals are
+// checked against, 0 without rules.
+func (s *AttentionService) ruleVersion() uint64 {
+	if s.rules == nil {
+		return 0
+	}
+	return s.rules.version()
+}
+
+// computeSignals computes pr's signals from its stored reviews. batch, when
+// non-nil, shares lookups across the PRs of a SignalsForPRs call.
+func (s *AttentionService) computeSignals(ctx context.Context, pr model.PullRequest, reviews []model.Review, thresholds model.EffectiveThresholds, batch *signalBatch) model.AttentionSignals {
 	// Collapse to each reviewer's latest review to avoid double-counting when
 	// the same person has reviewed multiple times (e.g., approve → request changes → approve).
 	latestByReviewer := make(map[string]model.Review, len(reviews))
@@ -114,17 +349,150 @@ func (s *AttentionService) SignalsForPR(ctx context.Context, pr model.PullReques
 		}
 	}
 
-	// Count approvals and locate the authenticated user's review SHA in one pass.
+	// Approvals on an older commit no longer count when the base branch
+	// dismisses stale approvals; GitHub will have dismissed or will dismiss them.
+	dismissStale := s.dismissesStaleReviews(ctx, pr, batch)
+	isCurrent := func(r model.Review) bool {
+		return !dismissStale || r.CommitID == "" || r.CommitID == pr.HeadSHA
+	}
+
+	// Count approvals and locate the authenticated user's review in one pass.
 	approvalCount := 0
-	var userReviewSHA string
+	var userReview model.Review
 	for login, r := range latestByReviewer {
-		if r.State == model.ReviewStateApproved && !r.IsBot {
+		if r.State == model.ReviewStateApproved && !r.IsBot && isCurrent(r) {
 			approvalCount++
 		}
 		if login == s.username {
-			userReviewSHA = r.CommitID
+			userReview = r
+		}
+	}
+
+	signals := ComputeAttentionSignals(pr, approvalCount, userReview.CommitID, thresholds, s.username)
+	signals.ApprovalDismissed = signals.HasStaleReview && dismissStale &&
+		(userReview.State == model.ReviewStateApproved || userReview.State == model.ReviewStateDismissed)
+	signals.Mentioned = s.awaitingReply(ctx, pr, reviews, batch)
+	if sla, ok := ComputeSLAStatus(pr, reviews, thresholds.FirstReviewSLAHours, time.Now()); ok {
+		signals.SLABreached = sla.State == model.SLAOverdue
+	}
+	signals.FirstTimeContributor = s.isFirstTimeContributor(ctx, pr, batch)
+	signals.NewcomerWaiting = thresholds.FirstTimerBoostEnabled &&
+		signals.FirstTimeContributor &&
+		approvalCount < thresholds.ReviewCountThreshold
+	if s.rules != nil {
+		signals.Rules = s.rules.Match(ctx, RuleInput{PR: pr, Signals: signals, Me: s.username})
+	}
+	return signals
+}
+
+// isFirstTimeContributor reports whether pr's author has never had a PR
+// merged in its repository. GitHub's author association answers this for
+// first-timers, prior contributors, and team members, who are never flagged;
+// for authors of no or unknown association, stored merge history decides.
+func (s *AttentionService) isFirstTimeContributor(ctx context.Context, pr model.PullRequest, batch *signalBatch) bool {
+	if pr.Status != model.PRStatusOpen {
+		return false
+	}
+	switch pr.AuthorAssociation {
+	case model.AssociationFirstTimeContributor, model.AssociationFirstTimer:
+		return true
+	case model.AssociationContributor, model.AssociationOwner, model.AssociationMember, model.AssociationCollaborator:
+		return false
+	}
+	if s.contributors == nil || pr.Author == "" {
+		return false
+	}
+	key := pr.RepoFullName + "\x00" + pr.Author
+	if batch != nil {
+		if firstTimer, seen := batch.firstTimers[key]; seen {
+			return firstTimer
 		}
 	}
+	merged, err := s.contributors.HasMergedPR(ctx, pr.RepoFullName, pr.Author)
+	if err != nil {
+		s.logger.Warn("failed to check contributor history for attention signals", "pr_id", pr.ID, "error", err)
+		return false
+	}
+	if batch != nil {
+		batch.firstTimers[key] = !merged
+	}
+	return !merged
+}
 
-	return ComputeAttentionSignals(pr, approvalCount, userReviewSHA, thresholds, s.username), nil
+// awaitingReply reports whether someone @mentioned the user on pr after the
+// user's last review or comment there. reviews are the PR's stored reviews.
+// Store errors report false.
+func (s *AttentionService) awaitingReply(ctx context.Context, pr model.PullRequest, reviews []model.Review, batch *signalBatch) bool {
+	if s.mentions == nil || s.username == "" {
+		return false
+	}
+	if batch != nil && batch.mentioned != nil && !batch.mentioned[pr.ID] {
+		return false
+	}
+	mentions, err := s.mentions.GetMentionsByPR(ctx, pr.ID)
+	if err != nil {
+		s.logger.Warn("failed to get mentions for attention signals", "pr_id", pr.ID, "error", err)
+		return false
+	}
+
+	var lastMention time.Time
+	for _, m := range mentions {
+		if strings.EqualFold(m.Login, s.username) && m.CreatedAt.After(lastMention) {
+			lastMention = m.CreatedAt
+		}
+	}
+	if lastMention.IsZero() {
+		return false
+	}
+
+	for _, r := range reviews {
+		if strings.EqualFold(r.ReviewerLogin, s.username) && !r.SubmittedAt.Before(lastMention) {
+			return false
+		}
+	}
+	comments, err := s.reviewStore.GetIssueCommentsByPR(ctx, pr.ID)
+	if err != nil {
+		s.logger.Warn("failed to get issue comments for attention signals", "pr_id", pr.ID, "error", err)
+		return false
+	}
+	for _, c := range comments {
+		if strings.EqualFold(c.Author, s.username) && !c.CreatedAt.Before(lastMention) {
+			return false
+		}
+	}
+	reviewComments, err := s.reviewStore.GetReviewCommentsByPR(ctx, pr.ID)
+	if err != nil {
+		s.logger.Warn("failed to get review comments for attention signals", "pr_id", pr.ID, "error", err)
+		return false
+	}
+	for _, c := range reviewComments {
+		if strings.EqualFold(c.Author, s.username) && !c.CreatedAt.Before(lastMention) {
+			return false
+		}
+	}
+	return true
+}
+
+// dismissesStaleReviews reports whether the PR's base branch dismisses
+// approvals on new pushes. Unknown branches and store errors report false.
+func (s *AttentionService) dismissesStaleReviews(ctx context.Context, pr model.PullRequest, batch *signalBatch) bool {
+	if s.protections == nil {
+		return false
+	}
+	key := pr.RepoFullName + "\x00" + pr.BaseBranch
+	if batch != nil {
+		if dismiss, seen := batch.dismissStale[key]; seen {
+			return dismiss
+		}
+	}
+	protection, err := s.protections.Get(ctx, pr.RepoFullName, pr.BaseBranch)
+	if err != nil {
+		s.logger.Warn("failed to get branch protection for attention signals", "repo", pr.RepoFullName, "branch", pr.BaseBranch, "error", err)
+		return false
+	}
+	dismiss := protection != nil && protection.DismissStaleReviews
+	if batch != nil {
+		batch.dismissStale[key] = dismiss
+	}
+	return dismiss
 }
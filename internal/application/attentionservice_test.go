@@ -63,6 +63,23 @@ func defaultThresholds() model.EffectiveThresholds {
 	}
 }
 
+func TestComputeAttentionSignals_RequestAging(t *testing.T) {
+	thresholds := defaultThresholds()
+	thresholds.RequestAgeDays = 2
+	pr := prWithAge(10)
+	pr.ReviewRequests = []model.ReviewRequest{{Reviewer: testAuthor, RequestedAt: time.Now().Add(-50 * time.Hour)}}
+
+	assert.True(t, application.ComputeAttentionSignals(pr, 1, "", thresholds, testAuthor).RequestAging)
+
+	pr.ReviewRequests[0].RequestedAt = time.Now().Add(-time.Hour)
+	assert.False(t, application.ComputeAttentionSignals(pr, 1, "", thresholds, testAuthor).RequestAging,
+		"a fresh re-request on an old PR is not aging")
+
+	pr.ReviewRequests[0].RequestedAt = time.Now().Add(-50 * time.Hour)
+	thresholds.RequestAgeDays = 0
+	assert.False(t, application.ComputeAttentionSignals(pr, 1, "", thresholds, testAuthor).RequestAging, "threshold 0 disables")
+}
+
 func TestComputeAttentionSignals_NeedsMoreReviews(t *testing.T) {
 	thresholds := defaultThresholds()
 	pr := prWithAge(0)
@@ -336,3 +353,194 @@ func TestEffectiveThresholdsFor_GlobalFallbackOnStoreError(t *testing.T) {
 	assert.Equal(t, defaults.StaleReviewEnabled, effective.StaleReviewEnabled)
 	assert.Equal(t, defaults.CIFailureEnabled, effective.CIFailureEnabled)
 }
+
+// staticBranchProtectionStore returns the same protection for every branch.
+type staticBranchProtectionStore struct {
+	protection *model.BranchProtection
+}
+
+func (s *staticBranchProtectionStore) Upsert(_ context.Context, _ model.BranchProtection) error {
+	return nil
+}
+
+func (s *staticBranchProtectionStore) Get(_ context.Context, _, _ string) (*model.BranchProtection, error) {
+	return s.protection, nil
+}
+
+func TestSignalsForPR_DismissStaleReviews(t *testing.T) {
+	now := time.Now()
+	pr := model.PullRequest{ID: 1, RepoFullName: "owner/repo", BaseBranch: "main", HeadSHA: "sha2", Status: model.PRStatusOpen, OpenedAt: now}
+	thresholds := defaultThresholds() // ReviewCountThreshold = 1
+	reviews := []model.Review{
+		{ReviewerLogin: testAuthor, State: model.ReviewStateApproved, SubmittedAt: now, CommitID: "sha1"},
+	}
+
+	newService := func(dismiss bool) *application.AttentionService {
+		return application.NewAttentionService(
+			&attentionThresholdStore{global: model.DefaultGlobalSettings()},
+			&mockReviewStore{stubReviews: reviews},
+			testAuthor,
+		).WithBranchProtectionStore(&staticBranchProtectionStore{
+			protection: &model.BranchProtection{RepoFullName: "owner/repo", Branch: "main", DismissStaleReviews: dismiss},
+		})
+	}
+
+	t.Run("stale approval still counts when the branch keeps approvals", func(t *testing.T) {
+		signals, err := newService(false).SignalsForPR(context.Background(), pr, thresholds)
+		require.NoError(t, err)
+		assert.False(t, signals.NeedsMoreReviews)
+		assert.True(t, signals.HasStaleReview)
+		assert.False(t, signals.ApprovalDismissed)
+	})
+
+	t.Run("stale approval is dismissed when the branch dismisses stale reviews", func(t *testing.T) {
+		signals, err := newService(true).SignalsForPR(context.Background(), pr, thresholds)
+		require.NoError(t, err)
+		assert.True(t, signals.NeedsMoreReviews, "dismissed approval should not count toward threshold")
+		assert.True(t, signals.HasStaleReview)
+		assert.True(t, signals.ApprovalDismissed)
+	})
+}
+
+// staticContributorStore reports a fixed merge history for every author.
+type staticContributorStore struct {
+	merged bool
+	calls  int
+}
+
+func (s *staticContributorStore) HasMergedPR(_ context.Context, _, _ string) (bool, error) {
+	s.calls++
+	return s.merged, nil
+}
+
+func TestSignalsForPR_FirstTimeContributor(t *testing.T) {
+	newService := func(contributors *staticContributorStore) *application.AttentionService {
+		return application.NewAttentionService(
+			&attentionThresholdStore{global: model.DefaultGlobalSettings()},
+			&mockReviewStore{},
+			testAuthor,
+		).WithContributorStore(contributors)
+	}
+	prBy := func(association model.AuthorAssociation) model.PullRequest {
+		return model.PullRequest{ID: 1, RepoFullName: "owner/repo", Author: "newbie", Status: model.PRStatusOpen, AuthorAssociation: association, OpenedAt: time.Now()}
+	}
+
+	t.Run("GitHub's association settles it without history", func(t *testing.T) {
+		contributors := &staticContributorStore{merged: true}
+		signals, err := newService(contributors).SignalsForPR(context.Background(), prBy(model.AssociationFirstTimeContributor), defaultThresholds())
+		require.NoError(t, err)
+		assert.True(t, signals.FirstTimeContributor)
+
+		signals, err = newService(contributors).SignalsForPR(context.Background(), prBy(model.AssociationMember), defaultThresholds())
+		require.NoError(t, err)
+		assert.False(t, signals.FirstTimeContributor, "team members are never flagged")
+		assert.Zero(t, contributors.calls)
+	})
+
+	t.Run("stored history decides for unknown associations", func(t *testing.T) {
+		signals, err := newService(&staticContributorStore{merged: false}).SignalsForPR(context.Background(), prBy(model.AssociationNone), defaultThresholds())
+		require.NoError(t, err)
+		assert.True(t, signals.FirstTimeContributor)
+
+		signals, err = newService(&staticContributorStore{merged: true}).SignalsForPR(context.Background(), prBy(""), defaultThresholds())
+		require.NoError(t, err)
+		assert.False(t, signals.FirstTimeContributor)
+	})
+
+	t.Run("boost adds a signal only when enabled", func(t *testing.T) {
+		pr := prBy(model.AssociationFirstTimer)
+		signals, err := newService(&staticContributorStore{}).SignalsForPR(context.Background(), pr, defaultThresholds())
+		require.NoError(t, err)
+		assert.False(t, signals.NewcomerWaiting)
+		assert.Equal(t, 1, signals.Severity(), "the badge alone does not raise severity")
+
+		boosted := defaultThresholds()
+		boosted.FirstTimerBoostEnabled = true
+		signals, err = newService(&staticContributorStore{}).SignalsForPR(context.Background(), pr, boosted)
+		require.NoError(t, err)
+		assert.True(t, signals.NewcomerWaiting)
+		assert.Equal(t, 2, signals.Severity())
+	})
+}
+
+func TestSignalsForPR_SignalCache(t *testing.T) {
+	now := time.Now()
+	pr := model.PullRequest{ID: 1, HeadSHA: "sha1", Status: model.PRStatusOpen, OpenedAt: now, UpdatedAt: now}
+	approval := []model.Review{{ReviewerLogin: "bob", State: model.ReviewStateApproved, SubmittedAt: now, CommitID: "sha1"}}
+
+	newService := func() (*application.AttentionService, *mockReviewStore) {
+		reviews := &mockReviewStore{}
+		svc := application.NewAttentionService(&attentionThresholdStore{global: model.DefaultGlobalSettings()}, reviews, testAuthor).
+			WithSignalCache(time.Minute)
+		return svc, reviews
+	}
+	needsReviews := func(t *testing.T, svc *application.AttentionService, pr model.PullRequest, thresholds model.EffectiveThresholds) bool {
+		t.Helper()
+		signals, err := svc.SignalsForPR(context.Background(), pr, thresholds)
+		require.NoError(t, err)
+		return signals.NeedsMoreReviews
+	}
+
+	t.Run("reuses signals until the PR is invalidated", func(t *testing.T) {
+		svc, reviews := newService()
+		assert.True(t, needsReviews(t, svc, pr, defaultThresholds()))
+
+		reviews.stubReviews = approval
+		assert.True(t, needsReviews(t, svc, pr, defaultThresholds()), "cached signals should be reused")
+
+		svc.InvalidateSignals(pr.ID)
+		assert.False(t, needsReviews(t, svc, pr, defaultThresholds()))
+	})
+
+	t.Run("recomputes when the PR or thresholds change", func(t *testing.T) {
+		svc, reviews := newService()
+		assert.True(t, needsReviews(t, svc, pr, defaultThresholds()))
+		reviews.stubReviews = approval
+
+		updated := pr
+		updated.UpdatedAt = now.Add(time.Minute)
+		assert.False(t, needsReviews(t, svc, updated, defaultThresholds()), "a newer PR should not reuse older signals")
+
+		stricter := defaultThresholds()
+		stricter.ReviewCountThreshold = 2
+		assert.True(t, needsReviews(t, svc, updated, stricter), "changed thresholds should not reuse older signals")
+	})
+
+	t.Run("does not cache after a store error", func(t *testing.T) {
+		svc, reviews := newService()
+		reviews.stubErr = errors.New("db unavailable")
+		assert.False(t, needsReviews(t, svc, pr, defaultThresholds()))
+
+		reviews.stubErr = nil
+		assert.True(t, needsReviews(t, svc, pr, defaultThresholds()))
+	})
+}
+
+func TestSignalsForPRs(t *testing.T) {
+	now := time.Now()
+	reviews := []model.Review{{ReviewerLogin: testAuthor, State: model.ReviewStateApproved, SubmittedAt: now, CommitID: "sha1"}}
+	contributors := &staticContributorStore{}
+	svc := application.NewAttentionService(
+		&attentionThresholdStore{global: model.DefaultGlobalSettings()},
+		&mockReviewStore{stubReviews: reviews},
+		testAuthor,
+	).WithContributorStore(contributors)
+
+	prs := []model.PullRequest{
+		{ID: 1, RepoFullName: "owner/repo", Author: "newbie", HeadSHA: "sha1", Status: model.PRStatusOpen, OpenedAt: now},
+		{ID: 2, RepoFullName: "owner/repo", Author: "newbie", HeadSHA: "sha2", Status: model.PRStatusOpen, OpenedAt: now},
+	}
+	thresholds := map[string]model.EffectiveThresholds{"owner/repo": defaultThresholds()}
+
+	byPR := svc.SignalsForPRs(context.Background(), prs, thresholds)
+	require.Len(t, byPR, 2)
+	assert.Equal(t, 1, contributors.calls, "contributor history is looked up once per repo and author")
+	for _, pr := range prs {
+		single, err := svc.SignalsForPR(context.Background(), pr, defaultThresholds())
+		require.NoError(t, err)
+		assert.Equal(t, single, byPR[pr.ID], "PR %d", pr.ID)
+	}
+	assert.False(t, byPR[1].HasStaleReview)
+	assert.True(t, byPR[2].HasStaleReview)
+	assert.True(t, byPR[2].FirstTimeContributor)
+}
@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// AutocompleteService suggests emoji shortcodes and @mentions while writing
+// comments and reviews.
+type AutocompleteService struct {
+	participants driven.ParticipantStore
+}
+
+// NewAutocompleteService creates an AutocompleteService.
+func NewAutocompleteService(participants driven.ParticipantStore) *AutocompleteService {
+	return &AutocompleteService{participants: participants}
+}
+
+// Emoji returns up to limit shortcodes matching query, ignoring case:
+// those starting with it first, then those containing it.
+func (s *AutocompleteService) Emoji(query string, limit int) []EmojiShortcode {
+	query = strings.ToLower(strings.Trim(query, ":"))
+
+	var prefixed, contained []EmojiShortcode
+	for _, e := range emojiShortcodes {
+		switch {
+		case strings.HasPrefix(e.Code, query):
+			prefixed = append(prefixed, e)
+		case strings.Contains(e.Code, query):
+			contained = append(contained, e)
+		}
+	}
+
+	matches := append(prefixed, contained...)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Users returns up to limit logins seen on repoFullName's PRs (every
+// repository when empty) that start with prefix, most active first.
+func (s *AutocompleteService) Users(ctx context.Context, repoFullName, prefix string, limit int) ([]string, error) {
+	return s.participants.SearchParticipants(ctx, repoFullName, strings.TrimPrefix(prefix, "@"), limit)
+}
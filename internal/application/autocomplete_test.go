@@ -0,0 +1,25 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutocompleteService_Emoji(t *testing.T) {
+	svc := NewAutocompleteService(nil)
+
+	got := svc.Emoji("ROCK", 5)
+	assert.Equal(t, []EmojiShortcode{{"rocket", "🚀"}}, got)
+
+	got = svc.Emoji(":up", 10)
+	codes := make([]string, 0, len(got))
+	for _, e := range got {
+		codes = append(codes, e.Code)
+	}
+	assert.Equal(t, "upside_down_face", codes[0], "prefix matches come first")
+	assert.Contains(t, codes, "thumbsup")
+
+	assert.Len(t, svc.Emoji("", 3), 3)
+	assert.Empty(t, svc.Emoji("nosuchemoji", 5))
+}
@@ -0,0 +1,25 @@
+package application
+
+import "github.com/ericfisherdev/mygitpanel/internal/domain/model"
+
+// BoardColumnFor places an open PR on the board from its stored state and
+// aggregated review status. Earlier rules win: drafts stay in Draft whatever
+// their checks, a failing CI run outranks any review verdict, and an approved
+// PR is only ready to merge once CI is not pending and it has no conflicts.
+func BoardColumnFor(pr model.PullRequest, review model.ReviewState) model.BoardColumn {
+	switch {
+	case pr.IsDraft:
+		return model.BoardDraft
+	case pr.CIStatus == model.CIStatusFailing:
+		return model.BoardCIFailing
+	case review == model.ReviewStateChangesRequested:
+		return model.BoardChangesRequested
+	case review == model.ReviewStateApproved:
+		if pr.CIStatus == model.CIStatusPending || pr.MergeableStatus == model.MergeableConflicted {
+			return model.BoardApproved
+		}
+		return model.BoardReadyToMerge
+	default:
+		return model.BoardAwaitingReview
+	}
+}
@@ -0,0 +1,34 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestBoardColumnFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		pr     model.PullRequest
+		review model.ReviewState
+		want   model.BoardColumn
+	}{
+		{"draft outranks failing CI", model.PullRequest{IsDraft: true, CIStatus: model.CIStatusFailing}, model.ReviewStateApproved, model.BoardDraft},
+		{"failing CI outranks reviews", model.PullRequest{CIStatus: model.CIStatusFailing}, model.ReviewStateApproved, model.BoardCIFailing},
+		{"changes requested", model.PullRequest{CIStatus: model.CIStatusPassing}, model.ReviewStateChangesRequested, model.BoardChangesRequested},
+		{"approved with pending CI", model.PullRequest{CIStatus: model.CIStatusPending}, model.ReviewStateApproved, model.BoardApproved},
+		{"approved with conflicts", model.PullRequest{CIStatus: model.CIStatusPassing, MergeableStatus: model.MergeableConflicted}, model.ReviewStateApproved, model.BoardApproved},
+		{"approved and green", model.PullRequest{CIStatus: model.CIStatusPassing, MergeableStatus: model.MergeableMergeable}, model.ReviewStateApproved, model.BoardReadyToMerge},
+		{"approved without CI", model.PullRequest{CIStatus: model.CIStatusUnknown}, model.ReviewStateApproved, model.BoardReadyToMerge},
+		{"no reviews yet", model.PullRequest{CIStatus: model.CIStatusPassing}, model.ReviewStatePending, model.BoardAwaitingReview},
+		{"only comments", model.PullRequest{}, model.ReviewStateCommented, model.BoardAwaitingReview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, application.BoardColumnFor(tt.pr, tt.review))
+		})
+	}
+}
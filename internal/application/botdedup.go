@@ -0,0 +1,154 @@
+package application
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// botRepeatSimilarity is the share of distinct words two bot comments must
+// have in common to count as reposts of the same comment.
+const botRepeatSimilarity = 0.8
+
+// volatileTokenPattern matches the parts of a bot comment that change
+// between reposts: commit SHAs, numbers, and percentages.
+var volatileTokenPattern = regexp.MustCompile(`\b[0-9a-f]{7,40}\b|\d+(\.\d+)?%?`)
+
+// dedupBotThreads collapses reposts of the same bot review comment into the
+// most recent thread, moving the older roots into its Repeats. Threads are
+// reposts when a bot started them, nobody replied, they share a file, line,
+// and resolved state, and their bodies are similar. An outdated comment has
+// lost its line, so it matches any line of its file.
+func dedupBotThreads(threads []CommentThread, botUsernames []string) []CommentThread {
+	// Newest first, so each cluster is keyed by the copy that is kept.
+	order := make([]int, len(threads))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return threads[order[a]].RootComment.CreatedAt.After(threads[order[b]].RootComment.CreatedAt)
+	})
+
+	dropped := make(map[int]bool)
+	var kept []int
+	for _, i := range order {
+		t := threads[i]
+		if len(t.Replies) > 0 || !isBotUser(t.RootComment.Author, botUsernames) {
+			continue
+		}
+		for _, k := range kept {
+			if isBotThreadRepeat(threads[k], t) {
+				dropped[i] = true
+				threads[k].Repeats = append(threads[k].Repeats, t.RootComment)
+				break
+			}
+		}
+		if !dropped[i] {
+			kept = append(kept, i)
+		}
+	}
+	if len(dropped) == 0 {
+		return threads
+	}
+
+	result := make([]CommentThread, 0, len(threads)-len(dropped))
+	for i, t := range threads {
+		if !dropped[i] {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// isBotThreadRepeat reports whether older, a bot thread without replies,
+// reposts the root comment of newest.
+func isBotThreadRepeat(newest, older CommentThread) bool {
+	a, b := newest.RootComment, older.RootComment
+	if !strings.EqualFold(a.Author, b.Author) || a.Path != b.Path || newest.IsResolved != older.IsResolved {
+		return false
+	}
+	if a.Line != b.Line && !b.IsOutdated {
+		return false
+	}
+	return similarBotBodies(a.Body, b.Body)
+}
+
+// dedupBotIssueComments collapses reposts of the same bot PR comment into the
+// most recent copy. It returns the remaining comments in their original order
+// and the older copies, newest first, keyed by the ID of the copy kept.
+func dedupBotIssueComments(comments []model.IssueComment) ([]model.IssueComment, map[int64][]model.IssueComment) {
+	order := make([]int, len(comments))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return comments[order[a]].CreatedAt.After(comments[order[b]].CreatedAt)
+	})
+
+	var repeats map[int64][]model.IssueComment
+	dropped := make(map[int]bool)
+	var kept []int
+	for _, i := range order {
+		c := comments[i]
+		if !c.IsBot {
+			continue
+		}
+		for _, k := range kept {
+			newest := comments[k]
+			if strings.EqualFold(newest.Author, c.Author) && similarBotBodies(newest.Body, c.Body) {
+				if repeats == nil {
+					repeats = make(map[int64][]model.IssueComment)
+				}
+				repeats[newest.ID] = append(repeats[newest.ID], c)
+				dropped[i] = true
+				break
+			}
+		}
+		if !dropped[i] {
+			kept = append(kept, i)
+		}
+	}
+	if len(dropped) == 0 {
+		return comments, nil
+	}
+
+	result := make([]model.IssueComment, 0, len(comments)-len(dropped))
+	for i, c := range comments {
+		if !dropped[i] {
+			result = append(result, c)
+		}
+	}
+	return result, repeats
+}
+
+// similarBotBodies reports whether two bot comment bodies say the same thing,
+// ignoring case, punctuation, SHAs, and numbers, by the overlap of their words.
+func similarBotBodies(a, b string) bool {
+	wordsA, wordsB := botBodyWords(a), botBodyWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return len(wordsA) == len(wordsB)
+	}
+
+	shared := 0
+	for w := range wordsA {
+		if wordsB[w] {
+			shared++
+		}
+	}
+	union := len(wordsA) + len(wordsB) - shared
+	return float64(shared)/float64(union) >= botRepeatSimilarity
+}
+
+// botBodyWords returns the distinct lower-cased words of a bot comment body
+// with its volatile tokens and punctuation removed.
+func botBodyWords(body string) map[string]bool {
+	body = volatileTokenPattern.ReplaceAllString(strings.ToLower(body), " ")
+	words := make(map[string]bool)
+	for _, w := range strings.FieldsFunc(body, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		words[w] = true
+	}
+	return words
+}
@@ -0,0 +1,73 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestDedupBotThreads(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	bots := []string{"lint-bot"}
+	comment := func(id int64, author, path string, line int, body string, hours int) model.ReviewComment {
+		return model.ReviewComment{ID: id, Author: author, Path: path, Line: line, Body: body, CreatedAt: base.Add(time.Duration(hours) * time.Hour)}
+	}
+
+	outdated := comment(2, "lint-bot", "a.go", 0, "Unused variable `x` (rule 12, commit 3f2a9c1)", 1)
+	outdated.IsOutdated = true
+	threads := []CommentThread{
+		{RootComment: comment(1, "lint-bot", "a.go", 10, "Unused variable `x` (rule 12, commit 9b8e7d6)", 0)},
+		{RootComment: outdated},
+		{RootComment: comment(3, "Lint-Bot", "a.go", 10, "Unused variable `x` (rule 12, commit 1a2b3c4)", 2)},
+		{RootComment: comment(4, "lint-bot", "a.go", 10, "Function is too long; split it up", 3)},
+		{RootComment: comment(5, "lint-bot", "b.go", 10, "Unused variable `x` (rule 12)", 4)},
+		{RootComment: comment(6, "alice", "a.go", 10, "Unused variable `x`", 5)},
+		{
+			RootComment: comment(7, "lint-bot", "a.go", 10, "Unused variable `x` (rule 12)", 6),
+			Replies:     []model.ReviewComment{comment(8, "alice", "a.go", 10, "Intentional", 7)},
+		},
+	}
+
+	got := dedupBotThreads(threads, bots)
+
+	ids := make([]int64, len(got))
+	for i, thread := range got {
+		ids[i] = thread.RootComment.ID
+	}
+	assert.Equal(t, []int64{3, 4, 5, 6, 7}, ids, "older reposts fold into the newest copy without replies")
+	require.Len(t, got[0].Repeats, 2)
+	assert.Equal(t, int64(2), got[0].Repeats[0].ID, "repeats are newest first")
+	assert.Equal(t, int64(1), got[0].Repeats[1].ID)
+	for _, thread := range got[1:] {
+		assert.Empty(t, thread.Repeats, thread.RootComment.ID)
+	}
+}
+
+func TestDedupBotIssueComments(t *testing.T) {
+	base := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	comments := []model.IssueComment{
+		{ID: 1, Author: "codecov", IsBot: true, Body: "Coverage is 81.2% (+0.4%) at abc1234", CreatedAt: base},
+		{ID: 2, Author: "alice", Body: "Coverage is 81.2% (+0.4%) at abc1234", CreatedAt: base.Add(time.Hour)},
+		{ID: 3, Author: "codecov", IsBot: true, Body: "Coverage is 80.9% (-0.3%) at def5678", CreatedAt: base.Add(2 * time.Hour)},
+		{ID: 4, Author: "codecov", IsBot: true, Body: "Bundle size grew past the configured budget", CreatedAt: base.Add(3 * time.Hour)},
+	}
+
+	got, repeats := dedupBotIssueComments(comments)
+
+	ids := make([]int64, len(got))
+	for i, c := range got {
+		ids[i] = c.ID
+	}
+	assert.Equal(t, []int64{2, 3, 4}, ids)
+	require.Len(t, repeats[3], 1)
+	assert.Equal(t, int64(1), repeats[3][0].ID)
+	assert.Len(t, repeats, 1)
+
+	got, repeats = dedupBotIssueComments(comments[:2])
+	assert.Len(t, got, 2)
+	assert.Nil(t, repeats)
+}
@@ -0,0 +1,35 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithBranchProtectionStore enables recording each base branch's review
+// rules: required approvals, code owner review, and stale approval dismissal.
+// They are fetched alongside required status checks, once per branch per
+// poll cycle.
+func (s *PollService) WithBranchProtectionStore(store driven.BranchProtectionStore) *PollService {
+	s.protections = store
+	return s
+}
+
+// refreshBranchProtection fetches and stores the branch's review rules.
+// Fetch failures keep the previously stored values.
+func (s *PollService) refreshBranchProtection(ctx context.Context, gh driven.GitHubClient, repoFullName, branch string) {
+	if s.protections == nil {
+		return
+	}
+
+	protection, err := gh.FetchBranchProtection(ctx, repoFullName, branch)
+	if err != nil {
+		slog.Error("fetch review enforcement failed", "repo", repoFullName, "branch", branch, "error", err)
+		return
+	}
+
+	if err := s.protections.Upsert(ctx, protection); err != nil {
+		slog.Error("store branch protection failed", "repo", repoFullName, "branch", branch, "error", err)
+	}
+}
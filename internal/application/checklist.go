@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// ParseChecklist counts the Markdown task list items in body: list items
+// starting with "[ ]" or "[x]", with any list marker and indentation. Items
+// inside fenced code blocks are ignored.
+func ParseChecklist(body string) model.ChecklistProgress {
+	var progress model.ChecklistProgress
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		item, ok := strings.CutPrefix(trimListMarker(trimmed), "[")
+		if !ok || len(item) < 2 || item[1] != ']' || (len(item) > 2 && item[2] != ' ') {
+			continue
+		}
+		switch item[0] {
+		case ' ':
+			progress.Total++
+		case 'x', 'X':
+			progress.Total++
+			progress.Done++
+		}
+	}
+	return progress
+}
+
+// trimListMarker strips a leading "-", "*", "+", or "1." list marker and the
+// space after it. Lines without one are returned as "" so they never match.
+func trimListMarker(line string) string {
+	if rest, ok := strings.CutPrefix(line, "- "); ok {
+		return rest
+	}
+	if rest, ok := strings.CutPrefix(line, "* "); ok {
+		return rest
+	}
+	if rest, ok := strings.CutPrefix(line, "+ "); ok {
+		return rest
+	}
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 {
+		if rest, ok := strings.CutPrefix(line[digits:], ". "); ok {
+			return rest
+		}
+	}
+	return ""
+}
+
+// WithChecklistStore enables counting the task lists in each synced PR's body
+// and conversation comments.
+func (s *PollService) WithChecklistStore(store driven.ChecklistStore) *PollService {
+	s.checklists = store
+	return s
+}
+
+// recordChecklist stores the task list progress of a synced PR: body is the
+// PR description as fetched, and the comments are the stored conversation.
+func (s *PollService) recordChecklist(ctx context.Context, pr model.PullRequest, body string) {
+	if s.checklists == nil {
+		return
+	}
+	progress := ParseChecklist(body)
+	comments, err := s.reviewStore.GetIssueCommentsByPR(ctx, pr.ID)
+	if err != nil {
+		slog.Error("get issue comments for checklist failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+	for _, c := range comments {
+		progress = progress.Add(ParseChecklist(c.Body))
+	}
+	if err := s.checklists.SetChecklist(ctx, pr.ID, progress); err != nil {
+		slog.Error("store checklist failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	}
+}
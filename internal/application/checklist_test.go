@@ -0,0 +1,44 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestParseChecklist(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want model.ChecklistProgress
+	}{
+		{"no task list", "Fixes the parser.\n- a plain item", model.ChecklistProgress{}},
+		{"mixed markers", "- [x] tests\n* [ ] docs\n+ [X] changelog\n1. [ ] release note", model.ChecklistProgress{Done: 2, Total: 4}},
+		{"nested items", "- [x] parent\n  - [ ] child", model.ChecklistProgress{Done: 1, Total: 2}},
+		{"fenced code is ignored", "```\n- [ ] example\n```\n- [x] real", model.ChecklistProgress{Done: 1, Total: 1}},
+		{"links are not items", "- [docs](https://example.com)\n- [ ]missing space", model.ChecklistProgress{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, application.ParseChecklist(tt.body))
+		})
+	}
+}
+
+func TestComputeAttentionSignals_ChecklistIncomplete(t *testing.T) {
+	thresholds := defaultThresholds()
+	thresholds.ChecklistEnabled = true
+	pr := prWithAge(0)
+	pr.Checklist = model.ChecklistProgress{Done: 1, Total: 3}
+
+	assert.True(t, application.ComputeAttentionSignals(pr, 1, "", thresholds, testAuthor).ChecklistIncomplete)
+
+	pr.IsDraft = true
+	assert.False(t, application.ComputeAttentionSignals(pr, 1, "", thresholds, testAuthor).ChecklistIncomplete, "drafts are not ready for review")
+
+	pr.IsDraft = false
+	assert.False(t, application.ComputeAttentionSignals(pr, 1, "", defaultThresholds(), testAuthor).ChecklistIncomplete, "off unless enabled")
+}
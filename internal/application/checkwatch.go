@@ -0,0 +1,131 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// checkWatchMaxAge is how long a watch waits for checks to finish before it
+// is dropped, so a PR whose checks never report does not keep its repo on the
+// fast refresh forever.
+const checkWatchMaxAge = 6 * time.Hour
+
+// CheckWatchService notifies the user when a watched PR's CI checks finish.
+// While any watch is open, the watched repos are refreshed on Run's short
+// interval instead of waiting for their adaptive poll tier.
+type CheckWatchService struct {
+	store     driven.CheckWatchStore
+	prStore   driven.PRStore
+	inbox     driven.InboxStore
+	refresher RepoRefresher // optional; watches wait for the regular poll when nil
+	now       func() time.Time
+}
+
+// NewCheckWatchService creates a CheckWatchService. refresher may be nil.
+func NewCheckWatchService(store driven.CheckWatchStore, prStore driven.PRStore, inbox driven.InboxStore, refresher RepoRefresher) *CheckWatchService {
+	return &CheckWatchService{
+		store:     store,
+		prStore:   prStore,
+		inbox:     inbox,
+		refresher: refresher,
+		now:       time.Now,
+	}
+}
+
+// Run checks the watched PRs every interval until ctx is canceled.
+func (s *CheckWatchService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.checkWatches(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkWatches refreshes each watched repo once, then records an inbox entry
+// and drops the watch for every PR whose checks have finished. Watches on PRs
+// that closed or waited past checkWatchMaxAge are dropped without one.
+func (s *CheckWatchService) checkWatches(ctx context.Context) {
+	watches, err := s.store.ListCheckWatches(ctx)
+	if err != nil {
+		slog.Error("failed to list check watches", "error", err)
+		return
+	}
+	if len(watches) == 0 {
+		return
+	}
+
+	if s.refresher != nil {
+		refreshed := make(map[string]bool)
+		for _, w := range watches {
+			if refreshed[w.RepoFullName] {
+				continue
+			}
+			refreshed[w.RepoFullName] = true
+			if err := s.refresher.RefreshRepo(ctx, w.RepoFullName); err != nil {
+				slog.Warn("failed to refresh repo for check watch", "repo", w.RepoFullName, "error", err)
+			}
+		}
+	}
+
+	for _, w := range watches {
+		s.checkWatch(ctx, w)
+	}
+}
+
+// checkWatch settles a single watch if its PR's checks have finished.
+func (s *CheckWatchService) checkWatch(ctx context.Context, w model.CheckWatch) {
+	pr, err := s.prStore.GetByNumber(ctx, w.RepoFullName, w.PRNumber)
+	if err != nil {
+		slog.Warn("failed to get PR for check watch", "repo", w.RepoFullName, "pr", w.PRNumber, "error", err)
+		return
+	}
+
+	switch {
+	case pr == nil || pr.Status != model.PRStatusOpen:
+		slog.Info("dropping check watch on closed PR", "repo", w.RepoFullName, "pr", w.PRNumber)
+	case pr.CIStatus == model.CIStatusPassing || pr.CIStatus == model.CIStatusFailing:
+		if !s.notify(ctx, *pr) {
+			return
+		}
+	case s.now().Sub(w.CreatedAt) > checkWatchMaxAge:
+		slog.Info("dropping check watch after waiting too long", "repo", w.RepoFullName, "pr", w.PRNumber)
+	default:
+		return
+	}
+
+	if err := s.store.SetCheckWatch(ctx, w.PRID, false); err != nil {
+		slog.Error("failed to drop check watch", "repo", w.RepoFullName, "pr", w.PRNumber, "error", err)
+	}
+}
+
+// notify records the finished checks of pr in the inbox and reports whether
+// it succeeded.
+func (s *CheckWatchService) notify(ctx context.Context, pr model.PullRequest) bool {
+	summary := "Checks passed"
+	if pr.CIStatus == model.CIStatusFailing {
+		summary = "Checks failed"
+	}
+	entry := model.InboxEvent{
+		Kind:         model.InboxChecksFinished,
+		RepoFullName: pr.RepoFullName,
+		PRNumber:     pr.Number,
+		PRTitle:      pr.Title,
+		Summary:      summary,
+		SourceID:     "checks-" + pr.HeadSHA,
+		OccurredAt:   s.now(),
+	}
+	if _, err := s.inbox.Add(ctx, entry); err != nil {
+		slog.Error("failed to record finished checks", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memCheckWatchStore is an in-memory CheckWatchStore.
+type memCheckWatchStore struct {
+	watches []model.CheckWatch
+}
+
+func (m *memCheckWatchStore) SetCheckWatch(_ context.Context, prID int64, watching bool) error {
+	if watching {
+		panic("unused")
+	}
+	kept := m.watches[:0]
+	for _, w := range m.watches {
+		if w.PRID != prID {
+			kept = append(kept, w)
+		}
+	}
+	m.watches = kept
+	return nil
+}
+
+func (m *memCheckWatchStore) IsWatchingChecks(_ context.Context, _ int64) (bool, error) {
+	panic("unused")
+}
+
+func (m *memCheckWatchStore) ListCheckWatches(_ context.Context) ([]model.CheckWatch, error) {
+	return append([]model.CheckWatch(nil), m.watches...), nil
+}
+
+// recordingRefresher records the repos it is asked to refresh.
+type recordingRefresher struct {
+	repos []string
+}
+
+func (r *recordingRefresher) RefreshRepo(_ context.Context, repoFullName string) error {
+	r.repos = append(r.repos, repoFullName)
+	return nil
+}
+
+func TestCheckWatchService_CheckWatches(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	pr := func(id int64, number int, status model.PRStatus, ci model.CIStatus) model.PullRequest {
+		return model.PullRequest{ID: id, Number: number, RepoFullName: "o/r", Title: "PR", HeadSHA: "sha", Status: status, CIStatus: ci}
+	}
+	watch := func(pr model.PullRequest, age time.Duration) model.CheckWatch {
+		return model.CheckWatch{PRID: pr.ID, RepoFullName: pr.RepoFullName, PRNumber: pr.Number, CreatedAt: now.Add(-age)}
+	}
+
+	passed := pr(1, 1, model.PRStatusOpen, model.CIStatusPassing)
+	failed := pr(2, 2, model.PRStatusOpen, model.CIStatusFailing)
+	running := pr(3, 3, model.PRStatusOpen, model.CIStatusPending)
+	stale := pr(4, 4, model.PRStatusOpen, model.CIStatusPending)
+	merged := pr(5, 5, model.PRStatusMerged, model.CIStatusPending)
+
+	store := &memCheckWatchStore{watches: []model.CheckWatch{
+		watch(passed, time.Minute),
+		watch(failed, time.Minute),
+		watch(running, time.Minute),
+		watch(stale, 7*time.Hour),
+		watch(merged, time.Minute),
+	}}
+	inbox := &memInboxStore{}
+	refresher := &recordingRefresher{}
+	svc := NewCheckWatchService(store, &testPRStore{prs: []model.PullRequest{passed, failed, running, stale, merged}}, inbox, refresher)
+	svc.now = func() time.Time { return now }
+
+	svc.checkWatches(context.Background())
+
+	assert.Equal(t, []string{"o/r"}, refresher.repos, "each watched repo is refreshed once")
+	require.Len(t, inbox.events, 2)
+	assert.Equal(t, model.InboxChecksFinished, inbox.events[0].Kind)
+	assert.Equal(t, "Checks passed", inbox.events[0].Summary)
+	assert.Equal(t, "Checks failed", inbox.events[1].Summary)
+	assert.Equal(t, "checks-sha", inbox.events[1].SourceID)
+
+	require.Len(t, store.watches, 1, "finished, closed, and expired watches are dropped")
+	assert.Equal(t, running.ID, store.watches[0].PRID)
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// CircuitState describes whether a repository is being polled normally or
+// has been taken out of rotation after repeated unreachable errors.
+type CircuitState int
+
+const (
+	// CircuitClosed means the repo polls on its activity tier schedule.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen means the repo keeps answering 404/403 (or has moved) and
+	// is only retried once its backoff elapses.
+	CircuitOpen
+	// CircuitHalfOpen means an open circuit's backoff has elapsed. The next
+	// poll is a probe: success closes the circuit, failure re-opens it.
+	CircuitHalfOpen
+)
+
+// Backoff and circuit breaker tuning.
+const (
+	// breakerThreshold is the number of consecutive unreachable failures
+	// after which the circuit opens. Moves open it immediately.
+	breakerThreshold = 3
+	// backoffBase is the retry delay after the first failure; it doubles per
+	// consecutive failure up to backoffMax for unreachable repos, and up to
+	// transientBackoffMax, the slowest tier interval, for everything else so
+	// a network or server outage never delays a poll longer than a quiet
+	// repo would wait anyway.
+	backoffBase         = 1 * time.Minute
+	backoffMax          = 6 * time.Hour
+	transientBackoffMax = intervalStale
+)
+
+// String returns a human-readable name for the circuit state.
+func (c CircuitState) String() string {
+	switch c {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// failureBackoff returns the delay before retrying a repo after the given
+// number of consecutive failures, capped at limit.
+func failureBackoff(failures int, limit time.Duration) time.Duration {
+	if failures < 1 {
+		return min(backoffBase, limit)
+	}
+
+	backoff := backoffBase
+	for i := 1; i < failures; i++ {
+		backoff *= 2
+		if backoff >= limit {
+			return limit
+		}
+	}
+	return min(backoff, limit)
+}
+
+// isUnreachable reports whether err means the repository itself is gone,
+// forbidden, or renamed, as opposed to a transient network or server problem.
+func isUnreachable(err error) bool {
+	var moved *driven.RepoMovedError
+	return errors.Is(err, driven.ErrRepoUnreachable) || errors.As(err, &moved)
+}
+
+// recordPollFailure pushes the repo's next poll out by an exponential backoff
+// and opens its circuit once it has been unreachable breakerThreshold times
+// in a row. Transient errors back off too, for at most transientBackoffMax,
+// but never open the circuit.
+func (s *PollService) recordPollFailure(repoFullName string, err error) {
+	now := time.Now()
+
+	s.schedulesMu.Lock()
+	sched, exists := s.schedules[repoFullName]
+	if !exists {
+		sched.tier = TierStale
+	}
+	wasOpen := sched.circuitOpen
+
+	sched.failures++
+	sched.lastError = err.Error()
+	sched.lastFailedAt = now
+	if isUnreachable(err) {
+		sched.unreachableStreak++
+	} else {
+		sched.unreachableStreak = 0
+	}
+
+	var moved *driven.RepoMovedError
+	if errors.As(err, &moved) {
+		sched.movedTo = moved.To
+	}
+
+	sched.circuitOpen = sched.movedTo != "" || sched.unreachableStreak >= breakerThreshold
+	limit := transientBackoffMax
+	if isUnreachable(err) {
+		limit = backoffMax
+	}
+	sched.nextPollAt = now.Add(failureBackoff(sched.failures, limit))
+	s.schedules[repoFullName] = sched
+	s.schedulesMu.Unlock()
+
+	if sched.circuitOpen && !wasOpen {
+		slog.Warn("repo circuit opened; polling paused until backoff elapses",
+			"repo", repoFullName,
+			"failures", sched.failures,
+			"moved_to", sched.movedTo,
+			"next_poll", sched.nextPollAt.Format(time.RFC3339),
+			"error", err,
+		)
+		return
+	}
+
+	slog.Info("repo poll backing off",
+		"repo", repoFullName,
+		"failures", sched.failures,
+		"circuit", circuitState(sched, now).String(),
+		"next_poll", sched.nextPollAt.Format(time.RFC3339),
+	)
+}
+
+// circuitState derives the externally visible circuit state of a schedule.
+func circuitState(sched repoSchedule, now time.Time) CircuitState {
+	switch {
+	case !sched.circuitOpen:
+		return CircuitClosed
+	case now.Before(sched.nextPollAt):
+		return CircuitOpen
+	default:
+		return CircuitHalfOpen
+	}
+}
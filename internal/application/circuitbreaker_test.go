@@ -0,0 +1,116 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+func TestFailureBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		limit    time.Duration
+		want     time.Duration
+	}{
+		{0, backoffMax, time.Minute},
+		{1, backoffMax, time.Minute},
+		{2, backoffMax, 2 * time.Minute},
+		{4, backoffMax, 8 * time.Minute},
+		{9, backoffMax, 256 * time.Minute},
+		{10, backoffMax, 6 * time.Hour},
+		{100, backoffMax, 6 * time.Hour},
+		{5, transientBackoffMax, 16 * time.Minute},
+		{6, transientBackoffMax, 30 * time.Minute},
+		{100, transientBackoffMax, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, failureBackoff(tt.failures, tt.limit), "failures=%d limit=%s", tt.failures, tt.limit)
+	}
+}
+
+func TestCircuitStateString(t *testing.T) {
+	assert.Equal(t, "closed", CircuitClosed.String())
+	assert.Equal(t, "open", CircuitOpen.String())
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+	assert.Equal(t, "unknown", CircuitState(99).String())
+}
+
+func TestRecordPollFailure_OpensAfterThreshold(t *testing.T) {
+	svc := &PollService{schedules: make(map[string]repoSchedule)}
+	notFound := fmt.Errorf("listing pull requests: %w", driven.ErrRepoUnreachable)
+
+	for i := 1; i < breakerThreshold; i++ {
+		svc.recordPollFailure("org/gone", notFound)
+		assert.Equal(t, CircuitClosed, svc.Schedules()["org/gone"].Circuit, "failure %d should not open the circuit", i)
+	}
+
+	svc.recordPollFailure("org/gone", notFound)
+	info := svc.Schedules()["org/gone"]
+	assert.Equal(t, CircuitOpen, info.Circuit)
+	assert.Equal(t, breakerThreshold, info.ConsecutiveFailures)
+	assert.Contains(t, info.LastError, "repository unreachable")
+	assert.WithinDuration(t, time.Now(), info.LastFailedAt, time.Second)
+	assert.Equal(t, TierStale, info.Tier, "a repo that never polled successfully defaults to the stale tier")
+	assert.WithinDuration(t, time.Now().Add(failureBackoff(breakerThreshold, backoffMax)), info.NextPollAt, time.Second)
+}
+
+func TestRecordPollFailure_TransientErrorsBackOffOnly(t *testing.T) {
+	svc := &PollService{schedules: make(map[string]repoSchedule)}
+
+	for range breakerThreshold + 2 {
+		svc.recordPollFailure("org/flaky", errors.New("connection reset"))
+	}
+
+	info := svc.Schedules()["org/flaky"]
+	assert.Equal(t, CircuitClosed, info.Circuit)
+	assert.Equal(t, breakerThreshold+2, info.ConsecutiveFailures)
+	assert.True(t, info.NextPollAt.After(time.Now().Add(10*time.Minute)))
+
+	for range 10 {
+		svc.recordPollFailure("org/flaky", errors.New("connection reset"))
+	}
+	info = svc.Schedules()["org/flaky"]
+	assert.WithinDuration(t, time.Now().Add(transientBackoffMax), info.NextPollAt, time.Second,
+		"a long outage is retried at the slowest tier interval")
+}
+
+func TestRecordPollFailure_MoveOpensImmediately(t *testing.T) {
+	svc := &PollService{schedules: make(map[string]repoSchedule)}
+
+	svc.recordPollFailure("org/old", &driven.RepoMovedError{From: "org/old", To: "org/new"})
+
+	info := svc.Schedules()["org/old"]
+	assert.Equal(t, CircuitOpen, info.Circuit)
+	assert.Equal(t, "org/new", info.MovedTo)
+}
+
+func TestCircuitState_HalfOpenAfterBackoff(t *testing.T) {
+	now := time.Now()
+	open := repoSchedule{circuitOpen: true, nextPollAt: now.Add(time.Minute)}
+
+	assert.Equal(t, CircuitClosed, circuitState(repoSchedule{}, now))
+	assert.Equal(t, CircuitOpen, circuitState(open, now))
+	assert.Equal(t, CircuitHalfOpen, circuitState(open, now.Add(2*time.Minute)))
+}
+
+func TestUpdateSchedule_ClosesCircuit(t *testing.T) {
+	svc := &PollService{
+		prStore:   &testPRStore{},
+		schedules: map[string]repoSchedule{"org/back": {circuitOpen: true, failures: 5, lastError: "boom"}},
+	}
+
+	svc.updateSchedule(t.Context(), "org/back")
+
+	info, ok := svc.Schedules()["org/back"]
+	require.True(t, ok)
+	assert.Equal(t, CircuitClosed, info.Circuit)
+	assert.Zero(t, info.ConsecutiveFailures)
+	assert.Empty(t, info.LastError)
+}
@@ -0,0 +1,278 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// MaxComparedPRs caps how many PRs can be compared side by side.
+const MaxComparedPRs = 4
+
+var (
+	// ErrPRNotFound is returned when a PR ID does not match any tracked pull request.
+	ErrPRNotFound = errors.New("pull request not found")
+
+	// ErrSelfLink is returned when linking a PR to itself.
+	ErrSelfLink = errors.New("a pull request cannot compete with itself")
+)
+
+// CompetingPR is a pull request that addresses the same work as another PR,
+// together with the reasons the two were associated.
+type CompetingPR struct {
+	PR            model.PullRequest
+	ManualLink    bool   // linked explicitly by the user
+	SharedJiraKey string // non-empty when both PRs reference the same Jira issue
+}
+
+// PRComparison is the side-by-side summary of a single PR in a comparison.
+type PRComparison struct {
+	PR                model.PullRequest
+	ChecksPassed      int
+	ChecksFailed      int
+	ChecksPending     int
+	ReviewStatus      model.ReviewState
+	Approvals         int
+	ChangesRequested  int
+	UnresolvedThreads int
+}
+
+// ComparisonService finds PRs that compete to solve the same problem and
+// builds side-by-side comparisons of their diff stats, checks, reviews, and age.
+type ComparisonService struct {
+	prStore   driven.PRStore
+	linkStore driven.PRLinkStore
+	reviewSvc *ReviewService
+	healthSvc *HealthService
+}
+
+// NewComparisonService creates a ComparisonService. reviewSvc and healthSvc
+// may be nil, in which case review and check columns are left empty.
+func NewComparisonService(
+	prStore driven.PRStore,
+	linkStore driven.PRLinkStore,
+	reviewSvc *ReviewService,
+	healthSvc *HealthService,
+) *ComparisonService {
+	return &ComparisonService{
+		prStore:   prStore,
+		linkStore: linkStore,
+		reviewSvc: reviewSvc,
+		healthSvc: healthSvc,
+	}
+}
+
+// CompetingPRs returns PRs manually linked to prID plus open PRs that share
+// its Jira key. Manually linked PRs come first, then PRs by number.
+func (s *ComparisonService) CompetingPRs(ctx context.Context, prID int64) ([]CompetingPR, error) {
+	byID, err := s.prsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, ok := byID[prID]
+	if !ok {
+		return nil, ErrPRNotFound
+	}
+
+	linkedIDs, err := s.linkStore.ListLinkedIDs(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	competing := make(map[int64]*CompetingPR)
+	for _, id := range linkedIDs {
+		if other, ok := byID[id]; ok {
+			competing[id] = &CompetingPR{PR: other, ManualLink: true}
+		}
+	}
+
+	if pr.JiraKey != "" {
+		for id, other := range byID {
+			if id == prID || other.JiraKey != pr.JiraKey {
+				continue
+			}
+			if c, ok := competing[id]; ok {
+				c.SharedJiraKey = pr.JiraKey
+				continue
+			}
+			if other.Status == model.PRStatusOpen {
+				competing[id] = &CompetingPR{PR: other, SharedJiraKey: pr.JiraKey}
+			}
+		}
+	}
+
+	result := make([]CompetingPR, 0, len(competing))
+	for _, c := range competing {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ManualLink != result[j].ManualLink {
+			return result[i].ManualLink
+		}
+		if result[i].PR.RepoFullName != result[j].PR.RepoFullName {
+			return result[i].PR.RepoFullName < result[j].PR.RepoFullName
+		}
+		return result[i].PR.Number < result[j].PR.Number
+	})
+
+	return result, nil
+}
+
+// Link records that two PRs compete to solve the same problem.
+func (s *ComparisonService) Link(ctx context.Context, prID, otherID int64) error {
+	if prID == otherID {
+		return ErrSelfLink
+	}
+
+	byID, err := s.prsByID(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := byID[prID]; !ok {
+		return ErrPRNotFound
+	}
+	if _, ok := byID[otherID]; !ok {
+		return ErrPRNotFound
+	}
+
+	return s.linkStore.Link(ctx, prID, otherID)
+}
+
+// LinkByNumber links prID to the PR identified by repository and number, as
+// typed by a user. An empty repoFullName means the same repository as prID.
+func (s *ComparisonService) LinkByNumber(ctx context.Context, prID int64, repoFullName string, number int) error {
+	byID, err := s.prsByID(ctx)
+	if err != nil {
+		return err
+	}
+
+	pr, ok := byID[prID]
+	if !ok {
+		return ErrPRNotFound
+	}
+	if repoFullName == "" {
+		repoFullName = pr.RepoFullName
+	}
+
+	for id, other := range byID {
+		if other.Number == number && strings.EqualFold(other.RepoFullName, repoFullName) {
+			if id == prID {
+				return ErrSelfLink
+			}
+			return s.linkStore.Link(ctx, prID, id)
+		}
+	}
+	return ErrPRNotFound
+}
+
+// Unlink removes a manual link between two PRs.
+func (s *ComparisonService) Unlink(ctx context.Context, prID, otherID int64) error {
+	return s.linkStore.Unlink(ctx, prID, otherID)
+}
+
+// Compare builds a side-by-side summary of the given PRs in the order given.
+// Enrichment failures are non-fatal: the affected columns are left empty.
+func (s *ComparisonService) Compare(ctx context.Context, prIDs []int64) ([]PRComparison, error) {
+	if len(prIDs) < 2 || len(prIDs) > MaxComparedPRs {
+		return nil, fmt.Errorf("compare requires between 2 and %d pull requests", MaxComparedPRs)
+	}
+
+	byID, err := s.prsByID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range prIDs {
+		if _, ok := byID[id]; !ok {
+			return nil, ErrPRNotFound
+		}
+	}
+
+	var checkRuns map[int64][]model.CheckRun
+	if s.healthSvc != nil {
+		// A failed load leaves the check columns at zero.
+		checkRuns, _ = s.healthSvc.CheckRunsByPR(ctx, prIDs)
+	}
+
+	result := make([]PRComparison, 0, len(prIDs))
+	for _, id := range prIDs {
+		result = append(result, s.summarize(ctx, byID[id], checkRuns[id]))
+	}
+
+	return result, nil
+}
+
+// summarize computes one comparison column from pr and its check runs.
+func (s *ComparisonService) summarize(ctx context.Context, pr model.PullRequest, checkRuns []model.CheckRun) PRComparison {
+	c := PRComparison{
+		PR:           pr,
+		ReviewStatus: model.ReviewStatePending,
+	}
+
+	for _, run := range checkRuns {
+		switch {
+		case run.Status != "completed":
+			c.ChecksPending++
+		case run.Conclusion == "success" || run.Conclusion == "neutral" || run.Conclusion == "skipped":
+			c.ChecksPassed++
+		default:
+			c.ChecksFailed++
+		}
+	}
+
+	if s.reviewSvc != nil {
+		summary, err := s.reviewSvc.GetPRReviewSummary(ctx, pr.ID, pr.HeadSHA)
+		if err == nil && summary != nil {
+			c.ReviewStatus = summary.ReviewStatus
+			c.UnresolvedThreads = summary.UnresolvedThreadCount
+			c.Approvals, c.ChangesRequested = countLatestVerdicts(summary.Reviews)
+		}
+	}
+
+	return c
+}
+
+// countLatestVerdicts counts approvals and change requests using each human
+// reviewer's most recent review. Reviews must already have IsBot populated.
+func countLatestVerdicts(reviews []model.Review) (approvals, changesRequested int) {
+	latest := make(map[string]model.Review)
+	for _, r := range reviews {
+		if r.IsBot {
+			continue
+		}
+		if existing, ok := latest[r.ReviewerLogin]; !ok || r.SubmittedAt.After(existing.SubmittedAt) {
+			latest[r.ReviewerLogin] = r
+		}
+	}
+
+	for _, r := range latest {
+		switch r.State {
+		case model.ReviewStateApproved:
+			approvals++
+		case model.ReviewStateChangesRequested:
+			changesRequested++
+		case model.ReviewStateCommented, model.ReviewStatePending, model.ReviewStateDismissed:
+		}
+	}
+	return approvals, changesRequested
+}
+
+// prsByID loads all stored PRs keyed by ID.
+func (s *ComparisonService) prsByID(ctx context.Context) (map[int64]model.PullRequest, error) {
+	prs, err := s.prStore.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int64]model.PullRequest, len(prs))
+	for _, pr := range prs {
+		byID[pr.ID] = pr
+	}
+	return byID, nil
+}
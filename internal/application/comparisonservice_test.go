@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func comparisonTestPRs() []model.PullRequest {
+	return []model.PullRequest{
+		{ID: 1, RepoFullName: "org/api", Number: 10, Status: model.PRStatusOpen, JiraKey: "PROJ-7"},
+		{ID: 2, RepoFullName: "org/api", Number: 11, Status: model.PRStatusOpen, JiraKey: "PROJ-7", CIStatus: model.CIStatusFailing},
+		{ID: 3, RepoFullName: "org/api", Number: 12, Status: model.PRStatusClosed, JiraKey: "PROJ-7"},
+		{ID: 4, RepoFullName: "org/web", Number: 5, Status: model.PRStatusOpen},
+		{ID: 5, RepoFullName: "org/web", Number: 6, Status: model.PRStatusOpen, JiraKey: "PROJ-9"},
+	}
+}
+
+func TestCompetingPRs(t *testing.T) {
+	ctx := context.Background()
+	links := newTestPRLinkStore()
+	svc := NewComparisonService(&testPRStore{prs: comparisonTestPRs()}, links, nil, nil)
+
+	require.NoError(t, svc.Link(ctx, 1, 4))
+	require.NoError(t, svc.Link(ctx, 1, 2))
+
+	competing, err := svc.CompetingPRs(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, competing, 2, "closed PR sharing the Jira key is excluded unless linked")
+
+	// Manual links sort first; within them, by repo then number.
+	assert.Equal(t, int64(2), competing[0].PR.ID)
+	assert.True(t, competing[0].ManualLink)
+	assert.Equal(t, "PROJ-7", competing[0].SharedJiraKey, "manual link also records the shared key")
+	assert.Equal(t, int64(4), competing[1].PR.ID)
+	assert.True(t, competing[1].ManualLink)
+	assert.Empty(t, competing[1].SharedJiraKey)
+}
+
+func TestCompetingPRs_JiraKeyOnly(t *testing.T) {
+	svc := NewComparisonService(&testPRStore{prs: comparisonTestPRs()}, newTestPRLinkStore(), nil, nil)
+
+	competing, err := svc.CompetingPRs(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, competing, 1)
+	assert.Equal(t, int64(1), competing[0].PR.ID)
+	assert.False(t, competing[0].ManualLink)
+	assert.Equal(t, "PROJ-7", competing[0].SharedJiraKey)
+}
+
+func TestCompetingPRs_UnknownPR(t *testing.T) {
+	svc := NewComparisonService(&testPRStore{prs: comparisonTestPRs()}, newTestPRLinkStore(), nil, nil)
+
+	_, err := svc.CompetingPRs(context.Background(), 99)
+	assert.ErrorIs(t, err, ErrPRNotFound)
+}
+
+func TestComparisonLink_Validation(t *testing.T) {
+	ctx := context.Background()
+	svc := NewComparisonService(&testPRStore{prs: comparisonTestPRs()}, newTestPRLinkStore(), nil, nil)
+
+	assert.ErrorIs(t, svc.Link(ctx, 1, 1), ErrSelfLink)
+	assert.ErrorIs(t, svc.Link(ctx, 1, 99), ErrPRNotFound)
+}
+
+func TestComparisonLinkByNumber(t *testing.T) {
+	ctx := context.Background()
+	links := newTestPRLinkStore()
+	svc := NewComparisonService(&testPRStore{prs: comparisonTestPRs()}, links, nil, nil)
+
+	// Empty repo resolves against the source PR's repository.
+	require.NoError(t, svc.LinkByNumber(ctx, 1, "", 12))
+	assert.True(t, links.links[linkKey(1, 3)])
+
+	require.NoError(t, svc.LinkByNumber(ctx, 1, "ORG/web", 5))
+	assert.True(t, links.links[linkKey(1, 4)], "repository match is case-insensitive")
+
+	assert.ErrorIs(t, svc.LinkByNumber(ctx, 1, "", 10), ErrSelfLink)
+	assert.ErrorIs(t, svc.LinkByNumber(ctx, 1, "", 5), ErrPRNotFound, "org/api has no #5")
+	assert.ErrorIs(t, svc.LinkByNumber(ctx, 99, "", 10), ErrPRNotFound)
+}
+
+func TestCompare(t *testing.T) {
+	now := time.Now()
+	reviewStore := &testReviewStore{
+		reviews: []model.Review{
+			{ReviewerLogin: "alice", State: model.ReviewStateChangesRequested, SubmittedAt: now.Add(-2 * time.Hour)},
+			{ReviewerLogin: "alice", State: model.ReviewStateApproved, SubmittedAt: now.Add(-time.Hour)},
+			{ReviewerLogin: "bob", State: model.ReviewStateChangesRequested, SubmittedAt: now},
+			{ReviewerLogin: "ci-bot", State: model.ReviewStateApproved, SubmittedAt: now},
+		},
+	}
+	checkStore := &testCheckStore{
+		runs: []model.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success"},
+			{Name: "lint", Status: "completed", Conclusion: "failure"},
+			{Name: "e2e", Status: "in_progress"},
+		},
+	}
+	prStore := &testPRStore{prs: comparisonTestPRs()}
+	svc := NewComparisonService(
+		prStore,
+		newTestPRLinkStore(),
+		NewReviewService(reviewStore, &testBotConfigStore{usernames: []string{"ci-bot"}}),
+		NewHealthService(checkStore, prStore),
+	)
+
+	result, err := svc.Compare(context.Background(), []int64{2, 1})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+
+	assert.Equal(t, int64(2), result[0].PR.ID, "columns follow the requested order")
+	assert.Equal(t, 1, result[0].Approvals, "latest review per human reviewer counts")
+	assert.Equal(t, 1, result[0].ChangesRequested)
+	assert.Equal(t, 1, result[0].ChecksPassed)
+	assert.Equal(t, 1, result[0].ChecksFailed)
+	assert.Equal(t, 1, result[0].ChecksPending)
+	assert.Equal(t, model.ReviewStateChangesRequested, result[0].ReviewStatus)
+}
+
+func TestCompare_Bounds(t *testing.T) {
+	svc := NewComparisonService(&testPRStore{prs: comparisonTestPRs()}, newTestPRLinkStore(), nil, nil)
+	ctx := context.Background()
+
+	_, err := svc.Compare(ctx, []int64{1})
+	require.Error(t, err)
+
+	_, err = svc.Compare(ctx, []int64{1, 2, 3, 4, 5})
+	require.Error(t, err)
+
+	_, err = svc.Compare(ctx, []int64{1, 99})
+	assert.ErrorIs(t, err, ErrPRNotFound)
+}
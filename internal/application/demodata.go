@@ -0,0 +1,412 @@
+package application
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // fake commit SHAs, not security
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// demoUser stands for the dashboard's user in the demo data; it is replaced
+// with the configured username when seeding.
+const demoUser = "@me"
+
+// demoReview is a review in the demo data. Outdated reviews target an
+// earlier commit than the PR's head.
+type demoReview struct {
+	reviewer string
+	state    model.ReviewState
+	body     string
+	outdated bool
+	hoursAgo int
+}
+
+// demoThread is an inline review comment thread in the demo data. The first
+// reply is the comment that opens it; the thread belongs to the PR's
+// review by the same author, if any.
+type demoThread struct {
+	path     string
+	line     int
+	hunk     string
+	replies  [][2]string // author, body
+	resolved bool
+	outdated bool
+	hoursAgo int
+}
+
+// demoPR is a pull request in the demo data. Times are relative to the seed
+// time so the dashboard always looks current.
+type demoPR struct {
+	repo        string
+	number      int
+	title       string
+	author      string
+	branch      string
+	status      model.PRStatus
+	draft       bool
+	needsReview bool
+	ci          model.CIStatus
+	mergeable   model.MergeableStatus
+	labels      []string
+	size        [3]int // additions, deletions, changed files
+	openedHours int
+	idleHours   int
+	reviews     []demoReview
+	threads     []demoThread
+	comments    [][2]string // author, body
+}
+
+var demoRepos = []string{"acme/storefront", "acme/payments-api", "acme/mobile-app", "acme/infra"}
+
+var demoPRs = []demoPR{
+	{
+		repo: "acme/storefront", number: 412, title: "Add saved carts to checkout", author: "priya-k",
+		branch: "feature/saved-carts", status: model.PRStatusOpen, needsReview: true,
+		ci: model.CIStatusPassing, mergeable: model.MergeableMergeable, labels: []string{"feature"},
+		size: [3]int{320, 45, 12}, openedHours: 30, idleHours: 2,
+		reviews: []demoReview{{reviewer: "jordan-lee", state: model.ReviewStateCommented, body: "Looks good overall, one question inline.", hoursAgo: 20}},
+		threads: []demoThread{{
+			path: "src/cart/SavedCart.tsx", line: 42, hoursAgo: 20, resolved: true,
+			hunk: "@@ -38,6 +38,10 @@ export function SavedCart({ cart }: Props) {\n   const [name, setName] = useState(cart.name);\n+  useEffect(() => {\n+    saveCart({ ...cart, name });\n+  }, [name]);",
+			replies: [][2]string{
+				{"jordan-lee", "This saves on every keystroke. Should it be debounced?"},
+				{"priya-k", "Good call, added a 300ms debounce."},
+			},
+		}},
+	},
+	{
+		repo: "acme/storefront", number: 409, title: "Fix price rounding for JPY", author: demoUser,
+		branch: "fix/jpy-rounding", status: model.PRStatusOpen,
+		ci: model.CIStatusFailing, mergeable: model.MergeableMergeable, labels: []string{"bug"},
+		size: [3]int{58, 21, 4}, openedHours: 50, idleHours: 6,
+		reviews: []demoReview{{reviewer: "priya-k", state: model.ReviewStateChangesRequested, body: "JPY needs handling in the formatter too.", hoursAgo: 6}},
+		threads: []demoThread{{
+			path: "src/pricing/format.ts", line: 17, hoursAgo: 6,
+			hunk: "@@ -14,5 +14,7 @@ export function formatPrice(amount: number, currency: string) {\n-  return (amount / 100).toFixed(2);\n+  const digits = currency === 'JPY' ? 0 : 2;\n+  return (amount / 10 ** digits).toFixed(digits);",
+			replies: [][2]string{
+				{"priya-k", "KRW and VND have no minor units either. Can we read this from Intl.NumberFormat instead of hard-coding JPY?"},
+			},
+		}},
+		comments: [][2]string{{"github-actions[bot]", "Visual diff: 3 screenshots changed in `checkout/summary`."}},
+	},
+	{
+		repo: "acme/storefront", number: 401, title: "Bump vite from 5.2.0 to 5.4.8", author: "dependabot[bot]",
+		branch: "dependabot/npm_and_yarn/vite-5.4.8", status: model.PRStatusOpen,
+		ci: model.CIStatusPassing, mergeable: model.MergeableMergeable, labels: []string{"dependencies"},
+		size: [3]int{12, 10, 2}, openedHours: 150, idleHours: 130,
+	},
+	{
+		repo: "acme/storefront", number: 398, title: "Migrate product grid to CSS grid", author: "marco-r",
+		branch: "marco/css-grid", status: model.PRStatusOpen, draft: true,
+		ci: model.CIStatusPending, mergeable: model.MergeableUnknown, labels: []string{"refactor"},
+		size: [3]int{140, 190, 9}, openedHours: 10, idleHours: 1,
+	},
+	{
+		repo: "acme/payments-api", number: 1187, title: "Idempotency keys for the refund endpoint", author: "sam-okafor",
+		branch: "sam/refund-idempotency", status: model.PRStatusOpen, needsReview: true,
+		ci: model.CIStatusPassing, mergeable: model.MergeableConflicted, labels: []string{"api", "needs-migration"},
+		size: [3]int{860, 120, 27}, openedHours: 75, idleHours: 9,
+		reviews: []demoReview{
+			{reviewer: "priya-k", state: model.ReviewStateApproved, body: "Migration looks safe to run online.", hoursAgo: 30, outdated: true},
+			{reviewer: "jordan-lee", state: model.ReviewStateCommented, hoursAgo: 12},
+		},
+		threads: []demoThread{
+			{
+				path: "internal/refund/handler.go", line: 88, hoursAgo: 12,
+				hunk: "@@ -80,6 +80,14 @@ func (h *Handler) Refund(w http.ResponseWriter, r *http.Request) {\n+\tkey := r.Header.Get(\"Idempotency-Key\")\n+\tif key == \"\" {\n+\t\twriteError(w, http.StatusBadRequest, \"missing Idempotency-Key\")",
+				replies: [][2]string{
+					{"jordan-lee", "Existing clients don't send this header yet. Can we make it optional for a release?"},
+					{"sam-okafor", "Fair. I'll log a warning instead of rejecting until v3."},
+					{"jordan-lee", "👍"},
+				},
+			},
+			{
+				path: "migrations/0042_refund_keys.sql", line: 3, hoursAgo: 40, outdated: true, resolved: true,
+				hunk:    "@@ -0,0 +1,5 @@\n+CREATE TABLE refund_keys (\n+  key TEXT PRIMARY KEY,\n+  refund_id BIGINT NOT NULL",
+				replies: [][2]string{{"priya-k", "Add an index on created_at so the cleanup job stays fast."}},
+			},
+		},
+		comments: [][2]string{{"sam-okafor", "Rebased on main; the conflict is in the generated client, which I'll regenerate after review."}},
+	},
+	{
+		repo: "acme/payments-api", number: 1182, title: "Retry webhook delivery with jittered backoff", author: demoUser,
+		branch: "webhook-backoff", status: model.PRStatusOpen,
+		ci: model.CIStatusPassing, mergeable: model.MergeableMergeable, labels: []string{"reliability"},
+		size: [3]int{210, 64, 6}, openedHours: 26, idleHours: 3,
+		reviews: []demoReview{
+			{reviewer: "sam-okafor", state: model.ReviewStateApproved, body: "Nice, the jitter test is great.", hoursAgo: 4},
+			{reviewer: "marco-r", state: model.ReviewStateApproved, hoursAgo: 3},
+		},
+	},
+	{
+		repo: "acme/payments-api", number: 1179, title: "Remove the legacy Stripe v1 client", author: "jordan-lee",
+		branch: "jordan/drop-stripe-v1", status: model.PRStatusMerged,
+		ci: model.CIStatusPassing, mergeable: model.MergeableMergeable, labels: []string{"cleanup"},
+		size: [3]int{15, 1240, 31}, openedHours: 120, idleHours: 48,
+		reviews: []demoReview{{reviewer: demoUser, state: model.ReviewStateApproved, body: "So satisfying.", hoursAgo: 50}},
+	},
+	{
+		repo: "acme/payments-api", number: 1175, title: "Experiment: gRPC gateway", author: "marco-r",
+		branch: "marco/grpc-gateway", status: model.PRStatusClosed,
+		ci: model.CIStatusFailing, mergeable: model.MergeableMergeable, labels: []string{"experiment"},
+		size: [3]int{1900, 30, 44}, openedHours: 300, idleHours: 96,
+		comments: [][2]string{{"marco-r", "Closing in favour of the REST v3 plan from the design review."}},
+	},
+	{
+		repo: "acme/mobile-app", number: 233, title: "Offline mode for order history", author: "aisha-b",
+		branch: "aisha/offline-orders", status: model.PRStatusOpen, needsReview: true,
+		ci: model.CIStatusPending, mergeable: model.MergeableMergeable, labels: []string{"feature", "ios", "android"},
+		size: [3]int{540, 80, 19}, openedHours: 20, idleHours: 0,
+	},
+	{
+		repo: "acme/mobile-app", number: 229, title: "Crash on Android 14 when rotating during payment", author: "sam-okafor",
+		branch: "fix/android14-rotation", status: model.PRStatusOpen,
+		ci: model.CIStatusFailing, mergeable: model.MergeableMergeable, labels: []string{"bug", "P1"},
+		size: [3]int{34, 12, 3}, openedHours: 45, idleHours: 4,
+		reviews: []demoReview{{reviewer: demoUser, state: model.ReviewStateChangesRequested, body: "This fixes rotation, but the payment sheet still leaks.", outdated: true, hoursAgo: 30}},
+		threads: []demoThread{{
+			path: "android/app/src/main/java/com/acme/pay/PaymentActivity.kt", line: 61, hoursAgo: 30,
+			hunk: "@@ -58,4 +58,7 @@ class PaymentActivity : AppCompatActivity() {\n+    override fun onSaveInstanceState(outState: Bundle) {\n+        outState.putParcelable(\"sheet\", sheet)",
+			replies: [][2]string{
+				{demoUser, "The sheet holds the Activity context, so parcelling it leaks on rotation. Keep only its state here."},
+				{"sam-okafor", "Pushed a fix that stores the sheet state instead. Mind taking another look?"},
+			},
+		}},
+	},
+	{
+		repo: "acme/infra", number: 87, title: "Terraform: move staging to us-east-2", author: "jordan-lee",
+		branch: "jordan/staging-us-east-2", status: model.PRStatusOpen, needsReview: true,
+		ci: model.CIStatusPassing, mergeable: model.MergeableMergeable, labels: []string{"infra"},
+		size: [3]int{96, 88, 7}, openedHours: 8, idleHours: 8,
+		comments: [][2]string{{"atlantis[bot]", "Ran plan for dir `staging`: 14 to add, 2 to change, 14 to destroy."}},
+	},
+	{
+		repo: "acme/infra", number: 85, title: "Rotate database credentials monthly", author: "aisha-b",
+		branch: "aisha/rotate-db-creds", status: model.PRStatusMerged,
+		ci: model.CIStatusPassing, mergeable: model.MergeableMergeable, labels: []string{"security"},
+		size: [3]int{120, 18, 5}, openedHours: 60, idleHours: 20,
+		reviews: []demoReview{{reviewer: "jordan-lee", state: model.ReviewStateApproved, hoursAgo: 22}},
+	},
+}
+
+// DemoSeeder fills the stores with a fictional team's repositories, pull
+// requests, reviews, comment threads, and check runs, without calling GitHub,
+// so the dashboard can be tried and screenshotted without a token. The data
+// covers each PR state, CI result, and attention signal the dashboard shows.
+type DemoSeeder struct {
+	repoStore   driven.RepoStore
+	prStore     driven.PRStore
+	reviewStore driven.ReviewStore
+	checkStore  driven.CheckStore
+	username    string
+}
+
+// NewDemoSeeder creates a DemoSeeder. username is the dashboard's user, who
+// authors some of the PRs and is asked to review others.
+func NewDemoSeeder(repoStore driven.RepoStore, prStore driven.PRStore, reviewStore driven.ReviewStore, checkStore driven.CheckStore, username string) *DemoSeeder {
+	return &DemoSeeder{
+		repoStore:   repoStore,
+		prStore:     prStore,
+		reviewStore: reviewStore,
+		checkStore:  checkStore,
+		username:    username,
+	}
+}
+
+// Seed stores the demo data with times relative to now. It is meant for an
+// empty database; repositories that already exist are left as they are.
+func (s *DemoSeeder) Seed(ctx context.Context, now time.Time) error {
+	for _, fullName := range demoRepos {
+		owner, name, _ := strings.Cut(fullName, "/")
+		repo := model.Repository{FullName: fullName, Owner: owner, Name: name, AddedAt: now.Add(-30 * 24 * time.Hour)}
+		if err := s.repoStore.Add(ctx, repo); err != nil && !errors.Is(err, driven.ErrRepoAlreadyExists) {
+			return fmt.Errorf("seed demo repository %s: %w", fullName, err)
+		}
+	}
+
+	var nextID int64
+	id := func() int64 {
+		nextID++
+		return nextID
+	}
+	for _, d := range demoPRs {
+		if err := s.seedPR(ctx, d, now, id); err != nil {
+			return fmt.Errorf("seed demo PR %s#%d: %w", d.repo, d.number, err)
+		}
+	}
+	return nil
+}
+
+// seedPR stores one demo PR with its reviews, threads, comments, and checks.
+// id hands out review, comment, and check run IDs.
+func (s *DemoSeeder) seedPR(ctx context.Context, d demoPR, now time.Time, id func() int64) error {
+	ago := func(hours int) time.Time { return now.Add(-time.Duration(hours) * time.Hour) }
+	headSHA := demoSHA(d.repo, d.number, "head")
+	oldSHA := demoSHA(d.repo, d.number, "previous")
+	url := fmt.Sprintf("https://github.com/%s/pull/%d", d.repo, d.number)
+
+	pr := model.PullRequest{
+		Number:          d.number,
+		RepoFullName:    d.repo,
+		Title:           d.title,
+		Author:          s.login(d.author),
+		Status:          d.status,
+		IsDraft:         d.draft,
+		URL:             url,
+		Branch:          d.branch,
+		BaseBranch:      "main",
+		NeedsReview:     d.needsReview,
+		HeadSHA:         headSHA,
+		Additions:       d.size[0],
+		Deletions:       d.size[1],
+		ChangedFiles:    d.size[2],
+		MergeableStatus: d.mergeable,
+		CIStatus:        d.ci,
+		Labels:          d.labels,
+		OpenedAt:        ago(d.openedHours),
+		UpdatedAt:       ago(d.idleHours),
+		LastActivityAt:  ago(d.idleHours),
+	}
+	if d.status != model.PRStatusOpen {
+		pr.ClosedAt = ago(d.idleHours)
+	}
+	if err := s.prStore.Upsert(ctx, pr); err != nil {
+		return err
+	}
+	stored, err := s.prStore.GetByNumber(ctx, d.repo, d.number)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return errors.New("not found after upsert")
+	}
+	prID := stored.ID
+
+	reviewIDs := make(map[string]int64)
+	for _, r := range d.reviews {
+		review := model.Review{
+			ID:            id(),
+			PRID:          prID,
+			ReviewerLogin: s.login(r.reviewer),
+			State:         r.state,
+			Body:          r.body,
+			CommitID:      headSHA,
+			SubmittedAt:   ago(r.hoursAgo),
+		}
+		if r.outdated {
+			review.CommitID = oldSHA
+		}
+		if err := s.reviewStore.UpsertReview(ctx, review); err != nil {
+			return err
+		}
+		reviewIDs[r.reviewer] = review.ID
+	}
+
+	for _, t := range d.threads {
+		commitID := headSHA
+		if t.outdated {
+			commitID = oldSHA
+		}
+		var rootID int64
+		for i, reply := range t.replies {
+			at := ago(t.hoursAgo).Add(time.Duration(i) * 20 * time.Minute)
+			comment := model.ReviewComment{
+				ID:          id(),
+				ReviewID:    reviewIDs[reply[0]],
+				PRID:        prID,
+				Author:      s.login(reply[0]),
+				Body:        reply[1],
+				Path:        t.path,
+				Line:        t.line,
+				Side:        "RIGHT",
+				SubjectType: "line",
+				DiffHunk:    t.hunk,
+				CommitID:    commitID,
+				IsResolved:  t.resolved,
+				IsOutdated:  t.outdated,
+				CreatedAt:   at,
+				UpdatedAt:   at,
+			}
+			if i == 0 {
+				rootID = comment.ID
+			} else {
+				comment.InReplyToID = &rootID
+			}
+			if err := s.reviewStore.UpsertReviewComment(ctx, comment); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, c := range d.comments {
+		at := ago(d.idleHours).Add(-time.Duration(len(d.comments)-i) * time.Hour)
+		comment := model.IssueComment{
+			ID:        id(),
+			PRID:      prID,
+			Author:    s.login(c[0]),
+			Body:      c[1],
+			IsBot:     strings.HasSuffix(c[0], "[bot]"),
+			CreatedAt: at,
+			UpdatedAt: at,
+		}
+		if err := s.reviewStore.UpsertIssueComment(ctx, comment); err != nil {
+			return err
+		}
+	}
+
+	return s.checkStore.ReplaceCheckRunsForPR(ctx, prID, demoCheckRuns(d.ci, prID, url, ago(d.idleHours), id))
+}
+
+// login replaces demoUser with the configured username.
+func (s *DemoSeeder) login(name string) string {
+	if name == demoUser {
+		return s.username
+	}
+	return name
+}
+
+// demoCheckRuns returns build, test, and lint runs whose results add up to ci.
+func demoCheckRuns(ci model.CIStatus, prID int64, prURL string, startedAt time.Time, id func() int64) []model.CheckRun {
+	type result struct{ status, conclusion string }
+	results := map[string]result{"build": {"completed", "success"}, "test": {"completed", "success"}, "lint": {"completed", "success"}}
+	switch ci {
+	case model.CIStatusFailing:
+		results["test"] = result{"completed", "failure"}
+	case model.CIStatusPending:
+		results["test"] = result{"in_progress", ""}
+		results["lint"] = result{"queued", ""}
+	}
+
+	runs := make([]model.CheckRun, 0, len(results))
+	for _, name := range []string{"build", "lint", "test"} {
+		r := results[name]
+		run := model.CheckRun{
+			ID:          id(),
+			PRID:        prID,
+			Name:        name,
+			Status:      r.status,
+			Conclusion:  r.conclusion,
+			IsRequired:  name != "lint",
+			DetailsURL:  prURL + "/checks",
+			StartedAt:   startedAt,
+			CompletedAt: startedAt.Add(4 * time.Minute),
+		}
+		if r.status != "completed" {
+			run.CompletedAt = time.Time{}
+		}
+		runs = append(runs, run)
+	}
+	return runs
+}
+
+// demoSHA derives a stable fake commit SHA for a demo PR.
+func demoSHA(repo string, number int, rev string) string {
+	sum := sha1.Sum(fmt.Appendf(nil, "%s#%d@%s", repo, number, rev)) //nolint:gosec // fake commit SHAs, not security
+	return hex.EncodeToString(sum[:])
+}
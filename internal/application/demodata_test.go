@@ -0,0 +1,53 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestDemoSeeder(t *testing.T) {
+	prStore := &mockPRStore{}
+	reviewStore := newMockReviewStore()
+	checkStore := newMockCheckStore()
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	seeder := application.NewDemoSeeder(&mockRepoStore{}, prStore, reviewStore, checkStore, "octocat")
+	require.NoError(t, seeder.Seed(context.Background(), now))
+
+	statuses := make(map[model.PRStatus]int)
+	var mine, toReview int
+	for _, u := range prStore.upserts {
+		statuses[u.PR.Status]++
+		if u.PR.Author == "octocat" {
+			mine++
+		}
+		if u.PR.NeedsReview {
+			toReview++
+		}
+		assert.False(t, u.PR.OpenedAt.After(now), "%s#%d opened in the future", u.PR.RepoFullName, u.PR.Number)
+	}
+	assert.Positive(t, statuses[model.PRStatusOpen])
+	assert.Positive(t, statuses[model.PRStatusMerged])
+	assert.Positive(t, statuses[model.PRStatusClosed])
+	assert.Positive(t, mine, "the configured user authors some PRs")
+	assert.Positive(t, toReview, "the configured user is asked to review some PRs")
+
+	assert.NotEmpty(t, reviewStore.upsertedReviews)
+	assert.NotEmpty(t, reviewStore.upsertedIssueComments)
+	var replies int
+	for _, c := range reviewStore.upsertedReviewComments {
+		assert.NotEqual(t, "@me", c.Author)
+		if c.InReplyToID != nil {
+			replies++
+		}
+	}
+	assert.Positive(t, replies, "threads have replies")
+	assert.Len(t, checkStore.replaced, len(prStore.upserts), "every PR has check runs")
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// defaultDrainGrace bounds how long an in-flight PR sync may continue
+	// after shutdown begins. It must fit within main's shutdown timeout.
+	defaultDrainGrace = 5 * time.Second
+
+	// rollbackTimeout bounds the compensating write of an interrupted sync.
+	rollbackTimeout = 2 * time.Second
+)
+
+// WithDrainGrace overrides how long an in-flight PR sync may keep running
+// after the poll context is canceled before it is rolled back.
+func (s *PollService) WithDrainGrace(grace time.Duration) *PollService {
+	s.drainGrace = grace
+	return s
+}
+
+// Drained returns a channel that is closed once Start has returned, meaning
+// no poll work is in flight and the stores are no longer written to.
+func (s *PollService) Drained() <-chan struct{} {
+	return s.drained
+}
+
+// drainContext returns a context that keeps the values of parent but is only
+// canceled grace after parent is, letting work already under way reach its
+// next checkpoint. The returned cancel func must be called to release it.
+func drainContext(parent context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parent))
+	stop := context.AfterFunc(parent, func() {
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-ctx.Done():
+		}
+	})
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
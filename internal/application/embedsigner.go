@@ -0,0 +1,48 @@
+package application
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+)
+
+// embedKeyContext separates the embed signing key from other uses of the
+// secret key.
+const embedKeyContext = "mygitpanel embed token v1"
+
+// EmbedSigner issues and checks the tokens that grant read-only access to a
+// repository's embeddable PR list. A token is an HMAC of the repository's
+// full name, so it needs no storage; rotating the secret key revokes every
+// token.
+type EmbedSigner struct {
+	key []byte
+}
+
+// NewEmbedSigner creates an EmbedSigner whose key is derived from secret.
+func NewEmbedSigner(secret []byte) *EmbedSigner {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(embedKeyContext))
+	return &EmbedSigner{key: mac.Sum(nil)}
+}
+
+// Token returns the embed token for repoFullName. Names are compared case
+// insensitively, as GitHub does.
+func (s *EmbedSigner) Token(repoFullName string) string {
+	return base64.RawURLEncoding.EncodeToString(s.sign(repoFullName))
+}
+
+// Valid reports whether token grants access to repoFullName.
+func (s *EmbedSigner) Valid(repoFullName, token string) bool {
+	got, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(got, s.sign(repoFullName))
+}
+
+func (s *EmbedSigner) sign(repoFullName string) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(strings.ToLower(repoFullName)))
+	return mac.Sum(nil)
+}
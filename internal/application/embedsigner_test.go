@@ -0,0 +1,24 @@
+package application_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+)
+
+func TestEmbedSigner(t *testing.T) {
+	signer := application.NewEmbedSigner(bytes.Repeat([]byte{1}, 32))
+	token := signer.Token("org/api")
+
+	assert.True(t, signer.Valid("org/api", token))
+	assert.True(t, signer.Valid("Org/API", token), "repository names are case insensitive")
+	assert.False(t, signer.Valid("org/web", token), "a token is scoped to one repository")
+	assert.False(t, signer.Valid("org/api", ""))
+	assert.False(t, signer.Valid("org/api", "not base64!"))
+
+	other := application.NewEmbedSigner(bytes.Repeat([]byte{2}, 32))
+	assert.False(t, other.Valid("org/api", token), "changing the secret key revokes tokens")
+}
@@ -0,0 +1,48 @@
+package application
+
+// EmojiShortcode is a GitHub emoji shortcode, without colons, and the
+// character GitHub renders for it.
+type EmojiShortcode struct {
+	Code  string
+	Emoji string
+}
+
+// emojiShortcodes is the subset of GitHub's shortcodes offered by emoji
+// autocomplete, roughly in order of how often they show up in code review.
+// GitHub renders any shortcode typed by hand, so the list need not be complete.
+var emojiShortcodes = []EmojiShortcode{
+	{"+1", "👍"}, {"thumbsup", "👍"}, {"-1", "👎"}, {"thumbsdown", "👎"},
+	{"tada", "🎉"}, {"rocket", "🚀"}, {"heart", "❤️"}, {"eyes", "👀"},
+	{"laughing", "😆"}, {"confused", "😕"}, {"smile", "😄"}, {"smiley", "😃"},
+	{"grinning", "😀"}, {"slightly_smiling_face", "🙂"}, {"wink", "😉"}, {"joy", "😂"},
+	{"sweat_smile", "😅"}, {"thinking", "🤔"}, {"raised_eyebrow", "🤨"}, {"neutral_face", "😐"},
+	{"upside_down_face", "🙃"}, {"sob", "😭"}, {"cry", "😢"}, {"scream", "😱"},
+	{"exploding_head", "🤯"}, {"facepalm", "🤦"}, {"shrug", "🤷"}, {"pray", "🙏"},
+	{"clap", "👏"}, {"raised_hands", "🙌"}, {"muscle", "💪"}, {"ok_hand", "👌"},
+	{"wave", "👋"}, {"point_up", "☝️"}, {"point_down", "👇"}, {"point_right", "👉"},
+	{"point_left", "👈"}, {"v", "✌️"}, {"crossed_fingers", "🤞"}, {"handshake", "🤝"},
+	{"white_check_mark", "✅"}, {"heavy_check_mark", "✔️"}, {"x", "❌"}, {"warning", "⚠️"},
+	{"no_entry", "⛔"}, {"stop_sign", "🛑"}, {"question", "❓"}, {"exclamation", "❗"},
+	{"bangbang", "‼️"}, {"information_source", "ℹ️"}, {"bulb", "💡"}, {"memo", "📝"},
+	{"pencil2", "✏️"}, {"lock", "🔒"}, {"unlock", "🔓"}, {"key", "🔑"},
+	{"bug", "🐛"}, {"fire", "🔥"}, {"sparkles", "✨"}, {"zap", "⚡"},
+	{"boom", "💥"}, {"star", "⭐"}, {"star2", "🌟"}, {"100", "💯"},
+	{"construction", "🚧"}, {"hammer", "🔨"}, {"wrench", "🔧"}, {"gear", "⚙️"},
+	{"package", "📦"}, {"recycle", "♻️"}, {"wastebasket", "🗑️"}, {"truck", "🚚"},
+	{"art", "🎨"}, {"lipstick", "💄"}, {"books", "📚"}, {"book", "📖"},
+	{"mag", "🔍"}, {"link", "🔗"}, {"pushpin", "📌"}, {"paperclip", "📎"},
+	{"chart_with_upwards_trend", "📈"}, {"chart_with_downwards_trend", "📉"}, {"bar_chart", "📊"}, {"alembic", "⚗️"},
+	{"test_tube", "🧪"}, {"microscope", "🔬"}, {"rotating_light", "🚨"}, {"ambulance", "🚑"},
+	{"adhesive_bandage", "🩹"}, {"bookmark", "🔖"}, {"label", "🏷️"}, {"globe_with_meridians", "🌐"},
+	{"iphone", "📱"}, {"computer", "💻"}, {"whale", "🐳"}, {"penguin", "🐧"},
+	{"apple", "🍎"}, {"snake", "🐍"}, {"crab", "🦀"}, {"gopher", "🐹"},
+	{"coffee", "☕"}, {"beers", "🍻"}, {"cake", "🍰"}, {"gift", "🎁"},
+	{"trophy", "🏆"}, {"medal_sports", "🏅"}, {"dart", "🎯"}, {"checkered_flag", "🏁"},
+	{"hourglass", "⌛"}, {"stopwatch", "⏱️"}, {"calendar", "📆"}, {"alarm_clock", "⏰"},
+	{"speech_balloon", "💬"}, {"thought_balloon", "💭"}, {"mega", "📣"}, {"bell", "🔔"},
+	{"no_bell", "🔕"}, {"zzz", "💤"}, {"see_no_evil", "🙈"}, {"skull", "💀"},
+	{"ghost", "👻"}, {"robot", "🤖"}, {"alien", "👽"}, {"unicorn", "🦄"},
+	{"arrow_up", "⬆️"}, {"arrow_down", "⬇️"}, {"arrow_right", "➡️"}, {"arrow_left", "⬅️"},
+	{"leftwards_arrow_with_hook", "↩️"}, {"twisted_rightwards_arrows", "🔀"}, {"heavy_plus_sign", "➕"}, {"heavy_minus_sign", "➖"},
+	{"green_heart", "💚"}, {"blue_heart", "💙"}, {"purple_heart", "💜"}, {"broken_heart", "💔"},
+}
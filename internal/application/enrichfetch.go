@@ -0,0 +1,42 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// enrichFetchConcurrency bounds the GitHub requests a PR sync makes at once
+// while fetching its reviews or checks. GitHub's secondary rate limits punish
+// bursts of concurrent requests, so this stays small.
+const enrichFetchConcurrency = 4
+
+// runFetches runs the independent GitHub fetches of a PR sync concurrently and
+// waits for all of them. Each fetch handles its own error, so one failing does
+// not cancel the others. When the rate limit is down to the pacing reserve,
+// a secondary rate limit is in effect, or the API looks degraded, the fetches
+// run one at a time instead.
+func (s *PollService) runFetches(ctx context.Context, fetches ...func(context.Context)) {
+	var g errgroup.Group
+	g.SetLimit(s.fetchConcurrency(time.Now()))
+	for _, fetch := range fetches {
+		g.Go(func() error {
+			fetch(ctx)
+			return nil
+		})
+	}
+	_ = g.Wait() // fetches never return errors
+}
+
+// fetchConcurrency returns how many fetches runFetches may run at once.
+func (s *PollService) fetchConcurrency(now time.Time) int {
+	if status, ok := s.GitHubStatus(); ok && status.RateLimit > 0 && status.RateReset.After(now) &&
+		float64(status.RateRemaining) <= pacingReserveShare*float64(status.RateLimit) {
+		return 1
+	}
+	if _, limited := s.rateLimitedUntil(now); limited || s.degradedReason(now) != "" {
+		return 1
+	}
+	return enrichFetchConcurrency
+}
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestFetchConcurrency(t *testing.T) {
+	now := time.Now()
+
+	t.Run("bounded without rate limit data", func(t *testing.T) {
+		svc := &PollService{ghClient: &statusClient{}}
+		assert.Equal(t, enrichFetchConcurrency, svc.fetchConcurrency(now))
+	})
+
+	t.Run("sequential within the pacing reserve", func(t *testing.T) {
+		svc := &PollService{ghClient: &statusClient{status: model.GitHubAPIStatus{
+			RateLimit: 5000, RateRemaining: 400, RateReset: now.Add(10 * time.Minute),
+		}}}
+		assert.Equal(t, 1, svc.fetchConcurrency(now))
+	})
+
+	t.Run("sequential while degraded", func(t *testing.T) {
+		svc := &PollService{ghClient: &statusClient{}}
+		svc.outage.statusPage.Degraded = true
+		assert.Equal(t, 1, svc.fetchConcurrency(now))
+	})
+}
+
+func TestRunFetches(t *testing.T) {
+	svc := &PollService{ghClient: &statusClient{}}
+
+	var running, peak, done atomic.Int32
+	fetch := func(context.Context) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		done.Add(1)
+	}
+	svc.runFetches(t.Context(), fetch, fetch, fetch, fetch, fetch, fetch)
+
+	assert.Equal(t, int32(6), done.Load(), "runFetches waits for every fetch")
+	assert.Greater(t, peak.Load(), int32(1), "fetches overlap")
+	assert.LessOrEqual(t, peak.Load(), int32(enrichFetchConcurrency))
+}
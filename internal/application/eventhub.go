@@ -0,0 +1,112 @@
+package application
+
+import (
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// eventBufferSize is how many events a subscriber may fall behind before
+// further events to it are dropped.
+const eventBufferSize = 64
+
+// EventFilter selects which events a subscriber receives. Empty fields match
+// everything.
+type EventFilter struct {
+	Repos []string            // repository full names, matched case-insensitively
+	Types []model.PREventType // event types
+}
+
+// matches reports whether e passes the filter.
+func (f EventFilter) matches(e model.PREvent) bool {
+	if len(f.Repos) > 0 && !containsFold(f.Repos, e.RepoFullName) {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == e.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// eventSubscriber is one live subscription.
+type eventSubscriber struct {
+	filter EventFilter
+	ch     chan model.PREvent
+}
+
+// EventHub fans PR events out to in-process subscribers such as WebSocket
+// clients. Publishing never blocks the poll loop: a subscriber whose buffer
+// is full misses events rather than stalling everyone else.
+type EventHub struct {
+	mu     sync.Mutex
+	nextID int
+	subs   map[int]*eventSubscriber
+	logger *slog.Logger
+}
+
+// NewEventHub creates an EventHub with no subscribers.
+func NewEventHub() *EventHub {
+	return &EventHub{
+		subs:   make(map[int]*eventSubscriber),
+		logger: slog.Default(),
+	}
+}
+
+// Subscribe registers a subscriber and returns its event channel and a cancel
+// function. cancel unregisters the subscriber and closes the channel; it is
+// safe to call more than once.
+func (h *EventHub) Subscribe(filter EventFilter) (<-chan model.PREvent, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	sub := &eventSubscriber{filter: filter, ch: make(chan model.PREvent, eventBufferSize)}
+	h.subs[id] = sub
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subs, id)
+			close(sub.ch)
+		})
+	}
+	return sub.ch, cancel
+}
+
+// Publish delivers e to every subscriber whose filter matches it.
+func (h *EventHub) Publish(e model.PREvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, sub := range h.subs {
+		if !sub.filter.matches(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			h.logger.Warn("event subscriber is falling behind, dropping event",
+				"type", e.Type, "repo", e.RepoFullName, "pr", e.PRNumber)
+		}
+	}
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package application_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestEventHub_FiltersByRepoAndType(t *testing.T) {
+	hub := application.NewEventHub()
+
+	all, cancelAll := hub.Subscribe(application.EventFilter{})
+	defer cancelAll()
+	reviews, cancelReviews := hub.Subscribe(application.EventFilter{
+		Repos: []string{"Org/Repo"},
+		Types: []model.PREventType{model.PREventReviewAdded},
+	})
+	defer cancelReviews()
+
+	hub.Publish(model.PREvent{Type: model.PREventUpdated, RepoFullName: "org/repo", PRNumber: 1})
+	hub.Publish(model.PREvent{Type: model.PREventReviewAdded, RepoFullName: "org/other", PRNumber: 2})
+	hub.Publish(model.PREvent{Type: model.PREventReviewAdded, RepoFullName: "org/repo", PRNumber: 3})
+
+	assert.Len(t, all, 3)
+	require.Len(t, reviews, 1)
+	got := <-reviews
+	assert.Equal(t, 3, got.PRNumber, "repo filter should match case-insensitively")
+}
+
+func TestEventHub_DropsWhenSubscriberFallsBehind(t *testing.T) {
+	hub := application.NewEventHub()
+	events, cancel := hub.Subscribe(application.EventFilter{})
+	defer cancel()
+
+	// Publishing far past the buffer must not block.
+	for i := range 1000 {
+		hub.Publish(model.PREvent{Type: model.PREventUpdated, RepoFullName: "org/repo", PRNumber: i})
+	}
+
+	assert.Equal(t, cap(events), len(events))
+	assert.Equal(t, 0, (<-events).PRNumber, "oldest events are kept")
+}
+
+func TestEventHub_CancelClosesChannel(t *testing.T) {
+	hub := application.NewEventHub()
+	events, cancel := hub.Subscribe(application.EventFilter{})
+
+	cancel()
+	cancel() // idempotent
+
+	_, open := <-events
+	assert.False(t, open)
+
+	// Publishing after cancel must not panic on the closed channel.
+	hub.Publish(model.PREvent{Type: model.PREventUpdated, RepoFullName: "org/repo"})
+}
+
+func TestPREventType_IsValid(t *testing.T) {
+	assert.True(t, model.PREventCheckCompleted.IsValid())
+	assert.False(t, model.PREventType("pr.deleted").IsValid())
+}
@@ -0,0 +1,403 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// Event webhook retry schedule: the delay doubles from webhookBaseBackoff
+// after each failed attempt, up to webhookMaxBackoff, and a delivery that has
+// failed webhookMaxAttempts times is marked failed.
+const (
+	webhookBaseBackoff = 30 * time.Second
+	webhookMaxBackoff  = 30 * time.Minute
+	webhookMaxAttempts = 8
+)
+
+const (
+	// maxEventWebhooks bounds the endpoints each event is delivered to.
+	maxEventWebhooks = 20
+
+	// webhookDeliveryRetention is how long finished deliveries stay in the log.
+	webhookDeliveryRetention = 7 * 24 * time.Hour
+
+	// webhookDeliveryLogLimit bounds the deliveries returned for one webhook.
+	webhookDeliveryLogLimit = 50
+)
+
+// ErrInvalidEventWebhook is returned for a webhook with a malformed URL, an
+// unknown event, or a malformed repository, or when the webhook limit is
+// reached.
+var ErrInvalidEventWebhook = errors.New("invalid event webhook")
+
+// eventWebhookPayload is the JSON body delivered to event webhooks.
+type eventWebhookPayload struct {
+	Event       string              `json:"event"`
+	OccurredAt  string              `json:"occurred_at"`
+	Repository  string              `json:"repository"`
+	Number      int                 `json:"number"`
+	PullRequest *eventWebhookPR     `json:"pull_request,omitempty"`
+	Review      *eventWebhookReview `json:"review,omitempty"`
+	Check       *eventWebhookCheck  `json:"check,omitempty"`
+	Signals     []string            `json:"signals,omitempty"`
+	Rules       []string            `json:"rules,omitempty"`
+}
+
+// eventWebhookPR is the PR context in an event webhook payload.
+type eventWebhookPR struct {
+	Title      string `json:"title"`
+	Author     string `json:"author"`
+	URL        string `json:"url"`
+	Branch     string `json:"branch"`
+	BaseBranch string `json:"base_branch"`
+	IsDraft    bool   `json:"is_draft"`
+	CIStatus   string `json:"ci_status"`
+}
+
+// eventWebhookReview is the review in a review.submitted payload.
+type eventWebhookReview struct {
+	Reviewer    string `json:"reviewer"`
+	State       string `json:"state"`
+	Body        string `json:"body"`
+	SubmittedAt string `json:"submitted_at"`
+}
+
+// eventWebhookCheck is the check run in a check.failed payload.
+type eventWebhookCheck struct {
+	Name       string `json:"name"`
+	Conclusion string `json:"conclusion"`
+	Required   bool   `json:"required"`
+	DetailsURL string `json:"details_url"`
+}
+
+// EventWebhookService delivers signed JSON to user-configured endpoints when
+// PRs are created, start needing attention, get a review, or fail a check. It
+// listens to the EventHub, so it only sees what the poll loop publishes.
+// Every delivery is logged; failed ones are retried with exponential backoff.
+type EventWebhookService struct {
+	store   driven.EventWebhookStore
+	prStore driven.PRStore
+	sender  driven.EventWebhookSender
+	logger  *slog.Logger
+	now     func() time.Time
+}
+
+// NewEventWebhookService creates an EventWebhookService.
+func NewEventWebhookService(store driven.EventWebhookStore, prStore driven.PRStore, sender driven.EventWebhookSender) *EventWebhookService {
+	return &EventWebhookService{store: store, prStore: prStore, sender: sender, logger: slog.Default(), now: time.Now}
+}
+
+// Create validates and stores a webhook with a freshly generated signing
+// secret, returned on the webhook. It returns ErrInvalidEventWebhook for a bad
+// webhook or when the webhook limit is reached.
+func (s *EventWebhookService) Create(ctx context.Context, hook model.EventWebhook) (model.EventWebhook, error) {
+	hook.URL = strings.TrimSpace(hook.URL)
+	hook.RepoFullName = strings.TrimSpace(hook.RepoFullName)
+
+	u, err := url.Parse(hook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return model.EventWebhook{}, fmt.Errorf("%w: URL must be an absolute http or https URL", ErrInvalidEventWebhook)
+	}
+	for _, e := range hook.Events {
+		if !e.Valid() {
+			return model.EventWebhook{}, fmt.Errorf("%w: unknown event %q", ErrInvalidEventWebhook, e)
+		}
+	}
+	if hook.RepoFullName != "" && !validate.IsValidRepoName(hook.RepoFullName) {
+		return model.EventWebhook{}, fmt.Errorf("%w: repository must be in owner/name form", ErrInvalidEventWebhook)
+	}
+
+	existing, err := s.store.List(ctx)
+	if err != nil {
+		return model.EventWebhook{}, err
+	}
+	if len(existing) >= maxEventWebhooks {
+		return model.EventWebhook{}, fmt.Errorf("%w: at most %d webhooks", ErrInvalidEventWebhook, maxEventWebhooks)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return model.EventWebhook{}, fmt.Errorf("generate webhook secret: %w", err)
+	}
+	hook.Secret = hex.EncodeToString(raw)
+	hook.CreatedAt = s.now().UTC()
+
+	id, err := s.store.Create(ctx, hook)
+	if err != nil {
+		return model.EventWebhook{}, err
+	}
+	hook.ID = id
+	return hook, nil
+}
+
+// List returns all webhooks, enabled or not.
+func (s *EventWebhookService) List(ctx context.Context) ([]model.EventWebhook, error) {
+	return s.store.List(ctx)
+}
+
+// SetEnabled turns a webhook on or off. It returns
+// driven.ErrEventWebhookNotFound if it does not exist.
+func (s *EventWebhookService) SetEnabled(ctx context.Context, id int64, enabled bool) error {
+	return s.store.SetEnabled(ctx, id, enabled)
+}
+
+// Delete removes a webhook and its delivery log. It returns
+// driven.ErrEventWebhookNotFound if it does not exist.
+func (s *EventWebhookService) Delete(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Deliveries returns the most recent deliveries to a webhook, newest first.
+// It returns driven.ErrEventWebhookNotFound if the webhook does not exist.
+func (s *EventWebhookService) Deliveries(ctx context.Context, id int64) ([]model.WebhookDelivery, error) {
+	hook, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hook == nil {
+		return nil, driven.ErrEventWebhookNotFound
+	}
+	return s.store.ListDeliveries(ctx, id, webhookDeliveryLogLimit)
+}
+
+// Run delivers webhooks for events published on hub until ctx is canceled.
+// A new event is attempted right away; every interval, deliveries whose
+// retry has come are attempted again and old finished ones are pruned.
+func (s *EventWebhookService) Run(ctx context.Context, hub *EventHub, interval time.Duration) {
+	events, cancel := hub.Subscribe(EventFilter{Types: []model.PREventType{
+		model.PREventOpened, model.PREventAttentionChanged, model.PREventReviewAdded, model.PREventCheckCompleted,
+	}})
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			s.dispatch(ctx, e)
+		case <-ticker.C:
+			s.processDue(ctx)
+		}
+	}
+}
+
+// dispatch records a delivery of e for every matching webhook and attempts
+// each one.
+func (s *EventWebhookService) dispatch(ctx context.Context, e model.PREvent) {
+	event, ok := webhookEventFor(e)
+	if !ok {
+		return
+	}
+
+	hooks, err := s.store.List(ctx)
+	if err != nil {
+		s.logger.Error("failed to list event webhooks", "error", err)
+		return
+	}
+	var matching []model.EventWebhook
+	for _, hook := range hooks {
+		if hook.Matches(event, e.RepoFullName) {
+			matching = append(matching, hook)
+		}
+	}
+	if len(matching) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(s.payload(ctx, event, e))
+	if err != nil {
+		s.logger.Error("failed to encode event webhook payload", "event", event, "error", err)
+		return
+	}
+
+	now := s.now().UTC()
+	for _, hook := range matching {
+		d := model.WebhookDelivery{
+			WebhookID:     hook.ID,
+			Event:         event,
+			RepoFullName:  e.RepoFullName,
+			PRNumber:      e.PRNumber,
+			Payload:       string(payload),
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		id, err := s.store.AddDelivery(ctx, d)
+		if err != nil {
+			s.logger.Error("failed to record event webhook delivery", "webhook", hook.ID, "event", event, "error", err)
+			continue
+		}
+		d.ID = id
+		s.attempt(ctx, hook, d)
+	}
+}
+
+// webhookEventFor maps e to the webhook event it triggers, if any. A PR
+// needs attention when it has a signal or rule match now and had none before.
+func webhookEventFor(e model.PREvent) (model.WebhookEventType, bool) {
+	switch {
+	case e.Type == model.PREventOpened:
+		return model.WebhookPRCreated, true
+	case e.Attention != nil:
+		if !e.Attention.HasAny() || (e.PreviousAttention != nil && e.PreviousAttention.HasAny()) {
+			return "", false
+		}
+		return model.WebhookPRNeedsAttention, true
+	case e.Review != nil:
+		return model.WebhookReviewSubmitted, true
+	case e.CheckRun != nil:
+		// Canceled runs are usually superseded by a newer push, not failures.
+		switch e.CheckRun.Conclusion {
+		case "failure", "timed_out", "action_required":
+			return model.WebhookCheckFailed, true
+		}
+	}
+	return "", false
+}
+
+// payload builds the body delivered for e. PR context comes from the event
+// when it carries the PR and from the store otherwise; it is left out if the
+// PR cannot be loaded.
+func (s *EventWebhookService) payload(ctx context.Context, event model.WebhookEventType, e model.PREvent) eventWebhookPayload {
+	p := eventWebhookPayload{
+		Event:      string(event),
+		OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339),
+		Repository: e.RepoFullName,
+		Number:     e.PRNumber,
+	}
+
+	pr := e.PullRequest
+	if pr == nil {
+		loaded, err := s.prStore.GetByNumber(ctx, e.RepoFullName, e.PRNumber)
+		if err != nil {
+			s.logger.Warn("failed to load PR for event webhook", "repo", e.RepoFullName, "pr", e.PRNumber, "error", err)
+		}
+		pr = loaded
+	}
+	if pr != nil {
+		p.PullRequest = &eventWebhookPR{
+			Title:      pr.Title,
+			Author:     pr.Author,
+			URL:        pr.URL,
+			Branch:     pr.Branch,
+			BaseBranch: pr.BaseBranch,
+			IsDraft:    pr.IsDraft,
+			CIStatus:   string(pr.CIStatus),
+		}
+	}
+
+	if e.Review != nil {
+		p.Review = &eventWebhookReview{
+			Reviewer:    e.Review.ReviewerLogin,
+			State:       string(e.Review.State),
+			Body:        e.Review.Body,
+			SubmittedAt: e.Review.SubmittedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	if e.CheckRun != nil {
+		p.Check = &eventWebhookCheck{
+			Name:       e.CheckRun.Name,
+			Conclusion: e.CheckRun.Conclusion,
+			Required:   e.CheckRun.IsRequired,
+			DetailsURL: e.CheckRun.DetailsURL,
+		}
+	}
+	if e.Attention != nil {
+		for _, signal := range model.AllAttentionSignals {
+			if e.Attention.Has(signal) {
+				p.Signals = append(p.Signals, string(signal))
+			}
+		}
+		for _, m := range e.Attention.Rules {
+			p.Rules = append(p.Rules, m.Name)
+		}
+	}
+	return p
+}
+
+// processDue retries every pending delivery whose next attempt has come, then
+// prunes finished deliveries past the retention window.
+func (s *EventWebhookService) processDue(ctx context.Context) {
+	now := s.now().UTC()
+	due, err := s.store.ListDueDeliveries(ctx, now)
+	if err != nil {
+		s.logger.Error("failed to list due webhook deliveries", "error", err)
+		return
+	}
+
+	hooks := make(map[int64]*model.EventWebhook)
+	for _, d := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		hook, seen := hooks[d.WebhookID]
+		if !seen {
+			if hook, err = s.store.Get(ctx, d.WebhookID); err != nil {
+				s.logger.Error("failed to load event webhook", "webhook", d.WebhookID, "error", err)
+				continue
+			}
+			hooks[d.WebhookID] = hook
+		}
+		if hook == nil || !hook.Enabled {
+			// Paused webhooks keep their pending deliveries until re-enabled.
+			continue
+		}
+		s.attempt(ctx, *hook, d)
+	}
+
+	if n, err := s.store.PruneDeliveries(ctx, now.Add(-webhookDeliveryRetention)); err != nil {
+		s.logger.Error("failed to prune webhook deliveries", "error", err)
+	} else if n > 0 {
+		s.logger.Debug("pruned webhook deliveries", "count", n)
+	}
+}
+
+// attempt sends d to hook and records the outcome: delivered on a 2xx,
+// rescheduled otherwise, and failed once it runs out of attempts.
+func (s *EventWebhookService) attempt(ctx context.Context, hook model.EventWebhook, d model.WebhookDelivery) {
+	sendCtx, cancel := context.WithTimeout(ctx, webhookSendTimeout)
+	status, sendErr := s.sender.SendEvent(sendCtx, hook.URL, hook.Secret, d)
+	cancel()
+
+	now := s.now().UTC()
+	d.Attempts++
+	d.ResponseStatus = status
+	switch {
+	case sendErr == nil:
+		d.Status = model.WebhookDeliveryDelivered
+		d.LastError = ""
+		d.DeliveredAt = now
+	case d.Attempts < webhookMaxAttempts:
+		d.Status = model.WebhookDeliveryPending
+		d.LastError = sendErr.Error()
+		d.NextAttemptAt = now.Add(retryBackoff(d.Attempts, webhookBaseBackoff, webhookMaxBackoff))
+	default:
+		d.Status = model.WebhookDeliveryFailed
+		d.LastError = sendErr.Error()
+	}
+	if sendErr != nil {
+		s.logger.Warn("event webhook delivery failed",
+			"webhook", hook.ID, "delivery", d.ID, "event", d.Event, "attempts", d.Attempts, "error", sendErr)
+	}
+
+	if err := s.store.UpdateDelivery(ctx, d); err != nil {
+		s.logger.Error("failed to update webhook delivery", "delivery", d.ID, "error", err)
+	}
+}
@@ -0,0 +1,265 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memEventWebhookStore is an in-memory EventWebhookStore.
+type memEventWebhookStore struct {
+	hooks      []model.EventWebhook
+	deliveries []model.WebhookDelivery
+}
+
+func (m *memEventWebhookStore) Create(_ context.Context, hook model.EventWebhook) (int64, error) {
+	hook.ID = int64(len(m.hooks) + 1)
+	m.hooks = append(m.hooks, hook)
+	return hook.ID, nil
+}
+
+func (m *memEventWebhookStore) Get(_ context.Context, id int64) (*model.EventWebhook, error) {
+	for i := range m.hooks {
+		if m.hooks[i].ID == id {
+			hook := m.hooks[i]
+			return &hook, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memEventWebhookStore) List(_ context.Context) ([]model.EventWebhook, error) {
+	return m.hooks, nil
+}
+
+func (m *memEventWebhookStore) SetEnabled(_ context.Context, id int64, enabled bool) error {
+	for i := range m.hooks {
+		if m.hooks[i].ID == id {
+			m.hooks[i].Enabled = enabled
+			return nil
+		}
+	}
+	return driven.ErrEventWebhookNotFound
+}
+
+func (m *memEventWebhookStore) Delete(_ context.Context, id int64) error {
+	for i, h := range m.hooks {
+		if h.ID == id {
+			m.hooks = append(m.hooks[:i], m.hooks[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrEventWebhookNotFound
+}
+
+func (m *memEventWebhookStore) AddDelivery(_ context.Context, d model.WebhookDelivery) (int64, error) {
+	d.ID = int64(len(m.deliveries) + 1)
+	m.deliveries = append(m.deliveries, d)
+	return d.ID, nil
+}
+
+func (m *memEventWebhookStore) UpdateDelivery(_ context.Context, d model.WebhookDelivery) error {
+	m.deliveries[d.ID-1] = d
+	return nil
+}
+
+func (m *memEventWebhookStore) ListDeliveries(_ context.Context, webhookID int64, _ int) ([]model.WebhookDelivery, error) {
+	var out []model.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.WebhookID == webhookID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (m *memEventWebhookStore) ListDueDeliveries(_ context.Context, now time.Time) ([]model.WebhookDelivery, error) {
+	var out []model.WebhookDelivery
+	for _, d := range m.deliveries {
+		if d.Status == model.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (m *memEventWebhookStore) PruneDeliveries(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+// stubEventSender records every delivery and fails with err, if set.
+type stubEventSender struct {
+	err  error
+	sent []model.WebhookDelivery
+	urls []string
+}
+
+func (s *stubEventSender) SendEvent(_ context.Context, url, _ string, d model.WebhookDelivery) (int, error) {
+	s.sent = append(s.sent, d)
+	s.urls = append(s.urls, url)
+	if s.err != nil {
+		return 500, s.err
+	}
+	return 200, nil
+}
+
+func TestEventWebhookService_Create(t *testing.T) {
+	store := &memEventWebhookStore{}
+	svc := NewEventWebhookService(store, &testPRStore{}, &stubEventSender{})
+	ctx := context.Background()
+
+	hook, err := svc.Create(ctx, model.EventWebhook{
+		URL:     " https://ci.example.com/hook ",
+		Events:  []model.WebhookEventType{model.WebhookCheckFailed},
+		Enabled: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), hook.ID)
+	assert.Equal(t, "https://ci.example.com/hook", hook.URL)
+	assert.Len(t, hook.Secret, 64, "a hex-encoded 256-bit secret")
+
+	for name, bad := range map[string]model.EventWebhook{
+		"relative URL":  {URL: "/hook"},
+		"bad scheme":    {URL: "ftp://example.com"},
+		"unknown event": {URL: "https://example.com", Events: []model.WebhookEventType{"pr.exploded"}},
+		"bad repo":      {URL: "https://example.com", RepoFullName: "not a repo"},
+	} {
+		_, err := svc.Create(ctx, bad)
+		assert.ErrorIs(t, err, ErrInvalidEventWebhook, name)
+	}
+}
+
+func TestEventWebhookService_Dispatch(t *testing.T) {
+	store := &memEventWebhookStore{hooks: []model.EventWebhook{
+		{ID: 1, URL: "https://all.example.com", Enabled: true},
+		{ID: 2, URL: "https://checks.example.com", Events: []model.WebhookEventType{model.WebhookCheckFailed}, Enabled: true},
+		{ID: 3, URL: "https://other.example.com", RepoFullName: "acme/web", Enabled: true},
+		{ID: 4, URL: "https://off.example.com", Enabled: false},
+	}}
+	sender := &stubEventSender{}
+	pr := &model.PullRequest{RepoFullName: "acme/api", Number: 7, Title: "Fix it", Author: "alice"}
+	svc := NewEventWebhookService(store, &testPRStore{pr: pr}, sender)
+	ctx := context.Background()
+	at := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	svc.dispatch(ctx, model.PREvent{
+		Type: model.PREventCheckCompleted, RepoFullName: "acme/api", PRNumber: 7, OccurredAt: at,
+		CheckRun: &model.CheckRun{Name: "build", Conclusion: "failure", IsRequired: true},
+	})
+	assert.Equal(t, []string{"https://all.example.com", "https://checks.example.com"}, sender.urls)
+	require.Len(t, store.deliveries, 2)
+	d := store.deliveries[0]
+	assert.Equal(t, model.WebhookDeliveryDelivered, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, 200, d.ResponseStatus)
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal([]byte(d.Payload), &body))
+	assert.Equal(t, "check.failed", body["event"])
+	assert.Equal(t, "2026-10-16T12:00:00Z", body["occurred_at"])
+	assert.Equal(t, "Fix it", body["pull_request"].(map[string]any)["title"], "PR context is loaded from the store")
+	assert.Equal(t, "build", body["check"].(map[string]any)["name"])
+
+	// Passing and canceled checks are not failures.
+	sender.urls = nil
+	svc.dispatch(ctx, model.PREvent{Type: model.PREventCheckCompleted, RepoFullName: "acme/api", PRNumber: 7,
+		CheckRun: &model.CheckRun{Name: "build", Conclusion: "canceled"}})
+	assert.Empty(t, sender.urls)
+}
+
+func TestWebhookEventFor(t *testing.T) {
+	none := &model.AttentionSignals{}
+	failing := &model.AttentionSignals{HasCIFailure: true}
+	stale := &model.AttentionSignals{HasCIFailure: true, HasStaleReview: true}
+
+	tests := []struct {
+		name  string
+		event model.PREvent
+		want  model.WebhookEventType
+	}{
+		{"opened", model.PREvent{Type: model.PREventOpened}, model.WebhookPRCreated},
+		{"review", model.PREvent{Type: model.PREventReviewAdded, Review: &model.Review{State: model.ReviewStateCommented}}, model.WebhookReviewSubmitted},
+		{"starts needing attention", model.PREvent{Type: model.PREventAttentionChanged, Attention: failing, PreviousAttention: none}, model.WebhookPRNeedsAttention},
+		{"new PR needing attention", model.PREvent{Type: model.PREventAttentionChanged, Attention: failing}, model.WebhookPRNeedsAttention},
+		{"already needed attention", model.PREvent{Type: model.PREventAttentionChanged, Attention: stale, PreviousAttention: failing}, ""},
+		{"attention cleared", model.PREvent{Type: model.PREventAttentionChanged, Attention: none, PreviousAttention: failing}, ""},
+		{"timed out check", model.PREvent{Type: model.PREventCheckCompleted, CheckRun: &model.CheckRun{Conclusion: "timed_out"}}, model.WebhookCheckFailed},
+		{"successful check", model.PREvent{Type: model.PREventCheckCompleted, CheckRun: &model.CheckRun{Conclusion: "success"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := webhookEventFor(tt.event)
+			assert.Equal(t, tt.want != "", ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEventWebhookService_RetriesWithBackoff(t *testing.T) {
+	store := &memEventWebhookStore{hooks: []model.EventWebhook{{ID: 1, URL: "https://down.example.com", Enabled: true}}}
+	sender := &stubEventSender{err: errors.New("webhook: unexpected status 500")}
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	svc := NewEventWebhookService(store, &testPRStore{}, sender)
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	svc.dispatch(ctx, model.PREvent{Type: model.PREventOpened, RepoFullName: "acme/api", PRNumber: 7,
+		PullRequest: &model.PullRequest{RepoFullName: "acme/api", Number: 7}})
+	require.Len(t, store.deliveries, 1)
+	d := store.deliveries[0]
+	assert.Equal(t, model.WebhookDeliveryPending, d.Status)
+	assert.Equal(t, 500, d.ResponseStatus)
+	assert.Equal(t, "webhook: unexpected status 500", d.LastError)
+	assert.Equal(t, now.Add(webhookBaseBackoff), d.NextAttemptAt)
+
+	// Not due yet.
+	svc.processDue(ctx)
+	assert.Len(t, sender.sent, 1)
+
+	for range webhookMaxAttempts - 1 {
+		now = now.Add(webhookMaxBackoff)
+		svc.processDue(ctx)
+	}
+	d = store.deliveries[0]
+	assert.Equal(t, webhookMaxAttempts, d.Attempts)
+	assert.Equal(t, model.WebhookDeliveryFailed, d.Status)
+	assert.Equal(t, d.Payload, sender.sent[len(sender.sent)-1].Payload, "retries resend the stored payload")
+
+	// A recovered endpoint gets pending deliveries on the next pass; a
+	// paused one keeps them.
+	store.deliveries[0].Status = model.WebhookDeliveryPending
+	require.NoError(t, svc.SetEnabled(ctx, 1, false))
+	sender.err = nil
+	sent := len(sender.sent)
+	svc.processDue(ctx)
+	assert.Len(t, sender.sent, sent)
+	require.NoError(t, svc.SetEnabled(ctx, 1, true))
+	svc.processDue(ctx)
+	assert.Equal(t, model.WebhookDeliveryDelivered, store.deliveries[0].Status)
+	assert.Equal(t, now, store.deliveries[0].DeliveredAt)
+}
+
+func TestEventWebhookService_Deliveries(t *testing.T) {
+	store := &memEventWebhookStore{hooks: []model.EventWebhook{{ID: 1, URL: "https://example.com", Enabled: true}}}
+	svc := NewEventWebhookService(store, &testPRStore{}, &stubEventSender{})
+
+	_, err := svc.Deliveries(context.Background(), 9)
+	assert.ErrorIs(t, err, driven.ErrEventWebhookNotFound)
+	got, err := svc.Deliveries(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, retryBackoff(1, webhookBaseBackoff, webhookMaxBackoff))
+	assert.Equal(t, 4*time.Minute, retryBackoff(4, webhookBaseBackoff, webhookMaxBackoff))
+	assert.Equal(t, 30*time.Minute, retryBackoff(8, webhookBaseBackoff, webhookMaxBackoff))
+}
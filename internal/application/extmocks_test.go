@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
 // mockReviewStore records review-store write calls and returns configurable
@@ -51,12 +52,32 @@ func (m *mockReviewStore) GetReviewsByPR(_ context.Context, _ int64) ([]model.Re
 	return m.stubReviews, m.stubErr
 }
 
+func (m *mockReviewStore) GetReviewsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.Review, error) {
+	result := make(map[int64][]model.Review, len(prIDs))
+	for _, id := range prIDs {
+		reviews, err := m.GetReviewsByPR(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = reviews
+	}
+	return result, nil
+}
+
 func (m *mockReviewStore) GetReviewCommentsByPR(_ context.Context, _ int64) ([]model.ReviewComment, error) {
 	return nil, nil
 }
 
-func (m *mockReviewStore) GetIssueCommentsByPR(_ context.Context, _ int64) ([]model.IssueComment, error) {
-	return nil, nil
+func (m *mockReviewStore) GetIssueCommentsByPR(_ context.Context, prID int64) ([]model.IssueComment, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var comments []model.IssueComment
+	for _, c := range m.upsertedIssueComments {
+		if c.PRID == prID {
+			comments = append(comments, c)
+		}
+	}
+	return comments, nil
 }
 
 func (m *mockReviewStore) UpdateCommentResolution(_ context.Context, commentID int64, isResolved bool) error {
@@ -93,3 +114,6 @@ func (*noopPRStoreMixin) ListNeedingReview(_ context.Context) ([]model.PullReque
 func (*noopPRStoreMixin) ListIgnoredWithPRData(_ context.Context) ([]model.PullRequest, error) {
 	return nil, nil
 }
+func (*noopPRStoreMixin) CountByStatus(_ context.Context) ([]driven.PRStatusCount, error) {
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithClosedPRLookback limits each poll to the merged and closed PRs updated
+// within lookback, so a repository's first sync does not page through its
+// whole history. Open PRs are always fetched. Zero, the default, fetches
+// every PR, as do clients that cannot list a window.
+func (s *PollService) WithClosedPRLookback(lookback time.Duration) *PollService {
+	s.closedLookback = lookback
+	return s
+}
+
+// RefreshRepoFullHistory polls a repository like RefreshRepo, fetching its
+// closed PRs past the lookback window too. If the poll fails, the next one
+// tries again.
+func (s *PollService) RefreshRepoFullHistory(ctx context.Context, repoFullName string) error {
+	slog.Info("full history refresh requested", "repo", repoFullName)
+
+	done := make(chan error, 1)
+	req := refreshRequest{
+		repoFullName: repoFullName,
+		fullHistory:  true,
+		done:         done,
+	}
+
+	select {
+	case s.refreshCh <- req:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetchPRs lists the repository's PRs for a poll: the open PRs and the
+// closed PRs within the lookback window, or all of them when there is no
+// window or a full history fetch is pending.
+func (s *PollService) fetchPRs(ctx context.Context, gh driven.GitHubClient, repoFullName string) ([]model.PullRequest, error) {
+	windowed, ok := gh.(driven.PRWindowFetcher)
+	if !ok || s.closedLookback <= 0 || s.fullHistory[repoFullName] {
+		prs, err := gh.FetchPullRequests(ctx, repoFullName, "all")
+		if err == nil {
+			delete(s.fullHistory, repoFullName)
+		}
+		return prs, err
+	}
+	return windowed.FetchPullRequestsSince(ctx, repoFullName, time.Now().Add(-s.closedLookback))
+}
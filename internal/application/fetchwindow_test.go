@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// windowClient records which PR listing a poll used.
+type windowClient struct {
+	driven.GitHubClient
+	calls []string
+	since time.Time
+}
+
+func (c *windowClient) FetchPullRequests(_ context.Context, _ string, state string) ([]model.PullRequest, error) {
+	c.calls = append(c.calls, state)
+	return nil, nil
+}
+
+func (c *windowClient) FetchPullRequestsSince(_ context.Context, _ string, since time.Time) ([]model.PullRequest, error) {
+	c.calls = append(c.calls, "since")
+	c.since = since
+	return nil, nil
+}
+
+func TestFetchPRs_ClosedLookback(t *testing.T) {
+	client := &windowClient{}
+	svc := &PollService{fullHistory: make(map[string]bool)}
+
+	_, err := svc.fetchPRs(t.Context(), client, "o/r")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all"}, client.calls, "no window by default")
+
+	svc.WithClosedPRLookback(90 * 24 * time.Hour)
+	client.calls = nil
+	_, err = svc.fetchPRs(t.Context(), client, "o/r")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"since"}, client.calls)
+	assert.WithinDuration(t, time.Now().Add(-90*24*time.Hour), client.since, time.Minute)
+
+	svc.fullHistory["o/r"] = true
+	client.calls = nil
+	_, err = svc.fetchPRs(t.Context(), client, "o/r")
+	require.NoError(t, err)
+	_, err = svc.fetchPRs(t.Context(), client, "o/r")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"all", "since"}, client.calls, "a full history fetch happens once")
+}
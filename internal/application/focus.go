@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// DefaultFocusSize is how many PRs the daily focus list shows unless asked
+// for more.
+const DefaultFocusSize = 5
+
+// focusSkipWindowDays is how many earlier days of skips lower a PR's rank.
+const focusSkipWindowDays = 7
+
+// FocusCandidate is a PR considered for the daily focus list with its
+// attention signals.
+type FocusCandidate struct {
+	PR      model.PullRequest
+	Signals model.AttentionSignals
+}
+
+// FocusEntry is one PR on the daily focus list.
+type FocusEntry struct {
+	PR      model.PullRequest
+	Signals model.AttentionSignals
+	// Score is the PR's attention severity, plus one for a pending review
+	// request, less one per day it was skipped in the past week.
+	Score int
+	// Skipped marks a PR skipped today; skipped PRs rank after the rest.
+	Skipped bool
+}
+
+// FocusList is the user's daily focus list.
+type FocusList struct {
+	Day     string // YYYY-MM-DD, see model.FocusDay
+	Entries []FocusEntry
+	Done    int // PRs marked done today that have not changed since
+	More    int // eligible PRs beyond the requested size
+}
+
+// FocusService builds the daily focus list, a short ordered queue of the
+// PRs most worth acting on, and records the user's done and skip marks,
+// which feed back into the order.
+type FocusService struct {
+	store driven.FocusStore
+}
+
+// NewFocusService creates a new FocusService.
+func NewFocusService(store driven.FocusStore) *FocusService {
+	return &FocusService{store: store}
+}
+
+// Mark records state for the PR on today's list.
+func (s *FocusService) Mark(ctx context.Context, prID int64, state model.FocusState, now time.Time) error {
+	if !state.IsValid() {
+		return fmt.Errorf("invalid focus state %q", state)
+	}
+	return s.store.SetFocusMark(ctx, model.FocusMark{PRID: prID, Day: model.FocusDay(now), State: state, MarkedAt: now})
+}
+
+// Clear removes the PR's mark from today's list, returning it to its rank.
+func (s *FocusService) Clear(ctx context.Context, prID int64, now time.Time) error {
+	return s.store.ClearFocusMark(ctx, prID, model.FocusDay(now))
+}
+
+// Today picks up to size PRs from candidates for username's focus list of
+// the day now falls on; see BuildFocusList.
+func (s *FocusService) Today(ctx context.Context, candidates []FocusCandidate, username string, size int, now time.Time) (FocusList, error) {
+	today := model.FocusDay(now)
+	marks, err := s.store.ListFocusMarks(ctx, model.FocusDay(now.AddDate(0, 0, -focusSkipWindowDays)))
+	if err != nil {
+		return FocusList{Day: today}, fmt.Errorf("list focus marks: %w", err)
+	}
+	return BuildFocusList(candidates, marks, username, size, now), nil
+}
+
+// BuildFocusList ranks the open PRs among candidates that need the user,
+// those with a pending review request or an attention signal, and keeps the
+// first size. Drafts count only when the user wrote them. PRs marked done
+// today are left out unless they changed afterwards. The rest are ordered
+// by skipped today last, then highest score, then oldest review request,
+// falling back to when the PR was opened.
+func BuildFocusList(candidates []FocusCandidate, marks []model.FocusMark, username string, size int, now time.Time) FocusList {
+	today := model.FocusDay(now)
+	todays := make(map[int64]model.FocusMark)
+	pastSkips := make(map[int64]int)
+	for _, m := range marks {
+		switch {
+		case m.Day == today:
+			todays[m.PRID] = m
+		case m.Day < today && m.State == model.FocusSkipped:
+			pastSkips[m.PRID]++
+		}
+	}
+
+	list := FocusList{Day: today}
+	waitingSince := make(map[int64]time.Time)
+	for _, c := range candidates {
+		pr := c.PR
+		if pr.Status != model.PRStatusOpen || (pr.IsDraft && pr.Author != username) {
+			continue
+		}
+		if !pr.NeedsReview && !c.Signals.HasAny() {
+			continue
+		}
+		mark, marked := todays[pr.ID]
+		if marked && mark.State == model.FocusDone && !pr.UpdatedAt.After(mark.MarkedAt) {
+			list.Done++
+			continue
+		}
+
+		entry := FocusEntry{PR: pr, Signals: c.Signals, Score: c.Signals.Severity() - pastSkips[pr.ID]}
+		if pr.NeedsReview {
+			entry.Score++
+		}
+		entry.Skipped = marked && mark.State == model.FocusSkipped
+		list.Entries = append(list.Entries, entry)
+
+		waitingSince[pr.ID] = pr.OpenedAt
+		if at, ok := pr.ReviewRequestedAt(username); ok {
+			waitingSince[pr.ID] = at
+		}
+	}
+
+	sort.SliceStable(list.Entries, func(i, j int) bool {
+		a, b := list.Entries[i], list.Entries[j]
+		if a.Skipped != b.Skipped {
+			return !a.Skipped
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return waitingSince[a.PR.ID].Before(waitingSince[b.PR.ID])
+	})
+
+	if size > 0 && len(list.Entries) > size {
+		list.More = len(list.Entries) - size
+		list.Entries = list.Entries[:size]
+	}
+	return list
+}
@@ -0,0 +1,69 @@
+package application_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestBuildFocusList(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 0, 0, 0, time.Local)
+	today := model.FocusDay(now)
+	yesterday := model.FocusDay(now.AddDate(0, 0, -1))
+	open := func(id int64, days int) model.PullRequest {
+		return model.PullRequest{ID: id, Status: model.PRStatusOpen, OpenedAt: now.AddDate(0, 0, -days), UpdatedAt: now.Add(-48 * time.Hour)}
+	}
+	requested := func(pr model.PullRequest, hoursAgo int) model.PullRequest {
+		pr.NeedsReview = true
+		pr.ReviewRequests = []model.ReviewRequest{{Reviewer: testAuthor, RequestedAt: now.Add(-time.Duration(hoursAgo) * time.Hour)}}
+		return pr
+	}
+
+	urgent := model.AttentionSignals{IsAgeUrgent: true, NeedsMoreReviews: true}
+	changedSinceDone := requested(open(6, 1), 5)
+	changedSinceDone.UpdatedAt = now.Add(-time.Minute)
+	draft := requested(open(8, 1), 5)
+	draft.IsDraft = true
+	closed := requested(open(9, 1), 5)
+	closed.Status = model.PRStatusMerged
+	candidates := []application.FocusCandidate{
+		{PR: requested(open(1, 1), 2)},                   // score 1, requested 2h ago
+		{PR: requested(open(2, 20), 30)},                 // score 1, requested 30h ago
+		{PR: open(3, 10), Signals: urgent},               // score 2
+		{PR: requested(open(4, 10), 1), Signals: urgent}, // score 3, skipped today
+		{PR: requested(open(5, 1), 5)},                   // done today
+		{PR: changedSinceDone},                           // done today, changed since
+		{PR: open(7, 1)},                                 // nothing to do
+		{PR: draft},
+		{PR: closed},
+		{PR: requested(open(10, 1), 50), Signals: urgent}, // score 1 after two earlier skips
+	}
+	marks := []model.FocusMark{
+		{PRID: 10, Day: model.FocusDay(now.AddDate(0, 0, -2)), State: model.FocusSkipped},
+		{PRID: 10, Day: yesterday, State: model.FocusSkipped},
+		{PRID: 4, Day: today, State: model.FocusSkipped, MarkedAt: now.Add(-time.Hour)},
+		{PRID: 5, Day: today, State: model.FocusDone, MarkedAt: now.Add(-time.Hour)},
+		{PRID: 6, Day: today, State: model.FocusDone, MarkedAt: now.Add(-time.Hour)},
+		{PRID: 1, Day: yesterday, State: model.FocusDone},
+	}
+
+	list := application.BuildFocusList(candidates, marks, testAuthor, 0, now)
+
+	ids := make([]int64, len(list.Entries))
+	for i, e := range list.Entries {
+		ids[i] = e.PR.ID
+	}
+	assert.Equal(t, []int64{3, 10, 2, 6, 1, 4}, ids)
+	assert.Equal(t, today, list.Day)
+	assert.Equal(t, 1, list.Done)
+	assert.True(t, list.Entries[5].Skipped)
+	assert.Equal(t, 1, list.Entries[1].Score, "each earlier skip costs a point")
+
+	list = application.BuildFocusList(candidates, marks, testAuthor, 2, now)
+	assert.Len(t, list.Entries, 2)
+	assert.Equal(t, 4, list.More)
+}
@@ -53,6 +53,12 @@ func (s *HealthService) GetPRHealthSummary(ctx context.Context, prID int64, repo
 	}, nil
 }
 
+// CheckRunsByPR returns the stored check runs of each of prIDs, loaded in
+// one query. PRs without check runs are absent.
+func (s *HealthService) CheckRunsByPR(ctx context.Context, prIDs []int64) (map[int64][]model.CheckRun, error) {
+	return s.checkStore.GetCheckRunsByPRs(ctx, prIDs)
+}
+
 // computeCombinedCIStatus aggregates check runs from the Checks API and the
 // combined status from the Status API into a single CIStatus value.
 // Priority: failing > pending > passing > unknown.
@@ -0,0 +1,234 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// maxIgnoreRules bounds the rules evaluated for every new PR.
+const maxIgnoreRules = 50
+
+// ErrInvalidIgnoreRule is returned for a rule with a missing name or an
+// expression that does not compile or uses an attention signal.
+var ErrInvalidIgnoreRule = errors.New("invalid ignore rule")
+
+// IgnoreRuleService manages user-defined ignore rules and applies them: a PR
+// an enabled rule matches is added to the ignore list with the rule's name as
+// the reason. Rules are applied to PRs opened after them and, whenever a rule
+// is created or changed, to every PR in the queue. A matched PR that is
+// unignored stays in the queue until the rules next change.
+type IgnoreRuleService struct {
+	store    driven.IgnoreRuleStore
+	ignores  driven.IgnoreStore
+	prStore  driven.PRStore
+	username string
+	logger   *slog.Logger
+}
+
+// NewIgnoreRuleService creates an IgnoreRuleService. username is what `me`
+// means in rule expressions.
+func NewIgnoreRuleService(store driven.IgnoreRuleStore, ignores driven.IgnoreStore, prStore driven.PRStore, username string) *IgnoreRuleService {
+	return &IgnoreRuleService{store: store, ignores: ignores, prStore: prStore, username: username, logger: slog.Default()}
+}
+
+// Validate compiles expr without storing anything. It returns an error
+// wrapping ErrInvalidIgnoreRule that describes the first problem. Attention
+// signals are computed after ignoring, so expressions cannot use them.
+func (s *IgnoreRuleService) Validate(expr string) error {
+	if _, err := CompileRuleExpr(expr); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidIgnoreRule, err)
+	}
+	tokens, _ := lexRuleExpr(expr)
+	for _, tok := range tokens {
+		if tok.kind == tokIdent && model.AttentionSignal(tok.text).Valid() {
+			return fmt.Errorf("%w: attention signal %s at %d cannot be used in an ignore rule", ErrInvalidIgnoreRule, tok.text, tok.pos)
+		}
+	}
+	return nil
+}
+
+// validate normalizes and checks rule.
+func (s *IgnoreRuleService) validate(rule *model.IgnoreRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Expression = strings.TrimSpace(rule.Expression)
+
+	if rule.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidIgnoreRule)
+	}
+	if len(rule.Name) > maxRuleNameLen {
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidIgnoreRule, maxRuleNameLen)
+	}
+	return s.Validate(rule.Expression)
+}
+
+// List returns all rules, enabled or not.
+func (s *IgnoreRuleService) List(ctx context.Context) ([]model.IgnoreRule, error) {
+	return s.store.List(ctx)
+}
+
+// Create validates and stores a rule, then ignores the queued PRs it matches.
+// It returns ErrInvalidIgnoreRule for a bad rule or when the rule limit is
+// reached.
+func (s *IgnoreRuleService) Create(ctx context.Context, rule model.IgnoreRule) (model.IgnoreRule, error) {
+	if err := s.validate(&rule); err != nil {
+		return model.IgnoreRule{}, err
+	}
+	existing, err := s.store.List(ctx)
+	if err != nil {
+		return model.IgnoreRule{}, err
+	}
+	if len(existing) >= maxIgnoreRules {
+		return model.IgnoreRule{}, fmt.Errorf("%w: at most %d rules", ErrInvalidIgnoreRule, maxIgnoreRules)
+	}
+
+	rule.CreatedAt = time.Now().UTC()
+	id, err := s.store.Create(ctx, rule)
+	if err != nil {
+		return model.IgnoreRule{}, err
+	}
+	rule.ID = id
+	s.sweep(ctx)
+	return rule, nil
+}
+
+// Update validates and replaces a rule, then ignores the queued PRs the rules
+// now match. It returns ErrInvalidIgnoreRule for a bad rule and
+// driven.ErrIgnoreRuleNotFound if it does not exist.
+func (s *IgnoreRuleService) Update(ctx context.Context, rule model.IgnoreRule) error {
+	if err := s.validate(&rule); err != nil {
+		return err
+	}
+	if err := s.store.Update(ctx, rule); err != nil {
+		return err
+	}
+	s.sweep(ctx)
+	return nil
+}
+
+// Delete removes a rule. PRs it ignored stay ignored. It returns
+// driven.ErrIgnoreRuleNotFound if it does not exist.
+func (s *IgnoreRuleService) Delete(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Apply ignores every queued PR an enabled rule matches and returns how many
+// it ignored.
+func (s *IgnoreRuleService) Apply(ctx context.Context) (int, error) {
+	rules, err := s.compiled(ctx)
+	if err != nil || len(rules) == 0 {
+		return 0, err
+	}
+	prs, err := s.prStore.ListAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list PRs: %w", err)
+	}
+
+	ignored := 0
+	for _, pr := range prs {
+		ok, err := s.apply(ctx, rules, pr)
+		if err != nil {
+			return ignored, err
+		}
+		if ok {
+			ignored++
+		}
+	}
+	return ignored, nil
+}
+
+// Run applies the rules to each PR opened after them, as pr.opened events
+// are published on hub, until ctx is canceled.
+func (s *IgnoreRuleService) Run(ctx context.Context, hub *EventHub) {
+	events, cancel := hub.Subscribe(EventFilter{Types: []model.PREventType{model.PREventOpened}})
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			s.applyOpened(ctx, e)
+		}
+	}
+}
+
+// applyOpened applies the rules to the PR of a pr.opened event.
+func (s *IgnoreRuleService) applyOpened(ctx context.Context, e model.PREvent) {
+	if e.PullRequest == nil {
+		return
+	}
+	rules, err := s.compiled(ctx)
+	if err != nil {
+		s.logger.Warn("failed to list ignore rules", "error", err)
+		return
+	}
+	if _, err := s.apply(ctx, rules, *e.PullRequest); err != nil {
+		s.logger.Warn("failed to apply ignore rules", "repo", e.RepoFullName, "pr", e.PRNumber, "error", err)
+	}
+}
+
+// sweep applies the rules to the queue after a rule change. The change is
+// already stored, so a failure is logged rather than returned.
+func (s *IgnoreRuleService) sweep(ctx context.Context) {
+	n, err := s.Apply(ctx)
+	if err != nil {
+		s.logger.Warn("failed to apply ignore rules", "error", err)
+		return
+	}
+	if n > 0 {
+		s.logger.Info("ignore rules applied", "ignored", n)
+	}
+}
+
+// compiledIgnoreRule is an enabled ignore rule with its parsed expression.
+type compiledIgnoreRule struct {
+	rule model.IgnoreRule
+	expr *RuleExpr
+}
+
+// compiled returns the enabled rules with their parsed expressions. Stored
+// rules that no longer compile are logged and skipped.
+func (s *IgnoreRuleService) compiled(ctx context.Context) ([]compiledIgnoreRule, error) {
+	stored, err := s.store.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list ignore rules: %w", err)
+	}
+	var compiled []compiledIgnoreRule
+	for _, rule := range stored {
+		if !rule.Enabled {
+			continue
+		}
+		expr, err := CompileRuleExpr(rule.Expression)
+		if err != nil {
+			s.logger.Warn("skipping ignore rule that does not compile", "rule", rule.ID, "error", err)
+			continue
+		}
+		compiled = append(compiled, compiledIgnoreRule{rule: rule, expr: expr})
+	}
+	return compiled, nil
+}
+
+// apply ignores pr under the first rule it matches, reporting whether it did.
+func (s *IgnoreRuleService) apply(ctx context.Context, rules []compiledIgnoreRule, pr model.PullRequest) (bool, error) {
+	in := RuleInput{PR: pr, Me: s.username}
+	for _, c := range rules {
+		if !c.expr.Match(in) {
+			continue
+		}
+		if err := s.ignores.Ignore(ctx, pr.ID, "rule: "+c.rule.Name); err != nil {
+			return false, fmt.Errorf("ignore %s#%d: %w", pr.RepoFullName, pr.Number, err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
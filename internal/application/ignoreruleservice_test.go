@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memIgnoreRuleStore is an in-memory IgnoreRuleStore.
+type memIgnoreRuleStore struct {
+	rules []model.IgnoreRule
+}
+
+func (m *memIgnoreRuleStore) Create(_ context.Context, rule model.IgnoreRule) (int64, error) {
+	rule.ID = int64(len(m.rules) + 1)
+	m.rules = append(m.rules, rule)
+	return rule.ID, nil
+}
+
+func (m *memIgnoreRuleStore) Update(_ context.Context, rule model.IgnoreRule) error {
+	for i, r := range m.rules {
+		if r.ID == rule.ID {
+			m.rules[i] = rule
+			return nil
+		}
+	}
+	return driven.ErrIgnoreRuleNotFound
+}
+
+func (m *memIgnoreRuleStore) Delete(_ context.Context, id int64) error {
+	for i, r := range m.rules {
+		if r.ID == id {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrIgnoreRuleNotFound
+}
+
+func (m *memIgnoreRuleStore) List(_ context.Context) ([]model.IgnoreRule, error) {
+	return append([]model.IgnoreRule(nil), m.rules...), nil
+}
+
+// memIgnoreStore is an in-memory IgnoreStore recording each PR's reason.
+type memIgnoreStore struct {
+	reasons map[int64]string
+}
+
+func (m *memIgnoreStore) Ignore(_ context.Context, prID int64, reason string) error {
+	if m.reasons == nil {
+		m.reasons = make(map[int64]string)
+	}
+	m.reasons[prID] = reason
+	return nil
+}
+
+func (m *memIgnoreStore) Unignore(_ context.Context, prID int64) error {
+	delete(m.reasons, prID)
+	return nil
+}
+
+func (m *memIgnoreStore) IsIgnored(_ context.Context, prID int64) (bool, error) {
+	_, ok := m.reasons[prID]
+	return ok, nil
+}
+
+func (m *memIgnoreStore) ListIgnored(context.Context) ([]driven.IgnoredPR, error) { return nil, nil }
+
+func (m *memIgnoreStore) ListIgnoredIDs(context.Context) (map[int64]struct{}, error) {
+	return nil, nil
+}
+
+func TestIgnoreRuleService_Create(t *testing.T) {
+	svc := NewIgnoreRuleService(&memIgnoreRuleStore{}, &memIgnoreStore{}, &testPRStore{}, "alice")
+	ctx := context.Background()
+
+	rule, err := svc.Create(ctx, model.IgnoreRule{Name: " Bots ", Expression: `author =~ "\\[bot\\]$"`, Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Bots", rule.Name)
+	assert.NotZero(t, rule.ID)
+
+	for _, bad := range []model.IgnoreRule{
+		{Name: "", Expression: "draft"},
+		{Name: "x", Expression: "drafty"},
+		{Name: "x", Expression: "draft && ci_failure"},
+	} {
+		_, err := svc.Create(ctx, bad)
+		assert.ErrorIs(t, err, ErrInvalidIgnoreRule, "%+v", bad)
+	}
+
+	err = svc.Validate(`draft || stale_review`)
+	require.ErrorIs(t, err, ErrInvalidIgnoreRule)
+	assert.Contains(t, err.Error(), "attention signal stale_review at 10")
+	assert.ErrorIs(t, svc.Delete(ctx, 99), driven.ErrIgnoreRuleNotFound)
+}
+
+func TestIgnoreRuleService_AppliesRules(t *testing.T) {
+	prs := &testPRStore{prs: []model.PullRequest{
+		{ID: 1, RepoFullName: "org/repo", Number: 1, Author: "dependabot[bot]"},
+		{ID: 2, RepoFullName: "org/repo", Number: 2, Author: "alice", Labels: []string{"wip"}},
+		{ID: 3, RepoFullName: "org/repo", Number: 3, Author: "bob"},
+	}}
+	ignores := &memIgnoreStore{}
+	svc := NewIgnoreRuleService(&memIgnoreRuleStore{}, ignores, prs, "alice")
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, model.IgnoreRule{Name: "Bots", Expression: `author =~ "\\[bot\\]$"`, Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]string{1: "rule: Bots"}, ignores.reasons, "creating a rule applies it to the queue")
+
+	mine, err := svc.Create(ctx, model.IgnoreRule{Name: "My drafts", Expression: `author == me && labels contains "wip"`})
+	require.NoError(t, err)
+	assert.NotContains(t, ignores.reasons, int64(2), "disabled rules are not applied")
+
+	mine.Enabled = true
+	require.NoError(t, svc.Update(ctx, mine))
+	assert.Equal(t, "rule: My drafts", ignores.reasons[2])
+	assert.NotContains(t, ignores.reasons, int64(3))
+}
+
+func TestIgnoreRuleService_AppliesToOpenedPRs(t *testing.T) {
+	ignores := &memIgnoreStore{}
+	svc := NewIgnoreRuleService(&memIgnoreRuleStore{}, ignores, &testPRStore{}, "alice")
+	ctx := context.Background()
+	_, err := svc.Create(ctx, model.IgnoreRule{Name: "Bots", Expression: `author == "renovate[bot]"`, Enabled: true})
+	require.NoError(t, err)
+
+	opened := func(pr model.PullRequest) model.PREvent {
+		return model.PREvent{Type: model.PREventOpened, RepoFullName: pr.RepoFullName, PRNumber: pr.Number, PullRequest: &pr}
+	}
+	svc.applyOpened(ctx, opened(model.PullRequest{ID: 7, RepoFullName: "org/repo", Number: 7, Author: "bob"}))
+	svc.applyOpened(ctx, opened(model.PullRequest{ID: 8, RepoFullName: "org/repo", Number: 8, Author: "renovate[bot]"}))
+	svc.applyOpened(ctx, model.PREvent{Type: model.PREventOpened, RepoFullName: "org/repo", PRNumber: 9})
+
+	assert.Equal(t, map[int64]string{8: "rule: Bots"}, ignores.reasons)
+}
@@ -0,0 +1,246 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// inboxSummaryLen bounds comment excerpts stored with inbox events.
+const inboxSummaryLen = 140
+
+// InboxService turns PR events into attention inbox entries: review requests
+// for the user, and CI failures, comments, and approvals on the user's own
+// PRs. Watch states adjust that scope: watched PRs are treated like the user's
+// own and review requests on unwatched PRs are skipped. It listens to the
+// EventHub, so it only sees what the poll loop publishes.
+type InboxService struct {
+	store    driven.InboxStore
+	prStore  driven.PRStore
+	username string
+	watches  driven.WatchStore // optional; every PR has the default watch state when nil
+
+	// requested remembers, per PR ID, whether the PR last asked for the
+	// user's review, so a re-request is told apart from one already
+	// recorded. Only the Run goroutine touches it.
+	requested map[int64]bool
+}
+
+// NewInboxService creates an InboxService for username.
+func NewInboxService(store driven.InboxStore, prStore driven.PRStore, username string) *InboxService {
+	return &InboxService{
+		store:     store,
+		prStore:   prStore,
+		username:  username,
+		requested: make(map[int64]bool),
+	}
+}
+
+// WithWatchStore makes the inbox follow per-PR watch states.
+func (s *InboxService) WithWatchStore(store driven.WatchStore) *InboxService {
+	s.watches = store
+	return s
+}
+
+// List returns inbox events newest first, at most limit.
+func (s *InboxService) List(ctx context.Context, unreadOnly bool, limit int) ([]model.InboxEvent, error) {
+	return s.store.List(ctx, unreadOnly, limit)
+}
+
+// UnreadCount returns the number of unread inbox events.
+func (s *InboxService) UnreadCount(ctx context.Context) (int, error) {
+	return s.store.UnreadCount(ctx)
+}
+
+// SetRead marks one inbox event read or unread.
+func (s *InboxService) SetRead(ctx context.Context, id int64, read bool) error {
+	return s.store.SetRead(ctx, id, read)
+}
+
+// MarkAllRead marks every inbox event read.
+func (s *InboxService) MarkAllRead(ctx context.Context) error {
+	return s.store.MarkAllRead(ctx)
+}
+
+// Run records inbox events for PR events published on hub until ctx is canceled.
+func (s *InboxService) Run(ctx context.Context, hub *EventHub) {
+	events, cancel := hub.Subscribe(EventFilter{Types: []model.PREventType{
+		model.PREventUpdated, model.PREventReviewAdded, model.PREventCheckCompleted, model.PREventCommentAdded,
+	}})
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			s.record(ctx, e)
+		}
+	}
+}
+
+// record adds the inbox event e calls for, if any.
+func (s *InboxService) record(ctx context.Context, e model.PREvent) {
+	if e.Type == model.PREventUpdated {
+		if e.PullRequest != nil {
+			s.recordReviewRequest(ctx, *e.PullRequest, e.OccurredAt)
+		}
+		return
+	}
+
+	// The remaining events concern the user's own and watched PRs.
+	pr, err := s.prStore.GetByNumber(ctx, e.RepoFullName, e.PRNumber)
+	if err != nil || pr == nil {
+		slog.Warn("failed to load PR for inbox", "repo", e.RepoFullName, "pr", e.PRNumber, "error", err)
+		return
+	}
+	if !strings.EqualFold(pr.Author, s.username) && s.watchState(ctx, pr.ID) != model.WatchStateWatching {
+		return
+	}
+
+	entry, ok := s.ownPREntry(e)
+	if !ok {
+		return
+	}
+	entry.RepoFullName = pr.RepoFullName
+	entry.PRNumber = pr.Number
+	entry.PRTitle = pr.Title
+	entry.OccurredAt = e.OccurredAt
+	s.add(ctx, entry)
+}
+
+// recordReviewRequest adds review_requested when pr starts asking for the
+// user's review, unless the user unwatched it. The first request for a PR
+// uses an empty source ID, which also keeps a restart from recording a
+// request already in the inbox; a re-request observed while running gets its
+// own entry.
+func (s *InboxService) recordReviewRequest(ctx context.Context, pr model.PullRequest, at time.Time) {
+	requested := pr.NeedsReview && pr.Status == model.PRStatusOpen && !strings.EqualFold(pr.Author, s.username) &&
+		s.watchState(ctx, pr.ID) != model.WatchStateUnwatched
+	was, known := s.requested[pr.ID]
+	s.requested[pr.ID] = requested
+	if !requested || was {
+		return
+	}
+
+	entry := model.InboxEvent{
+		Kind:         model.InboxReviewRequested,
+		RepoFullName: pr.RepoFullName,
+		PRNumber:     pr.Number,
+		PRTitle:      pr.Title,
+		Actor:        pr.Author,
+		OccurredAt:   at,
+	}
+	if s.add(ctx, entry) || !known {
+		return
+	}
+	entry.SourceID = at.UTC().Format(time.RFC3339Nano)
+	s.add(ctx, entry)
+}
+
+// watchState returns the user's watch state for a PR. Failures are logged
+// and treated as the default state.
+func (s *InboxService) watchState(ctx context.Context, prID int64) model.WatchState {
+	if s.watches == nil {
+		return model.WatchStateDefault
+	}
+	state, err := s.watches.GetWatchState(ctx, prID)
+	if err != nil {
+		slog.Warn("failed to load watch state for inbox", "pr_id", prID, "error", err)
+		return model.WatchStateDefault
+	}
+	return state
+}
+
+// ownPREntry maps a review, check, or comment event on the user's own PR to
+// an inbox entry without PR context. ok is false when e is not inbox-worthy.
+func (s *InboxService) ownPREntry(e model.PREvent) (entry model.InboxEvent, ok bool) {
+	switch {
+	case e.Review != nil:
+		r := e.Review
+		if r.IsBot || strings.EqualFold(r.ReviewerLogin, s.username) {
+			return entry, false
+		}
+		entry = model.InboxEvent{Actor: r.ReviewerLogin, SourceID: "review-" + strconv.FormatInt(r.ID, 10)}
+		switch r.State {
+		case model.ReviewStateApproved:
+			entry.Kind = model.InboxApprovalReceived
+			entry.Summary = excerpt(r.Body)
+		case model.ReviewStateChangesRequested:
+			entry.Kind = model.InboxNewComment
+			entry.Summary = "Requested changes"
+			if body := excerpt(r.Body); body != "" {
+				entry.Summary += ": " + body
+			}
+		case model.ReviewStateCommented:
+			entry.Kind = model.InboxNewComment
+			entry.Summary = excerpt(r.Body)
+			if entry.Summary == "" {
+				entry.Summary = "Left review comments"
+			}
+		default:
+			return entry, false
+		}
+		return entry, true
+
+	case e.CheckRun != nil:
+		// Canceled runs are usually superseded by a newer push, not failures.
+		switch e.CheckRun.Conclusion {
+		case "failure", "timed_out", "action_required":
+		default:
+			return entry, false
+		}
+		return model.InboxEvent{
+			Kind:     model.InboxCIFailed,
+			Summary:  e.CheckRun.Name + " " + strings.ReplaceAll(e.CheckRun.Conclusion, "_", " "),
+			SourceID: "check-" + strconv.FormatInt(e.CheckRun.ID, 10),
+		}, true
+
+	case e.Comment != nil:
+		c := e.Comment
+		if c.IsBot || strings.EqualFold(c.Author, s.username) {
+			return entry, false
+		}
+		return model.InboxEvent{
+			Kind:     model.InboxNewComment,
+			Actor:    c.Author,
+			Summary:  excerpt(c.Body),
+			SourceID: "comment-" + strconv.FormatInt(c.ID, 10),
+		}, true
+	}
+	return entry, false
+}
+
+// add stores entry and reports whether it was new. Failures are logged.
+func (s *InboxService) add(ctx context.Context, entry model.InboxEvent) bool {
+	added, err := s.store.Add(ctx, entry)
+	if err != nil {
+		slog.Error("failed to record inbox event", "kind", entry.Kind, "repo", entry.RepoFullName, "pr", entry.PRNumber, "error", err)
+		return false
+	}
+	return added
+}
+
+// excerpt returns the first non-empty line of body, shortened to inboxSummaryLen runes.
+func excerpt(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if utf8.RuneCountInString(line) > inboxSummaryLen {
+			line = string([]rune(line)[:inboxSummaryLen-1]) + "…"
+		}
+		return line
+	}
+	return ""
+}
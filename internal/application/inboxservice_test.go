@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memInboxStore is an in-memory InboxStore.
+type memInboxStore struct {
+	events []model.InboxEvent
+}
+
+func (m *memInboxStore) Add(_ context.Context, e model.InboxEvent) (bool, error) {
+	for _, existing := range m.events {
+		if existing.Kind == e.Kind && existing.RepoFullName == e.RepoFullName && existing.PRNumber == e.PRNumber && existing.SourceID == e.SourceID {
+			return false, nil
+		}
+	}
+	e.ID = int64(len(m.events) + 1)
+	m.events = append(m.events, e)
+	return true, nil
+}
+
+func (m *memInboxStore) List(_ context.Context, _ bool, _ int) ([]model.InboxEvent, error) {
+	return m.events, nil
+}
+
+func (m *memInboxStore) UnreadCount(_ context.Context) (int, error) { return len(m.events), nil }
+
+func (m *memInboxStore) SetRead(_ context.Context, _ int64, _ bool) error { return nil }
+
+func (m *memInboxStore) MarkAllRead(_ context.Context) error { return nil }
+
+func TestInboxService_ReviewRequests(t *testing.T) {
+	store := &memInboxStore{}
+	svc := NewInboxService(store, &testPRStore{}, "me")
+	ctx := context.Background()
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+
+	updated := func(needsReview bool, at time.Time) model.PREvent {
+		pr := model.PullRequest{ID: 1, Number: 5, RepoFullName: "o/r", Title: "Fix", Author: "alice", Status: model.PRStatusOpen, NeedsReview: needsReview}
+		return model.PREvent{Type: model.PREventUpdated, RepoFullName: "o/r", PRNumber: 5, OccurredAt: at, PullRequest: &pr}
+	}
+
+	svc.record(ctx, updated(true, at))
+	svc.record(ctx, updated(true, at.Add(time.Minute)))
+	require.Len(t, store.events, 1, "a standing request is recorded once")
+	assert.Equal(t, model.InboxReviewRequested, store.events[0].Kind)
+	assert.Equal(t, "alice", store.events[0].Actor)
+
+	// Review submitted, then re-requested.
+	svc.record(ctx, updated(false, at.Add(2*time.Minute)))
+	svc.record(ctx, updated(true, at.Add(3*time.Minute)))
+	require.Len(t, store.events, 2)
+
+	// After a restart the in-memory state is gone; the existing entry stops a duplicate.
+	restarted := NewInboxService(store, &testPRStore{}, "me")
+	restarted.record(ctx, updated(true, at.Add(4*time.Minute)))
+	assert.Len(t, store.events, 2)
+}
+
+func TestInboxService_OwnPREvents(t *testing.T) {
+	store := &memInboxStore{}
+	prStore := &testPRStore{prs: []model.PullRequest{
+		{ID: 1, Number: 5, RepoFullName: "o/r", Title: "Mine", Author: "Me"},
+		{ID: 2, Number: 6, RepoFullName: "o/r", Title: "Theirs", Author: "alice"},
+	}}
+	svc := NewInboxService(store, prStore, "me")
+	ctx := context.Background()
+
+	event := func(number int, set func(*model.PREvent)) model.PREvent {
+		e := model.PREvent{RepoFullName: "o/r", PRNumber: number, OccurredAt: time.Now()}
+		set(&e)
+		return e
+	}
+	review := func(id int64, login string, state model.ReviewState, body string) func(*model.PREvent) {
+		return func(e *model.PREvent) {
+			e.Type = model.PREventReviewAdded
+			e.Review = &model.Review{ID: id, ReviewerLogin: login, State: state, Body: body}
+		}
+	}
+	check := func(id int64, conclusion string) func(*model.PREvent) {
+		return func(e *model.PREvent) {
+			e.Type = model.PREventCheckCompleted
+			e.CheckRun = &model.CheckRun{ID: id, Name: "build", Status: "completed", Conclusion: conclusion}
+		}
+	}
+	comment := func(id int64, author string, isBot bool) func(*model.PREvent) {
+		return func(e *model.PREvent) {
+			e.Type = model.PREventCommentAdded
+			e.Comment = &model.IssueComment{ID: id, Author: author, Body: "\n  Looks good\nmore", IsBot: isBot}
+		}
+	}
+
+	for _, e := range []model.PREvent{
+		event(5, review(1, "alice", model.ReviewStateApproved, "")),
+		event(5, review(2, "bob", model.ReviewStateChangesRequested, "Needs tests")),
+		event(5, review(3, "me", model.ReviewStateCommented, "self")),
+		event(5, check(10, "failure")),
+		event(5, check(11, "success")),
+		event(5, check(12, "cancelled")),
+		event(5, comment(20, "carol", false)),
+		event(5, comment(21, "ci-bot", true)),
+		event(6, review(4, "bob", model.ReviewStateApproved, "")),
+	} {
+		svc.record(ctx, e)
+	}
+
+	var got []string
+	for _, e := range store.events {
+		got = append(got, fmt.Sprintf("%s %s %s %q", e.Kind, e.SourceID, e.Actor, e.Summary))
+		assert.Equal(t, "Mine", e.PRTitle)
+	}
+	assert.Equal(t, []string{
+		`approval_received review-1 alice ""`,
+		`new_comment review-2 bob "Requested changes: Needs tests"`,
+		`ci_failed check-10  "build failure"`,
+		`new_comment comment-20 carol "Looks good"`,
+	}, got)
+}
+
+// memWatchStore is an in-memory WatchStore.
+type memWatchStore map[int64]model.WatchState
+
+func (m memWatchStore) SetWatchState(_ context.Context, prID int64, state model.WatchState) error {
+	m[prID] = state
+	return nil
+}
+
+func (m memWatchStore) GetWatchState(_ context.Context, prID int64) (model.WatchState, error) {
+	return m[prID], nil
+}
+
+func (m memWatchStore) ListWatchStates(_ context.Context) (map[int64]model.WatchState, error) {
+	return m, nil
+}
+
+func TestInboxService_WatchStates(t *testing.T) {
+	store := &memInboxStore{}
+	prStore := &testPRStore{prs: []model.PullRequest{
+		{ID: 1, Number: 5, RepoFullName: "o/r", Title: "Watched", Author: "alice", Status: model.PRStatusOpen},
+		{ID: 2, Number: 6, RepoFullName: "o/r", Title: "Unwatched", Author: "alice", Status: model.PRStatusOpen, NeedsReview: true},
+	}}
+	svc := NewInboxService(store, prStore, "me").
+		WithWatchStore(memWatchStore{1: model.WatchStateWatching, 2: model.WatchStateUnwatched})
+	ctx := context.Background()
+	now := time.Now()
+
+	svc.record(ctx, model.PREvent{Type: model.PREventUpdated, RepoFullName: "o/r", PRNumber: 6, OccurredAt: now, PullRequest: &prStore.prs[1]})
+	svc.record(ctx, model.PREvent{
+		Type: model.PREventCheckCompleted, RepoFullName: "o/r", PRNumber: 5, OccurredAt: now,
+		CheckRun: &model.CheckRun{ID: 10, Name: "build", Status: "completed", Conclusion: "failure"},
+	})
+	svc.record(ctx, model.PREvent{
+		Type: model.PREventCheckCompleted, RepoFullName: "o/r", PRNumber: 6, OccurredAt: now,
+		CheckRun: &model.CheckRun{ID: 11, Name: "build", Status: "completed", Conclusion: "failure"},
+	})
+
+	require.Len(t, store.events, 1, "no review request for the unwatched PR, no CI failure for a PR neither owned nor watched")
+	assert.Equal(t, model.InboxCIFailed, store.events[0].Kind)
+	assert.Equal(t, "Watched", store.events[0].PRTitle)
+}
+
+func TestExcerpt(t *testing.T) {
+	assert.Equal(t, "", excerpt("  \n\t\n"))
+	long := excerpt(fmt.Sprintf("%0200d", 0))
+	assert.Equal(t, inboxSummaryLen, len([]rune(long)))
+	assert.Equal(t, '…', []rune(long)[inboxSummaryLen-1])
+}
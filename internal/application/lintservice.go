@@ -0,0 +1,158 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// maxLintRulesPerRepo bounds the rules evaluated on every PR sync.
+const maxLintRulesPerRepo = 20
+
+// ErrInvalidLintRule is returned for a rule with an unknown target or a
+// pattern that is empty or not a valid regular expression.
+var ErrInvalidLintRule = errors.New("invalid lint rule")
+
+// LintViolations returns the rules pr fails: those whose pattern does not
+// match the PR's title or branch. Rules with invalid patterns are skipped.
+func LintViolations(pr model.PullRequest, rules []model.LintRule) []model.LintRule {
+	var failed []model.LintRule
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		if !re.MatchString(rule.Value(pr)) {
+			failed = append(failed, rule)
+		}
+	}
+	return failed
+}
+
+// LintService checks PR titles and branch names against per-repository
+// naming conventions. PRs are checked as they are synced, and all of a
+// repository's open PRs are rechecked when its rules change.
+type LintService struct {
+	store   driven.LintStore
+	prStore driven.PRStore
+	logger  *slog.Logger
+}
+
+// NewLintService creates a LintService.
+func NewLintService(store driven.LintStore, prStore driven.PRStore) *LintService {
+	return &LintService{store: store, prStore: prStore, logger: slog.Default()}
+}
+
+// Rules returns a repository's lint rules.
+func (s *LintService) Rules(ctx context.Context, repoFullName string) ([]model.LintRule, error) {
+	return s.store.ListRules(ctx, repoFullName)
+}
+
+// SetRules validates and replaces a repository's rules, then rechecks its
+// open PRs. It returns ErrInvalidLintRule for a bad rule and
+// driven.ErrRepoNotFound if the repository is not watched.
+func (s *LintService) SetRules(ctx context.Context, repoFullName string, rules []model.LintRule) ([]model.LintRule, error) {
+	if len(rules) > maxLintRulesPerRepo {
+		return nil, fmt.Errorf("%w: at most %d rules per repository", ErrInvalidLintRule, maxLintRulesPerRepo)
+	}
+	for _, rule := range rules {
+		if !rule.Target.Valid() {
+			return nil, fmt.Errorf("%w: target must be title or branch", ErrInvalidLintRule)
+		}
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("%w: pattern is required", ErrInvalidLintRule)
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLintRule, err)
+		}
+	}
+
+	saved, err := s.store.ReplaceRules(ctx, repoFullName, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	prs, err := s.prStore.GetByRepository(ctx, repoFullName)
+	if err != nil {
+		return nil, fmt.Errorf("list PRs to lint in %s: %w", repoFullName, err)
+	}
+	for _, pr := range prs {
+		if pr.Status == model.PRStatusOpen {
+			s.record(ctx, pr, saved)
+		}
+	}
+	return saved, nil
+}
+
+// LintPR checks a stored PR against its repository's rules and records the
+// result. Failures are logged; linting never blocks a sync.
+func (s *LintService) LintPR(ctx context.Context, pr model.PullRequest) {
+	rules, err := s.store.ListRules(ctx, pr.RepoFullName)
+	if err != nil {
+		s.logger.Warn("failed to list lint rules", "repo", pr.RepoFullName, "error", err)
+		return
+	}
+	s.record(ctx, pr, rules)
+}
+
+// record stores the rules pr fails, clearing earlier violations.
+func (s *LintService) record(ctx context.Context, pr model.PullRequest, rules []model.LintRule) {
+	failed := LintViolations(pr, rules)
+	ids := make([]int64, 0, len(failed))
+	for _, rule := range failed {
+		ids = append(ids, rule.ID)
+	}
+	if err := s.store.SetViolations(ctx, pr.ID, ids); err != nil {
+		s.logger.Warn("failed to store lint violations", "pr_id", pr.ID, "error", err)
+	}
+}
+
+// ViolationsByPR returns the failed rules of open PRs keyed by PR ID, for one
+// repository or all of them when repoFullName is empty.
+func (s *LintService) ViolationsByPR(ctx context.Context, repoFullName string) (map[int64][]model.LintRule, error) {
+	return s.store.ViolationsByPR(ctx, repoFullName)
+}
+
+// Violations returns open PRs that fail at least one rule, for one repository
+// or all of them when repoFullName is empty, ordered by repository and number.
+func (s *LintService) Violations(ctx context.Context, repoFullName string) ([]model.PRLintViolations, error) {
+	byPR, err := s.store.ViolationsByPR(ctx, repoFullName)
+	if err != nil {
+		return nil, err
+	}
+	if len(byPR) == 0 {
+		return nil, nil
+	}
+
+	open, err := s.prStore.GetByStatus(ctx, model.PRStatusOpen)
+	if err != nil {
+		return nil, fmt.Errorf("list open PRs: %w", err)
+	}
+	var result []model.PRLintViolations
+	for _, pr := range open {
+		if rules, ok := byPR[pr.ID]; ok {
+			result = append(result, model.PRLintViolations{PR: pr, Violations: rules})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].PR, result[j].PR
+		if a.RepoFullName != b.RepoFullName {
+			return a.RepoFullName < b.RepoFullName
+		}
+		return a.Number < b.Number
+	})
+	return result, nil
+}
+
+// WithLintService checks each synced PR's title and branch against its
+// repository's lint rules.
+func (s *PollService) WithLintService(svc *LintService) *PollService {
+	s.lint = svc
+	return s
+}
@@ -0,0 +1,77 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// fakeLintStore keeps rules and violations in memory.
+type fakeLintStore struct {
+	rules      []model.LintRule
+	violations map[int64][]int64
+}
+
+func (f *fakeLintStore) ListRules(_ context.Context, _ string) ([]model.LintRule, error) {
+	return f.rules, nil
+}
+
+func (f *fakeLintStore) ReplaceRules(_ context.Context, repoFullName string, rules []model.LintRule) ([]model.LintRule, error) {
+	f.rules = nil
+	for i, rule := range rules {
+		rule.ID, rule.RepoFullName = int64(i+1), repoFullName
+		f.rules = append(f.rules, rule)
+	}
+	return f.rules, nil
+}
+
+func (f *fakeLintStore) SetViolations(_ context.Context, prID int64, ruleIDs []int64) error {
+	if f.violations == nil {
+		f.violations = make(map[int64][]int64)
+	}
+	f.violations[prID] = ruleIDs
+	return nil
+}
+
+func (f *fakeLintStore) ViolationsByPR(_ context.Context, _ string) (map[int64][]model.LintRule, error) {
+	return nil, nil
+}
+
+func TestLintViolations(t *testing.T) {
+	rules := []model.LintRule{
+		{ID: 1, Target: model.LintTargetTitle, Pattern: `^(feat|fix|chore)(\(.+\))?: `},
+		{ID: 2, Target: model.LintTargetBranch, Pattern: `^[A-Z]+-\d+`},
+		{ID: 3, Target: model.LintTargetTitle, Pattern: `(`},
+	}
+
+	failed := application.LintViolations(model.PullRequest{Title: "fix(api): handle nil", Branch: "cleanup"}, rules)
+	require.Len(t, failed, 1, "invalid patterns are skipped")
+	assert.Equal(t, int64(2), failed[0].ID)
+
+	assert.Empty(t, application.LintViolations(model.PullRequest{Title: "feat: add lint", Branch: "PROJ-12-lint"}, rules))
+}
+
+func TestLintService_SetRules(t *testing.T) {
+	ctx := context.Background()
+	store := &fakeLintStore{}
+	prs := &mockPRStore{stored: []model.PullRequest{
+		{ID: 10, RepoFullName: "org/api", Title: "Add lint", Status: model.PRStatusOpen},
+		{ID: 11, RepoFullName: "org/api", Title: "feat: merged", Status: model.PRStatusMerged},
+	}}
+	svc := application.NewLintService(store, prs)
+
+	_, err := svc.SetRules(ctx, "org/api", []model.LintRule{{Target: "body", Pattern: "x"}})
+	assert.ErrorIs(t, err, application.ErrInvalidLintRule)
+	_, err = svc.SetRules(ctx, "org/api", []model.LintRule{{Target: model.LintTargetTitle, Pattern: "[a-"}})
+	assert.ErrorIs(t, err, application.ErrInvalidLintRule)
+
+	saved, err := svc.SetRules(ctx, "org/api", []model.LintRule{{Target: model.LintTargetTitle, Pattern: `^feat: `}})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, map[int64][]int64{10: {saved[0].ID}}, store.violations, "only open PRs are rechecked")
+}
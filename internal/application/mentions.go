@@ -0,0 +1,123 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// maxLoginLen is GitHub's maximum username length.
+const maxLoginLen = 39
+
+// ParseMentions returns the distinct logins @mentioned in a Markdown comment
+// body, in order of first appearance. Like GitHub, it ignores mentions inside
+// code spans, fenced code blocks, and quoted lines, as well as email addresses
+// and team mentions (@org/team). Logins compare case-insensitively.
+func ParseMentions(body string) []string {
+	var logins []string
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+
+		// Even-numbered segments between backticks are outside code spans.
+		for i, segment := range strings.Split(line, "`") {
+			if i%2 == 1 {
+				continue
+			}
+			for _, login := range mentionsInText(segment) {
+				if !containsFold(logins, login) {
+					logins = append(logins, login)
+				}
+			}
+		}
+	}
+	return logins
+}
+
+// MentionsLogin reports whether body @mentions login.
+func MentionsLogin(body, login string) bool {
+	return login != "" && containsFold(ParseMentions(body), login)
+}
+
+// mentionsInText scans plain text, with Markdown code already removed, for
+// @login tokens.
+func mentionsInText(text string) []string {
+	var logins []string
+	for i := 0; i < len(text); i++ {
+		if text[i] != '@' || (i > 0 && !mentionBoundary(text[i-1])) {
+			continue
+		}
+		end := i + 1
+		for end < len(text) && (isAlnum(text[end]) || text[end] == '-') {
+			end++
+		}
+		login := text[i+1 : end]
+		i = end - 1
+		if end < len(text) && text[end] == '/' {
+			continue // team mention
+		}
+		if validLogin(login) {
+			logins = append(logins, login)
+		}
+	}
+	return logins
+}
+
+// mentionBoundary reports whether c may precede an @mention. Letters, digits,
+// and a few punctuation marks rule it out, as in user@example.com or a/@b.
+func mentionBoundary(c byte) bool {
+	return !isAlnum(c) && !strings.ContainsRune("_-./@", rune(c))
+}
+
+// validLogin reports whether s has the shape of a GitHub username: 1-39
+// alphanumerics or single hyphens, not starting or ending with a hyphen.
+func validLogin(s string) bool {
+	return s != "" && len(s) <= maxLoginLen &&
+		s[0] != '-' && s[len(s)-1] != '-' && !strings.Contains(s, "--")
+}
+
+func isAlnum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// WithMentionStore enables recording the @mentions in each review and
+// comment body as review data is fetched.
+func (s *PollService) WithMentionStore(store driven.MentionStore) *PollService {
+	s.mentions = store
+	return s
+}
+
+// recordMentions replaces the stored mentions of one comment with those
+// parsed from body, so an edit that drops a mention also drops its row.
+// comment carries the source, comment ID, author, and time; self-mentions are
+// not recorded.
+func (s *PollService) recordMentions(ctx context.Context, pr model.PullRequest, comment model.Mention, body string) {
+	if s.mentions == nil {
+		return
+	}
+
+	var mentions []model.Mention
+	for _, login := range ParseMentions(body) {
+		if strings.EqualFold(login, comment.Author) {
+			continue
+		}
+		m := comment
+		m.PRID = pr.ID
+		m.Login = login
+		mentions = append(mentions, m)
+	}
+
+	if err := s.mentions.SetMentions(ctx, comment.Source, comment.CommentID, mentions); err != nil {
+		slog.Error("store mentions failed", "repo", pr.RepoFullName, "pr", pr.Number, "source", comment.Source, "comment", comment.CommentID, "error", err)
+	}
+}
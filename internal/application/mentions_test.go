@@ -0,0 +1,191 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// mockMentionStore keeps mentions in memory, keyed by source and comment ID.
+type mockMentionStore struct {
+	mu        sync.Mutex
+	byComment map[string][]model.Mention
+}
+
+func newMockMentionStore() *mockMentionStore {
+	return &mockMentionStore{byComment: make(map[string][]model.Mention)}
+}
+
+func (m *mockMentionStore) SetMentions(_ context.Context, source model.MentionSource, commentID int64, mentions []model.Mention) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byComment[fmt.Sprintf("%s/%d", source, commentID)] = mentions
+	return nil
+}
+
+func (m *mockMentionStore) GetMentionsByPR(_ context.Context, prID int64) ([]model.Mention, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var mentions []model.Mention
+	for _, list := range m.byComment {
+		for _, mention := range list {
+			if mention.PRID == prID {
+				mentions = append(mentions, mention)
+			}
+		}
+	}
+	return mentions, nil
+}
+
+func (m *mockMentionStore) ListPRIDsMentioning(_ context.Context, _ string) ([]int64, error) {
+	return nil, nil
+}
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{"single", "@alice can you look?", []string{"alice"}},
+		{"several in order, deduplicated ignoring case", "cc @bob @alice and @Bob", []string{"bob", "alice"}},
+		{"trailing punctuation", "thanks @alice, and (@bob).", []string{"alice", "bob"}},
+		{"hyphenated login", "ping @octo-cat", []string{"octo-cat"}},
+		{"email address", "mail alice@example.com", nil},
+		{"team mention", "@org/reviewers please", nil},
+		{"code span", "run `@alice` or @bob", []string{"bob"}},
+		{"fenced code block", "```\n@alice\n```\n@bob", []string{"bob"}},
+		{"quoted reply", "> @alice wrote this\nagreed", nil},
+		{"invalid login shapes", "@-alice @alice- @al--ice @", nil},
+		{"no mentions", "LGTM", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, application.ParseMentions(tt.body))
+		})
+	}
+}
+
+func TestMentionsLogin(t *testing.T) {
+	assert.True(t, application.MentionsLogin("hey @TestUser", "testuser"))
+	assert.False(t, application.MentionsLogin("hey @testuser2", "testuser"))
+	assert.False(t, application.MentionsLogin("hey @testuser", ""))
+}
+
+func TestPollRepo_RecordsMentions(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 90, Author: "alice", RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now},
+			}, nil
+		},
+		fetchReviews: func(_ context.Context, _ string, _ int) ([]model.Review, error) {
+			return []model.Review{
+				{ID: 1, ReviewerLogin: "bob", State: model.ReviewStateCommented, Body: "cc @testuser", SubmittedAt: now},
+			}, nil
+		},
+		fetchReviewComments: func(_ context.Context, _ string, _ int) ([]model.ReviewComment, error) {
+			return []model.ReviewComment{
+				{ID: 2, Author: "testuser", Body: "@testuser note to self", CreatedAt: now},
+			}, nil
+		},
+		fetchIssueComments: func(_ context.Context, _ string, _ int) ([]model.IssueComment, error) {
+			return []model.IssueComment{
+				{ID: 3, Author: "alice", Body: "@testuser @carol thoughts?", CreatedAt: now},
+			}, nil
+		},
+	}
+
+	mentions := newMockMentionStore()
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+	svc := application.NewPollService(ghClient, &mockPRStore{}, repoStore, newMockReviewStore(), newMockCheckStore(), "testuser", nil, time.Hour, nil, nil).
+		WithMentionStore(mentions)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	require.NoError(t, svc.RefreshRepo(ctx, "org/repo"))
+	cancel()
+	<-done
+
+	got, err := mentions.GetMentionsByPR(context.Background(), 90)
+	require.NoError(t, err)
+
+	type key struct {
+		source model.MentionSource
+		login  string
+	}
+	var keys []key
+	for _, m := range got {
+		assert.Equal(t, now, m.CreatedAt)
+		keys = append(keys, key{m.Source, m.Login})
+	}
+	assert.ElementsMatch(t, []key{
+		{model.MentionInReview, "testuser"},
+		{model.MentionInIssueComment, "testuser"},
+		{model.MentionInIssueComment, "carol"},
+	}, keys, "self-mentions are skipped")
+}
+
+func TestSignalsForPR_Mentioned(t *testing.T) {
+	mentionedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	pr := model.PullRequest{ID: 7, Number: 7, Author: "alice", RepoFullName: "org/repo", OpenedAt: mentionedAt}
+
+	newService := func(reviews []model.Review, comments []model.IssueComment) *application.AttentionService {
+		reviewStore := newMockReviewStore()
+		reviewStore.stubReviews = reviews
+		reviewStore.upsertedIssueComments = comments
+		mentions := newMockMentionStore()
+		require.NoError(t, mentions.SetMentions(context.Background(), model.MentionInIssueComment, 1, []model.Mention{
+			{PRID: pr.ID, Source: model.MentionInIssueComment, CommentID: 1, Author: "alice", Login: "TestUser", CreatedAt: mentionedAt},
+		}))
+		return application.NewAttentionService(&attentionThresholdStore{global: model.DefaultGlobalSettings()}, reviewStore, "testuser").
+			WithMentionStore(mentions)
+	}
+	thresholds := model.EffectiveThresholds{}
+
+	t.Run("no reply", func(t *testing.T) {
+		signals, err := newService(nil, nil).SignalsForPR(context.Background(), pr, thresholds)
+		require.NoError(t, err)
+		assert.True(t, signals.Mentioned)
+		assert.True(t, signals.HasAny())
+		assert.True(t, signals.Has(model.SignalMentioned))
+	})
+
+	t.Run("replied with a comment", func(t *testing.T) {
+		signals, err := newService(nil, []model.IssueComment{
+			{ID: 2, PRID: pr.ID, Author: "testuser", CreatedAt: mentionedAt.Add(time.Minute)},
+		}).SignalsForPR(context.Background(), pr, thresholds)
+		require.NoError(t, err)
+		assert.False(t, signals.Mentioned)
+	})
+
+	t.Run("replied with a review", func(t *testing.T) {
+		signals, err := newService([]model.Review{
+			{ID: 3, ReviewerLogin: "testuser", State: model.ReviewStateCommented, SubmittedAt: mentionedAt.Add(time.Hour)},
+		}, nil).SignalsForPR(context.Background(), pr, thresholds)
+		require.NoError(t, err)
+		assert.False(t, signals.Mentioned)
+	})
+
+	t.Run("comment before the mention is not a reply", func(t *testing.T) {
+		signals, err := newService(nil, []model.IssueComment{
+			{ID: 2, PRID: pr.ID, Author: "testuser", CreatedAt: mentionedAt.Add(-time.Minute)},
+		}).SignalsForPR(context.Background(), pr, thresholds)
+		require.NoError(t, err)
+		assert.True(t, signals.Mentioned)
+	})
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// MergeRequirements lists what pr must satisfy to merge under its base
+// branch's protection, and whether each is currently met. It returns nil when
+// the branch neither requires reviews nor has required status checks.
+// protection may be nil when the branch's rules have not been fetched.
+func MergeRequirements(pr model.PullRequest, protection *model.BranchProtection, reviews []model.Review, checkRuns []model.CheckRun) []model.MergeRequirement {
+	var requirements []model.MergeRequirement
+
+	if protection != nil && protection.RequiresReviews {
+		approvals, changesRequested := decisiveReviews(pr, protection.DismissStaleReviews, reviews)
+
+		if need := protection.RequiredApprovals; need > 0 {
+			requirements = append(requirements, model.MergeRequirement{
+				Kind:  model.RequirementApprovals,
+				State: metIf(approvals >= need),
+				Have:  approvals,
+				Need:  need,
+			})
+		}
+
+		if protection.RequireCodeOwnerReviews {
+			// Whether an approver owns the changed files is only known to
+			// GitHub, so an approval leaves this undetermined.
+			state := model.RequirementUnknown
+			if approvals == 0 {
+				state = model.RequirementUnmet
+			}
+			requirements = append(requirements, model.MergeRequirement{Kind: model.RequirementCodeOwnerReview, State: state})
+		}
+
+		requirements = append(requirements, model.MergeRequirement{
+			Kind:  model.RequirementNoChangesRequested,
+			State: metIf(!changesRequested),
+		})
+	}
+
+	if req, ok := requiredChecksRequirement(checkRuns); ok {
+		requirements = append(requirements, req)
+	}
+
+	if len(requirements) == 0 {
+		return nil
+	}
+
+	conflicts := model.MergeRequirement{Kind: model.RequirementNoConflicts, State: model.RequirementUnknown}
+	switch pr.MergeableStatus {
+	case model.MergeableMergeable:
+		conflicts.State = model.RequirementMet
+	case model.MergeableConflicted:
+		conflicts.State = model.RequirementUnmet
+	}
+	return append(requirements, conflicts)
+}
+
+// decisiveReviews counts the current approvals on pr and reports whether any
+// reviewer's latest verdict requests changes. Like GitHub, it takes each
+// reviewer's latest approval, change request, or dismissal; comments do not
+// change a verdict. Bots and the author are ignored, and when dismissStale is
+// set, approvals of older commits do not count.
+func decisiveReviews(pr model.PullRequest, dismissStale bool, reviews []model.Review) (approvals int, changesRequested bool) {
+	latest := make(map[string]model.Review)
+	for _, r := range reviews {
+		if r.IsBot || strings.EqualFold(r.ReviewerLogin, pr.Author) {
+			continue
+		}
+		switch r.State {
+		case model.ReviewStateApproved, model.ReviewStateChangesRequested, model.ReviewStateDismissed:
+		default:
+			continue
+		}
+		login := strings.ToLower(r.ReviewerLogin)
+		if existing, seen := latest[login]; !seen || r.SubmittedAt.After(existing.SubmittedAt) {
+			latest[login] = r
+		}
+	}
+
+	for _, r := range latest {
+		switch r.State {
+		case model.ReviewStateApproved:
+			if !dismissStale || r.CommitID == "" || r.CommitID == pr.HeadSHA {
+				approvals++
+			}
+		case model.ReviewStateChangesRequested:
+			changesRequested = true
+		}
+	}
+	return approvals, changesRequested
+}
+
+// requiredChecksRequirement summarizes the required check runs. ok is false
+// when none are required.
+func requiredChecksRequirement(checkRuns []model.CheckRun) (req model.MergeRequirement, ok bool) {
+	req = model.MergeRequirement{Kind: model.RequirementStatusChecks, State: model.RequirementMet}
+	for _, run := range checkRuns {
+		if !run.IsRequired {
+			continue
+		}
+		req.Need++
+		switch {
+		case run.Status != "completed":
+			if req.State == model.RequirementMet {
+				req.State = model.RequirementPending
+			}
+		case run.Conclusion == "success" || run.Conclusion == "neutral" || run.Conclusion == "skipped":
+			req.Have++
+		default:
+			req.State = model.RequirementUnmet
+		}
+	}
+	return req, req.Need > 0
+}
+
+// metIf maps a condition to met or unmet.
+func metIf(ok bool) model.RequirementState {
+	if ok {
+		return model.RequirementMet
+	}
+	return model.RequirementUnmet
+}
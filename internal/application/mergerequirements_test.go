@@ -0,0 +1,92 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestMergeRequirements(t *testing.T) {
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	pr := model.PullRequest{Author: "alice", HeadSHA: "head", MergeableStatus: model.MergeableMergeable}
+	review := func(login string, state model.ReviewState, commit string, offset time.Duration) model.Review {
+		return model.Review{ReviewerLogin: login, State: state, CommitID: commit, SubmittedAt: at.Add(offset)}
+	}
+	states := func(reqs []model.MergeRequirement) map[model.MergeRequirementKind]model.RequirementState {
+		result := make(map[model.MergeRequirementKind]model.RequirementState, len(reqs))
+		for _, r := range reqs {
+			result[r.Kind] = r.State
+		}
+		return result
+	}
+
+	t.Run("unprotected branch without required checks", func(t *testing.T) {
+		assert.Nil(t, MergeRequirements(pr, nil, nil, []model.CheckRun{{Status: "completed", Conclusion: "failure"}}))
+		assert.Nil(t, MergeRequirements(pr, &model.BranchProtection{}, nil, nil))
+	})
+
+	t.Run("reviews and checks satisfied", func(t *testing.T) {
+		protection := &model.BranchProtection{RequiresReviews: true, RequiredApprovals: 2}
+		reviews := []model.Review{
+			review("bob", model.ReviewStateChangesRequested, "old", 0),
+			review("bob", model.ReviewStateApproved, "head", time.Hour),
+			review("bob", model.ReviewStateCommented, "head", 2*time.Hour), // comments keep the approval
+			review("carol", model.ReviewStateApproved, "old", 0),
+		}
+		runs := []model.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success", IsRequired: true},
+			{Name: "lint", Status: "completed", Conclusion: "failure"},
+		}
+
+		reqs := MergeRequirements(pr, protection, reviews, runs)
+		assert.Equal(t, []model.MergeRequirement{
+			{Kind: model.RequirementApprovals, State: model.RequirementMet, Have: 2, Need: 2},
+			{Kind: model.RequirementNoChangesRequested, State: model.RequirementMet},
+			{Kind: model.RequirementStatusChecks, State: model.RequirementMet, Have: 1, Need: 1},
+			{Kind: model.RequirementNoConflicts, State: model.RequirementMet},
+		}, reqs)
+	})
+
+	t.Run("stale approvals dismissed and changes requested", func(t *testing.T) {
+		protection := &model.BranchProtection{RequiresReviews: true, RequiredApprovals: 1, DismissStaleReviews: true, RequireCodeOwnerReviews: true}
+		reviews := []model.Review{
+			review("carol", model.ReviewStateApproved, "old", 0),
+			review("dave", model.ReviewStateChangesRequested, "head", 0),
+			review("alice", model.ReviewStateApproved, "head", 0), // the author
+			{ReviewerLogin: "ci-bot", State: model.ReviewStateApproved, CommitID: "head", IsBot: true},
+		}
+		conflicted := pr
+		conflicted.MergeableStatus = model.MergeableConflicted
+
+		got := states(MergeRequirements(conflicted, protection, reviews, nil))
+		assert.Equal(t, map[model.MergeRequirementKind]model.RequirementState{
+			model.RequirementApprovals:          model.RequirementUnmet,
+			model.RequirementCodeOwnerReview:    model.RequirementUnmet,
+			model.RequirementNoChangesRequested: model.RequirementUnmet,
+			model.RequirementNoConflicts:        model.RequirementUnmet,
+		}, got)
+	})
+
+	t.Run("code owner review undetermined once approved", func(t *testing.T) {
+		protection := &model.BranchProtection{RequiresReviews: true, RequireCodeOwnerReviews: true}
+		got := states(MergeRequirements(pr, protection, []model.Review{review("bob", model.ReviewStateApproved, "head", 0)}, nil))
+		assert.Equal(t, model.RequirementUnknown, got[model.RequirementCodeOwnerReview])
+		assert.NotContains(t, got, model.RequirementApprovals, "no approval count is required")
+	})
+
+	t.Run("required checks pending and failing", func(t *testing.T) {
+		pending := []model.CheckRun{
+			{Status: "completed", Conclusion: "success", IsRequired: true},
+			{Status: "in_progress", IsRequired: true},
+		}
+		got := MergeRequirements(pr, nil, nil, pending)
+		assert.Equal(t, model.MergeRequirement{Kind: model.RequirementStatusChecks, State: model.RequirementPending, Have: 1, Need: 2}, got[0])
+
+		failing := append(pending, model.CheckRun{Status: "completed", Conclusion: "failure", IsRequired: true})
+		got = MergeRequirements(pr, nil, nil, failing)
+		assert.Equal(t, model.RequirementUnmet, got[0].State)
+	})
+}
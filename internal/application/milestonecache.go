@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// milestoneCacheTTL is how long a repository's milestones are reused before
+// they are fetched again. Milestones are created and closed rarely.
+const milestoneCacheTTL = 15 * time.Minute
+
+// milestoneEntry is one repository's cached milestones.
+type milestoneEntry struct {
+	milestones []model.Milestone
+	fetchedAt  time.Time
+}
+
+// MilestoneCache keeps each repository's open milestones in memory, so the
+// milestone dropdown on every PR detail does not cost an API call.
+type MilestoneCache struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]milestoneEntry
+}
+
+// NewMilestoneCache creates an empty MilestoneCache.
+func NewMilestoneCache() *MilestoneCache {
+	return &MilestoneCache{now: time.Now, entries: make(map[string]milestoneEntry)}
+}
+
+// Milestones returns the open milestones of repoFullName, fetching them with
+// lister when they are not cached or older than milestoneCacheTTL. Failed
+// fetches are not cached.
+func (c *MilestoneCache) Milestones(ctx context.Context, repoFullName string, lister driven.MilestoneLister) ([]model.Milestone, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[repoFullName]
+	c.mu.Unlock()
+	if ok && c.now().Sub(entry.fetchedAt) < milestoneCacheTTL {
+		return entry.milestones, nil
+	}
+
+	milestones, err := lister.FetchMilestones(ctx, repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.entries[repoFullName] = milestoneEntry{milestones: milestones, fetchedAt: c.now()}
+	c.mu.Unlock()
+	return milestones, nil
+}
+
+// Invalidate drops the cached milestones of repoFullName.
+func (c *MilestoneCache) Invalidate(repoFullName string) {
+	c.mu.Lock()
+	delete(c.entries, repoFullName)
+	c.mu.Unlock()
+}
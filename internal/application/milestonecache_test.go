@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// countingMilestoneLister serves fixed milestones and counts fetches.
+type countingMilestoneLister struct {
+	milestones []model.Milestone
+	err        error
+	calls      int
+}
+
+func (l *countingMilestoneLister) FetchMilestones(context.Context, string) ([]model.Milestone, error) {
+	l.calls++
+	return l.milestones, l.err
+}
+
+func TestMilestoneCache(t *testing.T) {
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	cache := NewMilestoneCache()
+	cache.now = func() time.Time { return now }
+	lister := &countingMilestoneLister{milestones: []model.Milestone{{Number: 1, Title: "v1.0"}}}
+	ctx := context.Background()
+
+	got, err := cache.Milestones(ctx, "acme/api", lister)
+	require.NoError(t, err)
+	assert.Equal(t, lister.milestones, got)
+
+	_, _ = cache.Milestones(ctx, "acme/api", lister)
+	assert.Equal(t, 1, lister.calls, "served from the cache")
+
+	_, _ = cache.Milestones(ctx, "acme/web", lister)
+	assert.Equal(t, 2, lister.calls, "cached per repository")
+
+	now = now.Add(milestoneCacheTTL)
+	_, _ = cache.Milestones(ctx, "acme/api", lister)
+	assert.Equal(t, 3, lister.calls, "refetched once stale")
+
+	cache.Invalidate("acme/api")
+	lister.err = errors.New("boom")
+	_, err = cache.Milestones(ctx, "acme/api", lister)
+	require.Error(t, err)
+	lister.err = nil
+	_, _ = cache.Milestones(ctx, "acme/api", lister)
+	assert.Equal(t, 5, lister.calls, "failures are not cached")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
 // testReviewStore is a configurable ReviewStore stub for white-box tests.
@@ -25,6 +26,18 @@ func (m *testReviewStore) UpsertIssueComment(_ context.Context, _ model.IssueCom
 func (m *testReviewStore) GetReviewsByPR(_ context.Context, _ int64) ([]model.Review, error) {
 	return m.reviews, nil
 }
+
+func (m *testReviewStore) GetReviewsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.Review, error) {
+	result := make(map[int64][]model.Review, len(prIDs))
+	for _, id := range prIDs {
+		reviews, err := m.GetReviewsByPR(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = reviews
+	}
+	return result, nil
+}
 func (m *testReviewStore) GetReviewCommentsByPR(_ context.Context, _ int64) ([]model.ReviewComment, error) {
 	return m.reviewComments, nil
 }
@@ -44,8 +57,17 @@ type testBotConfigStore struct {
 func (m *testBotConfigStore) Add(_ context.Context, config model.BotConfig) (model.BotConfig, error) {
 	return config, nil
 }
-func (m *testBotConfigStore) Remove(_ context.Context, _ string) error             { return nil }
-func (m *testBotConfigStore) ListAll(_ context.Context) ([]model.BotConfig, error) { return nil, nil }
+func (m *testBotConfigStore) Remove(_ context.Context, _ string) error { return nil }
+func (m *testBotConfigStore) SetNitpickPatterns(_ context.Context, _ string, _ []string) error {
+	return nil
+}
+func (m *testBotConfigStore) ListAll(_ context.Context) ([]model.BotConfig, error) {
+	bots := make([]model.BotConfig, len(m.usernames))
+	for i, username := range m.usernames {
+		bots[i] = model.BotConfig{Username: username}
+	}
+	return bots, nil
+}
 func (m *testBotConfigStore) GetUsernames(_ context.Context) ([]string, error) {
 	return m.usernames, nil
 }
@@ -64,10 +86,24 @@ func (s *testCheckStore) GetCheckRunsByPR(_ context.Context, _ int64) ([]model.C
 	return s.runs, nil
 }
 
+func (s *testCheckStore) GetCheckRunsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.CheckRun, error) {
+	result := make(map[int64][]model.CheckRun, len(prIDs))
+	for _, id := range prIDs {
+		runs, err := s.GetCheckRunsByPR(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = runs
+	}
+	return result, nil
+}
+
 // testPRStore is a configurable PRStore stub for white-box tests.
-// GetByNumber returns the pr field; all other methods are no-ops.
+// GetByNumber returns the pr field, or the entry of prs with the requested
+// number when pr is nil; ListAll returns prs; all other methods are no-ops.
 type testPRStore struct {
-	pr *model.PullRequest
+	pr  *model.PullRequest
+	prs []model.PullRequest
 }
 
 func (s *testPRStore) Upsert(_ context.Context, _ model.PullRequest) error { return nil }
@@ -77,14 +113,71 @@ func (s *testPRStore) GetByRepository(_ context.Context, _ string) ([]model.Pull
 func (s *testPRStore) GetByStatus(_ context.Context, _ model.PRStatus) ([]model.PullRequest, error) {
 	return nil, nil
 }
-func (s *testPRStore) GetByNumber(_ context.Context, _ string, _ int) (*model.PullRequest, error) {
-	return s.pr, nil
+func (s *testPRStore) GetByNumber(_ context.Context, _ string, number int) (*model.PullRequest, error) {
+	if s.pr != nil {
+		return s.pr, nil
+	}
+	for i := range s.prs {
+		if s.prs[i].Number == number {
+			return &s.prs[i], nil
+		}
+	}
+	return nil, nil
 }
-func (s *testPRStore) ListAll(_ context.Context) ([]model.PullRequest, error) { return nil, nil }
+func (s *testPRStore) ListAll(_ context.Context) ([]model.PullRequest, error) { return s.prs, nil }
 func (s *testPRStore) ListNeedingReview(_ context.Context) ([]model.PullRequest, error) {
 	return nil, nil
 }
 func (s *testPRStore) ListIgnoredWithPRData(_ context.Context) ([]model.PullRequest, error) {
 	return nil, nil
 }
+func (s *testPRStore) CountByStatus(_ context.Context) ([]driven.PRStatusCount, error) {
+	counts := make(map[driven.PRStatusCount]int)
+	for _, pr := range s.prs {
+		counts[driven.PRStatusCount{RepoFullName: pr.RepoFullName, Status: pr.Status, CIStatus: pr.CIStatus}]++
+	}
+	result := make([]driven.PRStatusCount, 0, len(counts))
+	for key, n := range counts {
+		key.Count = n
+		result = append(result, key)
+	}
+	return result, nil
+}
 func (s *testPRStore) Delete(_ context.Context, _ string, _ int) error { return nil }
+
+// testPRLinkStore is an in-memory PRLinkStore for white-box tests.
+type testPRLinkStore struct {
+	links map[[2]int64]bool
+}
+
+func newTestPRLinkStore() *testPRLinkStore {
+	return &testPRLinkStore{links: make(map[[2]int64]bool)}
+}
+
+func linkKey(a, b int64) [2]int64 {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int64{a, b}
+}
+
+func (s *testPRLinkStore) Link(_ context.Context, prID, otherID int64) error {
+	s.links[linkKey(prID, otherID)] = true
+	return nil
+}
+func (s *testPRLinkStore) Unlink(_ context.Context, prID, otherID int64) error {
+	delete(s.links, linkKey(prID, otherID))
+	return nil
+}
+func (s *testPRLinkStore) ListLinkedIDs(_ context.Context, prID int64) ([]int64, error) {
+	var ids []int64
+	for key := range s.links {
+		switch prID {
+		case key[0]:
+			ids = append(ids, key[1])
+		case key[1]:
+			ids = append(ids, key[0])
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// GitHub incident detection tuning.
+const (
+	// statusPageInterval is how often GitHub's status page is read.
+	statusPageInterval = 5 * time.Minute
+	// errorRateWindow is how far back poll outcomes count toward the error
+	// rate, and errorRateMinPolls how many it takes to judge it at all.
+	errorRateWindow   = 15 * time.Minute
+	errorRateMinPolls = 4
+	// degradedPollsPerTick caps scheduled polls per ticker tick while the
+	// API is degraded. Manual refreshes are not capped.
+	degradedPollsPerTick = 1
+)
+
+// outageState tracks signs of a GitHub-wide incident: the status page's
+// latest report and the outcomes of recent polls.
+type outageState struct {
+	mu              sync.Mutex
+	statusPage      model.GitHubServiceStatus
+	statusCheckedAt time.Time
+	outcomes        []pollOutcome // oldest first, pruned to errorRateWindow
+	degraded        bool          // last state seen by throttlePolling, for transition logs
+}
+
+// pollOutcome is one finished poll.
+type pollOutcome struct {
+	at     time.Time
+	failed bool
+}
+
+// WithStatusPage enables reading GitHub's status page, so API incidents are
+// reported before polls start failing. Degradation is also inferred from
+// the poll error rate without it.
+func (s *PollService) WithStatusPage(page driven.GitHubStatusPage) *PollService {
+	s.statusPage = page
+	return s
+}
+
+// checkStatusPage reads the status page when the last read is older than
+// statusPageInterval. A failed read keeps the previous report.
+func (s *PollService) checkStatusPage(ctx context.Context) {
+	if s.statusPage == nil {
+		return
+	}
+
+	s.outage.mu.Lock()
+	due := time.Since(s.outage.statusCheckedAt) >= statusPageInterval
+	if due {
+		s.outage.statusCheckedAt = time.Now()
+	}
+	s.outage.mu.Unlock()
+	if !due {
+		return
+	}
+
+	status, err := s.statusPage.FetchAPIStatus(ctx)
+	if err != nil {
+		slog.Debug("failed to read GitHub status page", "error", err)
+		return
+	}
+
+	s.outage.mu.Lock()
+	s.outage.statusPage = status
+	s.outage.mu.Unlock()
+}
+
+// recordPollOutcome adds a finished poll to the error rate. Failures of
+// repositories that are gone, forbidden, or moved say nothing about GitHub
+// and are left out.
+func (s *PollService) recordPollOutcome(err error, now time.Time) {
+	if err != nil && isUnreachable(err) {
+		return
+	}
+
+	s.outage.mu.Lock()
+	defer s.outage.mu.Unlock()
+	s.outage.outcomes = append(pruneOutcomes(s.outage.outcomes, now), pollOutcome{at: now, failed: err != nil})
+}
+
+// pruneOutcomes drops outcomes older than errorRateWindow.
+func pruneOutcomes(outcomes []pollOutcome, now time.Time) []pollOutcome {
+	cutoff := now.Add(-errorRateWindow)
+	i := 0
+	for i < len(outcomes) && outcomes[i].at.Before(cutoff) {
+		i++
+	}
+	return outcomes[i:]
+}
+
+// degradedReason explains why the GitHub API looks degraded, or returns ""
+// when it looks healthy. It is degraded while the status page reports an
+// incident, or while at least half of the recent polls failed.
+func (s *PollService) degradedReason(now time.Time) string {
+	s.outage.mu.Lock()
+	defer s.outage.mu.Unlock()
+
+	if s.outage.statusPage.Degraded {
+		return fmt.Sprintf("GitHub's status page lists API requests as %q.", s.outage.statusPage.Description)
+	}
+
+	outcomes := pruneOutcomes(s.outage.outcomes, now)
+	failed := 0
+	for _, o := range outcomes {
+		if o.failed {
+			failed++
+		}
+	}
+	if len(outcomes) >= errorRateMinPolls && failed*2 >= len(outcomes) {
+		return fmt.Sprintf("%d of the last %d GitHub polls failed.", failed, len(outcomes))
+	}
+	return ""
+}
+
+// throttlePolling reports whether scheduled polling should be capped at
+// degradedPollsPerTick this tick, logging when an incident starts or ends.
+func (s *PollService) throttlePolling(now time.Time) bool {
+	reason := s.degradedReason(now)
+	degraded := reason != ""
+
+	s.outage.mu.Lock()
+	changed := degraded != s.outage.degraded
+	s.outage.degraded = degraded
+	s.outage.mu.Unlock()
+
+	switch {
+	case changed && degraded:
+		slog.Warn("GitHub API degraded; slowing scheduled polling", "reason", reason)
+	case changed:
+		slog.Info("GitHub API recovered; scheduled polling resumed")
+	}
+	return degraded
+}
@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+type statusPageFunc func(ctx context.Context) (model.GitHubServiceStatus, error)
+
+func (f statusPageFunc) FetchAPIStatus(ctx context.Context) (model.GitHubServiceStatus, error) {
+	return f(ctx)
+}
+
+func TestDegradedReason_ErrorRate(t *testing.T) {
+	now := time.Now()
+	reset := errors.New("502 bad gateway")
+	svc := &PollService{}
+
+	svc.recordPollOutcome(reset, now)
+	svc.recordPollOutcome(reset, now)
+	assert.Empty(t, svc.degradedReason(now), "too few polls to judge")
+
+	svc.recordPollOutcome(nil, now)
+	svc.recordPollOutcome(reset, now)
+	assert.Equal(t, "3 of the last 4 GitHub polls failed.", svc.degradedReason(now))
+
+	notFound := fmt.Errorf("listing pull requests: %w", driven.ErrRepoUnreachable)
+	svc.recordPollOutcome(notFound, now)
+	assert.Equal(t, "3 of the last 4 GitHub polls failed.", svc.degradedReason(now), "unreachable repos do not count")
+
+	later := now.Add(errorRateWindow + time.Minute)
+	assert.Empty(t, svc.degradedReason(later), "old failures age out")
+}
+
+func TestCheckStatusPage(t *testing.T) {
+	calls := 0
+	svc := (&PollService{}).WithStatusPage(statusPageFunc(func(context.Context) (model.GitHubServiceStatus, error) {
+		calls++
+		return model.GitHubServiceStatus{Degraded: true, Description: "Partial outage"}, nil
+	}))
+
+	svc.checkStatusPage(t.Context())
+	svc.checkStatusPage(t.Context())
+	assert.Equal(t, 1, calls, "the status page is read at most every statusPageInterval")
+	assert.Equal(t, `GitHub's status page lists API requests as "Partial outage".`, svc.degradedReason(time.Now()))
+
+	status := svc.SyncStatus()
+	assert.True(t, status.Degraded)
+	assert.False(t, status.Offline)
+}
+
+func TestThrottlePolling(t *testing.T) {
+	now := time.Now()
+	svc := &PollService{}
+	assert.False(t, svc.throttlePolling(now))
+
+	for range errorRateMinPolls {
+		svc.recordPollOutcome(errors.New("timeout"), now)
+	}
+	assert.True(t, svc.throttlePolling(now))
+
+	for range errorRateMinPolls + 1 {
+		svc.recordPollOutcome(nil, now)
+	}
+	assert.False(t, svc.throttlePolling(now), "polling resumes once most polls succeed")
+}
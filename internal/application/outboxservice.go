@@ -0,0 +1,266 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// Outbox retry schedule: the delay doubles from outboxBaseBackoff after each
+// failed attempt, up to outboxMaxBackoff, and an action that has failed
+// outboxMaxAttempts times stops retrying and is marked failed.
+const (
+	outboxBaseBackoff = time.Minute
+	outboxMaxBackoff  = time.Hour
+	outboxMaxAttempts = 10
+)
+
+// outboxSendTimeout bounds a single replayed GitHub write.
+const outboxSendTimeout = 30 * time.Second
+
+// ErrOutboundActionNotFound is returned when retrying or discarding an
+// action that is no longer in the outbox.
+var ErrOutboundActionNotFound = errors.New("outbound action not found")
+
+// reviewPayload is the JSON stored in OutboundAction.Payload for reviews.
+// The review body lives in OutboundAction.Body.
+type reviewPayload struct {
+	CommitID string                    `json:"commit_id"`
+	Event    string                    `json:"event"`
+	Comments []driven.DraftLineComment `json:"comments,omitempty"`
+}
+
+// OutboxService keeps GitHub writes that failed with a transient error and
+// replays them with exponential backoff, so composed review and comment text
+// survives a GitHub outage or rate limit.
+type OutboxService struct {
+	store driven.OutboxStore
+	// writerFor returns a writer authenticated for the repository, or an
+	// error when no token is available.
+	writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)
+	now       func() time.Time
+
+	// mu serializes sends so the retry loop and a manual retry never post
+	// the same action twice.
+	mu sync.Mutex
+}
+
+// NewOutboxService creates an OutboxService.
+func NewOutboxService(store driven.OutboxStore, writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)) *OutboxService {
+	return &OutboxService{store: store, writerFor: writerFor, now: time.Now}
+}
+
+// EnqueueReview queues a review whose submission failed with cause.
+func (s *OutboxService) EnqueueReview(ctx context.Context, repoFullName string, prNumber int, req driven.ReviewRequest, cause error) (model.OutboundAction, error) {
+	payload, err := json.Marshal(reviewPayload{CommitID: req.CommitID, Event: req.Event, Comments: req.Comments})
+	if err != nil {
+		return model.OutboundAction{}, fmt.Errorf("encode review payload: %w", err)
+	}
+	return s.enqueue(ctx, model.OutboundAction{
+		Kind:         model.OutboundReview,
+		RepoFullName: repoFullName,
+		PRNumber:     prNumber,
+		Body:         req.Body,
+		Payload:      string(payload),
+	}, cause)
+}
+
+// EnqueueReply queues a review thread reply whose submission failed with cause.
+func (s *OutboxService) EnqueueReply(ctx context.Context, repoFullName string, prNumber int, inReplyTo int64, body string, cause error) (model.OutboundAction, error) {
+	return s.enqueue(ctx, model.OutboundAction{
+		Kind:         model.OutboundReply,
+		RepoFullName: repoFullName,
+		PRNumber:     prNumber,
+		Body:         body,
+		InReplyTo:    inReplyTo,
+	}, cause)
+}
+
+// EnqueueIssueComment queues a PR comment whose submission failed with cause.
+func (s *OutboxService) EnqueueIssueComment(ctx context.Context, repoFullName string, prNumber int, body string, cause error) (model.OutboundAction, error) {
+	return s.enqueue(ctx, model.OutboundAction{
+		Kind:         model.OutboundIssueComment,
+		RepoFullName: repoFullName,
+		PRNumber:     prNumber,
+		Body:         body,
+	}, cause)
+}
+
+// enqueue stores action as pending, counting the failed first attempt.
+func (s *OutboxService) enqueue(ctx context.Context, action model.OutboundAction, cause error) (model.OutboundAction, error) {
+	now := s.now().UTC()
+	action.Status = model.OutboundPending
+	action.Attempts = 1
+	action.NextAttemptAt = now.Add(outboxBackoff(1))
+	action.CreatedAt = now
+	if cause != nil {
+		action.LastError = cause.Error()
+	}
+
+	id, err := s.store.Add(ctx, action)
+	if err != nil {
+		return model.OutboundAction{}, err
+	}
+	action.ID = id
+	return action, nil
+}
+
+// List returns every queued action, newest first.
+func (s *OutboxService) List(ctx context.Context) ([]model.OutboundAction, error) {
+	return s.store.List(ctx)
+}
+
+// ListForPR returns the queued actions for one PR, newest first.
+func (s *OutboxService) ListForPR(ctx context.Context, repoFullName string, prNumber int) ([]model.OutboundAction, error) {
+	all, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var actions []model.OutboundAction
+	for _, a := range all {
+		if a.RepoFullName == repoFullName && a.PRNumber == prNumber {
+			actions = append(actions, a)
+		}
+	}
+	return actions, nil
+}
+
+// Retry sends one action now, whatever its status or schedule. It returns the
+// send error, if any, after recording it on the action.
+func (s *OutboxService) Retry(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	action, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if action == nil {
+		return ErrOutboundActionNotFound
+	}
+	return s.attempt(ctx, *action)
+}
+
+// Discard removes an action without sending it.
+func (s *OutboxService) Discard(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Run replays due actions every interval until ctx is canceled.
+func (s *OutboxService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.processDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processDue sends every pending action whose next attempt has come.
+func (s *OutboxService) processDue(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due, err := s.store.ListDue(ctx, s.now().UTC())
+	if err != nil {
+		slog.Error("failed to list due outbound actions", "error", err)
+		return
+	}
+	for _, action := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.attempt(ctx, action); err != nil {
+			slog.Warn("outbound action failed", "id", action.ID, "kind", action.Kind, "repo", action.RepoFullName, "pr", action.PRNumber, "attempts", action.Attempts+1, "error", err)
+		}
+	}
+}
+
+// attempt sends action and records the outcome: the action is deleted on
+// success, rescheduled after a transient error, and marked failed after a
+// permanent one or when it runs out of attempts. Callers hold s.mu.
+func (s *OutboxService) attempt(ctx context.Context, action model.OutboundAction) error {
+	sendErr := s.send(ctx, action)
+	if sendErr == nil {
+		if err := s.store.Delete(ctx, action.ID); err != nil {
+			return fmt.Errorf("remove sent outbound action: %w", err)
+		}
+		return nil
+	}
+
+	action.Attempts++
+	action.LastError = sendErr.Error()
+	if errors.Is(sendErr, driven.ErrTransient) && action.Attempts < outboxMaxAttempts {
+		action.Status = model.OutboundPending
+		action.NextAttemptAt = s.now().UTC().Add(outboxBackoff(action.Attempts))
+	} else {
+		action.Status = model.OutboundFailed
+	}
+	if err := s.store.Update(ctx, action); err != nil {
+		slog.Error("failed to update outbound action", "id", action.ID, "error", err)
+	}
+	return sendErr
+}
+
+// send replays action against GitHub. A missing token counts as transient,
+// since the user may yet add one.
+func (s *OutboxService) send(ctx context.Context, action model.OutboundAction) error {
+	writer, err := s.writerFor(ctx, action.RepoFullName)
+	if err != nil {
+		return fmt.Errorf("%w: %w", driven.ErrTransient, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, outboxSendTimeout)
+	defer cancel()
+
+	switch action.Kind {
+	case model.OutboundReview:
+		var p reviewPayload
+		if err := json.Unmarshal([]byte(action.Payload), &p); err != nil {
+			return fmt.Errorf("decode review payload: %w", err)
+		}
+		return writer.SubmitReview(ctx, action.RepoFullName, action.PRNumber, driven.ReviewRequest{
+			CommitID: p.CommitID,
+			Event:    p.Event,
+			Body:     action.Body,
+			Comments: p.Comments,
+		})
+	case model.OutboundReply:
+		return writer.CreateReplyComment(ctx, action.RepoFullName, action.PRNumber, action.InReplyTo, action.Body)
+	case model.OutboundIssueComment:
+		return writer.CreateIssueComment(ctx, action.RepoFullName, action.PRNumber, action.Body)
+	default:
+		return fmt.Errorf("unknown outbound action kind %q", action.Kind)
+	}
+}
+
+// outboxBackoff returns the delay before the retry that follows attempts
+// failed attempts.
+func outboxBackoff(attempts int) time.Duration {
+	return retryBackoff(attempts, outboxBaseBackoff, outboxMaxBackoff)
+}
+
+// retryBackoff returns the delay before the retry that follows attempts
+// failed attempts: base after the first, doubling after each one, capped at
+// maxDelay.
+func retryBackoff(attempts int, base, maxDelay time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= maxDelay {
+			return maxDelay
+		}
+	}
+	return d
+}
@@ -0,0 +1,192 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memOutboxStore is an in-memory driven.OutboxStore.
+type memOutboxStore struct {
+	nextID  int64
+	actions map[int64]model.OutboundAction
+}
+
+func newMemOutboxStore() *memOutboxStore {
+	return &memOutboxStore{actions: make(map[int64]model.OutboundAction)}
+}
+
+func (m *memOutboxStore) Add(_ context.Context, a model.OutboundAction) (int64, error) {
+	m.nextID++
+	a.ID = m.nextID
+	m.actions[a.ID] = a
+	return a.ID, nil
+}
+
+func (m *memOutboxStore) Get(_ context.Context, id int64) (*model.OutboundAction, error) {
+	a, ok := m.actions[id]
+	if !ok {
+		return nil, nil
+	}
+	return &a, nil
+}
+
+func (m *memOutboxStore) List(_ context.Context) ([]model.OutboundAction, error) {
+	var list []model.OutboundAction
+	for _, a := range m.actions {
+		list = append(list, a)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID > list[j].ID })
+	return list, nil
+}
+
+func (m *memOutboxStore) ListDue(_ context.Context, now time.Time) ([]model.OutboundAction, error) {
+	var list []model.OutboundAction
+	for _, a := range m.actions {
+		if a.Status == model.OutboundPending && !a.NextAttemptAt.After(now) {
+			list = append(list, a)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (m *memOutboxStore) Update(_ context.Context, a model.OutboundAction) error {
+	m.actions[a.ID] = a
+	return nil
+}
+
+func (m *memOutboxStore) Delete(_ context.Context, id int64) error {
+	delete(m.actions, id)
+	return nil
+}
+
+// outboxWriter records replayed writes and fails them with err.
+type outboxWriter struct {
+	driven.GitHubWriter
+	err      error
+	reviews  []driven.ReviewRequest
+	replies  []string
+	comments []string
+}
+
+func (w *outboxWriter) SubmitReview(_ context.Context, _ string, _ int, req driven.ReviewRequest) error {
+	w.reviews = append(w.reviews, req)
+	return w.err
+}
+
+func (w *outboxWriter) CreateReplyComment(_ context.Context, _ string, _ int, inReplyTo int64, body string) error {
+	w.replies = append(w.replies, fmt.Sprintf("%d:%s", inReplyTo, body))
+	return w.err
+}
+
+func (w *outboxWriter) CreateIssueComment(_ context.Context, _ string, _ int, body string) error {
+	w.comments = append(w.comments, body)
+	return w.err
+}
+
+func newTestOutbox(writer *outboxWriter, now *time.Time) (*OutboxService, *memOutboxStore) {
+	store := newMemOutboxStore()
+	svc := NewOutboxService(store, func(context.Context, string) (driven.GitHubWriter, error) {
+		return writer, nil
+	})
+	svc.now = func() time.Time { return *now }
+	return svc, store
+}
+
+func TestOutboxService_ReplaysDueActions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	writer := &outboxWriter{}
+	svc, store := newTestOutbox(writer, &now)
+
+	cause := fmt.Errorf("boom: %w", driven.ErrTransient)
+	review := driven.ReviewRequest{CommitID: "abc", Event: "COMMENT", Body: "looks good", Comments: []driven.DraftLineComment{{Path: "a.go", Line: 3, Side: "RIGHT", Body: "nit"}}}
+	_, err := svc.EnqueueReview(ctx, "org/repo", 1, review, cause)
+	require.NoError(t, err)
+	_, err = svc.EnqueueReply(ctx, "org/repo", 1, 99, "thanks", cause)
+	require.NoError(t, err)
+
+	queued, err := svc.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, queued, 2)
+	assert.Equal(t, 1, queued[0].Attempts)
+	assert.Equal(t, "boom: transient GitHub error", queued[0].LastError)
+
+	// Not due yet.
+	svc.processDue(ctx)
+	assert.Empty(t, writer.reviews)
+
+	now = now.Add(outboxBaseBackoff)
+	svc.processDue(ctx)
+	require.Len(t, writer.reviews, 1)
+	assert.Equal(t, review, writer.reviews[0])
+	assert.Equal(t, []string{"99:thanks"}, writer.replies)
+	assert.Empty(t, store.actions, "sent actions are removed")
+}
+
+func TestOutboxService_TransientFailureBacksOff(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	writer := &outboxWriter{err: fmt.Errorf("502: %w", driven.ErrTransient)}
+	svc, store := newTestOutbox(writer, &now)
+
+	action, err := svc.EnqueueIssueComment(ctx, "org/repo", 1, "hello", writer.err)
+	require.NoError(t, err)
+
+	now = now.Add(outboxBaseBackoff)
+	svc.processDue(ctx)
+
+	got := store.actions[action.ID]
+	assert.Equal(t, model.OutboundPending, got.Status)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Equal(t, now.Add(2*outboxBaseBackoff), got.NextAttemptAt)
+
+	for range outboxMaxAttempts {
+		now = now.Add(outboxMaxBackoff)
+		svc.processDue(ctx)
+	}
+	got = store.actions[action.ID]
+	assert.Equal(t, model.OutboundFailed, got.Status, "gives up after the maximum attempts")
+	assert.Equal(t, outboxMaxAttempts, got.Attempts)
+	assert.Equal(t, "hello", got.Body, "composed text is kept")
+}
+
+func TestOutboxService_PermanentFailureMarksFailed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	writer := &outboxWriter{err: errors.New("422 validation failed")}
+	svc, store := newTestOutbox(writer, &now)
+
+	action, err := svc.EnqueueIssueComment(ctx, "org/repo", 1, "hello", driven.ErrTransient)
+	require.NoError(t, err)
+
+	err = svc.Retry(ctx, action.ID)
+	require.Error(t, err)
+	got := store.actions[action.ID]
+	assert.Equal(t, model.OutboundFailed, got.Status)
+	assert.Equal(t, "422 validation failed", got.LastError)
+
+	// A manual retry still sends failed actions.
+	writer.err = nil
+	require.NoError(t, svc.Retry(ctx, action.ID))
+	assert.Empty(t, store.actions)
+	assert.ErrorIs(t, svc.Retry(ctx, action.ID), ErrOutboundActionNotFound)
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, outboxBackoff(1))
+	assert.Equal(t, 2*time.Minute, outboxBackoff(2))
+	assert.Equal(t, 32*time.Minute, outboxBackoff(6))
+	assert.Equal(t, time.Hour, outboxBackoff(7))
+	assert.Equal(t, time.Hour, outboxBackoff(20))
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Poll pacing tuning.
+const (
+	// pacingReserveShare is the share of the rate limit kept back for
+	// manual refreshes and actions taken from the dashboard.
+	pacingReserveShare = 0.1
+	// defaultCallsPerPoll is the assumed API cost of one repo poll until
+	// polls have been measured.
+	defaultCallsPerPoll = 10.0
+	// callsPerPollWeight is the weight of the newest measurement in the
+	// running average of API calls per poll.
+	callsPerPollWeight = 0.2
+	// pollSpread is the part of each one-minute tick across which scheduled
+	// polls are spread, and pollJitter the randomized share of each slot.
+	pollSpread = 45 * time.Second
+	pollJitter = 0.25
+)
+
+// PollPacing is the poll loop's latest pacing decision, for status
+// endpoints. Budget is -1 when the rate limit is unknown and polling is not
+// capped.
+type PollPacing struct {
+	DecidedAt     time.Time
+	Due           int
+	Budget        int
+	Polled        int
+	Deferred      int
+	CallsPerPoll  float64
+	RateRemaining int
+	RateReset     time.Time
+	// Reason explains a cap: "rate limit" or "github degraded"; empty when
+	// every due repo was polled.
+	Reason string
+}
+
+// pacerState is the pacing bookkeeping shared by pollDueRepos and Pacing.
+type pacerState struct {
+	mu           sync.Mutex
+	last         PollPacing
+	callsPerPoll float64 // running average; 0 until the first measurement
+	credit       float64 // polls the rate limit has allowed but not yet used
+}
+
+// Pacing returns the poll loop's latest pacing decision. It is zero before
+// the first scheduled tick.
+func (s *PollService) Pacing() PollPacing {
+	s.pacer.mu.Lock()
+	defer s.pacer.mu.Unlock()
+	return s.pacer.last
+}
+
+// pollBudget returns how many due repos this tick may poll so the remaining
+// rate limit, minus pacingReserveShare, lasts until it resets. The allowance
+// accrues as fractional credit, so a budget under one poll a minute still
+// polls every few ticks; unused credit is capped to avoid bursts. It returns
+// -1 when the rate limit is unknown or has already reset.
+func (s *PollService) pollBudget(now time.Time) (budget int, remaining int, reset time.Time) {
+	status, ok := s.GitHubStatus()
+	if !ok || status.RateLimit == 0 || !status.RateReset.After(now) {
+		return -1, status.RateRemaining, status.RateReset
+	}
+
+	s.pacer.mu.Lock()
+	defer s.pacer.mu.Unlock()
+
+	calls := s.pacer.callsPerPoll
+	if calls <= 0 {
+		calls = defaultCallsPerPoll
+	}
+	usable := float64(status.RateRemaining) - pacingReserveShare*float64(status.RateLimit)
+	if usable <= 0 {
+		s.pacer.credit = 0
+		return 0, status.RateRemaining, status.RateReset
+	}
+
+	ticks := math.Ceil(status.RateReset.Sub(now).Minutes())
+	perTick := usable / calls / ticks
+	s.pacer.credit = math.Min(s.pacer.credit+perTick, math.Max(perTick, 1))
+	return int(s.pacer.credit), status.RateRemaining, status.RateReset
+}
+
+// spendPollCredit records that polled repos used up their share of the
+// pacing credit.
+func (s *PollService) spendPollCredit(polled int) {
+	s.pacer.mu.Lock()
+	defer s.pacer.mu.Unlock()
+	s.pacer.credit = math.Max(s.pacer.credit-float64(polled), 0)
+}
+
+// measurePoll folds one poll's API cost into the calls-per-poll average,
+// from the active client's remaining quota before and after. Polls routed
+// to another account, or spanning a rate-limit reset, are not measured.
+func (s *PollService) measurePoll(poll func()) {
+	before, ok := s.GitHubStatus()
+	poll()
+	after, _ := s.GitHubStatus()
+	if !ok || !after.ObservedAt.After(before.ObservedAt) || !after.RateReset.Equal(before.RateReset) {
+		return
+	}
+	calls := float64(before.RateRemaining - after.RateRemaining)
+	if calls < 0 {
+		return
+	}
+
+	s.pacer.mu.Lock()
+	defer s.pacer.mu.Unlock()
+	if s.pacer.callsPerPoll == 0 {
+		s.pacer.callsPerPoll = calls
+		return
+	}
+	s.pacer.callsPerPoll += callsPerPollWeight * (calls - s.pacer.callsPerPoll)
+}
+
+// recordPacing stores and logs a tick's pacing decision.
+func (s *PollService) recordPacing(p PollPacing) {
+	s.pacer.mu.Lock()
+	p.CallsPerPoll = s.pacer.callsPerPoll
+	s.pacer.last = p
+	s.pacer.mu.Unlock()
+
+	slog.Debug("poll pacing",
+		"due", p.Due,
+		"budget", p.Budget,
+		"polled", p.Polled,
+		"deferred", p.Deferred,
+		"calls_per_poll", math.Round(p.CallsPerPoll*10)/10,
+		"rate_remaining", p.RateRemaining,
+		"rate_reset", p.RateReset.Format(time.RFC3339),
+		"reason", p.Reason,
+	)
+}
+
+// pollSlot returns when the i-th of n polls in a tick should start: evenly
+// spread across pollSpread from start, each slot shifted by up to
+// pollJitter of its width so repos do not hit GitHub in lockstep.
+func pollSlot(start time.Time, i, n int) time.Time {
+	if n <= 1 || i == 0 {
+		return start
+	}
+	width := pollSpread / time.Duration(n)
+	//nolint:gosec // jitter only spreads load; it needs no cryptographic randomness
+	jitter := time.Duration((rand.Float64()*2 - 1) * pollJitter * float64(width))
+	return start.Add(time.Duration(i)*width + jitter)
+}
+
+// waitUntil sleeps until t while still serving manual refresh requests. It
+// returns false when ctx is canceled first.
+func (s *PollService) waitUntil(ctx context.Context, t time.Time) bool {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		case req := <-s.refreshCh:
+			req.done <- s.handleRefresh(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// statusClient is a GitHub client that only reports API status.
+type statusClient struct {
+	driven.GitHubClient
+	status model.GitHubAPIStatus
+}
+
+func (c *statusClient) APIStatus() model.GitHubAPIStatus { return c.status }
+
+func TestPollBudget(t *testing.T) {
+	now := time.Now()
+
+	t.Run("unlimited without rate limit data", func(t *testing.T) {
+		svc := &PollService{ghClient: &statusClient{}}
+		budget, _, _ := svc.pollBudget(now)
+		assert.Equal(t, -1, budget)
+	})
+
+	t.Run("spreads remaining quota until reset", func(t *testing.T) {
+		// 5000 remaining minus a 500 reserve, at 10 calls per poll over
+		// 30 minutes, allows 15 polls a tick.
+		svc := &PollService{ghClient: &statusClient{status: model.GitHubAPIStatus{
+			RateLimit: 5000, RateRemaining: 5000, RateReset: now.Add(30 * time.Minute),
+		}}}
+		budget, remaining, _ := svc.pollBudget(now)
+		assert.Equal(t, 15, budget)
+		assert.Equal(t, 5000, remaining)
+	})
+
+	t.Run("accrues credit below one poll a tick", func(t *testing.T) {
+		// 600 remaining minus 500 reserved, at 10 calls per poll over 20
+		// minutes, allows half a poll a tick.
+		svc := &PollService{ghClient: &statusClient{status: model.GitHubAPIStatus{
+			RateLimit: 5000, RateRemaining: 600, RateReset: now.Add(20 * time.Minute),
+		}}}
+		budget, _, _ := svc.pollBudget(now)
+		assert.Equal(t, 0, budget)
+		budget, _, _ = svc.pollBudget(now)
+		assert.Equal(t, 1, budget)
+		svc.spendPollCredit(1)
+		budget, _, _ = svc.pollBudget(now)
+		assert.Equal(t, 0, budget)
+	})
+
+	t.Run("nothing left beyond the reserve", func(t *testing.T) {
+		svc := &PollService{ghClient: &statusClient{status: model.GitHubAPIStatus{
+			RateLimit: 5000, RateRemaining: 400, RateReset: now.Add(10 * time.Minute),
+		}}}
+		budget, _, _ := svc.pollBudget(now)
+		assert.Equal(t, 0, budget)
+	})
+}
+
+func TestMeasurePoll(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	client := &statusClient{status: model.GitHubAPIStatus{RateLimit: 5000, RateRemaining: 4000, RateReset: reset, ObservedAt: time.Now()}}
+	svc := &PollService{ghClient: client}
+
+	poll := func(calls int) func() {
+		return func() {
+			client.status.RateRemaining -= calls
+			client.status.ObservedAt = client.status.ObservedAt.Add(time.Second)
+		}
+	}
+
+	svc.measurePoll(poll(20))
+	assert.InDelta(t, 20, svc.pacer.callsPerPoll, 0.001)
+	svc.measurePoll(poll(10))
+	assert.InDelta(t, 18, svc.pacer.callsPerPoll, 0.001)
+
+	svc.measurePoll(func() {}) // routed to another account: no response on this client
+	assert.InDelta(t, 18, svc.pacer.callsPerPoll, 0.001)
+}
+
+func TestPollSlot(t *testing.T) {
+	start := time.Now()
+	assert.Equal(t, start, pollSlot(start, 0, 5))
+
+	width := pollSpread / 5
+	for i := 1; i < 5; i++ {
+		slot := pollSlot(start, i, 5)
+		center := start.Add(time.Duration(i) * width)
+		assert.WithinDuration(t, center, slot, time.Duration(pollJitter*float64(width)))
+	}
+}
@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// pendingCommentTTL bounds how long a local echo waits for GitHub's copy.
+// Past it the echo is dropped even unmatched, e.g. after the comment was
+// edited or deleted on GitHub before the next poll.
+const pendingCommentTTL = 15 * time.Minute
+
+// WithPendingCommentStore makes EchoReply and EchoIssueComment store local
+// echoes of comments posted from the app.
+func (s *ReviewService) WithPendingCommentStore(store driven.PendingCommentStore) *ReviewService {
+	s.pending = store
+	return s
+}
+
+// EchoReply stores a pending copy of a reply the user just posted to the
+// thread rooted at rootID, so the thread shows it before the next poll. The
+// echo takes its file position from the root comment. It is a no-op without a
+// pending store, or when the poll loop already synced GitHub's copy.
+func (s *ReviewService) EchoReply(ctx context.Context, prID, rootID int64, author, body string) error {
+	if s.pending == nil {
+		return nil
+	}
+
+	comments, err := s.reviewStore.GetReviewCommentsByPR(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	echo := model.ReviewComment{
+		PRID:        prID,
+		Author:      author,
+		Body:        body,
+		InReplyToID: &rootID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, c := range comments {
+		if c.ID == rootID {
+			echo.Path = c.Path
+			echo.Line = c.Line
+			echo.StartLine = c.StartLine
+			echo.Side = c.Side
+			echo.SubjectType = c.SubjectType
+			echo.DiffHunk = c.DiffHunk
+			echo.CommitID = c.CommitID
+		}
+		if !c.PendingSync && now.Sub(c.CreatedAt) < pendingCommentTTL && sameReviewComment(c, echo) {
+			return nil
+		}
+	}
+
+	_, err = s.pending.AddPendingReviewComment(ctx, echo)
+	return err
+}
+
+// EchoIssueComment stores a pending copy of a PR comment the user just
+// posted; see EchoReply.
+func (s *ReviewService) EchoIssueComment(ctx context.Context, prID int64, author, body string) error {
+	if s.pending == nil {
+		return nil
+	}
+
+	comments, err := s.reviewStore.GetIssueCommentsByPR(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	echo := model.IssueComment{PRID: prID, Author: author, Body: body, CreatedAt: now, UpdatedAt: now}
+	for _, c := range comments {
+		if !c.PendingSync && now.Sub(c.CreatedAt) < pendingCommentTTL && sameIssueComment(c, echo) {
+			return nil
+		}
+	}
+
+	_, err = s.pending.AddPendingIssueComment(ctx, echo)
+	return err
+}
+
+// WithPendingCommentStore makes the poll loop replace local comment echoes
+// with the copies it fetches from GitHub.
+func (s *PollService) WithPendingCommentStore(store driven.PendingCommentStore) *PollService {
+	s.pending = store
+	return s
+}
+
+// reconcilePendingReviewComments removes pending review comments on pr that
+// fetched, the PR's review comments as just returned by GitHub, now contains,
+// and those older than pendingCommentTTL.
+func (s *PollService) reconcilePendingReviewComments(ctx context.Context, pr model.PullRequest, fetched []model.ReviewComment) {
+	if s.pending == nil {
+		return
+	}
+
+	stored, err := s.reviewStore.GetReviewCommentsByPR(ctx, pr.ID)
+	if err != nil {
+		slog.Warn("failed to load review comments for reconciliation", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+
+	matched := make(map[int64]bool)
+	for _, local := range stored {
+		if !local.PendingSync {
+			continue
+		}
+		synced := time.Since(local.CreatedAt) > pendingCommentTTL
+		for _, remote := range fetched {
+			if !matched[remote.ID] && sameReviewComment(remote, local) {
+				matched[remote.ID] = true
+				synced = true
+				break
+			}
+		}
+		if !synced {
+			continue
+		}
+		if err := s.pending.DeletePendingReviewComment(ctx, local.ID); err != nil {
+			slog.Error("delete pending review comment failed", "repo", pr.RepoFullName, "pr", pr.Number, "comment", local.ID, "error", err)
+		}
+	}
+}
+
+// reconcilePendingIssueComments is reconcilePendingReviewComments for issue comments.
+func (s *PollService) reconcilePendingIssueComments(ctx context.Context, pr model.PullRequest, fetched []model.IssueComment) {
+	if s.pending == nil {
+		return
+	}
+
+	stored, err := s.reviewStore.GetIssueCommentsByPR(ctx, pr.ID)
+	if err != nil {
+		slog.Warn("failed to load issue comments for reconciliation", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+
+	matched := make(map[int64]bool)
+	for _, local := range stored {
+		if !local.PendingSync {
+			continue
+		}
+		synced := time.Since(local.CreatedAt) > pendingCommentTTL
+		for _, remote := range fetched {
+			if !matched[remote.ID] && sameIssueComment(remote, local) {
+				matched[remote.ID] = true
+				synced = true
+				break
+			}
+		}
+		if !synced {
+			continue
+		}
+		if err := s.pending.DeletePendingIssueComment(ctx, local.ID); err != nil {
+			slog.Error("delete pending issue comment failed", "repo", pr.RepoFullName, "pr", pr.Number, "comment", local.ID, "error", err)
+		}
+	}
+}
+
+// sameReviewComment reports whether a and b look like the same reply: same
+// author, thread, and body.
+func sameReviewComment(a, b model.ReviewComment) bool {
+	if (a.InReplyToID == nil) != (b.InReplyToID == nil) ||
+		(a.InReplyToID != nil && *a.InReplyToID != *b.InReplyToID) {
+		return false
+	}
+	return strings.EqualFold(a.Author, b.Author) && sameCommentBody(a.Body, b.Body)
+}
+
+// sameIssueComment reports whether a and b have the same author and body.
+func sameIssueComment(a, b model.IssueComment) bool {
+	return strings.EqualFold(a.Author, b.Author) && sameCommentBody(a.Body, b.Body)
+}
+
+// sameCommentBody compares bodies the way GitHub stores them, ignoring line
+// ending style and surrounding whitespace.
+func sameCommentBody(a, b string) bool {
+	normalize := func(s string) string {
+		return strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	}
+	return normalize(a) == normalize(b)
+}
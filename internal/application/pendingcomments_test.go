@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// testPendingStore adds PendingCommentStore to testReviewStore, keeping
+// pending comments in the same slices the getters return.
+type testPendingStore struct {
+	testReviewStore
+	nextID int64
+}
+
+func (m *testPendingStore) AddPendingReviewComment(_ context.Context, c model.ReviewComment) (int64, error) {
+	m.nextID--
+	c.ID = m.nextID
+	c.PendingSync = true
+	m.reviewComments = append(m.reviewComments, c)
+	return c.ID, nil
+}
+
+func (m *testPendingStore) AddPendingIssueComment(_ context.Context, c model.IssueComment) (int64, error) {
+	m.nextID--
+	c.ID = m.nextID
+	c.PendingSync = true
+	m.issueComments = append(m.issueComments, c)
+	return c.ID, nil
+}
+
+func (m *testPendingStore) DeletePendingReviewComment(_ context.Context, id int64) error {
+	for i, c := range m.reviewComments {
+		if c.ID == id && c.PendingSync {
+			m.reviewComments = append(m.reviewComments[:i], m.reviewComments[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *testPendingStore) DeletePendingIssueComment(_ context.Context, id int64) error {
+	for i, c := range m.issueComments {
+		if c.ID == id && c.PendingSync {
+			m.issueComments = append(m.issueComments[:i], m.issueComments[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func TestEchoReply_ReconciledOnPoll(t *testing.T) {
+	ctx := context.Background()
+	rootID := int64(100)
+	store := &testPendingStore{testReviewStore: testReviewStore{reviewComments: []model.ReviewComment{
+		{ID: rootID, PRID: 1, Author: "alice", Body: "why?", Path: "main.go", Line: 7, CommitID: "abc", CreatedAt: time.Now().Add(-time.Hour)},
+	}}}
+	reviewSvc := NewReviewService(store, &testBotConfigStore{}).WithPendingCommentStore(store)
+
+	require.NoError(t, reviewSvc.EchoReply(ctx, 1, rootID, "me", "because"))
+	require.Len(t, store.reviewComments, 2)
+	echo := store.reviewComments[1]
+	assert.True(t, echo.PendingSync)
+	assert.Equal(t, "main.go", echo.Path, "position comes from the root comment")
+	assert.Equal(t, 7, echo.Line)
+
+	pollSvc := &PollService{reviewStore: store}
+	pollSvc.WithPendingCommentStore(store)
+	pr := model.PullRequest{ID: 1, RepoFullName: "org/repo", Number: 5}
+
+	// GitHub has not caught up yet: the echo stays.
+	pollSvc.reconcilePendingReviewComments(ctx, pr, store.reviewComments[:1])
+	assert.Len(t, store.reviewComments, 2)
+
+	synced := model.ReviewComment{ID: 101, PRID: 1, Author: "Me", Body: "because\r\n", InReplyToID: &rootID}
+	pollSvc.reconcilePendingReviewComments(ctx, pr, []model.ReviewComment{store.reviewComments[0], synced})
+	require.Len(t, store.reviewComments, 1)
+	assert.Equal(t, rootID, store.reviewComments[0].ID)
+}
+
+func TestEchoIssueComment(t *testing.T) {
+	ctx := context.Background()
+	store := &testPendingStore{}
+	reviewSvc := NewReviewService(store, &testBotConfigStore{}).WithPendingCommentStore(store)
+
+	t.Run("skipped when already synced", func(t *testing.T) {
+		store.issueComments = []model.IssueComment{{ID: 9, PRID: 1, Author: "me", Body: "hi", CreatedAt: time.Now()}}
+		require.NoError(t, reviewSvc.EchoIssueComment(ctx, 1, "me", "hi"))
+		assert.Len(t, store.issueComments, 1)
+	})
+
+	t.Run("stale echoes expire", func(t *testing.T) {
+		store.issueComments = nil
+		require.NoError(t, reviewSvc.EchoIssueComment(ctx, 1, "me", "hi"))
+		require.Len(t, store.issueComments, 1)
+
+		pollSvc := &PollService{reviewStore: store, pending: store}
+		pr := model.PullRequest{ID: 1}
+		pollSvc.reconcilePendingIssueComments(ctx, pr, nil)
+		assert.Len(t, store.issueComments, 1)
+
+		store.issueComments[0].CreatedAt = time.Now().Add(-2 * pendingCommentTTL)
+		pollSvc.reconcilePendingIssueComments(ctx, pr, nil)
+		assert.Empty(t, store.issueComments)
+	})
+}
@@ -3,7 +3,9 @@ package application
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,25 +18,68 @@ import (
 type refreshRequest struct {
 	repoFullName string
 	prNumber     int
+	fullHistory  bool // fetch closed PRs past the lookback window (see RefreshRepoFullHistory)
 	done         chan error
 }
 
 // PollService orchestrates periodic GitHub polling, PR discovery,
 // and persistence.
 type PollService struct {
-	ghClient      driven.GitHubClient
-	startupClient driven.GitHubClient // original client used as fallback when token is unavailable
-	activeToken   string              // last token used to build ghClient; "" means startup client is active
-	prStore       driven.PRStore
-	repoStore     driven.RepoStore
-	reviewStore   driven.ReviewStore
-	checkStore    driven.CheckStore
-	username      string
-	teamSlugs     []string
-	interval      time.Duration
-	refreshCh     chan refreshRequest
-	tokenProvider func(ctx context.Context) (string, error) // optional; re-reads token each cycle
-	clientFactory func(token string) driven.GitHubClient    // optional; creates a new GitHub client with the given token
+	ghClient       driven.GitHubClient
+	startupClient  driven.GitHubClient // original client used as fallback when token is unavailable
+	activeToken    string              // last token used to build ghClient; "" means startup client is active
+	prStore        driven.PRStore
+	repoStore      driven.RepoStore
+	reviewStore    driven.ReviewStore
+	checkStore     driven.CheckStore
+	username       string
+	refreshCh      chan refreshRequest
+	tokenProvider  func(ctx context.Context) (string, error) // optional; re-reads token each cycle
+	clientFactory  func(token string) driven.GitHubClient    // optional; creates a new GitHub client with the given token
+	repoRenamer    driven.RepoRenamer                        // optional; migrates repos GitHub reports as moved
+	repoAccounts   driven.GitHubRepoAccountStore             // optional; routes repos to named account tokens
+	protections    driven.BranchProtectionStore              // optional; persists review rules per branch
+	mentions       driven.MentionStore                       // optional; records @mentions in comment bodies
+	syncRecorder   driven.RepoSyncRecorder                   // optional; persists each repo's last successful poll
+	pending        driven.PendingCommentStore                // optional; local echoes replaced by synced comments
+	events         *EventHub                                 // optional; receives PR change events
+	lint           *LintService                              // optional; records title and branch rule violations
+	checklists     driven.ChecklistStore                     // optional; records task list progress
+	repoMetadata   driven.RepoMetadataStore                  // optional; records repo descriptions, visibility, and open PR counts
+	statusPage     driven.GitHubStatusPage                   // optional; reports GitHub API incidents
+	repoSettings   driven.RepoSettingsStore                  // optional; overrides which checks are required or counted
+	journal        driven.PRJournalStore                     // optional; appends stored-state changes to the event journal
+	reviewRequests driven.ReviewRequestStore                 // optional; records when pending review requests were made
+	signalCache    *AttentionService                         // optional; drops cached attention signals of synced PRs
+	reviewRollups  *ReviewRollupService                      // optional; stores each synced PR's aggregated review state
+	// archiveRetention skips merged and closed PRs older than the archive
+	// keeps (see WithArchiveRetention); zero stores every PR.
+	archiveRetention time.Duration
+	// closedLookback limits each poll to closed PRs updated this recently;
+	// zero fetches them all. fullHistory holds the repos whose next poll
+	// fetches them all anyway (see RefreshRepoFullHistory); it is only used
+	// from the Start goroutine.
+	closedLookback time.Duration
+	fullHistory    map[string]bool
+	// relevantOnly stores only PRs that involve the user in repositories
+	// without a PR scope of their own (see WithRelevantPRsOnly).
+	relevantOnly bool
+	// eventAttention and lastSignals back attention.changed events (see publishAttentionChange).
+	eventAttention *AttentionService
+	lastSignals    map[int64]model.AttentionSignals
+
+	// outage tracks GitHub API incidents that slow scheduled polling (see outage.go).
+	outage outageState
+	// pacer spreads scheduled polls to fit the rate limit (see pacing.go).
+	pacer pacerState
+	// secondaryLimit holds GitHub's latest secondary rate limit (see secondarylimit.go).
+	secondaryLimit secondaryLimitState
+
+	// accountClients caches one client per named GitHub account (see clientForRepo).
+	accountClients map[int64]accountClient
+	// providers holds the clients for repositories hosted outside GitHub (see
+	// WithSCMProvider); nil polls every repository from GitHub.
+	providers map[model.Provider]providerClient
 
 	// branchProtectionCache caches required status check contexts per
 	// "repo/branch" key during a poll cycle. Branch protection rarely changes,
@@ -48,6 +93,28 @@ type PollService struct {
 	// schedules holds per-repository adaptive polling state. Each repo is
 	// classified into an activity tier that determines its polling frequency.
 	schedules map[string]repoSchedule
+
+	// statusMu guards the fields below plus writes to ghClient, so health probes
+	// can read loop progress and the active client from HTTP goroutines. The
+	// Start goroutine is the only writer and reads ghClient without locking.
+	statusMu       sync.RWMutex
+	startedAt      time.Time
+	lastProgressAt time.Time
+
+	// settingsMu guards the settings that can be changed while running (see
+	// UpdateSettings).
+	settingsMu sync.RWMutex
+	teamSlugs  []string
+	interval   time.Duration
+
+	// teamsChanged wakes the poll loop to recompute NeedsReview from the
+	// stored review requests after UpdateSettings changes the team slugs.
+	teamsChanged chan struct{}
+
+	// drainGrace is how long an in-flight PR sync may keep running after
+	// shutdown begins (see syncPR). drained is closed when Start returns.
+	drainGrace time.Duration
+	drained    chan struct{}
 }
 
 // NewPollService creates a new PollService with all required dependencies.
@@ -69,28 +136,43 @@ func NewPollService(
 	tokenProvider func(ctx context.Context) (string, error), // may be nil
 	clientFactory func(token string) driven.GitHubClient, // may be nil
 ) *PollService {
-	return &PollService{
-		ghClient:      ghClient,
-		startupClient: ghClient,
-		prStore:       prStore,
-		repoStore:     repoStore,
-		reviewStore:   reviewStore,
-		checkStore:    checkStore,
-		username:      username,
-		teamSlugs:     teamSlugs,
-		interval:      interval,
-		refreshCh:     make(chan refreshRequest),
-		schedules:     make(map[string]repoSchedule),
-		tokenProvider: tokenProvider,
-		clientFactory: clientFactory,
-	}
+	s := &PollService{
+		ghClient:       ghClient,
+		startupClient:  ghClient,
+		prStore:        prStore,
+		repoStore:      repoStore,
+		reviewStore:    reviewStore,
+		checkStore:     checkStore,
+		username:       username,
+		teamSlugs:      teamSlugs,
+		interval:       interval,
+		refreshCh:      make(chan refreshRequest),
+		teamsChanged:   make(chan struct{}, 1),
+		schedules:      make(map[string]repoSchedule),
+		accountClients: make(map[int64]accountClient),
+		fullHistory:    make(map[string]bool),
+		tokenProvider:  tokenProvider,
+		clientFactory:  clientFactory,
+		drainGrace:     defaultDrainGrace,
+		drained:        make(chan struct{}),
+	}
+	s.watchSecondaryRateLimit(ghClient)
+	return s
 }
 
 // Start begins the polling loop. It runs an immediate full poll to initialize
 // schedules, then uses a 1-minute resolution ticker with per-repo adaptive
 // scheduling. It also listens for manual refresh requests. Start blocks until
-// the context is canceled.
+// the context is canceled and any in-flight PR sync has finished or been
+// rolled back, then closes the channel returned by Drained.
 func (s *PollService) Start(ctx context.Context) {
+	defer close(s.drained)
+
+	s.statusMu.Lock()
+	s.startedAt = time.Now()
+	s.lastProgressAt = s.startedAt
+	s.statusMu.Unlock()
+
 	// Initial poll fetches all repos and initializes adaptive schedules.
 	if err := s.pollAll(ctx); err != nil {
 		slog.Error("initial poll failed", "error", err)
@@ -107,12 +189,15 @@ func (s *PollService) Start(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
-			slog.Info("poll service stopped")
+			slog.Info("poll service drained")
 			return
 		case <-ticker.C:
+			s.markProgress()
 			s.pollDueRepos(ctx)
 		case req := <-s.refreshCh:
 			req.done <- s.handleRefresh(ctx, req)
+		case <-s.teamsChanged:
+			s.recomputeNeedsReview(ctx)
 		}
 	}
 }
@@ -123,17 +208,86 @@ func (s *PollService) Schedules() map[string]ScheduleInfo {
 	s.schedulesMu.RLock()
 	defer s.schedulesMu.RUnlock()
 
+	now := time.Now()
 	result := make(map[string]ScheduleInfo, len(s.schedules))
 	for repo, sched := range s.schedules {
 		result[repo] = ScheduleInfo{
-			Tier:       sched.tier,
-			NextPollAt: sched.nextPollAt,
-			LastPolled: sched.lastPolled,
+			Tier:                sched.tier,
+			NextPollAt:          sched.nextPollAt,
+			LastPolled:          sched.lastPolled,
+			Circuit:             circuitState(sched, now),
+			ConsecutiveFailures: sched.failures,
+			LastError:           sched.lastError,
+			LastFailedAt:        sched.lastFailedAt,
+			MovedTo:             sched.movedTo,
 		}
 	}
 	return result
 }
 
+// LoopStatus reports when the poll loop started and when it last made
+// progress (a tick or a completed repo poll). Both are zero until Start runs.
+// A lastProgressAt that stops advancing means the loop is wedged.
+func (s *PollService) LoopStatus() (startedAt, lastProgressAt time.Time) {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+	return s.startedAt, s.lastProgressAt
+}
+
+// GitHubStatus returns the rate-limit and credential state of the active GitHub
+// client. ok is false when the client does not track API status.
+func (s *PollService) GitHubStatus() (status model.GitHubAPIStatus, ok bool) {
+	s.statusMu.RLock()
+	client := s.ghClient
+	s.statusMu.RUnlock()
+
+	reporter, ok := client.(driven.GitHubStatusReporter)
+	if !ok {
+		return model.GitHubAPIStatus{}, false
+	}
+	return reporter.APIStatus(), true
+}
+
+// markProgress records that the poll loop is alive.
+func (s *PollService) markProgress() {
+	s.statusMu.Lock()
+	s.lastProgressAt = time.Now()
+	s.statusMu.Unlock()
+}
+
+// setClient swaps the active GitHub client under statusMu.
+func (s *PollService) setClient(client driven.GitHubClient, token string) {
+	s.statusMu.Lock()
+	s.ghClient = client
+	s.statusMu.Unlock()
+	s.activeToken = token
+	s.watchSecondaryRateLimit(client)
+}
+
+// UpdateSettings replaces the team slugs and poll interval while the service
+// runs, for config file reloads. When the teams change, NeedsReview is
+// recomputed for every stored PR without waiting for the next repo poll.
+func (s *PollService) UpdateSettings(interval time.Duration, teamSlugs []string) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	s.interval = interval
+	if slices.Equal(s.teamSlugs, teamSlugs) {
+		return
+	}
+	s.teamSlugs = teamSlugs
+	select {
+	case s.teamsChanged <- struct{}{}:
+	default: // a recompute is already pending
+	}
+}
+
+// currentTeamSlugs returns the team slugs in effect.
+func (s *PollService) currentTeamSlugs() []string {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.teamSlugs
+}
+
 // RefreshRepo triggers a manual refresh for a specific repository, bypassing
 // the polling interval. It blocks until the refresh completes or the context
 // is canceled.
@@ -158,9 +312,9 @@ func (s *PollService) RefreshRepo(ctx context.Context, repoFullName string) erro
 	}
 }
 
-// RefreshPR triggers a manual refresh for a specific PR's repository.
-// The PR number is logged for audit but the full repository is re-fetched
-// since we do not have a single-PR fetch endpoint in the port interface.
+// RefreshPR triggers a manual refresh of a single PR: it is re-fetched with
+// its reviews and checks without re-polling the rest of the repository. It
+// blocks until the refresh completes or the context is canceled.
 func (s *PollService) RefreshPR(ctx context.Context, repoFullName string, prNumber int) error {
 	slog.Info("manual PR refresh requested", "repo", repoFullName, "pr_number", prNumber)
 
@@ -196,20 +350,17 @@ func (s *PollService) maybeRefreshToken(ctx context.Context) {
 	token, err := s.tokenProvider(ctx)
 	if err != nil {
 		slog.Debug("token provider error; reverting to startup client", "error", err)
-		s.ghClient = s.startupClient
-		s.activeToken = ""
+		s.setClient(s.startupClient, "")
 		return
 	}
 	if token == "" {
-		s.ghClient = s.startupClient
-		s.activeToken = ""
+		s.setClient(s.startupClient, "")
 		return
 	}
 	if token == s.activeToken {
 		return // client already up to date; skip redundant allocation
 	}
-	s.ghClient = s.clientFactory(token)
-	s.activeToken = token
+	s.setClient(s.clientFactory(token), token)
 	slog.Debug("github client hot-swapped with token from credential store")
 }
 
@@ -233,13 +384,18 @@ func (s *PollService) pollAll(ctx context.Context) error {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
+		if repo.Paused {
+			continue
+		}
+		if s.abortForRateLimit(time.Now()) {
+			break
+		}
 
-		if err := s.pollRepo(ctx, repo.FullName); err != nil {
+		if err := s.pollAndSchedule(ctx, repo.FullName); err != nil {
 			slog.Error("repo poll failed", "repo", repo.FullName, "error", err)
 			pollErrors++
-		} else {
-			s.updateSchedule(ctx, repo.FullName)
 		}
+		s.markProgress()
 	}
 
 	slog.Info("poll cycle complete",
@@ -251,11 +407,42 @@ func (s *PollService) pollAll(ctx context.Context) error {
 	return nil
 }
 
+// pollAndSchedule polls a repo and reschedules it: on success by activity
+// tier, on failure by exponential backoff (see recordPollFailure).
+func (s *PollService) pollAndSchedule(ctx context.Context, repoFullName string) error {
+	err := s.pollRepo(ctx, repoFullName)
+
+	// A moved repo is migrated to its new name and polled there right away.
+	var moved *driven.RepoMovedError
+	if errors.As(err, &moved) && s.migrateMovedRepo(ctx, moved) {
+		repoFullName = moved.To
+		err = s.pollRepo(ctx, repoFullName)
+	}
+
+	if ctx.Err() == nil {
+		s.recordPollOutcome(err, time.Now())
+	}
+	if err != nil {
+		if ctx.Err() == nil {
+			s.recordPollFailure(repoFullName, err)
+		}
+		return err
+	}
+	s.updateSchedule(ctx, repoFullName)
+	s.recordSync(ctx, repoFullName)
+	return nil
+}
+
 // pollRepo is the core PR discovery logic for a single repository.
-// It fetches all PRs (open, closed, merged) and stores them unconditionally.
-// NeedsReview is still computed to flag PRs where the user is a requested reviewer.
+// It fetches all PRs (open, closed, merged) and stores them, or only those
+// that involve the user when the repository's PR scope is relevant.
+// NeedsReview is computed to flag PRs where the user is a requested reviewer.
 func (s *PollService) pollRepo(ctx context.Context, repoFullName string) error {
-	prs, err := s.ghClient.FetchPullRequests(ctx, repoFullName, "all")
+	gh, username := s.clientForRepo(ctx, repoFullName)
+	teamSlugs := s.currentTeamSlugs()
+	relevantOnly := s.storesRelevantOnly(ctx, repoFullName)
+
+	prs, err := s.fetchPRs(ctx, gh, repoFullName)
 	if err != nil {
 		return err
 	}
@@ -271,35 +458,39 @@ func (s *PollService) pollRepo(ctx context.Context, repoFullName string) error {
 	}
 
 	fetchedNumbers := make(map[int]bool, len(prs))
-	var skippedUnchanged int
+	var skippedUnchanged, skippedIrrelevant int
 
 	for _, pr := range prs {
+		// Checkpoint: once shutdown begins, stop before starting another PR.
+		// Stale cleanup is skipped too, since not every PR was looked at.
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
 		fetchedNumbers[pr.Number] = true
+		if s.beyondRetention(pr) {
+			continue
+		}
 
-		pr.NeedsReview = IsReviewRequestedFrom(pr, s.username, s.teamSlugs)
+		pr.NeedsReview = IsReviewRequestedFrom(pr, username, teamSlugs)
 		pr.JiraKey = ExtractJiraKey(pr.Branch, pr.Title)
 
-		if stored, ok := storedByNumber[pr.Number]; ok {
+		stored, ok := storedByNumber[pr.Number]
+		if !ok && relevantOnly && !IsRelevantTo(pr, username, teamSlugs) {
+			skippedIrrelevant++
+			continue
+		}
+
+		var previous *model.PullRequest
+		if ok {
 			if stored.UpdatedAt.Equal(pr.UpdatedAt) && stored.NeedsReview == pr.NeedsReview && stored.JiraKey == pr.JiraKey {
 				skippedUnchanged++
 				continue
 			}
+			previous = &stored
 		}
 
-		if err := s.prStore.Upsert(ctx, pr); err != nil {
-			slog.Error("upsert failed", "repo", repoFullName, "pr", pr.Number, "error", err)
-			continue
-		}
-
-		// Fetch review and health data for changed PRs. We need the stored PR's ID
-		// (auto-increment) for foreign key references in review/check tables.
-		storedPR, err := s.prStore.GetByNumber(ctx, pr.RepoFullName, pr.Number)
-		if err != nil || storedPR == nil {
-			slog.Error("failed to retrieve PR for review fetch", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
-		} else {
-			s.fetchReviewData(ctx, *storedPR)
-			s.fetchHealthData(ctx, *storedPR)
-		}
+		s.syncPR(ctx, gh, pr, previous)
 	}
 
 	// Clean up stored open PRs that no longer appear in the API response.
@@ -317,16 +508,84 @@ func (s *PollService) pollRepo(ctx context.Context, repoFullName string) error {
 		}
 	}
 
+	s.syncRepoMetadata(ctx, gh, repoFullName, countOpenPRs(prs))
+
 	slog.Info("repo polled",
 		"repo", repoFullName,
 		"fetched", len(prs),
 		"skipped_unchanged", skippedUnchanged,
+		"skipped_irrelevant", skippedIrrelevant,
 		"cleaned_up", cleanedUp,
 	)
 
 	return nil
 }
 
+// syncPR stores a changed PR and fetches its review and health data.
+// previous is the stored row before this poll, nil for a newly discovered PR.
+//
+// The sync runs to completion even if ctx is canceled partway through, for up
+// to drainGrace, so shutdown does not leave a PR with a fresh updated_at but
+// stale reviews and checks (the next poll would skip it as unchanged). If the
+// grace period runs out first, the PR row is rolled back to previous (or
+// deleted if it was new) so the next poll redoes the whole sync.
+func (s *PollService) syncPR(ctx context.Context, gh driven.GitHubClient, pr model.PullRequest, previous *model.PullRequest) {
+	syncCtx, cancel := drainContext(ctx, s.drainGrace)
+	defer cancel()
+
+	if err := s.prStore.Upsert(syncCtx, pr); err != nil {
+		slog.Error("upsert failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+
+	// Fetch review and health data for changed PRs. We need the stored PR's ID
+	// (auto-increment) for foreign key references in review/check tables.
+	storedPR, err := s.prStore.GetByNumber(syncCtx, pr.RepoFullName, pr.Number)
+	if err != nil || storedPR == nil {
+		slog.Error("failed to retrieve PR for review fetch", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+
+	eventState := s.captureEventState(syncCtx, *storedPR, previous)
+	s.fetchReviewData(syncCtx, gh, *storedPR)
+	s.recordReviewRollup(syncCtx, *storedPR)
+	s.fetchHealthData(syncCtx, gh, *storedPR)
+	if s.lint != nil {
+		s.lint.LintPR(syncCtx, *storedPR)
+	}
+	s.recordChecklist(syncCtx, *storedPR, pr.Body)
+	s.recordReviewRequests(syncCtx, gh, *storedPR, pr)
+	if s.signalCache != nil {
+		s.signalCache.InvalidateSignals(storedPR.ID)
+	}
+
+	if syncCtx.Err() != nil {
+		s.rollbackPRSync(ctx, pr, previous)
+		return
+	}
+	s.publishPREvents(syncCtx, pr.RepoFullName, pr.Number, eventState)
+}
+
+// rollbackPRSync restores a PR row after an interrupted sync so its change is
+// detected again on the next poll. ctx is typically already canceled, so the
+// rollback gets its own short deadline.
+func (s *PollService) rollbackPRSync(ctx context.Context, pr model.PullRequest, previous *model.PullRequest) {
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	var err error
+	if previous != nil {
+		err = s.prStore.Upsert(rollbackCtx, *previous)
+	} else {
+		err = s.prStore.Delete(rollbackCtx, pr.RepoFullName, pr.Number)
+	}
+	if err != nil {
+		slog.Error("PR sync rollback failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+	slog.Warn("PR sync interrupted by shutdown; rolled back", "repo", pr.RepoFullName, "pr", pr.Number)
+}
+
 // IsReviewRequestedFrom checks if a PR has a review request for the given user
 // or any of the given team slugs.
 func IsReviewRequestedFrom(pr model.PullRequest, username string, teamSlugs []string) bool {
@@ -348,48 +607,85 @@ func IsReviewRequestedFrom(pr model.PullRequest, username string, teamSlugs []st
 }
 
 // fetchReviewData fetches reviews, review comments, issue comments, and thread
-// resolution for a PR and stores them via ReviewStore. Each fetch step is
-// independent -- partial failures are logged but do not abort the overall operation.
-func (s *PollService) fetchReviewData(ctx context.Context, pr model.PullRequest) {
-	reviews, err := s.ghClient.FetchReviews(ctx, pr.RepoFullName, pr.Number)
-	if err != nil {
-		slog.Error("fetch reviews failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+// resolution for a PR and stores them via ReviewStore, along with the
+// @mentions in their bodies when a MentionStore is configured. The fetches run
+// concurrently (see runFetches) and are stored once all have returned. Each
+// fetch step is independent -- partial failures are logged but do not abort
+// the overall operation.
+func (s *PollService) fetchReviewData(ctx context.Context, gh driven.GitHubClient, pr model.PullRequest) {
+	var (
+		reviews                         []model.Review
+		comments                        []model.ReviewComment
+		issueComments                   []model.IssueComment
+		resolutionMap                   map[int64]bool
+		reviewsErr, commentsErr         error
+		issueCommentsErr, resolutionErr error
+	)
+	s.runFetches(ctx,
+		func(ctx context.Context) { reviews, reviewsErr = gh.FetchReviews(ctx, pr.RepoFullName, pr.Number) },
+		func(ctx context.Context) {
+			comments, commentsErr = gh.FetchReviewComments(ctx, pr.RepoFullName, pr.Number)
+		},
+		func(ctx context.Context) {
+			issueComments, issueCommentsErr = gh.FetchIssueComments(ctx, pr.RepoFullName, pr.Number)
+		},
+		func(ctx context.Context) {
+			resolutionMap, resolutionErr = gh.FetchThreadResolution(ctx, pr.RepoFullName, pr.Number)
+		},
+	)
+
+	if reviewsErr != nil {
+		slog.Error("fetch reviews failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", reviewsErr)
 	} else {
 		for _, review := range reviews {
 			review.PRID = pr.ID
 			if err := s.reviewStore.UpsertReview(ctx, review); err != nil {
 				slog.Error("upsert review failed", "repo", pr.RepoFullName, "pr", pr.Number, "review", review.ID, "error", err)
+				continue
 			}
+			s.recordMentions(ctx, pr, model.Mention{
+				Source: model.MentionInReview, CommentID: review.ID, Author: review.ReviewerLogin, CreatedAt: review.SubmittedAt,
+			}, review.Body)
 		}
 	}
 
-	comments, err := s.ghClient.FetchReviewComments(ctx, pr.RepoFullName, pr.Number)
-	if err != nil {
-		slog.Error("fetch review comments failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	if commentsErr != nil {
+		slog.Error("fetch review comments failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", commentsErr)
 	} else {
 		for _, comment := range comments {
 			comment.PRID = pr.ID
+			comment.IsOutdated = comment.OutdatedAt(pr.HeadSHA)
 			if err := s.reviewStore.UpsertReviewComment(ctx, comment); err != nil {
 				slog.Error("upsert review comment failed", "repo", pr.RepoFullName, "pr", pr.Number, "comment", comment.ID, "error", err)
+				continue
 			}
+			s.recordMentions(ctx, pr, model.Mention{
+				Source: model.MentionInReviewComment, CommentID: comment.ID, Author: comment.Author, CreatedAt: comment.CreatedAt,
+			}, comment.Body)
 		}
+		s.reconcilePendingReviewComments(ctx, pr, comments)
 	}
 
-	issueComments, err := s.ghClient.FetchIssueComments(ctx, pr.RepoFullName, pr.Number)
-	if err != nil {
-		slog.Error("fetch issue comments failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	if issueCommentsErr != nil {
+		slog.Error("fetch issue comments failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", issueCommentsErr)
 	} else {
 		for _, ic := range issueComments {
 			ic.PRID = pr.ID
 			if err := s.reviewStore.UpsertIssueComment(ctx, ic); err != nil {
 				slog.Error("upsert issue comment failed", "repo", pr.RepoFullName, "pr", pr.Number, "comment", ic.ID, "error", err)
+				continue
 			}
+			s.recordMentions(ctx, pr, model.Mention{
+				Source: model.MentionInIssueComment, CommentID: ic.ID, Author: ic.Author, CreatedAt: ic.CreatedAt,
+			}, ic.Body)
 		}
+		s.reconcilePendingIssueComments(ctx, pr, issueComments)
 	}
 
-	resolutionMap, err := s.ghClient.FetchThreadResolution(ctx, pr.RepoFullName, pr.Number)
-	if err != nil {
-		slog.Error("fetch thread resolution failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	// Resolution is applied after the comments are stored, since it updates
+	// their rows.
+	if resolutionErr != nil {
+		slog.Error("fetch thread resolution failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", resolutionErr)
 	} else {
 		for commentID, isResolved := range resolutionMap {
 			if err := s.reviewStore.UpdateCommentResolution(ctx, commentID, isResolved); err != nil {
@@ -408,13 +704,42 @@ func (s *PollService) fetchReviewData(ctx context.Context, pr model.PullRequest)
 }
 
 // fetchHealthData fetches check runs, combined status, PR detail, and required
-// status checks for a PR and persists them. Each fetch step is independent --
-// partial failures are logged but do not abort the overall operation.
-func (s *PollService) fetchHealthData(ctx context.Context, pr model.PullRequest) {
-	// Step 1: Fetch PR detail (diff stats + mergeable status).
-	detail, err := s.ghClient.FetchPRDetail(ctx, pr.RepoFullName, pr.Number)
-	if err != nil {
-		slog.Error("fetch PR detail failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+// status checks for a PR and persists them. The fetches run concurrently (see
+// runFetches) and are persisted once all have returned. Each fetch step is
+// independent -- partial failures are logged but do not abort the overall
+// operation.
+func (s *PollService) fetchHealthData(ctx context.Context, gh driven.GitHubClient, pr model.PullRequest) {
+	// Required status checks are cached per branch per cycle, so they are
+	// only fetched on a cache miss.
+	cacheKey := pr.RepoFullName + "/" + pr.BaseBranch
+	requiredContexts, cached := s.branchProtectionCache[cacheKey]
+
+	var (
+		detail                   *model.PRDetail
+		checkRuns                []model.CheckRun
+		combinedStatus           *model.CombinedStatus
+		detailErr, checkRunsErr  error
+		combinedErr, requiredErr error
+	)
+	fetches := []func(context.Context){
+		func(ctx context.Context) { detail, detailErr = gh.FetchPRDetail(ctx, pr.RepoFullName, pr.Number) },
+		func(ctx context.Context) {
+			checkRuns, checkRunsErr = gh.FetchCheckRuns(ctx, pr.RepoFullName, pr.HeadSHA)
+		},
+		func(ctx context.Context) {
+			combinedStatus, combinedErr = gh.FetchCombinedStatus(ctx, pr.RepoFullName, pr.HeadSHA)
+		},
+	}
+	if !cached {
+		fetches = append(fetches, func(ctx context.Context) {
+			requiredContexts, requiredErr = gh.FetchRequiredStatusChecks(ctx, pr.RepoFullName, pr.BaseBranch)
+		})
+	}
+	s.runFetches(ctx, fetches...)
+
+	// Step 1: Store PR detail (diff stats + mergeable status).
+	if detailErr != nil {
+		slog.Error("fetch PR detail failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", detailErr)
 	} else if detail != nil {
 		pr.Additions = detail.Additions
 		pr.Deletions = detail.Deletions
@@ -425,36 +750,35 @@ func (s *PollService) fetchHealthData(ctx context.Context, pr model.PullRequest)
 		}
 	}
 
-	// Step 2: Fetch check runs.
-	checkRuns, err := s.ghClient.FetchCheckRuns(ctx, pr.RepoFullName, pr.HeadSHA)
-	if err != nil {
-		slog.Error("fetch check runs failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
-		return // Skip remaining check processing without check runs.
-	}
-
-	// Step 3: Fetch combined status (may fail independently).
-	var combinedStatus *model.CombinedStatus
-	combinedStatus, err = s.ghClient.FetchCombinedStatus(ctx, pr.RepoFullName, pr.HeadSHA)
-	if err != nil {
-		slog.Error("fetch combined status failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
-		// Continue with nil combined status.
-	}
-
-	// Step 4: Fetch required status checks from branch protection (cached per branch per cycle).
-	cacheKey := pr.RepoFullName + "/" + pr.BaseBranch
-	requiredContexts, cached := s.branchProtectionCache[cacheKey]
+	// Step 2: Cache required status checks from branch protection.
 	if !cached {
-		requiredContexts, err = s.ghClient.FetchRequiredStatusChecks(ctx, pr.RepoFullName, pr.BaseBranch)
-		if err != nil {
-			slog.Error("fetch required status checks failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		if requiredErr != nil {
+			slog.Error("fetch required status checks failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", requiredErr)
 			// Continue with nil requiredContexts -- all checks default to not required.
 		}
 		// Cache even nil results to avoid repeated 404/403 calls for the same branch.
 		s.branchProtectionCache[cacheKey] = requiredContexts
 	}
 
-	// Step 5: Mark required checks.
+	// Step 3: Check runs are required for the rest.
+	if checkRunsErr != nil {
+		slog.Error("fetch check runs failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", checkRunsErr)
+		return // Skip remaining check processing without check runs.
+	}
+
+	// Step 4: Combined status may fail independently.
+	if combinedErr != nil {
+		slog.Error("fetch combined status failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", combinedErr)
+		combinedStatus = nil // Continue with nil combined status.
+	}
+	if !cached {
+		s.refreshBranchProtection(ctx, gh, pr.RepoFullName, pr.BaseBranch)
+	}
+
+	// Step 5: Mark required checks, then apply the repo's manual overrides.
+	settings := s.repoSettingsFor(ctx, pr.RepoFullName)
 	markRequiredChecks(checkRuns, requiredContexts)
+	applyCheckOverrides(checkRuns, settings)
 
 	// Step 6: Set PRID on all check runs.
 	for i := range checkRuns {
@@ -466,8 +790,10 @@ func (s *PollService) fetchHealthData(ctx context.Context, pr model.PullRequest)
 		slog.Error("replace check runs failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
 	}
 
-	// Step 8: Compute and persist combined CI status.
-	ciStatus := computeCombinedCIStatus(checkRuns, combinedStatus)
+	// Step 8: Compute and persist combined CI status, leaving out the repo's
+	// ignored checks. They stay in the stored check runs.
+	countedRuns, countedStatus := withoutIgnoredChecks(checkRuns, combinedStatus, settings)
+	ciStatus := computeCombinedCIStatus(countedRuns, countedStatus)
 	pr.CIStatus = ciStatus
 	if err := s.prStore.Upsert(ctx, pr); err != nil {
 		slog.Error("upsert CI status failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
@@ -493,7 +819,19 @@ func (s *PollService) initializeSchedules(ctx context.Context) {
 	}
 
 	for _, repo := range repos {
-		s.updateSchedule(ctx, repo.FullName)
+		if repo.Paused {
+			continue
+		}
+
+		s.schedulesMu.RLock()
+		_, exists := s.schedules[repo.FullName]
+		s.schedulesMu.RUnlock()
+
+		// Repos polled by pollAll already have a schedule, including the
+		// backoff of a failed poll, which must not be reset here.
+		if !exists {
+			s.updateSchedule(ctx, repo.FullName)
+		}
 	}
 }
 
@@ -511,6 +849,7 @@ func (s *PollService) updateSchedule(ctx context.Context, repoFullName string) {
 	nextPoll := time.Now().Add(tierInterval(tier))
 
 	s.schedulesMu.Lock()
+	previous := s.schedules[repoFullName]
 	s.schedules[repoFullName] = repoSchedule{
 		tier:       tier,
 		nextPollAt: nextPoll,
@@ -518,6 +857,10 @@ func (s *PollService) updateSchedule(ctx context.Context, repoFullName string) {
 	}
 	s.schedulesMu.Unlock()
 
+	if previous.circuitOpen {
+		slog.Info("repo circuit closed; polling resumed", "repo", repoFullName, "failed_polls", previous.failures)
+	}
+
 	slog.Info("repo tier updated",
 		"repo", repoFullName,
 		"tier", tier.String(),
@@ -526,10 +869,16 @@ func (s *PollService) updateSchedule(ctx context.Context, repoFullName string) {
 }
 
 // pollDueRepos checks each repo's adaptive schedule and polls only those
-// that are due. New repos without a schedule are polled immediately.
+// that are due. New repos without a schedule are polled immediately. The
+// polls are spread across the tick with jitter, and capped by the rate-limit
+// pacing budget (see pollBudget) and, while the GitHub API is degraded, by
+// degradedPollsPerTick. Capped repos stay due for later ticks.
 func (s *PollService) pollDueRepos(ctx context.Context) {
 	// Re-read token from credential store each cycle; env var token is the fallback.
 	s.maybeRefreshToken(ctx)
+	s.checkStatusPage(ctx)
+	start := time.Now()
+	throttled := s.throttlePolling(start)
 
 	// Reset per-cycle branch protection cache.
 	s.branchProtectionCache = make(map[string][]string)
@@ -540,31 +889,58 @@ func (s *PollService) pollDueRepos(ctx context.Context) {
 		return
 	}
 
-	var polled int
+	var due []string
 	for _, repo := range repos {
-		if ctx.Err() != nil {
-			return
+		if repo.Paused {
+			continue // Paused repos are only polled on manual refresh.
 		}
 
 		s.schedulesMu.RLock()
 		schedule, exists := s.schedules[repo.FullName]
 		s.schedulesMu.RUnlock()
 
-		if exists && time.Now().Before(schedule.nextPollAt) {
+		if exists && start.Before(schedule.nextPollAt) {
 			continue // Not due yet.
 		}
+		due = append(due, repo.FullName)
+	}
 
-		if err := s.pollRepo(ctx, repo.FullName); err != nil {
-			slog.Error("adaptive repo poll failed", "repo", repo.FullName, "error", err)
-		} else {
-			s.updateSchedule(ctx, repo.FullName)
+	budget, remaining, reset := s.pollBudget(start)
+	pacing := PollPacing{DecidedAt: start, Due: len(due), Budget: budget, RateRemaining: remaining, RateReset: reset}
+	toPoll := due
+	if budget >= 0 && budget < len(toPoll) {
+		toPoll, pacing.Reason = toPoll[:budget], "rate limit"
+	}
+	if throttled && degradedPollsPerTick < len(toPoll) {
+		toPoll, pacing.Reason = toPoll[:degradedPollsPerTick], "github degraded"
+	}
+
+	for i, repoFullName := range toPoll {
+		if !s.waitUntil(ctx, pollSlot(start, i, len(toPoll))) {
+			return
+		}
+		if s.abortForRateLimit(time.Now()) {
+			pacing.Reason = "secondary rate limit"
+			break
 		}
-		polled++
+		s.measurePoll(func() {
+			if err := s.pollAndSchedule(ctx, repoFullName); err != nil {
+				slog.Error("adaptive repo poll failed", "repo", repoFullName, "error", err)
+			}
+		})
+		s.markProgress()
+		pacing.Polled++
+	}
+	if budget >= 0 {
+		s.spendPollCredit(pacing.Polled)
 	}
+	pacing.Deferred = len(due) - pacing.Polled
+	s.recordPacing(pacing)
 
 	slog.Info("adaptive poll cycle",
 		"repos_checked", len(repos),
-		"repos_polled", polled,
+		"repos_polled", pacing.Polled,
+		"repos_deferred", pacing.Deferred,
 	)
 }
 
@@ -572,12 +948,14 @@ func (s *PollService) pollDueRepos(ctx context.Context) {
 // adaptive schedule is recalculated based on fresh activity data.
 func (s *PollService) handleRefresh(ctx context.Context, req refreshRequest) error {
 	if req.repoFullName != "" {
+		if req.fullHistory {
+			s.fullHistory[req.repoFullName] = true
+		}
 		s.maybeRefreshToken(ctx)
-		err := s.pollRepo(ctx, req.repoFullName)
-		if err == nil {
-			s.updateSchedule(ctx, req.repoFullName)
+		if req.prNumber != 0 {
+			return s.refreshPR(ctx, req.repoFullName, req.prNumber)
 		}
-		return err
+		return s.pollAndSchedule(ctx, req.repoFullName)
 	}
 	// pollAll calls maybeRefreshToken internally; avoid a redundant call.
 	return s.pollAll(ctx)
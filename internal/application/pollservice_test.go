@@ -2,7 +2,9 @@ package application_test
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 
 	"github.com/ericfisherdev/mygitpanel/internal/application"
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
 )
 
 // --- Mock implementations ---
@@ -25,6 +28,7 @@ type mockGitHubClient struct {
 	fetchCombinedStatus       func(ctx context.Context, repoFullName string, ref string) (*model.CombinedStatus, error)
 	fetchPRDetail             func(ctx context.Context, repoFullName string, prNumber int) (*model.PRDetail, error)
 	fetchRequiredStatusChecks func(ctx context.Context, repoFullName string, branch string) ([]string, error)
+	fetchBranchProtection     func(ctx context.Context, repoFullName string, branch string) (model.BranchProtection, error)
 }
 
 func (m *mockGitHubClient) FetchPullRequests(ctx context.Context, repoFullName string, state string) ([]model.PullRequest, error) {
@@ -87,6 +91,13 @@ func (m *mockGitHubClient) FetchRequiredStatusChecks(ctx context.Context, repoFu
 	return nil, nil
 }
 
+func (m *mockGitHubClient) FetchBranchProtection(ctx context.Context, repoFullName string, branch string) (model.BranchProtection, error) {
+	if m.fetchBranchProtection != nil {
+		return m.fetchBranchProtection(ctx, repoFullName, branch)
+	}
+	return model.BranchProtection{RepoFullName: repoFullName, Branch: branch}, nil
+}
+
 type upsertCall struct {
 	PR model.PullRequest
 }
@@ -164,7 +175,12 @@ func (m *mockRepoStore) Remove(_ context.Context, _ string) error {
 	return nil
 }
 
-func (m *mockRepoStore) GetByFullName(_ context.Context, _ string) (*model.Repository, error) {
+func (m *mockRepoStore) GetByFullName(_ context.Context, fullName string) (*model.Repository, error) {
+	for _, r := range m.repos {
+		if r.FullName == fullName {
+			return &r, nil
+		}
+	}
 	return nil, nil
 }
 
@@ -197,6 +213,18 @@ func (m *mockCheckStore) GetCheckRunsByPR(_ context.Context, prID int64) ([]mode
 	return m.replaced[prID], nil
 }
 
+func (m *mockCheckStore) GetCheckRunsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.CheckRun, error) {
+	result := make(map[int64][]model.CheckRun, len(prIDs))
+	for _, id := range prIDs {
+		runs, err := m.GetCheckRunsByPR(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = runs
+	}
+	return result, nil
+}
+
 func (m *mockCheckStore) reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -364,6 +392,91 @@ func TestPollRepo_TeamReviewRequest(t *testing.T) {
 	assert.Equal(t, 20, prStore.upserts[0].PR.Number)
 }
 
+func TestUpdateSettings_TeamsApplyOnNextPoll(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{{
+				Number:             20,
+				Author:             "alice",
+				RepoFullName:       "org/repo",
+				Status:             model.PRStatusOpen,
+				UpdatedAt:          now,
+				RequestedTeamSlugs: []string{"my-team"},
+			}}, nil
+		},
+	}
+	prStore := &mockPRStore{}
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), newMockCheckStore(), "testuser", nil, time.Hour, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	require.NoError(t, svc.RefreshRepo(ctx, "org/repo"))
+	prStore.mu.Lock()
+	require.NotEmpty(t, prStore.upserts)
+	assert.False(t, prStore.upserts[len(prStore.upserts)-1].PR.NeedsReview, "not a member of my-team yet")
+	prStore.upserts = nil
+	prStore.mu.Unlock()
+
+	svc.UpdateSettings(time.Hour, []string{"my-team"})
+	require.NoError(t, svc.RefreshRepo(ctx, "org/repo"))
+
+	prStore.mu.Lock()
+	defer prStore.mu.Unlock()
+	require.NotEmpty(t, prStore.upserts)
+	assert.True(t, prStore.upserts[0].PR.NeedsReview)
+}
+
+func TestPollRepo_RelevantPRsOnly(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 1, Author: "testuser", RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now},
+				{Number: 2, Author: "alice", RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now},
+				{Number: 3, Author: "alice", RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now, Assignees: []string{"testuser"}},
+				// Stored while it involved the user, so it keeps syncing.
+				{Number: 4, Author: "alice", RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now},
+			}, nil
+		},
+	}
+	prStore := &mockPRStore{stored: []model.PullRequest{{ID: 4, Number: 4, RepoFullName: "org/repo", Status: model.PRStatusOpen}}}
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), newMockCheckStore(), "testuser", nil, time.Hour, nil, nil).
+		WithRelevantPRsOnly(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	require.NoError(t, svc.RefreshRepo(ctx, "org/repo"))
+
+	prStore.mu.Lock()
+	defer prStore.mu.Unlock()
+	upserted := make(map[int]bool)
+	for _, u := range prStore.upserts {
+		upserted[u.PR.Number] = true
+	}
+	assert.Equal(t, map[int]bool{1: true, 3: true, 4: true}, upserted)
+	assert.Empty(t, prStore.deletes, "skipped PRs are not stale")
+}
+
 func TestPollRepo_Deduplication(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 
@@ -613,6 +726,36 @@ func TestPollRepo_FetchesReviewData(t *testing.T) {
 	assert.Equal(t, int64(60), reviewStore.upsertedIssueComments[0].PRID, "issue comment PRID should match stored PR ID")
 }
 
+func TestPollRepo_MarksOutdatedReviewComments(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	position := 3
+
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 61, RepoFullName: "org/repo", Status: model.PRStatusOpen, HeadSHA: "head", UpdatedAt: now},
+			}, nil
+		},
+		fetchReviewComments: func(_ context.Context, _ string, _ int) ([]model.ReviewComment, error) {
+			return []model.ReviewComment{
+				{ID: 1, CommitID: "old", OriginalLine: 5},
+				{ID: 2, CommitID: "old", OriginalLine: 5, Position: &position},
+				{ID: 3, CommitID: "head", OriginalLine: 5},
+				{ID: 4, CommitID: "old", SubjectType: "file"},
+			}, nil
+		},
+	}
+
+	reviewStore := newMockReviewStore()
+	pollRepoViaFull(t, ghClient, &mockPRStore{}, reviewStore, newMockCheckStore(), "testuser", nil, "org/repo")
+
+	require.Len(t, reviewStore.upsertedReviewComments, 4)
+	assert.True(t, reviewStore.upsertedReviewComments[0].IsOutdated, "line gone from the diff of a newer head")
+	assert.False(t, reviewStore.upsertedReviewComments[1].IsOutdated, "line still in the diff")
+	assert.False(t, reviewStore.upsertedReviewComments[2].IsOutdated, "made on the current head")
+	assert.False(t, reviewStore.upsertedReviewComments[3].IsOutdated, "file-level comment")
+}
+
 func TestPollRepo_SkipsReviewDataForUnchangedPRs(t *testing.T) {
 	now := time.Now().Truncate(time.Second)
 
@@ -747,6 +890,375 @@ func TestAdaptiveScheduling(t *testing.T) {
 	<-done
 }
 
+func TestFailingRepoBacksOff(t *testing.T) {
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, repoFullName string, _ string) ([]model.PullRequest, error) {
+			if repoFullName == "org/gone" {
+				return nil, fmt.Errorf("listing pull requests: %w", driven.ErrRepoUnreachable)
+			}
+			return nil, nil
+		},
+	}
+	repoStore := &mockRepoStore{
+		repos: []model.Repository{{FullName: "org/gone"}, {FullName: "org/ok"}},
+	}
+
+	svc := application.NewPollService(
+		ghClient, &mockPRStore{}, repoStore,
+		newMockReviewStore(), newMockCheckStore(),
+		"testuser", nil, 5*time.Minute, nil, nil,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// Manual refreshes count as failures too and push the next poll further out.
+	for range 2 {
+		require.Error(t, svc.RefreshRepo(ctx, "org/gone"))
+	}
+
+	schedules := svc.Schedules()
+	gone := schedules["org/gone"]
+	assert.Equal(t, 3, gone.ConsecutiveFailures, "initial poll failure must survive schedule init")
+	assert.Equal(t, application.CircuitOpen, gone.Circuit)
+	assert.True(t, gone.LastPolled.IsZero())
+	assert.True(t, gone.NextPollAt.After(time.Now().Add(3*time.Minute)))
+
+	ok := schedules["org/ok"]
+	assert.Equal(t, application.CircuitClosed, ok.Circuit)
+	assert.Zero(t, ok.ConsecutiveFailures)
+
+	cancel()
+	<-done
+}
+
+func TestPausedRepoIsOnlyPolledOnRefresh(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, repoFullName string, _ string) ([]model.PullRequest, error) {
+			mu.Lock()
+			fetched = append(fetched, repoFullName)
+			mu.Unlock()
+			return nil, nil
+		},
+	}
+	repoStore := &mockRepoStore{
+		repos: []model.Repository{{FullName: "org/active"}, {FullName: "org/paused", Paused: true}},
+	}
+
+	svc := application.NewPollService(
+		ghClient, &mockPRStore{}, repoStore,
+		newMockReviewStore(), newMockCheckStore(),
+		"testuser", nil, 5*time.Minute, nil, nil,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	assert.NotContains(t, fetched, "org/paused", "paused repo must be skipped by background polling")
+	assert.Contains(t, fetched, "org/active")
+	mu.Unlock()
+
+	_, scheduled := svc.Schedules()["org/paused"]
+	assert.False(t, scheduled, "paused repo should not be scheduled")
+
+	require.NoError(t, svc.RefreshRepo(ctx, "org/paused"))
+	mu.Lock()
+	assert.Contains(t, fetched, "org/paused", "manual refresh should still poll a paused repo")
+	mu.Unlock()
+
+	cancel()
+	<-done
+}
+
+// renamingRepoStore is a mockRepoStore that also implements driven.RepoRenamer.
+type renamingRepoStore struct {
+	mockRepoStore
+	renames []string
+}
+
+func (m *renamingRepoStore) RenameRepo(_ context.Context, from, to string) error {
+	m.renames = append(m.renames, from+" -> "+to)
+	for i := range m.repos {
+		if m.repos[i].FullName == from {
+			m.repos[i].FullName = to
+			return nil
+		}
+	}
+	return driven.ErrRepoNotFound
+}
+
+func TestMovedRepoIsMigrated(t *testing.T) {
+	var mu sync.Mutex
+	var fetched []string
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, repoFullName string, _ string) ([]model.PullRequest, error) {
+			mu.Lock()
+			fetched = append(fetched, repoFullName)
+			mu.Unlock()
+			if repoFullName == "org/old" {
+				return nil, &driven.RepoMovedError{From: "org/old", To: "neworg/new"}
+			}
+			return nil, nil
+		},
+	}
+	repoStore := &renamingRepoStore{
+		mockRepoStore: mockRepoStore{repos: []model.Repository{{FullName: "org/old"}}},
+	}
+
+	svc := application.NewPollService(
+		ghClient, &mockPRStore{}, repoStore,
+		newMockReviewStore(), newMockCheckStore(),
+		"testuser", nil, 5*time.Minute, nil, nil,
+	).WithRepoRenamer(repoStore)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, svc.RefreshRepo(ctx, "neworg/new"))
+
+	schedules := svc.Schedules()
+	assert.NotContains(t, schedules, "org/old")
+	require.Contains(t, schedules, "neworg/new")
+	assert.Equal(t, application.CircuitClosed, schedules["neworg/new"].Circuit)
+	assert.Zero(t, schedules["neworg/new"].ConsecutiveFailures)
+
+	cancel()
+	<-done
+
+	assert.Equal(t, []string{"org/old -> neworg/new"}, repoStore.renames)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"org/old", "neworg/new", "neworg/new"}, fetched,
+		"moved repo should be polled under its new name immediately and from then on")
+}
+
+// staticAccountStore assigns repositories to fixed GitHub accounts.
+type staticAccountStore map[string]model.GitHubAccount
+
+func (m staticAccountStore) GetForRepo(_ context.Context, repoFullName string) (model.GitHubAccount, error) {
+	return m[repoFullName], nil
+}
+
+func (m staticAccountStore) GetRepoAccounts(_ context.Context, _ []string) (map[string]int64, error) {
+	return nil, nil
+}
+
+func (m staticAccountStore) SetRepoAccount(_ context.Context, _ string, _ int64) error {
+	return nil
+}
+
+func TestAccountRoutingUsesAssignedToken(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	var mu sync.Mutex
+	polledWith := make(map[string]string)
+
+	clientFor := func(token string) *mockGitHubClient {
+		return &mockGitHubClient{
+			fetchPRs: func(_ context.Context, repoFullName string, _ string) ([]model.PullRequest, error) {
+				mu.Lock()
+				polledWith[repoFullName] = token
+				mu.Unlock()
+				return []model.PullRequest{{
+					Number: 1, Author: "alice", RepoFullName: repoFullName, Status: model.PRStatusOpen,
+					RequestedReviewers: []string{"octo-work"}, UpdatedAt: now,
+				}}, nil
+			},
+		}
+	}
+
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "acme/api"}, {FullName: "octocat/dotfiles"}}}
+	prStore := &mockPRStore{}
+	accounts := staticAccountStore{
+		"acme/api": {ID: 1, Name: "work", Username: "octo-work", Token: "ghp_work"},
+	}
+
+	svc := application.NewPollService(
+		clientFor("ghp_default"), prStore, repoStore,
+		newMockReviewStore(), newMockCheckStore(),
+		"octocat", nil, 5*time.Minute, nil,
+		func(token string) driven.GitHubClient { return clientFor(token) },
+	).WithAccountRouting(accounts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	prStore.reset()
+
+	require.NoError(t, svc.RefreshRepo(ctx, "acme/api"))
+	require.NoError(t, svc.RefreshRepo(ctx, "octocat/dotfiles"))
+	cancel()
+	<-done
+
+	mu.Lock()
+	assert.Equal(t, map[string]string{"acme/api": "ghp_work", "octocat/dotfiles": "ghp_default"}, polledWith)
+	mu.Unlock()
+
+	needsReview := make(map[string]bool)
+	for _, u := range prStore.upserts {
+		needsReview[u.PR.RepoFullName] = needsReview[u.PR.RepoFullName] || u.PR.NeedsReview
+	}
+	assert.True(t, needsReview["acme/api"], "review requests should be matched against the account's login")
+	assert.False(t, needsReview["octocat/dotfiles"], "unassigned repos should use the default login")
+}
+
+// mockSCMProvider is a mockGitHubClient for another provider; its writes
+// are never called by the poll loop.
+type mockSCMProvider struct {
+	*mockGitHubClient
+	driven.GitHubWriter
+}
+
+func TestSCMProviderRouting(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	var mu sync.Mutex
+	polledBy := make(map[string]string)
+
+	clientNamed := func(name string) *mockGitHubClient {
+		return &mockGitHubClient{
+			fetchPRs: func(_ context.Context, repoFullName string, _ string) ([]model.PullRequest, error) {
+				mu.Lock()
+				polledBy[repoFullName] = name
+				mu.Unlock()
+				return []model.PullRequest{{
+					Number: 1, Author: "bob", RepoFullName: repoFullName, Status: model.PRStatusOpen,
+					RequestedReviewers: []string{"alice-bb"}, UpdatedAt: now,
+				}}, nil
+			},
+		}
+	}
+
+	repoStore := &mockRepoStore{repos: []model.Repository{
+		{FullName: "acme/api"},
+		{FullName: "acme/infra", Provider: model.ProviderBitbucket},
+	}}
+	prStore := &mockPRStore{}
+	svc := application.NewPollService(
+		clientNamed("github"), prStore, repoStore,
+		newMockReviewStore(), newMockCheckStore(),
+		"octocat", nil, 5*time.Minute, nil, nil,
+	).WithSCMProvider(model.ProviderBitbucket, mockSCMProvider{mockGitHubClient: clientNamed("bitbucket")}, "alice-bb")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	prStore.reset()
+
+	require.NoError(t, svc.RefreshRepo(ctx, "acme/api"))
+	require.NoError(t, svc.RefreshRepo(ctx, "acme/infra"))
+	cancel()
+	<-done
+
+	mu.Lock()
+	assert.Equal(t, map[string]string{"acme/api": "github", "acme/infra": "bitbucket"}, polledBy)
+	mu.Unlock()
+
+	needsReview := make(map[string]bool)
+	for _, u := range prStore.upserts {
+		needsReview[u.PR.RepoFullName] = needsReview[u.PR.RepoFullName] || u.PR.NeedsReview
+	}
+	assert.True(t, needsReview["acme/infra"], "review requests should be matched against the provider login")
+	assert.False(t, needsReview["acme/api"])
+}
+
+// statusReportingClient is a mockGitHubClient that also implements
+// driven.GitHubStatusReporter.
+type statusReportingClient struct {
+	*mockGitHubClient
+	status model.GitHubAPIStatus
+}
+
+func (c *statusReportingClient) APIStatus() model.GitHubAPIStatus {
+	return c.status
+}
+
+func TestLoopStatus(t *testing.T) {
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return nil, nil
+		},
+	}
+	svc := application.NewPollService(
+		ghClient, &mockPRStore{}, &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}},
+		newMockReviewStore(), newMockCheckStore(),
+		"testuser", nil, 5*time.Minute, nil, nil,
+	)
+
+	startedAt, lastProgressAt := svc.LoopStatus()
+	assert.True(t, startedAt.IsZero(), "loop should not report a start before Start runs")
+	assert.True(t, lastProgressAt.IsZero())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	startedAt, lastProgressAt = svc.LoopStatus()
+	assert.False(t, startedAt.IsZero())
+	assert.False(t, lastProgressAt.Before(startedAt), "initial poll should record progress")
+
+	cancel()
+	<-done
+}
+
+func TestGitHubStatus(t *testing.T) {
+	t.Run("client without status tracking", func(t *testing.T) {
+		svc := application.NewPollService(
+			&mockGitHubClient{}, &mockPRStore{}, &mockRepoStore{},
+			newMockReviewStore(), newMockCheckStore(),
+			"testuser", nil, 5*time.Minute, nil, nil,
+		)
+
+		_, ok := svc.GitHubStatus()
+		assert.False(t, ok)
+	})
+
+	t.Run("client with status tracking", func(t *testing.T) {
+		client := &statusReportingClient{
+			mockGitHubClient: &mockGitHubClient{},
+			status:           model.GitHubAPIStatus{RateLimit: 5000, RateRemaining: 42, Unauthorized: true},
+		}
+		svc := application.NewPollService(
+			client, &mockPRStore{}, &mockRepoStore{},
+			newMockReviewStore(), newMockCheckStore(),
+			"testuser", nil, 5*time.Minute, nil, nil,
+		)
+
+		status, ok := svc.GitHubStatus()
+		require.True(t, ok)
+		assert.Equal(t, 42, status.RateRemaining)
+		assert.True(t, status.Unauthorized)
+	})
+}
+
 // adaptiveMockPRStore extends mockPRStore with per-repo PR lookup support.
 type adaptiveMockPRStore struct {
 	noopPRStoreMixin
@@ -796,3 +1308,312 @@ func (m *adaptiveMockPRStore) Delete(_ context.Context, repoFullName string, num
 	m.deletes = append(m.deletes, deleteCall{RepoFullName: repoFullName, Number: number})
 	return nil
 }
+
+func TestPollRepo_PublishesCheckCompletedOnce(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 80, Author: "testuser", RepoFullName: "org/repo", Status: model.PRStatusOpen, HeadSHA: "abc", UpdatedAt: now},
+			}, nil
+		},
+		fetchCheckRuns: func(_ context.Context, _ string, _ string) ([]model.CheckRun, error) {
+			return []model.CheckRun{{ID: 5, Name: "test", Status: "completed", Conclusion: "success"}}, nil
+		},
+	}
+
+	// The PR is already tracked with the check still running.
+	prStore := &mockPRStore{
+		stored: []model.PullRequest{
+			{ID: 80, Number: 80, Author: "testuser", RepoFullName: "org/repo", Status: model.PRStatusOpen, HeadSHA: "abc", UpdatedAt: now.Add(-time.Hour)},
+		},
+	}
+	checkStore := newMockCheckStore()
+	checkStore.replaced[80] = []model.CheckRun{{ID: 5, PRID: 80, Name: "test", Status: "in_progress"}}
+
+	hub := application.NewEventHub()
+	events, cancelSub := hub.Subscribe(application.EventFilter{})
+	defer cancelSub()
+
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), checkStore, "testuser", nil, time.Hour, nil, nil).
+		WithEventHub(hub, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+
+	// The stored PR never changes, so both the initial poll and the refresh
+	// treat it as updated; only the first sees the check complete.
+	require.NoError(t, svc.RefreshRepo(ctx, "org/repo"))
+	cancel()
+	<-done
+	cancelSub()
+
+	counts := make(map[model.PREventType]int)
+	for e := range events {
+		counts[e.Type]++
+		if e.Type == model.PREventCheckCompleted {
+			require.NotNil(t, e.CheckRun)
+			assert.Equal(t, int64(5), e.CheckRun.ID)
+		}
+	}
+	assert.Equal(t, 2, counts[model.PREventUpdated])
+	assert.Equal(t, 1, counts[model.PREventCheckCompleted])
+}
+
+func TestPollRepo_PublishesCommentAddedForNewComments(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	var fetches int
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 81, Author: "testuser", RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now},
+			}, nil
+		},
+		fetchIssueComments: func(_ context.Context, _ string, _ int) ([]model.IssueComment, error) {
+			fetches++
+			comments := []model.IssueComment{{ID: 1, Author: "alice", Body: "old"}}
+			if fetches > 1 {
+				comments = append(comments, model.IssueComment{ID: 2, Author: "bob", Body: "new"})
+			}
+			return comments, nil
+		},
+	}
+
+	prStore := &mockPRStore{
+		stored: []model.PullRequest{
+			{ID: 81, Number: 81, Author: "testuser", RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now.Add(-time.Hour)},
+		},
+	}
+
+	hub := application.NewEventHub()
+	events, cancelSub := hub.Subscribe(application.EventFilter{Types: []model.PREventType{model.PREventCommentAdded}})
+	defer cancelSub()
+
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), newMockCheckStore(), "testuser", nil, time.Hour, nil, nil).
+		WithEventHub(hub, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+
+	// The initial poll finds the first comment, the refresh the second; each
+	// is published once.
+	require.NoError(t, svc.RefreshRepo(ctx, "org/repo"))
+	cancel()
+	<-done
+	cancelSub()
+
+	var ids []int64
+	for e := range events {
+		require.NotNil(t, e.Comment)
+		ids = append(ids, e.Comment.ID)
+	}
+	assert.Equal(t, []int64{1, 2}, ids)
+}
+
+func TestPollRepo_PublishesOpenedOnlyForPRsNewSinceLastSync(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, repo string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 1, RepoFullName: repo, Status: model.PRStatusOpen, UpdatedAt: now},
+			}, nil
+		},
+	}
+
+	hub := application.NewEventHub()
+	events, cancelSub := hub.Subscribe(application.EventFilter{Types: []model.PREventType{model.PREventOpened}})
+	defer cancelSub()
+
+	// org/old was polled before, so its new PR was just opened; org/new is
+	// being polled for the first time, so its PR is existing history.
+	repoStore := &mockRepoStore{repos: []model.Repository{
+		{FullName: "org/old", LastSyncedAt: now.Add(-time.Minute)},
+		{FullName: "org/new"},
+	}}
+	svc := application.NewPollService(ghClient, &mockPRStore{}, repoStore, newMockReviewStore(), newMockCheckStore(), "testuser", nil, time.Hour, nil, nil).
+		WithEventHub(hub, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	// The mock store does not keep upserts, so the refresh discovers the PR
+	// again and both polls publish it.
+	require.NoError(t, svc.RefreshRepo(ctx, "org/old"))
+	cancel()
+	<-done
+	cancelSub()
+
+	var repos []string
+	for e := range events {
+		require.NotNil(t, e.PullRequest)
+		repos = append(repos, e.RepoFullName)
+	}
+	assert.Equal(t, []string{"org/old", "org/old"}, repos)
+}
+
+func TestShutdownFinishesInFlightPRSync(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 1, RepoFullName: "org/repo", Status: model.PRStatusOpen, HeadSHA: "a", UpdatedAt: now},
+				{Number: 2, RepoFullName: "org/repo", Status: model.PRStatusOpen, HeadSHA: "b", UpdatedAt: now},
+			}, nil
+		},
+		fetchReviews: func(_ context.Context, _ string, _ int) ([]model.Review, error) {
+			cancel() // Shutdown begins mid-sync of the first PR.
+			return nil, nil
+		},
+		fetchCheckRuns: func(ctx context.Context, _ string, _ string) ([]model.CheckRun, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return []model.CheckRun{{ID: 9, Name: "build", Status: "completed"}}, nil
+		},
+	}
+	prStore := &mockPRStore{}
+	checkStore := newMockCheckStore()
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), checkStore, "testuser", nil, time.Hour, nil, nil)
+	go svc.Start(ctx)
+
+	select {
+	case <-svc.Drained():
+	case <-time.After(5 * time.Second):
+		t.Fatal("poll service did not drain")
+	}
+
+	checkStore.mu.Lock()
+	assert.Len(t, checkStore.replaced[1], 1, "the in-flight PR should finish syncing")
+	checkStore.mu.Unlock()
+
+	prStore.mu.Lock()
+	defer prStore.mu.Unlock()
+	for _, u := range prStore.upserts {
+		assert.Equal(t, 1, u.PR.Number, "no PR should be started after shutdown begins")
+	}
+	assert.Empty(t, prStore.deletes, "stale cleanup must not run on a partial poll")
+}
+
+func TestShutdownRollsBackPRSyncPastGrace(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	previous := model.PullRequest{ID: 1, Number: 1, RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now.Add(-time.Hour)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	ghClient := &mockGitHubClient{
+		fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+			return []model.PullRequest{
+				{Number: 1, RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now},
+				{Number: 2, RepoFullName: "org/repo", Status: model.PRStatusOpen, UpdatedAt: now},
+			}, nil
+		},
+		fetchReviews: func(syncCtx context.Context, _ string, _ int) ([]model.Review, error) {
+			cancel()
+			<-syncCtx.Done() // A fetch that outlasts the drain grace period.
+			return nil, syncCtx.Err()
+		},
+	}
+	prStore := &mockPRStore{stored: []model.PullRequest{previous}}
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), newMockCheckStore(), "testuser", nil, time.Hour, nil, nil).
+		WithDrainGrace(10 * time.Millisecond)
+	go svc.Start(ctx)
+
+	select {
+	case <-svc.Drained():
+	case <-time.After(5 * time.Second):
+		t.Fatal("poll service did not drain")
+	}
+
+	prStore.mu.Lock()
+	defer prStore.mu.Unlock()
+	require.NotEmpty(t, prStore.upserts)
+	last := prStore.upserts[len(prStore.upserts)-1].PR
+	assert.True(t, last.UpdatedAt.Equal(previous.UpdatedAt), "interrupted sync should restore the previous row")
+}
+
+// prFetchingClient is a mockGitHubClient that can also fetch a single PR.
+type prFetchingClient struct {
+	*mockGitHubClient
+	fetchPR func(ctx context.Context, repoFullName string, number int) (*model.PullRequest, error)
+}
+
+func (c *prFetchingClient) FetchPullRequest(ctx context.Context, repoFullName string, number int) (*model.PullRequest, error) {
+	return c.fetchPR(ctx, repoFullName, number)
+}
+
+func TestRefreshPR_FetchesOnlyThatPR(t *testing.T) {
+	updated := time.Now().Truncate(time.Second)
+	var listings atomic.Int32
+	ghClient := &prFetchingClient{
+		mockGitHubClient: &mockGitHubClient{
+			fetchPRs: func(_ context.Context, _ string, _ string) ([]model.PullRequest, error) {
+				listings.Add(1)
+				return nil, nil
+			},
+			fetchCheckRuns: func(_ context.Context, _ string, _ string) ([]model.CheckRun, error) {
+				return []model.CheckRun{{ID: 9, Name: "build", Status: "completed"}}, nil
+			},
+		},
+		fetchPR: func(_ context.Context, repoFullName string, number int) (*model.PullRequest, error) {
+			return &model.PullRequest{
+				Number: number, RepoFullName: repoFullName, Status: model.PRStatusOpen, HeadSHA: "b", UpdatedAt: updated,
+				RequestedReviewers: []string{"testuser"},
+			}, nil
+		},
+	}
+	// The stored row is as fresh as GitHub's, which a repo poll would skip.
+	prStore := &mockPRStore{stored: []model.PullRequest{
+		{ID: 7, Number: 5, RepoFullName: "org/repo", Status: model.PRStatusOpen, HeadSHA: "b", UpdatedAt: updated},
+	}}
+	checkStore := newMockCheckStore()
+	repoStore := &mockRepoStore{repos: []model.Repository{{FullName: "org/repo"}}}
+
+	svc := application.NewPollService(ghClient, prStore, repoStore, newMockReviewStore(), checkStore, "testuser", nil, time.Hour, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.Start(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	listings.Store(0)
+	prStore.reset()
+
+	require.NoError(t, svc.RefreshPR(ctx, "org/repo", 5))
+
+	assert.Zero(t, listings.Load(), "a single-PR refresh should not list the repository")
+	prStore.mu.Lock()
+	require.NotEmpty(t, prStore.upserts)
+	assert.Equal(t, 5, prStore.upserts[0].PR.Number)
+	assert.True(t, prStore.upserts[0].PR.NeedsReview)
+	prStore.mu.Unlock()
+	checkStore.mu.Lock()
+	assert.Len(t, checkStore.replaced[7], 1, "the PR's checks should be re-fetched")
+	checkStore.mu.Unlock()
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// PRChanges is what happened on a PR since the user last viewed it. The
+// user's own reviews and comments are left out; they already know about them.
+type PRChanges struct {
+	Since time.Time
+
+	// PreviousHeadSHA is the head when last viewed; set only when new
+	// commits have been pushed since.
+	PreviousHeadSHA string
+	// PreviousCIStatus is the CI status when last viewed; set only when it
+	// has changed since.
+	PreviousCIStatus model.CIStatus
+	// CompletedChecks counts check runs that finished since the last view.
+	CompletedChecks int
+
+	NewReviewIDs        map[int64]bool
+	NewReviewCommentIDs map[int64]bool
+	NewIssueCommentIDs  map[int64]bool
+}
+
+// IsEmpty reports whether nothing changed.
+func (c PRChanges) IsEmpty() bool {
+	return c.PreviousHeadSHA == "" && c.PreviousCIStatus == "" && c.CompletedChecks == 0 &&
+		len(c.NewReviewIDs) == 0 && len(c.NewReviewCommentIDs) == 0 && len(c.NewIssueCommentIDs) == 0
+}
+
+// ChangesSinceView compares pr and its current review data and check runs
+// against view. summary may be nil when review data is unavailable, in which
+// case only commit and CI changes are reported.
+func ChangesSinceView(view model.PRView, pr model.PullRequest, summary *PRReviewSummary, checkRuns []model.CheckRun, username string) PRChanges {
+	changes := PRChanges{
+		Since:               view.ViewedAt,
+		NewReviewIDs:        make(map[int64]bool),
+		NewReviewCommentIDs: make(map[int64]bool),
+		NewIssueCommentIDs:  make(map[int64]bool),
+	}
+
+	if view.HeadSHA != "" && pr.HeadSHA != view.HeadSHA {
+		changes.PreviousHeadSHA = view.HeadSHA
+	}
+	if view.CIStatus != "" && pr.CIStatus != view.CIStatus {
+		changes.PreviousCIStatus = view.CIStatus
+	}
+	for _, run := range checkRuns {
+		if run.Status == "completed" && run.CompletedAt.After(view.ViewedAt) {
+			changes.CompletedChecks++
+		}
+	}
+
+	if summary == nil {
+		return changes
+	}
+	newSince := func(author string, at time.Time) bool {
+		return at.After(view.ViewedAt) && !strings.EqualFold(author, username)
+	}
+	for _, r := range summary.Reviews {
+		if r.State != model.ReviewStatePending && newSince(r.ReviewerLogin, r.SubmittedAt) {
+			changes.NewReviewIDs[r.ID] = true
+		}
+	}
+	for _, thread := range summary.Threads {
+		for _, c := range append([]model.ReviewComment{thread.RootComment}, thread.Replies...) {
+			if newSince(c.Author, c.CreatedAt) {
+				changes.NewReviewCommentIDs[c.ID] = true
+			}
+		}
+	}
+	for _, c := range summary.IssueComments {
+		if newSince(c.Author, c.CreatedAt) {
+			changes.NewIssueCommentIDs[c.ID] = true
+		}
+	}
+	return changes
+}
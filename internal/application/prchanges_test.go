@@ -0,0 +1,63 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestChangesSinceView(t *testing.T) {
+	viewed := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	before, after := viewed.Add(-time.Hour), viewed.Add(time.Hour)
+	view := model.PRView{PRID: 1, ViewedAt: viewed, HeadSHA: "aaa", CIStatus: model.CIStatusFailing}
+
+	t.Run("nothing changed", func(t *testing.T) {
+		pr := model.PullRequest{ID: 1, HeadSHA: "aaa", CIStatus: model.CIStatusFailing}
+		summary := &PRReviewSummary{
+			Reviews:       []model.Review{{ID: 1, ReviewerLogin: "bob", State: model.ReviewStateApproved, SubmittedAt: before}},
+			IssueComments: []model.IssueComment{{ID: 2, Author: "bob", CreatedAt: before}},
+		}
+		changes := ChangesSinceView(view, pr, summary, []model.CheckRun{{Status: "completed", CompletedAt: before}}, "me")
+		assert.True(t, changes.IsEmpty())
+	})
+
+	t.Run("new activity", func(t *testing.T) {
+		pr := model.PullRequest{ID: 1, HeadSHA: "bbb", CIStatus: model.CIStatusPassing}
+		summary := &PRReviewSummary{
+			Reviews: []model.Review{
+				{ID: 1, ReviewerLogin: "bob", State: model.ReviewStateApproved, SubmittedAt: after},
+				{ID: 2, ReviewerLogin: "Me", State: model.ReviewStateCommented, SubmittedAt: after},
+			},
+			Threads: []CommentThread{{
+				RootComment: model.ReviewComment{ID: 10, Author: "me", CreatedAt: before},
+				Replies:     []model.ReviewComment{{ID: 11, Author: "bob", CreatedAt: after}},
+			}},
+			IssueComments: []model.IssueComment{{ID: 20, Author: "carol", CreatedAt: after}},
+		}
+		runs := []model.CheckRun{
+			{ID: 1, Status: "completed", CompletedAt: after},
+			{ID: 2, Status: "in_progress"},
+		}
+
+		changes := ChangesSinceView(view, pr, summary, runs, "me")
+		assert.False(t, changes.IsEmpty())
+		assert.Equal(t, viewed, changes.Since)
+		assert.Equal(t, "aaa", changes.PreviousHeadSHA)
+		assert.Equal(t, model.CIStatusFailing, changes.PreviousCIStatus)
+		assert.Equal(t, 1, changes.CompletedChecks)
+		assert.Equal(t, map[int64]bool{1: true}, changes.NewReviewIDs, "the user's own review is not new to them")
+		assert.Equal(t, map[int64]bool{11: true}, changes.NewReviewCommentIDs)
+		assert.Equal(t, map[int64]bool{20: true}, changes.NewIssueCommentIDs)
+	})
+
+	t.Run("without review data", func(t *testing.T) {
+		pr := model.PullRequest{ID: 1, HeadSHA: "bbb", CIStatus: model.CIStatusFailing}
+		changes := ChangesSinceView(view, pr, nil, nil, "me")
+		assert.Equal(t, "aaa", changes.PreviousHeadSHA)
+		assert.Empty(t, changes.PreviousCIStatus)
+		assert.Empty(t, changes.NewReviewIDs)
+	})
+}
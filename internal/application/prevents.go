@@ -0,0 +1,230 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// prEventState is what the poll loop knew about a PR before re-fetching it,
+// used to tell new reviews and newly completed checks apart from old ones.
+type prEventState struct {
+	// previous is the stored row before this poll, nil for a newly
+	// discovered PR.
+	previous *model.PullRequest
+	// discovered is true for PRs seen for the first time; their existing
+	// reviews and checks are history rather than events.
+	discovered bool
+	// opened is true for discovered open PRs in a repository that was
+	// polled before, i.e. PRs opened since the last poll rather than ones
+	// found when the repository was added.
+	opened bool
+	// reviewIDs, completedChecks, and commentIDs are nil when the stored
+	// data could not be read, which suppresses the corresponding events for
+	// this cycle.
+	reviewIDs       map[int64]bool
+	completedChecks map[int64]bool
+	commentIDs      map[int64]bool
+}
+
+// WithEventHub publishes PR changes observed while polling to hub. When
+// attention is non-nil, changes in a PR's attention signals are published too.
+func (s *PollService) WithEventHub(hub *EventHub, attention *AttentionService) *PollService {
+	s.events = hub
+	s.eventAttention = attention
+	s.lastSignals = make(map[int64]model.AttentionSignals)
+	return s
+}
+
+// captureEventState records the stored reviews and completed checks of pr
+// before it is re-fetched, along with previous, its row before this poll. It
+// returns nil when neither events nor the event journal are enabled.
+func (s *PollService) captureEventState(ctx context.Context, pr model.PullRequest, previous *model.PullRequest) *prEventState {
+	if s.events == nil && s.journal == nil {
+		return nil
+	}
+
+	state := &prEventState{previous: previous, discovered: previous == nil}
+	if state.discovered {
+		if pr.Status == model.PRStatusOpen {
+			repo, err := s.repoStore.GetByFullName(ctx, pr.RepoFullName)
+			if err != nil {
+				slog.Warn("failed to load repository for events", "repo", pr.RepoFullName, "error", err)
+			}
+			state.opened = repo != nil && !repo.LastSyncedAt.IsZero()
+		}
+		return state
+	}
+
+	if reviews, err := s.reviewStore.GetReviewsByPR(ctx, pr.ID); err != nil {
+		slog.Warn("failed to load reviews for events", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	} else {
+		state.reviewIDs = make(map[int64]bool, len(reviews))
+		for _, r := range reviews {
+			state.reviewIDs[r.ID] = true
+		}
+	}
+
+	if runs, err := s.checkStore.GetCheckRunsByPR(ctx, pr.ID); err != nil {
+		slog.Warn("failed to load check runs for events", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	} else {
+		state.completedChecks = make(map[int64]bool, len(runs))
+		for _, run := range runs {
+			if run.Status == "completed" {
+				state.completedChecks[run.ID] = true
+			}
+		}
+	}
+
+	if comments, err := s.reviewStore.GetIssueCommentsByPR(ctx, pr.ID); err != nil {
+		slog.Warn("failed to load issue comments for events", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	} else {
+		state.commentIDs = make(map[int64]bool, len(comments))
+		for _, c := range comments {
+			state.commentIDs[c.ID] = true
+		}
+	}
+
+	return state
+}
+
+// publishPREvents compares the freshly stored data for a changed PR against
+// state, appends the changes to the event journal, and publishes the
+// resulting events.
+func (s *PollService) publishPREvents(ctx context.Context, repoFullName string, number int, state *prEventState) {
+	if state == nil {
+		return
+	}
+
+	pr, err := s.prStore.GetByNumber(ctx, repoFullName, number)
+	if err != nil || pr == nil {
+		slog.Warn("failed to load PR for events", "repo", repoFullName, "pr", number, "error", err)
+		return
+	}
+
+	if s.journal != nil {
+		s.recordJournal(ctx, *pr, state)
+	}
+	if s.events == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	event := func(t model.PREventType) model.PREvent {
+		return model.PREvent{Type: t, RepoFullName: pr.RepoFullName, PRNumber: pr.Number, OccurredAt: now}
+	}
+
+	e := event(model.PREventUpdated)
+	e.PullRequest = pr
+	s.events.Publish(e)
+
+	if state.opened {
+		e := event(model.PREventOpened)
+		e.PullRequest = pr
+		s.events.Publish(e)
+	}
+
+	if !state.discovered {
+		s.publishNewReviews(ctx, *pr, state, event)
+		s.publishCompletedChecks(ctx, *pr, state, event)
+		s.publishNewComments(ctx, *pr, state, event)
+	}
+	s.publishAttentionChange(ctx, *pr, state.discovered, event)
+}
+
+// publishNewReviews publishes review.added for reviews not stored before the fetch.
+func (s *PollService) publishNewReviews(ctx context.Context, pr model.PullRequest, state *prEventState, event func(model.PREventType) model.PREvent) {
+	if state.reviewIDs == nil {
+		return
+	}
+	reviews, err := s.reviewStore.GetReviewsByPR(ctx, pr.ID)
+	if err != nil {
+		slog.Warn("failed to load reviews for events", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+	for _, r := range reviews {
+		if state.reviewIDs[r.ID] {
+			continue
+		}
+		e := event(model.PREventReviewAdded)
+		e.Review = &r
+		s.events.Publish(e)
+	}
+}
+
+// publishCompletedChecks publishes check.completed for check runs that were
+// pending or absent before the fetch and are now completed.
+func (s *PollService) publishCompletedChecks(ctx context.Context, pr model.PullRequest, state *prEventState, event func(model.PREventType) model.PREvent) {
+	if state.completedChecks == nil {
+		return
+	}
+	runs, err := s.checkStore.GetCheckRunsByPR(ctx, pr.ID)
+	if err != nil {
+		slog.Warn("failed to load check runs for events", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+	for _, run := range runs {
+		if run.Status != "completed" || state.completedChecks[run.ID] {
+			continue
+		}
+		e := event(model.PREventCheckCompleted)
+		e.CheckRun = &run
+		s.events.Publish(e)
+	}
+}
+
+// publishNewComments publishes comment.added for PR conversation comments not
+// stored before the fetch. Inline review comments arrive as part of a review
+// and are covered by review.added.
+func (s *PollService) publishNewComments(ctx context.Context, pr model.PullRequest, state *prEventState, event func(model.PREventType) model.PREvent) {
+	if state.commentIDs == nil {
+		return
+	}
+	comments, err := s.reviewStore.GetIssueCommentsByPR(ctx, pr.ID)
+	if err != nil {
+		slog.Warn("failed to load issue comments for events", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+	for _, c := range comments {
+		if state.commentIDs[c.ID] {
+			continue
+		}
+		e := event(model.PREventCommentAdded)
+		e.Comment = &c
+		s.events.Publish(e)
+	}
+}
+
+// publishAttentionChange publishes attention.changed when a PR's signals
+// differ from the last ones observed. Signals are remembered in memory only,
+// so the first evaluation of an already-tracked PR after startup sets the
+// baseline; a newly discovered PR is compared against no signals.
+// Only the poll goroutine touches lastSignals, so it needs no lock.
+func (s *PollService) publishAttentionChange(ctx context.Context, pr model.PullRequest, discovered bool, event func(model.PREventType) model.PREvent) {
+	if s.eventAttention == nil {
+		return
+	}
+
+	signals, err := s.eventAttention.SignalsForPR(ctx, pr, s.eventAttention.EffectiveThresholdsFor(ctx, pr.RepoFullName))
+	if err != nil {
+		slog.Warn("failed to compute attention signals for events", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		return
+	}
+
+	previous, known := s.lastSignals[pr.ID]
+	s.lastSignals[pr.ID] = signals
+	if !known && !discovered {
+		return
+	}
+	if signals.Equal(previous) {
+		return
+	}
+
+	e := event(model.PREventAttentionChanged)
+	e.Attention = &signals
+	e.PreviousAttention = &previous
+	e.PullRequest = &pr
+	s.events.Publish(e)
+}
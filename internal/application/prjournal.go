@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithJournalStore appends every change the poll loop observes in a PR's
+// stored state to the event journal: status transitions, CI status flips,
+// new reviews, and new review requests.
+func (s *PollService) WithJournalStore(store driven.PRJournalStore) *PollService {
+	s.journal = store
+	return s
+}
+
+// recordJournal appends the changes between state, captured before pr was
+// re-fetched, and pr as now stored. PRs found when their repository was
+// added are history and get no entries; PRs opened since the last poll get
+// their opening and initial review requests.
+func (s *PollService) recordJournal(ctx context.Context, pr model.PullRequest, state *prEventState) {
+	if state.discovered && !state.opened {
+		return
+	}
+
+	now := time.Now().UTC()
+	var entries []model.PRJournalEntry
+	add := func(kind model.PRJournalKind, actor, from, to string) {
+		entries = append(entries, model.PRJournalEntry{
+			PRID: pr.ID, RepoFullName: pr.RepoFullName, PRNumber: pr.Number,
+			Kind: kind, Actor: actor, From: from, To: to, OccurredAt: now,
+		})
+	}
+
+	var before model.PullRequest
+	if state.previous != nil {
+		before = *state.previous
+	}
+	if before.Status != pr.Status {
+		add(model.JournalStatusChanged, "", string(before.Status), string(pr.Status))
+	}
+	if state.previous != nil && before.CIStatus != pr.CIStatus {
+		add(model.JournalCIChanged, "", string(before.CIStatus), string(pr.CIStatus))
+	}
+	for _, req := range pr.ReviewRequests {
+		if _, ok := findReviewRequest(before.ReviewRequests, req); ok {
+			continue
+		}
+		to := ""
+		if req.IsTeam {
+			to = "team"
+		}
+		add(model.JournalReviewRequested, req.Reviewer, "", to)
+	}
+
+	if state.reviewIDs != nil {
+		reviews, err := s.reviewStore.GetReviewsByPR(ctx, pr.ID)
+		if err != nil {
+			slog.Warn("failed to load reviews for the event journal", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		}
+		for _, r := range reviews {
+			if !state.reviewIDs[r.ID] {
+				add(model.JournalReviewAdded, r.ReviewerLogin, "", string(r.State))
+			}
+		}
+	}
+
+	if err := s.journal.AppendPREvents(ctx, entries); err != nil {
+		slog.Error("failed to append to the event journal", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	}
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memJournal is an in-memory PRJournalStore.
+type memJournal struct {
+	entries []model.PRJournalEntry
+}
+
+func (m *memJournal) AppendPREvents(_ context.Context, entries []model.PRJournalEntry) error {
+	m.entries = append(m.entries, entries...)
+	return nil
+}
+
+func (m *memJournal) ListPREvents(_ context.Context, _ model.PRJournalFilter) ([]model.PRJournalEntry, error) {
+	return m.entries, nil
+}
+
+func journalSummary(entries []model.PRJournalEntry) []string {
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = string(e.Kind) + " " + e.Actor + " " + e.From + ">" + e.To
+	}
+	return out
+}
+
+func TestRecordJournal_ChangedPR(t *testing.T) {
+	journal := &memJournal{}
+	reviews := &prReviewStore{reviews: map[int64][]model.Review{
+		1: {{ID: 10, ReviewerLogin: "alice", State: model.ReviewStateCommented}, {ID: 11, ReviewerLogin: "bob", State: model.ReviewStateApproved}},
+	}}
+	svc := &PollService{reviewStore: reviews, journal: journal}
+
+	previous := model.PullRequest{ID: 1, Status: model.PRStatusOpen, CIStatus: model.CIStatusPending, ReviewRequests: []model.ReviewRequest{{Reviewer: "alice"}}}
+	pr := previous
+	pr.CIStatus = model.CIStatusFailing
+	pr.ReviewRequests = []model.ReviewRequest{{Reviewer: "Alice"}, {Reviewer: "carol"}, {Reviewer: "core", IsTeam: true}}
+
+	svc.recordJournal(context.Background(), pr, &prEventState{previous: &previous, reviewIDs: map[int64]bool{10: true}})
+
+	assert.Equal(t, []string{
+		"ci_changed  pending>failing",
+		"review_requested carol >",
+		"review_requested core >team",
+		"review_added bob >approved",
+	}, journalSummary(journal.entries))
+}
+
+func TestRecordJournal_NewPRs(t *testing.T) {
+	journal := &memJournal{}
+	svc := &PollService{reviewStore: &prReviewStore{}, journal: journal}
+	pr := model.PullRequest{ID: 2, Status: model.PRStatusOpen, ReviewRequests: []model.ReviewRequest{{Reviewer: "alice"}}}
+
+	svc.recordJournal(context.Background(), pr, &prEventState{discovered: true})
+	assert.Empty(t, journal.entries, "PRs found when the repo was added are history")
+
+	svc.recordJournal(context.Background(), pr, &prEventState{discovered: true, opened: true})
+	require.Len(t, journal.entries, 2)
+	assert.Equal(t, []string{"status_changed  >open", "review_requested alice >"}, journalSummary(journal.entries))
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// refreshPR re-syncs a single PR with its reviews and checks, even when it
+// looks unchanged. Clients that cannot fetch one PR (see driven.PRFetcher)
+// fall back to polling the whole repository.
+func (s *PollService) refreshPR(ctx context.Context, repoFullName string, number int) error {
+	gh, username := s.clientForRepo(ctx, repoFullName)
+	fetcher, ok := gh.(driven.PRFetcher)
+	if !ok {
+		return s.pollAndSchedule(ctx, repoFullName)
+	}
+
+	pr, err := fetcher.FetchPullRequest(ctx, repoFullName, number)
+	if err != nil {
+		return err
+	}
+	stored, err := s.prStore.GetByNumber(ctx, repoFullName, number)
+	if err != nil {
+		return err
+	}
+	if s.beyondRetention(*pr) {
+		return nil
+	}
+
+	teamSlugs := s.currentTeamSlugs()
+	pr.NeedsReview = IsReviewRequestedFrom(*pr, username, teamSlugs)
+	pr.JiraKey = ExtractJiraKey(pr.Branch, pr.Title)
+	if stored == nil && s.storesRelevantOnly(ctx, repoFullName) && !IsRelevantTo(*pr, username, teamSlugs) {
+		slog.Info("refreshed PR skipped as irrelevant", "repo", repoFullName, "pr", number)
+		return nil
+	}
+
+	s.syncPR(ctx, gh, *pr, stored)
+	s.updateSchedule(ctx, repoFullName)
+	slog.Info("PR refreshed", "repo", repoFullName, "pr", number)
+	return nil
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// WithRelevantPRsOnly makes the poller store only the PRs that involve the
+// user in repositories whose PR scope is left at the default. A repository's
+// own scope setting overrides it either way.
+func (s *PollService) WithRelevantPRsOnly(on bool) *PollService {
+	s.relevantOnly = on
+	return s
+}
+
+// storesRelevantOnly reports whether only relevant PRs of repoFullName are
+// stored, from its PR scope setting or, when it has none, the default.
+func (s *PollService) storesRelevantOnly(ctx context.Context, repoFullName string) bool {
+	switch s.repoSettingsFor(ctx, repoFullName).PRScope {
+	case model.PRScopeAll:
+		return false
+	case model.PRScopeRelevant:
+		return true
+	default:
+		return s.relevantOnly
+	}
+}
+
+// IsRelevantTo reports whether a fetched PR involves username: they wrote
+// it, their or one of their teams' review is requested, it is assigned to
+// them, or its description @mentions them. Mentions in comments are not
+// seen, since comments are only fetched for stored PRs.
+func IsRelevantTo(pr model.PullRequest, username string, teamSlugs []string) bool {
+	if strings.EqualFold(pr.Author, username) || IsReviewRequestedFrom(pr, username, teamSlugs) {
+		return true
+	}
+	return containsFold(pr.Assignees, username) || MentionsLogin(pr.Body, username)
+}
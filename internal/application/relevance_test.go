@@ -0,0 +1,45 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestIsRelevantTo(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   model.PullRequest
+		want bool
+	}{
+		{name: "author", pr: model.PullRequest{Author: "Me"}, want: true},
+		{name: "review requested", pr: model.PullRequest{Author: "alice", RequestedReviewers: []string{"me"}}, want: true},
+		{name: "team review requested", pr: model.PullRequest{Author: "alice", RequestedTeamSlugs: []string{"core"}}, want: true},
+		{name: "assignee", pr: model.PullRequest{Author: "alice", Assignees: []string{"bob", "ME"}}, want: true},
+		{name: "mentioned", pr: model.PullRequest{Author: "alice", Body: "cc @me for the schema"}, want: true},
+		{name: "mention in code", pr: model.PullRequest{Author: "alice", Body: "`@me`"}, want: false},
+		{name: "uninvolved", pr: model.PullRequest{Author: "alice", RequestedReviewers: []string{"bob"}, Body: "@meg"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsRelevantTo(tt.pr, "me", []string{"core"}))
+		})
+	}
+}
+
+func TestStoresRelevantOnly(t *testing.T) {
+	settings := staticRepoSettingsStore{
+		"o/all":      {RepoFullName: "o/all", PRScope: model.PRScopeAll},
+		"o/relevant": {RepoFullName: "o/relevant", PRScope: model.PRScopeRelevant},
+	}
+
+	svc := (&PollService{}).WithRepoSettingsStore(settings)
+	assert.False(t, svc.storesRelevantOnly(t.Context(), "o/default"))
+	assert.True(t, svc.storesRelevantOnly(t.Context(), "o/relevant"))
+
+	svc.WithRelevantPRsOnly(true)
+	assert.True(t, svc.storesRelevantOnly(t.Context(), "o/default"))
+	assert.False(t, svc.storesRelevantOnly(t.Context(), "o/all"), "the repo's all PRs toggle wins")
+}
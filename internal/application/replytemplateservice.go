@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// maxReplyTemplateNameLen bounds template names so the composer dropdowns stay readable.
+const maxReplyTemplateNameLen = 80
+
+var (
+	// ErrInvalidReplyTemplateName is returned when a template name is empty or too long.
+	ErrInvalidReplyTemplateName = errors.New("template name must be 1-80 characters")
+
+	// ErrInvalidReplyTemplateKind is returned when creating a template of an unknown kind.
+	ErrInvalidReplyTemplateKind = errors.New("template kind must be snippet or review")
+
+	// ErrEmptyReplyTemplateBody is returned when a template has no text.
+	ErrEmptyReplyTemplateBody = errors.New("template text must not be empty")
+)
+
+// replyTemplateVar matches a {{variable}} placeholder, allowing spaces inside
+// the braces.
+var replyTemplateVar = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// ReplyTemplateService manages saved reply snippets and review templates and
+// fills in their variables for a PR.
+type ReplyTemplateService struct {
+	store driven.ReplyTemplateStore
+}
+
+// NewReplyTemplateService creates a ReplyTemplateService.
+func NewReplyTemplateService(store driven.ReplyTemplateStore) *ReplyTemplateService {
+	return &ReplyTemplateService{store: store}
+}
+
+// Create validates and stores a template.
+func (s *ReplyTemplateService) Create(ctx context.Context, name string, kind model.ReplyTemplateKind, body string) (model.ReplyTemplate, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxReplyTemplateNameLen {
+		return model.ReplyTemplate{}, ErrInvalidReplyTemplateName
+	}
+	if !kind.Valid() {
+		return model.ReplyTemplate{}, ErrInvalidReplyTemplateKind
+	}
+	if strings.TrimSpace(body) == "" {
+		return model.ReplyTemplate{}, ErrEmptyReplyTemplateBody
+	}
+
+	tpl := model.ReplyTemplate{Name: name, Kind: kind, Body: body, CreatedAt: time.Now().UTC()}
+	id, err := s.store.Create(ctx, tpl)
+	if err != nil {
+		return model.ReplyTemplate{}, err
+	}
+	tpl.ID = id
+	return tpl, nil
+}
+
+// List returns all templates ordered by kind, then name.
+func (s *ReplyTemplateService) List(ctx context.Context) ([]model.ReplyTemplate, error) {
+	return s.store.List(ctx)
+}
+
+// Delete removes a template by ID.
+func (s *ReplyTemplateService) Delete(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// ExpandReplyTemplate fills in the variables of a template body for pr.
+// me is the GitHub login of the user posting, and may be empty. Supported
+// variables are {{author}}, {{pr_title}}, {{pr_number}}, {{repo}},
+// {{branch}}, {{base_branch}} and {{me}}; unknown ones are left as written.
+func ExpandReplyTemplate(body string, pr model.PullRequest, me string) string {
+	values := map[string]string{
+		"author":      pr.Author,
+		"pr_title":    pr.Title,
+		"pr_number":   strconv.Itoa(pr.Number),
+		"repo":        pr.RepoFullName,
+		"branch":      pr.Branch,
+		"base_branch": pr.BaseBranch,
+		"me":          me,
+	}
+	return replyTemplateVar.ReplaceAllStringFunc(body, func(match string) string {
+		name := replyTemplateVar.FindStringSubmatch(match)[1]
+		if v, ok := values[name]; ok {
+			return v
+		}
+		return match
+	})
+}
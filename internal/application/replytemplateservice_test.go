@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memReplyTemplateStore is an in-memory ReplyTemplateStore.
+type memReplyTemplateStore struct {
+	templates []model.ReplyTemplate
+}
+
+func (m *memReplyTemplateStore) Create(_ context.Context, tpl model.ReplyTemplate) (int64, error) {
+	tpl.ID = int64(len(m.templates) + 1)
+	m.templates = append(m.templates, tpl)
+	return tpl.ID, nil
+}
+
+func (m *memReplyTemplateStore) List(_ context.Context) ([]model.ReplyTemplate, error) {
+	return m.templates, nil
+}
+
+func (m *memReplyTemplateStore) Delete(_ context.Context, id int64) error {
+	for i, t := range m.templates {
+		if t.ID == id {
+			m.templates = append(m.templates[:i], m.templates[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func TestReplyTemplateService_CreateValidates(t *testing.T) {
+	ctx := context.Background()
+	store := &memReplyTemplateStore{}
+	svc := NewReplyTemplateService(store)
+
+	_, err := svc.Create(ctx, "  ", model.ReplyTemplateSnippet, "hi")
+	assert.ErrorIs(t, err, ErrInvalidReplyTemplateName)
+
+	_, err = svc.Create(ctx, "thanks", "other", "hi")
+	assert.ErrorIs(t, err, ErrInvalidReplyTemplateKind)
+
+	_, err = svc.Create(ctx, "thanks", model.ReplyTemplateSnippet, " \n")
+	assert.ErrorIs(t, err, ErrEmptyReplyTemplateBody)
+	assert.Empty(t, store.templates)
+
+	tpl, err := svc.Create(ctx, " thanks ", model.ReplyTemplateSnippet, "Thanks {{author}}!")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tpl.ID)
+	assert.Equal(t, "thanks", tpl.Name)
+}
+
+func TestExpandReplyTemplate(t *testing.T) {
+	pr := model.PullRequest{
+		Number:       42,
+		RepoFullName: "org/repo",
+		Title:        "Add caching",
+		Author:       "alice",
+		Branch:       "feature/cache",
+		BaseBranch:   "main",
+	}
+
+	got := ExpandReplyTemplate("Thanks @{{author}} for #{{pr_number}} ({{ pr_title }}) in {{repo}}: {{branch}} -> {{base_branch}}. -- {{me}}", pr, "bob")
+	assert.Equal(t, "Thanks @alice for #42 (Add caching) in org/repo: feature/cache -> main. -- bob", got)
+
+	assert.Equal(t, "{{unknown}} and {{AUTHOR}}", ExpandReplyTemplate("{{unknown}} and {{AUTHOR}}", pr, ""), "unknown variables are left as written")
+}
@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+var (
+	// ErrTeamImportUnavailable is returned when importing a team's
+	// repositories without a GitHub client configured for it.
+	ErrTeamImportUnavailable = errors.New("team import is not available")
+
+	// ErrInvalidTeam is returned when a team is not in org/team-slug form.
+	ErrInvalidTeam = errors.New("team must be in org/team-slug format")
+)
+
+// RepoRefresher polls a repository immediately, blocking until it finishes.
+type RepoRefresher interface {
+	RefreshRepo(ctx context.Context, repoFullName string) error
+}
+
+// RepoImportService adds many repositories to the watch list at once, from a
+// pasted list or a GitHub team's repositories.
+type RepoImportService struct {
+	importer      driven.RepoImporter
+	refresher     RepoRefresher                             // optional; imported repos wait for the next poll when nil
+	tokenProvider func(ctx context.Context) (string, error) // optional; team import is unavailable when nil
+	listerFactory func(token string) driven.TeamRepoLister  // optional; team import is unavailable when nil
+	now           func() time.Time
+}
+
+// NewRepoImportService creates a RepoImportService. refresher may be nil.
+func NewRepoImportService(importer driven.RepoImporter, refresher RepoRefresher) *RepoImportService {
+	return &RepoImportService{importer: importer, refresher: refresher, now: time.Now}
+}
+
+// WithTeamRepoLister enables importing a team's repositories. tokenProvider
+// is called per import so a token saved in the GUI applies without a restart.
+func (s *RepoImportService) WithTeamRepoLister(tokenProvider func(ctx context.Context) (string, error), factory func(token string) driven.TeamRepoLister) *RepoImportService {
+	s.tokenProvider = tokenProvider
+	s.listerFactory = factory
+	return s
+}
+
+// ParseRepoList splits a pasted or uploaded repository list into names, one
+// per line. Blank lines and lines starting with # are skipped.
+func ParseRepoList(text string) []string {
+	var names []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names
+}
+
+// TeamRepos returns the repositories of team, given as "org/team-slug".
+func (s *RepoImportService) TeamRepos(ctx context.Context, team string) ([]string, error) {
+	if s.tokenProvider == nil || s.listerFactory == nil {
+		return nil, ErrTeamImportUnavailable
+	}
+	team = strings.TrimSpace(team)
+	if !validate.IsValidRepoName(team) {
+		return nil, ErrInvalidTeam
+	}
+	org, slug, _ := strings.Cut(team, "/")
+
+	token, err := s.tokenProvider(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get GitHub token: %w", err)
+	}
+	return s.listerFactory(token).ListTeamRepos(ctx, org, slug)
+}
+
+// Import validates names and adds the valid ones in a single transaction,
+// returning one result per name in the order given. Newly added
+// repositories are refreshed one at a time in the background.
+func (s *RepoImportService) Import(ctx context.Context, names []string) ([]model.RepoImportResult, error) {
+	results := make([]model.RepoImportResult, len(names))
+	seen := make(map[string]bool, len(names))
+	var repos []model.Repository
+	now := s.now().UTC()
+
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		results[i].FullName = name
+		switch {
+		case !validate.IsValidRepoName(name):
+			results[i].Status = model.RepoImportInvalid
+		case seen[strings.ToLower(name)]:
+			results[i].Status = model.RepoImportDuplicate
+		default:
+			seen[strings.ToLower(name)] = true
+			owner, repo, _ := strings.Cut(name, "/")
+			repos = append(repos, model.Repository{FullName: name, Owner: owner, Name: repo, AddedAt: now})
+		}
+	}
+
+	var added []string
+	if len(repos) > 0 {
+		var err error
+		if added, err = s.importer.AddMany(ctx, repos); err != nil {
+			return nil, err
+		}
+	}
+
+	isAdded := make(map[string]bool, len(added))
+	for _, name := range added {
+		isAdded[name] = true
+	}
+	for i := range results {
+		if results[i].Status != "" {
+			continue
+		}
+		if isAdded[results[i].FullName] {
+			results[i].Status = model.RepoImportAdded
+		} else {
+			results[i].Status = model.RepoImportAlreadyWatched
+		}
+	}
+
+	s.refreshInBackground(added)
+	return results, nil
+}
+
+// refreshInBackground polls the given repositories one after another so a
+// large import does not burst the GitHub rate limit.
+func (s *RepoImportService) refreshInBackground(names []string) {
+	if s.refresher == nil || len(names) == 0 {
+		return
+	}
+	go func() { //nolint:contextcheck // intentional background context for fire-and-forget
+		for _, name := range names {
+			if err := s.refresher.RefreshRepo(context.Background(), name); err != nil {
+				slog.Error("imported repo refresh failed", "repo", name, "error", err)
+			}
+		}
+	}()
+}
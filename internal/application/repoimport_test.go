@@ -0,0 +1,109 @@
+package application_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+type fakeRepoImporter struct {
+	watched map[string]bool
+	got     []model.Repository
+}
+
+func (f *fakeRepoImporter) AddMany(_ context.Context, repos []model.Repository) ([]string, error) {
+	f.got = repos
+	var added []string
+	for _, r := range repos {
+		if !f.watched[r.FullName] {
+			added = append(added, r.FullName)
+		}
+	}
+	return added, nil
+}
+
+type fakeRepoRefresher struct {
+	mu        sync.Mutex
+	refreshed []string
+}
+
+func (f *fakeRepoRefresher) RefreshRepo(_ context.Context, repoFullName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refreshed = append(f.refreshed, repoFullName)
+	return nil
+}
+
+func (f *fakeRepoRefresher) names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.refreshed...)
+}
+
+type fakeTeamRepoLister struct {
+	org, slug string
+}
+
+func (f *fakeTeamRepoLister) ListTeamRepos(_ context.Context, org, slug string) ([]string, error) {
+	f.org, f.slug = org, slug
+	return []string{"acme/api", "acme/web"}, nil
+}
+
+func TestParseRepoList(t *testing.T) {
+	got := application.ParseRepoList("org/api\n\n  # platform\n org/web \r\norg/cli")
+	assert.Equal(t, []string{"org/api", "org/web", "org/cli"}, got)
+}
+
+func TestRepoImportService_Import(t *testing.T) {
+	importer := &fakeRepoImporter{watched: map[string]bool{"org/old": true}}
+	refresher := &fakeRepoRefresher{}
+	svc := application.NewRepoImportService(importer, refresher)
+
+	results, err := svc.Import(context.Background(), []string{"org/api", "not a repo", "org/old", "Org/API", "org/web"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.RepoImportResult{
+		{FullName: "org/api", Status: model.RepoImportAdded},
+		{FullName: "not a repo", Status: model.RepoImportInvalid},
+		{FullName: "org/old", Status: model.RepoImportAlreadyWatched},
+		{FullName: "Org/API", Status: model.RepoImportDuplicate},
+		{FullName: "org/web", Status: model.RepoImportAdded},
+	}, results)
+	require.Len(t, importer.got, 3, "valid, distinct names are added together")
+	assert.Equal(t, "org", importer.got[0].Owner)
+	assert.Equal(t, "api", importer.got[0].Name)
+
+	assert.Eventually(t, func() bool { return len(refresher.names()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"org/api", "org/web"}, refresher.names(), "only added repos are refreshed")
+}
+
+func TestRepoImportService_TeamRepos(t *testing.T) {
+	svc := application.NewRepoImportService(&fakeRepoImporter{}, nil)
+	_, err := svc.TeamRepos(context.Background(), "acme/platform")
+	assert.ErrorIs(t, err, application.ErrTeamImportUnavailable)
+
+	lister := &fakeTeamRepoLister{}
+	var usedToken string
+	svc.WithTeamRepoLister(
+		func(context.Context) (string, error) { return "tok", nil },
+		func(token string) driven.TeamRepoLister { usedToken = token; return lister },
+	)
+
+	_, err = svc.TeamRepos(context.Background(), "platform")
+	assert.ErrorIs(t, err, application.ErrInvalidTeam)
+
+	names, err := svc.TeamRepos(context.Background(), " acme/platform ")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme/api", "acme/web"}, names)
+	assert.Equal(t, "tok", usedToken)
+	assert.Equal(t, "acme", lister.org)
+	assert.Equal(t, "platform", lister.slug)
+}
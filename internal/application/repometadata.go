@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// repoMetadataMaxAge is how long fetched repository metadata is trusted
+// before the poll loop fetches it again. Descriptions, default branches, and
+// visibility rarely change, so one extra API call per repo a day is enough.
+const repoMetadataMaxAge = 24 * time.Hour
+
+// WithRepoMetadataStore enables storing each repository's metadata: the open
+// PR count after every poll, and the description, default branch,
+// visibility, and archived flag when first polled and then daily.
+func (s *PollService) WithRepoMetadataStore(store driven.RepoMetadataStore) *PollService {
+	s.repoMetadata = store
+	return s
+}
+
+// syncRepoMetadata stores the open PR count seen by this poll and refetches
+// the rest of the metadata once it is older than repoMetadataMaxAge. A repo
+// that became archived on GitHub is logged, since it will get no new PRs.
+// Failures are logged; they do not fail the poll.
+func (s *PollService) syncRepoMetadata(ctx context.Context, gh driven.GitHubClient, repoFullName string, openPRs int) {
+	if s.repoMetadata == nil {
+		return
+	}
+
+	repo, err := s.repoStore.GetByFullName(ctx, repoFullName)
+	if err != nil || repo == nil {
+		slog.Warn("failed to load repo for metadata", "repo", repoFullName, "error", err)
+		return
+	}
+
+	metadata := repo.Metadata
+	metadata.OpenPRCount = openPRs
+	if fetcher, ok := gh.(driven.RepoMetadataFetcher); ok && time.Since(metadata.FetchedAt) >= repoMetadataMaxAge {
+		fetched, err := fetcher.FetchRepoMetadata(ctx, repoFullName)
+		if err != nil {
+			slog.Warn("failed to fetch repo metadata", "repo", repoFullName, "error", err)
+		} else {
+			if fetched.Archived && !repo.Metadata.Archived && !repo.Metadata.FetchedAt.IsZero() {
+				slog.Warn("watched repository was archived on GitHub", "repo", repoFullName)
+			}
+			fetched.OpenPRCount = openPRs
+			fetched.FetchedAt = time.Now()
+			metadata = fetched
+		}
+	}
+
+	if metadata == repo.Metadata {
+		return
+	}
+	if err := s.repoMetadata.SetMetadata(ctx, repoFullName, metadata); err != nil {
+		slog.Warn("failed to store repo metadata", "repo", repoFullName, "error", err)
+	}
+}
+
+// countOpenPRs returns how many of prs are open.
+func countOpenPRs(prs []model.PullRequest) int {
+	n := 0
+	for _, pr := range prs {
+		if pr.Status == model.PRStatusOpen {
+			n++
+		}
+	}
+	return n
+}
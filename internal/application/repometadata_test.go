@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// metadataRepoStore serves one repository and records metadata writes.
+type metadataRepoStore struct {
+	driven.RepoStore
+	repo   model.Repository
+	stored []model.RepoMetadata
+}
+
+func (m *metadataRepoStore) GetByFullName(_ context.Context, _ string) (*model.Repository, error) {
+	repo := m.repo
+	return &repo, nil
+}
+
+func (m *metadataRepoStore) SetMetadata(_ context.Context, _ string, metadata model.RepoMetadata) error {
+	m.stored = append(m.stored, metadata)
+	m.repo.Metadata = metadata
+	return nil
+}
+
+// metadataClient is a GitHub client that only fetches repository metadata.
+type metadataClient struct {
+	driven.GitHubClient
+	fetches int
+}
+
+func (c *metadataClient) FetchRepoMetadata(_ context.Context, _ string) (model.RepoMetadata, error) {
+	c.fetches++
+	return model.RepoMetadata{Description: "API", DefaultBranch: "main", Archived: true}, nil
+}
+
+func TestSyncRepoMetadata(t *testing.T) {
+	store := &metadataRepoStore{repo: model.Repository{FullName: "org/api"}}
+	client := &metadataClient{}
+	svc := (&PollService{repoStore: store}).WithRepoMetadataStore(store)
+
+	svc.syncRepoMetadata(t.Context(), client, "org/api", 3)
+	require.Len(t, store.stored, 1)
+	got := store.stored[0]
+	assert.Equal(t, "API", got.Description)
+	assert.Equal(t, "main", got.DefaultBranch)
+	assert.True(t, got.Archived)
+	assert.Equal(t, 3, got.OpenPRCount)
+	assert.WithinDuration(t, time.Now(), got.FetchedAt, time.Second)
+
+	svc.syncRepoMetadata(t.Context(), client, "org/api", 3)
+	assert.Len(t, store.stored, 1, "unchanged metadata is not rewritten")
+
+	svc.syncRepoMetadata(t.Context(), client, "org/api", 5)
+	require.Len(t, store.stored, 2)
+	assert.Equal(t, 5, store.stored[1].OpenPRCount)
+	assert.Equal(t, 1, client.fetches, "fresh metadata is not refetched")
+
+	store.repo.Metadata.FetchedAt = time.Now().Add(-repoMetadataMaxAge)
+	svc.syncRepoMetadata(t.Context(), client, "org/api", 5)
+	assert.Equal(t, 2, client.fetches, "stale metadata is refetched")
+}
+
+func TestCountOpenPRs(t *testing.T) {
+	prs := []model.PullRequest{{Status: model.PRStatusOpen}, {Status: model.PRStatusMerged}, {Status: model.PRStatusOpen}}
+	assert.Equal(t, 2, countOpenPRs(prs))
+}
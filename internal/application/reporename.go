@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithRepoRenamer enables automatic migration of repositories that GitHub
+// reports as renamed or transferred. Without it a moved repo keeps its old
+// name and its circuit stays open until the user re-adds it.
+func (s *PollService) WithRepoRenamer(renamer driven.RepoRenamer) *PollService {
+	s.repoRenamer = renamer
+	return s
+}
+
+// migrateMovedRepo moves a renamed repository's stored data to its new full
+// name and drops the old name's schedule. If the new name is already watched,
+// the stale entry is removed instead. It reports whether the old name is gone,
+// in which case the caller should carry on under moved.To.
+func (s *PollService) migrateMovedRepo(ctx context.Context, moved *driven.RepoMovedError) bool {
+	if s.repoRenamer == nil {
+		return false
+	}
+
+	err := s.repoRenamer.RenameRepo(ctx, moved.From, moved.To)
+	switch {
+	case err == nil:
+		slog.Info("repo moved on GitHub; migrated stored data to new name",
+			"from", moved.From,
+			"to", moved.To,
+		)
+	case errors.Is(err, driven.ErrRepoAlreadyExists):
+		if err := s.repoStore.Remove(ctx, moved.From); err != nil && !errors.Is(err, driven.ErrRepoNotFound) {
+			slog.Error("failed to remove stale name of moved repo", "from", moved.From, "to", moved.To, "error", err)
+			return false
+		}
+		slog.Info("repo moved on GitHub to an already watched name; removed stale entry",
+			"from", moved.From,
+			"to", moved.To,
+		)
+	default:
+		slog.Error("failed to migrate moved repo", "from", moved.From, "to", moved.To, "error", err)
+		return false
+	}
+
+	s.schedulesMu.Lock()
+	delete(s.schedules, moved.From)
+	s.schedulesMu.Unlock()
+
+	return true
+}
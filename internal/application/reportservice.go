@@ -0,0 +1,420 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+const (
+	// maxScheduledReports bounds the reports that can be scheduled.
+	maxScheduledReports = 20
+
+	// reportListLimit bounds the PRs and threads listed in each report section.
+	reportListLimit = 10
+
+	// reportPeriod is the span of merged PRs a report covers.
+	reportPeriod = 7 * 24 * time.Hour
+)
+
+// ErrInvalidScheduledReport is returned for a report with a missing name, an
+// unknown destination, a malformed target or template, an out-of-range
+// schedule, or an unknown repo group, or when the report limit is reached.
+var ErrInvalidScheduledReport = errors.New("invalid scheduled report")
+
+// DefaultReportTemplate is used by reports without a template of their own.
+// It renders as plain text with Markdown-style bullets, which read well in
+// both Slack and GitHub.
+const DefaultReportTemplate = `*{{.Name}}*: {{.Scope}}, {{.From.Format "Jan 2"}} to {{.To.Format "Jan 2"}}
+
+*Merged this week: {{.MergedCount}}*
+{{range .Merged}}- {{.Repo}}#{{.Number}} {{.Title}} by {{.Author}}, merged after {{.Days}}d
+{{else}}- none
+{{end}}
+*Open PRs: {{.OpenCount}}*, oldest first
+{{range .Open}}- {{.Repo}}#{{.Number}} {{.Title}} by {{.Author}}, open {{.Days}}d
+{{else}}- none
+{{end}}
+*Top unresolved threads*
+{{range .Threads}}- {{.Repo}}#{{.Number}} {{.Path}}{{if .Line}}:{{.Line}}{{end}}, {{.Comments}} comment{{if ne .Comments 1}}s{{end}}, {{.Days}}d old
+{{else}}- none
+{{end}}`
+
+// ReportData is the data a report template renders.
+type ReportData struct {
+	Name        string
+	Scope       string // the repo group's name, or "All repositories"
+	From, To    time.Time
+	MergedCount int
+	Merged      []ReportPR // newest first, at most reportListLimit
+	OpenCount   int
+	Open        []ReportPR // oldest first, at most reportListLimit
+	Threads     []ReportThread
+}
+
+// ReportPR is a pull request listed in a report.
+type ReportPR struct {
+	Repo   string
+	Number int
+	Title  string
+	Author string
+	URL    string
+	Days   int // days open, or days from opening to merge for merged PRs
+}
+
+// ReportThread is an unresolved review thread listed in a report.
+type ReportThread struct {
+	Repo     string
+	Number   int
+	PRTitle  string
+	URL      string // the PR's URL
+	Path     string
+	Line     int
+	Author   string // who started the thread
+	Comments int
+	Days     int // days since the thread started
+}
+
+// ReportService generates weekly repository health summaries from stored
+// data and posts them to Slack or as GitHub issue comments on schedule.
+type ReportService struct {
+	store       driven.ScheduledReportStore
+	prStore     driven.PRStore
+	reviewStore driven.ReviewStore
+	groups      driven.RepoGroupStore
+	sender      driven.ReportSender
+	writerFor   func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)
+	now         func() time.Time
+}
+
+// NewReportService creates a ReportService. Reports can be managed and
+// previewed, but are only sent once WithDelivery is called.
+func NewReportService(store driven.ScheduledReportStore, prStore driven.PRStore, reviewStore driven.ReviewStore, groups driven.RepoGroupStore) *ReportService {
+	return &ReportService{store: store, prStore: prStore, reviewStore: reviewStore, groups: groups, now: time.Now}
+}
+
+// WithDelivery sets how Run posts reports: to Slack through sender, and as
+// GitHub comments through the writer writerFor returns for the repository.
+func (s *ReportService) WithDelivery(sender driven.ReportSender,
+	writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error),
+) *ReportService {
+	s.sender = sender
+	s.writerFor = writerFor
+	return s
+}
+
+// Create validates and stores a report. It returns ErrInvalidScheduledReport
+// for a bad report or when the report limit is reached.
+func (s *ReportService) Create(ctx context.Context, report model.ScheduledReport) (model.ScheduledReport, error) {
+	report.Name = strings.TrimSpace(report.Name)
+	report.Target = strings.TrimSpace(report.Target)
+	if strings.TrimSpace(report.Template) == "" {
+		report.Template = ""
+	}
+	if err := s.validate(ctx, report); err != nil {
+		return model.ScheduledReport{}, err
+	}
+
+	existing, err := s.store.List(ctx)
+	if err != nil {
+		return model.ScheduledReport{}, err
+	}
+	if len(existing) >= maxScheduledReports {
+		return model.ScheduledReport{}, fmt.Errorf("%w: at most %d reports", ErrInvalidScheduledReport, maxScheduledReports)
+	}
+
+	report.CreatedAt = s.now().UTC()
+	id, err := s.store.Create(ctx, report)
+	if err != nil {
+		return model.ScheduledReport{}, err
+	}
+	report.ID = id
+	return report, nil
+}
+
+// List returns all scheduled reports.
+func (s *ReportService) List(ctx context.Context) ([]model.ScheduledReport, error) {
+	return s.store.List(ctx)
+}
+
+// Delete removes a report. It returns driven.ErrScheduledReportNotFound if
+// it does not exist.
+func (s *ReportService) Delete(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Preview renders report as it would be posted now, without sending it. It
+// returns ErrInvalidScheduledReport for a bad template or unknown repo group.
+func (s *ReportService) Preview(ctx context.Context, report model.ScheduledReport) (string, error) {
+	return s.render(ctx, report, s.now())
+}
+
+// Run sends the reports that are due every interval until ctx is canceled.
+func (s *ReportService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.sendDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDue renders and posts every due report. A failed report is logged and
+// tried again on the next run.
+func (s *ReportService) sendDue(ctx context.Context) {
+	reports, err := s.store.List(ctx)
+	if err != nil {
+		slog.Error("failed to list scheduled reports", "error", err)
+		return
+	}
+
+	now := s.now()
+	for _, report := range reports {
+		if !report.Due(now) {
+			continue
+		}
+		text, err := s.render(ctx, report, now)
+		if err == nil {
+			err = s.deliver(ctx, report, text)
+		}
+		if err != nil {
+			slog.Warn("scheduled report failed", "report", report.ID, "name", report.Name, "error", err)
+			continue
+		}
+		if err := s.store.MarkSent(ctx, report.ID, now); err != nil {
+			slog.Error("failed to record scheduled report", "report", report.ID, "error", err)
+			continue
+		}
+		slog.Info("scheduled report sent", "report", report.ID, "name", report.Name, "destination", report.Destination)
+	}
+}
+
+// deliver posts text to the report's destination.
+func (s *ReportService) deliver(ctx context.Context, report model.ScheduledReport, text string) error {
+	switch report.Destination {
+	case model.ReportToSlack:
+		if s.sender == nil {
+			return errors.New("report delivery is not configured")
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, webhookSendTimeout)
+		defer cancel()
+		return s.sender.SendReport(sendCtx, report.Target, text)
+	case model.ReportToGitHubIssue:
+		if s.writerFor == nil {
+			return errors.New("report delivery is not configured")
+		}
+		repo, number, ok := parseIssueTarget(report.Target)
+		if !ok {
+			return fmt.Errorf("malformed issue %q", report.Target)
+		}
+		writer, err := s.writerFor(ctx, repo)
+		if err != nil {
+			return err
+		}
+		return writer.CreateIssueComment(ctx, repo, number, text)
+	default:
+		return fmt.Errorf("unknown destination %q", report.Destination)
+	}
+}
+
+// render generates report's data as of now and executes its template.
+func (s *ReportService) render(ctx context.Context, report model.ScheduledReport, now time.Time) (string, error) {
+	tmpl, err := parseReportTemplate(report.Template)
+	if err != nil {
+		return "", err
+	}
+	data, err := s.reportData(ctx, report, now)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidScheduledReport, err)
+	}
+	return b.String(), nil
+}
+
+// reportData collects the merged PRs of the week before now, the open PRs,
+// and the busiest unresolved threads of the repositories report covers.
+func (s *ReportService) reportData(ctx context.Context, report model.ScheduledReport, now time.Time) (ReportData, error) {
+	data := ReportData{Name: report.Name, Scope: "All repositories", From: now.Add(-reportPeriod).UTC(), To: now.UTC()}
+
+	var repos map[string]bool
+	if report.GroupID != 0 {
+		group, err := s.groups.Get(ctx, report.GroupID)
+		if err != nil {
+			return ReportData{}, err
+		}
+		if group == nil {
+			return ReportData{}, fmt.Errorf("%w: repo group %d does not exist", ErrInvalidScheduledReport, report.GroupID)
+		}
+		data.Scope = group.Name
+		repos = make(map[string]bool, len(group.Repos))
+		for _, name := range group.Repos {
+			repos[name] = true
+		}
+	}
+
+	prs, err := s.prStore.ListAll(ctx)
+	if err != nil {
+		return ReportData{}, err
+	}
+
+	var merged, open []model.PullRequest
+	for _, pr := range prs {
+		if repos != nil && !repos[pr.RepoFullName] {
+			continue
+		}
+		switch {
+		case pr.Status == model.PRStatusMerged && !pr.ClosedAt.Before(data.From) && !pr.ClosedAt.After(now):
+			merged = append(merged, pr)
+		case pr.Status == model.PRStatusOpen:
+			open = append(open, pr)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].ClosedAt.After(merged[j].ClosedAt) })
+	sort.SliceStable(open, func(i, j int) bool { return open[i].OpenedAt.Before(open[j].OpenedAt) })
+
+	data.MergedCount = len(merged)
+	for _, pr := range merged[:min(len(merged), reportListLimit)] {
+		days := 0
+		if d, ok := pr.TimeToMerge(); ok {
+			days = int(d.Hours() / 24)
+		}
+		data.Merged = append(data.Merged, toReportPR(pr, days))
+	}
+	data.OpenCount = len(open)
+	for _, pr := range open[:min(len(open), reportListLimit)] {
+		data.Open = append(data.Open, toReportPR(pr, int(now.Sub(pr.OpenedAt).Hours()/24)))
+	}
+
+	data.Threads, err = s.topUnresolvedThreads(ctx, open, now)
+	if err != nil {
+		return ReportData{}, err
+	}
+	return data, nil
+}
+
+// topUnresolvedThreads returns the unresolved review threads of prs with the
+// most comments, oldest first among equals.
+func (s *ReportService) topUnresolvedThreads(ctx context.Context, prs []model.PullRequest, now time.Time) ([]ReportThread, error) {
+	var threads []ReportThread
+	for _, pr := range prs {
+		comments, err := s.reviewStore.GetReviewCommentsByPR(ctx, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range groupIntoThreads(comments) {
+			if t.IsResolved {
+				continue
+			}
+			threads = append(threads, ReportThread{
+				Repo:     pr.RepoFullName,
+				Number:   pr.Number,
+				PRTitle:  pr.Title,
+				URL:      pr.URL,
+				Path:     t.RootComment.Path,
+				Line:     t.RootComment.Line,
+				Author:   t.RootComment.Author,
+				Comments: 1 + len(t.Replies),
+				Days:     int(now.Sub(t.RootComment.CreatedAt).Hours() / 24),
+			})
+		}
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		if threads[i].Comments != threads[j].Comments {
+			return threads[i].Comments > threads[j].Comments
+		}
+		return threads[i].Days > threads[j].Days
+	})
+	return threads[:min(len(threads), reportListLimit)], nil
+}
+
+// toReportPR converts a PR for listing in a report.
+func toReportPR(pr model.PullRequest, days int) ReportPR {
+	return ReportPR{Repo: pr.RepoFullName, Number: pr.Number, Title: pr.Title, Author: pr.Author, URL: pr.URL, Days: days}
+}
+
+// validate checks a report's fields, its template, and that its repo group exists.
+func (s *ReportService) validate(ctx context.Context, report model.ScheduledReport) error {
+	if report.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidScheduledReport)
+	}
+	switch report.Destination {
+	case model.ReportToSlack:
+		u, err := url.Parse(report.Target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: Slack webhook URL must be an absolute http or https URL", ErrInvalidScheduledReport)
+		}
+	case model.ReportToGitHubIssue:
+		if _, _, ok := parseIssueTarget(report.Target); !ok {
+			return fmt.Errorf("%w: issue must be in owner/repo#number form", ErrInvalidScheduledReport)
+		}
+	default:
+		return fmt.Errorf("%w: unknown destination %q", ErrInvalidScheduledReport, report.Destination)
+	}
+	if report.Weekday < time.Sunday || report.Weekday > time.Saturday {
+		return fmt.Errorf("%w: unknown weekday", ErrInvalidScheduledReport)
+	}
+	if report.Hour < 0 || report.Hour > 23 {
+		return fmt.Errorf("%w: hour must be between 0 and 23", ErrInvalidScheduledReport)
+	}
+	if report.GroupID != 0 {
+		group, err := s.groups.Get(ctx, report.GroupID)
+		if err != nil {
+			return err
+		}
+		if group == nil {
+			return fmt.Errorf("%w: repo group does not exist", ErrInvalidScheduledReport)
+		}
+	}
+	tmpl, err := parseReportTemplate(report.Template)
+	if err != nil {
+		return err
+	}
+	// Unknown fields only surface when the template runs.
+	if err := tmpl.Execute(new(strings.Builder), ReportData{}); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidScheduledReport, err)
+	}
+	return nil
+}
+
+// parseReportTemplate parses a report template, the default when src is empty.
+func parseReportTemplate(src string) (*template.Template, error) {
+	if src == "" {
+		src = DefaultReportTemplate
+	}
+	tmpl, err := template.New("report").Option("missingkey=error").Parse(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidScheduledReport, err)
+	}
+	return tmpl, nil
+}
+
+// parseIssueTarget splits an owner/repo#number issue reference.
+func parseIssueTarget(target string) (repoFullName string, number int, ok bool) {
+	repoFullName, num, found := strings.Cut(target, "#")
+	if !found || !validate.IsValidRepoName(repoFullName) {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(num)
+	if err != nil || number <= 0 {
+		return "", 0, false
+	}
+	return repoFullName, number, true
+}
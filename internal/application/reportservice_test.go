@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memReportStore is an in-memory ScheduledReportStore.
+type memReportStore struct {
+	reports []model.ScheduledReport
+}
+
+func (m *memReportStore) Create(_ context.Context, report model.ScheduledReport) (int64, error) {
+	report.ID = int64(len(m.reports) + 1)
+	m.reports = append(m.reports, report)
+	return report.ID, nil
+}
+
+func (m *memReportStore) List(_ context.Context) ([]model.ScheduledReport, error) {
+	return append([]model.ScheduledReport(nil), m.reports...), nil
+}
+
+func (m *memReportStore) Delete(_ context.Context, _ int64) error { panic("unused") }
+
+func (m *memReportStore) MarkSent(_ context.Context, id int64, at time.Time) error {
+	for i := range m.reports {
+		if m.reports[i].ID == id {
+			m.reports[i].LastSentAt = at
+		}
+	}
+	return nil
+}
+
+// memGroupStore serves repo groups by ID.
+type memGroupStore struct {
+	driven.RepoGroupStore
+	groups []model.RepoGroup
+}
+
+func (m *memGroupStore) Get(_ context.Context, id int64) (*model.RepoGroup, error) {
+	for i := range m.groups {
+		if m.groups[i].ID == id {
+			return &m.groups[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// recordingReportSender records the reports posted to Slack.
+type recordingReportSender struct {
+	urls, texts []string
+}
+
+func (r *recordingReportSender) SendReport(_ context.Context, url, text string) error {
+	r.urls = append(r.urls, url)
+	r.texts = append(r.texts, text)
+	return nil
+}
+
+func newTestReportService(store *memReportStore, now time.Time) *ReportService {
+	day := 24 * time.Hour
+	prs := []model.PullRequest{
+		{ID: 1, RepoFullName: "acme/api", Number: 1, Title: "Old open", Author: "bob", Status: model.PRStatusOpen, OpenedAt: now.Add(-20 * day)},
+		{ID: 2, RepoFullName: "acme/api", Number: 2, Title: "New open", Author: "carol", Status: model.PRStatusOpen, OpenedAt: now.Add(-2 * day)},
+		{ID: 3, RepoFullName: "acme/api", Number: 3, Title: "Merged", Author: "bob", Status: model.PRStatusMerged, OpenedAt: now.Add(-6 * day), ClosedAt: now.Add(-3 * day)},
+		{ID: 4, RepoFullName: "acme/api", Number: 4, Title: "Merged long ago", Status: model.PRStatusMerged, OpenedAt: now.Add(-30 * day), ClosedAt: now.Add(-10 * day)},
+		{ID: 5, RepoFullName: "acme/web", Number: 5, Title: "Other repo", Status: model.PRStatusOpen, OpenedAt: now.Add(-40 * day)},
+	}
+	reviews := &prReviewStore{comments: map[int64][]model.ReviewComment{
+		1: {
+			{ID: 10, Author: "carol", Path: "a.go", Line: 4, CreatedAt: now.Add(-5 * day)},
+			{ID: 11, Author: "bob", InReplyToID: int64Ptr(10)},
+			{ID: 12, Author: "carol", Path: "b.go", Line: 1, IsResolved: true},
+		},
+		2: {{ID: 20, Author: "bob", Path: "c.go", CreatedAt: now.Add(-day)}},
+	}}
+	groups := &memGroupStore{groups: []model.RepoGroup{{ID: 7, Name: "API", Repos: []string{"acme/api"}}}}
+	svc := NewReportService(store, &testPRStore{prs: prs}, reviews, groups)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestReportService_Preview(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	svc := newTestReportService(&memReportStore{}, now)
+
+	text, err := svc.Preview(context.Background(), model.ScheduledReport{Name: "Weekly", GroupID: 7})
+	require.NoError(t, err)
+
+	assert.Equal(t, `*Weekly*: API, Oct 5 to Oct 12
+
+*Merged this week: 1*
+- acme/api#3 Merged by bob, merged after 3d
+
+*Open PRs: 2*, oldest first
+- acme/api#1 Old open by bob, open 20d
+- acme/api#2 New open by carol, open 2d
+
+*Top unresolved threads*
+- acme/api#1 a.go:4, 2 comments, 5d old
+- acme/api#2 c.go, 1 comment, 1d old
+`, text)
+
+	text, err = svc.Preview(context.Background(), model.ScheduledReport{Name: "All", Template: "{{.Scope}}: {{.OpenCount}} open"})
+	require.NoError(t, err)
+	assert.Equal(t, "All repositories: 3 open", text)
+
+	_, err = svc.Preview(context.Background(), model.ScheduledReport{Template: "{{.Nope}}"})
+	assert.ErrorIs(t, err, ErrInvalidScheduledReport)
+}
+
+func TestReportService_CreateValidates(t *testing.T) {
+	svc := newTestReportService(&memReportStore{}, time.Now())
+	ctx := context.Background()
+
+	valid := model.ScheduledReport{Name: "Weekly", Destination: model.ReportToGitHubIssue, Target: "acme/api#12", Weekday: time.Monday, Hour: 9}
+	created, err := svc.Create(ctx, valid)
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+
+	for name, mutate := range map[string]func(*model.ScheduledReport){
+		"no name":       func(r *model.ScheduledReport) { r.Name = " " },
+		"bad issue":     func(r *model.ScheduledReport) { r.Target = "acme/api" },
+		"bad slack url": func(r *model.ScheduledReport) { r.Destination, r.Target = model.ReportToSlack, "hooks.slack.com" },
+		"bad hour":      func(r *model.ScheduledReport) { r.Hour = 24 },
+		"bad weekday":   func(r *model.ScheduledReport) { r.Weekday = 7 },
+		"unknown group": func(r *model.ScheduledReport) { r.GroupID = 99 },
+		"bad template":  func(r *model.ScheduledReport) { r.Template = "{{.Open" },
+		"unknown field": func(r *model.ScheduledReport) { r.Template = "{{.Nope}}" },
+	} {
+		report := valid
+		mutate(&report)
+		_, err := svc.Create(ctx, report)
+		assert.ErrorIs(t, err, ErrInvalidScheduledReport, name)
+	}
+}
+
+func TestReportService_SendDue(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC) // a Monday
+	created := now.Add(-30 * 24 * time.Hour)
+	store := &memReportStore{reports: []model.ScheduledReport{
+		{ID: 1, Name: "Slack", Destination: model.ReportToSlack, Target: "https://hooks.slack.com/x", Template: "slack {{.OpenCount}}", Weekday: time.Monday, Hour: 9, CreatedAt: created},
+		{ID: 2, Name: "GitHub", Destination: model.ReportToGitHubIssue, Target: "acme/api#12", Template: "github", Weekday: time.Sunday, Hour: 18, CreatedAt: created},
+		{ID: 3, Name: "Sent", Destination: model.ReportToSlack, Target: "https://hooks.slack.com/y", Weekday: time.Monday, Hour: 9, CreatedAt: created, LastSentAt: now.Add(-10 * time.Minute)},
+		{ID: 4, Name: "Later", Destination: model.ReportToSlack, Target: "https://hooks.slack.com/z", Weekday: time.Monday, Hour: 10, CreatedAt: now.Add(-24 * time.Hour)},
+	}}
+	svc := newTestReportService(store, now)
+	sender := &recordingReportSender{}
+	writer := &outboxWriter{}
+	svc.WithDelivery(sender, func(context.Context, string) (driven.GitHubWriter, error) { return writer, nil })
+
+	svc.sendDue(context.Background())
+
+	assert.Equal(t, []string{"https://hooks.slack.com/x"}, sender.urls)
+	assert.Equal(t, []string{"slack 3"}, sender.texts)
+	assert.Equal(t, []string{"github"}, writer.comments)
+	assert.Equal(t, now, store.reports[0].LastSentAt)
+	assert.Equal(t, now, store.reports[1].LastSentAt)
+	assert.True(t, store.reports[3].LastSentAt.IsZero(), "not due before its first scheduled time")
+
+	sender.urls = nil
+	svc.sendDue(context.Background())
+	assert.Empty(t, sender.urls, "a report is sent once per scheduled time")
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithRepoSettingsStore enables per-repository settings during polling:
+// check names the user marked required or optional override what branch
+// protection reports, which matters when protection cannot be read, and
+// ignored checks are left out of the combined CI status.
+func (s *PollService) WithRepoSettingsStore(store driven.RepoSettingsStore) *PollService {
+	s.repoSettings = store
+	return s
+}
+
+// repoSettingsFor returns a repository's settings. Store failures are logged
+// and yield empty settings, so checks are classified as if none were saved.
+func (s *PollService) repoSettingsFor(ctx context.Context, repoFullName string) model.RepoSettings {
+	if s.repoSettings == nil {
+		return model.RepoSettings{RepoFullName: repoFullName}
+	}
+	settings, err := s.repoSettings.GetRepoSettings(ctx, repoFullName)
+	if err != nil {
+		slog.Error("get repo settings failed", "repo", repoFullName, "error", err)
+		return model.RepoSettings{RepoFullName: repoFullName}
+	}
+	return settings
+}
+
+// applyCheckOverrides marks the repository's manually required checks
+// required and its optional checks not required. It runs after
+// markRequiredChecks has applied branch protection.
+func applyCheckOverrides(checkRuns []model.CheckRun, settings model.RepoSettings) {
+	for i := range checkRuns {
+		if required, overridden := settings.CheckRequirement(checkRuns[i].Name); overridden {
+			checkRuns[i].IsRequired = required
+		}
+	}
+}
+
+// withoutIgnoredChecks returns the check runs and combined status that count
+// toward the combined CI status, dropping the repository's ignored checks.
+// When a status context is dropped, the combined state is recomputed from
+// the remaining contexts, as GitHub's own state still includes it; nil is
+// returned when none remain. The inputs are not modified.
+func withoutIgnoredChecks(checkRuns []model.CheckRun, combinedStatus *model.CombinedStatus, settings model.RepoSettings) ([]model.CheckRun, *model.CombinedStatus) {
+	if len(settings.IgnoredChecks) == 0 {
+		return checkRuns, combinedStatus
+	}
+
+	counted := make([]model.CheckRun, 0, len(checkRuns))
+	for _, cr := range checkRuns {
+		if !settings.IgnoresCheck(cr.Name) {
+			counted = append(counted, cr)
+		}
+	}
+
+	if combinedStatus == nil {
+		return counted, nil
+	}
+	var statuses []model.CommitStatus
+	for _, st := range combinedStatus.Statuses {
+		if !settings.IgnoresCheck(st.Context) {
+			statuses = append(statuses, st)
+		}
+	}
+	if len(statuses) == len(combinedStatus.Statuses) {
+		return counted, combinedStatus
+	}
+	if len(statuses) == 0 {
+		return counted, nil
+	}
+	return counted, &model.CombinedStatus{State: combinedState(statuses), Statuses: statuses}
+}
+
+// combinedState aggregates commit statuses the way GitHub's combined status
+// does: failure if any failed or errored, pending if any is pending,
+// success otherwise.
+func combinedState(statuses []model.CommitStatus) string {
+	state := "success"
+	for _, st := range statuses {
+		switch st.State {
+		case "failure", "error":
+			return "failure"
+		case "pending":
+			state = "pending"
+		}
+	}
+	return state
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestApplyCheckOverrides(t *testing.T) {
+	checkRuns := []model.CheckRun{
+		{Name: "build", IsRequired: true},
+		{Name: "codecov/patch", IsRequired: true},
+		{Name: "E2E"},
+		{Name: "lint"},
+	}
+
+	applyCheckOverrides(checkRuns, model.RepoSettings{
+		RequiredChecks: []string{"e2e"},
+		OptionalChecks: []string{"codecov/patch"},
+	})
+
+	assert.True(t, checkRuns[0].IsRequired, "branch protection stands without an override")
+	assert.False(t, checkRuns[1].IsRequired, "optional overrides branch protection")
+	assert.True(t, checkRuns[2].IsRequired, "required supplements branch protection, case-insensitively")
+	assert.False(t, checkRuns[3].IsRequired)
+}
+
+func TestWithoutIgnoredChecks(t *testing.T) {
+	settings := model.RepoSettings{IgnoredChecks: []string{"Codecov/patch", "flaky"}}
+	checkRuns := []model.CheckRun{
+		{Name: "build", Status: "completed", Conclusion: "success"},
+		{Name: "flaky", Status: "completed", Conclusion: "failure"},
+	}
+	combined := &model.CombinedStatus{State: "failure", Statuses: []model.CommitStatus{
+		{Context: "ci/jenkins", State: "success"},
+		{Context: "codecov/patch", State: "failure"},
+	}}
+
+	runs, status := withoutIgnoredChecks(checkRuns, combined, settings)
+	assert.Equal(t, []model.CheckRun{checkRuns[0]}, runs)
+	assert.Equal(t, &model.CombinedStatus{State: "success", Statuses: combined.Statuses[:1]}, status,
+		"the combined state is recomputed without the ignored context")
+	assert.Equal(t, model.CIStatusPassing, computeCombinedCIStatus(runs, status))
+	assert.Equal(t, model.CIStatusFailing, computeCombinedCIStatus(checkRuns, combined), "inputs are unchanged")
+
+	_, status = withoutIgnoredChecks(nil, &model.CombinedStatus{State: "failure", Statuses: combined.Statuses[1:]}, settings)
+	assert.Nil(t, status, "no status remains when every context is ignored")
+
+	runs, status = withoutIgnoredChecks(checkRuns, combined, model.RepoSettings{})
+	assert.Equal(t, checkRuns, runs)
+	assert.Same(t, combined, status, "nothing is dropped without ignored checks")
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// RepoTrashService makes removing a repository undoable. Removed repositories
+// keep their PRs, reviews, and checks for the retention period, during which
+// they can be restored; afterwards they are purged for good.
+type RepoTrashService struct {
+	store     driven.RepoTrash
+	retention time.Duration
+	now       func() time.Time
+}
+
+// NewRepoTrashService creates a RepoTrashService that purges removed
+// repositories retention after their removal.
+func NewRepoTrashService(store driven.RepoTrash, retention time.Duration) *RepoTrashService {
+	return &RepoTrashService{store: store, retention: retention, now: time.Now}
+}
+
+// Retention returns how long removed repositories can be restored.
+func (s *RepoTrashService) Retention() time.Duration {
+	return s.retention
+}
+
+// Remove takes a repository off the watch list, keeping its data until
+// purged. It returns driven.ErrRepoNotFound if the repository is not watched.
+func (s *RepoTrashService) Remove(ctx context.Context, fullName string) error {
+	return s.store.Trash(ctx, fullName, s.now())
+}
+
+// Restore puts a removed repository back on the watch list with its history.
+// It returns driven.ErrRepoNotFound if the repository is not removed or was
+// already purged.
+func (s *RepoTrashService) Restore(ctx context.Context, fullName string) error {
+	return s.store.Restore(ctx, fullName)
+}
+
+// ListRemoved returns the restorable repositories, most recently removed first.
+func (s *RepoTrashService) ListRemoved(ctx context.Context) ([]model.Repository, error) {
+	return s.store.ListTrashed(ctx)
+}
+
+// PurgeAt returns when a repository removed at removedAt is purged.
+func (s *RepoTrashService) PurgeAt(removedAt time.Time) time.Time {
+	return removedAt.Add(s.retention)
+}
+
+// Purge deletes repositories removed longer than the retention period ago.
+func (s *RepoTrashService) Purge(ctx context.Context) (int, error) {
+	n, err := s.store.PurgeTrashed(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("purge removed repositories: %w", err)
+	}
+	return n, nil
+}
+
+// Run purges expired removed repositories every interval until ctx is canceled.
+func (s *RepoTrashService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if n, err := s.Purge(ctx); err != nil {
+			slog.Error("removed repository cleanup failed", "error", err)
+		} else if n > 0 {
+			slog.Info("purged removed repositories", "count", n, "retention", s.retention)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
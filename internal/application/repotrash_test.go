@@ -0,0 +1,47 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+type fakeRepoTrash struct {
+	trashedAt    time.Time
+	purgedBefore time.Time
+}
+
+func (f *fakeRepoTrash) Trash(_ context.Context, _ string, at time.Time) error {
+	f.trashedAt = at
+	return nil
+}
+
+func (f *fakeRepoTrash) Restore(context.Context, string) error { return nil }
+
+func (f *fakeRepoTrash) ListTrashed(context.Context) ([]model.Repository, error) { return nil, nil }
+
+func (f *fakeRepoTrash) PurgeTrashed(_ context.Context, cutoff time.Time) (int, error) {
+	f.purgedBefore = cutoff
+	return 2, nil
+}
+
+func TestRepoTrashService(t *testing.T) {
+	store := &fakeRepoTrash{}
+	retention := 7 * 24 * time.Hour
+	svc := application.NewRepoTrashService(store, retention)
+
+	require.NoError(t, svc.Remove(context.Background(), "org/api"))
+	assert.WithinDuration(t, time.Now(), store.trashedAt, time.Minute)
+	assert.Equal(t, store.trashedAt.Add(retention), svc.PurgeAt(store.trashedAt))
+
+	n, err := svc.Purge(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.WithinDuration(t, time.Now().Add(-retention), store.purgedBefore, time.Minute)
+}
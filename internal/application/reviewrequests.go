@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WithReviewRequestStore enables recording when each pending review request
+// on a synced PR was made: from the PR's timeline when the client can read
+// it, otherwise from when a poll first saw the request.
+func (s *PollService) WithReviewRequestStore(store driven.ReviewRequestStore) *PollService {
+	s.reviewRequests = store
+	return s
+}
+
+// recordReviewRequests stores the pending review requests of a synced PR.
+// fetched carries the requested reviewers as fetched from GitHub; stored is
+// the PR row, whose requests from earlier polls keep their times. The
+// timeline is only read when a request is new.
+func (s *PollService) recordReviewRequests(ctx context.Context, gh driven.GitHubClient, stored, fetched model.PullRequest) {
+	if s.reviewRequests == nil {
+		return
+	}
+
+	requests := make([]model.ReviewRequest, 0, len(fetched.RequestedReviewers)+len(fetched.RequestedTeamSlugs))
+	for _, login := range fetched.RequestedReviewers {
+		requests = append(requests, model.ReviewRequest{Reviewer: login})
+	}
+	for _, slug := range fetched.RequestedTeamSlugs {
+		requests = append(requests, model.ReviewRequest{Reviewer: slug, IsTeam: true})
+	}
+
+	if !fillRequestTimes(requests, stored.ReviewRequests) {
+		if fetcher, ok := gh.(driven.ReviewRequestFetcher); ok {
+			timeline, err := fetcher.FetchReviewRequests(ctx, stored.RepoFullName, stored.Number)
+			if err != nil {
+				slog.Warn("fetch review request times failed", "repo", stored.RepoFullName, "pr", stored.Number, "error", err)
+			}
+			fillRequestTimes(requests, timeline)
+		}
+	}
+	now := time.Now().UTC()
+	for i := range requests {
+		if requests[i].RequestedAt.IsZero() {
+			requests[i].RequestedAt = now
+		}
+	}
+
+	if err := s.reviewRequests.SetReviewRequests(ctx, stored.ID, requests); err != nil {
+		slog.Error("store review requests failed", "repo", stored.RepoFullName, "pr", stored.Number, "error", err)
+	}
+}
+
+// fillRequestTimes sets the time of each request in requests that has none
+// from the matching request in known. It reports whether every request now
+// has a time.
+func fillRequestTimes(requests, known []model.ReviewRequest) bool {
+	complete := true
+	for i, req := range requests {
+		if !req.RequestedAt.IsZero() {
+			continue
+		}
+		if k, ok := findReviewRequest(known, req); ok {
+			requests[i].RequestedAt = k.RequestedAt
+			continue
+		}
+		complete = false
+	}
+	return complete
+}
+
+// findReviewRequest returns the request in requests for the same reviewer
+// as req, ignoring case.
+func findReviewRequest(requests []model.ReviewRequest, req model.ReviewRequest) (model.ReviewRequest, bool) {
+	for _, r := range requests {
+		if r.IsTeam == req.IsTeam && strings.EqualFold(r.Reviewer, req.Reviewer) {
+			return r, true
+		}
+	}
+	return model.ReviewRequest{}, false
+}
+
+// recomputeNeedsReview re-derives NeedsReview for every stored PR from its
+// stored review requests and the current team slugs, storing the PRs whose
+// flag changed. A flagged PR with no stored requests predates their recording
+// and keeps its flag until it next syncs.
+func (s *PollService) recomputeNeedsReview(ctx context.Context) {
+	prs, err := s.prStore.ListAll(ctx)
+	if err != nil {
+		slog.Error("recompute needs review: list PRs failed", "error", err)
+		return
+	}
+
+	teamSlugs := s.currentTeamSlugs()
+	usernames := make(map[string]string)
+	var changed int
+	for _, pr := range prs {
+		if ctx.Err() != nil {
+			return
+		}
+		if pr.NeedsReview && len(pr.ReviewRequests) == 0 {
+			continue
+		}
+		username, ok := usernames[pr.RepoFullName]
+		if !ok {
+			_, username = s.clientForRepo(ctx, pr.RepoFullName)
+			usernames[pr.RepoFullName] = username
+		}
+
+		needsReview := IsReviewRequestedFrom(pr, username, teamSlugs)
+		if needsReview == pr.NeedsReview {
+			continue
+		}
+		pr.NeedsReview = needsReview
+		if err := s.prStore.Upsert(ctx, pr); err != nil {
+			slog.Error("recompute needs review: store PR failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+			continue
+		}
+		if s.signalCache != nil {
+			s.signalCache.InvalidateSignals(pr.ID)
+		}
+		changed++
+	}
+	slog.Info("needs review recomputed for new teams", "changed", changed)
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memReviewRequests records the last review requests stored per PR.
+type memReviewRequests map[int64][]model.ReviewRequest
+
+func (m memReviewRequests) SetReviewRequests(_ context.Context, prID int64, requests []model.ReviewRequest) error {
+	m[prID] = requests
+	return nil
+}
+
+// timelineClient is a GitHub client that only reads review request times.
+type timelineClient struct {
+	driven.GitHubClient
+	requests []model.ReviewRequest
+	fetches  int
+}
+
+func (c *timelineClient) FetchReviewRequests(_ context.Context, _ string, _ int) ([]model.ReviewRequest, error) {
+	c.fetches++
+	return c.requests, nil
+}
+
+func TestRecordReviewRequests(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	store := memReviewRequests{}
+	svc := (&PollService{}).WithReviewRequestStore(store)
+	client := &timelineClient{requests: []model.ReviewRequest{
+		{Reviewer: "core", IsTeam: true, RequestedAt: monday.Add(time.Hour)},
+	}}
+
+	stored := model.PullRequest{ID: 1, RepoFullName: "org/api", Number: 7, ReviewRequests: []model.ReviewRequest{
+		{Reviewer: "alice", RequestedAt: monday},
+		{Reviewer: "bob", RequestedAt: monday},
+	}}
+	fetched := model.PullRequest{RequestedReviewers: []string{"Alice"}, RequestedTeamSlugs: []string{"core"}}
+
+	svc.recordReviewRequests(t.Context(), client, stored, fetched)
+	require.Len(t, store[1], 2)
+	assert.Equal(t, model.ReviewRequest{Reviewer: "Alice", RequestedAt: monday}, store[1][0], "known requests keep their time")
+	assert.Equal(t, model.ReviewRequest{Reviewer: "core", IsTeam: true, RequestedAt: monday.Add(time.Hour)}, store[1][1], "new requests take the timeline's time")
+	assert.Equal(t, 1, client.fetches)
+
+	fetched.RequestedTeamSlugs = nil
+	svc.recordReviewRequests(t.Context(), client, stored, fetched)
+	assert.Len(t, store[1], 1)
+	assert.Equal(t, 1, client.fetches, "no timeline read when every request is known")
+
+	fetched.RequestedReviewers = []string{"dave"}
+	svc.recordReviewRequests(t.Context(), client, stored, fetched)
+	require.Len(t, store[1], 1)
+	assert.WithinDuration(t, time.Now(), store[1][0].RequestedAt, time.Second, "requests missing from the timeline date from now")
+}
+
+func TestReviewRequestedAt(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	pr := model.PullRequest{ReviewRequests: []model.ReviewRequest{
+		{Reviewer: "web", IsTeam: true, RequestedAt: monday.Add(2 * time.Hour)},
+		{Reviewer: "core", IsTeam: true, RequestedAt: monday.Add(time.Hour)},
+		{Reviewer: "alice", RequestedAt: monday.Add(3 * time.Hour)},
+	}}
+
+	at, ok := pr.ReviewRequestedAt("ALICE")
+	assert.True(t, ok)
+	assert.Equal(t, monday.Add(3*time.Hour), at, "a direct request wins over team requests")
+
+	_, ok = pr.ReviewRequestedAt("bob")
+	assert.False(t, ok, "team requests only count when NeedsReview says one is bob's")
+
+	pr.NeedsReview = true
+	at, ok = pr.ReviewRequestedAt("bob")
+	assert.True(t, ok)
+	assert.Equal(t, monday.Add(time.Hour), at, "the oldest team request stands in")
+}
+
+// upsertRecorder is a testPRStore that records the PRs upserted into it.
+type upsertRecorder struct {
+	testPRStore
+	upserted []model.PullRequest
+}
+
+func (s *upsertRecorder) Upsert(_ context.Context, pr model.PullRequest) error {
+	s.upserted = append(s.upserted, pr)
+	return nil
+}
+
+func TestRecomputeNeedsReview(t *testing.T) {
+	team := []model.ReviewRequest{{Reviewer: "core", IsTeam: true}}
+	store := &upsertRecorder{testPRStore: testPRStore{prs: []model.PullRequest{
+		{ID: 1, Number: 1, ReviewRequests: team, RequestedTeamSlugs: []string{"core"}},
+		{ID: 2, Number: 2, NeedsReview: true, ReviewRequests: team, RequestedTeamSlugs: []string{"core"}},
+		// Flagged before review requests were stored: left for the next sync.
+		{ID: 3, Number: 3, NeedsReview: true},
+		{ID: 4, Number: 4, NeedsReview: true, ReviewRequests: []model.ReviewRequest{{Reviewer: "me"}}, RequestedReviewers: []string{"me"}},
+	}}}
+	svc := &PollService{prStore: store, username: "me", teamSlugs: []string{"core"}}
+
+	svc.recomputeNeedsReview(t.Context())
+	require.Len(t, store.upserted, 1)
+	assert.Equal(t, 1, store.upserted[0].Number)
+	assert.True(t, store.upserted[0].NeedsReview)
+
+	store.upserted = nil
+	svc.teamSlugs = nil
+	svc.recomputeNeedsReview(t.Context())
+	require.Len(t, store.upserted, 1, "the direct request on #4 still stands")
+	assert.Equal(t, 2, store.upserted[0].Number)
+	assert.False(t, store.upserted[0].NeedsReview)
+}
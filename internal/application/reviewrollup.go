@@ -0,0 +1,127 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// ComputeReviewRollup aggregates a PR's stored reviews and review comments
+// the way the PR detail view does: the overall review status, the human
+// reviewers whose latest review approves, and the unresolved threads after
+// collapsing reposted bot comments.
+func ComputeReviewRollup(reviews []model.Review, comments []model.ReviewComment, botUsernames []string) model.ReviewRollup {
+	marked := make([]model.Review, len(reviews))
+	for i, r := range reviews {
+		r.IsBot = r.IsBot || isBotUser(r.ReviewerLogin, botUsernames)
+		marked[i] = r
+	}
+
+	rollup := model.ReviewRollup{Status: aggregateReviewStatus(marked, botUsernames)}
+	rollup.Approvals, _ = countLatestVerdicts(marked)
+	for _, t := range dedupBotThreads(groupIntoThreads(comments), botUsernames) {
+		if !t.IsResolved {
+			rollup.UnresolvedThreads++
+		}
+	}
+	return rollup
+}
+
+// ReviewRollupService keeps the review rollup stored on each PR row up to
+// date: the poll loop records it for every synced PR, and it is recomputed
+// for every PR when the bot list changes.
+type ReviewRollupService struct {
+	reviewStore driven.ReviewStore
+	bots        driven.BotConfigStore
+	prStore     driven.PRStore
+	store       driven.ReviewRollupStore
+}
+
+// NewReviewRollupService creates a ReviewRollupService.
+func NewReviewRollupService(reviewStore driven.ReviewStore, bots driven.BotConfigStore, prStore driven.PRStore, store driven.ReviewRollupStore) *ReviewRollupService {
+	return &ReviewRollupService{reviewStore: reviewStore, bots: bots, prStore: prStore, store: store}
+}
+
+// Record recomputes and stores the rollup of the PR with prID.
+func (s *ReviewRollupService) Record(ctx context.Context, prID int64) error {
+	botUsernames, err := s.bots.GetUsernames(ctx)
+	if err != nil {
+		return err
+	}
+	return s.record(ctx, prID, botUsernames)
+}
+
+// Backfill records the rollup of every stored PR that has none yet, such as
+// PRs stored before rollups existed that have not changed since.
+func (s *ReviewRollupService) Backfill(ctx context.Context) error {
+	return s.recordAll(ctx, func(pr model.PullRequest) bool { return !pr.ReviewRollup.Known() })
+}
+
+// RecordAll recomputes the rollup of every stored PR, for when a change such
+// as the bot list affects them all.
+func (s *ReviewRollupService) RecordAll(ctx context.Context) error {
+	return s.recordAll(ctx, func(model.PullRequest) bool { return true })
+}
+
+// recordAll records the rollups of the stored PRs for which include is true.
+// A PR that fails is logged and skipped so the rest are still recorded.
+func (s *ReviewRollupService) recordAll(ctx context.Context, include func(model.PullRequest) bool) error {
+	prs, err := s.prStore.ListAll(ctx)
+	if err != nil {
+		return err
+	}
+	botUsernames, err := s.bots.GetUsernames(ctx)
+	if err != nil {
+		return err
+	}
+
+	var recorded int
+	for _, pr := range prs {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !include(pr) {
+			continue
+		}
+		if err := s.record(ctx, pr.ID, botUsernames); err != nil {
+			slog.Warn("failed to record review rollup", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+			continue
+		}
+		recorded++
+	}
+	if recorded > 0 {
+		slog.Info("review rollups recorded", "prs", recorded)
+	}
+	return nil
+}
+
+func (s *ReviewRollupService) record(ctx context.Context, prID int64, botUsernames []string) error {
+	reviews, err := s.reviewStore.GetReviewsByPR(ctx, prID)
+	if err != nil {
+		return err
+	}
+	comments, err := s.reviewStore.GetReviewCommentsByPR(ctx, prID)
+	if err != nil {
+		return err
+	}
+	return s.store.SetReviewRollup(ctx, prID, ComputeReviewRollup(reviews, comments, botUsernames))
+}
+
+// WithReviewRollups records the review rollup of each synced PR once its
+// reviews and comments are stored.
+func (s *PollService) WithReviewRollups(svc *ReviewRollupService) *PollService {
+	s.reviewRollups = svc
+	return s
+}
+
+// recordReviewRollup stores the review rollup of a synced PR.
+func (s *PollService) recordReviewRollup(ctx context.Context, pr model.PullRequest) {
+	if s.reviewRollups == nil {
+		return
+	}
+	if err := s.reviewRollups.Record(ctx, pr.ID); err != nil {
+		slog.Error("record review rollup failed", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	}
+}
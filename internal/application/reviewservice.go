@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
@@ -20,6 +21,9 @@ type CommentThread struct {
 	RootComment model.ReviewComment
 	Replies     []model.ReviewComment // Sorted by CreatedAt.
 	IsResolved  bool                  // From root comment's IsResolved field.
+	// Repeats are earlier postings of the same bot comment, newest first,
+	// collapsed into this thread.
+	Repeats []model.ReviewComment
 }
 
 // Suggestion represents a structured proposed code change extracted from a
@@ -35,9 +39,12 @@ type Suggestion struct {
 
 // PRReviewSummary is the complete enriched view of a PR's review state.
 type PRReviewSummary struct {
-	Reviews               []model.Review
-	Threads               []CommentThread
-	IssueComments         []model.IssueComment
+	Reviews       []model.Review
+	Threads       []CommentThread
+	IssueComments []model.IssueComment
+	// IssueCommentRepeats holds earlier postings of the same bot comment,
+	// newest first, keyed by the ID of the copy kept in IssueComments.
+	IssueCommentRepeats   map[int64][]model.IssueComment
 	Suggestions           []Suggestion
 	BotUsernames          []string
 	ReviewStatus          model.ReviewState
@@ -53,6 +60,7 @@ type PRReviewSummary struct {
 type ReviewService struct {
 	reviewStore    driven.ReviewStore
 	botConfigStore driven.BotConfigStore
+	pending        driven.PendingCommentStore // optional; stores echoes of comments posted from the app
 }
 
 // NewReviewService creates a new ReviewService with the required dependencies.
@@ -68,12 +76,17 @@ func NewReviewService(
 
 // GetPRReviewSummary assembles the complete enriched view of a PR's review state.
 // It loads reviews, comments, and bot config, then enriches with threading,
-// suggestions, bot detection, outdated detection, and status aggregation.
+// suggestions, bot and nitpick detection, outdated detection, and status
+// aggregation.
 func (s *ReviewService) GetPRReviewSummary(ctx context.Context, prID int64, headSHA string) (*PRReviewSummary, error) {
-	botUsernames, err := s.botConfigStore.GetUsernames(ctx)
+	bots, err := s.botConfigStore.ListAll(ctx)
 	if err != nil {
 		return nil, err
 	}
+	botUsernames := make([]string, len(bots))
+	for i, bot := range bots {
+		botUsernames[i] = bot.Username
+	}
 
 	reviews, err := s.reviewStore.GetReviewsByPR(ctx, prID)
 	if err != nil {
@@ -90,9 +103,10 @@ func (s *ReviewService) GetPRReviewSummary(ctx context.Context, prID int64, head
 		return nil, err
 	}
 
-	// Mark reviews: IsBot and outdated.
+	// Mark reviews: IsBot, IsNitpick, and outdated.
 	for i := range reviews {
 		reviews[i].IsBot = isBotUser(reviews[i].ReviewerLogin, botUsernames)
+		reviews[i].IsNitpick = IsNitpickComment(reviews[i].ReviewerLogin, reviews[i].Body, bots)
 		if isReviewOutdated(reviews[i], headSHA) {
 			slog.Debug("review marked outdated",
 				"review_id", reviews[i].ID,
@@ -107,7 +121,9 @@ func (s *ReviewService) GetPRReviewSummary(ctx context.Context, prID int64, head
 		issueComments[i].IsBot = isBotUser(issueComments[i].Author, botUsernames)
 	}
 
-	threads := groupIntoThreads(reviewComments)
+	// Collapse bot comments reposted after each push into the latest copy.
+	threads := dedupBotThreads(groupIntoThreads(reviewComments), botUsernames)
+	issueComments, issueCommentRepeats := dedupBotIssueComments(issueComments)
 	suggestions := extractSuggestions(reviewComments)
 	reviewStatus := aggregateReviewStatus(reviews, botUsernames)
 
@@ -126,6 +142,7 @@ func (s *ReviewService) GetPRReviewSummary(ctx context.Context, prID int64, head
 		Reviews:               reviews,
 		Threads:               threads,
 		IssueComments:         issueComments,
+		IssueCommentRepeats:   issueCommentRepeats,
 		Suggestions:           suggestions,
 		BotUsernames:          botUsernames,
 		ReviewStatus:          reviewStatus,
@@ -137,6 +154,84 @@ func (s *ReviewService) GetPRReviewSummary(ctx context.Context, prID int64, head
 	}, nil
 }
 
+// ReviewersByPR returns, for each PR, the people who have submitted a review,
+// in order of their first review. Bots, the PR's author, and pending reviews
+// are left out. Bot usernames and reviews are loaded once for the whole batch.
+func (s *ReviewService) ReviewersByPR(ctx context.Context, prs []model.PullRequest) (map[int64][]string, error) {
+	botUsernames, err := s.botConfigStore.GetUsernames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewsByPR, err := s.reviewStore.GetReviewsByPRs(ctx, prIDs(prs))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[int64][]string, len(prs))
+	for _, pr := range prs {
+		reviews := reviewsByPR[pr.ID]
+		sort.SliceStable(reviews, func(i, j int) bool {
+			return reviews[i].SubmittedAt.Before(reviews[j].SubmittedAt)
+		})
+
+		seen := make(map[string]bool)
+		for _, r := range reviews {
+			login := strings.ToLower(r.ReviewerLogin)
+			if seen[login] || r.State == model.ReviewStatePending ||
+				strings.EqualFold(r.ReviewerLogin, pr.Author) || isBotUser(r.ReviewerLogin, botUsernames) {
+				continue
+			}
+			seen[login] = true
+			result[pr.ID] = append(result[pr.ID], r.ReviewerLogin)
+		}
+	}
+	return result, nil
+}
+
+// ReviewStatusByPR returns each PR's aggregated review status, as in
+// GetPRReviewSummary, without loading comments. The status stored on the PR
+// row is used when it has been rolled up; for the rest, bot usernames and
+// reviews are loaded once for the whole batch.
+func (s *ReviewService) ReviewStatusByPR(ctx context.Context, prs []model.PullRequest) (map[int64]model.ReviewState, error) {
+	result := make(map[int64]model.ReviewState, len(prs))
+	var pending []model.PullRequest
+	for _, pr := range prs {
+		if pr.ReviewRollup.Known() {
+			result[pr.ID] = pr.ReviewRollup.Status
+		} else {
+			pending = append(pending, pr)
+		}
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	botUsernames, err := s.botConfigStore.GetUsernames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewsByPR, err := s.reviewStore.GetReviewsByPRs(ctx, prIDs(pending))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pr := range pending {
+		result[pr.ID] = aggregateReviewStatus(reviewsByPR[pr.ID], botUsernames)
+	}
+	return result, nil
+}
+
+// prIDs returns the IDs of prs, for the batch store lookups.
+func prIDs(prs []model.PullRequest) []int64 {
+	ids := make([]int64, len(prs))
+	for i, pr := range prs {
+		ids[i] = pr.ID
+	}
+	return ids
+}
+
 // isBotUser checks if the login matches any configured bot username (case-insensitive).
 func isBotUser(login string, botUsernames []string) bool {
 	for _, bot := range botUsernames {
@@ -147,23 +242,28 @@ func isBotUser(login string, botUsernames []string) bool {
 	return false
 }
 
-// IsNitpickComment returns true if the author is a bot AND the body contains
-// a nitpick pattern indicator.
-func IsNitpickComment(author, body string, botUsernames []string) bool {
-	if !isBotUser(author, botUsernames) {
+// DefaultNitpickPatterns are the markers that flag a bot review as a nitpick
+// for every bot. Each bot's configuration can add its own.
+var DefaultNitpickPatterns = []string{
+	"**nitpick",
+	"[nitpick]",
+	"(nitpick)",
+	"nitpick:",
+	"nitpick (non-blocking)",
+}
+
+// IsNitpickComment returns true if the author is one of bots AND the body
+// contains one of DefaultNitpickPatterns or the bot's own nitpick patterns.
+func IsNitpickComment(author, body string, bots []model.BotConfig) bool {
+	i := slices.IndexFunc(bots, func(bot model.BotConfig) bool {
+		return strings.EqualFold(author, bot.Username)
+	})
+	if i < 0 {
 		return false
 	}
 
 	lowerBody := strings.ToLower(body)
-	nitpickPatterns := []string{
-		"**nitpick",
-		"[nitpick]",
-		"(nitpick)",
-		"nitpick:",
-		"nitpick (non-blocking)",
-	}
-
-	for _, pattern := range nitpickPatterns {
+	for _, pattern := range slices.Concat(DefaultNitpickPatterns, bots[i].NitpickPatterns) {
 		if strings.Contains(lowerBody, pattern) {
 			return true
 		}
@@ -171,6 +271,19 @@ func IsNitpickComment(author, body string, botUsernames []string) bool {
 	return false
 }
 
+// NormalizeNitpickPatterns trims and lowercases nitpick patterns, dropping
+// blank and repeated ones, so they match review bodies case-insensitively.
+func NormalizeNitpickPatterns(patterns []string) []string {
+	var result []string
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern != "" && !slices.Contains(result, pattern) {
+			result = append(result, pattern)
+		}
+	}
+	return result
+}
+
 // isReviewOutdated returns true if the review targets a commit other than
 // the current PR head SHA.
 func isReviewOutdated(review model.Review, headSHA string) bool {
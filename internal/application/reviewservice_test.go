@@ -150,7 +150,7 @@ func TestIsBotUser(t *testing.T) {
 // --- Tests for isNitpickComment ---
 
 func TestIsNitpickComment(t *testing.T) {
-	bots := []string{"coderabbitai"}
+	bots := []model.BotConfig{{Username: "coderabbitai"}, {Username: "sonar[bot]", NitpickPatterns: []string{"minor:"}}}
 
 	tests := []struct {
 		name   string
@@ -165,6 +165,8 @@ func TestIsNitpickComment(t *testing.T) {
 		{"non-blocking nitpick", "coderabbitai", "nitpick (non-blocking)", true},
 		{"non-bot author", "humanuser", "**Nitpick** blah", false},
 		{"bot no nitpick", "coderabbitai", "This is a regular comment", false},
+		{"bot's own pattern", "sonar[bot]", "Minor: rename this", true},
+		{"another bot's pattern", "coderabbitai", "Minor: rename this", false},
 	}
 
 	for _, tt := range tests {
@@ -175,6 +177,11 @@ func TestIsNitpickComment(t *testing.T) {
 	}
 }
 
+func TestNormalizeNitpickPatterns(t *testing.T) {
+	assert.Equal(t, []string{"minor:", "🧹 nit"}, NormalizeNitpickPatterns([]string{" Minor: ", "", "🧹 Nit", "minor:", "  "}))
+	assert.Nil(t, NormalizeNitpickPatterns(nil))
+}
+
 // --- Tests for isReviewOutdated ---
 
 func TestIsReviewOutdated(t *testing.T) {
@@ -373,3 +380,63 @@ func TestGetPRReviewSummary(t *testing.T) {
 	assert.True(t, summary.HasCoderabbitReview)
 	assert.True(t, summary.AwaitingCoderabbit, "coderabbit reviewed old-sha, not current-sha")
 }
+
+func TestReviewersByPR(t *testing.T) {
+	now := time.Now()
+	reviewStore := &testReviewStore{
+		reviews: []model.Review{
+			{ID: 1, ReviewerLogin: "bob", State: model.ReviewStateApproved, SubmittedAt: now},
+			{ID: 2, ReviewerLogin: "alice", State: model.ReviewStateCommented, SubmittedAt: now.Add(-time.Hour)},
+			{ID: 3, ReviewerLogin: "Alice", State: model.ReviewStateApproved, SubmittedAt: now.Add(time.Minute)},
+			{ID: 4, ReviewerLogin: "coderabbitai", State: model.ReviewStateCommented, SubmittedAt: now},
+			{ID: 5, ReviewerLogin: "author", State: model.ReviewStateCommented, SubmittedAt: now},
+			{ID: 6, ReviewerLogin: "carol", State: model.ReviewStatePending, SubmittedAt: now},
+		},
+	}
+	botConfigStore := &testBotConfigStore{usernames: []string{"coderabbitai"}}
+
+	svc := NewReviewService(reviewStore, botConfigStore)
+	got, err := svc.ReviewersByPR(context.Background(), []model.PullRequest{{ID: 42, Author: "author"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int64][]string{42: {"alice", "bob"}}, got)
+}
+
+func TestReviewStatusByPR(t *testing.T) {
+	now := time.Now()
+	reviewStore := &testReviewStore{
+		reviews: []model.Review{
+			{ID: 1, ReviewerLogin: "alice", State: model.ReviewStateApproved, SubmittedAt: now},
+			{ID: 2, ReviewerLogin: "coderabbitai", State: model.ReviewStateChangesRequested, SubmittedAt: now},
+		},
+	}
+	botConfigStore := &testBotConfigStore{usernames: []string{"coderabbitai"}}
+
+	svc := NewReviewService(reviewStore, botConfigStore)
+	got, err := svc.ReviewStatusByPR(context.Background(), []model.PullRequest{{ID: 42}})
+
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]model.ReviewState{42: model.ReviewStateApproved}, got)
+}
+
+func TestComputeReviewRollup(t *testing.T) {
+	now := time.Now()
+	reviews := []model.Review{
+		{ID: 1, ReviewerLogin: "alice", State: model.ReviewStateChangesRequested, SubmittedAt: now},
+		{ID: 2, ReviewerLogin: "alice", State: model.ReviewStateApproved, SubmittedAt: now.Add(time.Hour)},
+		{ID: 3, ReviewerLogin: "bob", State: model.ReviewStateApproved, SubmittedAt: now},
+		{ID: 4, ReviewerLogin: "coderabbitai", State: model.ReviewStateApproved, SubmittedAt: now},
+	}
+	comments := []model.ReviewComment{
+		{ID: 10, Author: "alice", Path: "a.go", Line: 1, CreatedAt: now, IsResolved: true},
+		{ID: 11, Author: "bob", Path: "a.go", Line: 5, CreatedAt: now},
+		{ID: 12, Author: "bob", InReplyToID: int64Ptr(11), CreatedAt: now.Add(time.Minute)},
+		// The same bot comment posted twice counts once.
+		{ID: 20, Author: "coderabbitai", Path: "b.go", Line: 3, Body: "Consider handling the error.", CreatedAt: now},
+		{ID: 21, Author: "coderabbitai", Path: "b.go", Line: 3, Body: "Consider handling the error.", CreatedAt: now.Add(time.Hour)},
+	}
+
+	got := ComputeReviewRollup(reviews, comments, []string{"coderabbitai"})
+
+	assert.Equal(t, model.ReviewRollup{Status: model.ReviewStateApproved, Approvals: 2, UnresolvedThreads: 2}, got)
+}
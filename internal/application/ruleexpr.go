@@ -0,0 +1,459 @@
+package application
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// maxRuleExprLen bounds an attention rule expression, which is evaluated for
+// every PR on every poll.
+const maxRuleExprLen = 1000
+
+// RuleInput is what an attention rule expression is evaluated against: a
+// stored PR, its built-in attention signals, and the authenticated user.
+type RuleInput struct {
+	PR      model.PullRequest
+	Signals model.AttentionSignals
+	Me      string
+}
+
+// ruleType is the static type of an expression node.
+type ruleType int
+
+const (
+	ruleBool ruleType = iota
+	ruleNumber
+	ruleString
+	ruleList
+)
+
+func (t ruleType) String() string {
+	switch t {
+	case ruleBool:
+		return "bool"
+	case ruleNumber:
+		return "number"
+	case ruleString:
+		return "string"
+	}
+	return "list"
+}
+
+// ruleValue is the result of evaluating an expression node; only the field
+// of its type is set.
+type ruleValue struct {
+	b    bool
+	n    float64
+	s    string
+	list []string
+}
+
+// ruleField is an identifier usable in expressions.
+type ruleField struct {
+	typ ruleType
+	get func(RuleInput) ruleValue
+}
+
+// ruleFields are the identifiers expressions may reference, keyed by name.
+// Every attention signal is also a bool field of the same name; see init.
+var ruleFields = map[string]ruleField{
+	"repo":          stringField(func(in RuleInput) string { return in.PR.RepoFullName }),
+	"number":        numberField(func(in RuleInput) int { return in.PR.Number }),
+	"title":         stringField(func(in RuleInput) string { return in.PR.Title }),
+	"author":        stringField(func(in RuleInput) string { return in.PR.Author }),
+	"branch":        stringField(func(in RuleInput) string { return in.PR.Branch }),
+	"base_branch":   stringField(func(in RuleInput) string { return in.PR.BaseBranch }),
+	"status":        stringField(func(in RuleInput) string { return string(in.PR.Status) }),
+	"ci_status":     stringField(func(in RuleInput) string { return string(in.PR.CIStatus) }),
+	"mergeable":     stringField(func(in RuleInput) string { return string(in.PR.MergeableStatus) }),
+	"jira_key":      stringField(func(in RuleInput) string { return in.PR.JiraKey }),
+	"me":            stringField(func(in RuleInput) string { return in.Me }),
+	"draft":         boolField(func(in RuleInput) bool { return in.PR.IsDraft }),
+	"needs_review":  boolField(func(in RuleInput) bool { return in.PR.NeedsReview }),
+	"first_timer":   boolField(func(in RuleInput) bool { return in.Signals.FirstTimeContributor }),
+	"additions":     numberField(func(in RuleInput) int { return in.PR.Additions }),
+	"deletions":     numberField(func(in RuleInput) int { return in.PR.Deletions }),
+	"changed_files": numberField(func(in RuleInput) int { return in.PR.ChangedFiles }),
+	"age_days":      numberField(func(in RuleInput) int { return in.PR.DaysSinceOpened() }),
+	"idle_days":     numberField(func(in RuleInput) int { return in.PR.DaysSinceLastActivity() }),
+	"labels":        listField(func(in RuleInput) []string { return in.PR.Labels }),
+	"reviewers":     listField(func(in RuleInput) []string { return in.PR.RequestedReviewers }),
+}
+
+func init() {
+	for _, signal := range model.AllAttentionSignals {
+		ruleFields[string(signal)] = boolField(func(in RuleInput) bool { return in.Signals.Has(signal) })
+	}
+}
+
+func stringField(get func(RuleInput) string) ruleField {
+	return ruleField{typ: ruleString, get: func(in RuleInput) ruleValue { return ruleValue{s: get(in)} }}
+}
+
+func numberField(get func(RuleInput) int) ruleField {
+	return ruleField{typ: ruleNumber, get: func(in RuleInput) ruleValue { return ruleValue{n: float64(get(in))} }}
+}
+
+func boolField(get func(RuleInput) bool) ruleField {
+	return ruleField{typ: ruleBool, get: func(in RuleInput) ruleValue { return ruleValue{b: get(in)} }}
+}
+
+func listField(get func(RuleInput) []string) ruleField {
+	return ruleField{typ: ruleList, get: func(in RuleInput) ruleValue { return ruleValue{list: get(in)} }}
+}
+
+// RuleFieldNames returns the identifiers attention rule expressions may
+// reference, sorted.
+func RuleFieldNames() []string {
+	names := make([]string, 0, len(ruleFields))
+	for name := range ruleFields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// RuleExpr is a compiled attention rule expression. Compilation checks
+// identifiers and operand types, so evaluation cannot fail.
+type RuleExpr struct {
+	root ruleNode
+}
+
+// Match reports whether the expression holds for in.
+func (e *RuleExpr) Match(in RuleInput) bool {
+	return e.root.eval(in).b
+}
+
+// ruleNode is a type-checked expression node.
+type ruleNode struct {
+	typ  ruleType
+	eval func(RuleInput) ruleValue
+}
+
+// CompileRuleExpr parses and type-checks an attention rule expression. The
+// grammar is boolean logic (||, &&, !, parentheses) over comparisons of
+// fields and literals: == and != for any matching types, <, <=, >, >= for
+// numbers, =~ against a regular expression string literal, and contains for
+// a list or string holding a string. A bare bool field is a predicate too.
+// Errors name the offending position.
+func CompileRuleExpr(src string) (*RuleExpr, error) {
+	if strings.TrimSpace(src) == "" {
+		return nil, fmt.Errorf("expression is required")
+	}
+	if len(src) > maxRuleExprLen {
+		return nil, fmt.Errorf("expression is longer than %d characters", maxRuleExprLen)
+	}
+	tokens, err := lexRuleExpr(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &ruleParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+	}
+	if root.typ != ruleBool {
+		return nil, fmt.Errorf("expression is a %s, not a condition", root.typ)
+	}
+	return &RuleExpr{root: root}, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type ruleToken struct {
+	kind tokenKind
+	text string // operator or identifier text, or the unquoted string
+	num  float64
+	pos  int // 1-based column
+}
+
+// ruleOperators are the symbolic operators, longest first so that "<=" wins
+// over "<".
+var ruleOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "<", ">", "!"}
+
+func lexRuleExpr(src string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	i := 0
+	for i < len(src) {
+		c := rune(src[i])
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, ruleToken{kind: tokLParen, text: "(", pos: pos})
+			i++
+		case c == ')':
+			tokens = append(tokens, ruleToken{kind: tokRParen, text: ")", pos: pos})
+			i++
+		case c == '"':
+			end := i + 1
+			for end < len(src) && src[end] != '"' {
+				if src[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("unterminated string at %d", pos)
+			}
+			s, err := strconv.Unquote(src[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %v", pos, err)
+			}
+			tokens = append(tokens, ruleToken{kind: tokString, text: s, pos: pos})
+			i = end + 1
+		case unicode.IsDigit(c):
+			end := i
+			for end < len(src) && (unicode.IsDigit(rune(src[end])) || src[end] == '.') {
+				end++
+			}
+			n, err := strconv.ParseFloat(src[i:end], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at %d", src[i:end], pos)
+			}
+			tokens = append(tokens, ruleToken{kind: tokNumber, text: src[i:end], num: n, pos: pos})
+			i = end
+		case c == '_' || unicode.IsLetter(c):
+			end := i
+			for end < len(src) && (src[end] == '_' || unicode.IsLetter(rune(src[end])) || unicode.IsDigit(rune(src[end]))) {
+				end++
+			}
+			tokens = append(tokens, ruleToken{kind: tokIdent, text: src[i:end], pos: pos})
+			i = end
+		default:
+			op := ""
+			for _, candidate := range ruleOperators {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at %d", c, pos)
+			}
+			tokens = append(tokens, ruleToken{kind: tokOp, text: op, pos: pos})
+			i += len(op)
+		}
+	}
+	return append(tokens, ruleToken{kind: tokEOF, text: "end of expression", pos: len(src) + 1}), nil
+}
+
+// ruleParser is a recursive descent parser over lexed tokens.
+type ruleParser struct {
+	tokens []ruleToken
+	next   int
+}
+
+func (p *ruleParser) peek() ruleToken {
+	return p.tokens[p.next]
+}
+
+func (p *ruleParser) take() ruleToken {
+	tok := p.tokens[p.next]
+	if tok.kind != tokEOF {
+		p.next++
+	}
+	return tok
+}
+
+// isOp reports whether the next token is the operator op; "contains" is a
+// word operator lexed as an identifier.
+func (p *ruleParser) isOp(op string) bool {
+	tok := p.peek()
+	return (tok.kind == tokOp || tok.kind == tokIdent) && tok.text == op
+}
+
+func (p *ruleParser) parseOr() (ruleNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return ruleNode{}, err
+	}
+	for p.isOp("||") {
+		op := p.take()
+		right, err := p.parseAnd()
+		if err != nil {
+			return ruleNode{}, err
+		}
+		if err := requireBools(op, left, right); err != nil {
+			return ruleNode{}, err
+		}
+		l, r := left.eval, right.eval
+		left = ruleNode{typ: ruleBool, eval: func(in RuleInput) ruleValue { return ruleValue{b: l(in).b || r(in).b} }}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseAnd() (ruleNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return ruleNode{}, err
+	}
+	for p.isOp("&&") {
+		op := p.take()
+		right, err := p.parseUnary()
+		if err != nil {
+			return ruleNode{}, err
+		}
+		if err := requireBools(op, left, right); err != nil {
+			return ruleNode{}, err
+		}
+		l, r := left.eval, right.eval
+		left = ruleNode{typ: ruleBool, eval: func(in RuleInput) ruleValue { return ruleValue{b: l(in).b && r(in).b} }}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseUnary() (ruleNode, error) {
+	if !p.isOp("!") {
+		return p.parseComparison()
+	}
+	op := p.take()
+	operand, err := p.parseUnary()
+	if err != nil {
+		return ruleNode{}, err
+	}
+	if err := requireBools(op, operand); err != nil {
+		return ruleNode{}, err
+	}
+	eval := operand.eval
+	return ruleNode{typ: ruleBool, eval: func(in RuleInput) ruleValue { return ruleValue{b: !eval(in).b} }}, nil
+}
+
+func (p *ruleParser) parseComparison() (ruleNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return ruleNode{}, err
+	}
+	for _, op := range []string{"==", "!=", "<", "<=", ">", ">=", "=~", "contains"} {
+		if p.isOp(op) {
+			opTok := p.take()
+			rightTok := p.peek()
+			right, err := p.parsePrimary()
+			if err != nil {
+				return ruleNode{}, err
+			}
+			return compare(opTok, left, right, rightTok)
+		}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parsePrimary() (ruleNode, error) {
+	tok := p.take()
+	switch tok.kind {
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return ruleNode{}, err
+		}
+		if closing := p.take(); closing.kind != tokRParen {
+			return ruleNode{}, fmt.Errorf("expected ) at %d, got %q", closing.pos, closing.text)
+		}
+		return inner, nil
+	case tokString:
+		v := ruleValue{s: tok.text}
+		return ruleNode{typ: ruleString, eval: func(RuleInput) ruleValue { return v }}, nil
+	case tokNumber:
+		v := ruleValue{n: tok.num}
+		return ruleNode{typ: ruleNumber, eval: func(RuleInput) ruleValue { return v }}, nil
+	case tokIdent:
+		switch tok.text {
+		case "true", "false":
+			v := ruleValue{b: tok.text == "true"}
+			return ruleNode{typ: ruleBool, eval: func(RuleInput) ruleValue { return v }}, nil
+		}
+		field, ok := ruleFields[tok.text]
+		if !ok {
+			return ruleNode{}, fmt.Errorf("unknown field %q at %d", tok.text, tok.pos)
+		}
+		return ruleNode{typ: field.typ, eval: field.get}, nil
+	}
+	return ruleNode{}, fmt.Errorf("unexpected %q at %d", tok.text, tok.pos)
+}
+
+// requireBools checks that every operand of a logical operator is a condition.
+func requireBools(op ruleToken, operands ...ruleNode) error {
+	for _, operand := range operands {
+		if operand.typ != ruleBool {
+			return fmt.Errorf("%s at %d needs conditions, got a %s", op.text, op.pos, operand.typ)
+		}
+	}
+	return nil
+}
+
+// compare type-checks a comparison and returns its node. rightTok is the
+// right operand's first token, whose text is the pattern for =~.
+func compare(op ruleToken, left, right ruleNode, rightTok ruleToken) (ruleNode, error) {
+	l, r := left.eval, right.eval
+	mismatch := func() error {
+		return fmt.Errorf("cannot compare %s %s %s at %d", left.typ, op.text, right.typ, op.pos)
+	}
+	cond := func(f func(a, b ruleValue) bool) (ruleNode, error) {
+		return ruleNode{typ: ruleBool, eval: func(in RuleInput) ruleValue { return ruleValue{b: f(l(in), r(in))} }}, nil
+	}
+
+	switch op.text {
+	case "==", "!=":
+		if left.typ != right.typ || left.typ == ruleList {
+			return ruleNode{}, mismatch()
+		}
+		want := op.text == "=="
+		return cond(func(a, b ruleValue) bool { return (a.b == b.b && a.n == b.n && a.s == b.s) == want })
+	case "<", "<=", ">", ">=":
+		if left.typ != ruleNumber || right.typ != ruleNumber {
+			return ruleNode{}, mismatch()
+		}
+		switch op.text {
+		case "<":
+			return cond(func(a, b ruleValue) bool { return a.n < b.n })
+		case "<=":
+			return cond(func(a, b ruleValue) bool { return a.n <= b.n })
+		case ">":
+			return cond(func(a, b ruleValue) bool { return a.n > b.n })
+		default:
+			return cond(func(a, b ruleValue) bool { return a.n >= b.n })
+		}
+	case "=~":
+		if left.typ != ruleString || rightTok.kind != tokString {
+			return ruleNode{}, fmt.Errorf("=~ at %d needs a string on the left and a pattern literal on the right", op.pos)
+		}
+		re, err := regexp.Compile(rightTok.text)
+		if err != nil {
+			return ruleNode{}, fmt.Errorf("invalid pattern at %d: %v", rightTok.pos, err)
+		}
+		return cond(func(a, _ ruleValue) bool { return re.MatchString(a.s) })
+	default: // contains
+		if right.typ != ruleString {
+			return ruleNode{}, mismatch()
+		}
+		switch left.typ {
+		case ruleList:
+			return cond(func(a, b ruleValue) bool {
+				return slices.ContainsFunc(a.list, func(item string) bool { return strings.EqualFold(item, b.s) })
+			})
+		case ruleString:
+			return cond(func(a, b ruleValue) bool { return strings.Contains(strings.ToLower(a.s), strings.ToLower(b.s)) })
+		}
+		return ruleNode{}, mismatch()
+	}
+}
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestCompileRuleExpr_Match(t *testing.T) {
+	in := RuleInput{
+		PR: model.PullRequest{
+			RepoFullName:       "org/api",
+			Number:             42,
+			Title:              "WIP: Add caching",
+			Author:             "alice",
+			Branch:             "feature/cache",
+			Status:             model.PRStatusOpen,
+			CIStatus:           model.CIStatusFailing,
+			Additions:          1200,
+			Labels:             []string{"Security", "backend"},
+			RequestedReviewers: []string{"bob"},
+			OpenedAt:           time.Now().Add(-72 * time.Hour),
+		},
+		Signals: model.AttentionSignals{HasCIFailure: true},
+		Me:      "alice",
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`ci_status == "failing" && author == me && age_days > 1`, true},
+		{`ci_status == "failing" && author != me`, false},
+		{`ci_failure`, true},
+		{`!ci_failure || draft`, false},
+		{`draft == false`, true},
+		{`additions >= 1200 && deletions < 1`, true},
+		{`number == 42`, true},
+		{`labels contains "security"`, true},
+		{`reviewers contains "carol"`, false},
+		{`title contains "wip"`, true},
+		{`branch =~ "^feature/"`, true},
+		{`repo =~ "^org/(web|ui)$"`, false},
+		{`(age_days > 10 || additions > 1000) && status == "open"`, true},
+		{`needs_more_reviews`, false},
+		{`true`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := CompileRuleExpr(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.Match(in))
+		})
+	}
+}
+
+func TestCompileRuleExpr_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{``, "expression is required"},
+		{`ci_status == "failing" &&`, `unexpected "end of expression" at 26`},
+		{`ci == "failing"`, `unknown field "ci" at 1`},
+		{`author == 3`, "cannot compare string == number at 8"},
+		{`age_days > "1"`, "cannot compare number > string at 10"},
+		{`labels == "x"`, "cannot compare list == string"},
+		{`title =~ "("`, "invalid pattern at 10"},
+		{`title =~ author`, "=~ at 7 needs"},
+		{`additions && draft`, "&& at 11 needs conditions, got a number"},
+		{`author`, "expression is a string, not a condition"},
+		{`(draft`, "expected ) at 7"},
+		{`draft draft`, `unexpected "draft" at 7`},
+		{`title == "open`, "unterminated string at 10"},
+		{`draft # x`, `unexpected '#' at 7`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := CompileRuleExpr(tt.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRuleFieldNames_IncludesSignals(t *testing.T) {
+	names := RuleFieldNames()
+	for _, signal := range model.AllAttentionSignals {
+		assert.Contains(t, names, string(signal))
+	}
+	assert.Contains(t, names, "me")
+	assert.IsIncreasing(t, names)
+}
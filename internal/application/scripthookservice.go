@@ -0,0 +1,248 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+const (
+	// maxScriptHooks bounds the hooks that may run for a single event.
+	maxScriptHooks = 50
+
+	// maxScriptLen bounds a hook's script.
+	maxScriptLen = 20000
+)
+
+// ErrInvalidScriptHook is returned for a hook with a missing name, an unknown
+// event or runtime, a malformed repository, or a script that does not compile
+// or runs a program that is not allowlisted.
+var ErrInvalidScriptHook = errors.New("invalid script hook")
+
+// ScriptHookService runs user-provided scripts when PRs are opened, approved,
+// sent back with changes requested, fail CI, or receive a comment. It listens
+// to the EventHub, so it only sees what the poll loop publishes. Comments a
+// script asks for are posted as the user.
+type ScriptHookService struct {
+	store    driven.ScriptHookStore
+	prStore  driven.PRStore
+	runner   driven.HookRunner
+	username string
+	logger   *slog.Logger
+
+	// writerFor returns a writer for the repository; optional, script
+	// comments are logged and dropped when nil.
+	writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)
+}
+
+// NewScriptHookService creates a ScriptHookService for username.
+func NewScriptHookService(store driven.ScriptHookStore, prStore driven.PRStore, runner driven.HookRunner, username string) *ScriptHookService {
+	return &ScriptHookService{store: store, prStore: prStore, runner: runner, username: username, logger: slog.Default()}
+}
+
+// WithWriter lets scripts comment on PRs through the writer writerFor returns.
+func (s *ScriptHookService) WithWriter(writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)) *ScriptHookService {
+	s.writerFor = writerFor
+	return s
+}
+
+// Create validates and stores a hook. It returns ErrInvalidScriptHook for a
+// bad hook or when the hook limit is reached.
+func (s *ScriptHookService) Create(ctx context.Context, hook model.ScriptHook) (model.ScriptHook, error) {
+	hook.Name = strings.TrimSpace(hook.Name)
+	hook.Script = strings.TrimSpace(hook.Script)
+	hook.RepoFullName = strings.TrimSpace(hook.RepoFullName)
+
+	switch {
+	case hook.Name == "":
+		return model.ScriptHook{}, fmt.Errorf("%w: name is required", ErrInvalidScriptHook)
+	case len(hook.Name) > maxRuleNameLen:
+		return model.ScriptHook{}, fmt.Errorf("%w: name is longer than %d characters", ErrInvalidScriptHook, maxRuleNameLen)
+	case !hook.Event.Valid():
+		return model.ScriptHook{}, fmt.Errorf("%w: unknown event %q", ErrInvalidScriptHook, hook.Event)
+	case hook.Runtime != model.HookRuntimeStarlark && hook.Runtime != model.HookRuntimeShell:
+		return model.ScriptHook{}, fmt.Errorf("%w: runtime must be starlark or shell", ErrInvalidScriptHook)
+	case hook.Script == "":
+		return model.ScriptHook{}, fmt.Errorf("%w: script is required", ErrInvalidScriptHook)
+	case len(hook.Script) > maxScriptLen:
+		return model.ScriptHook{}, fmt.Errorf("%w: script is longer than %d characters", ErrInvalidScriptHook, maxScriptLen)
+	case hook.RepoFullName != "" && !validate.IsValidRepoName(hook.RepoFullName):
+		return model.ScriptHook{}, fmt.Errorf("%w: repository must be in owner/name form", ErrInvalidScriptHook)
+	}
+	if err := s.runner.Check(hook); err != nil {
+		return model.ScriptHook{}, fmt.Errorf("%w: %v", ErrInvalidScriptHook, err)
+	}
+
+	existing, err := s.store.List(ctx)
+	if err != nil {
+		return model.ScriptHook{}, err
+	}
+	if len(existing) >= maxScriptHooks {
+		return model.ScriptHook{}, fmt.Errorf("%w: at most %d hooks", ErrInvalidScriptHook, maxScriptHooks)
+	}
+
+	hook.CreatedAt = time.Now().UTC()
+	id, err := s.store.Create(ctx, hook)
+	if err != nil {
+		return model.ScriptHook{}, err
+	}
+	hook.ID = id
+	return hook, nil
+}
+
+// List returns all hooks, enabled or not.
+func (s *ScriptHookService) List(ctx context.Context) ([]model.ScriptHook, error) {
+	return s.store.List(ctx)
+}
+
+// SetEnabled turns a hook on or off. It returns driven.ErrScriptHookNotFound
+// if it does not exist.
+func (s *ScriptHookService) SetEnabled(ctx context.Context, id int64, enabled bool) error {
+	return s.store.SetEnabled(ctx, id, enabled)
+}
+
+// Delete removes a hook. It returns driven.ErrScriptHookNotFound if it does
+// not exist.
+func (s *ScriptHookService) Delete(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Run executes matching hooks for PR events published on hub until ctx is
+// canceled. Hooks run one at a time in creation order; a failed hook is
+// logged and not retried.
+func (s *ScriptHookService) Run(ctx context.Context, hub *EventHub) {
+	events, cancel := hub.Subscribe(EventFilter{Types: []model.PREventType{
+		model.PREventOpened, model.PREventReviewAdded, model.PREventCheckCompleted, model.PREventCommentAdded,
+	}})
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			s.dispatch(ctx, e)
+		}
+	}
+}
+
+// dispatch runs the hooks matching e.
+func (s *ScriptHookService) dispatch(ctx context.Context, e model.PREvent) {
+	inv, ok := s.invocation(e)
+	if !ok {
+		return
+	}
+
+	hooks, err := s.store.List(ctx)
+	if err != nil {
+		s.logger.Error("failed to list script hooks", "error", err)
+		return
+	}
+	var matching []model.ScriptHook
+	for _, hook := range hooks {
+		if hook.Matches(inv.Event, e.RepoFullName) {
+			matching = append(matching, hook)
+		}
+	}
+	if len(matching) == 0 {
+		return
+	}
+
+	if e.PullRequest != nil {
+		inv.PullRequest = *e.PullRequest
+	} else {
+		pr, err := s.prStore.GetByNumber(ctx, e.RepoFullName, e.PRNumber)
+		if err != nil || pr == nil {
+			s.logger.Warn("failed to load PR for script hooks", "repo", e.RepoFullName, "pr", e.PRNumber, "error", err)
+			return
+		}
+		inv.PullRequest = *pr
+	}
+
+	for _, hook := range matching {
+		s.runHook(ctx, hook, inv)
+	}
+}
+
+// invocation maps e to the hook event it triggers, if any. Comments by the
+// user are skipped, so a commented hook that posts a comment cannot trigger
+// itself.
+func (s *ScriptHookService) invocation(e model.PREvent) (model.HookInvocation, bool) {
+	inv := model.HookInvocation{OccurredAt: e.OccurredAt}
+	switch {
+	case e.Type == model.PREventOpened:
+		inv.Event = model.HookEventOpened
+	case e.Review != nil:
+		switch e.Review.State {
+		case model.ReviewStateApproved:
+			inv.Event = model.HookEventApproved
+		case model.ReviewStateChangesRequested:
+			inv.Event = model.HookEventChangesRequested
+		default:
+			return inv, false
+		}
+		inv.Review = e.Review
+	case e.CheckRun != nil:
+		// Canceled runs are usually superseded by a newer push, not failures.
+		switch e.CheckRun.Conclusion {
+		case "failure", "timed_out", "action_required":
+		default:
+			return inv, false
+		}
+		inv.Event = model.HookEventCIFailed
+		inv.CheckRun = e.CheckRun
+	case e.Comment != nil:
+		if strings.EqualFold(e.Comment.Author, s.username) {
+			return inv, false
+		}
+		inv.Event = model.HookEventCommented
+		inv.Comment = e.Comment
+	default:
+		return inv, false
+	}
+	return inv, true
+}
+
+// runHook runs hook and performs the actions it requested.
+func (s *ScriptHookService) runHook(ctx context.Context, hook model.ScriptHook, inv model.HookInvocation) {
+	pr := inv.PullRequest
+	result, err := s.runner.Run(ctx, hook, inv)
+	if err != nil {
+		s.logger.Warn("script hook failed",
+			"hook", hook.ID, "event", inv.Event, "repo", pr.RepoFullName, "pr", pr.Number, "error", err, "output", result.Output)
+		return
+	}
+	s.logger.Info("script hook ran",
+		"hook", hook.ID, "event", inv.Event, "repo", pr.RepoFullName, "pr", pr.Number, "actions", len(result.Actions), "output", result.Output)
+
+	for _, action := range result.Actions {
+		if action.Kind != model.HookActionComment {
+			continue
+		}
+		if err := s.comment(ctx, pr, action.Body); err != nil {
+			s.logger.Warn("script hook comment failed", "hook", hook.ID, "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+		}
+	}
+}
+
+// comment posts body on pr.
+func (s *ScriptHookService) comment(ctx context.Context, pr model.PullRequest, body string) error {
+	if s.writerFor == nil {
+		return errors.New("commenting is not available")
+	}
+	writer, err := s.writerFor(ctx, pr.RepoFullName)
+	if err != nil {
+		return err
+	}
+	return writer.CreateIssueComment(ctx, pr.RepoFullName, pr.Number, body)
+}
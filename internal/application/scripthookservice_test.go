@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// memScriptHookStore is an in-memory ScriptHookStore.
+type memScriptHookStore struct {
+	hooks []model.ScriptHook
+}
+
+func (m *memScriptHookStore) Create(_ context.Context, hook model.ScriptHook) (int64, error) {
+	hook.ID = int64(len(m.hooks) + 1)
+	m.hooks = append(m.hooks, hook)
+	return hook.ID, nil
+}
+
+func (m *memScriptHookStore) SetEnabled(_ context.Context, id int64, enabled bool) error {
+	for i := range m.hooks {
+		if m.hooks[i].ID == id {
+			m.hooks[i].Enabled = enabled
+			return nil
+		}
+	}
+	return driven.ErrScriptHookNotFound
+}
+
+func (m *memScriptHookStore) Delete(_ context.Context, id int64) error {
+	for i, h := range m.hooks {
+		if h.ID == id {
+			m.hooks = append(m.hooks[:i], m.hooks[i+1:]...)
+			return nil
+		}
+	}
+	return driven.ErrScriptHookNotFound
+}
+
+func (m *memScriptHookStore) List(_ context.Context) ([]model.ScriptHook, error) {
+	return m.hooks, nil
+}
+
+// stubHookRunner rejects scripts containing "bad" and records every run,
+// returning result.
+type stubHookRunner struct {
+	result model.HookResult
+	runs   []model.HookInvocation
+}
+
+func (r *stubHookRunner) Check(hook model.ScriptHook) error {
+	if hook.Script == "bad" {
+		return errors.New("does not compile")
+	}
+	return nil
+}
+
+func (r *stubHookRunner) Run(_ context.Context, _ model.ScriptHook, inv model.HookInvocation) (model.HookResult, error) {
+	r.runs = append(r.runs, inv)
+	return r.result, nil
+}
+
+func TestScriptHookService_Create(t *testing.T) {
+	svc := NewScriptHookService(&memScriptHookStore{}, &testPRStore{}, &stubHookRunner{}, "alice")
+	ctx := context.Background()
+
+	hook, err := svc.Create(ctx, model.ScriptHook{
+		Name: " Label ", Event: model.HookEventOpened, Runtime: model.HookRuntimeStarlark, Script: "print(pr)", Enabled: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Label", hook.Name)
+	assert.NotZero(t, hook.ID)
+
+	for name, bad := range map[string]model.ScriptHook{
+		"no name":       {Event: model.HookEventOpened, Runtime: model.HookRuntimeStarlark, Script: "x"},
+		"unknown event": {Name: "x", Event: "merged", Runtime: model.HookRuntimeStarlark, Script: "x"},
+		"lua":           {Name: "x", Event: model.HookEventOpened, Runtime: "lua", Script: "x"},
+		"no script":     {Name: "x", Event: model.HookEventOpened, Runtime: model.HookRuntimeShell, Script: " "},
+		"bad repo":      {Name: "x", Event: model.HookEventOpened, Runtime: model.HookRuntimeStarlark, Script: "x", RepoFullName: "acme"},
+		"runner check":  {Name: "x", Event: model.HookEventOpened, Runtime: model.HookRuntimeStarlark, Script: "bad"},
+	} {
+		_, err := svc.Create(ctx, bad)
+		assert.ErrorIs(t, err, ErrInvalidScriptHook, name)
+	}
+}
+
+func TestScriptHookService_Dispatch(t *testing.T) {
+	store := &memScriptHookStore{}
+	runner := &stubHookRunner{result: model.HookResult{Actions: []model.HookAction{{Kind: model.HookActionComment, Body: "Thanks!"}}}}
+	writer := &outboxWriter{}
+	pr := model.PullRequest{ID: 3, RepoFullName: "acme/api", Number: 7, Title: "Add caching"}
+	svc := NewScriptHookService(store, &testPRStore{pr: &pr}, runner, "alice").
+		WithWriter(func(context.Context, string) (driven.GitHubWriter, error) { return writer, nil })
+	ctx := context.Background()
+
+	for _, hook := range []model.ScriptHook{
+		{Name: "thanks", Event: model.HookEventApproved, Runtime: model.HookRuntimeStarlark, Script: "x", Enabled: true},
+		{Name: "other repo", Event: model.HookEventApproved, Runtime: model.HookRuntimeStarlark, Script: "x", RepoFullName: "acme/web", Enabled: true},
+		{Name: "off", Event: model.HookEventApproved, Runtime: model.HookRuntimeStarlark, Script: "x"},
+		{Name: "ci", Event: model.HookEventCIFailed, Runtime: model.HookRuntimeStarlark, Script: "x", Enabled: true},
+		{Name: "chat", Event: model.HookEventCommented, Runtime: model.HookRuntimeStarlark, Script: "x", Enabled: true},
+	} {
+		_, err := svc.Create(ctx, hook)
+		require.NoError(t, err)
+	}
+
+	event := func(e model.PREvent) model.PREvent {
+		e.RepoFullName, e.PRNumber, e.OccurredAt = "acme/api", 7, time.Now()
+		return e
+	}
+	svc.dispatch(ctx, event(model.PREvent{Type: model.PREventReviewAdded, Review: &model.Review{ReviewerLogin: "bob", State: model.ReviewStateApproved}}))
+	svc.dispatch(ctx, event(model.PREvent{Type: model.PREventReviewAdded, Review: &model.Review{State: model.ReviewStateCommented}}))
+	svc.dispatch(ctx, event(model.PREvent{Type: model.PREventCheckCompleted, CheckRun: &model.CheckRun{Name: "build", Conclusion: "success"}}))
+	svc.dispatch(ctx, event(model.PREvent{Type: model.PREventCheckCompleted, CheckRun: &model.CheckRun{Name: "build", Conclusion: "timed_out"}}))
+	svc.dispatch(ctx, event(model.PREvent{Type: model.PREventCommentAdded, Comment: &model.IssueComment{Author: "Alice", Body: "Thanks!"}}))
+
+	require.Len(t, runner.runs, 2, "only enabled hooks for the repo and event run; the user's own comments are skipped")
+	assert.Equal(t, model.HookEventApproved, runner.runs[0].Event)
+	assert.Equal(t, "bob", runner.runs[0].Review.ReviewerLogin)
+	assert.Equal(t, "Add caching", runner.runs[0].PullRequest.Title, "the PR is loaded for events that do not carry it")
+	assert.Equal(t, model.HookEventCIFailed, runner.runs[1].Event)
+	assert.Equal(t, []string{"Thanks!", "Thanks!"}, writer.comments)
+}
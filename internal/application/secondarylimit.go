@@ -0,0 +1,69 @@
+package application
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// pollCycleBudget is how long one scheduled poll cycle may take: the
+// scheduler ticks once a minute. A secondary rate limit that lifts later
+// than this ends the cycle instead of stalling it.
+const pollCycleBudget = time.Minute
+
+// secondaryLimitState records GitHub's latest secondary rate limit, reported
+// by the client from HTTP goroutines.
+type secondaryLimitState struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// watchSecondaryRateLimit registers the service to be told when client backs
+// off a secondary rate limit. Clients that do not report them are ignored.
+func (s *PollService) watchSecondaryRateLimit(client driven.GitHubClient) {
+	if notifier, ok := client.(driven.SecondaryRateLimitNotifier); ok {
+		notifier.NotifySecondaryRateLimit(s.recordSecondaryRateLimit)
+	}
+}
+
+// recordSecondaryRateLimit records that GitHub limited requests until until.
+// The client logs whether it waits; either way polling is limited until then.
+func (s *PollService) recordSecondaryRateLimit(until time.Time, _ bool) {
+	s.secondaryLimit.mu.Lock()
+	defer s.secondaryLimit.mu.Unlock()
+	if until.After(s.secondaryLimit.until) {
+		s.secondaryLimit.until = until
+	}
+}
+
+// rateLimitedUntil returns when the latest secondary rate limit lifts. ok is
+// false when none is in effect at now.
+func (s *PollService) rateLimitedUntil(now time.Time) (until time.Time, ok bool) {
+	s.secondaryLimit.mu.Lock()
+	defer s.secondaryLimit.mu.Unlock()
+	return s.secondaryLimit.until, now.Before(s.secondaryLimit.until)
+}
+
+// abortForRateLimit reports whether a poll cycle must stop at now because
+// the secondary rate limit in effect lifts after the cycle's budget, logging
+// when it does. The repositories not yet polled stay due for the next cycle.
+func (s *PollService) abortForRateLimit(now time.Time) bool {
+	until, ok := s.rateLimitedUntil(now)
+	if !ok || until.Sub(now) <= pollCycleBudget {
+		return false
+	}
+	slog.Warn("github secondary rate limit exceeds the poll cycle; stopping cycle", "resume_at", until.Format(time.RFC3339))
+	return true
+}
+
+// rateLimitedStatus is the degraded SyncStatus shown while a secondary rate
+// limit is in effect.
+func rateLimitedStatus(until time.Time) SyncStatus {
+	return SyncStatus{
+		Degraded: true,
+		Reason:   fmt.Sprintf("GitHub rate limited polling; resuming at %s.", until.Local().Format("15:04")),
+	}
+}
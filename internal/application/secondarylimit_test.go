@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// limitingClient answers every PR listing with a secondary rate limit that
+// lifts after wait.
+type limitingClient struct {
+	driven.GitHubClient
+	wait   time.Duration
+	notify func(until time.Time, waiting bool)
+	polled []string
+}
+
+func (c *limitingClient) NotifySecondaryRateLimit(notify func(time.Time, bool)) {
+	c.notify = notify
+}
+
+func (c *limitingClient) FetchPullRequests(_ context.Context, repoFullName string, _ string) ([]model.PullRequest, error) {
+	c.polled = append(c.polled, repoFullName)
+	c.notify(time.Now().Add(c.wait), false)
+	return nil, errors.New("secondary rate limit")
+}
+
+func TestPollAll_StopsOnLongSecondaryRateLimit(t *testing.T) {
+	client := &limitingClient{wait: 10 * time.Minute}
+	repos := &listRepoStore{repos: []model.Repository{{FullName: "o/a"}, {FullName: "o/b"}, {FullName: "o/c"}}}
+	svc := NewPollService(client, &testPRStore{}, repos, nil, nil, "me", nil, time.Minute, nil, nil)
+	require.NotNil(t, client.notify, "the service registers for rate limit reports")
+
+	require.NoError(t, svc.pollAll(t.Context()))
+	assert.Equal(t, []string{"o/a"}, client.polled, "the cycle stops once the limit outlasts it")
+
+	status := svc.SyncStatus()
+	assert.True(t, status.Degraded)
+	assert.Contains(t, status.Reason, "GitHub rate limited polling; resuming at ")
+	assert.Equal(t, 1, svc.fetchConcurrency(time.Now()))
+}
+
+func TestPollAll_WaitsOutShortSecondaryRateLimit(t *testing.T) {
+	client := &limitingClient{wait: 10 * time.Second}
+	repos := &listRepoStore{repos: []model.Repository{{FullName: "o/a"}, {FullName: "o/b"}}}
+	svc := NewPollService(client, &testPRStore{}, repos, nil, nil, "me", nil, time.Minute, nil, nil)
+
+	require.NoError(t, svc.pollAll(t.Context()))
+	assert.Equal(t, []string{"o/a", "o/b"}, client.polled, "a limit within the cycle budget does not stop it")
+	assert.True(t, svc.SyncStatus().Degraded, "the wait is still shown")
+}
+
+func TestRecordSecondaryRateLimit_KeepsLatest(t *testing.T) {
+	svc := &PollService{}
+	now := time.Now()
+
+	_, ok := svc.rateLimitedUntil(now)
+	assert.False(t, ok)
+
+	svc.recordSecondaryRateLimit(now.Add(5*time.Minute), true)
+	svc.recordSecondaryRateLimit(now.Add(time.Minute), true)
+	until, ok := svc.rateLimitedUntil(now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(5*time.Minute), until)
+
+	_, ok = svc.rateLimitedUntil(now.Add(6 * time.Minute))
+	assert.False(t, ok, "the limit lapses")
+}
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+	"github.com/ericfisherdev/mygitpanel/internal/validate"
+)
+
+// webhookSendTimeout bounds a single webhook delivery.
+const webhookSendTimeout = 10 * time.Second
+
+var (
+	// ErrInvalidWebhookSignal is returned when creating a webhook for an unknown signal.
+	ErrInvalidWebhookSignal = errors.New("unknown attention signal")
+
+	// ErrInvalidWebhookURL is returned when a webhook URL is not an absolute http(s) URL.
+	ErrInvalidWebhookURL = errors.New("webhook URL must be an absolute http or https URL")
+
+	// ErrInvalidWebhookRepo is returned when a webhook is scoped to a malformed repository name.
+	ErrInvalidWebhookRepo = errors.New("repository must be in owner/name form")
+)
+
+// SignalWebhookService calls configured webhooks when an attention signal
+// starts firing for a PR. It listens to attention.changed events on the
+// EventHub, so it only sees what the poll loop publishes.
+type SignalWebhookService struct {
+	store  driven.SignalWebhookStore
+	sender driven.WebhookSender
+}
+
+// NewSignalWebhookService creates a SignalWebhookService.
+func NewSignalWebhookService(store driven.SignalWebhookStore, sender driven.WebhookSender) *SignalWebhookService {
+	return &SignalWebhookService{store: store, sender: sender}
+}
+
+// Create validates and stores a webhook. An empty repoFullName routes the
+// signal for every watched repository.
+func (s *SignalWebhookService) Create(ctx context.Context, signal model.AttentionSignal, rawURL, repoFullName string) (model.SignalWebhook, error) {
+	if !signal.Valid() {
+		return model.SignalWebhook{}, ErrInvalidWebhookSignal
+	}
+
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return model.SignalWebhook{}, ErrInvalidWebhookURL
+	}
+
+	repoFullName = strings.TrimSpace(repoFullName)
+	if repoFullName != "" && !validate.IsValidRepoName(repoFullName) {
+		return model.SignalWebhook{}, ErrInvalidWebhookRepo
+	}
+
+	hook := model.SignalWebhook{Signal: signal, URL: rawURL, RepoFullName: repoFullName, CreatedAt: time.Now().UTC()}
+	id, err := s.store.Create(ctx, hook)
+	if err != nil {
+		return model.SignalWebhook{}, err
+	}
+	hook.ID = id
+	return hook, nil
+}
+
+// List returns all configured webhooks.
+func (s *SignalWebhookService) List(ctx context.Context) ([]model.SignalWebhook, error) {
+	return s.store.List(ctx)
+}
+
+// Delete removes a webhook by ID.
+func (s *SignalWebhookService) Delete(ctx context.Context, id int64) error {
+	return s.store.Delete(ctx, id)
+}
+
+// Run delivers webhooks for attention changes published on hub until ctx is
+// canceled. Deliveries are sequential; a failed delivery is logged and not retried.
+func (s *SignalWebhookService) Run(ctx context.Context, hub *EventHub) {
+	events, cancel := hub.Subscribe(EventFilter{Types: []model.PREventType{model.PREventAttentionChanged}})
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			s.dispatch(ctx, e)
+		}
+	}
+}
+
+// dispatch sends e to every webhook routing a signal that was off before e
+// and is on now.
+func (s *SignalWebhookService) dispatch(ctx context.Context, e model.PREvent) {
+	if e.Attention == nil {
+		return
+	}
+	var previous model.AttentionSignals
+	if e.PreviousAttention != nil {
+		previous = *e.PreviousAttention
+	}
+
+	var raised []model.AttentionSignal
+	for _, signal := range model.AllAttentionSignals {
+		if e.Attention.Has(signal) && !previous.Has(signal) {
+			raised = append(raised, signal)
+		}
+	}
+	if len(raised) == 0 {
+		return
+	}
+
+	hooks, err := s.store.List(ctx)
+	if err != nil {
+		slog.Error("failed to list signal webhooks", "error", err)
+		return
+	}
+
+	var pr model.PullRequest
+	if e.PullRequest != nil {
+		pr = *e.PullRequest
+	} else {
+		pr = model.PullRequest{RepoFullName: e.RepoFullName, Number: e.PRNumber}
+	}
+
+	for _, signal := range raised {
+		for _, hook := range hooks {
+			if !hook.Matches(signal, e.RepoFullName) {
+				continue
+			}
+			fired := model.SignalFired{Signal: signal, PullRequest: pr, Signals: *e.Attention, FiredAt: e.OccurredAt}
+			sendCtx, cancel := context.WithTimeout(ctx, webhookSendTimeout)
+			err := s.sender.SendSignalFired(sendCtx, hook.URL, fired)
+			cancel()
+			if err != nil {
+				slog.Warn("signal webhook delivery failed",
+					"webhook", hook.ID, "signal", signal, "repo", e.RepoFullName, "pr", e.PRNumber, "error", err)
+				continue
+			}
+			slog.Info("signal webhook delivered", "webhook", hook.ID, "signal", signal, "repo", e.RepoFullName, "pr", e.PRNumber)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memSignalWebhookStore is an in-memory SignalWebhookStore.
+type memSignalWebhookStore struct {
+	hooks []model.SignalWebhook
+}
+
+func (m *memSignalWebhookStore) Create(_ context.Context, hook model.SignalWebhook) (int64, error) {
+	hook.ID = int64(len(m.hooks) + 1)
+	m.hooks = append(m.hooks, hook)
+	return hook.ID, nil
+}
+
+func (m *memSignalWebhookStore) List(_ context.Context) ([]model.SignalWebhook, error) {
+	return m.hooks, nil
+}
+
+func (m *memSignalWebhookStore) Delete(_ context.Context, id int64) error {
+	for i, h := range m.hooks {
+		if h.ID == id {
+			m.hooks = append(m.hooks[:i], m.hooks[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// sentWebhook records one SendSignalFired call.
+type sentWebhook struct {
+	url   string
+	event model.SignalFired
+}
+
+// recordingSender is a WebhookSender that records deliveries.
+type recordingSender struct {
+	mu   sync.Mutex
+	sent []sentWebhook
+}
+
+func (r *recordingSender) SendSignalFired(_ context.Context, url string, event model.SignalFired) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent = append(r.sent, sentWebhook{url: url, event: event})
+	return nil
+}
+
+func TestSignalWebhookService_Create(t *testing.T) {
+	svc := NewSignalWebhookService(&memSignalWebhookStore{}, &recordingSender{})
+	ctx := context.Background()
+
+	hook, err := svc.Create(ctx, model.SignalCIFailure, " http://lamp.local/red ", "")
+	require.NoError(t, err)
+	assert.Equal(t, "http://lamp.local/red", hook.URL)
+	assert.NotZero(t, hook.ID)
+
+	_, err = svc.Create(ctx, "on_fire", "http://lamp.local/red", "")
+	assert.ErrorIs(t, err, ErrInvalidWebhookSignal)
+	_, err = svc.Create(ctx, model.SignalCIFailure, "ftp://lamp.local/red", "")
+	assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+	_, err = svc.Create(ctx, model.SignalCIFailure, "/relative", "")
+	assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+	_, err = svc.Create(ctx, model.SignalCIFailure, "http://lamp.local/red", "not a repo")
+	assert.ErrorIs(t, err, ErrInvalidWebhookRepo)
+}
+
+func TestSignalWebhookService_DispatchesRaisedSignals(t *testing.T) {
+	store := &memSignalWebhookStore{}
+	sender := &recordingSender{}
+	svc := NewSignalWebhookService(store, sender)
+	ctx := context.Background()
+
+	_, err := svc.Create(ctx, model.SignalCIFailure, "http://lamp.local/red", "")
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, model.SignalAgeUrgent, "http://lamp.local/amber", "org/other")
+	require.NoError(t, err)
+	_, err = svc.Create(ctx, model.SignalNeedsMoreReviews, "http://lamp.local/blue", "")
+	require.NoError(t, err)
+
+	pr := model.PullRequest{RepoFullName: "org/repo", Number: 7, Title: "Fix it"}
+	svc.dispatch(ctx, model.PREvent{
+		Type:              model.PREventAttentionChanged,
+		RepoFullName:      "org/repo",
+		PRNumber:          7,
+		OccurredAt:        time.Now(),
+		PullRequest:       &pr,
+		Attention:         &model.AttentionSignals{HasCIFailure: true, IsAgeUrgent: true, NeedsMoreReviews: true},
+		PreviousAttention: &model.AttentionSignals{NeedsMoreReviews: true},
+	})
+
+	require.Len(t, sender.sent, 1, "only newly raised signals routed to this repo fire")
+	assert.Equal(t, "http://lamp.local/red", sender.sent[0].url)
+	assert.Equal(t, model.SignalCIFailure, sender.sent[0].event.Signal)
+	assert.Equal(t, "Fix it", sender.sent[0].event.PullRequest.Title)
+
+	// A signal clearing does not fire anything.
+	svc.dispatch(ctx, model.PREvent{
+		Type:              model.PREventAttentionChanged,
+		RepoFullName:      "org/repo",
+		PRNumber:          7,
+		Attention:         &model.AttentionSignals{},
+		PreviousAttention: &model.AttentionSignals{HasCIFailure: true},
+	})
+	assert.Len(t, sender.sent, 1)
+}
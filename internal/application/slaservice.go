@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// ComputeSLAStatus evaluates pr against a first-review SLA of hours, measured
+// from when the PR was opened. The first review is the earliest submitted
+// review by someone other than the author, ignoring bots. ok is false when no
+// SLA applies: hours is 0, or the PR is a draft or closed without a review.
+func ComputeSLAStatus(pr model.PullRequest, reviews []model.Review, hours int, now time.Time) (status model.SLAStatus, ok bool) {
+	if hours <= 0 || pr.OpenedAt.IsZero() {
+		return status, false
+	}
+
+	var first *time.Time
+	for _, r := range reviews {
+		if r.IsBot || r.State == model.ReviewStatePending || r.SubmittedAt.IsZero() ||
+			strings.EqualFold(r.ReviewerLogin, pr.Author) {
+			continue
+		}
+		if first == nil || r.SubmittedAt.Before(*first) {
+			at := r.SubmittedAt
+			first = &at
+		}
+	}
+
+	status = model.SLAStatus{
+		Hours:         hours,
+		Deadline:      pr.OpenedAt.Add(time.Duration(hours) * time.Hour),
+		FirstReviewAt: first,
+	}
+	switch {
+	case first != nil && first.After(status.Deadline):
+		status.State = model.SLAMissed
+	case first != nil:
+		status.State = model.SLAMet
+	case pr.IsDraft || pr.Status != model.PRStatusOpen:
+		return model.SLAStatus{}, false
+	case now.After(status.Deadline):
+		status.State = model.SLAOverdue
+	default:
+		status.State = model.SLAPending
+	}
+	return status, true
+}
+
+// SLABreach is an open PR still waiting for a first review past its SLA deadline.
+type SLABreach struct {
+	PR     model.PullRequest
+	Status model.SLAStatus
+}
+
+// SLAService tracks PRs against each repository's first-review SLA, set per
+// repo alongside the attention threshold overrides.
+type SLAService struct {
+	prStore        driven.PRStore
+	reviewStore    driven.ReviewStore
+	thresholdStore driven.ThresholdStore
+	inbox          driven.InboxStore // optional; receives an entry per breach
+	now            func() time.Time
+}
+
+// NewSLAService creates an SLAService.
+func NewSLAService(prStore driven.PRStore, reviewStore driven.ReviewStore, thresholdStore driven.ThresholdStore) *SLAService {
+	return &SLAService{
+		prStore:        prStore,
+		reviewStore:    reviewStore,
+		thresholdStore: thresholdStore,
+		now:            time.Now,
+	}
+}
+
+// WithInboxStore makes Run add an attention inbox entry for each PR that
+// breaches its SLA.
+func (s *SLAService) WithInboxStore(store driven.InboxStore) *SLAService {
+	s.inbox = store
+	return s
+}
+
+// HoursFor returns the repo's first-review SLA in hours, 0 when it has none.
+// Store errors are logged and treated as no SLA.
+func (s *SLAService) HoursFor(ctx context.Context, repoFullName string) int {
+	threshold, err := s.thresholdStore.GetRepoThreshold(ctx, repoFullName)
+	if err != nil {
+		slog.Warn("failed to get repo SLA", "repo", repoFullName, "error", err)
+		return 0
+	}
+	if threshold.FirstReviewSLAHours == nil {
+		return 0
+	}
+	return *threshold.FirstReviewSLAHours
+}
+
+// StatusesFor computes the SLA status of each of prs whose repository has an
+// SLA, keyed by PR ID. Each repo's SLA is resolved once and the reviews of all
+// the PRs are loaded in one query. PRs without an applicable SLA are absent;
+// a store error is logged and yields none.
+func (s *SLAService) StatusesFor(ctx context.Context, prs []model.PullRequest) map[int64]model.SLAStatus {
+	hoursByRepo := make(map[string]int)
+	var withSLA []model.PullRequest
+	for _, pr := range prs {
+		hours, seen := hoursByRepo[pr.RepoFullName]
+		if !seen {
+			hours = s.HoursFor(ctx, pr.RepoFullName)
+			hoursByRepo[pr.RepoFullName] = hours
+		}
+		if hours > 0 {
+			withSLA = append(withSLA, pr)
+		}
+	}
+	if len(withSLA) == 0 {
+		return nil
+	}
+
+	reviewsByPR, err := s.reviewStore.GetReviewsByPRs(ctx, prIDs(withSLA))
+	if err != nil {
+		slog.Warn("failed to get reviews for SLA", "prs", len(withSLA), "error", err)
+		return nil
+	}
+
+	now := s.now()
+	statuses := make(map[int64]model.SLAStatus, len(withSLA))
+	for _, pr := range withSLA {
+		if status, ok := ComputeSLAStatus(pr, reviewsByPR[pr.ID], hoursByRepo[pr.RepoFullName], now); ok {
+			statuses[pr.ID] = status
+		}
+	}
+	return statuses
+}
+
+// Breaches returns the open PRs past their SLA deadline without a first
+// review, most overdue first.
+func (s *SLAService) Breaches(ctx context.Context) ([]SLABreach, error) {
+	prs, err := s.prStore.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]model.PullRequest, 0, len(prs))
+	for _, pr := range prs {
+		if pr.Status == model.PRStatusOpen {
+			open = append(open, pr)
+		}
+	}
+
+	statuses := s.StatusesFor(ctx, open)
+	var breaches []SLABreach
+	for _, pr := range open {
+		if status, ok := statuses[pr.ID]; ok && status.State == model.SLAOverdue {
+			breaches = append(breaches, SLABreach{PR: pr, Status: status})
+		}
+	}
+
+	sort.SliceStable(breaches, func(i, j int) bool {
+		return breaches[i].Status.Deadline.Before(breaches[j].Status.Deadline)
+	})
+	return breaches, nil
+}
+
+// Run checks for new SLA breaches every interval until ctx is canceled and
+// records each in the attention inbox. A breach is recorded once per PR and
+// deadline, so restarts and repeated checks do not duplicate it. Run returns
+// immediately without an inbox store.
+func (s *SLAService) Run(ctx context.Context, interval time.Duration) {
+	if s.inbox == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.recordBreaches(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recordBreaches adds an inbox entry for every current breach.
+func (s *SLAService) recordBreaches(ctx context.Context) {
+	breaches, err := s.Breaches(ctx)
+	if err != nil {
+		slog.Error("failed to check SLA breaches", "error", err)
+		return
+	}
+	for _, b := range breaches {
+		entry := model.InboxEvent{
+			Kind:         model.InboxSLABreached,
+			RepoFullName: b.PR.RepoFullName,
+			PRNumber:     b.PR.Number,
+			PRTitle:      b.PR.Title,
+			Actor:        b.PR.Author,
+			Summary:      "No review within the " + formatSLAHours(b.Status.Hours) + " SLA",
+			SourceID:     "sla-" + b.Status.Deadline.UTC().Format(time.RFC3339),
+			OccurredAt:   b.Status.Deadline,
+		}
+		if _, err := s.inbox.Add(ctx, entry); err != nil {
+			slog.Error("failed to record SLA breach", "repo", b.PR.RepoFullName, "pr", b.PR.Number, "error", err)
+		}
+	}
+}
+
+// formatSLAHours renders an SLA length, e.g. "24h" or "2d".
+func formatSLAHours(hours int) string {
+	if hours >= 24 && hours%24 == 0 {
+		return strconv.Itoa(hours/24) + "d"
+	}
+	return strconv.Itoa(hours) + "h"
+}
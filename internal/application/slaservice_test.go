@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// slaThresholdStore is a ThresholdStore returning the same repo override for every repo.
+type slaThresholdStore struct {
+	repo model.RepoThreshold
+}
+
+func (m *slaThresholdStore) GetGlobalSettings(_ context.Context) (model.GlobalSettings, error) {
+	return model.DefaultGlobalSettings(), nil
+}
+
+func (m *slaThresholdStore) GetRepoThreshold(_ context.Context, _ string) (model.RepoThreshold, error) {
+	return m.repo, nil
+}
+
+func (m *slaThresholdStore) SetGlobalSettings(_ context.Context, _ model.GlobalSettings) error {
+	panic("unused")
+}
+
+func (m *slaThresholdStore) SetRepoThreshold(_ context.Context, _ model.RepoThreshold) error {
+	panic("unused")
+}
+
+func (m *slaThresholdStore) DeleteRepoThreshold(_ context.Context, _ string) error {
+	panic("unused")
+}
+
+func TestComputeSLAStatus(t *testing.T) {
+	opened := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	deadline := opened.Add(24 * time.Hour)
+	open := model.PullRequest{Author: "alice", Status: model.PRStatusOpen, OpenedAt: opened}
+	review := func(login string, at time.Time) model.Review {
+		return model.Review{ReviewerLogin: login, State: model.ReviewStateCommented, SubmittedAt: at}
+	}
+
+	tests := []struct {
+		name    string
+		pr      model.PullRequest
+		reviews []model.Review
+		hours   int
+		now     time.Time
+		want    model.SLAState
+		wantOK  bool
+	}{
+		{name: "no SLA", pr: open, hours: 0, now: opened},
+		{name: "pending", pr: open, hours: 24, now: opened.Add(time.Hour), want: model.SLAPending, wantOK: true},
+		{name: "overdue", pr: open, hours: 24, now: deadline.Add(time.Hour), want: model.SLAOverdue, wantOK: true},
+		{
+			name:    "met",
+			pr:      open,
+			reviews: []model.Review{review("bob", opened.Add(2*time.Hour))},
+			hours:   24, now: deadline.Add(time.Hour), want: model.SLAMet, wantOK: true,
+		},
+		{
+			name:    "missed",
+			pr:      open,
+			reviews: []model.Review{review("bob", deadline.Add(time.Hour))},
+			hours:   24, now: deadline.Add(2 * time.Hour), want: model.SLAMissed, wantOK: true,
+		},
+		{
+			name: "author, bot, and pending reviews do not count",
+			pr:   open,
+			reviews: []model.Review{
+				review("Alice", opened.Add(time.Hour)),
+				{ReviewerLogin: "ci-bot", State: model.ReviewStateCommented, SubmittedAt: opened.Add(time.Hour), IsBot: true},
+				{ReviewerLogin: "bob", State: model.ReviewStatePending, SubmittedAt: opened.Add(time.Hour)},
+			},
+			hours: 24, now: deadline.Add(time.Hour), want: model.SLAOverdue, wantOK: true,
+		},
+		{
+			name:    "earliest review wins",
+			pr:      open,
+			reviews: []model.Review{review("carol", deadline.Add(time.Hour)), review("bob", opened.Add(time.Hour))},
+			hours:   24, now: deadline.Add(2 * time.Hour), want: model.SLAMet, wantOK: true,
+		},
+		{
+			name:  "draft waiting for review",
+			pr:    model.PullRequest{Author: "alice", Status: model.PRStatusOpen, IsDraft: true, OpenedAt: opened},
+			hours: 24, now: deadline.Add(time.Hour),
+		},
+		{
+			name:  "merged without review",
+			pr:    model.PullRequest{Author: "alice", Status: model.PRStatusMerged, OpenedAt: opened},
+			hours: 24, now: deadline.Add(time.Hour),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ComputeSLAStatus(tt.pr, tt.reviews, tt.hours, tt.now)
+			require.Equal(t, tt.wantOK, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.want, got.State)
+			assert.Equal(t, deadline, got.Deadline)
+			assert.Equal(t, tt.want == model.SLAOverdue || tt.want == model.SLAMissed, got.Breached())
+		})
+	}
+}
+
+func TestSLAService_BreachesRecordedOnce(t *testing.T) {
+	opened := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	hours := 4
+	prStore := &testPRStore{prs: []model.PullRequest{
+		{ID: 1, Number: 5, RepoFullName: "o/r", Title: "Old", Author: "alice", Status: model.PRStatusOpen, OpenedAt: opened},
+		{ID: 2, Number: 6, RepoFullName: "o/r", Title: "Older", Author: "alice", Status: model.PRStatusOpen, OpenedAt: opened.Add(-time.Hour)},
+		{ID: 3, Number: 7, RepoFullName: "o/r", Title: "New", Author: "alice", Status: model.PRStatusOpen, OpenedAt: opened.Add(5 * time.Hour)},
+		{ID: 4, Number: 8, RepoFullName: "o/r", Title: "Closed", Author: "alice", Status: model.PRStatusClosed, OpenedAt: opened},
+	}}
+	inbox := &memInboxStore{}
+	svc := NewSLAService(prStore, &testReviewStore{}, &slaThresholdStore{repo: model.RepoThreshold{FirstReviewSLAHours: &hours}}).
+		WithInboxStore(inbox)
+	svc.now = func() time.Time { return opened.Add(6 * time.Hour) }
+	ctx := context.Background()
+
+	breaches, err := svc.Breaches(ctx)
+	require.NoError(t, err)
+	require.Len(t, breaches, 2)
+	assert.Equal(t, 6, breaches[0].PR.Number, "most overdue first")
+	assert.Equal(t, 5, breaches[1].PR.Number)
+
+	svc.recordBreaches(ctx)
+	svc.recordBreaches(ctx)
+	require.Len(t, inbox.events, 2)
+	assert.Equal(t, model.InboxSLABreached, inbox.events[0].Kind)
+	assert.Equal(t, "No review within the 4h SLA", inbox.events[0].Summary)
+}
+
+func TestSLAService_NoSLAConfigured(t *testing.T) {
+	prStore := &testPRStore{prs: []model.PullRequest{
+		{ID: 1, Number: 5, RepoFullName: "o/r", Author: "alice", Status: model.PRStatusOpen, OpenedAt: time.Now().Add(-72 * time.Hour)},
+	}}
+	svc := NewSLAService(prStore, &testReviewStore{}, &slaThresholdStore{})
+
+	breaches, err := svc.Breaches(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, breaches)
+}
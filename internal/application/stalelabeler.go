@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// StaleLabeler marks open PRs that have gone idle longer than their
+// repository's stale policy allows, by adding a label or posting a comment
+// on GitHub. Each PR is marked at most once per idle period; in dry-run mode
+// the PRs that would be marked are only reported in the inbox.
+type StaleLabeler struct {
+	prStore   driven.PRStore
+	settings  driven.RepoSettingsStore
+	marks     driven.StaleMarkStore
+	writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error)
+	inbox     driven.InboxStore // optional; receives an entry per marked PR
+	now       func() time.Time
+}
+
+// NewStaleLabeler creates a StaleLabeler that writes through the writer
+// writerFor returns for each repository.
+func NewStaleLabeler(prStore driven.PRStore, settings driven.RepoSettingsStore, marks driven.StaleMarkStore,
+	writerFor func(ctx context.Context, repoFullName string) (driven.GitHubWriter, error),
+) *StaleLabeler {
+	return &StaleLabeler{
+		prStore:   prStore,
+		settings:  settings,
+		marks:     marks,
+		writerFor: writerFor,
+		now:       time.Now,
+	}
+}
+
+// WithInboxStore makes the labeler add an attention inbox entry for each PR
+// it marks, or would mark in dry-run mode.
+func (l *StaleLabeler) WithInboxStore(store driven.InboxStore) *StaleLabeler {
+	l.inbox = store
+	return l
+}
+
+// Run marks idle PRs every interval until ctx is canceled.
+func (l *StaleLabeler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		l.markStale(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// markStale marks every open PR that is idle past its repository's policy
+// and was not already marked in its current idle period.
+func (l *StaleLabeler) markStale(ctx context.Context) {
+	prs, err := l.prStore.ListAll(ctx)
+	if err != nil {
+		slog.Error("failed to list PRs for stale labeling", "error", err)
+		return
+	}
+
+	var repos []string
+	for _, pr := range prs {
+		if pr.Status == model.PRStatusOpen && !slices.Contains(repos, pr.RepoFullName) {
+			repos = append(repos, pr.RepoFullName)
+		}
+	}
+	settings, err := l.settings.ListRepoSettings(ctx, repos)
+	if err != nil {
+		slog.Error("failed to list repo settings for stale labeling", "error", err)
+		return
+	}
+	marks, err := l.marks.ListStaleMarks(ctx)
+	if err != nil {
+		slog.Error("failed to list stale marks", "error", err)
+		return
+	}
+
+	now := l.now()
+	for _, pr := range prs {
+		policy := settings[pr.RepoFullName].Stale
+		if !isStale(pr, policy, now) {
+			continue
+		}
+		// A dry-run mark does not stop the real one once dry run is turned off.
+		if mark, ok := marks[pr.ID]; ok && mark.ActivityAt.Equal(pr.LastActivityAt) && (policy.DryRun || !mark.DryRun) {
+			continue
+		}
+		l.mark(ctx, pr, policy, now)
+	}
+}
+
+// isStale reports whether pr is an open PR idle for at least the policy's
+// AfterDays, without an exempt label, and not yet carrying the stale label
+// when the policy adds one.
+func isStale(pr model.PullRequest, policy model.StalePolicy, now time.Time) bool {
+	if !policy.Enabled() || pr.Status != model.PRStatusOpen || pr.LastActivityAt.IsZero() {
+		return false
+	}
+	if now.Sub(pr.LastActivityAt) < time.Duration(policy.AfterDays)*24*time.Hour {
+		return false
+	}
+	if policy.Exempts(pr.Labels) {
+		return false
+	}
+	if policy.Action != model.StaleActionComment &&
+		slices.ContainsFunc(pr.Labels, func(l string) bool { return strings.EqualFold(l, policy.LabelOrDefault()) }) {
+		return false
+	}
+	return true
+}
+
+// mark labels or comments on pr, unless the policy is a dry run, then
+// records the mark and an inbox entry. Write failures are logged and leave
+// the PR unmarked, so the next run tries again.
+func (l *StaleLabeler) mark(ctx context.Context, pr model.PullRequest, policy model.StalePolicy, now time.Time) {
+	summary := staleSummary(policy)
+	if !policy.DryRun {
+		if err := l.write(ctx, pr, policy); err != nil {
+			slog.Error("failed to mark stale PR", "repo", pr.RepoFullName, "pr", pr.Number, "action", policy.Action, "error", err)
+			return
+		}
+	}
+	slog.Info("marked stale PR", "repo", pr.RepoFullName, "pr", pr.Number, "summary", summary)
+
+	mark := model.StaleMark{PRID: pr.ID, ActivityAt: pr.LastActivityAt, MarkedAt: now, DryRun: policy.DryRun}
+	if err := l.marks.RecordStaleMark(ctx, mark); err != nil {
+		slog.Error("failed to record stale mark", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	}
+
+	if l.inbox == nil {
+		return
+	}
+	entry := model.InboxEvent{
+		Kind:         model.InboxStaleMarked,
+		RepoFullName: pr.RepoFullName,
+		PRNumber:     pr.Number,
+		PRTitle:      pr.Title,
+		Actor:        pr.Author,
+		Summary:      summary,
+		SourceID:     "stale-" + pr.LastActivityAt.UTC().Format(time.RFC3339),
+		OccurredAt:   now,
+	}
+	if _, err := l.inbox.Add(ctx, entry); err != nil {
+		slog.Error("failed to record stale PR", "repo", pr.RepoFullName, "pr", pr.Number, "error", err)
+	}
+}
+
+// write adds the stale label or posts the stale comment on GitHub.
+func (l *StaleLabeler) write(ctx context.Context, pr model.PullRequest, policy model.StalePolicy) error {
+	writer, err := l.writerFor(ctx, pr.RepoFullName)
+	if err != nil {
+		return err
+	}
+	if policy.Action == model.StaleActionComment {
+		return writer.CreateIssueComment(ctx, pr.RepoFullName, pr.Number, staleComment(policy.AfterDays))
+	}
+	return writer.AddLabels(ctx, pr.RepoFullName, pr.Number, []string{policy.LabelOrDefault()})
+}
+
+// staleSummary describes what the policy does to an idle PR, for the inbox.
+func staleSummary(policy model.StalePolicy) string {
+	var summary string
+	switch {
+	case policy.Action == model.StaleActionComment && policy.DryRun:
+		summary = "Would comment"
+	case policy.Action == model.StaleActionComment:
+		summary = "Commented"
+	case policy.DryRun:
+		summary = fmt.Sprintf("Would label %q", policy.LabelOrDefault())
+	default:
+		summary = fmt.Sprintf("Labeled %q", policy.LabelOrDefault())
+	}
+	summary += fmt.Sprintf(" after %d days without activity", policy.AfterDays)
+	if policy.DryRun {
+		summary += " (dry run)"
+	}
+	return summary
+}
+
+// staleComment is the comment posted on PRs idle for days.
+func staleComment(days int) string {
+	return fmt.Sprintf("This pull request has had no activity for %d days. "+
+		"Is it still needed? Push a commit or leave a comment to keep it active, or close it if it is no longer relevant.", days)
+}
@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// staticRepoSettingsStore serves fixed settings per repository.
+type staticRepoSettingsStore map[string]model.RepoSettings
+
+func (s staticRepoSettingsStore) GetRepoSettings(_ context.Context, repoFullName string) (model.RepoSettings, error) {
+	return s[repoFullName], nil
+}
+
+func (s staticRepoSettingsStore) ListRepoSettings(_ context.Context, _ []string) (map[string]model.RepoSettings, error) {
+	return s, nil
+}
+
+func (s staticRepoSettingsStore) SetRepoSettings(_ context.Context, _ model.RepoSettings) error {
+	panic("unused")
+}
+
+func (s staticRepoSettingsStore) ReplaceRepoSettings(_ context.Context, _ model.RepoSettings, _ model.RepoThreshold) error {
+	panic("unused")
+}
+
+// memStaleMarkStore is an in-memory StaleMarkStore.
+type memStaleMarkStore map[int64]model.StaleMark
+
+func (m memStaleMarkStore) ListStaleMarks(_ context.Context) (map[int64]model.StaleMark, error) {
+	return m, nil
+}
+
+func (m memStaleMarkStore) RecordStaleMark(_ context.Context, mark model.StaleMark) error {
+	m[mark.PRID] = mark
+	return nil
+}
+
+// labelWriter records the labels and comments posted through it.
+type labelWriter struct {
+	outboxWriter
+	labels []string
+}
+
+func (w *labelWriter) AddLabels(_ context.Context, repoFullName string, prNumber int, labels []string) error {
+	for _, label := range labels {
+		w.labels = append(w.labels, repoFullName+"#"+label)
+	}
+	return nil
+}
+
+func TestStaleLabeler_MarkStale(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	idle := now.Add(-20 * 24 * time.Hour)
+	pr := func(id int64, repo string, lastActivity time.Time, labels ...string) model.PullRequest {
+		return model.PullRequest{ID: id, Number: int(id), RepoFullName: repo, Title: "PR", Author: "alice",
+			Status: model.PRStatusOpen, LastActivityAt: lastActivity, Labels: labels}
+	}
+	prs := []model.PullRequest{
+		pr(1, "o/label", idle),
+		pr(2, "o/label", now.Add(-time.Hour)),
+		pr(3, "o/label", idle, "Pinned"),
+		pr(4, "o/label", idle, "STALE"),
+		pr(5, "o/comment", idle),
+		pr(6, "o/dry", idle),
+		pr(7, "o/off", idle),
+	}
+	prs = append(prs, model.PullRequest{ID: 8, Number: 8, RepoFullName: "o/label", Status: model.PRStatusClosed, LastActivityAt: idle})
+	settings := staticRepoSettingsStore{
+		"o/label":   {Stale: model.StalePolicy{AfterDays: 14, Action: model.StaleActionLabel, ExemptLabels: []string{"pinned"}}},
+		"o/comment": {Stale: model.StalePolicy{AfterDays: 14, Action: model.StaleActionComment}},
+		"o/dry":     {Stale: model.StalePolicy{AfterDays: 14, DryRun: true}},
+	}
+	marks := memStaleMarkStore{}
+	writer := &labelWriter{}
+	inbox := &memInboxStore{}
+	labeler := NewStaleLabeler(&testPRStore{prs: prs}, settings, marks,
+		func(context.Context, string) (driven.GitHubWriter, error) { return writer, nil }).
+		WithInboxStore(inbox)
+	labeler.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	labeler.markStale(ctx)
+	labeler.markStale(ctx)
+
+	assert.Equal(t, []string{"o/label#stale"}, writer.labels, "exempt, already labeled, active, and closed PRs are skipped")
+	require.Len(t, writer.comments, 1, "each PR is marked once per idle period")
+	assert.Contains(t, writer.comments[0], "no activity for 14 days")
+	assert.Len(t, marks, 3)
+	assert.True(t, marks[6].DryRun)
+
+	require.Len(t, inbox.events, 3)
+	assert.Equal(t, model.InboxStaleMarked, inbox.events[0].Kind)
+	assert.Equal(t, `Labeled "stale" after 14 days without activity`, inbox.events[0].Summary)
+	assert.Equal(t, `Would label "stale" after 14 days without activity (dry run)`, inbox.events[2].Summary)
+
+	settings["o/dry"] = model.RepoSettings{Stale: model.StalePolicy{AfterDays: 14}}
+	labeler.markStale(ctx)
+	assert.Equal(t, []string{"o/label#stale", "o/dry#stale"}, writer.labels, "turning dry run off marks PRs seen in the dry run")
+}
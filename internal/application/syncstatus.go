@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// SyncStatus summarizes whether the poll loop is keeping stored GitHub data
+// fresh. Reason explains an offline or degraded status for stale-data
+// banners. Degraded means GitHub still answers but is having an incident;
+// it is never set together with Offline.
+type SyncStatus struct {
+	Offline  bool
+	Degraded bool
+	Reason   string
+}
+
+// WithSyncRecorder enables persisting each repository's last successful
+// poll, so last-synced times survive restarts and outages.
+func (s *PollService) WithSyncRecorder(recorder driven.RepoSyncRecorder) *PollService {
+	s.syncRecorder = recorder
+	return s
+}
+
+// recordSync stores that repoFullName was just polled successfully.
+func (s *PollService) recordSync(ctx context.Context, repoFullName string) {
+	if s.syncRecorder == nil {
+		return
+	}
+	if err := s.syncRecorder.MarkSynced(ctx, repoFullName, time.Now()); err != nil {
+		slog.Warn("failed to record repo sync", "repo", repoFullName, "error", err)
+	}
+}
+
+// SyncStatus reports whether GitHub is currently reachable. It is offline
+// when GitHub rejected the active token, or when every repository polled
+// since startup failed its latest poll with a transient error. Repositories
+// that are gone, forbidden, or moved fail on their own and do not count.
+// While a secondary rate limit holds polling back it is degraded instead,
+// naming when polling resumes, since the limit explains the failed polls.
+// Otherwise it is degraded while GitHub reports an API incident or many
+// recent polls failed (see degradedReason).
+func (s *PollService) SyncStatus() SyncStatus {
+	if status, ok := s.GitHubStatus(); ok && status.Unauthorized {
+		return SyncStatus{Offline: true, Reason: "GitHub rejected the configured token."}
+	}
+	now := time.Now()
+	if until, ok := s.rateLimitedUntil(now); ok {
+		return rateLimitedStatus(until)
+	}
+	if s.everyPollFailing() {
+		return SyncStatus{Offline: true, Reason: "GitHub is unreachable; the latest poll of every repository failed."}
+	}
+	if reason := s.degradedReason(now); reason != "" {
+		return SyncStatus{Degraded: true, Reason: reason}
+	}
+	return SyncStatus{}
+}
+
+// everyPollFailing reports whether every repository that counts toward
+// SyncStatus failed its latest poll.
+func (s *PollService) everyPollFailing() bool {
+	s.schedulesMu.RLock()
+	defer s.schedulesMu.RUnlock()
+
+	considered := 0
+	for _, sched := range s.schedules {
+		if sched.unreachableStreak > 0 || sched.movedTo != "" {
+			continue
+		}
+		if sched.failures == 0 {
+			return false
+		}
+		considered++
+	}
+	return considered > 0
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+func TestSyncStatus(t *testing.T) {
+	reset := errors.New("connection reset")
+	notFound := fmt.Errorf("listing pull requests: %w", driven.ErrRepoUnreachable)
+
+	t.Run("online before any poll", func(t *testing.T) {
+		svc := &PollService{schedules: make(map[string]repoSchedule)}
+		assert.False(t, svc.SyncStatus().Offline)
+	})
+
+	t.Run("offline when every repo fails transiently", func(t *testing.T) {
+		svc := &PollService{schedules: make(map[string]repoSchedule)}
+		svc.recordPollFailure("org/a", reset)
+		svc.recordPollFailure("org/b", reset)
+
+		status := svc.SyncStatus()
+		assert.True(t, status.Offline)
+		assert.Contains(t, status.Reason, "unreachable")
+	})
+
+	t.Run("online while one repo still syncs", func(t *testing.T) {
+		svc := &PollService{schedules: map[string]repoSchedule{"org/ok": {lastPolled: time.Now()}}}
+		svc.recordPollFailure("org/a", reset)
+		assert.False(t, svc.SyncStatus().Offline)
+	})
+
+	t.Run("gone and moved repos do not count", func(t *testing.T) {
+		svc := &PollService{schedules: make(map[string]repoSchedule)}
+		svc.recordPollFailure("org/gone", notFound)
+		svc.recordPollFailure("org/old", &driven.RepoMovedError{From: "org/old", To: "org/new"})
+		assert.False(t, svc.SyncStatus().Offline)
+	})
+}
+
+type syncRecorderFunc func(ctx context.Context, fullName string, at time.Time) error
+
+func (f syncRecorderFunc) MarkSynced(ctx context.Context, fullName string, at time.Time) error {
+	return f(ctx, fullName, at)
+}
+
+func TestRecordSync(t *testing.T) {
+	var got string
+	var gotAt time.Time
+	svc := (&PollService{}).WithSyncRecorder(syncRecorderFunc(func(_ context.Context, fullName string, at time.Time) error {
+		got, gotAt = fullName, at
+		return nil
+	}))
+
+	svc.recordSync(t.Context(), "org/repo")
+
+	assert.Equal(t, "org/repo", got)
+	assert.WithinDuration(t, time.Now(), gotAt, time.Second)
+}
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// SystemStats is a snapshot of what the server has stored and how polling is
+// going, for the system status endpoint and the settings drawer.
+type SystemStats struct {
+	GeneratedAt time.Time
+	PRsByStatus map[model.PRStatus]int
+	// OpenPRsByCI counts open PRs only; the CI state of closed PRs no
+	// longer matters.
+	OpenPRsByCI map[model.CIStatus]int
+	Repos       []RepoPollStats // sorted by name
+	// OutboxPending and OutboxFailed count queued GitHub writes. Both are
+	// zero when no outbox is configured.
+	OutboxPending int
+	OutboxFailed  int
+}
+
+// RepoPollStats is a watched repository's latest poll result. The poll fields
+// are zero until the repository has been polled.
+type RepoPollStats struct {
+	FullName            string
+	Paused              bool
+	OpenPRs             int
+	Tier                ActivityTier
+	LastPolledAt        time.Time
+	NextPollAt          time.Time
+	ConsecutiveFailures int
+	LastError           string
+	LastFailedAt        time.Time
+}
+
+// SystemService summarizes stored PRs, poll results, and the outbox.
+type SystemService struct {
+	prStore   driven.PRStore
+	repoStore driven.RepoStore
+	pollSvc   *PollService       // optional; repos report no poll results when nil
+	outbox    driven.OutboxStore // optional; the outbox counts stay zero when nil
+}
+
+// NewSystemService creates a SystemService.
+func NewSystemService(prStore driven.PRStore, repoStore driven.RepoStore, pollSvc *PollService) *SystemService {
+	return &SystemService{prStore: prStore, repoStore: repoStore, pollSvc: pollSvc}
+}
+
+// WithOutboxStore includes the queued GitHub writes in the stats.
+func (s *SystemService) WithOutboxStore(store driven.OutboxStore) *SystemService {
+	s.outbox = store
+	return s
+}
+
+// Stats returns the current system stats.
+func (s *SystemService) Stats(ctx context.Context) (SystemStats, error) {
+	stats := SystemStats{
+		GeneratedAt: time.Now(),
+		PRsByStatus: make(map[model.PRStatus]int),
+		OpenPRsByCI: make(map[model.CIStatus]int),
+	}
+
+	counts, err := s.prStore.CountByStatus(ctx)
+	if err != nil {
+		return SystemStats{}, fmt.Errorf("counting PRs: %w", err)
+	}
+	openByRepo := make(map[string]int)
+	for _, c := range counts {
+		stats.PRsByStatus[c.Status] += c.Count
+		if c.Status == model.PRStatusOpen {
+			ci := c.CIStatus
+			if ci == "" {
+				ci = model.CIStatusUnknown
+			}
+			stats.OpenPRsByCI[ci] += c.Count
+			openByRepo[c.RepoFullName] += c.Count
+		}
+	}
+
+	repos, err := s.repoStore.ListAll(ctx)
+	if err != nil {
+		return SystemStats{}, fmt.Errorf("listing repos: %w", err)
+	}
+	var schedules map[string]ScheduleInfo
+	if s.pollSvc != nil {
+		schedules = s.pollSvc.Schedules()
+	}
+	stats.Repos = make([]RepoPollStats, 0, len(repos))
+	for _, repo := range repos {
+		sched := schedules[repo.FullName]
+		stats.Repos = append(stats.Repos, RepoPollStats{
+			FullName:            repo.FullName,
+			Paused:              repo.Paused,
+			OpenPRs:             openByRepo[repo.FullName],
+			Tier:                sched.Tier,
+			LastPolledAt:        sched.LastPolled,
+			NextPollAt:          sched.NextPollAt,
+			ConsecutiveFailures: sched.ConsecutiveFailures,
+			LastError:           sched.LastError,
+			LastFailedAt:        sched.LastFailedAt,
+		})
+	}
+	sort.Slice(stats.Repos, func(i, j int) bool {
+		return stats.Repos[i].FullName < stats.Repos[j].FullName
+	})
+
+	if s.outbox != nil {
+		actions, err := s.outbox.List(ctx)
+		if err != nil {
+			return SystemStats{}, fmt.Errorf("listing outbox: %w", err)
+		}
+		for _, a := range actions {
+			switch a.Status {
+			case model.OutboundPending:
+				stats.OutboxPending++
+			case model.OutboundFailed:
+				stats.OutboxFailed++
+			}
+		}
+	}
+
+	return stats, nil
+}
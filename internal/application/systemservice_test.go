@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// listRepoStore lists a fixed set of repositories.
+type listRepoStore struct {
+	driven.RepoStore
+	repos []model.Repository
+}
+
+func (s *listRepoStore) ListAll(_ context.Context) ([]model.Repository, error) { return s.repos, nil }
+
+func TestSystemService_Stats(t *testing.T) {
+	ctx := context.Background()
+	polled := time.Now().Add(-time.Minute)
+
+	prs := &testPRStore{prs: []model.PullRequest{
+		{RepoFullName: "org/b", Status: model.PRStatusOpen, CIStatus: model.CIStatusFailing},
+		{RepoFullName: "org/b", Status: model.PRStatusOpen},
+		{RepoFullName: "org/a", Status: model.PRStatusOpen, CIStatus: model.CIStatusPassing},
+		{RepoFullName: "org/a", Status: model.PRStatusMerged, CIStatus: model.CIStatusFailing},
+	}}
+	repos := &listRepoStore{repos: []model.Repository{{FullName: "org/b", Paused: true}, {FullName: "org/a"}}}
+	poll := &PollService{schedules: map[string]repoSchedule{
+		"org/a": {tier: TierWarm, lastPolled: polled, failures: 2, lastError: "timeout"},
+	}}
+	outbox := newMemOutboxStore()
+	for _, status := range []model.OutboundStatus{model.OutboundPending, model.OutboundPending, model.OutboundFailed} {
+		_, err := outbox.Add(ctx, model.OutboundAction{Status: status})
+		require.NoError(t, err)
+	}
+
+	stats, err := NewSystemService(prs, repos, poll).WithOutboxStore(outbox).Stats(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[model.PRStatus]int{model.PRStatusOpen: 3, model.PRStatusMerged: 1}, stats.PRsByStatus)
+	assert.Equal(t, map[model.CIStatus]int{
+		model.CIStatusFailing: 1, model.CIStatusPassing: 1, model.CIStatusUnknown: 1,
+	}, stats.OpenPRsByCI, "merged PRs and their CI are not counted")
+	assert.Equal(t, 2, stats.OutboxPending)
+	assert.Equal(t, 1, stats.OutboxFailed)
+
+	require.Len(t, stats.Repos, 2)
+	assert.Equal(t, RepoPollStats{
+		FullName: "org/a", OpenPRs: 1, Tier: TierWarm, LastPolledAt: polled, ConsecutiveFailures: 2, LastError: "timeout",
+	}, stats.Repos[0])
+	assert.Equal(t, RepoPollStats{FullName: "org/b", Paused: true, OpenPRs: 2}, stats.Repos[1], "never polled")
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// TeamStatsPeriods are the leaderboard periods offered, in days.
+var TeamStatsPeriods = []int{7, 30, 90, 365}
+
+// DefaultTeamStatsPeriod is the leaderboard period used when none is chosen.
+const DefaultTeamStatsPeriod = 30
+
+// TeamStatsService ranks reviewers by their review activity for the opt-in
+// team stats leaderboard.
+type TeamStatsService struct {
+	store driven.TeamStatsStore
+	now   func() time.Time
+}
+
+// NewTeamStatsService creates a TeamStatsService.
+func NewTeamStatsService(store driven.TeamStatsStore) *TeamStatsService {
+	return &TeamStatsService{store: store, now: time.Now}
+}
+
+// IsTeamStatsPeriod reports whether days is one of TeamStatsPeriods.
+func IsTeamStatsPeriod(days int) bool {
+	return slices.Contains(TeamStatsPeriods, days)
+}
+
+// Leaderboard returns reviewer stats over the last days days, optionally for
+// one repository. Reviewers are ranked by reviews given, then by PRs
+// reviewed, then by faster average response, then by login.
+func (s *TeamStatsService) Leaderboard(ctx context.Context, days int, repoFullName string) ([]model.ReviewerStats, error) {
+	since := s.now().AddDate(0, 0, -days)
+	stats, err := s.store.ReviewerStats(ctx, since, repoFullName)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		switch {
+		case a.ReviewsGiven != b.ReviewsGiven:
+			return a.ReviewsGiven > b.ReviewsGiven
+		case a.PRsReviewed != b.PRsReviewed:
+			return a.PRsReviewed > b.PRsReviewed
+		case a.AvgResponseTime != b.AvgResponseTime:
+			return a.AvgResponseTime < b.AvgResponseTime
+		default:
+			return a.Login < b.Login
+		}
+	})
+	return stats, nil
+}
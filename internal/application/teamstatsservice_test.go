@@ -0,0 +1,49 @@
+package application_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/application"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+type fakeTeamStatsStore struct {
+	stats []model.ReviewerStats
+	since time.Time
+	repo  string
+}
+
+func (f *fakeTeamStatsStore) ReviewerStats(_ context.Context, since time.Time, repoFullName string) ([]model.ReviewerStats, error) {
+	f.since, f.repo = since, repoFullName
+	return f.stats, nil
+}
+
+func TestTeamStatsService_Leaderboard(t *testing.T) {
+	store := &fakeTeamStatsStore{stats: []model.ReviewerStats{
+		{Login: "dave", ReviewsGiven: 3, PRsReviewed: 2, AvgResponseTime: 5 * time.Hour},
+		{Login: "alice", ReviewsGiven: 8, PRsReviewed: 4},
+		{Login: "carol", ReviewsGiven: 3, PRsReviewed: 2, AvgResponseTime: time.Hour},
+		{Login: "bob", ReviewsGiven: 3, PRsReviewed: 3},
+	}}
+
+	got, err := application.NewTeamStatsService(store).Leaderboard(context.Background(), 7, "org/api")
+	require.NoError(t, err)
+
+	logins := make([]string, 0, len(got))
+	for _, s := range got {
+		logins = append(logins, s.Login)
+	}
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave"}, logins)
+	assert.Equal(t, "org/api", store.repo)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), store.since, time.Minute)
+}
+
+func TestIsTeamStatsPeriod(t *testing.T) {
+	assert.True(t, application.IsTeamStatsPeriod(30))
+	assert.False(t, application.IsTeamStatsPeriod(14))
+}
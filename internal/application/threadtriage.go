@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// PRThreads pairs a pull request with some of its review threads.
+type PRThreads struct {
+	PR      model.PullRequest
+	Threads []CommentThread // Sorted by file path, then line.
+}
+
+// UnresolvedThreads returns the unresolved review threads of the open PRs
+// the user is involved in: PRs they authored, PRs waiting for their review,
+// and PRs they have reviewed or commented on in a thread. usernameFor names
+// the user in a repository, since accounts can differ per repository. PRs
+// without unresolved threads are left out; the rest keep the order of prs.
+func (s *ReviewService) UnresolvedThreads(ctx context.Context, prs []model.PullRequest, usernameFor func(repoFullName string) string) ([]PRThreads, error) {
+	var result []PRThreads
+	for _, pr := range prs {
+		if pr.Status != model.PRStatusOpen {
+			continue
+		}
+		username := usernameFor(pr.RepoFullName)
+
+		comments, err := s.reviewStore.GetReviewCommentsByPR(ctx, pr.ID)
+		if err != nil {
+			return nil, err
+		}
+		involved := strings.EqualFold(pr.Author, username) || pr.NeedsReview ||
+			commentedOn(comments, username)
+		if !involved {
+			reviews, err := s.reviewStore.GetReviewsByPR(ctx, pr.ID)
+			if err != nil {
+				return nil, err
+			}
+			involved = reviewedBy(reviews, username)
+		}
+		if !involved {
+			continue
+		}
+
+		var unresolved []CommentThread
+		for _, t := range groupIntoThreads(comments) {
+			if !t.IsResolved {
+				unresolved = append(unresolved, t)
+			}
+		}
+		if len(unresolved) == 0 {
+			continue
+		}
+		sort.SliceStable(unresolved, func(i, j int) bool {
+			a, b := unresolved[i].RootComment, unresolved[j].RootComment
+			if a.Path != b.Path {
+				return a.Path < b.Path
+			}
+			return a.Line < b.Line
+		})
+		result = append(result, PRThreads{PR: pr, Threads: unresolved})
+	}
+	return result, nil
+}
+
+// SetThreadResolved records the resolution of the thread rooted at rootID
+// locally, so it shows before the next poll syncs it from GitHub.
+func (s *ReviewService) SetThreadResolved(ctx context.Context, rootID int64, resolved bool) error {
+	return s.reviewStore.UpdateCommentResolution(ctx, rootID, resolved)
+}
+
+// commentedOn reports whether username wrote any of comments.
+func commentedOn(comments []model.ReviewComment, username string) bool {
+	for _, c := range comments {
+		if strings.EqualFold(c.Author, username) {
+			return true
+		}
+	}
+	return false
+}
+
+// reviewedBy reports whether username submitted any of reviews.
+func reviewedBy(reviews []model.Review, username string) bool {
+	for _, r := range reviews {
+		if strings.EqualFold(r.ReviewerLogin, username) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// prReviewStore serves review comments and reviews per PR ID.
+type prReviewStore struct {
+	testReviewStore
+	comments map[int64][]model.ReviewComment
+	reviews  map[int64][]model.Review
+}
+
+func (m *prReviewStore) GetReviewCommentsByPR(_ context.Context, prID int64) ([]model.ReviewComment, error) {
+	return m.comments[prID], nil
+}
+
+func (m *prReviewStore) GetReviewsByPR(_ context.Context, prID int64) ([]model.Review, error) {
+	return m.reviews[prID], nil
+}
+
+func (m *prReviewStore) GetReviewsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.Review, error) {
+	result := make(map[int64][]model.Review, len(prIDs))
+	for _, id := range prIDs {
+		reviews, err := m.GetReviewsByPR(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		result[id] = reviews
+	}
+	return result, nil
+}
+
+func TestReviewService_UnresolvedThreads(t *testing.T) {
+	store := &prReviewStore{
+		comments: map[int64][]model.ReviewComment{
+			1: {
+				{ID: 10, Author: "bob", Path: "b.go", Line: 3},
+				{ID: 11, Author: "bob", Path: "a.go", Line: 9},
+				{ID: 12, Author: "bob", Path: "a.go", Line: 2},
+				{ID: 13, Author: "bob", Path: "a.go", Line: 1, IsResolved: true},
+				{ID: 14, Author: "me", InReplyToID: int64Ptr(10)},
+			},
+			2: {{ID: 20, Author: "carol", Path: "x.go"}},
+			3: {{ID: 30, Author: "me", Path: "y.go"}},
+			4: {{ID: 40, Author: "carol", Path: "z.go"}},
+			5: {{ID: 50, Author: "carol", Path: "z.go", IsResolved: true}},
+			6: {{ID: 60, Author: "carol", Path: "w.go"}},
+		},
+		reviews: map[int64][]model.Review{6: {{ReviewerLogin: "Me"}}},
+	}
+	svc := NewReviewService(store, &testBotConfigStore{})
+
+	prs := []model.PullRequest{
+		{ID: 1, Author: "me", Status: model.PRStatusOpen},
+		{ID: 2, Author: "carol", Status: model.PRStatusOpen},                    // not involved
+		{ID: 3, Author: "carol", Status: model.PRStatusOpen},                    // commented
+		{ID: 4, Author: "carol", Status: model.PRStatusOpen, NeedsReview: true}, // review requested
+		{ID: 5, Author: "me", Status: model.PRStatusOpen},                       // all resolved
+		{ID: 6, Author: "carol", Status: model.PRStatusOpen},                    // reviewed
+		{ID: 7, Author: "me", Status: model.PRStatusMerged},
+	}
+	got, err := svc.UnresolvedThreads(context.Background(), prs, func(string) string { return "me" })
+	require.NoError(t, err)
+
+	var ids []int64
+	for _, p := range got {
+		ids = append(ids, p.PR.ID)
+	}
+	assert.Equal(t, []int64{1, 3, 4, 6}, ids)
+
+	var roots []int64
+	for _, th := range got[0].Threads {
+		roots = append(roots, th.RootComment.ID)
+	}
+	assert.Equal(t, []int64{12, 11, 10}, roots, "sorted by file and line, resolved threads left out")
+	assert.Len(t, got[0].Threads[2].Replies, 1)
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"sort"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+	"github.com/ericfisherdev/mygitpanel/internal/domain/port/driven"
+)
+
+// WhatsNew is what the user has not yet acknowledged: changelog releases and
+// the schema migrations applied since the panel was last dismissed.
+type WhatsNew struct {
+	Releases   []model.Release         // newest first
+	Migrations []model.SchemaMigration // in version order
+}
+
+// IsEmpty reports whether there is nothing to show.
+func (w WhatsNew) IsEmpty() bool {
+	return len(w.Releases) == 0 && len(w.Migrations) == 0
+}
+
+// WhatsNewService decides what the what's-new panel shows after an upgrade
+// and records when the user dismisses it.
+type WhatsNewService struct {
+	store         driven.WhatsNewStore
+	releases      []model.Release
+	migrations    []model.SchemaMigration
+	schemaVersion uint
+}
+
+// NewWhatsNewService creates a WhatsNewService over the embedded changelog
+// and migrations. schemaVersion is the database schema version after this
+// startup's migrations ran.
+func NewWhatsNewService(store driven.WhatsNewStore, releases []model.Release, migrations []model.SchemaMigration, schemaVersion uint) *WhatsNewService {
+	sorted := append([]model.Release(nil), releases...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID > sorted[j].ID })
+
+	return &WhatsNewService{
+		store:         store,
+		releases:      sorted,
+		migrations:    migrations,
+		schemaVersion: schemaVersion,
+	}
+}
+
+// Initialize records a baseline the first time it runs against a database.
+// A fresh install (previousSchema 0) starts with nothing to show; a database
+// upgraded from a build without the panel shows the newest release and the
+// migrations this startup applied. Later calls leave the stored state alone.
+func (s *WhatsNewService) Initialize(ctx context.Context, previousSchema uint) error {
+	if _, ok, err := s.store.GetSeen(ctx); err != nil || ok {
+		return err
+	}
+
+	if previousSchema == 0 {
+		return s.Dismiss(ctx)
+	}
+
+	baseline := model.WhatsNewSeen{SchemaVersion: previousSchema}
+	if len(s.releases) > 1 {
+		baseline.ReleaseID = s.releases[1].ID
+	}
+	return s.store.SetSeen(ctx, baseline)
+}
+
+// Pending returns the releases and migrations the user has not acknowledged.
+func (s *WhatsNewService) Pending(ctx context.Context) (WhatsNew, error) {
+	seen, _, err := s.store.GetSeen(ctx)
+	if err != nil {
+		return WhatsNew{}, err
+	}
+
+	var result WhatsNew
+	for _, r := range s.releases {
+		if r.ID > seen.ReleaseID {
+			result.Releases = append(result.Releases, r)
+		}
+	}
+	for _, m := range s.migrations {
+		if m.Version > seen.SchemaVersion && m.Version <= s.schemaVersion {
+			result.Migrations = append(result.Migrations, m)
+		}
+	}
+	return result, nil
+}
+
+// Dismiss acknowledges everything currently pending.
+func (s *WhatsNewService) Dismiss(ctx context.Context) error {
+	seen := model.WhatsNewSeen{SchemaVersion: s.schemaVersion}
+	if len(s.releases) > 0 {
+		seen.ReleaseID = s.releases[0].ID
+	}
+	return s.store.SetSeen(ctx, seen)
+}
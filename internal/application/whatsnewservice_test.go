@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// memWhatsNewStore is an in-memory WhatsNewStore.
+type memWhatsNewStore struct {
+	seen model.WhatsNewSeen
+	ok   bool
+}
+
+func (m *memWhatsNewStore) GetSeen(_ context.Context) (model.WhatsNewSeen, bool, error) {
+	return m.seen, m.ok, nil
+}
+
+func (m *memWhatsNewStore) SetSeen(_ context.Context, seen model.WhatsNewSeen) error {
+	m.seen, m.ok = seen, true
+	return nil
+}
+
+func whatsNewTestData() ([]model.Release, []model.SchemaMigration) {
+	releases := []model.Release{
+		{ID: "2026-09-01", Changes: []string{"old"}},
+		{ID: "2026-10-16", Changes: []string{"new"}, Notices: []string{"check settings"}},
+	}
+	migrations := []model.SchemaMigration{
+		{Version: 18, Description: "add api tokens"},
+		{Version: 19, Description: "add branch protection"},
+	}
+	return releases, migrations
+}
+
+func TestWhatsNew_FreshInstallShowsNothing(t *testing.T) {
+	ctx := context.Background()
+	releases, migrations := whatsNewTestData()
+	svc := NewWhatsNewService(&memWhatsNewStore{}, releases, migrations, 19)
+
+	require.NoError(t, svc.Initialize(ctx, 0))
+
+	pending, err := svc.Pending(ctx)
+	require.NoError(t, err)
+	assert.True(t, pending.IsEmpty())
+}
+
+func TestWhatsNew_UpgradeShowsLatestReleaseAndAppliedMigrations(t *testing.T) {
+	ctx := context.Background()
+	releases, migrations := whatsNewTestData()
+	store := &memWhatsNewStore{}
+	svc := NewWhatsNewService(store, releases, migrations, 19)
+
+	require.NoError(t, svc.Initialize(ctx, 18))
+
+	pending, err := svc.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending.Releases, 1)
+	assert.Equal(t, "2026-10-16", pending.Releases[0].ID)
+	assert.Equal(t, []model.SchemaMigration{{Version: 19, Description: "add branch protection"}}, pending.Migrations)
+
+	require.NoError(t, svc.Dismiss(ctx))
+	pending, err = svc.Pending(ctx)
+	require.NoError(t, err)
+	assert.True(t, pending.IsEmpty())
+
+	// A restart must not reset the dismissal.
+	require.NoError(t, NewWhatsNewService(store, releases, migrations, 19).Initialize(ctx, 19))
+	assert.Equal(t, model.WhatsNewSeen{ReleaseID: "2026-10-16", SchemaVersion: 19}, store.seen)
+}
+
+func TestWhatsNew_LaterUpgradeShowsEverythingSinceDismissal(t *testing.T) {
+	ctx := context.Background()
+	releases, migrations := whatsNewTestData()
+	store := &memWhatsNewStore{seen: model.WhatsNewSeen{ReleaseID: "2026-08-01", SchemaVersion: 17}, ok: true}
+	svc := NewWhatsNewService(store, releases, migrations, 19)
+
+	require.NoError(t, svc.Initialize(ctx, 17))
+
+	pending, err := svc.Pending(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending.Releases, 2)
+	assert.Equal(t, "2026-10-16", pending.Releases[0].ID, "newest release first")
+	assert.Len(t, pending.Migrations, 2)
+}
@@ -0,0 +1,69 @@
+// Package changelog provides the release notes embedded in the binary for
+// the in-app what's-new panel.
+package changelog
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+//go:embed CHANGELOG.md
+var changelogMD string
+
+// needsAttentionHeading starts the list of notices within a release.
+const needsAttentionHeading = "### Needs attention"
+
+// Releases returns the embedded changelog, newest release first.
+func Releases() ([]model.Release, error) {
+	return Parse(changelogMD)
+}
+
+// Parse reads changelog markdown: "## YYYY-MM-DD" release headings followed
+// by "- " bullets, with bullets after "### Needs attention" recorded as
+// notices. Text before the first release heading is ignored. Releases are
+// returned newest first.
+func Parse(markdown string) ([]model.Release, error) {
+	var releases []model.Release
+	var current *model.Release
+	inNotices := false
+
+	scanner := bufio.NewScanner(strings.NewReader(markdown))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "## "):
+			id := strings.TrimSpace(strings.TrimPrefix(line, "## "))
+			if _, err := time.Parse(time.DateOnly, id); err != nil {
+				return nil, fmt.Errorf("line %d: release heading %q is not a YYYY-MM-DD date", lineNo, id)
+			}
+			releases = append(releases, model.Release{ID: id})
+			current = &releases[len(releases)-1]
+			inNotices = false
+		case current == nil:
+			// Preamble.
+		case line == needsAttentionHeading:
+			inNotices = true
+		case strings.HasPrefix(line, "- "):
+			item := strings.TrimSpace(strings.TrimPrefix(line, "- "))
+			if inNotices {
+				current.Notices = append(current.Notices, item)
+			} else {
+				current.Changes = append(current.Changes, item)
+			}
+		case line != "":
+			return nil, fmt.Errorf("line %d: unexpected changelog line %q", lineNo, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read changelog: %w", err)
+	}
+
+	sort.SliceStable(releases, func(i, j int) bool { return releases[i].ID > releases[j].ID })
+	return releases, nil
+}
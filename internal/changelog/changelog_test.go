@@ -0,0 +1,62 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+func TestReleases_EmbeddedChangelogParses(t *testing.T) {
+	releases, err := Releases()
+	require.NoError(t, err)
+	require.NotEmpty(t, releases)
+	for _, r := range releases {
+		assert.NotEmpty(t, r.Changes, "release %s has no changes", r.ID)
+	}
+}
+
+func TestParse(t *testing.T) {
+	md := `# Changelog
+
+Preamble text is ignored.
+
+## 2026-09-01
+
+- Older change.
+
+## 2026-10-16
+
+- New thing.
+- Another thing.
+
+### Needs attention
+
+- A signal now defaults to off.
+`
+	releases, err := Parse(md)
+	require.NoError(t, err)
+
+	assert.Equal(t, []model.Release{
+		{ID: "2026-10-16", Changes: []string{"New thing.", "Another thing."}, Notices: []string{"A signal now defaults to off."}},
+		{ID: "2026-09-01", Changes: []string{"Older change."}},
+	}, releases)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+	}{
+		{name: "heading is not a date", md: "## v1.2.0\n- change\n"},
+		{name: "stray paragraph in release", md: "## 2026-10-16\nSome prose.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.md)
+			assert.Error(t, err)
+		})
+	}
+}
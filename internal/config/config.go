@@ -1,56 +1,174 @@
-// Package config loads application configuration from environment variables.
+// Package config loads application configuration from environment variables
+// and an optional YAML config file.
 package config
 
 import (
 	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
 
-// Config holds the application configuration loaded from environment variables.
+// Config holds the application configuration loaded from environment variables
+// and the optional config file.
 type Config struct {
 	GitHubToken    string
 	GitHubUsername string
 	GitHubTeams    []string
-	PollInterval   time.Duration
-	ListenAddr     string
-	DBPath         string
-	SecretKey      []byte // 32-byte AES-256 key; nil when MYGITPANEL_SECRET_KEY is not set.
+	// GitHubBaseURL and GitHubGraphQLURL point the GitHub adapter at a GitHub
+	// Enterprise Server instance; both are empty for github.com.
+	GitHubBaseURL    string
+	GitHubGraphQLURL string
+	// GitHubFake is a githubfake scenario file. When set, a fake GitHub API
+	// serving it starts on a loopback port and is polled instead of GitHub,
+	// for end-to-end tests.
+	GitHubFake string
+	// BitbucketUsername and BitbucketAppPassword authenticate with Bitbucket
+	// Cloud for repositories added with the bitbucket provider; Bitbucket
+	// polling is off when either is empty.
+	BitbucketUsername    string
+	BitbucketAppPassword string
+	PollInterval         time.Duration
+	ListenAddr           string
+	BasePath             string // URL prefix behind a reverse proxy, e.g. "/mygitpanel"; empty at the root.
+	DBPath               string
+	// DBMemory keeps the database in memory instead of at DBPath, for demos
+	// and integration tests; everything is lost on exit.
+	DBMemory bool
+	// DBFixtures is a SQL file that seeds the memory database after
+	// migrations; empty starts it empty.
+	DBFixtures   string
+	SecretKey    []byte // 32-byte AES-256 key; nil when MYGITPANEL_SECRET_KEY is not set.
+	RefreshToken string // bearer token for the CI refresh endpoint; empty disables it.
+	// ReadOnly opens an existing database file read-only and serves it
+	// without polling or changes, e.g. to browse a snapshot.
+	ReadOnly bool
+	// ReadOnlyOnNewerSchema serves a database migrated by a newer release
+	// read-only instead of refusing to start.
+	ReadOnlyOnNewerSchema bool
+	// ArchiveRetentionDays deletes merged and closed PRs this many days after
+	// they closed; 0 keeps the archive forever.
+	ArchiveRetentionDays int
+	// ClosedPRLookbackDays limits polling to merged and closed PRs updated
+	// in this many days, so a first sync does not page through a repo's whole
+	// history; 0 fetches them all. Open PRs are always fetched.
+	ClosedPRLookbackDays int
+	// RemovedRepoRetentionDays keeps removed repositories and their history
+	// restorable for this many days before they are purged.
+	RemovedRepoRetentionDays int
+	// TeamStats enables the reviewer leaderboard page and API. Off by
+	// default because ranking colleagues is not welcome on every team.
+	TeamStats bool
+	// RelevantPRsOnly stores only the PRs that involve the user -- authored,
+	// review requested, assigned, or mentioning them -- in repositories
+	// without a PR scope of their own, to keep huge monorepos manageable.
+	RelevantPRsOnly bool
+	// MCPWrites lets AI assistants post comments through the MCP endpoint.
+	// Off by default, and even when on, each comment must be confirmed.
+	MCPWrites bool
+	// TLSCertFile and TLSKeyFile serve HTTPS with a PEM certificate and key
+	// from disk. TLSAutocertHost instead obtains a certificate for that
+	// hostname from Let's Encrypt, caching it in TLSAutocertDir. All are
+	// empty for plain HTTP.
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSAutocertHost string
+	TLSAutocertDir  string
+	// TLSClientCAFile is a PEM bundle of CAs whose client certificates are
+	// required for /api/ requests; empty accepts API requests without one.
+	TLSClientCAFile string
+	// HookCommands are the programs shell script hooks may run; empty
+	// allows Starlark hooks only.
+	HookCommands []string
+	// ConfigFile is the path of the config file the settings were read from;
+	// empty when only env vars are used.
+	ConfigFile string
 }
 
 // Load reads configuration from environment variables and returns a validated Config.
 // Required variables: MYGITPANEL_GITHUB_USERNAME.
 // Optional variables: MYGITPANEL_GITHUB_TOKEN (warns when absent; polling disabled until set),
 // MYGITPANEL_SECRET_KEY (warns when absent; credential storage disabled).
+// MYGITPANEL_REFRESH_TOKEN (enables the CI repo refresh endpoint).
+// Each secret may instead be supplied as a file path via the matching _FILE
+// variable, for Docker and Kubernetes mounted secrets.
 // Optional variables with defaults: MYGITPANEL_POLL_INTERVAL (5m),
 // MYGITPANEL_LISTEN_ADDR (127.0.0.1:8080), MYGITPANEL_DB_PATH (mygitpanel.db).
+// MYGITPANEL_BASE_PATH serves the app under a URL prefix behind a reverse proxy.
+// Optional GitHub Enterprise Server variables: MYGITPANEL_GITHUB_BASE_URL and
+// MYGITPANEL_GITHUB_GRAPHQL_URL (derived from the base URL when unset).
+// MYGITPANEL_GITHUB_FAKE names a githubfake scenario to poll instead of GitHub.
+// MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA (false) opts into read-only mode when
+// the database schema is newer than the binary.
+// MYGITPANEL_ARCHIVE_RETENTION_DAYS (0, keep forever) prunes merged and closed
+// PRs from the archive that many days after they closed.
+// MYGITPANEL_CLOSED_PR_LOOKBACK_DAYS (90, 0 for all) limits polling to merged
+// and closed PRs updated that recently.
+// MYGITPANEL_TEAM_STATS (false) opts into the reviewer leaderboard.
+// MYGITPANEL_TLS_CERT_FILE and MYGITPANEL_TLS_KEY_FILE, or
+// MYGITPANEL_TLS_AUTOCERT_HOST with MYGITPANEL_TLS_AUTOCERT_DIR (autocert next
+// to the database), serve HTTPS; MYGITPANEL_TLS_CLIENT_CA_FILE additionally
+// requires client certificates for the API.
+// MYGITPANEL_CONFIG_FILE names an optional YAML config file; see LoadFile.
 func Load() (*Config, error) {
-	var cfg Config
+	return LoadFile(os.Getenv("MYGITPANEL_CONFIG_FILE"))
+}
+
+// LoadFile is Load with the config file at path, which overrides
+// MYGITPANEL_CONFIG_FILE. An empty path reads env vars only. Settings in the
+// file override the defaults, and env vars override the file. Secrets are
+// never read from the file.
+func LoadFile(path string) (*Config, error) {
+	cfg := Config{ConfigFile: path}
+
+	var file fileSettings
+	if path != "" {
+		var err error
+		if file, err = readConfigFile(path); err != nil {
+			return nil, err
+		}
+	}
 
 	// MYGITPANEL_GITHUB_TOKEN is optional — app starts without it but polling is
 	// disabled until credentials are configured via the GUI.
-	token, tokenSet := os.LookupEnv("MYGITPANEL_GITHUB_TOKEN")
-	if !tokenSet || token == "" {
+	token, err := lookupSecret("MYGITPANEL_GITHUB_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
 		slog.Warn("MYGITPANEL_GITHUB_TOKEN not set — polling disabled until credentials configured via GUI")
 		cfg.GitHubToken = ""
 	} else {
 		cfg.GitHubToken = token
 	}
 
-	username, ok := os.LookupEnv("MYGITPANEL_GITHUB_USERNAME")
-	if !ok || username == "" {
+	if file.GitHubUsername != nil {
+		cfg.GitHubUsername = *file.GitHubUsername
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_GITHUB_USERNAME"); ok && v != "" {
+		cfg.GitHubUsername = v
+	}
+	if cfg.GitHubUsername == "" {
+		if path != "" {
+			return nil, fmt.Errorf("MYGITPANEL_GITHUB_USERNAME is required but not set (or set github_username in %s)", path)
+		}
 		return nil, fmt.Errorf("MYGITPANEL_GITHUB_USERNAME is required but not set")
 	}
-	cfg.GitHubUsername = username
 
 	// aesKeyHexLen is the required length of the hex-encoded 32-byte AES-256 key.
 	const aesKeyHexLen = 64
 
 	// MYGITPANEL_SECRET_KEY is optional — credential storage is disabled when absent.
-	if keyHex, ok := os.LookupEnv("MYGITPANEL_SECRET_KEY"); ok && keyHex != "" {
+	keyHex, err := lookupSecret("MYGITPANEL_SECRET_KEY")
+	if err != nil {
+		return nil, err
+	}
+	if keyHex != "" {
 		if len(keyHex) != aesKeyHexLen {
 			return nil, fmt.Errorf("MYGITPANEL_SECRET_KEY must be a %d-character hex string (32 bytes)", aesKeyHexLen)
 		}
@@ -64,7 +182,16 @@ func Load() (*Config, error) {
 		cfg.SecretKey = nil
 	}
 
+	// MYGITPANEL_REFRESH_TOKEN is optional — the CI refresh endpoint is disabled when absent.
+	cfg.RefreshToken, err = lookupSecret("MYGITPANEL_REFRESH_TOKEN")
+	if err != nil {
+		return nil, err
+	}
+
 	cfg.PollInterval = 5 * time.Minute
+	if file.PollInterval != nil {
+		cfg.PollInterval = *file.PollInterval
+	}
 	if v, ok := os.LookupEnv("MYGITPANEL_POLL_INTERVAL"); ok {
 		parsed, err := time.ParseDuration(v)
 		if err != nil {
@@ -74,28 +201,335 @@ func Load() (*Config, error) {
 	}
 
 	cfg.ListenAddr = "127.0.0.1:8080"
+	if file.ListenAddr != nil {
+		cfg.ListenAddr = *file.ListenAddr
+	}
 	if v, ok := os.LookupEnv("MYGITPANEL_LISTEN_ADDR"); ok {
 		cfg.ListenAddr = v
 	}
 
+	if file.BasePath != nil {
+		cfg.BasePath = *file.BasePath
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_BASE_PATH"); ok {
+		cfg.BasePath = v
+	}
+	if cfg.BasePath, err = NormalizeBasePath(cfg.BasePath); err != nil {
+		return nil, fmt.Errorf("MYGITPANEL_BASE_PATH: %w", err)
+	}
+
 	cfg.DBPath = "mygitpanel.db"
+	if file.DBPath != nil {
+		cfg.DBPath = *file.DBPath
+	}
 	if v, ok := os.LookupEnv("MYGITPANEL_DB_PATH"); ok {
 		cfg.DBPath = v
 	}
+	if err := cfg.loadDBBackend(file); err != nil {
+		return nil, err
+	}
 
-	var githubTeams []string
-	if v, ok := os.LookupEnv("MYGITPANEL_GITHUB_TEAMS"); ok && v != "" {
-		for _, slug := range strings.Split(v, ",") {
-			slug = strings.TrimSpace(slug)
-			if slug != "" {
-				githubTeams = append(githubTeams, slug)
-			}
+	if file.GitHubBaseURL != nil {
+		cfg.GitHubBaseURL = *file.GitHubBaseURL
+	}
+	if v := strings.TrimSpace(os.Getenv("MYGITPANEL_GITHUB_BASE_URL")); v != "" {
+		cfg.GitHubBaseURL = v
+	}
+	if file.GitHubGraphQLURL != nil {
+		cfg.GitHubGraphQLURL = *file.GitHubGraphQLURL
+	}
+	if v := strings.TrimSpace(os.Getenv("MYGITPANEL_GITHUB_GRAPHQL_URL")); v != "" {
+		cfg.GitHubGraphQLURL = v
+	}
+	if err := ValidateGitHubURLs(cfg.GitHubBaseURL, cfg.GitHubGraphQLURL); err != nil {
+		return nil, err
+	}
+
+	if file.GitHubFake != nil {
+		cfg.GitHubFake = *file.GitHubFake
+	}
+	if v := strings.TrimSpace(os.Getenv("MYGITPANEL_GITHUB_FAKE")); v != "" {
+		cfg.GitHubFake = v
+	}
+	if cfg.GitHubFake != "" && cfg.GitHubBaseURL != "" {
+		return nil, fmt.Errorf("MYGITPANEL_GITHUB_FAKE cannot be combined with MYGITPANEL_GITHUB_BASE_URL")
+	}
+
+	if file.BitbucketUsername != nil {
+		cfg.BitbucketUsername = *file.BitbucketUsername
+	}
+	if v := strings.TrimSpace(os.Getenv("MYGITPANEL_BITBUCKET_USERNAME")); v != "" {
+		cfg.BitbucketUsername = v
+	}
+	if cfg.BitbucketAppPassword, err = lookupSecret("MYGITPANEL_BITBUCKET_APP_PASSWORD"); err != nil {
+		return nil, err
+	}
+
+	if file.ReadOnly != nil {
+		cfg.ReadOnly = *file.ReadOnly
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_READ_ONLY"); ok && v != "" {
+		readOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("MYGITPANEL_READ_ONLY must be true or false, got %q", v)
+		}
+		cfg.ReadOnly = readOnly
+	}
+
+	if file.ReadOnlyOnNewerSchema != nil {
+		cfg.ReadOnlyOnNewerSchema = *file.ReadOnlyOnNewerSchema
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA"); ok && v != "" {
+		readOnly, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA must be true or false, got %q", v)
 		}
+		cfg.ReadOnlyOnNewerSchema = readOnly
+	}
+
+	if file.ArchiveRetentionDays != nil {
+		cfg.ArchiveRetentionDays = *file.ArchiveRetentionDays
 	}
-	if githubTeams == nil {
-		githubTeams = []string{}
+	if v, ok := os.LookupEnv("MYGITPANEL_ARCHIVE_RETENTION_DAYS"); ok && v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("MYGITPANEL_ARCHIVE_RETENTION_DAYS must be a whole number of days, 0 to keep forever, got %q", v)
+		}
+		cfg.ArchiveRetentionDays = days
 	}
-	cfg.GitHubTeams = githubTeams
+
+	cfg.ClosedPRLookbackDays = 90
+	if file.ClosedPRLookbackDays != nil {
+		cfg.ClosedPRLookbackDays = *file.ClosedPRLookbackDays
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_CLOSED_PR_LOOKBACK_DAYS"); ok && v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return nil, fmt.Errorf("MYGITPANEL_CLOSED_PR_LOOKBACK_DAYS must be a whole number of days, 0 to fetch all, got %q", v)
+		}
+		cfg.ClosedPRLookbackDays = days
+	}
+
+	cfg.RemovedRepoRetentionDays = 7
+	if file.RemovedRepoRetentionDays != nil {
+		cfg.RemovedRepoRetentionDays = *file.RemovedRepoRetentionDays
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_REMOVED_REPO_RETENTION_DAYS"); ok && v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 1 {
+			return nil, fmt.Errorf("MYGITPANEL_REMOVED_REPO_RETENTION_DAYS must be a whole number of days, at least 1, got %q", v)
+		}
+		cfg.RemovedRepoRetentionDays = days
+	}
+
+	if file.TeamStats != nil {
+		cfg.TeamStats = *file.TeamStats
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_TEAM_STATS"); ok && v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("MYGITPANEL_TEAM_STATS must be true or false, got %q", v)
+		}
+		cfg.TeamStats = enabled
+	}
+
+	if file.RelevantPRsOnly != nil {
+		cfg.RelevantPRsOnly = *file.RelevantPRsOnly
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_RELEVANT_PRS_ONLY"); ok && v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("MYGITPANEL_RELEVANT_PRS_ONLY must be true or false, got %q", v)
+		}
+		cfg.RelevantPRsOnly = enabled
+	}
+
+	if file.MCPWrites != nil {
+		cfg.MCPWrites = *file.MCPWrites
+	}
+	if v, ok := os.LookupEnv("MYGITPANEL_MCP_WRITES"); ok && v != "" {
+		enabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("MYGITPANEL_MCP_WRITES must be true or false, got %q", v)
+		}
+		cfg.MCPWrites = enabled
+	}
+
+	if err := cfg.loadTLS(file); err != nil {
+		return nil, err
+	}
+
+	cfg.GitHubTeams = listSetting(file.GitHubTeams, "MYGITPANEL_GITHUB_TEAMS")
+	cfg.HookCommands = listSetting(file.HookCommands, "MYGITPANEL_HOOK_COMMANDS")
 
 	return &cfg, nil
 }
+
+// listSetting returns the comma-separated items of envVar when it is set,
+// otherwise fromFile; never nil.
+func listSetting(fromFile []string, envVar string) []string {
+	items := fromFile
+	if v, ok := os.LookupEnv(envVar); ok && v != "" {
+		items = nil
+		for _, item := range strings.Split(v, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	if items == nil {
+		items = []string{}
+	}
+	return items
+}
+
+// reservedPathPrefixes are the top-level paths the app routes itself; a base
+// path starting with one would be ambiguous.
+var reservedPathPrefixes = []string{"/app", "/api", "/static", "/embed"}
+
+// NormalizeBasePath turns a base path such as "mygitpanel/" into the form
+// used for routing, "/mygitpanel". Empty and "/" mean the root and return "".
+func NormalizeBasePath(p string) (string, error) {
+	p = strings.Trim(strings.TrimSpace(p), "/")
+	if p == "" {
+		return "", nil
+	}
+	p = "/" + p
+	if strings.ContainsAny(p, "?#%\\ ") || strings.Contains(p, "//") {
+		return "", fmt.Errorf("must be a plain URL path such as /mygitpanel, got %q", p)
+	}
+	for _, segment := range strings.Split(p[1:], "/") {
+		if segment == "." || segment == ".." {
+			return "", fmt.Errorf("must not contain . or .. segments, got %q", p)
+		}
+	}
+	for _, reserved := range reservedPathPrefixes {
+		if p == reserved || strings.HasPrefix(p, reserved+"/") {
+			return "", fmt.Errorf("must not start with %s, which the app routes itself", reserved)
+		}
+	}
+	return p, nil
+}
+
+// loadTLS reads the TLS settings from file and env vars and checks that they
+// describe exactly one way of getting a certificate.
+func (cfg *Config) loadTLS(file fileSettings) error {
+	settings := []struct {
+		dst    *string
+		file   *string
+		envVar string
+	}{
+		{&cfg.TLSCertFile, file.TLSCertFile, "MYGITPANEL_TLS_CERT_FILE"},
+		{&cfg.TLSKeyFile, file.TLSKeyFile, "MYGITPANEL_TLS_KEY_FILE"},
+		{&cfg.TLSAutocertHost, file.TLSAutocertHost, "MYGITPANEL_TLS_AUTOCERT_HOST"},
+		{&cfg.TLSAutocertDir, file.TLSAutocertDir, "MYGITPANEL_TLS_AUTOCERT_DIR"},
+		{&cfg.TLSClientCAFile, file.TLSClientCAFile, "MYGITPANEL_TLS_CLIENT_CA_FILE"},
+	}
+	for _, s := range settings {
+		if s.file != nil {
+			*s.dst = *s.file
+		}
+		if v := strings.TrimSpace(os.Getenv(s.envVar)); v != "" {
+			*s.dst = v
+		}
+	}
+
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return fmt.Errorf("MYGITPANEL_TLS_CERT_FILE and MYGITPANEL_TLS_KEY_FILE must be set together")
+	}
+	if cfg.TLSCertFile != "" && cfg.TLSAutocertHost != "" {
+		return fmt.Errorf("MYGITPANEL_TLS_AUTOCERT_HOST cannot be combined with MYGITPANEL_TLS_CERT_FILE; use one or the other")
+	}
+	if cfg.TLSClientCAFile != "" && !cfg.TLSEnabled() {
+		return fmt.Errorf("MYGITPANEL_TLS_CLIENT_CA_FILE requires TLS; set MYGITPANEL_TLS_CERT_FILE or MYGITPANEL_TLS_AUTOCERT_HOST")
+	}
+	if cfg.TLSAutocertHost != "" && cfg.TLSAutocertDir == "" {
+		cfg.TLSAutocertDir = filepath.Join(filepath.Dir(cfg.DBPath), "autocert")
+	}
+	return nil
+}
+
+// loadDBBackend reads MYGITPANEL_DB and MYGITPANEL_DB_FIXTURES. Fixtures
+// only seed a memory database, so a real one is never written by accident.
+func (cfg *Config) loadDBBackend(file fileSettings) error {
+	backend := "sqlite"
+	if file.DB != nil {
+		backend = *file.DB
+	}
+	if v := strings.TrimSpace(os.Getenv("MYGITPANEL_DB")); v != "" {
+		backend = v
+	}
+	switch backend {
+	case "sqlite":
+	case "memory":
+		cfg.DBMemory = true
+	default:
+		return fmt.Errorf("MYGITPANEL_DB must be sqlite or memory, got %q", backend)
+	}
+
+	if file.DBFixtures != nil {
+		cfg.DBFixtures = *file.DBFixtures
+	}
+	if v := strings.TrimSpace(os.Getenv("MYGITPANEL_DB_FIXTURES")); v != "" {
+		cfg.DBFixtures = v
+	}
+	if cfg.DBFixtures != "" && !cfg.DBMemory {
+		return fmt.Errorf("MYGITPANEL_DB_FIXTURES only seeds an in-memory database; set MYGITPANEL_DB=memory")
+	}
+	return nil
+}
+
+// TLSEnabled reports whether the server listens for HTTPS.
+func (cfg *Config) TLSEnabled() bool {
+	return cfg.TLSCertFile != "" || cfg.TLSAutocertHost != ""
+}
+
+// ValidateGitHubURLs checks GitHub Enterprise endpoint overrides. Both may be
+// empty (github.com). Each non-empty URL must be absolute http or https, and
+// a GraphQL URL is only meaningful together with a base URL.
+func ValidateGitHubURLs(baseURL, graphqlURL string) error {
+	if baseURL == "" && graphqlURL != "" {
+		return fmt.Errorf("MYGITPANEL_GITHUB_GRAPHQL_URL requires MYGITPANEL_GITHUB_BASE_URL")
+	}
+	for name, raw := range map[string]string{
+		"MYGITPANEL_GITHUB_BASE_URL":    baseURL,
+		"MYGITPANEL_GITHUB_GRAPHQL_URL": graphqlURL,
+	} {
+		if raw == "" {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid URL: %w", name, err)
+		}
+		if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%s must be an absolute http or https URL, got %q", name, raw)
+		}
+	}
+	return nil
+}
+
+// lookupSecret returns the value of the env var name, or the contents of the
+// file named by name+"_FILE" when only that variant is set. Trailing
+// whitespace is trimmed from file contents since secret files usually end in
+// a newline. Setting both variants is an error so it is never ambiguous which
+// value is in effect. Returns "" when neither is set.
+func lookupSecret(name string) (string, error) {
+	fileVar := name + "_FILE"
+	value, valueSet := os.LookupEnv(name)
+	path, pathSet := os.LookupEnv(fileVar)
+
+	if valueSet && value != "" && pathSet && path != "" {
+		return "", fmt.Errorf("%s and %s are both set; use only one", name, fileVar)
+	}
+	if !pathSet || path == "" {
+		return value, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s: read secret file: %w", fileVar, err)
+	}
+	return strings.TrimRight(string(data), " \t\r\n"), nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -16,8 +17,36 @@ var allConfigKeys = []string{
 	"MYGITPANEL_GITHUB_TEAMS",
 	"MYGITPANEL_POLL_INTERVAL",
 	"MYGITPANEL_LISTEN_ADDR",
+	"MYGITPANEL_BASE_PATH",
 	"MYGITPANEL_DB_PATH",
+	"MYGITPANEL_DB",
+	"MYGITPANEL_DB_FIXTURES",
 	"MYGITPANEL_SECRET_KEY",
+	"MYGITPANEL_GITHUB_TOKEN_FILE",
+	"MYGITPANEL_SECRET_KEY_FILE",
+	"MYGITPANEL_REFRESH_TOKEN",
+	"MYGITPANEL_REFRESH_TOKEN_FILE",
+	"MYGITPANEL_GITHUB_BASE_URL",
+	"MYGITPANEL_GITHUB_GRAPHQL_URL",
+	"MYGITPANEL_GITHUB_FAKE",
+	"MYGITPANEL_BITBUCKET_USERNAME",
+	"MYGITPANEL_BITBUCKET_APP_PASSWORD",
+	"MYGITPANEL_BITBUCKET_APP_PASSWORD_FILE",
+	"MYGITPANEL_READ_ONLY",
+	"MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA",
+	"MYGITPANEL_ARCHIVE_RETENTION_DAYS",
+	"MYGITPANEL_CLOSED_PR_LOOKBACK_DAYS",
+	"MYGITPANEL_REMOVED_REPO_RETENTION_DAYS",
+	"MYGITPANEL_TEAM_STATS",
+	"MYGITPANEL_RELEVANT_PRS_ONLY",
+	"MYGITPANEL_MCP_WRITES",
+	"MYGITPANEL_TLS_CERT_FILE",
+	"MYGITPANEL_TLS_KEY_FILE",
+	"MYGITPANEL_TLS_AUTOCERT_HOST",
+	"MYGITPANEL_TLS_AUTOCERT_DIR",
+	"MYGITPANEL_TLS_CLIENT_CA_FILE",
+	"MYGITPANEL_HOOK_COMMANDS",
+	"MYGITPANEL_CONFIG_FILE",
 }
 
 // isolateConfigEnv saves and unsets all MYGITPANEL_ env vars so tests don't
@@ -140,6 +169,20 @@ func TestLoad_GitHubTeams_Empty(t *testing.T) {
 	assert.Equal(t, []string{}, cfg.GitHubTeams)
 }
 
+func TestLoad_HookCommands(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, cfg.HookCommands)
+
+	t.Setenv("MYGITPANEL_HOOK_COMMANDS", "/usr/local/bin/label-pr, jira-note")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/usr/local/bin/label-pr", "jira-note"}, cfg.HookCommands)
+}
+
 func TestLoad_SecretKey_Absent(t *testing.T) {
 	isolateConfigEnv(t)
 	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
@@ -186,3 +229,341 @@ func TestLoad_SecretKey_NotHex(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "MYGITPANEL_SECRET_KEY")
 }
+
+// writeSecretFile writes content to a temp file and returns its path.
+func writeSecretFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_SecretsFromFiles(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+	t.Setenv("MYGITPANEL_GITHUB_TOKEN_FILE", writeSecretFile(t, "ghp_fromfile\n"))
+	t.Setenv("MYGITPANEL_SECRET_KEY_FILE", writeSecretFile(t, "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20\n"))
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_fromfile", cfg.GitHubToken, "trailing newline should be trimmed")
+	assert.Len(t, cfg.SecretKey, 32)
+}
+
+func TestLoad_SecretFile_Missing(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+	t.Setenv("MYGITPANEL_GITHUB_TOKEN_FILE", filepath.Join(t.TempDir(), "does-not-exist"))
+
+	cfg, err := Load()
+
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_GITHUB_TOKEN_FILE")
+}
+
+func TestLoad_SecretFile_ConflictsWithEnv(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+	t.Setenv("MYGITPANEL_SECRET_KEY", "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20")
+	t.Setenv("MYGITPANEL_SECRET_KEY_FILE", writeSecretFile(t, "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"))
+
+	cfg, err := Load()
+
+	assert.Nil(t, cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both set")
+}
+
+func TestLoad_RefreshToken(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+	t.Setenv("MYGITPANEL_REFRESH_TOKEN_FILE", writeSecretFile(t, "ci-secret\n"))
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "ci-secret", cfg.RefreshToken)
+}
+
+func TestLoad_GitHubEnterpriseURLs(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseURL     string
+		graphqlURL  string
+		wantErr     string
+		wantBaseURL string
+	}{
+		{name: "github.com by default"},
+		{name: "base only", baseURL: "https://ghe.example.com", wantBaseURL: "https://ghe.example.com"},
+		{name: "base and graphql", baseURL: "https://ghe.example.com/api/v3/", graphqlURL: "https://ghe.example.com/api/graphql", wantBaseURL: "https://ghe.example.com/api/v3/"},
+		{name: "graphql without base", graphqlURL: "https://ghe.example.com/api/graphql", wantErr: "requires MYGITPANEL_GITHUB_BASE_URL"},
+		{name: "relative base", baseURL: "ghe.example.com", wantErr: "MYGITPANEL_GITHUB_BASE_URL must be an absolute"},
+		{name: "unsupported scheme", baseURL: "ftp://ghe.example.com", wantErr: "MYGITPANEL_GITHUB_BASE_URL must be an absolute"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfigEnv(t)
+			t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+			if tt.baseURL != "" {
+				t.Setenv("MYGITPANEL_GITHUB_BASE_URL", tt.baseURL)
+			}
+			if tt.graphqlURL != "" {
+				t.Setenv("MYGITPANEL_GITHUB_GRAPHQL_URL", tt.graphqlURL)
+			}
+
+			cfg, err := Load()
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBaseURL, cfg.GitHubBaseURL)
+			assert.Equal(t, tt.graphqlURL, cfg.GitHubGraphQLURL)
+		})
+	}
+}
+
+func TestLoad_ReadOnlyOnNewerSchema(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.ReadOnlyOnNewerSchema, "off by default")
+
+	t.Setenv("MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.ReadOnlyOnNewerSchema)
+
+	t.Setenv("MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA", "sometimes")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_READ_ONLY_ON_NEWER_SCHEMA")
+}
+
+func TestLoad_DBMemory(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.DBMemory, "sqlite on disk by default")
+
+	t.Setenv("MYGITPANEL_DB_FIXTURES", "demo.sql")
+	_, err = Load()
+	require.Error(t, err, "fixtures never seed a database on disk")
+	assert.Contains(t, err.Error(), "MYGITPANEL_DB=memory")
+
+	t.Setenv("MYGITPANEL_DB", "memory")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.DBMemory)
+	assert.Equal(t, "demo.sql", cfg.DBFixtures)
+
+	t.Setenv("MYGITPANEL_DB", "postgres")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_DB")
+}
+
+func TestLoad_GitHubFake(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+	t.Setenv("MYGITPANEL_GITHUB_FAKE", "scenario.yaml")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, "scenario.yaml", cfg.GitHubFake)
+
+	t.Setenv("MYGITPANEL_GITHUB_BASE_URL", "https://ghe.example.com/api/v3/")
+	_, err = Load()
+	require.Error(t, err, "the fake replaces the GitHub endpoint")
+	assert.Contains(t, err.Error(), "MYGITPANEL_GITHUB_BASE_URL")
+}
+
+func TestLoad_Bitbucket(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.BitbucketUsername)
+	assert.Empty(t, cfg.BitbucketAppPassword)
+
+	secret := filepath.Join(t.TempDir(), "bitbucket")
+	require.NoError(t, os.WriteFile(secret, []byte("app-password\n"), 0o600))
+	t.Setenv("MYGITPANEL_BITBUCKET_USERNAME", "alice")
+	t.Setenv("MYGITPANEL_BITBUCKET_APP_PASSWORD_FILE", secret)
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, "alice", cfg.BitbucketUsername)
+	assert.Equal(t, "app-password", cfg.BitbucketAppPassword)
+}
+
+func TestLoad_MCPWrites(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.MCPWrites, "off by default")
+
+	t.Setenv("MYGITPANEL_MCP_WRITES", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.MCPWrites)
+
+	t.Setenv("MYGITPANEL_MCP_WRITES", "ask")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_MCP_WRITES")
+}
+
+func TestLoad_ReadOnly(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.ReadOnly, "off by default")
+
+	t.Setenv("MYGITPANEL_READ_ONLY", "true")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.ReadOnly)
+
+	t.Setenv("MYGITPANEL_READ_ONLY", "sometimes")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_READ_ONLY")
+}
+
+func TestLoad_ArchiveRetentionDays(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.ArchiveRetentionDays, "archive kept forever by default")
+
+	t.Setenv("MYGITPANEL_ARCHIVE_RETENTION_DAYS", "365")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 365, cfg.ArchiveRetentionDays)
+
+	t.Setenv("MYGITPANEL_ARCHIVE_RETENTION_DAYS", "-1")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_ARCHIVE_RETENTION_DAYS")
+}
+
+func TestLoad_ClosedPRLookbackDays(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 90, cfg.ClosedPRLookbackDays)
+
+	t.Setenv("MYGITPANEL_CLOSED_PR_LOOKBACK_DAYS", "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Zero(t, cfg.ClosedPRLookbackDays, "0 fetches the full history")
+
+	t.Setenv("MYGITPANEL_CLOSED_PR_LOOKBACK_DAYS", "-1")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_CLOSED_PR_LOOKBACK_DAYS")
+}
+
+func TestLoad_RemovedRepoRetentionDays(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, 7, cfg.RemovedRepoRetentionDays)
+
+	t.Setenv("MYGITPANEL_REMOVED_REPO_RETENTION_DAYS", "30")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, 30, cfg.RemovedRepoRetentionDays)
+
+	t.Setenv("MYGITPANEL_REMOVED_REPO_RETENTION_DAYS", "0")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "MYGITPANEL_REMOVED_REPO_RETENTION_DAYS")
+}
+
+func TestLoad_TLS(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+	t.Setenv("MYGITPANEL_DB_PATH", "/var/lib/mygitpanel/mygitpanel.db")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.TLSEnabled(), "plain HTTP by default")
+
+	t.Setenv("MYGITPANEL_TLS_CERT_FILE", "/etc/tls/cert.pem")
+	_, err = Load()
+	require.Error(t, err, "a certificate needs its key")
+	assert.Contains(t, err.Error(), "MYGITPANEL_TLS_KEY_FILE")
+
+	t.Setenv("MYGITPANEL_TLS_KEY_FILE", "/etc/tls/key.pem")
+	t.Setenv("MYGITPANEL_TLS_CLIENT_CA_FILE", "/etc/tls/clients.pem")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.TLSEnabled())
+	assert.Equal(t, "/etc/tls/clients.pem", cfg.TLSClientCAFile)
+
+	t.Setenv("MYGITPANEL_TLS_AUTOCERT_HOST", "panel.example.com")
+	_, err = Load()
+	require.Error(t, err, "autocert and certificate files are exclusive")
+	assert.Contains(t, err.Error(), "MYGITPANEL_TLS_AUTOCERT_HOST")
+
+	os.Unsetenv("MYGITPANEL_TLS_CERT_FILE")
+	os.Unsetenv("MYGITPANEL_TLS_KEY_FILE")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.TLSEnabled())
+	assert.Equal(t, "/var/lib/mygitpanel/autocert", cfg.TLSAutocertDir, "cache defaults to next to the database")
+
+	os.Unsetenv("MYGITPANEL_TLS_AUTOCERT_HOST")
+	_, err = Load()
+	require.Error(t, err, "client certificates need TLS")
+	assert.Contains(t, err.Error(), "MYGITPANEL_TLS_CLIENT_CA_FILE")
+}
+
+func TestLoad_BasePath(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.BasePath, "served from the root by default")
+
+	for value, want := range map[string]string{
+		"/mygitpanel":   "/mygitpanel",
+		"mygitpanel/":   "/mygitpanel",
+		" /tools/prs/ ": "/tools/prs",
+		"/":             "",
+	} {
+		t.Setenv("MYGITPANEL_BASE_PATH", value)
+		cfg, err = Load()
+		require.NoError(t, err, value)
+		assert.Equal(t, want, cfg.BasePath, value)
+	}
+
+	for _, value := range []string{"/app", "/api/v1", "/a/../b", "/a?b", "/a//b"} {
+		t.Setenv("MYGITPANEL_BASE_PATH", value)
+		_, err = Load()
+		require.Error(t, err, value)
+		assert.Contains(t, err.Error(), "MYGITPANEL_BASE_PATH")
+	}
+}
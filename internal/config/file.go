@@ -0,0 +1,291 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// secretFileKeys are config file keys that are rejected because secrets
+// belong in env vars or _FILE mounts, not in a file that is often checked in.
+var secretFileKeys = map[string]string{
+	"github_token":  "MYGITPANEL_GITHUB_TOKEN",
+	"secret_key":    "MYGITPANEL_SECRET_KEY",
+	"refresh_token": "MYGITPANEL_REFRESH_TOKEN",
+
+	"bitbucket_app_password": "MYGITPANEL_BITBUCKET_APP_PASSWORD",
+}
+
+// fileSettings holds the values set in a config file. Nil fields were not set.
+type fileSettings struct {
+	GitHubUsername           *string
+	GitHubTeams              []string // nil when unset; empty when set to []
+	GitHubBaseURL            *string
+	GitHubGraphQLURL         *string
+	GitHubFake               *string
+	BitbucketUsername        *string
+	PollInterval             *time.Duration
+	ListenAddr               *string
+	BasePath                 *string
+	DBPath                   *string
+	DB                       *string
+	DBFixtures               *string
+	ReadOnly                 *bool
+	ReadOnlyOnNewerSchema    *bool
+	ArchiveRetentionDays     *int
+	ClosedPRLookbackDays     *int
+	RemovedRepoRetentionDays *int
+	TeamStats                *bool
+	RelevantPRsOnly          *bool
+	MCPWrites                *bool
+	TLSCertFile              *string
+	TLSKeyFile               *string
+	TLSAutocertHost          *string
+	TLSAutocertDir           *string
+	TLSClientCAFile          *string
+	HookCommands             []string // nil when unset; empty when set to []
+}
+
+// readConfigFile parses the YAML config file at path. Keys mirror the env var
+// names without the MYGITPANEL_ prefix, in lower case:
+//
+//	github_username: octocat
+//	github_teams: [platform, infra]
+//	poll_interval: 5m
+//	listen_addr: 127.0.0.1:8080
+//
+// Errors name the file and the offending key.
+func readConfigFile(path string) (fileSettings, error) {
+	var settings fileSettings
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return settings, fmt.Errorf("config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return settings, nil // empty file
+		}
+		return settings, fmt.Errorf("config file %s: %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		return settings, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return settings, fmt.Errorf("config file %s: top level must be a mapping of keys to values", path)
+	}
+
+	seen := make(map[string]bool, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, value := root.Content[i], root.Content[i+1]
+		key := keyNode.Value
+		if seen[key] {
+			return settings, fmt.Errorf("config file %s: line %d: %s is set more than once", path, keyNode.Line, key)
+		}
+		seen[key] = true
+
+		if err := settings.set(key, value); err != nil {
+			return settings, fmt.Errorf("config file %s: line %d: %s: %w", path, keyNode.Line, key, err)
+		}
+	}
+
+	return settings, nil
+}
+
+// set decodes value into the field named by key.
+func (s *fileSettings) set(key string, value *yaml.Node) error {
+	if envVar, ok := secretFileKeys[key]; ok {
+		return fmt.Errorf("secrets are not read from the config file; set %s or %s_FILE instead", envVar, envVar)
+	}
+
+	switch key {
+	case "github_username":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		if v == "" {
+			return errors.New("must not be empty")
+		}
+		s.GitHubUsername = &v
+	case "github_teams":
+		teams, err := decodeList(value, "team slugs")
+		if err != nil {
+			return err
+		}
+		s.GitHubTeams = teams
+	case "hook_commands":
+		commands, err := decodeList(value, "programs")
+		if err != nil {
+			return err
+		}
+		s.HookCommands = commands
+	case "github_base_url", "github_graphql_url":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		v = strings.TrimSpace(v)
+		if v != "" {
+			if u, err := url.Parse(v); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				return fmt.Errorf("must be an absolute http or https URL, got %q", v)
+			}
+		}
+		if key == "github_base_url" {
+			s.GitHubBaseURL = &v
+		} else {
+			s.GitHubGraphQLURL = &v
+		}
+	case "poll_interval":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("must be a positive duration such as 5m, got %q", v)
+		}
+		s.PollInterval = &d
+	case "listen_addr":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		s.ListenAddr = &v
+	case "tls_cert_file", "tls_key_file", "tls_autocert_host", "tls_autocert_dir", "tls_client_ca_file":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		v = strings.TrimSpace(v)
+		switch key {
+		case "tls_cert_file":
+			s.TLSCertFile = &v
+		case "tls_key_file":
+			s.TLSKeyFile = &v
+		case "tls_autocert_host":
+			s.TLSAutocertHost = &v
+		case "tls_autocert_dir":
+			s.TLSAutocertDir = &v
+		default:
+			s.TLSClientCAFile = &v
+		}
+	case "base_path":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		if _, err := NormalizeBasePath(v); err != nil {
+			return err
+		}
+		s.BasePath = &v
+	case "db_path":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		if v == "" {
+			return errors.New("must not be empty")
+		}
+		s.DBPath = &v
+	case "db", "db_fixtures", "github_fake", "bitbucket_username":
+		v, err := decodeString(value)
+		if err != nil {
+			return err
+		}
+		switch key {
+		case "db":
+			s.DB = &v
+		case "db_fixtures":
+			s.DBFixtures = &v
+		case "bitbucket_username":
+			s.BitbucketUsername = &v
+		default:
+			s.GitHubFake = &v
+		}
+	case "read_only", "read_only_on_newer_schema", "team_stats", "relevant_prs_only", "mcp_writes":
+		var v bool
+		if value.Kind != yaml.ScalarNode || value.Decode(&v) != nil {
+			return fmt.Errorf("must be true or false, got %q", value.Value)
+		}
+		switch key {
+		case "read_only":
+			s.ReadOnly = &v
+		case "team_stats":
+			s.TeamStats = &v
+		case "relevant_prs_only":
+			s.RelevantPRsOnly = &v
+		case "mcp_writes":
+			s.MCPWrites = &v
+		default:
+			s.ReadOnlyOnNewerSchema = &v
+		}
+	case "archive_retention_days":
+		var v int
+		if value.Kind != yaml.ScalarNode || value.Decode(&v) != nil || v < 0 {
+			return fmt.Errorf("must be a whole number of days, 0 to keep forever, got %q", value.Value)
+		}
+		s.ArchiveRetentionDays = &v
+	case "closed_pr_lookback_days":
+		var v int
+		if value.Kind != yaml.ScalarNode || value.Decode(&v) != nil || v < 0 {
+			return fmt.Errorf("must be a whole number of days, 0 to fetch all, got %q", value.Value)
+		}
+		s.ClosedPRLookbackDays = &v
+	case "removed_repo_retention_days":
+		var v int
+		if value.Kind != yaml.ScalarNode || value.Decode(&v) != nil || v < 1 {
+			return fmt.Errorf("must be a whole number of days, at least 1, got %q", value.Value)
+		}
+		s.RemovedRepoRetentionDays = &v
+	default:
+		return errors.New("unknown key")
+	}
+	return nil
+}
+
+// decodeString decodes a scalar node as a string.
+func decodeString(value *yaml.Node) (string, error) {
+	if value.Kind != yaml.ScalarNode {
+		return "", errors.New("must be a single value")
+	}
+	return value.Value, nil
+}
+
+// decodeList accepts either a YAML list or a comma-separated string, the
+// latter matching list env vars such as MYGITPANEL_GITHUB_TEAMS. noun names
+// the items in errors.
+func decodeList(value *yaml.Node, noun string) ([]string, error) {
+	var raw []string
+	switch value.Kind {
+	case yaml.ScalarNode:
+		raw = strings.Split(value.Value, ",")
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("must be a list of %s", noun)
+			}
+			raw = append(raw, item.Value)
+		}
+	default:
+		return nil, fmt.Errorf("must be a list of %s", noun)
+	}
+
+	items := []string{}
+	for _, item := range raw {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
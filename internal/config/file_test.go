@@ -0,0 +1,218 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfigFile writes a YAML config file to a temp dir and returns its path.
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mygitpanel.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFile_Settings(t *testing.T) {
+	isolateConfigEnv(t)
+	path := writeConfigFile(t, `
+github_username: fileuser
+github_teams: [platform, " infra "]
+poll_interval: 2m
+listen_addr: 0.0.0.0:9000
+db_path: /data/panel.db
+github_base_url: https://ghe.example.com/api/v3/
+read_only: true
+read_only_on_newer_schema: true
+archive_retention_days: 90
+team_stats: true
+relevant_prs_only: true
+`)
+
+	cfg, err := LoadFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, path, cfg.ConfigFile)
+	assert.Equal(t, "fileuser", cfg.GitHubUsername)
+	assert.Equal(t, []string{"platform", "infra"}, cfg.GitHubTeams)
+	assert.Equal(t, 2*time.Minute, cfg.PollInterval)
+	assert.Equal(t, "0.0.0.0:9000", cfg.ListenAddr)
+	assert.Equal(t, "/data/panel.db", cfg.DBPath)
+	assert.Equal(t, "https://ghe.example.com/api/v3/", cfg.GitHubBaseURL)
+	assert.True(t, cfg.ReadOnly)
+	assert.True(t, cfg.ReadOnlyOnNewerSchema)
+	assert.Equal(t, 90, cfg.ArchiveRetentionDays)
+	assert.True(t, cfg.TeamStats)
+	assert.True(t, cfg.RelevantPRsOnly)
+}
+
+func TestLoadFile_EnvOverridesFile(t *testing.T) {
+	isolateConfigEnv(t)
+	path := writeConfigFile(t, `
+github_username: fileuser
+github_teams: platform,infra
+poll_interval: 2m
+listen_addr: 0.0.0.0:9000
+`)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "envuser")
+	t.Setenv("MYGITPANEL_GITHUB_TEAMS", "backend")
+	t.Setenv("MYGITPANEL_POLL_INTERVAL", "10m")
+
+	cfg, err := LoadFile(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "envuser", cfg.GitHubUsername)
+	assert.Equal(t, []string{"backend"}, cfg.GitHubTeams)
+	assert.Equal(t, 10*time.Minute, cfg.PollInterval)
+	assert.Equal(t, "0.0.0.0:9000", cfg.ListenAddr, "unset env var keeps the file value")
+	assert.Equal(t, "mygitpanel.db", cfg.DBPath, "key absent from both falls back to the default")
+}
+
+func TestLoad_ConfigFileFromEnv(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_CONFIG_FILE", writeConfigFile(t, "github_username: fileuser\n"))
+
+	cfg, err := Load()
+
+	require.NoError(t, err)
+	assert.Equal(t, "fileuser", cfg.GitHubUsername)
+}
+
+func TestLoadFile_EmptyFile(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	cfg, err := LoadFile(writeConfigFile(t, ""))
+
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, cfg.PollInterval)
+}
+
+func TestLoadFile_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr []string
+	}{
+		{name: "unknown key", content: "github_username: u\npoll_intervall: 5m\n", wantErr: []string{"line 2", "poll_intervall", "unknown key"}},
+		{name: "bad duration", content: "github_username: u\npoll_interval: soon\n", wantErr: []string{"poll_interval", `"soon"`}},
+		{name: "negative duration", content: "github_username: u\npoll_interval: -1m\n", wantErr: []string{"poll_interval", "positive"}},
+		{name: "bad bool", content: "github_username: u\nread_only_on_newer_schema: maybe\n", wantErr: []string{"read_only_on_newer_schema", "true or false"}},
+		{name: "bad retention", content: "github_username: u\narchive_retention_days: 1y\n", wantErr: []string{"archive_retention_days", "whole number"}},
+		{name: "teams as mapping", content: "github_username: u\ngithub_teams: {a: b}\n", wantErr: []string{"github_teams", "list of team slugs"}},
+		{name: "relative url", content: "github_username: u\ngithub_base_url: ghe.example.com\n", wantErr: []string{"github_base_url", "absolute"}},
+		{name: "duplicate key", content: "github_username: u\ngithub_username: v\n", wantErr: []string{"github_username", "more than once"}},
+		{name: "secret in file", content: "github_username: u\ngithub_token: ghp_x\n", wantErr: []string{"github_token", "MYGITPANEL_GITHUB_TOKEN_FILE"}},
+		{name: "not a mapping", content: "- github_username\n", wantErr: []string{"mapping"}},
+		{name: "username missing", content: "poll_interval: 5m\n", wantErr: []string{"MYGITPANEL_GITHUB_USERNAME", "github_username"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateConfigEnv(t)
+			path := writeConfigFile(t, tt.content)
+
+			cfg, err := LoadFile(path)
+
+			assert.Nil(t, cfg)
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestLoadFile_Missing(t *testing.T) {
+	isolateConfigEnv(t)
+	t.Setenv("MYGITPANEL_GITHUB_USERNAME", "testuser")
+
+	_, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config file")
+}
+
+func TestDiff(t *testing.T) {
+	base := &Config{GitHubUsername: "u", GitHubTeams: []string{"a"}, PollInterval: time.Minute, ListenAddr: ":8080", DBPath: "x.db"}
+
+	same := *base
+	assert.Equal(t, Change{}, Diff(base, &same))
+
+	teams := *base
+	teams.GitHubTeams = []string{"a", "b"}
+	assert.Equal(t, Change{Reloaded: true}, Diff(base, &teams))
+
+	moved := *base
+	moved.ListenAddr = ":9090"
+	moved.DBPath = "y.db"
+	assert.Equal(t, Change{RestartRequired: []string{"listen_addr", "db_path"}}, Diff(base, &moved))
+}
+
+func TestWatch_AppliesReloadableChanges(t *testing.T) {
+	isolateConfigEnv(t)
+	path := writeConfigFile(t, "github_username: u\ngithub_teams: [a]\n")
+	cfg, err := LoadFile(path)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var applied []*Config
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Watch(ctx, cfg, 10*time.Millisecond, func(next *Config) {
+			mu.Lock()
+			applied = append(applied, next)
+			mu.Unlock()
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	// A broken file is ignored and the current settings stay.
+	require.NoError(t, os.WriteFile(path, []byte("github_username: u\ngithub_teams: [a]\nbogus: 1\n"), 0o600))
+	// A restart-only change alone is not applied.
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("github_username: u\ngithub_teams: [a]\nlisten_addr: 0.0.0.0:1\n"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Empty(t, applied)
+	mu.Unlock()
+
+	require.NoError(t, os.WriteFile(path, []byte("github_username: u\ngithub_teams: [a, b]\npoll_interval: 1m\n"), 0o600))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(applied) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"a", "b"}, applied[0].GitHubTeams)
+	assert.Equal(t, time.Minute, applied[0].PollInterval)
+}
+
+func TestWatch_NoConfigFile(t *testing.T) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Watch(context.Background(), &Config{}, time.Millisecond, func(*Config) { t.Error("apply called") })
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return without a config file")
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+)
+
+// Change describes how a reloaded Config differs from the one in effect.
+type Change struct {
+	// Reloaded is true when a setting that can be applied while running
+	// (poll_interval, github_teams) changed.
+	Reloaded bool
+	// RestartRequired names the keys that changed but only take effect after
+	// a restart, such as listen_addr.
+	RestartRequired []string
+}
+
+// Diff compares next against cfg. Only settings that can come from the config
+// file are compared; secrets are read once at startup.
+func Diff(cfg, next *Config) Change {
+	var c Change
+	c.Reloaded = cfg.PollInterval != next.PollInterval || !slices.Equal(cfg.GitHubTeams, next.GitHubTeams)
+
+	restart := []struct {
+		key     string
+		changed bool
+	}{
+		{"github_username", cfg.GitHubUsername != next.GitHubUsername},
+		{"github_base_url", cfg.GitHubBaseURL != next.GitHubBaseURL},
+		{"github_graphql_url", cfg.GitHubGraphQLURL != next.GitHubGraphQLURL},
+		{"github_fake", cfg.GitHubFake != next.GitHubFake},
+		{"bitbucket_username", cfg.BitbucketUsername != next.BitbucketUsername},
+		{"listen_addr", cfg.ListenAddr != next.ListenAddr},
+		{"base_path", cfg.BasePath != next.BasePath},
+		{"db_path", cfg.DBPath != next.DBPath},
+		{"db", cfg.DBMemory != next.DBMemory},
+		{"db_fixtures", cfg.DBFixtures != next.DBFixtures},
+		{"read_only", cfg.ReadOnly != next.ReadOnly},
+		{"read_only_on_newer_schema", cfg.ReadOnlyOnNewerSchema != next.ReadOnlyOnNewerSchema},
+		{"archive_retention_days", cfg.ArchiveRetentionDays != next.ArchiveRetentionDays},
+		{"closed_pr_lookback_days", cfg.ClosedPRLookbackDays != next.ClosedPRLookbackDays},
+		{"removed_repo_retention_days", cfg.RemovedRepoRetentionDays != next.RemovedRepoRetentionDays},
+		{"team_stats", cfg.TeamStats != next.TeamStats},
+		{"relevant_prs_only", cfg.RelevantPRsOnly != next.RelevantPRsOnly},
+		{"mcp_writes", cfg.MCPWrites != next.MCPWrites},
+		{"tls_cert_file", cfg.TLSCertFile != next.TLSCertFile},
+		{"tls_key_file", cfg.TLSKeyFile != next.TLSKeyFile},
+		{"tls_autocert_host", cfg.TLSAutocertHost != next.TLSAutocertHost},
+		{"tls_autocert_dir", cfg.TLSAutocertDir != next.TLSAutocertDir},
+		{"tls_client_ca_file", cfg.TLSClientCAFile != next.TLSClientCAFile},
+		{"hook_commands", !slices.Equal(cfg.HookCommands, next.HookCommands)},
+	}
+	for _, r := range restart {
+		if r.changed {
+			c.RestartRequired = append(c.RestartRequired, r.key)
+		}
+	}
+	return c
+}
+
+// Watch checks cfg.ConfigFile for changes every interval until ctx is
+// canceled. When the file changes it is reloaded with the same env var
+// overrides; if the reloadable settings differ, apply is called with the new
+// Config. Keys that need a restart are logged rather than applied, and a file
+// that fails to load or validate is logged and leaves the current settings
+// in place. Watch returns immediately when no config file is in use.
+func Watch(ctx context.Context, cfg *Config, interval time.Duration, apply func(*Config)) {
+	if cfg.ConfigFile == "" {
+		return
+	}
+
+	current := cfg
+	stamp := fileStamp(cfg.ConfigFile)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		next := fileStamp(cfg.ConfigFile)
+		if next == stamp {
+			continue
+		}
+		stamp = next
+
+		reloaded, err := LoadFile(cfg.ConfigFile)
+		if err != nil {
+			slog.Error("config file reload failed; keeping current settings", "error", err)
+			continue
+		}
+
+		change := Diff(current, reloaded)
+		if len(change.RestartRequired) > 0 {
+			slog.Warn("config file changes take effect after restart",
+				"path", cfg.ConfigFile, "keys", change.RestartRequired)
+		}
+		if change.Reloaded {
+			slog.Info("config file reloaded", "path", cfg.ConfigFile,
+				"poll_interval", reloaded.PollInterval, "github_teams", reloaded.GitHubTeams)
+			apply(reloaded)
+		}
+
+		// Restart-only settings keep the values the process is running with,
+		// so pending changes are reported on every reload until a restart.
+		merged := *current
+		merged.PollInterval = reloaded.PollInterval
+		merged.GitHubTeams = reloaded.GitHubTeams
+		current = &merged
+	}
+}
+
+// fileStampValue identifies a version of a file by size and modification time.
+type fileStampValue struct {
+	size    int64
+	modTime time.Time
+}
+
+// fileStamp returns the current stamp of path; a missing file has a zero stamp.
+func fileStamp(path string) fileStampValue {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStampValue{}
+	}
+	return fileStampValue{size: info.Size(), modTime: info.ModTime()}
+}
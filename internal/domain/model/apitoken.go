@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// APITokenScope limits what a REST API token may do.
+type APITokenScope string
+
+// APITokenScope values.
+const (
+	APITokenScopeRead  APITokenScope = "read"  // GET requests only
+	APITokenScopeWrite APITokenScope = "write" // all requests
+)
+
+// Valid reports whether s is a known scope.
+func (s APITokenScope) Valid() bool {
+	return s == APITokenScopeRead || s == APITokenScopeWrite
+}
+
+// APIToken is a managed credential for the REST API. Only a hash of the secret
+// is stored; Prefix keeps enough of it to tell tokens apart in the settings list.
+type APIToken struct {
+	ID         int64
+	Name       string
+	Prefix     string
+	Scope      APITokenScope
+	CreatedAt  time.Time
+	LastUsedAt time.Time // zero if never used
+}
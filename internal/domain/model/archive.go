@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// ArchiveFilter narrows the archive of merged and closed pull requests.
+// Zero values match everything.
+type ArchiveFilter struct {
+	RepoFullName string
+	Status       PRStatus  // PRStatusMerged or PRStatusClosed; empty matches both.
+	ClosedAfter  time.Time // inclusive
+	ClosedBefore time.Time // exclusive
+}
+
+// RepoMergeHistory summarizes one repository's merged and closed PRs within
+// an archive filter.
+type RepoMergeHistory struct {
+	RepoFullName string
+	Merged       int
+	Closed       int // closed without merging
+	// MedianTimeToMerge is the median open-to-merge duration of the merged
+	// PRs; zero when none merged.
+	MedianTimeToMerge time.Duration
+}
@@ -3,30 +3,158 @@ package model
 // EffectiveThresholds is the merged result of global settings and per-repo overrides.
 // Per-repo overrides take precedence over global defaults for non-nil fields.
 type EffectiveThresholds struct {
-	ReviewCountThreshold int
-	AgeUrgencyDays       int
-	StaleReviewEnabled   bool
-	CIFailureEnabled     bool
+	ReviewCountThreshold   int
+	AgeUrgencyDays         int
+	StaleReviewEnabled     bool
+	CIFailureEnabled       bool
+	FirstReviewSLAHours    int  // 0 means no SLA; per-repo only
+	FirstTimerBoostEnabled bool // global only; enables NewcomerWaiting
+	ChecklistEnabled       bool // global only; enables ChecklistIncomplete
+	RequestAgeDays         int  // global only; 0 disables RequestAging
 }
 
 // AttentionSignals is a transient model computed at query time from PR data and
 // thresholds. It is never persisted to the database.
 type AttentionSignals struct {
-	NeedsMoreReviews bool // fewer than threshold approvals
-	IsAgeUrgent      bool // open longer than threshold days
-	HasStaleReview   bool // user's last review is on an outdated commit
-	HasCIFailure     bool // own PR with failing CI
+	NeedsMoreReviews    bool // fewer than threshold approvals
+	IsAgeUrgent         bool // open longer than threshold days
+	HasStaleReview      bool // user's last review is on an outdated commit
+	HasCIFailure        bool // own PR with failing CI
+	Mentioned           bool // user @mentioned and has not replied since
+	SLABreached         bool // no first review within the repo's SLA
+	NewcomerWaiting     bool // first-time contributor short of approvals; needs FirstTimerBoostEnabled
+	ChecklistIncomplete bool // ready for review with unchecked task list items; needs ChecklistEnabled
+	RequestAging        bool // user's review requested longer than threshold days ago
+
+	// ApprovalDismissed refines HasStaleReview: the user approved an older
+	// commit and the base branch dismisses stale approvals, so the approval
+	// no longer counts. It is not a separate signal for HasAny or Severity.
+	ApprovalDismissed bool
+
+	// FirstTimeContributor marks an author with no merged PR in the repo yet.
+	// It drives a card badge and is not a signal for HasAny or Severity;
+	// NewcomerWaiting is its boosted counterpart.
+	FirstTimeContributor bool
+
+	// Rules are the user-defined attention rules the PR matches, in rule
+	// order; nil when none match. Each adds its severity to Severity.
+	Rules []RuleMatch
+}
+
+// AttentionSignal names a single attention signal, e.g. for routing webhooks.
+type AttentionSignal string
+
+// AttentionSignal values, one per field of AttentionSignals.
+const (
+	SignalNeedsMoreReviews    AttentionSignal = "needs_more_reviews"
+	SignalAgeUrgent           AttentionSignal = "age_urgent"
+	SignalStaleReview         AttentionSignal = "stale_review"
+	SignalCIFailure           AttentionSignal = "ci_failure"
+	SignalApprovalDismissed   AttentionSignal = "approval_dismissed"
+	SignalMentioned           AttentionSignal = "mentioned"
+	SignalSLABreached         AttentionSignal = "sla_breached"
+	SignalNewcomerWaiting     AttentionSignal = "newcomer_waiting"
+	SignalChecklistIncomplete AttentionSignal = "checklist_incomplete"
+	SignalRequestAging        AttentionSignal = "request_aging"
+)
+
+// AllAttentionSignals lists every AttentionSignal in display order.
+var AllAttentionSignals = []AttentionSignal{
+	SignalNeedsMoreReviews,
+	SignalAgeUrgent,
+	SignalStaleReview,
+	SignalCIFailure,
+	SignalApprovalDismissed,
+	SignalMentioned,
+	SignalSLABreached,
+	SignalNewcomerWaiting,
+	SignalChecklistIncomplete,
+	SignalRequestAging,
+}
+
+// Valid reports whether s is a known signal.
+func (s AttentionSignal) Valid() bool {
+	for _, known := range AllAttentionSignals {
+		if s == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Has reports whether the named signal is active.
+func (a AttentionSignals) Has(signal AttentionSignal) bool {
+	switch signal {
+	case SignalNeedsMoreReviews:
+		return a.NeedsMoreReviews
+	case SignalAgeUrgent:
+		return a.IsAgeUrgent
+	case SignalStaleReview:
+		return a.HasStaleReview
+	case SignalCIFailure:
+		return a.HasCIFailure
+	case SignalApprovalDismissed:
+		return a.ApprovalDismissed
+	case SignalMentioned:
+		return a.Mentioned
+	case SignalSLABreached:
+		return a.SLABreached
+	case SignalNewcomerWaiting:
+		return a.NewcomerWaiting
+	case SignalChecklistIncomplete:
+		return a.ChecklistIncomplete
+	case SignalRequestAging:
+		return a.RequestAging
+	}
+	return false
 }
 
-// HasAny returns true if any attention signal is active.
+// HasAny returns true if any attention signal is active or any rule matches.
 func (a AttentionSignals) HasAny() bool {
-	return a.NeedsMoreReviews || a.IsAgeUrgent || a.HasStaleReview || a.HasCIFailure
+	return a.NeedsMoreReviews || a.IsAgeUrgent || a.HasStaleReview || a.HasCIFailure || a.Mentioned || a.SLABreached || a.NewcomerWaiting || a.ChecklistIncomplete || a.RequestAging ||
+		len(a.Rules) > 0
+}
+
+// HasRule reports whether the rule with the given ID matched.
+func (a AttentionSignals) HasRule(ruleID int64) bool {
+	for _, m := range a.Rules {
+		if m.RuleID == ruleID {
+			return true
+		}
+	}
+	return false
 }
 
-// Severity returns the count of active signals (0–4), used to determine
-// border color intensity in the UI.
+// Equal reports whether a and b have the same signals and rule matches.
+func (a AttentionSignals) Equal(b AttentionSignals) bool {
+	if len(a.Rules) != len(b.Rules) {
+		return false
+	}
+	for i := range a.Rules {
+		if a.Rules[i] != b.Rules[i] {
+			return false
+		}
+	}
+	return a.NeedsMoreReviews == b.NeedsMoreReviews &&
+		a.IsAgeUrgent == b.IsAgeUrgent &&
+		a.HasStaleReview == b.HasStaleReview &&
+		a.HasCIFailure == b.HasCIFailure &&
+		a.Mentioned == b.Mentioned &&
+		a.SLABreached == b.SLABreached &&
+		a.NewcomerWaiting == b.NewcomerWaiting &&
+		a.ChecklistIncomplete == b.ChecklistIncomplete &&
+		a.RequestAging == b.RequestAging &&
+		a.ApprovalDismissed == b.ApprovalDismissed &&
+		a.FirstTimeContributor == b.FirstTimeContributor
+}
+
+// Severity returns the count of active signals (0–9) plus the severity of
+// each matched rule, used to determine border color intensity in the UI.
 func (a AttentionSignals) Severity() int {
 	count := 0
+	for _, m := range a.Rules {
+		count += m.Severity
+	}
 	if a.NeedsMoreReviews {
 		count++
 	}
@@ -39,5 +167,20 @@ func (a AttentionSignals) Severity() int {
 	if a.HasCIFailure {
 		count++
 	}
+	if a.Mentioned {
+		count++
+	}
+	if a.SLABreached {
+		count++
+	}
+	if a.NewcomerWaiting {
+		count++
+	}
+	if a.ChecklistIncomplete {
+		count++
+	}
+	if a.RequestAging {
+		count++
+	}
 	return count
 }
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// MaxRuleSeverity is the highest severity an AttentionRule may assign.
+const MaxRuleSeverity = 5
+
+// AttentionRule is a user-defined attention signal: a predicate expression
+// over a PR's stored fields and built-in signals, e.g.
+// `ci_status == "failing" && author == me && age_days > 1`. A matching rule
+// adds Severity to the PR's attention severity.
+type AttentionRule struct {
+	ID         int64
+	Name       string
+	Expression string
+	Severity   int    // 1 to MaxRuleSeverity
+	NotifyURL  string // optional; posted to when the rule starts matching a PR
+	Enabled    bool
+	CreatedAt  time.Time
+}
+
+// RuleMatch records an AttentionRule that matched a PR.
+type RuleMatch struct {
+	RuleID   int64
+	Name     string
+	Severity int
+}
+
+// RuleMatched is the payload delivered to an AttentionRule's NotifyURL.
+type RuleMatched struct {
+	Rule        AttentionRule
+	PullRequest PullRequest
+	MatchedAt   time.Time
+}
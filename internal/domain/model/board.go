@@ -0,0 +1,47 @@
+package model
+
+// BoardColumn is a column of the PR board view. PRs are placed in columns
+// automatically from their stored state; there is no manual dragging.
+type BoardColumn string
+
+// BoardColumn values.
+const (
+	BoardDraft            BoardColumn = "draft"
+	BoardAwaitingReview   BoardColumn = "awaiting_review"
+	BoardChangesRequested BoardColumn = "changes_requested"
+	BoardApproved         BoardColumn = "approved"
+	BoardCIFailing        BoardColumn = "ci_failing"
+	BoardReadyToMerge     BoardColumn = "ready_to_merge"
+)
+
+// AllBoardColumns returns every board column in display order.
+func AllBoardColumns() []BoardColumn {
+	return []BoardColumn{
+		BoardDraft,
+		BoardAwaitingReview,
+		BoardChangesRequested,
+		BoardApproved,
+		BoardCIFailing,
+		BoardReadyToMerge,
+	}
+}
+
+// Label returns the column heading.
+func (c BoardColumn) Label() string {
+	switch c {
+	case BoardDraft:
+		return "Draft"
+	case BoardAwaitingReview:
+		return "Awaiting review"
+	case BoardChangesRequested:
+		return "Changes requested"
+	case BoardApproved:
+		return "Approved"
+	case BoardCIFailing:
+		return "CI failing"
+	case BoardReadyToMerge:
+		return "Ready to merge"
+	default:
+		return string(c)
+	}
+}
@@ -8,4 +8,7 @@ type BotConfig struct {
 	ID       int64
 	Username string // e.g., "coderabbitai", "github-actions[bot]"
 	AddedAt  time.Time
+	// NitpickPatterns are lowercase substrings that mark this bot's reviews
+	// as nitpicks, on top of the built-in markers.
+	NitpickPatterns []string
 }
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// BranchProtection holds the branch protection settings that affect how
+// reviews on pull requests targeting a branch are treated.
+type BranchProtection struct {
+	RepoFullName string
+	Branch       string
+	// RequiresReviews is true when pull requests need approving reviews
+	// before they can merge; the review settings below only apply then.
+	RequiresReviews         bool
+	RequiredApprovals       int  // approving reviews needed to merge
+	RequireCodeOwnerReviews bool // a code owner of the changed files must approve
+	// DismissStaleReviews is true when pushing new commits dismisses
+	// existing approvals.
+	DismissStaleReviews bool
+	UpdatedAt           time.Time
+}
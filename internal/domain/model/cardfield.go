@@ -0,0 +1,69 @@
+package model
+
+// CardField identifies an optional detail shown on PR cards.
+type CardField string
+
+// CardField values.
+const (
+	CardFieldCIStatus  CardField = "ci_status"
+	CardFieldSize      CardField = "size"
+	CardFieldLabels    CardField = "labels"
+	CardFieldAge       CardField = "age"
+	CardFieldJiraKey   CardField = "jira_key"
+	CardFieldReviewers CardField = "reviewers"
+)
+
+// AllCardFields returns every card field in their default order.
+func AllCardFields() []CardField {
+	return []CardField{
+		CardFieldCIStatus,
+		CardFieldSize,
+		CardFieldLabels,
+		CardFieldAge,
+		CardFieldJiraKey,
+		CardFieldReviewers,
+	}
+}
+
+// DefaultCardFields returns the fields shown before the user has chosen any.
+func DefaultCardFields() []CardField {
+	return []CardField{CardFieldCIStatus}
+}
+
+// Label returns the human-readable name of the field.
+func (f CardField) Label() string {
+	switch f {
+	case CardFieldCIStatus:
+		return "CI status"
+	case CardFieldSize:
+		return "Size"
+	case CardFieldLabels:
+		return "Labels"
+	case CardFieldAge:
+		return "Age"
+	case CardFieldJiraKey:
+		return "Jira key"
+	case CardFieldReviewers:
+		return "Reviewers"
+	default:
+		return string(f)
+	}
+}
+
+// NormalizeCardFields drops unknown and duplicate fields. Unlike quick
+// actions, the order is the user's choice and is kept as given.
+func NormalizeCardFields(fields []CardField) []CardField {
+	known := make(map[CardField]bool, len(AllCardFields()))
+	for _, f := range AllCardFields() {
+		known[f] = true
+	}
+
+	result := make([]CardField, 0, len(fields))
+	for _, f := range fields {
+		if known[f] {
+			result = append(result, f)
+			known[f] = false
+		}
+	}
+	return result
+}
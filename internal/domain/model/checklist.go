@@ -0,0 +1,25 @@
+package model
+
+// ChecklistProgress counts Markdown task list items ("- [ ]" and "- [x]").
+type ChecklistProgress struct {
+	Done  int
+	Total int
+}
+
+// Add returns the combined progress of p and other.
+func (p ChecklistProgress) Add(other ChecklistProgress) ChecklistProgress {
+	return ChecklistProgress{Done: p.Done + other.Done, Total: p.Total + other.Total}
+}
+
+// Incomplete reports whether there are items and some are unchecked.
+func (p ChecklistProgress) Incomplete() bool {
+	return p.Total > 0 && p.Done < p.Total
+}
+
+// Percent returns the share of checked items, 0-100; 0 when there are none.
+func (p ChecklistProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
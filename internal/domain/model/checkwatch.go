@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// CheckWatch is a request to be notified when a PR's CI checks finish.
+type CheckWatch struct {
+	PRID         int64
+	RepoFullName string
+	PRNumber     int
+	CreatedAt    time.Time
+}
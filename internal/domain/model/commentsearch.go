@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// CommentKind distinguishes the two kinds of PR comments.
+type CommentKind string
+
+const (
+	CommentKindReview CommentKind = "review" // inline comment on the diff
+	CommentKindIssue  CommentKind = "issue"  // comment on the PR conversation
+)
+
+// CommentSearchFilter narrows a search over stored review and issue
+// comments. Zero values match everything.
+type CommentSearchFilter struct {
+	Text         string    // words the comment body must contain
+	Author       string    // exact login, case-insensitive
+	RepoFullName string    // restricts results to one repository
+	After        time.Time // inclusive
+	Before       time.Time // exclusive
+	Limit        int       // maximum results; zero means the store's default
+}
+
+// CommentSearchResult is one comment matched by a comment search, with the
+// pull request it belongs to.
+type CommentSearchResult struct {
+	Kind         CommentKind
+	CommentID    int64
+	RepoFullName string
+	PRNumber     int
+	PRTitle      string
+	Author       string
+	Body         string
+	// Snippet is the part of the body around the matched words; empty when
+	// the search had no text.
+	Snippet   string
+	Path      string // file the review comment is on; empty for issue comments
+	CreatedAt time.Time
+}
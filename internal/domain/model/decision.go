@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Decision is a review thread that a reviewer marked as settling a recurring
+// question, together with a short summary of the outcome. Decisions are kept
+// by repository and PR number so they outlive the pull request record.
+type Decision struct {
+	ID            int64
+	RepoFullName  string
+	PRNumber      int
+	PRTitle       string
+	RootCommentID int64 // GitHub ID of the thread's root review comment
+	FilePath      string
+	Summary       string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
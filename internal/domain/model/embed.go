@@ -0,0 +1,81 @@
+package model
+
+import "strings"
+
+// EmbedColumn identifies an optional column of the embeddable PR list. The
+// PR number and title are always shown.
+type EmbedColumn string
+
+// EmbedColumn values.
+const (
+	EmbedColumnAuthor    EmbedColumn = "author"
+	EmbedColumnCIStatus  EmbedColumn = "ci_status"
+	EmbedColumnReview    EmbedColumn = "review"
+	EmbedColumnAge       EmbedColumn = "age"
+	EmbedColumnLabels    EmbedColumn = "labels"
+	EmbedColumnSize      EmbedColumn = "size"
+	EmbedColumnReviewers EmbedColumn = "reviewers"
+)
+
+// AllEmbedColumns returns every embed column in their default order.
+func AllEmbedColumns() []EmbedColumn {
+	return []EmbedColumn{
+		EmbedColumnAuthor,
+		EmbedColumnCIStatus,
+		EmbedColumnReview,
+		EmbedColumnAge,
+		EmbedColumnLabels,
+		EmbedColumnSize,
+		EmbedColumnReviewers,
+	}
+}
+
+// DefaultEmbedColumns returns the columns shown when an embed URL names none.
+func DefaultEmbedColumns() []EmbedColumn {
+	return []EmbedColumn{EmbedColumnAuthor, EmbedColumnCIStatus, EmbedColumnReview, EmbedColumnAge}
+}
+
+// Label returns the column heading.
+func (c EmbedColumn) Label() string {
+	switch c {
+	case EmbedColumnAuthor:
+		return "Author"
+	case EmbedColumnCIStatus:
+		return "CI"
+	case EmbedColumnReview:
+		return "Review"
+	case EmbedColumnAge:
+		return "Age"
+	case EmbedColumnLabels:
+		return "Labels"
+	case EmbedColumnSize:
+		return "Size"
+	case EmbedColumnReviewers:
+		return "Reviewers"
+	default:
+		return string(c)
+	}
+}
+
+// ParseEmbedColumns parses a comma-separated column list, keeping the given
+// order and dropping unknown and duplicate names. An empty or entirely
+// unknown list yields DefaultEmbedColumns.
+func ParseEmbedColumns(raw string) []EmbedColumn {
+	known := make(map[EmbedColumn]bool, len(AllEmbedColumns()))
+	for _, c := range AllEmbedColumns() {
+		known[c] = true
+	}
+
+	var result []EmbedColumn
+	for _, name := range strings.Split(raw, ",") {
+		c := EmbedColumn(strings.ToLower(strings.TrimSpace(name)))
+		if known[c] {
+			result = append(result, c)
+			known[c] = false
+		}
+	}
+	if len(result) == 0 {
+		return DefaultEmbedColumns()
+	}
+	return result
+}
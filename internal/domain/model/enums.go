@@ -10,6 +10,15 @@ const (
 	PRStatusMerged PRStatus = "merged"
 )
 
+// IsValid reports whether s is a known PR status.
+func (s PRStatus) IsValid() bool {
+	switch s {
+	case PRStatusOpen, PRStatusClosed, PRStatusMerged:
+		return true
+	}
+	return false
+}
+
 // ReviewState represents the state of a review.
 type ReviewState string
 
@@ -52,3 +61,28 @@ const (
 	CommentTypeGeneral CommentType = "general" // Issue comment / PR-level discussion.
 	CommentTypeFile    CommentType = "file"    // Review comment on a file, not a specific line.
 )
+
+// AuthorAssociation is GitHub's relationship between a PR author and the
+// repository. Empty means unknown, e.g. for PRs not yet re-polled.
+type AuthorAssociation string
+
+// AuthorAssociation values.
+const (
+	AssociationOwner                AuthorAssociation = "OWNER"
+	AssociationMember               AuthorAssociation = "MEMBER"
+	AssociationCollaborator         AuthorAssociation = "COLLABORATOR"
+	AssociationContributor          AuthorAssociation = "CONTRIBUTOR" // has previously committed to the repo
+	AssociationFirstTimeContributor AuthorAssociation = "FIRST_TIME_CONTRIBUTOR"
+	AssociationFirstTimer           AuthorAssociation = "FIRST_TIMER" // first contribution anywhere on GitHub
+	AssociationNone                 AuthorAssociation = "NONE"
+)
+
+// IsExternal reports whether the author is outside the repository's team:
+// not an owner, organization member, or collaborator. Unknown reports false.
+func (a AuthorAssociation) IsExternal() bool {
+	switch a {
+	case "", AssociationOwner, AssociationMember, AssociationCollaborator:
+		return false
+	}
+	return true
+}
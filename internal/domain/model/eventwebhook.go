@@ -0,0 +1,85 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// WebhookEventType is an event an EventWebhook can subscribe to.
+type WebhookEventType string
+
+// WebhookEventType values.
+const (
+	// WebhookPRCreated fires when a PR is opened in a watched repository.
+	WebhookPRCreated WebhookEventType = "pr.created"
+	// WebhookPRNeedsAttention fires when a PR that needed no attention
+	// starts raising an attention signal or matching an attention rule.
+	WebhookPRNeedsAttention WebhookEventType = "pr.needs_attention"
+	// WebhookReviewSubmitted fires when a review is submitted on a PR.
+	WebhookReviewSubmitted WebhookEventType = "review.submitted"
+	// WebhookCheckFailed fires when a check run completes unsuccessfully.
+	WebhookCheckFailed WebhookEventType = "check.failed"
+)
+
+// AllWebhookEventTypes lists every WebhookEventType in display order.
+var AllWebhookEventTypes = []WebhookEventType{
+	WebhookPRCreated, WebhookPRNeedsAttention, WebhookReviewSubmitted, WebhookCheckFailed,
+}
+
+// Valid reports whether t is a known webhook event type.
+func (t WebhookEventType) Valid() bool {
+	return slices.Contains(AllWebhookEventTypes, t)
+}
+
+// EventWebhook receives signed JSON for the events it subscribes to, for
+// integrations such as chat bots or build dashboards.
+type EventWebhook struct {
+	ID  int64
+	URL string
+	// Secret signs every delivery with HMAC-SHA256 so receivers can verify
+	// the sender.
+	Secret       string
+	Events       []WebhookEventType // empty subscribes to every event
+	RepoFullName string             // empty matches every watched repository
+	Enabled      bool
+	CreatedAt    time.Time
+}
+
+// Matches reports whether the webhook receives event for a PR in repoFullName.
+func (w EventWebhook) Matches(event WebhookEventType, repoFullName string) bool {
+	return w.Enabled &&
+		(len(w.Events) == 0 || slices.Contains(w.Events, event)) &&
+		(w.RepoFullName == "" || w.RepoFullName == repoFullName)
+}
+
+// WebhookDeliveryStatus is where a webhook delivery stands.
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryStatus values.
+const (
+	// WebhookDeliveryPending deliveries are retried once NextAttemptAt passes.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryDelivered deliveries got a 2xx response.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryFailed deliveries ran out of attempts.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent, or to be sent, to an EventWebhook. The
+// payload is fixed when the event occurs, so retries send the same body.
+type WebhookDelivery struct {
+	ID           int64
+	WebhookID    int64
+	Event        WebhookEventType
+	RepoFullName string
+	PRNumber     int
+	Payload      string // the JSON body
+
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	ResponseStatus int // HTTP status of the last attempt; 0 when no response arrived
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	DeliveredAt    time.Time // zero until delivered
+}
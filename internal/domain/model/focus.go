@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// FocusState is the user's response to a PR on the daily focus list.
+type FocusState string
+
+// FocusState values.
+const (
+	FocusDone    FocusState = "done"
+	FocusSkipped FocusState = "skipped"
+)
+
+// IsValid reports whether s is a known focus state.
+func (s FocusState) IsValid() bool {
+	return s == FocusDone || s == FocusSkipped
+}
+
+// FocusMark records that the user marked a PR done or skipped it on the
+// focus list of one day.
+type FocusMark struct {
+	PRID     int64
+	Day      string // YYYY-MM-DD, see FocusDay
+	State    FocusState
+	MarkedAt time.Time
+}
+
+// FocusDay returns the focus list day t falls on, in local time.
+func FocusDay(t time.Time) string {
+	return t.Local().Format(time.DateOnly)
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// GitHubAccount is a named GitHub credential that watched repositories can be
+// routed through, e.g. separate work and personal accounts. Repositories
+// without an assignment use the default token. Token is plaintext at the
+// domain boundary; the adapter layer encrypts it for storage.
+type GitHubAccount struct {
+	ID        int64
+	Name      string
+	Username  string // login the token authenticates as, recorded on creation
+	Token     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// GitHubAPIStatus is a snapshot of the GitHub API state as observed on the most
+// recent API response. A zero ObservedAt means no response has been seen yet.
+type GitHubAPIStatus struct {
+	RateLimit     int
+	RateRemaining int
+	RateReset     time.Time
+	// Unauthorized is true when the most recent response was a 401, which means
+	// the configured token is missing, revoked, or expired.
+	Unauthorized bool
+	ObservedAt   time.Time
+}
+
+// GitHubServiceStatus is GitHub's own report of API health from its public
+// status page. Description is the page's wording, e.g. "Degraded Performance".
+type GitHubServiceStatus struct {
+	Degraded    bool
+	Description string
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// IgnoreRule is a user-defined ignore filter: an attention rule expression,
+// e.g. `author == "dependabot[bot]" || labels contains "wip"`, whose matching
+// PRs are added to the ignore list.
+type IgnoreRule struct {
+	ID         int64
+	Name       string
+	Expression string
+	Enabled    bool
+	CreatedAt  time.Time
+}
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+// InboxEventKind identifies what an inbox event is about.
+type InboxEventKind string
+
+// InboxEventKind values.
+const (
+	InboxReviewRequested  InboxEventKind = "review_requested"
+	InboxCIFailed         InboxEventKind = "ci_failed"
+	InboxNewComment       InboxEventKind = "new_comment"
+	InboxApprovalReceived InboxEventKind = "approval_received"
+	InboxSLABreached      InboxEventKind = "sla_breached"
+	InboxChecksFinished   InboxEventKind = "checks_finished"
+	InboxStaleMarked      InboxEventKind = "stale_marked"
+)
+
+// InboxEvent is one discrete thing that happened on a watched PR and may
+// need the user's attention, tracked as read or unread. Events are kept by
+// repository and PR number so they outlive the pull request record.
+type InboxEvent struct {
+	ID           int64
+	Kind         InboxEventKind
+	RepoFullName string
+	PRNumber     int
+	PRTitle      string
+	Actor        string // login of whoever caused the event; empty for CI
+	Summary      string // short description, e.g. the check name or a comment excerpt
+	// SourceID identifies the GitHub object behind the event (review, check
+	// run, or comment ID) so the same event is never recorded twice.
+	SourceID   string
+	OccurredAt time.Time
+	ReadAt     *time.Time // nil while unread
+}
+
+// IsRead reports whether the event has been marked read.
+func (e InboxEvent) IsRead() bool {
+	return e.ReadAt != nil
+}
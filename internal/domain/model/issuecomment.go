@@ -10,6 +10,10 @@ type IssueComment struct {
 	Author    string
 	Body      string
 	IsBot     bool
+	HTMLURL   string // The comment's page on GitHub; empty until synced.
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// PendingSync marks a local echo of a comment posted from this app; see
+	// ReviewComment.PendingSync.
+	PendingSync bool
 }
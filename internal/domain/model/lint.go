@@ -0,0 +1,49 @@
+package model
+
+// LintTarget is the part of a PR a lint rule checks.
+type LintTarget string
+
+// LintTarget values.
+const (
+	LintTargetTitle  LintTarget = "title"
+	LintTargetBranch LintTarget = "branch"
+)
+
+// Valid reports whether t is a known target.
+func (t LintTarget) Valid() bool {
+	return t == LintTargetTitle || t == LintTargetBranch
+}
+
+// LintRule is a per-repository naming convention: a PR's title or branch must
+// match Pattern, a Go regular expression such as `^(feat|fix|chore)(\(.+\))?: `
+// for Conventional Commits or `^[A-Z]+-\d+` for a Jira key prefix.
+type LintRule struct {
+	ID           int64
+	RepoFullName string
+	Target       LintTarget
+	Pattern      string
+	Description  string // optional; shown instead of the pattern, e.g. "Conventional Commits"
+}
+
+// Value returns the part of pr the rule checks.
+func (r LintRule) Value(pr PullRequest) string {
+	if r.Target == LintTargetBranch {
+		return pr.Branch
+	}
+	return pr.Title
+}
+
+// Label describes the rule for people: its description, or its target and
+// pattern when it has none.
+func (r LintRule) Label() string {
+	if r.Description != "" {
+		return r.Description
+	}
+	return string(r.Target) + " must match " + r.Pattern
+}
+
+// PRLintViolations pairs a PR with the rules it failed at its last sync.
+type PRLintViolations struct {
+	PR         PullRequest
+	Violations []LintRule
+}
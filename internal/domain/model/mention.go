@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// MentionSource identifies the kind of comment a mention appears in.
+type MentionSource string
+
+// MentionSource values, one per comment kind stored for a PR.
+const (
+	MentionInReview        MentionSource = "review"
+	MentionInReviewComment MentionSource = "review_comment"
+	MentionInIssueComment  MentionSource = "issue_comment"
+)
+
+// Mention records that a comment on a PR @mentions a GitHub login.
+type Mention struct {
+	PRID      int64
+	Source    MentionSource
+	CommentID int64
+	Author    string // who wrote the comment
+	Login     string // who was mentioned
+	CreatedAt time.Time
+}
@@ -0,0 +1,35 @@
+package model
+
+// MergeRequirementKind identifies one condition a PR must meet before it can
+// merge into a protected branch.
+type MergeRequirementKind string
+
+// MergeRequirementKind values, in checklist order.
+const (
+	RequirementApprovals          MergeRequirementKind = "approvals"
+	RequirementCodeOwnerReview    MergeRequirementKind = "code_owner_review"
+	RequirementNoChangesRequested MergeRequirementKind = "no_changes_requested"
+	RequirementStatusChecks       MergeRequirementKind = "status_checks"
+	RequirementNoConflicts        MergeRequirementKind = "no_conflicts"
+)
+
+// RequirementState is whether a merge requirement is currently satisfied.
+type RequirementState string
+
+// RequirementState values.
+const (
+	RequirementMet     RequirementState = "met"
+	RequirementUnmet   RequirementState = "unmet"
+	RequirementPending RequirementState = "pending" // waiting on something in progress, e.g. CI
+	RequirementUnknown RequirementState = "unknown" // cannot be determined from stored data
+)
+
+// MergeRequirement is a transient model computed from a PR's branch
+// protection, reviews, and checks. Have and Need count toward requirements
+// with a numeric target, such as approvals; both are zero otherwise.
+type MergeRequirement struct {
+	Kind  MergeRequirementKind
+	State RequirementState
+	Have  int
+	Need  int
+}
@@ -0,0 +1,7 @@
+package model
+
+// Milestone is an open milestone of a repository that PRs can be assigned to.
+type Milestone struct {
+	Number int // GitHub's per-repository milestone number, used to assign it
+	Title  string
+}
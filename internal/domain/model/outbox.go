@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// OutboundActionKind identifies the GitHub write an outbound action replays.
+type OutboundActionKind string
+
+// OutboundActionKind values, one per write that carries composed text.
+const (
+	OutboundReview       OutboundActionKind = "review"
+	OutboundReply        OutboundActionKind = "reply"
+	OutboundIssueComment OutboundActionKind = "issue_comment"
+)
+
+// OutboundStatus is where an outbound action stands.
+type OutboundStatus string
+
+// OutboundStatus values. Actions that reach GitHub are deleted, so only
+// pending and failed ones are stored.
+const (
+	// OutboundPending actions are retried once NextAttemptAt passes.
+	OutboundPending OutboundStatus = "pending"
+	// OutboundFailed actions were rejected by GitHub or ran out of attempts;
+	// they are kept so the composed text is not lost.
+	OutboundFailed OutboundStatus = "failed"
+)
+
+// OutboundAction is a GitHub write that failed with a transient error and is
+// queued for retry.
+type OutboundAction struct {
+	ID           int64
+	Kind         OutboundActionKind
+	RepoFullName string
+	PRNumber     int
+	Body         string // the review, reply, or comment text
+	InReplyTo    int64  // root review comment ID, for replies
+	// Payload holds kind-specific request data as JSON, such as a review's
+	// event and inline comments.
+	Payload string
+
+	Status        OutboundStatus
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// PREventType identifies the kind of change a PREvent describes.
+type PREventType string
+
+// PREventType values.
+const (
+	PREventUpdated          PREventType = "pr.updated"
+	PREventOpened           PREventType = "pr.opened"
+	PREventReviewAdded      PREventType = "review.added"
+	PREventCheckCompleted   PREventType = "check.completed"
+	PREventCommentAdded     PREventType = "comment.added"
+	PREventAttentionChanged PREventType = "attention.changed"
+)
+
+// IsValid reports whether t is a known event type.
+func (t PREventType) IsValid() bool {
+	switch t {
+	case PREventUpdated, PREventOpened, PREventReviewAdded, PREventCheckCompleted, PREventCommentAdded, PREventAttentionChanged:
+		return true
+	}
+	return false
+}
+
+// PREvent is a change to a watched pull request observed while polling.
+// The payload field matching Type is set; attention.changed also carries
+// the PR so consumers have its context.
+type PREvent struct {
+	Type         PREventType
+	RepoFullName string
+	PRNumber     int
+	OccurredAt   time.Time
+
+	PullRequest *PullRequest      // pr.updated, pr.opened, attention.changed
+	Review      *Review           // review.added
+	CheckRun    *CheckRun         // check.completed
+	Comment     *IssueComment     // comment.added
+	Attention   *AttentionSignals // attention.changed
+
+	// PreviousAttention is the signals before an attention.changed event;
+	// all false for a newly discovered PR.
+	PreviousAttention *AttentionSignals
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// PRJournalKind is the kind of change a PRJournalEntry records.
+type PRJournalKind string
+
+// PRJournalKind values.
+const (
+	// JournalStatusChanged records a PR opening, closing, merging, or
+	// reopening; From and To are PRStatus values, From empty for a PR
+	// opened since the last poll.
+	JournalStatusChanged PRJournalKind = "status_changed"
+	// JournalCIChanged records a change of the combined CI status; From and
+	// To are CIStatus values.
+	JournalCIChanged PRJournalKind = "ci_changed"
+	// JournalReviewAdded records a submitted review; Actor is the reviewer
+	// and To the review state.
+	JournalReviewAdded PRJournalKind = "review_added"
+	// JournalReviewRequested records a new review request; Actor is the
+	// requested user, or the team slug with To set to "team".
+	JournalReviewRequested PRJournalKind = "review_requested"
+)
+
+// AllPRJournalKinds lists every PRJournalKind.
+var AllPRJournalKinds = []PRJournalKind{
+	JournalStatusChanged, JournalCIChanged, JournalReviewAdded, JournalReviewRequested,
+}
+
+// Valid reports whether k is a known journal kind.
+func (k PRJournalKind) Valid() bool {
+	return slices.Contains(AllPRJournalKinds, k)
+}
+
+// PRJournalEntry is one change to a PR's stored state, appended to the event
+// journal by the poll loop when it observes the change.
+type PRJournalEntry struct {
+	ID           int64 // increases with every entry; use as a cursor
+	PRID         int64
+	RepoFullName string
+	PRNumber     int
+	Kind         PRJournalKind
+	Actor        string
+	From         string
+	To           string
+	OccurredAt   time.Time // when the poll loop observed the change
+}
+
+// PRJournalFilter narrows a journal query. Zero values match everything.
+type PRJournalFilter struct {
+	RepoFullName string
+	PRNumber     int // only with RepoFullName
+	Kinds        []PRJournalKind
+	AfterID      int64 // entries with a greater ID, for following the journal
+	Limit        int   // maximum entries; zero means the store's default
+}
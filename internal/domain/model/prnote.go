@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// PRNote is a private annotation on a pull request. Notes are never posted to
+// GitHub and, like decisions, are kept by repository and PR number so they
+// outlive the pull request record.
+type PRNote struct {
+	ID           int64
+	RepoFullName string
+	PRNumber     int
+	Body         string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// PRView records the user's last visit to a PR's detail panel and the state of
+// the PR at the time, so the next visit can summarize what changed since.
+type PRView struct {
+	PRID     int64
+	ViewedAt time.Time
+	HeadSHA  string   // head commit when viewed; a different head means new commits
+	CIStatus CIStatus // combined CI status when viewed
+}
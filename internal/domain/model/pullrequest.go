@@ -25,14 +25,40 @@ type PullRequest struct {
 	OpenedAt        time.Time
 	UpdatedAt       time.Time
 	LastActivityAt  time.Time
+	ClosedAt        time.Time // When the PR was merged or closed; zero while open.
+
+	// AuthorAssociation is the author's relationship to the repository as
+	// reported by GitHub.
+	AuthorAssociation AuthorAssociation
+
+	// Milestone is the title of the PR's milestone; empty when it has none.
+	Milestone string
+
+	// Checklist is the task list progress across the body and conversation
+	// comments as of the last sync.
+	Checklist ChecklistProgress
+
+	// ReviewRequests are the pending review requests as of the last sync,
+	// with when each was made.
+	ReviewRequests []ReviewRequest
+
+	// ReviewRollup is the review state aggregated from the stored reviews
+	// and threads as of the last sync.
+	ReviewRollup ReviewRollup
 
 	// JiraKey is the detected Jira issue key (e.g. "PROJ-123") extracted from
 	// Branch or Title during polling. Empty if none detected.
 	JiraKey string
 
-	// Transient fields populated during GitHub fetch, not persisted.
+	// RequestedReviewers and RequestedTeamSlugs are the logins and team slugs
+	// whose review is pending. Polling fills them from GitHub; PRs read from
+	// the store derive them from ReviewRequests.
 	RequestedReviewers []string
 	RequestedTeamSlugs []string
+
+	// Body and Assignees are populated during GitHub fetch and not persisted.
+	Body      string
+	Assignees []string
 }
 
 // DaysSinceOpened returns the number of days since the PR was opened.
@@ -45,6 +71,15 @@ func (pr PullRequest) DaysSinceLastActivity() int {
 	return int(time.Since(pr.LastActivityAt).Hours() / 24)
 }
 
+// TimeToMerge returns how long the PR was open before it merged. ok is false
+// for PRs that are open, closed without merging, or have no close time.
+func (pr PullRequest) TimeToMerge() (d time.Duration, ok bool) {
+	if pr.Status != PRStatusMerged || pr.ClosedAt.IsZero() {
+		return 0, false
+	}
+	return pr.ClosedAt.Sub(pr.OpenedAt), true
+}
+
 // IsStale returns true if the PR has had no activity for the given number of days.
 func (pr PullRequest) IsStale(thresholdDays int) bool {
 	return pr.DaysSinceLastActivity() >= thresholdDays
@@ -0,0 +1,89 @@
+package model
+
+// QuickAction identifies a one-click action shown on PR cards.
+type QuickAction string
+
+// QuickAction values.
+const (
+	QuickActionOpenGitHub   QuickAction = "open_github"
+	QuickActionCopyBranch   QuickAction = "copy_branch"
+	QuickActionCopyCheckout QuickAction = "copy_checkout"
+	QuickActionIgnore       QuickAction = "ignore"
+	QuickActionRefresh      QuickAction = "refresh"
+	QuickActionApprove      QuickAction = "approve"
+	// QuickActionRequestChanges opens a dialog to request changes with a
+	// review body, optionally started from a review template.
+	QuickActionRequestChanges QuickAction = "request_changes"
+)
+
+// AllQuickActions returns every quick action in display order.
+func AllQuickActions() []QuickAction {
+	return []QuickAction{
+		QuickActionOpenGitHub,
+		QuickActionCopyBranch,
+		QuickActionCopyCheckout,
+		QuickActionIgnore,
+		QuickActionRefresh,
+		QuickActionApprove,
+		QuickActionRequestChanges,
+	}
+}
+
+// DefaultQuickActions returns the actions shown before the user has chosen any.
+func DefaultQuickActions() []QuickAction {
+	return []QuickAction{QuickActionIgnore}
+}
+
+// Label returns the human-readable name of the action.
+func (a QuickAction) Label() string {
+	switch a {
+	case QuickActionOpenGitHub:
+		return "Open in GitHub"
+	case QuickActionCopyBranch:
+		return "Copy branch name"
+	case QuickActionCopyCheckout:
+		return "Copy checkout command"
+	case QuickActionIgnore:
+		return "Ignore"
+	case QuickActionRefresh:
+		return "Refresh"
+	case QuickActionApprove:
+		return "Approve"
+	case QuickActionRequestChanges:
+		return "Request changes"
+	default:
+		return string(a)
+	}
+}
+
+// NormalizeQuickActions drops unknown and duplicate actions and returns the
+// rest in display order, so stored and submitted values are always canonical.
+func NormalizeQuickActions(actions []QuickAction) []QuickAction {
+	selected := make(map[QuickAction]bool, len(actions))
+	for _, a := range actions {
+		selected[a] = true
+	}
+
+	result := make([]QuickAction, 0, len(selected))
+	for _, a := range AllQuickActions() {
+		if selected[a] {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// ApproveSettings configures the one-click approve action.
+type ApproveSettings struct {
+	// Message is the optional body of the approving review; empty approves
+	// without a comment.
+	Message string
+	// Confirm asks before approving.
+	Confirm bool
+}
+
+// DefaultApproveSettings returns the approve settings used before the user has
+// saved any: no message, with a confirmation prompt.
+func DefaultApproveSettings() ApproveSettings {
+	return ApproveSettings{Confirm: true}
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// ReplyTemplateKind says which composer a reply template is offered in.
+type ReplyTemplateKind string
+
+// ReplyTemplateKind values.
+const (
+	// ReplyTemplateSnippet is a canned reply for thread replies and PR comments.
+	ReplyTemplateSnippet ReplyTemplateKind = "snippet"
+	// ReplyTemplateReview is a template for the review body.
+	ReplyTemplateReview ReplyTemplateKind = "review"
+)
+
+// Valid reports whether k is a known template kind.
+func (k ReplyTemplateKind) Valid() bool {
+	return k == ReplyTemplateSnippet || k == ReplyTemplateReview
+}
+
+// ReplyTemplate is a saved piece of text inserted into a composer. Its body
+// may contain variables such as {{author}} that are filled in from the PR.
+type ReplyTemplate struct {
+	ID        int64
+	Name      string
+	Kind      ReplyTemplateKind
+	Body      string
+	CreatedAt time.Time
+}
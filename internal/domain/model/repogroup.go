@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// RepoGroup is a named set of watched repositories, such as "Backend" or
+// "Infra", used to scope the dashboard. A repository may belong to several
+// groups.
+type RepoGroup struct {
+	ID        int64
+	Name      string
+	Repos     []string // repository full names, sorted
+	CreatedAt time.Time
+}
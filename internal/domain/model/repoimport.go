@@ -0,0 +1,18 @@
+package model
+
+// RepoImportStatus is the outcome of one line of a bulk repo import.
+type RepoImportStatus string
+
+// RepoImportStatus values.
+const (
+	RepoImportAdded          RepoImportStatus = "added"
+	RepoImportAlreadyWatched RepoImportStatus = "already_watched"
+	RepoImportDuplicate      RepoImportStatus = "duplicate" // listed earlier in the same import
+	RepoImportInvalid        RepoImportStatus = "invalid"   // not in owner/repo form
+)
+
+// RepoImportResult reports what a bulk import did with one repository.
+type RepoImportResult struct {
+	FullName string
+	Status   RepoImportStatus
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"slices"
+	"time"
+)
+
+// ReportDestination is where a ScheduledReport is posted.
+type ReportDestination string
+
+// ReportDestination values.
+const (
+	// ReportToSlack posts to a Slack incoming webhook URL.
+	ReportToSlack ReportDestination = "slack"
+	// ReportToGitHubIssue posts a comment on a GitHub issue or PR, given as
+	// owner/repo#number.
+	ReportToGitHubIssue ReportDestination = "github_issue"
+)
+
+// AllReportDestinations lists every ReportDestination in display order.
+var AllReportDestinations = []ReportDestination{ReportToSlack, ReportToGitHubIssue}
+
+// Valid reports whether d is a known report destination.
+func (d ReportDestination) Valid() bool {
+	return slices.Contains(AllReportDestinations, d)
+}
+
+// ScheduledReport is a weekly repository health summary, generated from
+// stored data and posted to Slack or GitHub.
+type ScheduledReport struct {
+	ID          int64
+	Name        string
+	GroupID     int64 // repo group covered; 0 covers every watched repository
+	Destination ReportDestination
+	Target      string // Slack webhook URL or owner/repo#number
+	Template    string // text/template source; empty uses the default template
+	Weekday     time.Weekday
+	Hour        int       // UTC hour of day
+	LastSentAt  time.Time // zero until first sent
+	CreatedAt   time.Time
+}
+
+// ScheduledAt returns the latest time at or before now the report is
+// scheduled for.
+func (r ScheduledReport) ScheduledAt(now time.Time) time.Time {
+	now = now.UTC()
+	slot := time.Date(now.Year(), now.Month(), now.Day(), r.Hour, 0, 0, 0, time.UTC)
+	slot = slot.AddDate(0, 0, -((int(now.Weekday()) - int(r.Weekday) + 7) % 7))
+	if slot.After(now) {
+		slot = slot.AddDate(0, 0, -7)
+	}
+	return slot
+}
+
+// Due reports whether a scheduled time has passed since the report was last
+// sent. Scheduled times before the report was created do not count, and a
+// report missed for several weeks is sent once.
+func (r ScheduledReport) Due(now time.Time) bool {
+	slot := r.ScheduledAt(now)
+	return slot.After(r.LastSentAt) && !slot.Before(r.CreatedAt)
+}
@@ -0,0 +1,71 @@
+package model
+
+import "strings"
+
+// RepoSettings holds per-repository configuration other than the attention
+// threshold overrides.
+type RepoSettings struct {
+	RepoFullName string
+	// RequiredChecks are check names treated as required on top of those
+	// branch protection requires, for when protection cannot be read.
+	// OptionalChecks are never treated as required, even when branch
+	// protection lists them. Names match case-insensitively.
+	RequiredChecks []string
+	OptionalChecks []string
+	// IgnoredChecks are check run names and commit status contexts left
+	// out of the PR's combined CI status, e.g. a coverage check that is
+	// always red. They are still listed with the PR's checks.
+	IgnoredChecks []string
+
+	// Stale configures labeling or commenting on PRs that have gone idle.
+	Stale StalePolicy
+
+	// PRScope chooses which of the repository's PRs are stored.
+	PRScope PRScope
+}
+
+// PRScope chooses which of a repository's PRs the poller stores.
+type PRScope string
+
+// PRScope values.
+const (
+	// PRScopeDefault follows the relevant_prs_only setting.
+	PRScopeDefault PRScope = ""
+	// PRScopeAll stores every PR.
+	PRScopeAll PRScope = "all"
+	// PRScopeRelevant stores only the PRs that involve the user.
+	PRScopeRelevant PRScope = "relevant"
+)
+
+// IsValid reports whether s is a known PR scope.
+func (s PRScope) IsValid() bool {
+	return s == PRScopeDefault || s == PRScopeAll || s == PRScopeRelevant
+}
+
+// CheckRequirement reports whether the check named name is marked required
+// or optional in the settings. overridden is false when neither list names
+// it, leaving branch protection to decide; optional wins if both do.
+func (s RepoSettings) CheckRequirement(name string) (required, overridden bool) {
+	for _, optional := range s.OptionalChecks {
+		if strings.EqualFold(optional, name) {
+			return false, true
+		}
+	}
+	for _, req := range s.RequiredChecks {
+		if strings.EqualFold(req, name) {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// IgnoresCheck reports whether the check run or status context named name is
+// left out of the combined CI status.
+func (s RepoSettings) IgnoresCheck(name string) bool {
+	for _, ignored := range s.IgnoredChecks {
+		if strings.EqualFold(ignored, name) {
+			return true
+		}
+	}
+	return false
+}
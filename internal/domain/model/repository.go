@@ -2,6 +2,20 @@ package model
 
 import "time"
 
+// Provider is the source code host a repository lives on.
+type Provider string
+
+// Provider values.
+const (
+	ProviderGitHub    Provider = "github"
+	ProviderBitbucket Provider = "bitbucket"
+)
+
+// Valid reports whether p is a known provider.
+func (p Provider) Valid() bool {
+	return p == ProviderGitHub || p == ProviderBitbucket
+}
+
 // Repository represents a GitHub repository watched by ReviewHub.
 type Repository struct {
 	ID       int64
@@ -9,4 +23,34 @@ type Repository struct {
 	Owner    string
 	Name     string
 	AddedAt  time.Time
+
+	// Provider is where the repository is hosted; empty means GitHub.
+	Provider Provider
+
+	// Paused repositories are skipped by background polling; a manual
+	// refresh still polls them.
+	Paused bool
+
+	// LastSyncedAt is when the repository was last polled successfully; zero
+	// if it never was.
+	LastSyncedAt time.Time
+
+	// RemovedAt is when the repository was removed from the watch list; zero
+	// while it is watched. Removed repositories keep their data until purged.
+	RemovedAt time.Time
+
+	Metadata RepoMetadata
+}
+
+// RepoMetadata describes a watched repository as GitHub reports it.
+type RepoMetadata struct {
+	Description   string
+	DefaultBranch string
+	Private       bool
+	Archived      bool
+	OpenPRCount   int
+
+	// FetchedAt is when the fields other than OpenPRCount were last fetched;
+	// zero if they never were.
+	FetchedAt time.Time
 }
@@ -12,4 +12,5 @@ type Review struct {
 	CommitID      string // SHA of the commit this review targets; used for outdated detection.
 	SubmittedAt   time.Time
 	IsBot         bool
+	IsNitpick     bool // a bot review whose body carries a nitpick marker
 }
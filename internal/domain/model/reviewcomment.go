@@ -16,9 +16,25 @@ type ReviewComment struct {
 	SubjectType string // From GitHub: "line" or "file".
 	DiffHunk    string
 	CommitID    string // SHA of the commit this comment targets.
-	IsResolved  bool
-	IsOutdated  bool
-	InReplyToID *int64
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// Position is the comment's offset in the PR's current diff, nil once
+	// the line it was made on no longer exists there.
+	Position     *int
+	OriginalLine int // Line in the commit the comment was first made on.
+	IsResolved   bool
+	IsOutdated   bool // Set from OutdatedAt when the comment is synced.
+	InReplyToID  *int64
+	HTMLURL      string // The comment's page on GitHub; empty until synced.
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// PendingSync marks a comment the user just posted from this app, stored
+	// under a local (negative) ID until the poll loop fetches GitHub's copy.
+	PendingSync bool
+}
+
+// OutdatedAt reports whether the comment no longer applies to the PR at
+// headSHA: it targets an earlier commit and its line is gone from the current
+// diff. File-level comments have no line and are never outdated.
+func (c ReviewComment) OutdatedAt(headSHA string) bool {
+	return c.CommitID != "" && headSHA != "" && c.CommitID != headSHA &&
+		c.Position == nil && c.OriginalLine > 0
 }
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// ReviewedPR is a closed or merged pull request the user reviewed, with a
+// summary of their participation, for the review history archive.
+type ReviewedPR struct {
+	PullRequest    PullRequest
+	LastState      ReviewState // state of the user's most recent review
+	LastReviewedAt time.Time
+	CommentCount   int // inline review comments written by the user
+	Decisions      []Decision
+}
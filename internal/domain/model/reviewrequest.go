@@ -0,0 +1,34 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// ReviewRequest is a pending request for a user's or a team's review of a PR.
+type ReviewRequest struct {
+	Reviewer    string // login, or team slug when IsTeam
+	IsTeam      bool
+	RequestedAt time.Time
+}
+
+// ReviewRequestedAt returns when login's review was requested. A direct
+// request wins; otherwise, when NeedsReview says one of login's teams was
+// asked, the oldest team request stands in. ok is false when neither exists
+// or the time is unknown.
+func (pr PullRequest) ReviewRequestedAt(login string) (at time.Time, ok bool) {
+	var team time.Time
+	for _, r := range pr.ReviewRequests {
+		switch {
+		case r.RequestedAt.IsZero():
+		case !r.IsTeam && strings.EqualFold(r.Reviewer, login):
+			return r.RequestedAt, true
+		case r.IsTeam && (team.IsZero() || r.RequestedAt.Before(team)):
+			team = r.RequestedAt
+		}
+	}
+	if pr.NeedsReview && !team.IsZero() {
+		return team, true
+	}
+	return time.Time{}, false
+}
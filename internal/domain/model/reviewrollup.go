@@ -0,0 +1,29 @@
+package model
+
+// ReviewRollup is a PR's review state aggregated from its stored reviews and
+// threads. It is kept on the PR row so listings and filters need no per-PR
+// review lookups.
+type ReviewRollup struct {
+	// Status is the overall review status as in the PR detail view; empty
+	// until the PR is first rolled up.
+	Status ReviewState
+	// Approvals counts human reviewers whose latest review approves.
+	Approvals int
+	// UnresolvedThreads counts review threads not yet resolved.
+	UnresolvedThreads int
+}
+
+// IsRollupStatus reports whether s is a status a rollup can have: dismissed
+// reviews only ever appear on individual reviews.
+func IsRollupStatus(s ReviewState) bool {
+	switch s {
+	case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateCommented, ReviewStatePending:
+		return true
+	}
+	return false
+}
+
+// Known reports whether the rollup has been computed.
+func (r ReviewRollup) Known() bool {
+	return r.Status != ""
+}
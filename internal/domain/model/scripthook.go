@@ -0,0 +1,93 @@
+package model
+
+import "time"
+
+// HookEvent is the PR event that triggers a ScriptHook.
+type HookEvent string
+
+// HookEvent values.
+const (
+	HookEventOpened           HookEvent = "opened"
+	HookEventApproved         HookEvent = "approved"
+	HookEventChangesRequested HookEvent = "changes_requested"
+	HookEventCIFailed         HookEvent = "ci_failed"
+	HookEventCommented        HookEvent = "commented"
+)
+
+// AllHookEvents lists every HookEvent in display order.
+var AllHookEvents = []HookEvent{
+	HookEventOpened, HookEventApproved, HookEventChangesRequested, HookEventCIFailed, HookEventCommented,
+}
+
+// Valid reports whether e is a known hook event.
+func (e HookEvent) Valid() bool {
+	for _, known := range AllHookEvents {
+		if e == known {
+			return true
+		}
+	}
+	return false
+}
+
+// HookRuntime is how a ScriptHook's script is executed.
+type HookRuntime string
+
+// HookRuntime values.
+const (
+	// HookRuntimeStarlark runs the script as Starlark in a sandbox with no
+	// file system or network access.
+	HookRuntimeStarlark HookRuntime = "starlark"
+	// HookRuntimeShell runs an allowlisted program with whitespace-separated
+	// arguments, without a shell, passing the invocation as JSON on stdin.
+	HookRuntimeShell HookRuntime = "shell"
+)
+
+// ScriptHook runs a user-provided script when Event occurs on a PR, for
+// automations such as labeling or updating a ticket.
+type ScriptHook struct {
+	ID           int64
+	Name         string
+	Event        HookEvent
+	Runtime      HookRuntime
+	Script       string // Starlark source, or the command line for the shell runtime
+	RepoFullName string // empty matches every watched repository
+	Enabled      bool
+	CreatedAt    time.Time
+}
+
+// Matches reports whether the hook runs for event on a PR in repoFullName.
+func (h ScriptHook) Matches(event HookEvent, repoFullName string) bool {
+	return h.Enabled && h.Event == event && (h.RepoFullName == "" || h.RepoFullName == repoFullName)
+}
+
+// HookInvocation is what a ScriptHook receives: the event, the PR, and the
+// review, check run, or comment that caused it.
+type HookInvocation struct {
+	Event       HookEvent
+	PullRequest PullRequest
+	Review      *Review       // approved, changes_requested
+	CheckRun    *CheckRun     // ci_failed
+	Comment     *IssueComment // commented
+	OccurredAt  time.Time
+}
+
+// HookActionKind identifies an action a script asks the app to perform.
+type HookActionKind string
+
+// HookActionKind values.
+const (
+	// HookActionComment posts Body as a comment on the PR.
+	HookActionComment HookActionKind = "comment"
+)
+
+// HookAction is an action requested by a script, performed after it returns.
+type HookAction struct {
+	Kind HookActionKind
+	Body string
+}
+
+// HookResult is the outcome of running a ScriptHook.
+type HookResult struct {
+	Actions []HookAction
+	Output  string // log lines or process output, truncated
+}
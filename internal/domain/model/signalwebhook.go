@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// SignalWebhook calls URL whenever Signal starts firing for a PR, for personal
+// automations such as a desk light that turns red on a CI failure.
+type SignalWebhook struct {
+	ID           int64
+	Signal       AttentionSignal
+	URL          string
+	RepoFullName string // empty matches every watched repository
+	CreatedAt    time.Time
+}
+
+// Matches reports whether the webhook routes signal for a PR in repoFullName.
+func (w SignalWebhook) Matches(signal AttentionSignal, repoFullName string) bool {
+	return w.Signal == signal && (w.RepoFullName == "" || w.RepoFullName == repoFullName)
+}
+
+// SignalFired is the payload delivered to a SignalWebhook.
+type SignalFired struct {
+	Signal      AttentionSignal
+	PullRequest PullRequest
+	Signals     AttentionSignals // all signals of the PR at the time it fired
+	FiredAt     time.Time
+}
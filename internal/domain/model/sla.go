@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// SLAState is where a PR stands against its repository's first-review SLA.
+type SLAState string
+
+// SLAState values.
+const (
+	SLAPending SLAState = "pending" // awaiting a first review; deadline ahead
+	SLAOverdue SLAState = "overdue" // awaiting a first review; deadline passed
+	SLAMet     SLAState = "met"     // first review arrived in time
+	SLAMissed  SLAState = "missed"  // first review arrived after the deadline
+)
+
+// SLAStatus is a transient model computed from a PR's reviews and the
+// repository's first-review SLA. It is never persisted.
+type SLAStatus struct {
+	State         SLAState
+	Hours         int // the SLA: first review within this many hours of opening
+	Deadline      time.Time
+	FirstReviewAt *time.Time // nil while awaiting a first review
+}
+
+// Breached reports whether the first review is late or missing past the deadline.
+func (s SLAStatus) Breached() bool {
+	return s.State == SLAOverdue || s.State == SLAMissed
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// StaleAction is what the stale labeler does to a PR that has gone idle.
+type StaleAction string
+
+// StaleAction values.
+const (
+	StaleActionLabel   StaleAction = "label"
+	StaleActionComment StaleAction = "comment"
+)
+
+// IsValid reports whether a is a known stale action.
+func (a StaleAction) IsValid() bool {
+	return a == StaleActionLabel || a == StaleActionComment
+}
+
+// DefaultStaleLabel is the label added to idle PRs when none is configured.
+const DefaultStaleLabel = "stale"
+
+// StalePolicy configures the stale labeler for one repository.
+type StalePolicy struct {
+	// AfterDays is how long an open PR must go without activity before it is
+	// marked stale; 0 disables the labeler for the repository.
+	AfterDays int
+	Action    StaleAction
+	Label     string // label to add; DefaultStaleLabel when empty
+	// DryRun reports the PRs that would be marked in the inbox without
+	// labeling or commenting on GitHub.
+	DryRun bool
+	// ExemptLabels keep PRs carrying any of them from being marked.
+	ExemptLabels []string
+}
+
+// Enabled reports whether the policy marks idle PRs.
+func (p StalePolicy) Enabled() bool {
+	return p.AfterDays > 0
+}
+
+// LabelOrDefault returns the label to add to idle PRs.
+func (p StalePolicy) LabelOrDefault() string {
+	if p.Label == "" {
+		return DefaultStaleLabel
+	}
+	return p.Label
+}
+
+// Exempts reports whether any of labels exempts a PR from being marked.
+func (p StalePolicy) Exempts(labels []string) bool {
+	for _, label := range labels {
+		for _, exempt := range p.ExemptLabels {
+			if strings.EqualFold(label, exempt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// StaleMark records that a PR was marked stale. ActivityAt is the PR's last
+// activity when it was marked, so it is marked at most once per idle period.
+type StaleMark struct {
+	PRID       int64
+	ActivityAt time.Time
+	MarkedAt   time.Time
+	DryRun     bool
+}
@@ -0,0 +1,9 @@
+package model
+
+// SuggestedReviewer is a reviewer GitHub suggests for a pull request based on
+// who has recently edited or commented on the changed code.
+type SuggestedReviewer struct {
+	Login       string
+	IsAuthor    bool // recently authored changes to the same files
+	IsCommenter bool // recently commented on changes to the same files
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// ReviewerStats summarizes one reviewer's activity over a period for the team
+// stats leaderboard.
+type ReviewerStats struct {
+	Login        string
+	ReviewsGiven int // submitted reviews, excluding pending ones
+	PRsReviewed  int // distinct PRs first reviewed in the period
+	// AvgResponseTime is the mean time from a PR opening to the reviewer's
+	// first review of it, over PRsReviewed; zero when PRsReviewed is zero.
+	AvgResponseTime time.Duration
+	// SuggestionsApplied counts the reviewer's suggested changes whose lines
+	// were later changed, GitHub's best available sign that they were applied.
+	SuggestionsApplied int
+}
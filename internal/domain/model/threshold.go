@@ -6,11 +6,23 @@ type GlobalSettings struct {
 	AgeUrgencyDays       int
 	StaleReviewEnabled   bool
 	CIFailureEnabled     bool
+	// FirstTimerBoostEnabled raises first-time contributors' PRs in the
+	// attention ranking until they have enough approvals. Off by default.
+	FirstTimerBoostEnabled bool
+	// ChecklistEnabled flags PRs marked ready for review whose task lists
+	// still have unchecked items. Off by default.
+	ChecklistEnabled bool
+	// RequestAgeDays is how many days a review request from the user can
+	// wait before it needs attention; 0 disables the signal.
+	RequestAgeDays int
 }
 
 // defaultAgeUrgencyDays is the default number of days before a PR is considered age-urgent.
 const defaultAgeUrgencyDays = 7
 
+// defaultRequestAgeDays is the default number of days a review request can wait.
+const defaultRequestAgeDays = 2
+
 // DefaultGlobalSettings returns the hard-coded defaults used when no global
 // settings row exists in the database.
 func DefaultGlobalSettings() GlobalSettings {
@@ -19,6 +31,7 @@ func DefaultGlobalSettings() GlobalSettings {
 		AgeUrgencyDays:       defaultAgeUrgencyDays,
 		StaleReviewEnabled:   true,
 		CIFailureEnabled:     true,
+		RequestAgeDays:       defaultRequestAgeDays,
 	}
 }
 
@@ -30,4 +43,8 @@ type RepoThreshold struct {
 	AgeUrgencyDays     *int
 	StaleReviewEnabled *bool
 	CIFailureEnabled   *bool
+	// FirstReviewSLAHours is the response-time SLA: a first review is due
+	// within this many hours of a PR opening. It has no global default, so
+	// nil (or 0) means the repo has no SLA.
+	FirstReviewSLAHours *int
 }
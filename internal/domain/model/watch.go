@@ -0,0 +1,40 @@
+package model
+
+// WatchState is the user's per-PR override of whether a pull request is
+// relevant to them, independent of review requests.
+type WatchState string
+
+// WatchState values.
+const (
+	// WatchStateDefault leaves relevance to the PR's review request.
+	WatchStateDefault WatchState = ""
+	// WatchStateWatching keeps the PR in the needing-attention views and
+	// notifications even though the user is neither author nor reviewer.
+	WatchStateWatching WatchState = "watching"
+	// WatchStateUnwatched drops the PR's review request from the
+	// needing-attention views and notifications without ignoring the PR.
+	WatchStateUnwatched WatchState = "unwatched"
+)
+
+// IsValid reports whether s is a known watch state.
+func (s WatchState) IsValid() bool {
+	switch s {
+	case WatchStateDefault, WatchStateWatching, WatchStateUnwatched:
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedsAttention reports whether a PR in state s belongs in the user's
+// needing-attention views, given whether it requests the user's review.
+func (s WatchState) NeedsAttention(reviewRequested bool) bool {
+	switch s {
+	case WatchStateWatching:
+		return true
+	case WatchStateUnwatched:
+		return false
+	default:
+		return reviewRequested
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+// Release is one entry of the embedded changelog shown in the what's-new panel.
+type Release struct {
+	ID      string   // release date as YYYY-MM-DD; later releases sort higher
+	Changes []string // user-facing changes, one line each
+	Notices []string // settings or behavior changes that need the user's attention
+}
+
+// SchemaMigration describes one database migration embedded in the binary.
+type SchemaMigration struct {
+	Version     uint
+	Description string
+}
+
+// WhatsNewSeen records what the user last acknowledged in the what's-new panel.
+type WhatsNewSeen struct {
+	ReleaseID     string
+	SchemaVersion uint
+}
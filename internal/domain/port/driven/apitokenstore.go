@@ -0,0 +1,30 @@
+package driven
+
+import (
+	"context"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// APITokenStore defines the driven port for REST API token persistence.
+// Secrets never reach the store; tokens are looked up by their hash.
+type APITokenStore interface {
+	// Create persists a token with the hash of its secret and returns the assigned ID.
+	Create(ctx context.Context, token model.APIToken, hash string) (int64, error)
+
+	// List returns all tokens, newest first.
+	List(ctx context.Context) ([]model.APIToken, error)
+
+	// Delete revokes a token by ID.
+	Delete(ctx context.Context, id int64) error
+
+	// GetByHash returns the token whose secret hashes to hash, or nil if none does.
+	GetByHash(ctx context.Context, hash string) (*model.APIToken, error)
+
+	// Count returns the number of tokens.
+	Count(ctx context.Context) (int, error)
+
+	// TouchLastUsed records that a token authenticated a request at the given time.
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
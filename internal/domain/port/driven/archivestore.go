@@ -0,0 +1,23 @@
+package driven
+
+import (
+	"context"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ArchiveStore defines the driven port for the archive of merged and closed
+// pull requests.
+type ArchiveStore interface {
+	// ListArchived returns merged and closed PRs matching filter, most
+	// recently closed first, skipping offset and returning at most limit.
+	ListArchived(ctx context.Context, filter model.ArchiveFilter, limit, offset int) ([]model.PullRequest, error)
+	// MergeHistory returns per-repository merge counts for the PRs matching
+	// filter, ordered by repository name.
+	MergeHistory(ctx context.Context, filter model.ArchiveFilter) ([]model.RepoMergeHistory, error)
+	// PruneClosedBefore deletes merged and closed PRs, with their reviews,
+	// comments, and checks, that closed before cutoff. It returns the number
+	// of PRs deleted.
+	PruneClosedBefore(ctx context.Context, cutoff time.Time) (int, error)
+}
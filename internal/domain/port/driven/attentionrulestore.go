@@ -0,0 +1,33 @@
+package driven
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ErrAttentionRuleNotFound indicates the requested attention rule does not exist.
+var ErrAttentionRuleNotFound = errors.New("attention rule not found")
+
+// AttentionRuleStore defines the driven port for user-defined attention rules.
+type AttentionRuleStore interface {
+	// Create persists a rule and returns the assigned ID.
+	Create(ctx context.Context, rule model.AttentionRule) (int64, error)
+
+	// Update replaces a rule's name, expression, severity, notify URL, and
+	// enabled flag. Returns ErrAttentionRuleNotFound if it does not exist.
+	Update(ctx context.Context, rule model.AttentionRule) error
+
+	// Delete removes a rule. Returns ErrAttentionRuleNotFound if it does not exist.
+	Delete(ctx context.Context, id int64) error
+
+	// List returns all rules ordered by creation.
+	List(ctx context.Context) ([]model.AttentionRule, error)
+}
+
+// RuleNotifier delivers attention rule notifications over HTTP.
+type RuleNotifier interface {
+	// SendRuleMatched posts the match to url. Non-2xx responses are errors.
+	SendRuleMatched(ctx context.Context, url string, match model.RuleMatched) error
+}
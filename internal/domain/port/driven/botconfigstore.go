@@ -19,10 +19,13 @@ var (
 
 // BotConfigStore defines the driven port for managing bot username configuration.
 // Add returns ErrBotAlreadyExists if the username already exists.
-// Remove returns ErrBotNotFound if the username does not exist.
+// Remove and SetNitpickPatterns return ErrBotNotFound if the username does
+// not exist.
 type BotConfigStore interface {
 	Add(ctx context.Context, config model.BotConfig) (model.BotConfig, error)
 	Remove(ctx context.Context, username string) error
+	// SetNitpickPatterns replaces the bot's extra nitpick markers.
+	SetNitpickPatterns(ctx context.Context, username string, patterns []string) error
 	ListAll(ctx context.Context) ([]model.BotConfig, error)
 	// GetUsernames returns only the username strings, ordered alphabetically.
 	GetUsernames(ctx context.Context) ([]string, error)
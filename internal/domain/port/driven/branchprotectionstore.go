@@ -0,0 +1,17 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// BranchProtectionStore defines the driven port for persisting branch
+// protection settings fetched during polling.
+type BranchProtectionStore interface {
+	// Upsert records the settings for a repository branch, replacing any previous ones.
+	Upsert(ctx context.Context, protection model.BranchProtection) error
+	// Get returns the settings for a repository branch. Returns nil, nil if the
+	// branch has not been fetched yet.
+	Get(ctx context.Context, repoFullName, branch string) (*model.BranchProtection, error)
+}
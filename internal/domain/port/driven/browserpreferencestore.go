@@ -0,0 +1,19 @@
+package driven
+
+import (
+	"context"
+	"time"
+)
+
+// BrowserPreferenceStore defines the driven port for GUI preferences kept per
+// browser, keyed by an anonymous browser ID rather than a user account.
+type BrowserPreferenceStore interface {
+	// ListPreferences returns the browser's preferences by key; empty when it
+	// has none.
+	ListPreferences(ctx context.Context, browserID string) (map[string]string, error)
+	// SetPreference stores or replaces one preference. An empty value deletes it.
+	SetPreference(ctx context.Context, browserID, key, value string) error
+	// PrunePreferences deletes every preference of browsers that saved none
+	// since cutoff and returns how many were deleted.
+	PrunePreferences(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,18 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// CardLayoutStore defines the driven port for the PR card field preference.
+type CardLayoutStore interface {
+	// GetCardFields returns the visible card fields in display order.
+	// Returns model.DefaultCardFields() if the preference has never been saved.
+	GetCardFields(ctx context.Context) ([]model.CardField, error)
+
+	// SetCardFields persists the visible card fields in display order. An
+	// empty slice hides all of them.
+	SetCardFields(ctx context.Context, fields []model.CardField) error
+}
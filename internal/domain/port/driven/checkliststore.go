@@ -0,0 +1,13 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ChecklistStore defines the driven port for a PR's task list progress. It is
+// recorded separately from PRStore.Upsert, which leaves it unchanged.
+type ChecklistStore interface {
+	SetChecklist(ctx context.Context, prID int64, progress model.ChecklistProgress) error
+}
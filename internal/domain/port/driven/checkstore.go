@@ -14,4 +14,7 @@ type CheckStore interface {
 	ReplaceCheckRunsForPR(ctx context.Context, prID int64, runs []model.CheckRun) error
 	// GetCheckRunsByPR returns all check runs for the given PR, ordered by name.
 	GetCheckRunsByPR(ctx context.Context, prID int64) ([]model.CheckRun, error)
+	// GetCheckRunsByPRs returns the check runs of several PRs at once, keyed
+	// by PR ID.
+	GetCheckRunsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.CheckRun, error)
 }
@@ -0,0 +1,21 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// CheckWatchStore defines the driven port for "notify when checks complete"
+// requests.
+type CheckWatchStore interface {
+	// SetCheckWatch starts or stops watching a PR's checks. Starting an
+	// existing watch keeps its original creation time.
+	SetCheckWatch(ctx context.Context, prID int64, watching bool) error
+
+	// IsWatchingChecks reports whether a PR's checks are watched.
+	IsWatchingChecks(ctx context.Context, prID int64) (bool, error)
+
+	// ListCheckWatches returns every watch, oldest first.
+	ListCheckWatches(ctx context.Context) ([]model.CheckWatch, error)
+}
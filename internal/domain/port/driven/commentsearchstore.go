@@ -0,0 +1,14 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// CommentSearchStore defines the driven port for full-text search over the
+// stored review and issue comments of every PR.
+type CommentSearchStore interface {
+	// SearchComments returns the comments matching filter, newest first.
+	SearchComments(ctx context.Context, filter model.CommentSearchFilter) ([]model.CommentSearchResult, error)
+}
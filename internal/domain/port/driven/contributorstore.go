@@ -0,0 +1,10 @@
+package driven
+
+import "context"
+
+// ContributorStore defines the driven port for a repository's contributor
+// history, as far as it is stored locally.
+type ContributorStore interface {
+	// HasMergedPR reports whether author has a stored merged PR in the repository.
+	HasMergedPR(ctx context.Context, repoFullName, author string) (bool, error)
+}
@@ -0,0 +1,21 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// DecisionStore defines the driven port for the review decisions log.
+type DecisionStore interface {
+	// Save records a decision, replacing the summary if the thread is already a decision.
+	Save(ctx context.Context, decision model.Decision) error
+	// Delete removes the decision for a thread. No-op if the thread is not a decision.
+	Delete(ctx context.Context, rootCommentID int64) error
+	// ListByPR returns the decisions recorded on a single pull request.
+	ListByPR(ctx context.Context, repoFullName string, prNumber int) ([]model.Decision, error)
+	// Search returns decisions whose summary, file path, or PR title contains
+	// query, most recently updated first. Empty repoFullName matches all
+	// repositories and an empty query matches all decisions.
+	Search(ctx context.Context, repoFullName, query string) ([]model.Decision, error)
+}
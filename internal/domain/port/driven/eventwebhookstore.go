@@ -0,0 +1,59 @@
+package driven
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ErrEventWebhookNotFound indicates the requested event webhook does not exist.
+var ErrEventWebhookNotFound = errors.New("event webhook not found")
+
+// EventWebhookStore defines the driven port for event webhooks and their
+// delivery log.
+type EventWebhookStore interface {
+	// Create persists a webhook and returns the assigned ID.
+	Create(ctx context.Context, hook model.EventWebhook) (int64, error)
+
+	// Get returns one webhook, or nil if it does not exist.
+	Get(ctx context.Context, id int64) (*model.EventWebhook, error)
+
+	// List returns all webhooks ordered by creation.
+	List(ctx context.Context) ([]model.EventWebhook, error)
+
+	// SetEnabled turns a webhook on or off. Returns ErrEventWebhookNotFound
+	// if it does not exist.
+	SetEnabled(ctx context.Context, id int64, enabled bool) error
+
+	// Delete removes a webhook and its deliveries. Returns
+	// ErrEventWebhookNotFound if it does not exist.
+	Delete(ctx context.Context, id int64) error
+
+	// AddDelivery stores a new delivery and returns its ID.
+	AddDelivery(ctx context.Context, delivery model.WebhookDelivery) (int64, error)
+
+	// UpdateDelivery saves a delivery's status, attempts, response status,
+	// last error, next attempt time, and delivery time.
+	UpdateDelivery(ctx context.Context, delivery model.WebhookDelivery) error
+
+	// ListDeliveries returns a webhook's deliveries, newest first, at most limit.
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]model.WebhookDelivery, error)
+
+	// ListDueDeliveries returns pending deliveries whose next attempt is at
+	// or before now, oldest first.
+	ListDueDeliveries(ctx context.Context, now time.Time) ([]model.WebhookDelivery, error)
+
+	// PruneDeliveries deletes delivered and failed deliveries created before
+	// cutoff and returns how many were removed.
+	PruneDeliveries(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// EventWebhookSender delivers signed event webhook payloads over HTTP.
+type EventWebhookSender interface {
+	// SendEvent posts delivery's payload to url, signed with secret. It
+	// returns the response status, 0 when none arrived; non-2xx responses
+	// are errors.
+	SendEvent(ctx context.Context, url, secret string, delivery model.WebhookDelivery) (int, error)
+}
@@ -0,0 +1,20 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// FocusStore defines the driven port for done and skip marks on the daily
+// focus list.
+type FocusStore interface {
+	// SetFocusMark records mark, replacing the PR's mark for the same day.
+	SetFocusMark(ctx context.Context, mark model.FocusMark) error
+
+	// ClearFocusMark removes a PR's mark for day, if any.
+	ClearFocusMark(ctx context.Context, prID int64, day string) error
+
+	// ListFocusMarks returns the marks of the day since and every later day.
+	ListFocusMarks(ctx context.Context, since string) ([]model.FocusMark, error)
+}
@@ -0,0 +1,41 @@
+package driven
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ErrGitHubAccountExists indicates a GitHub account with the same name already exists.
+var ErrGitHubAccountExists = errors.New("github account already exists")
+
+// GitHubAccountStore defines the driven port for named GitHub credential persistence.
+// Tokens are stored encrypted; all methods return decrypted plaintext at the domain boundary.
+type GitHubAccountStore interface {
+	// Create persists a new GitHub account and returns the assigned ID.
+	// Returns ErrGitHubAccountExists if the name is taken.
+	Create(ctx context.Context, account model.GitHubAccount) (int64, error)
+
+	// Delete removes a GitHub account by ID. Repositories assigned to it
+	// fall back to the default token.
+	Delete(ctx context.Context, id int64) error
+
+	// List returns all GitHub accounts with decrypted tokens, ordered by name.
+	List(ctx context.Context) ([]model.GitHubAccount, error)
+}
+
+// GitHubRepoAccountStore defines the driven port for routing repositories to GitHub accounts.
+type GitHubRepoAccountStore interface {
+	// GetForRepo returns the account assigned to the repository.
+	// Returns a zero-value GitHubAccount (ID==0) and nil error if none is assigned.
+	GetForRepo(ctx context.Context, repoFullName string) (model.GitHubAccount, error)
+
+	// GetRepoAccounts returns the assigned account ID for each given repo in a
+	// single query. Unassigned repos map to 0.
+	GetRepoAccounts(ctx context.Context, repoFullNames []string) (map[string]int64, error)
+
+	// SetRepoAccount assigns a repository to an account. Pass accountID=0 to
+	// clear the assignment.
+	SetRepoAccount(ctx context.Context, repoFullName string, accountID int64) error
+}
@@ -2,10 +2,29 @@ package driven
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 )
 
+// ErrRepoUnreachable is wrapped by GitHubClient errors when GitHub answers 404
+// or 403 for a repository: it was deleted, renamed without a redirect, made
+// private, or the token lost access. Retrying soon is unlikely to help.
+var ErrRepoUnreachable = errors.New("repository unreachable")
+
+// RepoMovedError is returned when GitHub redirects a repository to a new full
+// name after a rename or transfer.
+type RepoMovedError struct {
+	From string
+	To   string
+}
+
+func (e *RepoMovedError) Error() string {
+	return fmt.Sprintf("repository %s moved to %s", e.From, e.To)
+}
+
 // GitHubClient defines the driven port for fetching data from the GitHub API.
 type GitHubClient interface {
 	FetchPullRequests(ctx context.Context, repoFullName string, state string) ([]model.PullRequest, error)
@@ -25,4 +44,77 @@ type GitHubClient interface {
 	// FetchRequiredStatusChecks returns the list of required status check contexts
 	// for the given branch's protection rules. Returns empty slice if unprotected.
 	FetchRequiredStatusChecks(ctx context.Context, repoFullName string, branch string) ([]string, error)
+	// FetchBranchProtection returns the branch's pull request review rules:
+	// required approvals, code owner review, and stale approval dismissal.
+	// RequiresReviews is false if the branch does not require reviews or the
+	// token cannot read its protection.
+	FetchBranchProtection(ctx context.Context, repoFullName string, branch string) (model.BranchProtection, error)
+}
+
+// GitHubStatusReporter is an optional interface implemented by GitHub clients
+// that track rate-limit and credential state across calls. Consumers type-assert
+// a GitHubClient against it rather than requiring it on every implementation.
+type GitHubStatusReporter interface {
+	APIStatus() model.GitHubAPIStatus
+}
+
+// TeamRepoLister lists the repositories a GitHub organization team has
+// access to, as "owner/repo" full names, for the bulk repo import.
+type TeamRepoLister interface {
+	ListTeamRepos(ctx context.Context, org, teamSlug string) ([]string, error)
+}
+
+// RepoMetadataFetcher is an optional interface implemented by GitHub clients
+// that can fetch a repository's description, default branch, visibility, and
+// archived flag. OpenPRCount and FetchedAt are left zero.
+type RepoMetadataFetcher interface {
+	FetchRepoMetadata(ctx context.Context, repoFullName string) (model.RepoMetadata, error)
+}
+
+// LabelLister is an optional interface implemented by GitHub clients that
+// can list the labels defined in a repository, for the label editor.
+type LabelLister interface {
+	FetchRepoLabels(ctx context.Context, repoFullName string) ([]string, error)
+}
+
+// MilestoneLister is an optional interface implemented by GitHub clients
+// that can list a repository's open milestones, for milestone assignment.
+type MilestoneLister interface {
+	FetchMilestones(ctx context.Context, repoFullName string) ([]model.Milestone, error)
+}
+
+// PRWindowFetcher is an optional interface implemented by GitHub clients that
+// can list a repository's PRs without paging through its full history: every
+// open PR, plus the closed and merged PRs updated at or after since.
+type PRWindowFetcher interface {
+	FetchPullRequestsSince(ctx context.Context, repoFullName string, since time.Time) ([]model.PullRequest, error)
+}
+
+// PRFetcher is an optional interface implemented by GitHub clients that can
+// fetch a single pull request by number, so refreshing one PR does not
+// re-list the whole repository.
+type PRFetcher interface {
+	FetchPullRequest(ctx context.Context, repoFullName string, prNumber int) (*model.PullRequest, error)
+}
+
+// ReviewRequestFetcher is an optional interface implemented by GitHub clients
+// that can read when each pending review request on a PR was made, from its
+// timeline. Re-requests report the latest request.
+type ReviewRequestFetcher interface {
+	FetchReviewRequests(ctx context.Context, repoFullName string, prNumber int) ([]model.ReviewRequest, error)
+}
+
+// SecondaryRateLimitNotifier is an optional interface implemented by GitHub
+// clients that back off when GitHub answers with a secondary rate limit. The
+// client calls notify with the time the limit lifts; waiting is true when the
+// client sleeps until then and retries, and false when the wait was too long
+// and the request failed instead. notify must not block.
+type SecondaryRateLimitNotifier interface {
+	NotifySecondaryRateLimit(notify func(until time.Time, waiting bool))
+}
+
+// GitHubStatusPage reports the health of the GitHub API as published on
+// GitHub's status page, independently of any repository or token.
+type GitHubStatusPage interface {
+	FetchAPIStatus(ctx context.Context) (model.GitHubServiceStatus, error)
 }
@@ -1,6 +1,16 @@
 package driven
 
-import "context"
+import (
+	"context"
+	"errors"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ErrTransient is wrapped by GitHubWriter errors when the failure is likely
+// temporary: a rate limit, a 5xx from GitHub, or a network error. The same
+// write may succeed if retried later.
+var ErrTransient = errors.New("transient GitHub error")
 
 // DraftLineComment represents a single inline comment to be submitted as part
 // of a pull request review.
@@ -34,12 +44,34 @@ type GitHubWriter interface {
 	// CreateIssueComment creates a top-level (non-diff) comment on a pull request.
 	CreateIssueComment(ctx context.Context, repoFullName string, prNumber int, body string) error
 
+	// ResolveReviewThread marks the review thread that starts with the given
+	// root comment as resolved.
+	ResolveReviewThread(ctx context.Context, repoFullName string, prNumber int, rootCommentID int64) error
+
 	// ConvertPullRequestToDraft converts a ready-for-review PR to draft status.
 	ConvertPullRequestToDraft(ctx context.Context, repoFullName string, prNumber int) error
 
 	// MarkPullRequestReadyForReview converts a draft PR to ready-for-review status.
 	MarkPullRequestReadyForReview(ctx context.Context, repoFullName string, prNumber int) error
 
+	// FetchSuggestedReviewers returns GitHub's suggested reviewers for a pull
+	// request. It lives here rather than on GitHubClient because it is fetched
+	// on demand with the requesting user's token, next to RequestReviewers.
+	FetchSuggestedReviewers(ctx context.Context, repoFullName string, prNumber int) ([]model.SuggestedReviewer, error)
+
+	// RequestReviewers asks the given users to review a pull request.
+	RequestReviewers(ctx context.Context, repoFullName string, prNumber int, logins []string) error
+
+	// AddLabels adds labels to a pull request, keeping the ones it has.
+	AddLabels(ctx context.Context, repoFullName string, prNumber int, labels []string) error
+
+	// RemoveLabel removes a label from a pull request.
+	RemoveLabel(ctx context.Context, repoFullName string, prNumber int, label string) error
+
+	// SetMilestone assigns the milestone with the given number to a pull
+	// request; 0 clears it.
+	SetMilestone(ctx context.Context, repoFullName string, prNumber int, milestone int) error
+
 	// ValidateToken verifies that the given GitHub personal access token is valid
 	// and returns the authenticated username on success.
 	ValidateToken(ctx context.Context, token string) (username string, err error)
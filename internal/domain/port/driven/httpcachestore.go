@@ -0,0 +1,21 @@
+package driven
+
+import (
+	"context"
+	"time"
+)
+
+// HTTPCacheStore defines the driven port for cached GitHub API responses,
+// keyed by request URL. Entries are opaque serialized responses that let the
+// GitHub adapter send conditional requests after a restart.
+type HTTPCacheStore interface {
+	// GetResponse returns the cached response for key; ok is false on a miss.
+	GetResponse(ctx context.Context, key string) (response []byte, ok bool, err error)
+	// SetResponse stores or replaces the response for key.
+	SetResponse(ctx context.Context, key string, response []byte) error
+	// DeleteResponse removes the response for key, if any.
+	DeleteResponse(ctx context.Context, key string) error
+	// PruneResponses deletes responses last stored before cutoff and returns
+	// how many were deleted.
+	PruneResponses(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,27 @@
+package driven
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ErrIgnoreRuleNotFound indicates the requested ignore rule does not exist.
+var ErrIgnoreRuleNotFound = errors.New("ignore rule not found")
+
+// IgnoreRuleStore defines the driven port for user-defined ignore rules.
+type IgnoreRuleStore interface {
+	// Create persists a rule and returns the assigned ID.
+	Create(ctx context.Context, rule model.IgnoreRule) (int64, error)
+
+	// Update replaces a rule's name, expression, and enabled flag. Returns
+	// ErrIgnoreRuleNotFound if it does not exist.
+	Update(ctx context.Context, rule model.IgnoreRule) error
+
+	// Delete removes a rule. Returns ErrIgnoreRuleNotFound if it does not exist.
+	Delete(ctx context.Context, id int64) error
+
+	// List returns all rules ordered by creation.
+	List(ctx context.Context) ([]model.IgnoreRule, error)
+}
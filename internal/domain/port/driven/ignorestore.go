@@ -10,13 +10,15 @@ import (
 // pure domain entity.
 type IgnoredPR struct {
 	PRID      int64
+	Reason    string // empty when none was given
 	IgnoredAt time.Time
 }
 
 // IgnoreStore defines the driven port for the PR ignore list.
 type IgnoreStore interface {
-	// Ignore marks a PR as ignored. Idempotent — silently succeeds if already ignored.
-	Ignore(ctx context.Context, prID int64) error
+	// Ignore marks a PR as ignored with an optional reason. Idempotent —
+	// ignoring an ignored PR keeps its ignored_at and replaces its reason.
+	Ignore(ctx context.Context, prID int64, reason string) error
 
 	// Unignore removes a PR from the ignore list. No-op if the PR is not ignored.
 	Unignore(ctx context.Context, prID int64) error
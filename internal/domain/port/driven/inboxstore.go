@@ -0,0 +1,22 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// InboxStore defines the driven port for the attention inbox.
+type InboxStore interface {
+	// Add records an event unless one with the same kind, repository, PR
+	// number, and source ID already exists. It reports whether the event was new.
+	Add(ctx context.Context, event model.InboxEvent) (bool, error)
+	// List returns events newest first, at most limit. unreadOnly excludes read events.
+	List(ctx context.Context, unreadOnly bool, limit int) ([]model.InboxEvent, error)
+	// UnreadCount returns the number of unread events.
+	UnreadCount(ctx context.Context) (int, error)
+	// SetRead marks one event read or unread. No-op if the event does not exist.
+	SetRead(ctx context.Context, id int64, read bool) error
+	// MarkAllRead marks every unread event read.
+	MarkAllRead(ctx context.Context) error
+}
@@ -0,0 +1,22 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// LintStore defines the driven port for PR title and branch lint rules and
+// the violations found when PRs are synced.
+type LintStore interface {
+	// ListRules returns a repository's rules in creation order.
+	ListRules(ctx context.Context, repoFullName string) ([]model.LintRule, error)
+	// ReplaceRules replaces all of a repository's rules, dropping the
+	// violations of the old ones, and returns the new rules with IDs set.
+	ReplaceRules(ctx context.Context, repoFullName string, rules []model.LintRule) ([]model.LintRule, error)
+	// SetViolations replaces the rules a PR fails with ruleIDs.
+	SetViolations(ctx context.Context, prID int64, ruleIDs []int64) error
+	// ViolationsByPR returns the rules a PR fails, keyed by PR ID, for open
+	// PRs in a repository, or in all repositories when repoFullName is empty.
+	ViolationsByPR(ctx context.Context, repoFullName string) (map[int64][]model.LintRule, error)
+}
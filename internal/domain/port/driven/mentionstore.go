@@ -0,0 +1,19 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// MentionStore defines the driven port for @mentions parsed from PR comments.
+type MentionStore interface {
+	// SetMentions replaces the mentions stored for one comment. An empty
+	// slice clears them, e.g. after an edit removes every @mention.
+	SetMentions(ctx context.Context, source model.MentionSource, commentID int64, mentions []model.Mention) error
+	// GetMentionsByPR returns the mentions in a PR's comments, oldest first.
+	GetMentionsByPR(ctx context.Context, prID int64) ([]model.Mention, error)
+	// ListPRIDsMentioning returns the IDs of PRs with at least one comment
+	// mentioning login. Logins match case-insensitively.
+	ListPRIDsMentioning(ctx context.Context, login string) ([]int64, error)
+}
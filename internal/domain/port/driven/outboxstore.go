@@ -0,0 +1,25 @@
+package driven
+
+import (
+	"context"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// OutboxStore defines the driven port for GitHub writes queued for retry.
+type OutboxStore interface {
+	// Add stores a new action and returns its ID.
+	Add(ctx context.Context, action model.OutboundAction) (int64, error)
+	// Get returns one action, or nil if it does not exist.
+	Get(ctx context.Context, id int64) (*model.OutboundAction, error)
+	// List returns all stored actions, newest first.
+	List(ctx context.Context) ([]model.OutboundAction, error)
+	// ListDue returns pending actions whose next attempt is at or before now,
+	// oldest first.
+	ListDue(ctx context.Context, now time.Time) ([]model.OutboundAction, error)
+	// Update saves an action's status, attempts, last error, and next attempt time.
+	Update(ctx context.Context, action model.OutboundAction) error
+	// Delete removes an action. No-op if it does not exist.
+	Delete(ctx context.Context, id int64) error
+}
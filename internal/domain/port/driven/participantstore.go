@@ -0,0 +1,13 @@
+package driven
+
+import "context"
+
+// ParticipantStore defines the driven port for looking up the GitHub users
+// seen on stored pull requests, e.g. for @mention autocomplete.
+type ParticipantStore interface {
+	// SearchParticipants returns up to limit logins that authored, reviewed,
+	// or commented on a stored PR of repoFullName (every repository when
+	// empty) and start with prefix, ignoring case. Bots are excluded. The
+	// most active users come first.
+	SearchParticipants(ctx context.Context, repoFullName, prefix string, limit int) ([]string, error)
+}
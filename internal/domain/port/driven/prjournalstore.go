@@ -0,0 +1,16 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// PRJournalStore defines the driven port for the append-only PR event journal.
+type PRJournalStore interface {
+	// AppendPREvents stores entries in order. The store assigns their IDs.
+	AppendPREvents(ctx context.Context, entries []model.PRJournalEntry) error
+
+	// ListPREvents returns the entries matching filter, oldest first.
+	ListPREvents(ctx context.Context, filter model.PRJournalFilter) ([]model.PRJournalEntry, error)
+}
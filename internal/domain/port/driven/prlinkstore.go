@@ -0,0 +1,17 @@
+package driven
+
+import "context"
+
+// PRLinkStore defines the driven port for manual links between pull requests
+// that compete to solve the same problem. Links are undirected: linking A to B
+// is the same as linking B to A.
+type PRLinkStore interface {
+	// Link associates two PRs. Idempotent — silently succeeds if already linked.
+	Link(ctx context.Context, prID, otherID int64) error
+
+	// Unlink removes the association between two PRs. No-op if not linked.
+	Unlink(ctx context.Context, prID, otherID int64) error
+
+	// ListLinkedIDs returns the IDs of all PRs linked to the given PR.
+	ListLinkedIDs(ctx context.Context, prID int64) ([]int64, error)
+}
@@ -0,0 +1,25 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// PRNoteStore defines the driven port for private per-PR notes.
+type PRNoteStore interface {
+	// Add stores a new note and returns it with its ID and timestamps set.
+	Add(ctx context.Context, note model.PRNote) (model.PRNote, error)
+	// Delete removes a note. No-op if the note does not exist.
+	Delete(ctx context.Context, id int64) error
+	// ListByPR returns the notes on a single pull request, oldest first.
+	ListByPR(ctx context.Context, repoFullName string, prNumber int) ([]model.PRNote, error)
+	// Search returns notes whose body contains query, most recently updated
+	// first. Empty repoFullName matches all repositories and an empty query
+	// matches all notes.
+	Search(ctx context.Context, repoFullName, query string) ([]model.PRNote, error)
+	// Import stores notes exported from another instance, keeping their
+	// timestamps. Notes identical to an existing one on the same PR are
+	// skipped. It returns the number of notes added.
+	Import(ctx context.Context, notes []model.PRNote) (int, error)
+}
@@ -15,5 +15,17 @@ type PRStore interface {
 	ListAll(ctx context.Context) ([]model.PullRequest, error)
 	ListNeedingReview(ctx context.Context) ([]model.PullRequest, error)
 	ListIgnoredWithPRData(ctx context.Context) ([]model.PullRequest, error)
+	// CountByStatus counts the PRs ListAll would return, grouped by
+	// repository, status, and CI status, without loading them.
+	CountByStatus(ctx context.Context) ([]PRStatusCount, error)
 	Delete(ctx context.Context, repoFullName string, number int) error
 }
+
+// PRStatusCount is the number of a repository's PRs that share a status and
+// CI status.
+type PRStatusCount struct {
+	RepoFullName string
+	Status       model.PRStatus
+	CIStatus     model.CIStatus
+	Count        int
+}
@@ -0,0 +1,16 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// PRViewStore defines the driven port for the last time each PR was viewed.
+type PRViewStore interface {
+	// GetPRView returns the last recorded view of a PR, or nil if the PR has
+	// never been viewed.
+	GetPRView(ctx context.Context, prID int64) (*model.PRView, error)
+	// RecordPRView stores view as the PR's last view, replacing any earlier one.
+	RecordPRView(ctx context.Context, view model.PRView) error
+}
@@ -0,0 +1,25 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// QuickActionStore defines the driven port for the PR card quick action preference.
+type QuickActionStore interface {
+	// GetQuickActions returns the enabled quick actions in display order.
+	// Returns model.DefaultQuickActions() if the preference has never been saved.
+	GetQuickActions(ctx context.Context) ([]model.QuickAction, error)
+
+	// SetQuickActions persists the enabled quick actions. An empty slice hides
+	// all actions.
+	SetQuickActions(ctx context.Context, actions []model.QuickAction) error
+
+	// GetApproveSettings returns the approve action settings.
+	// Returns model.DefaultApproveSettings() if they have never been saved.
+	GetApproveSettings(ctx context.Context) (model.ApproveSettings, error)
+
+	// SetApproveSettings persists the approve action settings.
+	SetApproveSettings(ctx context.Context, settings model.ApproveSettings) error
+}
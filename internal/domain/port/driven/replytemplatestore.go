@@ -0,0 +1,20 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ReplyTemplateStore defines the driven port for saved reply snippets and
+// review templates.
+type ReplyTemplateStore interface {
+	// Create persists a template and returns the assigned ID.
+	Create(ctx context.Context, tpl model.ReplyTemplate) (int64, error)
+
+	// List returns all templates ordered by kind, then name.
+	List(ctx context.Context) ([]model.ReplyTemplate, error)
+
+	// Delete removes a template by ID.
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,33 @@
+package driven
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// Sentinel errors returned by RepoGroupStore implementations.
+var (
+	// ErrRepoGroupNotFound indicates the requested repo group does not exist.
+	ErrRepoGroupNotFound = errors.New("repo group not found")
+
+	// ErrRepoGroupExists indicates another group already has the name, ignoring case.
+	ErrRepoGroupExists = errors.New("repo group already exists")
+)
+
+// RepoGroupStore defines the driven port for named repository groups.
+type RepoGroupStore interface {
+	// Create stores a new group with its repositories and returns it with ID
+	// and CreatedAt set. Returns ErrRepoGroupExists if the name is taken.
+	Create(ctx context.Context, group model.RepoGroup) (model.RepoGroup, error)
+	// Update renames a group and replaces its repositories. Returns
+	// ErrRepoGroupNotFound or ErrRepoGroupExists.
+	Update(ctx context.Context, group model.RepoGroup) error
+	// Delete removes a group. Returns ErrRepoGroupNotFound if it does not exist.
+	Delete(ctx context.Context, id int64) error
+	// Get returns a group by ID, or nil if it does not exist.
+	Get(ctx context.Context, id int64) (*model.RepoGroup, error)
+	// List returns all groups ordered by name.
+	List(ctx context.Context) ([]model.RepoGroup, error)
+}
@@ -0,0 +1,28 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// RepoSettingsStore defines the driven port for per-repository settings.
+type RepoSettingsStore interface {
+	// GetRepoSettings returns a repository's settings, empty settings for
+	// the repository when none are saved.
+	GetRepoSettings(ctx context.Context, repoFullName string) (model.RepoSettings, error)
+
+	// ListRepoSettings returns the saved settings of the given repositories,
+	// keyed by full name. Repositories without saved settings are omitted.
+	ListRepoSettings(ctx context.Context, repoFullNames []string) (map[string]model.RepoSettings, error)
+
+	// SetRepoSettings saves a repository's settings, replacing earlier ones.
+	// Returns ErrRepoNotFound if the repository is not watched.
+	SetRepoSettings(ctx context.Context, settings model.RepoSettings) error
+
+	// ReplaceRepoSettings saves a repository's settings and threshold
+	// overrides in one transaction, removing the override when it sets
+	// nothing. Neither is changed when either write fails. Returns
+	// ErrRepoNotFound if the repository is not watched.
+	ReplaceRepoSettings(ctx context.Context, settings model.RepoSettings, threshold model.RepoThreshold) error
+}
@@ -3,6 +3,7 @@ package driven
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
 )
@@ -21,9 +22,65 @@ var (
 // Remove returns ErrRepoNotFound if the repository does not exist.
 // GetByFullName returns (nil, nil) if the repository does not exist —
 // queries return nil for missing entities rather than an error.
+// Repositories in the RepoTrash are treated as not watched, except that Add
+// restores one instead of failing.
 type RepoStore interface {
 	Add(ctx context.Context, repo model.Repository) error
 	Remove(ctx context.Context, fullName string) error
 	GetByFullName(ctx context.Context, fullName string) (*model.Repository, error)
 	ListAll(ctx context.Context) ([]model.Repository, error)
 }
+
+// RepoTrash keeps removed repositories restorable. Trash hides a watched
+// repository and its PRs from RepoStore and PR listings without deleting
+// anything; Restore brings it back. PurgeTrashed deletes repositories trashed
+// before cutoff for good, with their PRs, reviews, and checks, and returns
+// how many it deleted. Trash returns ErrRepoNotFound if the repository is not
+// watched, and Restore if it is not in the trash.
+type RepoTrash interface {
+	Trash(ctx context.Context, fullName string, at time.Time) error
+	Restore(ctx context.Context, fullName string) error
+	ListTrashed(ctx context.Context) ([]model.Repository, error)
+	PurgeTrashed(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// OrphanSweeper deletes data left behind by repositories and pull requests
+// that no longer exist, such as PRs of a repository removed while foreign key
+// enforcement was off. SweepOrphans returns how many rows it deleted per
+// table; tables with none are omitted.
+type OrphanSweeper interface {
+	SweepOrphans(ctx context.Context) (map[string]int, error)
+}
+
+// RepoRenamer moves a watched repository and all data stored under its full
+// name to a new full name, for when GitHub reports a rename or transfer.
+// RenameRepo returns ErrRepoNotFound if from is not watched and
+// ErrRepoAlreadyExists if to is already watched.
+type RepoRenamer interface {
+	RenameRepo(ctx context.Context, from, to string) error
+}
+
+// RepoPauser pauses and resumes background polling of a watched repository.
+// SetPaused returns ErrRepoNotFound if the repository is not watched.
+type RepoPauser interface {
+	SetPaused(ctx context.Context, fullName string, paused bool) error
+}
+
+// RepoSyncRecorder records when a watched repository last synced with GitHub.
+// MarkSynced returns ErrRepoNotFound if the repository is not watched.
+type RepoSyncRecorder interface {
+	MarkSynced(ctx context.Context, fullName string, at time.Time) error
+}
+
+// RepoMetadataStore records what GitHub reports about a watched repository.
+// SetMetadata returns ErrRepoNotFound if the repository is not watched.
+type RepoMetadataStore interface {
+	SetMetadata(ctx context.Context, fullName string, metadata model.RepoMetadata) error
+}
+
+// RepoImporter adds many repositories at once for the bulk import.
+// AddMany inserts repos in a single transaction, skips those already
+// watched, and returns the full names it added.
+type RepoImporter interface {
+	AddMany(ctx context.Context, repos []model.Repository) ([]string, error)
+}
@@ -0,0 +1,18 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ReviewHistoryStore defines the driven port for the archive of closed and
+// merged pull requests the user reviewed.
+type ReviewHistoryStore interface {
+	// ListReviewed returns closed or merged PRs with at least one review by
+	// any of reviewers (matched case-insensitively), most recently reviewed
+	// first, up to limit. Empty repoFullName matches all repositories; a
+	// non-empty query must appear in the PR title, one of the reviewers'
+	// review or comment bodies, or a decision summary on the PR.
+	ListReviewed(ctx context.Context, reviewers []string, repoFullName, query string, limit int) ([]model.ReviewedPR, error)
+}
@@ -0,0 +1,14 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ReviewRequestStore defines the driven port for a PR's pending review
+// requests. They are recorded separately from PRStore.Upsert, which leaves
+// them unchanged.
+type ReviewRequestStore interface {
+	SetReviewRequests(ctx context.Context, prID int64, requests []model.ReviewRequest) error
+}
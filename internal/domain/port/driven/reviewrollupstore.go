@@ -0,0 +1,14 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ReviewRollupStore defines the driven port for a PR's aggregated review
+// state. It is recorded separately from PRStore.Upsert, which leaves it
+// unchanged.
+type ReviewRollupStore interface {
+	SetReviewRollup(ctx context.Context, prID int64, rollup model.ReviewRollup) error
+}
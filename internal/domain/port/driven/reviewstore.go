@@ -13,6 +13,9 @@ type ReviewStore interface {
 	UpsertReviewComment(ctx context.Context, comment model.ReviewComment) error
 	UpsertIssueComment(ctx context.Context, comment model.IssueComment) error
 	GetReviewsByPR(ctx context.Context, prID int64) ([]model.Review, error)
+	// GetReviewsByPRs returns the reviews of several PRs at once, keyed by PR
+	// ID, so pages listing many PRs need one query rather than one per PR.
+	GetReviewsByPRs(ctx context.Context, prIDs []int64) (map[int64][]model.Review, error)
 	GetReviewCommentsByPR(ctx context.Context, prID int64) ([]model.ReviewComment, error)
 	GetIssueCommentsByPR(ctx context.Context, prID int64) ([]model.IssueComment, error)
 	UpdateCommentResolution(ctx context.Context, commentID int64, isResolved bool) error
@@ -20,3 +23,19 @@ type ReviewStore interface {
 	// associated with the given PR. Used for cleanup when a PR is removed.
 	DeleteReviewsByPR(ctx context.Context, prID int64) error
 }
+
+// PendingCommentStore stores local echoes of comments posted from this app so
+// they show before the poll loop syncs GitHub's copy. Pending comments are
+// returned by the ReviewStore getters with PendingSync set.
+type PendingCommentStore interface {
+	// AddPendingReviewComment stores comment under a new local ID, which is
+	// negative so it never collides with a GitHub ID, and returns that ID.
+	AddPendingReviewComment(ctx context.Context, comment model.ReviewComment) (int64, error)
+	// AddPendingIssueComment is AddPendingReviewComment for issue comments.
+	AddPendingIssueComment(ctx context.Context, comment model.IssueComment) (int64, error)
+	// DeletePendingReviewComment removes a pending review comment. Comments
+	// synced from GitHub are never removed.
+	DeletePendingReviewComment(ctx context.Context, id int64) error
+	// DeletePendingIssueComment removes a pending issue comment.
+	DeletePendingIssueComment(ctx context.Context, id int64) error
+}
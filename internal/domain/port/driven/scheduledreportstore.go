@@ -0,0 +1,35 @@
+package driven
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ErrScheduledReportNotFound indicates the requested scheduled report does not exist.
+var ErrScheduledReportNotFound = errors.New("scheduled report not found")
+
+// ScheduledReportStore defines the driven port for scheduled report persistence.
+type ScheduledReportStore interface {
+	// Create persists a report and returns the assigned ID.
+	Create(ctx context.Context, report model.ScheduledReport) (int64, error)
+
+	// List returns all reports ordered by name.
+	List(ctx context.Context) ([]model.ScheduledReport, error)
+
+	// Delete removes a report. Returns ErrScheduledReportNotFound if it does
+	// not exist.
+	Delete(ctx context.Context, id int64) error
+
+	// MarkSent records when a report was last sent.
+	MarkSent(ctx context.Context, id int64, at time.Time) error
+}
+
+// ReportSender posts report text to a chat webhook.
+type ReportSender interface {
+	// SendReport posts text to url as a Slack-compatible message. Non-2xx
+	// responses are errors.
+	SendReport(ctx context.Context, url, text string) error
+}
@@ -0,0 +1,20 @@
+package driven
+
+import "errors"
+
+// ErrUnsupported is wrapped by SCMProvider errors for actions the provider
+// has no equivalent of, such as draft toggles on Bitbucket Cloud.
+var ErrUnsupported = errors.New("not supported by this provider")
+
+// SCMProvider is a source code host that repositories are polled from and
+// written to: pull requests, reviews, comments, pipelines as check runs, and
+// review and comment writes. Its methods are GitHubClient's and
+// GitHubWriter's, whose results are already provider-neutral domain types.
+// Other providers answer empty results for data they do not have, such as
+// thread resolution or branch protection, and ErrUnsupported for writes.
+// Repository names are "owner/repo", where the owner is the provider's
+// namespace, e.g. a Bitbucket workspace.
+type SCMProvider interface {
+	GitHubClient
+	GitHubWriter
+}
@@ -0,0 +1,38 @@
+package driven
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// ErrScriptHookNotFound indicates the requested script hook does not exist.
+var ErrScriptHookNotFound = errors.New("script hook not found")
+
+// ScriptHookStore defines the driven port for script hook persistence.
+type ScriptHookStore interface {
+	// Create persists a hook and returns the assigned ID.
+	Create(ctx context.Context, hook model.ScriptHook) (int64, error)
+
+	// SetEnabled turns a hook on or off. Returns ErrScriptHookNotFound if it
+	// does not exist.
+	SetEnabled(ctx context.Context, id int64, enabled bool) error
+
+	// Delete removes a hook. Returns ErrScriptHookNotFound if it does not exist.
+	Delete(ctx context.Context, id int64) error
+
+	// List returns all hooks ordered by creation.
+	List(ctx context.Context) ([]model.ScriptHook, error)
+}
+
+// HookRunner executes script hooks.
+type HookRunner interface {
+	// Check reports whether hook could run, e.g. that its Starlark compiles
+	// or its program is allowed, without running it.
+	Check(hook model.ScriptHook) error
+
+	// Run executes hook for invocation and returns the actions it requested.
+	// A script that fails still returns the output it produced.
+	Run(ctx context.Context, hook model.ScriptHook, invocation model.HookInvocation) (model.HookResult, error)
+}
@@ -0,0 +1,25 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// SignalWebhookStore defines the driven port for attention signal webhook persistence.
+type SignalWebhookStore interface {
+	// Create persists a webhook and returns the assigned ID.
+	Create(ctx context.Context, hook model.SignalWebhook) (int64, error)
+
+	// List returns all webhooks ordered by signal, then creation.
+	List(ctx context.Context) ([]model.SignalWebhook, error)
+
+	// Delete removes a webhook by ID.
+	Delete(ctx context.Context, id int64) error
+}
+
+// WebhookSender delivers webhook payloads over HTTP.
+type WebhookSender interface {
+	// SendSignalFired posts the event to url. Non-2xx responses are errors.
+	SendSignalFired(ctx context.Context, url string, event model.SignalFired) error
+}
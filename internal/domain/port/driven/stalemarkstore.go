@@ -0,0 +1,17 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// StaleMarkStore defines the driven port for the record of PRs the stale
+// labeler has marked.
+type StaleMarkStore interface {
+	// ListStaleMarks returns the latest mark of every marked PR, keyed by PR ID.
+	ListStaleMarks(ctx context.Context) (map[int64]model.StaleMark, error)
+
+	// RecordStaleMark saves a PR's mark, replacing any earlier one.
+	RecordStaleMark(ctx context.Context, mark model.StaleMark) error
+}
@@ -0,0 +1,17 @@
+package driven
+
+import (
+	"context"
+	"time"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// TeamStatsStore defines the driven port for aggregate review statistics.
+type TeamStatsStore interface {
+	// ReviewerStats returns per-reviewer activity since the given time, in no
+	// particular order, with logins in lower case. Bot reviews and reviews
+	// of one's own PRs are excluded. Empty repoFullName covers all
+	// repositories.
+	ReviewerStats(ctx context.Context, since time.Time, repoFullName string) ([]model.ReviewerStats, error)
+}
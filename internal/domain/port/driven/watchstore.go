@@ -0,0 +1,22 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// WatchStore defines the driven port for per-PR watch states.
+type WatchStore interface {
+	// SetWatchState records the watch state of a PR. WatchStateDefault
+	// clears any override.
+	SetWatchState(ctx context.Context, prID int64, state model.WatchState) error
+
+	// GetWatchState returns the watch state of a PR, WatchStateDefault when
+	// none is set.
+	GetWatchState(ctx context.Context, prID int64) (model.WatchState, error)
+
+	// ListWatchStates returns the watch state of every PR that has one,
+	// keyed by PR ID.
+	ListWatchStates(ctx context.Context) (map[int64]model.WatchState, error)
+}
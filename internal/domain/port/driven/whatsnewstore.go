@@ -0,0 +1,18 @@
+package driven
+
+import (
+	"context"
+
+	"github.com/ericfisherdev/mygitpanel/internal/domain/model"
+)
+
+// WhatsNewStore defines the driven port for remembering which changelog
+// release and schema version the user has acknowledged.
+type WhatsNewStore interface {
+	// GetSeen returns the last acknowledged release and schema version.
+	// ok is false when nothing has been recorded yet.
+	GetSeen(ctx context.Context) (seen model.WhatsNewSeen, ok bool, err error)
+
+	// SetSeen records the acknowledged release and schema version.
+	SetSeen(ctx context.Context, seen model.WhatsNewSeen) error
+}